@@ -10,12 +10,15 @@ import (
 	"cosmossdk.io/log"
 	cometabci "github.com/cometbft/cometbft/abci/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/skip-mev/connect/v2/abci/strategies/aggregator"
 	compression "github.com/skip-mev/connect/v2/abci/strategies/codec"
 	"github.com/skip-mev/connect/v2/abci/strategies/currencypair"
 	connectabci "github.com/skip-mev/connect/v2/abci/types"
 	"github.com/skip-mev/connect/v2/abci/ve/types"
+	"github.com/skip-mev/connect/v2/pkg/tracing"
 	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
 	servicemetrics "github.com/skip-mev/connect/v2/service/metrics"
 	servicetypes "github.com/skip-mev/connect/v2/service/servers/oracle/types"
@@ -78,6 +81,11 @@ func (h *VoteExtensionHandler) ExtendVoteHandler() sdk.ExtendVoteHandler {
 	return func(ctx sdk.Context, req *cometabci.RequestExtendVote) (resp *cometabci.ResponseExtendVote, err error) {
 		start := time.Now()
 
+		// trace the vote extension, including the request to the oracle
+		spanCtx, span := tracing.Start(ctx.Context(), "ve.extend_vote", trace.WithAttributes(
+			attribute.Int64("height", req.GetHeight()),
+		))
+
 		// measure latencies from invocation to return, catch panics first
 		defer func() {
 			// catch panics if possible
@@ -98,6 +106,7 @@ func (h *VoteExtensionHandler) ExtendVoteHandler() sdk.ExtendVoteHandler {
 				"err", err,
 			)
 			connectabci.RecordLatencyAndStatus(h.metrics, latency, err, servicemetrics.ExtendVote)
+			tracing.End(span, err)
 
 			// ignore all non-panic errors
 			var p ErrPanic
@@ -133,7 +142,7 @@ func (h *VoteExtensionHandler) ExtendVoteHandler() sdk.ExtendVoteHandler {
 
 		// Create a context with a timeout to ensure we do not wait forever for the oracle
 		// to respond.
-		reqCtx, cancel := context.WithTimeout(ctx.Context(), h.timeout)
+		reqCtx, cancel := context.WithTimeout(spanCtx, h.timeout)
 		defer cancel()
 
 		// To ensure liveness, we return a vote even if the oracle is not running
@@ -0,0 +1,638 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	cmdconfig "github.com/skip-mev/connect/v2/cmd/connect/config"
+	"github.com/skip-mev/connect/v2/cmd/constants"
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	oraclemetrics "github.com/skip-mev/connect/v2/oracle/metrics"
+	oracletypes "github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/log"
+	oraclemath "github.com/skip-mev/connect/v2/pkg/math/oracle"
+	"github.com/skip-mev/connect/v2/pkg/remoteconfig"
+	"github.com/skip-mev/connect/v2/pkg/signing"
+	"github.com/skip-mev/connect/v2/providers/apis/marketmap"
+	oraclefactory "github.com/skip-mev/connect/v2/providers/factories/oracle"
+	mmclienttypes "github.com/skip-mev/connect/v2/service/clients/marketmap/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+const (
+	// outputText is the tabular output format of the CLI commands.
+	outputText = "text"
+	// outputJSON is the JSON output format of the CLI commands.
+	outputJSON = "json"
+
+	// pricesPollInterval is the interval at which prices get polls the oracle for prices.
+	pricesPollInterval = 250 * time.Millisecond
+)
+
+var (
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the oracle config.",
+	}
+	configValidateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the oracle config and market config without running the oracle.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, marketCfg, err := loadConfigs()
+			if err != nil {
+				return err
+			}
+
+			if err := validateConfigs(cfg, marketCfg); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "config is valid")
+			return nil
+		},
+	}
+
+	configSignCmd = &cobra.Command{
+		Use:   "sign <file>",
+		Short: "Sign a config file and its version for use as a remote config, writing the version and the hex-encoded signature to <file>.sig.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			signer, err := signing.NewSignerFromConfig(config.SigningConfig{
+				Enabled:   true,
+				Algorithm: configSignAlgorithm,
+				KeySource: configSignKeySource,
+			})
+			if err != nil {
+				return err
+			}
+
+			version := configSignVersion
+			if version == 0 {
+				version = uint64(time.Now().Unix()) //nolint:gosec
+			}
+
+			path, err := signConfigFile(signer, args[0], version)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "wrote signature of version %d to %s (public key %s)\n", version, path, hex.EncodeToString(signer.PublicKey()))
+			return nil
+		},
+	}
+
+	providersCmd = &cobra.Command{
+		Use:   "providers",
+		Short: "Inspect the configured providers.",
+	}
+	providersListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the providers of the oracle config, and the number of markets each provides if a market config is given.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, marketCfg, err := loadConfigs()
+			if err != nil {
+				return err
+			}
+
+			return writeProviders(cmd.OutOrStdout(), cfg, marketCfg)
+		},
+	}
+
+	pricesCmd = &cobra.Command{
+		Use:   "prices",
+		Short: "Query prices without running the oracle daemon.",
+	}
+	pricesGetCmd = &cobra.Command{
+		Use:   "get",
+		Short: "Run the configured providers until every enabled market has a price (or the timeout elapses), and print the prices.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return getPrices(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+
+	// cli flag-bound values.
+	cliOutput         string
+	cliLogLevel       string
+	cliTimeout        time.Duration
+	pricesGetProvider []string
+
+	configSignKeySource string
+	configSignAlgorithm string
+	configSignVersion   uint64
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{configCmd, providersCmd, pricesCmd, doctorCmd} {
+		cmd.PersistentFlags().StringVar(
+			&oracleCfgPath,
+			"oracle-config",
+			"",
+			"Path to the oracle config file.",
+		)
+		cmd.PersistentFlags().StringVar(
+			&marketCfgPath,
+			"market-config-path",
+			"",
+			"Path to the market config file. If empty, the markets are fetched from the market map provider.",
+		)
+		cmd.PersistentFlags().StringVar(
+			&marketMapProvider,
+			"marketmap-provider",
+			marketmap.Name,
+			"MarketMap provider to use (marketmap_api, dydx_api, dydx_migration_api).",
+		)
+		cmd.PersistentFlags().StringVar(
+			&marketMapEndPoint,
+			"market-map-endpoint",
+			"",
+			"Use a custom listen-to endpoint for market-map (overwrites what is provided in oracle-config).",
+		)
+	}
+
+	for _, cmd := range []*cobra.Command{providersListCmd, pricesGetCmd, doctorCmd} {
+		cmd.Flags().StringVarP(
+			&cliOutput,
+			"output",
+			"o",
+			outputText,
+			"Output format (text, json).",
+		)
+	}
+
+	for _, cmd := range []*cobra.Command{pricesGetCmd, doctorCmd} {
+		cmd.Flags().StringVar(
+			&cliLogLevel,
+			"log-level",
+			"error",
+			"Log level of the oracle's logs, which are written to stderr (debug, info, warn, error).",
+		)
+	}
+
+	pricesGetCmd.Flags().DurationVar(
+		&cliTimeout,
+		"timeout",
+		30*time.Second,
+		"Maximum time to wait for every enabled market to have a price.",
+	)
+	pricesGetCmd.Flags().StringSliceVar(
+		&pricesGetProvider,
+		"provider",
+		nil,
+		"Only run the given providers. Can be repeated or comma separated. If empty, all configured providers are run.",
+	)
+
+	configSignCmd.Flags().StringVar(
+		&configSignKeySource,
+		"key-source",
+		"",
+		"Source of the hex-encoded private key to sign with, e.g. file:/path/to/key or env:NAME.",
+	)
+	configSignCmd.Flags().StringVar(
+		&configSignAlgorithm,
+		"algorithm",
+		config.SigningAlgorithmEd25519,
+		"Signature algorithm (ed25519 or secp256k1).",
+	)
+	configSignCmd.Flags().Uint64Var(
+		&configSignVersion,
+		"version",
+		0,
+		"Version of the config file. Remote config sources only apply versions newer than the version they applied before. If zero, the current unix time is used.",
+	)
+
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSignCmd)
+	providersCmd.AddCommand(providersListCmd)
+	pricesCmd.AddCommand(pricesGetCmd)
+}
+
+// loadConfigs reads the oracle config and the market config the same way the oracle daemon does.
+// The market config is empty if no market config path is set.
+func loadConfigs() (config.OracleConfig, mmtypes.MarketMap, error) {
+	cfg, err := cmdconfig.ReadOracleConfigWithOverrides(oracleCfgPath, marketMapProvider)
+	if err != nil {
+		return config.OracleConfig{}, mmtypes.MarketMap{}, fmt.Errorf("failed to get oracle config: %w", err)
+	}
+
+	if marketMapEndPoint != "" {
+		cfg, err = overwriteMarketMapEndpoint(cfg, marketMapEndPoint)
+		if err != nil {
+			return config.OracleConfig{}, mmtypes.MarketMap{}, fmt.Errorf("failed to overwrite market endpoint %s: %w", marketMapEndPoint, err)
+		}
+	}
+
+	var marketCfg mmtypes.MarketMap
+	if marketCfgPath != "" {
+		marketCfg, err = cmdconfig.ReadMarketConfigFromFile(marketCfgPath)
+		if err != nil {
+			return config.OracleConfig{}, mmtypes.MarketMap{}, fmt.Errorf("failed to read market config file: %w", err)
+		}
+	}
+
+	return cfg, marketCfg, nil
+}
+
+// signConfigFile signs the contents of a config file along with the given version, and writes the
+// version and the hex-encoded signature next to it, where remote config sources expect them. It
+// returns the path of the signature.
+func signConfigFile(signer *signing.Signer, path string, version uint64) (string, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signer.SignMessage(remoteconfig.SignedMessage(version, bz))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign %s: %w", path, err)
+	}
+
+	sigPath := path + remoteconfig.SignatureExtension
+	if err := os.WriteFile(sigPath, remoteconfig.EncodeSignature(version, signature), 0o600); err != nil {
+		return "", err
+	}
+
+	return sigPath, nil
+}
+
+// validateConfigs performs the checks the oracle daemon performs on startup, and checks that the
+// providers of the oracle config and the market config are consistent. All errors found are
+// returned.
+func validateConfigs(cfg config.OracleConfig, marketCfg mmtypes.MarketMap) error {
+	var errs []error
+
+	if err := cfg.ValidateBasic(); err != nil {
+		errs = append(errs, fmt.Errorf("oracle config is invalid: %w", err))
+	}
+
+	known := make(map[string]struct{})
+	for _, provider := range append(constants.Providers, constants.AlternativeMarketMapProviders...) {
+		known[provider.Name] = struct{}{}
+	}
+	for name := range cfg.Providers {
+		if _, ok := known[name]; !ok {
+			errs = append(errs, fmt.Errorf("provider %s is not supported", name))
+		}
+	}
+
+	if len(marketCfg.Markets) == 0 {
+		// the markets are fetched from the market map provider.
+		if provider, ok := cfg.Providers[marketMapProvider]; ok && marketMapProvider == marketmap.Name {
+			for _, endpoint := range provider.API.Endpoints {
+				if err := isValidGRPCEndpoint(endpoint.URL); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+
+	if err := marketCfg.ValidateBasic(); err != nil {
+		errs = append(errs, fmt.Errorf("market config is invalid: %w", err))
+	}
+
+	if err := oraclefactory.ValidateEVMMetadata(marketCfg).Err(); err != nil {
+		errs = append(errs, fmt.Errorf("market config has invalid provider metadata: %w", err))
+	}
+
+	tickers := make([]string, 0, len(marketCfg.Markets))
+	for ticker := range marketCfg.Markets {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+
+	for _, ticker := range tickers {
+		market := marketCfg.Markets[ticker]
+		for _, providerCfg := range market.ProviderConfigs {
+			provider, ok := cfg.Providers[providerCfg.Name]
+			switch {
+			case !ok:
+				errs = append(errs, fmt.Errorf("market %s uses provider %s which is not in the oracle config", ticker, providerCfg.Name))
+			case provider.Type != oracletypes.ConfigType:
+				errs = append(errs, fmt.Errorf("market %s uses provider %s which is not a price provider", ticker, providerCfg.Name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// providerInfo is the summary of a provider printed by providers list.
+type providerInfo struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Transport string   `json:"transport"`
+	Endpoints []string `json:"endpoints"`
+	Markets   int      `json:"markets"`
+}
+
+// writeProviders writes a summary of each provider of the oracle config to w, sorted by name.
+func writeProviders(w io.Writer, cfg config.OracleConfig, marketCfg mmtypes.MarketMap) error {
+	markets := make(map[string]int)
+	for _, market := range marketCfg.Markets {
+		for _, providerCfg := range market.ProviderConfigs {
+			markets[providerCfg.Name]++
+		}
+	}
+
+	infos := make([]providerInfo, 0, len(cfg.Providers))
+	for name, provider := range cfg.Providers {
+		info := providerInfo{
+			Name:      name,
+			Type:      provider.Type,
+			Endpoints: make([]string, 0),
+			Markets:   markets[name],
+		}
+
+		switch {
+		case provider.API.Enabled:
+			info.Transport = "api"
+			for _, endpoint := range provider.API.Endpoints {
+				info.Endpoints = append(info.Endpoints, endpoint.URL)
+			}
+		case provider.WebSocket.Enabled:
+			info.Transport = "websocket"
+			for _, endpoint := range provider.WebSocket.Endpoints {
+				info.Endpoints = append(info.Endpoints, endpoint.URL)
+			}
+		}
+
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	if cliOutput == outputJSON {
+		return json.NewEncoder(w).Encode(infos)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tTRANSPORT\tMARKETS\tENDPOINTS")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", info.Name, info.Type, info.Transport, info.Markets, strings.Join(info.Endpoints, ","))
+	}
+
+	return tw.Flush()
+}
+
+// priceInfo is an aggregated price printed by prices get.
+type priceInfo struct {
+	// Price is the price in the quote currency.
+	Price string `json:"price"`
+	// Raw is the price scaled by the decimals of the market, as reported by the oracle.
+	Raw string `json:"raw"`
+	// Providers are the prices reported by each provider, keyed by provider name.
+	Providers map[string]string `json:"providers,omitempty"`
+}
+
+// getPrices runs the oracle with the configured providers until every enabled market has a price
+// or the timeout elapses, and writes the aggregated prices, and the prices and errors of each
+// provider, to w.
+func getPrices(ctx context.Context, w io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	logger := newCLILogger()
+	defer logger.Sync()
+
+	cfg, marketCfg, err := loadConfigs()
+	if err != nil {
+		return err
+	}
+
+	if len(pricesGetProvider) > 0 {
+		selected := make(map[string]struct{}, len(pricesGetProvider))
+		for _, name := range pricesGetProvider {
+			if _, ok := cfg.Providers[name]; !ok {
+				return fmt.Errorf("provider %s is not in the oracle config", name)
+			}
+			selected[name] = struct{}{}
+		}
+
+		for name, provider := range cfg.Providers {
+			if _, ok := selected[name]; !ok && provider.Type != mmclienttypes.ConfigType {
+				delete(cfg.Providers, name)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cliTimeout)
+	defer cancel()
+
+	orc, err := startOracle(ctx, logger, cfg, marketCfg)
+	if err != nil {
+		return err
+	}
+	defer orc.Stop()
+
+	ticker := time.NewTicker(pricesPollInterval)
+	defer ticker.Stop()
+
+	for !hasAllPrices(orc) {
+		select {
+		case <-ctx.Done():
+			logger.Warn("timed out waiting for the prices of all markets")
+			return writePrices(w, orc)
+		case <-ticker.C:
+		}
+	}
+
+	return writePrices(w, orc)
+}
+
+// newCLILogger returns a logger that writes the oracle's logs at the configured log level to
+// stderr, so that they are not mixed with the output of the CLI commands.
+func newCLILogger() *zap.Logger {
+	logCfg := log.NewDefaultConfig()
+	logCfg.StdOutLogLevel = cliLogLevel
+	logCfg.WriteTo = ""
+	return log.NewLogger(logCfg)
+}
+
+// startOracle creates an oracle with the given configs and starts it in the background until the
+// given context is done. The markets are fetched from the market map provider if the market config
+// is empty. The caller must stop the oracle.
+func startOracle(
+	ctx context.Context,
+	logger *zap.Logger,
+	cfg config.OracleConfig,
+	marketCfg mmtypes.MarketMap,
+) (oracle.Oracle, error) {
+	aggregator, err := oraclemath.NewIndexPriceAggregator(
+		logger,
+		marketCfg,
+		oraclemetrics.NewNopMetrics(),
+		oraclemath.WithAggregationConfig(cfg.Aggregation),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data aggregator: %w", err)
+	}
+
+	oracleOpts := []oracle.Option{
+		oracle.WithLogger(logger),
+		oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+		oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+		oracle.WithMarketMapperFactory(oraclefactory.MarketMapProviderFactory),
+		oracle.WithMetrics(oraclemetrics.NewNopMetrics()),
+	}
+	if len(marketCfg.Markets) > 0 {
+		oracleOpts = append(oracleOpts, oracle.WithMarketMap(marketCfg))
+	}
+
+	orc, err := oracle.New(cfg, aggregator, oracleOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oracle: %w", err)
+	}
+
+	go func() {
+		if err := orc.Start(ctx); err != nil && ctx.Err() == nil {
+			logger.Error("failed to start oracle", zap.Error(err))
+		}
+	}()
+
+	return orc, nil
+}
+
+// hasAllPrices returns true if the oracle has a price for every enabled market of its market map.
+func hasAllPrices(orc oracle.Oracle) bool {
+	marketMap := orc.GetMarketMap()
+	if len(marketMap.Markets) == 0 {
+		return false
+	}
+
+	prices := orc.GetPrices()
+	for ticker, market := range marketMap.Markets {
+		if _, ok := prices[ticker]; market.Ticker.Enabled && !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writePrices writes the aggregated prices of the oracle, and the prices and errors of each of its
+// providers, to w.
+func writePrices(w io.Writer, orc oracle.Oracle) error {
+	marketMap := orc.GetMarketMap()
+
+	// the provider prices are keyed by off-chain ticker, so they are attributed to markets through
+	// the market map.
+	offChainTickers := make(map[string]map[string]string)
+	for ticker, market := range marketMap.Markets {
+		for _, providerCfg := range market.ProviderConfigs {
+			if offChainTickers[providerCfg.Name] == nil {
+				offChainTickers[providerCfg.Name] = make(map[string]string)
+			}
+			offChainTickers[providerCfg.Name][providerCfg.OffChainTicker] = ticker
+		}
+	}
+
+	prices := make(map[string]*priceInfo)
+	for ticker, price := range orc.GetPrices() {
+		market, ok := marketMap.Markets[ticker]
+		if !ok || price == nil {
+			continue
+		}
+
+		scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(market.Ticker.Decimals), nil))
+		raw, _ := price.Int(nil)
+		prices[ticker] = &priceInfo{
+			Price:     new(big.Float).Quo(price, scale).Text('f', int(market.Ticker.Decimals)),
+			Raw:       raw.String(),
+			Providers: make(map[string]string),
+		}
+	}
+
+	var errs []string
+	for name, state := range orc.GetProviderState() {
+		if state.Provider == nil {
+			continue
+		}
+
+		for ticker, result := range state.Provider.GetData() {
+			if info, ok := prices[offChainTickers[name][ticker.GetOffChainTicker()]]; ok {
+				info.Providers[name] = result.Value.String()
+			}
+		}
+
+		for ticker, result := range state.Provider.GetErrors() {
+			errs = append(errs, fmt.Sprintf("%s %s: %s", name, ticker.GetOffChainTicker(), result.Error()))
+		}
+	}
+	sort.Strings(errs)
+
+	var missing []string
+	for ticker, market := range marketMap.Markets {
+		if _, ok := prices[ticker]; market.Ticker.Enabled && !ok {
+			missing = append(missing, ticker)
+		}
+	}
+	sort.Strings(missing)
+
+	if cliOutput == outputJSON {
+		return json.NewEncoder(w).Encode(struct {
+			Prices  map[string]*priceInfo `json:"prices"`
+			Missing []string              `json:"missing,omitempty"`
+			Errors  []string              `json:"errors,omitempty"`
+		}{
+			Prices:  prices,
+			Missing: missing,
+			Errors:  errs,
+		})
+	}
+
+	tickers := make([]string, 0, len(prices))
+	for ticker := range prices {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MARKET\tPRICE\tPROVIDERS")
+	for _, ticker := range tickers {
+		info := prices[ticker]
+
+		providers := make([]string, 0, len(info.Providers))
+		for name, price := range info.Providers {
+			providers = append(providers, name+"="+price)
+		}
+		sort.Strings(providers)
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", ticker, info.Price, strings.Join(providers, " "))
+	}
+	for _, ticker := range missing {
+		fmt.Fprintf(tw, "%s\t-\t\n", ticker)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		fmt.Fprintln(w, "\nErrors:")
+		for _, err := range errs {
+			fmt.Fprintln(w, "  "+err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cmdconfig "github.com/skip-mev/connect/v2/cmd/connect/config"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+	"github.com/skip-mev/connect/v2/pkg/remoteconfig"
+	"github.com/skip-mev/connect/v2/pkg/signing"
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/uniswapv3"
+	"github.com/skip-mev/connect/v2/providers/apis/marketmap"
+	"github.com/skip-mev/connect/v2/providers/websockets/coinbase"
+	"github.com/skip-mev/connect/v2/providers/websockets/okx"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+func testMarketMap(providers ...string) mmtypes.MarketMap {
+	cp := connecttypes.NewCurrencyPair("BTC", "USD")
+
+	providerConfigs := make([]mmtypes.ProviderConfig, 0, len(providers))
+	for _, provider := range providers {
+		providerConfigs = append(providerConfigs, mmtypes.ProviderConfig{
+			Name:           provider,
+			OffChainTicker: "BTC-USD",
+		})
+	}
+
+	return mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			cp.String(): {
+				Ticker: mmtypes.Ticker{
+					CurrencyPair:     cp,
+					MinProviderCount: 1,
+					Decimals:         8,
+					Enabled:          true,
+				},
+				ProviderConfigs: providerConfigs,
+			},
+		},
+	}
+}
+
+func TestValidateConfigs(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       func() config.OracleConfig
+		marketCfg mmtypes.MarketMap
+		errMsgs   []string
+	}{
+		{
+			name:      "default config with a market config",
+			cfg:       cmdconfig.DefaultOracleConfig,
+			marketCfg: testMarketMap(coinbase.Name, okx.Name),
+		},
+		{
+			name: "default config without a market config",
+			cfg:  cmdconfig.DefaultOracleConfig,
+		},
+		{
+			name: "unknown provider",
+			cfg: func() config.OracleConfig {
+				cfg := cmdconfig.DefaultOracleConfig()
+				provider := cfg.Providers[coinbase.Name]
+				provider.Name = "unknown"
+				cfg.Providers["unknown"] = provider
+				return cfg
+			},
+			errMsgs: []string{"provider unknown is not supported"},
+		},
+		{
+			name: "market uses a provider missing from the oracle config",
+			cfg: func() config.OracleConfig {
+				cfg := cmdconfig.DefaultOracleConfig()
+				delete(cfg.Providers, okx.Name)
+				return cfg
+			},
+			marketCfg: testMarketMap(coinbase.Name, okx.Name),
+			errMsgs:   []string{"market BTC/USD uses provider okx_ws which is not in the oracle config"},
+		},
+		{
+			name:      "market uses a market map provider",
+			cfg:       cmdconfig.DefaultOracleConfig,
+			marketCfg: testMarketMap(marketmap.Name),
+			errMsgs:   []string{"market BTC/USD uses provider marketmap_api which is not a price provider"},
+		},
+		{
+			name: "invalid evm provider metadata",
+			cfg:  cmdconfig.DefaultOracleConfig,
+			marketCfg: func() mmtypes.MarketMap {
+				marketCfg := testMarketMap(uniswapv3.ProviderNames[constants.BASE])
+				market := marketCfg.Markets["BTC/USD"]
+				market.ProviderConfigs[0].Metadata_JSON = `{"address": "0xd0b53d9277642d899df5c87a3966a349a798f224", "base_decimal": 18}`
+				return marketCfg
+			}(),
+			errMsgs: []string{
+				`$.markets["BTC/USD"].provider_configs[0].metadata_JSON.address: address "0xd0b53d9277642d899df5c87a3966a349a798f224" is not checksummed, expected "0xd0b53D9277642d899DF5C87A3966A349A798F224"`,
+				`$.markets["BTC/USD"].provider_configs[0].metadata_JSON.base_decimal: unknown field "base_decimal", did you mean "base_decimals"?`,
+			},
+		},
+		{
+			name: "invalid market map endpoint",
+			cfg: func() config.OracleConfig {
+				cfg := cmdconfig.DefaultOracleConfig()
+				provider := cfg.Providers[marketmap.Name]
+				provider.API.Endpoints = []config.Endpoint{{URL: "http://localhost:9090"}}
+				cfg.Providers[marketmap.Name] = provider
+				return cfg
+			},
+			errMsgs: []string{"expected gRPC endpoint but got HTTP endpoint"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfigs(tt.cfg(), tt.marketCfg)
+			if len(tt.errMsgs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, msg := range tt.errMsgs {
+				require.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
+
+func TestWriteProviders(t *testing.T) {
+	cfg := cmdconfig.DefaultOracleConfig()
+	marketCfg := testMarketMap(coinbase.Name, okx.Name)
+
+	defer func(output string) { cliOutput = output }(cliOutput)
+	cliOutput = outputJSON
+
+	var buf bytes.Buffer
+	require.NoError(t, writeProviders(&buf, cfg, marketCfg))
+
+	var infos []providerInfo
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &infos))
+	require.Len(t, infos, len(cfg.Providers))
+
+	byName := make(map[string]providerInfo, len(infos))
+	for i, info := range infos {
+		if i > 0 {
+			require.Less(t, infos[i-1].Name, info.Name)
+		}
+		byName[info.Name] = info
+	}
+
+	require.Equal(t, "websocket", byName[coinbase.Name].Transport)
+	require.Equal(t, 1, byName[coinbase.Name].Markets)
+	require.Equal(t, "api", byName[marketmap.Name].Transport)
+	require.Equal(t, 0, byName[marketmap.Name].Markets)
+
+	// the text output has a header and a row per provider
+	cliOutput = outputText
+	buf.Reset()
+	require.NoError(t, writeProviders(&buf, cfg, marketCfg))
+	require.Len(t, bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")), len(cfg.Providers)+1)
+}
+
+func TestSignConfigFile(t *testing.T) {
+	signer, err := signing.NewSigner(config.SigningAlgorithmEd25519, []byte(strings.Repeat("\x01", 32)))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "oracle.json")
+	bz := []byte(`{"providers": {}}`)
+	require.NoError(t, os.WriteFile(path, bz, 0o600))
+
+	sigPath, err := signConfigFile(signer, path, 7)
+	require.NoError(t, err)
+	require.Equal(t, path+".sig", sigPath)
+
+	// the signature and its version are accepted by remote config sources.
+	signature, err := os.ReadFile(sigPath)
+	require.NoError(t, err)
+
+	verifier, err := remoteconfig.NewVerifier(config.SigningAlgorithmEd25519, hex.EncodeToString(signer.PublicKey()))
+	require.NoError(t, err)
+	version, err := verifier.Verify(bz, signature)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), version)
+
+	_, err = signConfigFile(signer, filepath.Join(t.TempDir(), "missing.json"), 7)
+	require.Error(t, err)
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"os"
 	"reflect"
 	"slices"
 	"strings"
@@ -11,8 +14,10 @@ import (
 
 	"github.com/skip-mev/connect/v2/cmd/constants"
 	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/schema"
 	"github.com/skip-mev/connect/v2/providers/apis/dydx"
 	mmtypes "github.com/skip-mev/connect/v2/service/clients/marketmap/types"
+	marketmaptypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 )
 
 const (
@@ -26,6 +31,41 @@ const (
 	DefaultMetricsEnabled = true
 	// DefaultTelemetryDisabled is the default value for disabling telemetry.
 	DefaultTelemetryDisabled = false
+	// DefaultTracingEnabled is the default value for exporting traces in connect.
+	DefaultTracingEnabled = false
+	// DefaultTracingEndpoint is the default OTLP gRPC endpoint traces are exported to.
+	DefaultTracingEndpoint = "localhost:4317"
+	// DefaultTracingSampleRatio is the default fraction of traces that are sampled.
+	DefaultTracingSampleRatio = 1.0
+	// DefaultSigningEnabled is the default value for signing price reports in connect.
+	DefaultSigningEnabled = false
+	// DefaultSigningAlgorithm is the default algorithm price reports are signed with.
+	DefaultSigningAlgorithm = config.SigningAlgorithmEd25519
+	// DefaultPushEnabled is the default value for serving the websocket push API in connect.
+	DefaultPushEnabled = false
+	// DefaultPushThreshold is the default minimum relative change of a price for it to be pushed.
+	DefaultPushThreshold = 0.0005
+	// DefaultHistoryEnabled is the default value for persisting the price history in connect.
+	DefaultHistoryEnabled = false
+	// DefaultHistoryPath is the default path of the price history database.
+	DefaultHistoryPath = "connect-history.db"
+	// DefaultHistoryRetention is the default duration the price history is kept for, i.e. a week.
+	DefaultHistoryRetention = 604800000000000
+	// DefaultExportTimeout is the default timeout of exporting prices to a time-series database.
+	DefaultExportTimeout = 5000000000
+	// DefaultInfluxDBMeasurement is the default measurement prices are exported to InfluxDB as.
+	DefaultInfluxDBMeasurement = "connect_price"
+	// DefaultTimescaleDBTable is the default table prices are exported to TimescaleDB in.
+	DefaultTimescaleDBTable = "connect_prices"
+	// DefaultRemoteWriteMetricName is the default metric name prices are exported to a Prometheus
+	// remote-write endpoint as.
+	DefaultRemoteWriteMetricName = "connect_price"
+	// DefaultCurrencyPairsEnabled is the default value for restricting the markets connect prices to
+	// the chain's currency pairs.
+	DefaultCurrencyPairsEnabled = false
+	// DefaultCurrencyPairsInterval is the default interval at which the chain's currency pairs are
+	// queried, i.e. a minute.
+	DefaultCurrencyPairsInterval = 60000000000
 	// DefaultHost is the default for the connect oracle server host.
 	DefaultHost = "0.0.0.0"
 	// DefaultPort is the default for the connect oracle server port.
@@ -51,6 +91,42 @@ func DefaultOracleConfig() config.OracleConfig {
 				PushAddress: TelemetryPushAddress,
 			},
 		},
+		Tracing: config.TracingConfig{
+			Enabled:     DefaultTracingEnabled,
+			Endpoint:    DefaultTracingEndpoint,
+			SampleRatio: DefaultTracingSampleRatio,
+		},
+		Signing: config.SigningConfig{
+			Enabled:   DefaultSigningEnabled,
+			Algorithm: DefaultSigningAlgorithm,
+		},
+		Push: config.PushConfig{
+			Enabled:   DefaultPushEnabled,
+			Threshold: DefaultPushThreshold,
+		},
+		History: config.HistoryConfig{
+			Enabled:   DefaultHistoryEnabled,
+			Path:      DefaultHistoryPath,
+			Retention: DefaultHistoryRetention,
+		},
+		Export: config.ExportConfig{
+			InfluxDB: config.InfluxDBExportConfig{
+				Measurement: DefaultInfluxDBMeasurement,
+				Timeout:     DefaultExportTimeout,
+			},
+			TimescaleDB: config.TimescaleDBExportConfig{
+				Table:   DefaultTimescaleDBTable,
+				Timeout: DefaultExportTimeout,
+			},
+			RemoteWrite: config.RemoteWriteExportConfig{
+				MetricName: DefaultRemoteWriteMetricName,
+				Timeout:    DefaultExportTimeout,
+			},
+		},
+		CurrencyPairs: config.CurrencyPairsConfig{
+			Enabled:  DefaultCurrencyPairsEnabled,
+			Interval: DefaultCurrencyPairsInterval,
+		},
 		Providers: make(map[string]config.ProviderConfig),
 		Host:      DefaultHost,
 		Port:      DefaultPort,
@@ -110,10 +186,18 @@ func ReadOracleConfigWithOverrides(path string, marketMapProvider string) (confi
 	// if the path is non-nil read data from a file\
 	SetDefaults()
 	if path != "" {
-		viper.SetConfigFile(path)
-		viper.SetConfigType("json")
+		bz, err := os.ReadFile(path)
+		if err != nil {
+			return config.OracleConfig{}, err
+		}
 
-		if err := viper.ReadInConfig(); err != nil {
+		bz, err = parseOracleConfigFile(path, bz)
+		if err != nil {
+			return config.OracleConfig{}, err
+		}
+
+		viper.SetConfigType("json")
+		if err := viper.ReadConfig(bytes.NewReader(bz)); err != nil {
 			return config.OracleConfig{}, err
 		}
 	}
@@ -140,6 +224,61 @@ func ReadOracleConfigWithOverrides(path string, marketMapProvider string) (confi
 	return cfg, cfg.ValidateBasic()
 }
 
+// ValidateOracleConfigFile validates the contents of an oracle config file against the schema of
+// the oracle config, without applying the defaults and overrides of ReadOracleConfigWithOverrides.
+// The format of the file is selected by the extension of the given path.
+func ValidateOracleConfigFile(path string, bz []byte) error {
+	_, err := parseOracleConfigFile(path, bz)
+	return err
+}
+
+// parseOracleConfigFile parses the contents of a json, toml or yaml oracle config file into json
+// with its environment variables interpolated, and strictly validates it, so that every violation
+// is reported at once rather than only the first unmarshal error.
+func parseOracleConfigFile(path string, bz []byte) ([]byte, error) {
+	bz, err := config.ParseFile(path, bz)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := schema.Validate(bz, &config.OracleConfig{}).Err(); err != nil {
+		return nil, fmt.Errorf("oracle config %s is invalid: %w", path, err)
+	}
+
+	return bz, nil
+}
+
+// ReadMarketConfigFromFile reads a market map from a json, toml or yaml file with its environment
+// variables interpolated, and validates it.
+func ReadMarketConfigFromFile(path string) (marketmaptypes.MarketMap, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return marketmaptypes.MarketMap{}, err
+	}
+
+	return ParseMarketConfig(path, bz)
+}
+
+// ParseMarketConfig is ReadMarketConfigFromFile for the contents of a market config file that has
+// already been read. The format of the file is selected by the extension of the given path.
+func ParseMarketConfig(path string, bz []byte) (marketmaptypes.MarketMap, error) {
+	bz, err := config.ParseFile(path, bz)
+	if err != nil {
+		return marketmaptypes.MarketMap{}, err
+	}
+
+	var marketMap marketmaptypes.MarketMap
+	if err := json.Unmarshal(bz, &marketMap); err != nil {
+		return marketmaptypes.MarketMap{}, fmt.Errorf("error unmarshalling market config: %w", err)
+	}
+
+	if err := marketMap.ValidateBasic(); err != nil {
+		return marketmaptypes.MarketMap{}, err
+	}
+
+	return marketMap, nil
+}
+
 // oracleConfigFromViper unmarshals an oracle config from viper, validates it, and returns it.
 func oracleConfigFromViper() (config.OracleConfig, error) {
 	var cfg config.OracleConfig
@@ -206,6 +345,7 @@ func updateEndpointFromEnvironment(endpoint config.Endpoint, providerName string
 	endpointURL := viper.Get(fmt.Sprintf("providers.%s.%s.endpoints.%d.url", providerName, configType, idx))
 	endpointAPIKey := viper.Get(fmt.Sprintf("providers.%s.%s.endpoints.%d.authentication.apiKey", providerName, configType, idx))
 	endpointAPIKeyHeader := viper.Get(fmt.Sprintf("providers.%s.%s.endpoints.%d.authentication.apiKeyHeader", providerName, configType, idx))
+	endpointAPIKeySource := viper.Get(fmt.Sprintf("providers.%s.%s.endpoints.%d.authentication.apiKeySource", providerName, configType, idx))
 
 	// if the environment variable exists, set the endpoint to the value of the environment variable
 	if endpointURL != nil {
@@ -220,7 +360,11 @@ func updateEndpointFromEnvironment(endpoint config.Endpoint, providerName string
 		endpoint.Authentication.APIKeyHeader = endpointAPIKeyHeader.(string)
 	}
 
-	return endpoint, endpointURL != nil || endpointAPIKey != nil || endpointAPIKeyHeader != nil
+	if endpointAPIKeySource != nil {
+		endpoint.Authentication.APIKeySource = endpointAPIKeySource.(string)
+	}
+
+	return endpoint, endpointURL != nil || endpointAPIKey != nil || endpointAPIKeyHeader != nil || endpointAPIKeySource != nil
 }
 
 func GetNodeEndpointFromConfig(cfg config.OracleConfig) (config.Endpoint, error) {
@@ -3,6 +3,7 @@ package config_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -350,6 +351,47 @@ func TestReadOracleConfigWithOverrides(t *testing.T) {
 		require.Equal(t, expectedConfig.Metrics.PrometheusServerAddress, cfg.Metrics.PrometheusServerAddress)
 	})
 
+	t.Run("overriding variables via a yaml config with environment variables", func(t *testing.T) {
+		t.Setenv("CONNECT_TEST_API_KEY", endpointOverride.Authentication.APIKey)
+
+		path := filepath.Join(t.TempDir(), "connect-config.yaml")
+		overrides := fmt.Sprintf(`
+updateInterval: %s
+metrics:
+  prometheusServerAddress: "%s"
+providers:
+  %s:
+    api:
+      endpoints:
+        - url: "%s"
+        - url: "%s"
+          authentication:
+            apiKey: "${CONNECT_TEST_API_KEY}"
+            apiKeyHeader: "%s"
+  %s:
+    webSocket:
+      endpoints:
+        - url: "%s"
+`,
+			updateIntervalOverride,
+			prometheusServerOverride,
+			raydium.Name,
+			raydium.DefaultAPIConfig.Endpoints[0].URL,
+			endpointOverride.URL,
+			endpointOverride.Authentication.APIKeyHeader,
+			coinbase.Name,
+			endpointOverride.URL,
+		)
+		require.NoError(t, os.WriteFile(path, []byte(overrides), 0o600))
+
+		cfg, err := cmdconfig.ReadOracleConfigWithOverrides(path, marketmap.Name)
+		require.NoError(t, err)
+
+		require.Equal(t, expectedConfig.Providers, cfg.Providers)
+		require.Equal(t, expectedConfig.UpdateInterval, cfg.UpdateInterval)
+		require.Equal(t, expectedConfig.Metrics.PrometheusServerAddress, cfg.Metrics.PrometheusServerAddress)
+	})
+
 	t.Run("overriding a nonexistent provider via config fails", func(t *testing.T) {
 		// create a temp file in the current directory
 		tmpfile, err := os.CreateTemp("", "connect-config-*.json")
@@ -426,6 +468,39 @@ func TestOracleConfigWithExtraKeys(t *testing.T) {
 		_, err = cmdconfig.ReadOracleConfigWithOverrides(tmpfile.Name(), marketmap.Name)
 		require.Error(t, err)
 	})
+
+	t.Run("every schema violation of an oracle config is reported", func(t *testing.T) {
+		// create a temp file in the current directory
+		tmpfile, err := os.CreateTemp("", "connect-config-*.json")
+		require.NoError(t, err)
+
+		defer os.Remove(tmpfile.Name())
+
+		overrides := `
+		{
+			"updateInteval": "250ms",
+			"maxPriceAge": "two minutes",
+			"providers": {
+				"raydium_api": {
+					"api": {
+						"endpoints": [
+							{
+								"url": "somewhere"
+							}
+						]
+					}
+				}
+			}
+		}
+		`
+		tmpfile.Write([]byte(overrides))
+
+		_, err = cmdconfig.ReadOracleConfigWithOverrides(tmpfile.Name(), marketmap.Name)
+		require.ErrorContains(t, err, "found 3 schema violation(s)")
+		require.ErrorContains(t, err, `$.maxPriceAge: invalid duration "two minutes"`)
+		require.ErrorContains(t, err, `$.providers.raydium_api.api.endpoints[0].url: invalid URL "somewhere"`)
+		require.ErrorContains(t, err, `$.updateInteval: unknown field "updateInteval", did you mean "updateInterval"?`)
+	})
 }
 
 func filterMarketMapProvidersFromOracleConfig(cfg oracleconfig.OracleConfig, mmProvider string) oracleconfig.OracleConfig {
@@ -0,0 +1,516 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	oracletypes "github.com/skip-mev/connect/v2/oracle/types"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+const (
+	// doctorCheckConfig checks that the oracle config and market config are valid.
+	doctorCheckConfig = "config"
+	// doctorCheckEndpoint checks that an endpoint of a provider can be connected to.
+	doctorCheckEndpoint = "endpoint"
+	// doctorCheckAuth checks that the API key of an endpoint can be read, and is not rejected.
+	doctorCheckAuth = "auth"
+	// doctorCheckPrice checks that a provider reports a price of a market.
+	doctorCheckPrice = "price"
+	// doctorCheckScale checks that a market has a price at its decimals, and that the prices of
+	// its providers agree with it.
+	doctorCheckScale = "scale"
+
+	// doctorStatusPass is the status of a check that passed.
+	doctorStatusPass = "pass"
+	// doctorStatusFail is the status of a check that failed.
+	doctorStatusFail = "fail"
+
+	// doctorDialTimeout is the timeout of connecting to an endpoint, and of a request to it.
+	doctorDialTimeout = 5 * time.Second
+)
+
+var (
+	doctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that the oracle config works before deploying it.",
+		Long: "Check the oracle config and market config, connect to every endpoint of the configured providers, " +
+			"verify their API keys, run the providers until each reports a price of every market it provides " +
+			"(or the timeout elapses), and check that the prices agree with the decimals of each market. A " +
+			"pass/fail report is printed, and the command fails if any check failed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDoctor(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+
+	// doctorMaxDeviation is the relative deviation of a provider's price from the aggregated price
+	// above which the scale check of a market fails.
+	doctorMaxDeviation float64
+)
+
+func init() {
+	doctorCmd.Flags().DurationVar(
+		&cliTimeout,
+		"timeout",
+		30*time.Second,
+		"Maximum time to wait for every provider to report a price of each market it provides.",
+	)
+	doctorCmd.Flags().Float64Var(
+		&doctorMaxDeviation,
+		"max-deviation",
+		0.1,
+		"Maximum relative deviation of a provider's price from the aggregated price, e.g. 0.1 for 10%.",
+	)
+}
+
+// doctorCheck is the result of a single check of connect doctor.
+type doctorCheck struct {
+	// Check is the kind of the check.
+	Check string `json:"check"`
+	// Subject is what was checked, e.g. a provider's endpoint or a market.
+	Subject string `json:"subject"`
+	// Status is either pass or fail.
+	Status string `json:"status"`
+	// Detail describes the result of the check.
+	Detail string `json:"detail"`
+}
+
+// providerResults are the prices and errors a provider reported, keyed by off-chain ticker.
+type providerResults struct {
+	prices map[string]*big.Float
+	errors map[string]string
+}
+
+// runDoctor runs every check of the configured oracle, and writes the report to w. An error is
+// returned if any check failed.
+func runDoctor(ctx context.Context, w io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	logger := newCLILogger()
+	defer logger.Sync()
+
+	cfg, marketCfg, err := loadConfigs()
+	if err != nil {
+		return err
+	}
+
+	checks := []doctorCheck{checkConfigs(cfg, marketCfg)}
+	checks = append(checks, checkEndpoints(ctx, cfg)...)
+
+	if checks[0].Status == doctorStatusPass {
+		ctx, cancel := context.WithTimeout(ctx, cliTimeout)
+		defer cancel()
+
+		orc, err := startOracle(ctx, logger, cfg, marketCfg)
+		if err != nil {
+			return err
+		}
+		defer orc.Stop()
+
+		ticker := time.NewTicker(pricesPollInterval)
+		defer ticker.Stop()
+
+	wait:
+		for !hasAllProviderPrices(orc) {
+			select {
+			case <-ctx.Done():
+				logger.Warn("timed out waiting for the prices of all providers")
+				break wait
+			case <-ticker.C:
+			}
+		}
+
+		checks = append(checks, checkPrices(orc.GetMarketMap(), orc.GetPrices(), getProviderResults(orc), doctorMaxDeviation)...)
+	}
+
+	return writeDoctorReport(w, checks)
+}
+
+// checkConfigs checks the oracle config and market config as done by config validate.
+func checkConfigs(cfg config.OracleConfig, marketCfg mmtypes.MarketMap) doctorCheck {
+	check := doctorCheck{Check: doctorCheckConfig, Subject: oracleCfgPath}
+	if err := validateConfigs(cfg, marketCfg); err != nil {
+		check.Status = doctorStatusFail
+		check.Detail = strings.ReplaceAll(err.Error(), "\n", "; ")
+		return check
+	}
+
+	check.Status = doctorStatusPass
+	check.Detail = "config is valid"
+	return check
+}
+
+// checkEndpoints connects to every endpoint of the enabled providers of the oracle config, and
+// checks the API key of each endpoint that has one. The endpoints are checked concurrently, and the
+// checks are returned sorted by provider.
+func checkEndpoints(ctx context.Context, cfg config.OracleConfig) []doctorCheck {
+	names := make([]string, 0, len(cfg.Providers))
+	for name := range cfg.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type providerEndpoint struct {
+		provider string
+		endpoint config.Endpoint
+	}
+
+	var endpoints []providerEndpoint
+	for _, name := range names {
+		switch provider := cfg.Providers[name]; {
+		case provider.API.Enabled:
+			for _, endpoint := range provider.API.Endpoints {
+				endpoints = append(endpoints, providerEndpoint{provider: name, endpoint: endpoint})
+			}
+		case provider.WebSocket.Enabled:
+			for _, endpoint := range provider.WebSocket.Endpoints {
+				endpoints = append(endpoints, providerEndpoint{provider: name, endpoint: endpoint})
+			}
+		}
+	}
+
+	results := make([][]doctorCheck, len(endpoints))
+	var wg sync.WaitGroup
+	for i, pe := range endpoints {
+		wg.Add(1)
+		go func(i int, pe providerEndpoint) {
+			defer wg.Done()
+			results[i] = checkEndpoint(ctx, pe.provider, pe.endpoint)
+		}(i, pe)
+	}
+	wg.Wait()
+
+	var checks []doctorCheck
+	for _, result := range results {
+		checks = append(checks, result...)
+	}
+
+	return checks
+}
+
+// checkEndpoint connects to the given endpoint of a provider and, if the endpoint has an API key,
+// checks that the key can be read and is not rejected by the endpoint.
+func checkEndpoint(ctx context.Context, provider string, endpoint config.Endpoint) []doctorCheck {
+	subject := provider + " " + endpoint.URL
+	checks := []doctorCheck{{Check: doctorCheckEndpoint, Subject: subject}}
+
+	start := time.Now()
+	if err := dialEndpoint(ctx, endpoint.URL); err != nil {
+		checks[0].Status = doctorStatusFail
+		checks[0].Detail = err.Error()
+		return checks
+	}
+	checks[0].Status = doctorStatusPass
+	checks[0].Detail = fmt.Sprintf("connected in %s", time.Since(start).Round(time.Millisecond))
+
+	if !endpoint.Authentication.Enabled() {
+		return checks
+	}
+
+	auth := doctorCheck{Check: doctorCheckAuth, Subject: subject, Status: doctorStatusFail}
+	key, err := secrets.APIKey(endpoint.Authentication)
+	switch {
+	case err != nil:
+		auth.Detail = fmt.Sprintf("failed to read api key: %s", err)
+	case key.Value() == "":
+		auth.Detail = "api key is empty"
+	default:
+		auth.Status, auth.Detail = probeAPIKey(ctx, endpoint.URL, endpoint.Authentication.APIKeyHeader, key.Value())
+	}
+
+	return append(checks, auth)
+}
+
+// dialEndpoint opens a connection to the host of the given endpoint URL, with a TLS handshake for
+// https and wss URLs. Endpoints without a scheme, e.g. gRPC endpoints, are dialed as host:port.
+func dialEndpoint(ctx context.Context, rawURL string) error {
+	address, useTLS := rawURL, false
+	if strings.Contains(rawURL, "://") {
+		// endpoint URLs may be templates, e.g. with a %s for the ticker, which is not a valid escape.
+		u, err := url.Parse(strings.ReplaceAll(rawURL, "%", ""))
+		if err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+
+		port := u.Port()
+		switch u.Scheme {
+		case "https", "wss":
+			useTLS = true
+			if port == "" {
+				port = "443"
+			}
+		case "http", "ws":
+			if port == "" {
+				port = "80"
+			}
+		default:
+			return fmt.Errorf("unsupported scheme %s", u.Scheme)
+		}
+		address = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, doctorDialTimeout)
+	defer cancel()
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if useTLS {
+		conn, err = (&tls.Dialer{}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// probeAPIKey sends a request with the given API key to the endpoint, and returns whether the key
+// was rejected. Only the HTTP status is inspected, as the endpoint may expect parameters that are
+// not known here, so the key is only reported as loaded for endpoints that cannot be requested
+// as is.
+func probeAPIKey(ctx context.Context, rawURL, header, key string) (string, string) {
+	if !strings.HasPrefix(rawURL, "http") || strings.Contains(rawURL, "%") {
+		return doctorStatusPass, "api key loaded"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return doctorStatusFail, err.Error()
+	}
+	req.Header.Set(header, key)
+
+	client := &http.Client{
+		Transport: connecthttp.NewRoundTripperWithHeaders(
+			http.DefaultTransport,
+			connecthttp.WithConnectVersionUserAgent(),
+		),
+		Timeout: doctorDialTimeout,
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return doctorStatusFail, err.Error()
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return doctorStatusFail, fmt.Sprintf("api key rejected with status %s", resp.Status)
+	}
+
+	return doctorStatusPass, fmt.Sprintf("api key loaded, endpoint responded with status %s", resp.Status)
+}
+
+// getProviderResults returns the prices and errors reported by each provider of the oracle.
+func getProviderResults(orc oracle.Oracle) map[string]providerResults {
+	results := make(map[string]providerResults)
+	for name, state := range orc.GetProviderState() {
+		if state.Provider == nil {
+			continue
+		}
+
+		result := providerResults{
+			prices: make(map[string]*big.Float),
+			errors: make(map[string]string),
+		}
+		for ticker, data := range state.Provider.GetData() {
+			result.prices[ticker.GetOffChainTicker()] = data.Value
+		}
+		for ticker, unresolved := range state.Provider.GetErrors() {
+			result.errors[ticker.GetOffChainTicker()] = unresolved.Error()
+		}
+		results[name] = result
+	}
+
+	return results
+}
+
+// hasAllProviderPrices returns true if every provider of the oracle reported a price of each
+// enabled market it provides.
+func hasAllProviderPrices(orc oracle.Oracle) bool {
+	marketMap := orc.GetMarketMap()
+	if len(marketMap.Markets) == 0 {
+		return false
+	}
+
+	results := getProviderResults(orc)
+	for _, market := range marketMap.Markets {
+		if !market.Ticker.Enabled {
+			continue
+		}
+
+		for _, providerCfg := range market.ProviderConfigs {
+			if _, ok := results[providerCfg.Name].prices[providerCfg.OffChainTicker]; !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// checkPrices checks that each provider reported a price of every enabled market it provides, and
+// that each market has an aggregated price at its decimals that the prices of its providers agree
+// with. A provider price that deviates from the aggregated price by more than maxDeviation usually
+// means the decimals, invert or normalize by pair of the market are misconfigured. The checks are
+// returned sorted by market.
+func checkPrices(
+	marketMap mmtypes.MarketMap,
+	prices oracletypes.Prices,
+	results map[string]providerResults,
+	maxDeviation float64,
+) []doctorCheck {
+	tickers := make([]string, 0, len(marketMap.Markets))
+	for ticker, market := range marketMap.Markets {
+		if market.Ticker.Enabled {
+			tickers = append(tickers, ticker)
+		}
+	}
+	sort.Strings(tickers)
+
+	var checks []doctorCheck
+	for _, ticker := range tickers {
+		market := marketMap.Markets[ticker]
+
+		providerPrices := make(map[string]*big.Float)
+		for _, providerCfg := range market.ProviderConfigs {
+			check := doctorCheck{Check: doctorCheckPrice, Subject: ticker + " " + providerCfg.Name}
+
+			price, ok := results[providerCfg.Name].prices[providerCfg.OffChainTicker]
+			switch {
+			case ok && price != nil:
+				check.Status = doctorStatusPass
+				check.Detail = fmt.Sprintf("%s reported %s", providerCfg.OffChainTicker, price.Text('g', 10))
+				if providerCfg.NormalizeByPair == nil {
+					if providerCfg.Invert && price.Sign() != 0 {
+						price = new(big.Float).Quo(big.NewFloat(1), price)
+					}
+					providerPrices[providerCfg.Name] = price
+				}
+			case results[providerCfg.Name].errors[providerCfg.OffChainTicker] != "":
+				check.Status = doctorStatusFail
+				check.Detail = fmt.Sprintf("%s: %s", providerCfg.OffChainTicker, results[providerCfg.Name].errors[providerCfg.OffChainTicker])
+			default:
+				check.Status = doctorStatusFail
+				check.Detail = fmt.Sprintf("no price reported for %s", providerCfg.OffChainTicker)
+			}
+
+			checks = append(checks, check)
+		}
+
+		checks = append(checks, checkScale(ticker, market.Ticker.Decimals, prices[ticker], providerPrices, maxDeviation))
+	}
+
+	return checks
+}
+
+// checkScale checks that the aggregated price of a market is non-zero at the market's decimals,
+// and that the given provider prices deviate from it by at most maxDeviation.
+func checkScale(
+	ticker string,
+	decimals uint64,
+	price *big.Float,
+	providerPrices map[string]*big.Float,
+	maxDeviation float64,
+) doctorCheck {
+	check := doctorCheck{Check: doctorCheckScale, Subject: ticker, Status: doctorStatusFail}
+
+	if price == nil {
+		check.Detail = "no aggregated price"
+		return check
+	}
+
+	if raw, _ := price.Int(nil); raw.Sign() <= 0 {
+		check.Detail = fmt.Sprintf("price rounds to %s with %d decimals", raw, decimals)
+		return check
+	}
+
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(decimals), nil))
+	aggregated := new(big.Float).Quo(price, scale)
+
+	names := make([]string, 0, len(providerPrices))
+	for name := range providerPrices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var deviating []string
+	for _, name := range names {
+		diff := new(big.Float).Sub(providerPrices[name], aggregated)
+		deviation, _ := diff.Quo(diff.Abs(diff), aggregated).Float64()
+		if deviation > maxDeviation {
+			deviating = append(deviating, fmt.Sprintf(
+				"%s reported %s (%.2f%% off)",
+				name, providerPrices[name].Text('g', 10), deviation*100,
+			))
+		}
+	}
+
+	if len(deviating) > 0 {
+		check.Detail = fmt.Sprintf(
+			"aggregated price %s deviates from %s; check the decimals, invert and normalize by pair of the market",
+			aggregated.Text('g', 10), strings.Join(deviating, ", "),
+		)
+		return check
+	}
+
+	check.Status = doctorStatusPass
+	check.Detail = fmt.Sprintf("price %s with %d decimals", aggregated.Text('f', int(decimals)), decimals)
+	return check
+}
+
+// writeDoctorReport writes the checks to w, and returns an error if any check failed.
+func writeDoctorReport(w io.Writer, checks []doctorCheck) error {
+	failed := 0
+	for _, check := range checks {
+		if check.Status == doctorStatusFail {
+			failed++
+		}
+	}
+
+	if cliOutput == outputJSON {
+		if err := json.NewEncoder(w).Encode(checks); err != nil {
+			return err
+		}
+	} else {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CHECK\tSUBJECT\tSTATUS\tDETAIL")
+		for _, check := range checks {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", check.Check, check.Subject, check.Status, check.Detail)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "\n%d of %d checks passed\n", len(checks)-failed, len(checks))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	oracletypes "github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/websockets/coinbase"
+	"github.com/skip-mev/connect/v2/providers/websockets/okx"
+)
+
+func TestCheckEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	auth := config.Authentication{APIKey: "good", APIKeyHeader: "X-Api-Key"}
+	checks := checkEndpoint(context.Background(), "provider", config.Endpoint{URL: srv.URL, Authentication: auth})
+	require.Len(t, checks, 2)
+	require.Equal(t, doctorCheckEndpoint, checks[0].Check)
+	require.Equal(t, doctorStatusPass, checks[0].Status)
+	require.Equal(t, doctorCheckAuth, checks[1].Check)
+	require.Equal(t, doctorStatusPass, checks[1].Status)
+	require.Contains(t, checks[1].Detail, "200 OK")
+
+	// the key is rejected by the endpoint.
+	auth.APIKey = "bad"
+	checks = checkEndpoint(context.Background(), "provider", config.Endpoint{URL: srv.URL, Authentication: auth})
+	require.Equal(t, doctorStatusFail, checks[1].Status)
+	require.Contains(t, checks[1].Detail, "401 Unauthorized")
+
+	// the key cannot be read.
+	auth = config.Authentication{APIKeySource: "env:CONNECT_TEST_UNSET_API_KEY", APIKeyHeader: "X-Api-Key"}
+	checks = checkEndpoint(context.Background(), "provider", config.Endpoint{URL: srv.URL, Authentication: auth})
+	require.Equal(t, doctorStatusFail, checks[1].Status)
+	require.Contains(t, checks[1].Detail, "failed to read api key")
+
+	// templated urls are only dialed.
+	checks = checkEndpoint(context.Background(), "provider", config.Endpoint{
+		URL:            srv.URL + "/tickers/%s",
+		Authentication: config.Authentication{APIKey: "bad", APIKeyHeader: "X-Api-Key"},
+	})
+	require.Equal(t, doctorStatusPass, checks[0].Status)
+	require.Equal(t, doctorStatusPass, checks[1].Status)
+	require.Equal(t, "api key loaded", checks[1].Detail)
+
+	// endpoints without a scheme, e.g. gRPC endpoints, are dialed as host:port.
+	checks = checkEndpoint(context.Background(), "provider", config.Endpoint{URL: srv.Listener.Addr().String()})
+	require.Len(t, checks, 1)
+	require.Equal(t, doctorStatusPass, checks[0].Status)
+
+	// nothing is listening on a closed port.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	checks = checkEndpoint(context.Background(), "provider", config.Endpoint{URL: "http://" + closed})
+	require.Len(t, checks, 1)
+	require.Equal(t, doctorStatusFail, checks[0].Status)
+}
+
+func TestCheckPrices(t *testing.T) {
+	marketMap := testMarketMap(coinbase.Name, okx.Name)
+	ticker := "BTC/USD"
+
+	tests := []struct {
+		name     string
+		prices   oracletypes.Prices
+		results  map[string]providerResults
+		statuses []string
+		detail   string
+	}{
+		{
+			name:   "every provider reports a consistent price",
+			prices: oracletypes.Prices{ticker: big.NewFloat(6000000000000)},
+			results: map[string]providerResults{
+				coinbase.Name: {prices: map[string]*big.Float{"BTC-USD": big.NewFloat(60000)}},
+				okx.Name:      {prices: map[string]*big.Float{"BTC-USD": big.NewFloat(60100)}},
+			},
+			statuses: []string{doctorStatusPass, doctorStatusPass, doctorStatusPass},
+			detail:   "price 60000.00000000 with 8 decimals",
+		},
+		{
+			name:   "a provider reports an error",
+			prices: oracletypes.Prices{ticker: big.NewFloat(6000000000000)},
+			results: map[string]providerResults{
+				coinbase.Name: {prices: map[string]*big.Float{"BTC-USD": big.NewFloat(60000)}},
+				okx.Name:      {errors: map[string]string{"BTC-USD": "unknown ticker"}},
+			},
+			statuses: []string{doctorStatusPass, doctorStatusFail, doctorStatusPass},
+		},
+		{
+			name:   "a provider reports a price at the wrong scale",
+			prices: oracletypes.Prices{ticker: big.NewFloat(6000000000000)},
+			results: map[string]providerResults{
+				coinbase.Name: {prices: map[string]*big.Float{"BTC-USD": big.NewFloat(60000)}},
+				okx.Name:      {prices: map[string]*big.Float{"BTC-USD": big.NewFloat(0.06)}},
+			},
+			statuses: []string{doctorStatusPass, doctorStatusPass, doctorStatusFail},
+			detail:   "aggregated price 60000 deviates from okx_ws reported 0.06 (100.00% off); check the decimals, invert and normalize by pair of the market",
+		},
+		{
+			name:   "the price rounds to zero",
+			prices: oracletypes.Prices{ticker: big.NewFloat(0.5)},
+			results: map[string]providerResults{
+				coinbase.Name: {prices: map[string]*big.Float{"BTC-USD": big.NewFloat(0.000000005)}},
+				okx.Name:      {prices: map[string]*big.Float{"BTC-USD": big.NewFloat(0.000000005)}},
+			},
+			statuses: []string{doctorStatusPass, doctorStatusPass, doctorStatusFail},
+			detail:   "price rounds to 0 with 8 decimals",
+		},
+		{
+			name:     "no provider reports a price",
+			results:  map[string]providerResults{},
+			statuses: []string{doctorStatusFail, doctorStatusFail, doctorStatusFail},
+			detail:   "no aggregated price",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			checks := checkPrices(marketMap, tc.prices, tc.results, 0.1)
+			require.Len(t, checks, len(tc.statuses))
+
+			for i, check := range checks {
+				require.Equal(t, tc.statuses[i], check.Status, check.Detail)
+			}
+
+			scale := checks[len(checks)-1]
+			require.Equal(t, doctorCheckScale, scale.Check)
+			require.Equal(t, ticker, scale.Subject)
+			if tc.detail != "" {
+				require.Equal(t, tc.detail, scale.Detail)
+			}
+		})
+	}
+}
+
+func TestWriteDoctorReport(t *testing.T) {
+	defer func(output string) { cliOutput = output }(cliOutput)
+	cliOutput = outputJSON
+
+	checks := []doctorCheck{
+		{Check: doctorCheckConfig, Subject: "oracle.json", Status: doctorStatusPass, Detail: "config is valid"},
+		{Check: doctorCheckEndpoint, Subject: "okx_ws wss://ws.okx.com:8443/ws/v5/public", Status: doctorStatusFail, Detail: "i/o timeout"},
+	}
+
+	var buf bytes.Buffer
+	require.EqualError(t, writeDoctorReport(&buf, checks), "1 of 2 checks failed")
+
+	var decoded []doctorCheck
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, checks, decoded)
+
+	// the text output has a header, a row per check and a summary.
+	cliOutput = outputText
+	buf.Reset()
+	require.NoError(t, writeDoctorReport(&buf, checks[:1]))
+	require.Contains(t, buf.String(), "1 of 1 checks passed")
+	require.Len(t, bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")), 4)
+}
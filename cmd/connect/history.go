@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cmdconfig "github.com/skip-mev/connect/v2/cmd/connect/config"
+	"github.com/skip-mev/connect/v2/oracle/history"
+)
+
+var (
+	historyCmd = &cobra.Command{
+		Use:   "history",
+		Short: "Query the persisted price history of the oracle.",
+		Long: "Query the price history persisted by an oracle with history enabled. The records of the currency pair " +
+			"are written to stdout as JSON lines, or the currency pairs with a history if no pair is given. The " +
+			"database can only be read while the oracle is stopped; query the oracle's /history endpoint instead " +
+			"while it is running.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return queryHistory(cmd.OutOrStdout())
+		},
+	}
+
+	// history flag-bound values.
+	historyPath  string
+	historyPair  string
+	historyFrom  string
+	historyTo    string
+	historyLimit int
+)
+
+func init() {
+	historyCmd.Flags().StringVar(
+		&historyPath,
+		"path",
+		cmdconfig.DefaultHistoryPath,
+		"Path of the price history database.",
+	)
+	historyCmd.Flags().StringVar(
+		&historyPair,
+		"pair",
+		"",
+		"Currency pair to query the history of, e.g. BTC/USD. If empty, the currency pairs with a history are listed.",
+	)
+	historyCmd.Flags().StringVar(
+		&historyFrom,
+		"from",
+		"",
+		"RFC 3339 timestamp of the earliest record to query (inclusive).",
+	)
+	historyCmd.Flags().StringVar(
+		&historyTo,
+		"to",
+		"",
+		"RFC 3339 timestamp of the latest record to query (inclusive).",
+	)
+	historyCmd.Flags().IntVar(
+		&historyLimit,
+		"limit",
+		0,
+		"Maximum number of records to query. If zero, all records in the range are queried.",
+	)
+}
+
+// queryHistory writes the history of the currency pair set by the pair flag, in the range set by the
+// from and to flags, to w. If no pair is set, the currency pairs with a history are written.
+func queryHistory(w io.Writer) error {
+	var (
+		from, to time.Time
+		err      error
+	)
+	if historyFrom != "" {
+		if from, err = time.Parse(time.RFC3339, historyFrom); err != nil {
+			return fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if historyTo != "" {
+		if to, err = time.Parse(time.RFC3339, historyTo); err != nil {
+			return fmt.Errorf("invalid to: %w", err)
+		}
+	}
+
+	store, err := history.OpenReadOnly(historyPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	encoder := json.NewEncoder(w)
+	if historyPair == "" {
+		pairs, err := store.CurrencyPairs()
+		if err != nil {
+			return err
+		}
+
+		for _, pair := range pairs {
+			fmt.Fprintln(w, pair)
+		}
+		return nil
+	}
+
+	records, err := store.Query(strings.ToUpper(historyPair), from, to, historyLimit)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
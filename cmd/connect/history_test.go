@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/history"
+)
+
+func TestQueryHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	store, err := history.Open(path)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Write(map[string]history.Record{
+			"BTC/USD": {Timestamp: now.Add(time.Duration(i) * time.Second), Price: big.NewFloat(float64(60000 + i))},
+			"ETH/USD": {Timestamp: now.Add(time.Duration(i) * time.Second), Price: big.NewFloat(float64(3000 + i))},
+		}))
+	}
+	require.NoError(t, store.Close())
+
+	defer func(path, pair string, limit int) {
+		historyPath, historyPair, historyLimit = path, pair, limit
+	}(historyPath, historyPair, historyLimit)
+	historyPath = path
+
+	t.Run("writes the currency pairs without a pair", func(t *testing.T) {
+		historyPair = ""
+
+		var buf bytes.Buffer
+		require.NoError(t, queryHistory(&buf))
+		require.Equal(t, []string{"BTC/USD", "ETH/USD"}, strings.Fields(buf.String()))
+	})
+
+	t.Run("writes the records of the pair as json lines", func(t *testing.T) {
+		historyPair, historyLimit = "btc/usd", 2
+
+		var buf bytes.Buffer
+		require.NoError(t, queryHistory(&buf))
+
+		lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+		require.Len(t, lines, 2)
+
+		var record history.Record
+		require.NoError(t, json.Unmarshal(lines[0], &record))
+		require.Equal(t, now, record.Timestamp.UTC())
+	})
+}
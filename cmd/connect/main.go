@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 
 	//nolint: gosec
@@ -18,21 +21,38 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/skip-mev/connect/v2/cmd/build"
 	cmdconfig "github.com/skip-mev/connect/v2/cmd/connect/config"
 	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/alert"
+	"github.com/skip-mev/connect/v2/oracle/breaker"
 	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/dryrun"
+	"github.com/skip-mev/connect/v2/oracle/export"
+	"github.com/skip-mev/connect/v2/oracle/history"
 	oraclemetrics "github.com/skip-mev/connect/v2/oracle/metrics"
+	"github.com/skip-mev/connect/v2/oracle/payload"
+	"github.com/skip-mev/connect/v2/oracle/replay"
+	"github.com/skip-mev/connect/v2/oracle/submit"
+	oracletypes "github.com/skip-mev/connect/v2/oracle/types"
+	connectgrpc "github.com/skip-mev/connect/v2/pkg/grpc"
 	"github.com/skip-mev/connect/v2/pkg/log"
 	oraclemath "github.com/skip-mev/connect/v2/pkg/math/oracle"
+	"github.com/skip-mev/connect/v2/pkg/signing"
+	"github.com/skip-mev/connect/v2/pkg/tracing"
 	"github.com/skip-mev/connect/v2/providers/apis/marketmap"
+	"github.com/skip-mev/connect/v2/providers/base/debug"
 	oraclefactory "github.com/skip-mev/connect/v2/providers/factories/oracle"
 	mmservicetypes "github.com/skip-mev/connect/v2/service/clients/marketmap/types"
 	oracleserver "github.com/skip-mev/connect/v2/service/servers/oracle"
+	oracleservicetypes "github.com/skip-mev/connect/v2/service/servers/oracle/types"
 	promserver "github.com/skip-mev/connect/v2/service/servers/prometheus"
 	"github.com/skip-mev/connect/v2/service/validation"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	xoracletypes "github.com/skip-mev/connect/v2/x/oracle/types"
 )
 
 var (
@@ -64,6 +84,17 @@ var (
 	flagMaxPriceAge              = "max-price-age"
 	flagMode                     = "mode"
 	flagValidationPeriod         = "validation-period"
+	flagRecordTo                 = "record-to"
+	flagReplayFrom               = "replay-from"
+	flagDryRun                   = "dry-run"
+	flagShadow                   = "shadow"
+	flagDebugProviders           = "debug-provider-responses"
+	flagRemoteOracleConfig       = "remote-oracle-config"
+	flagRemoteMarketConfig       = "remote-market-config"
+	flagRemoteConfigPublicKey    = "remote-config-public-key"
+	flagRemoteConfigAlgorithm    = "remote-config-algorithm"
+	flagRemoteConfigRefresh      = "remote-config-refresh-interval"
+	flagRemoteConfigCacheDir     = "remote-config-cache-dir"
 
 	// flag-bound values.
 	oracleCfgPath       string
@@ -75,6 +106,7 @@ var (
 	logLevel            string
 	fileLogLevel        string
 	writeLogsTo         string
+	logBackend          string
 	marketMapEndPoint   string
 	maxLogSize          int
 	maxBackups          int
@@ -83,6 +115,17 @@ var (
 	disableRotatingLogs bool
 	mode                string
 	validationPeriod    time.Duration
+	recordTo            string
+	replayFrom          string
+	dryRun              bool
+	shadowOf            string
+	debugProviders      bool
+	remoteOracleCfgURL  string
+	remoteMarketCfgURL  string
+	remoteCfgPublicKey  string
+	remoteCfgAlgorithm  string
+	remoteCfgRefresh    time.Duration
+	remoteCfgCacheDir   string
 )
 
 const (
@@ -94,6 +137,7 @@ type runMode string
 const (
 	modeExec     runMode = "exec"
 	modeValidate runMode = "validate"
+	modeReplay   runMode = "replay"
 )
 
 func init() {
@@ -109,7 +153,7 @@ func init() {
 		"oracle-config",
 		"",
 		"",
-		"Path to the oracle config file.",
+		"Path to the oracle config file (json, toml or yaml).",
 	)
 	rootCmd.Flags().StringVarP(
 		&marketCfgPath,
@@ -153,6 +197,13 @@ func init() {
 		"info",
 		"Log level for the file logger (debug, info, warn, error, dpanic, panic, fatal).",
 	)
+	rootCmd.Flags().StringVarP(
+		&logBackend,
+		"log-backend",
+		"",
+		log.BackendZap,
+		"Logging backend (zap, zerolog).",
+	)
 	rootCmd.Flags().StringVarP(
 		&writeLogsTo,
 		"log-file",
@@ -207,7 +258,7 @@ func init() {
 		flagMode,
 		"m",
 		string(modeExec),
-		"Select the mode to run the oracle in.  Default is \"exec\" which will fetch prices as configured.  \"validate\" mode will run the oracle for a set period of time to validate the configuration.",
+		"Select the mode to run the oracle in.  Default is \"exec\" which will fetch prices as configured.  \"validate\" mode will run the oracle for a set period of time to validate the configuration.  \"replay\" mode will feed a recording through the configured aggregation and write the aggregated prices to stdout.",
 	)
 	rootCmd.Flags().DurationVar(
 		&validationPeriod,
@@ -215,6 +266,75 @@ func init() {
 		validation.DefaultValidationPeriod,
 		"Duration to run in validation mode.  Note: this flag is only used if mode == \"validate\"",
 	)
+	rootCmd.Flags().StringVarP(
+		&recordTo,
+		flagRecordTo,
+		"",
+		"",
+		"Path of the file to which all provider prices reported to the aggregator will be recorded. Overwrites any pre-existing file.",
+	)
+	rootCmd.Flags().StringVarP(
+		&replayFrom,
+		flagReplayFrom,
+		"",
+		"",
+		"Path of the recording to replay.  Note: this flag is only used if mode == \"replay\"",
+	)
+	rootCmd.Flags().BoolVar(
+		&dryRun,
+		flagDryRun,
+		false,
+		"Run the oracle without serving, submitting or exporting its prices, and write the difference of each tick's prices from the chain's current on-chain prices to stdout.",
+	)
+	rootCmd.Flags().StringVar(
+		&shadowOf,
+		flagShadow,
+		"",
+		"gRPC address of another oracle to run in shadow of. The oracle runs as with --dry-run, but its prices are compared to the prices published by the other oracle rather than the chain's.",
+	)
+	rootCmd.Flags().BoolVar(
+		&debugProviders,
+		flagDebugProviders,
+		false,
+		"Record the most recent raw responses of each provider, and serve them with the decoded values at /debug/providers/<provider>.",
+	)
+
+	rootCmd.Flags().StringVar(
+		&remoteOracleCfgURL,
+		flagRemoteOracleConfig,
+		"",
+		"URL of an oracle config file to fetch on startup and on each refresh (http(s)://, s3://<bucket>/<key> or git+<transport>://<repository>//<path>). The file is only applied if its detached signature, fetched from <url>.sig, is valid.",
+	)
+	rootCmd.Flags().StringVar(
+		&remoteMarketCfgURL,
+		flagRemoteMarketConfig,
+		"",
+		"URL of a market config file to fetch on startup and on each refresh. See --remote-oracle-config for the supported URLs.",
+	)
+	rootCmd.Flags().StringVar(
+		&remoteCfgPublicKey,
+		flagRemoteConfigPublicKey,
+		"",
+		"Hex-encoded public key that remote config files must be signed by.",
+	)
+	rootCmd.Flags().StringVar(
+		&remoteCfgAlgorithm,
+		flagRemoteConfigAlgorithm,
+		config.SigningAlgorithmEd25519,
+		"Signature algorithm of the remote config public key (ed25519 or secp256k1).",
+	)
+	rootCmd.Flags().DurationVar(
+		&remoteCfgRefresh,
+		flagRemoteConfigRefresh,
+		0,
+		"Interval at which remote config files are re-fetched and applied if they changed. If zero, they are only fetched on startup.",
+	)
+	rootCmd.Flags().StringVar(
+		&remoteCfgCacheDir,
+		flagRemoteConfigCacheDir,
+		"connect-remote-config",
+		"Directory in which the last verified copy of each remote config file is cached, and read from if the remote source is unavailable on startup.",
+	)
 
 	// these flags are connected to the OracleConfig.
 	rootCmd.Flags().Bool(
@@ -267,8 +387,17 @@ func init() {
 
 	rootCmd.MarkFlagsMutuallyExclusive("update-market-config-path", "market-config-path")
 	rootCmd.MarkFlagsMutuallyExclusive("market-map-endpoint", "market-config-path")
+	rootCmd.MarkFlagsMutuallyExclusive(flagRecordTo, flagReplayFrom)
+	rootCmd.MarkFlagsMutuallyExclusive(flagRemoteOracleConfig, "oracle-config")
+	rootCmd.MarkFlagsMutuallyExclusive(flagRemoteMarketConfig, "market-config-path")
+	rootCmd.MarkFlagsMutuallyExclusive(flagRemoteMarketConfig, "market-map-endpoint")
 
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(providersCmd)
+	rootCmd.AddCommand(pricesCmd)
+	rootCmd.AddCommand(doctorCmd)
 }
 
 // start the oracle-grpc server + oracle process, cancel on interrupt or terminate.
@@ -289,6 +418,7 @@ func runOracle() error {
 
 	// Set up logging.
 	logCfg := log.NewDefaultConfig()
+	logCfg.Backend = logBackend
 	logCfg.StdOutLogLevel = logLevel
 	logCfg.FileOutLogLevel = fileLogLevel
 	logCfg.DisableRotating = disableRotatingLogs
@@ -299,12 +429,16 @@ func runOracle() error {
 	logCfg.Compress = !disableCompressLogs
 
 	// Build logger.
-	logger := log.NewLogger(logCfg)
+	logger, levels := log.NewLoggerWithLevels(logCfg)
 	defer logger.Sync()
 
-	var cfg config.OracleConfig
-	var err error
+	// fetch and verify the remote configs, if any, which are then read from their cached copies.
+	remoteSources, err := loadRemoteConfigs(ctx, logger)
+	if err != nil {
+		return err
+	}
 
+	var cfg config.OracleConfig
 	cfg, err = cmdconfig.ReadOracleConfigWithOverrides(oracleCfgPath, marketMapProvider)
 	if err != nil {
 		return fmt.Errorf("failed to get oracle config: %w", err)
@@ -328,7 +462,7 @@ func runOracle() error {
 
 	var marketCfg mmtypes.MarketMap
 	if marketCfgPath != "" {
-		marketCfg, err = mmtypes.ReadMarketMapFromFile(marketCfgPath)
+		marketCfg, err = cmdconfig.ReadMarketConfigFromFile(marketCfgPath)
 		if err != nil {
 			return fmt.Errorf("failed to read market config file: %w", err)
 		}
@@ -348,18 +482,144 @@ func runOracle() error {
 	}
 
 	isValidateMode := runMode(mode) == modeValidate
+	if runMode(mode) == modeReplay {
+		return replayRecording(logger, cfg, marketCfg)
+	}
+
+	// nothing is submitted, exported, served or alerted on in a dry run, or in shadow of another
+	// oracle.
+	if shadowOf != "" {
+		dryRun = true
+	}
+	if dryRun {
+		cfg.Submit.Enabled = false
+		cfg.Alert.Enabled = false
+		cfg.Export = config.ExportConfig{}
+		cfg.Metrics.Enabled = false
+		cfg.Admin.Enabled = false
+	}
 
 	metrics := oraclemetrics.NewMetricsFromConfig(cfg.Metrics, nodeClient)
 
-	aggregator, err := oraclemath.NewIndexPriceAggregator(
+	// export traces of the price pipeline if configured.
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to shutdown tracing", zap.Error(err))
+		}
+	}()
+
+	// sign the price reports served by the oracle if configured.
+	var serverOpts []oracleserver.ServerOption
+	if cfg.Signing.Enabled {
+		signer, err := signing.NewSignerFromConfig(cfg.Signing)
+		if err != nil {
+			return fmt.Errorf("failed to create price report signer: %w", err)
+		}
+
+		logger.Info(
+			"signing price reports",
+			zap.String("algorithm", signer.Algorithm()),
+			zap.String("public_key", hex.EncodeToString(signer.PublicKey())),
+		)
+		serverOpts = append(serverOpts, oracleserver.WithSigner(signer))
+	}
+
+	// serve the websocket push API if configured.
+	if cfg.Push.Enabled {
+		logger.Info("serving websocket push api", zap.Float64("threshold", cfg.Push.Threshold))
+		serverOpts = append(serverOpts, oracleserver.WithPush(cfg.Push))
+	}
+
+	// record the raw responses of the providers and serve them for debugging if enabled.
+	if debugProviders {
+		logger.Info("serving raw provider responses", zap.String("path", oracleserver.DebugProvidersPath+"<provider>"))
+		serverOpts = append(serverOpts, oracleserver.WithProviderDebug(debug.Enable(debug.DefaultMaxBodySize)))
+	}
+
+	// serve the payloads of the configured target chains.
+	if len(cfg.Payload.Targets) > 0 {
+		builders, err := payload.NewBuildersFromConfig(cfg.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to create payload builders: %w", err)
+		}
+
+		for target, builder := range builders {
+			logger.Info("serving payload", zap.String("target", target), zap.String("encoder", builder.Encoder()))
+		}
+		serverOpts = append(serverOpts, oracleserver.WithPayloads(builders))
+	}
+
+	var aggregator oracle.PriceAggregator
+	aggregator, err = oraclemath.NewIndexPriceAggregator(
 		logger,
 		marketCfg,
 		metrics,
+		oraclemath.WithAggregationConfig(cfg.Aggregation),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create data aggregator: %w", err)
 	}
 
+	// halt the publication of markets whose price moves too far too quickly if configured.
+	if cfg.CircuitBreaker.Enabled {
+		breakerAggregator, err := breaker.NewAggregator(logger, aggregator, cfg.CircuitBreaker)
+		if err != nil {
+			return fmt.Errorf("failed to create circuit breaker: %w", err)
+		}
+
+		logger.Info("halting markets on extreme moves")
+		aggregator = breakerAggregator
+		serverOpts = append(serverOpts, oracleserver.WithCircuitBreaker(breakerAggregator))
+	}
+
+	// record the provider prices reported to the aggregator if configured.
+	if recordTo != "" {
+		f, err := os.Create(recordTo)
+		if err != nil {
+			return fmt.Errorf("failed to create recording %s: %w", recordTo, err)
+		}
+		defer f.Close()
+
+		logger.Info("recording provider prices", zap.String("path", recordTo))
+		aggregator = replay.NewRecorder(logger, aggregator, marketCfg, f)
+	}
+
+	// persist the history of the aggregated prices and serve it if configured.
+	if cfg.History.Enabled {
+		store, err := history.Open(cfg.History.Path)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		logger.Info(
+			"persisting price history",
+			zap.String("path", cfg.History.Path),
+			zap.Duration("retention", cfg.History.Retention),
+		)
+		aggregator = history.NewAggregator(logger, aggregator, marketCfg, store, cfg.History.Retention)
+		serverOpts = append(serverOpts, oracleserver.WithHistory(store))
+	}
+
+	// ship the aggregated prices to external time-series databases if configured.
+	exporters, err := export.NewExportersFromConfig(logger, cfg.Export)
+	if err != nil {
+		return fmt.Errorf("failed to create price exporters: %w", err)
+	}
+	if len(exporters) > 0 {
+		for _, exporter := range exporters {
+			logger.Info("exporting prices", zap.String("exporter", exporter.Name()))
+		}
+
+		exportAggregator := export.NewAggregator(logger, aggregator, exporters...)
+		go exportAggregator.Run(ctx)
+		aggregator = exportAggregator
+	}
+
 	// Define the oracle options. These determine how the oracle is created & executed.
 	oracleOpts := []oracle.Option{
 		oracle.WithLogger(logger),
@@ -373,6 +633,23 @@ func runOracle() error {
 		oracleOpts = append(oracleOpts, oracle.WithWriteTo(updateMarketCfgPath))
 	}
 
+	// restrict the markets to the currency pairs tracked by the chain if configured.
+	if cfg.CurrencyPairs.Enabled {
+		endpoint, err := cmdconfig.GetNodeEndpointFromConfig(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to get node endpoint to query currency pairs from: %w", err)
+		}
+
+		client, conn, err := oracle.NewCurrencyPairClient(endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to create currency pair client: %w", err)
+		}
+		defer conn.Close()
+
+		logger.Info("restricting markets to the chain's currency pairs", zap.String("endpoint", endpoint.URL))
+		oracleOpts = append(oracleOpts, oracle.WithCurrencyPairClient(client))
+	}
+
 	// Create the oracle and start the oracle.
 	orc, err := oracle.New(
 		cfg,
@@ -389,7 +666,123 @@ func runOracle() error {
 	}()
 	defer orc.Stop()
 
-	srv := oracleserver.NewOracleServer(orc, logger)
+	// submit the oracle's prices to a chain updated by oracle transactions if configured.
+	if cfg.Submit.Enabled {
+		target, _ := cfg.Payload.Target(cfg.Submit.Target)
+		builder, err := payload.NewBuilder(target)
+		if err != nil {
+			return fmt.Errorf("failed to create payload builder for submitter: %w", err)
+		}
+
+		submitter, err := submit.NewSubmitterFromConfig(ctx, logger, cfg.Submit, orc, builder)
+		if err != nil {
+			return fmt.Errorf("failed to create submitter: %w", err)
+		}
+
+		logger.Info(
+			"submitting prices",
+			zap.String("contract", cfg.Submit.Contract),
+			zap.String("from", submitter.From().Hex()),
+		)
+		go submitter.Run(ctx)
+	}
+
+	// alert on anomalies of the oracle if configured.
+	if cfg.Alert.Enabled {
+		notifiers, err := alert.NewNotifiersFromConfig(cfg.Alert)
+		if err != nil {
+			return fmt.Errorf("failed to create alert notifiers: %w", err)
+		}
+
+		alerter, err := alert.NewAlerter(logger, cfg.Alert, orc, notifiers)
+		if err != nil {
+			return fmt.Errorf("failed to create alerter: %w", err)
+		}
+
+		logger.Info(
+			"alerting on anomalies",
+			zap.Duration("interval", cfg.Alert.Interval),
+			zap.Int("webhooks", len(notifiers)),
+		)
+		go alerter.Run(ctx)
+	}
+
+	// authorize the requests of the server by bearer token, and serve it over tls, if configured.
+	auth, err := oracleserver.NewAuthenticator(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create authenticator: %w", err)
+	}
+	serverOpts = append(serverOpts, oracleserver.WithAuth(auth))
+	if cfg.Server.Auth.Enabled {
+		logger.Info("requiring bearer tokens", zap.Int("tokens", len(cfg.Server.Auth.Tokens)))
+	}
+
+	tlsCfg, err := oracleserver.NewTLSConfig(cfg.Server.TLS)
+	if err != nil {
+		return fmt.Errorf("failed to create tls config: %w", err)
+	}
+	if tlsCfg != nil {
+		logger.Info("serving over tls", zap.Bool("client_certs", cfg.Server.TLS.ClientCAFile != ""))
+		serverOpts = append(serverOpts, oracleserver.WithTLS(tlsCfg))
+	}
+
+	// serve the admin API to control the oracle at runtime if configured.
+	if cfg.Admin.Enabled {
+		serverOpts = append(serverOpts, oracleserver.WithAdmin(orc.(*oracle.OracleImpl), &levels))
+
+		if cfg.Admin.Address != "" {
+			adminTLS, err := oracleserver.NewTLSConfig(cfg.Admin.TLS)
+			if err != nil {
+				return fmt.Errorf("failed to create admin tls config: %w", err)
+			}
+
+			adminLn, err := net.Listen("tcp", cfg.Admin.Address)
+			if err != nil {
+				return fmt.Errorf("failed to listen on admin address: %w", err)
+			}
+			serverOpts = append(serverOpts, oracleserver.WithAdminListener(adminLn, adminTLS))
+		}
+
+		logger.Info("serving admin api", zap.String("address", cfg.Admin.Address))
+	}
+
+	srv := oracleserver.NewOracleServer(orc, logger, serverOpts...)
+
+	// reload the provider configs and market config on hangup, and when a remote config changes.
+	reloads := make(chan os.Signal, 1)
+	signal.Notify(reloads, syscall.SIGHUP)
+	defer signal.Stop(reloads)
+
+	var refreshes <-chan time.Time
+	if len(remoteSources) > 0 && remoteCfgRefresh > 0 {
+		ticker := time.NewTicker(remoteCfgRefresh)
+		defer ticker.Stop()
+		refreshes = ticker.C
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-reloads:
+				logger.Info("received hangup signal; reloading configs")
+			case <-refreshes:
+				if !syncRemoteConfigs(ctx, logger, remoteSources) {
+					continue
+				}
+
+				logger.Info("remote configs changed; reloading configs")
+			}
+
+			if err := reloadOracle(ctx, orc.(*oracle.OracleImpl)); err != nil {
+				logger.Error("failed to reload configs", zap.Error(err))
+				continue
+			}
+
+			logger.Info("successfully reloaded configs")
+		}
+	}()
 
 	// cancel oracle on interrupt or terminate
 	go func() {
@@ -399,6 +792,12 @@ func runOracle() error {
 		cancel()
 	}()
 
+	// write the difference of the oracle's prices from the chain's prices rather than serving them
+	// in a dry run.
+	if dryRun {
+		return runDryRun(ctx, logger, cfg, orc)
+	}
+
 	// start prometheus metrics
 	if cfg.Metrics.Enabled {
 		logger.Info("starting prometheus metrics", zap.String("address", cfg.Metrics.PrometheusServerAddress))
@@ -456,6 +855,125 @@ func runOracle() error {
 	return nil
 }
 
+// replayRecording feeds the recording at the replay-from path through an aggregator configured
+// with the oracle config's aggregation, and writes the aggregated prices of each tick to stdout as
+// JSON lines. The market map is read from the recording, or from the market config if the
+// recording does not contain one.
+func replayRecording(logger *zap.Logger, cfg config.OracleConfig, marketCfg mmtypes.MarketMap) error {
+	if replayFrom == "" {
+		return fmt.Errorf("%s must be set in replay mode", flagReplayFrom)
+	}
+
+	f, err := os.Open(replayFrom)
+	if err != nil {
+		return fmt.Errorf("failed to open recording %s: %w", replayFrom, err)
+	}
+	defer f.Close()
+
+	aggregator, err := oraclemath.NewIndexPriceAggregator(
+		logger,
+		marketCfg,
+		oraclemetrics.NewNopMetrics(),
+		oraclemath.WithAggregationConfig(cfg.Aggregation),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create data aggregator: %w", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	err = replay.Replay(aggregator, f, func(tick replay.Tick, prices oracletypes.Prices) error {
+		return encoder.Encode(struct {
+			Timestamp time.Time          `json:"timestamp"`
+			Prices    oracletypes.Prices `json:"prices"`
+		}{
+			Timestamp: tick.Timestamp,
+			Prices:    prices,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replay recording %s: %w", replayFrom, err)
+	}
+
+	return nil
+}
+
+// runDryRun writes a report of the difference of the oracle's prices from a reference's prices to
+// stdout as a JSON line at each update interval, until the given context is cancelled. The
+// reference is the oracle at the shadow address if set, and the chain's current on-chain prices
+// otherwise. If the chain cannot be reached, the reports only contain the oracle's prices.
+func runDryRun(ctx context.Context, logger *zap.Logger, cfg config.OracleConfig, orc oracle.Oracle) error {
+	var reference dryrun.Reference
+	if shadowOf != "" {
+		conn, err := connectgrpc.NewClient(
+			shadowOf,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithNoProxy(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to oracle %s: %w", shadowOf, err)
+		}
+		defer conn.Close()
+
+		logger.Info("running oracle in shadow of another oracle", zap.String("address", shadowOf))
+		reference = dryrun.NewOracleReference(shadowOf, oracleservicetypes.NewOracleClient(conn))
+	} else {
+		endpoint, err := cmdconfig.GetNodeEndpointFromConfig(cfg)
+		if err == nil {
+			var (
+				client xoracletypes.QueryClient
+				conn   *grpc.ClientConn
+			)
+			client, conn, err = oracle.NewCurrencyPairClient(endpoint)
+			if err == nil {
+				defer conn.Close()
+				reference = dryrun.NewChainReference(client)
+			}
+		}
+		if err != nil {
+			logger.Warn("failed to connect to node; on-chain prices are not compared in dry run", zap.Error(err))
+		}
+	}
+
+	runner, err := dryrun.NewRunner(logger, orc, reference, os.Stdout, cfg.UpdateInterval)
+	if err != nil {
+		return fmt.Errorf("failed to create dry run: %w", err)
+	}
+
+	logger.Info("running oracle in dry run mode; prices are not served, submitted or exported")
+	return runner.Run(ctx)
+}
+
+// reloadOracle re-reads the oracle config and market config and applies them to the running
+// oracle. Only the price provider configs and the market map are reloaded.
+func reloadOracle(ctx context.Context, orc *oracle.OracleImpl) error {
+	cfg, err := cmdconfig.ReadOracleConfigWithOverrides(oracleCfgPath, marketMapProvider)
+	if err != nil {
+		return fmt.Errorf("failed to get oracle config: %w", err)
+	}
+
+	if marketMapEndPoint != "" {
+		cfg, err = overwriteMarketMapEndpoint(cfg, marketMapEndPoint)
+		if err != nil {
+			return fmt.Errorf("failed to overwrite market endpoint %s: %w", marketMapEndPoint, err)
+		}
+	}
+
+	if err := orc.UpdateProviderConfigs(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to update provider configs: %w", err)
+	}
+
+	if marketCfgPath == "" {
+		return nil
+	}
+
+	marketCfg, err := cmdconfig.ReadMarketConfigFromFile(marketCfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to read market config file: %w", err)
+	}
+
+	return orc.UpdateMarketMap(marketCfg)
+}
+
 func overwriteMarketMapEndpoint(cfg config.OracleConfig, overwrite string) (config.OracleConfig, error) {
 	for providerName, provider := range cfg.Providers {
 		if provider.Type == mmservicetypes.ConfigType {
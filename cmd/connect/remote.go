@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	cmdconfig "github.com/skip-mev/connect/v2/cmd/connect/config"
+	"github.com/skip-mev/connect/v2/pkg/remoteconfig"
+)
+
+// loadRemoteConfigs fetches and verifies the remote oracle config and market config, if any, and
+// points the oracle config path and market config path at their verified cached copies, so that
+// the configs are read (and reloaded) like local files.
+func loadRemoteConfigs(ctx context.Context, logger *zap.Logger) ([]*remoteconfig.Source, error) {
+	if remoteOracleCfgURL == "" && remoteMarketCfgURL == "" {
+		return nil, nil
+	}
+
+	if remoteCfgPublicKey == "" {
+		return nil, fmt.Errorf("remote configs require a public key to verify their signatures")
+	}
+
+	verifier, err := remoteconfig.NewVerifier(remoteCfgAlgorithm, remoteCfgPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote config public key: %w", err)
+	}
+
+	var sources []*remoteconfig.Source
+	if remoteOracleCfgURL != "" {
+		source, err := loadRemoteConfig(ctx, logger, remoteOracleCfgURL, verifier, cmdconfig.ValidateOracleConfigFile)
+		if err != nil {
+			return nil, err
+		}
+
+		oracleCfgPath = source.Path()
+		sources = append(sources, source)
+	}
+
+	if remoteMarketCfgURL != "" {
+		validate := func(path string, bz []byte) error {
+			_, err := cmdconfig.ParseMarketConfig(path, bz)
+			return err
+		}
+
+		source, err := loadRemoteConfig(ctx, logger, remoteMarketCfgURL, verifier, validate)
+		if err != nil {
+			return nil, err
+		}
+
+		marketCfgPath = source.Path()
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+func loadRemoteConfig(
+	ctx context.Context,
+	logger *zap.Logger,
+	rawURL string,
+	verifier *remoteconfig.Verifier,
+	validate remoteconfig.Validator,
+) (*remoteconfig.Source, error) {
+	source, err := remoteconfig.NewSource(logger, rawURL, remoteCfgCacheDir, verifier, validate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := source.Load(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load remote config: %w", err)
+	}
+
+	logger.Info(
+		"loaded remote config",
+		zap.String("url", source.URL()),
+		zap.String("path", source.Path()),
+	)
+
+	return source, nil
+}
+
+// syncRemoteConfigs syncs each remote config, and returns true if any of them changed. Configs
+// that fail to sync (e.g. because their signature is invalid) are logged and left unchanged.
+func syncRemoteConfigs(ctx context.Context, logger *zap.Logger, sources []*remoteconfig.Source) bool {
+	var changed bool
+	for _, source := range sources {
+		ok, err := source.Sync(ctx)
+		if err != nil {
+			logger.Error("failed to sync remote config", zap.String("url", source.URL()), zap.Error(err))
+			continue
+		}
+
+		if ok {
+			logger.Info("remote config changed", zap.String("url", source.URL()))
+			changed = true
+		}
+	}
+
+	return changed
+}
@@ -4,18 +4,41 @@ import (
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/constants"
 	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/band"
 	binanceapi "github.com/skip-mev/connect/v2/providers/apis/binance"
 	bitstampapi "github.com/skip-mev/connect/v2/providers/apis/bitstamp"
+	bybitapi "github.com/skip-mev/connect/v2/providers/apis/bybit"
 	coinbaseapi "github.com/skip-mev/connect/v2/providers/apis/coinbase"
 	"github.com/skip-mev/connect/v2/providers/apis/coingecko"
 	"github.com/skip-mev/connect/v2/providers/apis/coinmarketcap"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/api3"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/aptos"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/chainlink"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/cosmwasm"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/curve"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc4626"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/evmcall"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/exchangerate"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/lido"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/osmosis"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/raydium"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/rocketpool"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/solana"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/sui"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/tron"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/uniswapv3"
+	"github.com/skip-mev/connect/v2/providers/apis/dia"
 	"github.com/skip-mev/connect/v2/providers/apis/dydx"
+	"github.com/skip-mev/connect/v2/providers/apis/forex"
 	krakenapi "github.com/skip-mev/connect/v2/providers/apis/kraken"
 	"github.com/skip-mev/connect/v2/providers/apis/marketmap"
+	okxapi "github.com/skip-mev/connect/v2/providers/apis/okx"
+	"github.com/skip-mev/connect/v2/providers/apis/orderbook"
 	"github.com/skip-mev/connect/v2/providers/apis/polymarket"
+	"github.com/skip-mev/connect/v2/providers/apis/pyth"
+	"github.com/skip-mev/connect/v2/providers/apis/redstone"
+	"github.com/skip-mev/connect/v2/providers/static"
 	"github.com/skip-mev/connect/v2/providers/volatile"
 	binancews "github.com/skip-mev/connect/v2/providers/websockets/binance"
 	"github.com/skip-mev/connect/v2/providers/websockets/bitfinex"
@@ -40,6 +63,26 @@ var (
 			API:  raydium.DefaultAPIConfig,
 			Type: types.ConfigType,
 		},
+		{
+			Name: solana.Name,
+			API:  solana.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: sui.Name,
+			API:  sui.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: aptos.Name,
+			API:  aptos.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: tron.Name,
+			API:  tron.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
 		{
 			Name: uniswapv3.ProviderNames[constants.ETHEREUM],
 			API:  uniswapv3.DefaultETHAPIConfig,
@@ -50,11 +93,81 @@ var (
 			API:  uniswapv3.DefaultBaseAPIConfig,
 			Type: types.ConfigType,
 		},
+		{
+			Name: curve.ProviderNames[constants.ETHEREUM],
+			API:  curve.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: balancer.ProviderNames[constants.ETHEREUM],
+			API:  balancer.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: chainlink.ProviderNames[constants.ETHEREUM],
+			API:  chainlink.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: chainlink.ProviderNames[constants.BASE],
+			API:  chainlink.DefaultBaseAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: api3.ProviderNames[constants.ETHEREUM],
+			API:  api3.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: api3.ProviderNames[constants.BASE],
+			API:  api3.DefaultBaseAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: lido.ProviderNames[constants.ETHEREUM],
+			API:  lido.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: rocketpool.ProviderNames[constants.ETHEREUM],
+			API:  rocketpool.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: erc4626.ProviderNames[constants.ETHEREUM],
+			API:  erc4626.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: erc4626.ProviderNames[constants.BASE],
+			API:  erc4626.DefaultBaseAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: evmcall.ProviderNames[constants.ETHEREUM],
+			API:  evmcall.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: evmcall.ProviderNames[constants.BASE],
+			API:  evmcall.DefaultBaseAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: exchangerate.ProviderNames[constants.ETHEREUM],
+			API:  exchangerate.DefaultETHAPIConfig,
+			Type: types.ConfigType,
+		},
 		{
 			Name: osmosis.Name,
 			API:  osmosis.DefaultAPIConfig,
 			Type: types.ConfigType,
 		},
+		{
+			Name: cosmwasm.ProviderNames[constants.NEUTRON],
+			API:  cosmwasm.DefaultNeutronAPIConfig,
+			Type: types.ConfigType,
+		},
 
 		// Exchange API providers
 		{
@@ -67,6 +180,11 @@ var (
 			API:  bitstampapi.DefaultAPIConfig,
 			Type: types.ConfigType,
 		},
+		{
+			Name: bybitapi.Name,
+			API:  bybitapi.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
 		{
 			Name: coinbaseapi.Name,
 			API:  coinbaseapi.DefaultAPIConfig,
@@ -87,11 +205,21 @@ var (
 			API:  krakenapi.DefaultAPIConfig,
 			Type: types.ConfigType,
 		},
+		{
+			Name: okxapi.Name,
+			API:  okxapi.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
 		{
 			Name: volatile.Name,
 			API:  volatile.DefaultAPIConfig,
 			Type: types.ConfigType,
 		},
+		{
+			Name: static.Name,
+			API:  static.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
 		// Exchange WebSocket providers
 		{
 			Name:      binancews.Name,
@@ -113,6 +241,11 @@ var (
 			WebSocket: bybit.DefaultWebSocketConfig,
 			Type:      types.ConfigType,
 		},
+		{
+			Name:      bybit.LinearName,
+			WebSocket: bybit.DefaultLinearWebSocketConfig,
+			Type:      types.ConfigType,
+		},
 		{
 			Name:      coinbase.Name,
 			WebSocket: coinbase.DefaultWebSocketConfig,
@@ -155,6 +288,63 @@ var (
 			Type:      types.ConfigType,
 		},
 
+		// Pyth provider
+		{
+			Name: pyth.Name,
+			API:  pyth.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
+
+		// RedStone provider
+		{
+			Name: redstone.Name,
+			API:  redstone.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
+
+		// Band provider
+		{
+			Name: band.Name,
+			API:  band.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
+
+		// DIA provider
+		{
+			Name: dia.Name,
+			API:  dia.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
+
+		// Forex provider
+		{
+			Name: forex.Name,
+			API:  forex.DefaultAPIConfig,
+			Type: types.ConfigType,
+		},
+
+		// Order book providers
+		{
+			Name: orderbook.BinanceName,
+			API:  orderbook.DefaultBinanceAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: orderbook.CoinbaseName,
+			API:  orderbook.DefaultCoinbaseAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: orderbook.KrakenName,
+			API:  orderbook.DefaultKrakenAPIConfig,
+			Type: types.ConfigType,
+		},
+		{
+			Name: orderbook.OKXName,
+			API:  orderbook.DefaultOKXAPIConfig,
+			Type: types.ConfigType,
+		},
+
 		// Polymarket provider
 		{
 			Name: polymarket.Name,
@@ -0,0 +1,184 @@
+package oracle
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+)
+
+var _ Controller = (*OracleImpl)(nil)
+
+// controls are the runtime controls of the oracle that are set by an operator. These are not
+// persisted, i.e. they are reset when the oracle is restarted.
+type controls struct {
+	mtx sync.RWMutex
+
+	// paused is the set of markets whose prices are not served.
+	paused map[string]struct{}
+	// disabled is the set of price providers whose prices are not aggregated.
+	disabled map[string]struct{}
+}
+
+// newControls returns the controls of an oracle with no paused markets or disabled providers.
+func newControls() *controls {
+	return &controls{
+		paused:   make(map[string]struct{}),
+		disabled: make(map[string]struct{}),
+	}
+}
+
+// PausePair stops serving the price of the given market, e.g. BTC/USD, until it is resumed. The
+// price of a paused market is still aggregated, so it is served as soon as the market is resumed.
+func (o *OracleImpl) PausePair(ticker string) error {
+	o.mut.RLock()
+	_, ok := o.marketMap.Markets[ticker]
+	o.mut.RUnlock()
+	if !ok {
+		return fmt.Errorf("market %s not found", ticker)
+	}
+
+	o.controls.mtx.Lock()
+	defer o.controls.mtx.Unlock()
+
+	o.controls.paused[ticker] = struct{}{}
+	o.logger.Info("paused market", zap.String("ticker", ticker))
+
+	return nil
+}
+
+// ResumePair resumes serving the price of the given paused market.
+func (o *OracleImpl) ResumePair(ticker string) error {
+	o.controls.mtx.Lock()
+	defer o.controls.mtx.Unlock()
+
+	if _, ok := o.controls.paused[ticker]; !ok {
+		return fmt.Errorf("market %s is not paused", ticker)
+	}
+
+	delete(o.controls.paused, ticker)
+	o.logger.Info("resumed market", zap.String("ticker", ticker))
+
+	return nil
+}
+
+// GetPausedPairs returns the paused markets, sorted by ticker.
+func (o *OracleImpl) GetPausedPairs() []string {
+	o.controls.mtx.RLock()
+	defer o.controls.mtx.RUnlock()
+
+	return sortedKeys(o.controls.paused)
+}
+
+// DisableProvider stops aggregating the prices of the given price provider until it is enabled.
+// The provider keeps fetching prices while it is disabled, so its prices are aggregated as soon
+// as it is enabled.
+func (o *OracleImpl) DisableProvider(name string) error {
+	o.mut.RLock()
+	_, ok := o.priceProviders[name]
+	o.mut.RUnlock()
+	if !ok {
+		return fmt.Errorf("provider %s not found", name)
+	}
+
+	o.controls.mtx.Lock()
+	defer o.controls.mtx.Unlock()
+
+	o.controls.disabled[name] = struct{}{}
+	o.logger.Info("disabled provider", zap.String("provider", name))
+
+	return nil
+}
+
+// EnableProvider resumes aggregating the prices of the given disabled price provider.
+func (o *OracleImpl) EnableProvider(name string) error {
+	o.controls.mtx.Lock()
+	defer o.controls.mtx.Unlock()
+
+	if _, ok := o.controls.disabled[name]; !ok {
+		return fmt.Errorf("provider %s is not disabled", name)
+	}
+
+	delete(o.controls.disabled, name)
+	o.logger.Info("enabled provider", zap.String("provider", name))
+
+	return nil
+}
+
+// GetDisabledProviders returns the disabled price providers, sorted by name.
+func (o *OracleImpl) GetDisabledProviders() []string {
+	o.controls.mtx.RLock()
+	defer o.controls.mtx.RUnlock()
+
+	return sortedKeys(o.controls.disabled)
+}
+
+// RefreshProviders immediately re-queries the given price providers rather than waiting for
+// their next interval, or every price provider if none are given. This is a no-op for websocket
+// providers, whose prices are streamed as they are updated.
+func (o *OracleImpl) RefreshProviders(names ...string) error {
+	o.mut.RLock()
+	defer o.mut.RUnlock()
+
+	if len(names) == 0 {
+		for name := range o.priceProviders {
+			names = append(names, name)
+		}
+	}
+
+	// Check every provider before refreshing any so that a bad request has no effect.
+	for _, name := range names {
+		if state, ok := o.priceProviders[name]; !ok || state.Provider == nil {
+			return fmt.Errorf("provider %s not found", name)
+		}
+	}
+
+	for _, name := range names {
+		o.logger.Info("refreshing provider", zap.String("provider", name))
+		o.priceProviders[name].Provider.Refresh()
+	}
+
+	return nil
+}
+
+// isDisabled returns true if the given price provider is disabled.
+func (o *OracleImpl) isDisabled(name string) bool {
+	o.controls.mtx.RLock()
+	defer o.controls.mtx.RUnlock()
+
+	_, ok := o.controls.disabled[name]
+	return ok
+}
+
+// withoutPaused returns the given prices without the prices of the paused markets.
+func (o *OracleImpl) withoutPaused(prices types.Prices) types.Prices {
+	o.controls.mtx.RLock()
+	defer o.controls.mtx.RUnlock()
+
+	if len(o.controls.paused) == 0 {
+		return prices
+	}
+
+	filtered := make(types.Prices, len(prices))
+	for ticker, price := range prices {
+		if _, ok := o.controls.paused[ticker]; !ok {
+			filtered[ticker] = price
+		}
+	}
+
+	return filtered
+}
+
+// sortedKeys returns the keys of the given set, sorted.
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
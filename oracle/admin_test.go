@@ -0,0 +1,134 @@
+package oracle_test
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mathtestutils "github.com/skip-mev/connect/v2/pkg/math/testutils"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// pricesAggregator serves a fixed set of prices.
+type pricesAggregator struct {
+	noOpPriceAggregator
+
+	prices types.Prices
+}
+
+func (a pricesAggregator) GetPrices() types.Prices {
+	return a.prices
+}
+
+func (s *OracleTestSuite) TestPausePair() {
+	aggregator := pricesAggregator{prices: types.Prices{
+		btcusdtCP.String(): big.NewFloat(100),
+		ethusdtCP.String(): big.NewFloat(10),
+	}}
+
+	orc, err := oracle.New(oracleCfg, aggregator, oracle.WithMarketMap(marketMap))
+	s.Require().NoError(err)
+	o := orc.(*oracle.OracleImpl)
+
+	// markets that are not in the market map cannot be paused.
+	s.Require().Error(o.PausePair("DOGE/USD"))
+
+	s.Require().NoError(o.PausePair(btcusdtCP.String()))
+	s.Require().Equal([]string{btcusdtCP.String()}, o.GetPausedPairs())
+	s.Require().Equal(types.Prices{ethusdtCP.String(): big.NewFloat(10)}, o.GetPrices())
+
+	// the aggregated prices are left untouched.
+	s.Require().Len(aggregator.prices, 2)
+
+	s.Require().NoError(o.ResumePair(btcusdtCP.String()))
+	s.Require().Empty(o.GetPausedPairs())
+	s.Require().Equal(aggregator.prices, o.GetPrices())
+
+	// markets that are not paused cannot be resumed.
+	s.Require().Error(o.ResumePair(btcusdtCP.String()))
+}
+
+func (s *OracleTestSuite) TestDisableProvider() {
+	newProvider := func(cfg config.ProviderConfig, price int64) *types.PriceProvider {
+		resolved := types.ResolvedPrices{
+			s.currencyPairs[0]: {
+				Value:     big.NewFloat(float64(price)),
+				Timestamp: time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}
+		response := providertypes.NewGetResponse[types.ProviderTicker, *big.Float](resolved, nil)
+		return testutils.CreateAPIProviderWithGetResponses[types.ProviderTicker, *big.Float](
+			s.T(),
+			s.logger,
+			cfg,
+			s.currencyPairs,
+			[]providertypes.GetResponse[types.ProviderTicker, *big.Float]{response},
+			200*time.Millisecond,
+		)
+	}
+
+	otherCfg := providerCfg1
+	otherCfg.Name = "api2"
+	otherCfg.API.Name = "api2"
+
+	market := s.marketmap.Markets[btcusdtCP.String()]
+	market.ProviderConfigs = []mmtypes.ProviderConfig{
+		{Name: providerCfg1.Name, OffChainTicker: coinbasebtcusd.GetOffChainTicker()},
+		{Name: otherCfg.Name, OffChainTicker: coinbasebtcusd.GetOffChainTicker()},
+	}
+	mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{btcusdtCP.String(): market}}
+
+	cfg := config.OracleConfig{
+		UpdateInterval: 500 * time.Millisecond,
+		MaxPriceAge:    1 * time.Minute,
+		Metrics:        oracleCfg.Metrics,
+		Host:           oracleCfg.Host,
+		Port:           oracleCfg.Port,
+	}
+	orc, err := oracle.New(
+		cfg,
+		mathtestutils.NewMedianAggregator(),
+		oracle.WithLogger(s.logger),
+		oracle.WithPriceProviders(newProvider(providerCfg1, 100), newProvider(otherCfg, 200)),
+		oracle.WithMarketMap(mm),
+	)
+	s.Require().NoError(err)
+	o := orc.(*oracle.OracleImpl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go o.Start(ctx)
+	defer o.Stop()
+
+	priceOf := func(expected float64) func() bool {
+		return func() bool {
+			price, ok := o.GetPrices()[s.currencyPairs[0].String()]
+			return ok && price.Cmp(big.NewFloat(expected)) == 0
+		}
+	}
+	s.Require().Eventually(priceOf(150), 5*time.Second, 100*time.Millisecond)
+
+	// unknown providers cannot be disabled or refreshed.
+	s.Require().Error(o.DisableProvider("unknown"))
+	s.Require().Error(o.RefreshProviders(providerCfg1.Name, "unknown"))
+
+	s.Require().NoError(o.DisableProvider(otherCfg.Name))
+	s.Require().Equal([]string{otherCfg.Name}, o.GetDisabledProviders())
+	s.Require().Eventually(priceOf(100), 5*time.Second, 100*time.Millisecond)
+
+	// disabled providers keep running so that they can be enabled immediately.
+	s.Require().True(o.GetProviderState()[otherCfg.Name].Provider.IsRunning())
+	s.Require().NoError(o.RefreshProviders())
+
+	s.Require().NoError(o.EnableProvider(otherCfg.Name))
+	s.Require().Empty(o.GetDisabledProviders())
+	s.Require().Eventually(priceOf(150), 5*time.Second, 100*time.Millisecond)
+
+	// providers that are not disabled cannot be enabled.
+	s.Require().Error(o.EnableProvider(otherCfg.Name))
+}
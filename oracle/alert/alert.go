@@ -0,0 +1,255 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// Kind is the kind of anomaly an alert is sent for.
+type Kind string
+
+const (
+	// KindUnhealthyProvider is the kind of alerts sent when a provider is not running, or is
+	// quarantined or probing after consecutive failures. The subject is the provider's name.
+	KindUnhealthyProvider Kind = "unhealthy_provider"
+
+	// KindPriceChange is the kind of alerts sent when a market's price moved by more than the
+	// max price change between checks. The subject is the market's ticker.
+	KindPriceChange Kind = "price_change"
+
+	// KindMissingPrice is the kind of alerts sent when a market had no price for the configured
+	// number of consecutive checks. The subject is the market's ticker.
+	KindMissingPrice Kind = "missing_price"
+)
+
+// Alert is a notification of an anomaly of the oracle.
+type Alert struct {
+	// Kind is the kind of the anomaly.
+	Kind Kind `json:"kind"`
+	// Subject is the provider or market the anomaly is of.
+	Subject string `json:"subject"`
+	// Message is a human-readable description of the anomaly.
+	Message string `json:"message"`
+	// Resolved is true if the anomaly is no longer observed.
+	Resolved bool `json:"resolved"`
+	// Timestamp is the time the anomaly was observed, or resolved.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Key returns the key alerts are deduplicated by, i.e. the kind and subject of the alert.
+func (a Alert) Key() string {
+	return string(a.Kind) + ":" + a.Subject
+}
+
+// Notifier sends alerts to an external service.
+type Notifier interface {
+	// Name is the name of the service the notifier sends alerts to.
+	Name() string
+
+	// Notify sends the given alert.
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// Source is the oracle whose anomalies are alerted on.
+type Source interface {
+	GetPrices() types.Prices
+	GetMarketMap() mmtypes.MarketMap
+	GetProviderState() map[string]oracle.ProviderState
+}
+
+// Alerter periodically checks the oracle for anomalies and sends an alert to each notifier for
+// every anomaly. Alerts are deduplicated by their kind and subject: an alert that keeps firing is
+// only sent again once the cooldown has elapsed, and a resolved alert is sent once the anomaly is
+// no longer observed.
+type Alerter struct {
+	logger    *zap.Logger
+	cfg       config.AlertConfig
+	source    Source
+	notifiers []Notifier
+
+	// last are the prices of the previous check.
+	last types.Prices
+	// missing is the number of consecutive checks each market had no price for.
+	missing map[string]int
+	// active are the alerts that were sent and have not been resolved, indexed by key.
+	active map[string]Alert
+	// sent is the time each alert was last sent, indexed by key.
+	sent map[string]time.Time
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+// Option is a functional option for the Alerter.
+type Option func(*Alerter)
+
+// WithClock sets the function used to determine the current time, which determines whether the
+// cooldown of an alert has elapsed. This is useful for testing.
+func WithClock(now func() time.Time) Option {
+	return func(a *Alerter) {
+		a.now = now
+	}
+}
+
+// NewAlerter returns a new Alerter that checks the given source and sends alerts to the given
+// notifiers.
+func NewAlerter(
+	logger *zap.Logger,
+	cfg config.AlertConfig,
+	source Source,
+	notifiers []Notifier,
+	opts ...Option,
+) (*Alerter, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	a := &Alerter{
+		logger:    logger.With(zap.String("process", "alerter")),
+		cfg:       cfg,
+		source:    source,
+		notifiers: notifiers,
+		missing:   make(map[string]int),
+		active:    make(map[string]Alert),
+		sent:      make(map[string]time.Time),
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a, nil
+}
+
+// Run checks the source at the configured interval until the given context is cancelled.
+func (a *Alerter) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		a.Check(ctx)
+	}
+}
+
+// Check checks the source for anomalies, sends the alerts that are due, and returns them.
+func (a *Alerter) Check(ctx context.Context) []Alert {
+	now := a.now().UTC()
+
+	firing := make(map[string]Alert)
+	fire := func(kind Kind, subject, message string) {
+		alert := Alert{Kind: kind, Subject: subject, Message: message, Timestamp: now}
+		firing[alert.Key()] = alert
+	}
+
+	if a.cfg.UnhealthyProviders {
+		for name, state := range a.source.GetProviderState() {
+			provider := state.Provider
+			switch {
+			case provider == nil:
+			case !provider.IsRunning():
+				fire(KindUnhealthyProvider, name, fmt.Sprintf("provider %s is not running", name))
+			case provider.Health() != providertypes.Healthy:
+				fire(KindUnhealthyProvider, name, fmt.Sprintf("provider %s is %s", name, provider.Health()))
+			}
+		}
+	}
+
+	prices := a.source.GetPrices()
+	for ticker, market := range a.source.GetMarketMap().Markets {
+		if !market.Ticker.Enabled {
+			continue
+		}
+
+		price, ok := prices[ticker]
+		if !ok || price == nil {
+			a.missing[ticker]++
+			if a.cfg.MissingTicks > 0 && a.missing[ticker] >= a.cfg.MissingTicks {
+				fire(KindMissingPrice, ticker, fmt.Sprintf("no price for %s for %d checks", ticker, a.missing[ticker]))
+			}
+
+			continue
+		}
+		delete(a.missing, ticker)
+
+		previous, ok := a.last[ticker]
+		if a.cfg.MaxPriceChange <= 0 || !ok || previous == nil || previous.Sign() == 0 {
+			continue
+		}
+
+		diff := new(big.Float).Sub(price, previous)
+		change, _ := diff.Quo(diff.Abs(diff), previous).Float64()
+		if change > a.cfg.MaxPriceChange {
+			fire(KindPriceChange, ticker, fmt.Sprintf(
+				"price of %s moved by %.2f%% from %s to %s",
+				ticker, change*100, previous.Text('g', 10), price.Text('g', 10),
+			))
+		}
+	}
+	a.last = prices
+
+	var sent []Alert
+	for key, alert := range firing {
+		if last, ok := a.sent[key]; ok && now.Sub(last) < a.cfg.Cooldown {
+			continue
+		}
+
+		a.active[key] = alert
+		a.sent[key] = now
+		sent = append(sent, alert)
+	}
+
+	for key, alert := range a.active {
+		if _, ok := firing[key]; ok {
+			continue
+		}
+
+		delete(a.active, key)
+		alert.Resolved = true
+		alert.Timestamp = now
+		sent = append(sent, alert)
+	}
+
+	sort.Slice(sent, func(i, j int) bool {
+		return sent[i].Key() < sent[j].Key()
+	})
+	for _, alert := range sent {
+		a.notify(ctx, alert)
+	}
+
+	return sent
+}
+
+// notify sends the given alert to each notifier, logging any failures.
+func (a *Alerter) notify(ctx context.Context, alert Alert) {
+	a.logger.Info(
+		"sending alert",
+		zap.String("kind", string(alert.Kind)),
+		zap.String("subject", alert.Subject),
+		zap.String("message", alert.Message),
+		zap.Bool("resolved", alert.Resolved),
+	)
+
+	for _, notifier := range a.notifiers {
+		if err := notifier.Notify(ctx, alert); err != nil {
+			a.logger.Error("failed to send alert", zap.String("notifier", notifier.Name()), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package alert_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/alert"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	"github.com/skip-mev/connect/v2/providers/base"
+	apihandlermocks "github.com/skip-mev/connect/v2/providers/base/api/handlers/mocks"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+var (
+	btc = mmtypes.NewTicker("BTC", "USD", 8, 1, true)
+	eth = mmtypes.NewTicker("ETH", "USD", 8, 1, true)
+)
+
+// source is an oracle with fixed prices and providers.
+type source struct {
+	prices    types.Prices
+	providers map[string]oracle.ProviderState
+}
+
+func (s *source) GetPrices() types.Prices {
+	return s.prices
+}
+
+func (s *source) GetMarketMap() mmtypes.MarketMap {
+	return mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			btc.String(): {Ticker: btc},
+			eth.String(): {Ticker: eth},
+		},
+	}
+}
+
+func (s *source) GetProviderState() map[string]oracle.ProviderState {
+	return s.providers
+}
+
+// notifier records the alerts it is notified of.
+type notifier struct {
+	mu     sync.Mutex
+	err    error
+	alerts []alert.Alert
+}
+
+func (n *notifier) Name() string {
+	return "test"
+}
+
+func (n *notifier) Notify(_ context.Context, a alert.Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.alerts = append(n.alerts, a)
+	return n.err
+}
+
+// clock is a manually advanced clock.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newAlerter(t *testing.T, cfg config.AlertConfig, src alert.Source, n alert.Notifier, c *clock) *alert.Alerter {
+	t.Helper()
+
+	cfg.Enabled = true
+	cfg.Interval = time.Second
+	cfg.Cooldown = time.Minute
+	cfg.Webhooks = []config.AlertWebhookConfig{{
+		Type:    config.AlertWebhookTypeJSON,
+		URL:     "https://alerts.example.com/hook",
+		Timeout: time.Second,
+	}}
+
+	alerter, err := alert.NewAlerter(zap.NewNop(), cfg, src, []alert.Notifier{n}, alert.WithClock(c.Now))
+	require.NoError(t, err)
+
+	return alerter
+}
+
+func TestAlerterInvalidConfig(t *testing.T) {
+	_, err := alert.NewAlerter(zap.NewNop(), config.AlertConfig{Enabled: true}, &source{}, nil)
+	require.Error(t, err)
+}
+
+func TestAlerterUnhealthyProviders(t *testing.T) {
+	handler := apihandlermocks.NewQueryHandler[types.ProviderTicker, *big.Float](t)
+	provider, err := types.NewPriceProvider(
+		base.WithName[types.ProviderTicker, *big.Float]("coinbase"),
+		base.WithAPIQueryHandler[types.ProviderTicker, *big.Float](handler),
+		base.WithAPIConfig[types.ProviderTicker, *big.Float](coinbase.DefaultAPIConfig),
+	)
+	require.NoError(t, err)
+
+	src := &source{
+		prices: types.Prices{btc.String(): big.NewFloat(60000), eth.String(): big.NewFloat(3000)},
+		providers: map[string]oracle.ProviderState{
+			"coinbase": {Provider: provider},
+			// providers that were not created are ignored.
+			"binance": {},
+		},
+	}
+	n := &notifier{}
+	c := &clock{now: time.Unix(1700000000, 0)}
+	alerter := newAlerter(t, config.AlertConfig{UnhealthyProviders: true}, src, n, c)
+
+	// the provider was never started.
+	sent := alerter.Check(context.Background())
+	require.Len(t, sent, 1)
+	require.Equal(t, alert.KindUnhealthyProvider, sent[0].Kind)
+	require.Equal(t, "coinbase", sent[0].Subject)
+	require.Equal(t, "provider coinbase is not running", sent[0].Message)
+	require.False(t, sent[0].Resolved)
+	require.Equal(t, sent, n.alerts)
+
+	// the alert is not sent again within the cooldown.
+	c.now = c.now.Add(30 * time.Second)
+	require.Empty(t, alerter.Check(context.Background()))
+
+	// but is sent again as a reminder once the cooldown elapsed.
+	c.now = c.now.Add(30 * time.Second)
+	require.Len(t, alerter.Check(context.Background()), 1)
+
+	// the alert is resolved once the provider is gone.
+	delete(src.providers, "coinbase")
+	sent = alerter.Check(context.Background())
+	require.Len(t, sent, 1)
+	require.True(t, sent[0].Resolved)
+	require.Equal(t, "unhealthy_provider:coinbase", sent[0].Key())
+	require.Len(t, n.alerts, 3)
+
+	// resolved alerts are only sent once.
+	require.Empty(t, alerter.Check(context.Background()))
+}
+
+func TestAlerterMissingPrices(t *testing.T) {
+	src := &source{prices: types.Prices{btc.String(): big.NewFloat(60000)}}
+	n := &notifier{err: fmt.Errorf("unavailable")}
+	c := &clock{now: time.Unix(1700000000, 0)}
+	alerter := newAlerter(t, config.AlertConfig{MissingTicks: 3}, src, n, c)
+
+	require.Empty(t, alerter.Check(context.Background()))
+	require.Empty(t, alerter.Check(context.Background()))
+
+	// failures of a notifier do not stop the alerter.
+	sent := alerter.Check(context.Background())
+	require.Len(t, sent, 1)
+	require.Equal(t, alert.KindMissingPrice, sent[0].Kind)
+	require.Equal(t, eth.String(), sent[0].Subject)
+	require.Equal(t, "no price for ETH/USD for 3 checks", sent[0].Message)
+
+	// a price resolves the alert, and resets the count of missing checks.
+	src.prices[eth.String()] = big.NewFloat(3000)
+	sent = alerter.Check(context.Background())
+	require.Len(t, sent, 1)
+	require.True(t, sent[0].Resolved)
+
+	delete(src.prices, eth.String())
+	require.Empty(t, alerter.Check(context.Background()))
+}
+
+func TestAlerterPriceChange(t *testing.T) {
+	src := &source{prices: types.Prices{btc.String(): big.NewFloat(60000), eth.String(): big.NewFloat(3000)}}
+	n := &notifier{}
+	c := &clock{now: time.Unix(1700000000, 0)}
+	alerter := newAlerter(t, config.AlertConfig{MaxPriceChange: 0.05}, src, n, c)
+
+	// there is no previous price to compare to.
+	require.Empty(t, alerter.Check(context.Background()))
+
+	src.prices = types.Prices{btc.String(): big.NewFloat(54000), eth.String(): big.NewFloat(3090)}
+	sent := alerter.Check(context.Background())
+	require.Len(t, sent, 1)
+	require.Equal(t, alert.KindPriceChange, sent[0].Kind)
+	require.Equal(t, btc.String(), sent[0].Subject)
+	require.Equal(t, "price of BTC/USD moved by 10.00% from 60000 to 54000", sent[0].Message)
+
+	// the price is stable again.
+	sent = alerter.Check(context.Background())
+	require.Len(t, sent, 1)
+	require.True(t, sent[0].Resolved)
+	require.Equal(t, btc.String(), sent[0].Subject)
+}
@@ -0,0 +1,194 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+const (
+	// maxErrorBodySize is the maximum number of bytes of an error response included in the error.
+	maxErrorBodySize = 512
+
+	// pagerDutySource is the source of the events sent to PagerDuty.
+	pagerDutySource = "connect"
+)
+
+var _ Notifier = (*WebhookNotifier)(nil)
+
+// WebhookNotifier posts alerts to a webhook. The body of each request depends on the type of the
+// webhook: generic webhooks receive the alert as a JSON object, Slack webhooks receive a message
+// with the alert's text, and PagerDuty receives an Events API v2 event that is triggered or
+// resolved using the alert's key as the dedup key.
+type WebhookNotifier struct {
+	cfg        config.AlertWebhookConfig
+	client     *http.Client
+	url        string
+	routingKey string
+}
+
+// NewNotifiersFromConfig returns a notifier for each webhook of the given config.
+func NewNotifiersFromConfig(cfg config.AlertConfig) ([]Notifier, error) {
+	notifiers := make([]Notifier, 0, len(cfg.Webhooks))
+	for i, webhook := range cfg.Webhooks {
+		notifier, err := NewWebhookNotifier(webhook)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier for webhook %d: %w", i, err)
+		}
+		notifiers = append(notifiers, notifier)
+	}
+
+	return notifiers, nil
+}
+
+// NewWebhookNotifier returns a new WebhookNotifier with the given config. The URL and routing key
+// are read from their sources, if set.
+func NewWebhookNotifier(cfg config.AlertWebhookConfig) (*WebhookNotifier, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	url := cfg.URL
+	if cfg.URLSource != "" {
+		value, err := readSecret(cfg.URLSource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook url: %w", err)
+		}
+		url = value
+	}
+
+	var routingKey string
+	if cfg.Type == config.AlertWebhookTypePagerDuty {
+		value, err := readSecret(cfg.RoutingKeySource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read routing key: %w", err)
+		}
+		routingKey = value
+	}
+
+	return &WebhookNotifier{
+		cfg: cfg,
+		client: &http.Client{
+			Transport: connecthttp.NewRoundTripperWithHeaders(
+				http.DefaultTransport,
+				connecthttp.WithConnectVersionUserAgent(),
+			),
+			Timeout: cfg.Timeout,
+		},
+		url:        url,
+		routingKey: routingKey,
+	}, nil
+}
+
+// Name returns the type of the webhook.
+func (n *WebhookNotifier) Name() string {
+	return n.cfg.Type
+}
+
+// Notify posts the given alert to the webhook.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	var body any
+	switch n.cfg.Type {
+	case config.AlertWebhookTypeSlack:
+		body = slackMessage{Text: text(alert)}
+	case config.AlertWebhookTypePagerDuty:
+		event := pagerDutyEvent{
+			RoutingKey:  n.routingKey,
+			EventAction: "trigger",
+			DedupKey:    alert.Key(),
+		}
+		if alert.Resolved {
+			event.EventAction = "resolve"
+		} else {
+			event.Payload = &pagerDutyPayload{
+				Summary:   alert.Message,
+				Source:    pagerDutySource,
+				Severity:  "warning",
+				Timestamp: alert.Timestamp.Format("2006-01-02T15:04:05.000Z07:00"),
+				Component: alert.Subject,
+				Class:     string(alert.Kind),
+			}
+		}
+		body = event
+	default:
+		body = alert
+	}
+
+	bz, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	return nil
+}
+
+// slackMessage is the body of a Slack incoming webhook request.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// pagerDutyEvent is the body of a PagerDuty Events API v2 request.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+// pagerDutyPayload is the payload of a triggered PagerDuty event.
+type pagerDutyPayload struct {
+	Summary   string `json:"summary"`
+	Source    string `json:"source"`
+	Severity  string `json:"severity"`
+	Timestamp string `json:"timestamp"`
+	Component string `json:"component"`
+	Class     string `json:"class"`
+}
+
+// text returns the text of the given alert, prefixed by its status.
+func text(alert Alert) string {
+	if alert.Resolved {
+		return "[RESOLVED] " + alert.Message
+	}
+
+	return "[FIRING] " + alert.Message
+}
+
+// readSecret returns the current value of the secret with the given reference.
+func readSecret(ref string) (string, error) {
+	src, err := secrets.NewSource(ref)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := secrets.NewSecret(src, 0)
+	if err != nil {
+		return "", err
+	}
+
+	return secret.Value(), nil
+}
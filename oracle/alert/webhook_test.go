@@ -0,0 +1,142 @@
+package alert_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/alert"
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// webhookServer records the bodies of the requests it receives.
+func webhookServer(t *testing.T, status int) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("rejected"))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &bodies
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	a := alert.Alert{
+		Kind:      alert.KindMissingPrice,
+		Subject:   "ETH/USD",
+		Message:   "no price for ETH/USD for 3 checks",
+		Timestamp: time.Unix(1700000000, 0).UTC(),
+	}
+
+	t.Run("posts the alert to generic webhooks", func(t *testing.T) {
+		srv, bodies := webhookServer(t, http.StatusOK)
+		notifier, err := alert.NewWebhookNotifier(config.AlertWebhookConfig{
+			Type:    config.AlertWebhookTypeJSON,
+			URL:     srv.URL,
+			Timeout: time.Second,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "webhook", notifier.Name())
+
+		require.NoError(t, notifier.Notify(context.Background(), a))
+		require.Len(t, *bodies, 1)
+		require.Equal(t, "missing_price", (*bodies)[0]["kind"])
+		require.Equal(t, "ETH/USD", (*bodies)[0]["subject"])
+		require.Equal(t, false, (*bodies)[0]["resolved"])
+	})
+
+	t.Run("posts a message to slack webhooks read from a secret", func(t *testing.T) {
+		srv, bodies := webhookServer(t, http.StatusOK)
+		t.Setenv("CONNECT_TEST_SLACK_WEBHOOK_URL", srv.URL)
+		notifiers, err := alert.NewNotifiersFromConfig(config.AlertConfig{
+			Webhooks: []config.AlertWebhookConfig{{
+				Type:      config.AlertWebhookTypeSlack,
+				URLSource: "env:CONNECT_TEST_SLACK_WEBHOOK_URL",
+				Timeout:   time.Second,
+			}},
+		})
+		require.NoError(t, err)
+		require.Len(t, notifiers, 1)
+
+		require.NoError(t, notifiers[0].Notify(context.Background(), a))
+		resolved := a
+		resolved.Resolved = true
+		require.NoError(t, notifiers[0].Notify(context.Background(), resolved))
+
+		require.Equal(t, []map[string]any{
+			{"text": "[FIRING] no price for ETH/USD for 3 checks"},
+			{"text": "[RESOLVED] no price for ETH/USD for 3 checks"},
+		}, *bodies)
+	})
+
+	t.Run("triggers and resolves pagerduty events", func(t *testing.T) {
+		srv, bodies := webhookServer(t, http.StatusAccepted)
+		t.Setenv("CONNECT_TEST_PAGERDUTY_KEY", "routing-key")
+		notifier, err := alert.NewWebhookNotifier(config.AlertWebhookConfig{
+			Type:             config.AlertWebhookTypePagerDuty,
+			URL:              srv.URL,
+			RoutingKeySource: "env:CONNECT_TEST_PAGERDUTY_KEY",
+			Timeout:          time.Second,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, notifier.Notify(context.Background(), a))
+		resolved := a
+		resolved.Resolved = true
+		require.NoError(t, notifier.Notify(context.Background(), resolved))
+
+		require.Len(t, *bodies, 2)
+		trigger := (*bodies)[0]
+		require.Equal(t, "routing-key", trigger["routing_key"])
+		require.Equal(t, "trigger", trigger["event_action"])
+		require.Equal(t, "missing_price:ETH/USD", trigger["dedup_key"])
+		payload := trigger["payload"].(map[string]any)
+		require.Equal(t, a.Message, payload["summary"])
+		require.Equal(t, "connect", payload["source"])
+		require.Equal(t, "ETH/USD", payload["component"])
+		require.Equal(t, "2023-11-14T22:13:20.000Z", payload["timestamp"])
+
+		resolve := (*bodies)[1]
+		require.Equal(t, "resolve", resolve["event_action"])
+		require.Equal(t, "missing_price:ETH/USD", resolve["dedup_key"])
+		require.NotContains(t, resolve, "payload")
+	})
+
+	t.Run("returns an error for non 2xx responses", func(t *testing.T) {
+		srv, _ := webhookServer(t, http.StatusBadRequest)
+		notifier, err := alert.NewWebhookNotifier(config.AlertWebhookConfig{
+			Type:    config.AlertWebhookTypeJSON,
+			URL:     srv.URL,
+			Timeout: time.Second,
+		})
+		require.NoError(t, err)
+
+		err = notifier.Notify(context.Background(), a)
+		require.ErrorContains(t, err, "400 Bad Request: rejected")
+	})
+
+	t.Run("fails if the routing key cannot be read", func(t *testing.T) {
+		_, err := alert.NewWebhookNotifier(config.AlertWebhookConfig{
+			Type:             config.AlertWebhookTypePagerDuty,
+			URL:              "https://events.pagerduty.com/v2/enqueue",
+			RoutingKeySource: "env:CONNECT_TEST_UNSET_PAGERDUTY_KEY",
+			Timeout:          time.Second,
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,336 @@
+package breaker
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+var _ oracle.PriceAggregator = (*Aggregator)(nil)
+
+// Halt is a market whose price is not published because its aggregated price moved too far too
+// quickly.
+type Halt struct {
+	// Ticker is the ticker of the market.
+	Ticker string
+	// Since is the time the market was halted.
+	Since time.Time
+	// LastPrice is the last price published before the move, which is held while the market is
+	// halted if its policy holds the last price.
+	LastPrice *big.Float
+	// Reference is the price published within the window that the move is measured from.
+	Reference *big.Float
+	// Price is the latest aggregated price of the market.
+	Price *big.Float
+	// Move is the fraction by which the latest aggregated price deviates from the reference price.
+	Move float64
+	// Ticks is the number of aggregations since the market was halted in which the move persisted.
+	Ticks int
+	// Held is true if the last price is published while the market is halted.
+	Held bool
+}
+
+// observation is a price published at a given time.
+type observation struct {
+	at    time.Time
+	price *big.Float
+}
+
+// Aggregator is a price aggregator that halts the publication of a market's price when the
+// aggregated price of the wrapped aggregator moves by more than the market's max move within the
+// market's window. While a market is halted, its price is not published, or its last price before
+// the move is held if its policy holds the last price. A halted market is resumed once the move
+// persists for the market's resume ticks, once its price returns to within the max move of the
+// price the move was measured from, or once it is cleared by an operator.
+type Aggregator struct {
+	mtx    sync.Mutex
+	logger *zap.Logger
+	cfg    config.CircuitBreakerConfig
+
+	// aggregator is the wrapped aggregator.
+	aggregator oracle.PriceAggregator
+
+	// now returns the current time.
+	now func() time.Time
+
+	// history are the prices published within the window of each market, oldest first.
+	history map[string][]observation
+	// halted are the markets that are currently halted, indexed by ticker.
+	halted map[string]*Halt
+	// prices are the prices published after the last aggregation.
+	prices types.Prices
+	// markets are the tickers of the market map the breaker was last updated with. Markets that are
+	// not in the market map, e.g. derived markets, are never discarded.
+	markets map[string]struct{}
+}
+
+// Option is a functional option for the circuit breaker aggregator.
+type Option func(*Aggregator)
+
+// WithClock sets the function used to determine the current time when measuring moves within the
+// window of a market. This is useful for testing.
+func WithClock(now func() time.Time) Option {
+	return func(a *Aggregator) {
+		a.now = now
+	}
+}
+
+// NewAggregator returns a new Aggregator that wraps the given aggregator and halts markets
+// according to the given config.
+func NewAggregator(
+	logger *zap.Logger,
+	aggregator oracle.PriceAggregator,
+	cfg config.CircuitBreakerConfig,
+	opts ...Option,
+) (*Aggregator, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	a := &Aggregator{
+		logger:     logger.With(zap.String("process", "circuit_breaker")),
+		cfg:        cfg,
+		aggregator: aggregator,
+		now:        time.Now,
+		history:    make(map[string][]observation),
+		halted:     make(map[string]*Halt),
+		prices:     make(types.Prices),
+		markets:    make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a, nil
+}
+
+// SetProviderPrices sets the prices for the given provider.
+func (a *Aggregator) SetProviderPrices(provider string, prices types.Prices) {
+	a.aggregator.SetProviderPrices(provider, prices)
+}
+
+// SetProviderWeights sets the weights for the given provider.
+func (a *Aggregator) SetProviderWeights(provider string, weights types.Weights) {
+	a.aggregator.SetProviderWeights(provider, weights)
+}
+
+// SetProviderVolumes sets the volumes for the given provider.
+func (a *Aggregator) SetProviderVolumes(provider string, volumes types.Volumes) {
+	a.aggregator.SetProviderVolumes(provider, volumes)
+}
+
+// UpdateMarketMap updates the market map of the wrapped aggregator, and discards the window and
+// halt of the markets that were removed from the market map, such that a market that is re-added
+// is measured from its next published price onwards.
+func (a *Aggregator) UpdateMarketMap(marketMap mmtypes.MarketMap) {
+	a.aggregator.UpdateMarketMap(marketMap)
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	for ticker := range a.markets {
+		if _, ok := marketMap.Markets[ticker]; !ok {
+			a.resume(ticker)
+		}
+	}
+
+	a.markets = make(map[string]struct{}, len(marketMap.Markets))
+	for ticker := range marketMap.Markets {
+		a.markets[ticker] = struct{}{}
+	}
+}
+
+// AggregatePrices aggregates the prices of the wrapped aggregator, and determines the prices that
+// are published. Markets whose price moved by more than their max move within their window are
+// halted.
+func (a *Aggregator) AggregatePrices() {
+	a.aggregator.AggregatePrices()
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	now := a.now().UTC()
+	prices := make(types.Prices)
+	for ticker, price := range a.aggregator.GetPrices() {
+		if price == nil {
+			continue
+		}
+
+		policy := a.cfg.PolicyForMarket(ticker)
+		if !policy.Enabled() {
+			prices[ticker] = price
+			continue
+		}
+
+		if a.check(ticker, price, policy, now) {
+			prices[ticker] = price
+			a.history[ticker] = append(a.history[ticker], observation{at: now, price: new(big.Float).Copy(price)})
+			continue
+		}
+
+		if halt := a.halted[ticker]; halt.Held {
+			prices[ticker] = new(big.Float).Copy(halt.LastPrice)
+		}
+	}
+
+	a.prices = prices
+}
+
+// check returns true if the given aggregated price of the market can be published. The market is
+// halted if the price moved by more than the policy's max move since any price published within
+// the policy's window, and a halted market is resumed if the move persisted for the policy's resume
+// ticks, or if the price returned to within the max move of the price the move was measured from.
+func (a *Aggregator) check(ticker string, price *big.Float, policy config.CircuitBreakerPolicy, now time.Time) bool {
+	if halt, ok := a.halted[ticker]; ok {
+		halt.Price = new(big.Float).Copy(price)
+		halt.Move = deviation(price, halt.Reference)
+		if halt.Move <= policy.MaxMove {
+			a.logger.Info("price returned to within max move; resuming market", zap.String("ticker", ticker))
+			a.resume(ticker)
+			return true
+		}
+
+		halt.Ticks++
+		if policy.ResumeTicks > 0 && halt.Ticks >= policy.ResumeTicks {
+			a.logger.Info(
+				"move persisted; resuming market at the new price",
+				zap.String("ticker", ticker),
+				zap.String("price", price.String()),
+				zap.Int("ticks", halt.Ticks),
+			)
+			a.resume(ticker)
+			return true
+		}
+
+		return false
+	}
+
+	// Prune the prices published before the window.
+	history := a.history[ticker]
+	for len(history) > 0 && now.Sub(history[0].at) > policy.Window {
+		history = history[1:]
+	}
+	a.history[ticker] = history
+
+	for _, obs := range history {
+		move := deviation(price, obs.price)
+		if move <= policy.MaxMove {
+			continue
+		}
+
+		a.halted[ticker] = &Halt{
+			Ticker:    ticker,
+			Since:     now,
+			LastPrice: new(big.Float).Copy(history[len(history)-1].price),
+			Reference: new(big.Float).Copy(obs.price),
+			Price:     new(big.Float).Copy(price),
+			Move:      move,
+			Held:      policy.HoldLastPrice,
+		}
+
+		a.logger.Warn(
+			"price moved beyond max move; halting market",
+			zap.String("ticker", ticker),
+			zap.String("price", price.String()),
+			zap.String("reference_price", obs.price.String()),
+			zap.Float64("move", move),
+			zap.Duration("window", policy.Window),
+			zap.Bool("hold_last_price", policy.HoldLastPrice),
+		)
+		return false
+	}
+
+	return true
+}
+
+// resume resumes the given market. Moves are measured from the next published price onwards.
+func (a *Aggregator) resume(ticker string) {
+	delete(a.halted, ticker)
+	delete(a.history, ticker)
+}
+
+// Clear clears the breaker of the given halted market, so that its next aggregated price is
+// published regardless of the move. An error is returned if the market is not halted.
+func (a *Aggregator) Clear(ticker string) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if _, ok := a.halted[ticker]; !ok {
+		return fmt.Errorf("market %s is not halted", ticker)
+	}
+
+	a.logger.Info("breaker cleared by operator; resuming market", zap.String("ticker", ticker))
+	a.resume(ticker)
+	return nil
+}
+
+// GetHalted returns the markets that are currently halted, sorted by ticker.
+func (a *Aggregator) GetHalted() []Halt {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	halted := make([]Halt, 0, len(a.halted))
+	for _, halt := range a.halted {
+		cpy := *halt
+		cpy.LastPrice = new(big.Float).Copy(halt.LastPrice)
+		cpy.Reference = new(big.Float).Copy(halt.Reference)
+		cpy.Price = new(big.Float).Copy(halt.Price)
+		halted = append(halted, cpy)
+	}
+
+	sort.Slice(halted, func(i, j int) bool {
+		return halted[i].Ticker < halted[j].Ticker
+	})
+	return halted
+}
+
+// GetPrices returns the prices published after the last aggregation, i.e. the aggregated prices
+// of the wrapped aggregator without the prices of halted markets, or with their last price if it
+// is held.
+func (a *Aggregator) GetPrices() types.Prices {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	prices := make(types.Prices, len(a.prices))
+	for ticker, price := range a.prices {
+		prices[ticker] = new(big.Float).Copy(price)
+	}
+
+	return prices
+}
+
+// GetUnconfirmedPrices returns the unconfirmed prices of the wrapped aggregator.
+func (a *Aggregator) GetUnconfirmedPrices() []string {
+	return a.aggregator.GetUnconfirmedPrices()
+}
+
+// Reset resets the wrapped aggregator.
+func (a *Aggregator) Reset() {
+	a.aggregator.Reset()
+}
+
+// deviation returns the fraction by which the price deviates from the reference price.
+func deviation(price, reference *big.Float) float64 {
+	if reference.Sign() == 0 {
+		if price.Sign() == 0 {
+			return 0
+		}
+
+		return 1
+	}
+
+	diff := new(big.Float).Sub(price, reference)
+	diff.Quo(diff.Abs(diff), new(big.Float).Abs(reference))
+
+	f, _ := diff.Float64()
+	return f
+}
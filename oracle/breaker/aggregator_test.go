@@ -0,0 +1,190 @@
+package breaker_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/breaker"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/mocks"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+const (
+	btcusd  = "BTC/USD"
+	pepeusd = "PEPE/USD"
+)
+
+// newAggregator returns a circuit breaker aggregator that wraps an aggregator publishing the
+// prices returned by the given function, along with a function advancing its clock.
+func newAggregator(
+	t *testing.T,
+	cfg config.CircuitBreakerConfig,
+	prices func() types.Prices,
+) (*breaker.Aggregator, func(time.Duration)) {
+	t.Helper()
+
+	wrapped := mocks.NewPriceAggregator(t)
+	wrapped.EXPECT().AggregatePrices().Return().Maybe()
+	wrapped.EXPECT().GetPrices().RunAndReturn(prices).Maybe()
+	wrapped.EXPECT().UpdateMarketMap(mock.Anything).Return().Maybe()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	aggregator, err := breaker.NewAggregator(zap.NewNop(), wrapped, cfg, breaker.WithClock(func() time.Time { return now }))
+	require.NoError(t, err)
+
+	return aggregator, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestAggregator(t *testing.T) {
+	var btc, pepe float64
+	prices := func() types.Prices {
+		return types.Prices{btcusd: big.NewFloat(btc), pepeusd: big.NewFloat(pepe)}
+	}
+
+	aggregate := func(a *breaker.Aggregator, btcPrice, pepePrice float64) types.Prices {
+		btc, pepe = btcPrice, pepePrice
+		a.AggregatePrices()
+		return a.GetPrices()
+	}
+
+	cfg := config.CircuitBreakerConfig{
+		Enabled: true,
+		Markets: map[string]config.CircuitBreakerPolicy{
+			btcusd: {MaxMove: 0.1, Window: time.Minute, ResumeTicks: 2},
+		},
+	}
+
+	t.Run("a market is halted on an extreme move and resumed once the move persists", func(t *testing.T) {
+		a, advance := newAggregator(t, cfg, prices)
+
+		published := aggregate(a, 100, 1)
+		require.Equal(t, "100", published[btcusd].String())
+
+		// a move within the max move is published.
+		advance(10 * time.Second)
+		published = aggregate(a, 105, 1)
+		require.Equal(t, "105", published[btcusd].String())
+		require.Empty(t, a.GetHalted())
+
+		// the move from 100 within the window exceeds the max move, so the market is halted. Markets
+		// without a policy are never halted.
+		advance(10 * time.Second)
+		published = aggregate(a, 111, 10)
+		require.NotContains(t, published, btcusd)
+		require.Equal(t, "10", published[pepeusd].String())
+
+		halted := a.GetHalted()
+		require.Len(t, halted, 1)
+		require.Equal(t, btcusd, halted[0].Ticker)
+		require.Equal(t, "105", halted[0].LastPrice.String())
+		require.Equal(t, "100", halted[0].Reference.String())
+		require.Equal(t, "111", halted[0].Price.String())
+		require.Equal(t, 0, halted[0].Ticks)
+		require.False(t, halted[0].Held)
+
+		// the market is resumed once the move persisted for the resume ticks.
+		advance(10 * time.Second)
+		published = aggregate(a, 112, 10)
+		require.NotContains(t, published, btcusd)
+		require.Equal(t, 1, a.GetHalted()[0].Ticks)
+
+		advance(10 * time.Second)
+		published = aggregate(a, 112, 10)
+		require.Equal(t, "112", published[btcusd].String())
+		require.Empty(t, a.GetHalted())
+	})
+
+	t.Run("moves are only measured within the window", func(t *testing.T) {
+		a, advance := newAggregator(t, cfg, prices)
+
+		aggregate(a, 100, 1)
+		advance(50 * time.Second)
+		aggregate(a, 109, 1)
+
+		// the move from 100 is no longer within the window.
+		advance(20 * time.Second)
+		published := aggregate(a, 118, 1)
+		require.Equal(t, "118", published[btcusd].String())
+		require.Empty(t, a.GetHalted())
+	})
+
+	t.Run("a market is resumed once its price returns", func(t *testing.T) {
+		a, advance := newAggregator(t, cfg, prices)
+
+		aggregate(a, 100, 1)
+		advance(time.Second)
+		require.NotContains(t, aggregate(a, 50, 1), btcusd)
+
+		advance(time.Second)
+		published := aggregate(a, 101, 1)
+		require.Equal(t, "101", published[btcusd].String())
+		require.Empty(t, a.GetHalted())
+	})
+
+	t.Run("the window and halt of a removed market are discarded", func(t *testing.T) {
+		a, advance := newAggregator(t, cfg, prices)
+
+		markets := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{btcusd: {}, pepeusd: {}}}
+		a.UpdateMarketMap(markets)
+
+		aggregate(a, 100, 1)
+		advance(time.Second)
+		require.NotContains(t, aggregate(a, 200, 1), btcusd)
+		require.Len(t, a.GetHalted(), 1)
+
+		a.UpdateMarketMap(mmtypes.MarketMap{Markets: map[string]mmtypes.Market{pepeusd: {}}})
+		require.Empty(t, a.GetHalted())
+
+		// the re-added market is not measured against its window before it was removed.
+		a.UpdateMarketMap(markets)
+		advance(time.Second)
+		require.Equal(t, "200", aggregate(a, 200, 1)[btcusd].String())
+		require.Empty(t, a.GetHalted())
+	})
+
+	t.Run("the last price is held if configured", func(t *testing.T) {
+		a, advance := newAggregator(t, config.CircuitBreakerConfig{
+			Enabled: true,
+			Default: config.CircuitBreakerPolicy{MaxMove: 0.5, Window: time.Minute, HoldLastPrice: true},
+		}, prices)
+
+		aggregate(a, 100, 1)
+		advance(time.Second)
+		published := aggregate(a, 100, 2)
+		require.Equal(t, "100", published[btcusd].String())
+		require.Equal(t, "1", published[pepeusd].String())
+
+		halted := a.GetHalted()
+		require.Len(t, halted, 1)
+		require.Equal(t, pepeusd, halted[0].Ticker)
+		require.True(t, halted[0].Held)
+
+		// the market is only resumed by an operator without resume ticks.
+		for i := 0; i < 10; i++ {
+			advance(time.Second)
+			require.Equal(t, "1", aggregate(a, 100, 2)[pepeusd].String())
+		}
+
+		require.Error(t, a.Clear(btcusd))
+		require.NoError(t, a.Clear(pepeusd))
+		require.Empty(t, a.GetHalted())
+
+		advance(time.Second)
+		require.Equal(t, "2", aggregate(a, 100, 2)[pepeusd].String())
+	})
+}
+
+func TestNewAggregator(t *testing.T) {
+	_, err := breaker.NewAggregator(zap.NewNop(), mocks.NewPriceAggregator(t), config.CircuitBreakerConfig{
+		Enabled: true,
+		Default: config.CircuitBreakerPolicy{MaxMove: 0.1},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"net"
+)
+
+// AdminConfig is the config for the admin API of the oracle server. The admin API lets operators
+// pause the publication of pairs, disable providers, adjust log levels, refresh providers and
+// clear circuit breakers at runtime, without restarting the oracle.
+type AdminConfig struct {
+	// Enabled indicates whether the admin API is served.
+	Enabled bool `json:"enabled"`
+
+	// TokenSource is a reference to a secret holding a bearer token that grants the admin role.
+	// The reference is one of env:<variable>, file:<path>, vault:<path>#<field> or
+	// awssm:<secret-id>[#<field>]. This may be omitted if the server's auth config accepts a
+	// token with the admin role.
+	TokenSource string `json:"tokenSource"`
+
+	// Address is the address of a separate listener that the admin API is served on, e.g.
+	// 127.0.0.1:8081. If set, the admin API is only served on this listener. If unset, the admin
+	// API is served on the oracle's listener.
+	Address string `json:"address"`
+
+	// TLS is the config for serving the admin listener over TLS. This is only used if the admin
+	// API is served on a separate listener.
+	TLS TLSConfig `json:"tls"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *AdminConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.TokenSource != "" {
+		if err := validateSecretSource(c.TokenSource); err != nil {
+			return fmt.Errorf("invalid admin token source: %w", err)
+		}
+	}
+
+	if c.Address != "" {
+		if _, _, err := net.SplitHostPort(c.Address); err != nil {
+			return fmt.Errorf("invalid admin address: %w", err)
+		}
+	}
+
+	if err := c.TLS.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid admin tls config: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestAdminConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      config.AdminConfig
+		expectedErr bool
+	}{
+		{
+			name: "good config",
+			config: config.AdminConfig{
+				Enabled:     true,
+				TokenSource: "env:CONNECT_ADMIN_TOKEN",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "good config with a separate listener",
+			config: config.AdminConfig{
+				Enabled:     true,
+				TokenSource: "env:CONNECT_ADMIN_TOKEN",
+				Address:     "127.0.0.1:8081",
+				TLS: config.TLSConfig{
+					Enabled:      true,
+					CertFile:     "admin.crt",
+					KeyFile:      "admin.key",
+					ClientCAFile: "ca.crt",
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "good config with no token source",
+			config: config.AdminConfig{
+				Enabled: true,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with invalid address",
+			config: config.AdminConfig{
+				Enabled: true,
+				Address: "8081",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with tls and no key file",
+			config: config.AdminConfig{
+				Enabled: true,
+				Address: "127.0.0.1:8081",
+				TLS:     config.TLSConfig{Enabled: true, CertFile: "admin.crt"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid token source",
+			config: config.AdminConfig{
+				Enabled:     true,
+				TokenSource: "CONNECT_ADMIN_TOKEN",
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "no admin api enabled",
+			config:      config.AdminConfig{},
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
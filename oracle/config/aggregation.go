@@ -0,0 +1,256 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// AggregationStrategyMedian aggregates provider prices by taking their median. This is the
+	// default strategy.
+	AggregationStrategyMedian = "median"
+
+	// AggregationStrategyTrimmedMean aggregates provider prices by discarding the highest and
+	// lowest prices and taking the mean of the remaining prices.
+	AggregationStrategyTrimmedMean = "trimmed_mean"
+
+	// AggregationStrategyWeightedMean aggregates provider prices by taking their mean, weighted
+	// by a configured weight per provider (e.g. the provider's volume or stake).
+	AggregationStrategyWeightedMean = "weighted_mean"
+
+	// AggregationStrategyTWAP aggregates provider prices by taking their median and averaging
+	// it over the last N aggregation ticks.
+	AggregationStrategyTWAP = "twap"
+
+	// AggregationStrategyVWAP aggregates provider prices by taking their mean, weighted by the
+	// volume each provider reports alongside its price (e.g. the 24h volume traded on the
+	// exchange).
+	AggregationStrategyVWAP = "vwap"
+)
+
+// AggregationConfig is the config for how the oracle aggregates the prices reported by each
+// provider into a single price per market.
+type AggregationConfig struct {
+	// Default is the aggregation strategy used for markets that do not have a market specific
+	// strategy. If unset, the median is used.
+	Default AggregationStrategyConfig `json:"default"`
+
+	// Markets maps a market's ticker (e.g. BTC/USD) to the aggregation strategy used for that
+	// market. Tickers are matched case-insensitively.
+	Markets map[string]AggregationStrategyConfig `json:"markets"`
+
+	// Derived maps the ticker of a derived market (e.g. ATOM/ETH) to the config used to derive
+	// its price from the index prices of other markets. Tickers are matched case-insensitively.
+	Derived map[string]DerivedMarketConfig `json:"derived"`
+
+	// Transforms maps a market's ticker (e.g. BTC/USD) to the pipeline of transforms that is
+	// applied, in order, to the converted price of each of its providers before the prices are
+	// aggregated. Tickers are matched case-insensitively.
+	Transforms map[string][]TransformConfig `json:"transforms"`
+
+	// Schedules maps a market's ticker (e.g. AAPL/USD) to its trading schedule. Markets without a
+	// schedule trade around the clock. Tickers are matched case-insensitively.
+	Schedules map[string]ScheduleConfig `json:"schedules"`
+
+	// Smoothing maps a market's ticker (e.g. BTC/USD) to the smoothing applied to its aggregated
+	// price over aggregation ticks before the price is published. Markets without a smoothing
+	// publish their aggregated price as is. Tickers are matched case-insensitively.
+	Smoothing map[string]SmoothingConfig `json:"smoothing"`
+
+	// ProviderWeights maps a provider's name to its weight in the aggregation of every market. A
+	// provider with a weight of 0 is observe-only: its prices are fetched, logged and compared to
+	// the aggregated price, but excluded from the aggregation, so that new providers can be vetted
+	// in production. The weights are used by the weighted_mean strategy, and are overridden by
+	// the weights of a market's strategy. Providers without a weight are given a weight of 1.
+	ProviderWeights map[string]float64 `json:"providerWeights"`
+}
+
+// AggregationStrategyConfig is the config for a single aggregation strategy.
+type AggregationStrategyConfig struct {
+	// Strategy is the name of the aggregation strategy. Must be one of median, trimmed_mean,
+	// weighted_mean, twap or vwap. If unset, the median is used.
+	Strategy string `json:"strategy"`
+
+	// TrimFraction is the fraction, in [0, 0.5), of prices discarded from each end of the sorted
+	// prices when using the trimmed_mean strategy.
+	TrimFraction float64 `json:"trimFraction"`
+
+	// Weights maps a provider's name to its weight when using the weighted_mean strategy, and
+	// overrides the provider's weight in the provider weights. Providers with a weight of 0 are
+	// observe-only for the market, regardless of the strategy. Providers without a weight are
+	// given a weight of 1.
+	Weights map[string]float64 `json:"weights"`
+
+	// ReportedWeights multiplies each provider's weight by the confidence weight the provider
+	// reports alongside each price (e.g. the inverse of the Pyth relative confidence interval)
+	// when using the weighted_mean strategy. Reported volumes are not confidence weights and are
+	// only used by the vwap strategy. Prices without a reported weight are only weighted by their
+	// provider's weight.
+	ReportedWeights bool `json:"reportedWeights"`
+
+	// TWAPWindow is the number of aggregation ticks averaged over when using the twap
+	// strategy.
+	TWAPWindow int `json:"twapWindow"`
+
+	// MaxDeviation is the maximum fraction by which a provider's price can deviate from the
+	// median of the remaining providers' prices before it is discarded as an outlier, e.g. 0.05
+	// discards prices that deviate by more than 5%. Outliers are discarded before the strategy
+	// is applied. If unset, outliers are not discarded.
+	MaxDeviation float64 `json:"maxDeviation"`
+
+	// MinPrice and MaxPrice bound the prices that are accepted from providers. Prices outside of
+	// the bounds are discarded before outliers are filtered, which guards against providers that
+	// report garbage values, e.g. due to a misconfigured contract address. The bounds are in
+	// terms of the unscaled price of the market. If unset, the respective bound is not enforced.
+	MinPrice float64 `json:"minPrice"`
+	MaxPrice float64 `json:"maxPrice"`
+
+	// MaxPriceChange is the maximum fraction by which a provider's price can deviate from the
+	// market's previous aggregated price, e.g. 0.1 discards prices that moved by more than 10%
+	// since the last aggregation tick. If the market has no previous aggregated price, or if
+	// unset, the price change is not bounded.
+	MaxPriceChange float64 `json:"maxPriceChange"`
+
+	// ConfirmDeviation is the fraction by which a market's aggregated price can move between
+	// aggregation ticks before the move must be confirmed, e.g. 0.02 requires moves of more than 2%
+	// to be confirmed. When exceeded, the previous price is kept and the market is re-queried from
+	// its API providers immediately; websocket providers confirm with the prices they stream by the
+	// next aggregation. The next aggregation is published only if it is within the confirm
+	// deviation of the held move; otherwise the previous price is kept again and the move is
+	// dropped. If unset, moves are published without confirmation.
+	ConfirmDeviation float64 `json:"confirmDeviation"`
+}
+
+// ForMarket returns the aggregation strategy config for the given market ticker.
+func (c *AggregationConfig) ForMarket(ticker string) AggregationStrategyConfig {
+	if cfg, ok := c.Markets[ticker]; ok {
+		return cfg
+	}
+
+	// Keys are lower-cased when the config is read via viper.
+	if cfg, ok := c.Markets[strings.ToLower(ticker)]; ok {
+		return cfg
+	}
+
+	return c.Default
+}
+
+// WeightsForMarket returns the weights of the providers of the given market ticker, i.e. the
+// provider weights overridden by the weights of the market's strategy.
+func (c *AggregationConfig) WeightsForMarket(ticker string) map[string]float64 {
+	strategy := c.ForMarket(ticker)
+	if len(c.ProviderWeights) == 0 {
+		return strategy.Weights
+	}
+
+	weights := make(map[string]float64, len(c.ProviderWeights)+len(strategy.Weights))
+	for provider, weight := range c.ProviderWeights {
+		weights[provider] = weight
+	}
+	for provider, weight := range strategy.Weights {
+		weights[provider] = weight
+	}
+
+	return weights
+}
+
+// IsObserveOnly returns true if the given provider has a weight of 0 for the given market ticker,
+// i.e. its prices are excluded from the market's aggregation.
+func (c *AggregationConfig) IsObserveOnly(ticker, provider string) bool {
+	weight, ok := c.WeightsForMarket(ticker)[provider]
+	return ok && weight == 0
+}
+
+// ValidateBasic performs basic validation of the aggregation config.
+func (c *AggregationConfig) ValidateBasic() error {
+	if err := c.Default.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid default aggregation strategy: %w", err)
+	}
+
+	for ticker, cfg := range c.Markets {
+		if err := cfg.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid aggregation strategy for market %s: %w", ticker, err)
+		}
+	}
+
+	for ticker, cfg := range c.Derived {
+		if err := cfg.ValidateBasic(ticker); err != nil {
+			return fmt.Errorf("invalid derived market %s: %w", ticker, err)
+		}
+	}
+
+	for ticker, transforms := range c.Transforms {
+		for i, transform := range transforms {
+			if err := transform.ValidateBasic(); err != nil {
+				return fmt.Errorf("invalid transform %d for market %s: %w", i, ticker, err)
+			}
+		}
+	}
+
+	for ticker, schedule := range c.Schedules {
+		if err := schedule.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid schedule for market %s: %w", ticker, err)
+		}
+	}
+
+	for ticker, smoothing := range c.Smoothing {
+		if err := smoothing.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid smoothing for market %s: %w", ticker, err)
+		}
+	}
+
+	for provider, weight := range c.ProviderWeights {
+		if weight < 0 {
+			return fmt.Errorf("weight for provider %s cannot be negative", provider)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBasic performs basic validation of the aggregation strategy config.
+func (c *AggregationStrategyConfig) ValidateBasic() error {
+	if c.MaxDeviation < 0 {
+		return fmt.Errorf("max deviation cannot be negative")
+	}
+
+	if c.MinPrice < 0 || c.MaxPrice < 0 {
+		return fmt.Errorf("price bounds cannot be negative")
+	}
+
+	if c.MaxPrice > 0 && c.MinPrice > c.MaxPrice {
+		return fmt.Errorf("min price %f cannot be greater than max price %f", c.MinPrice, c.MaxPrice)
+	}
+
+	if c.MaxPriceChange < 0 {
+		return fmt.Errorf("max price change cannot be negative")
+	}
+
+	if c.ConfirmDeviation < 0 {
+		return fmt.Errorf("confirm deviation cannot be negative")
+	}
+
+	for provider, weight := range c.Weights {
+		if weight < 0 {
+			return fmt.Errorf("weight for provider %s cannot be negative", provider)
+		}
+	}
+
+	switch c.Strategy {
+	case "", AggregationStrategyMedian:
+	case AggregationStrategyTrimmedMean:
+		if c.TrimFraction < 0 || c.TrimFraction >= 0.5 {
+			return fmt.Errorf("trim fraction must be in [0, 0.5)")
+		}
+	case AggregationStrategyWeightedMean:
+	case AggregationStrategyTWAP:
+		if c.TWAPWindow < 1 {
+			return fmt.Errorf("twap window must be at least 1")
+		}
+	case AggregationStrategyVWAP:
+	default:
+		return fmt.Errorf("unknown aggregation strategy: %s", c.Strategy)
+	}
+
+	return nil
+}
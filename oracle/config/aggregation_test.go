@@ -0,0 +1,575 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestAggregationConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      config.AggregationConfig
+		expectedErr bool
+	}{
+		{
+			name:        "good empty config",
+			config:      config.AggregationConfig{},
+			expectedErr: false,
+		},
+		{
+			name: "good config with a strategy per market",
+			config: config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{
+					Strategy: config.AggregationStrategyMedian,
+				},
+				Markets: map[string]config.AggregationStrategyConfig{
+					"BTC/USD": {
+						Strategy:     config.AggregationStrategyTrimmedMean,
+						TrimFraction: 0.25,
+						MaxDeviation: 0.05,
+					},
+					"ETH/USD": {
+						Strategy: config.AggregationStrategyWeightedMean,
+						Weights:  map[string]float64{"binance_ws": 2, "coinbase_ws": 1},
+					},
+					"USDT/USD": {
+						Strategy:   config.AggregationStrategyTWAP,
+						TWAPWindow: 10,
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with unknown default strategy",
+			config: config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{
+					Strategy: "mode",
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with trim fraction out of range",
+			config: config.AggregationConfig{
+				Markets: map[string]config.AggregationStrategyConfig{
+					"BTC/USD": {
+						Strategy:     config.AggregationStrategyTrimmedMean,
+						TrimFraction: 0.5,
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative weight",
+			config: config.AggregationConfig{
+				Markets: map[string]config.AggregationStrategyConfig{
+					"BTC/USD": {
+						Strategy: config.AggregationStrategyWeightedMean,
+						Weights:  map[string]float64{"binance_ws": -1},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with observe-only providers",
+			config: config.AggregationConfig{
+				ProviderWeights: map[string]float64{"binance_ws": 2, "kraken_ws": 0},
+				Markets: map[string]config.AggregationStrategyConfig{
+					"BTC/USD": {
+						Weights: map[string]float64{"coinbase_ws": 0},
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative provider weight",
+			config: config.AggregationConfig{
+				ProviderWeights: map[string]float64{"binance_ws": -1},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative max deviation",
+			config: config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{
+					MaxDeviation: -0.1,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with price bounds",
+			config: config.AggregationConfig{
+				Markets: map[string]config.AggregationStrategyConfig{
+					"BTC/USD": {
+						MinPrice:       1000,
+						MaxPrice:       1000000,
+						MaxPriceChange: 0.1,
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative min price",
+			config: config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{
+					MinPrice: -1,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with min price greater than max price",
+			config: config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{
+					MinPrice: 10,
+					MaxPrice: 1,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative confirm deviation",
+			config: config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{
+					ConfirmDeviation: -0.1,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative max price change",
+			config: config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{
+					MaxPriceChange: -0.1,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with vwap",
+			config: config.AggregationConfig{
+				Markets: map[string]config.AggregationStrategyConfig{
+					"BTC/USD": {
+						Strategy: config.AggregationStrategyVWAP,
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with no twap window",
+			config: config.AggregationConfig{
+				Markets: map[string]config.AggregationStrategyConfig{
+					"BTC/USD": {
+						Strategy: config.AggregationStrategyTWAP,
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with derived markets",
+			config: config.AggregationConfig{
+				Derived: map[string]config.DerivedMarketConfig{
+					"atom/eth": {
+						Decimals: 10,
+						MaxHops:  3,
+					},
+					"USD/JPY": {
+						Decimals: 3,
+						Path:     []config.DerivationStep{{Ticker: "JPY/USD", Invert: true}},
+					},
+					"ATOM/ETH": {
+						Decimals:         10,
+						Path:             []config.DerivationStep{{Ticker: "ATOM/USD"}, {Ticker: "ETH/USD", Invert: true}},
+						MinProviderCount: 2,
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with invalid derived market ticker",
+			config: config.AggregationConfig{
+				Derived: map[string]config.DerivedMarketConfig{
+					"ATOMETH": {
+						Decimals: 10,
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with derived market without decimals",
+			config: config.AggregationConfig{
+				Derived: map[string]config.DerivedMarketConfig{
+					"ATOM/ETH": {},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative derived max hops",
+			config: config.AggregationConfig{
+				Derived: map[string]config.DerivedMarketConfig{
+					"ATOM/ETH": {
+						Decimals: 10,
+						MaxHops:  -1,
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with derivation path that does not start at the base",
+			config: config.AggregationConfig{
+				Derived: map[string]config.DerivedMarketConfig{
+					"ATOM/ETH": {
+						Decimals: 10,
+						Path:     []config.DerivationStep{{Ticker: "ETH/USD", Invert: true}},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with transforms",
+			config: config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					"BTC/USD": {
+						{Type: config.TransformScale, Factor: 0.01, Provider: "binance_api"},
+						{Type: config.TransformClamp, Min: 1000, Max: 1000000},
+					},
+					"usdt/usd": {
+						{Type: config.TransformInvert, Provider: "kraken_api"},
+						{Type: config.TransformMultiply, Ticker: "USDC/USD", Invert: true},
+						{Type: config.TransformEMA, Alpha: 0.2},
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with unknown transform",
+			config: config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					"BTC/USD": {{Type: "round"}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with non-positive scale factor",
+			config: config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					"BTC/USD": {{Type: config.TransformScale}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid multiply ticker",
+			config: config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					"BTC/USD": {{Type: config.TransformMultiply, Ticker: "USDTUSD"}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with clamp without bounds",
+			config: config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					"BTC/USD": {{Type: config.TransformClamp}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with clamp min above max",
+			config: config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					"BTC/USD": {{Type: config.TransformClamp, Min: 2, Max: 1}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with ema alpha out of range",
+			config: config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					"BTC/USD": {{Type: config.TransformEMA, Alpha: 1.5}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with schedules",
+			config: config.AggregationConfig{
+				Schedules: map[string]config.ScheduleConfig{
+					"AAPL/USD": {
+						TimeZone: "America/New_York",
+						Sessions: []config.SessionConfig{
+							{Days: []string{"monday", "Tuesday", "wednesday", "thursday", "friday"}, Open: "09:30", Close: "16:00"},
+						},
+						Holidays: []string{"2024-12-25"},
+					},
+					"eur/usd": {
+						Sessions: []config.SessionConfig{{Open: "22:00", Close: "21:00"}},
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with unknown schedule time zone",
+			config: config.AggregationConfig{
+				Schedules: map[string]config.ScheduleConfig{
+					"AAPL/USD": {TimeZone: "America/Gotham"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with unknown session day",
+			config: config.AggregationConfig{
+				Schedules: map[string]config.ScheduleConfig{
+					"AAPL/USD": {Sessions: []config.SessionConfig{{Days: []string{"funday"}, Open: "09:30", Close: "16:00"}}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid session time",
+			config: config.AggregationConfig{
+				Schedules: map[string]config.ScheduleConfig{
+					"AAPL/USD": {Sessions: []config.SessionConfig{{Open: "9:30am", Close: "16:00"}}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with equal session open and close",
+			config: config.AggregationConfig{
+				Schedules: map[string]config.ScheduleConfig{
+					"AAPL/USD": {Sessions: []config.SessionConfig{{Open: "09:30", Close: "09:30"}}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid holiday",
+			config: config.AggregationConfig{
+				Schedules: map[string]config.ScheduleConfig{
+					"AAPL/USD": {Holidays: []string{"25/12/2024"}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with smoothing",
+			config: config.AggregationConfig{
+				Smoothing: map[string]config.SmoothingConfig{
+					"BTC/USD":  {Type: config.SmoothingEMA, Alpha: 0.5},
+					"PEPE/USD": {Type: config.SmoothingMedian, Window: 3},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with ema smoothing alpha out of range",
+			config: config.AggregationConfig{
+				Smoothing: map[string]config.SmoothingConfig{
+					"BTC/USD": {Type: config.SmoothingEMA, Alpha: 1.5},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with empty median smoothing window",
+			config: config.AggregationConfig{
+				Smoothing: map[string]config.SmoothingConfig{
+					"PEPE/USD": {Type: config.SmoothingMedian},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with unknown smoothing",
+			config: config.AggregationConfig{
+				Smoothing: map[string]config.SmoothingConfig{
+					"BTC/USD": {Type: "sma", Window: 3},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with derivation path that does not end at the quote",
+			config: config.AggregationConfig{
+				Derived: map[string]config.DerivedMarketConfig{
+					"ATOM/ETH": {
+						Decimals: 10,
+						Path:     []config.DerivationStep{{Ticker: "ATOM/USD"}},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAggregationConfigForMarket(t *testing.T) {
+	cfg := config.AggregationConfig{
+		Default: config.AggregationStrategyConfig{
+			Strategy: config.AggregationStrategyMedian,
+		},
+		Markets: map[string]config.AggregationStrategyConfig{
+			"BTC/USD": {
+				Strategy:   config.AggregationStrategyTWAP,
+				TWAPWindow: 10,
+			},
+			"eth/usd": {
+				Strategy:     config.AggregationStrategyTrimmedMean,
+				TrimFraction: 0.1,
+			},
+		},
+	}
+
+	require.Equal(t, config.AggregationStrategyTWAP, cfg.ForMarket("BTC/USD").Strategy)
+	require.Equal(t, config.AggregationStrategyTrimmedMean, cfg.ForMarket("ETH/USD").Strategy)
+	require.Equal(t, config.AggregationStrategyMedian, cfg.ForMarket("SOL/USD").Strategy)
+}
+
+func TestScheduleConfigIsOpen(t *testing.T) {
+	stocks := config.ScheduleConfig{
+		TimeZone: "America/New_York",
+		Sessions: []config.SessionConfig{
+			{Days: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, Open: "09:30", Close: "16:00"},
+		},
+		Holidays: []string{"2024-12-25"},
+	}
+	fx := config.ScheduleConfig{
+		TimeZone: "America/New_York",
+		Sessions: []config.SessionConfig{
+			{Days: []string{"sunday", "monday", "tuesday", "wednesday", "thursday"}, Open: "17:00", Close: "16:59"},
+		},
+		Holidays: []string{"2024-12-25"},
+	}
+	always := config.ScheduleConfig{Holidays: []string{"2024-12-25"}}
+
+	testCases := []struct {
+		name     string
+		schedule config.ScheduleConfig
+		time     time.Time
+		open     bool
+	}{
+		{"stocks at the open", stocks, time.Date(2024, 1, 5, 14, 30, 0, 0, time.UTC), true},
+		{"stocks before the open", stocks, time.Date(2024, 1, 5, 14, 29, 0, 0, time.UTC), false},
+		{"stocks at the close", stocks, time.Date(2024, 1, 5, 21, 0, 0, 0, time.UTC), false},
+		{"stocks during daylight saving time", stocks, time.Date(2024, 7, 1, 13, 30, 0, 0, time.UTC), true},
+		{"stocks on saturday", stocks, time.Date(2024, 1, 6, 16, 0, 0, 0, time.UTC), false},
+		{"stocks on a holiday", stocks, time.Date(2024, 12, 25, 16, 0, 0, 0, time.UTC), false},
+		{"fx overnight", fx, time.Date(2024, 1, 4, 5, 0, 0, 0, time.UTC), true},
+		{"fx after friday's close", fx, time.Date(2024, 1, 5, 22, 0, 0, 0, time.UTC), false},
+		{"fx on saturday", fx, time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC), false},
+		{"fx after sunday's open", fx, time.Date(2024, 1, 7, 22, 30, 0, 0, time.UTC), true},
+		{"fx on a holiday", fx, time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), false},
+		{"fx the morning after a holiday", fx, time.Date(2024, 12, 26, 10, 0, 0, 0, time.UTC), false},
+		{"fx the evening after a holiday", fx, time.Date(2024, 12, 26, 23, 0, 0, 0, time.UTC), true},
+		{"around the clock", always, time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC), true},
+		{"around the clock on a holiday", always, time.Date(2024, 12, 25, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.open, tc.schedule.IsOpen(tc.time))
+		})
+	}
+}
+
+func TestAggregationConfigScheduleForMarket(t *testing.T) {
+	cfg := config.AggregationConfig{
+		Schedules: map[string]config.ScheduleConfig{
+			"AAPL/USD": {TimeZone: "America/New_York"},
+			"eur/usd":  {TimeZone: "Europe/London"},
+		},
+	}
+
+	schedule, ok := cfg.ScheduleForMarket("AAPL/USD")
+	require.True(t, ok)
+	require.Equal(t, "America/New_York", schedule.TimeZone)
+
+	schedule, ok = cfg.ScheduleForMarket("EUR/USD")
+	require.True(t, ok)
+	require.Equal(t, "Europe/London", schedule.TimeZone)
+
+	_, ok = cfg.ScheduleForMarket("BTC/USD")
+	require.False(t, ok)
+}
+
+func TestAggregationConfigSmoothingForMarket(t *testing.T) {
+	cfg := config.AggregationConfig{
+		Smoothing: map[string]config.SmoothingConfig{
+			"BTC/USD":  {Type: config.SmoothingEMA, Alpha: 0.5},
+			"pepe/usd": {Type: config.SmoothingMedian, Window: 3},
+		},
+	}
+
+	smoothing, ok := cfg.SmoothingForMarket("BTC/USD")
+	require.True(t, ok)
+	require.Equal(t, config.SmoothingEMA, smoothing.Type)
+
+	smoothing, ok = cfg.SmoothingForMarket("PEPE/USD")
+	require.True(t, ok)
+	require.Equal(t, 3, smoothing.Window)
+
+	_, ok = cfg.SmoothingForMarket("ETH/USD")
+	require.False(t, ok)
+}
+
+func TestAggregationConfigWeightsForMarket(t *testing.T) {
+	cfg := config.AggregationConfig{
+		ProviderWeights: map[string]float64{"binance_ws": 2, "kraken_ws": 0},
+		Markets: map[string]config.AggregationStrategyConfig{
+			"BTC/USD": {
+				Strategy: config.AggregationStrategyWeightedMean,
+				Weights:  map[string]float64{"kraken_ws": 1, "coinbase_ws": 0},
+			},
+		},
+	}
+
+	// the weights of the market's strategy override the provider weights.
+	require.Equal(t, map[string]float64{
+		"binance_ws":  2,
+		"kraken_ws":   1,
+		"coinbase_ws": 0,
+	}, cfg.WeightsForMarket("BTC/USD"))
+	require.True(t, cfg.IsObserveOnly("BTC/USD", "coinbase_ws"))
+	require.False(t, cfg.IsObserveOnly("BTC/USD", "kraken_ws"))
+
+	require.Equal(t, cfg.ProviderWeights, cfg.WeightsForMarket("ETH/USD"))
+	require.True(t, cfg.IsObserveOnly("ETH/USD", "kraken_ws"))
+	require.False(t, cfg.IsObserveOnly("ETH/USD", "coinbase_ws"))
+	require.False(t, cfg.IsObserveOnly("ETH/USD", "okx_ws"))
+}
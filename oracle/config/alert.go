@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// AlertWebhookTypeJSON posts each alert as a JSON object to a generic webhook.
+	AlertWebhookTypeJSON = "webhook"
+
+	// AlertWebhookTypeSlack posts each alert as a message to a Slack incoming webhook.
+	AlertWebhookTypeSlack = "slack"
+
+	// AlertWebhookTypePagerDuty sends each alert as an event to the PagerDuty Events API v2.
+	// Alerts are triggered and resolved using their kind and subject as the dedup key.
+	AlertWebhookTypePagerDuty = "pagerduty"
+)
+
+// AlertConfig is the config for alerting on anomalies of the oracle. When enabled, the oracle is
+// checked at the configured interval, and an alert is sent to each webhook when a provider is
+// unhealthy, when a market's price moves by more than the configured fraction between checks, or
+// when a market has no price for the configured number of consecutive checks.
+type AlertConfig struct {
+	// Enabled indicates whether alerts are sent.
+	Enabled bool `json:"enabled"`
+
+	// Interval is the interval at which the oracle is checked for anomalies.
+	Interval time.Duration `json:"interval"`
+
+	// Cooldown is the minimum time between notifications of the same alert. An alert that is
+	// still firing after the cooldown is sent again as a reminder.
+	Cooldown time.Duration `json:"cooldown"`
+
+	// UnhealthyProviders indicates whether an alert is sent when a provider is not running, or
+	// is quarantined or probing after consecutive failures.
+	UnhealthyProviders bool `json:"unhealthyProviders"`
+
+	// MaxPriceChange is the fraction by which a market's price can move between checks before an
+	// alert is sent, e.g. 0.05 alerts on moves of more than 5%. If unset, price moves are not
+	// alerted on.
+	MaxPriceChange float64 `json:"maxPriceChange"`
+
+	// MissingTicks is the number of consecutive checks an enabled market can go without a price
+	// before an alert is sent. If unset, missing prices are not alerted on.
+	MissingTicks int `json:"missingTicks"`
+
+	// Webhooks are the webhooks alerts are sent to.
+	Webhooks []AlertWebhookConfig `json:"webhooks"`
+}
+
+// AlertWebhookConfig is the config for a single webhook alerts are sent to.
+type AlertWebhookConfig struct {
+	// Type is the type of the webhook. Must be one of webhook, slack or pagerduty.
+	Type string `json:"type"`
+
+	// URL is the URL alerts are posted to, e.g. https://events.pagerduty.com/v2/enqueue.
+	URL string `json:"url"`
+
+	// URLSource is a reference to the secret holding the URL, as an alternative to setting the URL
+	// in the config, e.g. for Slack webhooks whose URL is a credential. The reference is one of
+	// env:<variable>, file:<path>, vault:<path>#<field> or awssm:<secret-id>[#<field>].
+	URLSource string `json:"urlSource"`
+
+	// RoutingKeySource is a reference to the secret holding the integration key of the PagerDuty
+	// service alerts are routed to. This is required for pagerduty webhooks.
+	RoutingKeySource string `json:"routingKeySource"`
+
+	// Timeout is the timeout of a request to the webhook.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *AlertConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+
+	if c.Cooldown <= 0 {
+		return fmt.Errorf("cooldown must be greater than 0")
+	}
+
+	if c.MaxPriceChange < 0 {
+		return fmt.Errorf("max price change cannot be negative")
+	}
+
+	if c.MissingTicks < 0 {
+		return fmt.Errorf("missing ticks cannot be negative")
+	}
+
+	if !c.UnhealthyProviders && c.MaxPriceChange == 0 && c.MissingTicks == 0 {
+		return fmt.Errorf("at least one of unhealthy providers, max price change or missing ticks must be set")
+	}
+
+	if len(c.Webhooks) == 0 {
+		return fmt.Errorf("at least one webhook must be configured")
+	}
+
+	for i, webhook := range c.Webhooks {
+		if err := webhook.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid webhook %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *AlertWebhookConfig) ValidateBasic() error {
+	switch c.Type {
+	case AlertWebhookTypeJSON, AlertWebhookTypeSlack:
+	case AlertWebhookTypePagerDuty:
+		if err := validateSecretSource(c.RoutingKeySource); err != nil {
+			return fmt.Errorf("routing key source %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown webhook type: %s", c.Type)
+	}
+
+	switch {
+	case c.URL != "" && c.URLSource != "":
+		return fmt.Errorf("url and url source cannot both be set")
+	case c.URL != "":
+		if err := validateExportURL(c.URL); err != nil {
+			return err
+		}
+	case c.URLSource != "":
+		if err := validateSecretSource(c.URLSource); err != nil {
+			return fmt.Errorf("url source %w", err)
+		}
+	default:
+		return fmt.Errorf("url or url source must be set")
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+
+	return nil
+}
+
+// validateSecretSource returns an error if the given secret reference is not of the form
+// <scheme>:<location>.
+func validateSecretSource(ref string) error {
+	if scheme, location, ok := strings.Cut(ref, ":"); !ok || scheme == "" || location == "" {
+		return fmt.Errorf("must be of the form <scheme>:<location>")
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestAlertConfig(t *testing.T) {
+	webhook := config.AlertWebhookConfig{
+		Type:    config.AlertWebhookTypeJSON,
+		URL:     "https://alerts.example.com/hook",
+		Timeout: time.Second,
+	}
+
+	testCases := []struct {
+		name        string
+		config      config.AlertConfig
+		expectedErr bool
+	}{
+		{
+			name:        "good disabled config",
+			config:      config.AlertConfig{},
+			expectedErr: false,
+		},
+		{
+			name: "good config with every webhook type",
+			config: config.AlertConfig{
+				Enabled:            true,
+				Interval:           time.Second,
+				Cooldown:           time.Minute,
+				UnhealthyProviders: true,
+				MaxPriceChange:     0.05,
+				MissingTicks:       10,
+				Webhooks: []config.AlertWebhookConfig{
+					webhook,
+					{
+						Type:      config.AlertWebhookTypeSlack,
+						URLSource: "env:SLACK_WEBHOOK_URL",
+						Timeout:   time.Second,
+					},
+					{
+						Type:             config.AlertWebhookTypePagerDuty,
+						URL:              "https://events.pagerduty.com/v2/enqueue",
+						RoutingKeySource: "file:/etc/connect/pagerduty",
+						Timeout:          time.Second,
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with no interval",
+			config: config.AlertConfig{
+				Enabled:      true,
+				Cooldown:     time.Minute,
+				MissingTicks: 10,
+				Webhooks:     []config.AlertWebhookConfig{webhook},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no cooldown",
+			config: config.AlertConfig{
+				Enabled:      true,
+				Interval:     time.Second,
+				MissingTicks: 10,
+				Webhooks:     []config.AlertWebhookConfig{webhook},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative max price change",
+			config: config.AlertConfig{
+				Enabled:            true,
+				Interval:           time.Second,
+				Cooldown:           time.Minute,
+				UnhealthyProviders: true,
+				MaxPriceChange:     -0.05,
+				Webhooks:           []config.AlertWebhookConfig{webhook},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no checks",
+			config: config.AlertConfig{
+				Enabled:  true,
+				Interval: time.Second,
+				Cooldown: time.Minute,
+				Webhooks: []config.AlertWebhookConfig{webhook},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no webhooks",
+			config: config.AlertConfig{
+				Enabled:      true,
+				Interval:     time.Second,
+				Cooldown:     time.Minute,
+				MissingTicks: 10,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with unknown webhook type",
+			config: config.AlertConfig{
+				Enabled:      true,
+				Interval:     time.Second,
+				Cooldown:     time.Minute,
+				MissingTicks: 10,
+				Webhooks: []config.AlertWebhookConfig{{
+					Type:    "email",
+					URL:     "https://alerts.example.com/hook",
+					Timeout: time.Second,
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with both url and url source",
+			config: config.AlertConfig{
+				Enabled:      true,
+				Interval:     time.Second,
+				Cooldown:     time.Minute,
+				MissingTicks: 10,
+				Webhooks: []config.AlertWebhookConfig{{
+					Type:      config.AlertWebhookTypeSlack,
+					URL:       "https://hooks.slack.com/services/T/B/X",
+					URLSource: "env:SLACK_WEBHOOK_URL",
+					Timeout:   time.Second,
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid url",
+			config: config.AlertConfig{
+				Enabled:      true,
+				Interval:     time.Second,
+				Cooldown:     time.Minute,
+				MissingTicks: 10,
+				Webhooks: []config.AlertWebhookConfig{{
+					Type:    config.AlertWebhookTypeJSON,
+					URL:     "alerts.example.com/hook",
+					Timeout: time.Second,
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with pagerduty webhook without routing key",
+			config: config.AlertConfig{
+				Enabled:      true,
+				Interval:     time.Second,
+				Cooldown:     time.Minute,
+				MissingTicks: 10,
+				Webhooks: []config.AlertWebhookConfig{{
+					Type:    config.AlertWebhookTypePagerDuty,
+					URL:     "https://events.pagerduty.com/v2/enqueue",
+					Timeout: time.Second,
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with webhook without timeout",
+			config: config.AlertConfig{
+				Enabled:      true,
+				Interval:     time.Second,
+				Cooldown:     time.Minute,
+				MissingTicks: 10,
+				Webhooks: []config.AlertWebhookConfig{{
+					Type: config.AlertWebhookTypeJSON,
+					URL:  "https://alerts.example.com/hook",
+				}},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,10 +1,22 @@
 package config
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math/rand"
+	"strings"
 	"time"
 )
 
+const (
+	// BlockTagLatest is the block tag of the latest block observed by an EVM node.
+	BlockTagLatest = "latest"
+	// BlockTagSafe is the block tag of the latest block that is unlikely to be reorged.
+	BlockTagSafe = "safe"
+	// BlockTagFinalized is the block tag of the latest finalized block.
+	BlockTagFinalized = "finalized"
+)
+
 // APIConfig defines a config for an API based data provider.
 type APIConfig struct {
 	// Enabled indicates if the provider is enabled.
@@ -17,6 +29,13 @@ type APIConfig struct {
 	// Interval is the interval at which the provider should update the prices.
 	Interval time.Duration `json:"interval"`
 
+	// TickerIntervals overrides the interval at which the provider updates the prices of specific
+	// tickers, indexed by off-chain ticker. This allows tickers that need to be fresh to be fetched
+	// more often than the provider's other tickers, and tickers that rarely change (e.g. vault share
+	// rates) to be fetched less often. Tickers that are due at the same time are still fetched
+	// together, so they share requests to the provider's endpoints.
+	TickerIntervals map[string]time.Duration `json:"tickerIntervals"`
+
 	// ReconnectTimeout is the amount of time the provider should wait before
 	// reconnecting to the API.
 	ReconnectTimeout time.Duration `json:"reconnectTimeout"`
@@ -25,6 +44,12 @@ type APIConfig struct {
 	// within the interval.
 	MaxQueries int `json:"maxQueries"`
 
+	// MaxInFlight is the maximum number of requests the provider will have in flight at once,
+	// across all of its queries and endpoints. This bounds the number of connections a provider
+	// opens when its queries fan out into many requests (e.g. one request per ticker). If unset,
+	// the number of requests in flight is not limited.
+	MaxInFlight int `json:"maxInFlight"`
+
 	// Atomic is a flag that indicates whether the provider can fulfill its queries
 	// in a single request.
 	Atomic bool `json:"atomic"`
@@ -45,17 +70,295 @@ type APIConfig struct {
 	// block height incremented.  In the case where a data source has exceeded this limit and the block
 	// height is not increasing, price reporting will be skipped until the block height increases.
 	MaxBlockHeightAge time.Duration `json:"maxBlockHeightAge"`
+
+	// Failover is a flag that indicates whether an on-chain data source should treat its endpoints as
+	// a priority ordered list of fallbacks rather than querying all of them concurrently. When enabled,
+	// requests are sent to the first healthy endpoint and fail over to the next endpoint on error. A
+	// failed endpoint is retried once the ReconnectTimeout has elapsed.
+	Failover bool `json:"failover"`
+
+	// Retry is the retry policy applied to failed requests made by an on-chain data source. By
+	// default, failed requests are not retried.
+	Retry RetryConfig `json:"retry"`
+
+	// NewHeadsSubscription is a flag that indicates whether an on-chain data source should subscribe
+	// to new blocks over a websocket endpoint and only refresh its prices when a new block is observed.
+	// Requests made within the same block are served from a cache. At least one of the endpoints must
+	// be a websocket endpoint when enabled.
+	NewHeadsSubscription bool `json:"newHeadsSubscription"`
+
+	// BlockTag is the block tag at which an EVM data source reads on-chain state. This must be one of
+	// latest, safe or finalized. Reading at the safe or finalized block avoids reporting prices from
+	// blocks that are later reorged, at the cost of some latency. If unset, latest is used.
+	BlockTag string `json:"blockTag"`
+
+	// MaxBlockLag is the maximum age of the block at which an on-chain data source read a price,
+	// i.e. the time elapsed since the block's timestamp when the price is used. Prices read from
+	// older blocks, e.g. from an endpoint that lags behind the chain after a failover, are not
+	// used. If unset, the age of the block is not checked.
+	MaxBlockLag time.Duration `json:"maxBlockLag"`
+
+	// ChainID is the expected chain ID of the endpoints of an EVM data source. If set, the chain ID
+	// of each endpoint is verified via eth_chainId when the provider connects, and requests to an
+	// endpoint that is pointed at a different network fail. If unset, the chain ID is not verified.
+	ChainID uint64 `json:"chainId"`
+
+	// Sequencer is the check of the sequencer of an L2 chain (e.g. Arbitrum or Optimism) made before
+	// the prices of an EVM data source are trusted. By default, the sequencer is not checked.
+	Sequencer SequencerConfig `json:"sequencer"`
+
+	// Health is the policy used to quarantine the provider after consecutive failed requests. By
+	// default, the provider is never quarantined.
+	Health HealthConfig `json:"health"`
+
+	// AdaptiveInterval is the policy used to adapt the interval at which each ticker is fetched to
+	// the volatility of its price. By default, tickers are fetched at a fixed interval.
+	AdaptiveInterval AdaptiveIntervalConfig `json:"adaptiveInterval"`
+}
+
+// AdaptiveIntervalConfig defines how the interval at which a ticker is fetched adapts to the
+// volatility of its price. The volatility of a ticker is the root mean square of the relative
+// changes of its price between its last Window fetches. The ticker's interval is halved after each
+// fetch while the volatility is above HighVolatility, and doubled while it is below LowVolatility,
+// within [MinInterval, MaxInterval]. Each ticker starts at its configured interval.
+type AdaptiveIntervalConfig struct {
+	// Enabled indicates if the intervals of the tickers adapt to their volatility.
+	Enabled bool `json:"enabled"`
+
+	// MinInterval is the shortest interval at which a ticker is fetched.
+	MinInterval time.Duration `json:"minInterval"`
+
+	// MaxInterval is the longest interval at which a ticker is fetched.
+	MaxInterval time.Duration `json:"maxInterval"`
+
+	// Window is the number of recent prices of a ticker its volatility is computed over.
+	Window int `json:"window"`
+
+	// HighVolatility is the volatility, as a fraction of the price (e.g. 0.001 for 10 basis
+	// points), above which the interval of a ticker is shortened.
+	HighVolatility float64 `json:"highVolatility"`
+
+	// LowVolatility is the volatility below which the interval of a ticker is lengthened. A
+	// value of 0 only lengthens the interval of tickers whose price does not change.
+	LowVolatility float64 `json:"lowVolatility"`
+}
+
+// ValidateBasic performs basic validation of the adaptive interval config.
+func (a AdaptiveIntervalConfig) ValidateBasic() error {
+	if !a.Enabled {
+		return nil
+	}
+
+	if a.MinInterval <= 0 || a.MaxInterval < a.MinInterval {
+		return fmt.Errorf("adaptive min interval must be strictly positive and at most the max interval")
+	}
+
+	if a.Window < 2 {
+		return fmt.Errorf("adaptive window must be at least 2")
+	}
+
+	if a.LowVolatility < 0 || a.HighVolatility <= a.LowVolatility {
+		return fmt.Errorf("adaptive low volatility cannot be negative and must be less than the high volatility")
+	}
+
+	return nil
+}
+
+// SequencerConfig defines how the sequencer of an L2 chain is checked. While the sequencer is down,
+// the state of the chain is not updated by L1 and its prices may be stale, so requests made by the
+// data source fail until the sequencer has been back up for the grace period.
+type SequencerConfig struct {
+	// UptimeFeed is the address of the Chainlink sequencer uptime feed of the chain. If set, the
+	// feed is read along with each request, and requests fail while the feed reports the sequencer
+	// as down.
+	UptimeFeed string `json:"uptimeFeed"`
+
+	// GracePeriod is the amount of time after the uptime feed reports the sequencer as back up
+	// during which requests still fail, so that transactions queued on L1 during the outage can be
+	// processed first.
+	GracePeriod time.Duration `json:"gracePeriod"`
+
+	// CheckSyncing indicates whether the endpoint's syncing status is read via eth_syncing along
+	// with each request, in which case requests fail while the node is syncing.
+	CheckSyncing bool `json:"checkSyncing"`
+}
+
+// Enabled returns true if the sequencer is checked.
+func (s SequencerConfig) Enabled() bool {
+	return s.UptimeFeed != "" || s.CheckSyncing
+}
+
+// ValidateBasic performs basic validation of the sequencer config.
+func (s SequencerConfig) ValidateBasic() error {
+	if s.UptimeFeed != "" && !isHexAddress(s.UptimeFeed) {
+		return fmt.Errorf("sequencer uptime feed must be a hex address, got %s", s.UptimeFeed)
+	}
+
+	if s.GracePeriod < 0 {
+		return fmt.Errorf("sequencer grace period cannot be negative")
+	}
+
+	if s.GracePeriod > 0 && s.UptimeFeed == "" {
+		return fmt.Errorf("sequencer grace period can only be set with an uptime feed")
+	}
+
+	return nil
+}
+
+// isHexAddress returns true if the given string is a 0x-prefixed, 20 byte hex address.
+func isHexAddress(s string) bool {
+	if len(s) != 42 || !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return false
+	}
+
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
+}
+
+// HealthConfig defines when a provider is considered unhealthy. A provider is quarantined after the
+// given number of consecutive failed responses. While quarantined, the provider is not queried and
+// its prices are not reported. Once the cooldown has elapsed, the provider is probed and its prices
+// are only reported again once a probe succeeds.
+type HealthConfig struct {
+	// FailureThreshold is the number of consecutive failed responses after which the provider is
+	// quarantined. A value of 0 disables quarantining.
+	FailureThreshold int `json:"failureThreshold"`
+
+	// Cooldown is the amount of time a quarantined provider is not queried before it is probed.
+	Cooldown time.Duration `json:"cooldown"`
+}
+
+// Enabled returns true if the provider should be quarantined after consecutive failures.
+func (h HealthConfig) Enabled() bool {
+	return h.FailureThreshold > 0
+}
+
+// ValidateBasic performs basic validation of the health config.
+func (h HealthConfig) ValidateBasic() error {
+	if h.FailureThreshold < 0 {
+		return fmt.Errorf("health failure threshold cannot be negative")
+	}
+
+	if h.Enabled() && h.Cooldown <= 0 {
+		return fmt.Errorf("health cooldown must be strictly positive when the failure threshold is set")
+	}
+
+	return nil
+}
+
+// RetryConfig defines the retry policy for failed requests. Each attempt is bounded by the API
+// timeout, and attempts are separated by an exponential backoff with optional jitter. Requests that
+// failed for reasons that are not resolved by retrying (i.e. a misconfiguration) are not retried.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts made for a request, including the initial
+	// attempt. A value of 0 or 1 disables retries.
+	MaxAttempts int `json:"maxAttempts"`
+
+	// InitialBackoff is the amount of time to wait before the first retry. The backoff is doubled
+	// after each subsequent attempt.
+	InitialBackoff time.Duration `json:"initialBackoff"`
+
+	// MaxBackoff is the maximum amount of time to wait between attempts. If unset, the backoff is
+	// not capped.
+	MaxBackoff time.Duration `json:"maxBackoff"`
+
+	// RateLimitBackoff is the amount of time to wait before retrying a request that was rate
+	// limited. The backoff is doubled after each subsequent attempt. This is typically longer than
+	// the initial backoff, since rate limits take longer to clear than transient failures. If
+	// unset, rate limited requests use the initial backoff.
+	RateLimitBackoff time.Duration `json:"rateLimitBackoff"`
+
+	// Jitter is the fraction, in [0, 1], of each backoff that is randomized. This is used to avoid
+	// many providers retrying against the same endpoint in lockstep.
+	Jitter float64 `json:"jitter"`
+}
+
+// Enabled returns true if failed requests should be retried.
+func (r RetryConfig) Enabled() bool {
+	return r.MaxAttempts > 1
+}
+
+// Backoff returns the amount of time to wait after the given attempt failed. The backoff starts
+// from the rate limit backoff for rate limited attempts, doubles after each attempt, is capped by
+// the max backoff, and has the configured fraction randomized.
+func (r RetryConfig) Backoff(attempt int, rateLimited bool) time.Duration {
+	backoff := r.InitialBackoff
+	if rateLimited && r.RateLimitBackoff > 0 {
+		backoff = r.RateLimitBackoff
+	}
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if r.MaxBackoff > 0 && backoff >= r.MaxBackoff {
+			break
+		}
+	}
+
+	if r.MaxBackoff > 0 && backoff > r.MaxBackoff {
+		backoff = r.MaxBackoff
+	}
+
+	if r.Jitter > 0 {
+		//nolint:gosec // jitter does not need to be cryptographically secure
+		backoff -= time.Duration(r.Jitter * rand.Float64() * float64(backoff))
+	}
+
+	return backoff
+}
+
+// ValidateBasic performs basic validation of the retry config.
+func (r RetryConfig) ValidateBasic() error {
+	if r.MaxAttempts < 0 {
+		return fmt.Errorf("retry max attempts cannot be negative")
+	}
+
+	if r.InitialBackoff < 0 || r.MaxBackoff < 0 || r.RateLimitBackoff < 0 {
+		return fmt.Errorf("retry backoff cannot be negative")
+	}
+
+	if r.MaxBackoff > 0 && r.MaxBackoff < r.InitialBackoff {
+		return fmt.Errorf("retry max backoff cannot be less than the initial backoff")
+	}
+
+	if r.MaxBackoff > 0 && r.MaxBackoff < r.RateLimitBackoff {
+		return fmt.Errorf("retry max backoff cannot be less than the rate limit backoff")
+	}
+
+	if r.Jitter < 0 || r.Jitter > 1 {
+		return fmt.Errorf("retry jitter must be between 0 and 1")
+	}
+
+	return nil
 }
 
 // Endpoint holds all data necessary for an API provider to connect to a given endpoint
 // i.e. URL, headers, authentication, etc.
 type Endpoint struct {
 	// URL is the URL that is used to fetch data from the API.
-	URL string `json:"url"`
+	URL string `json:"url" schema:"url"`
 
 	// Authentication holds all data necessary for an API provider to authenticate with
 	// an endpoint.
 	Authentication Authentication `json:"authentication"`
+
+	// RateLimit is the rate limit applied to requests made to the endpoint by on-chain data
+	// sources. The limit is shared by all providers that use the same endpoint URL.
+	RateLimit RateLimitConfig `json:"rateLimit"`
+
+	// MaxBatchLength is the maximum number of calls an EVM data source sends to the endpoint in a
+	// single JSON-RPC batch request. Larger batches are split into several batch requests, which
+	// are sent concurrently. This is needed for nodes that limit the length of batch requests. A
+	// value of 0 does not limit the length of batch requests.
+	MaxBatchLength int `json:"maxBatchLength"`
+
+	// Coalesce is the policy used to coalesce the requests made to the endpoint by EVM data
+	// sources into shared batch requests. Requests are coalesced across all providers that use the
+	// same endpoint URL and authentication.
+	Coalesce CoalesceConfig `json:"coalesce"`
+
+	// BlockCache is the policy used to reuse the responses of the endpoint to EVM data sources
+	// while the latest block of the endpoint has not changed. The cache is shared by all providers
+	// that use the same endpoint URL.
+	BlockCache BlockCacheConfig `json:"blockCache"`
 }
 
 // ValidateBasic performs basic validation of the API endpoint.
@@ -64,9 +367,125 @@ func (e Endpoint) ValidateBasic() error {
 		return fmt.Errorf("endpoint url cannot be empty")
 	}
 
+	if err := e.RateLimit.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if e.MaxBatchLength < 0 {
+		return fmt.Errorf("endpoint max batch length cannot be negative")
+	}
+
+	if err := e.Coalesce.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if err := e.BlockCache.ValidateBasic(); err != nil {
+		return err
+	}
+
 	return e.Authentication.ValidateBasic()
 }
 
+// RateLimitConfig defines a token bucket rate limit. Tokens are added to the bucket at the given
+// rate, up to the burst size, and each request consumes a single token.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the number of requests that can be made per second. A value of 0
+	// disables rate limiting.
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+
+	// Burst is the maximum number of requests that can be made at once. If unset, a burst of 1
+	// is used.
+	Burst int `json:"burst"`
+}
+
+// Enabled returns true if requests should be rate limited.
+func (r RateLimitConfig) Enabled() bool {
+	return r.RequestsPerSecond > 0
+}
+
+// GetBurst returns the burst size of the rate limit, defaulting to 1.
+func (r RateLimitConfig) GetBurst() int {
+	if r.Burst == 0 {
+		return 1
+	}
+
+	return r.Burst
+}
+
+// ValidateBasic performs basic validation of the rate limit config.
+func (r RateLimitConfig) ValidateBasic() error {
+	if r.RequestsPerSecond < 0 {
+		return fmt.Errorf("rate limit requests per second cannot be negative")
+	}
+
+	if r.Burst < 0 {
+		return fmt.Errorf("rate limit burst cannot be negative")
+	}
+
+	return nil
+}
+
+// CoalesceConfig defines how the batch requests of several providers to the same endpoint are
+// coalesced. The first request of a batch opens a window, and the calls of all requests submitted
+// within the window are sent to the endpoint as a single JSON-RPC batch request.
+type CoalesceConfig struct {
+	// Window is the amount of time a batch is held open for the requests of other providers. A
+	// value of 0 disables coalescing.
+	Window time.Duration `json:"window"`
+
+	// MaxBatchSize is the maximum number of calls in a coalesced batch. A batch is sent as soon as
+	// the next request would exceed it. A value of 0 does not limit the size of a batch.
+	MaxBatchSize int `json:"maxBatchSize"`
+}
+
+// Enabled returns true if requests should be coalesced.
+func (c CoalesceConfig) Enabled() bool {
+	return c.Window > 0
+}
+
+// ValidateBasic performs basic validation of the coalesce config.
+func (c CoalesceConfig) ValidateBasic() error {
+	if c.Window < 0 {
+		return fmt.Errorf("coalesce window cannot be negative")
+	}
+
+	if c.MaxBatchSize < 0 {
+		return fmt.Errorf("coalesce max batch size cannot be negative")
+	}
+
+	return nil
+}
+
+// BlockCacheConfig defines how the responses of an endpoint are cached by block. The endpoint's
+// latest block is tracked from the responses of its calls, and calls that were already answered at
+// the latest block are served from the cache until a newer block is observed or the block was not
+// observed for MaxAge.
+type BlockCacheConfig struct {
+	// MaxAge is the amount of time the responses read at the latest block are reused for after the
+	// block was last observed. This should not exceed the block time of the chain, since a new block
+	// can only be observed by calling the endpoint. A value of 0 disables the cache.
+	MaxAge time.Duration `json:"maxAge"`
+}
+
+// Enabled returns true if responses should be cached.
+func (c BlockCacheConfig) Enabled() bool {
+	return c.MaxAge > 0
+}
+
+// ValidateBasic performs basic validation of the block cache config.
+func (c BlockCacheConfig) ValidateBasic() error {
+	if c.MaxAge < 0 {
+		return fmt.Errorf("block cache max age cannot be negative")
+	}
+
+	return nil
+}
+
+// IsWebSocket returns true if the endpoint is a websocket endpoint.
+func (e Endpoint) IsWebSocket() bool {
+	return strings.HasPrefix(e.URL, "ws://") || strings.HasPrefix(e.URL, "wss://")
+}
+
 // Authentication holds all data necessary for an API provider to authenticate with an
 // endpoint.
 type Authentication struct {
@@ -75,24 +494,53 @@ type Authentication struct {
 
 	// APIKeyHeader is the header that will be used to set the API key.
 	APIKeyHeader string `json:"apiKeyHeader"`
+
+	// APIKeySource is a reference to the secret from which the API key is read, as an alternative
+	// to setting the API key in the config. The reference is one of env:<variable>, file:<path>,
+	// vault:<path>#<field> or awssm:<secret-id>[#<field>].
+	APIKeySource string `json:"apiKeySource"`
+
+	// RefreshInterval is the interval at which the API key is re-read from its source, so that the
+	// key can be rotated without a restart. If unset, the API key is only read when the provider
+	// is created.
+	RefreshInterval time.Duration `json:"refreshInterval"`
 }
 
 // Enabled returns true if the authentication is enabled.
 func (a Authentication) Enabled() bool {
-	return a.APIKey != "" && a.APIKeyHeader != ""
+	return (a.APIKey != "" || a.APIKeySource != "") && a.APIKeyHeader != ""
 }
 
-// ValidateBasic performs basic validation of the API authentication. Specifically, the APIKey + APIKeyHeader
-// must be set atomically.
+// ValidateBasic performs basic validation of the API authentication. Specifically, the APIKey (or
+// APIKeySource) + APIKeyHeader must be set atomically.
 func (a Authentication) ValidateBasic() error {
-	if a.APIKey != "" && a.APIKeyHeader == "" {
+	if a.APIKey != "" && a.APIKeySource != "" {
+		return fmt.Errorf("api key and api key source cannot both be set")
+	}
+
+	hasKey := a.APIKey != "" || a.APIKeySource != ""
+	if hasKey && a.APIKeyHeader == "" {
 		return fmt.Errorf("api key header cannot be empty when api key is set")
 	}
 
-	if a.APIKey == "" && a.APIKeyHeader != "" {
+	if !hasKey && a.APIKeyHeader != "" {
 		return fmt.Errorf("api key cannot be empty when api key header is set")
 	}
 
+	if a.APIKeySource != "" {
+		if scheme, location, ok := strings.Cut(a.APIKeySource, ":"); !ok || scheme == "" || location == "" {
+			return fmt.Errorf("api key source must be of the form <scheme>:<location>")
+		}
+	}
+
+	if a.RefreshInterval < 0 {
+		return fmt.Errorf("api key refresh interval cannot be negative")
+	}
+
+	if a.RefreshInterval > 0 && a.APIKeySource == "" {
+		return fmt.Errorf("api key refresh interval can only be set with an api key source")
+	}
+
 	return nil
 }
 
@@ -106,6 +554,10 @@ func (c *APIConfig) ValidateBasic() error {
 		return fmt.Errorf("api max queries must be greater than 0")
 	}
 
+	if c.MaxInFlight < 0 {
+		return fmt.Errorf("api max in flight cannot be negative")
+	}
+
 	if c.Interval <= 0 || c.Timeout <= 0 || c.ReconnectTimeout <= 0 {
 		return fmt.Errorf("provider interval, timeout and reconnect timeout must be strictly positive")
 	}
@@ -114,6 +566,16 @@ func (c *APIConfig) ValidateBasic() error {
 		return fmt.Errorf("provider name cannot be empty")
 	}
 
+	for ticker, interval := range c.TickerIntervals {
+		if len(ticker) == 0 {
+			return fmt.Errorf("ticker interval ticker cannot be empty")
+		}
+
+		if interval <= 0 {
+			return fmt.Errorf("ticker interval of %s must be strictly positive", ticker)
+		}
+	}
+
 	if c.BatchSize > 0 && c.Atomic {
 		return fmt.Errorf("batch size cannot be set for atomic providers")
 	}
@@ -132,5 +594,65 @@ func (c *APIConfig) ValidateBasic() error {
 		return fmt.Errorf("max_block_height_age cannot be negative")
 	}
 
+	if c.MaxBlockLag < 0 {
+		return fmt.Errorf("max block lag cannot be negative")
+	}
+
+	if err := c.Retry.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if err := c.Sequencer.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if err := c.Health.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if err := c.AdaptiveInterval.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if c.NewHeadsSubscription && !c.hasWebSocketEndpoint() {
+		return fmt.Errorf("new heads subscription requires a websocket endpoint")
+	}
+
+	switch c.BlockTag {
+	case "", BlockTagLatest, BlockTagSafe, BlockTagFinalized:
+	default:
+		return fmt.Errorf("block tag must be one of %s, %s or %s, got %s", BlockTagLatest, BlockTagSafe, BlockTagFinalized, c.BlockTag)
+	}
+
 	return nil
 }
+
+// GetInterval returns the interval at which the price of the given off-chain ticker is updated,
+// defaulting to the provider's interval.
+func (c *APIConfig) GetInterval(ticker string) time.Duration {
+	if interval, ok := c.TickerIntervals[ticker]; ok {
+		return interval
+	}
+
+	return c.Interval
+}
+
+// GetBlockTag returns the block tag at which on-chain state is read, defaulting to latest.
+func (c *APIConfig) GetBlockTag() string {
+	if c.BlockTag == "" {
+		return BlockTagLatest
+	}
+
+	return c.BlockTag
+}
+
+// hasWebSocketEndpoint returns true if any of the endpoints is a websocket endpoint.
+func (c *APIConfig) hasWebSocketEndpoint() bool {
+	for _, e := range c.Endpoints {
+		if e.IsWebSocket() {
+			return true
+		}
+	}
+
+	return false
+}
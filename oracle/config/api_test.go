@@ -59,6 +59,53 @@ func TestAPIConfig(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "good config with ticker intervals",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				TickerIntervals:  map[string]time.Duration{"BTC/USD": 250 * time.Millisecond, "SDAI/DAI": time.Hour},
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with a non-positive ticker interval",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				TickerIntervals:  map[string]time.Duration{"BTC/USD": 0},
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with an invalid adaptive interval",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				AdaptiveInterval: config.AdaptiveIntervalConfig{
+					Enabled:     true,
+					MinInterval: time.Second,
+					MaxInterval: time.Millisecond,
+					Window:      10,
+				},
+			},
+			expectedErr: true,
+		},
 		{
 			name: "bad config with no max queries",
 			config: config.APIConfig{
@@ -135,6 +182,34 @@ func TestAPIConfig(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "good config with max in flight",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				MaxInFlight:      10,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative max in flight",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				MaxInFlight:      -1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+			},
+			expectedErr: true,
+		},
 		{
 			name: "good config with max_block_height_age",
 			config: config.APIConfig{
@@ -246,6 +321,463 @@ func TestAPIConfig(t *testing.T) {
 				BatchSize: 1,
 			},
 		},
+		{
+			name: "good config with api key source",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{
+					{
+						URL: "http://test.com",
+						Authentication: config.Authentication{
+							APIKeySource:    "env:TEST_API_KEY",
+							APIKeyHeader:    "X-API-KEY",
+							RefreshInterval: time.Minute,
+						},
+					},
+				},
+				BatchSize: 1,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with api key and api key source",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{
+					{
+						URL: "http://test.com",
+						Authentication: config.Authentication{
+							APIKey:       "test",
+							APIKeySource: "env:TEST_API_KEY",
+							APIKeyHeader: "X-API-KEY",
+						},
+					},
+				},
+				BatchSize: 1,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with malformed api key source",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{
+					{
+						URL: "http://test.com",
+						Authentication: config.Authentication{
+							APIKeySource: "TEST_API_KEY",
+							APIKeyHeader: "X-API-KEY",
+						},
+					},
+				},
+				BatchSize: 1,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with refresh interval without api key source",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{
+					{
+						URL: "http://test.com",
+						Authentication: config.Authentication{
+							APIKey:          "test",
+							APIKeyHeader:    "X-API-KEY",
+							RefreshInterval: time.Minute,
+						},
+					},
+				},
+				BatchSize: 1,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with retries",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Retry: config.RetryConfig{
+					MaxAttempts:      3,
+					InitialBackoff:   10 * time.Millisecond,
+					MaxBackoff:       100 * time.Millisecond,
+					RateLimitBackoff: 50 * time.Millisecond,
+					Jitter:           0.5,
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative retry attempts",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Retry: config.RetryConfig{
+					MaxAttempts: -1,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with retry max backoff less than initial backoff",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Retry: config.RetryConfig{
+					MaxAttempts:    3,
+					InitialBackoff: time.Second,
+					MaxBackoff:     time.Millisecond,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with retry max backoff less than rate limit backoff",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Retry: config.RetryConfig{
+					MaxAttempts:      3,
+					InitialBackoff:   time.Millisecond,
+					MaxBackoff:       time.Second,
+					RateLimitBackoff: time.Minute,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with retry jitter out of range",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Retry: config.RetryConfig{
+					MaxAttempts: 3,
+					Jitter:      1.5,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with endpoint rate limit",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{{
+					URL:       "http://test.com",
+					RateLimit: config.RateLimitConfig{RequestsPerSecond: 10, Burst: 5},
+				}},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative endpoint rate limit",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{{
+					URL:       "http://test.com",
+					RateLimit: config.RateLimitConfig{RequestsPerSecond: -1},
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with endpoint coalescing",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{{
+					URL:      "http://test.com",
+					Coalesce: config.CoalesceConfig{Window: 20 * time.Millisecond, MaxBatchSize: 100},
+				}},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative endpoint coalesce window",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{{
+					URL:      "http://test.com",
+					Coalesce: config.CoalesceConfig{Window: -time.Millisecond},
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with endpoint block cache",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{{
+					URL:        "http://test.com",
+					BlockCache: config.BlockCacheConfig{MaxAge: 12 * time.Second},
+				}},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative endpoint block cache max age",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints: []config.Endpoint{{
+					URL:        "http://test.com",
+					BlockCache: config.BlockCacheConfig{MaxAge: -time.Second},
+				}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with endpoint max batch length",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com", MaxBatchLength: 50}},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative endpoint max batch length",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com", MaxBatchLength: -1}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative max block lag",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				MaxBlockLag:      -time.Second,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with sequencer check",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Sequencer: config.SequencerConfig{
+					UptimeFeed:   "0xFdB631F5EE196F0ed6FAa767959853A9F217697D",
+					GracePeriod:  time.Hour,
+					CheckSyncing: true,
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with invalid sequencer uptime feed",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Sequencer: config.SequencerConfig{
+					UptimeFeed: "0xinvalid",
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with sequencer grace period without uptime feed",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Sequencer: config.SequencerConfig{
+					GracePeriod:  time.Hour,
+					CheckSyncing: true,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with health policy",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Health: config.HealthConfig{
+					FailureThreshold: 3,
+					Cooldown:         time.Minute,
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with health policy without cooldown",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				Health: config.HealthConfig{
+					FailureThreshold: 3,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with finalized block tag",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				BlockTag:         config.BlockTagFinalized,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with unknown block tag",
+			config: config.APIConfig{
+				Enabled:          true,
+				Timeout:          time.Second,
+				Interval:         time.Second,
+				ReconnectTimeout: time.Second,
+				MaxQueries:       1,
+				Name:             "test",
+				Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				BlockTag:         "pending",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with new heads subscription",
+			config: config.APIConfig{
+				Enabled:              true,
+				Timeout:              time.Second,
+				Interval:             time.Second,
+				ReconnectTimeout:     time.Second,
+				MaxQueries:           1,
+				Name:                 "test",
+				Endpoints:            []config.Endpoint{{URL: "http://test.com"}, {URL: "wss://test.com"}},
+				NewHeadsSubscription: true,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with new heads subscription and no websocket endpoint",
+			config: config.APIConfig{
+				Enabled:              true,
+				Timeout:              time.Second,
+				Interval:             time.Second,
+				ReconnectTimeout:     time.Second,
+				MaxQueries:           1,
+				Name:                 "test",
+				Endpoints:            []config.Endpoint{{URL: "http://test.com"}},
+				NewHeadsSubscription: true,
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CircuitBreakerConfig is the config for halting the publication of a market's price when its
+// aggregated price moves too far too quickly. A halted market is resumed once the move persists
+// for the configured number of aggregations, or once an operator clears its breaker via the admin
+// API of the oracle server, if enabled.
+type CircuitBreakerConfig struct {
+	// Enabled indicates whether the circuit breaker is enabled.
+	Enabled bool `json:"enabled"`
+
+	// Default is the policy of markets that do not have a market specific policy.
+	Default CircuitBreakerPolicy `json:"default"`
+
+	// Markets maps a market's ticker (e.g. BTC/USD) to the policy of that market. Tickers are
+	// matched case-insensitively.
+	Markets map[string]CircuitBreakerPolicy `json:"markets"`
+}
+
+// CircuitBreakerPolicy is the circuit breaker policy of a single market.
+type CircuitBreakerPolicy struct {
+	// MaxMove is the maximum fraction by which the market's aggregated price can move within the
+	// window before the market is halted, e.g. 0.2 halts the market if its price moves by more than
+	// 20%. If unset, the market is never halted.
+	MaxMove float64 `json:"maxMove"`
+
+	// Window is the amount of time over which moves are measured, i.e. the price is compared to
+	// every price published within the window.
+	Window time.Duration `json:"window"`
+
+	// ResumeTicks is the number of aggregations after the one that halted the market for which the
+	// move must persist before the market is resumed at the new price. If unset, a halted market is
+	// only resumed by an operator, or once its price returns to within the max move.
+	ResumeTicks int `json:"resumeTicks"`
+
+	// HoldLastPrice publishes the last price before the move while the market is halted, flagged as
+	// halted, instead of not publishing a price for the market.
+	HoldLastPrice bool `json:"holdLastPrice"`
+}
+
+// Enabled returns true if the market of the policy can be halted.
+func (p CircuitBreakerPolicy) Enabled() bool {
+	return p.MaxMove > 0
+}
+
+// PolicyForMarket returns the circuit breaker policy of the given market ticker.
+func (c *CircuitBreakerConfig) PolicyForMarket(ticker string) CircuitBreakerPolicy {
+	if policy, ok := c.Markets[ticker]; ok {
+		return policy
+	}
+
+	// Keys are lower-cased when the config is read via viper.
+	if policy, ok := c.Markets[strings.ToLower(ticker)]; ok {
+		return policy
+	}
+
+	return c.Default
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *CircuitBreakerConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if err := c.Default.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid default policy: %w", err)
+	}
+
+	for ticker, policy := range c.Markets {
+		if err := policy.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid policy for market %s: %w", ticker, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBasic performs basic validation of the policy.
+func (p *CircuitBreakerPolicy) ValidateBasic() error {
+	if p.MaxMove < 0 {
+		return fmt.Errorf("max move cannot be negative")
+	}
+
+	if p.ResumeTicks < 0 {
+		return fmt.Errorf("resume ticks cannot be negative")
+	}
+
+	if p.MaxMove > 0 && p.Window <= 0 {
+		return fmt.Errorf("window must be greater than 0")
+	}
+
+	return nil
+}
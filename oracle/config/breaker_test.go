@@ -0,0 +1,98 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestCircuitBreakerConfig(t *testing.T) {
+	policy := config.CircuitBreakerPolicy{
+		MaxMove:     0.2,
+		Window:      time.Minute,
+		ResumeTicks: 5,
+	}
+
+	testCases := []struct {
+		name        string
+		config      config.CircuitBreakerConfig
+		expectedErr bool
+	}{
+		{
+			name:        "good disabled config",
+			config:      config.CircuitBreakerConfig{Default: config.CircuitBreakerPolicy{MaxMove: -1}},
+			expectedErr: false,
+		},
+		{
+			name: "good config",
+			config: config.CircuitBreakerConfig{
+				Enabled: true,
+				Default: policy,
+				Markets: map[string]config.CircuitBreakerPolicy{
+					"PEPE/USD": {MaxMove: 0.5, Window: 10 * time.Second, HoldLastPrice: true},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "good config with markets that are never halted",
+			config: config.CircuitBreakerConfig{
+				Enabled: true,
+				Markets: map[string]config.CircuitBreakerPolicy{"BTC/USD": policy},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative max move",
+			config: config.CircuitBreakerConfig{
+				Enabled: true,
+				Default: config.CircuitBreakerPolicy{MaxMove: -0.1, Window: time.Minute},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no window",
+			config: config.CircuitBreakerConfig{
+				Enabled: true,
+				Markets: map[string]config.CircuitBreakerPolicy{"BTC/USD": {MaxMove: 0.2}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative resume ticks",
+			config: config.CircuitBreakerConfig{
+				Enabled: true,
+				Default: config.CircuitBreakerPolicy{MaxMove: 0.2, Window: time.Minute, ResumeTicks: -1},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerConfigPolicyForMarket(t *testing.T) {
+	cfg := config.CircuitBreakerConfig{
+		Default: config.CircuitBreakerPolicy{MaxMove: 0.2},
+		Markets: map[string]config.CircuitBreakerPolicy{
+			"BTC/USD":  {MaxMove: 0.1},
+			"pepe/usd": {MaxMove: 0.5},
+		},
+	}
+
+	require.Equal(t, 0.1, cfg.PolicyForMarket("BTC/USD").MaxMove)
+	require.Equal(t, 0.5, cfg.PolicyForMarket("PEPE/USD").MaxMove)
+	require.Equal(t, 0.2, cfg.PolicyForMarket("ETH/USD").MaxMove)
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// CurrencyPairsConfig is the config for restricting the markets the oracle prices to the currency
+// pairs tracked by the chain's x/oracle module. The currency pairs are queried from the node that
+// serves the market map, so that pairs added by governance are priced (and removed pairs are no
+// longer priced) without restarting the oracle.
+type CurrencyPairsConfig struct {
+	// Enabled indicates whether the markets are restricted to the chain's currency pairs.
+	Enabled bool `json:"enabled"`
+
+	// Interval is the interval at which the chain's currency pairs are queried.
+	Interval time.Duration `json:"interval"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *CurrencyPairsConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Interval <= 0 {
+		return fmt.Errorf("currency pairs interval must be greater than 0")
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestCurrencyPairsConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      config.CurrencyPairsConfig
+		expectedErr bool
+	}{
+		{
+			name: "good config",
+			config: config.CurrencyPairsConfig{
+				Enabled:  true,
+				Interval: time.Minute,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with no interval",
+			config: config.CurrencyPairsConfig{
+				Enabled: true,
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "no currency pairs enabled",
+			config:      config.CurrencyPairsConfig{Interval: -1},
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+)
+
+const (
+	// DefaultMaxDerivationHops is the default maximum number of index prices that are chained to
+	// derive the price of a derived market without a configured path.
+	DefaultMaxDerivationHops = 2
+
+	// MaxDerivedDecimals is the maximum number of decimals of a derived market. This matches the
+	// maximum number of decimals of a market in the market map.
+	MaxDerivedDecimals = 36
+)
+
+// DerivedMarketConfig is the config for a market whose price is derived from the index prices of
+// other markets, e.g. ATOM/ETH from ATOM/USD and ETH/USD, or USD/JPY by inverting JPY/USD. Derived
+// markets are only used if the market does not have an index price of its own in the same
+// aggregation, so they can also serve as a fallback for markets in the market map.
+type DerivedMarketConfig struct {
+	// Decimals is the number of decimals the derived price is scaled by.
+	Decimals uint64 `json:"decimals"`
+
+	// Path is the conversion path used to derive the price. The index price of each step is
+	// multiplied in order, starting from the base of the derived market and ending at its quote.
+	// If unset, the shortest path over the available index prices is used.
+	Path []DerivationStep `json:"path"`
+
+	// MaxHops is the maximum number of index prices that are chained when no path is configured.
+	// If unset, DefaultMaxDerivationHops is used.
+	MaxHops int `json:"maxHops"`
+
+	// MinProviderCount is the minimum number of providers each index price in the path must be
+	// aggregated from. The provider count of a derived price is the minimum provider count of its
+	// path, as a derived price is only as reliable as its weakest step. If unset, any index price
+	// is used.
+	MinProviderCount int `json:"minProviderCount"`
+}
+
+// DerivationStep is a single conversion of a derivation path.
+type DerivationStep struct {
+	// Ticker is the market whose index price is used, e.g. ETH/USD.
+	Ticker string `json:"ticker"`
+
+	// Invert uses the inverse of the index price, e.g. USD/ETH for a ticker of ETH/USD.
+	Invert bool `json:"invert"`
+}
+
+// CurrencyPair returns the currency pair the step converts, accounting for the step's inversion.
+func (s DerivationStep) CurrencyPair() (pkgtypes.CurrencyPair, error) {
+	cp, err := pkgtypes.CurrencyPairFromString(s.Ticker)
+	if err != nil {
+		return pkgtypes.CurrencyPair{}, fmt.Errorf("invalid derivation step ticker %s: %w", s.Ticker, err)
+	}
+
+	if s.Invert {
+		return cp.Invert(), nil
+	}
+
+	return cp, nil
+}
+
+// GetMaxHops returns the maximum number of index prices that are chained when no path is
+// configured.
+func (c *DerivedMarketConfig) GetMaxHops() int {
+	if c.MaxHops == 0 {
+		return DefaultMaxDerivationHops
+	}
+
+	return c.MaxHops
+}
+
+// ValidateBasic performs basic validation of the derived market config of the given ticker. The
+// configured path must convert the base of the ticker to its quote.
+func (c *DerivedMarketConfig) ValidateBasic(ticker string) error {
+	cp, err := pkgtypes.CurrencyPairFromString(ticker)
+	if err != nil {
+		return fmt.Errorf("invalid derived market ticker %s: %w", ticker, err)
+	}
+
+	if c.Decimals == 0 || c.Decimals > MaxDerivedDecimals {
+		return fmt.Errorf("decimals must be in [1, %d]", MaxDerivedDecimals)
+	}
+
+	if c.MaxHops < 0 {
+		return fmt.Errorf("max hops cannot be negative")
+	}
+
+	if c.MinProviderCount < 0 {
+		return fmt.Errorf("min provider count cannot be negative")
+	}
+
+	if len(c.Path) == 0 {
+		return nil
+	}
+
+	asset := cp.Base
+	for i, step := range c.Path {
+		stepCP, err := step.CurrencyPair()
+		if err != nil {
+			return err
+		}
+
+		if stepCP.Base != asset {
+			return fmt.Errorf("step %d of path converts %s, expected %s", i, stepCP.Base, asset)
+		}
+		asset = stepCP.Quote
+	}
+
+	if asset != cp.Quote {
+		return fmt.Errorf("path converts %s to %s, expected %s", cp.Base, asset, cp.Quote)
+	}
+
+	return nil
+}
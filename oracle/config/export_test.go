@@ -0,0 +1,138 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestExportConfig(t *testing.T) {
+	influx := config.InfluxDBExportConfig{
+		Enabled:     true,
+		URL:         "http://localhost:8086",
+		Bucket:      "prices",
+		Measurement: "connect_price",
+		Timeout:     time.Second,
+	}
+	timescale := config.TimescaleDBExportConfig{
+		Enabled:   true,
+		DSNSource: "env:CONNECT_TIMESCALEDB_DSN",
+		Table:     "public.connect_prices",
+		Timeout:   time.Second,
+	}
+	remoteWrite := config.RemoteWriteExportConfig{
+		Enabled:    true,
+		URL:        "https://prometheus.example.com/api/v1/write",
+		MetricName: "connect_price",
+		Timeout:    time.Second,
+	}
+
+	testCases := []struct {
+		name        string
+		config      func() config.ExportConfig
+		expectedErr bool
+	}{
+		{
+			name: "good config with all backends",
+			config: func() config.ExportConfig {
+				return config.ExportConfig{InfluxDB: influx, TimescaleDB: timescale, RemoteWrite: remoteWrite}
+			},
+			expectedErr: false,
+		},
+		{
+			name: "no backends enabled",
+			config: func() config.ExportConfig {
+				return config.ExportConfig{}
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad influxdb url",
+			config: func() config.ExportConfig {
+				cfg := influx
+				cfg.URL = "localhost:8086"
+				return config.ExportConfig{InfluxDB: cfg}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad influxdb config with no bucket",
+			config: func() config.ExportConfig {
+				cfg := influx
+				cfg.Bucket = ""
+				return config.ExportConfig{InfluxDB: cfg}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad influxdb authentication",
+			config: func() config.ExportConfig {
+				cfg := influx
+				cfg.Authentication = config.Authentication{APIKey: "Token secret"}
+				return config.ExportConfig{InfluxDB: cfg}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad timescaledb dsn source",
+			config: func() config.ExportConfig {
+				cfg := timescale
+				cfg.DSNSource = "host=localhost user=connect"
+				return config.ExportConfig{TimescaleDB: cfg}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad timescaledb table",
+			config: func() config.ExportConfig {
+				cfg := timescale
+				cfg.Table = "prices; DROP TABLE prices"
+				return config.ExportConfig{TimescaleDB: cfg}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad timescaledb config with no timeout",
+			config: func() config.ExportConfig {
+				cfg := timescale
+				cfg.Timeout = 0
+				return config.ExportConfig{TimescaleDB: cfg}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad remote write config with no metric name",
+			config: func() config.ExportConfig {
+				cfg := remoteWrite
+				cfg.MetricName = ""
+				return config.ExportConfig{RemoteWrite: cfg}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "disabled backends are not validated",
+			config: func() config.ExportConfig {
+				return config.ExportConfig{
+					InfluxDB:    config.InfluxDBExportConfig{URL: "invalid"},
+					TimescaleDB: config.TimescaleDBExportConfig{Table: "invalid table"},
+				}
+			},
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.config()
+			err := cfg.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
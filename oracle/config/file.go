@@ -0,0 +1,155 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FormatJSON is the format of config files with a .json extension.
+	FormatJSON = "json"
+	// FormatTOML is the format of config files with a .toml extension.
+	FormatTOML = "toml"
+	// FormatYAML is the format of config files with a .yaml or .yml extension.
+	FormatYAML = "yaml"
+)
+
+// envVarRegex matches the environment variable references interpolated into config files,
+// ${NAME} and ${NAME:-default}, as well as $$, which escapes a literal $.
+var envVarRegex = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// FileFormat returns the format of a config file based on its extension.
+func FileFormat(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return FormatJSON, nil
+	case ".toml":
+		return FormatTOML, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unsupported config file extension %q: expected .json, .toml, .yaml or .yml", ext)
+	}
+}
+
+// ReadFile reads the config file at the given path and returns its JSON encoding, so that configs
+// can be written in any of the supported formats (selected by the file's extension) and read by
+// the JSON config readers.
+//
+// Environment variables referenced by string values of the config as ${NAME} are replaced by their
+// values, so that configs can be committed without secrets, e.g.
+//
+//	url = "https://eth-mainnet.g.alchemy.com/v2/${ALCHEMY_KEY}"
+//
+// ${NAME:-default} is replaced by default if NAME is unset, and $$ is replaced by a literal $. An
+// error listing every referenced variable that is unset and has no default is returned.
+func ReadFile(path string) ([]byte, error) {
+	if _, err := FileFormat(path); err != nil {
+		return nil, err
+	}
+
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseFile(path, bz)
+}
+
+// ParseFile is ReadFile for the contents of a config file that has already been read, e.g. from a
+// remote source. The format is selected by the extension of the given path.
+func ParseFile(path string, bz []byte) ([]byte, error) {
+	format, err := FileFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := decodeFile(bz, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s config file %s: %w", format, path, err)
+	}
+
+	missing := make(map[string]struct{})
+	doc = interpolate(doc, os.LookupEnv, missing)
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("config file %s references unset environment variables: %s", path, strings.Join(names, ", "))
+	}
+
+	return json.Marshal(doc)
+}
+
+// decodeFile decodes a config file of the given format into its generic representation.
+func decodeFile(bz []byte, format string) (any, error) {
+	var doc any
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(bz))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		if err := toml.Unmarshal(bz, &doc); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(bz, &doc); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format %s", format)
+	}
+
+	return doc, nil
+}
+
+// interpolate replaces the environment variable references in the string values of a decoded
+// config, adding the names of unset variables without a default to missing.
+func interpolate(value any, lookup func(string) (string, bool), missing map[string]struct{}) any {
+	switch value := value.(type) {
+	case string:
+		return envVarRegex.ReplaceAllStringFunc(value, func(ref string) string {
+			if ref == "$$" {
+				return "$"
+			}
+
+			match := envVarRegex.FindStringSubmatch(ref)
+			if v, ok := lookup(match[1]); ok {
+				return v
+			}
+			if strings.Contains(ref, ":-") {
+				return match[2]
+			}
+
+			missing[match[1]] = struct{}{}
+			return ref
+		})
+	case map[string]any:
+		for k, v := range value {
+			value[k] = interpolate(v, lookup, missing)
+		}
+		return value
+	case []any:
+		for i, v := range value {
+			value[i] = interpolate(v, lookup, missing)
+		}
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,117 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestReadFile(t *testing.T) {
+	t.Setenv("CONNECT_TEST_API_KEY", "secret")
+
+	expected := `{"enabled":true,"endpoints":[{"url":"https://eth-mainnet.g.alchemy.com/v2/secret"}],"maxQueries":1,"name":"provider-$","timeout":"250ms"}`
+
+	testCases := []struct {
+		name        string
+		file        string
+		contents    string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name: "json",
+			file: "config.json",
+			contents: `{
+				"name": "provider-$$",
+				"enabled": true,
+				"timeout": "250ms",
+				"maxQueries": 1,
+				"endpoints": [{"url": "https://eth-mainnet.g.alchemy.com/v2/${CONNECT_TEST_API_KEY}"}]
+			}`,
+			expected: expected,
+		},
+		{
+			name: "toml",
+			file: "config.toml",
+			contents: `
+name = "provider-$$"
+enabled = true
+timeout = "250ms"
+maxQueries = 1
+
+[[endpoints]]
+url = "https://eth-mainnet.g.alchemy.com/v2/${CONNECT_TEST_API_KEY}"
+`,
+			expected: expected,
+		},
+		{
+			name: "yaml",
+			file: "config.yaml",
+			contents: `
+name: provider-$$
+enabled: true
+timeout: 250ms
+maxQueries: 1
+endpoints:
+  - url: https://eth-mainnet.g.alchemy.com/v2/${CONNECT_TEST_API_KEY}
+`,
+			expected: expected,
+		},
+		{
+			name:     "yml extension",
+			file:     "config.YML",
+			contents: `name: provider`,
+			expected: `{"name":"provider"}`,
+		},
+		{
+			name:     "defaults are used for unset variables",
+			file:     "config.json",
+			contents: `{"url": "${CONNECT_TEST_UNSET:-https://example.com}", "key": "${CONNECT_TEST_UNSET:-}", "set": "${CONNECT_TEST_API_KEY:-default}"}`,
+			expected: `{"key":"","set":"secret","url":"https://example.com"}`,
+		},
+		{
+			name:     "only string values are interpolated",
+			file:     "config.json",
+			contents: `{"${CONNECT_TEST_API_KEY}": 1, "price": 1.000000000000000001}`,
+			expected: `{"${CONNECT_TEST_API_KEY}":1,"price":1.000000000000000001}`,
+		},
+		{
+			name:        "every unset variable is reported",
+			file:        "config.json",
+			contents:    `{"a": "${CONNECT_TEST_UNSET_B}", "b": ["${CONNECT_TEST_UNSET_A}", "${CONNECT_TEST_UNSET_B}"]}`,
+			expectedErr: "references unset environment variables: CONNECT_TEST_UNSET_A, CONNECT_TEST_UNSET_B",
+		},
+		{
+			name:        "unsupported extension",
+			file:        "config.ini",
+			contents:    `name = provider`,
+			expectedErr: `unsupported config file extension ".ini"`,
+		},
+		{
+			name:        "invalid toml",
+			file:        "config.toml",
+			contents:    `name = `,
+			expectedErr: "failed to parse toml config file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.file)
+			require.NoError(t, os.WriteFile(path, []byte(tc.contents), 0o600))
+
+			bz, err := config.ReadFile(path)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(bz))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// HistoryConfig is the config for persisting the history of the oracle's aggregated prices to an
+// embedded store. The history can be queried through the oracle server and the connect CLI.
+type HistoryConfig struct {
+	// Enabled indicates whether the aggregated prices are persisted.
+	Enabled bool `json:"enabled"`
+
+	// Path is the path of the store's database file. It is created if it does not exist.
+	Path string `json:"path"`
+
+	// Retention is how long prices are kept for. Older prices are periodically pruned. If zero,
+	// prices are kept forever.
+	Retention time.Duration `json:"retention"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *HistoryConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Path) == 0 {
+		return fmt.Errorf("must supply a non-empty path if history is enabled")
+	}
+
+	if c.Retention < 0 {
+		return fmt.Errorf("history retention cannot be negative")
+	}
+
+	return nil
+}
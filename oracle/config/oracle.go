@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
@@ -26,6 +27,52 @@ type OracleConfig struct {
 	// Metrics is the metrics configurations for the oracle.
 	Metrics MetricsConfig `json:"metrics"`
 
+	// Tracing is the config for exporting traces of the oracle's price pipeline.
+	Tracing TracingConfig `json:"tracing"`
+
+	// Signing is the config for signing the price reports served by the oracle.
+	Signing SigningConfig `json:"signing"`
+
+	// Push is the config for the websocket push API of the oracle server.
+	Push PushConfig `json:"push"`
+
+	// History is the config for persisting the history of the oracle's aggregated prices.
+	History HistoryConfig `json:"history"`
+
+	// Export is the config for exporting the oracle's aggregated prices to external time-series
+	// databases.
+	Export ExportConfig `json:"export"`
+
+	// Payload is the config for formatting the oracle's aggregated prices into the payloads
+	// expected by the chains the oracle serves.
+	Payload PayloadConfig `json:"payload"`
+
+	// Submit is the config for submitting the oracle's prices to a chain that is updated by
+	// oracle transactions.
+	Submit SubmitConfig `json:"submit"`
+
+	// Alert is the config for alerting on anomalies of the oracle.
+	Alert AlertConfig `json:"alert"`
+
+	// CurrencyPairs is the config for restricting the markets the oracle prices to the currency
+	// pairs tracked by the chain.
+	CurrencyPairs CurrencyPairsConfig `json:"currencyPairs"`
+
+	// Aggregation is the config for how the oracle aggregates provider prices into a single
+	// price per market.
+	Aggregation AggregationConfig `json:"aggregation"`
+
+	// CircuitBreaker is the config for halting the publication of a market's price on extreme
+	// moves of its aggregated price.
+	CircuitBreaker CircuitBreakerConfig `json:"circuitBreaker"`
+
+	// Admin is the config for the admin API of the oracle server, which controls the oracle at
+	// runtime.
+	Admin AdminConfig `json:"admin"`
+
+	// Server is the config for securing the oracle's listener with TLS and bearer tokens.
+	Server ListenerConfig `json:"server"`
+
 	// Host is the host that the oracle will listen on.
 	Host string `json:"host"`
 
@@ -49,6 +96,14 @@ func (c *OracleConfig) ValidateBasic() error {
 		}
 	}
 
+	if err := c.Aggregation.ValidateBasic(); err != nil {
+		return fmt.Errorf("aggregation config is not formatted correctly: %w", err)
+	}
+
+	if err := c.CircuitBreaker.ValidateBasic(); err != nil {
+		return fmt.Errorf("circuit breaker config is not formatted correctly: %w", err)
+	}
+
 	if len(c.Host) == 0 {
 		return fmt.Errorf("oracle host cannot be empty")
 	}
@@ -57,16 +112,79 @@ func (c *OracleConfig) ValidateBasic() error {
 		return fmt.Errorf("oracle port cannot be empty")
 	}
 
+	if err := c.Tracing.ValidateBasic(); err != nil {
+		return fmt.Errorf("tracing config is not formatted correctly: %w", err)
+	}
+
+	if err := c.Signing.ValidateBasic(); err != nil {
+		return fmt.Errorf("signing config is not formatted correctly: %w", err)
+	}
+
+	if err := c.Push.ValidateBasic(); err != nil {
+		return fmt.Errorf("push config is not formatted correctly: %w", err)
+	}
+
+	if err := c.Admin.ValidateBasic(); err != nil {
+		return fmt.Errorf("admin config is not formatted correctly: %w", err)
+	}
+
+	if c.Admin.Enabled && c.Admin.TokenSource == "" && !c.Server.Auth.HasRole(RoleAdmin) {
+		return fmt.Errorf("admin config is not formatted correctly: an admin token source or a server token with the admin role is required")
+	}
+
+	if err := c.Server.ValidateBasic(); err != nil {
+		return fmt.Errorf("server config is not formatted correctly: %w", err)
+	}
+
+	if err := c.History.ValidateBasic(); err != nil {
+		return fmt.Errorf("history config is not formatted correctly: %w", err)
+	}
+
+	if err := c.Export.ValidateBasic(); err != nil {
+		return fmt.Errorf("export config is not formatted correctly: %w", err)
+	}
+
+	if err := c.Payload.ValidateBasic(); err != nil {
+		return fmt.Errorf("payload config is not formatted correctly: %w", err)
+	}
+
+	if err := c.Submit.ValidateBasic(); err != nil {
+		return fmt.Errorf("submit config is not formatted correctly: %w", err)
+	}
+
+	if c.Submit.Enabled {
+		target, ok := c.Payload.Target(c.Submit.Target)
+		if !ok {
+			return fmt.Errorf("submit target %s is not a payload target", c.Submit.Target)
+		}
+
+		if target.Encoder != PayloadEncoderEVM {
+			return fmt.Errorf("submit target %s must use the %s encoder", c.Submit.Target, PayloadEncoderEVM)
+		}
+	}
+
+	if err := c.Alert.ValidateBasic(); err != nil {
+		return fmt.Errorf("alert config is not formatted correctly: %w", err)
+	}
+
+	if err := c.CurrencyPairs.ValidateBasic(); err != nil {
+		return fmt.Errorf("currency pairs config is not formatted correctly: %w", err)
+	}
+
 	return c.Metrics.ValidateBasic()
 }
 
-// ReadOracleConfigFromFile reads a config from a file and returns the config.
+// ReadOracleConfigFromFile reads a config from a json, toml or yaml file and returns the config.
+// Environment variables referenced by the file are interpolated, see ReadFile.
 func ReadOracleConfigFromFile(path string) (OracleConfig, error) {
 	// Read in config file.
-	viper.SetConfigFile(path)
-	viper.SetConfigType("json")
+	bz, err := ReadFile(path)
+	if err != nil {
+		return OracleConfig{}, err
+	}
 
-	if err := viper.ReadInConfig(); err != nil {
+	viper.SetConfigType("json")
+	if err := viper.ReadConfig(bytes.NewReader(bz)); err != nil {
 		return OracleConfig{}, err
 	}
 
@@ -186,6 +186,40 @@ func TestOracleConfig(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "good config with an admin api authenticated by a server token",
+			config: config.OracleConfig{
+				UpdateInterval: time.Second,
+				MaxPriceAge:    time.Minute,
+				Admin:          config.AdminConfig{Enabled: true},
+				Server: config.ListenerConfig{
+					Auth: config.AuthConfig{
+						Enabled: true,
+						Tokens:  []config.TokenConfig{{Source: "env:CONNECT_ADMIN_TOKEN", Role: config.RoleAdmin}},
+					},
+				},
+				Host: "localhost",
+				Port: "8080",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with an admin api and no admin token",
+			config: config.OracleConfig{
+				UpdateInterval: time.Second,
+				MaxPriceAge:    time.Minute,
+				Admin:          config.AdminConfig{Enabled: true},
+				Server: config.ListenerConfig{
+					Auth: config.AuthConfig{
+						Enabled: true,
+						Tokens:  []config.TokenConfig{{Source: "env:CONNECT_READ_TOKEN", Role: config.RoleRead}},
+					},
+				},
+				Host: "localhost",
+				Port: "8080",
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
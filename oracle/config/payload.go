@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+const (
+	// PayloadEncoderConnect encodes prices as a Connect oracle vote extension, i.e. the payload
+	// that validators of a chain running the x/oracle module extend their votes with.
+	PayloadEncoderConnect = "connect"
+	// PayloadEncoderJSON encodes prices as a JSON document.
+	PayloadEncoderJSON = "json"
+	// PayloadEncoderEVM ABI-encodes prices, such that they can be passed to an EVM contract.
+	PayloadEncoderEVM = "evm"
+
+	// PayloadCompressionNone leaves the payload uncompressed.
+	PayloadCompressionNone = "none"
+	// PayloadCompressionZLib compresses the payload with zlib.
+	PayloadCompressionZLib = "zlib"
+	// PayloadCompressionZStd compresses the payload with zstd.
+	PayloadCompressionZStd = "zstd"
+)
+
+// PayloadConfig is the config for formatting the oracle's aggregated prices into the payloads
+// expected by the chains the oracle serves, e.g. a vote extension or the calldata of an oracle
+// transaction. Each target chain is configured independently, and its payload is served by the
+// oracle server.
+type PayloadConfig struct {
+	// Targets are the target chains, indexed by a name that identifies the target to clients.
+	Targets map[string]PayloadTargetConfig `json:"targets"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *PayloadConfig) ValidateBasic() error {
+	for name, target := range c.Targets {
+		if len(name) == 0 {
+			return fmt.Errorf("payload target name cannot be empty")
+		}
+
+		if err := target.ValidateBasic(); err != nil {
+			return fmt.Errorf("payload target %s is not formatted correctly: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Target returns the config of the target with the given name. The names of the targets may have
+// been lower-cased when the config was read, in which case the lower-cased name is looked up.
+func (c *PayloadConfig) Target(name string) (PayloadTargetConfig, bool) {
+	if target, ok := c.Targets[name]; ok {
+		return target, true
+	}
+
+	target, ok := c.Targets[strings.ToLower(name)]
+	return target, ok
+}
+
+// PayloadTargetConfig is the config of the payload of a single target chain.
+type PayloadTargetConfig struct {
+	// Encoder is the name of the encoder that formats the payload, e.g. connect, json or evm.
+	// Additional encoders can be registered with the payload package.
+	Encoder string `json:"encoder"`
+
+	// Compression is the compression of the payload of the connect encoder, i.e. none, zlib or
+	// zstd. This must match the vote extension codec of the chain. If unset, zlib is used.
+	Compression string `json:"compression"`
+
+	// Decimals is the number of decimals prices are scaled to, e.g. 8 for a chain that expects
+	// prices with an exponent of -8. If zero, each price keeps the decimals of its market.
+	Decimals uint64 `json:"decimals"`
+
+	// PairIDs are the IDs the chain assigns to each currency pair, e.g. {"BTC/USD": 0}. Prices
+	// of currency pairs without an ID are omitted from the payload. If empty, the ID of each
+	// currency pair is derived from the hash of the currency pair, as done by the hash currency
+	// pair strategy.
+	PairIDs map[string]uint64 `json:"pairIDs"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *PayloadTargetConfig) ValidateBasic() error {
+	if len(c.Encoder) == 0 {
+		return fmt.Errorf("encoder cannot be empty")
+	}
+
+	switch c.Compression {
+	case "", PayloadCompressionNone, PayloadCompressionZLib, PayloadCompressionZStd:
+	default:
+		return fmt.Errorf(
+			"invalid compression %s; expected %s, %s or %s",
+			c.Compression, PayloadCompressionNone, PayloadCompressionZLib, PayloadCompressionZStd,
+		)
+	}
+
+	if c.Decimals > mmtypes.DefaultMaxDecimals {
+		return fmt.Errorf("decimals must be at most %d; got %d", mmtypes.DefaultMaxDecimals, c.Decimals)
+	}
+
+	ids := make(map[uint64]string, len(c.PairIDs))
+	for pair, id := range c.PairIDs {
+		if _, err := pkgtypes.CurrencyPairFromString(pair); err != nil {
+			return fmt.Errorf("invalid currency pair %s: %w", pair, err)
+		}
+
+		if other, ok := ids[id]; ok {
+			return fmt.Errorf("currency pairs %s and %s have the same id %d", other, pair, id)
+		}
+		ids[id] = pair
+	}
+
+	return nil
+}
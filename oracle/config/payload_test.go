@@ -0,0 +1,108 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestPayloadConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      config.PayloadConfig
+		expectedErr bool
+	}{
+		{
+			name: "good config",
+			config: config.PayloadConfig{
+				Targets: map[string]config.PayloadTargetConfig{
+					"dydx": {
+						Encoder:     config.PayloadEncoderConnect,
+						Compression: config.PayloadCompressionZStd,
+						PairIDs:     map[string]uint64{"BTC/USD": 0, "ETH/USD": 1},
+					},
+					"evm": {
+						Encoder:  config.PayloadEncoderEVM,
+						Decimals: 8,
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "good config with no targets",
+			config:      config.PayloadConfig{},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with no encoder",
+			config: config.PayloadConfig{
+				Targets: map[string]config.PayloadTargetConfig{
+					"dydx": {},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with unknown compression",
+			config: config.PayloadConfig{
+				Targets: map[string]config.PayloadTargetConfig{
+					"dydx": {
+						Encoder:     config.PayloadEncoderConnect,
+						Compression: "gzip",
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with too many decimals",
+			config: config.PayloadConfig{
+				Targets: map[string]config.PayloadTargetConfig{
+					"evm": {
+						Encoder:  config.PayloadEncoderEVM,
+						Decimals: 37,
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid currency pair",
+			config: config.PayloadConfig{
+				Targets: map[string]config.PayloadTargetConfig{
+					"dydx": {
+						Encoder: config.PayloadEncoderConnect,
+						PairIDs: map[string]uint64{"BTCUSD": 0},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with duplicate ids",
+			config: config.PayloadConfig{
+				Targets: map[string]config.PayloadTargetConfig{
+					"dydx": {
+						Encoder: config.PayloadEncoderConnect,
+						PairIDs: map[string]uint64{"BTC/USD": 0, "ETH/USD": 0},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
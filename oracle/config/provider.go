@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"time"
+
+	"go.uber.org/zap/zapcore"
 )
 
 // ProviderConfig defines a config for a provider. To add a new provider, add the provider
@@ -21,6 +24,17 @@ type ProviderConfig struct {
 	// Type is the type of the provider (i.e. price, market map, other). This is used
 	// to determine how to construct the provider.
 	Type string `json:"type"`
+
+	// CacheTTL is the maximum age of the last good price of a ticker that the oracle reuses when
+	// the provider's latest fetch of the ticker failed. Such prices are reported as cached in the
+	// oracle's metrics. If unset, the last good price is reused for up to the oracle's max price age.
+	CacheTTL time.Duration `json:"cacheTTL"`
+
+	// LogLevel is the minimum level (debug, info, warn, error, dpanic, panic, fatal) of the logs
+	// written by the provider. This is used to quieten a noisy provider without raising the log
+	// level of the rest of the oracle. Note that the level of a provider can only be raised above
+	// the oracle's log level. If unset, the oracle's log level is used.
+	LogLevel string `json:"logLevel"`
 }
 
 func (c *ProviderConfig) ValidateBasic() error {
@@ -60,5 +74,15 @@ func (c *ProviderConfig) ValidateBasic() error {
 		return fmt.Errorf("type cannot be empty")
 	}
 
+	if c.CacheTTL < 0 {
+		return fmt.Errorf("cache ttl for %s cannot be negative", c.Name)
+	}
+
+	if len(c.LogLevel) > 0 {
+		if _, err := zapcore.ParseLevel(c.LogLevel); err != nil {
+			return fmt.Errorf("invalid log level for %s: %w", c.Name, err)
+		}
+	}
+
 	return nil
 }
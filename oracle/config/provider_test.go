@@ -196,6 +196,63 @@ func TestProviderConfig(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "negative cache ttl",
+			config: config.ProviderConfig{
+				API: config.APIConfig{
+					Enabled:          true,
+					Timeout:          time.Second,
+					Interval:         time.Second,
+					ReconnectTimeout: time.Second,
+					MaxQueries:       1,
+					Name:             "test",
+					Atomic:           true,
+					Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				},
+				Name:     "test",
+				Type:     "price_provider",
+				CacheTTL: -time.Second,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "good config with log level",
+			config: config.ProviderConfig{
+				API: config.APIConfig{
+					Enabled:          true,
+					Timeout:          time.Second,
+					Interval:         time.Second,
+					ReconnectTimeout: time.Second,
+					MaxQueries:       1,
+					Name:             "test",
+					Atomic:           true,
+					Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				},
+				Name:     "test",
+				Type:     "price_provider",
+				LogLevel: "warn",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid log level",
+			config: config.ProviderConfig{
+				API: config.APIConfig{
+					Enabled:          true,
+					Timeout:          time.Second,
+					Interval:         time.Second,
+					ReconnectTimeout: time.Second,
+					MaxQueries:       1,
+					Name:             "test",
+					Atomic:           true,
+					Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+				},
+				Name:     "test",
+				Type:     "price_provider",
+				LogLevel: "loud",
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
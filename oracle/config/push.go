@@ -0,0 +1,37 @@
+package config
+
+import "fmt"
+
+// PushConfig is the config for the websocket push API of the oracle server. When enabled, clients
+// can connect to the server over a websocket, subscribe to a set of currency pairs, and are pushed
+// the prices of those pairs whenever they change by more than a threshold.
+type PushConfig struct {
+	// Enabled indicates whether the websocket push API is served.
+	Enabled bool `json:"enabled"`
+
+	// Threshold is the default minimum relative change of a price since it was last pushed to a
+	// client for the price to be pushed again, e.g. 0.001 for a 0.1% change. Clients can override
+	// the threshold of their subscriptions. If zero, every change of a price is pushed.
+	Threshold float64 `json:"threshold"`
+
+	// MaxConnections is the maximum number of clients that can be connected at once. If zero, the
+	// number of clients is not limited.
+	MaxConnections int `json:"maxConnections"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *PushConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Threshold < 0 {
+		return fmt.Errorf("push threshold cannot be negative")
+	}
+
+	if c.MaxConnections < 0 {
+		return fmt.Errorf("push max connections cannot be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestPushConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      config.PushConfig
+		expectedErr bool
+	}{
+		{
+			name: "good config",
+			config: config.PushConfig{
+				Enabled:        true,
+				Threshold:      0.001,
+				MaxConnections: 100,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "good config with no threshold",
+			config: config.PushConfig{
+				Enabled: true,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with negative threshold",
+			config: config.PushConfig{
+				Enabled:   true,
+				Threshold: -0.001,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative max connections",
+			config: config.PushConfig{
+				Enabled:        true,
+				MaxConnections: -1,
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "no push enabled",
+			config:      config.PushConfig{Threshold: -1},
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
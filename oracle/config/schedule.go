@@ -0,0 +1,212 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Schedules are defined in the time zone of their market, which must be available regardless
+	// of the time zone database of the host.
+	_ "time/tzdata"
+)
+
+const (
+	// ScheduleTimeFormat is the format of the open and close times of a trading session.
+	ScheduleTimeFormat = "15:04"
+
+	// ScheduleDateFormat is the format of the holidays of a schedule.
+	ScheduleDateFormat = "2006-01-02"
+)
+
+// weekdays maps the lower-cased name of each day of the week to the day.
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// ScheduleConfig is the trading schedule of a market, e.g. of an equity or FX market that does not
+// trade around the clock. While a market is out of session, its previous index price is held and
+// the market is not reported as missing. The first aggregation after the market re-opens is not
+// subject to the market's max price change or confirm deviation, as prices are expected to gap at
+// the open. A schedule without sessions trades around the clock, apart from its holidays.
+type ScheduleConfig struct {
+	// TimeZone is the IANA time zone of the sessions and holidays, e.g. America/New_York. If
+	// unset, UTC is used.
+	TimeZone string `json:"timeZone"`
+
+	// Sessions are the trading sessions of the market. The market is open if any of its sessions
+	// is open.
+	Sessions []SessionConfig `json:"sessions"`
+
+	// Holidays are the dates, formatted as 2006-01-02, on which the market is closed all day.
+	Holidays []string `json:"holidays"`
+}
+
+// SessionConfig is a recurring trading session of a market.
+type SessionConfig struct {
+	// Days are the days of the week on which the session opens, e.g. monday. If unset, the
+	// session opens every day.
+	Days []string `json:"days"`
+
+	// Open and Close are the times of day, formatted as 15:04, at which the session opens and
+	// closes. A close before the open closes the session the following day, e.g. an open of 18:00
+	// and a close of 17:00 trades overnight.
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// ScheduleForMarket returns the trading schedule of the given market ticker, if any.
+func (c *AggregationConfig) ScheduleForMarket(ticker string) (ScheduleConfig, bool) {
+	if schedule, ok := c.Schedules[ticker]; ok {
+		return schedule, true
+	}
+
+	// Keys are lower-cased when the config is read via viper.
+	schedule, ok := c.Schedules[strings.ToLower(ticker)]
+	return schedule, ok
+}
+
+// IsOpen returns true if the market is in session at the given time. The schedule is assumed to
+// be valid.
+func (c *ScheduleConfig) IsOpen(t time.Time) bool {
+	loc, err := c.location()
+	if err != nil {
+		return true
+	}
+	t = t.In(loc)
+
+	if c.isHoliday(t) {
+		return false
+	}
+
+	if len(c.Sessions) == 0 {
+		return true
+	}
+
+	for _, session := range c.Sessions {
+		if session.isOpen(t, c.isHoliday) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateBasic performs basic validation of the schedule config.
+func (c *ScheduleConfig) ValidateBasic() error {
+	if _, err := c.location(); err != nil {
+		return fmt.Errorf("invalid time zone %s: %w", c.TimeZone, err)
+	}
+
+	for i, session := range c.Sessions {
+		if err := session.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid session %d: %w", i, err)
+		}
+	}
+
+	for _, holiday := range c.Holidays {
+		if _, err := time.Parse(ScheduleDateFormat, holiday); err != nil {
+			return fmt.Errorf("invalid holiday %s: %w", holiday, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBasic performs basic validation of the session config.
+func (c *SessionConfig) ValidateBasic() error {
+	for _, day := range c.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("invalid day %s", day)
+		}
+	}
+
+	open, err := parseTimeOfDay(c.Open)
+	if err != nil {
+		return fmt.Errorf("invalid open time %s: %w", c.Open, err)
+	}
+
+	closeTime, err := parseTimeOfDay(c.Close)
+	if err != nil {
+		return fmt.Errorf("invalid close time %s: %w", c.Close, err)
+	}
+
+	if open == closeTime {
+		return fmt.Errorf("open and close times cannot be equal")
+	}
+
+	return nil
+}
+
+// location returns the time zone of the schedule.
+func (c *ScheduleConfig) location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(c.TimeZone)
+}
+
+// isHoliday returns true if the date of the given time, in the time zone of the schedule, is a
+// holiday.
+func (c *ScheduleConfig) isHoliday(t time.Time) bool {
+	date := t.Format(ScheduleDateFormat)
+	for _, holiday := range c.Holidays {
+		if holiday == date {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOpen returns true if the session is open at the given time. A session that opened on the
+// previous day and closes today is open until its close, unless it opened on a holiday.
+func (c *SessionConfig) isOpen(t time.Time, isHoliday func(time.Time) bool) bool {
+	open, _ := parseTimeOfDay(c.Open)
+	closeTime, _ := parseTimeOfDay(c.Close)
+
+	hour, minute, second := t.Clock()
+	timeOfDay := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+
+	if open < closeTime {
+		return c.opensOn(t.Weekday()) && timeOfDay >= open && timeOfDay < closeTime
+	}
+
+	// The session trades overnight, so it is either open since today's open or since yesterday's.
+	if timeOfDay >= open {
+		return c.opensOn(t.Weekday())
+	}
+
+	yesterday := t.AddDate(0, 0, -1)
+	return timeOfDay < closeTime && c.opensOn(yesterday.Weekday()) && !isHoliday(yesterday)
+}
+
+// opensOn returns true if the session opens on the given day of the week.
+func (c *SessionConfig) opensOn(day time.Weekday) bool {
+	if len(c.Days) == 0 {
+		return true
+	}
+
+	for _, d := range c.Days {
+		if weekdays[strings.ToLower(d)] == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseTimeOfDay parses a time of day, formatted as 15:04, into the duration since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse(ScheduleTimeFormat, s)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
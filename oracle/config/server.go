@@ -0,0 +1,136 @@
+package config
+
+import "fmt"
+
+const (
+	// RoleRead grants access to the price APIs of the oracle server.
+	RoleRead = "read"
+	// RoleAdmin grants access to the price APIs and the admin API of the oracle server.
+	RoleAdmin = "admin"
+)
+
+// ListenerConfig is the config for securing a listener of the oracle server.
+type ListenerConfig struct {
+	// TLS is the config for serving the listener over TLS.
+	TLS TLSConfig `json:"tls"`
+
+	// Auth is the config for requiring clients of the listener to present a bearer token.
+	Auth AuthConfig `json:"auth"`
+}
+
+// TLSConfig is the config for serving a listener over TLS, and optionally requiring clients to
+// present a certificate (mutual TLS).
+type TLSConfig struct {
+	// Enabled indicates whether the listener is served over TLS.
+	Enabled bool `json:"enabled"`
+
+	// CertFile is the path to the PEM encoded certificate of the server.
+	CertFile string `json:"certFile"`
+
+	// KeyFile is the path to the PEM encoded private key of the server's certificate.
+	KeyFile string `json:"keyFile"`
+
+	// ClientCAFile is the path to the PEM encoded certificate authorities that client certificates
+	// are verified with. If set, clients must present a certificate signed by one of the
+	// authorities.
+	ClientCAFile string `json:"clientCaFile"`
+}
+
+// AuthConfig is the config for requiring clients to present a bearer token that grants them a
+// role.
+type AuthConfig struct {
+	// Enabled indicates whether clients must present a bearer token.
+	Enabled bool `json:"enabled"`
+
+	// Tokens are the bearer tokens accepted from clients.
+	Tokens []TokenConfig `json:"tokens"`
+}
+
+// TokenConfig is a bearer token and the role it grants.
+type TokenConfig struct {
+	// Source is a reference to the secret holding the token. The reference is one of
+	// env:<variable>, file:<path>, vault:<path>#<field> or awssm:<secret-id>[#<field>].
+	Source string `json:"source"`
+
+	// Role is the role granted by the token, i.e. read or admin.
+	Role string `json:"role"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *ListenerConfig) ValidateBasic() error {
+	if err := c.TLS.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid tls config: %w", err)
+	}
+
+	if err := c.Auth.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid auth config: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *TLSConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.CertFile == "" {
+		return fmt.Errorf("cert file cannot be empty")
+	}
+
+	if c.KeyFile == "" {
+		return fmt.Errorf("key file cannot be empty")
+	}
+
+	return nil
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *AuthConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Tokens) == 0 {
+		return fmt.Errorf("at least one token is required")
+	}
+
+	for i, token := range c.Tokens {
+		if err := token.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid token %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// HasRole returns true if the config accepts a token that grants the given role.
+func (c *AuthConfig) HasRole(role string) bool {
+	if !c.Enabled {
+		return false
+	}
+
+	for _, token := range c.Tokens {
+		if token.Role == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateBasic performs basic validation of the token.
+func (c *TokenConfig) ValidateBasic() error {
+	if err := validateSecretSource(c.Source); err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+
+	switch c.Role {
+	case RoleRead, RoleAdmin:
+	default:
+		return fmt.Errorf("unknown role %q; must be one of %s or %s", c.Role, RoleRead, RoleAdmin)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestListenerConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      config.ListenerConfig
+		expectedErr bool
+	}{
+		{
+			name: "good config",
+			config: config.ListenerConfig{
+				TLS: config.TLSConfig{
+					Enabled:      true,
+					CertFile:     "server.crt",
+					KeyFile:      "server.key",
+					ClientCAFile: "ca.crt",
+				},
+				Auth: config.AuthConfig{
+					Enabled: true,
+					Tokens: []config.TokenConfig{
+						{Source: "env:CONNECT_READ_TOKEN", Role: config.RoleRead},
+						{Source: "file:/etc/connect/admin-token", Role: config.RoleAdmin},
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name:        "good config with nothing enabled",
+			config:      config.ListenerConfig{Auth: config.AuthConfig{Tokens: []config.TokenConfig{{Role: "root"}}}},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with tls and no cert file",
+			config: config.ListenerConfig{
+				TLS: config.TLSConfig{Enabled: true, KeyFile: "server.key"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with auth and no tokens",
+			config: config.ListenerConfig{
+				Auth: config.AuthConfig{Enabled: true},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid token source",
+			config: config.ListenerConfig{
+				Auth: config.AuthConfig{
+					Enabled: true,
+					Tokens:  []config.TokenConfig{{Source: "CONNECT_READ_TOKEN", Role: config.RoleRead}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with unknown role",
+			config: config.ListenerConfig{
+				Auth: config.AuthConfig{
+					Enabled: true,
+					Tokens:  []config.TokenConfig{{Source: "env:CONNECT_TOKEN", Role: "root"}},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthConfigHasRole(t *testing.T) {
+	cfg := config.AuthConfig{
+		Enabled: true,
+		Tokens:  []config.TokenConfig{{Source: "env:CONNECT_READ_TOKEN", Role: config.RoleRead}},
+	}
+	require.True(t, cfg.HasRole(config.RoleRead))
+	require.False(t, cfg.HasRole(config.RoleAdmin))
+
+	cfg.Enabled = false
+	require.False(t, cfg.HasRole(config.RoleRead))
+}
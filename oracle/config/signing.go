@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SigningAlgorithmEd25519 is the algorithm of ed25519 signatures.
+	SigningAlgorithmEd25519 = "ed25519"
+	// SigningAlgorithmSecp256k1 is the algorithm of secp256k1 signatures.
+	SigningAlgorithmSecp256k1 = "secp256k1"
+)
+
+// SigningConfig is the config for signing the price reports served by the oracle. When enabled,
+// each report is signed with the oracle's private key so that consumers can verify the report
+// came from this oracle instance, regardless of the transport it was received over.
+type SigningConfig struct {
+	// Enabled indicates whether price reports should be signed.
+	Enabled bool `json:"enabled"`
+
+	// Algorithm is the signature algorithm, i.e. ed25519 or secp256k1.
+	Algorithm string `json:"algorithm"`
+
+	// KeySource is a reference to the secret holding the hex-encoded 32 byte private key, i.e. one
+	// of env:<variable>, file:<path>, vault:<path>#<field> or awssm:<secret-id>[#<field>].
+	KeySource string `json:"keySource"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *SigningConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	switch c.Algorithm {
+	case SigningAlgorithmEd25519, SigningAlgorithmSecp256k1:
+	default:
+		return fmt.Errorf("signing algorithm must be one of %s or %s; got %q", SigningAlgorithmEd25519, SigningAlgorithmSecp256k1, c.Algorithm)
+	}
+
+	if scheme, location, ok := strings.Cut(c.KeySource, ":"); !ok || scheme == "" || location == "" {
+		return fmt.Errorf("signing key source must be of the form <scheme>:<location>")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestSigningConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      config.SigningConfig
+		expectedErr bool
+	}{
+		{
+			name: "good config with ed25519",
+			config: config.SigningConfig{
+				Enabled:   true,
+				Algorithm: config.SigningAlgorithmEd25519,
+				KeySource: "file:/run/secrets/oracle_key",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "good config with secp256k1",
+			config: config.SigningConfig{
+				Enabled:   true,
+				Algorithm: config.SigningAlgorithmSecp256k1,
+				KeySource: "env:ORACLE_SIGNING_KEY",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with unknown algorithm",
+			config: config.SigningConfig{
+				Enabled:   true,
+				Algorithm: "rsa",
+				KeySource: "env:ORACLE_SIGNING_KEY",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no key source",
+			config: config.SigningConfig{
+				Enabled:   true,
+				Algorithm: config.SigningAlgorithmEd25519,
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "no signing enabled",
+			config:      config.SigningConfig{},
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
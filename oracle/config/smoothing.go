@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SmoothingEMA smooths the aggregated price with an exponential moving average over
+	// aggregation ticks.
+	SmoothingEMA = "ema"
+
+	// SmoothingMedian smooths the aggregated price by taking the median of the aggregated prices
+	// of the last N aggregation ticks.
+	SmoothingMedian = "median"
+)
+
+// SmoothingConfig is the config for the temporal smoothing of a market's aggregated price. The
+// smoothing is applied to the aggregated price of each aggregation tick before it is published,
+// which damps single tick spikes on thin markets.
+type SmoothingConfig struct {
+	// Type is the type of the smoothing. Must be one of ema or median.
+	Type string `json:"type"`
+
+	// Alpha is the smoothing factor, in (0, 1], of the ema smoothing. The smoothed price is
+	// alpha * price + (1 - alpha) * previous smoothed price, so an alpha of 1 disables smoothing.
+	Alpha float64 `json:"alpha"`
+
+	// Window is the number of aggregation ticks the median is taken over when using the median
+	// smoothing. A spike is discarded as long as it lasts fewer than half of the window's ticks,
+	// while a sustained move is published once it lasts more than half of them.
+	Window int `json:"window"`
+}
+
+// SmoothingForMarket returns the smoothing of the given market ticker, and whether the market's
+// aggregated price is smoothed.
+func (c *AggregationConfig) SmoothingForMarket(ticker string) (SmoothingConfig, bool) {
+	if smoothing, ok := c.Smoothing[ticker]; ok {
+		return smoothing, true
+	}
+
+	// Keys are lower-cased when the config is read via viper.
+	smoothing, ok := c.Smoothing[strings.ToLower(ticker)]
+	return smoothing, ok
+}
+
+// ValidateBasic performs basic validation of the smoothing config.
+func (c *SmoothingConfig) ValidateBasic() error {
+	switch c.Type {
+	case SmoothingEMA:
+		if c.Alpha <= 0 || c.Alpha > 1 {
+			return fmt.Errorf("ema alpha must be in (0, 1]")
+		}
+	case SmoothingMedian:
+		if c.Window < 1 {
+			return fmt.Errorf("median window must be at least 1")
+		}
+	default:
+		return fmt.Errorf("unknown smoothing: %s", c.Type)
+	}
+
+	return nil
+}
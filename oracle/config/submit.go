@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SubmitConfig is the config for submitting the oracle's prices to a chain that is updated by
+// oracle transactions rather than vote extensions. When enabled, the oracle periodically signs and
+// broadcasts a transaction that calls the configured method of an EVM contract with the payload of
+// the configured target.
+type SubmitConfig struct {
+	// Enabled indicates whether prices are submitted.
+	Enabled bool `json:"enabled"`
+
+	// Target is the name of the payload target whose payload is submitted. The target must use the
+	// evm encoder.
+	Target string `json:"target"`
+
+	// Endpoint is the JSON-RPC endpoint of the chain.
+	Endpoint Endpoint `json:"endpoint"`
+
+	// Contract is the address of the contract that is called.
+	Contract string `json:"contract"`
+
+	// Method is the name of the contract method that is called with the payload, i.e. the method
+	// with the signature <method>(uint64[],uint256[],uint8[]).
+	Method string `json:"method"`
+
+	// KeySource is a reference to the secret holding the hex-encoded secp256k1 private key that
+	// transactions are signed with. The reference is one of env:<variable>, file:<path>,
+	// vault:<path>#<field> or awssm:<secret-id>[#<field>].
+	KeySource string `json:"keySource"`
+
+	// Interval is the interval at which prices are submitted.
+	Interval time.Duration `json:"interval"`
+
+	// Timeout is the timeout of a submission, including gas estimation and broadcasting.
+	Timeout time.Duration `json:"timeout"`
+
+	// GasMultiplier is the multiplier applied to the estimated gas of a transaction to derive its
+	// gas limit. If unset, a multiplier of 1.2 is used.
+	GasMultiplier float64 `json:"gasMultiplier"`
+
+	// MaxFeePerGas is the maximum fee per gas, in wei, that is paid for a transaction. If a
+	// transaction would cost more, it is not submitted. If unset, the fee is not capped.
+	MaxFeePerGas uint64 `json:"maxFeePerGas"`
+
+	// MaxRetries is the maximum number of times a transaction is re-signed and re-broadcast after
+	// it was rejected for using a stale nonce.
+	MaxRetries int `json:"maxRetries"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *SubmitConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Target) == 0 {
+		return fmt.Errorf("target cannot be empty")
+	}
+
+	if err := c.Endpoint.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	if !common.IsHexAddress(c.Contract) {
+		return fmt.Errorf("contract must be a hex-encoded address; got %q", c.Contract)
+	}
+
+	if len(c.Method) == 0 {
+		return fmt.Errorf("method cannot be empty")
+	}
+
+	if scheme, location, ok := strings.Cut(c.KeySource, ":"); !ok || scheme == "" || location == "" {
+		return fmt.Errorf("key source must be of the form <scheme>:<location>")
+	}
+
+	if c.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+
+	if c.GasMultiplier != 0 && c.GasMultiplier < 1 {
+		return fmt.Errorf("gas multiplier must be at least 1")
+	}
+
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max retries cannot be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func goodSubmitConfig() config.SubmitConfig {
+	return config.SubmitConfig{
+		Enabled:       true,
+		Target:        "evm",
+		Endpoint:      config.Endpoint{URL: "http://localhost:8545"},
+		Contract:      "0x1234567890123456789012345678901234567890",
+		Method:        "updatePrices",
+		KeySource:     "env:SUBMITTER_KEY",
+		Interval:      10 * time.Second,
+		Timeout:       5 * time.Second,
+		GasMultiplier: 1.5,
+		MaxFeePerGas:  100_000_000_000,
+		MaxRetries:    3,
+	}
+}
+
+func TestSubmitConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		modify      func(*config.SubmitConfig)
+		expectedErr bool
+	}{
+		{
+			name:        "good config",
+			modify:      func(*config.SubmitConfig) {},
+			expectedErr: false,
+		},
+		{
+			name: "good config with default gas multiplier",
+			modify: func(c *config.SubmitConfig) {
+				c.GasMultiplier = 0
+			},
+			expectedErr: false,
+		},
+		{
+			name: "no submit enabled",
+			modify: func(c *config.SubmitConfig) {
+				*c = config.SubmitConfig{Interval: -1}
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with no target",
+			modify: func(c *config.SubmitConfig) {
+				c.Target = ""
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no endpoint",
+			modify: func(c *config.SubmitConfig) {
+				c.Endpoint = config.Endpoint{}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid contract",
+			modify: func(c *config.SubmitConfig) {
+				c.Contract = "0x1234"
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no method",
+			modify: func(c *config.SubmitConfig) {
+				c.Method = ""
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with invalid key source",
+			modify: func(c *config.SubmitConfig) {
+				c.KeySource = "0xdeadbeef"
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no interval",
+			modify: func(c *config.SubmitConfig) {
+				c.Interval = 0
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no timeout",
+			modify: func(c *config.SubmitConfig) {
+				c.Timeout = 0
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with gas multiplier below 1",
+			modify: func(c *config.SubmitConfig) {
+				c.GasMultiplier = 0.9
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with negative max retries",
+			modify: func(c *config.SubmitConfig) {
+				c.MaxRetries = -1
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := goodSubmitConfig()
+			tc.modify(&cfg)
+
+			err := cfg.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOracleConfigSubmitTarget(t *testing.T) {
+	cfg := config.OracleConfig{
+		UpdateInterval: time.Second,
+		MaxPriceAge:    time.Minute,
+		Host:           "localhost",
+		Port:           "8080",
+		Submit:         goodSubmitConfig(),
+	}
+
+	// the target must be a payload target.
+	require.Error(t, cfg.ValidateBasic())
+
+	// the target must use the evm encoder.
+	cfg.Payload.Targets = map[string]config.PayloadTargetConfig{
+		"evm": {Encoder: config.PayloadEncoderJSON},
+	}
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg.Payload.Targets["evm"] = config.PayloadTargetConfig{Encoder: config.PayloadEncoderEVM}
+	require.NoError(t, cfg.ValidateBasic())
+
+	// the names of the targets may be lower-cased when the config is read.
+	cfg.Submit.Target = "EVM"
+	require.NoError(t, cfg.ValidateBasic())
+}
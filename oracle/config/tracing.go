@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+)
+
+// TracingConfig is the config for exporting OpenTelemetry traces of the oracle's price fetching and
+// aggregation pipeline to an OTLP endpoint.
+type TracingConfig struct {
+	// Enabled indicates whether traces should be exported.
+	Enabled bool `json:"enabled"`
+
+	// Endpoint is the host and port of the OTLP gRPC endpoint that traces are exported to
+	// (e.g. localhost:4317).
+	Endpoint string `json:"endpoint"`
+
+	// Insecure disables TLS when connecting to the endpoint.
+	Insecure bool `json:"insecure"`
+
+	// SampleRatio is the fraction, in (0, 1], of traces that are sampled. Traces started by a
+	// caller that sampled its trace (e.g. a vote extension request) are always sampled.
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+// ValidateBasic performs basic validation of the config.
+func (c *TracingConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Endpoint) == 0 {
+		return fmt.Errorf("must supply a non-empty endpoint if tracing is enabled")
+	}
+
+	if c.SampleRatio <= 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be in (0, 1]; got %f", c.SampleRatio)
+	}
+
+	return nil
+}
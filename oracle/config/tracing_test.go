@@ -0,0 +1,68 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+func TestTracingConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      config.TracingConfig
+		expectedErr bool
+	}{
+		{
+			name: "good config with tracing",
+			config: config.TracingConfig{
+				Enabled:     true,
+				Endpoint:    "localhost:4317",
+				SampleRatio: 0.5,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "bad config with no endpoint",
+			config: config.TracingConfig{
+				Enabled:     true,
+				SampleRatio: 1,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with no sample ratio",
+			config: config.TracingConfig{
+				Enabled:  true,
+				Endpoint: "localhost:4317",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad config with sample ratio above 1",
+			config: config.TracingConfig{
+				Enabled:     true,
+				Endpoint:    "localhost:4317",
+				SampleRatio: 1.5,
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "no tracing enabled",
+			config:      config.TracingConfig{},
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
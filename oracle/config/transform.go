@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+)
+
+const (
+	// TransformScale multiplies the price by a constant factor, e.g. 0.01 for a feed that reports
+	// prices in cents.
+	TransformScale = "scale"
+
+	// TransformInvert inverts the price, e.g. for a feed that reports the price of the quote in
+	// units of the base.
+	TransformInvert = "invert"
+
+	// TransformMultiply multiplies the price by the index price of another market, or divides it
+	// by the index price if inverted.
+	TransformMultiply = "multiply"
+
+	// TransformClamp bounds the price to a minimum and/or maximum price.
+	TransformClamp = "clamp"
+
+	// TransformEMA smooths the price with an exponential moving average over aggregation ticks.
+	TransformEMA = "ema"
+)
+
+// TransformConfig is the config for a single step of a market's price transform pipeline. The
+// steps of a market are applied in order to the converted price of each of its providers, before
+// the prices are filtered and aggregated.
+type TransformConfig struct {
+	// Type is the type of the transform. Must be one of scale, invert, multiply, clamp or ema.
+	Type string `json:"type"`
+
+	// Provider restricts the transform to the prices of the given provider. If unset, the
+	// transform is applied to the prices of all providers of the market.
+	Provider string `json:"provider"`
+
+	// Factor is the factor the price is multiplied by when using the scale transform.
+	Factor float64 `json:"factor"`
+
+	// Ticker is the market whose index price the price is multiplied by when using the multiply
+	// transform, e.g. USDT/USD.
+	Ticker string `json:"ticker"`
+
+	// Invert divides the price by the index price of Ticker, instead of multiplying it, when
+	// using the multiply transform.
+	Invert bool `json:"invert"`
+
+	// Min and Max bound the price when using the clamp transform. If unset, the respective bound
+	// is not enforced.
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+
+	// Alpha is the smoothing factor, in (0, 1], of the ema transform. The smoothed price is
+	// alpha * price + (1 - alpha) * previous smoothed price, so an alpha of 1 disables smoothing.
+	Alpha float64 `json:"alpha"`
+}
+
+// TransformsForMarket returns the transform pipeline of the given market ticker.
+func (c *AggregationConfig) TransformsForMarket(ticker string) []TransformConfig {
+	if transforms, ok := c.Transforms[ticker]; ok {
+		return transforms
+	}
+
+	// Keys are lower-cased when the config is read via viper.
+	return c.Transforms[strings.ToLower(ticker)]
+}
+
+// ValidateBasic performs basic validation of the transform config.
+func (c *TransformConfig) ValidateBasic() error {
+	switch c.Type {
+	case TransformScale:
+		if c.Factor <= 0 {
+			return fmt.Errorf("scale factor must be positive")
+		}
+	case TransformInvert:
+	case TransformMultiply:
+		if _, err := pkgtypes.CurrencyPairFromString(c.Ticker); err != nil {
+			return fmt.Errorf("invalid multiply ticker %s: %w", c.Ticker, err)
+		}
+	case TransformClamp:
+		if c.Min < 0 || c.Max < 0 {
+			return fmt.Errorf("clamp bounds cannot be negative")
+		}
+
+		if c.Min == 0 && c.Max == 0 {
+			return fmt.Errorf("clamp requires a min or max price")
+		}
+
+		if c.Max > 0 && c.Min > c.Max {
+			return fmt.Errorf("min price %f cannot be greater than max price %f", c.Min, c.Max)
+		}
+	case TransformEMA:
+		if c.Alpha <= 0 || c.Alpha > 1 {
+			return fmt.Errorf("ema alpha must be in (0, 1]")
+		}
+	default:
+		return fmt.Errorf("unknown transform: %s", c.Type)
+	}
+
+	return nil
+}
@@ -4,4 +4,5 @@ const (
 	DYDX     = "dydx"
 	ETHEREUM = "ethereum"
 	BASE     = "base"
+	NEUTRON  = "neutron"
 )
@@ -0,0 +1,193 @@
+package oracle
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	connectgrpc "github.com/skip-mev/connect/v2/pkg/grpc"
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	oracletypes "github.com/skip-mev/connect/v2/x/oracle/types"
+)
+
+// NewCurrencyPairClient returns a client of the x/oracle module of the node at the given gRPC
+// endpoint, to be passed to WithCurrencyPairClient.
+func NewCurrencyPairClient(endpoint config.Endpoint) (oracletypes.QueryClient, *grpc.ClientConn, error) {
+	conn, err := connectgrpc.NewClient(
+		endpoint.URL,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithNoProxy(),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return oracletypes.NewQueryClient(conn), conn, nil
+}
+
+// listenForCurrencyPairUpdates is a goroutine that periodically queries the currency pairs tracked
+// by the chain's x/oracle module, and restricts the markets the oracle prices to them. This method
+// assumes a currency pair client is present, so callers of this method must nil check the client first.
+func (o *OracleImpl) listenForCurrencyPairUpdates(ctx context.Context) {
+	interval := o.cfg.CurrencyPairs.Interval
+	if interval <= 0 {
+		o.logger.Error("currency pairs interval must be greater than 0", zap.Duration("interval", interval))
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	o.logger.Info("listening for currency pair updates", zap.Duration("interval", interval))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			o.syncCurrencyPairs(ctx)
+		}
+	}
+}
+
+// syncCurrencyPairs queries the chain's currency pairs and updates the oracle with them. Failures
+// are logged, and leave the oracle's currency pairs unchanged.
+func (o *OracleImpl) syncCurrencyPairs(ctx context.Context) {
+	timeout := o.cfg.CurrencyPairs.Interval
+	if timeout <= 0 {
+		timeout = o.cfg.UpdateInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := o.cpClient.GetAllCurrencyPairs(ctx, &oracletypes.GetAllCurrencyPairsRequest{})
+	if err != nil {
+		o.logger.Error("failed to query currency pairs", zap.Error(err))
+		return
+	}
+
+	if err := o.UpdateCurrencyPairs(resp.CurrencyPairs); err != nil {
+		o.logger.Error("failed to update oracle with new currency pairs", zap.Error(err))
+	}
+}
+
+// UpdateCurrencyPairs restricts the markets the oracle prices to the given currency pairs, e.g.
+// the pairs tracked by the chain, and updates the providers accordingly. Enabled markets of other
+// pairs are treated as disabled, unless an enabled market of a given pair is normalized by them.
+// The oracle's market map is left as is, so that the markets of pairs that are added later start
+// being priced as soon as the oracle is updated with them.
+func (o *OracleImpl) UpdateCurrencyPairs(currencyPairs []connecttypes.CurrencyPair) error {
+	pairs := make(map[string]struct{}, len(currencyPairs))
+	for _, cp := range currencyPairs {
+		pairs[cp.String()] = struct{}{}
+	}
+
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	if o.currencyPairs != nil && maps.Equal(o.currencyPairs, pairs) {
+		o.logger.Debug("currency pairs have not changed")
+		return nil
+	}
+
+	var added, removed []string
+	for pair := range pairs {
+		if _, ok := o.currencyPairs[pair]; !ok {
+			added = append(added, pair)
+		}
+	}
+	for pair := range o.currencyPairs {
+		if _, ok := pairs[pair]; !ok {
+			removed = append(removed, pair)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	previous := o.currencyPairs
+	o.currencyPairs = pairs
+	if err := o.updateMarketMap(o.marketMap); err != nil {
+		o.currencyPairs = previous
+		return err
+	}
+
+	var missing []string
+	for pair := range pairs {
+		if _, ok := o.marketMap.Markets[pair]; !ok {
+			missing = append(missing, pair)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		o.logger.Warn("currency pairs have no market in the market map and will not be priced", zap.Strings("currency_pairs", missing))
+	}
+
+	o.logger.Info(
+		"updated oracle with new currency pairs",
+		zap.Int("num_currency_pairs", len(pairs)),
+		zap.Strings("added", added),
+		zap.Strings("removed", removed),
+	)
+
+	return nil
+}
+
+// activeMarketMap returns the markets the oracle prices, i.e. its market map restricted to the
+// currency pairs it was updated with, if any.
+func (o *OracleImpl) activeMarketMap() mmtypes.MarketMap {
+	return restrictMarketMap(o.marketMap, o.currencyPairs)
+}
+
+// restrictMarketMap returns a copy of the market map in which the enabled markets of currency
+// pairs that are not in the given set are disabled, unless they are used to normalize an enabled
+// market of a pair in the set. The market map is returned as is if the set is nil.
+func restrictMarketMap(marketMap mmtypes.MarketMap, pairs map[string]struct{}) mmtypes.MarketMap {
+	if pairs == nil {
+		return marketMap
+	}
+
+	// find the enabled markets of the set, and the enabled markets they are normalized by.
+	enabled := make(map[string]struct{})
+	queue := make([]string, 0, len(pairs))
+	for pair := range pairs {
+		if market, ok := marketMap.Markets[pair]; ok && market.Ticker.Enabled {
+			queue = append(queue, pair)
+		}
+	}
+	for len(queue) > 0 {
+		pair := queue[0]
+		queue = queue[1:]
+		if _, ok := enabled[pair]; ok {
+			continue
+		}
+		enabled[pair] = struct{}{}
+
+		for _, providerCfg := range marketMap.Markets[pair].ProviderConfigs {
+			if providerCfg.NormalizeByPair == nil {
+				continue
+			}
+
+			normalizeBy := providerCfg.NormalizeByPair.String()
+			if market, ok := marketMap.Markets[normalizeBy]; ok && market.Ticker.Enabled {
+				queue = append(queue, normalizeBy)
+			}
+		}
+	}
+
+	restricted := mmtypes.MarketMap{Markets: make(map[string]mmtypes.Market, len(marketMap.Markets))}
+	for ticker, market := range marketMap.Markets {
+		if _, ok := enabled[ticker]; !ok {
+			market.Ticker.Enabled = false
+		}
+		restricted.Markets[ticker] = market
+	}
+
+	return restricted
+}
@@ -0,0 +1,243 @@
+package oracle_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	oraclefactory "github.com/skip-mev/connect/v2/providers/factories/oracle"
+	"github.com/skip-mev/connect/v2/providers/websockets/okx"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	xoracletypes "github.com/skip-mev/connect/v2/x/oracle/types"
+)
+
+var (
+	coinbaseusdtusd = types.DefaultProviderTicker{
+		OffChainTicker: "USDTUSD",
+	}
+
+	// BTC/USD is normalized by USDT/USD.
+	normalizedMarketMap = mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			btcusdCP.String(): {
+				Ticker: mmtypes.Ticker{
+					CurrencyPair:     btcusdCP,
+					MinProviderCount: 1,
+					Decimals:         8,
+					Enabled:          true,
+				},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{
+						Name:            okx.Name,
+						OffChainTicker:  okxbtcusd.GetOffChainTicker(),
+						NormalizeByPair: &usdtusdCP,
+					},
+				},
+			},
+			usdtusdCP.String(): {
+				Ticker: mmtypes.Ticker{
+					CurrencyPair:     usdtusdCP,
+					MinProviderCount: 1,
+					Decimals:         8,
+					Enabled:          true,
+				},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{
+						Name:           coinbase.Name,
+						OffChainTicker: coinbaseusdtusd.GetOffChainTicker(),
+					},
+				},
+			},
+			ethusdtCP.String(): {
+				Ticker: mmtypes.Ticker{
+					CurrencyPair:     ethusdtCP,
+					MinProviderCount: 1,
+					Decimals:         8,
+					Enabled:          true,
+				},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{
+						Name:           coinbase.Name,
+						OffChainTicker: coinbaseethusd.GetOffChainTicker(),
+					},
+				},
+			},
+		},
+	}
+)
+
+// marketMapAggregator records the market map it was last updated with.
+type marketMapAggregator struct {
+	noOpPriceAggregator
+
+	mtx       sync.Mutex
+	marketMap mmtypes.MarketMap
+}
+
+func (a *marketMapAggregator) UpdateMarketMap(marketMap mmtypes.MarketMap) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.marketMap = marketMap
+}
+
+func (a *marketMapAggregator) enabledMarkets() []string {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	var enabled []string
+	for ticker, market := range a.marketMap.Markets {
+		if market.Ticker.Enabled {
+			enabled = append(enabled, ticker)
+		}
+	}
+
+	return enabled
+}
+
+// currencyPairClient serves the chain's currency pairs.
+type currencyPairClient struct {
+	xoracletypes.QueryClient
+
+	mtx   sync.Mutex
+	pairs []connecttypes.CurrencyPair
+}
+
+func (c *currencyPairClient) setPairs(pairs ...connecttypes.CurrencyPair) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.pairs = pairs
+}
+
+func (c *currencyPairClient) GetAllCurrencyPairs(
+	_ context.Context,
+	_ *xoracletypes.GetAllCurrencyPairsRequest,
+	_ ...grpc.CallOption,
+) (*xoracletypes.GetAllCurrencyPairsResponse, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return &xoracletypes.GetAllCurrencyPairsResponse{CurrencyPairs: c.pairs}, nil
+}
+
+func providerTickers(t *testing.T, o *oracle.OracleImpl, name string) []types.ProviderTicker {
+	t.Helper()
+
+	state, ok := o.GetProviderState()[name]
+	require.True(t, ok)
+
+	return state.Provider.GetIDs()
+}
+
+func TestUpdateCurrencyPairs(t *testing.T) {
+	t.Run("restricts the providers to the currency pairs and their normalization markets", func(t *testing.T) {
+		aggregator := &marketMapAggregator{}
+		orc, err := oracle.New(
+			oracleCfg,
+			aggregator,
+			oracle.WithLogger(logger),
+			oracle.WithMarketMap(normalizedMarketMap),
+			oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+			oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+		)
+		require.NoError(t, err)
+		o := orc.(*oracle.OracleImpl)
+		require.NoError(t, o.Init(context.Background()))
+
+		require.Len(t, providerTickers(t, o, coinbase.Name), 2)
+		require.Len(t, providerTickers(t, o, okx.Name), 1)
+
+		// BTC/USD is priced along with the USDT/USD market it is normalized by.
+		require.NoError(t, o.UpdateCurrencyPairs([]connecttypes.CurrencyPair{btcusdCP}))
+		require.ElementsMatch(t, []types.ProviderTicker{coinbaseusdtusd}, providerTickers(t, o, coinbase.Name))
+		require.ElementsMatch(t, []types.ProviderTicker{okxbtcusd}, providerTickers(t, o, okx.Name))
+		require.ElementsMatch(t, []string{btcusdCP.String(), usdtusdCP.String()}, aggregator.enabledMarkets())
+
+		// the oracle's market map is unchanged.
+		require.Equal(t, normalizedMarketMap, o.GetMarketMap())
+
+		// ETH/USDT is priced once it is added, and BTC/USD is no longer priced once it is removed.
+		require.NoError(t, o.UpdateCurrencyPairs([]connecttypes.CurrencyPair{ethusdtCP}))
+		require.ElementsMatch(t, []types.ProviderTicker{coinbaseethusd}, providerTickers(t, o, coinbase.Name))
+		require.Empty(t, providerTickers(t, o, okx.Name))
+		require.ElementsMatch(t, []string{ethusdtCP.String()}, aggregator.enabledMarkets())
+
+		// pairs without a market are not priced.
+		require.NoError(t, o.UpdateCurrencyPairs([]connecttypes.CurrencyPair{ethusdtCP, btcusdtCP}))
+		require.ElementsMatch(t, []types.ProviderTicker{coinbaseethusd}, providerTickers(t, o, coinbase.Name))
+
+		// nothing is priced if the chain tracks no pairs.
+		require.NoError(t, o.UpdateCurrencyPairs(nil))
+		require.Empty(t, providerTickers(t, o, coinbase.Name))
+		require.Empty(t, aggregator.enabledMarkets())
+	})
+
+	t.Run("market map updates are restricted to the currency pairs", func(t *testing.T) {
+		orc, err := oracle.New(
+			oracleCfg,
+			noOpPriceAggregator{},
+			oracle.WithLogger(logger),
+			oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+			oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+		)
+		require.NoError(t, err)
+		o := orc.(*oracle.OracleImpl)
+		require.NoError(t, o.Init(context.Background()))
+
+		require.NoError(t, o.UpdateCurrencyPairs([]connecttypes.CurrencyPair{ethusdtCP}))
+		require.NoError(t, o.UpdateMarketMap(marketMap))
+		require.ElementsMatch(t, []types.ProviderTicker{coinbaseethusd}, providerTickers(t, o, coinbase.Name))
+		require.ElementsMatch(t, []types.ProviderTicker{okxethusd}, providerTickers(t, o, okx.Name))
+		require.Equal(t, marketMap, o.GetMarketMap())
+	})
+
+	t.Run("queries the chain's currency pairs while running", func(t *testing.T) {
+		cfg := copyConfig(oracleCfg)
+		cfg.CurrencyPairs.Enabled = true
+		cfg.CurrencyPairs.Interval = 100 * time.Millisecond
+
+		client := &currencyPairClient{}
+		client.setPairs(ethusdtCP)
+
+		orc, err := oracle.New(
+			cfg,
+			noOpPriceAggregator{},
+			oracle.WithLogger(logger),
+			oracle.WithMarketMap(marketMap),
+			oracle.WithCurrencyPairClient(client),
+			oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+			oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+		)
+		require.NoError(t, err)
+		o := orc.(*oracle.OracleImpl)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go o.Start(ctx)
+		defer o.Stop()
+
+		numTickers := func() int {
+			state, ok := o.GetProviderState()[coinbase.Name]
+			if !ok || state.Provider == nil {
+				return 0
+			}
+			return len(state.Provider.GetIDs())
+		}
+
+		require.Eventually(t, func() bool { return numTickers() == 1 }, 5*time.Second, 50*time.Millisecond)
+		require.ElementsMatch(t, []types.ProviderTicker{coinbaseethusd}, providerTickers(t, o, coinbase.Name))
+
+		// a pair added by governance starts being priced.
+		client.setPairs(ethusdtCP, btcusdtCP)
+		require.Eventually(t, func() bool { return numTickers() == 2 }, 5*time.Second, 50*time.Millisecond)
+	})
+}
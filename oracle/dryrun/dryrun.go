@@ -0,0 +1,198 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// PriceSource is the source of the prices that are compared to the chain's prices, i.e. the oracle.
+type PriceSource interface {
+	GetPrices() types.Prices
+	GetMarketMap() mmtypes.MarketMap
+}
+
+// Diff is the difference between the oracle's price of a currency pair and the reference's price
+// of it.
+type Diff struct {
+	// CurrencyPair is the currency pair, e.g. BTC/USD.
+	CurrencyPair string `json:"currency_pair"`
+	// Decimals is the number of decimals of the prices, i.e. the decimals of the pair's market.
+	Decimals uint64 `json:"decimals"`
+	// Price is the oracle's price.
+	Price string `json:"price"`
+	// ReferencePrice is the reference's price, scaled to the decimals of the oracle's price. This
+	// is empty if the reference has no price for the pair.
+	ReferencePrice string `json:"reference_price,omitempty"`
+	// ReferenceHeight is the height of the block the reference's price was last updated at, if the
+	// reference is a chain.
+	ReferenceHeight uint64 `json:"reference_height,omitempty"`
+	// Change is the relative change of the oracle's price from the reference's price, e.g. 0.01 if
+	// the oracle's price is 1% higher. This is nil if the reference has no price for the pair.
+	Change *float64 `json:"change,omitempty"`
+}
+
+// Report is the report of a single tick of a dry run.
+type Report struct {
+	// Timestamp is the time of the tick.
+	Timestamp time.Time `json:"timestamp"`
+	// Reference is the name of the reference the oracle's prices are compared to, if any.
+	Reference string `json:"reference,omitempty"`
+	// Diffs are the differences of the oracle's prices from the reference's prices, sorted by
+	// currency pair.
+	Diffs []Diff `json:"diffs"`
+	// Missing are the currency pairs priced by the reference that the oracle has no price for.
+	Missing []string `json:"missing,omitempty"`
+	// Error is the error that occurred while querying the reference's prices, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Runner periodically compares the prices of the oracle to the prices of a reference, such as the
+// chain's current on-chain prices or the prices published by another oracle, and writes a report
+// of each tick as a JSON line. It is used to validate a config before going live, as the oracle's
+// prices are not served or submitted anywhere else.
+type Runner struct {
+	logger    *zap.Logger
+	source    PriceSource
+	reference Reference
+	out       io.Writer
+	interval  time.Duration
+}
+
+// NewRunner returns a new Runner that writes the reports of the given source to out at the given
+// interval. If the reference is nil, the reports only contain the oracle's prices.
+func NewRunner(
+	logger *zap.Logger,
+	source PriceSource,
+	reference Reference,
+	out io.Writer,
+	interval time.Duration,
+) (*Runner, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be greater than 0")
+	}
+
+	return &Runner{
+		logger:    logger.With(zap.String("process", "dry_run")),
+		source:    source,
+		reference: reference,
+		out:       out,
+		interval:  interval,
+	}, nil
+}
+
+// Run writes a report at the configured interval until the given context is cancelled.
+func (r *Runner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	encoder := json.NewEncoder(r.out)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := encoder.Encode(r.Report(ctx)); err != nil {
+			return fmt.Errorf("failed to write dry run report: %w", err)
+		}
+	}
+}
+
+// Report returns the report of the oracle's current prices. Failures to query the reference are
+// recorded in the report rather than returned, so that the oracle's prices are always reported.
+func (r *Runner) Report(ctx context.Context) Report {
+	report := Report{
+		Timestamp: time.Now().UTC(),
+		Diffs:     make([]Diff, 0),
+	}
+
+	marketMap := r.source.GetMarketMap()
+	for pair, price := range r.source.GetPrices() {
+		if price == nil {
+			continue
+		}
+
+		diff := Diff{CurrencyPair: pair, Price: intString(price)}
+		if market, ok := marketMap.Markets[pair]; ok {
+			diff.Decimals = market.Ticker.Decimals
+		}
+		report.Diffs = append(report.Diffs, diff)
+	}
+	sort.Slice(report.Diffs, func(i, j int) bool {
+		return report.Diffs[i].CurrencyPair < report.Diffs[j].CurrencyPair
+	})
+
+	if r.reference == nil {
+		return report
+	}
+	report.Reference = r.reference.Name()
+
+	ctx, cancel := context.WithTimeout(ctx, r.interval)
+	defer cancel()
+
+	prices, err := r.reference.Prices(ctx)
+	if err != nil {
+		r.logger.Error("failed to query reference prices", zap.String("reference", report.Reference), zap.Error(err))
+		report.Error = err.Error()
+		return report
+	}
+
+	for i := range report.Diffs {
+		diff := &report.Diffs[i]
+		price, ok := prices[diff.CurrencyPair]
+		if !ok {
+			continue
+		}
+		delete(prices, diff.CurrencyPair)
+
+		reference := scale(price.Price, price.Decimals, diff.Decimals)
+		diff.ReferencePrice = reference.String()
+		diff.ReferenceHeight = price.Height
+		if reference.Sign() != 0 {
+			own, _ := new(big.Int).SetString(diff.Price, 10)
+			change, _ := new(big.Float).Quo(
+				new(big.Float).SetInt(new(big.Int).Sub(own, reference)),
+				new(big.Float).SetInt(reference),
+			).Float64()
+			diff.Change = &change
+		}
+	}
+
+	for pair := range prices {
+		report.Missing = append(report.Missing, pair)
+	}
+	sort.Strings(report.Missing)
+
+	return report
+}
+
+// intString returns the integer part of the given price, as served by the oracle.
+func intString(price *big.Float) string {
+	i, _ := price.Int(nil)
+	return i.String()
+}
+
+// scale returns the given price with the given decimals scaled to the target decimals.
+func scale(price *big.Int, decimals, target uint64) *big.Int {
+	switch {
+	case decimals < target:
+		exp := new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(target-decimals), nil)
+		return new(big.Int).Mul(price, exp)
+	case decimals > target:
+		exp := new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(decimals-target), nil)
+		return new(big.Int).Quo(price, exp)
+	default:
+		return price
+	}
+}
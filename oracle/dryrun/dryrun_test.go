@@ -0,0 +1,201 @@
+package dryrun_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/skip-mev/connect/v2/oracle/dryrun"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	oracletypes "github.com/skip-mev/connect/v2/x/oracle/types"
+)
+
+var (
+	btc  = mmtypes.NewTicker("BTC", "USD", 8, 1, true)
+	eth  = mmtypes.NewTicker("ETH", "USD", 11, 1, true)
+	sol  = mmtypes.NewTicker("SOL", "USD", 8, 1, true)
+	atom = mmtypes.NewTicker("ATOM", "USD", 8, 1, true)
+)
+
+// source is a static source of prices.
+type source struct {
+	prices types.Prices
+}
+
+func (s *source) GetPrices() types.Prices {
+	return s.prices
+}
+
+func (s *source) GetMarketMap() mmtypes.MarketMap {
+	return mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			btc.String(): {Ticker: btc},
+			eth.String(): {Ticker: eth},
+			sol.String(): {Ticker: sol},
+		},
+	}
+}
+
+// queryClient serves the chain's currency pairs and prices.
+type queryClient struct {
+	oracletypes.QueryClient
+
+	err    error
+	pairs  []connecttypes.CurrencyPair
+	prices map[string]oracletypes.GetPriceResponse
+}
+
+func (c *queryClient) GetAllCurrencyPairs(
+	_ context.Context,
+	_ *oracletypes.GetAllCurrencyPairsRequest,
+	_ ...grpc.CallOption,
+) (*oracletypes.GetAllCurrencyPairsResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &oracletypes.GetAllCurrencyPairsResponse{CurrencyPairs: c.pairs}, nil
+}
+
+func (c *queryClient) GetPrice(
+	_ context.Context,
+	req *oracletypes.GetPriceRequest,
+	_ ...grpc.CallOption,
+) (*oracletypes.GetPriceResponse, error) {
+	price, ok := c.prices[req.CurrencyPair]
+	if !ok {
+		return nil, fmt.Errorf("no price for %s", req.CurrencyPair)
+	}
+
+	return &price, nil
+}
+
+func onChainPrice(price int64, decimals uint64) oracletypes.GetPriceResponse {
+	return oracletypes.GetPriceResponse{
+		Price:    &oracletypes.QuotePrice{Price: math.NewInt(price), BlockHeight: 10},
+		Decimals: decimals,
+	}
+}
+
+func TestReport(t *testing.T) {
+	src := &source{prices: types.Prices{
+		btc.String(): big.NewFloat(6060000000000),
+		eth.String(): big.NewFloat(300000000000000),
+	}}
+
+	client := &queryClient{
+		pairs: []connecttypes.CurrencyPair{
+			btc.CurrencyPair,
+			eth.CurrencyPair,
+			atom.CurrencyPair,
+			sol.CurrencyPair,
+		},
+		prices: map[string]oracletypes.GetPriceResponse{
+			btc.String(): onChainPrice(6000000000000, 8),
+			// the chain's price has fewer decimals than the market.
+			eth.String():  onChainPrice(3000000000, 6),
+			atom.String(): onChainPrice(800000000, 8),
+		},
+	}
+
+	runner, err := dryrun.NewRunner(zap.NewNop(), src, dryrun.NewChainReference(client), &bytes.Buffer{}, time.Second)
+	require.NoError(t, err)
+
+	report := runner.Report(context.Background())
+	require.Empty(t, report.Error)
+	require.Equal(t, "chain", report.Reference)
+	// pairs without an on-chain price are not missing.
+	require.Equal(t, []string{"ATOM/USD"}, report.Missing)
+	require.Len(t, report.Diffs, 2)
+
+	require.Equal(t, "BTC/USD", report.Diffs[0].CurrencyPair)
+	require.Equal(t, uint64(8), report.Diffs[0].Decimals)
+	require.Equal(t, "6060000000000", report.Diffs[0].Price)
+	require.Equal(t, "6000000000000", report.Diffs[0].ReferencePrice)
+	require.Equal(t, uint64(10), report.Diffs[0].ReferenceHeight)
+	require.InDelta(t, 0.01, *report.Diffs[0].Change, 1e-9)
+
+	require.Equal(t, "ETH/USD", report.Diffs[1].CurrencyPair)
+	require.Equal(t, "300000000000000", report.Diffs[1].ReferencePrice)
+	require.InDelta(t, 0, *report.Diffs[1].Change, 1e-9)
+
+	// pairs without an on-chain price are reported without one.
+	delete(client.prices, btc.String())
+	report = runner.Report(context.Background())
+	require.Empty(t, report.Diffs[0].ReferencePrice)
+	require.Nil(t, report.Diffs[0].Change)
+
+	// pairs not priced by the chain are reported without an on-chain price.
+	src.prices[sol.String()] = big.NewFloat(15000000000)
+	report = runner.Report(context.Background())
+	require.Equal(t, []string{"ATOM/USD"}, report.Missing)
+	require.Len(t, report.Diffs, 3)
+	require.Equal(t, "SOL/USD", report.Diffs[2].CurrencyPair)
+	require.Empty(t, report.Diffs[2].ReferencePrice)
+}
+
+func TestReportWithoutChain(t *testing.T) {
+	src := &source{prices: types.Prices{btc.String(): big.NewFloat(6060000000000)}}
+
+	// the oracle's prices are reported without a client.
+	runner, err := dryrun.NewRunner(zap.NewNop(), src, nil, &bytes.Buffer{}, time.Second)
+	require.NoError(t, err)
+
+	report := runner.Report(context.Background())
+	require.Empty(t, report.Error)
+	require.Empty(t, report.Reference)
+	require.Len(t, report.Diffs, 1)
+	require.Empty(t, report.Diffs[0].ReferencePrice)
+
+	// the oracle's prices are reported if the chain cannot be queried.
+	reference := dryrun.NewChainReference(&queryClient{err: fmt.Errorf("unavailable")})
+	runner, err = dryrun.NewRunner(zap.NewNop(), src, reference, &bytes.Buffer{}, time.Second)
+	require.NoError(t, err)
+
+	report = runner.Report(context.Background())
+	require.Contains(t, report.Error, "unavailable")
+	require.Len(t, report.Diffs, 1)
+	require.Equal(t, "6060000000000", report.Diffs[0].Price)
+}
+
+func TestRun(t *testing.T) {
+	src := &source{prices: types.Prices{btc.String(): big.NewFloat(6060000000000)}}
+	client := &queryClient{
+		pairs:  []connecttypes.CurrencyPair{btc.CurrencyPair},
+		prices: map[string]oracletypes.GetPriceResponse{btc.String(): onChainPrice(6000000000000, 8)},
+	}
+
+	_, err := dryrun.NewRunner(zap.NewNop(), src, dryrun.NewChainReference(client), &bytes.Buffer{}, 0)
+	require.Error(t, err)
+
+	out := &bytes.Buffer{}
+	runner, err := dryrun.NewRunner(zap.NewNop(), src, dryrun.NewChainReference(client), out, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+	require.NoError(t, runner.Run(ctx))
+
+	// each tick is written as a JSON line.
+	decoder := json.NewDecoder(out)
+	var ticks int
+	for decoder.More() {
+		var report dryrun.Report
+		require.NoError(t, decoder.Decode(&report))
+		require.Len(t, report.Diffs, 1)
+		require.Equal(t, "6000000000000", report.Diffs[0].ReferencePrice)
+		ticks++
+	}
+	require.Greater(t, ticks, 1)
+}
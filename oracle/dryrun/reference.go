@@ -0,0 +1,125 @@
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	servicetypes "github.com/skip-mev/connect/v2/service/servers/oracle/types"
+	oracletypes "github.com/skip-mev/connect/v2/x/oracle/types"
+)
+
+// Reference is the source of the prices that the oracle's prices are compared to.
+type Reference interface {
+	// Name returns the name of the reference, which is included in each report.
+	Name() string
+	// Prices returns the reference's current prices, indexed by currency pair.
+	Prices(ctx context.Context) (map[string]ReferencePrice, error)
+}
+
+// ReferencePrice is a price of a reference.
+type ReferencePrice struct {
+	// Price is the price, with the given number of decimals.
+	Price *big.Int
+	// Decimals is the number of decimals of the price.
+	Decimals uint64
+	// Height is the height of the block the price was last updated at, if the reference is a chain.
+	Height uint64
+}
+
+var (
+	_ Reference = (*ChainReference)(nil)
+	_ Reference = (*OracleReference)(nil)
+)
+
+// ChainReference is a Reference to the on-chain prices of a chain's x/oracle module.
+type ChainReference struct {
+	client oracletypes.QueryClient
+}
+
+// NewChainReference returns a new ChainReference that queries the prices with the given client.
+func NewChainReference(client oracletypes.QueryClient) *ChainReference {
+	return &ChainReference{client: client}
+}
+
+// Name returns the name of the reference.
+func (r *ChainReference) Name() string {
+	return "chain"
+}
+
+// Prices returns the on-chain prices of the currency pairs tracked by the chain. Pairs without an
+// on-chain price yet are omitted.
+func (r *ChainReference) Prices(ctx context.Context) (map[string]ReferencePrice, error) {
+	resp, err := r.client.GetAllCurrencyPairs(ctx, &oracletypes.GetAllCurrencyPairsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query currency pairs: %w", err)
+	}
+
+	prices := make(map[string]ReferencePrice, len(resp.CurrencyPairs))
+	for _, cp := range resp.CurrencyPairs {
+		// pairs without an on-chain price yet fail to query.
+		price, err := r.client.GetPrice(ctx, &oracletypes.GetPriceRequest{CurrencyPair: cp.String()})
+		if err != nil || price.Price == nil || price.Price.Price.IsNil() {
+			continue
+		}
+
+		prices[cp.String()] = ReferencePrice{
+			Price:    price.Price.Price.BigInt(),
+			Decimals: price.Decimals,
+			Height:   price.Price.BlockHeight,
+		}
+	}
+
+	return prices, nil
+}
+
+// OracleReference is a Reference to the prices published by another oracle, e.g. the oracle a
+// candidate configuration is run in shadow of.
+type OracleReference struct {
+	address string
+	client  servicetypes.OracleClient
+}
+
+// NewOracleReference returns a new OracleReference that queries the prices of the oracle at the
+// given address with the given client.
+func NewOracleReference(address string, client servicetypes.OracleClient) *OracleReference {
+	return &OracleReference{address: address, client: client}
+}
+
+// Name returns the name of the reference.
+func (r *OracleReference) Name() string {
+	return "oracle:" + r.address
+}
+
+// Prices returns the prices published by the oracle, with the decimals of its market map.
+func (r *OracleReference) Prices(ctx context.Context) (map[string]ReferencePrice, error) {
+	resp, err := r.client.Prices(ctx, &servicetypes.QueryPricesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prices: %w", err)
+	}
+
+	mm, err := r.client.MarketMap(ctx, &servicetypes.QueryMarketMapRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market map: %w", err)
+	}
+	if mm.MarketMap == nil {
+		return nil, fmt.Errorf("oracle has no market map")
+	}
+
+	prices := make(map[string]ReferencePrice, len(resp.Prices))
+	for pair, value := range resp.Prices {
+		market, ok := mm.MarketMap.Markets[pair]
+		if !ok {
+			continue
+		}
+
+		price, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid price %q for %s", value, pair)
+		}
+
+		prices[pair] = ReferencePrice{Price: price, Decimals: market.Ticker.Decimals}
+	}
+
+	return prices, nil
+}
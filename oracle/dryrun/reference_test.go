@@ -0,0 +1,104 @@
+package dryrun_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/skip-mev/connect/v2/oracle/dryrun"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	servicetypes "github.com/skip-mev/connect/v2/service/servers/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// oracleClient serves the prices and market map of another oracle.
+type oracleClient struct {
+	servicetypes.OracleClient
+
+	err       error
+	prices    map[string]string
+	marketMap *mmtypes.MarketMap
+}
+
+func (c *oracleClient) Prices(
+	_ context.Context,
+	_ *servicetypes.QueryPricesRequest,
+	_ ...grpc.CallOption,
+) (*servicetypes.QueryPricesResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &servicetypes.QueryPricesResponse{Prices: c.prices}, nil
+}
+
+func (c *oracleClient) MarketMap(
+	_ context.Context,
+	_ *servicetypes.QueryMarketMapRequest,
+	_ ...grpc.CallOption,
+) (*servicetypes.QueryMarketMapResponse, error) {
+	return &servicetypes.QueryMarketMapResponse{MarketMap: c.marketMap}, nil
+}
+
+func TestOracleReference(t *testing.T) {
+	client := &oracleClient{
+		prices: map[string]string{
+			btc.String(): "6000000000000",
+			// the other oracle's market has fewer decimals.
+			eth.String():  "300000000000",
+			atom.String(): "800000000",
+		},
+		marketMap: &mmtypes.MarketMap{
+			Markets: map[string]mmtypes.Market{
+				btc.String():  {Ticker: btc},
+				eth.String():  {Ticker: mmtypes.NewTicker("ETH", "USD", 8, 1, true)},
+				atom.String(): {Ticker: atom},
+			},
+		},
+	}
+	reference := dryrun.NewOracleReference("localhost:8080", client)
+	require.Equal(t, "oracle:localhost:8080", reference.Name())
+
+	src := &source{prices: types.Prices{
+		btc.String(): big.NewFloat(5940000000000),
+		eth.String(): big.NewFloat(300000000000000),
+	}}
+	runner, err := dryrun.NewRunner(zap.NewNop(), src, reference, &bytes.Buffer{}, time.Second)
+	require.NoError(t, err)
+
+	report := runner.Report(context.Background())
+	require.Empty(t, report.Error)
+	require.Equal(t, "oracle:localhost:8080", report.Reference)
+	require.Equal(t, []string{"ATOM/USD"}, report.Missing)
+	require.Len(t, report.Diffs, 2)
+
+	require.Equal(t, "6000000000000", report.Diffs[0].ReferencePrice)
+	require.Zero(t, report.Diffs[0].ReferenceHeight)
+	require.InDelta(t, -0.01, *report.Diffs[0].Change, 1e-9)
+
+	require.Equal(t, "300000000000000", report.Diffs[1].ReferencePrice)
+	require.InDelta(t, 0, *report.Diffs[1].Change, 1e-9)
+
+	// invalid prices of the other oracle fail the query.
+	client.prices[btc.String()] = "60000.0"
+	report = runner.Report(context.Background())
+	require.Contains(t, report.Error, "invalid price")
+	require.Len(t, report.Diffs, 2)
+
+	// the other oracle must serve its market map.
+	client.marketMap = nil
+	report = runner.Report(context.Background())
+	require.Contains(t, report.Error, "market map")
+
+	client.err = fmt.Errorf("unavailable")
+	report = runner.Report(context.Background())
+	require.Contains(t, report.Error, "unavailable")
+	require.Empty(t, report.Diffs[0].ReferencePrice)
+}
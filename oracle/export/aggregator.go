@@ -0,0 +1,175 @@
+package export
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+const (
+	// QueueSize is the number of ticks buffered per exporter. Ticks are dropped while the queue of
+	// an exporter is full, i.e. while its backend is down or slower than the oracle.
+	QueueSize = 1024
+
+	// MaxBatchSize is the maximum number of ticks shipped by an exporter at once.
+	MaxBatchSize = 100
+)
+
+var _ oracle.PriceAggregator = (*Aggregator)(nil)
+
+// Aggregator is a price aggregator that ships the aggregated prices of every tick to a set of
+// exporters. Ticks are shipped in the background by Run, so that a slow or unavailable backend
+// does not delay the oracle.
+type Aggregator struct {
+	logger *zap.Logger
+
+	// aggregator is the wrapped aggregator.
+	aggregator oracle.PriceAggregator
+
+	// exporters are the exporters ticks are shipped to.
+	exporters []Exporter
+
+	// queues are the ticks waiting to be shipped by each exporter.
+	queues []chan Tick
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+// NewAggregator returns a new Aggregator that wraps the given aggregator and ships its aggregated
+// prices to the given exporters once Run is called.
+func NewAggregator(logger *zap.Logger, aggregator oracle.PriceAggregator, exporters ...Exporter) *Aggregator {
+	queues := make([]chan Tick, len(exporters))
+	for i := range queues {
+		queues[i] = make(chan Tick, QueueSize)
+	}
+
+	return &Aggregator{
+		logger:     logger.With(zap.String("process", "export")),
+		aggregator: aggregator,
+		exporters:  exporters,
+		queues:     queues,
+		now:        time.Now,
+	}
+}
+
+// Run ships the queued ticks of each exporter until the given context is cancelled, after which
+// the exporters are closed. This blocks until all exporters are closed.
+func (a *Aggregator) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i, exporter := range a.exporters {
+		wg.Add(1)
+		go func(exporter Exporter, queue <-chan Tick) {
+			defer wg.Done()
+			a.run(ctx, exporter, queue)
+		}(exporter, a.queues[i])
+	}
+
+	wg.Wait()
+	closeAll(a.logger, a.exporters)
+}
+
+// run ships the ticks of the given queue with the exporter until the context is cancelled. Ticks
+// that are queued while a batch is being shipped are shipped together in the next batch. Batches
+// that fail to ship are dropped.
+func (a *Aggregator) run(ctx context.Context, exporter Exporter, queue <-chan Tick) {
+	logger := a.logger.With(zap.String("exporter", exporter.Name()))
+
+	for {
+		var batch []Tick
+		select {
+		case <-ctx.Done():
+			return
+		case tick := <-queue:
+			batch = append(batch, tick)
+		}
+
+	drain:
+		for len(batch) < MaxBatchSize {
+			select {
+			case tick := <-queue:
+				batch = append(batch, tick)
+			default:
+				break drain
+			}
+		}
+
+		if err := exporter.Export(ctx, batch); err != nil {
+			logger.Error("failed to export prices", zap.Int("ticks", len(batch)), zap.Error(err))
+			continue
+		}
+
+		logger.Debug("exported prices", zap.Int("ticks", len(batch)))
+	}
+}
+
+// SetProviderPrices sets the prices for the given provider.
+func (a *Aggregator) SetProviderPrices(provider string, prices types.Prices) {
+	a.aggregator.SetProviderPrices(provider, prices)
+}
+
+// SetProviderWeights sets the weights for the given provider.
+func (a *Aggregator) SetProviderWeights(provider string, weights types.Weights) {
+	a.aggregator.SetProviderWeights(provider, weights)
+}
+
+// SetProviderVolumes sets the volumes for the given provider.
+func (a *Aggregator) SetProviderVolumes(provider string, volumes types.Volumes) {
+	a.aggregator.SetProviderVolumes(provider, volumes)
+}
+
+// UpdateMarketMap updates the market map of the wrapped aggregator.
+func (a *Aggregator) UpdateMarketMap(marketMap mmtypes.MarketMap) {
+	a.aggregator.UpdateMarketMap(marketMap)
+}
+
+// AggregatePrices aggregates the prices of the wrapped aggregator and queues them to be shipped
+// by each exporter.
+func (a *Aggregator) AggregatePrices() {
+	a.aggregator.AggregatePrices()
+
+	aggregated := a.aggregator.GetPrices()
+	if len(aggregated) == 0 {
+		return
+	}
+
+	tick := Tick{
+		Timestamp: a.now().UTC(),
+		Prices:    make(types.Prices, len(aggregated)),
+	}
+	for pair, price := range aggregated {
+		if price != nil {
+			tick.Prices[pair] = new(big.Float).Copy(price)
+		}
+	}
+
+	for i, queue := range a.queues {
+		select {
+		case queue <- tick:
+		default:
+			a.logger.Debug("export queue is full; dropping tick", zap.String("exporter", a.exporters[i].Name()))
+		}
+	}
+}
+
+// GetPrices returns the aggregated prices of the wrapped aggregator.
+func (a *Aggregator) GetPrices() types.Prices {
+	return a.aggregator.GetPrices()
+}
+
+// GetUnconfirmedPrices returns the unconfirmed prices of the wrapped aggregator.
+func (a *Aggregator) GetUnconfirmedPrices() []string {
+	return a.aggregator.GetUnconfirmedPrices()
+}
+
+// Reset resets the wrapped aggregator.
+func (a *Aggregator) Reset() {
+	a.aggregator.Reset()
+}
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+// maxErrorBodySize is the maximum number of bytes of an error response included in the error.
+const maxErrorBodySize = 512
+
+// Tick is the aggregated prices of a single tick of the oracle.
+type Tick struct {
+	// Timestamp is the time at which the prices were aggregated.
+	Timestamp time.Time
+
+	// Prices are the aggregated prices, keyed by currency pair.
+	Prices types.Prices
+}
+
+// Exporter ships ticks to an external time-series database.
+type Exporter interface {
+	// Name is the name of the backend the exporter ships ticks to.
+	Name() string
+
+	// Export ships the given ticks, in chronological order.
+	Export(ctx context.Context, ticks []Tick) error
+
+	// Close releases the resources held by the exporter.
+	Close() error
+}
+
+// NewExportersFromConfig returns an exporter for each backend enabled in the given config.
+func NewExportersFromConfig(logger *zap.Logger, cfg config.ExportConfig) ([]Exporter, error) {
+	var exporters []Exporter
+
+	if cfg.InfluxDB.Enabled {
+		exporter, err := NewInfluxDBExporter(cfg.InfluxDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create influxdb exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	if cfg.TimescaleDB.Enabled {
+		exporter, err := NewTimescaleDBExporter(cfg.TimescaleDB)
+		if err != nil {
+			closeAll(logger, exporters)
+			return nil, fmt.Errorf("failed to create timescaledb exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	if cfg.RemoteWrite.Enabled {
+		exporter, err := NewRemoteWriteExporter(cfg.RemoteWrite)
+		if err != nil {
+			closeAll(logger, exporters)
+			return nil, fmt.Errorf("failed to create remote write exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	return exporters, nil
+}
+
+// closeAll closes the given exporters, logging any errors.
+func closeAll(logger *zap.Logger, exporters []Exporter) {
+	for _, exporter := range exporters {
+		if err := exporter.Close(); err != nil {
+			logger.Error("failed to close exporter", zap.String("exporter", exporter.Name()), zap.Error(err))
+		}
+	}
+}
+
+// float64Price returns the given price as a float64, which is the value type of the time-series
+// databases prices are exported to.
+func float64Price(price *big.Float) float64 {
+	f, _ := price.Float64()
+	return f
+}
+
+// newHTTPClient returns the client of an exporter that writes to an HTTP endpoint with the given
+// authentication and timeout.
+func newHTTPClient(auth config.Authentication, timeout time.Duration) (*http.Client, error) {
+	var transport http.RoundTripper = connecthttp.NewRoundTripperWithHeaders(
+		http.DefaultTransport,
+		connecthttp.WithConnectVersionUserAgent(),
+	)
+
+	if auth.Enabled() {
+		apiKey, err := secrets.APIKey(auth)
+		if err != nil {
+			return nil, err
+		}
+		transport = connecthttp.NewRoundTripperWithAuthentication(transport, auth.APIKeyHeader, apiKey.Value)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
+
+// post posts the given body to the url with the given headers, and returns an error if the
+// response does not have a 2xx status.
+func post(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package export_test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/export"
+	"github.com/skip-mev/connect/v2/oracle/mocks"
+	"github.com/skip-mev/connect/v2/oracle/types"
+)
+
+var (
+	start = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	ticks = []export.Tick{
+		{
+			Timestamp: start,
+			Prices: types.Prices{
+				"BTC/USD": big.NewFloat(60000.5),
+				"ETH/USD": big.NewFloat(3000),
+			},
+		},
+		{
+			Timestamp: start.Add(time.Second),
+			Prices: types.Prices{
+				"BTC/USD": big.NewFloat(60001),
+			},
+		},
+	}
+)
+
+func TestInfluxDBExporter(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, export.InfluxDBWritePath, r.URL.Path)
+		require.Equal(t, "org", r.URL.Query().Get("org"))
+		require.Equal(t, "prices", r.URL.Query().Get("bucket"))
+		require.Equal(t, "ns", r.URL.Query().Get("precision"))
+		require.Equal(t, "Token secret", r.Header.Get("Authorization"))
+
+		bz, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(bz)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := config.InfluxDBExportConfig{
+		Enabled:     true,
+		URL:         server.URL + "/",
+		Org:         "org",
+		Bucket:      "prices",
+		Measurement: "connect price",
+		Authentication: config.Authentication{
+			APIKey:       "Token secret",
+			APIKeyHeader: "Authorization",
+		},
+		Timeout: time.Second,
+	}
+	exporter, err := export.NewInfluxDBExporter(cfg)
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	require.NoError(t, exporter.Export(context.Background(), ticks))
+	require.Equal(t, strings.Join([]string{
+		`connect\ price,pair=BTC/USD price=60000.5 1717200000000000000`,
+		`connect\ price,pair=ETH/USD price=3000 1717200000000000000`,
+		`connect\ price,pair=BTC/USD price=60001 1717200001000000000`,
+		``,
+	}, "\n"), body)
+
+	// errors of the write api are returned
+	missing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"code":"not found","message":"bucket not found"}`, http.StatusNotFound)
+	}))
+	defer missing.Close()
+
+	cfg.URL = missing.URL
+	exporter, err = export.NewInfluxDBExporter(cfg)
+	require.NoError(t, err)
+	require.ErrorContains(t, exporter.Export(context.Background(), ticks), "bucket not found")
+}
+
+// series is a decoded prometheus.TimeSeries.
+type series struct {
+	labels  map[string]string
+	samples [][2]float64
+}
+
+// field is a decoded protobuf field.
+type field struct {
+	num   protowire.Number
+	bytes []byte
+	value uint64
+}
+
+// decodeFields decodes the fields of an encoded protobuf message.
+func decodeFields(t *testing.T, bz []byte) []field {
+	t.Helper()
+
+	var fields []field
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		require.Positive(t, n)
+		bz = bz[n:]
+
+		f := field{num: num}
+		switch typ {
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(bz)
+		case protowire.Fixed64Type:
+			f.value, n = protowire.ConsumeFixed64(bz)
+		case protowire.VarintType:
+			f.value, n = protowire.ConsumeVarint(bz)
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+		require.Positive(t, n)
+		bz = bz[n:]
+
+		fields = append(fields, f)
+	}
+
+	return fields
+}
+
+// decodeWriteRequest decodes the series of a snappy compressed prometheus.WriteRequest.
+func decodeWriteRequest(t *testing.T, compressed []byte) []series {
+	t.Helper()
+
+	bz, err := snappy.Decode(nil, compressed)
+	require.NoError(t, err)
+
+	var out []series
+	for _, ts := range decodeFields(t, bz) {
+		require.Equal(t, protowire.Number(1), ts.num)
+
+		s := series{labels: make(map[string]string)}
+		for _, f := range decodeFields(t, ts.bytes) {
+			switch f.num {
+			case 1:
+				label := decodeFields(t, f.bytes)
+				s.labels[string(label[0].bytes)] = string(label[1].bytes)
+			case 2:
+				sample := decodeFields(t, f.bytes)
+				s.samples = append(s.samples, [2]float64{math.Float64frombits(sample[0].value), float64(sample[1].value)})
+			}
+		}
+		out = append(out, s)
+	}
+
+	return out
+}
+
+func TestRemoteWriteExporter(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/write", r.URL.Path)
+		require.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		require.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		require.Equal(t, export.RemoteWriteVersion, r.Header.Get("X-Prometheus-Remote-Write-Version"))
+
+		var err error
+		body, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	exporter, err := export.NewRemoteWriteExporter(config.RemoteWriteExportConfig{
+		Enabled:    true,
+		URL:        server.URL + "/api/v1/write",
+		MetricName: "connect_price",
+		Timeout:    time.Second,
+	})
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	require.NoError(t, exporter.Export(context.Background(), ticks))
+	require.Equal(t, []series{
+		{
+			labels: map[string]string{"__name__": "connect_price", "pair": "BTC/USD"},
+			samples: [][2]float64{
+				{60000.5, float64(start.UnixMilli())},
+				{60001, float64(start.Add(time.Second).UnixMilli())},
+			},
+		},
+		{
+			labels:  map[string]string{"__name__": "connect_price", "pair": "ETH/USD"},
+			samples: [][2]float64{{3000, float64(start.UnixMilli())}},
+		},
+	}, decodeWriteRequest(t, body))
+}
+
+// fakeDB records the statements executed on it.
+type fakeDB struct {
+	queries []string
+	args    [][]any
+	closed  bool
+}
+
+func (db *fakeDB) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	db.queries = append(db.queries, query)
+	db.args = append(db.args, args)
+	return nil, nil
+}
+
+func (db *fakeDB) Close() error {
+	db.closed = true
+	return nil
+}
+
+func TestTimescaleDBExporter(t *testing.T) {
+	db := &fakeDB{}
+	exporter, err := export.NewTimescaleDBExporterWithDB(config.TimescaleDBExportConfig{
+		Enabled:   true,
+		DSNSource: "env:CONNECT_TIMESCALEDB_DSN",
+		Table:     "public.prices",
+		Timeout:   time.Second,
+	}, db)
+	require.NoError(t, err)
+
+	// the table is created on construction
+	require.Len(t, db.queries, 2)
+	require.Contains(t, db.queries[0], "CREATE TABLE IF NOT EXISTS public.prices")
+	require.Contains(t, db.queries[1], "create_hypertable")
+	require.Equal(t, []any{"public.prices"}, db.args[1])
+
+	require.NoError(t, exporter.Export(context.Background(), ticks[1:]))
+	require.Len(t, db.queries, 3)
+	require.Equal(t, "INSERT INTO public.prices (time, pair, price) VALUES ($1, $2, $3)", db.queries[2])
+	require.Equal(t, []any{start.Add(time.Second), "BTC/USD", "60001"}, db.args[2])
+
+	require.NoError(t, exporter.Close())
+	require.True(t, db.closed)
+}
+
+// fakeExporter records the ticks exported to it.
+type fakeExporter struct {
+	mtx    sync.Mutex
+	ticks  []export.Tick
+	closed bool
+}
+
+func (e *fakeExporter) Name() string { return "fake" }
+
+func (e *fakeExporter) Export(_ context.Context, ticks []export.Tick) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	e.ticks = append(e.ticks, ticks...)
+	return nil
+}
+
+func (e *fakeExporter) Close() error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	e.closed = true
+	return nil
+}
+
+func TestAggregator(t *testing.T) {
+	wrapped := mocks.NewPriceAggregator(t)
+	wrapped.On("AggregatePrices").Return()
+	wrapped.On("GetPrices").Return(types.Prices{"BTC/USD": big.NewFloat(60000)}).Once()
+	wrapped.On("GetPrices").Return(types.Prices{})
+
+	exporter := &fakeExporter{}
+	aggregator := export.NewAggregator(zap.NewNop(), wrapped, exporter)
+
+	// ticks are queued until the aggregator is run, and ticks without prices are not exported
+	aggregator.AggregatePrices()
+	aggregator.AggregatePrices()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		aggregator.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		exporter.mtx.Lock()
+		defer exporter.mtx.Unlock()
+		return len(exporter.ticks) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "60000", exporter.ticks[0].Prices["BTC/USD"].String())
+
+	// the exporters are closed once the aggregator stops running
+	cancel()
+	<-done
+	require.True(t, exporter.closed)
+}
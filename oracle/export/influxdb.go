@@ -0,0 +1,101 @@
+package export
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+const (
+	// InfluxDBName is the name of the InfluxDB exporter.
+	InfluxDBName = "influxdb"
+
+	// InfluxDBWritePath is the path of the InfluxDB v2 write API.
+	InfluxDBWritePath = "/api/v2/write"
+)
+
+// influxTagEscaper escapes the special characters of measurements and tag values in the line
+// protocol.
+var influxTagEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+var _ Exporter = (*InfluxDBExporter)(nil)
+
+// InfluxDBExporter writes ticks to an InfluxDB bucket in the line protocol. Each price is written
+// as a point of the configured measurement with a pair tag and a price field, e.g.
+//
+//	connect_price,pair=BTC/USD price=60000.5 1717200000000000000
+type InfluxDBExporter struct {
+	cfg    config.InfluxDBExportConfig
+	client *http.Client
+	url    string
+}
+
+// NewInfluxDBExporter returns a new InfluxDBExporter with the given config.
+func NewInfluxDBExporter(cfg config.InfluxDBExportConfig) (*InfluxDBExporter, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	client, err := newHTTPClient(cfg.Authentication, cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("org", cfg.Org)
+	query.Set("bucket", cfg.Bucket)
+	query.Set("precision", "ns")
+
+	return &InfluxDBExporter{
+		cfg:    cfg,
+		client: client,
+		url:    strings.TrimSuffix(cfg.URL, "/") + InfluxDBWritePath + "?" + query.Encode(),
+	}, nil
+}
+
+// Name returns the name of the exporter.
+func (e *InfluxDBExporter) Name() string {
+	return InfluxDBName
+}
+
+// Export writes the prices of the given ticks to the bucket in a single request.
+func (e *InfluxDBExporter) Export(ctx context.Context, ticks []Tick) error {
+	measurement := influxTagEscaper.Replace(e.cfg.Measurement)
+
+	var sb strings.Builder
+	for _, tick := range ticks {
+		timestamp := strconv.FormatInt(tick.Timestamp.UnixNano(), 10)
+
+		pairs := make([]string, 0, len(tick.Prices))
+		for pair := range tick.Prices {
+			pairs = append(pairs, pair)
+		}
+		sort.Strings(pairs)
+
+		for _, pair := range pairs {
+			sb.WriteString(measurement)
+			sb.WriteString(",pair=")
+			sb.WriteString(influxTagEscaper.Replace(pair))
+			sb.WriteString(" price=")
+			sb.WriteString(strconv.FormatFloat(float64Price(tick.Prices[pair]), 'g', -1, 64))
+			sb.WriteByte(' ')
+			sb.WriteString(timestamp)
+			sb.WriteByte('\n')
+		}
+	}
+
+	return post(ctx, e.client, e.url, []byte(sb.String()), map[string]string{
+		"Content-Type": "text/plain; charset=utf-8",
+	})
+}
+
+// Close closes the idle connections of the exporter.
+func (e *InfluxDBExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
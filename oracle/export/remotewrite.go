@@ -0,0 +1,126 @@
+package export
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+const (
+	// RemoteWriteName is the name of the Prometheus remote-write exporter.
+	RemoteWriteName = "remote_write"
+
+	// RemoteWriteVersion is the version of the remote-write protocol the exporter speaks.
+	RemoteWriteVersion = "0.1.0"
+)
+
+var _ Exporter = (*RemoteWriteExporter)(nil)
+
+// RemoteWriteExporter pushes ticks to a Prometheus remote-write endpoint. Each currency pair is
+// written as a series of the configured metric with a pair label, e.g.
+// connect_price{pair="BTC/USD"}.
+type RemoteWriteExporter struct {
+	cfg    config.RemoteWriteExportConfig
+	client *http.Client
+}
+
+// NewRemoteWriteExporter returns a new RemoteWriteExporter with the given config.
+func NewRemoteWriteExporter(cfg config.RemoteWriteExportConfig) (*RemoteWriteExporter, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	client, err := newHTTPClient(cfg.Authentication, cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteWriteExporter{
+		cfg:    cfg,
+		client: client,
+	}, nil
+}
+
+// Name returns the name of the exporter.
+func (e *RemoteWriteExporter) Name() string {
+	return RemoteWriteName
+}
+
+// Export pushes the prices of the given ticks to the endpoint in a single write request.
+func (e *RemoteWriteExporter) Export(ctx context.Context, ticks []Tick) error {
+	return post(ctx, e.client, e.cfg.URL, snappy.Encode(nil, e.encodeWriteRequest(ticks)), map[string]string{
+		"Content-Type":                      "application/x-protobuf",
+		"Content-Encoding":                  "snappy",
+		"X-Prometheus-Remote-Write-Version": RemoteWriteVersion,
+	})
+}
+
+// Close closes the idle connections of the exporter.
+func (e *RemoteWriteExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// encodeWriteRequest returns the protobuf encoding of the prometheus.WriteRequest holding a series
+// per currency pair, with a sample per tick:
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+//
+// Timestamps are in milliseconds, and samples are in chronological order as required by the
+// protocol.
+func (e *RemoteWriteExporter) encodeWriteRequest(ticks []Tick) []byte {
+	samples := make(map[string][]byte)
+	for _, tick := range ticks {
+		for pair, price := range tick.Prices {
+			var sample []byte
+			sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+			sample = protowire.AppendFixed64(sample, math.Float64bits(float64Price(price)))
+			sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+			sample = protowire.AppendVarint(sample, uint64(tick.Timestamp.UnixMilli()))
+
+			samples[pair] = protowire.AppendTag(samples[pair], 2, protowire.BytesType)
+			samples[pair] = protowire.AppendBytes(samples[pair], sample)
+		}
+	}
+
+	pairs := make([]string, 0, len(samples))
+	for pair := range samples {
+		pairs = append(pairs, pair)
+	}
+	sort.Strings(pairs)
+
+	var req []byte
+	for _, pair := range pairs {
+		// labels must be sorted by name.
+		var series []byte
+		series = appendLabel(series, "__name__", e.cfg.MetricName)
+		series = appendLabel(series, "pair", pair)
+		series = append(series, samples[pair]...)
+
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, series)
+	}
+
+	return req
+}
+
+// appendLabel appends a label with the given name and value to an encoded TimeSeries.
+func appendLabel(series []byte, name, value string) []byte {
+	var label []byte
+	label = protowire.AppendTag(label, 1, protowire.BytesType)
+	label = protowire.AppendString(label, name)
+	label = protowire.AppendTag(label, 2, protowire.BytesType)
+	label = protowire.AppendString(label, value)
+
+	series = protowire.AppendTag(series, 1, protowire.BytesType)
+	return protowire.AppendBytes(series, label)
+}
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	// register the postgres driver.
+	_ "github.com/lib/pq"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+const (
+	// TimescaleDBName is the name of the TimescaleDB exporter.
+	TimescaleDBName = "timescaledb"
+
+	// maxInsertRows is the maximum number of rows inserted by a single statement, which keeps the
+	// number of parameters of a statement below the limit of postgres.
+	maxInsertRows = 10000
+)
+
+// DB is the subset of *sql.DB used by the TimescaleDB exporter.
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	Close() error
+}
+
+var _ Exporter = (*TimescaleDBExporter)(nil)
+
+// TimescaleDBExporter inserts ticks into a TimescaleDB table with a row per price:
+//
+//	CREATE TABLE connect_prices (time TIMESTAMPTZ NOT NULL, pair TEXT NOT NULL, price NUMERIC NOT NULL)
+//
+// The table is created as a hypertable partitioned by time if it does not exist. Prices are
+// stored as NUMERIC so that they keep the precision of the aggregated price.
+type TimescaleDBExporter struct {
+	cfg config.TimescaleDBExportConfig
+	db  DB
+}
+
+// NewTimescaleDBExporter returns a new TimescaleDBExporter with the given config, connecting to
+// the database referenced by the config's DSN source.
+func NewTimescaleDBExporter(cfg config.TimescaleDBExportConfig) (*TimescaleDBExporter, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	dsn, err := secrets.Shared(cfg.DSNSource, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("postgres", dsn.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	return NewTimescaleDBExporterWithDB(cfg, db)
+}
+
+// NewTimescaleDBExporterWithDB returns a new TimescaleDBExporter with the given config that
+// inserts into the given database. The table is created if it does not exist.
+func NewTimescaleDBExporterWithDB(cfg config.TimescaleDBExportConfig, db DB) (*TimescaleDBExporter, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	// the table is validated to be an identifier, so it is safe to interpolate.
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (time TIMESTAMPTZ NOT NULL, pair TEXT NOT NULL, price NUMERIC NOT NULL)",
+		cfg.Table,
+	)); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create table %s: %w", cfg.Table, err)
+	}
+
+	if _, err := db.ExecContext(ctx, "SELECT create_hypertable($1, 'time', if_not_exists => TRUE)", cfg.Table); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create hypertable %s (is the timescaledb extension installed?): %w", cfg.Table, err)
+	}
+
+	return &TimescaleDBExporter{
+		cfg: cfg,
+		db:  db,
+	}, nil
+}
+
+// Name returns the name of the exporter.
+func (e *TimescaleDBExporter) Name() string {
+	return TimescaleDBName
+}
+
+// Export inserts the prices of the given ticks, in statements of at most maxInsertRows rows.
+func (e *TimescaleDBExporter) Export(ctx context.Context, ticks []Tick) error {
+	var rows [][]any
+	for _, tick := range ticks {
+		for pair, price := range tick.Prices {
+			rows = append(rows, []any{tick.Timestamp, pair, price.Text('g', -1)})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Timeout)
+	defer cancel()
+
+	for start := 0; start < len(rows); start += maxInsertRows {
+		end := min(start+maxInsertRows, len(rows))
+
+		var (
+			values = make([]string, 0, end-start)
+			args   = make([]any, 0, 3*(end-start))
+		)
+		for _, row := range rows[start:end] {
+			n := len(args)
+			values = append(values, fmt.Sprintf("($%d, $%d, $%d)", n+1, n+2, n+3))
+			args = append(args, row...)
+		}
+
+		if _, err := e.db.ExecContext(ctx, fmt.Sprintf(
+			"INSERT INTO %s (time, pair, price) VALUES %s",
+			e.cfg.Table,
+			strings.Join(values, ", "),
+		), args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the database.
+func (e *TimescaleDBExporter) Close() error {
+	return e.db.Close()
+}
@@ -283,6 +283,12 @@ type noOpPriceAggregator struct{}
 func (n noOpPriceAggregator) SetProviderPrices(_ string, _ oracletypes.Prices) {
 }
 
+func (n noOpPriceAggregator) SetProviderWeights(_ string, _ oracletypes.Weights) {
+}
+
+func (n noOpPriceAggregator) SetProviderVolumes(_ string, _ oracletypes.Volumes) {
+}
+
 func (n noOpPriceAggregator) UpdateMarketMap(_ mmtypes.MarketMap) {
 }
 
@@ -293,6 +299,10 @@ func (n noOpPriceAggregator) GetPrices() oracletypes.Prices {
 	return oracletypes.Prices{}
 }
 
+func (n noOpPriceAggregator) GetUnconfirmedPrices() []string {
+	return nil
+}
+
 func (n noOpPriceAggregator) Reset() {
 }
 
@@ -0,0 +1,176 @@
+package history
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// PruneInterval is the interval at which records older than the retention are pruned.
+const PruneInterval = 10 * time.Minute
+
+var _ oracle.PriceAggregator = (*Aggregator)(nil)
+
+// Aggregator is a price aggregator that persists the aggregated price of every currency pair,
+// along with the prices each provider reported for it, to a Store after every aggregation.
+type Aggregator struct {
+	mtx    sync.Mutex
+	logger *zap.Logger
+
+	// aggregator is the wrapped aggregator.
+	aggregator oracle.PriceAggregator
+
+	// store is the store the records are written to.
+	store *Store
+
+	// retention is how long records are kept for. If zero, records are never pruned.
+	retention time.Duration
+
+	// now returns the current time.
+	now func() time.Time
+
+	// lastPrune is the time records were last pruned.
+	lastPrune time.Time
+
+	// marketMap is the market map used to attribute provider prices to currency pairs.
+	marketMap mmtypes.MarketMap
+
+	// providers is the prices reported by each provider since the last reset.
+	providers map[string]types.Prices
+}
+
+// NewAggregator returns a new Aggregator that wraps the given aggregator and writes the
+// aggregated prices to the store. The given market map is the market map the aggregator was
+// constructed with. Records older than the retention are pruned every PruneInterval, unless the
+// retention is zero.
+func NewAggregator(
+	logger *zap.Logger,
+	aggregator oracle.PriceAggregator,
+	marketMap mmtypes.MarketMap,
+	store *Store,
+	retention time.Duration,
+) *Aggregator {
+	return &Aggregator{
+		logger:     logger.With(zap.String("process", "history")),
+		aggregator: aggregator,
+		store:      store,
+		retention:  retention,
+		now:        time.Now,
+		marketMap:  marketMap,
+		providers:  make(map[string]types.Prices),
+	}
+}
+
+// SetProviderPrices records and sets the prices for the given provider.
+func (a *Aggregator) SetProviderPrices(provider string, prices types.Prices) {
+	a.mtx.Lock()
+	copied := make(types.Prices, len(prices))
+	for ticker, price := range prices {
+		if price != nil {
+			copied[ticker] = new(big.Float).Copy(price)
+		}
+	}
+	a.providers[provider] = copied
+	a.mtx.Unlock()
+
+	a.aggregator.SetProviderPrices(provider, prices)
+}
+
+// SetProviderWeights sets the weights for the given provider.
+func (a *Aggregator) SetProviderWeights(provider string, weights types.Weights) {
+	a.aggregator.SetProviderWeights(provider, weights)
+}
+
+// SetProviderVolumes sets the volumes for the given provider.
+func (a *Aggregator) SetProviderVolumes(provider string, volumes types.Volumes) {
+	a.aggregator.SetProviderVolumes(provider, volumes)
+}
+
+// UpdateMarketMap updates the market map of the wrapped aggregator.
+func (a *Aggregator) UpdateMarketMap(marketMap mmtypes.MarketMap) {
+	a.mtx.Lock()
+	a.marketMap = marketMap
+	a.mtx.Unlock()
+
+	a.aggregator.UpdateMarketMap(marketMap)
+}
+
+// AggregatePrices aggregates the prices of the wrapped aggregator and persists the aggregated
+// prices. Failing to persist the prices is logged, and does not affect the aggregation.
+func (a *Aggregator) AggregatePrices() {
+	a.aggregator.AggregatePrices()
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	now := a.now().UTC()
+	prices := a.aggregator.GetPrices()
+
+	records := make(map[string]Record, len(prices))
+	for pair, price := range prices {
+		if price == nil {
+			continue
+		}
+
+		record := Record{
+			Timestamp: now,
+			Price:     price,
+		}
+
+		if market, ok := a.marketMap.Markets[pair]; ok {
+			for _, cfg := range market.ProviderConfigs {
+				if providerPrice, ok := a.providers[cfg.Name][cfg.OffChainTicker]; ok {
+					if record.Providers == nil {
+						record.Providers = make(map[string]*big.Float)
+					}
+					record.Providers[cfg.Name] = providerPrice
+				}
+			}
+		}
+
+		records[pair] = record
+	}
+
+	if len(records) > 0 {
+		if err := a.store.Write(records); err != nil {
+			a.logger.Error("failed to persist aggregated prices", zap.Error(err))
+		}
+	}
+
+	if a.retention > 0 && now.Sub(a.lastPrune) >= PruneInterval {
+		a.lastPrune = now
+
+		pruned, err := a.store.Prune(now.Add(-a.retention))
+		if err != nil {
+			a.logger.Error("failed to prune price history", zap.Error(err))
+			return
+		}
+
+		a.logger.Debug("pruned price history", zap.Int("records", pruned))
+	}
+}
+
+// GetPrices returns the aggregated prices of the wrapped aggregator.
+func (a *Aggregator) GetPrices() types.Prices {
+	return a.aggregator.GetPrices()
+}
+
+// GetUnconfirmedPrices returns the unconfirmed prices of the wrapped aggregator.
+func (a *Aggregator) GetUnconfirmedPrices() []string {
+	return a.aggregator.GetUnconfirmedPrices()
+}
+
+// Reset resets the wrapped aggregator and the recorded provider prices.
+func (a *Aggregator) Reset() {
+	a.mtx.Lock()
+	a.providers = make(map[string]types.Prices)
+	a.mtx.Unlock()
+
+	a.aggregator.Reset()
+}
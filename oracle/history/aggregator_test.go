@@ -0,0 +1,65 @@
+package history_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/history"
+	"github.com/skip-mev/connect/v2/oracle/metrics"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+var (
+	btcusd = mmtypes.Ticker{
+		CurrencyPair:     pkgtypes.NewCurrencyPair("BTC", "USD"),
+		Decimals:         8,
+		MinProviderCount: 1,
+		Enabled:          true,
+	}
+
+	marketMap = mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			btcusd.String(): {
+				Ticker: btcusd,
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "a", OffChainTicker: "BTC-USD"},
+					{Name: "b", OffChainTicker: "BTCUSD"},
+				},
+			},
+		},
+	}
+)
+
+func TestAggregator(t *testing.T) {
+	store, _ := newStore(t)
+	defer store.Close()
+
+	wrapped, err := oracle.NewIndexPriceAggregator(zap.NewNop(), marketMap, metrics.NewNopMetrics())
+	require.NoError(t, err)
+
+	aggregator := history.NewAggregator(zap.NewNop(), wrapped, marketMap, store, 0)
+
+	aggregator.Reset()
+	aggregator.SetProviderPrices("a", map[string]*big.Float{"BTC-USD": big.NewFloat(60000)})
+	aggregator.SetProviderPrices("b", map[string]*big.Float{"BTCUSD": big.NewFloat(60010)})
+	aggregator.AggregatePrices()
+	aggregated := aggregator.GetPrices()[btcusd.String()]
+
+	// ticks without aggregated prices are not persisted.
+	aggregator.Reset()
+	aggregator.AggregatePrices()
+
+	records, err := store.Query(btcusd.String(), time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	require.Equal(t, aggregated.String(), records[0].Price.String())
+	require.Equal(t, "60000", records[0].Providers["a"].String())
+	require.Equal(t, "60010", records[0].Providers["b"].String())
+}
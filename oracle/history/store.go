@@ -0,0 +1,208 @@
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// openTimeout is the time to wait for the lock on the database file, which is held by the
+// process that has the store open.
+const openTimeout = time.Second
+
+// pricesBucket is the bucket holding a nested bucket of records per currency pair. Records are
+// keyed by their big endian encoded unix nano timestamp, so they are ordered by time.
+var pricesBucket = []byte("prices")
+
+// Record is the aggregated price of a currency pair at a single tick of the oracle.
+type Record struct {
+	// Timestamp is the time at which the price was aggregated.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Price is the aggregated price.
+	Price *big.Float `json:"price"`
+
+	// Providers are the prices each provider reported for the currency pair, keyed by provider
+	// name. Prices are as reported by the provider, i.e. before they are normalized or inverted.
+	Providers map[string]*big.Float `json:"providers,omitempty"`
+}
+
+// Store is an embedded store of the history of aggregated prices, backed by a bolt database.
+// The database file can only be opened by a single process at a time.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the store at the given path, creating it if it does not exist.
+func Open(path string) (*Store, error) {
+	return open(path, false)
+}
+
+// OpenReadOnly opens the existing store at the given path for querying.
+func OpenReadOnly(path string) (*Store, error) {
+	return open(path, true)
+}
+
+func open(path string, readOnly bool) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{
+		Timeout:  openTimeout,
+		ReadOnly: readOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open price history %s (is it open by a running oracle?): %w", path, err)
+	}
+
+	if readOnly {
+		return &Store{db: db}, nil
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(pricesBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize price history %s: %w", path, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Write writes the records of a single tick, keyed by currency pair, in a single transaction.
+func (s *Store) Write(records map[string]Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		prices := tx.Bucket(pricesBucket)
+		for pair, record := range records {
+			bucket, err := prices.CreateBucketIfNotExists([]byte(pair))
+			if err != nil {
+				return fmt.Errorf("failed to create bucket for %s: %w", pair, err)
+			}
+
+			bz, err := json.Marshal(record)
+			if err != nil {
+				return fmt.Errorf("failed to encode record for %s: %w", pair, err)
+			}
+
+			if err := bucket.Put(encodeKey(record.Timestamp), bz); err != nil {
+				return fmt.Errorf("failed to write record for %s: %w", pair, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Query returns the records of the given currency pair with a timestamp in [from, to], in
+// chronological order. A zero to is unbounded. If limit is positive, at most limit records are
+// returned.
+func (s *Store) Query(pair string, from, to time.Time, limit int) ([]Record, error) {
+	records := make([]Record, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prices := tx.Bucket(pricesBucket)
+		if prices == nil {
+			return nil
+		}
+
+		bucket := prices.Bucket([]byte(pair))
+		if bucket == nil {
+			return nil
+		}
+
+		c := bucket.Cursor()
+		for k, v := c.Seek(encodeKey(from)); k != nil; k, v = c.Next() {
+			if !to.IsZero() && decodeKey(k).After(to) {
+				break
+			}
+
+			var record Record
+			if err := json.Unmarshal(v, &record); err != nil {
+				return fmt.Errorf("failed to decode record for %s: %w", pair, err)
+			}
+
+			records = append(records, record)
+			if limit > 0 && len(records) >= limit {
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return records, err
+}
+
+// CurrencyPairs returns the currency pairs that have records, in lexicographic order.
+func (s *Store) CurrencyPairs() ([]string, error) {
+	pairs := make([]string, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prices := tx.Bucket(pricesBucket)
+		if prices == nil {
+			return nil
+		}
+
+		return prices.ForEach(func(k, v []byte) error {
+			// nested buckets have a nil value.
+			if v == nil {
+				pairs = append(pairs, string(k))
+			}
+			return nil
+		})
+	})
+
+	return pairs, err
+}
+
+// Prune deletes all records with a timestamp before the given time, and returns the number of
+// deleted records.
+func (s *Store) Prune(before time.Time) (int, error) {
+	var pruned int
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		prices := tx.Bucket(pricesBucket)
+		return prices.ForEachBucket(func(pair []byte) error {
+			bucket := prices.Bucket(pair)
+
+			// keys are collected before deleting them, as deleting while iterating a cursor
+			// skips keys.
+			var keys [][]byte
+			c := bucket.Cursor()
+			for k, _ := c.First(); k != nil && decodeKey(k).Before(before); k, _ = c.Next() {
+				keys = append(keys, bytes.Clone(k))
+			}
+
+			for _, k := range keys {
+				if err := bucket.Delete(k); err != nil {
+					return fmt.Errorf("failed to prune records of %s: %w", pair, err)
+				}
+			}
+			pruned += len(keys)
+
+			return nil
+		})
+	})
+
+	return pruned, err
+}
+
+// encodeKey returns the key of a record with the given timestamp.
+func encodeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	if !t.IsZero() && t.UnixNano() > 0 {
+		binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	}
+
+	return key
+}
+
+// decodeKey returns the timestamp of a record with the given key.
+func decodeKey(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key))).UTC()
+}
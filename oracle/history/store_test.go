@@ -0,0 +1,157 @@
+package history_test
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/history"
+)
+
+var start = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newStore(t *testing.T) (*history.Store, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "history.db")
+	store, err := history.Open(path)
+	require.NoError(t, err)
+
+	return store, path
+}
+
+// write writes a record of the given price for each pair every second, starting at start.
+func write(t *testing.T, store *history.Store, prices []float64, pairs ...string) {
+	t.Helper()
+
+	for i, price := range prices {
+		records := make(map[string]history.Record)
+		for _, pair := range pairs {
+			records[pair] = history.Record{
+				Timestamp: start.Add(time.Duration(i) * time.Second),
+				Price:     big.NewFloat(price),
+				Providers: map[string]*big.Float{"a": big.NewFloat(price)},
+			}
+		}
+		require.NoError(t, store.Write(records))
+	}
+}
+
+func prices(records []history.Record) []float64 {
+	out := make([]float64, len(records))
+	for i, record := range records {
+		out[i], _ = record.Price.Float64()
+	}
+
+	return out
+}
+
+func TestStoreQuery(t *testing.T) {
+	store, _ := newStore(t)
+	defer store.Close()
+
+	write(t, store, []float64{1, 2, 3, 4, 5}, "BTC/USD", "ETH/USD")
+
+	testCases := []struct {
+		name     string
+		pair     string
+		from     time.Time
+		to       time.Time
+		limit    int
+		expected []float64
+	}{
+		{
+			name:     "all records",
+			pair:     "BTC/USD",
+			expected: []float64{1, 2, 3, 4, 5},
+		},
+		{
+			name:     "inclusive range",
+			pair:     "BTC/USD",
+			from:     start.Add(time.Second),
+			to:       start.Add(3 * time.Second),
+			expected: []float64{2, 3, 4},
+		},
+		{
+			name:     "from only",
+			pair:     "ETH/USD",
+			from:     start.Add(3 * time.Second),
+			expected: []float64{4, 5},
+		},
+		{
+			name:     "limit",
+			pair:     "BTC/USD",
+			from:     start.Add(time.Second),
+			limit:    2,
+			expected: []float64{2, 3},
+		},
+		{
+			name:     "unknown pair",
+			pair:     "SOL/USD",
+			expected: []float64{},
+		},
+		{
+			name:     "range after the last record",
+			pair:     "BTC/USD",
+			from:     start.Add(time.Hour),
+			expected: []float64{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := store.Query(tc.pair, tc.from, tc.to, tc.limit)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, prices(records))
+		})
+	}
+
+	records, err := store.Query("BTC/USD", start, start, 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, start, records[0].Timestamp)
+	require.Equal(t, "1", records[0].Providers["a"].String())
+
+	pairs, err := store.CurrencyPairs()
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC/USD", "ETH/USD"}, pairs)
+}
+
+func TestStorePrune(t *testing.T) {
+	store, _ := newStore(t)
+	defer store.Close()
+
+	write(t, store, []float64{1, 2, 3, 4, 5}, "BTC/USD", "ETH/USD")
+
+	pruned, err := store.Prune(start.Add(3 * time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 6, pruned)
+
+	for _, pair := range []string{"BTC/USD", "ETH/USD"} {
+		records, err := store.Query(pair, time.Time{}, time.Time{}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []float64{4, 5}, prices(records))
+	}
+}
+
+func TestStoreOpen(t *testing.T) {
+	store, path := newStore(t)
+	write(t, store, []float64{1}, "BTC/USD")
+
+	// the database cannot be opened while it is open by another store.
+	_, err := history.OpenReadOnly(path)
+	require.Error(t, err)
+
+	require.NoError(t, store.Close())
+
+	readOnly, err := history.OpenReadOnly(path)
+	require.NoError(t, err)
+	defer readOnly.Close()
+
+	records, err := readOnly.Query("BTC/USD", time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []float64{1}, prices(records))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/log"
 	"github.com/skip-mev/connect/v2/providers/base"
 	mmclienttypes "github.com/skip-mev/connect/v2/service/clients/marketmap/types"
 )
@@ -44,95 +45,124 @@ func (o *OracleImpl) Init(ctx context.Context) error {
 	return nil
 }
 
-// createPriceProvider creates a new price provider for the given provider configuration.
+// createPriceProvider creates a new price provider for the given provider configuration and
+// adds it to the oracle.
 func (o *OracleImpl) createPriceProvider(ctx context.Context, cfg config.ProviderConfig) error {
+	state, err := o.newPriceProviderState(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Add the provider to the oracle.
+	provider := state.Provider
+	o.priceProviders[provider.Name()] = state
+
+	// Add the provider name to the message here since we want these to ignore log sampling limits
+	o.logger.Info(
+		fmt.Sprintf("created %s provider state", provider.Name()),
+		zap.String("provider", provider.Name()),
+		zap.Int("num_tickers", len(provider.GetIDs())),
+	)
+	return nil
+}
+
+// newPriceProviderState constructs the state of a new price provider for the given provider
+// configuration.
+func (o *OracleImpl) newPriceProviderState(ctx context.Context, cfg config.ProviderConfig) (ProviderState, error) {
 	// Create the provider market map. This creates the tickers the provider is configured to
 	// support.
-	tickers, err := types.ProviderTickersFromMarketMap(cfg.Name, o.marketMap)
+	tickers, err := types.ProviderTickersFromMarketMap(cfg.Name, o.activeMarketMap())
+	if err != nil {
+		return ProviderState{}, fmt.Errorf("failed to create %s's provider market map: %w", cfg.Name, err)
+	}
+
+	logger, err := o.providerLogger(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create %s's provider market map: %w", cfg.Name, err)
+		return ProviderState{}, err
 	}
 
 	// Select the query handler based on the provider's configuration.
 	var provider *types.PriceProvider
 	switch {
 	case cfg.API.Enabled:
-		queryHandler, err := o.createAPIQueryHandler(ctx, cfg)
+		queryHandler, err := o.createAPIQueryHandler(ctx, logger, cfg)
 		if err != nil {
-			return fmt.Errorf("failed to create %s's api query handler: %w", cfg.Name, err)
+			return ProviderState{}, fmt.Errorf("failed to create %s's api query handler: %w", cfg.Name, err)
 		}
 
 		provider, err = types.NewPriceProvider(
 			base.WithName[types.ProviderTicker, *big.Float](cfg.Name),
-			base.WithLogger[types.ProviderTicker, *big.Float](o.logger),
+			base.WithLogger[types.ProviderTicker, *big.Float](logger),
 			base.WithAPIQueryHandler(queryHandler),
 			base.WithAPIConfig[types.ProviderTicker, *big.Float](cfg.API),
 			base.WithIDs[types.ProviderTicker, *big.Float](tickers),
 			base.WithMetrics[types.ProviderTicker, *big.Float](o.providerMetrics),
 		)
 		if err != nil {
-			return fmt.Errorf("failed to create %s's provider: %w", cfg.Name, err)
+			return ProviderState{}, fmt.Errorf("failed to create %s's provider: %w", cfg.Name, err)
 		}
 	case cfg.WebSocket.Enabled:
-		queryHandler, err := o.createWebSocketQueryHandler(ctx, cfg)
+		queryHandler, err := o.createWebSocketQueryHandler(ctx, logger, cfg)
 		if err != nil {
-			return fmt.Errorf("failed to create %s's web socket query handler: %w", cfg.Name, err)
+			return ProviderState{}, fmt.Errorf("failed to create %s's web socket query handler: %w", cfg.Name, err)
 		}
 
 		provider, err = types.NewPriceProvider(
 			base.WithName[types.ProviderTicker, *big.Float](cfg.Name),
-			base.WithLogger[types.ProviderTicker, *big.Float](o.logger),
+			base.WithLogger[types.ProviderTicker, *big.Float](logger),
 			base.WithWebSocketQueryHandler(queryHandler),
 			base.WithWebSocketConfig[types.ProviderTicker, *big.Float](cfg.WebSocket),
 			base.WithIDs[types.ProviderTicker, *big.Float](tickers),
 			base.WithMetrics[types.ProviderTicker, *big.Float](o.providerMetrics),
 		)
 		if err != nil {
-			return fmt.Errorf("failed to create %s's provider: %w", cfg.Name, err)
+			return ProviderState{}, fmt.Errorf("failed to create %s's provider: %w", cfg.Name, err)
 		}
 	default:
-		return fmt.Errorf("provider %s has no enabled query handlers", cfg.Name)
+		return ProviderState{}, fmt.Errorf("provider %s has no enabled query handlers", cfg.Name)
 	}
 
-	state := ProviderState{
+	return ProviderState{
 		Provider: provider,
 		Cfg:      cfg,
-	}
+	}, nil
+}
 
-	// Add the provider to the oracle.
-	o.priceProviders[provider.Name()] = state
+// providerLogger returns the logger of the given provider, which only writes logs at or above the
+// provider's configured log level.
+func (o *OracleImpl) providerLogger(cfg config.ProviderConfig) (*zap.Logger, error) {
+	logger, err := log.WithLevel(o.logger, cfg.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s's logger: %w", cfg.Name, err)
+	}
 
-	// Add the provider name to the message here since we want these to ignore log sampling limits
-	o.logger.Info(
-		fmt.Sprintf("created %s provider state", provider.Name()),
-		zap.String("provider", provider.Name()),
-		zap.Int("num_tickers", len(provider.GetIDs())),
-	)
-	return nil
+	return logger, nil
 }
 
 // createAPIQueryHandler creates a new API query handler for the given provider configuration.
 func (o *OracleImpl) createAPIQueryHandler(
 	ctx context.Context,
+	logger *zap.Logger,
 	cfg config.ProviderConfig,
 ) (types.PriceAPIQueryHandler, error) {
 	if o.priceAPIFactory == nil {
 		return nil, fmt.Errorf("cannot create provider; api query handler factory is not set")
 	}
 
-	return o.priceAPIFactory(ctx, o.logger, cfg, o.apiMetrics)
+	return o.priceAPIFactory(ctx, logger, cfg, o.apiMetrics)
 }
 
 // createWebSocketQueryHandler creates a new web socket query handler for the given provider configuration.
 func (o *OracleImpl) createWebSocketQueryHandler(
 	ctx context.Context,
+	logger *zap.Logger,
 	cfg config.ProviderConfig,
 ) (types.PriceWebSocketQueryHandler, error) {
 	if o.priceWSFactory == nil {
 		return nil, fmt.Errorf("cannot create provider; web socket query handler factory is not set")
 	}
 
-	return o.priceWSFactory(ctx, o.logger, cfg, o.wsMetrics)
+	return o.priceWSFactory(ctx, logger, cfg, o.wsMetrics)
 }
 
 // createMarketMapProvider creates a new market map provider for the given provider configuration.
@@ -141,8 +171,13 @@ func (o *OracleImpl) createMarketMapProvider(cfg config.ProviderConfig) error {
 		return fmt.Errorf("cannot create market map provider; market map factory is not set")
 	}
 
+	logger, err := o.providerLogger(cfg)
+	if err != nil {
+		return err
+	}
+
 	mapper, err := o.marketMapperFactory(
-		o.logger,
+		logger,
 		o.providerMetrics,
 		o.apiMetrics,
 		cfg,
@@ -16,19 +16,35 @@ type Oracle interface {
 	GetLastSyncTime() time.Time
 	GetPrices() types.Prices
 	GetMarketMap() mmtypes.MarketMap
+	GetProviderState() map[string]ProviderState
 	Start(ctx context.Context) error
 	Stop()
 }
 
+// Controller defines the runtime controls of an oracle. It is consumed by the admin API of the
+// oracle server.
+type Controller interface {
+	PausePair(ticker string) error
+	ResumePair(ticker string) error
+	GetPausedPairs() []string
+	DisableProvider(name string) error
+	EnableProvider(name string) error
+	GetDisabledProviders() []string
+	RefreshProviders(names ...string) error
+}
+
 // PriceAggregator is an interface for aggregating prices from multiple providers. Implementations of PriceAggregator
 // should be made safe for concurrent use.
 //
 //go:generate mockery --name PriceAggregator
 type PriceAggregator interface {
 	SetProviderPrices(provider string, prices types.Prices)
+	SetProviderWeights(provider string, weights types.Weights)
+	SetProviderVolumes(provider string, volumes types.Volumes)
 	UpdateMarketMap(mmtypes.MarketMap)
 	AggregatePrices()
 	GetPrices() types.Prices
+	GetUnconfirmedPrices() []string
 	Reset()
 }
 
@@ -36,6 +52,8 @@ type PriceAggregator interface {
 type generalProvider interface {
 	// Start starts the provider.
 	Start(ctx context.Context) error
+	// Stop stops the provider, blocking until it has exited.
+	Stop()
 	// Name is the provider's name.
 	Name() string
 }
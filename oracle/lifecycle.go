@@ -2,12 +2,14 @@ package oracle
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/base"
 )
 
 // Start starts the (blocking) oracle. This will initialize the oracle
@@ -16,6 +18,13 @@ func (o *OracleImpl) Start(ctx context.Context) error {
 	o.logger.Info("starting oracle")
 	o.running.Store(true)
 	defer o.running.Store(false)
+
+	// Restrict the markets to the chain's currency pairs before the providers are started. The
+	// markets are unrestricted until the currency pairs are first queried successfully.
+	if o.cpClient != nil {
+		o.syncCurrencyPairs(ctx)
+	}
+
 	if err := o.Init(ctx); err != nil {
 		o.logger.Error("failed to initialize oracle", zap.Error(err))
 		return err
@@ -26,7 +35,7 @@ func (o *OracleImpl) Start(ctx context.Context) error {
 
 	// Start all price providers which have tickers.
 	for name, state := range o.priceProviders {
-		providerTickers, err := types.ProviderTickersFromMarketMap(name, o.marketMap)
+		providerTickers, err := types.ProviderTickersFromMarketMap(name, o.activeMarketMap())
 		if err != nil {
 			o.logger.Error("failed to create provider market map", zap.String("provider", name), zap.Error(err))
 			return err
@@ -57,6 +66,15 @@ func (o *OracleImpl) Start(ctx context.Context) error {
 		}()
 	}
 
+	// Restrict the markets to the chain's currency pairs.
+	if o.cpClient != nil {
+		o.wg.Add(1)
+		go func() {
+			defer o.wg.Done()
+			o.listenForCurrencyPairUpdates(ctx)
+		}()
+	}
+
 	// Start price fetch loop.
 	ticker := time.NewTicker(o.cfg.UpdateInterval)
 	defer ticker.Stop()
@@ -69,7 +87,7 @@ func (o *OracleImpl) Start(ctx context.Context) error {
 			o.logger.Info("oracle stopped via context")
 			return ctx.Err()
 		case <-ticker.C:
-			o.fetchAllPrices()
+			o.fetchAllPrices(ctx)
 		}
 	}
 }
@@ -107,6 +125,12 @@ func (o *OracleImpl) execProviderFn(
 	}
 
 	err := p.Start(ctx)
+	if errors.Is(err, base.ErrAlreadyRunning) {
+		// The provider was started concurrently, e.g. by concurrent market map updates.
+		o.logger.Debug("provider is already running", zap.String("provider", p.Name()))
+		return
+	}
+
 	o.logger.Error("provider exited", zap.String("provider", p.Name()), zap.Error(err))
 }
 
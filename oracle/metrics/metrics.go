@@ -26,13 +26,18 @@ const (
 	// Version is a label for the Connect version.
 	Version = "version"
 
-	TicksMetricName            = "health_check_system_updates_total"
-	TickerTicksMetricName      = "health_check_ticker_updates_total"
-	PricesMetricName           = "provider_price"
-	AggregatePricesMetricName  = "aggregated_price"
-	ProviderTickMetricName     = "health_check_provider_updates_total"
-	ProviderCountMetricName    = "health_check_market_providers"
-	ConnectBuildInfoMetricName = "connect_build_info"
+	TicksMetricName             = "health_check_system_updates_total"
+	TickerTicksMetricName       = "health_check_ticker_updates_total"
+	PricesMetricName            = "provider_price"
+	AggregatePricesMetricName   = "aggregated_price"
+	ObservedDeviationMetricName = "provider_observed_deviation"
+	ProviderTickMetricName      = "health_check_provider_updates_total"
+	ProviderCountMetricName     = "health_check_market_providers"
+	StalePricesMetricName       = "health_check_provider_stale_prices_total"
+	CachedPricesMetricName      = "health_check_provider_cached_prices_total"
+	OutlierPricesMetricName     = "health_check_provider_outlier_prices_total"
+	OutOfBoundsPricesMetricName = "health_check_provider_out_of_bounds_prices_total"
+	ConnectBuildInfoMetricName  = "connect_build_info"
 )
 
 // Metrics is an interface that defines the API for oracle metrics.
@@ -53,11 +58,31 @@ type Metrics interface {
 	// UpdateAggregatePrice updates the aggregated price for the given pairID.
 	UpdateAggregatePrice(pairID string, decimals uint64, price float64)
 
+	// UpdateObservedDeviation updates the deviation of an observe-only provider's price for the
+	// given pairID from the aggregated price, as a fraction of the aggregated price.
+	UpdateObservedDeviation(providerName, pairID string, deviation float64)
+
 	// AddProviderTick increments the number of ticks for a given provider. Specifically,
 	// this is used to track the number of times a provider included a price update that
 	// was used in the aggregation.
 	AddProviderTick(providerName, pairID string, success bool)
 
+	// AddStalePrice increments the number of prices for a given provider that were rejected
+	// because they were older than the maximum price age (which is defined by the oracle config).
+	AddStalePrice(providerName, pairID string)
+
+	// AddCachedPrice increments the number of prices for a given provider that were served from
+	// the provider's last good price because the provider's latest fetch of the price failed.
+	AddCachedPrice(providerName, pairID string)
+
+	// AddOutlierPrice increments the number of prices for a given provider that were rejected
+	// because they deviated too far from the prices of the other providers.
+	AddOutlierPrice(providerName, pairID string)
+
+	// AddOutOfBoundsPrice increments the number of prices for a given provider that were rejected
+	// because they were outside of the market's configured price bounds.
+	AddOutOfBoundsPrice(providerName, pairID string)
+
 	// AddProviderCountForMarket increments the number of providers that were utilized
 	// to calculate the final price for a given market.
 	AddProviderCountForMarket(pairID string, count int)
@@ -78,7 +103,12 @@ type OracleMetricsImpl struct {
 	promTickerTicks       *prometheus.CounterVec
 	promPrices            *prometheus.GaugeVec
 	promAggregatePrices   *prometheus.GaugeVec
+	promObservedDeviation *prometheus.GaugeVec
 	promProviderTick      *prometheus.CounterVec
+	promStalePrices       *prometheus.CounterVec
+	promCachedPrices      *prometheus.CounterVec
+	promOutlierPrices     *prometheus.CounterVec
+	promOutOfBoundsPrices *prometheus.CounterVec
 	promProviderCount     *prometheus.GaugeVec
 	promConnectBuildInfo  *prometheus.GaugeVec
 	statsdClient          statsd.ClientInterface
@@ -145,11 +175,36 @@ func NewMetrics(statsdClient statsd.ClientInterface, nodeIdentifier string) Metr
 		Name:      AggregatePricesMetricName,
 		Help:      "Aggregate price for a given currency pair",
 	}, []string{PairIDLabel, DecimalsLabel})
+	ret.promObservedDeviation = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: OracleSubsystem,
+		Name:      ObservedDeviationMetricName,
+		Help:      "Deviation of an observe-only provider's price from the aggregated price, as a fraction of the aggregated price.",
+	}, []string{ProviderLabel, PairIDLabel})
 	ret.promProviderTick = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: OracleSubsystem,
 		Name:      ProviderTickMetricName,
 		Help:      "Number of ticks with a successful provider update.",
 	}, []string{ProviderLabel, PairIDLabel, SuccessLabel})
+	ret.promStalePrices = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: OracleSubsystem,
+		Name:      StalePricesMetricName,
+		Help:      "Number of provider prices that were rejected for being older than the max price age.",
+	}, []string{ProviderLabel, PairIDLabel})
+	ret.promCachedPrices = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: OracleSubsystem,
+		Name:      CachedPricesMetricName,
+		Help:      "Number of provider prices that were served from the last good price after a failed fetch.",
+	}, []string{ProviderLabel, PairIDLabel})
+	ret.promOutlierPrices = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: OracleSubsystem,
+		Name:      OutlierPricesMetricName,
+		Help:      "Number of provider prices that were rejected for deviating from the prices of the other providers.",
+	}, []string{ProviderLabel, PairIDLabel})
+	ret.promOutOfBoundsPrices = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: OracleSubsystem,
+		Name:      OutOfBoundsPricesMetricName,
+		Help:      "Number of provider prices that were rejected for being outside of the market's price bounds.",
+	}, []string{ProviderLabel, PairIDLabel})
 	ret.promProviderCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: OracleSubsystem,
 		Name:      ProviderCountMetricName,
@@ -165,7 +220,12 @@ func NewMetrics(statsdClient statsd.ClientInterface, nodeIdentifier string) Metr
 	prometheus.MustRegister(ret.promTickerTicks)
 	prometheus.MustRegister(ret.promPrices)
 	prometheus.MustRegister(ret.promAggregatePrices)
+	prometheus.MustRegister(ret.promObservedDeviation)
 	prometheus.MustRegister(ret.promProviderTick)
+	prometheus.MustRegister(ret.promStalePrices)
+	prometheus.MustRegister(ret.promCachedPrices)
+	prometheus.MustRegister(ret.promOutlierPrices)
+	prometheus.MustRegister(ret.promOutOfBoundsPrices)
 	prometheus.MustRegister(ret.promProviderCount)
 	prometheus.MustRegister(ret.promConnectBuildInfo)
 
@@ -196,11 +256,31 @@ func (m *noOpOracleMetrics) UpdatePrice(_, _ string, _ uint64, _ float64) {}
 // UpdateAggregatePrice updates the aggregated price for the given pairID.
 func (m *noOpOracleMetrics) UpdateAggregatePrice(string, uint64, float64) {}
 
+// UpdateObservedDeviation updates the deviation of an observe-only provider's price from the
+// aggregated price.
+func (m *noOpOracleMetrics) UpdateObservedDeviation(string, string, float64) {}
+
 // AddProviderTick increments the number of ticks for a given provider. Specifically,
 // this is used to track the number of times a provider included a price update that
 // was used in the aggregation.
 func (m *noOpOracleMetrics) AddProviderTick(_, _ string, _ bool) {}
 
+// AddStalePrice increments the number of prices for a given provider that were rejected
+// because they were older than the maximum price age.
+func (m *noOpOracleMetrics) AddStalePrice(_, _ string) {}
+
+// AddCachedPrice increments the number of prices for a given provider that were served from
+// the provider's last good price because the provider's latest fetch of the price failed.
+func (m *noOpOracleMetrics) AddCachedPrice(_, _ string) {}
+
+// AddOutlierPrice increments the number of prices for a given provider that were rejected
+// because they deviated too far from the prices of the other providers.
+func (m *noOpOracleMetrics) AddOutlierPrice(_, _ string) {}
+
+// AddOutOfBoundsPrice increments the number of prices for a given provider that were rejected
+// because they were outside of the market's configured price bounds.
+func (m *noOpOracleMetrics) AddOutOfBoundsPrice(_, _ string) {}
+
 // AddProviderCountForMarket increments the number of providers that were utilized
 // to calculate the final price for a given market.
 func (m *noOpOracleMetrics) AddProviderCountForMarket(string, int) {}
@@ -259,6 +339,19 @@ func (m *OracleMetricsImpl) UpdateAggregatePrice(
 	m.statsdClient.Gauge(metricName, price, []string{fmt.Sprintf("%d", decimals)}, 1)
 }
 
+// UpdateObservedDeviation updates the deviation of an observe-only provider's price for the given
+// pairID from the aggregated price.
+func (m *OracleMetricsImpl) UpdateObservedDeviation(providerName, pairID string, deviation float64) {
+	m.promObservedDeviation.With(prometheus.Labels{
+		ProviderLabel: strings.ToLower(providerName),
+		PairIDLabel:   strings.ToLower(pairID),
+	},
+	).Set(deviation)
+
+	metricName := strings.Join([]string{ObservedDeviationMetricName, m.nodeIdentifier, strings.ToLower(providerName), strings.ToLower(pairID)}, ".")
+	m.statsdClient.Gauge(metricName, deviation, []string{}, 1)
+}
+
 // AddProviderTick increments the number of ticks for a given provider. Specifically,
 // this is used to track the number of times a provider included a price update that
 // was used in the aggregation.
@@ -274,6 +367,58 @@ func (m *OracleMetricsImpl) AddProviderTick(providerName, pairID string, success
 	m.statsdClient.Incr(metricName, []string{fmt.Sprintf("%t", success)}, 1)
 }
 
+// AddStalePrice increments the number of prices for a given provider that were rejected
+// because they were older than the maximum price age.
+func (m *OracleMetricsImpl) AddStalePrice(providerName, pairID string) {
+	m.promStalePrices.With(prometheus.Labels{
+		ProviderLabel: strings.ToLower(providerName),
+		PairIDLabel:   strings.ToLower(pairID),
+	},
+	).Add(1)
+
+	metricName := strings.Join([]string{StalePricesMetricName, m.nodeIdentifier, strings.ToLower(providerName), strings.ToLower(pairID)}, ".")
+	m.statsdClient.Incr(metricName, []string{}, 1)
+}
+
+// AddCachedPrice increments the number of prices for a given provider that were served from
+// the provider's last good price because the provider's latest fetch of the price failed.
+func (m *OracleMetricsImpl) AddCachedPrice(providerName, pairID string) {
+	m.promCachedPrices.With(prometheus.Labels{
+		ProviderLabel: strings.ToLower(providerName),
+		PairIDLabel:   strings.ToLower(pairID),
+	},
+	).Add(1)
+
+	metricName := strings.Join([]string{CachedPricesMetricName, m.nodeIdentifier, strings.ToLower(providerName), strings.ToLower(pairID)}, ".")
+	m.statsdClient.Incr(metricName, []string{}, 1)
+}
+
+// AddOutlierPrice increments the number of prices for a given provider that were rejected
+// because they deviated too far from the prices of the other providers.
+func (m *OracleMetricsImpl) AddOutlierPrice(providerName, pairID string) {
+	m.promOutlierPrices.With(prometheus.Labels{
+		ProviderLabel: strings.ToLower(providerName),
+		PairIDLabel:   strings.ToLower(pairID),
+	},
+	).Add(1)
+
+	metricName := strings.Join([]string{OutlierPricesMetricName, m.nodeIdentifier, strings.ToLower(providerName), strings.ToLower(pairID)}, ".")
+	m.statsdClient.Incr(metricName, []string{}, 1)
+}
+
+// AddOutOfBoundsPrice increments the number of prices for a given provider that were rejected
+// because they were outside of the market's configured price bounds.
+func (m *OracleMetricsImpl) AddOutOfBoundsPrice(providerName, pairID string) {
+	m.promOutOfBoundsPrices.With(prometheus.Labels{
+		ProviderLabel: strings.ToLower(providerName),
+		PairIDLabel:   strings.ToLower(pairID),
+	},
+	).Add(1)
+
+	metricName := strings.Join([]string{OutOfBoundsPricesMetricName, m.nodeIdentifier, strings.ToLower(providerName), strings.ToLower(pairID)}, ".")
+	m.statsdClient.Incr(metricName, []string{}, 1)
+}
+
 // AddProviderCountForMarket increments the number of providers that were utilized
 // to calculate the final price for a given market.
 func (m *OracleMetricsImpl) AddProviderCountForMarket(market string, count int) {
@@ -17,6 +17,108 @@ func (_m *Metrics) EXPECT() *Metrics_Expecter {
 	return &Metrics_Expecter{mock: &_m.Mock}
 }
 
+// AddCachedPrice provides a mock function with given fields: providerName, pairID
+func (_m *Metrics) AddCachedPrice(providerName string, pairID string) {
+	_m.Called(providerName, pairID)
+}
+
+// Metrics_AddCachedPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCachedPrice'
+type Metrics_AddCachedPrice_Call struct {
+	*mock.Call
+}
+
+// AddCachedPrice is a helper method to define mock.On call
+//   - providerName string
+//   - pairID string
+func (_e *Metrics_Expecter) AddCachedPrice(providerName interface{}, pairID interface{}) *Metrics_AddCachedPrice_Call {
+	return &Metrics_AddCachedPrice_Call{Call: _e.mock.On("AddCachedPrice", providerName, pairID)}
+}
+
+func (_c *Metrics_AddCachedPrice_Call) Run(run func(providerName string, pairID string)) *Metrics_AddCachedPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Metrics_AddCachedPrice_Call) Return() *Metrics_AddCachedPrice_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Metrics_AddCachedPrice_Call) RunAndReturn(run func(string, string)) *Metrics_AddCachedPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddOutOfBoundsPrice provides a mock function with given fields: providerName, pairID
+func (_m *Metrics) AddOutOfBoundsPrice(providerName string, pairID string) {
+	_m.Called(providerName, pairID)
+}
+
+// Metrics_AddOutOfBoundsPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOutOfBoundsPrice'
+type Metrics_AddOutOfBoundsPrice_Call struct {
+	*mock.Call
+}
+
+// AddOutOfBoundsPrice is a helper method to define mock.On call
+//   - providerName string
+//   - pairID string
+func (_e *Metrics_Expecter) AddOutOfBoundsPrice(providerName interface{}, pairID interface{}) *Metrics_AddOutOfBoundsPrice_Call {
+	return &Metrics_AddOutOfBoundsPrice_Call{Call: _e.mock.On("AddOutOfBoundsPrice", providerName, pairID)}
+}
+
+func (_c *Metrics_AddOutOfBoundsPrice_Call) Run(run func(providerName string, pairID string)) *Metrics_AddOutOfBoundsPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Metrics_AddOutOfBoundsPrice_Call) Return() *Metrics_AddOutOfBoundsPrice_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Metrics_AddOutOfBoundsPrice_Call) RunAndReturn(run func(string, string)) *Metrics_AddOutOfBoundsPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddOutlierPrice provides a mock function with given fields: providerName, pairID
+func (_m *Metrics) AddOutlierPrice(providerName string, pairID string) {
+	_m.Called(providerName, pairID)
+}
+
+// Metrics_AddOutlierPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOutlierPrice'
+type Metrics_AddOutlierPrice_Call struct {
+	*mock.Call
+}
+
+// AddOutlierPrice is a helper method to define mock.On call
+//   - providerName string
+//   - pairID string
+func (_e *Metrics_Expecter) AddOutlierPrice(providerName interface{}, pairID interface{}) *Metrics_AddOutlierPrice_Call {
+	return &Metrics_AddOutlierPrice_Call{Call: _e.mock.On("AddOutlierPrice", providerName, pairID)}
+}
+
+func (_c *Metrics_AddOutlierPrice_Call) Run(run func(providerName string, pairID string)) *Metrics_AddOutlierPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Metrics_AddOutlierPrice_Call) Return() *Metrics_AddOutlierPrice_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Metrics_AddOutlierPrice_Call) RunAndReturn(run func(string, string)) *Metrics_AddOutlierPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddProviderCountForMarket provides a mock function with given fields: pairID, count
 func (_m *Metrics) AddProviderCountForMarket(pairID string, count int) {
 	_m.Called(pairID, count)
@@ -86,6 +188,40 @@ func (_c *Metrics_AddProviderTick_Call) RunAndReturn(run func(string, string, bo
 	return _c
 }
 
+// AddStalePrice provides a mock function with given fields: providerName, pairID
+func (_m *Metrics) AddStalePrice(providerName string, pairID string) {
+	_m.Called(providerName, pairID)
+}
+
+// Metrics_AddStalePrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddStalePrice'
+type Metrics_AddStalePrice_Call struct {
+	*mock.Call
+}
+
+// AddStalePrice is a helper method to define mock.On call
+//   - providerName string
+//   - pairID string
+func (_e *Metrics_Expecter) AddStalePrice(providerName interface{}, pairID interface{}) *Metrics_AddStalePrice_Call {
+	return &Metrics_AddStalePrice_Call{Call: _e.mock.On("AddStalePrice", providerName, pairID)}
+}
+
+func (_c *Metrics_AddStalePrice_Call) Run(run func(providerName string, pairID string)) *Metrics_AddStalePrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Metrics_AddStalePrice_Call) Return() *Metrics_AddStalePrice_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Metrics_AddStalePrice_Call) RunAndReturn(run func(string, string)) *Metrics_AddStalePrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddTick provides a mock function with given fields:
 func (_m *Metrics) AddTick() {
 	_m.Called()
@@ -298,6 +434,41 @@ func (_c *Metrics_UpdateAggregatePrice_Call) RunAndReturn(run func(string, uint6
 	return _c
 }
 
+// UpdateObservedDeviation provides a mock function with given fields: providerName, pairID, deviation
+func (_m *Metrics) UpdateObservedDeviation(providerName string, pairID string, deviation float64) {
+	_m.Called(providerName, pairID, deviation)
+}
+
+// Metrics_UpdateObservedDeviation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateObservedDeviation'
+type Metrics_UpdateObservedDeviation_Call struct {
+	*mock.Call
+}
+
+// UpdateObservedDeviation is a helper method to define mock.On call
+//   - providerName string
+//   - pairID string
+//   - deviation float64
+func (_e *Metrics_Expecter) UpdateObservedDeviation(providerName interface{}, pairID interface{}, deviation interface{}) *Metrics_UpdateObservedDeviation_Call {
+	return &Metrics_UpdateObservedDeviation_Call{Call: _e.mock.On("UpdateObservedDeviation", providerName, pairID, deviation)}
+}
+
+func (_c *Metrics_UpdateObservedDeviation_Call) Run(run func(providerName string, pairID string, deviation float64)) *Metrics_UpdateObservedDeviation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *Metrics_UpdateObservedDeviation_Call) Return() *Metrics_UpdateObservedDeviation_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Metrics_UpdateObservedDeviation_Call) RunAndReturn(run func(string, string, float64)) *Metrics_UpdateObservedDeviation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePrice provides a mock function with given fields: name, pairID, decimals, price
 func (_m *Metrics) UpdatePrice(name string, pairID string, decimals uint64, price float64) {
 	_m.Called(name, pairID, decimals, price)
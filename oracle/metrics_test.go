@@ -13,6 +13,7 @@ import (
 	mathtestutils "github.com/skip-mev/connect/v2/pkg/math/testutils"
 	"github.com/skip-mev/connect/v2/providers/base/testutils"
 	oraclefactory "github.com/skip-mev/connect/v2/providers/factories/oracle"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
 
 func (s *OracleTestSuite) TestMetrics() {
@@ -64,3 +65,130 @@ func (s *OracleTestSuite) TestMetrics() {
 	testOracle.Stop() // block on the oracle actually closing
 	metrics.AssertExpectations(s.T())
 }
+
+func (s *OracleTestSuite) TestStalePriceMetrics() {
+	cfg := config.OracleConfig{
+		UpdateInterval: 1 * time.Second,
+		MaxPriceAge:    1 * time.Minute,
+		Providers:      nil,
+		Metrics:        oracleCfg.Metrics,
+		Host:           oracleCfg.Host,
+		Port:           oracleCfg.Port,
+	}
+	resolved := types.ResolvedPrices{
+		s.currencyPairs[0]: {
+			Value:     big.NewFloat(100),
+			Timestamp: time.Date(1738, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	response := providertypes.NewGetResponse[types.ProviderTicker, *big.Float](resolved, nil)
+	provider := testutils.CreateAPIProviderWithGetResponses[types.ProviderTicker, *big.Float](
+		s.T(),
+		s.logger,
+		providerCfg1,
+		s.currencyPairs,
+		[]providertypes.GetResponse[types.ProviderTicker, *big.Float]{response},
+		200*time.Millisecond,
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*cfg.UpdateInterval)
+	defer cancel()
+
+	metrics := metricmocks.NewMetrics(s.T())
+	testOracle, err := oracle.New(
+		cfg,
+		mathtestutils.NewMedianAggregator(),
+		oracle.WithLogger(s.logger),
+		oracle.WithPriceProviders(provider),
+		oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+		oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+		oracle.WithMarketMap(s.marketmap),
+		oracle.WithMetrics(metrics),
+	)
+	s.Require().NoError(err)
+
+	metrics.EXPECT().SetConnectBuildInfo().Return()
+	metrics.EXPECT().AddTick().Return()
+	metrics.EXPECT().AddStalePrice(providerCfg1.Name, s.currencyPairs[0].GetOffChainTicker()).Return()
+
+	go func() {
+		err := testOracle.Start(ctx)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+				s.T().Errorf("Start() should have returned context.Canceled error. Got: %v", err)
+			}
+		}
+	}()
+
+	time.Sleep(3 * cfg.UpdateInterval)
+	s.Require().Equal(types.Prices{}, testOracle.GetPrices())
+	testOracle.Stop() // block on the oracle actually closing
+	metrics.AssertExpectations(s.T())
+}
+
+func (s *OracleTestSuite) TestCachedPriceMetrics() {
+	cfg := config.OracleConfig{
+		UpdateInterval: 1 * time.Second,
+		MaxPriceAge:    1 * time.Minute,
+		Providers:      nil,
+		Metrics:        oracleCfg.Metrics,
+		Host:           oracleCfg.Host,
+		Port:           oracleCfg.Port,
+	}
+
+	// The provider's latest fetch fails after a successful fetch, so its last good price is reused.
+	resolved := types.ResolvedPrices{
+		s.currencyPairs[0]: {
+			Value:     big.NewFloat(100),
+			Timestamp: time.Now().UTC(),
+		},
+	}
+	unresolved := types.UnResolvedPrices{
+		s.currencyPairs[0]: {
+			ErrorWithCode: providertypes.NewErrorWithCode(errors.New("no response"), providertypes.ErrorNoResponse),
+		},
+	}
+	provider := testutils.CreateAPIProviderWithGetResponses[types.ProviderTicker, *big.Float](
+		s.T(),
+		s.logger,
+		providerCfg1,
+		s.currencyPairs,
+		[]providertypes.GetResponse[types.ProviderTicker, *big.Float]{
+			providertypes.NewGetResponse[types.ProviderTicker, *big.Float](resolved, nil),
+			providertypes.NewGetResponse[types.ProviderTicker, *big.Float](nil, unresolved),
+		},
+		200*time.Millisecond,
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*cfg.UpdateInterval)
+	defer cancel()
+
+	metrics := metricmocks.NewMetrics(s.T())
+	testOracle, err := oracle.New(
+		cfg,
+		mathtestutils.NewMedianAggregator(),
+		oracle.WithLogger(s.logger),
+		oracle.WithPriceProviders(provider),
+		oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+		oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+		oracle.WithMarketMap(s.marketmap),
+		oracle.WithMetrics(metrics),
+	)
+	s.Require().NoError(err)
+
+	metrics.EXPECT().SetConnectBuildInfo().Return()
+	metrics.EXPECT().AddTick().Return()
+	metrics.EXPECT().AddCachedPrice(providerCfg1.Name, s.currencyPairs[0].GetOffChainTicker()).Return()
+
+	go func() {
+		err := testOracle.Start(ctx)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+				s.T().Errorf("Start() should have returned context.Canceled error. Got: %v", err)
+			}
+		}
+	}()
+
+	time.Sleep(3 * cfg.UpdateInterval)
+	s.Require().Contains(testOracle.GetPrices(), s.currencyPairs[0].GetOffChainTicker())
+	testOracle.Stop() // block on the oracle actually closing
+	metrics.AssertExpectations(s.T())
+}
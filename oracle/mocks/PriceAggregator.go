@@ -102,6 +102,53 @@ func (_c *PriceAggregator_GetPrices_Call) RunAndReturn(run func() map[string]*bi
 	return _c
 }
 
+// GetUnconfirmedPrices provides a mock function with given fields:
+func (_m *PriceAggregator) GetUnconfirmedPrices() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnconfirmedPrices")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// PriceAggregator_GetUnconfirmedPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnconfirmedPrices'
+type PriceAggregator_GetUnconfirmedPrices_Call struct {
+	*mock.Call
+}
+
+// GetUnconfirmedPrices is a helper method to define mock.On call
+func (_e *PriceAggregator_Expecter) GetUnconfirmedPrices() *PriceAggregator_GetUnconfirmedPrices_Call {
+	return &PriceAggregator_GetUnconfirmedPrices_Call{Call: _e.mock.On("GetUnconfirmedPrices")}
+}
+
+func (_c *PriceAggregator_GetUnconfirmedPrices_Call) Run(run func()) *PriceAggregator_GetUnconfirmedPrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *PriceAggregator_GetUnconfirmedPrices_Call) Return(_a0 []string) *PriceAggregator_GetUnconfirmedPrices_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PriceAggregator_GetUnconfirmedPrices_Call) RunAndReturn(run func() []string) *PriceAggregator_GetUnconfirmedPrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Reset provides a mock function with given fields:
 func (_m *PriceAggregator) Reset() {
 	_m.Called()
@@ -168,6 +215,74 @@ func (_c *PriceAggregator_SetProviderPrices_Call) RunAndReturn(run func(string,
 	return _c
 }
 
+// SetProviderWeights provides a mock function with given fields: provider, weights
+func (_m *PriceAggregator) SetProviderWeights(provider string, weights map[string]float64) {
+	_m.Called(provider, weights)
+}
+
+// PriceAggregator_SetProviderWeights_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetProviderWeights'
+type PriceAggregator_SetProviderWeights_Call struct {
+	*mock.Call
+}
+
+// SetProviderWeights is a helper method to define mock.On call
+//   - provider string
+//   - weights map[string]float64
+func (_e *PriceAggregator_Expecter) SetProviderWeights(provider interface{}, weights interface{}) *PriceAggregator_SetProviderWeights_Call {
+	return &PriceAggregator_SetProviderWeights_Call{Call: _e.mock.On("SetProviderWeights", provider, weights)}
+}
+
+func (_c *PriceAggregator_SetProviderWeights_Call) Run(run func(provider string, weights map[string]float64)) *PriceAggregator_SetProviderWeights_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]float64))
+	})
+	return _c
+}
+
+func (_c *PriceAggregator_SetProviderWeights_Call) Return() *PriceAggregator_SetProviderWeights_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PriceAggregator_SetProviderWeights_Call) RunAndReturn(run func(string, map[string]float64)) *PriceAggregator_SetProviderWeights_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetProviderVolumes provides a mock function with given fields: provider, volumes
+func (_m *PriceAggregator) SetProviderVolumes(provider string, volumes map[string]float64) {
+	_m.Called(provider, volumes)
+}
+
+// PriceAggregator_SetProviderVolumes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetProviderVolumes'
+type PriceAggregator_SetProviderVolumes_Call struct {
+	*mock.Call
+}
+
+// SetProviderVolumes is a helper method to define mock.On call
+//   - provider string
+//   - volumes map[string]float64
+func (_e *PriceAggregator_Expecter) SetProviderVolumes(provider interface{}, volumes interface{}) *PriceAggregator_SetProviderVolumes_Call {
+	return &PriceAggregator_SetProviderVolumes_Call{Call: _e.mock.On("SetProviderVolumes", provider, volumes)}
+}
+
+func (_c *PriceAggregator_SetProviderVolumes_Call) Run(run func(provider string, volumes map[string]float64)) *PriceAggregator_SetProviderVolumes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]float64))
+	})
+	return _c
+}
+
+func (_c *PriceAggregator_SetProviderVolumes_Call) Return() *PriceAggregator_SetProviderVolumes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *PriceAggregator_SetProviderVolumes_Call) RunAndReturn(run func(string, map[string]float64)) *PriceAggregator_SetProviderVolumes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateMarketMap provides a mock function with given fields: _a0
 func (_m *PriceAggregator) UpdateMarketMap(_a0 types.MarketMap) {
 	_m.Called(_a0)
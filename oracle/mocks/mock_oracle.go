@@ -8,6 +8,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	oracle "github.com/skip-mev/connect/v2/oracle"
+
 	time "time"
 
 	types "github.com/skip-mev/connect/v2/x/marketmap/types"
@@ -163,6 +165,53 @@ func (_c *Oracle_GetPrices_Call) RunAndReturn(run func() map[string]*big.Float)
 	return _c
 }
 
+// GetProviderState provides a mock function with given fields:
+func (_m *Oracle) GetProviderState() map[string]oracle.ProviderState {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProviderState")
+	}
+
+	var r0 map[string]oracle.ProviderState
+	if rf, ok := ret.Get(0).(func() map[string]oracle.ProviderState); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]oracle.ProviderState)
+		}
+	}
+
+	return r0
+}
+
+// Oracle_GetProviderState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProviderState'
+type Oracle_GetProviderState_Call struct {
+	*mock.Call
+}
+
+// GetProviderState is a helper method to define mock.On call
+func (_e *Oracle_Expecter) GetProviderState() *Oracle_GetProviderState_Call {
+	return &Oracle_GetProviderState_Call{Call: _e.mock.On("GetProviderState")}
+}
+
+func (_c *Oracle_GetProviderState_Call) Run(run func()) *Oracle_GetProviderState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Oracle_GetProviderState_Call) Return(_a0 map[string]oracle.ProviderState) *Oracle_GetProviderState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Oracle_GetProviderState_Call) RunAndReturn(run func() map[string]oracle.ProviderState) *Oracle_GetProviderState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsRunning provides a mock function with given fields:
 func (_m *Oracle) IsRunning() bool {
 	ret := _m.Called()
@@ -7,6 +7,7 @@ import (
 	"github.com/skip-mev/connect/v2/oracle/types"
 	mmclienttypes "github.com/skip-mev/connect/v2/service/clients/marketmap/types"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	oracletypes "github.com/skip-mev/connect/v2/x/oracle/types"
 )
 
 // Option is a functional option for the market map state.
@@ -70,6 +71,19 @@ func WithMarketMapperFactory(factory mmclienttypes.MarketMapFactory) Option {
 	}
 }
 
+// WithCurrencyPairClient sets the client used to query the currency pairs tracked by the chain's
+// x/oracle module. If set, the markets the oracle prices are restricted to the chain's currency
+// pairs, which are queried at the interval of the oracle config's currency pairs config.
+func WithCurrencyPairClient(client oracletypes.QueryClient) Option {
+	return func(m *OracleImpl) {
+		if client == nil {
+			panic("currency pair client cannot be nil")
+		}
+
+		m.cpClient = client
+	}
+}
+
 // WithWriteTo sets the file path to which market map updates will be written to. Note that this is optional.
 func WithWriteTo(filePath string) Option {
 	return func(m *OracleImpl) {
@@ -3,6 +3,7 @@ package oracle
 import (
 	"context"
 	"errors"
+	"maps"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -17,6 +18,7 @@ import (
 	wsmetrics "github.com/skip-mev/connect/v2/providers/base/websocket/metrics"
 	mmclienttypes "github.com/skip-mev/connect/v2/service/clients/marketmap/types"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	oracletypes "github.com/skip-mev/connect/v2/x/oracle/types"
 )
 
 var _ Oracle = (*OracleImpl)(nil)
@@ -47,6 +49,8 @@ type OracleImpl struct { //nolint:revive
 	aggregator PriceAggregator
 	// lastPriceSync is the last time the oracle successfully updated its prices.
 	lastPriceSync time.Time
+	// controls are the paused markets and disabled providers of the oracle, as set by an operator.
+	controls *controls
 
 	// -------------------Oracle Configuration Fields-------------------//
 	//
@@ -58,6 +62,11 @@ type OracleImpl struct { //nolint:revive
 	lastUpdated uint64
 	// writeTo is a path to write the market map to.
 	writeTo string
+	// currencyPairs is the set of currency pairs the oracle's markets are restricted to. If nil,
+	// every enabled market of the market map is priced.
+	currencyPairs map[string]struct{}
+	// cpClient queries the currency pairs tracked by the chain's x/oracle module.
+	cpClient oracletypes.QueryClient
 
 	// -------------------Provider Constructor Fields-------------------//
 	//
@@ -108,6 +117,7 @@ func New(
 		cfg:             cfg,
 		aggregator:      aggregator,
 		priceProviders:  make(map[string]ProviderState), // this will be initialized via the Init method.
+		controls:        newControls(),
 		logger:          zap.NewNop(),
 		wsMetrics:       wsmetrics.NewWebSocketMetricsFromConfig(cfg.Metrics),
 		apiMetrics:      apimetrics.NewAPIMetricsFromConfig(cfg.Metrics),
@@ -122,12 +132,12 @@ func New(
 	return orc, nil
 }
 
-// GetProviderState returns all providers and their state. This method is used for testing purposes only.
+// GetProviderState returns all price providers and their state, indexed by provider name.
 func (o *OracleImpl) GetProviderState() map[string]ProviderState {
 	o.mut.Lock()
 	defer o.mut.Unlock()
 
-	return o.priceProviders
+	return maps.Clone(o.priceProviders)
 }
 
 // GetMarketMap returns the market map.
@@ -149,5 +159,5 @@ func (o *OracleImpl) GetLastSyncTime() time.Time {
 }
 
 func (o *OracleImpl) GetPrices() types.Prices {
-	return o.aggregator.GetPrices()
+	return o.withoutPaused(o.aggregator.GetPrices())
 }
@@ -0,0 +1,146 @@
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/skip-mev/connect/v2/abci/strategies/codec"
+	vetypes "github.com/skip-mev/connect/v2/abci/ve/types"
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+var (
+	_ Encoder = (*ConnectEncoder)(nil)
+	_ Encoder = (*JSONEncoder)(nil)
+	_ Encoder = (*EVMEncoder)(nil)
+)
+
+// ConnectEncoder encodes prices as a Connect oracle vote extension. Each price is gob-encoded and
+// keyed by its currency pair ID, as done by the default currency pair strategy, and the vote
+// extension is compressed per the target's compression. Chains using the delta currency pair
+// strategy encode prices relative to their on-chain price, which is not known to the oracle, and
+// are not supported.
+type ConnectEncoder struct {
+	codec codec.VoteExtensionCodec
+}
+
+// NewConnectEncoder returns a new ConnectEncoder for the given target config.
+func NewConnectEncoder(cfg config.PayloadTargetConfig) (Encoder, error) {
+	var veCodec codec.VoteExtensionCodec = codec.NewDefaultVoteExtensionCodec()
+	switch cfg.Compression {
+	case "", config.PayloadCompressionZLib:
+		veCodec = codec.NewCompressionVoteExtensionCodec(veCodec, codec.NewZLibCompressor())
+	case config.PayloadCompressionZStd:
+		veCodec = codec.NewCompressionVoteExtensionCodec(veCodec, codec.NewZStdCompressor())
+	case config.PayloadCompressionNone:
+	default:
+		return nil, fmt.Errorf("unknown compression %s", cfg.Compression)
+	}
+
+	return &ConnectEncoder{codec: veCodec}, nil
+}
+
+// Encode encodes the prices as a vote extension.
+func (e *ConnectEncoder) Encode(prices []Price) ([]byte, error) {
+	ve := vetypes.OracleVoteExtension{
+		Prices: make(map[uint64][]byte, len(prices)),
+	}
+	for _, price := range prices {
+		bz, err := price.Price.GobEncode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode price of %s: %w", price.CurrencyPair, err)
+		}
+		ve.Prices[price.ID] = bz
+	}
+
+	return e.codec.Encode(ve)
+}
+
+// JSONPayload is the payload of the JSON encoder.
+type JSONPayload struct {
+	// Prices are the prices of the payload, sorted by ID.
+	Prices []JSONPrice `json:"prices"`
+}
+
+// JSONPrice is a price of the JSON payload.
+type JSONPrice struct {
+	// ID is the ID of the currency pair on the target chain.
+	ID uint64 `json:"id"`
+	// CurrencyPair is the currency pair, e.g. BTC/USD.
+	CurrencyPair string `json:"currency_pair"`
+	// Price is the integer price, such that the price is Price * 10^Exponent.
+	Price string `json:"price"`
+	// Exponent is the exponent of the price, i.e. the negative of its decimals.
+	Exponent int64 `json:"exponent"`
+}
+
+// JSONEncoder encodes prices as a JSONPayload, for chains and relayers that consume prices as
+// JSON.
+type JSONEncoder struct{}
+
+// NewJSONEncoder returns a new JSONEncoder.
+func NewJSONEncoder(config.PayloadTargetConfig) (Encoder, error) {
+	return &JSONEncoder{}, nil
+}
+
+// Encode encodes the prices as a JSONPayload.
+func (e *JSONEncoder) Encode(prices []Price) ([]byte, error) {
+	payload := JSONPayload{
+		Prices: make([]JSONPrice, len(prices)),
+	}
+	for i, price := range prices {
+		payload.Prices[i] = JSONPrice{
+			ID:           price.ID,
+			CurrencyPair: price.CurrencyPair.String(),
+			Price:        price.Price.String(),
+			Exponent:     -int64(price.Decimals),
+		}
+	}
+
+	return json.Marshal(payload)
+}
+
+// EVMArguments are the arguments the EVM encoder ABI-encodes prices as, i.e. the currency pair
+// IDs, the prices, and the decimals of each price. The payload can be decoded by a contract with
+// abi.decode(payload, (uint64[], uint256[], uint8[])).
+var EVMArguments = abi.Arguments{
+	{Name: "ids", Type: mustNewType("uint64[]")},
+	{Name: "prices", Type: mustNewType("uint256[]")},
+	{Name: "decimals", Type: mustNewType("uint8[]")},
+}
+
+// EVMEncoder ABI-encodes prices as EVMArguments, for oracle transactions to EVM contracts.
+type EVMEncoder struct{}
+
+// NewEVMEncoder returns a new EVMEncoder.
+func NewEVMEncoder(config.PayloadTargetConfig) (Encoder, error) {
+	return &EVMEncoder{}, nil
+}
+
+// Encode ABI-encodes the prices.
+func (e *EVMEncoder) Encode(prices []Price) ([]byte, error) {
+	var (
+		ids      = make([]uint64, len(prices))
+		values   = make([]*big.Int, len(prices))
+		decimals = make([]uint8, len(prices))
+	)
+	for i, price := range prices {
+		ids[i] = price.ID
+		values[i] = price.Price
+		decimals[i] = uint8(price.Decimals)
+	}
+
+	return EVMArguments.Pack(ids, values, decimals)
+}
+
+// mustNewType returns the ABI type with the given name.
+func mustNewType(name string) abi.Type {
+	t, err := abi.NewType(name, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
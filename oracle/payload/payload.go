@@ -0,0 +1,217 @@
+package payload
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/skip-mev/connect/v2/abci/strategies/currencypair"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// Price is the price of a currency pair as included in a payload.
+type Price struct {
+	// CurrencyPair is the currency pair of the price.
+	CurrencyPair pkgtypes.CurrencyPair
+	// ID is the ID the target chain assigns to the currency pair.
+	ID uint64
+	// Price is the price scaled to its decimals, i.e. the price is Price * 10^-Decimals.
+	Price *big.Int
+	// Decimals is the number of decimals of the price.
+	Decimals uint64
+}
+
+// Encoder encodes prices into the payload expected by a target chain.
+type Encoder interface {
+	// Encode encodes the given prices, which are sorted by ID.
+	Encode(prices []Price) ([]byte, error)
+}
+
+// EncoderFactory returns a new encoder for the given target config.
+type EncoderFactory func(cfg config.PayloadTargetConfig) (Encoder, error)
+
+var (
+	mtx sync.RWMutex
+
+	// encoders are the registered encoder factories, indexed by encoder name.
+	encoders = map[string]EncoderFactory{
+		config.PayloadEncoderConnect: NewConnectEncoder,
+		config.PayloadEncoderJSON:    NewJSONEncoder,
+		config.PayloadEncoderEVM:     NewEVMEncoder,
+	}
+)
+
+// RegisterEncoder registers an encoder factory with the given name, such that targets can be
+// configured with encoders for chains that are not supported out of the box. An error is returned
+// if an encoder with the same name is already registered.
+func RegisterEncoder(name string, factory EncoderFactory) error {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if _, ok := encoders[name]; ok {
+		return fmt.Errorf("encoder %s is already registered", name)
+	}
+
+	encoders[name] = factory
+	return nil
+}
+
+// Builder builds the payload of a target chain from the oracle's aggregated prices. The builder
+// maps each currency pair to its ID on the chain, scales its price to the decimals expected by the
+// chain, and encodes the prices with the encoder of the chain.
+type Builder struct {
+	cfg     config.PayloadTargetConfig
+	encoder Encoder
+
+	// pairIDs are the configured IDs of each currency pair, indexed by the normalized currency
+	// pair. If empty, IDs are derived from the hash of the currency pair.
+	pairIDs map[string]uint64
+}
+
+// NewBuilder returns a new Builder for the target with the given config.
+func NewBuilder(cfg config.PayloadTargetConfig) (*Builder, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	mtx.RLock()
+	factory, ok := encoders[cfg.Encoder]
+	mtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown encoder %s", cfg.Encoder)
+	}
+
+	encoder, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encoder %s: %w", cfg.Encoder, err)
+	}
+
+	// the keys of the config may have been lower-cased when it was read, so they are normalized
+	// to the format of the oracle's currency pairs.
+	pairIDs := make(map[string]uint64, len(cfg.PairIDs))
+	for pair, id := range cfg.PairIDs {
+		cp, err := pkgtypes.CurrencyPairFromString(pair)
+		if err != nil {
+			return nil, err
+		}
+		pairIDs[cp.String()] = id
+	}
+
+	return &Builder{
+		cfg:     cfg,
+		encoder: encoder,
+		pairIDs: pairIDs,
+	}, nil
+}
+
+// NewBuildersFromConfig returns a builder for each target of the given config, indexed by the
+// name of the target.
+func NewBuildersFromConfig(cfg config.PayloadConfig) (map[string]*Builder, error) {
+	builders := make(map[string]*Builder, len(cfg.Targets))
+	for name, target := range cfg.Targets {
+		builder, err := NewBuilder(target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create payload builder for %s: %w", name, err)
+		}
+		builders[name] = builder
+	}
+
+	return builders, nil
+}
+
+// Encoder returns the name of the encoder of the builder.
+func (b *Builder) Encoder() string {
+	return b.cfg.Encoder
+}
+
+// Build builds the payload from the given aggregated prices, which are scaled to the decimals of
+// their markets in the given market map. Prices of currency pairs that are not in the market map
+// or have no ID on the target chain, as well as negative prices, are omitted.
+func (b *Builder) Build(prices types.Prices, marketMap mmtypes.MarketMap) ([]byte, error) {
+	payload, err := b.Prices(prices, marketMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Encode(payload)
+}
+
+// Encode encodes the given prices, as returned by Prices, with the encoder of the builder.
+func (b *Builder) Encode(prices []Price) ([]byte, error) {
+	return b.encoder.Encode(prices)
+}
+
+// Prices returns the prices included in the payload built from the given aggregated prices,
+// sorted by ID.
+func (b *Builder) Prices(prices types.Prices, marketMap mmtypes.MarketMap) ([]Price, error) {
+	payload := make([]Price, 0, len(prices))
+	for ticker, price := range prices {
+		market, ok := marketMap.Markets[ticker]
+		if !ok || price == nil || price.Sign() < 0 {
+			continue
+		}
+
+		cp := market.Ticker.CurrencyPair
+		id, ok, err := b.pairID(cp)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		value, _ := price.Int(nil)
+		decimals := market.Ticker.Decimals
+		if b.cfg.Decimals != 0 {
+			value = scale(value, decimals, b.cfg.Decimals)
+			decimals = b.cfg.Decimals
+		}
+
+		payload = append(payload, Price{
+			CurrencyPair: cp,
+			ID:           id,
+			Price:        value,
+			Decimals:     decimals,
+		})
+	}
+
+	sort.Slice(payload, func(i, j int) bool {
+		return payload[i].ID < payload[j].ID
+	})
+
+	return payload, nil
+}
+
+// pairID returns the ID of the given currency pair on the target chain, and whether the currency
+// pair has an ID.
+func (b *Builder) pairID(cp pkgtypes.CurrencyPair) (uint64, bool, error) {
+	if len(b.pairIDs) == 0 {
+		id, err := currencypair.CurrencyPairToHashID(cp.String())
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to hash currency pair %s: %w", cp.String(), err)
+		}
+		return id, true, nil
+	}
+
+	id, ok := b.pairIDs[cp.String()]
+	return id, ok, nil
+}
+
+// scale rescales the given price from one number of decimals to another. Digits beyond the target
+// decimals are truncated.
+func scale(price *big.Int, from, to uint64) *big.Int {
+	switch {
+	case to > from:
+		factor := new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(to-from), nil)
+		return new(big.Int).Mul(price, factor)
+	case to < from:
+		factor := new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(from-to), nil)
+		return new(big.Int).Quo(price, factor)
+	default:
+		return price
+	}
+}
@@ -0,0 +1,223 @@
+package payload_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/abci/strategies/codec"
+	"github.com/skip-mev/connect/v2/abci/strategies/currencypair"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/payload"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+var (
+	btc = mmtypes.NewTicker("BTC", "USD", 5, 1, true)
+	eth = mmtypes.NewTicker("ETH", "USD", 10, 1, true)
+	sol = mmtypes.NewTicker("SOL", "USD", 8, 1, true)
+
+	marketMap = mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			btc.String(): {Ticker: btc},
+			eth.String(): {Ticker: eth},
+			sol.String(): {Ticker: sol},
+		},
+	}
+
+	prices = types.Prices{
+		// 60000.12345
+		btc.String(): big.NewFloat(6000012345),
+		// 3000.0000000001
+		eth.String(): new(big.Float).SetInt(big.NewInt(30000000000001)),
+		// not in the market map
+		"ATOM/USD": big.NewFloat(1000000000),
+		// negative
+		sol.String(): big.NewFloat(-1),
+	}
+)
+
+func TestBuilderPrices(t *testing.T) {
+	btcHash, err := currencypair.CurrencyPairToHashID(btc.String())
+	require.NoError(t, err)
+	ethHash, err := currencypair.CurrencyPairToHashID(eth.String())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		cfg      config.PayloadTargetConfig
+		expected map[uint64]payload.Price
+	}{
+		{
+			name: "market decimals with hashed ids",
+			cfg:  config.PayloadTargetConfig{Encoder: config.PayloadEncoderJSON},
+			expected: map[uint64]payload.Price{
+				btcHash: {CurrencyPair: btc.CurrencyPair, ID: btcHash, Price: big.NewInt(6000012345), Decimals: 5},
+				ethHash: {CurrencyPair: eth.CurrencyPair, ID: ethHash, Price: big.NewInt(30000000000001), Decimals: 10},
+			},
+		},
+		{
+			name: "fixed decimals with configured ids",
+			cfg: config.PayloadTargetConfig{
+				Encoder:  config.PayloadEncoderJSON,
+				Decimals: 8,
+				// keys may be lower-cased when the config is read.
+				PairIDs: map[string]uint64{"btc/usd": 3, "SOL/USD": 4},
+			},
+			expected: map[uint64]payload.Price{
+				3: {CurrencyPair: btc.CurrencyPair, ID: 3, Price: big.NewInt(6000012345000), Decimals: 8},
+			},
+		},
+		{
+			name: "fixed decimals truncate",
+			cfg: config.PayloadTargetConfig{
+				Encoder:  config.PayloadEncoderJSON,
+				Decimals: 2,
+				PairIDs:  map[string]uint64{"BTC/USD": 0, "ETH/USD": 1},
+			},
+			expected: map[uint64]payload.Price{
+				0: {CurrencyPair: btc.CurrencyPair, ID: 0, Price: big.NewInt(6000012), Decimals: 2},
+				1: {CurrencyPair: eth.CurrencyPair, ID: 1, Price: big.NewInt(300000), Decimals: 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder, err := payload.NewBuilder(tc.cfg)
+			require.NoError(t, err)
+
+			actual, err := builder.Prices(prices, marketMap)
+			require.NoError(t, err)
+			require.Len(t, actual, len(tc.expected))
+
+			for i, price := range actual {
+				if i > 0 {
+					require.Less(t, actual[i-1].ID, price.ID)
+				}
+				require.Equal(t, tc.expected[price.ID], price)
+			}
+		})
+	}
+}
+
+func TestEncoders(t *testing.T) {
+	pairIDs := map[string]uint64{"BTC/USD": 0, "ETH/USD": 1}
+
+	t.Run("connect", func(t *testing.T) {
+		for compression, veCodec := range map[string]codec.VoteExtensionCodec{
+			config.PayloadCompressionNone: codec.NewDefaultVoteExtensionCodec(),
+			config.PayloadCompressionZLib: codec.NewCompressionVoteExtensionCodec(
+				codec.NewDefaultVoteExtensionCodec(),
+				codec.NewZLibCompressor(),
+			),
+			config.PayloadCompressionZStd: codec.NewCompressionVoteExtensionCodec(
+				codec.NewDefaultVoteExtensionCodec(),
+				codec.NewZStdCompressor(),
+			),
+		} {
+			builder, err := payload.NewBuilder(config.PayloadTargetConfig{
+				Encoder:     config.PayloadEncoderConnect,
+				Compression: compression,
+				PairIDs:     pairIDs,
+			})
+			require.NoError(t, err)
+
+			bz, err := builder.Build(prices, marketMap)
+			require.NoError(t, err)
+
+			ve, err := veCodec.Decode(bz)
+			require.NoError(t, err, compression)
+			require.Len(t, ve.Prices, 2)
+
+			// prices are encoded as done by the default currency pair strategy.
+			var price big.Int
+			require.NoError(t, price.GobDecode(ve.Prices[0]))
+			require.Equal(t, big.NewInt(6000012345), &price)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		builder, err := payload.NewBuilder(config.PayloadTargetConfig{
+			Encoder:  config.PayloadEncoderJSON,
+			Decimals: 8,
+			PairIDs:  pairIDs,
+		})
+		require.NoError(t, err)
+
+		bz, err := builder.Build(prices, marketMap)
+		require.NoError(t, err)
+
+		var actual payload.JSONPayload
+		require.NoError(t, json.Unmarshal(bz, &actual))
+		require.Equal(t, payload.JSONPayload{
+			Prices: []payload.JSONPrice{
+				{ID: 0, CurrencyPair: "BTC/USD", Price: "6000012345000", Exponent: -8},
+				{ID: 1, CurrencyPair: "ETH/USD", Price: "300000000000", Exponent: -8},
+			},
+		}, actual)
+	})
+
+	t.Run("evm", func(t *testing.T) {
+		builder, err := payload.NewBuilder(config.PayloadTargetConfig{
+			Encoder: config.PayloadEncoderEVM,
+			PairIDs: pairIDs,
+		})
+		require.NoError(t, err)
+
+		bz, err := builder.Build(prices, marketMap)
+		require.NoError(t, err)
+
+		values, err := payload.EVMArguments.Unpack(bz)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{0, 1}, values[0])
+		require.Equal(t, []*big.Int{big.NewInt(6000012345), big.NewInt(30000000000001)}, values[1])
+		require.Equal(t, []uint8{5, 10}, values[2])
+	})
+}
+
+type firstPairEncoder struct{}
+
+func (firstPairEncoder) Encode(prices []payload.Price) ([]byte, error) {
+	return []byte(prices[0].CurrencyPair.String()), nil
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	_, err := payload.NewBuilder(config.PayloadTargetConfig{Encoder: "custom"})
+	require.Error(t, err)
+
+	require.NoError(t, payload.RegisterEncoder("custom", func(config.PayloadTargetConfig) (payload.Encoder, error) {
+		return firstPairEncoder{}, nil
+	}))
+	require.Error(t, payload.RegisterEncoder(config.PayloadEncoderJSON, nil))
+
+	builder, err := payload.NewBuilder(config.PayloadTargetConfig{Encoder: "custom", PairIDs: map[string]uint64{"BTC/USD": 0}})
+	require.NoError(t, err)
+	require.Equal(t, "custom", builder.Encoder())
+
+	bz, err := builder.Build(prices, marketMap)
+	require.NoError(t, err)
+	require.Equal(t, "BTC/USD", string(bz))
+}
+
+func TestNewBuildersFromConfig(t *testing.T) {
+	builders, err := payload.NewBuildersFromConfig(config.PayloadConfig{
+		Targets: map[string]config.PayloadTargetConfig{
+			"dydx": {Encoder: config.PayloadEncoderConnect},
+			"evm":  {Encoder: config.PayloadEncoderEVM, Decimals: 8},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, builders, 2)
+	require.Equal(t, config.PayloadEncoderConnect, builders["dydx"].Encoder())
+
+	_, err = payload.NewBuildersFromConfig(config.PayloadConfig{
+		Targets: map[string]config.PayloadTargetConfig{
+			"unknown": {Encoder: "unknown"},
+		},
+	})
+	require.Error(t, err)
+}
@@ -134,6 +134,66 @@ func (s *OracleTestSuite) TestProviders() {
 			},
 			expectedPrices: types.Prices{},
 		},
+		{
+			name: "1 provider with a price read from a lagging block",
+			factory: func() []*types.PriceProvider {
+				cfg := providerCfg1
+				cfg.API.MaxBlockLag = time.Minute
+
+				resolved := types.ResolvedPrices{
+					s.currencyPairs[0]: types.NewPriceResultWithBlock(
+						big.NewFloat(100),
+						time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC),
+						providertypes.BlockInfo{Number: 1, Timestamp: time.Now().Add(-time.Hour)},
+					),
+				}
+				response := providertypes.NewGetResponse[types.ProviderTicker, *big.Float](resolved, nil)
+				responses := []providertypes.GetResponse[types.ProviderTicker, *big.Float]{response}
+				provider := testutils.CreateAPIProviderWithGetResponses[types.ProviderTicker, *big.Float](
+					s.T(),
+					s.logger,
+					cfg,
+					s.currencyPairs,
+					responses,
+					200*time.Millisecond,
+				)
+
+				providers := []*types.PriceProvider{provider}
+				return providers
+			},
+			expectedPrices: types.Prices{},
+		},
+		{
+			name: "1 provider with a price read from a recent block",
+			factory: func() []*types.PriceProvider {
+				cfg := providerCfg1
+				cfg.API.MaxBlockLag = time.Hour
+
+				resolved := types.ResolvedPrices{
+					s.currencyPairs[0]: types.NewPriceResultWithBlock(
+						big.NewFloat(100),
+						time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC),
+						providertypes.BlockInfo{Number: 1, Timestamp: time.Now()},
+					),
+				}
+				response := providertypes.NewGetResponse[types.ProviderTicker, *big.Float](resolved, nil)
+				responses := []providertypes.GetResponse[types.ProviderTicker, *big.Float]{response}
+				provider := testutils.CreateAPIProviderWithGetResponses[types.ProviderTicker, *big.Float](
+					s.T(),
+					s.logger,
+					cfg,
+					s.currencyPairs,
+					responses,
+					200*time.Millisecond,
+				)
+
+				providers := []*types.PriceProvider{provider}
+				return providers
+			},
+			expectedPrices: types.Prices{
+				s.currencyPairs[0].String(): big.NewFloat(100),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,147 @@
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+var _ oracle.PriceAggregator = (*Recorder)(nil)
+
+// Recorder is a price aggregator that records the data each provider reports to the wrapped
+// aggregator. Every aggregation is written to the recording as a single JSON encoded Tick per
+// line, which can be fed back through an aggregator with Replay.
+type Recorder struct {
+	mtx    sync.Mutex
+	logger *zap.Logger
+
+	// aggregator is the wrapped aggregator.
+	aggregator oracle.PriceAggregator
+
+	// encoder writes the recorded ticks.
+	encoder *json.Encoder
+
+	// now returns the current time.
+	now func() time.Time
+
+	// marketMap is the market map to record with the next tick, if it was updated.
+	marketMap *mmtypes.MarketMap
+
+	// providers is the data reported by each provider since the last reset.
+	providers map[string]ProviderData
+}
+
+// NewRecorder returns a new Recorder that wraps the given aggregator and writes the recorded
+// ticks to w. The given market map is the market map the aggregator was constructed with, and is
+// recorded with the first tick.
+func NewRecorder(
+	logger *zap.Logger,
+	aggregator oracle.PriceAggregator,
+	marketMap mmtypes.MarketMap,
+	w io.Writer,
+) *Recorder {
+	return &Recorder{
+		logger:     logger.With(zap.String("process", "recorder")),
+		aggregator: aggregator,
+		encoder:    json.NewEncoder(w),
+		now:        time.Now,
+		marketMap:  &marketMap,
+		providers:  make(map[string]ProviderData),
+	}
+}
+
+// SetProviderPrices records and sets the prices for the given provider.
+func (r *Recorder) SetProviderPrices(provider string, prices types.Prices) {
+	r.mtx.Lock()
+	data := r.providers[provider]
+	data.Prices = make(types.Prices, len(prices))
+	for ticker, price := range prices {
+		if price != nil {
+			data.Prices[ticker] = new(big.Float).Copy(price)
+		}
+	}
+	r.providers[provider] = data
+	r.mtx.Unlock()
+
+	r.aggregator.SetProviderPrices(provider, prices)
+}
+
+// SetProviderWeights records and sets the weights for the given provider.
+func (r *Recorder) SetProviderWeights(provider string, weights types.Weights) {
+	r.mtx.Lock()
+	data := r.providers[provider]
+	data.Weights = maps.Clone(weights)
+	r.providers[provider] = data
+	r.mtx.Unlock()
+
+	r.aggregator.SetProviderWeights(provider, weights)
+}
+
+// SetProviderVolumes records and sets the volumes for the given provider.
+func (r *Recorder) SetProviderVolumes(provider string, volumes types.Volumes) {
+	r.mtx.Lock()
+	data := r.providers[provider]
+	data.Volumes = maps.Clone(volumes)
+	r.providers[provider] = data
+	r.mtx.Unlock()
+
+	r.aggregator.SetProviderVolumes(provider, volumes)
+}
+
+// UpdateMarketMap updates the market map of the wrapped aggregator. The market map is recorded
+// with the next tick.
+func (r *Recorder) UpdateMarketMap(marketMap mmtypes.MarketMap) {
+	r.mtx.Lock()
+	r.marketMap = &marketMap
+	r.mtx.Unlock()
+
+	r.aggregator.UpdateMarketMap(marketMap)
+}
+
+// AggregatePrices aggregates the prices of the wrapped aggregator and records the tick.
+func (r *Recorder) AggregatePrices() {
+	r.aggregator.AggregatePrices()
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	tick := Tick{
+		Timestamp: r.now().UTC(),
+		MarketMap: r.marketMap,
+		Providers: r.providers,
+	}
+	if err := r.encoder.Encode(tick); err != nil {
+		r.logger.Error("failed to record tick", zap.Error(err))
+		return
+	}
+
+	r.marketMap = nil
+}
+
+// GetPrices returns the aggregated prices of the wrapped aggregator.
+func (r *Recorder) GetPrices() types.Prices {
+	return r.aggregator.GetPrices()
+}
+
+// GetUnconfirmedPrices returns the unconfirmed prices of the wrapped aggregator.
+func (r *Recorder) GetUnconfirmedPrices() []string {
+	return r.aggregator.GetUnconfirmedPrices()
+}
+
+// Reset resets the wrapped aggregator and starts recording a new tick.
+func (r *Recorder) Reset() {
+	r.mtx.Lock()
+	r.providers = make(map[string]ProviderData)
+	r.mtx.Unlock()
+
+	r.aggregator.Reset()
+}
@@ -0,0 +1,106 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// Tick is a single recorded aggregation. It contains the prices and weights that each provider
+// reported to the aggregator, and the market map if it was updated since the previous tick.
+type Tick struct {
+	// Timestamp is the time at which the prices were aggregated.
+	Timestamp time.Time `json:"timestamp"`
+
+	// MarketMap is the market map used by the aggregator. This is only set on the first tick and
+	// on ticks following a market map update.
+	MarketMap *mmtypes.MarketMap `json:"marketMap,omitempty"`
+
+	// Providers is the data reported by each provider, keyed by provider name.
+	Providers map[string]ProviderData `json:"providers"`
+}
+
+// ProviderData is the data a provider reported to the aggregator in a single tick.
+type ProviderData struct {
+	// Prices are the prices reported by the provider, keyed by off-chain ticker.
+	Prices types.Prices `json:"prices"`
+
+	// Weights are the weights reported by the provider, keyed by off-chain ticker.
+	Weights types.Weights `json:"weights,omitempty"`
+
+	// Volumes are the volumes reported by the provider, keyed by off-chain ticker.
+	Volumes types.Volumes `json:"volumes,omitempty"`
+}
+
+// Reader reads recorded ticks from a recording.
+type Reader struct {
+	decoder *json.Decoder
+}
+
+// NewReader returns a new Reader that reads the ticks recorded to r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{decoder: json.NewDecoder(r)}
+}
+
+// Next returns the next recorded tick. io.EOF is returned once all ticks have been read.
+func (r *Reader) Next() (Tick, error) {
+	var tick Tick
+	if err := r.decoder.Decode(&tick); err != nil {
+		return Tick{}, err
+	}
+
+	return tick, nil
+}
+
+// Replay feeds the ticks recorded to r through the given aggregator in the order they were
+// recorded. After each tick is aggregated, fn is called with the tick and the aggregated prices.
+// Replay stops at the end of the recording, or at the first error returned by fn.
+func Replay(
+	aggregator oracle.PriceAggregator,
+	r io.Reader,
+	fn func(tick Tick, prices types.Prices) error,
+) error {
+	reader := NewReader(r)
+	for i := 0; ; i++ {
+		tick, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tick %d: %w", i, err)
+		}
+
+		if tick.MarketMap != nil {
+			aggregator.UpdateMarketMap(*tick.MarketMap)
+		}
+
+		aggregator.Reset()
+
+		// Set the provider data in a deterministic order.
+		providers := make([]string, 0, len(tick.Providers))
+		for provider := range tick.Providers {
+			providers = append(providers, provider)
+		}
+		sort.Strings(providers)
+
+		for _, provider := range providers {
+			data := tick.Providers[provider]
+			aggregator.SetProviderPrices(provider, data.Prices)
+			aggregator.SetProviderWeights(provider, data.Weights)
+			aggregator.SetProviderVolumes(provider, data.Volumes)
+		}
+
+		aggregator.AggregatePrices()
+
+		if err := fn(tick, aggregator.GetPrices()); err != nil {
+			return err
+		}
+	}
+}
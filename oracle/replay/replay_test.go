@@ -0,0 +1,172 @@
+package replay_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/metrics"
+	"github.com/skip-mev/connect/v2/oracle/replay"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+var (
+	btcusd = mmtypes.Ticker{
+		CurrencyPair:     pkgtypes.NewCurrencyPair("BTC", "USD"),
+		Decimals:         8,
+		MinProviderCount: 1,
+		Enabled:          true,
+	}
+	ethusd = mmtypes.Ticker{
+		CurrencyPair:     pkgtypes.NewCurrencyPair("ETH", "USD"),
+		Decimals:         11,
+		MinProviderCount: 1,
+		Enabled:          true,
+	}
+
+	marketMap = mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			btcusd.String(): {
+				Ticker: btcusd,
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "a", OffChainTicker: "BTC-USD"},
+					{Name: "b", OffChainTicker: "BTCUSD"},
+				},
+			},
+		},
+	}
+
+	updatedMarketMap = mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			btcusd.String(): marketMap.Markets[btcusd.String()],
+			ethusd.String(): {
+				Ticker:          ethusd,
+				ProviderConfigs: []mmtypes.ProviderConfig{{Name: "a", OffChainTicker: "ETH-USD"}},
+			},
+		},
+	}
+)
+
+func newAggregator(t *testing.T, marketMap mmtypes.MarketMap) *oracle.IndexPriceAggregator {
+	t.Helper()
+
+	aggregator, err := oracle.NewIndexPriceAggregator(zap.NewNop(), marketMap, metrics.NewNopMetrics())
+	require.NoError(t, err)
+
+	return aggregator
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var (
+		buf      bytes.Buffer
+		recorder = replay.NewRecorder(zap.NewNop(), newAggregator(t, marketMap), marketMap, &buf)
+		recorded []types.Prices
+	)
+
+	ticks := []struct {
+		marketMap *mmtypes.MarketMap
+		providers map[string]types.Prices
+	}{
+		{
+			providers: map[string]types.Prices{
+				"a": {"BTC-USD": big.NewFloat(60000)},
+				"b": {"BTCUSD": big.NewFloat(60100)},
+			},
+		},
+		{
+			marketMap: &updatedMarketMap,
+			providers: map[string]types.Prices{
+				"a": {"BTC-USD": big.NewFloat(60050), "ETH-USD": big.NewFloat(3000.123456789)},
+				"b": {"BTCUSD": big.NewFloat(60200)},
+			},
+		},
+		{
+			// Provider b is unavailable.
+			providers: map[string]types.Prices{
+				"a": {"BTC-USD": big.NewFloat(59900), "ETH-USD": big.NewFloat(2999)},
+			},
+		},
+	}
+
+	for _, tick := range ticks {
+		if tick.marketMap != nil {
+			recorder.UpdateMarketMap(*tick.marketMap)
+		}
+
+		recorder.Reset()
+		for provider, prices := range tick.providers {
+			recorder.SetProviderPrices(provider, prices)
+			recorder.SetProviderWeights(provider, types.Weights{})
+			recorder.SetProviderVolumes(provider, types.Volumes{})
+		}
+		recorder.AggregatePrices()
+
+		recorded = append(recorded, recorder.GetPrices())
+	}
+	require.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), len(ticks))
+	require.Contains(t, recorded[0], btcusd.String())
+	require.Contains(t, recorded[1], ethusd.String())
+
+	t.Run("replays the recorded ticks", func(t *testing.T) {
+		var (
+			replayed   []types.Prices
+			marketMaps int
+		)
+
+		// The aggregator is configured with the recorded market maps.
+		err := replay.Replay(
+			newAggregator(t, mmtypes.MarketMap{}),
+			bytes.NewReader(buf.Bytes()),
+			func(tick replay.Tick, prices types.Prices) error {
+				require.False(t, tick.Timestamp.IsZero())
+				if tick.MarketMap != nil {
+					marketMaps++
+				}
+
+				replayed = append(replayed, prices)
+				return nil
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, 2, marketMaps)
+
+		require.Len(t, replayed, len(recorded))
+		for i := range recorded {
+			require.Len(t, replayed[i], len(recorded[i]))
+			for ticker, price := range recorded[i] {
+				require.Zero(t, price.Cmp(replayed[i][ticker]), "tick %d ticker %s", i, ticker)
+			}
+		}
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		var count int
+		err := replay.Replay(
+			newAggregator(t, mmtypes.MarketMap{}),
+			bytes.NewReader(buf.Bytes()),
+			func(replay.Tick, types.Prices) error {
+				count++
+				return fmt.Errorf("stop")
+			},
+		)
+		require.Error(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("malformed recording", func(t *testing.T) {
+		err := replay.Replay(
+			newAggregator(t, mmtypes.MarketMap{}),
+			strings.NewReader(`{"timestamp":`),
+			func(replay.Tick, types.Prices) error { return nil },
+		)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,344 @@
+package submit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/payload"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+const (
+	// DefaultGasMultiplier is the multiplier applied to the estimated gas of a transaction if the
+	// config does not set one.
+	DefaultGasMultiplier = 1.2
+
+	// errNonceTooLow and errReplacementUnderpriced are the messages of the errors returned by EVM
+	// nodes for transactions with a nonce that was already used.
+	errNonceTooLow            = "nonce too low"
+	errReplacementUnderpriced = "replacement transaction underpriced"
+)
+
+// Client is the subset of the go-ethereum client used to submit transactions.
+type Client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error
+}
+
+// PriceSource is the source of the prices that are submitted, i.e. the oracle.
+type PriceSource interface {
+	GetPrices() types.Prices
+	GetMarketMap() mmtypes.MarketMap
+}
+
+// Submitter periodically signs and broadcasts transactions that update the prices of an EVM
+// contract, for chains that are updated by oracle transactions rather than vote extensions. Each
+// transaction calls the configured method of the contract with the payload built by the
+// submitter's payload builder. The gas of each transaction is estimated, its fees are derived from
+// the latest block, and transactions rejected for using a stale nonce are re-signed with a fresh
+// nonce.
+type Submitter struct {
+	logger *zap.Logger
+	cfg    config.SubmitConfig
+
+	client  Client
+	source  PriceSource
+	builder *payload.Builder
+
+	key      *ecdsa.PrivateKey
+	from     common.Address
+	contract common.Address
+	selector []byte
+
+	// signer signs transactions for the chain. This is set on the first submission.
+	signer ethtypes.Signer
+	// nonce is the nonce of the next transaction. This is only valid if hasNonce is set.
+	nonce    uint64
+	hasNonce bool
+}
+
+// NewSubmitter returns a new Submitter that submits the prices of the given source, encoded by the
+// given builder, in transactions signed with the given key.
+func NewSubmitter(
+	logger *zap.Logger,
+	cfg config.SubmitConfig,
+	client Client,
+	source PriceSource,
+	builder *payload.Builder,
+	key *ecdsa.PrivateKey,
+) (*Submitter, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if builder.Encoder() != config.PayloadEncoderEVM {
+		return nil, fmt.Errorf("payload must use the %s encoder; got %s", config.PayloadEncoderEVM, builder.Encoder())
+	}
+
+	signature := fmt.Sprintf("%s(uint64[],uint256[],uint8[])", cfg.Method)
+
+	return &Submitter{
+		logger:   logger.With(zap.String("process", "submit")),
+		cfg:      cfg,
+		client:   client,
+		source:   source,
+		builder:  builder,
+		key:      key,
+		from:     crypto.PubkeyToAddress(key.PublicKey),
+		contract: common.HexToAddress(cfg.Contract),
+		selector: crypto.Keccak256([]byte(signature))[:4],
+	}, nil
+}
+
+// NewSubmitterFromConfig returns a new Submitter from the given config. The endpoint of the config
+// is dialed, and the hex-encoded private key is read from the configured key source.
+func NewSubmitterFromConfig(
+	ctx context.Context,
+	logger *zap.Logger,
+	cfg config.SubmitConfig,
+	source PriceSource,
+	builder *payload.Builder,
+) (*Submitter, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	src, err := secrets.NewSource(cfg.KeySource)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := secrets.NewSecret(src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read submitter key: %w", err)
+	}
+
+	bz, err := hex.DecodeString(strings.TrimPrefix(secret.Value(), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("submitter key is not hex-encoded: %w", err)
+	}
+
+	key, err := crypto.ToECDSA(bz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid submitter key: %w", err)
+	}
+
+	rpcClient, err := ethmulticlient.DialEndpoint(ctx, cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial endpoint: %w", err)
+	}
+
+	return NewSubmitter(logger, cfg, ethclient.NewClient(rpcClient), source, builder, key)
+}
+
+// From returns the address of the account that signs the transactions.
+func (s *Submitter) From() common.Address {
+	return s.from
+}
+
+// Run submits the prices of the source at the configured interval until the given context is
+// cancelled. Failed submissions are logged and retried at the next interval.
+func (s *Submitter) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		tx, err := s.Submit(ctx)
+		switch {
+		case err != nil:
+			s.logger.Error("failed to submit prices", zap.Error(err))
+		case tx != nil:
+			s.logger.Debug(
+				"submitted prices",
+				zap.String("tx", tx.Hash().Hex()),
+				zap.Uint64("nonce", tx.Nonce()),
+				zap.Uint64("gas", tx.Gas()),
+			)
+		}
+	}
+}
+
+// Submit signs and broadcasts a transaction with the current prices of the source, and returns the
+// transaction. No transaction is submitted, and nil is returned, if there are no prices to submit.
+func (s *Submitter) Submit(ctx context.Context) (*ethtypes.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	prices, err := s.builder.Prices(s.source.GetPrices(), s.source.GetMarketMap())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build payload: %w", err)
+	}
+	if len(prices) == 0 {
+		return nil, nil
+	}
+
+	bz, err := s.builder.Encode(prices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	data := append(append([]byte{}, s.selector...), bz...)
+
+	if s.signer == nil {
+		chainID, err := s.client.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain id: %w", err)
+		}
+		s.signer = ethtypes.LatestSignerForChainID(chainID)
+	}
+
+	gas, err := s.client.EstimateGas(ctx, ethereum.CallMsg{From: s.from, To: &s.contract, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	multiplier := s.cfg.GasMultiplier
+	if multiplier == 0 {
+		multiplier = DefaultGasMultiplier
+	}
+	gas = uint64(math.Ceil(float64(gas) * multiplier))
+
+	fees, err := s.fees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		tx, err := s.send(ctx, gas, fees, data)
+		if err == nil {
+			return tx, nil
+		}
+
+		// the nonce is re-fetched before the next transaction, as it may not have been used.
+		s.hasNonce = false
+		if !isNonceError(err) || attempt >= s.cfg.MaxRetries {
+			return nil, err
+		}
+
+		s.logger.Debug("retrying transaction with stale nonce", zap.Int("attempt", attempt+1), zap.Error(err))
+	}
+}
+
+// send signs and broadcasts a transaction with the given gas, fees and data, using the next nonce
+// of the account.
+func (s *Submitter) send(ctx context.Context, gas uint64, fees ethtypes.TxData, data []byte) (*ethtypes.Transaction, error) {
+	if !s.hasNonce {
+		nonce, err := s.client.PendingNonceAt(ctx, s.from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		s.nonce, s.hasNonce = nonce, true
+	}
+
+	var txData ethtypes.TxData
+	switch fees := fees.(type) {
+	case *ethtypes.DynamicFeeTx:
+		txData = &ethtypes.DynamicFeeTx{
+			Nonce:     s.nonce,
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasFeeCap,
+			Gas:       gas,
+			To:        &s.contract,
+			Data:      data,
+		}
+	case *ethtypes.LegacyTx:
+		txData = &ethtypes.LegacyTx{
+			Nonce:    s.nonce,
+			GasPrice: fees.GasPrice,
+			Gas:      gas,
+			To:       &s.contract,
+			Data:     data,
+		}
+	}
+
+	tx, err := ethtypes.SignNewTx(s.key, s.signer, txData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	if err := s.client.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	s.nonce++
+	return tx, nil
+}
+
+// fees returns the fees of the next transaction. On chains with a base fee, the transaction pays
+// the suggested tip and a fee cap of twice the base fee plus the tip, so that it remains valid if
+// the base fee rises over the next blocks. Otherwise, the transaction pays the suggested gas
+// price. The fees are capped by the configured maximum fee per gas; an error is returned if the
+// transaction could not be included under the cap.
+func (s *Submitter) fees(ctx context.Context) (ethtypes.TxData, error) {
+	header, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	var maxFee *big.Int
+	if s.cfg.MaxFeePerGas > 0 {
+		maxFee = new(big.Int).SetUint64(s.cfg.MaxFeePerGas)
+	}
+
+	if header.BaseFee == nil {
+		gasPrice, err := s.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+
+		if maxFee != nil && gasPrice.Cmp(maxFee) > 0 {
+			return nil, fmt.Errorf("gas price %s exceeds max fee per gas %s", gasPrice, maxFee)
+		}
+
+		return &ethtypes.LegacyTx{GasPrice: gasPrice}, nil
+	}
+
+	tip, err := s.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas tip cap: %w", err)
+	}
+
+	feeCap := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tip)
+	if maxFee != nil && feeCap.Cmp(maxFee) > 0 {
+		if minFee := new(big.Int).Add(header.BaseFee, tip); minFee.Cmp(maxFee) > 0 {
+			return nil, fmt.Errorf("fee per gas %s exceeds max fee per gas %s", minFee, maxFee)
+		}
+		feeCap = maxFee
+	}
+
+	return &ethtypes.DynamicFeeTx{GasTipCap: tip, GasFeeCap: feeCap}, nil
+}
+
+// isNonceError returns true if the error was returned for a transaction with a nonce that was
+// already used.
+func isNonceError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, errNonceTooLow) || strings.Contains(msg, errReplacementUnderpriced)
+}
@@ -0,0 +1,194 @@
+package submit_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/payload"
+	"github.com/skip-mev/connect/v2/oracle/submit"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/testutils"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+var (
+	contract = common.HexToAddress("0x1234567890123456789012345678901234567890")
+	btc      = mmtypes.NewTicker("BTC", "USD", 8, 1, true)
+)
+
+// source is a static source of prices.
+type source struct {
+	prices types.Prices
+}
+
+func (s *source) GetPrices() types.Prices {
+	return s.prices
+}
+
+func (s *source) GetMarketMap() mmtypes.MarketMap {
+	return mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{btc.String(): {Ticker: btc}},
+	}
+}
+
+// newSubmitter returns a new submitter of the given source that signs with a funded key.
+func newSubmitter(
+	t *testing.T,
+	chain *testutils.SimulatedChain,
+	src submit.PriceSource,
+	cfg config.SubmitConfig,
+) (*submit.Submitter, *ethclient.Client, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	builder, err := payload.NewBuilder(config.PayloadTargetConfig{
+		Encoder: config.PayloadEncoderEVM,
+		PairIDs: map[string]uint64{btc.String(): 1},
+	})
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain.Fund(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(params.Ether))
+
+	client, err := ethclient.Dial(chain.URL())
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	submitter, err := submit.NewSubmitter(zap.NewNop(), cfg, client, src, builder, key)
+	require.NoError(t, err)
+	return submitter, client, key
+}
+
+func newConfig(chain *testutils.SimulatedChain) config.SubmitConfig {
+	return config.SubmitConfig{
+		Enabled:    true,
+		Target:     "evm",
+		Endpoint:   chain.Endpoint(),
+		Contract:   contract.Hex(),
+		Method:     "updatePrices",
+		KeySource:  "env:SUBMITTER_KEY",
+		Interval:   time.Second,
+		Timeout:    5 * time.Second,
+		MaxRetries: 1,
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	chain := testutils.NewSimulatedChain(t)
+	src := &source{prices: types.Prices{btc.String(): big.NewFloat(6000012345678)}}
+	submitter, client, _ := newSubmitter(t, chain, src, newConfig(chain))
+	ctx := context.Background()
+
+	tx, err := submitter.Submit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), tx.Nonce())
+	require.Equal(t, contract, *tx.To())
+	chain.Commit()
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+
+	// the transaction calls updatePrices with the evm payload.
+	selector := crypto.Keccak256([]byte("updatePrices(uint64[],uint256[],uint8[])"))[:4]
+	require.Equal(t, selector, tx.Data()[:4])
+	values, err := payload.EVMArguments.Unpack(tx.Data()[4:])
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, values[0])
+	require.Equal(t, []*big.Int{big.NewInt(6000012345678)}, values[1])
+	require.Equal(t, []uint8{8}, values[2])
+
+	// the gas limit covers the estimated gas with the default multiplier.
+	require.Greater(t, tx.Gas(), receipt.GasUsed)
+	require.Equal(t, ethtypes.DynamicFeeTxType, int(tx.Type()))
+
+	// the next transaction uses the next nonce.
+	tx, err = submitter.Submit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tx.Nonce())
+	chain.Commit()
+
+	// nothing is submitted without prices.
+	src.prices = nil
+	tx, err = submitter.Submit(ctx)
+	require.NoError(t, err)
+	require.Nil(t, tx)
+}
+
+func TestSubmitStaleNonce(t *testing.T) {
+	chain := testutils.NewSimulatedChain(t)
+	src := &source{prices: types.Prices{btc.String(): big.NewFloat(6000012345678)}}
+
+	submitter, client, key := newSubmitter(t, chain, src, newConfig(chain))
+
+	ctx := context.Background()
+	tx, err := submitter.Submit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), tx.Nonce())
+	chain.Commit()
+
+	// another transaction signed with the same key uses the submitter's next nonce.
+	other, err := ethtypes.SignNewTx(key, ethtypes.LatestSignerForChainID(big.NewInt(testutils.SimulatedChainID)), &ethtypes.DynamicFeeTx{
+		ChainID:   big.NewInt(testutils.SimulatedChainID),
+		Nonce:     1,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(100 * params.GWei),
+		Gas:       params.TxGas,
+		To:        &contract,
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(ctx, other))
+	chain.Commit()
+
+	// the submitter retries with a fresh nonce.
+	tx, err = submitter.Submit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), tx.Nonce())
+}
+
+func TestSubmitMaxFeePerGas(t *testing.T) {
+	chain := testutils.NewSimulatedChain(t)
+	src := &source{prices: types.Prices{btc.String(): big.NewFloat(6000012345678)}}
+
+	cfg := newConfig(chain)
+	cfg.MaxFeePerGas = 1
+	submitter, _, _ := newSubmitter(t, chain, src, cfg)
+
+	_, err := submitter.Submit(context.Background())
+	require.ErrorContains(t, err, "exceeds max fee per gas")
+}
+
+func TestNewSubmitterFromConfig(t *testing.T) {
+	chain := testutils.NewSimulatedChain(t)
+	src := &source{prices: types.Prices{btc.String(): big.NewFloat(6000012345678)}}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	t.Setenv("SUBMITTER_KEY", "0x"+hex.EncodeToString(crypto.FromECDSA(key)))
+
+	builder, err := payload.NewBuilder(config.PayloadTargetConfig{Encoder: config.PayloadEncoderEVM})
+	require.NoError(t, err)
+
+	submitter, err := submit.NewSubmitterFromConfig(context.Background(), zap.NewNop(), newConfig(chain), src, builder)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), submitter.From())
+
+	// only evm payloads can be submitted.
+	builder, err = payload.NewBuilder(config.PayloadTargetConfig{Encoder: config.PayloadEncoderJSON})
+	require.NoError(t, err)
+	_, err = submit.NewSubmitterFromConfig(context.Background(), zap.NewNop(), newConfig(chain), src, builder)
+	require.Error(t, err)
+}
@@ -80,6 +80,12 @@ type (
 
 	// Prices is a type alias for a map of ticker to a price.
 	Prices = map[string]*big.Float
+
+	// Weights is a type alias for a map of ticker to the weight reported for its price.
+	Weights = map[string]float64
+
+	// Volumes is a type alias for a map of ticker to the volume reported for its price.
+	Volumes = map[string]float64
 )
 
 var (
@@ -89,6 +95,15 @@ var (
 	// NewPriceResultWithCode is a function alias for the new price result with code.
 	NewPriceResultWithCode = providertypes.NewResultWithCode[*big.Float]
 
+	// NewPriceResultWithWeight is a function alias for the new price result with weight.
+	NewPriceResultWithWeight = providertypes.NewResultWithWeight[*big.Float]
+
+	// NewPriceResultWithVolume is a function alias for the new price result with volume.
+	NewPriceResultWithVolume = providertypes.NewResultWithVolume[*big.Float]
+
+	// NewPriceResultWithBlock is a function alias for the new price result read at a block.
+	NewPriceResultWithBlock = providertypes.NewResultWithBlock[*big.Float]
+
 	// NewPriceResponse is a function alias for the new price response.
 	NewPriceResponse = providertypes.NewGetResponse[ProviderTicker, *big.Float]
 
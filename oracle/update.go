@@ -1,14 +1,21 @@
 package oracle
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"reflect"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/tracing"
 	"github.com/skip-mev/connect/v2/providers/base"
+	mmclienttypes "github.com/skip-mev/connect/v2/service/clients/marketmap/types"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 )
 
@@ -19,14 +26,22 @@ func (o *OracleImpl) UpdateMarketMap(marketMap mmtypes.MarketMap) error {
 	o.mut.Lock()
 	defer o.mut.Unlock()
 
+	return o.updateMarketMap(marketMap)
+}
+
+// updateMarketMap updates the oracle's market map and the providers' market maps, which are
+// restricted to the oracle's currency pairs. The caller must hold the oracle's lock.
+func (o *OracleImpl) updateMarketMap(marketMap mmtypes.MarketMap) error {
 	if err := marketMap.ValidateBasic(); err != nil {
 		o.logger.Error("failed to validate market map", zap.Error(err))
 		return err
 	}
 
+	active := restrictMarketMap(marketMap, o.currencyPairs)
+
 	// Iterate over all existing price providers and update their market maps.
 	for name, state := range o.priceProviders {
-		providerTickers, err := types.ProviderTickersFromMarketMap(name, marketMap)
+		providerTickers, err := types.ProviderTickersFromMarketMap(name, active)
 		if err != nil {
 			o.logger.Error("failed to create provider market map", zap.String("provider", name), zap.Error(err))
 			return err
@@ -44,9 +59,89 @@ func (o *OracleImpl) UpdateMarketMap(marketMap mmtypes.MarketMap) error {
 
 	o.marketMap = marketMap
 	if o.aggregator != nil {
-		o.aggregator.UpdateMarketMap(o.marketMap)
+		o.aggregator.UpdateMarketMap(active)
+	}
+
+	return nil
+}
+
+// UpdateProviderConfigs updates the oracle's price provider configurations at runtime. Price
+// providers that are no longer configured are stopped and removed, price providers whose
+// configuration changed (e.g. a rotated API key) are stopped and replaced with a provider
+// constructed from the new configuration, and newly configured price providers are created.
+// Providers whose configuration did not change are left running, so the oracle continues to
+// report prices throughout the update. Changes to the market map provider require a restart.
+func (o *OracleImpl) UpdateProviderConfigs(ctx context.Context, cfg config.OracleConfig) error {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	if err := cfg.ValidateBasic(); err != nil {
+		o.logger.Error("failed to validate oracle config", zap.Error(err))
+		return err
+	}
+
+	// Construct all new and changed providers before touching the running providers so that
+	// a bad configuration leaves the oracle unchanged.
+	created := make(map[string]ProviderState)
+	for name, providerCfg := range cfg.Providers {
+		switch providerCfg.Type {
+		case types.ConfigType:
+		case mmclienttypes.ConfigType:
+			if current, ok := o.cfg.Providers[name]; !ok || !reflect.DeepEqual(current, providerCfg) {
+				o.logger.Warn("market map provider config changed; restart the oracle to apply it", zap.String("provider", name))
+			}
+			continue
+		default:
+			return fmt.Errorf("unknown provider type: %s", providerCfg.Type)
+		}
+
+		if state, ok := o.priceProviders[name]; ok && reflect.DeepEqual(state.Cfg, providerCfg) {
+			continue
+		}
+
+		state, err := o.newPriceProviderState(ctx, providerCfg)
+		if err != nil {
+			o.logger.Error("failed to create provider", zap.String("provider", name), zap.Error(err))
+			return fmt.Errorf("failed to create %s provider: %w", name, err)
+		}
+		created[name] = state
 	}
 
+	// Stop the providers that were removed or replaced.
+	for name, state := range o.priceProviders {
+		_, replaced := created[name]
+		if providerCfg, ok := cfg.Providers[name]; ok && providerCfg.Type == types.ConfigType && !replaced {
+			continue
+		}
+
+		o.logger.Info("stopping provider", zap.String("provider", name))
+		state.Provider.Stop()
+		delete(o.priceProviders, name)
+	}
+
+	// Add and start the new providers.
+	for name, state := range created {
+		o.priceProviders[name] = state
+
+		if o.mainCtx == nil {
+			continue
+		}
+
+		providerTickers, err := types.ProviderTickersFromMarketMap(name, o.activeMarketMap())
+		if err != nil {
+			o.logger.Error("failed to create provider market map", zap.String("provider", name), zap.Error(err))
+			return err
+		}
+
+		if _, err := o.UpdateProviderState(providerTickers, state); err != nil {
+			o.logger.Error("failed to update provider state", zap.String("provider", name), zap.Error(err))
+			return err
+		}
+	}
+
+	o.cfg.Providers = cfg.Providers
+	o.logger.Info("updated provider configs", zap.Int("num_created", len(created)), zap.Int("num_providers", len(o.priceProviders)))
+
 	return nil
 }
 
@@ -78,34 +173,63 @@ func (o *OracleImpl) UpdateProviderState(providerTickers []types.ProviderTicker,
 	return state, nil
 }
 
-func (o *OracleImpl) fetchAllPrices() {
+func (o *OracleImpl) fetchAllPrices(ctx context.Context) {
 	o.logger.Debug("starting price fetch loop")
+
+	// Each tick is the root of its own trace.
+	ctx, span := tracing.Start(ctx, "oracle.tick", trace.WithNewRoot())
 	defer func() {
+		var err error
 		if r := recover(); r != nil {
-			o.logger.Error("fetchAllPrices tick panicked", zap.Error(fmt.Errorf("%v", r)))
+			err = fmt.Errorf("%v", r)
+			o.logger.Error("fetchAllPrices tick panicked", zap.Error(err))
 		}
+
+		tracing.End(span, err)
 	}()
 
 	o.aggregator.Reset()
 
 	// Retrieve the latest prices from each provider.
 	o.mut.Lock()
-	for _, provider := range o.priceProviders {
-		o.fetchPrices(provider.Provider)
+	for name, provider := range o.priceProviders {
+		// The prices of disabled providers are dropped by the reset of the aggregator.
+		if o.isDisabled(name) {
+			o.logger.Debug("skipping disabled provider", zap.String("provider", name))
+			continue
+		}
+
+		o.fetchPrices(ctx, provider)
 	}
 	o.mut.Unlock()
 
 	o.logger.Debug("oracle fetched prices from providers")
 
 	// Compute aggregated prices and update the oracle.
+	_, aggregateSpan := tracing.Start(ctx, "oracle.aggregate")
 	o.aggregator.AggregatePrices()
+	aggregateSpan.SetAttributes(attribute.Int("prices", len(o.aggregator.GetPrices())))
+	aggregateSpan.End()
+
+	// Re-query the providers of markets whose price moved beyond their confirm deviation, so that
+	// the move is confirmed with fresh prices by the next aggregation.
+	if unconfirmed := o.aggregator.GetUnconfirmedPrices(); len(unconfirmed) > 0 {
+		o.refreshProviders(unconfirmed)
+	}
 	o.setLastSyncTime(time.Now().UTC())
 
 	// update the last sync time
 	o.metrics.AddTick()
 }
 
-func (o *OracleImpl) fetchPrices(provider *types.PriceProvider) {
+func (o *OracleImpl) fetchPrices(ctx context.Context, state ProviderState) {
+	provider := state.Provider
+
+	_, span := tracing.Start(ctx, "oracle.provider_prices", trace.WithAttributes(
+		attribute.String("provider", provider.Name()),
+	))
+	defer span.End()
+
 	defer func() {
 		if r := recover(); r != nil {
 			o.logger.Error(
@@ -144,31 +268,86 @@ func (o *OracleImpl) fetchPrices(provider *types.PriceProvider) {
 		return
 	}
 
+	// Prices of tickers whose latest fetch failed are the provider's last good prices. These
+	// are reused for up to the provider's cache ttl, if configured.
+	errs := provider.GetErrors()
+
 	timeFilteredPrices := make(types.Prices)
+	weights := make(types.Weights)
+	volumes := make(types.Volumes)
+	stale := 0
 	for pair, result := range prices {
-		// If the price is older than the maxCacheAge, skip it.
+		maxPriceAge := o.cfg.MaxPriceAge
+		_, cached := errs[pair]
+		if cached && state.Cfg.CacheTTL > 0 {
+			maxPriceAge = state.Cfg.CacheTTL
+		}
+
+		// If the price is older than the maxPriceAge, skip it.
 		diff := time.Now().UTC().Sub(result.Timestamp)
-		if diff > o.cfg.MaxPriceAge {
+		if diff > maxPriceAge {
 			o.logger.Debug(
-				"skipping price",
+				"skipping stale price",
 				zap.String("provider", provider.Name()),
 				zap.String("data handler type", string(provider.Type())),
 				zap.String("pair", pair.String()),
 				zap.Duration("diff", diff),
+				zap.Duration("max_price_age", maxPriceAge),
+				zap.Bool("cached", cached),
 			)
+			o.metrics.AddStalePrice(provider.Name(), pair.GetOffChainTicker())
+			stale++
 
 			continue
 		}
 
+		// If the price was read from a block that lags behind the chain, skip it.
+		if maxBlockLag := provider.GetAPIConfig().MaxBlockLag; maxBlockLag > 0 && !result.Block.IsZero() {
+			lag := time.Now().UTC().Sub(result.Block.Timestamp)
+			if lag > maxBlockLag {
+				o.logger.Debug(
+					"skipping price read from a lagging block",
+					zap.String("provider", provider.Name()),
+					zap.String("pair", pair.String()),
+					zap.Uint64("block_number", result.Block.Number),
+					zap.Duration("lag", lag),
+					zap.Duration("max_block_lag", maxBlockLag),
+				)
+				o.metrics.AddStalePrice(provider.Name(), pair.GetOffChainTicker())
+				stale++
+
+				continue
+			}
+		}
+
+		if cached {
+			o.logger.Debug(
+				"reusing cached price after failed fetch",
+				zap.String("provider", provider.Name()),
+				zap.String("pair", pair.String()),
+				zap.Duration("diff", diff),
+			)
+			o.metrics.AddCachedPrice(provider.Name(), pair.GetOffChainTicker())
+		}
+
 		o.logger.Debug(
 			"adding price",
 			zap.String("provider", provider.Name()),
 			zap.String("data handler type", string(provider.Type())),
 			zap.String("pair", pair.String()),
 			zap.String("price", result.Value.String()),
+			zap.Float64("weight", result.Weight),
+			zap.Float64("volume", result.Volume),
+			zap.Uint64("block_number", result.Block.Number),
 			zap.Duration("diff", diff),
 		)
 		timeFilteredPrices[pair.GetOffChainTicker()] = result.Value
+		if result.Weight > 0 {
+			weights[pair.GetOffChainTicker()] = result.Weight
+		}
+		if result.Volume > 0 {
+			volumes[pair.GetOffChainTicker()] = result.Volume
+		}
 	}
 
 	o.logger.Debug("provider returned prices",
@@ -176,7 +355,52 @@ func (o *OracleImpl) fetchPrices(provider *types.PriceProvider) {
 		zap.String("data handler type", string(provider.Type())),
 		zap.Int("prices", len(prices)),
 	)
+	span.SetAttributes(
+		attribute.Int("prices", len(timeFilteredPrices)),
+		attribute.Int("stale_prices", stale),
+		attribute.Int("failed_tickers", len(errs)),
+	)
 	o.aggregator.SetProviderPrices(provider.Name(), timeFilteredPrices)
+	o.aggregator.SetProviderWeights(provider.Name(), weights)
+	o.aggregator.SetProviderVolumes(provider.Name(), volumes)
+}
+
+// refreshProviders immediately re-queries the given markets from each of their price providers.
+// Only the tickers of the given markets are fetched, and the providers' regular queries are not
+// interrupted. Websocket providers are not re-queried, as their prices are streamed; their moves
+// are confirmed by the prices they report by the next aggregation.
+func (o *OracleImpl) refreshProviders(tickers []string) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	ids := make(map[string][]types.ProviderTicker)
+	for _, ticker := range tickers {
+		market, ok := o.marketMap.Markets[ticker]
+		if !ok {
+			continue
+		}
+
+		for _, providerCfg := range market.ProviderConfigs {
+			ids[providerCfg.Name] = append(
+				ids[providerCfg.Name],
+				types.NewProviderTicker(providerCfg.OffChainTicker, providerCfg.Metadata_JSON),
+			)
+		}
+	}
+
+	for name, providerTickers := range ids {
+		state, ok := o.priceProviders[name]
+		if !ok || state.Provider == nil {
+			continue
+		}
+
+		o.logger.Debug(
+			"refreshing provider tickers to confirm price move",
+			zap.String("provider", name),
+			zap.Int("tickers", len(providerTickers)),
+		)
+		state.Provider.RefreshIDs(providerTickers)
+	}
 }
 
 func (o *OracleImpl) setLastSyncTime(t time.Time) {
@@ -2,15 +2,22 @@ package oracle_test
 
 import (
 	"context"
+	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
 	"github.com/skip-mev/connect/v2/providers/apis/binance"
 	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	"github.com/skip-mev/connect/v2/providers/apis/kraken"
+	"github.com/skip-mev/connect/v2/providers/base"
+	apihandlermocks "github.com/skip-mev/connect/v2/providers/base/api/handlers/mocks"
 	oraclefactory "github.com/skip-mev/connect/v2/providers/factories/oracle"
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
 	"github.com/skip-mev/connect/v2/providers/websockets/okx"
@@ -627,3 +634,204 @@ func TestUpdateProviderState(t *testing.T) {
 		)
 	})
 }
+
+func TestUpdateProviderConfigs(t *testing.T) {
+	updatedCfg := func() config.OracleConfig {
+		cfg := oracleCfg
+		cfg.Providers = make(map[string]config.ProviderConfig)
+		for name, providerCfg := range oracleCfg.Providers {
+			cfg.Providers[name] = providerCfg
+		}
+
+		// Remove binance, rotate coinbase's config and add kraken.
+		delete(cfg.Providers, binance.Name)
+
+		coinbaseCfg := cfg.Providers[coinbase.Name]
+		coinbaseCfg.API.Interval = 2 * coinbaseCfg.API.Interval
+		cfg.Providers[coinbase.Name] = coinbaseCfg
+
+		cfg.Providers[kraken.Name] = config.ProviderConfig{
+			Name: kraken.Name,
+			API:  kraken.DefaultAPIConfig,
+			Type: types.ConfigType,
+		}
+
+		return cfg
+	}
+
+	t.Run("bad config is rejected and leaves the providers unchanged", func(t *testing.T) {
+		orc, err := oracle.New(
+			oracleCfg,
+			noOpPriceAggregator{},
+			oracle.WithLogger(logger),
+			oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+			oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+		)
+		require.NoError(t, err)
+		o := orc.(*oracle.OracleImpl)
+		require.NoError(t, o.Init(context.Background()))
+
+		cfg := updatedCfg()
+		cfg.UpdateInterval = 0
+		require.Error(t, o.UpdateProviderConfigs(context.Background(), cfg))
+
+		providers := o.GetProviderState()
+		require.Len(t, providers, 3)
+		require.Contains(t, providers, binance.Name)
+		require.NotContains(t, providers, kraken.Name)
+
+		o.Stop()
+	})
+
+	t.Run("can add, remove and replace providers with no running providers", func(t *testing.T) {
+		orc, err := oracle.New(
+			oracleCfg,
+			noOpPriceAggregator{},
+			oracle.WithLogger(logger),
+			oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+			oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+			oracle.WithMarketMap(marketMap),
+		)
+		require.NoError(t, err)
+		o := orc.(*oracle.OracleImpl)
+		require.NoError(t, o.Init(context.Background()))
+
+		before := o.GetProviderState()
+		coinbaseBefore, okxBefore := before[coinbase.Name], before[okx.Name]
+
+		cfg := updatedCfg()
+		require.NoError(t, o.UpdateProviderConfigs(context.Background(), cfg))
+
+		providers := o.GetProviderState()
+		require.Len(t, providers, 3)
+		require.NotContains(t, providers, binance.Name)
+
+		// The unchanged provider is kept and the changed provider is replaced.
+		require.Same(t, okxBefore.Provider, providers[okx.Name].Provider)
+		require.NotSame(t, coinbaseBefore.Provider, providers[coinbase.Name].Provider)
+		require.Equal(t, cfg.Providers[coinbase.Name], providers[coinbase.Name].Cfg)
+
+		cbTickers, err := types.ProviderTickersFromMarketMap(coinbase.Name, marketMap)
+		require.NoError(t, err)
+		checkProviderState(t, cbTickers, coinbase.Name, providertypes.API, false, providers[coinbase.Name])
+		checkProviderState(t, nil, kraken.Name, providertypes.API, false, providers[kraken.Name])
+
+		o.Stop()
+	})
+
+	t.Run("can replace running providers", func(t *testing.T) {
+		orc, err := oracle.New(
+			oracleCfg,
+			noOpPriceAggregator{},
+			oracle.WithLogger(logger),
+			oracle.WithPriceAPIQueryHandlerFactory(oraclefactory.APIQueryHandlerFactory),
+			oracle.WithPriceWebSocketQueryHandlerFactory(oraclefactory.WebSocketQueryHandlerFactory),
+			oracle.WithMarketMap(marketMap),
+		)
+		require.NoError(t, err)
+		o := orc.(*oracle.OracleImpl)
+
+		// Start the providers.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		go func() {
+			require.ErrorIs(t, o.Start(ctx), context.Canceled)
+		}()
+
+		time.Sleep(2 * time.Second)
+		coinbaseBefore := o.GetProviderState()[coinbase.Name]
+		require.True(t, coinbaseBefore.Provider.IsRunning())
+
+		require.NoError(t, o.UpdateProviderConfigs(ctx, updatedCfg()))
+
+		time.Sleep(2 * time.Second)
+
+		providers := o.GetProviderState()
+		require.Len(t, providers, 3)
+		require.False(t, coinbaseBefore.Provider.IsRunning())
+
+		cbTickers, err := types.ProviderTickersFromMarketMap(coinbase.Name, marketMap)
+		require.NoError(t, err)
+		checkProviderState(t, cbTickers, coinbase.Name, providertypes.API, true, providers[coinbase.Name])
+
+		okxTickers, err := types.ProviderTickersFromMarketMap(okx.Name, marketMap)
+		require.NoError(t, err)
+		checkProviderState(t, okxTickers, okx.Name, providertypes.WebSockets, true, providers[okx.Name])
+
+		o.Stop()
+	})
+}
+
+// unconfirmedAggregator reports a fixed set of unconfirmed prices.
+type unconfirmedAggregator struct {
+	noOpPriceAggregator
+
+	unconfirmed []string
+}
+
+func (a unconfirmedAggregator) GetUnconfirmedPrices() []string {
+	return a.unconfirmed
+}
+
+func (s *OracleTestSuite) TestRefreshUnconfirmedPrices() {
+	btcusd := types.NewProviderTicker("BTC/USD", "{}")
+	ethusd := types.NewProviderTicker("ETH/USD", "{}")
+
+	// The query handler blocks until it is stopped, so each query is due to a (re)start.
+	var queries atomic.Int32
+	handler := apihandlermocks.NewQueryHandler[types.ProviderTicker, *big.Float](s.T())
+	handler.On("Query", mock.Anything, mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+		queries.Add(1)
+		<-args.Get(0).(context.Context).Done()
+	})
+	refreshed := make(chan []types.ProviderTicker, 10)
+	handler.On("QueryOnce", mock.Anything, mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+		refreshed <- args.Get(1).([]types.ProviderTicker)
+	})
+
+	provider, err := types.NewPriceProvider(
+		base.WithName[types.ProviderTicker, *big.Float](providerCfg1.Name),
+		base.WithAPIQueryHandler[types.ProviderTicker, *big.Float](handler),
+		base.WithAPIConfig[types.ProviderTicker, *big.Float](providerCfg1.API),
+		base.WithLogger[types.ProviderTicker, *big.Float](s.logger),
+		base.WithIDs[types.ProviderTicker, *big.Float]([]types.ProviderTicker{btcusd, ethusd}),
+	)
+	s.Require().NoError(err)
+
+	market := s.marketmap.Markets[btcusdtCP.String()]
+	market.ProviderConfigs = []mmtypes.ProviderConfig{
+		{Name: providerCfg1.Name, OffChainTicker: btcusd.GetOffChainTicker(), Metadata_JSON: btcusd.GetJSON()},
+	}
+	mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{btcusdtCP.String(): market}}
+
+	cfg := config.OracleConfig{
+		UpdateInterval: 500 * time.Millisecond,
+		MaxPriceAge:    1 * time.Minute,
+		Metrics:        oracleCfg.Metrics,
+		Host:           oracleCfg.Host,
+		Port:           oracleCfg.Port,
+	}
+	orc, err := oracle.New(
+		cfg,
+		unconfirmedAggregator{unconfirmed: []string{btcusdtCP.String()}},
+		oracle.WithLogger(s.logger),
+		oracle.WithPriceProviders(provider),
+		oracle.WithMarketMap(mm),
+	)
+	s.Require().NoError(err)
+	o := orc.(*oracle.OracleImpl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go o.Start(ctx)
+	defer o.Stop()
+
+	// Only the ticker of the unconfirmed market is re-queried, and the provider is not restarted.
+	select {
+	case ids := <-refreshed:
+		s.Require().Equal([]types.ProviderTicker{btcusd}, ids)
+	case <-time.After(5 * time.Second):
+		s.T().Fatal("unconfirmed market was not re-queried")
+	}
+	s.Require().Equal(int32(1), queries.Load())
+}
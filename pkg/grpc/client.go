@@ -5,12 +5,14 @@ import (
 	"net"
 	"net/url"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	grpc "google.golang.org/grpc"
 )
 
 // NewClient is a wrapper around the `grpc.NewClient` function. Which strips the
 // (`http` / `https`) schemes from the URL, and returns a new client using a
-// plain url (<address>:<host>) as the target.
+// plain url (<address>:<host>) as the target. The trace context of each
+// request is propagated to the server.
 func NewClient(
 	target string,
 	opts ...grpc.DialOption,
@@ -32,6 +34,6 @@ func NewClient(
 	// create a new client
 	return grpc.NewClient(
 		fmt.Sprintf("%s:%s", host, port),
-		opts...,
+		append([]grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())}, opts...)...,
 	)
 }
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+var (
+	inFlightLimitersMtx sync.Mutex
+	// inFlightLimiters are the in-flight limiters of all limited providers, indexed by provider
+	// name. These are shared by all clients of a provider so that the limit applies to the
+	// provider as a whole.
+	inFlightLimiters = make(map[string]*InFlightLimiter)
+)
+
+// InFlightLimiter limits the number of requests that are in flight at once. A nil limiter does
+// not limit requests.
+type InFlightLimiter struct {
+	sem chan struct{}
+}
+
+// NewInFlightLimiter returns a new InFlightLimiter that allows at most limit requests in flight
+// at once. If the limit is not positive, nil is returned.
+func NewInFlightLimiter(limit int) *InFlightLimiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	return &InFlightLimiter{
+		sem: make(chan struct{}, limit),
+	}
+}
+
+// SharedInFlightLimiter returns the in-flight limiter of the given provider, or nil if the limit
+// is not positive. All clients of the same provider share a single limiter. If the provider's
+// limit changes (e.g. when its config is reloaded), the limiter is replaced; requests in flight
+// on the previous limiter are unaffected.
+func SharedInFlightLimiter(provider string, limit int) *InFlightLimiter {
+	inFlightLimitersMtx.Lock()
+	defer inFlightLimitersMtx.Unlock()
+
+	if limit <= 0 {
+		delete(inFlightLimiters, provider)
+		return nil
+	}
+
+	limiter, ok := inFlightLimiters[provider]
+	if !ok || limiter.Limit() != limit {
+		limiter = NewInFlightLimiter(limit)
+		inFlightLimiters[provider] = limiter
+	}
+
+	return limiter
+}
+
+// Limit returns the maximum number of requests in flight at once, or 0 if requests are not
+// limited.
+func (l *InFlightLimiter) Limit() int {
+	if l == nil {
+		return 0
+	}
+
+	return cap(l.sem)
+}
+
+// Acquire blocks until a request can be made, or until the context is cancelled. Every
+// successful call to Acquire must be followed by a call to Release once the request completes.
+func (l *InFlightLimiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release releases a request acquired via Acquire.
+func (l *InFlightLimiter) Release() {
+	if l == nil {
+		return
+	}
+
+	<-l.sem
+}
+
+// RoundTripperWithLimit is a round tripper that limits the number of requests in flight at once.
+type RoundTripperWithLimit struct {
+	// limiter limits the number of requests in flight.
+	limiter *InFlightLimiter
+
+	// next is the next round tripper in the chain.
+	next http.RoundTripper
+}
+
+// NewRoundTripperWithLimit creates a new RoundTripperWithLimit. If the limiter is nil, the next
+// round tripper is returned as is.
+func NewRoundTripperWithLimit(next http.RoundTripper, limiter *InFlightLimiter) http.RoundTripper {
+	if limiter == nil {
+		return next
+	}
+
+	return &RoundTripperWithLimit{
+		limiter: limiter,
+		next:    next,
+	}
+}
+
+// RoundTrip waits until the request can be made without exceeding the limit, and calls the
+// underlying RoundTripper. The request counts as in flight until its response headers have been
+// received.
+func (r *RoundTripperWithLimit) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := r.limiter.Acquire(req.Context()); err != nil {
+		return nil, err
+	}
+	defer r.limiter.Release()
+
+	return r.next.RoundTrip(req)
+}
@@ -0,0 +1,124 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+)
+
+// blockingRoundTripper blocks each request until it is released, and tracks the maximum number of
+// requests in flight.
+type blockingRoundTripper struct {
+	release chan struct{}
+
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (b *blockingRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	n := b.inFlight.Add(1)
+	defer b.inFlight.Add(-1)
+
+	for {
+		peak := b.maxInFlight.Load()
+		if n <= peak || b.maxInFlight.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	<-b.release
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestRoundTripperWithLimit(t *testing.T) {
+	t.Run("limits the requests in flight", func(t *testing.T) {
+		rt := &blockingRoundTripper{release: make(chan struct{})}
+		client := &http.Client{
+			Transport: connecthttp.NewRoundTripperWithLimit(rt, connecthttp.NewInFlightLimiter(2)),
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				req, err := http.NewRequest(http.MethodGet, "http://test.com", nil)
+				require.NoError(t, err)
+
+				_, err = client.Do(req)
+				require.NoError(t, err)
+			}()
+		}
+
+		// Release the requests one at a time once the limit is reached.
+		for i := 0; i < 5; i++ {
+			require.Eventually(t, func() bool { return rt.inFlight.Load() > 0 }, time.Second, time.Millisecond)
+			rt.release <- struct{}{}
+		}
+		wg.Wait()
+
+		require.Equal(t, int32(2), rt.maxInFlight.Load())
+	})
+
+	t.Run("waiting requests respect the context", func(t *testing.T) {
+		rt := &blockingRoundTripper{release: make(chan struct{})}
+		limiter := connecthttp.NewInFlightLimiter(1)
+		client := &http.Client{
+			Transport: connecthttp.NewRoundTripperWithLimit(rt, limiter),
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			req, err := http.NewRequest(http.MethodGet, "http://test.com", nil)
+			require.NoError(t, err)
+
+			_, err = client.Do(req)
+			require.NoError(t, err)
+		}()
+		require.Eventually(t, func() bool { return rt.inFlight.Load() == 1 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://test.com", nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(req)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		rt.release <- struct{}{}
+		<-done
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		rt := &blockingRoundTripper{}
+		require.Equal(t, http.RoundTripper(rt), connecthttp.NewRoundTripperWithLimit(rt, nil))
+		require.Nil(t, connecthttp.NewInFlightLimiter(0))
+	})
+}
+
+func TestSharedInFlightLimiter(t *testing.T) {
+	limiter := connecthttp.SharedInFlightLimiter("test", 2)
+	require.Equal(t, 2, limiter.Limit())
+
+	// Clients of the same provider share the limiter.
+	require.Same(t, limiter, connecthttp.SharedInFlightLimiter("test", 2))
+	require.NotSame(t, limiter, connecthttp.SharedInFlightLimiter("other", 2))
+
+	// The limiter is replaced if the limit changes.
+	updated := connecthttp.SharedInFlightLimiter("test", 3)
+	require.NotSame(t, limiter, updated)
+	require.Equal(t, 3, updated.Limit())
+
+	require.Nil(t, connecthttp.SharedInFlightLimiter("test", 0))
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
 	"github.com/skip-mev/connect/v2/cmd/build"
 )
 
@@ -61,16 +63,50 @@ func (r *RoundTripperWithHeaders) RoundTrip(req *http.Request) (*http.Response,
 	return r.next.RoundTrip(req)
 }
 
+// RoundTripperWithAuthentication is a round tripper that sets an API key header on the request.
+// The key is looked up on each request, so that a rotated key is used without re-creating the
+// client.
+type RoundTripperWithAuthentication struct {
+	// header is the header under which the key is set.
+	header string
+
+	// key returns the current key.
+	key func() string
+
+	// next is the next round tripper in the chain.
+	next http.RoundTripper
+}
+
+// NewRoundTripperWithAuthentication creates a new RoundTripperWithAuthentication.
+func NewRoundTripperWithAuthentication(next http.RoundTripper, header string, key func() string) *RoundTripperWithAuthentication {
+	return &RoundTripperWithAuthentication{
+		header: header,
+		key:    key,
+		next:   next,
+	}
+}
+
+// RoundTrip sets the current key on a clone of the request, and calls the underlying RoundTripper.
+func (r *RoundTripperWithAuthentication) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(r.header, r.key())
+
+	return r.next.RoundTrip(req)
+}
+
 // Client is a wrapper around the Go stdlib http client.
 type Client struct {
 	internal *http.Client
 }
 
 // NewClient returns a new Client with its internal http client
-// set to the default client.
+// set to a client that uses the default transport. The trace
+// context of each request is propagated to the server.
 func NewClient() *Client {
 	return &Client{
-		internal: http.DefaultClient,
+		internal: &http.Client{
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
 	}
 }
 
@@ -33,6 +33,33 @@ func TestRoundTripperWithHeaders(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRoundTripperWithAuthentication(t *testing.T) {
+	key := "old"
+	rt := &customRoundTripper{
+		expectedHeaderFields: map[string]string{
+			"X-Api-Key": "old",
+		},
+	}
+
+	client := &http.Client{
+		Transport: connecthttp.NewRoundTripperWithAuthentication(rt, "X-Api-Key", func() string { return key }),
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://test.com", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.NoError(t, err)
+
+	// The key is looked up on each request, and the original request is not modified.
+	key = "new"
+	rt.expectedHeaderFields["X-Api-Key"] = "new"
+
+	_, err = client.Do(req)
+	require.NoError(t, err)
+	require.Empty(t, req.Header.Get("X-Api-Key"))
+}
+
 type customRoundTripper struct {
 	expectedHeaderFields map[string]string
 }
@@ -6,13 +6,24 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rs/zerolog"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2" // Include this for lumberjack
 )
 
+const (
+	// BackendZap writes logs with zap's JSON encoder. This is the default backend.
+	BackendZap = "zap"
+	// BackendZerolog writes logs with zerolog.
+	BackendZerolog = "zerolog"
+)
+
 // Config is the configuration for the logger.
 type Config struct {
+	// Backend is the logging backend logs are written with. Must be one of zap or zerolog. If
+	// empty, zap is used.
+	Backend string
 	// StdOutLogLevel is the log level for the standard out logger.
 	StdOutLogLevel string
 	// FileOutLogLevel is the log level for the file logger.
@@ -36,6 +47,7 @@ type Config struct {
 // NewDefaultConfig creates a default configuration for the logger.
 func NewDefaultConfig() Config {
 	return Config{
+		Backend:         BackendZap,
 		StdOutLogLevel:  "info",
 		FileOutLogLevel: "info",
 		DisableRotating: false,
@@ -48,7 +60,30 @@ func NewDefaultConfig() Config {
 	}
 }
 
+// Levels are the levels of the outputs of a logger, which can be changed while the logger is in
+// use.
+type Levels struct {
+	// StdOut is the level of the standard out logger.
+	StdOut zap.AtomicLevel
+	// FileOut is the level of the file logger. This has no effect if the logger does not write to
+	// a file.
+	FileOut zap.AtomicLevel
+}
+
 func NewLogger(config Config) *zap.Logger {
+	logger, _ := NewLoggerWithLevels(config)
+	return logger
+}
+
+// NewLoggerWithLevels returns a new logger along with the levels of its outputs. Identical
+// messages are only de-duped if neither output is at the debug level when the logger is created,
+// so lowering a level to debug afterwards keeps de-duping messages.
+func NewLoggerWithLevels(config Config) (*zap.Logger, Levels) {
+	levels := Levels{
+		StdOut:  zap.NewAtomicLevel(),
+		FileOut: zap.NewAtomicLevel(),
+	}
+
 	encoderCfg := zap.NewProductionEncoderConfig()
 	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
 
@@ -70,11 +105,8 @@ func NewLogger(config Config) *zap.Logger {
 			logLevel = zapcore.InfoLevel // Fallback to info if setting fails
 		}
 
-		fileCore = zapcore.NewCore(
-			zapcore.NewJSONEncoder(encoderCfg),
-			fileSyncer,
-			logLevel,
-		)
+		levels.FileOut.SetLevel(logLevel)
+		fileCore = newCore(config.Backend, encoderCfg, fileSyncer, levels.FileOut)
 	}
 
 	// Setup the primary output to always include os.Stderr.
@@ -84,12 +116,10 @@ func NewLogger(config Config) *zap.Logger {
 		logLevel = zapcore.InfoLevel // Fallback to info if setting fails
 	}
 
+	levels.StdOut.SetLevel(logLevel)
+
 	// Setup the primary output to always include os.Stderr
-	stdCore := zapcore.NewCore(
-		zapcore.NewJSONEncoder(encoderCfg),
-		zapcore.Lock(os.Stderr),
-		logLevel,
-	)
+	stdCore := newCore(config.Backend, encoderCfg, zapcore.Lock(os.Stderr), levels.StdOut)
 
 	// Use zapcore.NewTee to write to both stderr and the file (if configured)
 	var core zapcore.Core
@@ -107,5 +137,45 @@ func NewLogger(config Config) *zap.Logger {
 		core,
 		zap.AddCaller(),
 		zap.Fields(zapcore.Field{Key: "pid", Type: zapcore.Int64Type, Integer: int64(os.Getpid())}),
-	)
+	), levels
+}
+
+// newCore returns a core that writes the entries enabled by the given level to the given writer
+// with the given backend. Unknown backends fall back to zap.
+func newCore(
+	backend string,
+	encoderCfg zapcore.EncoderConfig,
+	w zapcore.WriteSyncer,
+	level zapcore.LevelEnabler,
+) zapcore.Core {
+	switch backend {
+	case "", BackendZap:
+	case BackendZerolog:
+		return NewZerologCore(zerolog.New(w), level)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown log backend %s\nfalling back to zap", backend)
+	}
+
+	return zapcore.NewCore(zapcore.NewJSONEncoder(encoderCfg), w, level)
+}
+
+// WithLevel returns a child of the given logger that only writes entries at or above the given
+// level. The level of a child logger cannot be lower than the level of its parent, so a level that
+// is at or below the parent's level returns the parent logger. An empty level returns the parent
+// logger.
+func WithLevel(logger *zap.Logger, level string) (*zap.Logger, error) {
+	if len(level) == 0 {
+		return logger, nil
+	}
+
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log level: %w", err)
+	}
+
+	if lvl <= zapcore.LevelOf(logger.Core()) {
+		return logger, nil
+	}
+
+	return logger.WithOptions(zap.IncreaseLevel(lvl)), nil
 }
@@ -0,0 +1,59 @@
+package log_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/skip-mev/connect/v2/pkg/log"
+)
+
+func TestWithLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+
+	t.Run("raises the level of the child logger", func(t *testing.T) {
+		child, err := log.WithLevel(logger, "warn")
+		require.NoError(t, err)
+
+		child.Info("dropped")
+		child.Warn("written")
+		logger.Info("parent")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 2)
+		require.Equal(t, "written", entries[0].Message)
+		require.Equal(t, "parent", entries[1].Message)
+	})
+
+	t.Run("levels below the parent's level leave the logger unchanged", func(t *testing.T) {
+		child, err := log.WithLevel(logger, "debug")
+		require.NoError(t, err)
+		require.Equal(t, logger, child)
+
+		child, err = log.WithLevel(logger, "")
+		require.NoError(t, err)
+		require.Equal(t, logger, child)
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		_, err := log.WithLevel(logger, "loud")
+		require.Error(t, err)
+	})
+}
+
+func TestNewLoggerWithLevels(t *testing.T) {
+	cfg := log.NewDefaultConfig()
+	cfg.WriteTo = ""
+	cfg.StdOutLogLevel = "warn"
+
+	logger, levels := log.NewLoggerWithLevels(cfg)
+	require.Equal(t, zapcore.WarnLevel, levels.StdOut.Level())
+	require.False(t, logger.Core().Enabled(zapcore.InfoLevel))
+
+	levels.StdOut.SetLevel(zapcore.DebugLevel)
+	require.True(t, logger.Core().Enabled(zapcore.DebugLevel))
+}
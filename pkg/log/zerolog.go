@@ -0,0 +1,107 @@
+package log
+
+import (
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ zapcore.Core = (*zerologCore)(nil)
+
+// zerologCore is a zapcore.Core that writes log entries through a zerolog logger. This allows the
+// oracle, which logs via zap, to use zerolog as its logging backend.
+type zerologCore struct {
+	zapcore.LevelEnabler
+
+	// logger is the zerolog logger entries are written to.
+	logger zerolog.Logger
+
+	// fields are the fields added to the core via With.
+	fields []zapcore.Field
+}
+
+// NewZerologCore returns a zapcore.Core that writes all entries enabled by the given level to the
+// given zerolog logger. The core can be used to construct a zap logger with zap.New.
+func NewZerologCore(logger zerolog.Logger, level zapcore.LevelEnabler) zapcore.Core {
+	return &zerologCore{
+		LevelEnabler: level,
+		logger:       logger,
+	}
+}
+
+// With returns a copy of the core with the given fields added.
+func (c *zerologCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(clone.fields, c.fields...)
+	clone.fields = append(clone.fields, fields...)
+
+	return &clone
+}
+
+// Level returns the minimum enabled level of the core.
+func (c *zerologCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.LevelEnabler)
+}
+
+// Check adds the core to the checked entry if the entry's level is enabled.
+func (c *zerologCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write writes the entry and its fields to the zerolog logger. Note that zap is responsible for
+// panicking or exiting after panic and fatal entries are written.
+func (c *zerologCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	event := c.logger.WithLevel(zerologLevel(ent.Level)).
+		Time(zerolog.TimestampFieldName, ent.Time).
+		Fields(enc.Fields)
+	if len(ent.LoggerName) > 0 {
+		event = event.Str("logger", ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		event = event.Str(zerolog.CallerFieldName, ent.Caller.TrimmedPath())
+	}
+	if len(ent.Stack) > 0 {
+		event = event.Str(zerolog.ErrorStackFieldName, ent.Stack)
+	}
+
+	event.Msg(ent.Message)
+	return nil
+}
+
+// Sync is a no-op, since zerolog writes entries synchronously.
+func (c *zerologCore) Sync() error {
+	return nil
+}
+
+// zerologLevel returns the zerolog level of the given zap level.
+func zerologLevel(level zapcore.Level) zerolog.Level {
+	switch level {
+	case zap.DebugLevel:
+		return zerolog.DebugLevel
+	case zap.InfoLevel:
+		return zerolog.InfoLevel
+	case zap.WarnLevel:
+		return zerolog.WarnLevel
+	case zap.ErrorLevel, zap.DPanicLevel:
+		return zerolog.ErrorLevel
+	case zap.PanicLevel:
+		return zerolog.PanicLevel
+	case zap.FatalLevel:
+		return zerolog.FatalLevel
+	default:
+		return zerolog.NoLevel
+	}
+}
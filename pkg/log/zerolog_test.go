@@ -0,0 +1,33 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/skip-mev/connect/v2/pkg/log"
+)
+
+func TestZerologCore(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zap.New(log.NewZerologCore(zerolog.New(&buf), zapcore.InfoLevel)).
+		Named("oracle").
+		With(zap.String("provider", "binance"))
+
+	logger.Debug("dropped")
+	logger.Warn("failed to fetch", zap.Int("tickers", 3))
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "warn", entry[zerolog.LevelFieldName])
+	require.Equal(t, "failed to fetch", entry[zerolog.MessageFieldName])
+	require.Equal(t, "oracle", entry["logger"])
+	require.Equal(t, "binance", entry["provider"])
+	require.Equal(t, float64(3), entry["tickers"])
+	require.Contains(t, entry, zerolog.TimestampFieldName)
+}
@@ -3,11 +3,14 @@ package oracle
 import (
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/config"
 	oraclemetrics "github.com/skip-mev/connect/v2/oracle/metrics"
 	"github.com/skip-mev/connect/v2/oracle/types"
 	"github.com/skip-mev/connect/v2/pkg/math"
@@ -16,15 +19,26 @@ import (
 
 var _ oracle.PriceAggregator = &IndexPriceAggregator{}
 
-// IndexPriceAggregator is an aggregator that calculates the median price for each ticker,
+// IndexPriceAggregator is an aggregator that calculates the index price for each ticker,
 // resolved from a predefined set of conversion markets. A conversion market is a set of
 // markets that can be used to convert the prices of a set of tickers to a common ticker.
-// These are defined in the market map configuration.
+// These are defined in the market map configuration. By default, the index price is the
+// median of the converted prices, but the aggregation strategy can be configured per ticker.
 type IndexPriceAggregator struct {
-	mtx     sync.Mutex
-	logger  *zap.Logger
-	cfg     mmtypes.MarketMap
-	metrics oraclemetrics.Metrics
+	mtx         sync.Mutex
+	logger      *zap.Logger
+	cfg         mmtypes.MarketMap
+	metrics     oraclemetrics.Metrics
+	aggregation config.AggregationConfig
+
+	// strategies cache the aggregation strategy for each ticker.
+	strategies map[string]Aggregator
+	// smoothers cache the smoothing of the aggregated price for each ticker. Tickers without a
+	// smoothing are not cached.
+	smoothers map[string]Smoother
+	// smoothed caches the state of the ema transforms of each ticker. These are indexed by
+	// ticker -> provider and transform -> smoothed price.
+	smoothed map[string]map[string]*big.Float
 
 	// indexPrices cache the median prices for each ticker. These are unscaled prices.
 	indexPrices types.Prices
@@ -34,6 +48,46 @@ type IndexPriceAggregator struct {
 	// providerPrices cache the unscaled prices for each provider. These are indexed by
 	// provider -> offChainTicker -> price.
 	providerPrices map[string]types.Prices
+	// providerWeights cache the weights reported by each provider alongside its prices. These are
+	// indexed by provider -> offChainTicker -> weight.
+	providerWeights map[string]types.Weights
+	// providerVolumes cache the volumes reported by each provider alongside its prices. These are
+	// indexed by provider -> offChainTicker -> volume.
+	providerVolumes map[string]types.Volumes
+	// unconfirmed are the tickers whose aggregated price moved by more than the ticker's confirm
+	// deviation in the last aggregation, and whose previous price was kept pending confirmation.
+	unconfirmed map[string]struct{}
+	// candidates are the aggregated prices that were held pending confirmation in the last
+	// aggregation. The next aggregation publishes a ticker's price only if it confirms the ticker's
+	// candidate.
+	candidates map[string]*big.Float
+	// closed are the tickers whose market was out of session in the last aggregation.
+	closed map[string]struct{}
+	// providerCounts are the number of providers that the index prices of the last aggregation were
+	// aggregated from. Prices held out of session carry over the count of the last aggregation in
+	// session.
+	providerCounts map[string]int
+
+	// now returns the current time, which determines whether markets are in session.
+	now func() time.Time
+}
+
+// Option is a functional option for the index price aggregator.
+type Option func(*IndexPriceAggregator)
+
+// WithAggregationConfig sets the aggregation strategies used by the aggregator.
+func WithAggregationConfig(cfg config.AggregationConfig) Option {
+	return func(m *IndexPriceAggregator) {
+		m.aggregation = cfg
+	}
+}
+
+// WithClock sets the function used to determine the current time when checking whether markets
+// are in session. This is useful for testing trading schedules.
+func WithClock(now func() time.Time) Option {
+	return func(m *IndexPriceAggregator) {
+		m.now = now
+	}
 }
 
 // NewIndexPriceAggregator returns a new Index Price Aggregator.
@@ -41,6 +95,7 @@ func NewIndexPriceAggregator(
 	logger *zap.Logger,
 	cfg mmtypes.MarketMap,
 	metrics oraclemetrics.Metrics,
+	opts ...Option,
 ) (*IndexPriceAggregator, error) {
 	if logger == nil {
 		return nil, fmt.Errorf("logger cannot be nil")
@@ -51,31 +106,60 @@ func NewIndexPriceAggregator(
 		metrics = oraclemetrics.NewNopMetrics()
 	}
 
-	return &IndexPriceAggregator{
-		logger:         logger.With(zap.String("process", "index_price_aggregator")),
-		cfg:            cfg,
-		metrics:        metrics,
-		indexPrices:    make(types.Prices),
-		scaledPrices:   make(types.Prices),
-		providerPrices: make(map[string]types.Prices),
-	}, nil
+	m := &IndexPriceAggregator{
+		logger:          logger.With(zap.String("process", "index_price_aggregator")),
+		cfg:             cfg,
+		metrics:         metrics,
+		strategies:      make(map[string]Aggregator),
+		smoothers:       make(map[string]Smoother),
+		smoothed:        make(map[string]map[string]*big.Float),
+		indexPrices:     make(types.Prices),
+		scaledPrices:    make(types.Prices),
+		providerPrices:  make(map[string]types.Prices),
+		providerWeights: make(map[string]types.Weights),
+		providerVolumes: make(map[string]types.Volumes),
+		unconfirmed:     make(map[string]struct{}),
+		candidates:      make(map[string]*big.Float),
+		closed:          make(map[string]struct{}),
+		providerCounts:  make(map[string]int),
+		now:             time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if err := m.aggregation.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid aggregation config: %w", err)
+	}
+
+	return m, nil
 }
 
-// AggregatePrices implements the aggregate function for the median price calculation. Specifically, this
+// AggregatePrices implements the aggregate function for the index price calculation. Specifically, this
 // aggregation function aggregates the prices seen by each provider by first converting each price to a
-// common ticker and then calculating the median of the converted prices. Prices are converted either
+// common ticker and then applying the ticker's aggregation strategy (the median by default) to the
+// converted prices. Prices are converted either
 //
 //  1. Directly from the base ticker to the target ticker. i.e. I have BTC/USD and I want BTC/USD.
 //  2. Using the index price of an asset. i.e. I have BTC/USDT and I want BTC/USD. I can convert
 //     BTC/USDT to BTC/USD using the index price of USDT/USD.
 //
-// The index price cache contains the previously calculated median prices.
+// The index price cache contains the previously calculated median prices. Markets that are out of
+// session according to their trading schedule hold their previous index price and are not reported
+// as missing. Finally, the prices of the configured derived markets are derived from the aggregated
+// prices.
 func (m *IndexPriceAggregator) AggregatePrices() {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
 	indexPrices := make(types.Prices)
 	scaledPrices := make(types.Prices)
+	unconfirmed := make(map[string]struct{})
+	candidates := make(map[string]*big.Float)
+	closed := make(map[string]struct{})
+	providerCounts := make(map[string]int)
+	now := m.now()
 
 	var missingPrices []string
 
@@ -89,7 +173,43 @@ func (m *IndexPriceAggregator) AggregatePrices() {
 		// ex. BTC/USDT * Index USDT/USD = BTC/USD
 		//     BTC/USDC * Index USDC/USD = BTC/USD
 		target := market.Ticker
-		convertedPrices := m.CalculateConvertedPrices(market)
+
+		// If the market is out of session, hold its previous price rather than aggregating the
+		// prices of its last trading session.
+		if schedule, ok := m.aggregation.ScheduleForMarket(ticker); ok && !schedule.IsOpen(now) {
+			closed[target.String()] = struct{}{}
+
+			// Prices are expected to gap at the open, so the smoothing starts over.
+			delete(m.smoothers, ticker)
+			if previous, ok := m.indexPrices[target.String()]; ok && previous != nil {
+				m.logger.Debug(
+					"market is out of session; holding previous price",
+					zap.String("target_ticker", ticker),
+					zap.String("price", previous.String()),
+					zap.Int("provider_count", m.providerCounts[target.String()]),
+				)
+
+				// No provider reported the held price, but derived prices keep the provider count of
+				// the last aggregation in session.
+				m.metrics.AddProviderCountForMarket(target.String(), 0)
+				indexPrices[target.String()] = new(big.Float).Copy(previous)
+				scaledPrices[target.String()] = math.ScaleBigFloat(new(big.Float).Copy(previous), target.Decimals)
+				providerCounts[target.String()] = m.providerCounts[target.String()]
+				continue
+			}
+		}
+
+		convertedPrices := m.CalculateConvertedProviderPrices(market)
+
+		// Set aside the prices of observe-only providers. These are compared to the aggregated
+		// price, but are excluded from the aggregation.
+		convertedPrices, observed := m.splitObserved(ticker, convertedPrices)
+
+		// Discard any converted prices that are outside of the ticker's price bounds or that
+		// deviate too far from the prices of the remaining providers before the prices are
+		// aggregated.
+		convertedPrices = m.filterOutOfBounds(ticker, target.String(), convertedPrices)
+		convertedPrices = m.filterOutliers(ticker, convertedPrices)
 		m.metrics.AddProviderCountForMarket(target.String(), len(convertedPrices))
 
 		// We need to have at least the minimum number of providers to calculate the median.
@@ -106,17 +226,68 @@ func (m *IndexPriceAggregator) AggregatePrices() {
 			continue
 		}
 
-		// Take the median of the converted prices. This takes the average of the middle two
-		// prices if the number of prices is even.
-		price := math.CalculateMedian(convertedPrices)
+		// Aggregate the converted prices using the ticker's aggregation strategy.
+		price, err := m.strategy(ticker).Aggregate(convertedPrices)
+		if err != nil {
+			missingPrices = append(missingPrices, ticker)
+			m.logger.Debug(
+				"failed to aggregate converted prices",
+				zap.String("target_ticker", ticker),
+				zap.Error(err),
+			)
+
+			continue
+		}
+		m.compareObserved(ticker, target.String(), price, observed)
+
+		// Smooth the aggregated price over the previous aggregations of the ticker, if configured.
+		if smoother := m.smoother(ticker); smoother != nil {
+			smoothed := smoother.Smooth(price)
+			m.logger.Debug(
+				"smoothed aggregated price",
+				zap.String("target_ticker", ticker),
+				zap.String("price", price.String()),
+				zap.String("smoothed_price", smoothed.String()),
+			)
+
+			price = smoothed
+		}
+
+		// If the price moved by more than the ticker's confirm deviation, keep the previous price
+		// until the move is confirmed by the next aggregation.
+		if hold, candidate := m.confirmMove(ticker, target.String(), price); hold {
+			if candidate != nil {
+				m.logger.Info(
+					"aggregated price moved beyond confirm deviation; keeping previous price until confirmed",
+					zap.String("target_ticker", ticker),
+					zap.String("price", price.String()),
+					zap.String("previous_price", m.indexPrices[target.String()].String()),
+				)
+
+				unconfirmed[target.String()] = struct{}{}
+				candidates[target.String()] = candidate
+			} else {
+				m.logger.Info(
+					"aggregated price did not confirm the held move; keeping previous price",
+					zap.String("target_ticker", ticker),
+					zap.String("price", price.String()),
+					zap.String("candidate_price", m.candidates[target.String()].String()),
+					zap.String("previous_price", m.indexPrices[target.String()].String()),
+				)
+			}
+
+			price = m.indexPrices[target.String()]
+		}
 		indexPrices[target.String()] = new(big.Float).Copy(price)
+		providerCounts[target.String()] = len(convertedPrices)
 
 		// Scale the price to the target ticker's decimals.
 		scaledPrices[target.String()] = math.ScaleBigFloat(new(big.Float).Copy(price), target.Decimals)
 
 		m.logger.Debug(
-			"calculated median price",
+			"calculated aggregated price",
 			zap.String("target_ticker", ticker),
+			zap.String("strategy", m.aggregation.ForMarket(ticker).Strategy),
 			zap.String("unscaled_price", indexPrices[target.String()].String()),
 			zap.String("scaled_price", scaledPrices[target.String()].String()),
 			zap.Any("converted_prices", convertedPrices),
@@ -126,15 +297,29 @@ func (m *IndexPriceAggregator) AggregatePrices() {
 		m.metrics.UpdateAggregatePrice(target.String(), target.GetDecimals(), floatPrice)
 	}
 
+	// Derive the prices of markets that are not served directly from the aggregated prices. Markets
+	// of the market map whose price was derived are no longer missing, and markets that are out of
+	// session are not expected to have a price.
+	missingPrices = append(missingPrices, m.deriveMarkets(indexPrices, scaledPrices, providerCounts, unconfirmed)...)
+	missingPrices = slices.DeleteFunc(missingPrices, func(ticker string) bool {
+		_, ok := indexPrices[ticker]
+		_, out := closed[ticker]
+		return ok || out
+	})
+
 	// Update the aggregated data. These prices are going to be used as the index prices the
 	// next time we calculate prices.
-	m.logger.Debug("calculated median prices for price feeds", zap.Int("num_prices", len(indexPrices)))
+	m.logger.Debug("calculated aggregated prices for price feeds", zap.Int("num_prices", len(indexPrices)))
 	m.metrics.MissingPrices(missingPrices)
 	if len(missingPrices) > 0 {
 		m.logger.Info("failed to calculate prices for price feeds", zap.Strings("missing_prices", missingPrices))
 	}
 	m.indexPrices = indexPrices
 	m.scaledPrices = scaledPrices
+	m.unconfirmed = unconfirmed
+	m.candidates = candidates
+	m.closed = closed
+	m.providerCounts = providerCounts
 }
 
 // CalculateConvertedPrices calculates the converted prices for a given set of paths and target ticker.
@@ -143,6 +328,15 @@ func (m *IndexPriceAggregator) AggregatePrices() {
 func (m *IndexPriceAggregator) CalculateConvertedPrices(
 	market mmtypes.Market,
 ) []*big.Float {
+	return values(m.CalculateConvertedProviderPrices(market))
+}
+
+// CalculateConvertedProviderPrices calculates the converted prices for a given set of paths and
+// target ticker, along with the provider that reported each price and the weight it reported, if
+// any.
+func (m *IndexPriceAggregator) CalculateConvertedProviderPrices(
+	market mmtypes.Market,
+) []ProviderPrice {
 	m.logger.Debug("calculating converted prices", zap.String("ticker", market.Ticker.String()))
 	if len(market.ProviderConfigs) == 0 {
 		m.logger.Error(
@@ -153,7 +347,7 @@ func (m *IndexPriceAggregator) CalculateConvertedPrices(
 		return nil
 	}
 
-	convertedPrices := make([]*big.Float, 0, len(market.ProviderConfigs))
+	convertedPrices := make([]ProviderPrice, 0, len(market.ProviderConfigs))
 	for _, cfg := range market.ProviderConfigs {
 		// Calculate the converted price.
 		adjustedPrice, err := m.CalculateAdjustedPrice(cfg)
@@ -169,7 +363,26 @@ func (m *IndexPriceAggregator) CalculateConvertedPrices(
 			continue
 		}
 
-		convertedPrices = append(convertedPrices, adjustedPrice)
+		// Apply the ticker's transform pipeline to the converted price.
+		adjustedPrice, err = m.TransformPrice(market.Ticker.String(), cfg.Name, adjustedPrice)
+		if err != nil {
+			m.logger.Debug(
+				"failed to transform converted price",
+				zap.Error(err),
+				zap.String("target_ticker", market.Ticker.String()),
+				zap.Any("provider", cfg.Name),
+			)
+
+			m.metrics.AddProviderTick(cfg.Name, market.Ticker.String(), false)
+			continue
+		}
+
+		convertedPrices = append(convertedPrices, ProviderPrice{
+			Provider: cfg.Name,
+			Price:    adjustedPrice,
+			Weight:   m.GetProviderWeight(cfg),
+			Volume:   m.GetProviderVolume(cfg),
+		})
 		m.logger.Debug(
 			"calculated converted price",
 			zap.String("target_ticker", market.Ticker.String()),
@@ -215,3 +428,137 @@ func (m *IndexPriceAggregator) CalculateAdjustedPrice(
 	// Make sure that the price is adjusted by the market price.
 	return new(big.Float).Mul(price, normalizeByIndexPrice), nil
 }
+
+// strategy returns the aggregation strategy for the given ticker, creating it if it does not
+// exist yet. The strategy config is validated when the aggregator is constructed.
+func (m *IndexPriceAggregator) strategy(ticker string) Aggregator {
+	if strategy, ok := m.strategies[ticker]; ok {
+		return strategy
+	}
+
+	cfg := m.aggregation.ForMarket(ticker)
+	cfg.Weights = m.aggregation.WeightsForMarket(ticker)
+
+	strategy, err := NewAggregator(cfg)
+	if err != nil {
+		m.logger.Error(
+			"failed to create aggregation strategy; falling back to the median",
+			zap.String("target_ticker", ticker),
+			zap.Error(err),
+		)
+
+		strategy = MedianAggregator{}
+	}
+
+	m.strategies[ticker] = strategy
+	return strategy
+}
+
+// smoother returns the smoothing of the aggregated price of the given ticker, creating it if it
+// does not exist yet, or nil if the ticker's price is not smoothed. The smoothing config is
+// validated when the aggregator is constructed.
+func (m *IndexPriceAggregator) smoother(ticker string) Smoother {
+	if smoother, ok := m.smoothers[ticker]; ok {
+		return smoother
+	}
+
+	cfg, ok := m.aggregation.SmoothingForMarket(ticker)
+	if !ok {
+		return nil
+	}
+
+	smoother, err := NewSmoother(cfg)
+	if err != nil {
+		m.logger.Error(
+			"failed to create smoothing; publishing unsmoothed prices",
+			zap.String("target_ticker", ticker),
+			zap.Error(err),
+		)
+
+		return nil
+	}
+
+	m.smoothers[ticker] = smoother
+	return smoother
+}
+
+// confirmMove checks the given aggregated price of the ticker against the ticker's confirm
+// deviation. It returns true if the ticker's previous index price must be kept, along with the
+// candidate price that the next aggregation must confirm, if any. A price moving beyond the confirm
+// deviation of the previous index price becomes the candidate. If the ticker's price was held in the
+// previous aggregation, the given price is published only if it is within the confirm deviation of
+// the candidate; otherwise the previous index price is kept and the candidate is dropped. Moves of
+// markets that re-opened since the previous aggregation are not confirmed, as prices are expected
+// to gap at the open.
+func (m *IndexPriceAggregator) confirmMove(ticker, target string, price *big.Float) (bool, *big.Float) {
+	confirmDeviation := m.aggregation.ForMarket(ticker).ConfirmDeviation
+	if confirmDeviation <= 0 {
+		return false, nil
+	}
+
+	if _, ok := m.closed[target]; ok {
+		return false, nil
+	}
+
+	previous, ok := m.indexPrices[target]
+	if !ok || previous == nil || previous.Sign() == 0 {
+		return false, nil
+	}
+
+	maxDeviation := big.NewFloat(confirmDeviation)
+	if candidate, ok := m.candidates[target]; ok {
+		if candidate.Sign() == 0 {
+			return price.Sign() != 0, nil
+		}
+
+		return deviation(price, candidate).Cmp(maxDeviation) > 0, nil
+	}
+
+	if deviation(price, previous).Cmp(maxDeviation) > 0 {
+		return true, new(big.Float).Copy(price)
+	}
+
+	return false, nil
+}
+
+// filterOutOfBounds discards the converted prices of the given ticker that are outside of the
+// ticker's configured price bounds. The max price change is relative to the ticker's index price
+// from the previous aggregation, and is not enforced if the ticker's market re-opened since.
+func (m *IndexPriceAggregator) filterOutOfBounds(ticker, target string, prices []ProviderPrice) []ProviderPrice {
+	previous := m.indexPrices[target]
+	if _, ok := m.closed[target]; ok {
+		previous = nil
+	}
+
+	kept, rejected := FilterOutOfBounds(prices, m.aggregation.ForMarket(ticker), previous)
+	for _, price := range rejected {
+		m.logger.Debug(
+			"discarding out of bounds price",
+			zap.String("target_ticker", ticker),
+			zap.String("provider", price.Provider),
+			zap.String("price", price.Price.String()),
+		)
+
+		m.metrics.AddOutOfBoundsPrice(price.Provider, ticker)
+	}
+
+	return kept
+}
+
+// filterOutliers discards the converted prices of the given ticker that deviate from the median of
+// the remaining providers' prices by more than the ticker's configured max deviation.
+func (m *IndexPriceAggregator) filterOutliers(ticker string, prices []ProviderPrice) []ProviderPrice {
+	kept, outliers := FilterOutliers(prices, m.aggregation.ForMarket(ticker).MaxDeviation)
+	for _, outlier := range outliers {
+		m.logger.Debug(
+			"discarding outlier price",
+			zap.String("target_ticker", ticker),
+			zap.String("provider", outlier.Provider),
+			zap.String("price", outlier.Price.String()),
+		)
+
+		m.metrics.AddOutlierPrice(outlier.Provider, ticker)
+	}
+
+	return kept
+}
@@ -2,11 +2,16 @@ package oracle_test
 
 import (
 	"math/big"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/metrics"
+	metricmocks "github.com/skip-mev/connect/v2/oracle/metrics/mocks"
 	"github.com/skip-mev/connect/v2/oracle/types"
 	"github.com/skip-mev/connect/v2/pkg/math/oracle"
 	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
@@ -164,6 +169,156 @@ func TestAggregateData(t *testing.T) {
 	}
 }
 
+func TestAggregateDataWithConfirmDeviation(t *testing.T) {
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		metrics.NewNopMetrics(),
+		oracle.WithAggregationConfig(config.AggregationConfig{
+			Default: config.AggregationStrategyConfig{ConfirmDeviation: 0.1},
+		}),
+	)
+	require.NoError(t, err)
+	m.SetIndexPrices(types.Prices{USDT_USD.String(): big.NewFloat(1.0)})
+
+	aggregate := func(price float64) {
+		m.Reset()
+		m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(price)})
+		m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(price)})
+		m.AggregatePrices()
+	}
+	requirePrice := func(expected float64) {
+		actual, _ := m.GetIndexPrices()[USDT_USD.String()].Float64()
+		require.InDelta(t, expected, actual, 1e-9)
+	}
+
+	// A move within the confirm deviation is published immediately.
+	aggregate(1.05)
+	requirePrice(1.05)
+	require.Empty(t, m.GetUnconfirmedPrices())
+
+	// A move beyond the confirm deviation keeps the previous price until it is confirmed.
+	aggregate(1.5)
+	requirePrice(1.05)
+	require.Equal(t, []string{USDT_USD.String()}, m.GetUnconfirmedPrices())
+
+	// The next aggregation confirms the move.
+	aggregate(1.52)
+	requirePrice(1.52)
+	require.Empty(t, m.GetUnconfirmedPrices())
+
+	// A move that is not confirmed by the next aggregation keeps the previous price and is dropped.
+	aggregate(2.0)
+	requirePrice(1.52)
+	require.Equal(t, []string{USDT_USD.String()}, m.GetUnconfirmedPrices())
+
+	aggregate(1.2)
+	requirePrice(1.52)
+	require.Empty(t, m.GetUnconfirmedPrices())
+
+	// A move that persists after it was dropped is confirmed from scratch.
+	aggregate(1.2)
+	requirePrice(1.52)
+	require.Equal(t, []string{USDT_USD.String()}, m.GetUnconfirmedPrices())
+
+	aggregate(1.2)
+	requirePrice(1.2)
+	require.Empty(t, m.GetUnconfirmedPrices())
+}
+
+func TestAggregateDataWithSchedule(t *testing.T) {
+	var (
+		friday   = time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)
+		saturday = time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)
+		monday   = time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+
+		aggregation = config.AggregationConfig{
+			Default: config.AggregationStrategyConfig{MaxPriceChange: 0.2, ConfirmDeviation: 0.1},
+			Schedules: map[string]config.ScheduleConfig{
+				USDT_USD.String(): {
+					Sessions: []config.SessionConfig{
+						{Days: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, Open: "00:00", Close: "23:59"},
+					},
+				},
+			},
+		}
+	)
+
+	t.Run("prices are held out of session and gap at the open", func(t *testing.T) {
+		now := friday
+		m, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(aggregation),
+			oracle.WithClock(func() time.Time { return now }),
+		)
+		require.NoError(t, err)
+		m.SetIndexPrices(types.Prices{USDT_USD.String(): big.NewFloat(1.0)})
+
+		aggregate := func(price float64) {
+			m.Reset()
+			m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(price)})
+			m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(price)})
+			m.AggregatePrices()
+		}
+		requirePrice := func(expected float64) {
+			actual, _ := m.GetIndexPrices()[USDT_USD.String()].Float64()
+			require.InDelta(t, expected, actual, 1e-9)
+		}
+
+		// The market is in session.
+		aggregate(1.05)
+		requirePrice(1.05)
+		require.Empty(t, m.GetHeldPrices())
+		require.Equal(t, 2, m.GetProviderCounts()[USDT_USD.String()])
+
+		// The previous price is held while the market is out of session, along with the provider
+		// count it was aggregated from.
+		now = saturday
+		m.Reset()
+		m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(2.0)})
+		m.AggregatePrices()
+		requirePrice(1.05)
+		require.Equal(t, []string{USDT_USD.String()}, m.GetHeldPrices())
+		require.Equal(t, 2, m.GetProviderCounts()[USDT_USD.String()])
+
+		// The first price after the open is neither bounded by the max price change nor requires
+		// confirmation.
+		now = monday
+		aggregate(1.5)
+		requirePrice(1.5)
+		require.Empty(t, m.GetUnconfirmedPrices())
+		require.Empty(t, m.GetHeldPrices())
+
+		// Subsequent moves are checked again.
+		aggregate(1.7)
+		requirePrice(1.5)
+		require.Equal(t, []string{USDT_USD.String()}, m.GetUnconfirmedPrices())
+	})
+
+	t.Run("markets out of session are not missing", func(t *testing.T) {
+		metrics := metricmocks.NewMetrics(t)
+		metrics.On("AddProviderTick", mock.Anything, mock.Anything, mock.Anything).Maybe()
+		metrics.On("AddProviderCountForMarket", mock.Anything, mock.Anything).Maybe()
+		metrics.On("MissingPrices", mock.MatchedBy(func(missing []string) bool {
+			return !slices.Contains(missing, USDT_USD.String())
+		})).Once()
+
+		m, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics,
+			oracle.WithAggregationConfig(aggregation),
+			oracle.WithClock(func() time.Time { return saturday }),
+		)
+		require.NoError(t, err)
+
+		m.AggregatePrices()
+		require.Empty(t, m.GetIndexPrices())
+	})
+}
+
 func TestCalculateConvertedPrices(t *testing.T) {
 	testCases := []struct {
 		name           string
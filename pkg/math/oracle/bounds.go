@@ -0,0 +1,47 @@
+package oracle
+
+import (
+	"math/big"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// FilterOutOfBounds partitions the prices into the prices that are kept and the prices that are
+// discarded for being outside of the configured price bounds. A price is discarded if it is below
+// the min price, above the max price, or deviates from the previous aggregated price by more than
+// the max price change, expressed as a fraction of the previous price. Each bound is only enforced
+// if it is set, and the max price change is only enforced if there is a previous price.
+func FilterOutOfBounds(
+	prices []ProviderPrice,
+	cfg config.AggregationStrategyConfig,
+	previous *big.Float,
+) (kept []ProviderPrice, rejected []ProviderPrice) {
+	var minPrice, maxPrice, maxChange *big.Float
+	if cfg.MinPrice > 0 {
+		minPrice = big.NewFloat(cfg.MinPrice)
+	}
+	if cfg.MaxPrice > 0 {
+		maxPrice = big.NewFloat(cfg.MaxPrice)
+	}
+	if cfg.MaxPriceChange > 0 && previous != nil && previous.Sign() != 0 {
+		maxChange = big.NewFloat(cfg.MaxPriceChange)
+	}
+
+	if minPrice == nil && maxPrice == nil && maxChange == nil {
+		return prices, nil
+	}
+
+	kept = make([]ProviderPrice, 0, len(prices))
+	for _, price := range prices {
+		switch {
+		case minPrice != nil && price.Price.Cmp(minPrice) < 0,
+			maxPrice != nil && price.Price.Cmp(maxPrice) > 0,
+			maxChange != nil && deviation(price.Price, previous).Cmp(maxChange) > 0:
+			rejected = append(rejected, price)
+		default:
+			kept = append(kept, price)
+		}
+	}
+
+	return kept, rejected
+}
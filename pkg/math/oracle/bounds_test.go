@@ -0,0 +1,151 @@
+package oracle_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	metricmocks "github.com/skip-mev/connect/v2/oracle/metrics/mocks"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	"github.com/skip-mev/connect/v2/providers/apis/binance"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	"github.com/skip-mev/connect/v2/providers/websockets/kucoin"
+)
+
+func TestFilterOutOfBounds(t *testing.T) {
+	prices := []oracle.ProviderPrice{
+		{Provider: coinbase.Name, Price: big.NewFloat(100)},
+		{Provider: binance.Name, Price: big.NewFloat(105)},
+		{Provider: kucoin.Name, Price: big.NewFloat(1e18)},
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      config.AggregationStrategyConfig
+		previous *big.Float
+		kept     int
+		rejected []string
+	}{
+		{
+			name: "bounds disabled",
+			cfg:  config.AggregationStrategyConfig{},
+			kept: 3,
+		},
+		{
+			name:     "max price",
+			cfg:      config.AggregationStrategyConfig{MaxPrice: 1000},
+			kept:     2,
+			rejected: []string{kucoin.Name},
+		},
+		{
+			name:     "min price",
+			cfg:      config.AggregationStrategyConfig{MinPrice: 101},
+			kept:     2,
+			rejected: []string{coinbase.Name},
+		},
+		{
+			name:     "min and max price",
+			cfg:      config.AggregationStrategyConfig{MinPrice: 101, MaxPrice: 1000},
+			kept:     1,
+			rejected: []string{coinbase.Name, kucoin.Name},
+		},
+		{
+			name:     "price at the bounds is kept",
+			cfg:      config.AggregationStrategyConfig{MinPrice: 100, MaxPrice: 1e18},
+			kept:     3,
+			rejected: nil,
+		},
+		{
+			name: "max price change without a previous price",
+			cfg:  config.AggregationStrategyConfig{MaxPriceChange: 0.01},
+			kept: 3,
+		},
+		{
+			name:     "max price change",
+			cfg:      config.AggregationStrategyConfig{MaxPriceChange: 0.01},
+			previous: big.NewFloat(100.5),
+			kept:     1,
+			rejected: []string{binance.Name, kucoin.Name},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kept, rejected := oracle.FilterOutOfBounds(prices, tc.cfg, tc.previous)
+			require.Len(t, kept, tc.kept)
+			require.Len(t, rejected, len(tc.rejected))
+			for i, price := range rejected {
+				require.Equal(t, tc.rejected[i], price.Provider)
+			}
+		})
+	}
+}
+
+func TestAggregateDataWithPriceBounds(t *testing.T) {
+	newMetrics := func() *metricmocks.Metrics {
+		metrics := metricmocks.NewMetrics(t)
+		metrics.On("AddProviderTick", mock.Anything, mock.Anything, mock.Anything).Maybe()
+		metrics.On("UpdatePrice", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+		metrics.On("AddProviderCountForMarket", USDT_USD.String(), 2).Once()
+		metrics.On("AddTickerTick", USDT_USD.String()).Once()
+		metrics.On("UpdateAggregatePrice", USDT_USD.String(), mock.Anything, mock.Anything).Once()
+		metrics.On("AddProviderCountForMarket", mock.Anything, 0).Maybe()
+		metrics.On("MissingPrices", mock.Anything).Once()
+		metrics.On("AddOutOfBoundsPrice", binance.Name, USDT_USD.String()).Once()
+		return metrics
+	}
+
+	t.Run("price above the max price is discarded", func(t *testing.T) {
+		m, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			newMetrics(),
+			oracle.WithAggregationConfig(config.AggregationConfig{
+				Markets: map[string]config.AggregationStrategyConfig{
+					USDT_USD.String(): {MinPrice: 0.5, MaxPrice: 1.5},
+				},
+			}),
+		)
+		require.NoError(t, err)
+
+		// Binance reports a garbage price.
+		m.SetProviderPrices(coinbase.Name, types.Prices{
+			"USDT-USD":  big.NewFloat(1.0),
+			"USDC-USDT": big.NewFloat(1.0),
+		})
+		m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(50)})
+		m.AggregatePrices()
+
+		result := m.GetIndexPrices()
+		require.Len(t, result, 1)
+		require.Equal(t, big.NewFloat(1.0).SetPrec(36), result[USDT_USD.String()].SetPrec(36))
+	})
+
+	t.Run("price that moved too far since the previous tick is discarded", func(t *testing.T) {
+		m, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			newMetrics(),
+			oracle.WithAggregationConfig(config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{MaxPriceChange: 0.1},
+			}),
+		)
+		require.NoError(t, err)
+
+		m.SetIndexPrices(types.Prices{USDT_USD.String(): big.NewFloat(1.0)})
+		m.SetProviderPrices(coinbase.Name, types.Prices{
+			"USDT-USD":  big.NewFloat(1.0),
+			"USDC-USDT": big.NewFloat(1.0),
+		})
+		m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(1.2)})
+		m.AggregatePrices()
+
+		result := m.GetIndexPrices()
+		require.Len(t, result, 1)
+		require.Equal(t, big.NewFloat(1.0).SetPrec(36), result[USDT_USD.String()].SetPrec(36))
+	})
+}
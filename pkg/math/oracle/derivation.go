@@ -0,0 +1,230 @@
+package oracle
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+)
+
+// deriveMarkets derives the prices of the configured derived markets from the index prices of the
+// current aggregation, and adds them to the given index and scaled prices. Derived markets that
+// already have an index price are skipped, and derived prices are not used to derive the prices of
+// other derived markets. A derived price is unconfirmed if any index price in its path is held
+// pending confirmation. The tickers of the derived markets whose price could not be derived, and
+// that are not markets of the market map, are returned.
+func (m *IndexPriceAggregator) deriveMarkets(
+	indexPrices types.Prices,
+	scaledPrices types.Prices,
+	providerCounts map[string]int,
+	unconfirmed map[string]struct{},
+) []string {
+	if len(m.aggregation.Derived) == 0 {
+		return nil
+	}
+
+	var (
+		available = maps.Clone(indexPrices)
+		missing   []string
+	)
+
+	for _, ticker := range sortedKeys(m.aggregation.Derived) {
+		cfg := m.aggregation.Derived[ticker]
+
+		// The derived market config is validated when the aggregator is constructed.
+		cp, err := pkgtypes.CurrencyPairFromString(ticker)
+		if err != nil {
+			continue
+		}
+
+		target := cp.String()
+		if _, ok := available[target]; ok {
+			continue
+		}
+
+		path := cfg.Path
+		if len(path) == 0 {
+			var ok bool
+			if path, ok = FindDerivationPath(cp, available, cfg.GetMaxHops()); !ok {
+				missing = m.appendMissing(missing, target)
+				m.logger.Debug(
+					"no derivation path for derived market",
+					zap.String("target_ticker", target),
+					zap.Int("max_hops", cfg.GetMaxHops()),
+				)
+
+				continue
+			}
+		}
+
+		price, count, err := DerivePrice(path, available, providerCounts)
+		if err == nil && count < cfg.MinProviderCount {
+			err = fmt.Errorf("derivation path has %d providers, expected at least %d", count, cfg.MinProviderCount)
+		}
+		if err != nil {
+			missing = m.appendMissing(missing, target)
+			m.logger.Debug(
+				"failed to derive price",
+				zap.String("target_ticker", target),
+				zap.Any("path", path),
+				zap.Error(err),
+			)
+
+			continue
+		}
+
+		for _, step := range path {
+			// Steps were validated when the price was derived.
+			stepCP, _ := pkgtypes.CurrencyPairFromString(step.Ticker)
+			if _, ok := unconfirmed[stepCP.String()]; ok {
+				unconfirmed[target] = struct{}{}
+			}
+		}
+
+		indexPrices[target] = new(big.Float).Copy(price)
+		scaledPrices[target] = math.ScaleBigFloat(new(big.Float).Copy(price), cfg.Decimals)
+
+		m.logger.Debug(
+			"derived price",
+			zap.String("target_ticker", target),
+			zap.Any("path", path),
+			zap.Int("provider_count", count),
+			zap.String("unscaled_price", indexPrices[target].String()),
+			zap.String("scaled_price", scaledPrices[target].String()),
+		)
+		floatPrice, _ := price.Float64()
+		m.metrics.AddTickerTick(target)
+		m.metrics.UpdateAggregatePrice(target, cfg.Decimals, floatPrice)
+	}
+
+	return missing
+}
+
+// appendMissing appends the given derived market to the missing prices, unless the market is a
+// market of the market map, in which case it was already reported as missing by the aggregation.
+func (m *IndexPriceAggregator) appendMissing(missing []string, target string) []string {
+	if _, ok := m.cfg.Markets[target]; ok {
+		return missing
+	}
+
+	return append(missing, target)
+}
+
+// DerivePrice derives a price by multiplying the index prices of each step of the given path,
+// inverting the index prices of inverted steps. The provider count of the derived price is the
+// minimum provider count of the index prices in the path. An error is returned if an index price
+// in the path is missing, or if a zero index price is inverted.
+func DerivePrice(
+	path []config.DerivationStep,
+	indexPrices types.Prices,
+	providerCounts map[string]int,
+) (*big.Float, int, error) {
+	if len(path) == 0 {
+		return nil, 0, fmt.Errorf("derivation path is empty")
+	}
+
+	var (
+		price *big.Float
+		count = -1
+	)
+	for _, step := range path {
+		cp, err := pkgtypes.CurrencyPairFromString(step.Ticker)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		stepPrice, ok := indexPrices[cp.String()]
+		if !ok || stepPrice == nil {
+			return nil, 0, fmt.Errorf("missing index price for ticker: %s", cp)
+		}
+
+		if step.Invert {
+			if stepPrice.Sign() == 0 {
+				return nil, 0, fmt.Errorf("cannot invert zero index price of ticker: %s", cp)
+			}
+			stepPrice = new(big.Float).Quo(big.NewFloat(1), stepPrice)
+		}
+
+		if price == nil {
+			price = new(big.Float).Copy(stepPrice)
+		} else {
+			price = new(big.Float).Mul(price, stepPrice)
+		}
+
+		if c := providerCounts[cp.String()]; count < 0 || c < count {
+			count = c
+		}
+	}
+
+	return price, count, nil
+}
+
+// FindDerivationPath returns the shortest path of at most maxHops steps that converts the base of
+// the given currency pair to its quote using the given index prices. Each index price can be used
+// as is or inverted. Ties are broken by the lexicographic order of the tickers, such that the path
+// is deterministic.
+func FindDerivationPath(
+	cp pkgtypes.CurrencyPair,
+	indexPrices types.Prices,
+	maxHops int,
+) ([]config.DerivationStep, bool) {
+	// Build the conversions available from each asset.
+	conversions := make(map[string][]config.DerivationStep)
+	for _, ticker := range sortedKeys(indexPrices) {
+		pair, err := pkgtypes.CurrencyPairFromString(ticker)
+		if err != nil || indexPrices[ticker] == nil {
+			continue
+		}
+
+		conversions[pair.Base] = append(conversions[pair.Base], config.DerivationStep{Ticker: ticker})
+		if indexPrices[ticker].Sign() != 0 {
+			conversions[pair.Quote] = append(conversions[pair.Quote], config.DerivationStep{Ticker: ticker, Invert: true})
+		}
+	}
+
+	// Breadth-first search from the base to the quote.
+	paths := map[string][]config.DerivationStep{cp.Base: {}}
+	frontier := []string{cp.Base}
+	for hop := 0; hop < maxHops && len(frontier) > 0; hop++ {
+		var next []string
+		for _, asset := range frontier {
+			for _, step := range conversions[asset] {
+				// Steps were built from valid tickers above.
+				stepCP, _ := step.CurrencyPair()
+				if _, ok := paths[stepCP.Quote]; ok {
+					continue
+				}
+
+				path := append(slices.Clone(paths[asset]), step)
+				if stepCP.Quote == cp.Quote {
+					return path, true
+				}
+
+				paths[stepCP.Quote] = path
+				next = append(next, stepCP.Quote)
+			}
+		}
+		frontier = next
+	}
+
+	return nil, false
+}
+
+// sortedKeys returns the keys of the given map in lexicographic order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
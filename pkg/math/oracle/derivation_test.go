@@ -0,0 +1,390 @@
+package oracle_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+	"github.com/skip-mev/connect/v2/oracle/metrics"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/skip-mev/connect/v2/providers/apis/binance"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc4626"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+func TestFindDerivationPath(t *testing.T) {
+	indexPrices := types.Prices{
+		"ATOM/USD":  big.NewFloat(10),
+		"ETH/USD":   big.NewFloat(2000),
+		"JPY/USD":   big.NewFloat(0.0067),
+		"OSMO/ATOM": big.NewFloat(0.05),
+		"ZERO/USD":  big.NewFloat(0),
+	}
+
+	testCases := []struct {
+		name     string
+		cp       pkgtypes.CurrencyPair
+		maxHops  int
+		expected []config.DerivationStep
+		found    bool
+	}{
+		{
+			name:     "direct",
+			cp:       pkgtypes.NewCurrencyPair("ETH", "USD"),
+			maxHops:  1,
+			expected: []config.DerivationStep{{Ticker: "ETH/USD"}},
+			found:    true,
+		},
+		{
+			name:     "inverted",
+			cp:       pkgtypes.NewCurrencyPair("USD", "JPY"),
+			maxHops:  1,
+			expected: []config.DerivationStep{{Ticker: "JPY/USD", Invert: true}},
+			found:    true,
+		},
+		{
+			name:    "cross",
+			cp:      pkgtypes.NewCurrencyPair("ATOM", "ETH"),
+			maxHops: 2,
+			expected: []config.DerivationStep{
+				{Ticker: "ATOM/USD"},
+				{Ticker: "ETH/USD", Invert: true},
+			},
+			found: true,
+		},
+		{
+			name:    "three hops",
+			cp:      pkgtypes.NewCurrencyPair("OSMO", "JPY"),
+			maxHops: 3,
+			expected: []config.DerivationStep{
+				{Ticker: "OSMO/ATOM"},
+				{Ticker: "ATOM/USD"},
+				{Ticker: "JPY/USD", Invert: true},
+			},
+			found: true,
+		},
+		{
+			name:    "path exceeds max hops",
+			cp:      pkgtypes.NewCurrencyPair("OSMO", "JPY"),
+			maxHops: 2,
+			found:   false,
+		},
+		{
+			name:    "zero prices are not inverted",
+			cp:      pkgtypes.NewCurrencyPair("USD", "ZERO"),
+			maxHops: 2,
+			found:   false,
+		},
+		{
+			name:    "unknown asset",
+			cp:      pkgtypes.NewCurrencyPair("BTC", "USD"),
+			maxHops: 3,
+			found:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, found := oracle.FindDerivationPath(tc.cp, indexPrices, tc.maxHops)
+			require.Equal(t, tc.found, found)
+			require.Equal(t, tc.expected, path)
+		})
+	}
+}
+
+func TestDerivePrice(t *testing.T) {
+	indexPrices := types.Prices{
+		"ATOM/USD": big.NewFloat(10),
+		"ETH/USD":  big.NewFloat(2000),
+		"ZERO/USD": big.NewFloat(0),
+	}
+	providerCounts := map[string]int{
+		"ATOM/USD": 2,
+		"ETH/USD":  5,
+	}
+
+	t.Run("cross price with the minimum provider count", func(t *testing.T) {
+		price, count, err := oracle.DerivePrice(
+			[]config.DerivationStep{{Ticker: "ATOM/USD"}, {Ticker: "eth/usd", Invert: true}},
+			indexPrices,
+			providerCounts,
+		)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		f, _ := price.Float64()
+		require.InDelta(t, 0.005, f, 1e-12)
+	})
+
+	t.Run("inverted price", func(t *testing.T) {
+		price, count, err := oracle.DerivePrice(
+			[]config.DerivationStep{{Ticker: "ETH/USD", Invert: true}},
+			indexPrices,
+			providerCounts,
+		)
+		require.NoError(t, err)
+		require.Equal(t, 5, count)
+
+		f, _ := price.Float64()
+		require.InDelta(t, 0.0005, f, 1e-12)
+	})
+
+	t.Run("missing index price", func(t *testing.T) {
+		_, _, err := oracle.DerivePrice(
+			[]config.DerivationStep{{Ticker: "ATOM/USD"}, {Ticker: "BTC/USD", Invert: true}},
+			indexPrices,
+			providerCounts,
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("zero index price is not inverted", func(t *testing.T) {
+		_, _, err := oracle.DerivePrice(
+			[]config.DerivationStep{{Ticker: "ZERO/USD", Invert: true}},
+			indexPrices,
+			providerCounts,
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("empty path", func(t *testing.T) {
+		_, _, err := oracle.DerivePrice(nil, indexPrices, providerCounts)
+		require.Error(t, err)
+	})
+}
+
+func TestAggregateDataWithDerivedMarkets(t *testing.T) {
+	var (
+		atomusd = mmtypes.Ticker{
+			CurrencyPair:     pkgtypes.NewCurrencyPair("ATOM", "USD"),
+			Decimals:         8,
+			MinProviderCount: 1,
+			Enabled:          true,
+		}
+		ethusd = mmtypes.Ticker{
+			CurrencyPair:     pkgtypes.NewCurrencyPair("ETH", "USD"),
+			Decimals:         8,
+			MinProviderCount: 1,
+			Enabled:          true,
+		}
+		jpyusd = mmtypes.Ticker{
+			CurrencyPair:     pkgtypes.NewCurrencyPair("JPY", "USD"),
+			Decimals:         8,
+			MinProviderCount: 1,
+			Enabled:          true,
+		}
+		usdjpy = mmtypes.Ticker{
+			CurrencyPair:     pkgtypes.NewCurrencyPair("USD", "JPY"),
+			Decimals:         3,
+			MinProviderCount: 1,
+			Enabled:          true,
+		}
+	)
+
+	marketmap := mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			atomusd.String(): {
+				Ticker:          atomusd,
+				ProviderConfigs: []mmtypes.ProviderConfig{{Name: coinbase.Name, OffChainTicker: "ATOM-USD"}},
+			},
+			ethusd.String(): {
+				Ticker: ethusd,
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: coinbase.Name, OffChainTicker: "ETH-USD"},
+					{Name: binance.Name, OffChainTicker: "ETHUSD"},
+				},
+			},
+			jpyusd.String(): {
+				Ticker:          jpyusd,
+				ProviderConfigs: []mmtypes.ProviderConfig{{Name: coinbase.Name, OffChainTicker: "JPY-USD"}},
+			},
+			// USD/JPY is served directly by binance, and is derived when binance is unavailable.
+			usdjpy.String(): {
+				Ticker:          usdjpy,
+				ProviderConfigs: []mmtypes.ProviderConfig{{Name: binance.Name, OffChainTicker: "USDJPY"}},
+			},
+		},
+	}
+
+	aggregation := config.AggregationConfig{
+		Derived: map[string]config.DerivedMarketConfig{
+			// Keys are lower-cased when the config is read via viper.
+			"atom/eth": {Decimals: 10},
+			"USD/JPY": {
+				Decimals: 3,
+				Path:     []config.DerivationStep{{Ticker: "JPY/USD", Invert: true}},
+			},
+			"ETH/ATOM": {Decimals: 8, MinProviderCount: 2},
+			"ATOM/JPY": {Decimals: 8, MaxHops: 1},
+		},
+	}
+
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		metrics.NewNopMetrics(),
+		oracle.WithAggregationConfig(aggregation),
+	)
+	require.NoError(t, err)
+
+	t.Run("derives prices of markets that are not served directly", func(t *testing.T) {
+		m.SetProviderPrices(coinbase.Name, types.Prices{
+			"ATOM-USD": big.NewFloat(10),
+			"ETH-USD":  big.NewFloat(2000),
+			"JPY-USD":  big.NewFloat(0.008),
+		})
+		m.SetProviderPrices(binance.Name, types.Prices{
+			"ETHUSD": big.NewFloat(2000),
+			"USDJPY": big.NewFloat(150),
+		})
+		m.AggregatePrices()
+
+		prices := m.GetIndexPrices()
+		f, _ := prices["ATOM/ETH"].Float64()
+		require.InDelta(t, 0.005, f, 1e-12)
+
+		// The directly served price takes precedence over the derived price.
+		f, _ = prices["USD/JPY"].Float64()
+		require.InDelta(t, 150, f, 1e-12)
+
+		// ETH/ATOM is derived from ATOM/USD, which only has a single provider.
+		require.NotContains(t, prices, "ETH/ATOM")
+
+		// ATOM/JPY requires two hops.
+		require.NotContains(t, prices, "ATOM/JPY")
+
+		scaled := m.GetPrices()
+		require.Equal(t, big.NewFloat(0.005*1e10).SetPrec(36), scaled["ATOM/ETH"].SetPrec(36))
+	})
+
+	t.Run("derives prices of markets whose providers are unavailable", func(t *testing.T) {
+		m.Reset()
+		m.SetProviderPrices(coinbase.Name, types.Prices{
+			"ATOM-USD": big.NewFloat(10),
+			"ETH-USD":  big.NewFloat(2000),
+			"JPY-USD":  big.NewFloat(0.008),
+		})
+		m.AggregatePrices()
+
+		prices := m.GetIndexPrices()
+		f, _ := prices["USD/JPY"].Float64()
+		require.InDelta(t, 125, f, 1e-9)
+
+		scaled := m.GetPrices()
+		f, _ = scaled["USD/JPY"].Float64()
+		require.InDelta(t, 125000, f, 1e-6)
+	})
+
+	t.Run("derived prices are unconfirmed if their path is unconfirmed", func(t *testing.T) {
+		m, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(config.AggregationConfig{
+				Markets: map[string]config.AggregationStrategyConfig{
+					"JPY/USD": {ConfirmDeviation: 0.1},
+				},
+				Derived: map[string]config.DerivedMarketConfig{
+					"USD/JPY": {Decimals: 3},
+				},
+			}),
+		)
+		require.NoError(t, err)
+
+		m.SetProviderPrices(coinbase.Name, types.Prices{"JPY-USD": big.NewFloat(0.008)})
+		m.AggregatePrices()
+		require.Empty(t, m.GetUnconfirmedPrices())
+
+		m.SetProviderPrices(coinbase.Name, types.Prices{"JPY-USD": big.NewFloat(0.01)})
+		m.AggregatePrices()
+		require.Equal(t, []string{"JPY/USD", "USD/JPY"}, m.GetUnconfirmedPrices())
+
+		// The derived price is derived from the held price.
+		f, _ := m.GetIndexPrices()["USD/JPY"].Float64()
+		require.InDelta(t, 125, f, 1e-9)
+	})
+
+	t.Run("invalid derived market config", func(t *testing.T) {
+		_, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(config.AggregationConfig{
+				Derived: map[string]config.DerivedMarketConfig{
+					"ATOM/ETH": {Decimals: 8, Path: []config.DerivationStep{{Ticker: "ATOM/USD"}}},
+				},
+			}),
+		)
+		require.Error(t, err)
+	})
+}
+
+func TestAggregateDataWithVaultShareComposition(t *testing.T) {
+	var (
+		sdaidai = mmtypes.Ticker{
+			CurrencyPair:     pkgtypes.NewCurrencyPair("SDAI", "DAI"),
+			Decimals:         18,
+			MinProviderCount: 1,
+			Enabled:          true,
+		}
+		daiusd = mmtypes.Ticker{
+			CurrencyPair:     pkgtypes.NewCurrencyPair("DAI", "USD"),
+			Decimals:         8,
+			MinProviderCount: 1,
+			Enabled:          true,
+		}
+		erc4626Name = erc4626.ProviderNames[constants.ETHEREUM]
+	)
+
+	// The share rate of sDAI is quoted in DAI, which is quoted in USD by another provider.
+	marketmap := mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			sdaidai.String(): {
+				Ticker: sdaidai,
+				ProviderConfigs: []mmtypes.ProviderConfig{{
+					Name:           erc4626Name,
+					OffChainTicker: "SDAI/DAI",
+					Metadata_JSON:  erc4626.SDAIFeedConfig.MustToJSON(),
+				}},
+			},
+			daiusd.String(): {
+				Ticker:          daiusd,
+				ProviderConfigs: []mmtypes.ProviderConfig{{Name: coinbase.Name, OffChainTicker: "DAI-USD"}},
+			},
+		},
+	}
+
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		metrics.NewNopMetrics(),
+		oracle.WithAggregationConfig(config.AggregationConfig{
+			Derived: map[string]config.DerivedMarketConfig{
+				"SDAI/USD": {
+					Decimals: 8,
+					Path:     []config.DerivationStep{{Ticker: "SDAI/DAI"}, {Ticker: "DAI/USD"}},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	m.SetProviderPrices(erc4626Name, types.Prices{"SDAI/DAI": big.NewFloat(1.125)})
+	m.SetProviderPrices(coinbase.Name, types.Prices{"DAI-USD": big.NewFloat(0.9992)})
+	m.AggregatePrices()
+
+	f, _ := m.GetIndexPrices()["SDAI/USD"].Float64()
+	require.InDelta(t, 1.1241, f, 1e-12)
+
+	// The composed price is not available without the price of the underlying asset.
+	m.Reset()
+	m.SetProviderPrices(erc4626Name, types.Prices{"SDAI/DAI": big.NewFloat(1.125)})
+	m.AggregatePrices()
+	require.NotContains(t, m.GetIndexPrices(), "SDAI/USD")
+}
@@ -0,0 +1,51 @@
+package oracle
+
+import (
+	"math/big"
+
+	"go.uber.org/zap"
+)
+
+// splitObserved partitions the converted prices of the given ticker into the prices that are
+// aggregated and the prices of the ticker's observe-only providers, i.e. the providers with a
+// weight of 0.
+func (m *IndexPriceAggregator) splitObserved(
+	ticker string,
+	prices []ProviderPrice,
+) (aggregated []ProviderPrice, observed []ProviderPrice) {
+	aggregated = make([]ProviderPrice, 0, len(prices))
+	for _, price := range prices {
+		if m.aggregation.IsObserveOnly(ticker, price.Provider) {
+			observed = append(observed, price)
+			continue
+		}
+
+		aggregated = append(aggregated, price)
+	}
+
+	return aggregated, observed
+}
+
+// compareObserved logs the prices of the observe-only providers of the given ticker alongside
+// their deviation from the ticker's aggregated price, and reports the deviation as a metric.
+func (m *IndexPriceAggregator) compareObserved(ticker, target string, price *big.Float, observed []ProviderPrice) {
+	if price.Sign() == 0 {
+		return
+	}
+
+	for _, p := range observed {
+		diff := new(big.Float).Sub(p.Price, price)
+		d, _ := diff.Quo(diff, price).Float64()
+
+		m.logger.Info(
+			"observed price of observe-only provider",
+			zap.String("target_ticker", ticker),
+			zap.String("provider", p.Provider),
+			zap.String("price", p.Price.String()),
+			zap.String("aggregated_price", price.String()),
+			zap.Float64("deviation", d),
+		)
+
+		m.metrics.UpdateObservedDeviation(p.Provider, target, d)
+	}
+}
@@ -0,0 +1,74 @@
+package oracle_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	metricmocks "github.com/skip-mev/connect/v2/oracle/metrics/mocks"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	"github.com/skip-mev/connect/v2/providers/apis/binance"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+)
+
+func TestAggregateDataWithObserveOnlyProviders(t *testing.T) {
+	metrics := metricmocks.NewMetrics(t)
+	metrics.On("AddProviderTick", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	metrics.On("UpdatePrice", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	metrics.On("AddProviderCountForMarket", USDT_USD.String(), 2).Once()
+	metrics.On("AddTickerTick", USDT_USD.String()).Once()
+	metrics.On("UpdateAggregatePrice", USDT_USD.String(), mock.Anything, mock.Anything).Once()
+	metrics.On("AddProviderCountForMarket", mock.Anything, 0).Maybe()
+	metrics.On("MissingPrices", mock.Anything).Once()
+	metrics.On("UpdateObservedDeviation", binance.Name, USDT_USD.String(), mock.MatchedBy(func(d float64) bool {
+		return math.Abs(d-0.5) < 1e-9
+	})).Once()
+
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		metrics,
+		oracle.WithAggregationConfig(config.AggregationConfig{
+			ProviderWeights: map[string]float64{binance.Name: 0},
+		}),
+	)
+	require.NoError(t, err)
+
+	// The price reported by binance is observed, but excluded from the aggregation.
+	m.SetProviderPrices(coinbase.Name, types.Prices{
+		"USDT-USD":  big.NewFloat(1.0),
+		"USDC-USDT": big.NewFloat(1.0),
+	})
+	m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(1.5)})
+	m.AggregatePrices()
+
+	result := m.GetIndexPrices()
+	require.Len(t, result, 1)
+	require.Equal(t, big.NewFloat(1.0).SetPrec(36), result[USDT_USD.String()].SetPrec(36))
+}
+
+func TestAggregateDataWithObserveOnlyProvidersBelowMinProviderCount(t *testing.T) {
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		nil,
+		oracle.WithAggregationConfig(config.AggregationConfig{
+			Markets: map[string]config.AggregationStrategyConfig{
+				USDT_USD.String(): {Weights: map[string]float64{binance.Name: 0}},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	// Observe-only providers do not count towards the market's min provider count.
+	m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(1.0)})
+	m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(1.0)})
+	m.AggregatePrices()
+
+	require.Empty(t, m.GetIndexPrices())
+}
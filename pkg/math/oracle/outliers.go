@@ -0,0 +1,60 @@
+package oracle
+
+import (
+	"math/big"
+
+	"github.com/skip-mev/connect/v2/pkg/math"
+)
+
+// minOutlierFilterPrices is the minimum number of prices required to filter outliers. With
+// fewer prices, it is not possible to tell which of the prices is the outlier.
+const minOutlierFilterPrices = 3
+
+// FilterOutliers partitions the prices into the prices that are kept and the prices that are
+// discarded as outliers. Outliers are discarded one at a time: the price that deviates the most
+// from the median of the remaining prices is discarded if its deviation, expressed as a fraction
+// of the median, exceeds the max deviation. This repeats until no remaining price exceeds the max
+// deviation. Outliers are only filtered while there are at least three remaining prices and the
+// max deviation is positive.
+func FilterOutliers(
+	prices []ProviderPrice,
+	maxDeviation float64,
+) (kept []ProviderPrice, outliers []ProviderPrice) {
+	kept = prices
+	if maxDeviation <= 0 {
+		return kept, nil
+	}
+
+	threshold := big.NewFloat(maxDeviation)
+	for len(kept) >= minOutlierFilterPrices {
+		median := math.CalculateMedian(values(kept))
+		if median.Sign() == 0 {
+			break
+		}
+
+		// Find the price that deviates the most from the median.
+		worst, worstDeviation := -1, threshold
+		for i, price := range kept {
+			if d := deviation(price.Price, median); d.Cmp(worstDeviation) > 0 {
+				worst, worstDeviation = i, d
+			}
+		}
+
+		if worst < 0 {
+			break
+		}
+
+		outliers = append(outliers, kept[worst])
+		kept = append(append(make([]ProviderPrice, 0, len(kept)-1), kept[:worst]...), kept[worst+1:]...)
+	}
+
+	return kept, outliers
+}
+
+// deviation returns |price - reference| / |reference|.
+func deviation(price, reference *big.Float) *big.Float {
+	diff := new(big.Float).Sub(price, reference)
+	diff.Abs(diff)
+
+	return diff.Quo(diff, new(big.Float).Abs(reference))
+}
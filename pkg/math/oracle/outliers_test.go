@@ -0,0 +1,136 @@
+package oracle_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	metricmocks "github.com/skip-mev/connect/v2/oracle/metrics/mocks"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	"github.com/skip-mev/connect/v2/providers/apis/binance"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	"github.com/skip-mev/connect/v2/providers/websockets/kucoin"
+)
+
+func TestFilterOutliers(t *testing.T) {
+	testCases := []struct {
+		name         string
+		prices       []oracle.ProviderPrice
+		maxDeviation float64
+		kept         int
+		outliers     []string
+	}{
+		{
+			name: "filter disabled",
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(100)},
+				{Provider: binance.Name, Price: big.NewFloat(101)},
+				{Provider: kucoin.Name, Price: big.NewFloat(200)},
+			},
+			maxDeviation: 0,
+			kept:         3,
+		},
+		{
+			name: "too few prices to filter",
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(100)},
+				{Provider: kucoin.Name, Price: big.NewFloat(200)},
+			},
+			maxDeviation: 0.05,
+			kept:         2,
+		},
+		{
+			name: "no outliers",
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(100)},
+				{Provider: binance.Name, Price: big.NewFloat(101)},
+				{Provider: kucoin.Name, Price: big.NewFloat(99)},
+			},
+			maxDeviation: 0.05,
+			kept:         3,
+		},
+		{
+			name: "single outlier",
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(100)},
+				{Provider: binance.Name, Price: big.NewFloat(101)},
+				{Provider: kucoin.Name, Price: big.NewFloat(200)},
+			},
+			maxDeviation: 0.05,
+			kept:         2,
+			outliers:     []string{kucoin.Name},
+		},
+		{
+			name: "multiple outliers",
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(100)},
+				{Provider: binance.Name, Price: big.NewFloat(101)},
+				{Provider: kucoin.Name, Price: big.NewFloat(1000)},
+				{Provider: "okx_ws", Price: big.NewFloat(99)},
+				{Provider: "bybit_ws", Price: big.NewFloat(50)},
+			},
+			maxDeviation: 0.05,
+			kept:         3,
+			outliers:     []string{kucoin.Name, "bybit_ws"},
+		},
+		{
+			name: "price at the max deviation is kept",
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(100)},
+				{Provider: binance.Name, Price: big.NewFloat(100)},
+				{Provider: kucoin.Name, Price: big.NewFloat(110)},
+			},
+			maxDeviation: 0.1,
+			kept:         3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kept, outliers := oracle.FilterOutliers(tc.prices, tc.maxDeviation)
+			require.Len(t, kept, tc.kept)
+			require.Len(t, outliers, len(tc.outliers))
+			for i, outlier := range outliers {
+				require.Equal(t, tc.outliers[i], outlier.Provider)
+			}
+		})
+	}
+}
+
+func TestAggregateDataWithOutliers(t *testing.T) {
+	metrics := metricmocks.NewMetrics(t)
+	metrics.On("AddProviderTick", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	metrics.On("UpdatePrice", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	metrics.On("AddProviderCountForMarket", USDT_USD.String(), 2).Once()
+	metrics.On("AddTickerTick", USDT_USD.String()).Once()
+	metrics.On("UpdateAggregatePrice", USDT_USD.String(), mock.Anything, mock.Anything).Once()
+	metrics.On("AddProviderCountForMarket", mock.Anything, 0).Maybe()
+	metrics.On("MissingPrices", mock.Anything).Once()
+	metrics.On("AddOutlierPrice", coinbase.Name, USDT_USD.String()).Once()
+
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		metrics,
+		oracle.WithAggregationConfig(config.AggregationConfig{
+			Default: config.AggregationStrategyConfig{MaxDeviation: 0.05},
+		}),
+	)
+	require.NoError(t, err)
+
+	// The inverted USDC/USDT price reported by coinbase is an outlier.
+	m.SetProviderPrices(coinbase.Name, types.Prices{
+		"USDT-USD":  big.NewFloat(1.0),
+		"USDC-USDT": big.NewFloat(2.0),
+	})
+	m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(1.02)})
+	m.AggregatePrices()
+
+	result := m.GetIndexPrices()
+	require.Len(t, result, 1)
+	require.Equal(t, big.NewFloat(1.01).SetPrec(36), result[USDT_USD.String()].SetPrec(36))
+}
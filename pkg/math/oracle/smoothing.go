@@ -0,0 +1,86 @@
+package oracle
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/math"
+)
+
+// Smoother defines the interface for the temporal smoothing of the aggregated price of a single
+// market. A Smoother is created per market, and keeps state across aggregation ticks.
+type Smoother interface {
+	// Smooth records the aggregated price of the current tick and returns the smoothed price.
+	Smooth(price *big.Float) *big.Float
+}
+
+// NewSmoother returns the Smoother for the given smoothing config.
+func NewSmoother(cfg config.SmoothingConfig) (Smoother, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Type {
+	case config.SmoothingEMA:
+		return NewEMASmoother(cfg.Alpha), nil
+	case config.SmoothingMedian:
+		return NewMedianSmoother(cfg.Window), nil
+	default:
+		return nil, fmt.Errorf("unknown smoothing: %s", cfg.Type)
+	}
+}
+
+// EMASmoother smooths prices with an exponential moving average.
+type EMASmoother struct {
+	alpha    float64
+	smoothed *big.Float
+}
+
+// NewEMASmoother returns a new EMASmoother with the given smoothing factor.
+func NewEMASmoother(alpha float64) *EMASmoother {
+	return &EMASmoother{alpha: alpha}
+}
+
+// Smooth returns the exponential moving average of the prices, including the given price. The
+// first price is returned as is.
+func (s *EMASmoother) Smooth(price *big.Float) *big.Float {
+	if s.smoothed != nil {
+		// ema = previous + alpha * (price - previous)
+		delta := new(big.Float).Sub(price, s.smoothed)
+		price = new(big.Float).Add(s.smoothed, delta.Mul(delta, big.NewFloat(s.alpha)))
+	}
+
+	s.smoothed = new(big.Float).Copy(price)
+	return price
+}
+
+// MedianSmoother smooths prices by taking the median of the prices of the last N ticks.
+type MedianSmoother struct {
+	window  int
+	history []*big.Float
+}
+
+// NewMedianSmoother returns a new MedianSmoother that takes the median over the given number of
+// ticks.
+func NewMedianSmoother(window int) *MedianSmoother {
+	return &MedianSmoother{
+		window:  window,
+		history: make([]*big.Float, 0, window),
+	}
+}
+
+// Smooth records the price and returns the median of the prices over the window. Until the window
+// is filled, the median is taken over all recorded ticks.
+func (s *MedianSmoother) Smooth(price *big.Float) *big.Float {
+	if len(s.history) == s.window {
+		s.history = s.history[1:]
+	}
+	s.history = append(s.history, new(big.Float).Copy(price))
+
+	// The median sorts the prices, so it is taken over a copy of the history.
+	prices := make([]*big.Float, len(s.history))
+	copy(prices, s.history)
+
+	return new(big.Float).Copy(math.CalculateMedian(prices))
+}
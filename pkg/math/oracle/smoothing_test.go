@@ -0,0 +1,155 @@
+package oracle_test
+
+import (
+	"maps"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/metrics"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	"github.com/skip-mev/connect/v2/providers/apis/binance"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+func TestSmoother(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      config.SmoothingConfig
+		prices   []float64
+		expected []float64
+		err      bool
+	}{
+		{
+			name:     "ema",
+			cfg:      config.SmoothingConfig{Type: config.SmoothingEMA, Alpha: 0.5},
+			prices:   []float64{100, 200, 200, 100},
+			expected: []float64{100, 150, 175, 137.5},
+		},
+		{
+			name:     "ema with an alpha of 1 does not smooth",
+			cfg:      config.SmoothingConfig{Type: config.SmoothingEMA, Alpha: 1},
+			prices:   []float64{100, 200, 50},
+			expected: []float64{100, 200, 50},
+		},
+		{
+			name:     "median discards a single tick spike",
+			cfg:      config.SmoothingConfig{Type: config.SmoothingMedian, Window: 3},
+			prices:   []float64{100, 101, 500, 102, 103},
+			expected: []float64{100, 100.5, 101, 102, 103},
+		},
+		{
+			name:     "median follows a sustained move",
+			cfg:      config.SmoothingConfig{Type: config.SmoothingMedian, Window: 3},
+			prices:   []float64{100, 100, 200, 200, 200},
+			expected: []float64{100, 100, 100, 200, 200},
+		},
+		{
+			name: "invalid config",
+			cfg:  config.SmoothingConfig{Type: config.SmoothingMedian},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			smoother, err := oracle.NewSmoother(tc.cfg)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for i, price := range tc.prices {
+				actual, _ := smoother.Smooth(big.NewFloat(price)).Float64()
+				require.InDelta(t, tc.expected[i], actual, 1e-9, "tick %d", i)
+			}
+		})
+	}
+}
+
+func TestAggregateDataWithSmoothing(t *testing.T) {
+	var (
+		friday   = time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)
+		saturday = time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)
+		now      = friday
+	)
+
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		metrics.NewNopMetrics(),
+		oracle.WithAggregationConfig(config.AggregationConfig{
+			Smoothing: map[string]config.SmoothingConfig{
+				USDT_USD.String(): {Type: config.SmoothingEMA, Alpha: 0.5},
+			},
+			Schedules: map[string]config.ScheduleConfig{
+				USDT_USD.String(): {
+					Sessions: []config.SessionConfig{
+						{Days: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, Open: "00:00", Close: "23:59"},
+					},
+				},
+			},
+		}),
+		oracle.WithClock(func() time.Time { return now }),
+	)
+	require.NoError(t, err)
+
+	aggregate := func(price float64) {
+		m.Reset()
+		m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(price)})
+		m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(price)})
+		m.AggregatePrices()
+	}
+	requirePrice := func(expected float64) {
+		actual, _ := m.GetIndexPrices()[USDT_USD.String()].Float64()
+		require.InDelta(t, expected, actual, 1e-9)
+	}
+
+	// The first price is published as is, and subsequent prices are smoothed.
+	aggregate(1.0)
+	requirePrice(1.0)
+	aggregate(1.2)
+	requirePrice(1.1)
+
+	// The smoothed price is scaled to the decimals of the market.
+	scaled, _ := m.GetPrices()[USDT_USD.String()].Float64()
+	require.InDelta(t, 1.1*1e6, scaled, 1e-3)
+
+	// The smoothing starts over once the market re-opens.
+	now = saturday
+	aggregate(2.0)
+	requirePrice(1.1)
+
+	now = friday.AddDate(0, 0, 7)
+	aggregate(1.5)
+	requirePrice(1.5)
+
+	// The smoothing starts over once a removed market is re-added.
+	removed := mmtypes.MarketMap{Markets: maps.Clone(marketmap.Markets)}
+	delete(removed.Markets, USDT_USD.String())
+	m.UpdateMarketMap(removed)
+	m.UpdateMarketMap(marketmap)
+
+	aggregate(3.0)
+	requirePrice(3.0)
+
+	t.Run("invalid smoothing config", func(t *testing.T) {
+		_, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(config.AggregationConfig{
+				Smoothing: map[string]config.SmoothingConfig{
+					USDT_USD.String(): {Type: config.SmoothingEMA},
+				},
+			}),
+		)
+		require.Error(t, err)
+	})
+}
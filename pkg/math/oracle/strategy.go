@@ -0,0 +1,207 @@
+package oracle
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/math"
+)
+
+// ProviderPrice is the converted price of a market as reported by a single provider config.
+type ProviderPrice struct {
+	// Provider is the name of the provider.
+	Provider string
+	// Price is the converted price.
+	Price *big.Float
+	// Weight is the confidence weight the provider reported alongside the price, or 0 if the
+	// provider did not report a weight.
+	Weight float64
+	// Volume is the volume the provider reported alongside the price, or 0 if the provider did
+	// not report a volume.
+	Volume float64
+}
+
+// Aggregator defines the interface for a strategy that aggregates the converted prices of a
+// single market into the market's index price. An Aggregator is created per market, so it may
+// keep state across aggregation ticks.
+type Aggregator interface {
+	// Aggregate returns the aggregated price given the converted prices reported by each
+	// provider for the current tick.
+	Aggregate(prices []ProviderPrice) (*big.Float, error)
+}
+
+// NewAggregator returns the Aggregator for the given aggregation strategy config.
+func NewAggregator(cfg config.AggregationStrategyConfig) (Aggregator, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Strategy {
+	case "", config.AggregationStrategyMedian:
+		return MedianAggregator{}, nil
+	case config.AggregationStrategyTrimmedMean:
+		return TrimmedMeanAggregator{TrimFraction: cfg.TrimFraction}, nil
+	case config.AggregationStrategyWeightedMean:
+		return WeightedMeanAggregator{Weights: cfg.Weights, ReportedWeights: cfg.ReportedWeights}, nil
+	case config.AggregationStrategyTWAP:
+		return NewTWAPAggregator(cfg.TWAPWindow), nil
+	case config.AggregationStrategyVWAP:
+		return VWAPAggregator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown aggregation strategy: %s", cfg.Strategy)
+	}
+}
+
+// MedianAggregator aggregates prices by taking their median. This takes the average of the
+// middle two prices if the number of prices is even.
+type MedianAggregator struct{}
+
+// Aggregate returns the median of the prices.
+func (MedianAggregator) Aggregate(prices []ProviderPrice) (*big.Float, error) {
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no prices to aggregate")
+	}
+
+	return math.CalculateMedian(values(prices)), nil
+}
+
+// TrimmedMeanAggregator aggregates prices by discarding the given fraction of prices from each
+// end of the sorted prices and taking the mean of the remaining prices.
+type TrimmedMeanAggregator struct {
+	TrimFraction float64
+}
+
+// Aggregate returns the trimmed mean of the prices.
+func (a TrimmedMeanAggregator) Aggregate(prices []ProviderPrice) (*big.Float, error) {
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no prices to aggregate")
+	}
+
+	sorted := values(prices)
+	math.SortBigFloats(sorted)
+
+	trim := int(a.TrimFraction * float64(len(sorted)))
+	return mean(sorted[trim : len(sorted)-trim]), nil
+}
+
+// WeightedMeanAggregator aggregates prices by taking their mean weighted by the weight of the
+// reporting provider. Providers without a weight are given a weight of 1. If ReportedWeights is
+// set, the weight of the provider is multiplied by the confidence weight the provider reported
+// alongside each price, e.g. the inverse of the price's relative confidence interval. Reported
+// volumes are never used. Prices without a reported weight are only weighted by their
+// provider's weight.
+type WeightedMeanAggregator struct {
+	Weights         map[string]float64
+	ReportedWeights bool
+}
+
+// Aggregate returns the weighted mean of the prices.
+func (a WeightedMeanAggregator) Aggregate(prices []ProviderPrice) (*big.Float, error) {
+	var (
+		sum         = new(big.Float)
+		totalWeight = new(big.Float)
+	)
+	for _, p := range prices {
+		w, ok := a.Weights[p.Provider]
+		if !ok {
+			w = 1
+		}
+
+		if a.ReportedWeights && p.Weight > 0 {
+			w *= p.Weight
+		}
+
+		weight := big.NewFloat(w)
+		sum.Add(sum, new(big.Float).Mul(p.Price, weight))
+		totalWeight.Add(totalWeight, weight)
+	}
+
+	if totalWeight.Sign() == 0 {
+		return nil, fmt.Errorf("total weight of prices is zero")
+	}
+
+	return sum.Quo(sum, totalWeight), nil
+}
+
+// VWAPAggregator aggregates prices by taking their mean weighted by the volume each provider
+// reported alongside its price, so that thin markets do not get the same weight as deep ones.
+// Prices without a reported volume are discarded. If no price has a reported volume, the median
+// of the prices is used.
+type VWAPAggregator struct{}
+
+// Aggregate returns the volume weighted mean of the prices.
+func (VWAPAggregator) Aggregate(prices []ProviderPrice) (*big.Float, error) {
+	var (
+		sum         = new(big.Float)
+		totalVolume = new(big.Float)
+	)
+	for _, p := range prices {
+		if p.Volume <= 0 {
+			continue
+		}
+
+		volume := big.NewFloat(p.Volume)
+		sum.Add(sum, new(big.Float).Mul(p.Price, volume))
+		totalVolume.Add(totalVolume, volume)
+	}
+
+	if totalVolume.Sign() == 0 {
+		return MedianAggregator{}.Aggregate(prices)
+	}
+
+	return sum.Quo(sum, totalVolume), nil
+}
+
+// TWAPAggregator aggregates prices by taking the median of the prices at each tick and averaging
+// the medians over the last N ticks. Since the oracle aggregates prices at a fixed interval, the
+// simple average over ticks is a time-weighted average.
+type TWAPAggregator struct {
+	window  int
+	history []*big.Float
+}
+
+// NewTWAPAggregator returns a new TWAPAggregator that averages over the given number of ticks.
+func NewTWAPAggregator(window int) *TWAPAggregator {
+	return &TWAPAggregator{
+		window:  window,
+		history: make([]*big.Float, 0, window),
+	}
+}
+
+// Aggregate records the median of the prices for the current tick and returns the average of
+// the medians over the window. Until the window is filled, the average is taken over all
+// recorded ticks.
+func (a *TWAPAggregator) Aggregate(prices []ProviderPrice) (*big.Float, error) {
+	median, err := MedianAggregator{}.Aggregate(prices)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(a.history) == a.window {
+		a.history = a.history[1:]
+	}
+	a.history = append(a.history, new(big.Float).Copy(median))
+
+	return mean(a.history), nil
+}
+
+// values returns the prices without their providers. The returned slice is safe to sort.
+func values(prices []ProviderPrice) []*big.Float {
+	vals := make([]*big.Float, len(prices))
+	for i, p := range prices {
+		vals[i] = p.Price
+	}
+
+	return vals
+}
+
+// mean returns the arithmetic mean of the values.
+func mean(vals []*big.Float) *big.Float {
+	sum := new(big.Float)
+	for _, v := range vals {
+		sum.Add(sum, v)
+	}
+
+	return sum.Quo(sum, new(big.Float).SetInt64(int64(len(vals))))
+}
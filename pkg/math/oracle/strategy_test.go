@@ -0,0 +1,316 @@
+package oracle_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/metrics"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	"github.com/skip-mev/connect/v2/providers/apis/binance"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	"github.com/skip-mev/connect/v2/providers/apis/pyth"
+	"github.com/skip-mev/connect/v2/providers/websockets/kucoin"
+)
+
+func providerPrices(prices ...float64) []oracle.ProviderPrice {
+	result := make([]oracle.ProviderPrice, len(prices))
+	for i, price := range prices {
+		result[i] = oracle.ProviderPrice{Provider: "provider", Price: big.NewFloat(price)}
+	}
+
+	return result
+}
+
+func TestAggregators(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         config.AggregationStrategyConfig
+		prices      []oracle.ProviderPrice
+		expected    *big.Float
+		expectedErr bool
+	}{
+		{
+			name:     "default strategy is the median",
+			cfg:      config.AggregationStrategyConfig{},
+			prices:   providerPrices(3, 1, 2),
+			expected: big.NewFloat(2),
+		},
+		{
+			name:     "median of an even number of prices",
+			cfg:      config.AggregationStrategyConfig{Strategy: config.AggregationStrategyMedian},
+			prices:   providerPrices(4, 1, 2, 3),
+			expected: big.NewFloat(2.5),
+		},
+		{
+			name:        "median of no prices",
+			cfg:         config.AggregationStrategyConfig{Strategy: config.AggregationStrategyMedian},
+			prices:      nil,
+			expectedErr: true,
+		},
+		{
+			name: "trimmed mean discards the extremes",
+			cfg: config.AggregationStrategyConfig{
+				Strategy:     config.AggregationStrategyTrimmedMean,
+				TrimFraction: 0.2,
+			},
+			prices:   providerPrices(100, 1, 2, 3, 4),
+			expected: big.NewFloat(3),
+		},
+		{
+			name: "trimmed mean with too few prices to trim is the mean",
+			cfg: config.AggregationStrategyConfig{
+				Strategy:     config.AggregationStrategyTrimmedMean,
+				TrimFraction: 0.2,
+			},
+			prices:   providerPrices(1, 2),
+			expected: big.NewFloat(1.5),
+		},
+		{
+			name: "weighted mean",
+			cfg: config.AggregationStrategyConfig{
+				Strategy: config.AggregationStrategyWeightedMean,
+				Weights:  map[string]float64{coinbase.Name: 3, kucoin.Name: 0},
+			},
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(10)},
+				{Provider: binance.Name, Price: big.NewFloat(20)},
+				{Provider: kucoin.Name, Price: big.NewFloat(1000)},
+			},
+			expected: big.NewFloat(12.5),
+		},
+		{
+			name: "weighted mean ignores reported weights by default",
+			cfg: config.AggregationStrategyConfig{
+				Strategy: config.AggregationStrategyWeightedMean,
+			},
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(10), Weight: 3},
+				{Provider: binance.Name, Price: big.NewFloat(20)},
+			},
+			expected: big.NewFloat(15),
+		},
+		{
+			name: "weighted mean with reported weights",
+			cfg: config.AggregationStrategyConfig{
+				Strategy:        config.AggregationStrategyWeightedMean,
+				Weights:         map[string]float64{binance.Name: 2},
+				ReportedWeights: true,
+			},
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(10), Weight: 6},
+				{Provider: binance.Name, Price: big.NewFloat(20), Weight: 1},
+				{Provider: kucoin.Name, Price: big.NewFloat(30)},
+			},
+			// (6 * 10 + 2 * 20 + 1 * 30) / 9
+			expected: new(big.Float).Quo(big.NewFloat(130), big.NewFloat(9)),
+		},
+		{
+			name: "weighted mean with no weight",
+			cfg: config.AggregationStrategyConfig{
+				Strategy: config.AggregationStrategyWeightedMean,
+				Weights:  map[string]float64{coinbase.Name: 0},
+			},
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(10)},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "vwap weights prices by their reported volumes",
+			cfg: config.AggregationStrategyConfig{
+				Strategy: config.AggregationStrategyVWAP,
+			},
+			prices: []oracle.ProviderPrice{
+				{Provider: coinbase.Name, Price: big.NewFloat(10), Volume: 300},
+				{Provider: binance.Name, Price: big.NewFloat(20), Volume: 100},
+				{Provider: kucoin.Name, Price: big.NewFloat(1000)},
+			},
+			// (300 * 10 + 100 * 20) / 400
+			expected: big.NewFloat(12.5),
+		},
+		{
+			name: "vwap ignores reported confidence weights",
+			cfg: config.AggregationStrategyConfig{
+				Strategy: config.AggregationStrategyVWAP,
+			},
+			prices: []oracle.ProviderPrice{
+				{Provider: pyth.Name, Price: big.NewFloat(1000), Weight: 5000},
+				{Provider: coinbase.Name, Price: big.NewFloat(10), Volume: 300},
+				{Provider: binance.Name, Price: big.NewFloat(20), Volume: 100},
+			},
+			// (300 * 10 + 100 * 20) / 400
+			expected: big.NewFloat(12.5),
+		},
+		{
+			name: "weighted mean with reported weights ignores reported volumes",
+			cfg: config.AggregationStrategyConfig{
+				Strategy:        config.AggregationStrategyWeightedMean,
+				ReportedWeights: true,
+			},
+			prices: []oracle.ProviderPrice{
+				{Provider: pyth.Name, Price: big.NewFloat(10), Weight: 3},
+				{Provider: coinbase.Name, Price: big.NewFloat(20), Volume: 300},
+			},
+			// (3 * 10 + 1 * 20) / 4
+			expected: big.NewFloat(12.5),
+		},
+		{
+			name: "vwap without reported volumes is the median",
+			cfg: config.AggregationStrategyConfig{
+				Strategy: config.AggregationStrategyVWAP,
+			},
+			prices:   providerPrices(3, 1, 2),
+			expected: big.NewFloat(2),
+		},
+		{
+			name:        "vwap of no prices",
+			cfg:         config.AggregationStrategyConfig{Strategy: config.AggregationStrategyVWAP},
+			prices:      nil,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator, err := oracle.NewAggregator(tc.cfg)
+			require.NoError(t, err)
+
+			price, err := aggregator.Aggregate(tc.prices)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.SetPrec(36), price.SetPrec(36))
+		})
+	}
+
+	t.Run("invalid strategy", func(t *testing.T) {
+		_, err := oracle.NewAggregator(config.AggregationStrategyConfig{Strategy: "mode"})
+		require.Error(t, err)
+	})
+}
+
+func TestTWAPAggregator(t *testing.T) {
+	aggregator := oracle.NewTWAPAggregator(3)
+
+	expected := []float64{
+		1,        // [1]
+		2,        // [1, 3]
+		3,        // [1, 3, 5]
+		5,        // [3, 5, 7]
+		20.0 / 3, // [5, 7, 8]
+	}
+	for i, median := range []float64{1, 3, 5, 7, 8} {
+		price, err := aggregator.Aggregate(providerPrices(median-1, median, median+1))
+		require.NoError(t, err)
+		require.Equal(t, big.NewFloat(expected[i]).SetPrec(36), price.SetPrec(36))
+	}
+
+	_, err := aggregator.Aggregate(nil)
+	require.Error(t, err)
+}
+
+func TestAggregateDataWithStrategies(t *testing.T) {
+	aggregation := config.AggregationConfig{
+		Markets: map[string]config.AggregationStrategyConfig{
+			"usdt/usd": {
+				Strategy: config.AggregationStrategyWeightedMean,
+				Weights:  map[string]float64{coinbase.Name: 3},
+			},
+		},
+	}
+
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		metrics.NewNopMetrics(),
+		oracle.WithAggregationConfig(aggregation),
+	)
+	require.NoError(t, err)
+
+	m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(1.1)})
+	m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(0.9)})
+	m.AggregatePrices()
+
+	// (3 * 1.1 + 0.9) / 4
+	result := m.GetIndexPrices()
+	require.Len(t, result, 1)
+	require.Equal(t, big.NewFloat(1.05).SetPrec(36), result[USDT_USD.String()].SetPrec(36))
+
+	t.Run("weights prices by their reported weights", func(t *testing.T) {
+		aggregation.Markets["usdt/usd"] = config.AggregationStrategyConfig{
+			Strategy:        config.AggregationStrategyWeightedMean,
+			ReportedWeights: true,
+		}
+
+		m, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(aggregation),
+		)
+		require.NoError(t, err)
+
+		m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(1.1)})
+		m.SetProviderWeights(coinbase.Name, types.Weights{"USDT-USD": 3})
+		m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(0.9)})
+		m.AggregatePrices()
+
+		// (3 * 1.1 + 0.9) / 4
+		result := m.GetIndexPrices()
+		require.Len(t, result, 1)
+		require.Equal(t, big.NewFloat(1.05).SetPrec(36), result[USDT_USD.String()].SetPrec(36))
+
+		// Reported weights are discarded on reset.
+		m.Reset()
+		m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(1.1)})
+		m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(0.9)})
+		m.AggregatePrices()
+
+		result = m.GetIndexPrices()
+		require.Equal(t, big.NewFloat(1).SetPrec(36), result[USDT_USD.String()].SetPrec(36))
+	})
+
+	t.Run("weights prices by their reported volumes", func(t *testing.T) {
+		aggregation.Markets["usdt/usd"] = config.AggregationStrategyConfig{
+			Strategy: config.AggregationStrategyVWAP,
+		}
+
+		m, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(aggregation),
+		)
+		require.NoError(t, err)
+
+		// Confidence weights are not volumes, so only binance's price has a volume.
+		m.SetProviderPrices(coinbase.Name, types.Prices{"USDT-USD": big.NewFloat(1.1)})
+		m.SetProviderWeights(coinbase.Name, types.Weights{"USDT-USD": 3})
+		m.SetProviderPrices(binance.Name, types.Prices{"USDTUSD": big.NewFloat(0.9)})
+		m.SetProviderVolumes(binance.Name, types.Volumes{"USDTUSD": 100})
+		m.AggregatePrices()
+
+		result := m.GetIndexPrices()
+		require.Len(t, result, 1)
+		require.Equal(t, big.NewFloat(0.9).SetPrec(36), result[USDT_USD.String()].SetPrec(36))
+	})
+
+	t.Run("invalid aggregation config", func(t *testing.T) {
+		_, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(config.AggregationConfig{
+				Default: config.AggregationStrategyConfig{Strategy: "mode"},
+			}),
+		)
+		require.Error(t, err)
+	})
+}
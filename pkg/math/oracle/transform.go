@@ -0,0 +1,121 @@
+package oracle
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+)
+
+// TransformPrice applies the transform pipeline of the given market ticker to the converted price
+// reported by the given provider. Steps that are restricted to another provider are skipped. The
+// multiply transform uses the index prices of the previous aggregation, in the same way prices
+// are normalized by pair. An error is returned if a step cannot be applied, in which case the
+// provider's price is not used.
+func (m *IndexPriceAggregator) TransformPrice(
+	ticker string,
+	provider string,
+	price *big.Float,
+) (*big.Float, error) {
+	for i, transform := range m.aggregation.TransformsForMarket(ticker) {
+		if transform.Provider != "" && transform.Provider != provider {
+			continue
+		}
+
+		var err error
+		switch transform.Type {
+		case config.TransformScale:
+			price = new(big.Float).Mul(price, big.NewFloat(transform.Factor))
+		case config.TransformInvert:
+			price, err = invert(price)
+		case config.TransformMultiply:
+			price, err = m.multiply(price, transform)
+		case config.TransformClamp:
+			price = clamp(price, transform.Min, transform.Max)
+		case config.TransformEMA:
+			price = m.smooth(ticker, fmt.Sprintf("%s/%d", provider, i), price, transform.Alpha)
+		default:
+			// Transforms are validated when the aggregator is constructed.
+			err = fmt.Errorf("unknown transform: %s", transform.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s transform %d: %w", transform.Type, i, err)
+		}
+	}
+
+	return price, nil
+}
+
+// multiply multiplies the price by the index price of the transform's ticker, or divides it by the
+// index price if the transform is inverted.
+func (m *IndexPriceAggregator) multiply(
+	price *big.Float,
+	transform config.TransformConfig,
+) (*big.Float, error) {
+	// The ticker is validated when the aggregator is constructed.
+	cp, err := pkgtypes.CurrencyPairFromString(transform.Ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	indexPrice, err := m.GetIndexPrice(cp)
+	if err != nil {
+		return nil, err
+	}
+
+	if transform.Invert {
+		if indexPrice.Sign() == 0 {
+			return nil, fmt.Errorf("cannot divide by zero index price of ticker: %s", cp)
+		}
+
+		return new(big.Float).Quo(price, indexPrice), nil
+	}
+
+	return new(big.Float).Mul(price, indexPrice), nil
+}
+
+// smooth returns the exponential moving average of the prices reported for the given market and
+// key, including the given price. The first price of each market and key is returned as is.
+func (m *IndexPriceAggregator) smooth(
+	ticker string,
+	key string,
+	price *big.Float,
+	alpha float64,
+) *big.Float {
+	if _, ok := m.smoothed[ticker]; !ok {
+		m.smoothed[ticker] = make(map[string]*big.Float)
+	}
+
+	if previous, ok := m.smoothed[ticker][key]; ok {
+		// ema = previous + alpha * (price - previous)
+		delta := new(big.Float).Sub(price, previous)
+		price = new(big.Float).Add(previous, delta.Mul(delta, big.NewFloat(alpha)))
+	}
+
+	m.smoothed[ticker][key] = new(big.Float).Copy(price)
+	return price
+}
+
+// invert returns the inverse of the given price.
+func invert(price *big.Float) (*big.Float, error) {
+	if price.Sign() == 0 {
+		return nil, fmt.Errorf("cannot invert zero price")
+	}
+
+	return new(big.Float).Quo(big.NewFloat(1), price), nil
+}
+
+// clamp bounds the given price by the given min and max prices. Bounds that are zero are not
+// enforced.
+func clamp(price *big.Float, minPrice, maxPrice float64) *big.Float {
+	if minPrice > 0 && price.Cmp(big.NewFloat(minPrice)) < 0 {
+		return big.NewFloat(minPrice)
+	}
+
+	if maxPrice > 0 && price.Cmp(big.NewFloat(maxPrice)) > 0 {
+		return big.NewFloat(maxPrice)
+	}
+
+	return price
+}
@@ -0,0 +1,223 @@
+package oracle_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/metrics"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math/oracle"
+	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/skip-mev/connect/v2/providers/apis/binance"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+func TestTransformPrice(t *testing.T) {
+	ticker := "BTC/USD"
+
+	testCases := []struct {
+		name       string
+		transforms []config.TransformConfig
+		index      types.Prices
+		price      *big.Float
+		expected   *big.Float
+		err        bool
+	}{
+		{
+			name:     "no transforms",
+			price:    big.NewFloat(70000),
+			expected: big.NewFloat(70000),
+		},
+		{
+			name:       "scale",
+			transforms: []config.TransformConfig{{Type: config.TransformScale, Factor: 0.01}},
+			price:      big.NewFloat(7000000),
+			expected:   big.NewFloat(70000),
+		},
+		{
+			name:       "invert",
+			transforms: []config.TransformConfig{{Type: config.TransformInvert}},
+			price:      big.NewFloat(0.0000125),
+			expected:   big.NewFloat(80000),
+		},
+		{
+			name:       "invert zero price",
+			transforms: []config.TransformConfig{{Type: config.TransformInvert}},
+			price:      big.NewFloat(0),
+			err:        true,
+		},
+		{
+			name:       "multiply by index price",
+			transforms: []config.TransformConfig{{Type: config.TransformMultiply, Ticker: "USDT/USD"}},
+			index:      types.Prices{"USDT/USD": big.NewFloat(1.25)},
+			price:      big.NewFloat(64000),
+			expected:   big.NewFloat(80000),
+		},
+		{
+			name:       "divide by index price",
+			transforms: []config.TransformConfig{{Type: config.TransformMultiply, Ticker: "USD/USDT", Invert: true}},
+			index:      types.Prices{"USD/USDT": big.NewFloat(0.8)},
+			price:      big.NewFloat(64000),
+			expected:   big.NewFloat(80000),
+		},
+		{
+			name:       "multiply by missing index price",
+			transforms: []config.TransformConfig{{Type: config.TransformMultiply, Ticker: "USDT/USD"}},
+			price:      big.NewFloat(64000),
+			err:        true,
+		},
+		{
+			name:       "clamp below the min price",
+			transforms: []config.TransformConfig{{Type: config.TransformClamp, Min: 1000, Max: 100000}},
+			price:      big.NewFloat(10),
+			expected:   big.NewFloat(1000),
+		},
+		{
+			name:       "clamp above the max price",
+			transforms: []config.TransformConfig{{Type: config.TransformClamp, Max: 100000}},
+			price:      big.NewFloat(200000),
+			expected:   big.NewFloat(100000),
+		},
+		{
+			name: "steps are applied in order",
+			transforms: []config.TransformConfig{
+				{Type: config.TransformInvert},
+				{Type: config.TransformScale, Factor: 2},
+				{Type: config.TransformClamp, Max: 150000},
+			},
+			price:    big.NewFloat(0.0000125),
+			expected: big.NewFloat(150000),
+		},
+		{
+			name: "steps restricted to another provider are skipped",
+			transforms: []config.TransformConfig{
+				{Type: config.TransformScale, Factor: 0.01, Provider: binance.Name},
+				{Type: config.TransformScale, Factor: 2, Provider: coinbase.Name},
+			},
+			price:    big.NewFloat(35000),
+			expected: big.NewFloat(70000),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := oracle.NewIndexPriceAggregator(
+				logger,
+				mmtypes.MarketMap{},
+				metrics.NewNopMetrics(),
+				oracle.WithAggregationConfig(config.AggregationConfig{
+					Transforms: map[string][]config.TransformConfig{ticker: tc.transforms},
+				}),
+			)
+			require.NoError(t, err)
+
+			if tc.index != nil {
+				m.SetIndexPrices(tc.index)
+			}
+
+			price, err := m.TransformPrice(ticker, coinbase.Name, tc.price)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.SetPrec(36), price.SetPrec(36))
+		})
+	}
+
+	t.Run("ema smooths prices per provider", func(t *testing.T) {
+		m, err := oracle.NewIndexPriceAggregator(
+			logger,
+			mmtypes.MarketMap{},
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					// Keys are lower-cased when the config is read via viper.
+					"btc/usd": {{Type: config.TransformEMA, Alpha: 0.5}},
+				},
+			}),
+		)
+		require.NoError(t, err)
+
+		// The first price is returned as is.
+		price, err := m.TransformPrice(ticker, coinbase.Name, big.NewFloat(100))
+		require.NoError(t, err)
+		require.Equal(t, big.NewFloat(100).SetPrec(36), price.SetPrec(36))
+
+		price, err = m.TransformPrice(ticker, coinbase.Name, big.NewFloat(200))
+		require.NoError(t, err)
+		require.Equal(t, big.NewFloat(150).SetPrec(36), price.SetPrec(36))
+
+		price, err = m.TransformPrice(ticker, coinbase.Name, big.NewFloat(250))
+		require.NoError(t, err)
+		require.Equal(t, big.NewFloat(200).SetPrec(36), price.SetPrec(36))
+
+		// The prices of each provider are smoothed separately.
+		price, err = m.TransformPrice(ticker, binance.Name, big.NewFloat(300))
+		require.NoError(t, err)
+		require.Equal(t, big.NewFloat(300).SetPrec(36), price.SetPrec(36))
+	})
+}
+
+func TestAggregateDataWithTransforms(t *testing.T) {
+	btcusd := mmtypes.Ticker{
+		CurrencyPair:     pkgtypes.NewCurrencyPair("BTC", "USD"),
+		Decimals:         8,
+		MinProviderCount: 2,
+		Enabled:          true,
+	}
+
+	marketmap := mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			btcusd.String(): {
+				Ticker: btcusd,
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: coinbase.Name, OffChainTicker: "BTC-USD"},
+					{Name: binance.Name, OffChainTicker: "BTCUSD_CENTS"},
+				},
+			},
+		},
+	}
+
+	m, err := oracle.NewIndexPriceAggregator(
+		logger,
+		marketmap,
+		metrics.NewNopMetrics(),
+		oracle.WithAggregationConfig(config.AggregationConfig{
+			Markets: map[string]config.AggregationStrategyConfig{
+				btcusd.String(): {MaxPrice: 1000000},
+			},
+			Transforms: map[string][]config.TransformConfig{
+				btcusd.String(): {{Type: config.TransformScale, Factor: 0.01, Provider: binance.Name}},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	// Binance reports the price in cents, which is normalized before the price bounds are applied.
+	m.SetProviderPrices(coinbase.Name, types.Prices{"BTC-USD": big.NewFloat(70000)})
+	m.SetProviderPrices(binance.Name, types.Prices{"BTCUSD_CENTS": big.NewFloat(7100000)})
+	m.AggregatePrices()
+
+	prices := m.GetIndexPrices()
+	require.Equal(t, big.NewFloat(70500).SetPrec(36), prices[btcusd.String()].SetPrec(36))
+
+	t.Run("invalid transform config", func(t *testing.T) {
+		_, err := oracle.NewIndexPriceAggregator(
+			logger,
+			marketmap,
+			metrics.NewNopMetrics(),
+			oracle.WithAggregationConfig(config.AggregationConfig{
+				Transforms: map[string][]config.TransformConfig{
+					btcusd.String(): {{Type: config.TransformEMA}},
+				},
+			}),
+		)
+		require.Error(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"maps"
 	"math/big"
+	"sort"
 
 	"github.com/skip-mev/connect/v2/oracle/types"
 	pkgtypes "github.com/skip-mev/connect/v2/pkg/types"
@@ -36,6 +37,22 @@ func (m *IndexPriceAggregator) GetProviderPrice(
 	return price, nil
 }
 
+// GetProviderWeight returns the weight the provider reported alongside its price of the
+// provider config's ticker, or 0 if the provider did not report a weight.
+func (m *IndexPriceAggregator) GetProviderWeight(
+	cfg mmtypes.ProviderConfig,
+) float64 {
+	return m.providerWeights[cfg.Name][cfg.OffChainTicker]
+}
+
+// GetProviderVolume returns the volume the provider reported alongside its price of the
+// provider config's ticker, or 0 if the provider did not report a volume.
+func (m *IndexPriceAggregator) GetProviderVolume(
+	cfg mmtypes.ProviderConfig,
+) float64 {
+	return m.providerVolumes[cfg.Name][cfg.OffChainTicker]
+}
+
 // GetIndexPrice returns the relevant index price. Note that the aggregator's
 // index price cache stores prices in the form of ticker -> price.
 func (m *IndexPriceAggregator) GetIndexPrice(
@@ -80,6 +97,24 @@ func (m *IndexPriceAggregator) UpdateMarketMap(marketMap mmtypes.MarketMap) {
 	defer m.mtx.Unlock()
 
 	m.cfg = marketMap
+
+	// Drop the strategies, smoothing and transform state of markets that were removed, such that
+	// any state they keep (e.g. the TWAP history or the smoothing window) is discarded.
+	for ticker := range m.strategies {
+		if _, ok := marketMap.Markets[ticker]; !ok {
+			delete(m.strategies, ticker)
+		}
+	}
+	for ticker := range m.smoothers {
+		if _, ok := marketMap.Markets[ticker]; !ok {
+			delete(m.smoothers, ticker)
+		}
+	}
+	for ticker := range m.smoothed {
+		if _, ok := marketMap.Markets[ticker]; !ok {
+			delete(m.smoothed, ticker)
+		}
+	}
 }
 
 // GetMarketMap returns the market map for the oracle.
@@ -102,12 +137,82 @@ func (m *IndexPriceAggregator) SetProviderPrices(provider string, data types.Pri
 	m.providerPrices[provider] = data
 }
 
+// SetProviderWeights updates the data aggregator with the weights the given provider reported
+// alongside its prices.
+func (m *IndexPriceAggregator) SetProviderWeights(provider string, weights types.Weights) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if weights == nil {
+		weights = make(types.Weights)
+	}
+
+	m.providerWeights[provider] = weights
+}
+
+// SetProviderVolumes updates the data aggregator with the volumes the given provider reported
+// alongside its prices.
+func (m *IndexPriceAggregator) SetProviderVolumes(provider string, volumes types.Volumes) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if volumes == nil {
+		volumes = make(types.Volumes)
+	}
+
+	m.providerVolumes[provider] = volumes
+}
+
 // Reset resets the data aggregator for all providers.
 func (m *IndexPriceAggregator) Reset() {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
 	m.providerPrices = make(map[string]types.Prices)
+	m.providerWeights = make(map[string]types.Weights)
+	m.providerVolumes = make(map[string]types.Volumes)
+}
+
+// GetHeldPrices returns the tickers whose market was out of session in the last aggregation, and
+// whose previous price is held by GetPrices until the market is back in session.
+func (m *IndexPriceAggregator) GetHeldPrices() []string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	tickers := make([]string, 0, len(m.closed))
+	for ticker := range m.closed {
+		if _, ok := m.indexPrices[ticker]; ok {
+			tickers = append(tickers, ticker)
+		}
+	}
+	sort.Strings(tickers)
+
+	return tickers
+}
+
+// GetProviderCounts returns the number of providers each index price of the last aggregation was
+// aggregated from. Prices held out of session report the count of the last aggregation in session.
+func (m *IndexPriceAggregator) GetProviderCounts() map[string]int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return maps.Clone(m.providerCounts)
+}
+
+// GetUnconfirmedPrices returns the tickers whose aggregated price moved by more than the ticker's
+// confirm deviation in the last aggregation. The previous prices of these tickers are returned by
+// GetPrices until the move is confirmed.
+func (m *IndexPriceAggregator) GetUnconfirmedPrices() []string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	tickers := make([]string, 0, len(m.unconfirmed))
+	for ticker := range m.unconfirmed {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+
+	return tickers
 }
 
 // GetPrices returns the aggregated data the aggregator has. Specifically, the
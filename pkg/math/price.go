@@ -0,0 +1,236 @@
+package math
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PricePrecision is the minimum precision, in bits, of the big.Float representation of a Price.
+	PricePrecision = 128
+
+	// MaxExponent is the largest absolute exponent of a Price. 10^78 exceeds the largest uint256,
+	// so no price needs a larger scale, and bounding the exponent bounds the cost of rescaling
+	// prices whose exponent is read from remote data.
+	MaxExponent = 78
+)
+
+// Price is a fixed-point decimal price whose value is Value * 10^Exponent. Providers receive prices
+// in different scales, e.g. a Chainlink answer with 8 decimals, a Pyth price with an exponent of -5
+// or an ERC20 amount with 18 decimals. Price keeps the raw integer and its scale together, such that
+// conversions between scales are explicit and exact rather than implied by the caller. Prices should
+// be created with NewPrice or ParsePrice, which reject exponents beyond MaxExponent. Price is used
+// where fixed-point integers are read; the aggregator operates on the big.Float returned by BigFloat.
+type Price struct {
+	// Value is the unscaled integer value of the price.
+	Value *big.Int
+	// Exponent is the power of ten the value is multiplied by, e.g. -8 for a value with 8 decimals.
+	Exponent int64
+}
+
+// NewPrice returns a new Price with the value value * 10^exponent. The value is copied. An error is
+// returned if the absolute exponent exceeds MaxExponent.
+func NewPrice(value *big.Int, exponent int64) (Price, error) {
+	if err := validateExponent(exponent); err != nil {
+		return Price{}, err
+	}
+
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	return Price{
+		Value:    new(big.Int).Set(value),
+		Exponent: exponent,
+	}, nil
+}
+
+// NewPriceWithDecimals returns a new Price for a value with the given number of decimals, i.e. the
+// value is divided by 10^decimals.
+func NewPriceWithDecimals(value *big.Int, decimals uint64) (Price, error) {
+	return NewPrice(value, -toExponent(decimals))
+}
+
+// NewPriceFromBigFloat returns a new Price with the given number of decimals from a big.Float. Any
+// digits beyond the given decimals are truncated.
+func NewPriceFromBigFloat(f *big.Float, decimals uint64) (Price, error) {
+	exponent := -toExponent(decimals)
+	if err := validateExponent(exponent); err != nil {
+		return Price{}, err
+	}
+
+	if f == nil {
+		return NewPrice(nil, exponent)
+	}
+
+	scaled := new(big.Float).SetPrec(max(f.Prec(), PricePrecision)).Set(f)
+	scaled.Mul(scaled, new(big.Float).SetInt(pow10(-exponent)))
+
+	value, _ := scaled.Int(nil)
+	return NewPrice(value, exponent)
+}
+
+// ParsePrice parses a decimal string into a Price without any loss of precision. The string may
+// have a sign, a fractional part and a decimal exponent, e.g. "-1.25", "61033.66879713" or "1e-8".
+// Strings whose exponent, including the digits of the fractional part, exceeds MaxExponent are
+// rejected.
+func ParsePrice(s string) (Price, error) {
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(s[i+1:], 10, 64)
+		if err != nil {
+			return Price{}, fmt.Errorf("invalid exponent in price %q: %w", s, err)
+		}
+		mantissa, exponent = s[:i], exp
+	}
+
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		fraction := mantissa[i+1:]
+		mantissa = mantissa[:i] + fraction
+		exponent -= int64(len(fraction))
+	}
+
+	// Reject values such as "1.-5" that split an otherwise valid integer.
+	digits := strings.TrimLeft(mantissa, "+-")
+	if len(digits) == 0 || len(mantissa)-len(digits) > 1 || strings.ContainsAny(digits, "+-") {
+		return Price{}, fmt.Errorf("invalid price %q", s)
+	}
+
+	if err := validateExponent(exponent); err != nil {
+		return Price{}, fmt.Errorf("invalid price %q: %w", s, err)
+	}
+
+	value, ok := new(big.Int).SetString(mantissa, 10)
+	if !ok {
+		return Price{}, fmt.Errorf("invalid price %q", s)
+	}
+
+	return Price{Value: value, Exponent: exponent}, nil
+}
+
+// Sign returns -1, 0 or 1 depending on whether the price is negative, zero or positive.
+func (p Price) Sign() int {
+	if p.Value == nil {
+		return 0
+	}
+
+	return p.Value.Sign()
+}
+
+// Cmp compares the price to another price, returning -1, 0 or 1 depending on whether the price is
+// less than, equal to or greater than the other price. Prices of different scales are compared by
+// value.
+func (p Price) Cmp(other Price) int {
+	exponent := min(p.Exponent, other.Exponent)
+	return p.rescale(exponent).Value.Cmp(other.rescale(exponent).Value)
+}
+
+// Rescale returns the price with the given exponent. Increasing the exponent drops the least
+// significant digits, truncating the price towards zero. An error is returned if the absolute
+// exponent exceeds MaxExponent.
+func (p Price) Rescale(exponent int64) (Price, error) {
+	if err := validateExponent(exponent); err != nil {
+		return Price{}, err
+	}
+
+	return p.rescale(exponent), nil
+}
+
+// rescale returns the price with the given exponent, which must be within MaxExponent.
+func (p Price) rescale(exponent int64) Price {
+	value := p.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	switch {
+	case exponent < p.Exponent:
+		value = new(big.Int).Mul(value, pow10(p.Exponent-exponent))
+	case exponent > p.Exponent:
+		value = new(big.Int).Quo(value, pow10(exponent-p.Exponent))
+	default:
+		value = new(big.Int).Set(value)
+	}
+
+	return Price{Value: value, Exponent: exponent}
+}
+
+// Int returns the integer value of the price with the given number of decimals, i.e. the price
+// multiplied by 10^decimals. Digits beyond the given decimals are truncated.
+func (p Price) Int(decimals uint64) (*big.Int, error) {
+	price, err := p.Rescale(-toExponent(decimals))
+	if err != nil {
+		return nil, err
+	}
+
+	return price.Value, nil
+}
+
+// BigFloat returns the price as a big.Float. The precision of the result is at least
+// PricePrecision and is large enough to represent the value exactly if the exponent is not
+// negative.
+func (p Price) BigFloat() *big.Float {
+	value := p.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	prec := max(uint(value.BitLen()), PricePrecision) //nolint:gosec
+	f := new(big.Float).SetPrec(prec).SetInt(value)
+	switch {
+	case p.Exponent > 0:
+		scale := pow10(p.Exponent)
+		f.SetPrec(prec + uint(scale.BitLen())) //nolint:gosec
+		f.Mul(f, new(big.Float).SetInt(scale))
+	case p.Exponent < 0:
+		f.Quo(f, new(big.Float).SetInt(pow10(-p.Exponent)))
+	}
+
+	return f
+}
+
+// String returns the price as a decimal string, e.g. "61033.66879713".
+func (p Price) String() string {
+	if p.Exponent >= 0 {
+		return p.rescale(0).Value.String()
+	}
+
+	digits := p.rescale(p.Exponent).Value.String()
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	decimals := int(-p.Exponent)
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+}
+
+// validateExponent returns an error if the absolute exponent exceeds MaxExponent.
+func validateExponent(exponent int64) error {
+	if exponent < -MaxExponent || exponent > MaxExponent {
+		return fmt.Errorf("exponent %d is out of range [%d, %d]", exponent, -MaxExponent, MaxExponent)
+	}
+
+	return nil
+}
+
+// pow10 returns 10^exponent for a non-negative exponent.
+func pow10(exponent int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(exponent), nil)
+}
+
+// toExponent converts a number of decimals to an exponent, capping it at the maximum int64.
+func toExponent(decimals uint64) int64 {
+	if decimals > math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return int64(decimals) //nolint:gosec // handled above
+}
@@ -0,0 +1,543 @@
+package math_test
+
+import (
+	gomath "math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/pkg/math"
+)
+
+func TestNewPrice(t *testing.T) {
+	t.Run("copies the value", func(t *testing.T) {
+		value := big.NewInt(100)
+		price, err := math.NewPrice(value, -2)
+		require.NoError(t, err)
+
+		value.SetInt64(200)
+		require.Equal(t, big.NewInt(100), price.Value)
+		require.Equal(t, int64(-2), price.Exponent)
+	})
+
+	t.Run("nil value is zero", func(t *testing.T) {
+		price, err := math.NewPrice(nil, -2)
+		require.NoError(t, err)
+		require.Equal(t, 0, price.Sign())
+		require.Equal(t, "0.00", price.String())
+	})
+
+	t.Run("decimals are a negative exponent", func(t *testing.T) {
+		price, err := math.NewPriceWithDecimals(big.NewInt(6103366879713), 8)
+		require.NoError(t, err)
+		require.Equal(t, int64(-8), price.Exponent)
+		require.Equal(t, "61033.66879713", price.String())
+	})
+
+	t.Run("exponent range", func(t *testing.T) {
+		for _, exponent := range []int64{-math.MaxExponent, 0, math.MaxExponent} {
+			price, err := math.NewPrice(big.NewInt(1), exponent)
+			require.NoError(t, err)
+			require.Equal(t, exponent, price.Exponent)
+		}
+
+		for _, exponent := range []int64{-math.MaxExponent - 1, math.MaxExponent + 1, gomath.MinInt64, gomath.MaxInt64} {
+			_, err := math.NewPrice(big.NewInt(1), exponent)
+			require.Error(t, err)
+		}
+
+		_, err := math.NewPriceWithDecimals(big.NewInt(1), math.MaxExponent+1)
+		require.Error(t, err)
+
+		_, err = math.NewPriceWithDecimals(big.NewInt(1), gomath.MaxUint64)
+		require.Error(t, err)
+	})
+}
+
+func TestNewPriceFromBigFloat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		in       *big.Float
+		decimals uint64
+		out      string
+		err      bool
+	}{
+		{
+			name:     "zero",
+			in:       big.NewFloat(0),
+			decimals: 6,
+			out:      "0.000000",
+		},
+		{
+			name:     "integer",
+			in:       big.NewFloat(1),
+			decimals: 6,
+			out:      "1.000000",
+		},
+		{
+			name:     "digits beyond the decimals are truncated",
+			in:       big.NewFloat(1.23456789),
+			decimals: 4,
+			out:      "1.2345",
+		},
+		{
+			name:     "negative values are truncated towards zero",
+			in:       big.NewFloat(-1.23456789),
+			decimals: 4,
+			out:      "-1.2345",
+		},
+		{
+			name:     "value that has more 0s than decimals",
+			in:       big.NewFloat(1e-16),
+			decimals: 6,
+			out:      "0.000000",
+		},
+		{
+			name:     "no decimals",
+			in:       big.NewFloat(420420.42),
+			decimals: 0,
+			out:      "420420",
+		},
+		{
+			name:     "nil value",
+			in:       nil,
+			decimals: 2,
+			out:      "0.00",
+		},
+		{
+			name:     "low precision values are scaled at the price precision",
+			in:       new(big.Float).SetPrec(24).SetInt64(16777215),
+			decimals: 18,
+			out:      "16777215.000000000000000000",
+		},
+		{
+			name:     "decimals beyond the max exponent",
+			in:       big.NewFloat(1),
+			decimals: math.MaxExponent + 1,
+			err:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, err := math.NewPriceFromBigFloat(tc.in, tc.decimals)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.out, price.String())
+			require.Equal(t, -int64(tc.decimals), price.Exponent) //nolint:gosec
+		})
+	}
+}
+
+func TestParsePrice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		in       string
+		value    *big.Int
+		exponent int64
+		err      bool
+	}{
+		{
+			name:     "integer",
+			in:       "42",
+			value:    big.NewInt(42),
+			exponent: 0,
+		},
+		{
+			name:     "decimal",
+			in:       "61033.66879713",
+			value:    big.NewInt(6103366879713),
+			exponent: -8,
+		},
+		{
+			name:     "negative decimal",
+			in:       "-1.25",
+			value:    big.NewInt(-125),
+			exponent: -2,
+		},
+		{
+			name:     "explicit positive sign",
+			in:       "+1.5",
+			value:    big.NewInt(15),
+			exponent: -1,
+		},
+		{
+			name:     "leading decimal point",
+			in:       ".5",
+			value:    big.NewInt(5),
+			exponent: -1,
+		},
+		{
+			name:     "trailing decimal point",
+			in:       "5.",
+			value:    big.NewInt(5),
+			exponent: 0,
+		},
+		{
+			name:     "negative exponent",
+			in:       "1e-8",
+			value:    big.NewInt(1),
+			exponent: -8,
+		},
+		{
+			name:     "positive exponent with fraction",
+			in:       "1.5E3",
+			value:    big.NewInt(15),
+			exponent: 2,
+		},
+		{
+			name:     "value has a lot of 0s",
+			in:       "0.0000000000000001",
+			value:    big.NewInt(1),
+			exponent: -16,
+		},
+		{
+			name:     "value exceeds float64 precision",
+			in:       "420420420.420420420",
+			value:    big.NewInt(420420420420420420),
+			exponent: -9,
+		},
+		{
+			name: "empty",
+			in:   "",
+			err:  true,
+		},
+		{
+			name: "only a sign",
+			in:   "-",
+			err:  true,
+		},
+		{
+			name: "double sign",
+			in:   "--1",
+			err:  true,
+		},
+		{
+			name: "sign in the fraction",
+			in:   "1.-5",
+			err:  true,
+		},
+		{
+			name: "letters",
+			in:   "abc",
+			err:  true,
+		},
+		{
+			name: "multiple decimal points",
+			in:   "1.2.3",
+			err:  true,
+		},
+		{
+			name: "missing exponent",
+			in:   "1e",
+			err:  true,
+		},
+		{
+			name: "invalid exponent",
+			in:   "1e1.5",
+			err:  true,
+		},
+		{
+			name:     "max exponent",
+			in:       "1e78",
+			value:    big.NewInt(1),
+			exponent: 78,
+		},
+		{
+			name:     "min exponent",
+			in:       "1e-78",
+			value:    big.NewInt(1),
+			exponent: -78,
+		},
+		{
+			name: "exponent beyond the max exponent",
+			in:   "1e79",
+			err:  true,
+		},
+		{
+			name: "exponent beyond the min exponent",
+			in:   "1e-79",
+			err:  true,
+		},
+		{
+			name: "huge exponent",
+			in:   "1e9223372036854775807",
+			err:  true,
+		},
+		{
+			name: "fraction beyond the min exponent",
+			in:   "0." + strings.Repeat("0", 78) + "1",
+			err:  true,
+		},
+		{
+			name:     "fraction shifted into range by the exponent",
+			in:       "0." + strings.Repeat("0", 78) + "1e10",
+			value:    big.NewInt(1),
+			exponent: -69,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, err := math.ParsePrice(tc.in)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.value, price.Value)
+			require.Equal(t, tc.exponent, price.Exponent)
+		})
+	}
+}
+
+func TestPriceRescale(t *testing.T) {
+	testCases := []struct {
+		name     string
+		price    math.Price
+		exponent int64
+		out      math.Price
+	}{
+		{
+			name:     "same exponent",
+			price:    math.Price{Value: big.NewInt(12345), Exponent: -2},
+			exponent: -2,
+			out:      math.Price{Value: big.NewInt(12345), Exponent: -2},
+		},
+		{
+			name:     "more decimals",
+			price:    math.Price{Value: big.NewInt(12345), Exponent: -2},
+			exponent: -5,
+			out:      math.Price{Value: big.NewInt(12345000), Exponent: -5},
+		},
+		{
+			name:     "fewer decimals truncates",
+			price:    math.Price{Value: big.NewInt(12345), Exponent: -2},
+			exponent: 0,
+			out:      math.Price{Value: big.NewInt(123), Exponent: 0},
+		},
+		{
+			name:     "fewer decimals truncates negative values towards zero",
+			price:    math.Price{Value: big.NewInt(-12399), Exponent: -2},
+			exponent: 0,
+			out:      math.Price{Value: big.NewInt(-123), Exponent: 0},
+		},
+		{
+			name:     "positive exponent",
+			price:    math.Price{Value: big.NewInt(15), Exponent: 2},
+			exponent: -1,
+			out:      math.Price{Value: big.NewInt(15000), Exponent: -1},
+		},
+		{
+			name:     "nil value",
+			price:    math.Price{Exponent: -2},
+			exponent: 1,
+			out:      math.Price{Value: big.NewInt(0), Exponent: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := tc.price.Rescale(tc.exponent)
+			require.NoError(t, err)
+			require.Equal(t, tc.out, out)
+		})
+	}
+
+	t.Run("does not modify the price", func(t *testing.T) {
+		price := math.Price{Value: big.NewInt(12345), Exponent: -2}
+		out, err := price.Rescale(-4)
+		require.NoError(t, err)
+
+		out.Value.SetInt64(0)
+		require.Equal(t, math.Price{Value: big.NewInt(12345), Exponent: -2}, price)
+	})
+
+	t.Run("exponent beyond the max exponent", func(t *testing.T) {
+		price := math.Price{Value: big.NewInt(12345), Exponent: -2}
+
+		_, err := price.Rescale(-math.MaxExponent - 1)
+		require.Error(t, err)
+
+		_, err = price.Rescale(gomath.MaxInt64)
+		require.Error(t, err)
+	})
+}
+
+func TestPriceInt(t *testing.T) {
+	price := math.Price{Value: big.NewInt(6103366879713), Exponent: -8}
+
+	for decimals, expected := range map[uint64]*big.Int{
+		8: big.NewInt(6103366879713),
+		9: big.NewInt(61033668797130),
+		2: big.NewInt(6103366),
+		0: big.NewInt(61033),
+	} {
+		out, err := price.Int(decimals)
+		require.NoError(t, err)
+		require.Equal(t, expected, out)
+	}
+
+	_, err := price.Int(math.MaxExponent + 1)
+	require.Error(t, err)
+}
+
+func TestPriceCmp(t *testing.T) {
+	testCases := []struct {
+		name  string
+		a, b  math.Price
+		order int
+	}{
+		{
+			name:  "equal prices with different scales",
+			a:     math.Price{Value: big.NewInt(15), Exponent: -1},
+			b:     math.Price{Value: big.NewInt(1500), Exponent: -3},
+			order: 0,
+		},
+		{
+			name:  "less than",
+			a:     math.Price{Value: big.NewInt(1499), Exponent: -3},
+			b:     math.Price{Value: big.NewInt(15), Exponent: -1},
+			order: -1,
+		},
+		{
+			name:  "greater than",
+			a:     math.Price{Value: big.NewInt(2), Exponent: 1},
+			b:     math.Price{Value: big.NewInt(1999), Exponent: -2},
+			order: 1,
+		},
+		{
+			name:  "negative",
+			a:     math.Price{Value: big.NewInt(-1), Exponent: 0},
+			b:     math.Price{Value: big.NewInt(0), Exponent: -8},
+			order: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.order, tc.a.Cmp(tc.b))
+			require.Equal(t, -tc.order, tc.b.Cmp(tc.a))
+		})
+	}
+}
+
+func TestPriceBigFloat(t *testing.T) {
+	testCases := []struct {
+		name  string
+		price math.Price
+		out   *big.Float
+	}{
+		{
+			name:  "zero",
+			price: math.Price{Value: big.NewInt(0), Exponent: -8},
+			out:   big.NewFloat(0),
+		},
+		{
+			name:  "decimals",
+			price: math.Price{Value: big.NewInt(6103366879713), Exponent: -8},
+			out:   big.NewFloat(61033.66879713),
+		},
+		{
+			name:  "positive exponent",
+			price: math.Price{Value: big.NewInt(15), Exponent: 3},
+			out:   big.NewFloat(15000),
+		},
+		{
+			name:  "negative value",
+			price: math.Price{Value: big.NewInt(-125), Exponent: -2},
+			out:   big.NewFloat(-1.25),
+		},
+		{
+			name:  "18 decimals",
+			price: math.Price{Value: big.NewInt(1500000000000000000), Exponent: -18},
+			out:   big.NewFloat(1.5),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := tc.price.BigFloat()
+			require.GreaterOrEqual(t, out.Prec(), uint(math.PricePrecision))
+			require.Equal(t, tc.out.SetPrec(40), out.SetPrec(40))
+		})
+	}
+
+	t.Run("keeps precision beyond float64", func(t *testing.T) {
+		price, err := math.ParsePrice("420420420420420.420420420")
+		require.NoError(t, err)
+
+		// Converting back to the same number of decimals recovers the exact value.
+		out, err := math.NewPriceFromBigFloat(price.BigFloat(), 9)
+		require.NoError(t, err)
+		require.Equal(t, price.Value, out.Value)
+	})
+
+	t.Run("large values are exact", func(t *testing.T) {
+		value, ok := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+		require.True(t, ok)
+
+		out, _ := math.Price{Value: value, Exponent: 2}.BigFloat().Int(nil)
+		require.Equal(t, new(big.Int).Mul(value, big.NewInt(100)), out)
+	})
+}
+
+func TestPriceString(t *testing.T) {
+	testCases := []struct {
+		name  string
+		price math.Price
+		out   string
+	}{
+		{
+			name:  "integer",
+			price: math.Price{Value: big.NewInt(42), Exponent: 0},
+			out:   "42",
+		},
+		{
+			name:  "positive exponent",
+			price: math.Price{Value: big.NewInt(42), Exponent: 3},
+			out:   "42000",
+		},
+		{
+			name:  "decimals",
+			price: math.Price{Value: big.NewInt(12345), Exponent: -2},
+			out:   "123.45",
+		},
+		{
+			name:  "value smaller than one",
+			price: math.Price{Value: big.NewInt(5), Exponent: -3},
+			out:   "0.005",
+		},
+		{
+			name:  "negative value smaller than one",
+			price: math.Price{Value: big.NewInt(-5), Exponent: -3},
+			out:   "-0.005",
+		},
+		{
+			name:  "trailing zeros are kept",
+			price: math.Price{Value: big.NewInt(100), Exponent: -2},
+			out:   "1.00",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.out, tc.price.String())
+		})
+	}
+
+	t.Run("round trips through parsing", func(t *testing.T) {
+		for _, tc := range testCases {
+			price, err := math.ParsePrice(tc.price.String())
+			require.NoError(t, err)
+			require.Equal(t, 0, price.Cmp(tc.price), tc.name)
+		}
+	})
+}
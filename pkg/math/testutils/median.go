@@ -39,6 +39,12 @@ func (m *MedianAggregator) SetProviderPrices(provider string, data types.Prices)
 	m.providerPrices[provider] = data
 }
 
+// SetProviderWeights is a no-op, as the median does not weight prices.
+func (m *MedianAggregator) SetProviderWeights(_ string, _ types.Weights) {}
+
+// SetProviderVolumes is a no-op, as the median does not weight prices.
+func (m *MedianAggregator) SetProviderVolumes(_ string, _ types.Volumes) {}
+
 func (m *MedianAggregator) UpdateMarketMap(_ mmtypes.MarketMap) {}
 
 // AggregatePrices inputs the aggregated prices from all providers and computes
@@ -85,6 +91,12 @@ func (m *MedianAggregator) GetPrices() types.Prices {
 	return m.finalPrices
 }
 
+// GetUnconfirmedPrices returns no tickers, as the median aggregator does not require price moves
+// to be confirmed.
+func (m *MedianAggregator) GetUnconfirmedPrices() []string {
+	return nil
+}
+
 // Reset resets the data aggregator for all providers.
 func (m *MedianAggregator) Reset() {
 	m.mtx.Lock()
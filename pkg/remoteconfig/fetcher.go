@@ -0,0 +1,270 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a single fetch of a remote config file.
+	DefaultTimeout = 30 * time.Second
+
+	// MaxFileSize is the maximum size of a remote config file or signature.
+	MaxFileSize = 16 << 20
+
+	// gitSchemePrefix is the prefix of the schemes of git URLs, e.g. git+https.
+	gitSchemePrefix = "git+"
+	// gitPathSeparator separates the repository from the path of the file within it in git URLs.
+	gitPathSeparator = "//"
+)
+
+// Fetcher fetches a remote config file and its detached signature.
+type Fetcher interface {
+	Fetch(ctx context.Context) (file []byte, signature []byte, err error)
+}
+
+// FetcherOption configures the fetchers returned by NewFetcher.
+type FetcherOption func(*fetcherOptions)
+
+type fetcherOptions struct {
+	client  *http.Client
+	timeout time.Duration
+}
+
+// WithHTTPClient sets the client used to fetch HTTP and S3 URLs.
+func WithHTTPClient(client *http.Client) FetcherOption {
+	return func(o *fetcherOptions) {
+		o.client = client
+	}
+}
+
+// WithTimeout sets the timeout of a single fetch. DefaultTimeout is used if unset.
+func WithTimeout(timeout time.Duration) FetcherOption {
+	return func(o *fetcherOptions) {
+		o.timeout = timeout
+	}
+}
+
+// NewFetcher returns a Fetcher of the config file at the given URL. The signature of the file is
+// fetched from the same location, with SignatureExtension appended to the file's path. The
+// supported URLs are:
+//
+//   - http(s)://host/path/oracle.json
+//   - s3://bucket/path/oracle.json, fetched anonymously from the bucket's virtual-hosted endpoint.
+//     The region query parameter selects a regional endpoint, and the endpoint query parameter
+//     fetches from https://endpoint/bucket/path instead, e.g. for S3 compatible stores.
+//   - git+https://host/org/repo.git//path/oracle.json, git+ssh://... and git+file://..., fetched
+//     with a shallow clone of the repository using the git binary. The ref query parameter selects
+//     the branch or tag, which defaults to the repository's default branch.
+func NewFetcher(rawURL string, opts ...FetcherOption) (Fetcher, error) {
+	o := fetcherOptions{timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.client == nil {
+		o.client = &http.Client{
+			Transport: connecthttp.NewRoundTripperWithHeaders(
+				http.DefaultTransport,
+				connecthttp.WithConnectVersionUserAgent(),
+			),
+		}
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote config url %s: %w", rawURL, err)
+	}
+
+	switch {
+	case u.Scheme == "http" || u.Scheme == "https":
+		return newHTTPFetcher(o, u), nil
+	case u.Scheme == "s3":
+		return newS3Fetcher(o, u)
+	case strings.HasPrefix(u.Scheme, gitSchemePrefix):
+		return newGitFetcher(o, u)
+	default:
+		return nil, fmt.Errorf("unsupported remote config url %s: expected an http, https, s3 or git+<transport> url", rawURL)
+	}
+}
+
+// httpFetcher fetches a config file and its signature with GET requests.
+type httpFetcher struct {
+	opts         fetcherOptions
+	url          string
+	signatureURL string
+}
+
+func newHTTPFetcher(opts fetcherOptions, u *url.URL) *httpFetcher {
+	signatureURL := *u
+	signatureURL.Path += SignatureExtension
+	signatureURL.RawPath = ""
+
+	return &httpFetcher{
+		opts:         opts,
+		url:          u.String(),
+		signatureURL: signatureURL.String(),
+	}
+}
+
+// Fetch fetches the config file and its signature.
+func (f *httpFetcher) Fetch(ctx context.Context) ([]byte, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.opts.timeout)
+	defer cancel()
+
+	file, err := f.get(ctx, f.url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := f.get(ctx, f.signatureURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch signature: %w", err)
+	}
+
+	return file, signature, nil
+}
+
+func (f *httpFetcher) get(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.opts.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, rawURL)
+	}
+
+	return readLimited(resp.Body)
+}
+
+// newS3Fetcher returns a fetcher of an object of a bucket that can be read anonymously.
+func newS3Fetcher(opts fetcherOptions, u *url.URL) (*httpFetcher, error) {
+	bucket, key := u.Host, strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("invalid s3 url %s: expected s3://<bucket>/<key>", u)
+	}
+
+	query := u.Query()
+	var objectURL *url.URL
+	switch endpoint, region := query.Get("endpoint"), query.Get("region"); {
+	case endpoint != "":
+		base, err := url.Parse(endpoint)
+		if err != nil || base.Scheme == "" || base.Host == "" {
+			return nil, fmt.Errorf("invalid s3 endpoint %q: expected a url such as https://storage.example.com", endpoint)
+		}
+		objectURL = base.JoinPath(bucket, key)
+	case region != "":
+		objectURL = &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", bucket, region), Path: "/" + key}
+	default:
+		objectURL = &url.URL{Scheme: "https", Host: bucket + ".s3.amazonaws.com", Path: "/" + key}
+	}
+
+	return newHTTPFetcher(opts, objectURL), nil
+}
+
+// gitFetcher fetches a config file and its signature from a shallow clone of a git repository.
+type gitFetcher struct {
+	opts fetcherOptions
+	repo string
+	ref  string
+	path string
+}
+
+func newGitFetcher(opts fetcherOptions, u *url.URL) (*gitFetcher, error) {
+	repoPath, filePath, ok := strings.Cut(u.Path, gitPathSeparator)
+	if !ok || filePath == "" {
+		return nil, fmt.Errorf("invalid git url %s: expected git+<transport>://<repository>//<path>", u)
+	}
+
+	repo := *u
+	repo.Scheme = strings.TrimPrefix(u.Scheme, gitSchemePrefix)
+	repo.Path = repoPath
+	repo.RawPath = ""
+	repo.RawQuery = ""
+
+	return &gitFetcher{
+		opts: opts,
+		repo: repo.String(),
+		ref:  u.Query().Get("ref"),
+		path: filepath.FromSlash(filePath),
+	}, nil
+}
+
+// Fetch clones the repository into a temporary directory and reads the config file and its
+// signature from it.
+func (f *gitFetcher) Fetch(ctx context.Context) ([]byte, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.opts.timeout)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "connect-remote-config-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if f.ref != "" {
+		args = append(args, "--branch", f.ref)
+	}
+	args = append(args, "--", f.repo, dir)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, nil, fmt.Errorf("failed to clone %s: %w: %s", f.repo, err, strings.TrimSpace(stderr.String()))
+	}
+
+	file, err := readFile(filepath.Join(dir, f.path))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := readFile(filepath.Join(dir, f.path+SignatureExtension))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	return file, signature, nil
+}
+
+func readFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readLimited(f)
+}
+
+// readLimited reads r, failing if it is larger than MaxFileSize.
+func readLimited(r io.Reader) ([]byte, error) {
+	bz, err := io.ReadAll(io.LimitReader(r, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) > MaxFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", MaxFileSize)
+	}
+
+	return bz, nil
+}
@@ -0,0 +1,298 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/pkg/signing"
+)
+
+// SignatureExtension is appended to the path of a remote config file to get the path of its
+// detached signature, e.g. https://example.com/oracle.json is signed by
+// https://example.com/oracle.json.sig.
+const SignatureExtension = ".sig"
+
+// Validator validates the contents of a config file before it is applied. The name is the base
+// name of the remote file, which determines its format.
+type Validator func(name string, bz []byte) error
+
+// Verifier verifies the detached signatures of remote config files.
+type Verifier struct {
+	algorithm string
+	publicKey []byte
+}
+
+// NewVerifier returns a new Verifier of signatures made with the given algorithm (ed25519 or
+// secp256k1) by the holder of the private key of the given hex-encoded public key.
+func NewVerifier(algorithm, publicKey string) (*Verifier, error) {
+	key, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("public key is not hex-encoded: %w", err)
+	}
+
+	if err := signing.ValidatePublicKey(algorithm, key); err != nil {
+		return nil, err
+	}
+
+	return &Verifier{
+		algorithm: algorithm,
+		publicKey: key,
+	}, nil
+}
+
+// Verify verifies that the detached signature was made over the contents of a config file and its
+// version, and returns the version. The detached signature is the version, a positive integer,
+// followed by the hex or base64 encoded signature, separated by whitespace.
+func (v *Verifier) Verify(bz, detached []byte) (uint64, error) {
+	fields := strings.Fields(string(detached))
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("signature must consist of a version and a signature")
+	}
+
+	version, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil || version == 0 {
+		return 0, fmt.Errorf("signature version %s is not a positive integer", fields[0])
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(fields[1], "0x"))
+	if err != nil {
+		signature, err = base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return 0, fmt.Errorf("signature is neither hex nor base64 encoded")
+		}
+	}
+
+	if err := signing.VerifyMessage(v.algorithm, v.publicKey, signature, SignedMessage(version, bz)); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// SignedMessage returns the message that the detached signature of a config file is made over,
+// which binds the contents of the file to its version.
+func SignedMessage(version uint64, bz []byte) []byte {
+	return append([]byte(strconv.FormatUint(version, 10)+"\n"), bz...)
+}
+
+// EncodeSignature returns the detached signature of a config file, made over the signed message of
+// the given version, in the format expected by Verify.
+func EncodeSignature(version uint64, signature []byte) []byte {
+	return []byte(fmt.Sprintf("%d %s\n", version, hex.EncodeToString(signature)))
+}
+
+// Source is a config file that is fetched from a remote source, and applied only if its detached
+// signature is valid and its version is newer than the version of the config file applied before,
+// such that an older signed config file cannot be replayed. The last verified copy is cached on
+// disk, along with its signature, so that the config can be read from a local path, and so that the
+// oracle can still start if the remote source is unavailable.
+type Source struct {
+	logger    *zap.Logger
+	rawURL    string
+	name      string
+	fetcher   Fetcher
+	verifier  *Verifier
+	validate  Validator
+	cachePath string
+
+	// version is the version of the config file that was last applied, or 0 if none was applied.
+	version uint64
+}
+
+// NewSource returns a new Source of the config file at the given URL, caching its verified copy in
+// the given directory. See NewFetcher for the supported URLs. The validator, if non-nil, is run on
+// each verified file before it is applied.
+func NewSource(logger *zap.Logger, rawURL, cacheDir string, verifier *Verifier, validate Validator, opts ...FetcherOption) (*Source, error) {
+	if verifier == nil {
+		return nil, fmt.Errorf("remote config %s must be verified", rawURL)
+	}
+
+	fetcher, err := NewFetcher(rawURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := fileName(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cached copy is named after the remote file so that its format is preserved, and
+	// prefixed with a digest of the url so that files with the same name do not collide.
+	digest := sha256.Sum256([]byte(rawURL))
+
+	return &Source{
+		logger:    logger.With(zap.String("remote_config", name)),
+		rawURL:    rawURL,
+		name:      name,
+		fetcher:   fetcher,
+		verifier:  verifier,
+		validate:  validate,
+		cachePath: filepath.Join(cacheDir, hex.EncodeToString(digest[:4])+"-"+name),
+	}, nil
+}
+
+// URL returns the URL of the remote config file.
+func (s *Source) URL() string {
+	return s.rawURL
+}
+
+// Path returns the path of the cached copy of the last verified config file.
+func (s *Source) Path() string {
+	return s.cachePath
+}
+
+// Sync fetches the config file and its signature, and writes the file to Path if the signature is
+// valid, the file passes validation and it differs from the cached copy. A file that differs from
+// the cached copy must have a newer version than both the cached copy and the file applied before.
+// It returns true if the cached copy changed. Nothing is written if the file is not verified.
+func (s *Source) Sync(ctx context.Context) (bool, error) {
+	bz, signature, err := s.fetcher.Fetch(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch remote config %s: %w", s.rawURL, err)
+	}
+
+	version, err := s.verifier.Verify(bz, signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify remote config %s: %w", s.rawURL, err)
+	}
+
+	if version < s.version {
+		return false, fmt.Errorf("remote config %s has version %d, older than the applied version %d", s.rawURL, version, s.version)
+	}
+
+	if s.validate != nil {
+		if err := s.validate(s.name, bz); err != nil {
+			return false, fmt.Errorf("remote config %s is invalid: %w", s.rawURL, err)
+		}
+	}
+
+	if cached, cachedVersion, err := s.readCache(); err == nil {
+		if cachedVersion == version && bytes.Equal(cached, bz) {
+			s.version = version
+			return false, nil
+		}
+
+		if version <= cachedVersion {
+			return false, fmt.Errorf("remote config %s has version %d, not newer than the cached version %d", s.rawURL, version, cachedVersion)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.cachePath), 0o700); err != nil {
+		return false, err
+	}
+
+	// the signature is written first so that an interrupted write leaves a cached copy that fails
+	// verification, rather than an unverified one.
+	if err := writeFile(s.cachePath+SignatureExtension, signature); err != nil {
+		return false, err
+	}
+	if err := writeFile(s.cachePath, bz); err != nil {
+		return false, err
+	}
+
+	s.version = version
+	return true, nil
+}
+
+// Load syncs the config file, falling back to the cached copy if the remote source cannot be
+// synced, e.g. on startup while the remote source is down. The cached copy is verified again before
+// it is used, and is not used if it is older than the file applied before.
+func (s *Source) Load(ctx context.Context) error {
+	_, syncErr := s.Sync(ctx)
+	if syncErr == nil {
+		return nil
+	}
+
+	_, version, err := s.readCache()
+	if err != nil {
+		return fmt.Errorf("%w; %w", syncErr, err)
+	}
+
+	if version < s.version {
+		return fmt.Errorf("%w; cached copy has version %d, older than the applied version %d", syncErr, version, s.version)
+	}
+	s.version = version
+
+	s.logger.Warn(
+		"failed to sync remote config; using the cached copy",
+		zap.String("url", s.rawURL),
+		zap.String("path", s.cachePath),
+		zap.Uint64("version", version),
+		zap.Error(syncErr),
+	)
+
+	return nil
+}
+
+// readCache reads the cached copy of the config file and its signature, and returns the cached copy
+// along with its version if the signature is valid.
+func (s *Source) readCache() ([]byte, uint64, error) {
+	bz, err := os.ReadFile(s.cachePath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("no cached copy is available: %w", err)
+	}
+
+	signature, err := os.ReadFile(s.cachePath + SignatureExtension)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cached copy has no signature: %w", err)
+	}
+
+	version, err := s.verifier.Verify(bz, signature)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to verify cached copy: %w", err)
+	}
+
+	return bz, version, nil
+}
+
+// fileName returns the base name of the file referenced by a remote config URL.
+func fileName(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	p := u.Path
+	if u.Opaque != "" {
+		p = u.Opaque
+	}
+
+	name := path.Base(p)
+	if name == "." || name == "/" {
+		return "", fmt.Errorf("remote config url %s does not reference a file", rawURL)
+	}
+
+	return name, nil
+}
+
+// writeFile atomically replaces the file at the given path.
+func writeFile(path string, bz []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
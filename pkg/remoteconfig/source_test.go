@@ -0,0 +1,333 @@
+package remoteconfig_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/remoteconfig"
+	"github.com/skip-mev/connect/v2/pkg/signing"
+)
+
+var testKey = []byte(strings.Repeat("\x01", 32))
+
+// fileServer serves files from memory, so that tests can change them between syncs.
+type fileServer struct {
+	mtx   sync.Mutex
+	files map[string][]byte
+}
+
+func (s *fileServer) set(path string, bz []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.files[path] = bz
+}
+
+func (s *fileServer) remove(path string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.files, path)
+}
+
+func (s *fileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	bz, ok := s.files[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Write(bz)
+}
+
+func newFileServer(t *testing.T) (*fileServer, *httptest.Server) {
+	t.Helper()
+
+	fs := &fileServer{files: make(map[string][]byte)}
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+
+	return fs, srv
+}
+
+func newSigner(t *testing.T) (*signing.Signer, *remoteconfig.Verifier) {
+	t.Helper()
+
+	signer, err := signing.NewSigner(config.SigningAlgorithmEd25519, testKey)
+	require.NoError(t, err)
+
+	verifier, err := remoteconfig.NewVerifier(config.SigningAlgorithmEd25519, hex.EncodeToString(signer.PublicKey()))
+	require.NoError(t, err)
+
+	return signer, verifier
+}
+
+func sign(t *testing.T, signer *signing.Signer, version uint64, bz []byte) []byte {
+	t.Helper()
+
+	signature, err := signer.SignMessage(remoteconfig.SignedMessage(version, bz))
+	require.NoError(t, err)
+
+	return remoteconfig.EncodeSignature(version, signature)
+}
+
+func TestNewVerifier(t *testing.T) {
+	signer, verifier := newSigner(t)
+
+	bz := []byte(`{"providers": {}}`)
+	signature, err := signer.SignMessage(remoteconfig.SignedMessage(3, bz))
+	require.NoError(t, err)
+
+	for _, detached := range []string{
+		"3 " + hex.EncodeToString(signature),
+		"3\t0x" + hex.EncodeToString(signature) + "\n",
+		"3 " + base64.StdEncoding.EncodeToString(signature),
+	} {
+		version, err := verifier.Verify(bz, []byte(detached))
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), version)
+	}
+
+	// the signature binds the contents to the version.
+	_, err = verifier.Verify([]byte(`{"providers": []}`), []byte("3 "+hex.EncodeToString(signature)))
+	require.Error(t, err)
+	_, err = verifier.Verify(bz, []byte("4 "+hex.EncodeToString(signature)))
+	require.Error(t, err)
+
+	_, err = verifier.Verify(bz, []byte(hex.EncodeToString(signature)))
+	require.ErrorContains(t, err, "version and a signature")
+	_, err = verifier.Verify(bz, []byte("0 "+hex.EncodeToString(signature)))
+	require.ErrorContains(t, err, "not a positive integer")
+	_, err = verifier.Verify(bz, []byte("3 not-a-signature!"))
+	require.ErrorContains(t, err, "neither hex nor base64")
+
+	_, err = remoteconfig.NewVerifier(config.SigningAlgorithmEd25519, "not hex")
+	require.Error(t, err)
+	_, err = remoteconfig.NewVerifier(config.SigningAlgorithmSecp256k1, hex.EncodeToString(signer.PublicKey()))
+	require.Error(t, err)
+}
+
+func TestSourceSync(t *testing.T) {
+	signer, verifier := newSigner(t)
+	fs, srv := newFileServer(t)
+
+	v1 := []byte(`{"updateInterval": "250ms"}`)
+	fs.set("/configs/oracle.json", v1)
+	fs.set("/configs/oracle.json.sig", sign(t, signer, 1, v1))
+
+	var validated []string
+	validate := func(name string, bz []byte) error {
+		validated = append(validated, name)
+		if strings.Contains(string(bz), "invalid") {
+			return errors.New("invalid config")
+		}
+		return nil
+	}
+
+	source, err := remoteconfig.NewSource(zap.NewNop(), srv.URL+"/configs/oracle.json", t.TempDir(), verifier, validate)
+	require.NoError(t, err)
+
+	// the first sync writes the file.
+	changed, err := source.Sync(context.Background())
+	require.NoError(t, err)
+	require.True(t, changed)
+	requireFile(t, source.Path(), v1)
+	require.Equal(t, []string{"oracle.json"}, validated)
+
+	// syncing an unchanged file is a no-op.
+	changed, err = source.Sync(context.Background())
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// a file that is not signed by the key is not applied.
+	v2 := []byte(`{"updateInterval": "500ms"}`)
+	other, err := signing.NewSigner(config.SigningAlgorithmEd25519, []byte(strings.Repeat("\x02", 32)))
+	require.NoError(t, err)
+	fs.set("/configs/oracle.json", v2)
+	fs.set("/configs/oracle.json.sig", sign(t, other, 2, v2))
+
+	_, err = source.Sync(context.Background())
+	require.ErrorContains(t, err, "failed to verify")
+	requireFile(t, source.Path(), v1)
+
+	// a signed file that fails validation is not applied.
+	invalid := []byte(`{"updateInterval": "invalid"}`)
+	fs.set("/configs/oracle.json", invalid)
+	fs.set("/configs/oracle.json.sig", sign(t, signer, 2, invalid))
+
+	_, err = source.Sync(context.Background())
+	require.ErrorContains(t, err, "invalid config")
+	requireFile(t, source.Path(), v1)
+
+	// a missing signature is an error.
+	fs.set("/configs/oracle.json", v2)
+	fs.remove("/configs/oracle.json.sig")
+
+	_, err = source.Sync(context.Background())
+	require.ErrorContains(t, err, "failed to fetch signature")
+	requireFile(t, source.Path(), v1)
+
+	// a signed change that is not newer than the cached copy is not applied.
+	fs.set("/configs/oracle.json.sig", sign(t, signer, 1, v2))
+
+	_, err = source.Sync(context.Background())
+	require.ErrorContains(t, err, "not newer than the cached version 1")
+	requireFile(t, source.Path(), v1)
+
+	// a signed change with a newer version is applied.
+	fs.set("/configs/oracle.json.sig", sign(t, signer, 2, v2))
+
+	changed, err = source.Sync(context.Background())
+	require.NoError(t, err)
+	require.True(t, changed)
+	requireFile(t, source.Path(), v2)
+
+	// an older signed file cannot be replayed.
+	fs.set("/configs/oracle.json", v1)
+	fs.set("/configs/oracle.json.sig", sign(t, signer, 1, v1))
+
+	_, err = source.Sync(context.Background())
+	require.ErrorContains(t, err, "older than the applied version 2")
+	requireFile(t, source.Path(), v2)
+}
+
+func TestSourceLoad(t *testing.T) {
+	signer, verifier := newSigner(t)
+	fs, srv := newFileServer(t)
+	cacheDir := t.TempDir()
+
+	bz := []byte(`{"updateInterval": "250ms"}`)
+	fs.set("/oracle.json", bz)
+	fs.set("/oracle.json.sig", sign(t, signer, 2, bz))
+
+	source, err := remoteconfig.NewSource(zap.NewNop(), srv.URL+"/oracle.json", cacheDir, verifier, nil)
+	require.NoError(t, err)
+	require.NoError(t, source.Load(context.Background()))
+	requireFile(t, source.Path(), bz)
+
+	// the cached copy is used while the remote source is unavailable.
+	srv.Close()
+	require.NoError(t, source.Load(context.Background()))
+	requireFile(t, source.Path(), bz)
+
+	// the cached copy is not used if it is older than the file applied before.
+	old := []byte(`{"updateInterval": "1s"}`)
+	require.NoError(t, os.WriteFile(source.Path(), old, 0o600))
+	require.NoError(t, os.WriteFile(source.Path()+remoteconfig.SignatureExtension, sign(t, signer, 1, old), 0o600))
+	require.ErrorContains(t, source.Load(context.Background()), "older than the applied version 2")
+
+	// the cached copy is not used if it was tampered with.
+	require.NoError(t, os.WriteFile(source.Path(), []byte(`{"updateInterval": "1ms"}`), 0o600))
+	require.ErrorContains(t, source.Load(context.Background()), "failed to verify cached copy")
+
+	// there is nothing to fall back to without a cached copy.
+	source, err = remoteconfig.NewSource(zap.NewNop(), srv.URL+"/oracle.json", t.TempDir(), verifier, nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, source.Load(context.Background()), "no cached copy is available")
+}
+
+func TestNewSource(t *testing.T) {
+	_, verifier := newSigner(t)
+	cacheDir := t.TempDir()
+
+	a, err := remoteconfig.NewSource(zap.NewNop(), "https://a.example.com/oracle.yaml", cacheDir, verifier, nil)
+	require.NoError(t, err)
+	b, err := remoteconfig.NewSource(zap.NewNop(), "https://b.example.com/oracle.yaml", cacheDir, verifier, nil)
+	require.NoError(t, err)
+
+	// the cached copies keep the name of the remote file, and do not collide.
+	require.True(t, strings.HasSuffix(a.Path(), "-oracle.yaml"))
+	require.NotEqual(t, a.Path(), b.Path())
+
+	_, err = remoteconfig.NewSource(zap.NewNop(), "https://example.com/oracle.json", cacheDir, nil, nil)
+	require.Error(t, err)
+	_, err = remoteconfig.NewSource(zap.NewNop(), "https://example.com/", cacheDir, verifier, nil)
+	require.Error(t, err)
+	_, err = remoteconfig.NewSource(zap.NewNop(), "ftp://example.com/oracle.json", cacheDir, verifier, nil)
+	require.ErrorContains(t, err, "unsupported remote config url")
+}
+
+func TestS3Fetcher(t *testing.T) {
+	fs, srv := newFileServer(t)
+	fs.set("/bucket/configs/oracle.json", []byte("file"))
+	fs.set("/bucket/configs/oracle.json.sig", []byte("signature"))
+
+	fetcher, err := remoteconfig.NewFetcher("s3://bucket/configs/oracle.json?endpoint=" + srv.URL)
+	require.NoError(t, err)
+
+	file, signature, err := fetcher.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "file", string(file))
+	require.Equal(t, "signature", string(signature))
+
+	_, err = remoteconfig.NewFetcher("s3://bucket")
+	require.Error(t, err)
+	_, err = remoteconfig.NewFetcher("s3://bucket/oracle.json?endpoint=localhost")
+	require.Error(t, err)
+}
+
+func TestGitFetcher(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	git("init", "--quiet", "--initial-branch", "main")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "configs"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "configs", "oracle.json"), []byte("file"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "configs", "oracle.json.sig"), []byte("signature"), 0o600))
+	git("add", ".")
+	git("commit", "--quiet", "-m", "add config")
+
+	fetcher, err := remoteconfig.NewFetcher(fmt.Sprintf("git+file://%s//configs/oracle.json?ref=main", filepath.ToSlash(repo)))
+	require.NoError(t, err)
+
+	file, signature, err := fetcher.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "file", string(file))
+	require.Equal(t, "signature", string(signature))
+
+	fetcher, err = remoteconfig.NewFetcher(fmt.Sprintf("git+file://%s//configs/oracle.json?ref=unknown", filepath.ToSlash(repo)))
+	require.NoError(t, err)
+	_, _, err = fetcher.Fetch(context.Background())
+	require.ErrorContains(t, err, "failed to clone")
+
+	_, err = remoteconfig.NewFetcher("git+https://github.com/org/repo.git")
+	require.Error(t, err)
+}
+
+func requireFile(t *testing.T, path string, expected []byte) {
+	t.Helper()
+
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(bz))
+}
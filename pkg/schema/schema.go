@@ -0,0 +1,508 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// Root is the JSON path of the root of a document.
+	Root = "$"
+
+	// TagName is the struct tag holding the comma separated rules of a field, e.g.
+	// `schema:"address"`. Rules of slice and map fields apply to each of their elements.
+	TagName = "schema"
+
+	// MaxDecimals is the largest number of decimals accepted by the decimals rule. 10^77 is the
+	// largest power of ten that fits in a uint256.
+	MaxDecimals = 77
+
+	// maxSuggestionDistance is the largest edit distance between an unknown field and a known
+	// field for the known field to be suggested.
+	maxSuggestionDistance = 2
+)
+
+var (
+	durationType   = reflect.TypeOf(time.Duration(0))
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	unmarshalType  = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+	identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// rules are the rules that can be set on a field with the schema tag. Each rule returns a
+// description of the violation, or an empty string if the value is valid. Rules are only checked
+// on values of the expected type, and are not checked on empty strings, since whether a field is
+// required is left to the ValidateBasic of the config.
+var rules = map[string]func(value any) string{
+	"url":      checkURL,
+	"address":  checkAddress,
+	"decimals": checkDecimals,
+}
+
+// Violation is a value of a document that does not conform to its schema.
+type Violation struct {
+	// Path is the JSON path of the value, e.g. $.providers.okx_ws.api.timeout.
+	Path string `json:"path"`
+	// Message describes the violation and, where possible, how to fix it.
+	Message string `json:"message"`
+}
+
+// String returns the path and message of the violation.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// Violations are all of the violations of a document, ordered by path within each object.
+// Violations implement error so that they can be returned as one.
+type Violations []Violation
+
+// Error returns every violation on its own line.
+func (vs Violations) Error() string {
+	lines := make([]string, 0, len(vs)+1)
+	lines = append(lines, fmt.Sprintf("found %d schema violation(s):", len(vs)))
+	for _, v := range vs {
+		lines = append(lines, "  "+v.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Err returns the violations as an error, or nil if there are none.
+func (vs Violations) Err() error {
+	if len(vs) == 0 {
+		return nil
+	}
+	return vs
+}
+
+// Validate checks that the JSON document bz strictly conforms to the type of target, which is
+// typically a pointer to a zero value of a config. Unlike json.Unmarshal, which fails on the first
+// error and ignores unknown fields, Validate returns every violation of the document:
+//
+//   - fields that do not correspond to a field of the type (matched case-insensitively, as viper
+//     does), with a suggestion if a field of the type has a similar name.
+//   - values of the wrong JSON type, integers out of the range of their field, and durations that
+//     are neither a Go duration string nor an integer number of nanoseconds.
+//   - values that violate the rules set by the schema tags of their fields.
+//
+// Fields that are absent are not violations, since config files are allowed to only set a subset of
+// the config.
+func Validate(bz []byte, target any) Violations {
+	return ValidateAt(Root, bz, target)
+}
+
+// ValidateAt validates a JSON document that is embedded in another document at the given path,
+// e.g. a JSON string field, so that the paths of the violations are relative to the outer
+// document.
+func ValidateAt(path string, bz []byte, target any) Violations {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return Violations{{Path: path, Message: fmt.Sprintf("invalid JSON: %s", err)}}
+	}
+	if dec.More() {
+		return Violations{{Path: path, Message: "invalid JSON: unexpected data after the top-level value"}}
+	}
+
+	v := &validator{}
+	v.validate(path, doc, reflect.TypeOf(target), nil)
+	return v.violations
+}
+
+// validator accumulates the violations of a document.
+type validator struct {
+	violations Violations
+}
+
+func (v *validator) addf(path, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// validate checks a JSON value against a type, and the rules of the field the value belongs to.
+func (v *validator) validate(path string, value any, typ reflect.Type, fieldRules []string) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	// null leaves the field at its zero value.
+	if value == nil {
+		return
+	}
+
+	switch {
+	case typ == durationType:
+		v.validateDuration(path, value)
+		return
+	case typ == rawMessageType:
+		return
+	case reflect.PointerTo(typ).Implements(unmarshalType):
+		bz, _ := json.Marshal(value)
+		if err := json.Unmarshal(bz, reflect.New(typ).Interface()); err != nil {
+			v.addf(path, "invalid value: %s", err)
+		}
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		v.validateStruct(path, value, typ)
+	case reflect.Map:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.addf(path, "expected object, got %s", jsonType(value))
+			return
+		}
+		for _, key := range sortedKeys(obj) {
+			v.validate(ChildPath(path, key), obj[key], typ.Elem(), fieldRules)
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := value.([]any)
+		if !ok {
+			v.addf(path, "expected array, got %s", jsonType(value))
+			return
+		}
+		if typ.Kind() == reflect.Array && len(arr) > typ.Len() {
+			v.addf(path, "expected at most %d elements, got %d", typ.Len(), len(arr))
+		}
+		for i, elem := range arr {
+			v.validate(IndexPath(path, i), elem, typ.Elem(), fieldRules)
+		}
+	case reflect.Interface:
+		// any value is accepted.
+	default:
+		if v.validateScalar(path, value, typ) {
+			v.validateRules(path, value, fieldRules)
+		}
+	}
+}
+
+// validateStruct checks that a JSON value is an object whose fields are fields of the struct.
+func (v *validator) validateStruct(path string, value any, typ reflect.Type) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		v.addf(path, "expected object, got %s", jsonType(value))
+		return
+	}
+
+	fields := structFields(typ)
+	for _, key := range sortedKeys(obj) {
+		field, ok := lookupField(fields, key)
+		if !ok {
+			msg := fmt.Sprintf("unknown field %q", key)
+			if suggestion := suggestField(fields, key); suggestion != "" {
+				msg += fmt.Sprintf(", did you mean %q?", suggestion)
+			}
+			v.addf(ChildPath(path, key), "%s", msg)
+			continue
+		}
+
+		v.validate(ChildPath(path, key), obj[key], field.typ, field.rules)
+	}
+}
+
+// validateDuration checks that a JSON value is a Go duration string or an integer number of
+// nanoseconds.
+func (v *validator) validateDuration(path string, value any) {
+	switch value := value.(type) {
+	case string:
+		if _, err := time.ParseDuration(value); err != nil {
+			v.addf(path, "invalid duration %q, expected a duration such as \"250ms\" or \"1m30s\"", value)
+		}
+	case json.Number:
+		if _, err := value.Int64(); err != nil {
+			v.addf(path, "invalid duration %s, expected an integer number of nanoseconds", value)
+		}
+	default:
+		v.addf(path, "expected duration string or integer, got %s", jsonType(value))
+	}
+}
+
+// validateScalar checks that a JSON value is of the JSON type of a scalar type, and in range of
+// it. It returns true if the value is valid.
+func (v *validator) validateScalar(path string, value any, typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			v.addf(path, "expected string, got %s", jsonType(value))
+			return false
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			v.addf(path, "expected bool, got %s", jsonType(value))
+			return false
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := value.(json.Number)
+		if !ok {
+			v.addf(path, "expected integer, got %s", jsonType(value))
+			return false
+		}
+		i, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil || reflect.Zero(typ).OverflowInt(i) {
+			v.addf(path, "expected integer between %d and %d, got %s", minInt(typ), maxInt(typ), n)
+			return false
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := value.(json.Number)
+		if !ok {
+			v.addf(path, "expected non-negative integer, got %s", jsonType(value))
+			return false
+		}
+		u, err := strconv.ParseUint(n.String(), 10, 64)
+		if err != nil || reflect.Zero(typ).OverflowUint(u) {
+			v.addf(path, "expected integer between 0 and %d, got %s", maxUint(typ), n)
+			return false
+		}
+	case reflect.Float32, reflect.Float64:
+		n, ok := value.(json.Number)
+		if !ok {
+			v.addf(path, "expected number, got %s", jsonType(value))
+			return false
+		}
+		if _, err := n.Float64(); err != nil {
+			v.addf(path, "number %s is out of range", n)
+			return false
+		}
+	default:
+		v.addf(path, "unsupported field type %s", typ)
+		return false
+	}
+
+	return true
+}
+
+// validateRules checks a JSON value against the rules of its field.
+func (v *validator) validateRules(path string, value any, fieldRules []string) {
+	if s, ok := value.(string); ok && s == "" {
+		return
+	}
+
+	for _, name := range fieldRules {
+		rule, ok := rules[name]
+		if !ok {
+			v.addf(path, "unknown schema rule %q", name)
+			continue
+		}
+
+		if msg := rule(value); msg != "" {
+			v.addf(path, "%s", msg)
+		}
+	}
+}
+
+// checkURL checks that a value is an absolute URL, or a host:port address as used by gRPC
+// endpoints.
+func checkURL(value any) string {
+	s, ok := value.(string)
+	if !ok {
+		return "expected URL string"
+	}
+
+	if u, err := url.Parse(s); err == nil && u.Scheme != "" && u.Host != "" {
+		return ""
+	}
+	if host, port, err := net.SplitHostPort(s); err == nil && host != "" && port != "" {
+		return ""
+	}
+
+	return fmt.Sprintf("invalid URL %q, expected a URL with a scheme and host (e.g. https://example.com) or a host:port address", s)
+}
+
+// checkAddress checks that a value is an EIP-55 checksummed EVM address.
+func checkAddress(value any) string {
+	s, ok := value.(string)
+	if !ok {
+		return "expected address string"
+	}
+
+	if !common.IsHexAddress(s) {
+		return fmt.Sprintf("invalid address %q, expected a 0x prefixed 20 byte hex address", s)
+	}
+	if checksummed := common.HexToAddress(s).Hex(); s != checksummed {
+		return fmt.Sprintf("address %q is not checksummed, expected %q", s, checksummed)
+	}
+
+	return ""
+}
+
+// checkDecimals checks that a value is a number of decimals between 0 and MaxDecimals.
+func checkDecimals(value any) string {
+	n, ok := value.(json.Number)
+	if !ok {
+		return "expected integer decimals"
+	}
+
+	if d, err := n.Int64(); err != nil || d < 0 || d > MaxDecimals {
+		return fmt.Sprintf("decimals must be between 0 and %d, got %s", MaxDecimals, n)
+	}
+
+	return ""
+}
+
+// field is a JSON field of a struct.
+type field struct {
+	name  string
+	typ   reflect.Type
+	rules []string
+}
+
+// structFields returns the JSON fields of a struct, including the fields of embedded structs.
+func structFields(typ reflect.Type) []field {
+	var fields []field
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, structFields(embedded)...)
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var fieldRules []string
+		if tag := f.Tag.Get(TagName); tag != "" {
+			fieldRules = strings.Split(tag, ",")
+		}
+
+		fields = append(fields, field{name: name, typ: f.Type, rules: fieldRules})
+	}
+
+	return fields
+}
+
+// lookupField returns the field with the given name, preferring an exact match over a
+// case-insensitive match.
+func lookupField(fields []field, name string) (field, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+// suggestField returns the name of the field closest to the given unknown name, or an empty string
+// if no field is close.
+func suggestField(fields []field, name string) string {
+	var (
+		best     string
+		bestDist = maxSuggestionDistance + 1
+	)
+	for _, f := range fields {
+		if dist := editDistance(strings.ToLower(f.name), strings.ToLower(name)); dist < bestDist {
+			best, bestDist = f.name, dist
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// childPath returns the JSON path of a field of the value at path.
+func ChildPath(path, key string) string {
+	if identifierRegex.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%s]", path, strconv.Quote(key))
+}
+
+// IndexPath returns the JSON path of the element of the array at path with the given index.
+func IndexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// jsonType returns the name of the JSON type of a decoded value.
+func jsonType(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func minInt(typ reflect.Type) int64 {
+	return math.MinInt64 >> (64 - typ.Bits())
+}
+
+func maxInt(typ reflect.Type) int64 {
+	return math.MaxInt64 >> (64 - typ.Bits())
+}
+
+func maxUint(typ reflect.Type) uint64 {
+	return math.MaxUint64 >> (64 - typ.Bits())
+}
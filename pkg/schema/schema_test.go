@@ -0,0 +1,180 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/pkg/schema"
+)
+
+type endpoint struct {
+	URL string `json:"url" schema:"url"`
+}
+
+type token struct {
+	Address  string `json:"address" schema:"address"`
+	Decimals int64  `json:"decimals" schema:"decimals"`
+}
+
+type embedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type testConfig struct {
+	embedded
+
+	Enabled   bool                `json:"enabled"`
+	Interval  time.Duration       `json:"interval"`
+	MaxCount  uint8               `json:"maxCount"`
+	Ratio     float64             `json:"ratio"`
+	Name      string              `json:"name"`
+	Endpoints []endpoint          `json:"endpoints"`
+	Tokens    map[string]token    `json:"tokens"`
+	Decimals  []int64             `json:"decimals" schema:"decimals"`
+	ABI       json.RawMessage     `json:"abi"`
+	Nested    *testConfig         `json:"nested"`
+	Extra     map[string]any      `json:"extra"`
+	Ignored   string              `json:"-"`
+	Tagless   map[string][]string `json:""`
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		doc        string
+		violations schema.Violations
+	}{
+		{
+			name: "valid document",
+			doc: `{
+				"embedded": "value",
+				"enabled": true,
+				"interval": "1m30s",
+				"maxCount": 255,
+				"ratio": 0.5,
+				"name": "test",
+				"endpoints": [{"url": "https://example.com"}, {"url": "localhost:9090"}, {"url": ""}],
+				"tokens": {"WETH": {"address": "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "decimals": 18}},
+				"decimals": [6, 18],
+				"abi": [{"type": "function"}],
+				"nested": {"interval": 250000000},
+				"extra": {"anything": [1, "two"]},
+				"tagless": {"key": ["value"]}
+			}`,
+		},
+		{
+			name: "keys are matched case-insensitively",
+			doc:  `{"Enabled": true, "MAXCOUNT": 1, "Tagless": {}}`,
+		},
+		{
+			name: "null values are accepted",
+			doc:  `{"nested": null, "name": null, "endpoints": null}`,
+		},
+		{
+			name: "every violation is reported with its path",
+			doc: `{
+				"enabeld": true,
+				"interval": "90",
+				"maxCount": 256,
+				"ratio": "half",
+				"name": 1,
+				"endpoints": [{"url": "example.com"}, {"uri": "https://example.com"}],
+				"tokens": {
+					"WETH": {"address": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "decimals": 18},
+					"USD Coin": {"address": "0x1234", "decimals": 78}
+				},
+				"decimals": [6, -1],
+				"nested": {"nested": {"enabled": "yes"}},
+				"ignored": "value"
+			}`,
+			violations: schema.Violations{
+				{Path: "$.decimals[1]", Message: "decimals must be between 0 and 77, got -1"},
+				{Path: "$.enabeld", Message: `unknown field "enabeld", did you mean "enabled"?`},
+				{Path: "$.endpoints[0].url", Message: `invalid URL "example.com", expected a URL with a scheme and host (e.g. https://example.com) or a host:port address`},
+				{Path: "$.endpoints[1].uri", Message: `unknown field "uri", did you mean "url"?`},
+				{Path: "$.ignored", Message: `unknown field "ignored"`},
+				{Path: "$.interval", Message: `invalid duration "90", expected a duration such as "250ms" or "1m30s"`},
+				{Path: "$.maxCount", Message: "expected integer between 0 and 255, got 256"},
+				{Path: "$.name", Message: "expected string, got number"},
+				{Path: "$.nested.nested.enabled", Message: "expected bool, got string"},
+				{Path: "$.ratio", Message: "expected number, got string"},
+				{Path: `$.tokens["USD Coin"].address`, Message: `invalid address "0x1234", expected a 0x prefixed 20 byte hex address`},
+				{Path: `$.tokens["USD Coin"].decimals`, Message: "decimals must be between 0 and 77, got 78"},
+				{Path: "$.tokens.WETH.address", Message: `address "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" is not checksummed, expected "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"`},
+			},
+		},
+		{
+			name: "wrong container types",
+			doc:  `{"endpoints": {}, "tokens": [], "nested": "config"}`,
+			violations: schema.Violations{
+				{Path: "$.endpoints", Message: "expected array, got object"},
+				{Path: "$.nested", Message: "expected object, got string"},
+				{Path: "$.tokens", Message: "expected object, got array"},
+			},
+		},
+		{
+			name: "non-integer numbers",
+			doc:  `{"maxCount": 1.5, "interval": 1.5}`,
+			violations: schema.Violations{
+				{Path: "$.interval", Message: "invalid duration 1.5, expected an integer number of nanoseconds"},
+				{Path: "$.maxCount", Message: "expected integer between 0 and 255, got 1.5"},
+			},
+		},
+		{
+			name: "invalid json",
+			doc:  `{"enabled": true`,
+			violations: schema.Violations{
+				{Path: "$", Message: "invalid JSON: unexpected EOF"},
+			},
+		},
+		{
+			name: "trailing data",
+			doc:  `{} {}`,
+			violations: schema.Violations{
+				{Path: "$", Message: "invalid JSON: unexpected data after the top-level value"},
+			},
+		},
+		{
+			name: "top level type mismatch",
+			doc:  `[]`,
+			violations: schema.Violations{
+				{Path: "$", Message: "expected object, got array"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			violations := schema.Validate([]byte(tc.doc), &testConfig{})
+			require.Equal(t, tc.violations, violations)
+
+			if len(tc.violations) == 0 {
+				require.NoError(t, violations.Err())
+			} else {
+				require.Error(t, violations.Err())
+			}
+		})
+	}
+}
+
+func TestValidateAt(t *testing.T) {
+	path := schema.ChildPath(schema.IndexPath(schema.ChildPath(schema.Root, "tokens"), 2), "metadata_JSON")
+	require.Equal(t, "$.tokens[2].metadata_JSON", path)
+
+	violations := schema.ValidateAt(path, []byte(`{"decimal": 18}`), &token{})
+	require.Equal(t, schema.Violations{
+		{Path: "$.tokens[2].metadata_JSON.decimal", Message: `unknown field "decimal", did you mean "decimals"?`},
+	}, violations)
+}
+
+func TestViolationsError(t *testing.T) {
+	violations := schema.Violations{
+		{Path: "$.a", Message: "first"},
+		{Path: "$.b", Message: "second"},
+	}
+	require.Equal(t, "found 2 schema violation(s):\n  $.a: first\n  $.b: second", violations.Error())
+	require.NoError(t, schema.Violations(nil).Err())
+}
@@ -0,0 +1,219 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// AWSRegionEnv is the environment variable holding the AWS region.
+	AWSRegionEnv = "AWS_REGION"
+	// AWSDefaultRegionEnv is the environment variable holding the AWS region, if AWSRegionEnv is
+	// not set.
+	AWSDefaultRegionEnv = "AWS_DEFAULT_REGION"
+	// AWSAccessKeyIDEnv is the environment variable holding the AWS access key ID.
+	AWSAccessKeyIDEnv = "AWS_ACCESS_KEY_ID"
+	// AWSSecretAccessKeyEnv is the environment variable holding the AWS secret access key.
+	AWSSecretAccessKeyEnv = "AWS_SECRET_ACCESS_KEY"
+	// AWSSessionTokenEnv is the environment variable holding the (optional) AWS session token.
+	AWSSessionTokenEnv = "AWS_SESSION_TOKEN"
+	// AWSSecretsManagerEndpointEnv is the environment variable holding an (optional) endpoint that
+	// overrides the regional AWS Secrets Manager endpoint.
+	AWSSecretsManagerEndpointEnv = "AWS_ENDPOINT_URL_SECRETS_MANAGER"
+
+	awsSecretsManagerService = "secretsmanager"
+	awsSigningAlgorithm      = "AWS4-HMAC-SHA256"
+)
+
+// AWSSecretsManagerSource reads a secret from AWS Secrets Manager via the GetSecretValue API. The
+// region and credentials are read from the environment on each call to Read, so that rotated
+// credentials are picked up without a restart.
+type AWSSecretsManagerSource struct {
+	id     string
+	field  string
+	client *http.Client
+
+	// now returns the current time, and is used to sign requests.
+	now func() time.Time
+}
+
+// NewAWSSecretsManagerSource returns a new AWSSecretsManagerSource that reads the secret with the
+// given ID (name or ARN). If a field is given, the secret must be a JSON object and the value of the
+// field is returned. Otherwise, the secret string is returned as is.
+func NewAWSSecretsManagerSource(id, field string) *AWSSecretsManagerSource {
+	return &AWSSecretsManagerSource{
+		id:     id,
+		field:  field,
+		client: &http.Client{},
+		now:    time.Now,
+	}
+}
+
+// getSecretValueResponse is the response of the GetSecretValue API.
+type getSecretValueResponse struct {
+	SecretString string `json:"SecretString"`
+}
+
+// Read returns the current value of the secret.
+func (s *AWSSecretsManagerSource) Read(ctx context.Context) (string, error) {
+	region := os.Getenv(AWSRegionEnv)
+	if region == "" {
+		region = os.Getenv(AWSDefaultRegionEnv)
+	}
+	if region == "" {
+		return "", fmt.Errorf("%s is not set", AWSRegionEnv)
+	}
+
+	accessKeyID, secretAccessKey := os.Getenv(AWSAccessKeyIDEnv), os.Getenv(AWSSecretAccessKeyEnv)
+	if accessKeyID == "" || secretAccessKey == "" {
+		return "", fmt.Errorf("%s and %s must be set", AWSAccessKeyIDEnv, AWSSecretAccessKeyEnv)
+	}
+
+	endpoint := os.Getenv(AWSSecretsManagerEndpointEnv)
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com/", awsSecretsManagerService, region)
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": s.id})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if token := os.Getenv(AWSSessionTokenEnv); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+	signAWSRequest(req, body, region, accessKeyID, secretAccessKey, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read aws secret: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// The body of an error response describes the error and never contains the secret.
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("failed to read aws secret %s: %s: %s", s.id, resp.Status, msg)
+	}
+
+	var result getSecretValueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode aws secret: %w", err)
+	}
+
+	if s.field == "" {
+		if result.SecretString == "" {
+			return "", fmt.Errorf("aws secret %s is empty", s.id)
+		}
+
+		return result.SecretString, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(result.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("aws secret %s is not a json object", s.id)
+	}
+
+	return stringField(fields, s.field)
+}
+
+// signAWSRequest signs the request with AWS Signature Version 4. The request must not have a query
+// string, and the headers set on the request (aside from Host) are all signed.
+func signAWSRequest(req *http.Request, body []byte, region, accessKeyID, secretAccessKey string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders, canonicalHeaders := canonicalAWSHeaders(req)
+	canonicalRequest := fmt.Sprintf(
+		"%s\n%s\n\n%s\n%s\n%s",
+		req.Method,
+		canonicalAWSPath(req.URL),
+		canonicalHeaders,
+		signedHeaders,
+		hexSHA256(body),
+	)
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, awsSecretsManagerService)
+	stringToSign := fmt.Sprintf("%s\n%s\n%s\n%s", awsSigningAlgorithm, amzDate, scope, hexSHA256([]byte(canonicalRequest)))
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, awsSecretsManagerService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSigningAlgorithm,
+		accessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+// canonicalAWSHeaders returns the signed headers and canonical headers of the request, as defined
+// by AWS Signature Version 4. The headers are sorted by their lowercase names.
+func canonicalAWSHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	for k := range req.Header {
+		headers[strings.ToLower(k)] = req.Header.Get(k)
+	}
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var signed, canonical bytes.Buffer
+	for i, name := range names {
+		if i > 0 {
+			signed.WriteByte(';')
+		}
+		signed.WriteString(name)
+		fmt.Fprintf(&canonical, "%s:%s\n", name, headers[name])
+	}
+
+	return signed.String(), canonical.String()
+}
+
+// canonicalAWSPath returns the canonical URI of the request.
+func canonicalAWSPath(u *url.URL) string {
+	if path := u.EscapedPath(); path != "" {
+		return path
+	}
+
+	return "/"
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,110 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+func TestAWSSecretsManagerSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if r.Method != http.MethodPost ||
+			r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(auth, "/us-east-1/secretsmanager/aws4_request") ||
+			!strings.Contains(auth, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		var req struct {
+			SecretID string `json:"SecretId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch req.SecretID {
+		case "connect/api-keys":
+			_, _ = w.Write([]byte(`{"Name":"connect/api-keys","SecretString":"{\"coingecko\":\"cg-key\"}"}`))
+		case "connect/coingecko":
+			_, _ = w.Write([]byte(`{"Name":"connect/coingecko","SecretString":"cg-key"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException"}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(secrets.AWSSecretsManagerEndpointEnv, server.URL)
+	t.Setenv(secrets.AWSRegionEnv, "us-east-1")
+	t.Setenv(secrets.AWSAccessKeyIDEnv, "AKID")
+	t.Setenv(secrets.AWSSecretAccessKeyEnv, "secret")
+	t.Setenv(secrets.AWSSessionTokenEnv, "session")
+
+	testCases := []struct {
+		name        string
+		id          string
+		field       string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "json field",
+			id:       "connect/api-keys",
+			field:    "coingecko",
+			expected: "cg-key",
+		},
+		{
+			name:     "secret string",
+			id:       "connect/coingecko",
+			expected: "cg-key",
+		},
+		{
+			name:        "missing json field",
+			id:          "connect/api-keys",
+			field:       "other",
+			expectedErr: true,
+		},
+		{
+			name:        "secret string is not json",
+			id:          "connect/coingecko",
+			field:       "coingecko",
+			expectedErr: true,
+		},
+		{
+			name:        "missing secret",
+			id:          "connect/other",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := secrets.NewAWSSecretsManagerSource(tc.id, tc.field).Read(context.Background())
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, value)
+		})
+	}
+
+	t.Run("missing credentials", func(t *testing.T) {
+		t.Setenv(secrets.AWSAccessKeyIDEnv, "")
+
+		_, err := secrets.NewAWSSecretsManagerSource("connect/coingecko", "").Read(context.Background())
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,135 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// ReadTimeout is the maximum amount of time spent reading a secret from its source.
+const ReadTimeout = 10 * time.Second
+
+var (
+	sharedMtx sync.Mutex
+	// shared are the secrets read from a source, indexed by their reference. These are shared by
+	// all clients that reference the same secret so that the secret is only re-read once per
+	// refresh interval.
+	shared = make(map[string]*Secret)
+)
+
+// Secret holds the value of a secret. If the secret is read from a source with a refresh interval,
+// the source is re-read in the background once the interval has elapsed, so that the secret can be
+// rotated without a restart. If the source cannot be read, the last value read is kept.
+type Secret struct {
+	source  Source
+	refresh time.Duration
+
+	mtx sync.Mutex
+	// value is the last value read from the source.
+	value string
+	// readAt is the time at which the source was last read.
+	readAt time.Time
+	// reading is true while the source is being re-read.
+	reading bool
+}
+
+// NewStaticSecret returns a new Secret with a fixed value.
+func NewStaticSecret(value string) *Secret {
+	return &Secret{
+		value: value,
+	}
+}
+
+// NewSecret returns a new Secret that reads its value from the given source. The source is read
+// once when the secret is created, and re-read once every refresh interval. If the refresh interval
+// is not positive, the source is never re-read.
+func NewSecret(source Source, refresh time.Duration) (*Secret, error) {
+	if source == nil {
+		return nil, fmt.Errorf("source cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ReadTimeout)
+	defer cancel()
+
+	value, err := source.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Secret{
+		source:  source,
+		refresh: refresh,
+		value:   value,
+		readAt:  time.Now(),
+	}, nil
+}
+
+// Shared returns the secret referenced by the given reference (see NewSource). All clients that
+// reference the same secret share it. If the refresh interval of the secret changes (e.g. when the
+// config is reloaded), the secret is re-created.
+func Shared(ref string, refresh time.Duration) (*Secret, error) {
+	sharedMtx.Lock()
+	defer sharedMtx.Unlock()
+
+	if secret, ok := shared[ref]; ok && secret.refresh == refresh {
+		return secret, nil
+	}
+
+	source, err := NewSource(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := NewSecret(source, refresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret %s: %w", ref, err)
+	}
+
+	shared[ref] = secret
+	return secret, nil
+}
+
+// APIKey returns the API key of the given authentication. If the key is read from a source, the
+// secret is shared by all clients that reference the same source.
+func APIKey(auth config.Authentication) (*Secret, error) {
+	if auth.APIKeySource == "" {
+		return NewStaticSecret(auth.APIKey), nil
+	}
+
+	return Shared(auth.APIKeySource, auth.RefreshInterval)
+}
+
+// Value returns the current value of the secret. If the refresh interval has elapsed since the
+// source was last read, the source is re-read in the background and the current value is returned.
+func (s *Secret) Value() string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.source != nil && s.refresh > 0 && !s.reading && time.Since(s.readAt) >= s.refresh {
+		s.reading = true
+		go s.reread()
+	}
+
+	return s.value
+}
+
+// reread reads the source and updates the value of the secret, keeping the last value if the source
+// cannot be read. Either way, the source is not read again until the refresh interval has elapsed.
+func (s *Secret) reread() {
+	ctx, cancel := context.WithTimeout(context.Background(), ReadTimeout)
+	defer cancel()
+
+	value, err := s.source.Read(ctx)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.reading = false
+	s.readAt = time.Now()
+	if err == nil {
+		s.value = value
+	}
+}
@@ -0,0 +1,131 @@
+package secrets_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+// mockSource is a source whose value and error can be changed.
+type mockSource struct {
+	mtx   sync.Mutex
+	value string
+	err   error
+	reads int
+}
+
+func (m *mockSource) Read(context.Context) (string, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.reads++
+	return m.value, m.err
+}
+
+func (m *mockSource) set(value string, err error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.value, m.err = value, err
+}
+
+func (m *mockSource) numReads() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.reads
+}
+
+func TestSecret(t *testing.T) {
+	t.Run("static secret", func(t *testing.T) {
+		require.Equal(t, "key", secrets.NewStaticSecret("key").Value())
+	})
+
+	t.Run("fails if the source cannot be read", func(t *testing.T) {
+		_, err := secrets.NewSecret(&mockSource{err: fmt.Errorf("unavailable")}, time.Second)
+		require.Error(t, err)
+	})
+
+	t.Run("does not re-read the source without a refresh interval", func(t *testing.T) {
+		source := &mockSource{value: "old"}
+		secret, err := secrets.NewSecret(source, 0)
+		require.NoError(t, err)
+
+		source.set("new", nil)
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(t, "old", secret.Value())
+		require.Equal(t, 1, source.numReads())
+	})
+
+	t.Run("re-reads the source once the refresh interval has elapsed", func(t *testing.T) {
+		source := &mockSource{value: "old"}
+		secret, err := secrets.NewSecret(source, 10*time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, "old", secret.Value())
+
+		source.set("new", nil)
+		require.Eventually(t, func() bool { return secret.Value() == "new" }, time.Second, time.Millisecond)
+	})
+
+	t.Run("keeps the last value if the source cannot be re-read", func(t *testing.T) {
+		source := &mockSource{value: "old"}
+		secret, err := secrets.NewSecret(source, 10*time.Millisecond)
+		require.NoError(t, err)
+
+		source.set("", fmt.Errorf("unavailable"))
+		require.Eventually(t, func() bool {
+			secret.Value()
+			return source.numReads() > 2
+		}, time.Second, time.Millisecond)
+		require.Equal(t, "old", secret.Value())
+	})
+}
+
+func TestShared(t *testing.T) {
+	t.Setenv("CONNECT_TEST_SHARED_API_KEY", "key")
+
+	secret, err := secrets.Shared("env:CONNECT_TEST_SHARED_API_KEY", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "key", secret.Value())
+
+	// Clients that reference the same secret share it.
+	same, err := secrets.Shared("env:CONNECT_TEST_SHARED_API_KEY", time.Minute)
+	require.NoError(t, err)
+	require.Same(t, secret, same)
+
+	// The secret is re-created if the refresh interval changes.
+	updated, err := secrets.Shared("env:CONNECT_TEST_SHARED_API_KEY", time.Hour)
+	require.NoError(t, err)
+	require.NotSame(t, secret, updated)
+
+	_, err = secrets.Shared("env:CONNECT_TEST_MISSING_API_KEY", time.Minute)
+	require.Error(t, err)
+
+	_, err = secrets.Shared("API_KEY", time.Minute)
+	require.Error(t, err)
+}
+
+func TestAPIKey(t *testing.T) {
+	t.Setenv("CONNECT_TEST_AUTH_API_KEY", "from-env")
+
+	secret, err := secrets.APIKey(config.Authentication{
+		APIKey:       "from-config",
+		APIKeyHeader: "X-Api-Key",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "from-config", secret.Value())
+
+	secret, err = secrets.APIKey(config.Authentication{
+		APIKeySource: "env:CONNECT_TEST_AUTH_API_KEY",
+		APIKeyHeader: "X-Api-Key",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "from-env", secret.Value())
+}
@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// SchemeEnv is the scheme of secrets read from an environment variable, e.g. env:API_KEY.
+	SchemeEnv = "env"
+	// SchemeFile is the scheme of secrets read from a file, e.g. file:/run/secrets/api_key.
+	SchemeFile = "file"
+	// SchemeVault is the scheme of secrets read from HashiCorp Vault, e.g.
+	// vault:secret/data/connect#api_key.
+	SchemeVault = "vault"
+	// SchemeAWSSecretsManager is the scheme of secrets read from AWS Secrets Manager, e.g.
+	// awssm:connect/api-keys#coingecko.
+	SchemeAWSSecretsManager = "awssm"
+)
+
+// Source reads the current value of a secret.
+type Source interface {
+	// Read returns the current value of the secret.
+	Read(ctx context.Context) (string, error)
+}
+
+// NewSource returns the source referenced by the given reference. A reference is of the form
+// <scheme>:<location>, where the scheme is one of:
+//
+//   - env: the value of the environment variable named by the location.
+//   - file: the contents of the file at the location, with surrounding whitespace trimmed.
+//   - vault: the field of a HashiCorp Vault secret, where the location is <path>#<field> and the
+//     path is relative to /v1/ (e.g. secret/data/connect for the KV v2 engine). The address and
+//     token are read from the VAULT_ADDR and VAULT_TOKEN environment variables.
+//   - awssm: an AWS Secrets Manager secret, where the location is <secret-id>[#<field>]. If a field
+//     is given, the secret must be a JSON object and the field's value is returned. The region and
+//     credentials are read from the standard AWS environment variables.
+func NewSource(ref string) (Source, error) {
+	scheme, location, ok := strings.Cut(ref, ":")
+	if !ok || location == "" {
+		return nil, fmt.Errorf("invalid secret reference %q: expected <scheme>:<location>", ref)
+	}
+
+	switch scheme {
+	case SchemeEnv:
+		return EnvSource{Name: location}, nil
+	case SchemeFile:
+		return FileSource{Path: location}, nil
+	case SchemeVault:
+		path, field, ok := strings.Cut(location, "#")
+		if !ok || path == "" || field == "" {
+			return nil, fmt.Errorf("invalid vault secret reference %q: expected vault:<path>#<field>", ref)
+		}
+
+		return NewVaultSource(path, field), nil
+	case SchemeAWSSecretsManager:
+		id, field, _ := strings.Cut(location, "#")
+		if id == "" {
+			return nil, fmt.Errorf("invalid aws secrets manager reference %q: expected awssm:<secret-id>[#<field>]", ref)
+		}
+
+		return NewAWSSecretsManagerSource(id, field), nil
+	default:
+		return nil, fmt.Errorf("unknown secret scheme %q", scheme)
+	}
+}
+
+// EnvSource reads a secret from an environment variable. The variable is read on each call to
+// Read, so it reflects changes made to the environment of the process.
+type EnvSource struct {
+	// Name is the name of the environment variable.
+	Name string
+}
+
+// Read returns the value of the environment variable.
+func (s EnvSource) Read(context.Context) (string, error) {
+	value, ok := os.LookupEnv(s.Name)
+	if !ok || value == "" {
+		return "", fmt.Errorf("environment variable %s is not set", s.Name)
+	}
+
+	return value, nil
+}
+
+// FileSource reads a secret from a file, e.g. a mounted Kubernetes secret. The file is read on
+// each call to Read, so it reflects the current contents of the file.
+type FileSource struct {
+	// Path is the path of the file.
+	Path string
+}
+
+// Read returns the contents of the file, with surrounding whitespace trimmed.
+func (s FileSource) Read(context.Context) (string, error) {
+	bz, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	value := strings.TrimSpace(string(bz))
+	if value == "" {
+		return "", fmt.Errorf("secret file %s is empty", s.Path)
+	}
+
+	return value, nil
+}
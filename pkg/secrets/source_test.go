@@ -0,0 +1,116 @@
+package secrets_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+func TestNewSource(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ref         string
+		expected    secrets.Source
+		expectedErr bool
+	}{
+		{
+			name:     "env",
+			ref:      "env:API_KEY",
+			expected: secrets.EnvSource{Name: "API_KEY"},
+		},
+		{
+			name:     "file",
+			ref:      "file:/run/secrets/api_key",
+			expected: secrets.FileSource{Path: "/run/secrets/api_key"},
+		},
+		{
+			name:     "vault",
+			ref:      "vault:secret/data/connect#api_key",
+			expected: secrets.NewVaultSource("secret/data/connect", "api_key"),
+		},
+		{
+			name:        "vault without field",
+			ref:         "vault:secret/data/connect",
+			expectedErr: true,
+		},
+		{
+			name:     "aws secrets manager",
+			ref:      "awssm:connect/api-keys#coingecko",
+			expected: secrets.NewAWSSecretsManagerSource("connect/api-keys", "coingecko"),
+		},
+		{
+			name:     "aws secrets manager without field",
+			ref:      "awssm:connect/coingecko",
+			expected: secrets.NewAWSSecretsManagerSource("connect/coingecko", ""),
+		},
+		{
+			name:        "missing scheme",
+			ref:         "API_KEY",
+			expectedErr: true,
+		},
+		{
+			name:        "empty location",
+			ref:         "env:",
+			expectedErr: true,
+		},
+		{
+			name:        "unknown scheme",
+			ref:         "gcp:api_key",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			source, err := secrets.NewSource(tc.ref)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.IsType(t, tc.expected, source)
+
+			// The sources must not hold functions or clients to be compared directly.
+			switch expected := tc.expected.(type) {
+			case secrets.EnvSource, secrets.FileSource:
+				require.Equal(t, expected, source)
+			}
+		})
+	}
+}
+
+func TestEnvSource(t *testing.T) {
+	source := secrets.EnvSource{Name: "CONNECT_TEST_API_KEY"}
+
+	t.Setenv("CONNECT_TEST_API_KEY", "")
+	_, err := source.Read(context.Background())
+	require.Error(t, err)
+
+	t.Setenv("CONNECT_TEST_API_KEY", "key")
+	value, err := source.Read(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key", value)
+}
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_key")
+	source := secrets.FileSource{Path: path}
+
+	_, err := source.Read(context.Background())
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("  \n"), 0o600))
+	_, err = source.Read(context.Background())
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("key\n"), 0o600))
+	value, err := source.Read(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key", value)
+}
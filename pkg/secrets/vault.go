@@ -0,0 +1,112 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	// VaultAddrEnv is the environment variable holding the address of the Vault server.
+	VaultAddrEnv = "VAULT_ADDR"
+	// VaultTokenEnv is the environment variable holding the token used to authenticate with Vault.
+	VaultTokenEnv = "VAULT_TOKEN"
+	// VaultNamespaceEnv is the environment variable holding the (optional) Vault namespace.
+	VaultNamespaceEnv = "VAULT_NAMESPACE"
+)
+
+// VaultSource reads a field of a secret from HashiCorp Vault over its HTTP API. Both the KV v1 and
+// KV v2 secret engines are supported. The address and token are read from the environment on each
+// call to Read, so that a renewed token is picked up without a restart.
+type VaultSource struct {
+	path   string
+	field  string
+	client *http.Client
+}
+
+// NewVaultSource returns a new VaultSource that reads the given field of the secret at the given
+// path. The path is relative to /v1/, e.g. secret/data/connect for the KV v2 engine.
+func NewVaultSource(path, field string) *VaultSource {
+	return &VaultSource{
+		path:   strings.Trim(path, "/"),
+		field:  field,
+		client: &http.Client{},
+	}
+}
+
+// vaultResponse is the response returned by Vault when reading a secret. The KV v2 engine nests
+// the fields of the secret under data.data, whereas the KV v1 engine returns them under data.
+type vaultResponse struct {
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+// Read returns the current value of the field.
+func (s *VaultSource) Read(ctx context.Context) (string, error) {
+	addr := os.Getenv(VaultAddrEnv)
+	if addr == "" {
+		return "", fmt.Errorf("%s is not set", VaultAddrEnv)
+	}
+
+	token := os.Getenv(VaultTokenEnv)
+	if token == "" {
+		return "", fmt.Errorf("%s is not set", VaultTokenEnv)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(addr, "/"), s.path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv(VaultNamespaceEnv); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault secret: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read vault secret %s: %s", s.path, resp.Status)
+	}
+
+	var result vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode vault secret: %w", err)
+	}
+
+	data := result.Data
+	if nested, ok := data["data"]; ok {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(nested, &fields); err == nil {
+			data = fields
+		}
+	}
+
+	return stringField(data, s.field)
+}
+
+// stringField returns the value of the given string field.
+func stringField(fields map[string]json.RawMessage, field string) (string, error) {
+	raw, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("secret has no field %s", field)
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("secret field %s is not a string: %w", field, err)
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("secret field %s is empty", field)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,100 @@
+package secrets_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+func TestVaultSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/connect":
+			_, _ = w.Write([]byte(`{"data":{"data":{"api_key":"v2-key","number":1},"metadata":{"version":3}}}`))
+		case "/v1/kv/connect":
+			_, _ = w.Write([]byte(`{"data":{"api_key":"v1-key"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(secrets.VaultAddrEnv, server.URL)
+	t.Setenv(secrets.VaultTokenEnv, "token")
+
+	testCases := []struct {
+		name        string
+		path        string
+		field       string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "kv v2",
+			path:     "secret/data/connect",
+			field:    "api_key",
+			expected: "v2-key",
+		},
+		{
+			name:     "kv v1",
+			path:     "/kv/connect",
+			field:    "api_key",
+			expected: "v1-key",
+		},
+		{
+			name:        "missing field",
+			path:        "secret/data/connect",
+			field:       "other",
+			expectedErr: true,
+		},
+		{
+			name:        "field is not a string",
+			path:        "secret/data/connect",
+			field:       "number",
+			expectedErr: true,
+		},
+		{
+			name:        "missing secret",
+			path:        "secret/data/other",
+			field:       "api_key",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := secrets.NewVaultSource(tc.path, tc.field).Read(context.Background())
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, value)
+		})
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		t.Setenv(secrets.VaultTokenEnv, "other")
+
+		_, err := secrets.NewVaultSource("secret/data/connect", "api_key").Read(context.Background())
+		require.Error(t, err)
+	})
+
+	t.Run("missing address", func(t *testing.T) {
+		t.Setenv(secrets.VaultAddrEnv, "")
+
+		_, err := secrets.NewVaultSource("secret/data/connect", "api_key").Read(context.Background())
+		require.Error(t, err)
+	})
+}
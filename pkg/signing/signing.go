@@ -0,0 +1,185 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cosmosed25519 "github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+// signBytesPrefix is prepended to the sign bytes of each price report, so that a signature over a
+// price report cannot be mistaken for a signature over any other message signed with the same key.
+const signBytesPrefix = "connect/price-report/v1"
+
+// privateKeySize is the size of the private keys accepted by NewSigner, i.e. an ed25519 seed or a
+// secp256k1 scalar.
+const privateKeySize = 32
+
+// Signer signs the price reports served by the oracle.
+type Signer struct {
+	algorithm string
+	key       cryptotypes.PrivKey
+}
+
+// NewSigner returns a new Signer that signs with the given 32 byte private key. For ed25519, the
+// key is the seed of the private key.
+func NewSigner(algorithm string, key []byte) (*Signer, error) {
+	if len(key) != privateKeySize {
+		return nil, fmt.Errorf("private key must be %d bytes; got %d", privateKeySize, len(key))
+	}
+
+	var privKey cryptotypes.PrivKey
+	switch algorithm {
+	case config.SigningAlgorithmEd25519:
+		privKey = &cosmosed25519.PrivKey{Key: ed25519.NewKeyFromSeed(key)}
+	case config.SigningAlgorithmSecp256k1:
+		privKey = &secp256k1.PrivKey{Key: bytes.Clone(key)}
+	default:
+		return nil, fmt.Errorf("unknown signing algorithm %q", algorithm)
+	}
+
+	return &Signer{
+		algorithm: algorithm,
+		key:       privKey,
+	}, nil
+}
+
+// NewSignerFromConfig returns a new Signer from the given config. The hex-encoded private key is
+// read from the configured key source.
+func NewSignerFromConfig(cfg config.SigningConfig) (*Signer, error) {
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	source, err := secrets.NewSource(cfg.KeySource)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := secrets.NewSecret(source, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimPrefix(secret.Value(), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("signing key is not hex-encoded: %w", err)
+	}
+
+	return NewSigner(cfg.Algorithm, key)
+}
+
+// Algorithm returns the signature algorithm of the signer.
+func (s *Signer) Algorithm() string {
+	return s.algorithm
+}
+
+// PublicKey returns the public key that the signatures of the signer can be verified with. For
+// secp256k1, this is the 33 byte compressed public key.
+func (s *Signer) PublicKey() []byte {
+	return s.key.PubKey().Bytes()
+}
+
+// Sign signs the price report with the given prices and timestamp.
+func (s *Signer) Sign(prices map[string]string, timestamp time.Time) ([]byte, error) {
+	return s.key.Sign(SignBytes(prices, timestamp))
+}
+
+// SignMessage signs an arbitrary message, such as a config file. Unlike Sign, the message is
+// signed as is, so the signature can also be produced by other tools holding the same key.
+func (s *Signer) SignMessage(msg []byte) ([]byte, error) {
+	return s.key.Sign(msg)
+}
+
+// Verify verifies that the signature was made over the price report with the given prices and
+// timestamp by the holder of the private key of the given public key.
+func Verify(algorithm string, publicKey, signature []byte, prices map[string]string, timestamp time.Time) error {
+	if err := VerifyMessage(algorithm, publicKey, signature, SignBytes(prices, timestamp)); err != nil {
+		return fmt.Errorf("invalid price report signature: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyMessage verifies that the signature was made over the message by the holder of the private
+// key of the given public key.
+func VerifyMessage(algorithm string, publicKey, signature, msg []byte) error {
+	pubKey, err := newPubKey(algorithm, publicKey)
+	if err != nil {
+		return err
+	}
+
+	if !pubKey.VerifySignature(msg, signature) {
+		return fmt.Errorf("signature does not match the public key")
+	}
+
+	return nil
+}
+
+// ValidatePublicKey returns an error if the public key is not a valid public key of the algorithm.
+func ValidatePublicKey(algorithm string, publicKey []byte) error {
+	_, err := newPubKey(algorithm, publicKey)
+	return err
+}
+
+// newPubKey returns the public key of the algorithm with the given bytes.
+func newPubKey(algorithm string, publicKey []byte) (cryptotypes.PubKey, error) {
+	var pubKey cryptotypes.PubKey
+	switch algorithm {
+	case config.SigningAlgorithmEd25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("ed25519 public key must be %d bytes; got %d", ed25519.PublicKeySize, len(publicKey))
+		}
+		pubKey = &cosmosed25519.PubKey{Key: publicKey}
+	case config.SigningAlgorithmSecp256k1:
+		if len(publicKey) != secp256k1.PubKeySize {
+			return nil, fmt.Errorf("secp256k1 public key must be %d bytes; got %d", secp256k1.PubKeySize, len(publicKey))
+		}
+		pubKey = &secp256k1.PubKey{Key: publicKey}
+	default:
+		return nil, fmt.Errorf("unknown signing algorithm %q", algorithm)
+	}
+
+	return pubKey, nil
+}
+
+// SignBytes returns the bytes that are signed for the price report with the given prices and
+// timestamp. The sign bytes are the following lines, each terminated by a newline:
+//
+//	connect/price-report/v1
+//	<timestamp in unix nanoseconds>
+//	<currency pair>=<price>, for each price, sorted by currency pair
+//
+// ed25519 signs the sign bytes directly, whereas secp256k1 signs their SHA-256 digest.
+func SignBytes(prices map[string]string, timestamp time.Time) []byte {
+	pairs := make([]string, 0, len(prices))
+	for cp := range prices {
+		pairs = append(pairs, cp)
+	}
+	sort.Strings(pairs)
+
+	var buf bytes.Buffer
+	buf.WriteString(signBytesPrefix)
+	buf.WriteByte('\n')
+	buf.WriteString(strconv.FormatInt(timestamp.UnixNano(), 10))
+	buf.WriteByte('\n')
+	for _, cp := range pairs {
+		buf.WriteString(cp)
+		buf.WriteByte('=')
+		buf.WriteString(prices[cp])
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
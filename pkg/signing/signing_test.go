@@ -0,0 +1,112 @@
+package signing_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/signing"
+)
+
+var (
+	testKey = []byte(strings.Repeat("\x01", 32))
+
+	testPrices = map[string]string{
+		"BTC/USD": "6500000000000",
+		"ETH/USD": "350000000000",
+	}
+
+	testTimestamp = time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC)
+)
+
+func TestSigner(t *testing.T) {
+	for _, algorithm := range []string{config.SigningAlgorithmEd25519, config.SigningAlgorithmSecp256k1} {
+		t.Run(algorithm, func(t *testing.T) {
+			signer, err := signing.NewSigner(algorithm, testKey)
+			require.NoError(t, err)
+			require.Equal(t, algorithm, signer.Algorithm())
+
+			signature, err := signer.Sign(testPrices, testTimestamp)
+			require.NoError(t, err)
+			require.NoError(t, signing.Verify(algorithm, signer.PublicKey(), signature, testPrices, testTimestamp))
+
+			// The signature does not verify if the report was tampered with.
+			tampered := map[string]string{
+				"BTC/USD": "6500000000001",
+				"ETH/USD": "350000000000",
+			}
+			require.Error(t, signing.Verify(algorithm, signer.PublicKey(), signature, tampered, testTimestamp))
+			require.Error(t, signing.Verify(algorithm, signer.PublicKey(), signature, testPrices, testTimestamp.Add(time.Nanosecond)))
+
+			// The signature does not verify with another key.
+			other, err := signing.NewSigner(algorithm, []byte(strings.Repeat("\x02", 32)))
+			require.NoError(t, err)
+			require.Error(t, signing.Verify(algorithm, other.PublicKey(), signature, testPrices, testTimestamp))
+		})
+	}
+
+	t.Run("invalid key size", func(t *testing.T) {
+		_, err := signing.NewSigner(config.SigningAlgorithmEd25519, testKey[:31])
+		require.Error(t, err)
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		_, err := signing.NewSigner("rsa", testKey)
+		require.Error(t, err)
+
+		require.Error(t, signing.Verify("rsa", nil, nil, testPrices, testTimestamp))
+	})
+}
+
+func TestSignMessage(t *testing.T) {
+	msg := []byte(`{"providers": {}}`)
+
+	for _, algorithm := range []string{config.SigningAlgorithmEd25519, config.SigningAlgorithmSecp256k1} {
+		t.Run(algorithm, func(t *testing.T) {
+			signer, err := signing.NewSigner(algorithm, testKey)
+			require.NoError(t, err)
+			require.NoError(t, signing.ValidatePublicKey(algorithm, signer.PublicKey()))
+
+			signature, err := signer.SignMessage(msg)
+			require.NoError(t, err)
+			require.NoError(t, signing.VerifyMessage(algorithm, signer.PublicKey(), signature, msg))
+
+			require.Error(t, signing.VerifyMessage(algorithm, signer.PublicKey(), signature, []byte(`{"providers": []}`)))
+		})
+	}
+
+	require.Error(t, signing.ValidatePublicKey(config.SigningAlgorithmEd25519, testKey[:31]))
+	require.Error(t, signing.ValidatePublicKey(config.SigningAlgorithmSecp256k1, testKey))
+}
+
+func TestNewSignerFromConfig(t *testing.T) {
+	t.Setenv("CONNECT_TEST_SIGNING_KEY", "0x"+hex.EncodeToString(testKey))
+
+	signer, err := signing.NewSignerFromConfig(config.SigningConfig{
+		Enabled:   true,
+		Algorithm: config.SigningAlgorithmEd25519,
+		KeySource: "env:CONNECT_TEST_SIGNING_KEY",
+	})
+	require.NoError(t, err)
+
+	expected, err := signing.NewSigner(config.SigningAlgorithmEd25519, testKey)
+	require.NoError(t, err)
+	require.Equal(t, expected.PublicKey(), signer.PublicKey())
+
+	t.Setenv("CONNECT_TEST_SIGNING_KEY", "not hex")
+	_, err = signing.NewSignerFromConfig(config.SigningConfig{
+		Enabled:   true,
+		Algorithm: config.SigningAlgorithmEd25519,
+		KeySource: "env:CONNECT_TEST_SIGNING_KEY",
+	})
+	require.Error(t, err)
+}
+
+func TestSignBytes(t *testing.T) {
+	expected := "connect/price-report/v1\n1704067200000000001\nBTC/USD=6500000000000\nETH/USD=350000000000\n"
+	require.Equal(t, expected, string(signing.SignBytes(testPrices, testTimestamp)))
+}
@@ -0,0 +1,206 @@
+package band
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
+
+// APIHandler implements the PriceAPIDataHandler interface for Band, which can be used
+// by a base provider. The handler fetches data from the request prices endpoint of the
+// BandChain API, which serves the prices of the standard dataset. The off-chain ticker of
+// each market is expected to be a Band symbol, or a base and quote symbol separated by a
+// slash.
+type APIHandler struct {
+	api config.APIConfig
+}
+
+// NewAPIHandler returns a new Band PriceAPIDataHandler.
+func NewAPIHandler(
+	api config.APIConfig,
+) (types.PriceAPIDataHandler, error) {
+	if api.Name != Name {
+		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", Name)
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config for %s: %w", Name, err)
+	}
+
+	return &APIHandler{
+		api: api,
+	}, nil
+}
+
+// CreateURL returns the URL that is used to fetch data from the BandChain API for the
+// given tickers. Every symbol needed to price the tickers is requested at once.
+func (h *APIHandler) CreateURL(
+	tickers []types.ProviderTicker,
+) (string, error) {
+	if len(tickers) == 0 {
+		return "", fmt.Errorf("no tickers specified")
+	}
+
+	var (
+		url  strings.Builder
+		seen = make(map[string]struct{})
+	)
+	url.WriteString(h.api.Endpoints[0].URL)
+	url.WriteString(fmt.Sprintf(RequestPricesEndpoint, DefaultAskCount, DefaultMinCount))
+	for _, ticker := range tickers {
+		pair, err := ParsePair(ticker.GetOffChainTicker())
+		if err != nil {
+			return "", err
+		}
+
+		for _, symbol := range pair.Symbols() {
+			if _, ok := seen[symbol]; ok {
+				continue
+			}
+
+			seen[symbol] = struct{}{}
+			url.WriteString(fmt.Sprintf(SymbolQueryParam, symbol))
+		}
+	}
+
+	return url.String(), nil
+}
+
+// ParseResponse parses the response from the BandChain API. Every price of the standard
+// dataset is denominated in USD, so tickers with another quote are priced as the ratio of
+// the base and quote prices. The timestamp of each resolved price is the oldest resolve time
+// of the prices it was derived from.
+func (h *APIHandler) ParseResponse(
+	tickers []types.ProviderTicker,
+	resp *http.Response,
+) types.PriceResponse {
+	var result RequestPricesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+		)
+	}
+
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unresolved = make(types.UnResolvedPrices)
+		results    = make(map[string]PriceResult, len(result.PriceResults))
+	)
+
+	for _, pr := range result.PriceResults {
+		results[strings.ToUpper(pr.Symbol)] = pr
+	}
+
+	for _, ticker := range tickers {
+		pair, err := ParsePair(ticker.GetOffChainTicker())
+		if err != nil {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+			}
+			continue
+		}
+
+		missing := false
+		for _, symbol := range pair.Symbols() {
+			if _, ok := results[symbol]; !ok {
+				missing = true
+			}
+		}
+
+		if missing {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+			}
+			continue
+		}
+
+		price, timestamp, err := ParsePrice(pair, results)
+		if err != nil {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice),
+			}
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResult(price, timestamp)
+	}
+
+	return types.NewPriceResponse(resolved, unresolved)
+}
+
+// ParsePrice returns the price of the pair from the USD prices of its symbols, along with the
+// oldest resolve time of those prices.
+func ParsePrice(
+	pair Pair,
+	results map[string]PriceResult,
+) (*big.Float, time.Time, error) {
+	base, baseTime, err := usdPrice(pair.Base, results)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	quote, quoteTime, err := usdPrice(pair.Quote, results)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	timestamp := baseTime
+	if timestamp.IsZero() || (!quoteTime.IsZero() && quoteTime.Before(timestamp)) {
+		timestamp = quoteTime
+	}
+
+	return new(big.Float).Quo(base, quote), timestamp, nil
+}
+
+// usdPrice returns the USD price of the symbol and its resolve time. USD itself is priced at 1
+// with a zero resolve time, such that it never determines the timestamp of a pair.
+func usdPrice(
+	symbol string,
+	results map[string]PriceResult,
+) (*big.Float, time.Time, error) {
+	if symbol == QuoteSymbol {
+		return big.NewFloat(1), time.Time{}, nil
+	}
+
+	pr, ok := results[symbol]
+	if !ok {
+		return nil, time.Time{}, fmt.Errorf("no price for %s", symbol)
+	}
+
+	px, ok := new(big.Int).SetString(pr.Px, 10)
+	if !ok {
+		return nil, time.Time{}, fmt.Errorf("failed to parse price %s of %s", pr.Px, symbol)
+	}
+
+	if px.Sign() <= 0 {
+		return nil, time.Time{}, fmt.Errorf("price of %s must be positive: %s", symbol, pr.Px)
+	}
+
+	multiplier, ok := new(big.Int).SetString(pr.Multiplier, 10)
+	if !ok || multiplier.Sign() <= 0 {
+		return nil, time.Time{}, fmt.Errorf("invalid multiplier %s of %s", pr.Multiplier, symbol)
+	}
+
+	resolveTime, err := strconv.ParseInt(pr.ResolveTime, 10, 64)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse resolve time %s of %s: %w", pr.ResolveTime, symbol, err)
+	}
+
+	price := new(big.Float).Quo(new(big.Float).SetInt(px), new(big.Float).SetInt(multiplier))
+	return price, time.Unix(resolveTime, 0).UTC(), nil
+}
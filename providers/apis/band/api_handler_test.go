@@ -0,0 +1,328 @@
+package band_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/band"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var (
+	btcusd = types.DefaultProviderTicker{
+		OffChainTicker: "BTC",
+	}
+	ethbtc = types.DefaultProviderTicker{
+		OffChainTicker: "ETH/BTC",
+	}
+	usdjpy = types.DefaultProviderTicker{
+		OffChainTicker: "USD/JPY",
+	}
+)
+
+func TestNewAPIHandler(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		_, err := band.NewAPIHandler(band.DefaultAPIConfig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		cfg := band.DefaultAPIConfig
+		cfg.Name = "invalid"
+		_, err := band.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("disabled api", func(t *testing.T) {
+		cfg := band.DefaultAPIConfig
+		cfg.Enabled = false
+		_, err := band.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestParsePair(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ticker      string
+		expected    band.Pair
+		expectedErr bool
+	}{
+		{
+			name:     "single symbol is quoted in usd",
+			ticker:   "btc",
+			expected: band.Pair{Base: "BTC", Quote: "USD"},
+		},
+		{
+			name:     "base and quote",
+			ticker:   "ETH/BTC",
+			expected: band.Pair{Base: "ETH", Quote: "BTC"},
+		},
+		{
+			name:        "missing quote",
+			ticker:      "ETH/",
+			expectedErr: true,
+		},
+		{
+			name:        "same base and quote",
+			ticker:      "USD/USD",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pair, err := band.ParsePair(tc.ticker)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, pair)
+		})
+	}
+}
+
+func TestCreateURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cps         []types.ProviderTicker
+		url         string
+		expectedErr bool
+	}{
+		{
+			name: "single valid symbol",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			url:         "https://laozi1.bandchain.org/api/oracle/v1/request_prices?ask_count=16&min_count=10&symbols=BTC",
+			expectedErr: false,
+		},
+		{
+			name: "symbols are requested once and usd is never requested",
+			cps: []types.ProviderTicker{
+				btcusd,
+				ethbtc,
+				usdjpy,
+			},
+			url:         "https://laozi1.bandchain.org/api/oracle/v1/request_prices?ask_count=16&min_count=10&symbols=BTC&symbols=ETH&symbols=JPY",
+			expectedErr: false,
+		},
+		{
+			name:        "no symbols",
+			cps:         []types.ProviderTicker{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := band.NewAPIHandler(band.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			url, err := h.CreateURL(tc.cps)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.url, url)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	resolveTime := time.Unix(1713295012, 0).UTC()
+
+	testCases := []struct {
+		name     string
+		cps      []types.ProviderTicker
+		response *http.Response
+		expected types.PriceResponse
+	}{
+		{
+			name: "single valid symbol",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(`
+{
+	"price_results": [
+		{
+			"symbol": "BTC",
+			"multiplier": "1000000000",
+			"px": "61033668797130",
+			"request_id": "20387163",
+			"resolve_time": "1713295012"
+		}
+	]
+}
+	`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusd: {
+						Value:     big.NewFloat(61033.66879713),
+						Timestamp: resolveTime,
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "cross and inverse pairs",
+			cps: []types.ProviderTicker{
+				ethbtc,
+				usdjpy,
+			},
+			response: testutils.CreateResponseFromJSON(`
+{
+	"price_results": [
+		{
+			"symbol": "BTC",
+			"multiplier": "1000000000",
+			"px": "60000000000000",
+			"request_id": "20387163",
+			"resolve_time": "1713295012"
+		},
+		{
+			"symbol": "ETH",
+			"multiplier": "1000000000",
+			"px": "3000000000000",
+			"request_id": "20387163",
+			"resolve_time": "1713295020"
+		},
+		{
+			"symbol": "JPY",
+			"multiplier": "1000000000",
+			"px": "6250000",
+			"request_id": "20387163",
+			"resolve_time": "1713295012"
+		}
+	]
+}
+	`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					ethbtc: {
+						Value:     big.NewFloat(0.05),
+						Timestamp: resolveTime,
+					},
+					usdjpy: {
+						Value:     big.NewFloat(160),
+						Timestamp: resolveTime,
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "missing quote symbol",
+			cps: []types.ProviderTicker{
+				btcusd,
+				ethbtc,
+			},
+			response: testutils.CreateResponseFromJSON(`
+{
+	"price_results": [
+		{
+			"symbol": "ETH",
+			"multiplier": "1000000000",
+			"px": "3000000000000",
+			"request_id": "20387163",
+			"resolve_time": "1713295012"
+		}
+	]
+}
+	`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+					ethbtc: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+				},
+			),
+		},
+		{
+			name: "non-positive price",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(`
+{
+	"price_results": [
+		{
+			"symbol": "BTC",
+			"multiplier": "1000000000",
+			"px": "0",
+			"request_id": "20387163",
+			"resolve_time": "1713295012"
+		}
+	]
+}
+	`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("price must be positive"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "bad response",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`
+shout out my label that's me
+	`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("json error"), providertypes.ErrorFailedToDecode),
+					},
+				},
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := band.NewAPIHandler(band.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			resp := h.ParseResponse(tc.cps, tc.response)
+
+			require.Len(t, resp.Resolved, len(tc.expected.Resolved))
+			require.Len(t, resp.UnResolved, len(tc.expected.UnResolved))
+
+			for cp, result := range tc.expected.Resolved {
+				require.Contains(t, resp.Resolved, cp)
+				r := resp.Resolved[cp]
+				require.Equal(t, result.Value.SetPrec(18), r.Value.SetPrec(18))
+				require.Equal(t, result.Timestamp, r.Timestamp)
+			}
+
+			for cp := range tc.expected.UnResolved {
+				require.Contains(t, resp.UnResolved, cp)
+				require.Error(t, resp.UnResolved[cp])
+			}
+		})
+	}
+}
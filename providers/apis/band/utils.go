@@ -0,0 +1,119 @@
+package band
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// NOTE: All documentation for this file can be located on the Band Protocol docs.
+// API documentation: https://docs.bandchain.org/develop/api-endpoints. The public
+// BandChain endpoints do not require an API key.
+
+const (
+	// Name is the name of the Band provider.
+	Name = "band_api"
+
+	// URL is the base URL of the BandChain REST API.
+	URL = "https://laozi1.bandchain.org/api"
+
+	// RequestPricesEndpoint is the endpoint used to fetch the latest prices of the standard
+	// dataset. Each symbol is appended as a `symbols` query parameter.
+	RequestPricesEndpoint = "/oracle/v1/request_prices?ask_count=%d&min_count=%d"
+
+	// SymbolQueryParam is the query parameter used to request a single symbol.
+	SymbolQueryParam = "&symbols=%s"
+
+	// DefaultAskCount is the number of validators asked to report on the prices of the
+	// standard dataset.
+	DefaultAskCount = 16
+
+	// DefaultMinCount is the minimum number of validators that must have reported on the
+	// prices of the standard dataset.
+	DefaultMinCount = 10
+
+	// QuoteSymbol is the symbol in which every price of the standard dataset is denominated.
+	QuoteSymbol = "USD"
+
+	// PairSeparator separates the base and quote symbols of an off-chain ticker.
+	PairSeparator = "/"
+)
+
+// DefaultAPIConfig is the default configuration for the BandChain API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           true,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         3000 * time.Millisecond, // The standard dataset is updated every few blocks.
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+// Pair is the base and quote symbol of an off-chain ticker.
+type Pair struct {
+	Base  string
+	Quote string
+}
+
+// ParsePair parses an off-chain ticker into the Band symbols of its base and quote. The ticker
+// is either a single symbol, which is quoted in USD, or a base and quote symbol separated by
+// PairSeparator, e.g. `ETH/BTC` or `USD/JPY`.
+func ParsePair(ticker string) (Pair, error) {
+	base, quote, found := strings.Cut(strings.ToUpper(ticker), PairSeparator)
+	if !found {
+		quote = QuoteSymbol
+	}
+
+	if base == "" || quote == "" || base == quote {
+		return Pair{}, fmt.Errorf("invalid off-chain ticker %s", ticker)
+	}
+
+	return Pair{Base: base, Quote: quote}, nil
+}
+
+// Symbols returns the symbols that must be requested to price the pair. USD is never
+// requested since every price of the standard dataset is denominated in it.
+func (p Pair) Symbols() []string {
+	var symbols []string
+	for _, symbol := range []string{p.Base, p.Quote} {
+		if symbol != QuoteSymbol {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	return symbols
+}
+
+type (
+	// RequestPricesResponse is the response returned by the request prices endpoint of the
+	// BandChain API. The response format looks like the following:
+	//
+	//	{
+	//		"price_results": [
+	//			{
+	//				"symbol": "BTC",
+	//				"multiplier": "1000000000",
+	//				"px": "61033668797130",
+	//				"request_id": "20387163",
+	//				"resolve_time": "1713295012"
+	//			}
+	//		]
+	//	}
+	RequestPricesResponse struct {
+		PriceResults []PriceResult `json:"price_results"`
+	}
+
+	// PriceResult is the latest price of a single symbol of the standard dataset. The price is
+	// px / multiplier, denominated in USD.
+	PriceResult struct {
+		Symbol      string `json:"symbol"`
+		Multiplier  string `json:"multiplier"`
+		Px          string `json:"px"`
+		RequestID   string `json:"request_id"`
+		ResolveTime string `json:"resolve_time"`
+	}
+)
@@ -0,0 +1,148 @@
+package bybit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
+
+// APIHandler implements the PriceAPIDataHandler interface for Bybit.
+// for more information about the Bybit API, refer to the following link:
+// https://bybit-exchange.github.io/docs/v5/market/tickers
+type APIHandler struct {
+	// api is the config for the Bybit API.
+	api config.APIConfig
+}
+
+// NewAPIHandler returns a new Bybit PriceAPIDataHandler.
+func NewAPIHandler(
+	api config.APIConfig,
+) (types.PriceAPIDataHandler, error) {
+	if api.Name != Name {
+		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", Name)
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config for %s: %w", Name, err)
+	}
+
+	return &APIHandler{
+		api: api,
+	}, nil
+}
+
+// CreateURL returns the URL that is used to fetch data from the Bybit API for the
+// given tickers. Since tickers of different categories cannot be queried together, this
+// function will return an error if the ticker slice contains more than one ticker.
+func (h *APIHandler) CreateURL(
+	tickers []types.ProviderTicker,
+) (string, error) {
+	if len(tickers) != 1 {
+		return "", fmt.Errorf("expected 1 ticker, got %d", len(tickers))
+	}
+
+	cfg, err := GetTickerConfig(tickers[0])
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(h.api.Endpoints[0].URL, cfg.GetCategory(), tickers[0].GetOffChainTicker()), nil
+}
+
+// ParseResponse parses the ticker HTTP response from the Bybit API and returns the
+// resulting price. Note that this can only parse a single ticker at a time.
+func (h *APIHandler) ParseResponse(
+	tickers []types.ProviderTicker,
+	resp *http.Response,
+) types.PriceResponse {
+	if len(tickers) != 1 {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(
+				fmt.Errorf("expected 1 ticker, got %d", len(tickers)),
+				providertypes.ErrorInvalidResponse,
+			),
+		)
+	}
+
+	result, err := Decode(resp)
+	if err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+		)
+	}
+
+	if result.RetCode != SuccessCode {
+		err := fmt.Errorf("bybit API call error: code %d: %s", result.RetCode, result.RetMsg)
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+		)
+	}
+
+	ticker := tickers[0]
+	cfg, err := GetTickerConfig(ticker)
+	if err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+		)
+	}
+
+	for _, data := range result.Result.List {
+		if data.Symbol != ticker.GetOffChainTicker() {
+			continue
+		}
+
+		value := data.Price(cfg.PriceType)
+		price, err := math.Float64StringToBigFloat(value)
+		if err != nil {
+			wErr := fmt.Errorf("failed to convert price %s to big.Float: %w", value, err)
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(wErr, providertypes.ErrorFailedToParsePrice),
+			)
+		}
+
+		return types.NewPriceResponse(
+			types.ResolvedPrices{
+				ticker: types.NewPriceResult(price, time.Now().UTC()),
+			},
+			nil,
+		)
+	}
+
+	return types.NewPriceResponseWithErr(
+		tickers,
+		providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+	)
+}
+
+// GetTickerConfig returns the ticker configuration stored in the metadata of the ticker.
+func GetTickerConfig(ticker types.ProviderTicker) (TickerConfig, error) {
+	var cfg TickerConfig
+	if metadata := ticker.GetJSON(); len(metadata) > 0 {
+		if err := json.Unmarshal([]byte(metadata), &cfg); err != nil {
+			return cfg, fmt.Errorf("failed to unmarshal ticker config on ticker: %w", err)
+		}
+	}
+
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid ticker config: %w", err)
+	}
+
+	return cfg, nil
+}
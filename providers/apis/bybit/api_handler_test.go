@@ -0,0 +1,262 @@
+package bybit_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/bybit"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var (
+	btcusdt = types.DefaultProviderTicker{
+		OffChainTicker: "BTCUSDT",
+	}
+	btcusdtPerpIndex = types.DefaultProviderTicker{
+		OffChainTicker: "BTCUSDT",
+		JSON: bybit.TickerConfig{
+			Category:  bybit.CategoryLinear,
+			PriceType: bybit.PriceTypeIndex,
+		}.MustToJSON(),
+	}
+	btcusdtPerpMark = types.DefaultProviderTicker{
+		OffChainTicker: "BTCUSDT",
+		JSON: bybit.TickerConfig{
+			Category:  bybit.CategoryLinear,
+			PriceType: bybit.PriceTypeMark,
+		}.MustToJSON(),
+	}
+	spotIndex = types.DefaultProviderTicker{
+		OffChainTicker: "BTCUSDT",
+		JSON: bybit.TickerConfig{
+			PriceType: bybit.PriceTypeIndex,
+		}.MustToJSON(),
+	}
+	ethusdt = types.DefaultProviderTicker{
+		OffChainTicker: "ETHUSDT",
+	}
+
+	linearResponse = `{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[{"symbol":"BTCUSDT","lastPrice":"64587.40","indexPrice":"64590.12","markPrice":"64588.00"}]},"time":1672376496682}`
+)
+
+func TestCreateURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cps         []types.ProviderTicker
+		url         string
+		expectedErr bool
+	}{
+		{
+			name:        "empty",
+			cps:         []types.ProviderTicker{},
+			url:         "",
+			expectedErr: true,
+		},
+		{
+			name: "valid spot",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			url:         "https://api.bybit.com/v5/market/tickers?category=spot&symbol=BTCUSDT",
+			expectedErr: false,
+		},
+		{
+			name: "valid linear",
+			cps: []types.ProviderTicker{
+				btcusdtPerpIndex,
+			},
+			url:         "https://api.bybit.com/v5/market/tickers?category=linear&symbol=BTCUSDT",
+			expectedErr: false,
+		},
+		{
+			name: "index price of a spot market",
+			cps: []types.ProviderTicker{
+				spotIndex,
+			},
+			url:         "",
+			expectedErr: true,
+		},
+		{
+			name: "multiple tickers",
+			cps: []types.ProviderTicker{
+				btcusdt,
+				ethusdt,
+			},
+			url:         "",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := bybit.NewAPIHandler(bybit.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			url, err := h.CreateURL(tc.cps)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.url, url)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cps      []types.ProviderTicker
+		response *http.Response
+		expected types.PriceResponse
+	}{
+		{
+			name: "valid spot",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"symbol":"BTCUSDT","lastPrice":"64587.40"}]},"time":1672376496682}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusdt: {
+						Value: big.NewFloat(64587.4),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "valid linear index price",
+			cps: []types.ProviderTicker{
+				btcusdtPerpIndex,
+			},
+			response: testutils.CreateResponseFromJSON(linearResponse),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusdtPerpIndex: {
+						Value: big.NewFloat(64590.12),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "valid linear mark price",
+			cps: []types.ProviderTicker{
+				btcusdtPerpMark,
+			},
+			response: testutils.CreateResponseFromJSON(linearResponse),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusdtPerpMark: {
+						Value: big.NewFloat(64588),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "bad response",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`shout out my label that's me`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("bad response"), providertypes.ErrorFailedToDecode),
+					},
+				},
+			),
+		},
+		{
+			name: "error code",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"retCode":10001,"retMsg":"Not supported symbols","result":{},"time":1672376496682}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("not supported"), providertypes.ErrorInvalidResponse),
+					},
+				},
+			),
+		},
+		{
+			name: "bad price response",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"symbol":"BTCUSDT","lastPrice":"$64587.40"}]},"time":1672376496682}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("invalid syntax"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "no response",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[]},"time":1672376496682}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+				},
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := bybit.NewAPIHandler(bybit.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			now := time.Now()
+			resp := h.ParseResponse(tc.cps, tc.response)
+
+			require.Len(t, resp.Resolved, len(tc.expected.Resolved))
+			require.Len(t, resp.UnResolved, len(tc.expected.UnResolved))
+
+			for cp, result := range tc.expected.Resolved {
+				require.Contains(t, resp.Resolved, cp)
+				r := resp.Resolved[cp]
+				require.Equal(t, result.Value.SetPrec(18), r.Value.SetPrec(18))
+				require.True(t, r.Timestamp.After(now))
+			}
+
+			for cp, result := range tc.expected.UnResolved {
+				require.Contains(t, resp.UnResolved, cp)
+				require.Error(t, resp.UnResolved[cp])
+				require.Equal(t, result.Code(), resp.UnResolved[cp].Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package bybit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// NOTE: All documentation for this file can be located on the Bybit docs.
+// API documentation: https://bybit-exchange.github.io/docs/v5/market/tickers. This
+// API does not require a subscription to use (i.e. No API key is required).
+
+const (
+	// Name is the name of the Bybit API provider.
+	Name = "bybit_api"
+
+	// URL is the base URL of the Bybit API. This includes the category and the symbol of
+	// the ticker that need to be inserted into the URL.
+	URL = "https://api.bybit.com/v5/market/tickers?category=%s&symbol=%s"
+
+	// SuccessCode is the code returned by the Bybit API for a successful request.
+	SuccessCode = 0
+)
+
+// Category is the product category of a Bybit market.
+type Category string
+
+const (
+	// CategorySpot is the category of spot markets.
+	CategorySpot Category = "spot"
+	// CategoryLinear is the category of USDT and USDC margined perpetual and futures markets.
+	CategoryLinear Category = "linear"
+	// CategoryInverse is the category of coin margined perpetual and futures markets.
+	CategoryInverse Category = "inverse"
+)
+
+// PriceType is the price of a Bybit ticker that is reported.
+type PriceType string
+
+const (
+	// PriceTypeLast is the last traded price of the market.
+	PriceTypeLast PriceType = "last"
+	// PriceTypeIndex is the index price of a derivatives market.
+	PriceTypeIndex PriceType = "index"
+	// PriceTypeMark is the mark price of a derivatives market.
+	PriceTypeMark PriceType = "mark"
+)
+
+// DefaultAPIConfig is the default configuration for the Bybit API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           false,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         750 * time.Millisecond,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+// TickerConfig is the optional metadata that can be set on each ticker. The off-chain ticker
+// of each market is the Bybit symbol, e.g. BTCUSDT.
+type TickerConfig struct {
+	// Category is the product category of the market. If unset, the spot market is used.
+	Category Category `json:"category,omitempty"`
+	// PriceType is the price that is reported for the market. If unset, the last traded price
+	// is used. Index and mark prices are only available for the linear and inverse categories.
+	PriceType PriceType `json:"price_type,omitempty"`
+}
+
+// ValidateBasic validates the ticker configuration.
+func (tc *TickerConfig) ValidateBasic() error {
+	switch tc.Category {
+	case "", CategorySpot:
+		if tc.PriceType != "" && tc.PriceType != PriceTypeLast {
+			return fmt.Errorf("price type %s is not available for spot markets", tc.PriceType)
+		}
+	case CategoryLinear, CategoryInverse:
+		switch tc.PriceType {
+		case "", PriceTypeLast, PriceTypeIndex, PriceTypeMark:
+		default:
+			return fmt.Errorf("invalid price type %s", tc.PriceType)
+		}
+	default:
+		return fmt.Errorf("invalid category %s", tc.Category)
+	}
+
+	return nil
+}
+
+// GetCategory returns the category of the market, defaulting to spot.
+func (tc *TickerConfig) GetCategory() Category {
+	if tc.Category == "" {
+		return CategorySpot
+	}
+	return tc.Category
+}
+
+// MustToJSON converts the ticker configuration to JSON.
+func (tc TickerConfig) MustToJSON() string {
+	b, err := json.Marshal(tc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+type (
+	// Response is the expected response returned by the Bybit API.
+	// The response is json formatted.
+	// Response format:
+	//
+	//	{
+	//	  "retCode": 0,
+	//	  "retMsg": "OK",
+	//	  "result": {
+	//	    "category": "linear",
+	//	    "list": [
+	//	      {
+	//	        "symbol": "BTCUSDT",
+	//	        "lastPrice": "64587.40",
+	//	        "indexPrice": "64590.12",
+	//	        "markPrice": "64588.00"
+	//	      }
+	//	    ]
+	//	  },
+	//	  "time": 1672376496682
+	//	}
+	Response struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+		Result  Result `json:"result"`
+	}
+
+	// Result is the result of a Bybit tickers request.
+	Result struct {
+		Category string   `json:"category"`
+		List     []Ticker `json:"list"`
+	}
+
+	// Ticker is the ticker data of a single market returned by the Bybit API.
+	Ticker struct {
+		Symbol     string `json:"symbol"`
+		LastPrice  string `json:"lastPrice"`
+		IndexPrice string `json:"indexPrice"`
+		MarkPrice  string `json:"markPrice"`
+	}
+)
+
+// Price returns the price of the given type.
+func (t Ticker) Price(priceType PriceType) string {
+	switch priceType {
+	case PriceTypeIndex:
+		return t.IndexPrice
+	case PriceTypeMark:
+		return t.MarkPrice
+	default:
+		return t.LastPrice
+	}
+}
+
+// Decode decodes the given http response into a Response.
+func Decode(resp *http.Response) (Response, error) {
+	var result Response
+	err := json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
 	"github.com/skip-mev/connect/v2/providers/apis/coingecko"
 	"github.com/skip-mev/connect/v2/providers/base/testutils"
@@ -264,3 +265,49 @@ shout out my label that's me
 		})
 	}
 }
+
+func TestGetTier(t *testing.T) {
+	testCases := []struct {
+		name     string
+		endpoint config.Endpoint
+		expected coingecko.Tier
+	}{
+		{
+			name:     "public",
+			endpoint: config.Endpoint{URL: coingecko.URL},
+			expected: coingecko.TierPublic,
+		},
+		{
+			name: "demo",
+			endpoint: config.Endpoint{
+				URL: coingecko.URL,
+				Authentication: config.Authentication{
+					APIKey:       "key",
+					APIKeyHeader: coingecko.DemoAPIKeyHeader,
+				},
+			},
+			expected: coingecko.TierDemo,
+		},
+		{
+			name: "pro",
+			endpoint: config.Endpoint{
+				URL: coingecko.APIURL,
+				Authentication: config.Authentication{
+					APIKey:       "key",
+					APIKeyHeader: coingecko.APIKeyHeader,
+				},
+			},
+			expected: coingecko.TierPro,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, coingecko.GetTier(tc.endpoint))
+
+			limiter := coingecko.NewRateLimiter(tc.endpoint)
+			require.Equal(t, 1, limiter.Burst())
+			require.InDelta(t, float64(coingecko.RequestsPerMinute[tc.expected])/60, float64(limiter.Limit()), 1e-9)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
 )
@@ -28,6 +30,10 @@ const (
 	// APIKeyHeader is the header used to pass the API key to the CoinGecko API.
 	APIKeyHeader = "x-cg-pro-api-key" //nolint
 
+	// DemoAPIKeyHeader is the header used to pass a demo (free) API key to the CoinGecko
+	// API. Demo keys are used with the public URL.
+	DemoAPIKeyHeader = "x-cg-demo-api-key" //nolint
+
 	// PairPriceEndpoint is the URL used to fetch the price of a list of currency
 	// pairs. The ids are the base currencies and the vs_currencies are the quote
 	// currencies. Note that the IDs and vs_currencies are comma separated but are
@@ -57,6 +63,46 @@ var DefaultAPIConfig = config.APIConfig{
 	Endpoints:        []config.Endpoint{{URL: URL}},
 }
 
+// Tier is the CoinGecko API plan that is used. Each tier has its own published rate limit.
+type Tier string
+
+const (
+	// TierPublic is the tier of requests made to the public URL without an API key.
+	TierPublic Tier = "public"
+	// TierDemo is the tier of requests made to the public URL with a demo API key.
+	TierDemo Tier = "demo"
+	// TierPro is the tier of requests made to the pro URL with a paid API key.
+	TierPro Tier = "pro"
+)
+
+// RequestsPerMinute are the published rate limits of each tier. The public limit varies
+// between 5 and 15 requests per minute depending on global usage, so the lower bound is used.
+var RequestsPerMinute = map[Tier]int{
+	TierPublic: 5,
+	TierDemo:   30,
+	TierPro:    500,
+}
+
+// GetTier returns the tier of the given endpoint, based on its API key header.
+func GetTier(endpoint config.Endpoint) Tier {
+	if !endpoint.Authentication.Enabled() {
+		return TierPublic
+	}
+
+	if strings.EqualFold(endpoint.Authentication.APIKeyHeader, DemoAPIKeyHeader) {
+		return TierDemo
+	}
+
+	return TierPro
+}
+
+// NewRateLimiter returns a client-side rate limiter that respects the published rate limit of
+// the tier of the given endpoint.
+func NewRateLimiter(endpoint config.Endpoint) *rate.Limiter {
+	rpm := RequestsPerMinute[GetTier(endpoint)]
+	return rate.NewLimiter(rate.Every(time.Minute/time.Duration(rpm)), 1)
+}
+
 type (
 	// CoinGeckoResponse is the response returned by the CoinGecko API. The response
 	// format looks like the following:
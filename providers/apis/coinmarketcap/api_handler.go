@@ -12,6 +12,7 @@ import (
 
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
 )
 
 var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
@@ -24,11 +25,15 @@ type APIHandler struct {
 	api config.APIConfig
 	// cache maintains the latest set of tickers seen by the handler.
 	cache types.ProviderTickers
+	// metrics is used to track the API credits consumed by the handler.
+	metrics metrics.APIMetrics
 }
 
-// NewAPIHandler returns a new CoinMarketCap PriceAPIDataHandler.
+// NewAPIHandler returns a new CoinMarketCap PriceAPIDataHandler. The API credits consumed
+// by each request are reported to the given metrics.
 func NewAPIHandler(
 	api config.APIConfig,
+	apiMetrics metrics.APIMetrics,
 ) (types.PriceAPIDataHandler, error) {
 	if api.Name != Name {
 		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
@@ -42,9 +47,14 @@ func NewAPIHandler(
 		return nil, fmt.Errorf("invalid api config for %s: %w", Name, err)
 	}
 
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("metrics cannot be nil")
+	}
+
 	return &APIHandler{
-		api:   api,
-		cache: types.NewProviderTickers(),
+		api:     api,
+		cache:   types.NewProviderTickers(),
+		metrics: apiMetrics,
 	}, nil
 }
 
@@ -82,6 +92,11 @@ func (h *APIHandler) ParseResponse(
 		)
 	}
 
+	// Requests consume credits of the API plan even if they fail.
+	if result.Status.CreditCount > 0 {
+		h.metrics.AddAPICredits(Name, result.Status.CreditCount)
+	}
+
 	if result.Status.ErrorCode != 0 {
 		return types.NewPriceResponseWithErr(
 			tickers,
@@ -10,6 +10,8 @@ import (
 
 	"github.com/skip-mev/connect/v2/oracle/types"
 	"github.com/skip-mev/connect/v2/providers/apis/coinmarketcap"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	metricmocks "github.com/skip-mev/connect/v2/providers/base/api/metrics/mocks"
 	"github.com/skip-mev/connect/v2/providers/base/testutils"
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
@@ -57,7 +59,7 @@ func TestCreateURL(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			h, err := coinmarketcap.NewAPIHandler(coinmarketcap.DefaultAPIConfig)
+			h, err := coinmarketcap.NewAPIHandler(coinmarketcap.DefaultAPIConfig, metrics.NewNopAPIMetrics())
 			require.NoError(t, err)
 
 			url, err := h.CreateURL(tc.tickers)
@@ -294,7 +296,7 @@ func TestParseResponse(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			h, err := coinmarketcap.NewAPIHandler(coinmarketcap.DefaultAPIConfig)
+			h, err := coinmarketcap.NewAPIHandler(coinmarketcap.DefaultAPIConfig, metrics.NewNopAPIMetrics())
 			require.NoError(t, err)
 
 			// Update the cache since it is assumed that createURL is executed before ParseResponse.
@@ -321,3 +323,49 @@ func TestParseResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestCreditUsage(t *testing.T) {
+	t.Run("nil metrics", func(t *testing.T) {
+		_, err := coinmarketcap.NewAPIHandler(coinmarketcap.DefaultAPIConfig, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("credits are reported for successful and failed requests", func(t *testing.T) {
+		m := metricmocks.NewAPIMetrics(t)
+		m.EXPECT().AddAPICredits(coinmarketcap.Name, 2).Once()
+		m.EXPECT().AddAPICredits(coinmarketcap.Name, 1).Once()
+
+		h, err := coinmarketcap.NewAPIHandler(coinmarketcap.DefaultAPIConfig, m)
+		require.NoError(t, err)
+
+		tickers := []types.ProviderTicker{btcusd}
+		_, err = h.CreateURL(tickers)
+		require.NoError(t, err)
+
+		resp := h.ParseResponse(tickers, testutils.CreateResponseFromJSON(
+			`{"data":{"1":{"id":1,"quote":{"USD":{"price":6602.60701122}}}},"status":{"error_code":0,"credit_count":2}}`,
+		))
+		require.Contains(t, resp.Resolved, btcusd)
+
+		resp = h.ParseResponse(tickers, testutils.CreateResponseFromJSON(
+			`{"status":{"error_code":1008,"error_message":"rate limit","credit_count":1}}`,
+		))
+		require.Contains(t, resp.UnResolved, btcusd)
+	})
+
+	t.Run("no credits are reported if the count is missing", func(t *testing.T) {
+		m := metricmocks.NewAPIMetrics(t)
+
+		h, err := coinmarketcap.NewAPIHandler(coinmarketcap.DefaultAPIConfig, m)
+		require.NoError(t, err)
+
+		tickers := []types.ProviderTicker{btcusd}
+		_, err = h.CreateURL(tickers)
+		require.NoError(t, err)
+
+		resp := h.ParseResponse(tickers, testutils.CreateResponseFromJSON(
+			`{"data":{"1":{"id":1,"quote":{"USD":{"price":6602.60701122}}}},"status":{"error_code":0}}`,
+		))
+		require.Contains(t, resp.Resolved, btcusd)
+	})
+}
@@ -122,4 +122,6 @@ type CoinMarketCapQuote struct { //nolint
 type CoinMarketCapStatus struct { //nolint
 	ErrorCode    int64  `json:"error_code"`
 	ErrorMessage string `json:"error_message"`
+	// CreditCount is the number of API credits consumed by the request.
+	CreditCount int `json:"credit_count"`
 }
@@ -0,0 +1,370 @@
+package api3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// PriceFetcher is the API3 price fetcher. This fetcher is responsible for querying API3 dAPI
+// proxy contracts and returning the price of a given ticker. The price is derived from the value
+// returned by read() on the proxy, which always has 18 decimals.
+//
+// To read more about dAPIs, see the API3 documentation https://docs.api3.org/dapis.
+//
+// Similar to the Chainlink fetcher, we utilize the eth client's BatchCallContext to batch the calls
+// to the ethereum network.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the EVM client implementation. This is used to interact with the ethereum network.
+	client ethmulticlient.EVMClient
+	// abi is the dAPI proxy abi. This is used to pack the read call to the proxy contract and
+	// parse the result.
+	abi abi.ABI
+	// payload is the packed read call to the proxy contract. Since the payload is the same for all
+	// proxies, we can reuse this payload for all feeds.
+	payload []byte
+
+	mtx sync.Mutex
+	// feedCache is a cache of the tickers to feed configs. This is used to avoid unmarshalling
+	// the metadata for each ticker.
+	feedCache map[types.ProviderTicker]FeedConfig
+}
+
+// NewPriceFetcher returns a new API3 price fetcher.
+func NewPriceFetcher(
+	ctx context.Context,
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("api metrics is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if !IsValidProviderName(api.Name) {
+		return nil, fmt.Errorf("invalid api config name %s", api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	client, err := ethmulticlient.NewClientFromEndpoints(
+		ctx,
+		logger,
+		api,
+		apiMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client ethmulticlient.EVMClient,
+) (*PriceFetcher, error) {
+	proxyABI, err := abi.JSON(strings.NewReader(ProxyABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proxy abi: %w", err)
+	}
+
+	payload, err := proxyABI.Pack(ContractMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack read: %w", err)
+	}
+
+	return &PriceFetcher{
+		logger:    logger.With(zap.String("fetcher", api.Name)),
+		api:       api,
+		client:    client,
+		abi:       proxyABI,
+		payload:   payload,
+		feedCache: make(map[types.ProviderTicker]FeedConfig),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. This fetch utilizes the batch call to lower
+// overhead of making individual RPC calls for each ticker. The fetcher will read the value of
+// each dAPI proxy, validate that the value is positive and not stale, and scale it by the 18
+// decimals of the dAPI.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+	)
+
+	// Create a batch element for each ticker and feed.
+	batchElems := make([]rpc.BatchElem, len(tickers))
+	feeds := make([]FeedConfig, len(tickers))
+	for i, ticker := range tickers {
+		feed, err := f.GetFeed(ticker)
+		if err != nil {
+			f.logger.Debug(
+				"failed to get feed for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to get feed: %w", err),
+					providertypes.ErrorFailedToDecode,
+				),
+			)
+		}
+
+		// Create a batch element for the ticker and feed.
+		var result string
+		batchElems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   common.HexToAddress(feed.Address),
+					"data": hexutil.Bytes(f.payload), // read call to the proxy contract.
+				},
+				f.api.GetBlockTag(), // the configured block tag, latest by default.
+			},
+			Result: &result,
+		}
+		feeds[i] = feed
+	}
+
+	// Batch call to the EVM.
+	block, err := ethmulticlient.BatchCallWithBlock(ctx, f.client, f.api.GetBlockTag(), batchElems)
+	if err != nil {
+		f.logger.Debug(
+			"failed to batch call to ethereum network for all tickers",
+			zap.Error(err),
+		)
+
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+		)
+	}
+
+	// Parse the result from the batch call for each ticker.
+	now := time.Now().UTC()
+	for i, ticker := range tickers {
+		result := batchElems[i]
+		if result.Error != nil {
+			f.logger.Debug(
+				"failed to batch call to ethereum network for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(result.Error),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					result.Error,
+					ethmulticlient.ErrorCodeFromError(result.Error, providertypes.ErrorUnknown),
+				),
+			}
+
+			continue
+		}
+
+		// Parse the dAPI value from the result.
+		value, err := f.ParseDataFeed(result.Result)
+		if err != nil {
+			f.logger.Debug(
+				"failed to parse dapi value",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorFailedToParsePrice,
+				),
+			}
+
+			continue
+		}
+
+		// Ensure that the value is positive and recent enough to be used.
+		if err := value.ValidateBasic(now, feeds[i].GetMaxAge()); err != nil {
+			f.logger.Debug(
+				"invalid dapi value",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorCodeFromError(err, providertypes.ErrorInvalidResponse),
+				),
+			}
+
+			continue
+		}
+
+		price, err := ScalePrice(value.Value)
+		if err != nil {
+			f.logger.Debug(
+				"failed to scale dapi value",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorUnknown,
+				),
+			}
+
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResultWithBlock(price, now, block)
+	}
+
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// GetFeed returns the dAPI feed for the given ticker. This will unmarshal the metadata and
+// validate the feed config which contains all required information to query the EVM.
+func (f *PriceFetcher) GetFeed(
+	ticker types.ProviderTicker,
+) (FeedConfig, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if feed, ok := f.feedCache[ticker]; ok {
+		return feed, nil
+	}
+
+	var cfg FeedConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal feed config on ticker: %w", err)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid ticker feed config: %w", err)
+	}
+
+	f.feedCache[ticker] = cfg
+	return cfg, nil
+}
+
+// ParseDataFeed parses the value and timestamp of a dAPI from the result of the batch call.
+func (f *PriceFetcher) ParseDataFeed(
+	result interface{},
+) (DataFeed, error) {
+	r, ok := result.(*string)
+	if !ok {
+		return DataFeed{}, fmt.Errorf("expected result to be a string, got %T", result)
+	}
+
+	if r == nil {
+		return DataFeed{}, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return DataFeed{}, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := f.abi.Methods[ContractMethod].Outputs.UnpackValues(bz)
+	if err != nil {
+		return DataFeed{}, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	return DataFeed{
+		Value:     *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		Timestamp: *abi.ConvertType(out[1], new(uint32)).(*uint32),
+	}, nil
+}
+
+// DataFeed is the result of the read call to a dAPI proxy contract.
+type DataFeed struct {
+	// Value is the unscaled value of the dAPI.
+	Value *big.Int
+	// Timestamp is the unix timestamp at which the value was last updated.
+	Timestamp uint32
+}
+
+// ValidateBasic ensures that the value is positive, has been initialized and is no older than
+// the given max age. Stale values return an error with the ErrorStalePrice code.
+func (df DataFeed) ValidateBasic(now time.Time, maxAge time.Duration) error {
+	if df.Value == nil || df.Value.Sign() <= 0 {
+		return fmt.Errorf("value must be positive")
+	}
+
+	if df.Timestamp == 0 {
+		return fmt.Errorf("dapi has not been initialized")
+	}
+
+	updatedAt := time.Unix(int64(df.Timestamp), 0)
+	if age := now.Sub(updatedAt); age > maxAge {
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("value updated at %s is older than max age %s", updatedAt.UTC(), maxAge),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	return nil
+}
+
+// ScalePrice scales the value of a dAPI by its 18 decimals.
+func ScalePrice(
+	value *big.Int,
+) (*big.Float, error) {
+	price, err := math.NewPrice(value, -Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
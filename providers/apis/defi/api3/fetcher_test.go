@@ -0,0 +1,331 @@
+package api3_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	oracleconfig "github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/api3"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+var (
+	logger, _ = zap.NewDevelopment()
+
+	// FeedConfigs used for testing.
+	ethusdCfg = api3.FeedConfig{
+		Address: "0x5b0cf2b36a65a6BB085D501B971e4c102B9Cd473",
+		MaxAge:  3600,
+	}
+
+	// Tickers used for testing.
+	ethusdTicker = types.NewProviderTicker("ETH/USD", ethusdCfg.MustToJSON())
+)
+
+func TestFetch(t *testing.T) {
+	now := time.Now().Unix()
+
+	testCases := []struct {
+		name     string
+		tickers  []types.ProviderTicker
+		client   func() ethmulticlient.EVMClient
+		expected types.PriceResponse
+	}{
+		{
+			name: "fails to retrieve feed for an empty ticker",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("ETH/USD", ""),
+			},
+			client: func() ethmulticlient.EVMClient {
+				return mocks.NewEVMClient(t)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					types.NewProviderTicker("ETH/USD", ""): {},
+				},
+			},
+		},
+		{
+			name: "fails to make a batch call",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, fmt.Errorf("failed to make a batch call"), nil, nil)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					ethusdTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request has an error for a single ticker",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{""}, []error{fmt.Errorf("execution reverted")})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					ethusdTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request returns a result that cannot be parsed",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{"not a valid result"}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					ethusdTicker: {},
+				},
+			},
+		},
+		{
+			name: "value is stale",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				response := encodeDataFeed(t, "2500000000000000000000", now-7200)
+				return createEVMClientWithResponse(t, nil, []string{response}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					ethusdTicker: {},
+				},
+			},
+		},
+		{
+			name: "eth/usd mainnet result",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				response := encodeDataFeed(t, "2500123456780000000000", now-60)
+				return createEVMClientWithResponse(t, nil, []string{response}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					ethusdTicker: {
+						Value: big.NewFloat(2500.12345678),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := api3.NewPriceFetcherWithClient(logger, api3.DefaultETHAPIConfig, tc.client())
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Equal(t, len(tc.expected.Resolved), len(response.Resolved))
+			require.Equal(t, len(tc.expected.UnResolved), len(response.UnResolved))
+
+			for ticker, result := range tc.expected.Resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, result.Value.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+			}
+
+			for ticker := range tc.expected.UnResolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+}
+
+func TestFetchAtBlockTag(t *testing.T) {
+	response := encodeDataFeed(t, "2500123456780000000000", time.Now().Unix()-60)
+
+	client := mocks.NewEVMClient(t)
+	client.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+		require.Len(t, elems, 2)
+		require.Equal(t, oracleconfig.BlockTagSafe, elems[0].Args[1])
+
+		// The block is read at the same block tag as the call.
+		require.Equal(t, "eth_getBlockByNumber", elems[1].Method)
+		require.Equal(t, []interface{}{oracleconfig.BlockTagSafe, false}, elems[1].Args)
+		*elems[1].Result.(*ethmulticlient.BlockHeader) = testBlock
+
+		elems[0].Result = &response
+	})
+
+	api := api3.DefaultETHAPIConfig
+	api.BlockTag = oracleconfig.BlockTagSafe
+
+	fetcher, err := api3.NewPriceFetcherWithClient(logger, api, client)
+	require.NoError(t, err)
+
+	resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{ethusdTicker})
+	require.Len(t, resp.Resolved, 1)
+	for _, result := range resp.Resolved {
+		require.Equal(t, testBlock.Info(), result.Block)
+	}
+}
+
+func TestDataFeedValidateBasic(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name   string
+		feed   api3.DataFeed
+		maxAge time.Duration
+		err    bool
+		stale  bool
+	}{
+		{
+			name: "valid value",
+			feed: api3.DataFeed{
+				Value:     big.NewInt(100),
+				Timestamp: uint32(now.Unix()),
+			},
+			maxAge: time.Hour,
+			err:    false,
+		},
+		{
+			name: "non-positive value",
+			feed: api3.DataFeed{
+				Value:     big.NewInt(-1),
+				Timestamp: uint32(now.Unix()),
+			},
+			maxAge: time.Hour,
+			err:    true,
+		},
+		{
+			name: "uninitialized dapi",
+			feed: api3.DataFeed{
+				Value:     big.NewInt(100),
+				Timestamp: 0,
+			},
+			maxAge: time.Hour,
+			err:    true,
+		},
+		{
+			name: "older than max age",
+			feed: api3.DataFeed{
+				Value:     big.NewInt(100),
+				Timestamp: uint32(now.Add(-2 * time.Hour).Unix()),
+			},
+			maxAge: time.Hour,
+			err:    true,
+			stale:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.feed.ValidateBasic(now, tc.maxAge)
+			if tc.err {
+				require.Error(t, err)
+				require.Equal(t, tc.stale, providertypes.ErrorCodeFromError(err, providertypes.ErrorInvalidResponse) == providertypes.ErrorStalePrice)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFeedConfigValidateBasic(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		require.NoError(t, ethusdCfg.ValidateBasic())
+		require.Equal(t, time.Hour, ethusdCfg.GetMaxAge())
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		cfg := api3.FeedConfig{Address: "0x1234"}
+		require.Error(t, cfg.ValidateBasic())
+	})
+
+	t.Run("negative max age", func(t *testing.T) {
+		cfg := api3.FeedConfig{Address: ethusdCfg.Address, MaxAge: -1}
+		require.Error(t, cfg.ValidateBasic())
+	})
+
+	t.Run("unset max age uses the default", func(t *testing.T) {
+		cfg := api3.FeedConfig{Address: ethusdCfg.Address}
+		require.NoError(t, cfg.ValidateBasic())
+		require.Equal(t, api3.DefaultMaxAge, cfg.GetMaxAge())
+	})
+}
+
+func encodeDataFeed(t *testing.T, value string, timestamp int64) string {
+	t.Helper()
+
+	proxyABI, err := abi.JSON(strings.NewReader(api3.ProxyABI))
+	require.NoError(t, err)
+
+	v, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok)
+
+	bz, err := proxyABI.Methods[api3.ContractMethod].Outputs.Pack(v, uint32(timestamp))
+	require.NoError(t, err)
+
+	return hexutil.Encode(bz)
+}
+
+func createEVMClientWithResponse(
+	t *testing.T,
+	failedRequestErr error,
+	responses []string,
+	errs []error,
+) ethmulticlient.EVMClient {
+	t.Helper()
+
+	c := mocks.NewEVMClient(t)
+	if failedRequestErr != nil {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(failedRequestErr)
+	} else {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			elems, ok := args.Get(1).([]rpc.BatchElem)
+			require.True(t, ok)
+			// The last call reads the block at which the other calls are read, if any.
+			if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+				*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+				elems = elems[:n-1]
+			}
+			require.Equal(t, len(elems), len(responses))
+			require.Equal(t, len(elems), len(errs))
+
+			for i, elem := range elems {
+				elem.Result = &responses[i]
+				elem.Error = errs[i]
+				elems[i] = elem
+			}
+		})
+	}
+
+	return c
+}
@@ -0,0 +1,128 @@
+package api3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+const (
+	// BaseName is the name of the API3 API.
+	BaseName = "api3_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// ContractMethod is the contract method to call on the dAPI proxy.
+	ContractMethod = "read"
+
+	// ProxyABI is the ABI of the read method of the API3 dAPI proxy (IProxy) contract.
+	ProxyABI = `[{"inputs":[],"name":"read","outputs":[{"internalType":"int224","name":"value","type":"int224"},{"internalType":"uint32","name":"timestamp","type":"uint32"}],"stateMutability":"view","type":"function"}]`
+
+	// Decimals is the number of decimals of every dAPI value.
+	Decimals = 18
+
+	// ETH_URL is the URL for the API3 API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+
+	// BASE_URL is the URL for the API3 API. This uses a free public RPC provider on Base Mainnet.
+	BASE_URL = "https://mainnet.base.org"
+
+	// DefaultMaxAge is the default maximum age of a dAPI's value. dAPIs are updated at least once
+	// every 24 hours, so a value older than this (with some leeway for the update to land
+	// on-chain) is considered stale.
+	DefaultMaxAge = 25 * time.Hour
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+	constants.BASE:     strings.Join([]string{BaseName, constants.BASE}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedConfig is the configuration for an API3 dAPI. This is specific to each pair of tokens.
+type FeedConfig struct {
+	// Address is the address of the dAPI proxy.
+	Address string `json:"address" schema:"address"`
+	// MaxAge is the maximum age, in seconds, of the dAPI's value. This should be set to the
+	// heartbeat of the dAPI. If unset, DefaultMaxAge is used.
+	MaxAge int64 `json:"max_age"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if !common.IsHexAddress(fc.Address) {
+		return fmt.Errorf("proxy address is not a valid ethereum address")
+	}
+
+	if fc.MaxAge < 0 {
+		return fmt.Errorf("max age must be non-negative")
+	}
+
+	return nil
+}
+
+// GetMaxAge returns the maximum age of the dAPI's value.
+func (fc *FeedConfig) GetMaxAge() time.Duration {
+	if fc.MaxAge == 0 {
+		return DefaultMaxAge
+	}
+
+	return time.Duration(fc.MaxAge) * time.Second
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+var (
+	// DefaultETHAPIConfig is the default configuration for the API3 API. Specifically this is for
+	// Ethereum mainnet.
+	DefaultETHAPIConfig = config.APIConfig{
+		Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+		Atomic:            true,
+		Enabled:           true,
+		Timeout:           1000 * time.Millisecond,
+		Interval:          2000 * time.Millisecond,
+		ReconnectTimeout:  2000 * time.Millisecond,
+		MaxQueries:        1,
+		Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+		MaxBlockHeightAge: 30 * time.Second,
+	}
+
+	// DefaultBaseAPIConfig is the default configuration for the API3 API. Specifically this is for
+	// Base mainnet.
+	DefaultBaseAPIConfig = config.APIConfig{
+		Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.BASE),
+		Atomic:            true,
+		Enabled:           true,
+		Timeout:           1000 * time.Millisecond,
+		Interval:          2000 * time.Millisecond,
+		ReconnectTimeout:  2000 * time.Millisecond,
+		MaxQueries:        1,
+		Endpoints:         []config.Endpoint{{URL: BASE_URL}},
+		MaxBlockHeightAge: 30 * time.Second,
+	}
+)
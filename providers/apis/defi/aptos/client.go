@@ -0,0 +1,205 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// Client is the interface of the Aptos full node REST API used to read prices.
+type Client interface {
+	// GetResource returns the data of the resource of the given type held by an account.
+	GetResource(ctx context.Context, account, resourceType string) (json.RawMessage, error)
+
+	// GetTableItem returns the value of an item of the table with the given handle.
+	GetTableItem(ctx context.Context, handle string, item TableItemRequest) (json.RawMessage, error)
+}
+
+// TableItemRequest is the body of a table item request.
+type TableItemRequest struct {
+	KeyType   string      `json:"key_type"`
+	ValueType string      `json:"value_type"`
+	Key       interface{} `json:"key"`
+}
+
+// RESTClient is an implementation of the Aptos REST client. Multiple endpoints are queried in
+// priority order, failing over to the next endpoint when a request fails.
+type RESTClient struct {
+	api        config.APIConfig
+	apiMetrics metrics.APIMetrics
+
+	// clients are the http clients of each endpoint.
+	clients []*http.Client
+}
+
+var _ Client = (*RESTClient)(nil)
+
+// NewRESTClient returns a new RESTClient.
+func NewRESTClient(
+	api config.APIConfig,
+	apiMetrics metrics.APIMetrics,
+) (*RESTClient, error) {
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if api.Name != Name {
+		return nil, fmt.Errorf("invalid api name; expected %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api is not enabled")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("metrics is required")
+	}
+
+	inFlight := connecthttp.SharedInFlightLimiter(api.Name, api.MaxInFlight)
+	clients := make([]*http.Client, len(api.Endpoints))
+	for i, endpoint := range api.Endpoints {
+		var transport http.RoundTripper = connecthttp.NewRoundTripperWithHeaders(
+			connecthttp.NewRoundTripperWithLimit(http.DefaultTransport, inFlight),
+			connecthttp.WithConnectVersionUserAgent(),
+		)
+
+		// if authentication is enabled, add the authentication header. The key is looked up on
+		// each request so that it can be rotated.
+		if endpoint.Authentication.Enabled() {
+			apiKey, err := secrets.APIKey(endpoint.Authentication)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read api key: %w", err)
+			}
+
+			transport = connecthttp.NewRoundTripperWithAuthentication(transport, endpoint.Authentication.APIKeyHeader, apiKey.Value)
+		}
+
+		clients[i] = &http.Client{
+			Transport: otelhttp.NewTransport(transport),
+		}
+	}
+
+	return &RESTClient{
+		api:        api,
+		apiMetrics: apiMetrics,
+		clients:    clients,
+	}, nil
+}
+
+// GetResource returns the data of the resource of the given type held by an account.
+func (c *RESTClient) GetResource(ctx context.Context, account, resourceType string) (json.RawMessage, error) {
+	path := fmt.Sprintf("/v1/accounts/%s/resource/%s", account, url.PathEscape(resourceType))
+
+	var resource struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &resource); err != nil {
+		return nil, err
+	}
+
+	if len(resource.Data) == 0 {
+		return nil, fmt.Errorf("resource %s of account %s has no data", resourceType, account)
+	}
+
+	return resource.Data, nil
+}
+
+// GetTableItem returns the value of an item of the table with the given handle.
+func (c *RESTClient) GetTableItem(ctx context.Context, handle string, item TableItemRequest) (json.RawMessage, error) {
+	body, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode table item request: %w", err)
+	}
+
+	var value json.RawMessage
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v1/tables/%s/item", handle), body, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// do sends a request to each endpoint in order until one succeeds, and decodes its response into
+// out. The error of the last endpoint is returned if all endpoints fail.
+func (c *RESTClient) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	var err error
+	for i, client := range c.clients {
+		if err = c.doWithClient(ctx, client, i, method, path, body, out); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// doWithClient sends a request to a single endpoint and decodes its response into out.
+func (c *RESTClient) doWithClient(
+	ctx context.Context,
+	client *http.Client,
+	i int,
+	method, path string,
+	body []byte,
+	out interface{},
+) error {
+	redactedURL := metrics.RedactedEndpointURL(i)
+	start := time.Now()
+	defer func() {
+		c.apiMetrics.ObserveProviderResponseLatency(c.api.Name, redactedURL, time.Since(start))
+	}()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.api.Endpoints[i].URL, "/")+path, reader)
+	if err != nil {
+		return providertypes.NewErrorWithCode(err, providertypes.ErrorUnableToCreateURL)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		c.apiMetrics.AddRPCStatusCode(c.api.Name, redactedURL, metrics.RPCCodeError)
+		return fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	c.apiMetrics.AddHTTPStatusCode(c.api.Name, resp)
+	if resp.StatusCode != http.StatusOK {
+		// the API returns the reason of the failure in the body of the response.
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("request to %s failed with status %d: %s", path, resp.StatusCode, bytes.TrimSpace(msg)),
+			providertypes.ErrorCode(resp.StatusCode),
+		)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("failed to decode response: %w", err),
+			providertypes.ErrorFailedToDecode,
+		)
+	}
+
+	return nil
+}
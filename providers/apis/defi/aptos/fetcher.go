@@ -0,0 +1,252 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// PriceFetcher is the Aptos price fetcher. This fetcher reads prices from Move resources over the
+// full node REST API. The REST API reads a single resource per request, so the prices of all
+// tickers are read concurrently.
+//
+// Pyth stores the latest prices of all feeds in a table held by the LatestPriceInfo resource of
+// its package. The handle of this table is read the first time a Pyth ticker is fetched, after
+// which the price of each feed is read directly from the table.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the REST client used to query the full node.
+	client Client
+
+	mtx sync.Mutex
+	// feedCache is a cache of the tickers to feed configs.
+	feedCache map[types.ProviderTicker]FeedConfig
+	// handleCache is a cache of the resources of Pyth packages to the handles of their price
+	// tables. This never changes once the package is deployed.
+	handleCache map[string]string
+}
+
+// NewPriceFetcher returns a new Aptos PriceFetcher.
+func NewPriceFetcher(
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	client, err := NewRESTClient(api, apiMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new Aptos PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client Client,
+) (*PriceFetcher, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if api.Name != Name {
+		return nil, fmt.Errorf("invalid api config name; expected %s, got %s", Name, api.Name)
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	return &PriceFetcher{
+		logger:      logger.With(zap.String("fetcher", api.Name)),
+		api:         api,
+		client:      client,
+		feedCache:   make(map[types.ProviderTicker]FeedConfig),
+		handleCache: make(map[string]string),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. The price of each ticker is read
+// concurrently.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+
+		wg  sync.WaitGroup
+		mtx sync.Mutex
+	)
+
+	for _, ticker := range tickers {
+		feed, err := f.GetFeed(ticker)
+		if err != nil {
+			f.logger.Debug("failed to get feed for ticker", zap.String("ticker", ticker.String()), zap.Error(err))
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(ticker types.ProviderTicker, feed FeedConfig) {
+			defer wg.Done()
+
+			price, err := f.fetchPrice(ctx, feed)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			if err != nil {
+				f.logger.Debug("failed to read price", zap.String("ticker", ticker.String()), zap.Error(err))
+				unResolved[ticker] = providertypes.UnresolvedResult{
+					ErrorWithCode: providertypes.NewErrorWithCode(
+						err,
+						providertypes.ErrorCodeFromError(err, providertypes.ErrorInvalidResponse),
+					),
+				}
+				return
+			}
+
+			resolved[ticker] = types.NewPriceResult(price, time.Now().UTC())
+		}(ticker, feed)
+	}
+
+	wg.Wait()
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// fetchPrice reads the price of a single feed.
+func (f *PriceFetcher) fetchPrice(ctx context.Context, feed FeedConfig) (*big.Float, error) {
+	if feed.Layout == LayoutPyth {
+		return f.fetchPythPrice(ctx, feed)
+	}
+
+	data, err := f.client.GetResource(ctx, feed.Account, feed.GetResourceType())
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral))
+	}
+
+	if feed.Layout == LayoutPath {
+		return SelectPrice(data, *feed.Path)
+	}
+
+	price, err := DecodeSwitchboardPrice(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := price.ValidateBasic(time.Now().UTC(), feed.GetMaxAge()); err != nil {
+		return nil, err
+	}
+
+	return price.ScalePrice()
+}
+
+// fetchPythPrice reads the price of a Pyth feed from the price table of the Pyth package.
+func (f *PriceFetcher) fetchPythPrice(ctx context.Context, feed FeedConfig) (*big.Float, error) {
+	handle, err := f.getPriceTable(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := f.client.GetTableItem(ctx, handle, TableItemRequest{
+		KeyType:   feed.Account + "::price_identifier::PriceIdentifier",
+		ValueType: feed.Account + "::price_info::PriceInfo",
+		Key:       map[string]string{"bytes": feed.GetPriceID()},
+	})
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral))
+	}
+
+	price, err := DecodePythPrice(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := price.ValidateBasic(time.Now().UTC(), feed.GetMaxAge()); err != nil {
+		return nil, err
+	}
+
+	return price.ScalePrice()
+}
+
+// getPriceTable returns the handle of the price table of the Pyth package of the given feed,
+// reading it from the LatestPriceInfo resource if it is not cached.
+func (f *PriceFetcher) getPriceTable(ctx context.Context, feed FeedConfig) (string, error) {
+	key := feed.Account + "/" + feed.GetResourceType()
+
+	f.mtx.Lock()
+	handle, ok := f.handleCache[key]
+	f.mtx.Unlock()
+	if ok {
+		return handle, nil
+	}
+
+	data, err := f.client.GetResource(ctx, feed.Account, feed.GetResourceType())
+	if err != nil {
+		return "", providertypes.NewErrorWithCode(err, providertypes.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral))
+	}
+
+	var state PythState
+	if err := json.Unmarshal(data, &state); err != nil || state.Info.Handle == "" {
+		return "", fmt.Errorf("failed to read price table of pyth package %s", feed.Account)
+	}
+
+	f.mtx.Lock()
+	f.handleCache[key] = state.Info.Handle
+	f.mtx.Unlock()
+
+	return state.Info.Handle, nil
+}
+
+// GetFeed returns the Aptos feed for the given ticker. This will unmarshal the metadata and
+// validate the feed config which contains all required information to read the price.
+func (f *PriceFetcher) GetFeed(
+	ticker types.ProviderTicker,
+) (FeedConfig, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if feed, ok := f.feedCache[ticker]; ok {
+		return feed, nil
+	}
+
+	var feed FeedConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &feed); err != nil {
+		return FeedConfig{}, fmt.Errorf("failed to unmarshal feed config for ticker %s: %w", ticker.String(), err)
+	}
+
+	if err := feed.ValidateBasic(); err != nil {
+		return FeedConfig{}, fmt.Errorf("invalid feed config for ticker %s: %w", ticker.String(), err)
+	}
+
+	f.feedCache[ticker] = feed
+	return feed, nil
+}
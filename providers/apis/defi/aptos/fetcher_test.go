@@ -0,0 +1,322 @@
+package aptos_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/aptos"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+const (
+	pythPackage    = "0x7e783b349d3e89cf5931af376ebeadbfab855b3fa239b7ada8f5a92fbea6b387"
+	switchboard    = "0x7d7e436f0b2aafde60774efb26ccc432cf881b677aca7faaf2a01879bd19fb8f"
+	aggregator     = "0xdc1045b4d9fd1f4221fc2f91b2090d88483ba9745f29cf2d96574611204659a5"
+	vault          = "0x111ae3e5bc816a5e63c2da97d0aa3886519e0cd5e4b046659fa35796bd11542a"
+	priceTable     = "0xfe8e2ea2a3ef2bd61b29d70a5e7a45c0df3dbaf7ec8f3e1c30bd3b2fa1e8cd2a"
+	btcPriceID     = "e62df6c8b4a85fe1a67db44dc12de5db330f7ac66b72dc658afedf0f4a415b43"
+	switchboardRes = switchboard + "::aggregator::AggregatorRound<" + switchboard + "::aggregator::LatestConfirmedRound>"
+	vaultRes       = vault + "::stake_pool::StakePool"
+)
+
+var (
+	logger = zap.NewExample()
+
+	btcTicker = types.NewProviderTicker("BTC/USD", aptos.FeedConfig{
+		Account: pythPackage,
+		Layout:  aptos.LayoutPyth,
+		PriceID: btcPriceID,
+		MaxAge:  60,
+	}.MustToJSON())
+	aptTicker = types.NewProviderTicker("APT/USD", aptos.FeedConfig{
+		Account:      aggregator,
+		ResourceType: switchboardRes,
+		Layout:       aptos.LayoutSwitchboard,
+		MaxAge:       60,
+	}.MustToJSON())
+	vaultTicker = types.NewProviderTicker("STAPT/APT", aptos.FeedConfig{
+		Account:      vault,
+		ResourceType: vaultRes,
+		Layout:       aptos.LayoutPath,
+		Path:         &aptos.PathConfig{PricePath: "exchange_rate.value", Decimals: 8},
+	}.MustToJSON())
+)
+
+func TestFeedConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  aptos.FeedConfig
+		err  bool
+	}{
+		{
+			name: "valid pyth feed",
+			cfg:  aptos.FeedConfig{Account: pythPackage, Layout: aptos.LayoutPyth, PriceID: "0x" + btcPriceID},
+		},
+		{
+			name: "valid switchboard feed",
+			cfg:  aptos.FeedConfig{Account: aggregator, ResourceType: switchboardRes, Layout: aptos.LayoutSwitchboard},
+		},
+		{
+			name: "valid path feed",
+			cfg: aptos.FeedConfig{
+				Account:      vault,
+				ResourceType: vaultRes,
+				Layout:       aptos.LayoutPath,
+				Path:         &aptos.PathConfig{PricePath: "rate"},
+			},
+		},
+		{
+			name: "invalid account",
+			cfg:  aptos.FeedConfig{Account: "vault", ResourceType: vaultRes, Layout: aptos.LayoutSwitchboard},
+			err:  true,
+		},
+		{
+			name: "invalid resource type",
+			cfg:  aptos.FeedConfig{Account: aggregator, ResourceType: "AggregatorRound", Layout: aptos.LayoutSwitchboard},
+			err:  true,
+		},
+		{
+			name: "unknown layout",
+			cfg:  aptos.FeedConfig{Account: aggregator, ResourceType: switchboardRes, Layout: "chainlink"},
+			err:  true,
+		},
+		{
+			name: "pyth feed without price id",
+			cfg:  aptos.FeedConfig{Account: pythPackage, Layout: aptos.LayoutPyth},
+			err:  true,
+		},
+		{
+			name: "price id set for the switchboard layout",
+			cfg:  aptos.FeedConfig{Account: aggregator, ResourceType: switchboardRes, Layout: aptos.LayoutSwitchboard, PriceID: btcPriceID},
+			err:  true,
+		},
+		{
+			name: "switchboard feed without resource type",
+			cfg:  aptos.FeedConfig{Account: aggregator, Layout: aptos.LayoutSwitchboard},
+			err:  true,
+		},
+		{
+			name: "path feed without path",
+			cfg:  aptos.FeedConfig{Account: vault, ResourceType: vaultRes, Layout: aptos.LayoutPath},
+			err:  true,
+		},
+		{
+			name: "path set for the pyth layout",
+			cfg: aptos.FeedConfig{
+				Account: pythPackage,
+				Layout:  aptos.LayoutPyth,
+				PriceID: btcPriceID,
+				Path:    &aptos.PathConfig{PricePath: "rate"},
+			},
+			err: true,
+		},
+		{
+			name: "negative max age",
+			cfg:  aptos.FeedConfig{Account: pythPackage, Layout: aptos.LayoutPyth, PriceID: btcPriceID, MaxAge: -1},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// node is a fake Aptos full node serving the resources and table items of the tests.
+type node struct {
+	resources  map[string]string
+	tableItems map[string]string
+
+	resourceReads atomic.Int32
+}
+
+func (n *node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/accounts/"):
+		n.resourceReads.Add(1)
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/accounts/"), "/resource/")
+		data, ok := n.resources[parts[0]+"/"+parts[1]]
+		if !ok {
+			http.Error(w, `{"error_code":"resource_not_found"}`, http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprintf(w, `{"type":"resource","data":%s}`, data)
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/v1/tables/"):
+		var req aptos.TableItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		key, _ := json.Marshal(req.Key)
+		value, ok := n.tableItems[r.URL.Path+string(key)]
+		if !ok {
+			http.Error(w, `{"error_code":"table_item_not_found"}`, http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(w, value)
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func newNode(now time.Time) *node {
+	return &node{
+		resources: map[string]string{
+			pythPackage + "/" + pythPackage + "::state::LatestPriceInfo": fmt.Sprintf(`{"info":{"handle":"%s"}}`, priceTable),
+			aggregator + "/" + switchboardRes: fmt.Sprintf(
+				`{"result":{"value":"8500000000","dec":9,"neg":false},"round_confirmed_timestamp":"%d"}`,
+				now.Unix(),
+			),
+			vault + "/" + vaultRes: `{"exchange_rate":{"value":"105000000"}}`,
+		},
+		tableItems: map[string]string{
+			"/v1/tables/" + priceTable + "/item" + `{"bytes":"0x` + btcPriceID + `"}`: fmt.Sprintf(
+				`{"price_feed":{"price":{"price":{"magnitude":"6000000000000","negative":false},"expo":{"magnitude":"8","negative":true},"conf":"100","timestamp":"%d"}}}`,
+				now.Unix(),
+			),
+		},
+	}
+}
+
+func newFetcher(t *testing.T, urls ...string) *aptos.PriceFetcher {
+	t.Helper()
+
+	api := aptos.DefaultAPIConfig
+	api.Endpoints = nil
+	for _, url := range urls {
+		api.Endpoints = append(api.Endpoints, config.Endpoint{URL: url})
+	}
+
+	fetcher, err := aptos.NewPriceFetcher(logger, metrics.NewNopAPIMetrics(), api)
+	require.NoError(t, err)
+
+	return fetcher
+}
+
+func TestFetch(t *testing.T) {
+	now := time.Now()
+
+	t.Run("resolves all layouts", func(t *testing.T) {
+		n := newNode(now)
+		server := httptest.NewServer(n)
+		defer server.Close()
+
+		fetcher := newFetcher(t, server.URL)
+		resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{btcTicker, aptTicker, vaultTicker})
+		require.Empty(t, resp.UnResolved)
+		require.Len(t, resp.Resolved, 3)
+
+		expected := map[types.ProviderTicker]*big.Float{
+			btcTicker:   big.NewFloat(60000),
+			aptTicker:   big.NewFloat(8.5),
+			vaultTicker: big.NewFloat(1.05),
+		}
+		for ticker, price := range expected {
+			got, _ := resp.Resolved[ticker].Value.Float64()
+			want, _ := price.Float64()
+			require.InDelta(t, want, got, 1e-9)
+		}
+
+		// the handle of the price table is cached.
+		reads := n.resourceReads.Load()
+		resp = fetcher.Fetch(context.Background(), []types.ProviderTicker{btcTicker})
+		require.Len(t, resp.Resolved, 1)
+		require.Equal(t, reads, n.resourceReads.Load())
+	})
+
+	t.Run("stale prices are rejected", func(t *testing.T) {
+		server := httptest.NewServer(newNode(now.Add(-time.Hour)))
+		defer server.Close()
+
+		fetcher := newFetcher(t, server.URL)
+		resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{btcTicker, aptTicker, vaultTicker})
+		require.Len(t, resp.Resolved, 1)
+		require.Contains(t, resp.Resolved, vaultTicker)
+		require.Equal(t, providertypes.ErrorStalePrice, resp.UnResolved[btcTicker].Code())
+		require.Equal(t, providertypes.ErrorStalePrice, resp.UnResolved[aptTicker].Code())
+	})
+
+	t.Run("missing resources are unresolved", func(t *testing.T) {
+		n := newNode(now)
+		delete(n.resources, vault+"/"+vaultRes)
+		delete(n.tableItems, "/v1/tables/"+priceTable+"/item"+`{"bytes":"0x`+btcPriceID+`"}`)
+		server := httptest.NewServer(n)
+		defer server.Close()
+
+		fetcher := newFetcher(t, server.URL)
+		resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{btcTicker, aptTicker, vaultTicker})
+		require.Len(t, resp.Resolved, 1)
+		require.Contains(t, resp.Resolved, aptTicker)
+		require.Equal(t, providertypes.ErrorCode(http.StatusNotFound), resp.UnResolved[btcTicker].Code())
+		require.Equal(t, providertypes.ErrorCode(http.StatusNotFound), resp.UnResolved[vaultTicker].Code())
+	})
+
+	t.Run("exponents beyond the max exponent are unresolved", func(t *testing.T) {
+		n := newNode(now)
+		n.resources[vault+"/"+vaultRes] = `{"exchange_rate":{"value":"1e-9223372036854775807"}}`
+		n.tableItems["/v1/tables/"+priceTable+"/item"+`{"bytes":"0x`+btcPriceID+`"}`] = fmt.Sprintf(
+			`{"price_feed":{"price":{"price":{"magnitude":"6000000000000","negative":false},"expo":{"magnitude":"1000000000","negative":true},"conf":"100","timestamp":"%d"}}}`,
+			now.Unix(),
+		)
+		server := httptest.NewServer(n)
+		defer server.Close()
+
+		fetcher := newFetcher(t, server.URL)
+		resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{btcTicker, aptTicker, vaultTicker})
+		require.Len(t, resp.Resolved, 1)
+		require.Contains(t, resp.Resolved, aptTicker)
+		require.Contains(t, resp.UnResolved, btcTicker)
+		require.Contains(t, resp.UnResolved, vaultTicker)
+	})
+
+	t.Run("fails over to the next endpoint", func(t *testing.T) {
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer down.Close()
+
+		server := httptest.NewServer(newNode(now))
+		defer server.Close()
+
+		fetcher := newFetcher(t, down.URL, server.URL)
+		resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{btcTicker, aptTicker, vaultTicker})
+		require.Empty(t, resp.UnResolved)
+		require.Len(t, resp.Resolved, 3)
+	})
+
+	t.Run("invalid metadata is unresolved", func(t *testing.T) {
+		server := httptest.NewServer(newNode(now))
+		defer server.Close()
+
+		ticker := types.NewProviderTicker("BAD/USD", `{"account":"0x1","layout":"unknown"}`)
+		fetcher := newFetcher(t, server.URL)
+		resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{ticker})
+		require.Equal(t, providertypes.ErrorFailedToDecode, resp.UnResolved[ticker].Code())
+	})
+}
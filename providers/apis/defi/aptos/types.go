@@ -0,0 +1,208 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/skip-mev/connect/v2/pkg/math"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/cosmwasm"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// NOTE: Integers wider than 32 bits are encoded as strings by the Aptos API.
+
+// Price is a price read from a resource, along with the time at which it was published.
+type Price struct {
+	// Value is the unscaled value of the price.
+	Value *big.Int
+	// Exponent is the power of ten the value is multiplied by.
+	Exponent int64
+	// Timestamp is the unix timestamp at which the price was published.
+	Timestamp int64
+}
+
+// ValidateBasic ensures that the price is positive and no older than the given max age. A zero
+// max age disables the age check. Stale prices return an error with the ErrorStalePrice code.
+func (p Price) ValidateBasic(now time.Time, maxAge time.Duration) error {
+	if p.Value == nil || p.Value.Sign() <= 0 {
+		return fmt.Errorf("price must be positive")
+	}
+
+	if maxAge == 0 {
+		return nil
+	}
+
+	updatedAt := time.Unix(p.Timestamp, 0)
+	if age := now.Sub(updatedAt); age > maxAge {
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("price updated at %s is older than max age %s", updatedAt.UTC(), maxAge),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	return nil
+}
+
+// ScalePrice returns the price scaled by its exponent. An error is returned if the exponent is
+// out of range.
+func (p Price) ScalePrice() (*big.Float, error) {
+	price, err := math.NewPrice(p.Value, p.Exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
+
+// PythState is the LatestPriceInfo resource of the Pyth package, which holds the handle of the
+// table of the latest prices of all feeds.
+//
+//	{"info": {"handle": "0x..."}}
+type PythState struct {
+	Info struct {
+		Handle string `json:"handle"`
+	} `json:"info"`
+}
+
+// pythI64 is the signed integer type of the Pyth move package.
+type pythI64 struct {
+	Magnitude json.Number `json:"magnitude"`
+	Negative  bool        `json:"negative"`
+}
+
+// int returns the value of the signed integer.
+func (i pythI64) int() (*big.Int, error) {
+	v, ok := new(big.Int).SetString(i.Magnitude.String(), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid magnitude: %s", i.Magnitude)
+	}
+
+	if i.Negative {
+		v.Neg(v)
+	}
+
+	return v, nil
+}
+
+// pythPriceInfo is a PriceInfo item of the price table of the Pyth package.
+//
+//	{
+//	  "price_feed": {
+//	    "price": {"price": {"magnitude": "6000000000000", "negative": false}, "expo": {"magnitude": "8", "negative": true}, "timestamp": "1700000000"}
+//	  }
+//	}
+type pythPriceInfo struct {
+	PriceFeed struct {
+		Price struct {
+			Expo      pythI64     `json:"expo"`
+			Price     pythI64     `json:"price"`
+			Timestamp json.Number `json:"timestamp"`
+		} `json:"price"`
+	} `json:"price_feed"`
+}
+
+// DecodePythPrice decodes the price of a PriceInfo item of the Pyth price table.
+func DecodePythPrice(value json.RawMessage) (Price, error) {
+	var info pythPriceInfo
+	if err := json.Unmarshal(value, &info); err != nil {
+		return Price{}, fmt.Errorf("failed to decode pyth price info: %w", err)
+	}
+
+	price := info.PriceFeed.Price
+	v, err := price.Price.int()
+	if err != nil {
+		return Price{}, fmt.Errorf("invalid price: %w", err)
+	}
+
+	exponent, err := price.Expo.int()
+	if err != nil || !exponent.IsInt64() {
+		return Price{}, fmt.Errorf("invalid exponent: %s", price.Expo.Magnitude)
+	}
+
+	timestamp, err := price.Timestamp.Int64()
+	if err != nil {
+		return Price{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+
+	return Price{
+		Value:     v,
+		Exponent:  exponent.Int64(),
+		Timestamp: timestamp,
+	}, nil
+}
+
+// switchboardRound is an AggregatorRound resource of a Switchboard V2 aggregator. The result is a
+// SwitchboardDecimal, i.e. a value scaled by 10^-dec.
+//
+//	{
+//	  "result": {"value": "1500000000", "dec": 9, "neg": false},
+//	  "round_confirmed_timestamp": "1700000000"
+//	}
+type switchboardRound struct {
+	Result struct {
+		Value json.Number `json:"value"`
+		Dec   uint8       `json:"dec"`
+		Neg   bool        `json:"neg"`
+	} `json:"result"`
+	RoundConfirmedTimestamp json.Number `json:"round_confirmed_timestamp"`
+}
+
+// DecodeSwitchboardPrice decodes the result of an AggregatorRound resource of a Switchboard V2
+// aggregator.
+func DecodeSwitchboardPrice(data json.RawMessage) (Price, error) {
+	var round switchboardRound
+	if err := json.Unmarshal(data, &round); err != nil {
+		return Price{}, fmt.Errorf("failed to decode switchboard round: %w", err)
+	}
+
+	v, ok := new(big.Int).SetString(round.Result.Value.String(), 10)
+	if !ok {
+		return Price{}, fmt.Errorf("invalid result: %s", round.Result.Value)
+	}
+
+	if round.Result.Neg {
+		v.Neg(v)
+	}
+
+	timestamp, err := round.RoundConfirmedTimestamp.Int64()
+	if err != nil {
+		return Price{}, fmt.Errorf("invalid round confirmed timestamp: %w", err)
+	}
+
+	return Price{
+		Value:     v,
+		Exponent:  -int64(round.Result.Dec),
+		Timestamp: timestamp,
+	}, nil
+}
+
+// SelectPrice selects the price at the configured path of the data of a resource.
+func SelectPrice(data json.RawMessage, cfg PathConfig) (*big.Float, error) {
+	value, err := cosmwasm.SelectValue(data, cfg.PricePath)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := math.ParsePrice(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse price %s: %w", value, err)
+	}
+
+	price, err = math.NewPrice(price.Value, price.Exponent-cfg.Decimals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale price %s: %w", value, err)
+	}
+
+	scaled := price.BigFloat()
+	if scaled.Sign() <= 0 {
+		return nil, fmt.Errorf("price must be positive")
+	}
+
+	if cfg.Invert {
+		scaled = new(big.Float).Quo(big.NewFloat(1), scaled)
+	}
+
+	return scaled, nil
+}
@@ -0,0 +1,184 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/cosmwasm"
+)
+
+// NOTE: All documentation for this file can be located on the Aptos docs.
+// API documentation: https://aptos.dev/en/build/apis/fullnode-rest-api.
+
+const (
+	// Name is the name of the Aptos API.
+	Name = "aptos_api"
+
+	// LayoutPyth reads the price of a Pyth price feed from the price table of the Pyth package.
+	LayoutPyth = "pyth"
+
+	// LayoutSwitchboard reads the latest confirmed round of a Switchboard V2 aggregator.
+	LayoutSwitchboard = "switchboard"
+
+	// LayoutPath reads the price at a path of the data of a resource.
+	LayoutPath = "path"
+
+	// URL is the URL of the public Aptos mainnet full node.
+	URL = "https://fullnode.mainnet.aptoslabs.com"
+)
+
+var (
+	// addressRegex matches a hex encoded account address of up to 32 bytes.
+	addressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{1,64}$`)
+
+	// priceIDRegex matches a hex encoded 32 byte Pyth price identifier.
+	priceIDRegex = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)
+
+	// resourceTypeRegex matches a fully qualified move struct type, e.g. 0x1::coin::CoinInfo<0x1::aptos_coin::AptosCoin>.
+	resourceTypeRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{1,64}::\w+::\w+(<.+>)?$`)
+)
+
+// FeedConfig is the configuration of a price read from Aptos. This is specific to each pair of tokens.
+type FeedConfig struct {
+	// Account is the address of the account holding the resource, i.e. the address of the Pyth
+	// package or of a Switchboard aggregator.
+	Account string `json:"account"`
+
+	// ResourceType is the type of the resource holding the price. This defaults to the
+	// LatestPriceInfo resource of the Pyth package for the pyth layout, and is required otherwise.
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Layout is how the price is read from the resource. Must be one of pyth, switchboard or path.
+	Layout string `json:"layout"`
+
+	// PriceID is the hex encoded identifier of a Pyth price feed. This is required for the pyth
+	// layout, and must be unset otherwise.
+	PriceID string `json:"price_id,omitempty"`
+
+	// Path configures the path layout, and must be unset otherwise.
+	Path *PathConfig `json:"path,omitempty"`
+
+	// MaxAge is the maximum age, in seconds, of the price. This is only enforced by the pyth and
+	// switchboard layouts. If unset, the age of the price is not checked.
+	MaxAge int64 `json:"max_age"`
+}
+
+// PathConfig configures the price of a resource that stores it in one of its fields.
+type PathConfig struct {
+	// PricePath is the JSONPath-style selector of the price in the data of the resource, e.g.
+	// exchange_rate.value. Keys are separated by dots and array elements are selected by their
+	// index. The selected value must be a number or a string encoded number.
+	PricePath string `json:"price_path"`
+
+	// Decimals is the number of decimals of the selected price.
+	Decimals int64 `json:"decimals"`
+
+	// Invert is true if the selected price should be inverted, i.e. the resource stores the
+	// price of the quote in units of the base.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if !addressRegex.MatchString(fc.Account) {
+		return fmt.Errorf("invalid account address: %s", fc.Account)
+	}
+
+	if fc.ResourceType != "" && !resourceTypeRegex.MatchString(fc.ResourceType) {
+		return fmt.Errorf("invalid resource type: %s", fc.ResourceType)
+	}
+
+	if fc.MaxAge < 0 {
+		return fmt.Errorf("max age must be non-negative")
+	}
+
+	if fc.Layout != LayoutPyth && fc.PriceID != "" {
+		return fmt.Errorf("price id can only be set for the %s layout", LayoutPyth)
+	}
+
+	if fc.Layout != LayoutPath && fc.Path != nil {
+		return fmt.Errorf("path can only be set for the %s layout", LayoutPath)
+	}
+
+	switch fc.Layout {
+	case LayoutPyth:
+		if !priceIDRegex.MatchString(fc.PriceID) {
+			return fmt.Errorf("invalid price id: %s", fc.PriceID)
+		}
+	case LayoutSwitchboard:
+		if fc.ResourceType == "" {
+			return fmt.Errorf("resource type is required for the %s layout", LayoutSwitchboard)
+		}
+	case LayoutPath:
+		if fc.ResourceType == "" {
+			return fmt.Errorf("resource type is required for the %s layout", LayoutPath)
+		}
+
+		if fc.Path == nil {
+			return fmt.Errorf("path is required for the %s layout", LayoutPath)
+		}
+
+		return fc.Path.ValidateBasic()
+	default:
+		return fmt.Errorf("unknown layout: %s", fc.Layout)
+	}
+
+	return nil
+}
+
+// ValidateBasic validates the path configuration.
+func (pc *PathConfig) ValidateBasic() error {
+	if len(cosmwasm.ParsePath(pc.PricePath)) == 0 {
+		return fmt.Errorf("price path cannot be empty")
+	}
+
+	if pc.Decimals < 0 {
+		return fmt.Errorf("decimals must be non-negative")
+	}
+
+	return nil
+}
+
+// GetResourceType returns the type of the resource holding the price.
+func (fc *FeedConfig) GetResourceType() string {
+	if fc.ResourceType == "" && fc.Layout == LayoutPyth {
+		return fc.Account + "::state::LatestPriceInfo"
+	}
+
+	return fc.ResourceType
+}
+
+// GetPriceID returns the Pyth price identifier, 0x prefixed and lower case.
+func (fc *FeedConfig) GetPriceID() string {
+	return "0x" + strings.ToLower(strings.TrimPrefix(fc.PriceID, "0x"))
+}
+
+// GetMaxAge returns the maximum age of the price, or zero if the age is not checked.
+func (fc *FeedConfig) GetMaxAge() time.Duration {
+	return time.Duration(fc.MaxAge) * time.Second
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// DefaultAPIConfig is the default configuration for the Aptos API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           false,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         1000 * time.Millisecond,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
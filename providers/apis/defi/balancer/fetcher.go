@@ -0,0 +1,523 @@
+package balancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer/pool"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer/vault"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// oraclePairPrice is the IPriceOracle.Variable used to query the pair price from the pool's
+// built-in oracle.
+const oraclePairPrice = 0
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// PriceFetcher is the Balancer price fetcher. This fetcher is responsible for querying Balancer
+// pools and returning the price of a given ticker. Depending on the pool config, the price is
+// derived from:
+//
+//   - the balances from the vault's getPoolTokens and the pool's getNormalizedWeights for
+//     weighted pools.
+//   - the balances from the vault's getPoolTokens and the pool's getAmplificationParameter for
+//     stable pools.
+//   - the pool's getRate for the pool's BPT.
+//   - the time weighted average pair price of the pool's built-in oracle for pools with the
+//     oracle enabled.
+//
+// To read more about Balancer pools, see the Balancer documentation https://docs.balancer.fi/.
+//
+// Similar to the Uniswap V3 fetcher, we utilize the eth client's BatchCallContext to batch the calls
+// to the ethereum network.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the EVM client implementation. This is used to interact with the ethereum network.
+	client ethmulticlient.EVMClient
+	// poolABI is the Balancer pool abi. This is used to pack the calls to the pool contract and
+	// parse the results.
+	poolABI *abi.ABI
+	// vaultABI is the Balancer vault abi. This is used to pack the getPoolTokens call to the
+	// vault and parse the result.
+	vaultABI *abi.ABI
+
+	mtx sync.Mutex
+	// poolCache is a cache of the tickers to pool configs. This is used to avoid unmarshalling
+	// the metadata for each ticker.
+	poolCache map[types.ProviderTicker]PoolConfig
+}
+
+// NewPriceFetcher returns a new Balancer price fetcher.
+func NewPriceFetcher(
+	ctx context.Context,
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("api metrics is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if !IsValidProviderName(api.Name) {
+		return nil, fmt.Errorf("invalid api config name %s", api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	client, err := ethmulticlient.NewClientFromEndpoints(
+		ctx,
+		logger,
+		api,
+		apiMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client ethmulticlient.EVMClient,
+) (*PriceFetcher, error) {
+	poolABI, err := pool.PoolMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool abi: %w", err)
+	}
+
+	vaultABI, err := vault.VaultMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vault abi: %w", err)
+	}
+
+	return &PriceFetcher{
+		logger:    logger.With(zap.String("fetcher", api.Name)),
+		api:       api,
+		client:    client,
+		poolABI:   poolABI,
+		vaultABI:  vaultABI,
+		poolCache: make(map[types.ProviderTicker]PoolConfig),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. This fetch utilizes the batch call to lower
+// overhead of making individual RPC calls for each ticker. Each ticker makes one or two calls
+// depending on the method used to price the ticker.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+	)
+
+	// Create the batch elements for each ticker and pool. offsets[i] is the index of the first
+	// batch element of the i-th ticker.
+	var (
+		batchElems = make([]rpc.BatchElem, 0, 2*len(tickers))
+		pools      = make([]PoolConfig, len(tickers))
+		offsets    = make([]int, len(tickers))
+	)
+	for i, ticker := range tickers {
+		poolCfg, err := f.GetPool(ticker)
+		if err != nil {
+			f.logger.Debug(
+				"failed to get pool for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to get pool: %w", err),
+					providertypes.ErrorFailedToDecode,
+				),
+			)
+		}
+
+		calls, err := f.createCalls(poolCfg)
+		if err != nil {
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(err, providertypes.ErrorUnknown),
+			)
+		}
+
+		pools[i] = poolCfg
+		offsets[i] = len(batchElems)
+		batchElems = append(batchElems, calls...)
+	}
+
+	// Batch call to the EVM.
+	block, err := ethmulticlient.BatchCallWithBlock(ctx, f.client, f.api.GetBlockTag(), batchElems)
+	if err != nil {
+		f.logger.Debug(
+			"failed to batch call to ethereum network for all tickers",
+			zap.Error(err),
+		)
+
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+		)
+	}
+
+	// Parse the results from the batch call for each ticker.
+	now := time.Now().UTC()
+	for i, ticker := range tickers {
+		results := batchElems[offsets[i] : offsets[i]+numCalls(pools[i])]
+
+		var callErr error
+		for _, result := range results {
+			if result.Error != nil {
+				callErr = result.Error
+				break
+			}
+		}
+		if callErr != nil {
+			f.logger.Debug(
+				"failed to batch call to ethereum network for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(callErr),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					callErr,
+					ethmulticlient.ErrorCodeFromError(callErr, providertypes.ErrorUnknown),
+				),
+			}
+
+			continue
+		}
+
+		price, err := f.ParsePrice(pools[i], results)
+		if err != nil {
+			f.logger.Debug(
+				"failed to parse price",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorFailedToParsePrice,
+				),
+			}
+
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResultWithBlock(price, now, block)
+	}
+
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// GetPool returns the Balancer pool for the given ticker. This will unmarshal the metadata
+// and validate the pool config which contains all required information to query the EVM.
+func (f *PriceFetcher) GetPool(
+	ticker types.ProviderTicker,
+) (PoolConfig, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if cfg, ok := f.poolCache[ticker]; ok {
+		return cfg, nil
+	}
+
+	var cfg PoolConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal pool config on ticker: %w", err)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid ticker pool config: %w", err)
+	}
+
+	f.poolCache[ticker] = cfg
+	return cfg, nil
+}
+
+// createCalls creates the batch elements used to price the given pool.
+func (f *PriceFetcher) createCalls(cfg PoolConfig) ([]rpc.BatchElem, error) {
+	poolAddress := cfg.GetPoolAddress()
+
+	switch cfg.GetMethod() {
+	case MethodRate:
+		payload, err := f.poolABI.Pack(GetRateMethod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack getRate: %w", err)
+		}
+
+		return []rpc.BatchElem{f.ethCall(poolAddress, payload)}, nil
+	case MethodOracle:
+		payload, err := f.poolABI.Pack(GetTimeWeightedAverageMethod, []pool.IPriceOracleOracleAverageQuery{
+			{
+				Variable: oraclePairPrice,
+				Secs:     big.NewInt(cfg.GetTWAPWindow()),
+				Ago:      big.NewInt(0),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack getTimeWeightedAverage: %w", err)
+		}
+
+		return []rpc.BatchElem{f.ethCall(poolAddress, payload)}, nil
+	}
+
+	var poolID [32]byte
+	copy(poolID[:], common.FromHex(cfg.PoolID))
+	tokensPayload, err := f.vaultABI.Pack(GetPoolTokensMethod, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack getPoolTokens: %w", err)
+	}
+
+	method := GetNormalizedWeightsMethod
+	if cfg.GetMethod() == MethodStable {
+		method = GetAmplificationParameterMethod
+	}
+
+	payload, err := f.poolABI.Pack(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+
+	return []rpc.BatchElem{
+		f.ethCall(cfg.GetVault(), tokensPayload),
+		f.ethCall(poolAddress, payload),
+	}, nil
+}
+
+// ParsePrice parses the results of the calls made for the given pool into the price of the base
+// token in units of the quote token, or of the BPT for the rate method.
+func (f *PriceFetcher) ParsePrice(
+	cfg PoolConfig,
+	results []rpc.BatchElem,
+) (*big.Float, error) {
+	if len(results) != numCalls(cfg) {
+		return nil, fmt.Errorf("expected %d results, got %d", numCalls(cfg), len(results))
+	}
+
+	switch cfg.GetMethod() {
+	case MethodRate:
+		out, err := f.unpack(f.poolABI, GetRateMethod, results[0].Result)
+		if err != nil {
+			return nil, err
+		}
+
+		rate := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+		if rate.Sign() <= 0 {
+			return nil, fmt.Errorf("rate must be positive")
+		}
+
+		return NormalizeAmount(rate, FixedPointDecimals), nil
+	case MethodOracle:
+		out, err := f.unpack(f.poolABI, GetTimeWeightedAverageMethod, results[0].Result)
+		if err != nil {
+			return nil, err
+		}
+
+		averages := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+		if len(averages) != 1 || averages[0].Sign() <= 0 {
+			return nil, fmt.Errorf("oracle pair price must be positive")
+		}
+
+		// The oracle pair price is the price of the second token in units of the first token.
+		price := NormalizeAmount(averages[0], FixedPointDecimals)
+		if cfg.BaseIndex == 0 {
+			price.Quo(big.NewFloat(1), price)
+		}
+
+		return price, nil
+	}
+
+	out, err := f.unpack(f.vaultABI, GetPoolTokensMethod, results[0].Result)
+	if err != nil {
+		return nil, err
+	}
+
+	rawBalances := *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+	if int64(len(rawBalances)) <= max(cfg.BaseIndex, cfg.QuoteIndex) {
+		return nil, fmt.Errorf("pool has %d tokens", len(rawBalances))
+	}
+
+	switch cfg.GetMethod() {
+	case MethodStable:
+		return f.parseStablePrice(cfg, out, rawBalances, results[1].Result)
+	default:
+		return f.parseWeightedPrice(cfg, rawBalances, results[1].Result)
+	}
+}
+
+// parseWeightedPrice parses the spot price of a weighted pool from its balances and weights.
+func (f *PriceFetcher) parseWeightedPrice(
+	cfg PoolConfig,
+	rawBalances []*big.Int,
+	weightsResult interface{},
+) (*big.Float, error) {
+	out, err := f.unpack(f.poolABI, GetNormalizedWeightsMethod, weightsResult)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+	if len(weights) != len(rawBalances) {
+		return nil, fmt.Errorf("pool has %d tokens but %d weights", len(rawBalances), len(weights))
+	}
+
+	return CalculateWeightedSpotPrice(
+		NormalizeAmount(rawBalances[cfg.BaseIndex], cfg.Decimals[cfg.BaseIndex]),
+		NormalizeAmount(weights[cfg.BaseIndex], FixedPointDecimals),
+		NormalizeAmount(rawBalances[cfg.QuoteIndex], cfg.Decimals[cfg.QuoteIndex]),
+		NormalizeAmount(weights[cfg.QuoteIndex], FixedPointDecimals),
+	)
+}
+
+// parseStablePrice parses the spot price of a stable pool from its balances and amplification
+// parameter. Composable stable pools include their own BPT in the pool's tokens; the BPT is
+// excluded from the invariant.
+func (f *PriceFetcher) parseStablePrice(
+	cfg PoolConfig,
+	poolTokens []interface{},
+	rawBalances []*big.Int,
+	ampResult interface{},
+) (*big.Float, error) {
+	out, err := f.unpack(f.poolABI, GetAmplificationParameterMethod, ampResult)
+	if err != nil {
+		return nil, err
+	}
+
+	value := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	ampPrecision := *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	if ampPrecision.Sign() <= 0 {
+		return nil, fmt.Errorf("amplification precision must be positive")
+	}
+	amp := new(big.Float).SetPrec(precision).SetInt(value)
+	amp.Quo(amp, new(big.Float).SetInt(ampPrecision))
+
+	tokens := *abi.ConvertType(poolTokens[0], new([]common.Address)).(*[]common.Address)
+	if len(tokens) != len(rawBalances) || len(cfg.Decimals) != len(rawBalances) {
+		return nil, fmt.Errorf("decimals must be set for all %d pool tokens", len(rawBalances))
+	}
+
+	var (
+		bpt      = cfg.GetPoolAddress()
+		balances = make([]*big.Float, 0, len(rawBalances))
+		base     = -1
+		quote    = -1
+	)
+	for i, token := range tokens {
+		if token == bpt {
+			continue
+		}
+
+		switch int64(i) {
+		case cfg.BaseIndex:
+			base = len(balances)
+		case cfg.QuoteIndex:
+			quote = len(balances)
+		}
+
+		balances = append(balances, NormalizeAmount(rawBalances[i], cfg.Decimals[i]))
+	}
+
+	if base < 0 || quote < 0 {
+		return nil, fmt.Errorf("base and quote tokens cannot be the pool's bpt")
+	}
+
+	return CalculateStableSpotPrice(balances, amp, base, quote)
+}
+
+// unpack decodes the hex encoded result of an eth_call and unpacks the outputs of the given method.
+func (f *PriceFetcher) unpack(contractABI *abi.ABI, method string, result interface{}) ([]interface{}, error) {
+	r, ok := result.(*string)
+	if !ok {
+		return nil, fmt.Errorf("expected result to be a string, got %T", result)
+	}
+
+	if r == nil {
+		return nil, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := contractABI.Methods[method].Outputs.UnpackValues(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s values: %w", method, err)
+	}
+
+	return out, nil
+}
+
+// numCalls returns the number of batch elements used to price the given pool.
+func numCalls(cfg PoolConfig) int {
+	switch cfg.GetMethod() {
+	case MethodRate, MethodOracle:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ethCall returns an eth_call batch element for the given contract and call data, made at the
+// configured block tag.
+func (f *PriceFetcher) ethCall(to common.Address, payload []byte) rpc.BatchElem {
+	var result string
+	return rpc.BatchElem{
+		Method: "eth_call",
+		Args: []interface{}{
+			map[string]interface{}{
+				"to":   to,
+				"data": hexutil.Bytes(payload),
+			},
+			f.api.GetBlockTag(),
+		},
+		Result: &result,
+	}
+}
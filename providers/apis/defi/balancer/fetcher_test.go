@@ -0,0 +1,374 @@
+package balancer_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer/pool"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer/vault"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+)
+
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+var (
+	logger, _ = zap.NewDevelopment()
+
+	// Token addresses used for testing.
+	bal    = common.HexToAddress("0xba100000625a3754423978a60c9317c58a424e3D")
+	weth   = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	wsteth = common.HexToAddress("0x7f39C581F595B53c5cb19bD0b3f8dA6c935E2Ca0")
+
+	// PoolConfigs used for testing.
+	balwethCfg = balancer.PoolConfig{
+		PoolID:     "0x5c6ee304399dbdb9c8ef030ab642b10820db8f56000200000000000000000014",
+		BaseIndex:  0,
+		QuoteIndex: 1,
+		Decimals:   []int64{18, 18},
+	}
+	wstethwethCfg = balancer.PoolConfig{
+		PoolID:     "0x93d199263632a4ef4bb438f1feb99e57b4b5f0bd0000000000000000000005c2",
+		Method:     balancer.MethodStable,
+		BaseIndex:  0,
+		QuoteIndex: 2,
+		Decimals:   []int64{18, 18, 18},
+	}
+	bptCfg = balancer.PoolConfig{
+		PoolID: "0x93d199263632a4ef4bb438f1feb99e57b4b5f0bd0000000000000000000005c2",
+		Method: balancer.MethodRate,
+	}
+	balwethOracleCfg = balancer.PoolConfig{
+		PoolID:     "0x5c6ee304399dbdb9c8ef030ab642b10820db8f56000200000000000000000014",
+		Method:     balancer.MethodOracle,
+		BaseIndex:  0,
+		QuoteIndex: 1,
+		TWAPWindow: 600,
+	}
+
+	// Tickers used for testing.
+	balwethTicker       = types.NewProviderTicker("BAL/WETH", balwethCfg.MustToJSON())
+	wstethwethTicker    = types.NewProviderTicker("WSTETH/WETH", wstethwethCfg.MustToJSON())
+	bptTicker           = types.NewProviderTicker("WSTETH-WETH-BPT/WETH", bptCfg.MustToJSON())
+	balwethOracleTicker = types.NewProviderTicker("BAL/WETH", balwethOracleCfg.MustToJSON())
+)
+
+func TestFetch(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tickers    []types.ProviderTicker
+		client     func() ethmulticlient.EVMClient
+		expected   map[types.ProviderTicker]float64
+		unresolved []types.ProviderTicker
+	}{
+		{
+			name: "fails to retrieve pool for an empty ticker",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("BAL/WETH", ""),
+			},
+			client: func() ethmulticlient.EVMClient {
+				return mocks.NewEVMClient(t)
+			},
+			unresolved: []types.ProviderTicker{types.NewProviderTicker("BAL/WETH", "")},
+		},
+		{
+			name: "fails to make a batch call",
+			tickers: []types.ProviderTicker{
+				balwethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				c.On("BatchCallContext", mock.Anything, mock.Anything).Return(fmt.Errorf("failed to make a batch call"))
+				return c
+			},
+			unresolved: []types.ProviderTicker{balwethTicker},
+		},
+		{
+			name: "batch request has an error for one of the ticker's calls",
+			tickers: []types.ProviderTicker{
+				balwethTicker,
+				bptTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(
+					t,
+					c,
+					[]string{
+						encodePoolTokens(t, []common.Address{bal, weth}, "8000000000000000000000000", "1000000000000000000000"),
+						"",
+						encodeOutputs(t, balancer.GetRateMethod, mustBigInt(t, "1020000000000000000")),
+					},
+					[]error{nil, fmt.Errorf("execution reverted"), nil},
+				)
+				return c
+			},
+			expected: map[types.ProviderTicker]float64{
+				bptTicker: 1.02,
+			},
+			unresolved: []types.ProviderTicker{balwethTicker},
+		},
+		{
+			name: "weighted pool result",
+			tickers: []types.ProviderTicker{
+				balwethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(
+					t,
+					c,
+					[]string{
+						encodePoolTokens(t, []common.Address{bal, weth}, "8000000000000000000000000", "1000000000000000000000"),
+						encodeOutputs(t, balancer.GetNormalizedWeightsMethod, []*big.Int{
+							mustBigInt(t, "800000000000000000"),
+							mustBigInt(t, "200000000000000000"),
+						}),
+					},
+					[]error{nil, nil},
+				)
+				return c
+			},
+			expected: map[types.ProviderTicker]float64{
+				balwethTicker: 0.0005,
+			},
+		},
+		{
+			name: "stable pool result excludes the bpt",
+			tickers: []types.ProviderTicker{
+				wstethwethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				bpt := (&wstethwethCfg).GetPoolAddress()
+				expectBatchCall(
+					t,
+					c,
+					[]string{
+						encodePoolTokens(
+							t,
+							[]common.Address{wsteth, bpt, weth},
+							"1000000000000000000000",
+							"2596148429267413814265248164610048",
+							"1000000000000000000000",
+						),
+						encodeOutputs(t, balancer.GetAmplificationParameterMethod, big.NewInt(50000), false, big.NewInt(1000)),
+					},
+					[]error{nil, nil},
+				)
+				return c
+			},
+			expected: map[types.ProviderTicker]float64{
+				wstethwethTicker: 1,
+			},
+		},
+		{
+			name: "oracle pair price is inverted for the first token",
+			tickers: []types.ProviderTicker{
+				balwethOracleTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(
+					t,
+					c,
+					[]string{
+						encodeOutputs(t, balancer.GetTimeWeightedAverageMethod, []*big.Int{mustBigInt(t, "2000000000000000000000")}),
+					},
+					[]error{nil},
+				)
+				return c
+			},
+			expected: map[types.ProviderTicker]float64{
+				balwethOracleTicker: 0.0005,
+			},
+		},
+		{
+			name: "zero rate is rejected",
+			tickers: []types.ProviderTicker{
+				bptTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(t, c, []string{encodeOutputs(t, balancer.GetRateMethod, big.NewInt(0))}, []error{nil})
+				return c
+			},
+			unresolved: []types.ProviderTicker{bptTicker},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := balancer.NewPriceFetcherWithClient(logger, balancer.DefaultETHAPIConfig, tc.client())
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Equal(t, len(tc.expected), len(response.Resolved))
+			require.Equal(t, len(tc.unresolved), len(response.UnResolved))
+
+			for ticker, expected := range tc.expected {
+				require.Contains(t, response.Resolved, ticker)
+				require.InDelta(t, expected, mustFloat64(response.Resolved[ticker].Value), 1e-12*expected)
+			}
+
+			for _, ticker := range tc.unresolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+}
+
+func TestPoolConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  balancer.PoolConfig
+		err  bool
+	}{
+		{
+			name: "valid weighted config",
+			cfg:  balwethCfg,
+			err:  false,
+		},
+		{
+			name: "valid stable config",
+			cfg:  wstethwethCfg,
+			err:  false,
+		},
+		{
+			name: "valid rate config",
+			cfg:  bptCfg,
+			err:  false,
+		},
+		{
+			name: "valid oracle config",
+			cfg:  balwethOracleCfg,
+			err:  false,
+		},
+		{
+			name: "invalid pool id",
+			cfg:  balancer.PoolConfig{PoolID: "0x5c6ee304399dbdb9c8ef030ab642b10820db8f56", QuoteIndex: 1, Decimals: []int64{18, 18}},
+			err:  true,
+		},
+		{
+			name: "unresolved method",
+			cfg:  balancer.PoolConfig{PoolID: balwethCfg.PoolID, Method: "spot", QuoteIndex: 1, Decimals: []int64{18, 18}},
+			err:  true,
+		},
+		{
+			name: "same token indices",
+			cfg:  balancer.PoolConfig{PoolID: balwethCfg.PoolID, Decimals: []int64{18, 18}},
+			err:  true,
+		},
+		{
+			name: "missing decimals",
+			cfg:  balancer.PoolConfig{PoolID: balwethCfg.PoolID, QuoteIndex: 1, Decimals: []int64{18}},
+			err:  true,
+		},
+		{
+			name: "oracle on a token index above one",
+			cfg:  balancer.PoolConfig{PoolID: balwethCfg.PoolID, Method: balancer.MethodOracle, QuoteIndex: 2},
+			err:  true,
+		},
+		{
+			name: "invalid vault",
+			cfg:  balancer.PoolConfig{PoolID: balwethCfg.PoolID, QuoteIndex: 1, Decimals: []int64{18, 18}, Vault: "0x1234"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	require.Equal(t, common.HexToAddress("0x5c6ee304399dbdb9c8ef030ab642b10820db8f56"), balwethCfg.GetPoolAddress())
+	require.Equal(t, common.HexToAddress(balancer.DefaultVaultAddress), balwethCfg.GetVault())
+	require.Equal(t, balancer.MethodWeighted, balwethCfg.GetMethod())
+	require.Equal(t, int64(balancer.DefaultTWAPWindow), bptCfg.GetTWAPWindow())
+}
+
+func mustBigInt(t *testing.T, value string) *big.Int {
+	t.Helper()
+
+	v, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok)
+	return v
+}
+
+func encodeOutputs(t *testing.T, method string, values ...interface{}) string {
+	t.Helper()
+
+	contractABI, err := pool.PoolMetaData.GetAbi()
+	require.NoError(t, err)
+
+	return pack(t, contractABI, method, values...)
+}
+
+func encodePoolTokens(t *testing.T, tokens []common.Address, balances ...string) string {
+	t.Helper()
+
+	contractABI, err := vault.VaultMetaData.GetAbi()
+	require.NoError(t, err)
+
+	values := make([]*big.Int, len(balances))
+	for i, balance := range balances {
+		values[i] = mustBigInt(t, balance)
+	}
+
+	return pack(t, contractABI, balancer.GetPoolTokensMethod, tokens, values, big.NewInt(1))
+}
+
+func pack(t *testing.T, contractABI *abi.ABI, method string, values ...interface{}) string {
+	t.Helper()
+
+	bz, err := contractABI.Methods[method].Outputs.Pack(values...)
+	require.NoError(t, err)
+
+	return hexutil.Encode(bz)
+}
+
+func expectBatchCall(
+	t *testing.T,
+	c *mocks.EVMClient,
+	responses []string,
+	errs []error,
+) {
+	t.Helper()
+
+	c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+		// The last call reads the block at which the other calls are read, if any.
+		if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+			*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+			elems = elems[:n-1]
+		}
+		require.Equal(t, len(elems), len(responses))
+		require.Equal(t, len(elems), len(errs))
+
+		for i, elem := range elems {
+			elem.Result = &responses[i]
+			elem.Error = errs[i]
+			elems[i] = elem
+		}
+	}).Once()
+}
@@ -0,0 +1,143 @@
+package balancer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/skip-mev/connect/v2/pkg/math"
+)
+
+const (
+	// precision is the precision of the floats used in the pool math.
+	precision = 256
+
+	// maxInvariantIterations is the maximum number of newton iterations used to compute the
+	// invariant of a stable pool. This matches the Balancer StableMath library.
+	maxInvariantIterations = 255
+)
+
+// NormalizeAmount converts a raw token amount to a float, normalized by the token's decimals.
+func NormalizeAmount(amount *big.Int, decimals int64) *big.Float {
+	value := new(big.Float).SetPrec(precision).SetInt(amount)
+	return value.Mul(value, math.GetScalingFactor(0, decimals))
+}
+
+// CalculateWeightedSpotPrice calculates the spot price of the base token in units of the quote
+// token for a weighted pool. The balances must be normalized by the token decimals. This
+// calculation is equivalent to:
+//
+// price = (quoteBalance / quoteWeight) / (baseBalance / baseWeight).
+func CalculateWeightedSpotPrice(
+	baseBalance, baseWeight, quoteBalance, quoteWeight *big.Float,
+) (*big.Float, error) {
+	if baseBalance.Sign() <= 0 || quoteBalance.Sign() <= 0 {
+		return nil, fmt.Errorf("pool balances must be positive")
+	}
+
+	if baseWeight.Sign() <= 0 || quoteWeight.Sign() <= 0 {
+		return nil, fmt.Errorf("pool weights must be positive")
+	}
+
+	quote := new(big.Float).SetPrec(precision).Quo(quoteBalance, quoteWeight)
+	base := new(big.Float).SetPrec(precision).Quo(baseBalance, baseWeight)
+	return quote.Quo(quote, base), nil
+}
+
+// CalculateStableSpotPrice calculates the spot price of the token at the base index in units of
+// the token at the quote index for a stable pool. The balances must be normalized by the token
+// decimals, and amp is the amplification parameter divided by its precision. The stable invariant
+// D satisfies
+//
+// A * n^n * S + D = A * D * n^n + D^(n+1) / (n^n * P)
+//
+// where S is the sum and P is the product of the balances. The spot price is the ratio of the
+// partial derivatives of the invariant with respect to the base and quote balances:
+//
+// price = (A * n^n + D^(n+1) / (n^n * P * x_base)) / (A * n^n + D^(n+1) / (n^n * P * x_quote)).
+func CalculateStableSpotPrice(
+	balances []*big.Float,
+	amp *big.Float,
+	base, quote int,
+) (*big.Float, error) {
+	if base < 0 || quote < 0 || base >= len(balances) || quote >= len(balances) {
+		return nil, fmt.Errorf("token index out of range")
+	}
+
+	if amp.Sign() <= 0 {
+		return nil, fmt.Errorf("amplification parameter must be positive")
+	}
+
+	for _, balance := range balances {
+		if balance.Sign() <= 0 {
+			return nil, fmt.Errorf("pool balances must be positive")
+		}
+	}
+
+	n := new(big.Float).SetPrec(precision).SetInt64(int64(len(balances)))
+
+	// Balancer stores the amplification parameter as A * n^(n-1), so A * n^n = amp * n.
+	ann := new(big.Float).SetPrec(precision).Mul(amp, n)
+
+	invariant, err := calculateStableInvariant(balances, ann, n)
+	if err != nil {
+		return nil, err
+	}
+
+	// dp = D^(n+1) / (n^n * P).
+	dp := new(big.Float).SetPrec(precision).Set(invariant)
+	for _, balance := range balances {
+		dp.Mul(dp, invariant)
+		dp.Quo(dp, new(big.Float).SetPrec(precision).Mul(balance, n))
+	}
+
+	derivative := func(balance *big.Float) *big.Float {
+		d := new(big.Float).SetPrec(precision).Quo(dp, balance)
+		return d.Add(d, ann)
+	}
+
+	price := derivative(balances[base])
+	return price.Quo(price, derivative(balances[quote])), nil
+}
+
+// calculateStableInvariant computes the invariant of a stable pool via newton's method, mirroring
+// the Balancer StableMath library.
+func calculateStableInvariant(balances []*big.Float, ann, n *big.Float) (*big.Float, error) {
+	sum := new(big.Float).SetPrec(precision)
+	for _, balance := range balances {
+		sum.Add(sum, balance)
+	}
+
+	one := big.NewFloat(1)
+	nPlusOne := new(big.Float).SetPrec(precision).Add(n, one)
+	annMinusOne := new(big.Float).SetPrec(precision).Sub(ann, one)
+
+	// The invariant has converged once it changes by less than 1e-18 of its value.
+	tolerance := new(big.Float).SetPrec(precision).Mul(sum, math.GetScalingFactor(0, FixedPointDecimals))
+
+	invariant := new(big.Float).SetPrec(precision).Set(sum)
+	for i := 0; i < maxInvariantIterations; i++ {
+		dp := new(big.Float).SetPrec(precision).Set(invariant)
+		for _, balance := range balances {
+			dp.Mul(dp, invariant)
+			dp.Quo(dp, new(big.Float).SetPrec(precision).Mul(balance, n))
+		}
+
+		// D = (ann * S + dp * n) * D / ((ann - 1) * D + (n + 1) * dp).
+		numerator := new(big.Float).SetPrec(precision).Mul(ann, sum)
+		numerator.Add(numerator, new(big.Float).SetPrec(precision).Mul(dp, n))
+		numerator.Mul(numerator, invariant)
+
+		denominator := new(big.Float).SetPrec(precision).Mul(annMinusOne, invariant)
+		denominator.Add(denominator, new(big.Float).SetPrec(precision).Mul(nPlusOne, dp))
+
+		previous := invariant
+		invariant = numerator.Quo(numerator, denominator)
+
+		diff := new(big.Float).SetPrec(precision).Sub(invariant, previous)
+		if diff.Abs(diff).Cmp(tolerance) <= 0 {
+			return invariant, nil
+		}
+	}
+
+	return nil, fmt.Errorf("stable invariant did not converge")
+}
@@ -0,0 +1,97 @@
+package balancer_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer"
+)
+
+func TestNormalizeAmount(t *testing.T) {
+	amount, ok := new(big.Int).SetString("1500000000000000000", 10)
+	require.True(t, ok)
+
+	require.InDelta(t, 1.5, mustFloat64(balancer.NormalizeAmount(amount, 18)), 1e-12)
+	require.InDelta(t, 1.5, mustFloat64(balancer.NormalizeAmount(big.NewInt(1500000), 6)), 1e-12)
+}
+
+func TestCalculateWeightedSpotPrice(t *testing.T) {
+	t.Run("80/20 pool", func(t *testing.T) {
+		// 1000 WETH at a weight of 0.8 and 500,000 USDC at a weight of 0.2.
+		price, err := balancer.CalculateWeightedSpotPrice(
+			big.NewFloat(1000),
+			big.NewFloat(0.8),
+			big.NewFloat(500000),
+			big.NewFloat(0.2),
+		)
+		require.NoError(t, err)
+		require.InDelta(t, 2000, mustFloat64(price), 1e-9)
+	})
+
+	t.Run("empty pool", func(t *testing.T) {
+		_, err := balancer.CalculateWeightedSpotPrice(
+			big.NewFloat(0),
+			big.NewFloat(0.5),
+			big.NewFloat(100),
+			big.NewFloat(0.5),
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("zero weight", func(t *testing.T) {
+		_, err := balancer.CalculateWeightedSpotPrice(
+			big.NewFloat(100),
+			big.NewFloat(0),
+			big.NewFloat(100),
+			big.NewFloat(0.5),
+		)
+		require.Error(t, err)
+	})
+}
+
+func TestCalculateStableSpotPrice(t *testing.T) {
+	t.Run("balanced pool", func(t *testing.T) {
+		balances := []*big.Float{big.NewFloat(1000), big.NewFloat(1000), big.NewFloat(1000)}
+		price, err := balancer.CalculateStableSpotPrice(balances, big.NewFloat(200), 0, 2)
+		require.NoError(t, err)
+		require.InDelta(t, 1, mustFloat64(price), 1e-12)
+	})
+
+	t.Run("imbalanced pool", func(t *testing.T) {
+		balances := []*big.Float{big.NewFloat(1000), big.NewFloat(1100)}
+		price, err := balancer.CalculateStableSpotPrice(balances, big.NewFloat(100), 0, 1)
+		require.NoError(t, err)
+		require.InDelta(t, 1.000947616899275520786, mustFloat64(price), 1e-12)
+
+		// The price of the quote token in units of the base token is the inverse.
+		inverse, err := balancer.CalculateStableSpotPrice(balances, big.NewFloat(100), 1, 0)
+		require.NoError(t, err)
+		require.InDelta(t, 1, mustFloat64(new(big.Float).Mul(price, inverse)), 1e-12)
+	})
+
+	t.Run("low amplification approaches the constant product price", func(t *testing.T) {
+		balances := []*big.Float{big.NewFloat(100), big.NewFloat(200)}
+		price, err := balancer.CalculateStableSpotPrice(balances, big.NewFloat(1e-12), 0, 1)
+		require.NoError(t, err)
+		require.InDelta(t, 2, mustFloat64(price), 1e-6)
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		balances := []*big.Float{big.NewFloat(100), big.NewFloat(200)}
+		_, err := balancer.CalculateStableSpotPrice(balances, big.NewFloat(100), 0, 2)
+		require.Error(t, err)
+	})
+
+	t.Run("empty balance", func(t *testing.T) {
+		balances := []*big.Float{big.NewFloat(100), big.NewFloat(0)}
+		_, err := balancer.CalculateStableSpotPrice(balances, big.NewFloat(100), 0, 1)
+		require.Error(t, err)
+	})
+}
+
+func mustFloat64(f *big.Float) float64 {
+	v, _ := f.Float64()
+	return v
+}
@@ -0,0 +1,216 @@
+package balancer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+const (
+	// BaseName is the name of the Balancer API.
+	BaseName = "balancer_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// MethodWeighted prices the base token in units of the quote token from the balances and
+	// normalized weights of a weighted pool.
+	MethodWeighted = "weighted"
+
+	// MethodStable prices the base token in units of the quote token from the balances and
+	// amplification parameter of a stable pool.
+	MethodStable = "stable"
+
+	// MethodRate prices the pool's BPT in units of the pool's underlying tokens via the getRate
+	// method of the pool.
+	MethodRate = "rate"
+
+	// MethodOracle prices the base token in units of the quote token via the time weighted
+	// average pair price of the pool's built-in oracle. This is only available on two token pools
+	// with the oracle enabled, and is resistant to single block price manipulation.
+	MethodOracle = "oracle"
+
+	// GetPoolTokensMethod is the vault method that returns the tokens and balances of a pool.
+	GetPoolTokensMethod = "getPoolTokens"
+
+	// GetNormalizedWeightsMethod is the weighted pool method that returns the normalized weights
+	// of the pool's tokens.
+	GetNormalizedWeightsMethod = "getNormalizedWeights"
+
+	// GetAmplificationParameterMethod is the stable pool method that returns the amplification
+	// parameter of the pool.
+	GetAmplificationParameterMethod = "getAmplificationParameter"
+
+	// GetRateMethod is the pool method that returns the rate of the pool's BPT.
+	GetRateMethod = "getRate"
+
+	// GetTimeWeightedAverageMethod is the pool method that returns time weighted averages from
+	// the pool's built-in oracle.
+	GetTimeWeightedAverageMethod = "getTimeWeightedAverage"
+
+	// FixedPointDecimals is the number of decimals of the weights, rates and oracle prices
+	// returned by Balancer pools.
+	FixedPointDecimals = 18
+
+	// DefaultVaultAddress is the address of the Balancer V2 Vault. The vault is deployed to the
+	// same address on all chains.
+	DefaultVaultAddress = "0xBA12222222228d8Ba445958a75a0704d566BF2C8"
+
+	// DefaultTWAPWindow is the default window, in seconds, of the oracle's time weighted average.
+	DefaultTWAPWindow = 300
+
+	// ETH_URL is the URL for the Balancer API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// PoolConfig is the configuration for a Balancer pool. This is specific to each pair of tokens.
+type PoolConfig struct {
+	// PoolID is the id of the pool in the vault. The first 20 bytes of the id are the address of
+	// the pool contract.
+	PoolID string `json:"pool_id"`
+	// Method is the method used to derive the price. This must be one of weighted, stable, rate
+	// or oracle. If unset, weighted is used.
+	Method string `json:"method"`
+	// BaseIndex is the index of the base token in the pool's tokens, as returned by the vault.
+	BaseIndex int64 `json:"base_index"`
+	// QuoteIndex is the index of the quote token in the pool's tokens, as returned by the vault.
+	QuoteIndex int64 `json:"quote_index"`
+	// Decimals are the decimals of each of the pool's tokens, in the order returned by the vault.
+	// These are used to normalize the balances of the pool for the weighted and stable methods.
+	Decimals []int64 `json:"decimals" schema:"decimals"`
+	// TWAPWindow is the window, in seconds, of the time weighted average used by the oracle
+	// method. If unset, DefaultTWAPWindow is used.
+	TWAPWindow int64 `json:"twap_window"`
+	// Vault is the address of the vault. If unset, DefaultVaultAddress is used.
+	Vault string `json:"vault" schema:"address"`
+}
+
+// ValidateBasic validates the pool configuration.
+func (pc *PoolConfig) ValidateBasic() error {
+	id, err := hexutil.Decode(pc.PoolID)
+	if err != nil || len(id) != 32 {
+		return fmt.Errorf("pool id must be a 32 byte hex string")
+	}
+
+	if pc.Vault != "" && !common.IsHexAddress(pc.Vault) {
+		return fmt.Errorf("vault address is not a valid ethereum address")
+	}
+
+	if pc.TWAPWindow < 0 {
+		return fmt.Errorf("twap window must be non-negative")
+	}
+
+	switch pc.Method {
+	case MethodRate:
+		return nil
+	case "", MethodWeighted, MethodStable, MethodOracle:
+	default:
+		return fmt.Errorf("unknown pool method: %s", pc.Method)
+	}
+
+	if pc.BaseIndex < 0 || pc.QuoteIndex < 0 {
+		return fmt.Errorf("token indices must be non-negative")
+	}
+
+	if pc.BaseIndex == pc.QuoteIndex {
+		return fmt.Errorf("base and quote token indices must be different")
+	}
+
+	if pc.Method == MethodOracle {
+		if pc.BaseIndex > 1 || pc.QuoteIndex > 1 {
+			return fmt.Errorf("the oracle is only available on two token pools")
+		}
+
+		return nil
+	}
+
+	if int64(len(pc.Decimals)) <= max(pc.BaseIndex, pc.QuoteIndex) {
+		return fmt.Errorf("decimals must be set for the base and quote tokens")
+	}
+
+	for _, decimals := range pc.Decimals {
+		if decimals < 0 {
+			return fmt.Errorf("decimals must be non-negative")
+		}
+	}
+
+	return nil
+}
+
+// GetMethod returns the method used to derive the price.
+func (pc *PoolConfig) GetMethod() string {
+	if pc.Method == "" {
+		return MethodWeighted
+	}
+
+	return pc.Method
+}
+
+// GetPoolAddress returns the address of the pool contract, which is encoded in the pool id.
+func (pc *PoolConfig) GetPoolAddress() common.Address {
+	return common.BytesToAddress(common.FromHex(pc.PoolID)[:common.AddressLength])
+}
+
+// GetVault returns the address of the vault.
+func (pc *PoolConfig) GetVault() common.Address {
+	if pc.Vault == "" {
+		return common.HexToAddress(DefaultVaultAddress)
+	}
+
+	return common.HexToAddress(pc.Vault)
+}
+
+// GetTWAPWindow returns the window, in seconds, of the oracle's time weighted average.
+func (pc *PoolConfig) GetTWAPWindow() int64 {
+	if pc.TWAPWindow == 0 {
+		return DefaultTWAPWindow
+	}
+
+	return pc.TWAPWindow
+}
+
+// MustToJSON converts the pool configuration to JSON.
+func (pc PoolConfig) MustToJSON() string {
+	b, err := json.Marshal(pc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// DefaultETHAPIConfig is the default configuration for the Balancer API. Specifically this is for
+// Ethereum mainnet.
+var DefaultETHAPIConfig = config.APIConfig{
+	Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+	Atomic:            true,
+	Enabled:           true,
+	Timeout:           1000 * time.Millisecond,
+	Interval:          2000 * time.Millisecond,
+	ReconnectTimeout:  2000 * time.Millisecond,
+	MaxQueries:        1,
+	Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+	MaxBlockHeightAge: 30 * time.Second,
+}
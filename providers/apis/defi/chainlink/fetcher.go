@@ -0,0 +1,389 @@
+package chainlink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/chainlink/aggregator"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// PriceFetcher is the Chainlink price fetcher. This fetcher is responsible for querying Chainlink
+// AggregatorV3Interface contracts and returning the price of a given ticker. The price is derived
+// from the answer of the latest round of the feed, scaled by the decimals of the feed.
+//
+// To read more about Chainlink data feeds, see the Chainlink documentation
+// https://docs.chain.link/data-feeds/api-reference.
+//
+// Similar to the Uniswap V3 fetcher, we utilize the eth client's BatchCallContext to batch the calls
+// to the ethereum network.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the EVM client implementation. This is used to interact with the ethereum network.
+	client ethmulticlient.EVMClient
+	// abi is the aggregator v3 abi. This is used to pack the latestRoundData call to the feed contract
+	// and parse the result.
+	abi *abi.ABI
+	// payload is the packed latestRoundData call to the feed contract. Since the payload is the same
+	// for all feeds, we can reuse this payload for all feeds.
+	payload []byte
+
+	mtx sync.Mutex
+	// feedCache is a cache of the tickers to feed configs. This is used to avoid unmarshalling
+	// the metadata for each ticker.
+	feedCache map[types.ProviderTicker]FeedConfig
+}
+
+// NewPriceFetcher returns a new Chainlink price fetcher.
+func NewPriceFetcher(
+	ctx context.Context,
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("api metrics is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if !IsValidProviderName(api.Name) {
+		return nil, fmt.Errorf("invalid api config name %s", api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	client, err := ethmulticlient.NewClientFromEndpoints(
+		ctx,
+		logger,
+		api,
+		apiMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client ethmulticlient.EVMClient,
+) (*PriceFetcher, error) {
+	abi, err := aggregator.AggregatorMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aggregator abi: %w", err)
+	}
+
+	payload, err := abi.Pack(ContractMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack latestRoundData: %w", err)
+	}
+
+	return &PriceFetcher{
+		logger:    logger.With(zap.String("fetcher", api.Name)),
+		api:       api,
+		client:    client,
+		abi:       abi,
+		payload:   payload,
+		feedCache: make(map[types.ProviderTicker]FeedConfig),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. This fetch utilizes the batch call to lower
+// overhead of making individual RPC calls for each ticker. The fetcher will query the latest round
+// of each feed contract, validate that the round is complete and not stale, and scale the answer
+// by the decimals of the feed.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+	)
+
+	// Create a batch element for each ticker and feed.
+	batchElems := make([]rpc.BatchElem, len(tickers))
+	feeds := make([]FeedConfig, len(tickers))
+	for i, ticker := range tickers {
+		feed, err := f.GetFeed(ticker)
+		if err != nil {
+			f.logger.Debug(
+				"failed to get feed for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to get feed: %w", err),
+					providertypes.ErrorFailedToDecode,
+				),
+			)
+		}
+
+		// Create a batch element for the ticker and feed.
+		var result string
+		batchElems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   common.HexToAddress(feed.Address),
+					"data": hexutil.Bytes(f.payload), // latestRoundData call to the feed contract.
+				},
+				f.api.GetBlockTag(), // the configured block tag, latest by default.
+			},
+			Result: &result,
+		}
+		feeds[i] = feed
+	}
+
+	// Batch call to the EVM.
+	block, err := ethmulticlient.BatchCallWithBlock(ctx, f.client, f.api.GetBlockTag(), batchElems)
+	if err != nil {
+		f.logger.Debug(
+			"failed to batch call to ethereum network for all tickers",
+			zap.Error(err),
+		)
+
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+		)
+	}
+
+	// Parse the result from the batch call for each ticker.
+	now := time.Now().UTC()
+	for i, ticker := range tickers {
+		result := batchElems[i]
+		if result.Error != nil {
+			f.logger.Debug(
+				"failed to batch call to ethereum network for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(result.Error),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					result.Error,
+					ethmulticlient.ErrorCodeFromError(result.Error, providertypes.ErrorUnknown),
+				),
+			}
+
+			continue
+		}
+
+		// Parse the latest round data from the result.
+		round, err := f.ParseRoundData(result.Result)
+		if err != nil {
+			f.logger.Debug(
+				"failed to parse round data",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorFailedToParsePrice,
+				),
+			}
+
+			continue
+		}
+
+		// Ensure that the round is complete and recent enough to be used.
+		if err := round.ValidateBasic(now, feeds[i].GetMaxAge()); err != nil {
+			f.logger.Debug(
+				"invalid round data",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorCodeFromError(err, providertypes.ErrorInvalidResponse),
+				),
+			}
+
+			continue
+		}
+
+		price, err := ScalePrice(feeds[i], round.Answer)
+		if err != nil {
+			f.logger.Debug(
+				"failed to scale price",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorFailedToParsePrice,
+				),
+			}
+
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResultWithBlock(price, now, block)
+	}
+
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// GetFeed returns the chainlink feed for the given ticker. This will unmarshal the metadata
+// and validate the feed config which contains all required information to query the EVM.
+func (f *PriceFetcher) GetFeed(
+	ticker types.ProviderTicker,
+) (FeedConfig, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if feed, ok := f.feedCache[ticker]; ok {
+		return feed, nil
+	}
+
+	var cfg FeedConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal feed config on ticker: %w", err)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid ticker feed config: %w", err)
+	}
+
+	f.feedCache[ticker] = cfg
+	return cfg, nil
+}
+
+// ParseRoundData parses the latest round data from the result of the batch call.
+func (f *PriceFetcher) ParseRoundData(
+	result interface{},
+) (RoundData, error) {
+	r, ok := result.(*string)
+	if !ok {
+		return RoundData{}, fmt.Errorf("expected result to be a string, got %T", result)
+	}
+
+	if r == nil {
+		return RoundData{}, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return RoundData{}, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := f.abi.Methods[ContractMethod].Outputs.UnpackValues(bz)
+	if err != nil {
+		return RoundData{}, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	return RoundData{
+		RoundID:         *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		Answer:          *abi.ConvertType(out[1], new(*big.Int)).(**big.Int),
+		StartedAt:       *abi.ConvertType(out[2], new(*big.Int)).(**big.Int),
+		UpdatedAt:       *abi.ConvertType(out[3], new(*big.Int)).(**big.Int),
+		AnsweredInRound: *abi.ConvertType(out[4], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// RoundData is the result of the latestRoundData call to an AggregatorV3Interface contract.
+type RoundData struct {
+	// RoundID is the id of the round.
+	RoundID *big.Int
+	// Answer is the unscaled answer of the round.
+	Answer *big.Int
+	// StartedAt is the unix timestamp at which the round started.
+	StartedAt *big.Int
+	// UpdatedAt is the unix timestamp at which the round was last updated.
+	UpdatedAt *big.Int
+	// AnsweredInRound is the id of the round in which the answer was computed.
+	AnsweredInRound *big.Int
+}
+
+// ValidateBasic ensures that the round is complete, was computed in the current round, has a
+// positive answer and is no older than the given max age. Stale rounds return an error with the
+// ErrorStalePrice code.
+func (rd RoundData) ValidateBasic(now time.Time, maxAge time.Duration) error {
+	if rd.Answer == nil || rd.Answer.Sign() <= 0 {
+		return fmt.Errorf("answer must be positive")
+	}
+
+	if rd.UpdatedAt == nil || rd.UpdatedAt.Sign() == 0 {
+		return fmt.Errorf("round is not complete")
+	}
+
+	if rd.RoundID == nil || rd.AnsweredInRound == nil || rd.AnsweredInRound.Cmp(rd.RoundID) < 0 {
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("answer is stale: answered in round %s, current round %s", rd.AnsweredInRound, rd.RoundID),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	updatedAt := time.Unix(rd.UpdatedAt.Int64(), 0)
+	if age := now.Sub(updatedAt); age > maxAge {
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("round updated at %s is older than max age %s", updatedAt.UTC(), maxAge),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	return nil
+}
+
+// ScalePrice scales the answer of a feed by the decimals of the feed.
+func ScalePrice(
+	cfg FeedConfig,
+	answer *big.Int,
+) (*big.Float, error) {
+	price, err := math.NewPrice(answer, -cfg.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
@@ -0,0 +1,135 @@
+package chainlink
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+	"github.com/skip-mev/connect/v2/pkg/math"
+)
+
+const (
+	// BaseName is the name of the Chainlink API.
+	BaseName = "chainlink_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// ContractMethod is the contract method to call for the Chainlink API.
+	ContractMethod = "latestRoundData"
+
+	// ETH_URL is the URL for the Chainlink API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+
+	// BASE_URL is the URL for the Chainlink API. This uses a free public RPC provider on Base Mainnet.
+	BASE_URL = "https://mainnet.base.org"
+
+	// DefaultMaxAge is the default maximum age of a feed's latest round. Chainlink feeds have a
+	// heartbeat of at most 24 hours, so a round older than this (with some leeway for the update
+	// to land on-chain) is considered stale.
+	DefaultMaxAge = 25 * time.Hour
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+	constants.BASE:     strings.Join([]string{BaseName, constants.BASE}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedConfig is the configuration for a Chainlink AggregatorV3Interface feed. This is specific to
+// each pair of tokens.
+type FeedConfig struct {
+	// Address is the Chainlink aggregator (or proxy) address.
+	Address string `json:"address" schema:"address"`
+	// Decimals is the number of decimals of the feed's answer. This should be derived from the
+	// decimals() method of the feed contract.
+	Decimals int64 `json:"decimals" schema:"decimals"`
+	// MaxAge is the maximum age, in seconds, of the feed's latest round. This should be set to the
+	// heartbeat of the feed. If unset, DefaultMaxAge is used.
+	MaxAge int64 `json:"max_age"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if !common.IsHexAddress(fc.Address) {
+		return fmt.Errorf("feed address is not a valid ethereum address")
+	}
+
+	if fc.Decimals < 0 {
+		return fmt.Errorf("decimals must be non-negative")
+	}
+
+	if fc.Decimals > math.MaxExponent {
+		return fmt.Errorf("decimals must be at most %d", math.MaxExponent)
+	}
+
+	if fc.MaxAge < 0 {
+		return fmt.Errorf("max age must be non-negative")
+	}
+
+	return nil
+}
+
+// GetMaxAge returns the maximum age of the feed's latest round.
+func (fc *FeedConfig) GetMaxAge() time.Duration {
+	if fc.MaxAge == 0 {
+		return DefaultMaxAge
+	}
+
+	return time.Duration(fc.MaxAge) * time.Second
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+var (
+	// DefaultETHAPIConfig is the default configuration for the Chainlink API. Specifically this is for
+	// Ethereum mainnet.
+	DefaultETHAPIConfig = config.APIConfig{
+		Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+		Atomic:            true,
+		Enabled:           true,
+		Timeout:           1000 * time.Millisecond,
+		Interval:          2000 * time.Millisecond,
+		ReconnectTimeout:  2000 * time.Millisecond,
+		MaxQueries:        1,
+		Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+		MaxBlockHeightAge: 30 * time.Second,
+	}
+
+	// DefaultBaseAPIConfig is the default configuration for the Chainlink API. Specifically this is for
+	// Base mainnet.
+	DefaultBaseAPIConfig = config.APIConfig{
+		Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.BASE),
+		Atomic:            true,
+		Enabled:           true,
+		Timeout:           1000 * time.Millisecond,
+		Interval:          2000 * time.Millisecond,
+		ReconnectTimeout:  2000 * time.Millisecond,
+		MaxQueries:        1,
+		Endpoints:         []config.Endpoint{{URL: BASE_URL}},
+		MaxBlockHeightAge: 30 * time.Second,
+	}
+)
@@ -0,0 +1,151 @@
+package cosmwasm
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
+
+// APIHandler implements the PriceAPIDataHandler interface for CosmWasm smart queries. Each
+// ticker is configured with the contract to query, the query message and the path of the price
+// in the response, so that any CosmWasm oracle or AMM can be used as a price source.
+type APIHandler struct {
+	// api is the config for the CosmWasm API.
+	api config.APIConfig
+}
+
+// NewAPIHandler returns a new CosmWasm PriceAPIDataHandler.
+func NewAPIHandler(
+	api config.APIConfig,
+) (types.PriceAPIDataHandler, error) {
+	if !IsValidProviderName(api.Name) {
+		return nil, fmt.Errorf("expected api config name %s or %s%s<chain>, got %s", BaseName, BaseName, NameSeparator, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config for %s: %w", api.Name, err)
+	}
+
+	return &APIHandler{
+		api: api,
+	}, nil
+}
+
+// CreateURL returns the smart query URL of the given ticker. Since each ticker queries its own
+// contract, this function will return an error if the ticker slice contains more than one ticker.
+func (h *APIHandler) CreateURL(
+	tickers []types.ProviderTicker,
+) (string, error) {
+	if len(tickers) != 1 {
+		return "", fmt.Errorf("expected 1 ticker, got %d", len(tickers))
+	}
+
+	cfg, err := GetQueryConfig(tickers[0])
+	if err != nil {
+		return "", err
+	}
+
+	return CreateURL(h.api.Endpoints[0].URL, cfg.ContractAddress, cfg.Query), nil
+}
+
+// ParseResponse parses the smart query response of a single ticker and extracts the price
+// using the price path of the ticker.
+func (h *APIHandler) ParseResponse(
+	tickers []types.ProviderTicker,
+	resp *http.Response,
+) types.PriceResponse {
+	if len(tickers) != 1 {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(
+				fmt.Errorf("expected 1 ticker, got %d", len(tickers)),
+				providertypes.ErrorInvalidResponse,
+			),
+		)
+	}
+
+	ticker := tickers[0]
+	cfg, err := GetQueryConfig(ticker)
+	if err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+		)
+	}
+
+	result, err := Decode(resp)
+	if err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+		)
+	}
+
+	if len(result.Data) == 0 {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+		)
+	}
+
+	value, err := SelectValue(result.Data, cfg.PricePath)
+	if err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+		)
+	}
+
+	price, err := math.Float64StringToBigFloat(value)
+	if err != nil {
+		wErr := fmt.Errorf("failed to convert price %s to big.Float: %w", value, err)
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(wErr, providertypes.ErrorFailedToParsePrice),
+		)
+	}
+
+	if cfg.Invert {
+		if price.Sign() == 0 {
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(fmt.Errorf("cannot invert a zero price"), providertypes.ErrorFailedToParsePrice),
+			)
+		}
+		price = new(big.Float).Quo(big.NewFloat(1), price)
+	}
+
+	return types.NewPriceResponse(
+		types.ResolvedPrices{
+			ticker: types.NewPriceResult(price, time.Now().UTC()),
+		},
+		nil,
+	)
+}
+
+// GetQueryConfig returns the query configuration stored in the metadata of the ticker.
+func GetQueryConfig(ticker types.ProviderTicker) (QueryConfig, error) {
+	var cfg QueryConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal query config on ticker: %w", err)
+	}
+
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid query config: %w", err)
+	}
+
+	return cfg, nil
+}
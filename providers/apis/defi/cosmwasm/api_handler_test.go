@@ -0,0 +1,289 @@
+package cosmwasm_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/cosmwasm"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+const contractAddress = "neutron1qqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0s8jevwd"
+
+var (
+	ntrnusd = types.DefaultProviderTicker{
+		OffChainTicker: "NTRN/USD",
+		JSON: cosmwasm.QueryConfig{
+			ContractAddress: contractAddress,
+			Query:           json.RawMessage(`{"price":{"denom":"untrn"}}`),
+			PricePath:       "$.price",
+		}.MustToJSON(),
+	}
+	ntrnusdPool = types.DefaultProviderTicker{
+		OffChainTicker: "NTRN/USD",
+		JSON: cosmwasm.QueryConfig{
+			ContractAddress: contractAddress,
+			Query:           json.RawMessage(`{"price":{"denom":"untrn"}}`),
+			PricePath:       "pools.1.spot_price",
+		}.MustToJSON(),
+	}
+	usdntrn = types.DefaultProviderTicker{
+		OffChainTicker: "USD/NTRN",
+		JSON: cosmwasm.QueryConfig{
+			ContractAddress: contractAddress,
+			Query:           json.RawMessage(`{"price":{"denom":"untrn"}}`),
+			PricePath:       "price",
+			Invert:          true,
+		}.MustToJSON(),
+	}
+	noConfig = types.DefaultProviderTicker{
+		OffChainTicker: "NTRN/USD",
+	}
+
+	apiConfig = config.APIConfig{
+		Name:             cosmwasm.ProviderNames[constants.NEUTRON],
+		Atomic:           false,
+		Enabled:          true,
+		Timeout:          500 * time.Millisecond,
+		Interval:         1 * time.Second,
+		ReconnectTimeout: 2 * time.Second,
+		MaxQueries:       1,
+		Endpoints:        []config.Endpoint{{URL: "http://localhost:1317/"}},
+	}
+)
+
+func TestNewAPIHandler(t *testing.T) {
+	t.Run("base name", func(t *testing.T) {
+		cfg := apiConfig
+		cfg.Name = cosmwasm.BaseName
+		_, err := cosmwasm.NewAPIHandler(cfg)
+		require.NoError(t, err)
+	})
+
+	t.Run("dynamic name", func(t *testing.T) {
+		_, err := cosmwasm.NewAPIHandler(apiConfig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		cfg := apiConfig
+		cfg.Name = cosmwasm.BaseName + cosmwasm.NameSeparator
+		_, err := cosmwasm.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		cfg := apiConfig
+		cfg.Enabled = false
+		_, err := cosmwasm.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cps         []types.ProviderTicker
+		url         string
+		expectedErr bool
+	}{
+		{
+			name:        "empty",
+			cps:         []types.ProviderTicker{},
+			url:         "",
+			expectedErr: true,
+		},
+		{
+			name: "valid",
+			cps: []types.ProviderTicker{
+				ntrnusd,
+			},
+			url: "http://localhost:1317/cosmwasm/wasm/v1/contract/" + contractAddress +
+				"/smart/eyJwcmljZSI6eyJkZW5vbSI6InVudHJuIn19",
+			expectedErr: false,
+		},
+		{
+			name: "multiple tickers",
+			cps: []types.ProviderTicker{
+				ntrnusd,
+				usdntrn,
+			},
+			url:         "",
+			expectedErr: true,
+		},
+		{
+			name: "no query config",
+			cps: []types.ProviderTicker{
+				noConfig,
+			},
+			url:         "",
+			expectedErr: true,
+		},
+	}
+
+	h, err := cosmwasm.NewAPIHandler(apiConfig)
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url, err := h.CreateURL(tc.cps)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.url, url)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cps      []types.ProviderTicker
+		response *http.Response
+		expected types.PriceResponse
+	}{
+		{
+			name: "valid string price",
+			cps: []types.ProviderTicker{
+				ntrnusd,
+			},
+			response: testutils.CreateResponseFromJSON(`{"data":{"price":"0.5"}}`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					ntrnusd: {
+						Value: big.NewFloat(0.5),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "valid number price in an array",
+			cps: []types.ProviderTicker{
+				ntrnusdPool,
+			},
+			response: testutils.CreateResponseFromJSON(`{"data":{"pools":[{"spot_price":1},{"spot_price":0.25}]}}`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					ntrnusdPool: {
+						Value: big.NewFloat(0.25),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "valid inverted price",
+			cps: []types.ProviderTicker{
+				usdntrn,
+			},
+			response: testutils.CreateResponseFromJSON(`{"data":{"price":"0.5"}}`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					usdntrn: {
+						Value: big.NewFloat(2),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "price path not found",
+			cps: []types.ProviderTicker{
+				ntrnusdPool,
+			},
+			response: testutils.CreateResponseFromJSON(`{"data":{"pools":[{"spot_price":1}]}}`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					ntrnusdPool: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("index out of range"), providertypes.ErrorInvalidResponse),
+					},
+				},
+			),
+		},
+		{
+			name: "price is not a number",
+			cps: []types.ProviderTicker{
+				ntrnusd,
+			},
+			response: testutils.CreateResponseFromJSON(`{"data":{"price":"abc"}}`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					ntrnusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("failed to parse price"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "empty data",
+			cps: []types.ProviderTicker{
+				ntrnusd,
+			},
+			response: testutils.CreateResponseFromJSON(`{}`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					ntrnusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+				},
+			),
+		},
+		{
+			name: "bad response",
+			cps: []types.ProviderTicker{
+				ntrnusd,
+			},
+			response: testutils.CreateResponseFromJSON(`shout out my label thats me`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					ntrnusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("bad format"), providertypes.ErrorFailedToDecode),
+					},
+				},
+			),
+		},
+	}
+
+	h, err := cosmwasm.NewAPIHandler(apiConfig)
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			resp := h.ParseResponse(tc.cps, tc.response)
+
+			require.Len(t, resp.Resolved, len(tc.expected.Resolved))
+			require.Len(t, resp.UnResolved, len(tc.expected.UnResolved))
+
+			for cp, result := range tc.expected.Resolved {
+				require.Contains(t, resp.Resolved, cp)
+				r := resp.Resolved[cp]
+				require.Equal(t, result.Value.SetPrec(18), r.Value.SetPrec(18))
+				require.True(t, r.Timestamp.After(now))
+			}
+
+			for cp := range tc.expected.UnResolved {
+				require.Contains(t, resp.UnResolved, cp)
+				require.Error(t, resp.UnResolved[cp])
+				require.Equal(t, tc.expected.UnResolved[cp].Code(), resp.UnResolved[cp].Code())
+			}
+		})
+	}
+}
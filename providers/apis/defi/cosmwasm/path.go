@@ -0,0 +1,64 @@
+package cosmwasm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParsePath splits a JSONPath-style price path into its elements. The optional root ($) is
+// dropped, so $.pools.0.price, .pools.0.price and pools.0.price are equivalent.
+func ParsePath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimSpace(path), PathRoot)
+	path = strings.TrimPrefix(path, PathSeparator)
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, PathSeparator)
+}
+
+// SelectValue selects the value at the given path in the JSON data and returns it as a string.
+// The value must be a number or a string.
+func SelectValue(data []byte, path string) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	for _, elem := range ParsePath(path) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			next, ok := v[elem]
+			if !ok {
+				return "", fmt.Errorf("key %s not found at path %s", elem, path)
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(elem)
+			if err != nil {
+				return "", fmt.Errorf("expected an array index at path %s, got %s", path, elem)
+			}
+			if index < 0 || index >= len(v) {
+				return "", fmt.Errorf("index %d out of range at path %s", index, path)
+			}
+			value = v[index]
+		default:
+			return "", fmt.Errorf("cannot select %s of a %T at path %s", elem, value, path)
+		}
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		return v.String(), nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("expected a number or string at path %s, got %T", path, value)
+	}
+}
@@ -0,0 +1,154 @@
+package cosmwasm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+// NOTE: All documentation for this file can be located on the CosmWasm docs.
+// API documentation: https://docs.cosmwasm.com. Smart queries are served by the x/wasm
+// module of any CosmWasm enabled chain via its LCD (REST) endpoint.
+
+const (
+	// BaseName is the name of the CosmWasm API. Dynamic provider naming is supported via
+	// `BaseName“NameSeparator“Chain`, e.g. cosmwasm_api-neutron, so that several chains can
+	// be queried at the same time.
+	BaseName = "cosmwasm_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// SmartQueryURLSuffix is the path of the x/wasm smart query. This includes the contract
+	// address and the base64 encoded query message that need to be inserted into the URL.
+	SmartQueryURLSuffix = "cosmwasm/wasm/v1/contract/%s/smart/%s"
+
+	// URLSeparator is the separator between the base URL and the smart query path.
+	URLSeparator = "/"
+
+	// PathSeparator is the separator of the elements of a price path.
+	PathSeparator = "."
+
+	// PathRoot is the optional JSONPath-style root of a price path.
+	PathRoot = "$"
+
+	// NeutronURL is the URL of a public Neutron LCD endpoint.
+	NeutronURL = "https://neutron-api.polkachu.com"
+)
+
+// ProviderNames is the set of "dynamic" names with a default configuration mapped by chain.
+// Any other chain can be queried by configuring a provider named `BaseName“NameSeparator“Chain`
+// with an LCD endpoint of the chain.
+var ProviderNames = map[string]string{
+	constants.NEUTRON: strings.Join([]string{BaseName, constants.NEUTRON}, NameSeparator),
+}
+
+// DefaultNeutronAPIConfig is the default configuration for the CosmWasm API on Neutron.
+var DefaultNeutronAPIConfig = config.APIConfig{
+	Name:             ProviderNames[constants.NEUTRON],
+	Atomic:           false,
+	Enabled:          true,
+	Timeout:          5 * time.Second,
+	Interval:         2000 * time.Millisecond,
+	ReconnectTimeout: 5 * time.Second,
+	MaxQueries:       10,
+	BatchSize:        1,
+	Endpoints:        []config.Endpoint{{URL: NeutronURL}},
+}
+
+// IsValidProviderName returns true if the name is the base name of the provider or a dynamic
+// name of the form `BaseName“NameSeparator“Chain`.
+func IsValidProviderName(name string) bool {
+	if name == BaseName {
+		return true
+	}
+
+	chain, ok := strings.CutPrefix(name, BaseName+NameSeparator)
+	return ok && len(chain) > 0
+}
+
+// QueryConfig is the metadata that must be set on each ticker. It configures the smart query
+// that is issued against the contract and how the price is extracted from the response.
+type QueryConfig struct {
+	// ContractAddress is the bech32 address of the contract to query.
+	ContractAddress string `json:"contract_address"`
+	// Query is the JSON query message that is sent to the contract, e.g. {"price":{"denom":"untrn"}}.
+	Query json.RawMessage `json:"query"`
+	// PricePath is the JSONPath-style selector of the price in the data of the query response,
+	// e.g. $.price or pools.0.spot_price. Keys are separated by dots and array elements are
+	// selected by their index. The selected value must be a number or a string encoded number.
+	PricePath string `json:"price_path"`
+	// Invert is true if the selected price should be inverted, i.e. the contract reports the
+	// price of the quote in units of the base.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// ValidateBasic validates the query configuration.
+func (qc *QueryConfig) ValidateBasic() error {
+	if _, _, err := bech32.DecodeAndConvert(qc.ContractAddress); err != nil {
+		return fmt.Errorf("invalid contract address %s: %w", qc.ContractAddress, err)
+	}
+
+	if len(qc.Query) == 0 {
+		return fmt.Errorf("query cannot be empty")
+	}
+
+	var query map[string]json.RawMessage
+	if err := json.Unmarshal(qc.Query, &query); err != nil {
+		return fmt.Errorf("query must be a json object: %w", err)
+	}
+
+	if len(ParsePath(qc.PricePath)) == 0 {
+		return fmt.Errorf("price path cannot be empty")
+	}
+
+	return nil
+}
+
+// MustToJSON converts the query configuration to JSON.
+func (qc QueryConfig) MustToJSON() string {
+	b, err := json.Marshal(qc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// CreateURL creates the smart query URL of the given contract and query message.
+func CreateURL(baseURL, contractAddress string, query []byte) string {
+	return strings.Join(
+		[]string{
+			strings.TrimSuffix(baseURL, URLSeparator),
+			fmt.Sprintf(SmartQueryURLSuffix, contractAddress, base64.URLEncoding.EncodeToString(query)),
+		},
+		URLSeparator,
+	)
+}
+
+// Response is the expected response returned by a smart query. The response is json
+// formatted, and the data is the response of the contract.
+// Response format:
+//
+//	{
+//	  "data": {
+//	    "price": "1.2345"
+//	  }
+//	}
+type Response struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// Decode decodes the given http response into a Response.
+func Decode(resp *http.Response) (Response, error) {
+	var result Response
+	err := json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
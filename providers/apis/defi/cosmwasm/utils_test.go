@@ -0,0 +1,138 @@
+package cosmwasm_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/cosmwasm"
+)
+
+func TestQueryConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         cosmwasm.QueryConfig
+		expectedErr bool
+	}{
+		{
+			name: "valid",
+			cfg: cosmwasm.QueryConfig{
+				ContractAddress: contractAddress,
+				Query:           json.RawMessage(`{"price":{}}`),
+				PricePath:       "$.price",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid contract address",
+			cfg: cosmwasm.QueryConfig{
+				ContractAddress: "neutron1invalid",
+				Query:           json.RawMessage(`{"price":{}}`),
+				PricePath:       "price",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "empty query",
+			cfg: cosmwasm.QueryConfig{
+				ContractAddress: contractAddress,
+				PricePath:       "price",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "query is not an object",
+			cfg: cosmwasm.QueryConfig{
+				ContractAddress: contractAddress,
+				Query:           json.RawMessage(`"price"`),
+				PricePath:       "price",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "empty price path",
+			cfg: cosmwasm.QueryConfig{
+				ContractAddress: contractAddress,
+				Query:           json.RawMessage(`{"price":{}}`),
+				PricePath:       "$",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSelectValue(t *testing.T) {
+	data := []byte(`{"price":"1.5","twap":{"value":2},"pools":[{"price":"3"},{"price":4e-6}],"ok":true}`)
+
+	testCases := []struct {
+		name        string
+		path        string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "string value",
+			path:     "$.price",
+			expected: "1.5",
+		},
+		{
+			name:     "nested number value",
+			path:     "twap.value",
+			expected: "2",
+		},
+		{
+			name:     "array element",
+			path:     ".pools.1.price",
+			expected: "4e-6",
+		},
+		{
+			name:        "missing key",
+			path:        "twap.price",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid index",
+			path:        "pools.first.price",
+			expectedErr: true,
+		},
+		{
+			name:        "index out of range",
+			path:        "pools.2.price",
+			expectedErr: true,
+		},
+		{
+			name:        "not a number",
+			path:        "ok",
+			expectedErr: true,
+		},
+		{
+			name:        "object value",
+			path:        "twap",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := cosmwasm.SelectValue(data, tc.path)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, value)
+			}
+		})
+	}
+}
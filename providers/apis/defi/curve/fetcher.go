@@ -0,0 +1,536 @@
+package curve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/curve/pool"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/curve/registry"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// PriceFetcher is the Curve price fetcher. This fetcher is responsible for querying Curve pools
+// and returning the price of a given ticker. Pegged assets (e.g. stETH/ETH) are priced via the
+// get_dy method of the pool, which quotes the amount of the quote coin received for one unit of
+// the base coin. LP tokens are priced via the get_virtual_price method of the pool.
+//
+// To read more about Curve pools, see the Curve documentation
+// https://docs.curve.fi/stableswap-exchange/stableswap/pools/plain_pools/.
+//
+// Similar to the Uniswap V3 fetcher, we utilize the eth client's BatchCallContext to batch the calls
+// to the ethereum network.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the EVM client implementation. This is used to interact with the ethereum network.
+	client ethmulticlient.EVMClient
+	// poolABI is the Curve pool abi. This is used to pack the get_dy and get_virtual_price calls
+	// to the pool contract and parse the results.
+	poolABI *abi.ABI
+	// registryABI is the Curve registry abi. This is used to look up the coin indices of a pool.
+	registryABI *abi.ABI
+	// virtualPricePayload is the packed get_virtual_price call to the pool contract. Since the
+	// payload is the same for all pools, we can reuse this payload for all pools.
+	virtualPricePayload []byte
+
+	mtx sync.Mutex
+	// poolCache is a cache of the tickers to pool configs. This is used to avoid unmarshalling
+	// the metadata for each ticker.
+	poolCache map[types.ProviderTicker]PoolConfig
+	// indexCache is a cache of the tickers to the coin indices looked up from the registry. Coin
+	// indices never change for a given pool, so they only need to be looked up once.
+	indexCache map[types.ProviderTicker]CoinIndices
+}
+
+// CoinIndices are the indices of the base and quote coins in a pool.
+type CoinIndices struct {
+	// Base is the index of the base coin.
+	Base int64
+	// Quote is the index of the quote coin.
+	Quote int64
+}
+
+// NewPriceFetcher returns a new Curve price fetcher.
+func NewPriceFetcher(
+	ctx context.Context,
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("api metrics is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if !IsValidProviderName(api.Name) {
+		return nil, fmt.Errorf("invalid api config name %s", api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	client, err := ethmulticlient.NewClientFromEndpoints(
+		ctx,
+		logger,
+		api,
+		apiMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client ethmulticlient.EVMClient,
+) (*PriceFetcher, error) {
+	poolABI, err := pool.PoolMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool abi: %w", err)
+	}
+
+	registryABI, err := registry.RegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registry abi: %w", err)
+	}
+
+	payload, err := poolABI.Pack(GetVirtualPriceMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack get_virtual_price: %w", err)
+	}
+
+	return &PriceFetcher{
+		logger:              logger.With(zap.String("fetcher", api.Name)),
+		api:                 api,
+		client:              client,
+		poolABI:             poolABI,
+		registryABI:         registryABI,
+		virtualPricePayload: payload,
+		poolCache:           make(map[types.ProviderTicker]PoolConfig),
+		indexCache:          make(map[types.ProviderTicker]CoinIndices),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. This fetch utilizes the batch call to lower
+// overhead of making individual RPC calls for each ticker. Coin indices that must be looked up from
+// the registry are resolved in a separate batch call the first time a ticker is fetched.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+	)
+
+	pools := make([]PoolConfig, len(tickers))
+	for i, ticker := range tickers {
+		poolCfg, err := f.GetPool(ticker)
+		if err != nil {
+			f.logger.Debug(
+				"failed to get pool for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to get pool: %w", err),
+					providertypes.ErrorFailedToDecode,
+				),
+			)
+		}
+		pools[i] = poolCfg
+	}
+
+	// Look up the coin indices of any pools that use the registry.
+	if err := f.resolveCoinIndices(ctx, tickers, pools, unResolved); err != nil {
+		f.logger.Debug(
+			"failed to batch call to ethereum network for coin indices",
+			zap.Error(err),
+		)
+
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+		)
+	}
+
+	// Create a batch element for each ticker and pool.
+	var (
+		batchElems    = make([]rpc.BatchElem, 0, len(tickers))
+		batchTickers  = make([]types.ProviderTicker, 0, len(tickers))
+		batchPoolCfgs = make([]PoolConfig, 0, len(tickers))
+	)
+	for i, ticker := range tickers {
+		if _, ok := unResolved[ticker]; ok {
+			continue
+		}
+
+		payload, err := f.packPriceCall(ticker, pools[i])
+		if err != nil {
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorUnknown),
+			}
+
+			continue
+		}
+
+		var result string
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   common.HexToAddress(pools[i].Address),
+					"data": hexutil.Bytes(payload),
+				},
+				f.api.GetBlockTag(), // the configured block tag, latest by default.
+			},
+			Result: &result,
+		})
+		batchTickers = append(batchTickers, ticker)
+		batchPoolCfgs = append(batchPoolCfgs, pools[i])
+	}
+
+	if len(batchElems) == 0 {
+		return types.NewPriceResponse(resolved, unResolved)
+	}
+
+	// Batch call to the EVM.
+	block, err := ethmulticlient.BatchCallWithBlock(ctx, f.client, f.api.GetBlockTag(), batchElems)
+	if err != nil {
+		f.logger.Debug(
+			"failed to batch call to ethereum network for all tickers",
+			zap.Error(err),
+		)
+
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+		)
+	}
+
+	// Parse the result from the batch call for each ticker.
+	now := time.Now().UTC()
+	for i, ticker := range batchTickers {
+		result := batchElems[i]
+		if result.Error != nil {
+			f.logger.Debug(
+				"failed to batch call to ethereum network for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(result.Error),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					result.Error,
+					ethmulticlient.ErrorCodeFromError(result.Error, providertypes.ErrorUnknown),
+				),
+			}
+
+			continue
+		}
+
+		price, err := f.ParsePrice(batchPoolCfgs[i], result.Result)
+		if err != nil {
+			f.logger.Debug(
+				"failed to parse price",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorFailedToParsePrice,
+				),
+			}
+
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResultWithBlock(price, now, block)
+	}
+
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// GetPool returns the Curve pool for the given ticker. This will unmarshal the metadata
+// and validate the pool config which contains all required information to query the EVM.
+func (f *PriceFetcher) GetPool(
+	ticker types.ProviderTicker,
+) (PoolConfig, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if cfg, ok := f.poolCache[ticker]; ok {
+		return cfg, nil
+	}
+
+	var cfg PoolConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal pool config on ticker: %w", err)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid ticker pool config: %w", err)
+	}
+
+	f.poolCache[ticker] = cfg
+	return cfg, nil
+}
+
+// resolveCoinIndices looks up the coin indices of all pools that use the registry and whose
+// indices have not been looked up yet. Tickers whose indices cannot be looked up are added to
+// the unresolved prices.
+func (f *PriceFetcher) resolveCoinIndices(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+	pools []PoolConfig,
+	unResolved types.UnResolvedPrices,
+) error {
+	var (
+		batchElems   []rpc.BatchElem
+		batchTickers []types.ProviderTicker
+	)
+	for i, ticker := range tickers {
+		if pools[i].Method == GetVirtualPriceMethod || !pools[i].UsesRegistry() {
+			continue
+		}
+
+		if _, ok := f.getCoinIndices(ticker); ok {
+			continue
+		}
+
+		payload, err := f.registryABI.Pack(
+			GetCoinIndicesMethod,
+			common.HexToAddress(pools[i].Address),
+			common.HexToAddress(pools[i].BaseToken),
+			common.HexToAddress(pools[i].QuoteToken),
+		)
+		if err != nil {
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorUnknown),
+			}
+
+			continue
+		}
+
+		var result string
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   common.HexToAddress(pools[i].GetRegistry()),
+					"data": hexutil.Bytes(payload),
+				},
+				"latest",
+			},
+			Result: &result,
+		})
+		batchTickers = append(batchTickers, ticker)
+	}
+
+	if len(batchElems) == 0 {
+		return nil
+	}
+
+	if err := f.client.BatchCallContext(ctx, batchElems); err != nil {
+		return err
+	}
+
+	for i, ticker := range batchTickers {
+		if err := batchElems[i].Error; err != nil {
+			f.logger.Debug(
+				"failed to look up coin indices for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorUnknown)),
+			}
+
+			continue
+		}
+
+		indices, err := f.ParseCoinIndices(batchElems[i].Result)
+		if err != nil {
+			f.logger.Debug(
+				"failed to parse coin indices for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+			}
+
+			continue
+		}
+
+		f.setCoinIndices(ticker, indices)
+	}
+
+	return nil
+}
+
+// packPriceCall packs the call to the pool contract used to derive the price of the ticker.
+func (f *PriceFetcher) packPriceCall(ticker types.ProviderTicker, cfg PoolConfig) ([]byte, error) {
+	if cfg.Method == GetVirtualPriceMethod {
+		return f.virtualPricePayload, nil
+	}
+
+	indices := CoinIndices{Base: cfg.BaseIndex, Quote: cfg.QuoteIndex}
+	if cfg.UsesRegistry() {
+		var ok bool
+		if indices, ok = f.getCoinIndices(ticker); !ok {
+			return nil, fmt.Errorf("coin indices have not been looked up")
+		}
+	}
+
+	method := GetDyMethod
+	if cfg.CryptoPool {
+		method = GetDyCryptoMethod
+	}
+
+	// Quote one unit of the base coin.
+	dx := new(big.Int).Exp(big.NewInt(10), big.NewInt(cfg.BaseDecimals), nil)
+	return f.poolABI.Pack(method, big.NewInt(indices.Base), big.NewInt(indices.Quote), dx)
+}
+
+// ParsePrice parses the result of a get_dy or get_virtual_price call and normalizes it by the
+// decimals of the quote coin or of the virtual price respectively.
+func (f *PriceFetcher) ParsePrice(
+	cfg PoolConfig,
+	result interface{},
+) (*big.Float, error) {
+	bz, err := decodeResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	method, decimals := GetDyMethod, cfg.QuoteDecimals
+	if cfg.Method == GetVirtualPriceMethod {
+		method, decimals = GetVirtualPriceMethod, VirtualPriceDecimals
+	}
+
+	out, err := f.poolABI.Methods[method].Outputs.UnpackValues(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	amount := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("price must be positive")
+	}
+
+	price, err := math.NewPrice(amount, -decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
+
+// ParseCoinIndices parses the result of a get_coin_indices call to the registry.
+func (f *PriceFetcher) ParseCoinIndices(
+	result interface{},
+) (CoinIndices, error) {
+	bz, err := decodeResult(result)
+	if err != nil {
+		return CoinIndices{}, err
+	}
+
+	out, err := f.registryABI.Methods[GetCoinIndicesMethod].Outputs.UnpackValues(bz)
+	if err != nil {
+		return CoinIndices{}, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	// The coins are the underlying coins of a metapool, which must be quoted via
+	// get_dy_underlying.
+	if isUnderlying := *abi.ConvertType(out[2], new(bool)).(*bool); isUnderlying {
+		return CoinIndices{}, fmt.Errorf("coins are underlying coins of a metapool")
+	}
+
+	return CoinIndices{
+		Base:  (*abi.ConvertType(out[0], new(*big.Int)).(**big.Int)).Int64(),
+		Quote: (*abi.ConvertType(out[1], new(*big.Int)).(**big.Int)).Int64(),
+	}, nil
+}
+
+// getCoinIndices returns the coin indices looked up from the registry for the given ticker.
+func (f *PriceFetcher) getCoinIndices(ticker types.ProviderTicker) (CoinIndices, bool) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	indices, ok := f.indexCache[ticker]
+	return indices, ok
+}
+
+// setCoinIndices caches the coin indices looked up from the registry for the given ticker.
+func (f *PriceFetcher) setCoinIndices(ticker types.ProviderTicker, indices CoinIndices) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.indexCache[ticker] = indices
+}
+
+// decodeResult decodes the hex encoded result of an eth_call.
+func decodeResult(result interface{}) ([]byte, error) {
+	r, ok := result.(*string)
+	if !ok {
+		return nil, fmt.Errorf("expected result to be a string, got %T", result)
+	}
+
+	if r == nil {
+		return nil, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	return bz, nil
+}
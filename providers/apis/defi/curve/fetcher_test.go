@@ -0,0 +1,365 @@
+package curve_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/curve"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/curve/pool"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/curve/registry"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+var (
+	logger, _ = zap.NewDevelopment()
+
+	// PoolConfigs used for testing.
+	stethethCfg = curve.PoolConfig{
+		Address:       "0xDC24316b9AE028F1497c275EB9192a3Ea0f67022",
+		BaseIndex:     1,
+		QuoteIndex:    0,
+		BaseDecimals:  18,
+		QuoteDecimals: 18,
+	}
+	fraxusdcCfg = curve.PoolConfig{
+		Address:       "0xDcEF968d416a41Cdac0ED8702fAC8128A64241A2",
+		BaseToken:     "0x853d955aCEf822Db058eb8505911ED77F175b99e",
+		QuoteToken:    "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		BaseDecimals:  18,
+		QuoteDecimals: 6,
+	}
+	threecrvCfg = curve.PoolConfig{
+		Address: "0xbEbc44782C7dB0a1A60Cb6fe97d0b483032FF1C7",
+		Method:  curve.GetVirtualPriceMethod,
+	}
+
+	// Tickers used for testing.
+	stethethTicker = types.NewProviderTicker("STETH/ETH", stethethCfg.MustToJSON())
+	fraxusdcTicker = types.NewProviderTicker("FRAX/USDC", fraxusdcCfg.MustToJSON())
+	threecrvTicker = types.NewProviderTicker("3CRV/USD", threecrvCfg.MustToJSON())
+)
+
+func TestFetch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tickers  []types.ProviderTicker
+		client   func() ethmulticlient.EVMClient
+		expected types.PriceResponse
+	}{
+		{
+			name: "fails to retrieve pool for an empty ticker",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("STETH/ETH", ""),
+			},
+			client: func() ethmulticlient.EVMClient {
+				return mocks.NewEVMClient(t)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					types.NewProviderTicker("STETH/ETH", ""): {},
+				},
+			},
+		},
+		{
+			name: "fails to make a batch call",
+			tickers: []types.ProviderTicker{
+				stethethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				c.On("BatchCallContext", mock.Anything, mock.Anything).Return(fmt.Errorf("failed to make a batch call"))
+				return c
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					stethethTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request has an error for a single ticker",
+			tickers: []types.ProviderTicker{
+				stethethTicker,
+				threecrvTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(t, c, []string{"", encodeUint(t, curve.GetVirtualPriceMethod, "1030000000000000000")}, []error{fmt.Errorf("execution reverted"), nil})
+				return c
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					threecrvTicker: {
+						Value: big.NewFloat(1.03),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					stethethTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request returns a zero price",
+			tickers: []types.ProviderTicker{
+				stethethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(t, c, []string{encodeUint(t, curve.GetDyMethod, "0")}, []error{nil})
+				return c
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					stethethTicker: {},
+				},
+			},
+		},
+		{
+			name: "steth/eth and 3crv/usd results",
+			tickers: []types.ProviderTicker{
+				stethethTicker,
+				threecrvTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(
+					t,
+					c,
+					[]string{
+						encodeUint(t, curve.GetDyMethod, "999500000000000000"),
+						encodeUint(t, curve.GetVirtualPriceMethod, "1030000000000000000"),
+					},
+					[]error{nil, nil},
+				)
+				return c
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					stethethTicker: {
+						Value: big.NewFloat(0.9995),
+					},
+					threecrvTicker: {
+						Value: big.NewFloat(1.03),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+		{
+			name: "frax/usdc result with coin indices from the registry",
+			tickers: []types.ProviderTicker{
+				fraxusdcTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(t, c, []string{encodeCoinIndices(t, 0, 1, false)}, []error{nil})
+				expectBatchCall(t, c, []string{encodeUint(t, curve.GetDyMethod, "998700")}, []error{nil})
+				return c
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					fraxusdcTicker: {
+						Value: big.NewFloat(0.9987),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+		{
+			name: "coin indices of underlying coins are rejected",
+			tickers: []types.ProviderTicker{
+				fraxusdcTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				c := mocks.NewEVMClient(t)
+				expectBatchCall(t, c, []string{encodeCoinIndices(t, 0, 2, true)}, []error{nil})
+				return c
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					fraxusdcTicker: {},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := curve.NewPriceFetcherWithClient(logger, curve.DefaultETHAPIConfig, tc.client())
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Equal(t, len(tc.expected.Resolved), len(response.Resolved))
+			require.Equal(t, len(tc.expected.UnResolved), len(response.UnResolved))
+
+			for ticker, result := range tc.expected.Resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, result.Value.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+			}
+
+			for ticker := range tc.expected.UnResolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+
+	t.Run("coin indices are only looked up once", func(t *testing.T) {
+		c := mocks.NewEVMClient(t)
+		expectBatchCall(t, c, []string{encodeCoinIndices(t, 0, 1, false)}, []error{nil})
+		expectBatchCall(t, c, []string{encodeUint(t, curve.GetDyMethod, "998700")}, []error{nil})
+		expectBatchCall(t, c, []string{encodeUint(t, curve.GetDyMethod, "999100")}, []error{nil})
+
+		fetcher, err := curve.NewPriceFetcherWithClient(logger, curve.DefaultETHAPIConfig, c)
+		require.NoError(t, err)
+
+		response := fetcher.Fetch(context.Background(), []types.ProviderTicker{fraxusdcTicker})
+		require.Contains(t, response.Resolved, fraxusdcTicker)
+
+		response = fetcher.Fetch(context.Background(), []types.ProviderTicker{fraxusdcTicker})
+		require.Equal(t, big.NewFloat(0.9991).SetPrec(40), response.Resolved[fraxusdcTicker].Value.SetPrec(40))
+	})
+}
+
+func TestPoolConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  curve.PoolConfig
+		err  bool
+	}{
+		{
+			name: "valid get_dy config",
+			cfg:  stethethCfg,
+			err:  false,
+		},
+		{
+			name: "valid registry config",
+			cfg:  fraxusdcCfg,
+			err:  false,
+		},
+		{
+			name: "valid get_virtual_price config",
+			cfg:  threecrvCfg,
+			err:  false,
+		},
+		{
+			name: "invalid address",
+			cfg:  curve.PoolConfig{Address: "0x1234", BaseIndex: 1},
+			err:  true,
+		},
+		{
+			name: "unknown method",
+			cfg:  curve.PoolConfig{Address: stethethCfg.Address, Method: "price_oracle", BaseIndex: 1},
+			err:  true,
+		},
+		{
+			name: "same coin indices",
+			cfg:  curve.PoolConfig{Address: stethethCfg.Address},
+			err:  true,
+		},
+		{
+			name: "negative decimals",
+			cfg:  curve.PoolConfig{Address: stethethCfg.Address, BaseIndex: 1, QuoteDecimals: -1},
+			err:  true,
+		},
+		{
+			name: "only one token set",
+			cfg:  curve.PoolConfig{Address: stethethCfg.Address, BaseIndex: 1, BaseToken: fraxusdcCfg.BaseToken},
+			err:  true,
+		},
+		{
+			name: "invalid registry",
+			cfg: curve.PoolConfig{
+				Address:    fraxusdcCfg.Address,
+				BaseToken:  fraxusdcCfg.BaseToken,
+				QuoteToken: fraxusdcCfg.QuoteToken,
+				Registry:   "0x1234",
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	require.Equal(t, curve.DefaultRegistryAddress, fraxusdcCfg.GetRegistry())
+}
+
+func encodeUint(t *testing.T, method, value string) string {
+	t.Helper()
+
+	abi, err := pool.PoolMetaData.GetAbi()
+	require.NoError(t, err)
+
+	amount, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok)
+
+	bz, err := abi.Methods[method].Outputs.Pack(amount)
+	require.NoError(t, err)
+
+	return hexutil.Encode(bz)
+}
+
+func encodeCoinIndices(t *testing.T, i, j int64, isUnderlying bool) string {
+	t.Helper()
+
+	abi, err := registry.RegistryMetaData.GetAbi()
+	require.NoError(t, err)
+
+	bz, err := abi.Methods[curve.GetCoinIndicesMethod].Outputs.Pack(big.NewInt(i), big.NewInt(j), isUnderlying)
+	require.NoError(t, err)
+
+	return hexutil.Encode(bz)
+}
+
+func expectBatchCall(
+	t *testing.T,
+	c *mocks.EVMClient,
+	responses []string,
+	errs []error,
+) {
+	t.Helper()
+
+	c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+		// The last call reads the block at which the other calls are read, if any.
+		if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+			*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+			elems = elems[:n-1]
+		}
+		require.Equal(t, len(elems), len(responses))
+		require.Equal(t, len(elems), len(errs))
+
+		for i, elem := range elems {
+			elem.Result = &responses[i]
+			elem.Error = errs[i]
+			elems[i] = elem
+		}
+	}).Once()
+}
@@ -0,0 +1,176 @@
+package curve
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+const (
+	// BaseName is the name of the Curve API.
+	BaseName = "curve_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// GetDyMethod is the pool method that quotes the amount of coin j received for an amount of
+	// coin i. This is used to price pegged assets.
+	GetDyMethod = "get_dy"
+
+	// GetDyCryptoMethod is the ABI name of the get_dy overload of crypto (v2) pools, whose coin
+	// indices are uint256 rather than int128.
+	GetDyCryptoMethod = "get_dy0"
+
+	// GetVirtualPriceMethod is the pool method that returns the value of the pool's LP token in
+	// units of the pool's underlying coins. This is used to price LP tokens.
+	GetVirtualPriceMethod = "get_virtual_price"
+
+	// GetCoinIndicesMethod is the registry method that returns the indices of two coins in a pool.
+	GetCoinIndicesMethod = "get_coin_indices"
+
+	// VirtualPriceDecimals is the number of decimals of the virtual price of a pool.
+	VirtualPriceDecimals = 18
+
+	// DefaultRegistryAddress is the address of the Curve MetaRegistry on Ethereum Mainnet. This is
+	// used to look up the coin indices of a pool if the pool config does not set a registry.
+	DefaultRegistryAddress = "0xF98B45FA17DE75FB1aD0e7aFD971b0ca00e379fC"
+
+	// ETH_URL is the URL for the Curve API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// PoolConfig is the configuration for a Curve pool. This is specific to each pair of tokens.
+type PoolConfig struct {
+	// Address is the Curve pool address.
+	Address string `json:"address" schema:"address"`
+	// Method is the pool method used to derive the price. This must be one of get_dy, which
+	// prices the base coin in units of the quote coin, or get_virtual_price, which prices the
+	// pool's LP token in units of the pool's underlying coins. If unset, get_dy is used.
+	Method string `json:"method"`
+	// BaseIndex is the index of the base coin in the pool. This is only used by get_dy.
+	BaseIndex int64 `json:"base_index"`
+	// QuoteIndex is the index of the quote coin in the pool. This is only used by get_dy.
+	QuoteIndex int64 `json:"quote_index"`
+	// BaseToken is the address of the base coin. If both the base and quote tokens are set, the
+	// coin indices are looked up from the registry instead of the configured indices.
+	BaseToken string `json:"base_token" schema:"address"`
+	// QuoteToken is the address of the quote coin.
+	QuoteToken string `json:"quote_token" schema:"address"`
+	// Registry is the address of the registry used to look up the coin indices. If unset,
+	// DefaultRegistryAddress is used.
+	Registry string `json:"registry" schema:"address"`
+	// BaseDecimals is the number of decimals of the base coin. One unit of the base coin is
+	// quoted via get_dy.
+	BaseDecimals int64 `json:"base_decimals" schema:"decimals"`
+	// QuoteDecimals is the number of decimals of the quote coin. This is used to normalize the
+	// amount returned by get_dy.
+	QuoteDecimals int64 `json:"quote_decimals" schema:"decimals"`
+	// CryptoPool denotes a Curve crypto (v2) pool, whose coin indices are uint256 rather than
+	// int128.
+	CryptoPool bool `json:"crypto_pool"`
+}
+
+// ValidateBasic validates the pool configuration.
+func (pc *PoolConfig) ValidateBasic() error {
+	if !common.IsHexAddress(pc.Address) {
+		return fmt.Errorf("pool address is not a valid ethereum address")
+	}
+
+	switch pc.Method {
+	case "", GetDyMethod:
+	case GetVirtualPriceMethod:
+		return nil
+	default:
+		return fmt.Errorf("unknown pool method: %s", pc.Method)
+	}
+
+	if pc.BaseDecimals < 0 || pc.QuoteDecimals < 0 {
+		return fmt.Errorf("decimals must be non-negative")
+	}
+
+	if pc.UsesRegistry() {
+		if !common.IsHexAddress(pc.BaseToken) || !common.IsHexAddress(pc.QuoteToken) {
+			return fmt.Errorf("base and quote tokens must be valid ethereum addresses")
+		}
+
+		if pc.Registry != "" && !common.IsHexAddress(pc.Registry) {
+			return fmt.Errorf("registry address is not a valid ethereum address")
+		}
+
+		return nil
+	}
+
+	if pc.BaseToken != "" || pc.QuoteToken != "" {
+		return fmt.Errorf("both the base and quote tokens must be set to look up the coin indices")
+	}
+
+	if pc.BaseIndex < 0 || pc.QuoteIndex < 0 {
+		return fmt.Errorf("coin indices must be non-negative")
+	}
+
+	if pc.BaseIndex == pc.QuoteIndex {
+		return fmt.Errorf("base and quote coin indices must be different")
+	}
+
+	return nil
+}
+
+// UsesRegistry returns true if the coin indices of the pool should be looked up from the
+// registry.
+func (pc *PoolConfig) UsesRegistry() bool {
+	return pc.BaseToken != "" && pc.QuoteToken != ""
+}
+
+// GetRegistry returns the address of the registry used to look up the coin indices.
+func (pc *PoolConfig) GetRegistry() string {
+	if pc.Registry == "" {
+		return DefaultRegistryAddress
+	}
+
+	return pc.Registry
+}
+
+// MustToJSON converts the pool configuration to JSON.
+func (pc PoolConfig) MustToJSON() string {
+	b, err := json.Marshal(pc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// DefaultETHAPIConfig is the default configuration for the Curve API. Specifically this is for
+// Ethereum mainnet.
+var DefaultETHAPIConfig = config.APIConfig{
+	Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+	Atomic:            true,
+	Enabled:           true,
+	Timeout:           1000 * time.Millisecond,
+	Interval:          2000 * time.Millisecond,
+	ReconnectTimeout:  2000 * time.Millisecond,
+	MaxQueries:        1,
+	Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+	MaxBlockHeightAge: 30 * time.Second,
+}
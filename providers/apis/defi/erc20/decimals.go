@@ -0,0 +1,152 @@
+package erc20
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc20/token"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+)
+
+// DecimalsMethod is the ERC20 method that returns the number of decimals of a token. ERC4626
+// vaults implement the same method for the decimals of their shares.
+const DecimalsMethod = "decimals"
+
+// DecimalsCache reads the decimals of ERC20 tokens from their contracts and caches them. The
+// decimals of a token never change, so each token is only queried once.
+type DecimalsCache struct {
+	client ethmulticlient.EVMClient
+	// abi is the ERC20 abi. This is used to parse the result of the decimals call.
+	abi *abi.ABI
+	// payload is the packed decimals call. This is the same for all tokens.
+	payload []byte
+
+	mtx      sync.Mutex
+	decimals map[common.Address]int64
+}
+
+// NewDecimalsCache returns a new DecimalsCache that queries tokens using the given client.
+func NewDecimalsCache(client ethmulticlient.EVMClient) (*DecimalsCache, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	abi, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get erc20 abi: %w", err)
+	}
+
+	payload, err := abi.Pack(DecimalsMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack decimals: %w", err)
+	}
+
+	return &DecimalsCache{
+		client:   client,
+		abi:      abi,
+		payload:  payload,
+		decimals: make(map[common.Address]int64),
+	}, nil
+}
+
+// Get returns the cached decimals of the given token.
+func (c *DecimalsCache) Get(address common.Address) (int64, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	decimals, ok := c.decimals[address]
+	return decimals, ok
+}
+
+// Load queries the decimals of all given tokens that are not cached yet in a single batch call.
+// An error is returned if any of the tokens could not be queried; the decimals of the tokens that
+// were queried successfully are still cached.
+func (c *DecimalsCache) Load(ctx context.Context, tokens []common.Address) error {
+	var (
+		batchElems []rpc.BatchElem
+		queried    []common.Address
+		seen       = make(map[common.Address]struct{})
+	)
+	for _, address := range tokens {
+		if _, ok := seen[address]; ok {
+			continue
+		}
+		seen[address] = struct{}{}
+
+		if _, ok := c.Get(address); ok {
+			continue
+		}
+
+		var result string
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   address,
+					"data": hexutil.Bytes(c.payload),
+				},
+				"latest",
+			},
+			Result: &result,
+		})
+		queried = append(queried, address)
+	}
+
+	if len(batchElems) == 0 {
+		return nil
+	}
+
+	if err := c.client.BatchCallContext(ctx, batchElems); err != nil {
+		return fmt.Errorf("failed to batch call decimals: %w", err)
+	}
+
+	var errs []error
+	for i, address := range queried {
+		decimals, err := c.parseDecimals(batchElems[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to query decimals of %s: %w", address, err))
+			continue
+		}
+
+		c.mtx.Lock()
+		c.decimals[address] = decimals
+		c.mtx.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseDecimals parses the result of a decimals call.
+func (c *DecimalsCache) parseDecimals(elem rpc.BatchElem) (int64, error) {
+	if elem.Error != nil {
+		return 0, elem.Error
+	}
+
+	r, ok := elem.Result.(*string)
+	if !ok {
+		return 0, fmt.Errorf("expected result to be a string, got %T", elem.Result)
+	}
+
+	if r == nil {
+		return 0, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := c.abi.Methods[DecimalsMethod].Outputs.UnpackValues(bz)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	return int64(*abi.ConvertType(out[0], new(uint8)).(*uint8)), nil
+}
@@ -0,0 +1,117 @@
+package erc20_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc20"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+)
+
+var (
+	usdc = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	weth = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+)
+
+func TestNewDecimalsCache(t *testing.T) {
+	_, err := erc20.NewDecimalsCache(nil)
+	require.Error(t, err)
+
+	_, err = erc20.NewDecimalsCache(mocks.NewEVMClient(t))
+	require.NoError(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("queries and caches the decimals of each token once", func(t *testing.T) {
+		client := mocks.NewEVMClient(t)
+		expectBatchCall(t, client, []string{encodeDecimals(6), encodeDecimals(18)}, []error{nil, nil})
+
+		cache, err := erc20.NewDecimalsCache(client)
+		require.NoError(t, err)
+
+		require.NoError(t, cache.Load(context.Background(), []common.Address{usdc, weth, usdc}))
+
+		decimals, ok := cache.Get(usdc)
+		require.True(t, ok)
+		require.Equal(t, int64(6), decimals)
+
+		decimals, ok = cache.Get(weth)
+		require.True(t, ok)
+		require.Equal(t, int64(18), decimals)
+
+		// Cached tokens are not queried again.
+		require.NoError(t, cache.Load(context.Background(), []common.Address{usdc, weth}))
+	})
+
+	t.Run("fails to make a batch call", func(t *testing.T) {
+		client := mocks.NewEVMClient(t)
+		client.On("BatchCallContext", mock.Anything, mock.Anything).Return(fmt.Errorf("connection refused"))
+
+		cache, err := erc20.NewDecimalsCache(client)
+		require.NoError(t, err)
+
+		require.Error(t, cache.Load(context.Background(), []common.Address{usdc}))
+		_, ok := cache.Get(usdc)
+		require.False(t, ok)
+	})
+
+	t.Run("caches the tokens that were queried successfully", func(t *testing.T) {
+		client := mocks.NewEVMClient(t)
+		expectBatchCall(t, client, []string{encodeDecimals(6), ""}, []error{nil, fmt.Errorf("execution reverted")})
+
+		cache, err := erc20.NewDecimalsCache(client)
+		require.NoError(t, err)
+
+		require.Error(t, cache.Load(context.Background(), []common.Address{usdc, weth}))
+
+		decimals, ok := cache.Get(usdc)
+		require.True(t, ok)
+		require.Equal(t, int64(6), decimals)
+
+		_, ok = cache.Get(weth)
+		require.False(t, ok)
+	})
+
+	t.Run("fails to decode the result", func(t *testing.T) {
+		client := mocks.NewEVMClient(t)
+		expectBatchCall(t, client, []string{"0x"}, []error{nil})
+
+		cache, err := erc20.NewDecimalsCache(client)
+		require.NoError(t, err)
+
+		require.Error(t, cache.Load(context.Background(), []common.Address{usdc}))
+	})
+}
+
+func encodeDecimals(decimals uint8) string {
+	return hexutil.Encode(common.LeftPadBytes([]byte{decimals}, 32))
+}
+
+func expectBatchCall(
+	t *testing.T,
+	c *mocks.EVMClient,
+	responses []string,
+	errs []error,
+) {
+	t.Helper()
+
+	c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+		require.Equal(t, len(elems), len(responses))
+		require.Equal(t, len(elems), len(errs))
+
+		for i, elem := range elems {
+			elem.Result = &responses[i]
+			elem.Error = errs[i]
+			elems[i] = elem
+		}
+	}).Once()
+}
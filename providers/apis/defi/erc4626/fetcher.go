@@ -0,0 +1,763 @@
+package erc4626
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc20"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// Vault is the on-chain metadata of an ERC4626 vault that is required to price its shares.
+type Vault struct {
+	// Asset is the address of the underlying asset of the vault.
+	Asset common.Address
+	// ShareDecimals is the number of decimals of the vault shares.
+	ShareDecimals int64
+	// AssetDecimals is the number of decimals of the underlying asset.
+	AssetDecimals int64
+}
+
+// PriceFetcher is the ERC4626 price fetcher. This fetcher is responsible for reading the share
+// rate of ERC4626 yield vaults, e.g. sDAI, from the vault contracts. The price of a ticker is the
+// amount of the underlying asset that one share of the vault is worth, as returned by
+// convertToAssets. To quote the shares in another asset, e.g. sDAI/USD, the share rate can be
+// composed with the index price of the underlying asset via a derived market or the
+// normalize_by_pair of the market's provider config.
+//
+// Similar to the Chainlink fetcher, we utilize the eth client's BatchCallContext to batch the calls
+// to the ethereum network.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the EVM client implementation. This is used to interact with the ethereum network.
+	client ethmulticlient.EVMClient
+	// abi is the ERC4626 abi. This is used to pack the calls to the vault contracts and parse
+	// the results.
+	abi abi.ABI
+	// decimals is a cache of the decimals of the vault shares and underlying assets, read from
+	// the token contracts.
+	decimals *erc20.DecimalsCache
+
+	mtx sync.Mutex
+	// feedCache is a cache of the tickers to feed configs. This is used to avoid unmarshalling
+	// the metadata for each ticker.
+	feedCache map[types.ProviderTicker]FeedConfig
+	// assetCache is a cache of the vaults to their underlying assets. The asset of a vault never
+	// changes, so each vault is only queried once.
+	assetCache map[common.Address]common.Address
+	// pairCache is a cache of the results of verifying the symbols of each feed against its vault
+	// and underlying asset. A nil error means the pair was verified. The symbols of a token never
+	// change, so each feed is only verified once.
+	pairCache map[FeedConfig]error
+}
+
+// NewPriceFetcher returns a new ERC4626 price fetcher.
+func NewPriceFetcher(
+	ctx context.Context,
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("api metrics is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if !IsValidProviderName(api.Name) {
+		return nil, fmt.Errorf("invalid api config name %s", api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	client, err := ethmulticlient.NewClientFromEndpoints(
+		ctx,
+		logger,
+		api,
+		apiMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client ethmulticlient.EVMClient,
+) (*PriceFetcher, error) {
+	contractABI, err := abi.JSON(strings.NewReader(ContractABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse erc4626 abi: %w", err)
+	}
+
+	decimals, err := erc20.NewDecimalsCache(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decimals cache: %w", err)
+	}
+
+	return &PriceFetcher{
+		logger:     logger.With(zap.String("fetcher", api.Name)),
+		api:        api,
+		client:     client,
+		abi:        contractABI,
+		decimals:   decimals,
+		feedCache:  make(map[types.ProviderTicker]FeedConfig),
+		assetCache: make(map[common.Address]common.Address),
+		pairCache:  make(map[FeedConfig]error),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. This fetch utilizes the batch call to lower
+// overhead of making individual RPC calls for each ticker. The fetcher will query the amount of
+// the underlying asset that one share of each vault is worth, scaled by the decimals of the asset.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+	)
+
+	feeds := make([]FeedConfig, len(tickers))
+	for i, ticker := range tickers {
+		feed, err := f.GetFeed(ticker)
+		if err != nil {
+			f.logger.Debug(
+				"failed to get feed for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to get feed: %w", err),
+					providertypes.ErrorFailedToDecode,
+				),
+			)
+		}
+		feeds[i] = feed
+	}
+
+	// Resolve the underlying asset and decimals of each vault. Tickers whose vault cannot be
+	// resolved are excluded from the price query.
+	vaults, errs := f.ResolveVaults(ctx, tickers, feeds)
+	for ticker, err := range errs {
+		f.logger.Debug(
+			"failed to resolve vault",
+			zap.String("ticker", ticker.String()),
+			zap.Error(err),
+		)
+
+		unResolved[ticker] = providertypes.UnresolvedResult{
+			ErrorWithCode: providertypes.NewErrorWithCode(
+				err,
+				providertypes.ErrorAPIGeneral,
+			),
+		}
+	}
+
+	// Create a batch element for each ticker and vault.
+	var (
+		batchElems   = make([]rpc.BatchElem, 0, len(tickers))
+		batchTickers = make([]types.ProviderTicker, 0, len(tickers))
+		batchVaults  = make([]Vault, 0, len(tickers))
+	)
+	for i, ticker := range tickers {
+		if _, ok := unResolved[ticker]; ok {
+			continue
+		}
+
+		vault := vaults[i]
+		payload, err := f.abi.Pack(ConvertToAssetsMethod, OneShare(vault))
+		if err != nil {
+			f.logger.Debug(
+				"failed to pack payload for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to pack payload: %w", err),
+					providertypes.ErrorUnknown,
+				),
+			)
+		}
+
+		var result string
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   common.HexToAddress(feeds[i].Vault),
+					"data": hexutil.Bytes(payload), // convertToAssets call to the vault contract.
+				},
+				f.api.GetBlockTag(), // the configured block tag, latest by default.
+			},
+			Result: &result,
+		})
+		batchTickers = append(batchTickers, ticker)
+		batchVaults = append(batchVaults, vault)
+	}
+
+	if len(batchElems) == 0 {
+		return types.NewPriceResponse(resolved, unResolved)
+	}
+
+	// Batch call to the EVM.
+	block, err := ethmulticlient.BatchCallWithBlock(ctx, f.client, f.api.GetBlockTag(), batchElems)
+	if err != nil {
+		f.logger.Debug(
+			"failed to batch call to ethereum network for all tickers",
+			zap.Error(err),
+		)
+
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+		)
+	}
+
+	// Parse the result from the batch call for each ticker.
+	now := time.Now().UTC()
+	for i, ticker := range batchTickers {
+		result := batchElems[i]
+		if result.Error != nil {
+			f.logger.Debug(
+				"failed to batch call to ethereum network for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(result.Error),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					result.Error,
+					ethmulticlient.ErrorCodeFromError(result.Error, providertypes.ErrorUnknown),
+				),
+			}
+
+			continue
+		}
+
+		assets, err := f.ParseAssets(result.Result)
+		if err != nil {
+			f.logger.Debug(
+				"failed to parse share rate",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorFailedToParsePrice,
+				),
+			}
+
+			continue
+		}
+
+		price, err := ScalePrice(batchVaults[i], assets)
+		if err != nil {
+			f.logger.Debug(
+				"failed to scale price",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorFailedToParsePrice,
+				),
+			}
+
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResultWithBlock(price, now, block)
+	}
+
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// GetFeed returns the vault feed for the given ticker. This will unmarshal the metadata and
+// validate the feed config.
+func (f *PriceFetcher) GetFeed(
+	ticker types.ProviderTicker,
+) (FeedConfig, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if feed, ok := f.feedCache[ticker]; ok {
+		return feed, nil
+	}
+
+	var cfg FeedConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal feed config on ticker: %w", err)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid ticker feed config: %w", err)
+	}
+
+	f.feedCache[ticker] = cfg
+	return cfg, nil
+}
+
+// ResolveVaults returns the underlying asset and decimals of the vault of each ticker. The asset
+// of each vault is queried from the vault contract, and the decimals of the shares and the asset
+// are queried from the token contracts, the first time a vault is fetched. Both are cached, so
+// subsequent fetches do not make any additional calls. If the feed of a ticker has a base or quote
+// symbol, the symbols of the vault and its asset are verified against them as well, and the ticker
+// is refused if they do not match. The returned map contains the tickers whose vault could not be
+// resolved or verified.
+func (f *PriceFetcher) ResolveVaults(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+	feeds []FeedConfig,
+) ([]Vault, map[types.ProviderTicker]error) {
+	var (
+		vaults = make([]Vault, len(tickers))
+		errs   = make(map[types.ProviderTicker]error)
+	)
+
+	if err := f.resolveAssets(ctx, feeds); err != nil {
+		f.logger.Debug("failed to resolve vault assets", zap.Error(err))
+	}
+
+	var tokens []common.Address
+	for _, feed := range feeds {
+		address := common.HexToAddress(feed.Vault)
+		if asset, ok := f.getAsset(address); ok {
+			tokens = append(tokens, address, asset)
+		}
+	}
+
+	if err := f.decimals.Load(ctx, tokens); err != nil {
+		f.logger.Debug("failed to load token decimals", zap.Error(err))
+	}
+
+	if err := f.verifyPairs(ctx, feeds); err != nil {
+		f.logger.Debug("failed to verify vault symbols", zap.Error(err))
+	}
+
+	for i, ticker := range tickers {
+		address := common.HexToAddress(feeds[i].Vault)
+		asset, ok := f.getAsset(address)
+		if !ok {
+			errs[ticker] = fmt.Errorf("failed to query asset of vault %s", address)
+			continue
+		}
+
+		shareDecimals, ok := f.decimals.Get(address)
+		if !ok {
+			errs[ticker] = fmt.Errorf("failed to query decimals of vault %s", address)
+			continue
+		}
+
+		assetDecimals, ok := f.decimals.Get(asset)
+		if !ok {
+			errs[ticker] = fmt.Errorf("failed to query decimals of asset %s", asset)
+			continue
+		}
+
+		if feeds[i].HasSymbols() {
+			verified, err := f.getPairVerification(feeds[i])
+			if !verified {
+				errs[ticker] = fmt.Errorf("failed to query symbols of vault %s", address)
+				continue
+			}
+			if err != nil {
+				errs[ticker] = err
+				continue
+			}
+		}
+
+		vaults[i] = Vault{
+			Asset:         asset,
+			ShareDecimals: shareDecimals,
+			AssetDecimals: assetDecimals,
+		}
+	}
+
+	return vaults, errs
+}
+
+// resolveAssets queries the underlying assets of all vaults that are not cached yet.
+func (f *PriceFetcher) resolveAssets(
+	ctx context.Context,
+	feeds []FeedConfig,
+) error {
+	payload, err := f.abi.Pack(AssetMethod)
+	if err != nil {
+		return fmt.Errorf("failed to pack asset: %w", err)
+	}
+
+	var (
+		batchElems []rpc.BatchElem
+		queried    []common.Address
+		seen       = make(map[common.Address]struct{})
+	)
+	for _, feed := range feeds {
+		address := common.HexToAddress(feed.Vault)
+
+		if _, ok := seen[address]; ok {
+			continue
+		}
+		seen[address] = struct{}{}
+
+		if _, ok := f.getAsset(address); ok {
+			continue
+		}
+
+		var result string
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   address,
+					"data": hexutil.Bytes(payload),
+				},
+				"latest",
+			},
+			Result: &result,
+		})
+		queried = append(queried, address)
+	}
+
+	if len(batchElems) == 0 {
+		return nil
+	}
+
+	if err := f.client.BatchCallContext(ctx, batchElems); err != nil {
+		return fmt.Errorf("failed to batch call vault assets: %w", err)
+	}
+
+	for i, address := range queried {
+		asset, err := f.ParseAsset(batchElems[i])
+		if err != nil {
+			f.logger.Debug("failed to parse asset", zap.Stringer("vault", address), zap.Error(err))
+			continue
+		}
+
+		f.mtx.Lock()
+		f.assetCache[address] = asset
+		f.mtx.Unlock()
+	}
+
+	return nil
+}
+
+// verifyPairs queries the symbols and names of the vault and underlying asset of all feeds with a
+// base or quote symbol that are not verified yet, and caches whether they match. Feeds whose
+// tokens could not be queried are verified again on the next fetch.
+func (f *PriceFetcher) verifyPairs(
+	ctx context.Context,
+	feeds []FeedConfig,
+) error {
+	symbolPayload, err := f.abi.Pack(SymbolMethod)
+	if err != nil {
+		return fmt.Errorf("failed to pack symbol: %w", err)
+	}
+
+	namePayload, err := f.abi.Pack(NameMethod)
+	if err != nil {
+		return fmt.Errorf("failed to pack name: %w", err)
+	}
+
+	var (
+		batchElems []rpc.BatchElem
+		queried    []FeedConfig
+		seen       = make(map[FeedConfig]struct{})
+	)
+	for _, feed := range feeds {
+		if !feed.HasSymbols() {
+			continue
+		}
+
+		if _, ok := seen[feed]; ok {
+			continue
+		}
+		seen[feed] = struct{}{}
+
+		if verified, _ := f.getPairVerification(feed); verified {
+			continue
+		}
+
+		vault := common.HexToAddress(feed.Vault)
+		asset, ok := f.getAsset(vault)
+		if !ok {
+			continue
+		}
+
+		// The symbol and name of the vault shares, followed by those of the underlying asset.
+		for _, address := range []common.Address{vault, asset} {
+			for _, payload := range [][]byte{symbolPayload, namePayload} {
+				var result string
+				batchElems = append(batchElems, rpc.BatchElem{
+					Method: "eth_call",
+					Args: []interface{}{
+						map[string]interface{}{
+							"to":   address,
+							"data": hexutil.Bytes(payload),
+						},
+						"latest",
+					},
+					Result: &result,
+				})
+			}
+		}
+		queried = append(queried, feed)
+	}
+
+	if len(batchElems) == 0 {
+		return nil
+	}
+
+	if err := f.client.BatchCallContext(ctx, batchElems); err != nil {
+		return fmt.Errorf("failed to batch call vault symbols: %w", err)
+	}
+
+	for i, feed := range queried {
+		vault := common.HexToAddress(feed.Vault)
+		asset, _ := f.getAsset(vault)
+
+		var (
+			elems      = batchElems[4*i : 4*i+4]
+			mismatches []error
+			failed     bool
+		)
+		for j, token := range []struct {
+			kind     string
+			address  common.Address
+			expected string
+		}{
+			{"vault", vault, feed.BaseSymbol},
+			{"asset", asset, feed.QuoteSymbol},
+		} {
+			symbol, name, err := f.tokenSymbols(elems[2*j], elems[2*j+1])
+			if err != nil {
+				f.logger.Debug(
+					"failed to query token symbol",
+					zap.String(token.kind, token.address.Hex()),
+					zap.Error(err),
+				)
+
+				failed = true
+				break
+			}
+
+			if !MatchesSymbol(token.expected, symbol, name) {
+				mismatches = append(mismatches, fmt.Errorf(
+					"%s %s has symbol %q and name %q, expected %s",
+					token.kind, token.address, symbol, name, token.expected,
+				))
+			}
+		}
+
+		if failed {
+			continue
+		}
+
+		mismatch := errors.Join(mismatches...)
+		if mismatch != nil {
+			f.logger.Warn(
+				"vault does not match the configured pair; refusing to price it",
+				zap.String("vault", feed.Vault),
+				zap.Error(mismatch),
+			)
+		}
+
+		f.mtx.Lock()
+		f.pairCache[feed] = mismatch
+		f.mtx.Unlock()
+	}
+
+	return nil
+}
+
+// tokenSymbols parses the results of the symbol and name calls of a token. An error is returned if
+// neither could be queried.
+func (f *PriceFetcher) tokenSymbols(
+	symbolElem, nameElem rpc.BatchElem,
+) (string, string, error) {
+	symbol, symbolErr := f.ParseString(SymbolMethod, symbolElem)
+	name, nameErr := f.ParseString(NameMethod, nameElem)
+	if symbolErr != nil && nameErr != nil {
+		return "", "", errors.Join(symbolErr, nameErr)
+	}
+
+	return symbol, name, nil
+}
+
+// getPairVerification returns whether the symbols of the given feed were verified, and the
+// mismatch between them and the symbols of the vault, if any.
+func (f *PriceFetcher) getPairVerification(feed FeedConfig) (bool, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	err, ok := f.pairCache[feed]
+	return ok, err
+}
+
+// getAsset returns the cached underlying asset of the given vault.
+func (f *PriceFetcher) getAsset(vault common.Address) (common.Address, bool) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	asset, ok := f.assetCache[vault]
+	return asset, ok
+}
+
+// ParseAsset parses the underlying asset address from the result of an asset call.
+func (f *PriceFetcher) ParseAsset(
+	elem rpc.BatchElem,
+) (common.Address, error) {
+	if elem.Error != nil {
+		return common.Address{}, elem.Error
+	}
+
+	out, err := f.unpack(AssetMethod, elem.Result)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
+}
+
+// ParseString parses the result of a symbol or name call. Tokens that return a bytes32 rather than
+// a string, e.g. MKR, are supported as well.
+func (f *PriceFetcher) ParseString(
+	method string,
+	elem rpc.BatchElem,
+) (string, error) {
+	if elem.Error != nil {
+		return "", elem.Error
+	}
+
+	out, err := f.unpack(method, elem.Result)
+	if err == nil {
+		return strings.TrimSpace(*abi.ConvertType(out[0], new(string)).(*string)), nil
+	}
+
+	// bytes32 results are a single word, padded with zero bytes.
+	if r, ok := elem.Result.(*string); ok && r != nil {
+		if bz, decodeErr := hexutil.Decode(*r); decodeErr == nil && len(bz) == 32 {
+			return strings.TrimSpace(string(bytes.TrimRight(bz, "\x00"))), nil
+		}
+	}
+
+	return "", err
+}
+
+// ParseAssets parses the amount of the underlying asset from the result of a convertToAssets
+// call. An error is returned if the amount is not positive.
+func (f *PriceFetcher) ParseAssets(
+	result interface{},
+) (*big.Int, error) {
+	out, err := f.unpack(ConvertToAssetsMethod, result)
+	if err != nil {
+		return nil, err
+	}
+
+	assets := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	if assets.Sign() <= 0 {
+		return nil, fmt.Errorf("share rate must be positive")
+	}
+
+	return assets, nil
+}
+
+// unpack decodes the hex encoded result of a call and unpacks the outputs of the given method.
+func (f *PriceFetcher) unpack(
+	method string,
+	result interface{},
+) ([]interface{}, error) {
+	r, ok := result.(*string)
+	if !ok {
+		return nil, fmt.Errorf("expected result to be a string, got %T", result)
+	}
+
+	if r == nil {
+		return nil, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := f.abi.Methods[method].Outputs.UnpackValues(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	return out, nil
+}
+
+// OneShare returns one share of the given vault, in the smallest unit of the shares.
+func OneShare(vault Vault) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(vault.ShareDecimals), nil)
+}
+
+// ScalePrice scales the amount of the underlying asset that one share is worth by the decimals of
+// the asset.
+func ScalePrice(
+	vault Vault,
+	assets *big.Int,
+) (*big.Float, error) {
+	price, err := math.NewPrice(assets, -vault.AssetDecimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
@@ -0,0 +1,521 @@
+package erc4626_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc4626"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/testutils"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var (
+	logger, _ = zap.NewDevelopment()
+
+	sdai   = common.HexToAddress(erc4626.SDAIAddress)
+	dai    = common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	vault6 = common.HexToAddress("0xBEEF01735c132Ada46AA9aA4c54623cAA92A64CB")
+	usdc   = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	// Tickers used for testing.
+	sdaiTicker   = types.NewProviderTicker("SDAI/DAI", erc4626.SDAIFeedConfig.MustToJSON())
+	vault6Ticker = types.NewProviderTicker("STEAKUSDC/USDC", erc4626.FeedConfig{Vault: vault6.Hex()}.MustToJSON())
+
+	// Selectors of the contract methods that are called.
+	assetSelector           = selector("asset()")
+	decimalsSelector        = selector("decimals()")
+	convertToAssetsSelector = selector("convertToAssets(uint256)")
+	symbolSelector          = selector("symbol()")
+	nameSelector            = selector("name()")
+)
+
+// call is a contract call to the mocked chain.
+type call struct {
+	to       common.Address
+	selector string
+}
+
+// response is the result of a call to the mocked chain.
+type response struct {
+	result string
+	err    error
+}
+
+func TestFetch(t *testing.T) {
+	defaultChain := func() map[call]response {
+		return map[call]response{
+			{sdai, assetSelector}:           {result: encodeWord(dai.Bytes())},
+			{sdai, decimalsSelector}:        {result: encodeUint(t, "18")},
+			{dai, decimalsSelector}:         {result: encodeUint(t, "18")},
+			{sdai, convertToAssetsSelector}: {result: encodeUint(t, "1125000000000000000")},
+			{sdai, symbolSelector}:          {result: encodeString("sDAI")},
+			{sdai, nameSelector}:            {result: encodeString("Savings Dai")},
+			{dai, symbolSelector}:           {result: encodeString("DAI")},
+			{dai, nameSelector}:             {result: encodeString("Dai Stablecoin")},
+			{vault6, assetSelector}:         {result: encodeWord(usdc.Bytes())},
+			{vault6, decimalsSelector}:      {result: encodeUint(t, "18")},
+			{usdc, decimalsSelector}:        {result: encodeUint(t, "6")},
+			{vault6, convertToAssetsSelector}: {
+				result: encodeUint(t, "1062500"),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		tickers  []types.ProviderTicker
+		chain    func() map[call]response
+		expected types.PriceResponse
+	}{
+		{
+			name: "fails to retrieve feed for an invalid ticker",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("SDAI/DAI", `{"vault":"0x1234"}`),
+			},
+			chain: func() map[call]response {
+				return nil
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					types.NewProviderTicker("SDAI/DAI", `{"vault":"0x1234"}`): {},
+				},
+			},
+		},
+		{
+			name: "fails to resolve the asset of a vault",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+				vault6Ticker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{vault6, assetSelector}] = response{err: fmt.Errorf("execution reverted")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					sdaiTicker: {
+						Value: big.NewFloat(1.125),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					vault6Ticker: {},
+				},
+			},
+		},
+		{
+			name: "fails to resolve the decimals of an asset",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+				vault6Ticker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{usdc, decimalsSelector}] = response{err: fmt.Errorf("execution reverted")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					sdaiTicker: {
+						Value: big.NewFloat(1.125),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					vault6Ticker: {},
+				},
+			},
+		},
+		{
+			name: "convertToAssets reverts for a single ticker",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+				vault6Ticker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{sdai, convertToAssetsSelector}] = response{err: fmt.Errorf("execution reverted")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					vault6Ticker: {
+						Value: big.NewFloat(1.0625),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					sdaiTicker: {},
+				},
+			},
+		},
+		{
+			name: "refuses a vault whose asset does not match the quote symbol",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+				vault6Ticker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{dai, symbolSelector}] = response{result: encodeString("USDS")}
+				chain[call{dai, nameSelector}] = response{result: encodeString("USDS Stablecoin")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					vault6Ticker: {
+						Value: big.NewFloat(1.0625),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					sdaiTicker: {},
+				},
+			},
+		},
+		{
+			name: "refuses a vault whose shares do not match the base symbol",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{sdai, symbolSelector}] = response{result: encodeString("sUSDS")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					sdaiTicker: {},
+				},
+			},
+		},
+		{
+			name: "matches the name of a token if its symbol differs",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{sdai, symbolSelector}] = response{result: encodeString("SAVINGS")}
+				chain[call{sdai, nameSelector}] = response{result: encodeString("sdai")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					sdaiTicker: {
+						Value: big.NewFloat(1.125),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+		{
+			name: "supports tokens with bytes32 symbols",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{dai, symbolSelector}] = response{result: hexutil.Encode(common.RightPadBytes([]byte("DAI"), 32))}
+				chain[call{dai, nameSelector}] = response{err: fmt.Errorf("execution reverted")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					sdaiTicker: {
+						Value: big.NewFloat(1.125),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+		{
+			name: "fails to query the symbols of a vault",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{sdai, symbolSelector}] = response{err: fmt.Errorf("execution reverted")}
+				chain[call{sdai, nameSelector}] = response{err: fmt.Errorf("execution reverted")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					sdaiTicker: {},
+				},
+			},
+		},
+		{
+			name: "zero share rate",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+			},
+			chain: func() map[call]response {
+				chain := defaultChain()
+				chain[call{sdai, convertToAssetsSelector}] = response{result: encodeUint(t, "0")}
+				return chain
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					sdaiTicker: {},
+				},
+			},
+		},
+		{
+			name: "prices the shares of vaults with different decimals",
+			tickers: []types.ProviderTicker{
+				sdaiTicker,
+				vault6Ticker,
+			},
+			chain: defaultChain,
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					sdaiTicker: {
+						Value: big.NewFloat(1.125),
+					},
+					vault6Ticker: {
+						Value: big.NewFloat(1.0625),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := mocks.NewEVMClient(t)
+			if chain := tc.chain(); chain != nil {
+				mockChain(t, client, chain)
+			}
+
+			fetcher, err := erc4626.NewPriceFetcherWithClient(logger, erc4626.DefaultETHAPIConfig, client)
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Equal(t, len(tc.expected.Resolved), len(response.Resolved))
+			require.Equal(t, len(tc.expected.UnResolved), len(response.UnResolved))
+
+			for ticker, result := range tc.expected.Resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, result.Value.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+				require.Equal(t, testBlock.Info(), response.Resolved[ticker].Block)
+			}
+
+			for ticker := range tc.expected.UnResolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+}
+
+func TestFetchSimulatedChain(t *testing.T) {
+	chain := testutils.NewSimulatedChain(t)
+
+	sdai := chain.DeployERC4626Vault(
+		testutils.ERC20Token{Name: "Dai Stablecoin", Symbol: "DAI", Decimals: 18},
+		testutils.ERC4626Vault{
+			ERC20Token:     testutils.ERC20Token{Name: "Savings Dai", Symbol: "sDAI", Decimals: 18},
+			AssetsPerShare: big.NewInt(1_125_000_000_000_000_000),
+		},
+	)
+	steakUSDC := chain.DeployERC4626Vault(
+		testutils.ERC20Token{Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+		testutils.ERC4626Vault{
+			ERC20Token:     testutils.ERC20Token{Name: "Steakhouse USDC", Symbol: "steakUSDC", Decimals: 18},
+			AssetsPerShare: big.NewInt(1_062_500),
+		},
+	)
+
+	var (
+		sdaiTicker       = types.NewProviderTicker("SDAI/DAI", erc4626.FeedConfig{Vault: sdai.Hex(), BaseSymbol: "SDAI", QuoteSymbol: "DAI"}.MustToJSON())
+		steakUSDCTicker  = types.NewProviderTicker("STEAKUSDC/USDC", erc4626.FeedConfig{Vault: steakUSDC.Hex()}.MustToJSON())
+		mismatchedTicker = types.NewProviderTicker("SDAI/USDC", erc4626.FeedConfig{Vault: sdai.Hex(), QuoteSymbol: "USDC"}.MustToJSON())
+	)
+
+	api := erc4626.DefaultETHAPIConfig
+	api.Endpoints = []config.Endpoint{chain.Endpoint()}
+	api.ChainID = testutils.SimulatedChainID
+
+	fetcher, err := erc4626.NewPriceFetcher(context.Background(), logger, metrics.NewNopAPIMetrics(), api)
+	require.NoError(t, err)
+
+	response := fetcher.Fetch(context.Background(), []types.ProviderTicker{sdaiTicker, steakUSDCTicker, mismatchedTicker})
+	require.Len(t, response.Resolved, 2)
+	require.Len(t, response.UnResolved, 1)
+	require.Contains(t, response.UnResolved, mismatchedTicker)
+
+	require.Equal(t, big.NewFloat(1.125).SetPrec(40), response.Resolved[sdaiTicker].Value.SetPrec(40))
+	require.Equal(t, big.NewFloat(1.0625).SetPrec(40), response.Resolved[steakUSDCTicker].Value.SetPrec(40))
+
+	// the vaults are read at the latest block, i.e. the block that deployed the last contract.
+	require.Equal(t, uint64(4), response.Resolved[sdaiTicker].Block.Number)
+}
+
+func TestFetchCachesVaults(t *testing.T) {
+	chain := map[call]response{
+		{sdai, assetSelector}:           {result: encodeWord(dai.Bytes())},
+		{sdai, decimalsSelector}:        {result: encodeUint(t, "18")},
+		{dai, decimalsSelector}:         {result: encodeUint(t, "18")},
+		{sdai, convertToAssetsSelector}: {result: encodeUint(t, "1125000000000000000")},
+		{sdai, symbolSelector}:          {result: encodeString("sDAI")},
+		{sdai, nameSelector}:            {result: encodeString("Savings Dai")},
+		{dai, symbolSelector}:           {result: encodeString("DAI")},
+		{dai, nameSelector}:             {result: encodeString("Dai Stablecoin")},
+	}
+
+	client := mocks.NewEVMClient(t)
+	calls := mockChain(t, client, chain)
+
+	fetcher, err := erc4626.NewPriceFetcherWithClient(logger, erc4626.DefaultETHAPIConfig, client)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{sdaiTicker})
+		require.Len(t, resp.Resolved, 1)
+	}
+
+	// The asset, decimals and symbols are only queried on the first fetch.
+	require.Equal(t, 1, (*calls)[call{sdai, assetSelector}])
+	require.Equal(t, 1, (*calls)[call{sdai, decimalsSelector}])
+	require.Equal(t, 1, (*calls)[call{dai, decimalsSelector}])
+	require.Equal(t, 1, (*calls)[call{sdai, symbolSelector}])
+	require.Equal(t, 1, (*calls)[call{dai, symbolSelector}])
+	require.Equal(t, 2, (*calls)[call{sdai, convertToAssetsSelector}])
+}
+
+func TestFetchCachesMismatchedPairs(t *testing.T) {
+	chain := map[call]response{
+		{sdai, assetSelector}:    {result: encodeWord(usdc.Bytes())},
+		{sdai, decimalsSelector}: {result: encodeUint(t, "18")},
+		{usdc, decimalsSelector}: {result: encodeUint(t, "6")},
+		{sdai, symbolSelector}:   {result: encodeString("sDAI")},
+		{sdai, nameSelector}:     {result: encodeString("Savings Dai")},
+		{usdc, symbolSelector}:   {result: encodeString("USDC")},
+		{usdc, nameSelector}:     {result: encodeString("USD Coin")},
+	}
+
+	client := mocks.NewEVMClient(t)
+	calls := mockChain(t, client, chain)
+
+	fetcher, err := erc4626.NewPriceFetcherWithClient(logger, erc4626.DefaultETHAPIConfig, client)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{sdaiTicker})
+		require.Empty(t, resp.Resolved)
+		require.Contains(t, resp.UnResolved, sdaiTicker)
+		require.ErrorContains(t, resp.UnResolved[sdaiTicker], "expected DAI")
+	}
+
+	// The mismatched vault is never priced, and its symbols are not queried again.
+	require.Equal(t, 1, (*calls)[call{usdc, symbolSelector}])
+	require.Zero(t, (*calls)[call{sdai, convertToAssetsSelector}])
+}
+
+func TestMatchesSymbol(t *testing.T) {
+	require.True(t, erc4626.MatchesSymbol("", "sDAI", "Savings Dai"))
+	require.True(t, erc4626.MatchesSymbol("SDAI", "sDAI", "Savings Dai"))
+	require.True(t, erc4626.MatchesSymbol("savings dai", "sDAI", "Savings Dai"))
+	require.False(t, erc4626.MatchesSymbol("DAI", "sDAI", "Savings Dai"))
+}
+
+func TestScalePrice(t *testing.T) {
+	vault := erc4626.Vault{ShareDecimals: 18, AssetDecimals: 6}
+	require.Equal(t, "1000000000000000000", erc4626.OneShare(vault).String())
+
+	price, err := erc4626.ScalePrice(vault, big.NewInt(1062500))
+	require.NoError(t, err)
+	require.Equal(t, big.NewFloat(1.0625).SetPrec(40), price.SetPrec(40))
+
+	// ERC20 decimals are read from the chain, and may exceed the max exponent of a price.
+	_, err = erc4626.ScalePrice(erc4626.Vault{ShareDecimals: 18, AssetDecimals: 255}, big.NewInt(1))
+	require.Error(t, err)
+}
+
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+// mockChain mocks the batch calls of the client, responding to each call with the response of its
+// target and method selector. The returned map counts the calls made to each target and method.
+func mockChain(t *testing.T, client *mocks.EVMClient, chain map[call]response) *map[call]int {
+	t.Helper()
+
+	calls := make(map[call]int)
+	client.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+
+		for i, elem := range elems {
+			// The block at which the shares are priced is read along with them.
+			if elem.Method == "eth_getBlockByNumber" {
+				*elem.Result.(*ethmulticlient.BlockHeader) = testBlock
+				continue
+			}
+
+			msg, ok := elem.Args[0].(map[string]interface{})
+			require.True(t, ok)
+
+			to, ok := msg["to"].(common.Address)
+			require.True(t, ok)
+
+			data, ok := msg["data"].(hexutil.Bytes)
+			require.True(t, ok)
+
+			c := call{to, hexutil.Encode(data[:4])}
+			calls[c]++
+
+			resp, ok := chain[c]
+			require.True(t, ok, "unexpected call %v", c)
+
+			result := resp.result
+			elems[i].Result = &result
+			elems[i].Error = resp.err
+		}
+	}).Maybe()
+
+	return &calls
+}
+
+func selector(signature string) string {
+	return hexutil.Encode(crypto.Keccak256([]byte(signature))[:4])
+}
+
+func encodeWord(bz []byte) string {
+	return hexutil.Encode(common.LeftPadBytes(bz, 32))
+}
+
+func encodeUint(t *testing.T, value string) string {
+	t.Helper()
+
+	v, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok)
+
+	return encodeWord(v.Bytes())
+}
+
+// encodeString ABI encodes a string returned by a call.
+func encodeString(value string) string {
+	bz := common.LeftPadBytes([]byte{0x20}, 32)
+	bz = append(bz, common.LeftPadBytes(big.NewInt(int64(len(value))).Bytes(), 32)...)
+	bz = append(bz, common.RightPadBytes([]byte(value), (len(value)+31)/32*32)...)
+
+	return hexutil.Encode(bz)
+}
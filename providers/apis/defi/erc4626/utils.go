@@ -0,0 +1,156 @@
+package erc4626
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+const (
+	// BaseName is the name of the ERC4626 API.
+	BaseName = "erc4626_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// AssetMethod is the ERC4626 method that returns the address of the underlying asset of the vault.
+	AssetMethod = "asset"
+
+	// ConvertToAssetsMethod is the ERC4626 method that returns the amount of the underlying asset
+	// that the given amount of shares is worth.
+	ConvertToAssetsMethod = "convertToAssets"
+
+	// SymbolMethod is the ERC20 method that returns the symbol of a token. ERC4626 vaults implement
+	// the same method for the symbol of their shares.
+	SymbolMethod = "symbol"
+
+	// NameMethod is the ERC20 method that returns the name of a token.
+	NameMethod = "name"
+
+	// ContractABI is the ABI of the ERC4626 methods that are used to price vault shares, and of the
+	// ERC20 methods that are used to verify the symbols of the shares and the underlying asset.
+	ContractABI = `[{"inputs":[],"name":"asset","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"shares","type":"uint256"}],"name":"convertToAssets","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"}]`
+
+	// SDAIAddress is the address of the Savings Dai (sDAI) vault on Ethereum Mainnet.
+	SDAIAddress = "0x83F20F44975D03b1b09e64809B757c47f942BEeA"
+
+	// ETH_URL is the URL for the ERC4626 API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+
+	// BASE_URL is the URL for the ERC4626 API. This uses a free public RPC provider on Base Mainnet.
+	BASE_URL = "https://mainnet.base.org"
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+	constants.BASE:     strings.Join([]string{BaseName, constants.BASE}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedConfig is the configuration of an ERC4626 vault share rate. This is specific to each vault,
+// and prices one share of the vault in units of the vault's underlying asset.
+type FeedConfig struct {
+	// Vault is the address of the ERC4626 vault.
+	Vault string `json:"vault" schema:"address"`
+
+	// BaseSymbol is the expected symbol of the vault shares, i.e. the base of the ticker's pair,
+	// e.g. SDAI. If set, the vault is only priced if the symbol or name of the vault matches it.
+	BaseSymbol string `json:"base_symbol,omitempty"`
+
+	// QuoteSymbol is the expected symbol of the vault's underlying asset, i.e. the quote of the
+	// ticker's pair, e.g. DAI. If set, the vault is only priced if the symbol or name of the asset
+	// returned by asset() matches it.
+	QuoteSymbol string `json:"quote_symbol,omitempty"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if !common.IsHexAddress(fc.Vault) {
+		return fmt.Errorf("vault address is not a valid ethereum address")
+	}
+
+	if fc.BaseSymbol != strings.TrimSpace(fc.BaseSymbol) || fc.QuoteSymbol != strings.TrimSpace(fc.QuoteSymbol) {
+		return fmt.Errorf("base and quote symbols cannot have leading or trailing whitespace")
+	}
+
+	return nil
+}
+
+// HasSymbols returns true if the base or quote symbol is set, i.e. if the pair of the feed must be
+// verified against the vault before it is priced.
+func (fc *FeedConfig) HasSymbols() bool {
+	return fc.BaseSymbol != "" || fc.QuoteSymbol != ""
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// SDAIFeedConfig prices sDAI in DAI.
+var SDAIFeedConfig = FeedConfig{
+	Vault:       SDAIAddress,
+	BaseSymbol:  "SDAI",
+	QuoteSymbol: "DAI",
+}
+
+var (
+	// DefaultETHAPIConfig is the default configuration for the ERC4626 API. Specifically this is for
+	// Ethereum mainnet.
+	DefaultETHAPIConfig = config.APIConfig{
+		Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+		Atomic:            true,
+		Enabled:           true,
+		Timeout:           1000 * time.Millisecond,
+		Interval:          2000 * time.Millisecond,
+		ReconnectTimeout:  2000 * time.Millisecond,
+		MaxQueries:        1,
+		Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+		MaxBlockHeightAge: 30 * time.Second,
+	}
+
+	// DefaultBaseAPIConfig is the default configuration for the ERC4626 API. Specifically this is for
+	// Base mainnet.
+	DefaultBaseAPIConfig = config.APIConfig{
+		Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.BASE),
+		Atomic:            true,
+		Enabled:           true,
+		Timeout:           1000 * time.Millisecond,
+		Interval:          2000 * time.Millisecond,
+		ReconnectTimeout:  2000 * time.Millisecond,
+		MaxQueries:        1,
+		Endpoints:         []config.Endpoint{{URL: BASE_URL}},
+		MaxBlockHeightAge: 30 * time.Second,
+	}
+)
+
+// MatchesSymbol returns true if the symbol or name of a token matches the expected symbol, ignoring
+// case. Any token matches an empty expected symbol.
+func MatchesSymbol(expected, symbol, name string) bool {
+	if expected == "" {
+		return true
+	}
+
+	return strings.EqualFold(symbol, expected) || strings.EqualFold(name, expected)
+}
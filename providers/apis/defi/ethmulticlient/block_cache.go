@@ -0,0 +1,382 @@
+package ethmulticlient
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+var (
+	blockCachesMtx sync.Mutex
+	// blockCaches are the block caches of all endpoints that cache responses, indexed by endpoint
+	// URL. These are shared by all clients so that providers using the same endpoint share the
+	// latest block of the endpoint and the responses read at it.
+	blockCaches = make(map[string]*BlockCache)
+)
+
+// BatchCallFunc sends a batch call to an endpoint.
+type BatchCallFunc func(ctx context.Context, calls []rpc.BatchElem) error
+
+// BlockCache tracks the latest block of an endpoint, and caches the responses of the calls made to
+// the endpoint at that block. The latest block is observed from the eth_blockNumber and latest
+// eth_getBlockByNumber calls made to the endpoint, i.e. the block each EVM provider reads along with
+// its calls, so that a new block observed by any provider invalidates the cache of all of them.
+// Batches that do not read the latest block read it with an additional eth_blockNumber call when
+// they are sent, so that their responses can be cached as well. Calls that read the latest block are
+// never cached. Cached responses are served until a newer block is observed, or the latest block was
+// not observed for the max age.
+type BlockCache struct {
+	mtx sync.Mutex
+
+	maxAge time.Duration
+	// height is the latest block observed at the endpoint, or 0 if no block was observed.
+	height uint64
+	// observed is the time the latest block was last observed.
+	observed time.Time
+	// responses are the responses of the calls answered at the latest block, indexed by call.
+	responses map[string]json.RawMessage
+}
+
+// SharedBlockCache returns the block cache of the given endpoint, or nil if the endpoint does not
+// cache responses. All clients of the same endpoint URL share a single cache. If providers configure
+// different max ages for the same endpoint, the shortest max age is used.
+func SharedBlockCache(endpoint config.Endpoint) *BlockCache {
+	if !endpoint.BlockCache.Enabled() {
+		return nil
+	}
+
+	blockCachesMtx.Lock()
+	defer blockCachesMtx.Unlock()
+
+	b, ok := blockCaches[endpoint.URL]
+	if !ok {
+		b = NewBlockCache(endpoint.BlockCache.MaxAge)
+		blockCaches[endpoint.URL] = b
+		return b
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if endpoint.BlockCache.MaxAge < b.maxAge {
+		b.maxAge = endpoint.BlockCache.MaxAge
+	}
+
+	return b
+}
+
+// NewBlockCache returns a new BlockCache that serves the responses read at the latest block for
+// up to maxAge after the block was last observed.
+func NewBlockCache(maxAge time.Duration) *BlockCache {
+	return &BlockCache{
+		maxAge:    maxAge,
+		responses: make(map[string]json.RawMessage),
+	}
+}
+
+// Height returns the latest block observed at the endpoint, or 0 if no block was observed.
+func (b *BlockCache) Height() uint64 {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.height
+}
+
+// Observe records that the latest block of the endpoint is at the given height. A newer block
+// clears the cache. An older block, e.g. returned by a lagging node behind a load balancer, is
+// ignored.
+func (b *BlockCache) Observe(height uint64) {
+	if height == 0 {
+		return
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	switch {
+	case height > b.height:
+		b.height = height
+		b.responses = make(map[string]json.RawMessage)
+		b.observed = time.Now()
+	case height == b.height:
+		b.observed = time.Now()
+	}
+}
+
+// BatchCallContext serves the calls from the cache if every call that does not read the latest block
+// was answered at the latest block. The calls that do read the latest block are sent, and the other
+// calls are only served from the cache if no newer block was read. Otherwise, identical calls (e.g.
+// the calls of several pairs that read the same contract) are sent once with the given send
+// function, and their responses are copied into each of the calls. As with
+// rpc.Client.BatchCallContext, errors specific to a call are reported through the Error field of the
+// corresponding BatchElem, and are never cached.
+func (b *BlockCache) BatchCallContext(ctx context.Context, calls []rpc.BatchElem, send BatchCallFunc) error {
+	if len(calls) == 0 {
+		return send(ctx, calls)
+	}
+
+	keys := make([]string, len(calls))
+	for i, call := range calls {
+		key, err := cacheKey(calls[i : i+1])
+		if err != nil {
+			return send(ctx, calls)
+		}
+		keys[i] = key
+
+		// calls without a result cannot be copied into.
+		if call.Result == nil {
+			return send(ctx, calls)
+		}
+	}
+
+	var (
+		head []int
+		rest []int
+	)
+	for i, call := range calls {
+		if readsLatestBlock(call) {
+			head = append(head, i)
+		} else {
+			rest = append(rest, i)
+		}
+	}
+
+	restKeys := make([]string, len(rest))
+	for j, i := range rest {
+		restKeys[j] = keys[i]
+	}
+
+	if len(rest) == 0 || !b.cached(restKeys) {
+		return b.sendUnique(ctx, keys, calls, send)
+	}
+
+	if len(head) > 0 {
+		if err := b.readLatestBlock(ctx, calls, head, send); err != nil {
+			return err
+		}
+	}
+
+	restCalls := make([]rpc.BatchElem, len(rest))
+	for j, i := range rest {
+		restCalls[j] = calls[i]
+	}
+
+	if !b.fromCache(restKeys, restCalls) {
+		// a newer block was produced, so the calls are read at it.
+		if err := b.sendUnique(ctx, restKeys, restCalls, send); err != nil {
+			return err
+		}
+	}
+
+	for j, i := range rest {
+		calls[i].Error = restCalls[j].Error
+	}
+
+	return nil
+}
+
+// readLatestBlock sends the calls at the given indices, which read the latest block, and observes
+// the latest block from their responses. If the latest block cannot be read, the cache is cleared
+// so that the other calls are read again.
+func (b *BlockCache) readLatestBlock(ctx context.Context, calls []rpc.BatchElem, indices []int, send BatchCallFunc) error {
+	probe := make([]rpc.BatchElem, 0, len(indices))
+	for _, i := range indices {
+		probe = append(probe, rpc.BatchElem{
+			Method: calls[i].Method,
+			Args:   calls[i].Args,
+			Result: new(json.RawMessage),
+		})
+	}
+
+	if err := send(ctx, probe); err != nil {
+		return err
+	}
+
+	for j, i := range indices {
+		if probe[j].Error != nil {
+			calls[i].Error = probe[j].Error
+			continue
+		}
+
+		calls[i].Error = json.Unmarshal(*probe[j].Result.(*json.RawMessage), calls[i].Result)
+	}
+
+	height := latestHeight(probe)
+	if height == 0 {
+		b.clear()
+		return nil
+	}
+
+	b.Observe(height)
+	return nil
+}
+
+// sendUnique sends identical calls once with the given send function and copies their responses
+// into each of the calls. If none of the calls read the latest block, an eth_blockNumber call is
+// sent along with them. The latest block is then observed from the responses, and the responses are
+// cached if they were read at the latest block.
+func (b *BlockCache) sendUnique(ctx context.Context, keys []string, calls []rpc.BatchElem, send BatchCallFunc) error {
+	var (
+		index     = make(map[string]int)
+		positions = make([]int, len(calls))
+		unique    = make([]rpc.BatchElem, 0, len(calls))
+		uniqueKey = make([]string, 0, len(calls))
+	)
+	for i, call := range calls {
+		j, ok := index[keys[i]]
+		if !ok {
+			j = len(unique)
+			index[keys[i]] = j
+			unique = append(unique, rpc.BatchElem{
+				Method: call.Method,
+				Args:   call.Args,
+				Result: new(json.RawMessage),
+			})
+			uniqueKey = append(uniqueKey, keys[i])
+		}
+		positions[i] = j
+	}
+
+	if !slices.ContainsFunc(unique, readsLatestBlock) {
+		unique = append(unique, rpc.BatchElem{
+			Method: "eth_blockNumber",
+			Result: new(json.RawMessage),
+		})
+		uniqueKey = append(uniqueKey, "")
+	}
+
+	if err := send(ctx, unique); err != nil {
+		return err
+	}
+
+	for i := range calls {
+		elem := unique[positions[i]]
+		if elem.Error != nil {
+			calls[i].Error = elem.Error
+			continue
+		}
+
+		calls[i].Error = json.Unmarshal(*elem.Result.(*json.RawMessage), calls[i].Result)
+	}
+
+	b.toCache(uniqueKey, unique)
+	return nil
+}
+
+// cached returns true if all of the calls were answered at the latest block, and the latest block
+// was observed within the max age.
+func (b *BlockCache) cached(keys []string) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.hasLocked(keys)
+}
+
+// hasLocked returns true if all of the calls were answered at the latest block, and the latest
+// block was observed within the max age. The mutex must be held.
+func (b *BlockCache) hasLocked(keys []string) bool {
+	if b.height == 0 || time.Since(b.observed) > b.maxAge {
+		return false
+	}
+
+	for _, key := range keys {
+		if _, ok := b.responses[key]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// clear discards the cached responses.
+func (b *BlockCache) clear() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.responses = make(map[string]json.RawMessage)
+}
+
+// fromCache populates the calls from the cache. It returns false if any of the calls was not
+// answered at the latest block, or the latest block was not observed within the max age.
+func (b *BlockCache) fromCache(keys []string, calls []rpc.BatchElem) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if !b.hasLocked(keys) {
+		return false
+	}
+
+	for i, key := range keys {
+		if err := json.Unmarshal(b.responses[key], calls[i].Result); err != nil {
+			return false
+		}
+		calls[i].Error = nil
+	}
+
+	return true
+}
+
+// toCache observes the latest block from the responses of the calls, and caches the responses if
+// they were read at the latest block, i.e. if no newer block was observed by another provider.
+func (b *BlockCache) toCache(keys []string, calls []rpc.BatchElem) {
+	read := latestHeight(calls)
+	b.Observe(read)
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if read == 0 || read != b.height {
+		return
+	}
+
+	for i, call := range calls {
+		if call.Error != nil || readsLatestBlock(call) {
+			continue
+		}
+		b.responses[keys[i]] = *call.Result.(*json.RawMessage)
+	}
+}
+
+// readsLatestBlock returns true if the call reads the latest block, i.e. is an eth_blockNumber or
+// latest eth_getBlockByNumber call. The responses of these calls are never cached.
+func readsLatestBlock(call rpc.BatchElem) bool {
+	return call.Method == "eth_blockNumber" ||
+		(call.Method == "eth_getBlockByNumber" && len(call.Args) > 0 && call.Args[0] == "latest")
+}
+
+// latestHeight returns the latest block read by the given calls, i.e. the result of an
+// eth_blockNumber or latest eth_getBlockByNumber call, or 0 if none of the calls read it. The
+// results of the calls are expected to be raw messages.
+func latestHeight(calls []rpc.BatchElem) uint64 {
+	var height uint64
+	for _, call := range calls {
+		raw, ok := call.Result.(*json.RawMessage)
+		if call.Error != nil || !ok {
+			continue
+		}
+
+		switch {
+		case !readsLatestBlock(call):
+			continue
+		case call.Method == "eth_blockNumber":
+			var number hexutil.Uint64
+			if err := json.Unmarshal(*raw, &number); err == nil {
+				height = max(height, uint64(number))
+			}
+		default:
+			var header BlockHeader
+			if err := json.Unmarshal(*raw, &header); err == nil {
+				height = max(height, uint64(header.Number))
+			}
+		}
+	}
+
+	return height
+}
@@ -0,0 +1,251 @@
+package ethmulticlient_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+)
+
+// blockSender answers each eth_call with its contract address and each eth_blockNumber and
+// eth_getBlockByNumber call with its height, and fails calls to the fail contract. It records the
+// calls of each batch it sends.
+type blockSender struct {
+	height  uint64
+	batches [][]rpc.BatchElem
+}
+
+func (s *blockSender) send(_ context.Context, calls []rpc.BatchElem) error {
+	s.batches = append(s.batches, calls)
+
+	for i, call := range calls {
+		var result interface{}
+		switch call.Method {
+		case "eth_blockNumber":
+			result = hexutil.Uint64(s.height)
+		case "eth_getBlockByNumber":
+			result = ethmulticlient.BlockHeader{Number: hexutil.Uint64(s.height)}
+		case "eth_call":
+			if call.Args[0] == "fail" {
+				calls[i].Error = fmt.Errorf("execution reverted")
+				continue
+			}
+			result = call.Args[0]
+		}
+
+		bz, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		*calls[i].Result.(*json.RawMessage) = bz
+	}
+
+	return nil
+}
+
+func blockCacheCalls(contracts ...string) []rpc.BatchElem {
+	calls := make([]rpc.BatchElem, 0, len(contracts)+1)
+	for _, contract := range contracts {
+		calls = append(calls, rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{contract, "latest"},
+			Result: new(string),
+		})
+	}
+
+	return append(calls, ethmulticlient.EthGetBlockByNumberBatchElem("latest"))
+}
+
+func TestBlockCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("identical calls are sent once", func(t *testing.T) {
+		sender := &blockSender{height: 100}
+		cache := ethmulticlient.NewBlockCache(time.Minute)
+
+		calls := blockCacheCalls("pool", "pool", "feed")
+		require.NoError(t, cache.BatchCallContext(ctx, calls, sender.send))
+		require.Len(t, sender.batches, 1)
+		require.Len(t, sender.batches[0], 3)
+
+		require.Equal(t, "pool", *calls[0].Result.(*string))
+		require.Equal(t, "pool", *calls[1].Result.(*string))
+		require.Equal(t, "feed", *calls[2].Result.(*string))
+		require.Equal(t, hexutil.Uint64(100), calls[3].Result.(*ethmulticlient.BlockHeader).Number)
+		require.Equal(t, uint64(100), cache.Height())
+	})
+
+	t.Run("calls are served from the cache until a new block is observed", func(t *testing.T) {
+		sender := &blockSender{height: 100}
+		cache := ethmulticlient.NewBlockCache(time.Minute)
+
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+
+		// only the latest block is read, and the other calls are served from the cache.
+		calls := blockCacheCalls("pool")
+		require.NoError(t, cache.BatchCallContext(ctx, calls, sender.send))
+		require.Len(t, sender.batches, 2)
+		require.Len(t, sender.batches[1], 1)
+		require.Equal(t, "eth_getBlockByNumber", sender.batches[1][0].Method)
+		require.Equal(t, "pool", *calls[0].Result.(*string))
+		require.Equal(t, hexutil.Uint64(100), calls[1].Result.(*ethmulticlient.BlockHeader).Number)
+
+		// calls that were not answered at the latest block are sent.
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool", "feed"), sender.send))
+		require.Len(t, sender.batches, 3)
+		require.Len(t, sender.batches[2], 3)
+
+		// an older block is ignored.
+		cache.Observe(99)
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+		require.Len(t, sender.batches, 4)
+		require.Len(t, sender.batches[3], 1)
+
+		// a new block, e.g. observed by another provider, clears the cache.
+		sender.height = 101
+		cache.Observe(101)
+		calls = blockCacheCalls("pool")
+		require.NoError(t, cache.BatchCallContext(ctx, calls, sender.send))
+		require.Len(t, sender.batches, 5)
+		require.Len(t, sender.batches[4], 2)
+		require.Equal(t, hexutil.Uint64(101), calls[1].Result.(*ethmulticlient.BlockHeader).Number)
+	})
+
+	t.Run("a new block produced within the max age is read", func(t *testing.T) {
+		sender := &blockSender{height: 100}
+		cache := ethmulticlient.NewBlockCache(time.Hour)
+
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+
+		// the block is not observed by any other provider, but is read before the cache is served.
+		sender.height = 101
+		calls := blockCacheCalls("pool")
+		require.NoError(t, cache.BatchCallContext(ctx, calls, sender.send))
+		require.Len(t, sender.batches, 3)
+		require.Len(t, sender.batches[1], 1)
+		require.Len(t, sender.batches[2], 2)
+		require.Equal(t, "eth_call", sender.batches[2][0].Method)
+		require.Equal(t, hexutil.Uint64(101), calls[1].Result.(*ethmulticlient.BlockHeader).Number)
+		require.Equal(t, uint64(101), cache.Height())
+
+		// the calls read at the new block are cached.
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+		require.Len(t, sender.batches, 4)
+		require.Len(t, sender.batches[3], 1)
+	})
+
+	t.Run("calls that do not read the latest block are served without calling the endpoint", func(t *testing.T) {
+		sender := &blockSender{height: 100}
+		cache := ethmulticlient.NewBlockCache(time.Hour)
+
+		// the latest block is read along with the calls, so that their responses are cached.
+		calls := blockCacheCalls("pool")[:1]
+		require.NoError(t, cache.BatchCallContext(ctx, calls, sender.send))
+		require.Len(t, sender.batches, 1)
+		require.Len(t, sender.batches[0], 2)
+		require.Equal(t, "eth_blockNumber", sender.batches[0][1].Method)
+		require.Equal(t, uint64(100), cache.Height())
+
+		for i := 0; i < 3; i++ {
+			calls = blockCacheCalls("pool")[:1]
+			require.NoError(t, cache.BatchCallContext(ctx, calls, sender.send))
+			require.Equal(t, "pool", *calls[0].Result.(*string))
+		}
+		require.Len(t, sender.batches, 1)
+
+		// a new block observed by another provider clears the cache.
+		sender.height = 101
+		cache.Observe(101)
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool")[:1], sender.send))
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool")[:1], sender.send))
+		require.Len(t, sender.batches, 2)
+	})
+
+	t.Run("the latest block is not cached", func(t *testing.T) {
+		sender := &blockSender{height: 100}
+		cache := ethmulticlient.NewBlockCache(time.Hour)
+
+		block := []rpc.BatchElem{ethmulticlient.EthGetBlockByNumberBatchElem("latest")}
+		require.NoError(t, cache.BatchCallContext(ctx, block, sender.send))
+
+		sender.height = 101
+		block = []rpc.BatchElem{ethmulticlient.EthGetBlockByNumberBatchElem("latest")}
+		require.NoError(t, cache.BatchCallContext(ctx, block, sender.send))
+		require.Len(t, sender.batches, 2)
+		require.Equal(t, hexutil.Uint64(101), block[0].Result.(*ethmulticlient.BlockHeader).Number)
+	})
+
+	t.Run("responses read at an outdated block are not cached", func(t *testing.T) {
+		sender := &blockSender{height: 100}
+		cache := ethmulticlient.NewBlockCache(time.Minute)
+		cache.Observe(101)
+
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+		require.Len(t, sender.batches, 2)
+		require.Equal(t, uint64(101), cache.Height())
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		sender := &blockSender{height: 100}
+		cache := ethmulticlient.NewBlockCache(time.Minute)
+
+		calls := blockCacheCalls("fail", "fail")
+		require.NoError(t, cache.BatchCallContext(ctx, calls, sender.send))
+		require.Error(t, calls[0].Error)
+		require.Error(t, calls[1].Error)
+
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("fail"), sender.send))
+		require.Len(t, sender.batches, 2)
+	})
+
+	t.Run("the cache is bypassed once the block was not observed for the max age", func(t *testing.T) {
+		sender := &blockSender{height: 100}
+		cache := ethmulticlient.NewBlockCache(20 * time.Millisecond)
+
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+		require.Len(t, sender.batches, 2)
+		require.Len(t, sender.batches[1], 1)
+
+		time.Sleep(40 * time.Millisecond)
+		require.NoError(t, cache.BatchCallContext(ctx, blockCacheCalls("pool"), sender.send))
+		require.Len(t, sender.batches, 3)
+		require.Len(t, sender.batches[2], 2)
+	})
+
+	t.Run("batch errors are returned", func(t *testing.T) {
+		cache := ethmulticlient.NewBlockCache(time.Minute)
+		err := cache.BatchCallContext(ctx, blockCacheCalls("pool"), func(context.Context, []rpc.BatchElem) error {
+			return fmt.Errorf("connection refused")
+		})
+		require.EqualError(t, err, "connection refused")
+		require.Zero(t, cache.Height())
+	})
+}
+
+func TestSharedBlockCache(t *testing.T) {
+	require.Nil(t, ethmulticlient.SharedBlockCache(config.Endpoint{URL: "http://block-cache.test"}))
+
+	cache := ethmulticlient.SharedBlockCache(config.Endpoint{
+		URL:        "http://block-cache.test",
+		BlockCache: config.BlockCacheConfig{MaxAge: time.Minute},
+	})
+	require.NotNil(t, cache)
+	require.Same(t, cache, ethmulticlient.SharedBlockCache(config.Endpoint{
+		URL:        "http://block-cache.test",
+		BlockCache: config.BlockCacheConfig{MaxAge: time.Second},
+	}))
+	require.NotSame(t, cache, ethmulticlient.SharedBlockCache(config.Endpoint{
+		URL:        "http://other-block-cache.test",
+		BlockCache: config.BlockCacheConfig{MaxAge: time.Minute},
+	}))
+}
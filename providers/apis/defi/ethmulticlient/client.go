@@ -2,16 +2,39 @@ package ethmulticlient
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/time/rate"
+
 	"github.com/skip-mev/connect/v2/oracle/config"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
 
-	"github.com/ethereum/go-ethereum/rpc"
+const (
+	// rpcCodeExecutionReverted is the JSON-RPC error code returned by EVM nodes for calls that
+	// reverted.
+	rpcCodeExecutionReverted = 3
+	// rpcCodeLimitExceeded is the JSON-RPC error code returned by EVM nodes for requests that
+	// exceeded a rate limit.
+	rpcCodeLimitExceeded = -32005
 )
 
+// ErrChainIDMismatch is returned when the chain ID of an endpoint does not match the chain ID
+// expected by the API config.
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
 // EVMClient is an interface that abstracts the evm client.
 //
 //go:generate mockery --name EVMClient
@@ -31,6 +54,26 @@ type GoEthereumClientImpl struct {
 
 	// client is the underlying rpc client.
 	client *rpc.Client
+	// limiter is the rate limiter of the endpoint. This is shared by all clients of the endpoint, and
+	// is nil if the endpoint is not rate limited.
+	limiter *rate.Limiter
+	// maxBatchLength is the maximum number of calls sent in a single batch request, or 0 if the
+	// length of batch requests is not limited.
+	maxBatchLength int
+	// inFlight limits the number of requests the provider has in flight. This is shared by all
+	// clients of the provider, and is nil if the provider does not limit its requests in flight.
+	inFlight *connecthttp.InFlightLimiter
+	// coalescer coalesces the requests of all clients of the endpoint into shared batch requests.
+	// This is nil if the endpoint does not coalesce requests, in which case each request is sent
+	// as its own batch.
+	coalescer *Coalescer
+	// blockCache caches the responses of the endpoint at its latest block. This is shared by all
+	// clients of the endpoint, and is nil if the endpoint does not cache responses.
+	blockCache *BlockCache
+
+	mtx sync.Mutex
+	// chainIDVerified is true once the chain ID of the endpoint has been verified.
+	chainIDVerified bool
 }
 
 // NewGoEthereumClientImpl creates an EVMClient via a config.Endpoint. This
@@ -62,26 +105,53 @@ func NewGoEthereumClientImpl(
 		return nil, fmt.Errorf("expected endpoint at index %d, got %d endpoints", index, len(api.Endpoints))
 	}
 
-	var opts []rpc.ClientOption
-	endpoint := api.Endpoints[index] // pin
+	client, err := DialEndpoint(ctx, api.Endpoints[index])
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial go ethereum client: %w", err)
+	}
+
+	c := &GoEthereumClientImpl{
+		apiMetrics:     apiMetrics,
+		api:            api,
+		redactedURL:    metrics.RedactedEndpointURL(index),
+		client:         client,
+		limiter:        SharedRateLimiter(api.Endpoints[index]),
+		maxBatchLength: api.Endpoints[index].MaxBatchLength,
+		inFlight:       connecthttp.SharedInFlightLimiter(api.Name, api.MaxInFlight),
+		coalescer:      SharedCoalescer(api.Endpoints[index]),
+		blockCache:     SharedBlockCache(api.Endpoints[index]),
+	}
+
+	// Fail fast if the endpoint is pointed at the wrong network. If the endpoint cannot be
+	// reached yet, the chain ID is verified before the first request instead.
+	if err := c.verifyChainID(ctx); errors.Is(err, ErrChainIDMismatch) {
+		return nil, fmt.Errorf("endpoint %d of %s: %w", index, api.Name, err)
+	}
+
+	return c, nil
+}
+
+// DialEndpoint dials the given endpoint, including optional authentication via a specified http
+// header key and value.
+func DialEndpoint(ctx context.Context, endpoint config.Endpoint) (*rpc.Client, error) {
+	// Propagate the trace context of each request to the endpoint.
+	opts := []rpc.ClientOption{
+		rpc.WithHTTPClient(&http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}),
+	}
 	if endpoint.Authentication.Enabled() {
+		// The key is looked up on each request so that it can be rotated.
+		apiKey, err := secrets.APIKey(endpoint.Authentication)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read api key: %w", err)
+		}
+
 		opts = append(opts, rpc.WithHTTPAuth(func(h http.Header) error {
-			h.Set(endpoint.Authentication.APIKeyHeader, endpoint.Authentication.APIKey)
+			h.Set(endpoint.Authentication.APIKeyHeader, apiKey.Value())
 			return nil
 		}))
 	}
 
-	client, err := rpc.DialOptions(ctx, endpoint.URL, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial go ethereum client: %w", err)
-	}
-
-	return &GoEthereumClientImpl{
-		apiMetrics:  apiMetrics,
-		api:         api,
-		redactedURL: metrics.RedactedEndpointURL(index),
-		client:      client,
-	}, nil
+	return rpc.DialOptions(ctx, endpoint.URL, opts...)
 }
 
 // BatchCallContext sends all given requests as a single batch and waits for the server
@@ -91,18 +161,221 @@ func NewGoEthereumClientImpl(
 // sending the request. Any error specific to a request is reported through the Error field of
 // the corresponding BatchElem.
 //
-// Note that batch calls may not be executed atomically on the server side.
-func (c *GoEthereumClientImpl) BatchCallContext(ctx context.Context, calls []rpc.BatchElem) (err error) {
+// Note that batch calls may not be executed atomically on the server side. Responses may be served
+// from the endpoint's BlockCache.
+func (c *GoEthereumClientImpl) BatchCallContext(ctx context.Context, calls []rpc.BatchElem) error {
+	if c.blockCache != nil {
+		return c.blockCache.BatchCallContext(ctx, calls, c.batchCall)
+	}
+
+	return c.batchCall(ctx, calls)
+}
+
+// batchCall sends the calls to the endpoint, or via the endpoint's Coalescer, within the configured
+// API timeout, so that an unresponsive endpoint cannot block the fetch even if the given context has
+// no deadline.
+func (c *GoEthereumClientImpl) batchCall(ctx context.Context, calls []rpc.BatchElem) (err error) {
 	start := time.Now()
 	defer func() {
 		c.apiMetrics.ObserveProviderResponseLatency(c.api.Name, c.redactedURL, time.Since(start))
 	}()
 
-	if err = c.client.BatchCallContext(ctx, calls); err != nil {
-		c.apiMetrics.AddRPCStatusCode(c.api.Name, c.redactedURL, metrics.RPCCodeError)
+	ctx, cancel := context.WithTimeout(ctx, c.api.Timeout)
+	defer cancel()
+
+	if err = c.inFlight.Acquire(ctx); err != nil {
+		err = providertypes.NewErrorWithCode(
+			fmt.Errorf("too many requests in flight: %w", err),
+			providertypes.ErrorTimeout,
+		)
+		return
+	}
+	defer c.inFlight.Release()
+
+	if err = c.verifyChainID(ctx); err != nil {
+		c.apiMetrics.AddRPCStatusCode(c.api.Name, c.redactedURL, RPCCodeFromError(err))
+		return
+	}
+
+	if c.coalescer != nil {
+		err = c.coalescer.BatchCallContext(ctx, c.client, c.api.Timeout, calls)
+	} else {
+		err = sendBatch(ctx, c.client, c.limiter, c.maxBatchLength, calls)
+	}
+	if err != nil {
+		c.apiMetrics.AddRPCStatusCode(c.api.Name, c.redactedURL, RPCCodeFromError(err))
 		return
 	}
 
 	c.apiMetrics.AddRPCStatusCode(c.api.Name, c.redactedURL, metrics.RPCCodeOK)
 	return
 }
+
+// sendBatch sends the calls to the endpoint in batch requests of at most maxLength calls, or in a
+// single batch request if maxLength is 0. The batch requests are sent concurrently, and each waits
+// for the endpoint's rate limiter, if any. If only some of the batch requests fail, their error is
+// reported through the Error field of each of their calls, so that the calls of the other batch
+// requests can still be used. An error is only returned if all of the batch requests fail.
+func sendBatch(ctx context.Context, client *rpc.Client, limiter *rate.Limiter, maxLength int, calls []rpc.BatchElem) error {
+	if maxLength <= 0 || len(calls) <= maxLength {
+		return sendLimitedBatch(ctx, client, limiter, calls)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		chunks = (len(calls) + maxLength - 1) / maxLength
+		errs   = make([]error, chunks)
+	)
+	for i := 0; i < chunks; i++ {
+		chunk := calls[i*maxLength : min((i+1)*maxLength, len(calls))]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = sendLimitedBatch(ctx, client, limiter, chunk)
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		failed++
+		for j := i * maxLength; j < min((i+1)*maxLength, len(calls)); j++ {
+			calls[j].Error = err
+		}
+	}
+
+	if failed == chunks {
+		return errs[0]
+	}
+
+	return nil
+}
+
+// sendLimitedBatch waits for the rate limiter, if any, and sends the calls as a single batch request.
+func sendLimitedBatch(ctx context.Context, client *rpc.Client, limiter *rate.Limiter, calls []rpc.BatchElem) error {
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return providertypes.NewErrorWithCode(
+				fmt.Errorf("rate limit of endpoint exceeded: %w", err),
+				providertypes.ErrorRateLimitExceeded,
+			)
+		}
+	}
+
+	return client.BatchCallContext(ctx, calls)
+}
+
+// verifyChainID verifies that the chain ID of the endpoint matches the chain ID of the API config.
+// This is a no-op if the config does not set a chain ID or the chain ID was already verified.
+func (c *GoEthereumClientImpl) verifyChainID(ctx context.Context) error {
+	if c.api.ChainID == 0 {
+		return nil
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.chainIDVerified {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.api.Timeout)
+	defer cancel()
+
+	var chainID hexutil.Uint64
+	if err := c.client.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		return fmt.Errorf("failed to query chain id: %w", err)
+	}
+
+	if uint64(chainID) != c.api.ChainID {
+		return fmt.Errorf("%w: expected %d, got %d", ErrChainIDMismatch, c.api.ChainID, uint64(chainID))
+	}
+
+	c.chainIDVerified = true
+	return nil
+}
+
+// RPCCodeFromError categorizes an error returned by the go-ethereum RPC client into an RPC status
+// code. This allows operators to distinguish between endpoints that are slow, rate limiting,
+// unreachable, or failing.
+func RPCCodeFromError(err error) metrics.RPCCode {
+	if err == nil {
+		return metrics.RPCCodeOK
+	}
+
+	var codeErr providertypes.ErrorWithCode
+	if errors.As(err, &codeErr) && codeErr.Code() == providertypes.ErrorRateLimitExceeded {
+		return metrics.RPCCodeRateLimited
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return metrics.RPCCodeTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return metrics.RPCCodeTimeout
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.StatusCode == http.StatusTooManyRequests:
+			return metrics.RPCCodeRateLimited
+		case httpErr.StatusCode >= http.StatusInternalServerError:
+			return metrics.RPCCodeServerError
+		default:
+			return metrics.RPCCodeError
+		}
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return metrics.RPCCodeConnectionError
+	}
+
+	return metrics.RPCCodeError
+}
+
+// ErrorCodeFromError categorizes an error returned by the go-ethereum RPC client, either for an
+// entire batch or for a single request in the batch, into a provider error code. Errors that cannot
+// be categorized return the fallback error code.
+func ErrorCodeFromError(err error, fallback providertypes.ErrorCode) providertypes.ErrorCode {
+	if err == nil {
+		return providertypes.OK
+	}
+
+	if errors.Is(err, ErrChainIDMismatch) {
+		return providertypes.ErrorInvalidConfig
+	}
+
+	if errors.Is(err, ErrSequencerUnavailable) {
+		return providertypes.ErrorSequencerUnavailable
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return providertypes.ErrorCode(httpErr.StatusCode)
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.ErrorCode() {
+		case rpcCodeLimitExceeded:
+			return providertypes.ErrorRateLimitExceeded
+		case rpcCodeExecutionReverted:
+			return providertypes.ErrorContractReverted
+		}
+	}
+
+	if strings.Contains(err.Error(), "execution reverted") {
+		return providertypes.ErrorContractReverted
+	}
+
+	return providertypes.ErrorCodeFromError(err, fallback)
+}
@@ -0,0 +1,377 @@
+package ethmulticlient_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+func TestGoEthereumClientImpl(t *testing.T) {
+	t.Run("request is bounded by the api timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			select {
+			case <-done:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(done)
+
+		api := config.APIConfig{
+			Enabled:          true,
+			Timeout:          50 * time.Millisecond,
+			Interval:         time.Second,
+			ReconnectTimeout: time.Second,
+			MaxQueries:       1,
+			Name:             "test",
+			Endpoints:        []config.Endpoint{{URL: server.URL}},
+		}
+
+		client, err := ethmulticlient.NewGoEthereumClientImpl(context.Background(), metrics.NewNopAPIMetrics(), api, 0)
+		require.NoError(t, err)
+
+		start := time.Now()
+		err = client.BatchCallContext(context.Background(), []rpc.BatchElem{ethmulticlient.EthBlockNumberBatchElem()})
+		require.Error(t, err)
+		require.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestGoEthereumClientImplRateLimit(t *testing.T) {
+	var available atomic.Bool
+	available.Store(true)
+	server := newChainIDServer(t, "0x1", &available)
+	defer server.Close()
+
+	api := config.APIConfig{
+		Enabled:          true,
+		Timeout:          100 * time.Millisecond,
+		Interval:         time.Second,
+		ReconnectTimeout: time.Second,
+		MaxQueries:       1,
+		Name:             "test",
+		Endpoints: []config.Endpoint{{
+			URL:       server.URL,
+			RateLimit: config.RateLimitConfig{RequestsPerSecond: 1},
+		}},
+	}
+
+	// Both clients share the rate limit of the endpoint.
+	first, err := ethmulticlient.NewGoEthereumClientImpl(context.Background(), metrics.NewNopAPIMetrics(), api, 0)
+	require.NoError(t, err)
+	second, err := ethmulticlient.NewGoEthereumClientImpl(context.Background(), metrics.NewNopAPIMetrics(), api, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, first.BatchCallContext(context.Background(), []rpc.BatchElem{ethmulticlient.EthBlockNumberBatchElem()}))
+
+	// The next token is only available after a second, which exceeds the timeout of the request.
+	err = second.BatchCallContext(context.Background(), []rpc.BatchElem{ethmulticlient.EthBlockNumberBatchElem()})
+	require.ErrorContains(t, err, "rate limit")
+}
+
+func TestGoEthereumClientImplMaxBatchLength(t *testing.T) {
+	newClient := func(t *testing.T, url string) ethmulticlient.EVMClient {
+		t.Helper()
+
+		api := config.APIConfig{
+			Enabled:          true,
+			Timeout:          time.Second,
+			Interval:         time.Second,
+			ReconnectTimeout: time.Second,
+			MaxQueries:       1,
+			Name:             "test",
+			Endpoints:        []config.Endpoint{{URL: url, MaxBatchLength: 2}},
+		}
+
+		client, err := ethmulticlient.NewGoEthereumClientImpl(context.Background(), metrics.NewNopAPIMetrics(), api, 0)
+		require.NoError(t, err)
+
+		return client
+	}
+
+	t.Run("splits batches that exceed the max batch length", func(t *testing.T) {
+		s, server := newBatchServer(t)
+		client := newClient(t, server.URL)
+
+		batch, results := newBatch("a", "b", "c", "d", "e")
+		require.NoError(t, client.BatchCallContext(context.Background(), batch))
+		require.ElementsMatch(t, []int{2, 2, 1}, s.sizes())
+
+		for i, method := range []string{"a", "b", "c", "d", "e"} {
+			require.NoError(t, batch[i].Error)
+			require.Equal(t, method, *results[i])
+		}
+	})
+
+	t.Run("reports the failed batch requests through their calls", func(t *testing.T) {
+		_, server := newBatchServer(t)
+		client := newClient(t, server.URL)
+
+		batch, results := newBatch("a", "b", "unavailable", "c")
+		require.NoError(t, client.BatchCallContext(context.Background(), batch))
+
+		require.NoError(t, batch[0].Error)
+		require.Equal(t, "a", *results[0])
+		require.NoError(t, batch[1].Error)
+		require.Equal(t, "b", *results[1])
+		require.Error(t, batch[2].Error)
+		require.Error(t, batch[3].Error)
+	})
+
+	t.Run("fails if all batch requests fail", func(t *testing.T) {
+		_, server := newBatchServer(t)
+		client := newClient(t, server.URL)
+
+		batch, _ := newBatch("unavailable", "a", "unavailable")
+		require.Error(t, client.BatchCallContext(context.Background(), batch))
+	})
+}
+
+func TestGoEthereumClientImplChainID(t *testing.T) {
+	newAPI := func(url string, chainID uint64) config.APIConfig {
+		return config.APIConfig{
+			Enabled:          true,
+			Timeout:          time.Second,
+			Interval:         time.Second,
+			ReconnectTimeout: time.Second,
+			MaxQueries:       1,
+			Name:             "test",
+			Endpoints:        []config.Endpoint{{URL: url}},
+			ChainID:          chainID,
+		}
+	}
+
+	t.Run("fails fast if the endpoint is on a different chain", func(t *testing.T) {
+		var available atomic.Bool
+		available.Store(true)
+		server := newChainIDServer(t, "0x5", &available)
+		defer server.Close()
+
+		_, err := ethmulticlient.NewGoEthereumClientImpl(context.Background(), metrics.NewNopAPIMetrics(), newAPI(server.URL, 1), 0)
+		require.ErrorIs(t, err, ethmulticlient.ErrChainIDMismatch)
+	})
+
+	t.Run("succeeds if the endpoint is on the expected chain", func(t *testing.T) {
+		var available atomic.Bool
+		available.Store(true)
+		server := newChainIDServer(t, "0x1", &available)
+		defer server.Close()
+
+		client, err := ethmulticlient.NewGoEthereumClientImpl(context.Background(), metrics.NewNopAPIMetrics(), newAPI(server.URL, 1), 0)
+		require.NoError(t, err)
+
+		elem := ethmulticlient.EthBlockNumberBatchElem()
+		require.NoError(t, client.BatchCallContext(context.Background(), []rpc.BatchElem{elem}))
+		require.NoError(t, elem.Error)
+	})
+
+	t.Run("verifies the chain id before the first request if the endpoint was unreachable", func(t *testing.T) {
+		var available atomic.Bool
+		server := newChainIDServer(t, "0x5", &available)
+		defer server.Close()
+
+		client, err := ethmulticlient.NewGoEthereumClientImpl(context.Background(), metrics.NewNopAPIMetrics(), newAPI(server.URL, 1), 0)
+		require.NoError(t, err)
+
+		available.Store(true)
+		err = client.BatchCallContext(context.Background(), []rpc.BatchElem{ethmulticlient.EthBlockNumberBatchElem()})
+		require.ErrorIs(t, err, ethmulticlient.ErrChainIDMismatch)
+	})
+}
+
+// newChainIDServer returns a JSON-RPC server that reports the given chain id and a block number of
+// 1. The server responds with a 503 while available is false.
+func newChainIDServer(t *testing.T, chainID string, available *atomic.Bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		type request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		respond := func(req request) map[string]interface{} {
+			result := "0x1"
+			if req.Method == "eth_chainId" {
+				result = chainID
+			}
+			return map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		var batch []request
+		if err := json.Unmarshal(body, &batch); err == nil {
+			resps := make([]map[string]interface{}, len(batch))
+			for i, req := range batch {
+				resps[i] = respond(req)
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(resps))
+			return
+		}
+
+		var req request
+		require.NoError(t, json.Unmarshal(body, &req))
+		require.NoError(t, json.NewEncoder(w).Encode(respond(req)))
+	}))
+}
+
+func TestRPCCodeFromError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected metrics.RPCCode
+	}{
+		{
+			name:     "no error",
+			err:      nil,
+			expected: metrics.RPCCodeOK,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("post failed: %w", context.DeadlineExceeded),
+			expected: metrics.RPCCodeTimeout,
+		},
+		{
+			name:     "rate limited",
+			err:      rpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"},
+			expected: metrics.RPCCodeRateLimited,
+		},
+		{
+			name: "endpoint rate limit exceeded",
+			err: providertypes.NewErrorWithCode(
+				fmt.Errorf("rate limit of endpoint exceeded: %w", context.DeadlineExceeded),
+				providertypes.ErrorRateLimitExceeded,
+			),
+			expected: metrics.RPCCodeRateLimited,
+		},
+		{
+			name:     "server error",
+			err:      rpc.HTTPError{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"},
+			expected: metrics.RPCCodeServerError,
+		},
+		{
+			name:     "client error",
+			err:      rpc.HTTPError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"},
+			expected: metrics.RPCCodeError,
+		},
+		{
+			name:     "connection refused",
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")},
+			expected: metrics.RPCCodeConnectionError,
+		},
+		{
+			name:     "unknown error",
+			err:      fmt.Errorf("unknown"),
+			expected: metrics.RPCCodeError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ethmulticlient.RPCCodeFromError(tc.err))
+		})
+	}
+}
+
+func TestErrorCodeFromError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected providertypes.ErrorCode
+	}{
+		{
+			name:     "no error",
+			err:      nil,
+			expected: providertypes.OK,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("post failed: %w", context.DeadlineExceeded),
+			expected: providertypes.ErrorTimeout,
+		},
+		{
+			name:     "rate limited",
+			err:      rpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"},
+			expected: http.StatusTooManyRequests,
+		},
+		{
+			name:     "rate limited json-rpc error",
+			err:      jsonRPCError{code: -32005, message: "limit exceeded"},
+			expected: providertypes.ErrorRateLimitExceeded,
+		},
+		{
+			name:     "reverted call",
+			err:      jsonRPCError{code: 3, message: "execution reverted"},
+			expected: providertypes.ErrorContractReverted,
+		},
+		{
+			name:     "reverted call without error code",
+			err:      fmt.Errorf("execution reverted: Pausable: paused"),
+			expected: providertypes.ErrorContractReverted,
+		},
+		{
+			name:     "chain id mismatch",
+			err:      fmt.Errorf("%w: expected 1, got 5", ethmulticlient.ErrChainIDMismatch),
+			expected: providertypes.ErrorInvalidConfig,
+		},
+		{
+			name:     "sequencer unavailable",
+			err:      fmt.Errorf("%w: sequencer is down", ethmulticlient.ErrSequencerUnavailable),
+			expected: providertypes.ErrorSequencerUnavailable,
+		},
+		{
+			name:     "error with code",
+			err:      providertypes.NewErrorWithCode(fmt.Errorf("height is stale"), providertypes.ErrorStalePrice),
+			expected: providertypes.ErrorStalePrice,
+		},
+		{
+			name:     "unknown error",
+			err:      fmt.Errorf("unknown"),
+			expected: providertypes.ErrorAPIGeneral,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ethmulticlient.ErrorCodeFromError(tc.err, providertypes.ErrorAPIGeneral))
+		})
+	}
+}
+
+// jsonRPCError is a JSON-RPC error returned by an endpoint.
+type jsonRPCError struct {
+	code    int
+	message string
+}
+
+func (e jsonRPCError) Error() string {
+	return e.message
+}
+
+func (e jsonRPCError) ErrorCode() int {
+	return e.code
+}
@@ -0,0 +1,203 @@
+package ethmulticlient
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/time/rate"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+var (
+	coalescersMtx sync.Mutex
+	// coalescers are the coalescers of all endpoints that coalesce requests, indexed by endpoint URL
+	// and authentication. These are shared by all clients so that providers using the same endpoint
+	// share batch requests.
+	coalescers = make(map[coalescerKey]*Coalescer)
+)
+
+// coalescerKey identifies the endpoint of a coalescer. Requests are only coalesced across providers
+// that authenticate with the endpoint in the same way, since a coalesced batch is sent with the
+// credentials of a single provider.
+type coalescerKey struct {
+	url  string
+	auth config.Authentication
+}
+
+// Coalescer coalesces the batch requests of several clients of the same endpoint into shared batch
+// requests. The first request submitted to the coalescer opens a batch, and the calls of all
+// requests submitted before the batch's window elapses are sent to the endpoint as a single JSON-RPC
+// batch request. A coalesced batch counts as a single request against the endpoint's rate limit,
+// unless it is split into several batch requests to respect the endpoint's max batch length.
+type Coalescer struct {
+	mtx sync.Mutex
+
+	cfg config.CoalesceConfig
+	// limiter is the rate limiter of the endpoint, or nil if the endpoint is not rate limited.
+	limiter *rate.Limiter
+	// maxBatchLength is the maximum number of calls sent in a single batch request, or 0 if the
+	// length of batch requests is not limited.
+	maxBatchLength int
+	// pending is the batch that is currently open, if any.
+	pending *coalescedBatch
+}
+
+// coalescedBatch is a batch of calls submitted by one or more requests.
+type coalescedBatch struct {
+	// client is the client the batch is sent with, i.e. the client of the request that opened it.
+	client *rpc.Client
+	// timeout bounds the batch request, i.e. the timeout of the request that opened it.
+	timeout time.Duration
+	// calls are the calls of all requests in the batch. The results are decoded into raw messages,
+	// and copied into the results of each request once the batch completes.
+	calls []rpc.BatchElem
+	// timer sends the batch once its window elapses.
+	timer *time.Timer
+
+	// done is closed once the batch completes, after which err is the error of the batch request.
+	done chan struct{}
+	err  error
+}
+
+// SharedCoalescer returns the coalescer of the given endpoint, or nil if the endpoint does not
+// coalesce requests. All clients of the same endpoint URL and authentication share a single
+// coalescer. If providers configure different policies for the same endpoint, the shortest window
+// and the smallest max batch size and max batch length are used.
+func SharedCoalescer(endpoint config.Endpoint) *Coalescer {
+	if !endpoint.Coalesce.Enabled() {
+		return nil
+	}
+
+	coalescersMtx.Lock()
+	defer coalescersMtx.Unlock()
+
+	key := coalescerKey{url: endpoint.URL, auth: endpoint.Authentication}
+	c, ok := coalescers[key]
+	if !ok {
+		c = &Coalescer{
+			cfg:            endpoint.Coalesce,
+			limiter:        SharedRateLimiter(endpoint),
+			maxBatchLength: endpoint.MaxBatchLength,
+		}
+		coalescers[key] = c
+		return c
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if endpoint.Coalesce.Window < c.cfg.Window {
+		c.cfg.Window = endpoint.Coalesce.Window
+	}
+	if size := endpoint.Coalesce.MaxBatchSize; size > 0 && (c.cfg.MaxBatchSize == 0 || size < c.cfg.MaxBatchSize) {
+		c.cfg.MaxBatchSize = size
+	}
+	if length := endpoint.MaxBatchLength; length > 0 && (c.maxBatchLength == 0 || length < c.maxBatchLength) {
+		c.maxBatchLength = length
+	}
+
+	return c
+}
+
+// BatchCallContext adds the calls to the open batch, or opens a new batch with the given client and
+// timeout, and waits for the batch to complete. As with rpc.Client.BatchCallContext, errors specific
+// to a call are reported through the Error field of the corresponding BatchElem. If the context is
+// cancelled first, the calls are abandoned and the batch is still sent for the other requests.
+func (c *Coalescer) BatchCallContext(
+	ctx context.Context,
+	client *rpc.Client,
+	timeout time.Duration,
+	calls []rpc.BatchElem,
+) error {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	batch, offset := c.submit(client, timeout, calls)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-batch.done:
+	}
+
+	if batch.err != nil {
+		return batch.err
+	}
+
+	for i := range calls {
+		elem := batch.calls[offset+i]
+		if elem.Error != nil {
+			calls[i].Error = elem.Error
+			continue
+		}
+
+		calls[i].Error = json.Unmarshal(*elem.Result.(*json.RawMessage), calls[i].Result)
+	}
+
+	return nil
+}
+
+// submit adds the calls to the open batch and returns the batch along with the offset of the calls
+// within it. A new batch is opened if there is none, or if the calls would exceed the max batch size
+// of the open batch, in which case the open batch is sent right away.
+func (c *Coalescer) submit(client *rpc.Client, timeout time.Duration, calls []rpc.BatchElem) (*coalescedBatch, int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.pending != nil && c.cfg.MaxBatchSize > 0 && len(c.pending.calls)+len(calls) > c.cfg.MaxBatchSize {
+		if c.pending.timer.Stop() {
+			go c.send(c.pending)
+		}
+		c.pending = nil
+	}
+
+	if c.pending == nil {
+		batch := &coalescedBatch{
+			client:  client,
+			timeout: timeout,
+			done:    make(chan struct{}),
+		}
+		batch.timer = time.AfterFunc(c.cfg.Window, func() {
+			c.mtx.Lock()
+			if c.pending == batch {
+				c.pending = nil
+			}
+			c.mtx.Unlock()
+
+			c.send(batch)
+		})
+		c.pending = batch
+	}
+
+	batch := c.pending
+	offset := len(batch.calls)
+	for _, call := range calls {
+		batch.calls = append(batch.calls, rpc.BatchElem{
+			Method: call.Method,
+			Args:   call.Args,
+			Result: new(json.RawMessage),
+		})
+	}
+
+	return batch, offset
+}
+
+// send sends the batch to the endpoint and completes it. The batch is not bound to the context of
+// any of its requests, since each of them may be cancelled independently.
+func (c *Coalescer) send(batch *coalescedBatch) {
+	defer close(batch.done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), batch.timeout)
+	defer cancel()
+
+	c.mtx.Lock()
+	maxBatchLength := c.maxBatchLength
+	c.mtx.Unlock()
+
+	batch.err = sendBatch(ctx, batch.client, c.limiter, maxBatchLength, batch.calls)
+}
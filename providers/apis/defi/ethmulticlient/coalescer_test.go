@@ -0,0 +1,218 @@
+package ethmulticlient_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+)
+
+// batchServer responds to each call with its method, or with an error for the fail method, and
+// records the size of each batch request it receives. Batch requests that include the unavailable
+// method fail.
+type batchServer struct {
+	mtx     sync.Mutex
+	batches []int
+}
+
+func (s *batchServer) sizes() []int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return append([]int(nil), s.batches...)
+}
+
+func newBatchServer(t *testing.T) (*batchServer, *httptest.Server) {
+	t.Helper()
+
+	s := &batchServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var batch []struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.Unmarshal(body, &batch))
+
+		s.mtx.Lock()
+		s.batches = append(s.batches, len(batch))
+		s.mtx.Unlock()
+
+		for _, req := range batch {
+			if req.Method == "unavailable" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+
+		resps := make([]map[string]interface{}, len(batch))
+		for i, req := range batch {
+			resps[i] = map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": req.Method}
+			if req.Method == "fail" {
+				resps[i] = map[string]interface{}{
+					"jsonrpc": "2.0",
+					"id":      req.ID,
+					"error":   map[string]interface{}{"code": 3, "message": "execution reverted"},
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resps))
+	}))
+	t.Cleanup(server.Close)
+
+	return s, server
+}
+
+func newCoalescingClient(t *testing.T, name string, endpoint config.Endpoint) ethmulticlient.EVMClient {
+	t.Helper()
+
+	api := config.APIConfig{
+		Enabled:          true,
+		Timeout:          time.Second,
+		Interval:         time.Second,
+		ReconnectTimeout: time.Second,
+		MaxQueries:       1,
+		Name:             name,
+		Endpoints:        []config.Endpoint{endpoint},
+	}
+
+	client, err := ethmulticlient.NewGoEthereumClientImpl(context.Background(), metrics.NewNopAPIMetrics(), api, 0)
+	require.NoError(t, err)
+
+	return client
+}
+
+func newBatch(methods ...string) ([]rpc.BatchElem, []*string) {
+	results := make([]*string, len(methods))
+	batch := make([]rpc.BatchElem, len(methods))
+	for i, method := range methods {
+		results[i] = new(string)
+		batch[i] = rpc.BatchElem{Method: method, Result: results[i]}
+	}
+
+	return batch, results
+}
+
+func TestCoalescer(t *testing.T) {
+	t.Run("coalesces the requests of providers sharing an endpoint", func(t *testing.T) {
+		s, server := newBatchServer(t)
+		endpoint := config.Endpoint{
+			URL:      server.URL,
+			Coalesce: config.CoalesceConfig{Window: 100 * time.Millisecond},
+		}
+
+		chainlink := newCoalescingClient(t, "chainlink", endpoint)
+		erc4626 := newCoalescingClient(t, "erc4626", endpoint)
+
+		first, firstResults := newBatch("a", "fail")
+		second, secondResults := newBatch("b", "c")
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, chainlink.BatchCallContext(context.Background(), first))
+		}()
+		go func() {
+			defer wg.Done()
+			require.NoError(t, erc4626.BatchCallContext(context.Background(), second))
+		}()
+		wg.Wait()
+
+		require.Equal(t, []int{4}, s.sizes())
+
+		require.NoError(t, first[0].Error)
+		require.Equal(t, "a", *firstResults[0])
+		require.ErrorContains(t, first[1].Error, "execution reverted")
+		require.Empty(t, *firstResults[1])
+
+		require.NoError(t, second[0].Error)
+		require.Equal(t, "b", *secondResults[0])
+		require.NoError(t, second[1].Error)
+		require.Equal(t, "c", *secondResults[1])
+	})
+
+	t.Run("sends a batch once it reaches the max batch size", func(t *testing.T) {
+		s, server := newBatchServer(t)
+		endpoint := config.Endpoint{
+			URL:      server.URL,
+			Coalesce: config.CoalesceConfig{Window: time.Minute, MaxBatchSize: 2},
+		}
+
+		chainlink := newCoalescingClient(t, "chainlink", endpoint)
+		erc4626 := newCoalescingClient(t, "erc4626", endpoint)
+
+		first, firstResults := newBatch("a", "b")
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			require.NoError(t, chainlink.BatchCallContext(context.Background(), first))
+		}()
+
+		// Wait for the first request to open a batch.
+		time.Sleep(50 * time.Millisecond)
+
+		// The second request does not fit in the open batch, so the open batch is sent without
+		// waiting for its window to elapse.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			second, _ := newBatch("c")
+			erc4626.BatchCallContext(ctx, second)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the full batch was not sent")
+		}
+		require.Equal(t, []int{2}, s.sizes())
+		require.Equal(t, "a", *firstResults[0])
+		require.Equal(t, "b", *firstResults[1])
+	})
+
+	t.Run("abandons the calls of a cancelled request", func(t *testing.T) {
+		_, server := newBatchServer(t)
+		client := newCoalescingClient(t, "chainlink", config.Endpoint{
+			URL:      server.URL,
+			Coalesce: config.CoalesceConfig{Window: time.Minute},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		batch, _ := newBatch("a")
+		require.ErrorIs(t, client.BatchCallContext(ctx, batch), context.DeadlineExceeded)
+	})
+}
+
+func TestSharedCoalescer(t *testing.T) {
+	require.Nil(t, ethmulticlient.SharedCoalescer(config.Endpoint{URL: "http://uncoalesced.com"}))
+
+	endpoint := config.Endpoint{
+		URL:      "http://coalesced.com",
+		Coalesce: config.CoalesceConfig{Window: time.Second},
+	}
+	first := ethmulticlient.SharedCoalescer(endpoint)
+	require.NotNil(t, first)
+	require.Same(t, first, ethmulticlient.SharedCoalescer(endpoint))
+
+	// Requests are not coalesced across providers that authenticate differently.
+	endpoint.Authentication = config.Authentication{APIKey: "key", APIKeyHeader: "X-Api-Key"}
+	require.NotSame(t, first, ethmulticlient.SharedCoalescer(endpoint))
+}
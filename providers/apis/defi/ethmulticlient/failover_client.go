@@ -0,0 +1,206 @@
+package ethmulticlient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+)
+
+var _ EVMClient = (*FailoverRPCClient)(nil)
+
+// FailoverRPCClient implements the EVMClient interface by calling a single underlying EVMClient
+// at a time. The clients are ordered by priority, i.e. the order of the endpoints in the API config.
+// Requests are sent to the highest priority client that is considered healthy. If a request fails or
+// times out, the client is marked as unhealthy for the configured ReconnectTimeout and the request
+// is retried against the next client. Once the ReconnectTimeout has elapsed, the client is eligible
+// to serve requests again, which allows the provider to return to the primary endpoint once it has
+// recovered.
+type FailoverRPCClient struct {
+	mtx    sync.Mutex
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// underlying clients in priority order.
+	clients []EVMClient
+	// unhealthyUntil is the time until which each client is considered unhealthy.
+	unhealthyUntil []time.Time
+}
+
+// NewFailoverRPCClient returns a new FailoverRPCClient.
+func NewFailoverRPCClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	clients []EVMClient,
+) EVMClient {
+	return &FailoverRPCClient{
+		logger:         logger,
+		api:            api,
+		clients:        clients,
+		unhealthyUntil: make([]time.Time, len(clients)),
+	}
+}
+
+// NewFailoverRPCClientFromEndpoints creates a FailoverRPCClient from config endpoints.
+func NewFailoverRPCClientFromEndpoints(
+	ctx context.Context,
+	logger *zap.Logger,
+	api config.APIConfig,
+	apiMetrics metrics.APIMetrics,
+) (EVMClient, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context is nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	if len(api.Endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints provided")
+	}
+
+	clients := make([]EVMClient, len(api.Endpoints))
+	for i, endpoint := range api.Endpoints {
+		var err error
+		clients[i], err = NewGoEthereumClientImpl(ctx, apiMetrics, api, i)
+		if err != nil {
+			logger.Error(
+				"endpoint failed to construct client",
+				zap.String("endpoint.URL", endpoint.URL),
+				zap.Error(err),
+			)
+			return nil, fmt.Errorf("failed to create eth client from endpoint: %w", err)
+		}
+	}
+
+	return NewFailoverRPCClient(
+		logger.With(zap.String("failover_client", api.Name)),
+		api,
+		clients,
+	), nil
+}
+
+// BatchCallContext sends the batch call to the highest priority healthy client. If the call fails,
+// the client is marked as unhealthy and the call is retried against the next client in priority
+// order. Unhealthy clients are only tried once every healthy client has failed. An error is returned
+// only when every client failed to serve the request.
+func (f *FailoverRPCClient) BatchCallContext(ctx context.Context, batchElems []rpc.BatchElem) error {
+	if len(batchElems) == 0 {
+		f.logger.Debug("BatchCallContext called with 0 elems")
+		return nil
+	}
+
+	errs := make([]error, 0, len(f.clients))
+	for _, i := range f.order() {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+
+		// each attempt decodes into fresh results so that a partially populated response from a
+		// failed client does not leak into the results.
+		req := make([]rpc.BatchElem, len(batchElems))
+		for j, elem := range batchElems {
+			req[j] = rpc.BatchElem{Method: elem.Method, Args: elem.Args, Result: new(json.RawMessage)}
+		}
+
+		if err := f.clients[i].BatchCallContext(ctx, req); err != nil {
+			f.markUnhealthy(i)
+			f.logger.Debug(
+				"endpoint request failed; failing over to next endpoint",
+				zap.String("url", f.url(i)),
+				zap.Error(err),
+			)
+			errs = append(errs, fmt.Errorf("endpoint %d request failed: %w", i, err))
+			continue
+		}
+
+		f.markHealthy(i)
+		copyResults(batchElems, req)
+		return nil
+	}
+
+	return fmt.Errorf("all endpoints failed: %w", errors.Join(errs...))
+}
+
+// order returns the indices of the clients in the order in which they should be queried. Healthy
+// clients are returned first in priority order, followed by unhealthy clients in priority order.
+func (f *FailoverRPCClient) order() []int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	var (
+		now       = time.Now()
+		healthy   = make([]int, 0, len(f.clients))
+		unhealthy = make([]int, 0, len(f.clients))
+	)
+	for i := range f.clients {
+		if now.Before(f.unhealthyUntil[i]) {
+			unhealthy = append(unhealthy, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// markUnhealthy marks the client at the given index as unhealthy for the reconnect timeout.
+func (f *FailoverRPCClient) markUnhealthy(i int) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.unhealthyUntil[i] = time.Now().Add(f.api.ReconnectTimeout)
+}
+
+// markHealthy marks the client at the given index as healthy.
+func (f *FailoverRPCClient) markHealthy(i int) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.unhealthyUntil[i] = time.Time{}
+}
+
+// url returns the url of the endpoint at the given index.
+func (f *FailoverRPCClient) url(i int) string {
+	if i < len(f.api.Endpoints) {
+		return f.api.Endpoints[i].URL
+	}
+
+	return ""
+}
+
+// copyResults decodes the raw results of a successful attempt into the results of the request. The
+// error of an element is set if its result cannot be decoded.
+func copyResults(dst, src []rpc.BatchElem) {
+	for i := range dst {
+		dst[i].Error = src[i].Error
+		if dst[i].Error != nil || dst[i].Result == nil {
+			continue
+		}
+
+		raw, ok := src[i].Result.(*json.RawMessage)
+		if !ok || len(*raw) == 0 {
+			continue
+		}
+
+		dst[i].Error = json.Unmarshal(*raw, dst[i].Result)
+	}
+}
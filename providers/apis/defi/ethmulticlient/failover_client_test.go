@@ -0,0 +1,168 @@
+package ethmulticlient_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+)
+
+func TestFailoverClient(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+
+	api := config.APIConfig{
+		Timeout:          time.Second,
+		ReconnectTimeout: time.Hour,
+		Endpoints:        []config.Endpoint{{URL: "http://localhost:8545"}, {URL: "http://localhost:8546"}},
+	}
+
+	testcases := []struct {
+		name            string
+		client          ethmulticlient.EVMClient
+		args            []rpc.BatchElem
+		expectedResults []interface{}
+		err             error
+	}{
+		{
+			name:   "no elems, no-ops",
+			client: ethmulticlient.NewFailoverRPCClient(logger, api, []ethmulticlient.EVMClient{}),
+			args:   []rpc.BatchElem{},
+			err:    nil,
+		},
+		{
+			name: "primary success",
+			client: ethmulticlient.NewFailoverRPCClient(
+				logger,
+				api,
+				[]ethmulticlient.EVMClient{
+					createEVMClientWithResponse(t, nil, []string{"value1"}, []error{nil}),
+					mocks.NewEVMClient(t),
+				},
+			),
+			args:            []rpc.BatchElem{{Result: new(string)}},
+			expectedResults: []interface{}{"value1"},
+			err:             nil,
+		},
+		{
+			name: "primary failure fails over to secondary",
+			client: ethmulticlient.NewFailoverRPCClient(
+				logger,
+				api,
+				[]ethmulticlient.EVMClient{
+					createEVMClientWithResponse(t, fmt.Errorf("primary down"), nil, nil),
+					createEVMClientWithResponse(t, nil, []string{"value2"}, []error{nil}),
+				},
+			),
+			args:            []rpc.BatchElem{{Result: new(string)}},
+			expectedResults: []interface{}{"value2"},
+			err:             nil,
+		},
+		{
+			name: "all endpoints fail",
+			client: ethmulticlient.NewFailoverRPCClient(
+				logger,
+				api,
+				[]ethmulticlient.EVMClient{
+					createEVMClientWithResponse(t, fmt.Errorf("primary down"), nil, nil),
+					createEVMClientWithResponse(t, fmt.Errorf("secondary down"), nil, nil),
+				},
+			),
+			args: []rpc.BatchElem{{Result: new(string)}},
+			err:  fmt.Errorf("all endpoints failed"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.client.BatchCallContext(context.TODO(), tc.args)
+			if tc.err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.NoError(t, err)
+				for i, result := range tc.expectedResults {
+					require.Equal(t, result, *tc.args[i].Result.(*string))
+				}
+			}
+		})
+	}
+
+	t.Run("results of a failed endpoint are discarded", func(t *testing.T) {
+		// the primary populates the result before failing.
+		primary := mocks.NewEVMClient(t)
+		primary.On("BatchCallContext", mock.Anything, mock.Anything).Return(fmt.Errorf("primary down")).Run(func(args mock.Arguments) {
+			elems, ok := args.Get(1).([]rpc.BatchElem)
+			require.True(t, ok)
+			*elems[0].Result.(*json.RawMessage) = json.RawMessage(`"partial"`)
+		})
+		secondary := createEVMClientWithResponse(t, fmt.Errorf("secondary down"), nil, nil)
+
+		client := ethmulticlient.NewFailoverRPCClient(logger, api, []ethmulticlient.EVMClient{primary, secondary})
+
+		result := ""
+		require.Error(t, client.BatchCallContext(context.TODO(), []rpc.BatchElem{{Result: &result}}))
+		require.Empty(t, result)
+	})
+
+	t.Run("skips unhealthy primary until it recovers", func(t *testing.T) {
+		reconnect := 50 * time.Millisecond
+		cfg := api
+		cfg.ReconnectTimeout = reconnect
+
+		primary := mocks.NewEVMClient(t)
+		primary.On("BatchCallContext", mock.Anything, mock.Anything).Return(fmt.Errorf("primary down")).Once()
+		setBatchResponse(t, primary, "primary")
+		secondary := mocks.NewEVMClient(t)
+		setBatchResponse(t, secondary, "secondary")
+
+		client := ethmulticlient.NewFailoverRPCClient(logger, cfg, []ethmulticlient.EVMClient{primary, secondary})
+
+		// the primary fails and the secondary serves the request.
+		elems := []rpc.BatchElem{{Result: new(string)}}
+		require.NoError(t, client.BatchCallContext(context.TODO(), elems))
+		require.Equal(t, "secondary", *elems[0].Result.(*string))
+
+		// the primary is unhealthy so it is skipped entirely.
+		elems = []rpc.BatchElem{{Result: new(string)}}
+		require.NoError(t, client.BatchCallContext(context.TODO(), elems))
+		require.Equal(t, "secondary", *elems[0].Result.(*string))
+		primary.AssertNumberOfCalls(t, "BatchCallContext", 1)
+
+		// once the reconnect timeout has elapsed the primary is used again.
+		time.Sleep(2 * reconnect)
+		elems = []rpc.BatchElem{{Result: new(string)}}
+		require.NoError(t, client.BatchCallContext(context.TODO(), elems))
+		require.Equal(t, "primary", *elems[0].Result.(*string))
+	})
+}
+
+func setBatchResponse(t *testing.T, c *mocks.EVMClient, response string) {
+	t.Helper()
+
+	c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+
+		for i := range elems {
+			// decode into the result like an rpc client if it is a raw message.
+			if raw, ok := elems[i].Result.(*json.RawMessage); ok {
+				*raw = json.RawMessage(strconv.Quote(response))
+				continue
+			}
+
+			value := response
+			elems[i].Result = &value
+		}
+	})
+}
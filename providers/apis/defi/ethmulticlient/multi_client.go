@@ -14,6 +14,7 @@ import (
 
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
 
 // MultiRPCClient implements the EVMClient interface by calling multiple underlying EVMClients and choosing
@@ -216,7 +217,10 @@ func (m *MultiRPCClient) filterResponses(responses []result) ([]rpc.BatchElem, e
 
 	// check the block height
 	if valid := m.blockAgeChecker.IsHeightValid(maxHeight); !valid {
-		return nil, fmt.Errorf("height %d is stale and older than %d", maxHeight, m.api.MaxBlockHeightAge)
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("height %d is stale and older than %d", maxHeight, m.api.MaxBlockHeightAge),
+			providertypes.ErrorStalePrice,
+		)
 	}
 
 	return responses[maxHeightIndex].results, nil
@@ -2,7 +2,9 @@ package ethmulticlient_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/rpc"
@@ -209,7 +211,12 @@ func createEVMClientWithResponse(
 			require.Equal(t, len(elems), len(errs))
 
 			for i, elem := range elems {
-				elem.Result = &responses[i]
+				// decode into the result like an rpc client if it is a raw message.
+				if raw, ok := elem.Result.(*json.RawMessage); ok {
+					*raw = json.RawMessage(strconv.Quote(responses[i]))
+				} else {
+					elem.Result = &responses[i]
+				}
 				elem.Error = errs[i]
 				elems[i] = elem
 			}
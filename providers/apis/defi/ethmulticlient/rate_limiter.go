@@ -0,0 +1,48 @@
+package ethmulticlient
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+var (
+	limitersMtx sync.Mutex
+	// limiters are the rate limiters of all rate limited endpoints, indexed by endpoint URL. These
+	// are shared by all clients so that providers using the same endpoint share its rate limit.
+	limiters = make(map[string]*rate.Limiter)
+)
+
+// SharedRateLimiter returns the rate limiter of the given endpoint, or nil if the endpoint is not
+// rate limited. A batch request counts as a single request against the limit. All clients of the
+// same endpoint URL share a single limiter. If providers configure
+// different rate limits for the same endpoint, the most restrictive rate and burst are used.
+func SharedRateLimiter(endpoint config.Endpoint) *rate.Limiter {
+	if !endpoint.RateLimit.Enabled() {
+		return nil
+	}
+
+	limitersMtx.Lock()
+	defer limitersMtx.Unlock()
+
+	limit := rate.Limit(endpoint.RateLimit.RequestsPerSecond)
+	burst := endpoint.RateLimit.GetBurst()
+
+	limiter, ok := limiters[endpoint.URL]
+	if !ok {
+		limiter = rate.NewLimiter(limit, burst)
+		limiters[endpoint.URL] = limiter
+		return limiter
+	}
+
+	if limit < limiter.Limit() {
+		limiter.SetLimit(limit)
+	}
+	if burst < limiter.Burst() {
+		limiter.SetBurst(burst)
+	}
+
+	return limiter
+}
@@ -0,0 +1,53 @@
+package ethmulticlient_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+)
+
+func TestSharedRateLimiter(t *testing.T) {
+	t.Run("endpoint without a rate limit", func(t *testing.T) {
+		require.Nil(t, ethmulticlient.SharedRateLimiter(config.Endpoint{URL: "http://unlimited.com"}))
+	})
+
+	t.Run("endpoints with the same url share a limiter", func(t *testing.T) {
+		first := ethmulticlient.SharedRateLimiter(config.Endpoint{
+			URL:       "http://shared.com",
+			RateLimit: config.RateLimitConfig{RequestsPerSecond: 10},
+		})
+		require.NotNil(t, first)
+		require.Equal(t, rate.Limit(10), first.Limit())
+		require.Equal(t, 1, first.Burst())
+
+		second := ethmulticlient.SharedRateLimiter(config.Endpoint{
+			URL:       "http://shared.com",
+			RateLimit: config.RateLimitConfig{RequestsPerSecond: 10},
+		})
+		require.Same(t, first, second)
+
+		other := ethmulticlient.SharedRateLimiter(config.Endpoint{
+			URL:       "http://other.com",
+			RateLimit: config.RateLimitConfig{RequestsPerSecond: 10},
+		})
+		require.NotSame(t, first, other)
+	})
+
+	t.Run("the most restrictive limit is used", func(t *testing.T) {
+		limiter := ethmulticlient.SharedRateLimiter(config.Endpoint{
+			URL:       "http://restrictive.com",
+			RateLimit: config.RateLimitConfig{RequestsPerSecond: 10, Burst: 2},
+		})
+
+		ethmulticlient.SharedRateLimiter(config.Endpoint{
+			URL:       "http://restrictive.com",
+			RateLimit: config.RateLimitConfig{RequestsPerSecond: 5, Burst: 4},
+		})
+		require.Equal(t, rate.Limit(5), limiter.Limit())
+		require.Equal(t, 2, limiter.Burst())
+	})
+}
@@ -0,0 +1,85 @@
+package ethmulticlient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ EVMClient = (*RetryRPCClient)(nil)
+
+// RetryRPCClient implements the EVMClient interface by wrapping an underlying EVMClient and
+// retrying batch calls that fail to be sent. Retries are separated by an exponential backoff with
+// optional jitter, as defined by the retry policy in the API config. Rate limited calls back off
+// from the rate limit backoff instead of the initial backoff, and calls that failed for reasons
+// that are not resolved by retrying (i.e. a chain ID mismatch) are not retried. Note that errors
+// specific to a single request in the batch (i.e. a reverted call) are not retried.
+type RetryRPCClient struct {
+	logger *zap.Logger
+	retry  config.RetryConfig
+
+	// client is the underlying client.
+	client EVMClient
+}
+
+// NewRetryRPCClient returns a new RetryRPCClient.
+func NewRetryRPCClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client EVMClient,
+) EVMClient {
+	return &RetryRPCClient{
+		logger: logger.With(zap.String("retry_client", api.Name)),
+		retry:  api.Retry,
+		client: client,
+	}
+}
+
+// BatchCallContext sends the batch call to the underlying client, retrying up to the configured
+// maximum number of attempts if the call fails. An error is returned if every attempt failed or
+// the context was cancelled while waiting to retry.
+func (r *RetryRPCClient) BatchCallContext(ctx context.Context, batchElems []rpc.BatchElem) error {
+	var (
+		err     error
+		attempt int
+	)
+	for attempt = 1; ; attempt++ {
+		// each attempt operates on a copy of the request so that a partially populated response
+		// from a failed attempt does not leak into the results.
+		req := make([]rpc.BatchElem, len(batchElems))
+		copy(req, batchElems)
+
+		if err = r.client.BatchCallContext(ctx, req); err == nil {
+			copy(batchElems, req)
+			return nil
+		}
+
+		kind := ErrorCodeFromError(err, providertypes.ErrorAPIGeneral).Kind()
+		if attempt >= r.retry.MaxAttempts || !kind.Retryable() {
+			break
+		}
+
+		backoff := r.retry.Backoff(attempt, kind == providertypes.ErrorKindRateLimited)
+		r.logger.Debug(
+			"batch call failed; retrying",
+			zap.Int("attempt", attempt),
+			zap.String("kind", string(kind)),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context done while retrying batch call: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+	}
+
+	return fmt.Errorf("batch call failed after %d attempts: %w", attempt, err)
+}
@@ -0,0 +1,114 @@
+package ethmulticlient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+)
+
+func TestRetryClient(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+
+	api := config.APIConfig{
+		Name: "test",
+		Retry: config.RetryConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+			Jitter:         0.5,
+		},
+	}
+
+	t.Run("succeeds on the first attempt", func(t *testing.T) {
+		c := mocks.NewEVMClient(t)
+		setBatchResponse(t, c, "value")
+
+		client := ethmulticlient.NewRetryRPCClient(logger, api, c)
+		elems := []rpc.BatchElem{{}}
+		require.NoError(t, client.BatchCallContext(context.TODO(), elems))
+		require.Equal(t, "value", *elems[0].Result.(*string))
+		c.AssertNumberOfCalls(t, "BatchCallContext", 1)
+	})
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		c := mocks.NewEVMClient(t)
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(fmt.Errorf("transient")).Twice()
+		setBatchResponse(t, c, "value")
+
+		client := ethmulticlient.NewRetryRPCClient(logger, api, c)
+		elems := []rpc.BatchElem{{}}
+		require.NoError(t, client.BatchCallContext(context.TODO(), elems))
+		require.Equal(t, "value", *elems[0].Result.(*string))
+		c.AssertNumberOfCalls(t, "BatchCallContext", 3)
+	})
+
+	t.Run("fails after max attempts", func(t *testing.T) {
+		c := mocks.NewEVMClient(t)
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(fmt.Errorf("down"))
+
+		client := ethmulticlient.NewRetryRPCClient(logger, api, c)
+		err := client.BatchCallContext(context.TODO(), []rpc.BatchElem{{}})
+		require.ErrorContains(t, err, "batch call failed after 3 attempts")
+		c.AssertNumberOfCalls(t, "BatchCallContext", 3)
+	})
+
+	t.Run("stops retrying when the context is cancelled", func(t *testing.T) {
+		cfg := api
+		cfg.Retry.InitialBackoff = time.Hour
+		cfg.Retry.MaxBackoff = time.Hour
+
+		c := mocks.NewEVMClient(t)
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(fmt.Errorf("down"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		client := ethmulticlient.NewRetryRPCClient(logger, cfg, c)
+		err := client.BatchCallContext(ctx, []rpc.BatchElem{{}})
+		require.ErrorContains(t, err, "context done while retrying batch call")
+		c.AssertNumberOfCalls(t, "BatchCallContext", 1)
+	})
+	t.Run("backs off longer when rate limited", func(t *testing.T) {
+		cfg := api
+		cfg.Retry.RateLimitBackoff = time.Hour
+		cfg.Retry.MaxBackoff = time.Hour
+
+		c := mocks.NewEVMClient(t)
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(
+			rpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"},
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		client := ethmulticlient.NewRetryRPCClient(logger, cfg, c)
+		err := client.BatchCallContext(ctx, []rpc.BatchElem{{}})
+		require.ErrorContains(t, err, "context done while retrying batch call")
+		c.AssertNumberOfCalls(t, "BatchCallContext", 1)
+	})
+
+	t.Run("does not retry errors that are not retryable", func(t *testing.T) {
+		c := mocks.NewEVMClient(t)
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(
+			fmt.Errorf("%w: expected 1, got 5", ethmulticlient.ErrChainIDMismatch),
+		)
+
+		client := ethmulticlient.NewRetryRPCClient(logger, api, c)
+		err := client.BatchCallContext(context.TODO(), []rpc.BatchElem{{}})
+		require.ErrorIs(t, err, ethmulticlient.ErrChainIDMismatch)
+		require.ErrorContains(t, err, "batch call failed after 1 attempts")
+		c.AssertNumberOfCalls(t, "BatchCallContext", 1)
+	})
+}
@@ -0,0 +1,196 @@
+package ethmulticlient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/chainlink/aggregator"
+)
+
+var _ EVMClient = (*SequencerRPCClient)(nil)
+
+// ErrSequencerUnavailable is returned when the sequencer of an L2 chain is down, has not been back
+// up for the grace period, or when the endpoint is still syncing the chain.
+var ErrSequencerUnavailable = errors.New("sequencer unavailable")
+
+// SequencerRPCClient implements the EVMClient interface by wrapping an underlying EVMClient and
+// checking the sequencer of an L2 chain along with each batch call. The Chainlink sequencer uptime
+// feed and the endpoint's syncing status, as configured, are read in the same batch request as the
+// calls, and the batch call fails with ErrSequencerUnavailable unless the sequencer is up. Since
+// every call of the batch fails, the provider is quarantined by its health policy, if any, for as
+// long as the sequencer is down.
+type SequencerRPCClient struct {
+	logger *zap.Logger
+	cfg    config.SequencerConfig
+
+	// abi is the aggregator v3 abi, used to parse the latest round of the uptime feed.
+	abi *abi.ABI
+	// payload is the packed latestRoundData call to the uptime feed.
+	payload []byte
+
+	// client is the underlying client.
+	client EVMClient
+}
+
+// NewSequencerRPCClient returns a new SequencerRPCClient.
+func NewSequencerRPCClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client EVMClient,
+) (EVMClient, error) {
+	abi, err := aggregator.AggregatorMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aggregator abi: %w", err)
+	}
+
+	payload, err := abi.Pack("latestRoundData")
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack latestRoundData: %w", err)
+	}
+
+	return &SequencerRPCClient{
+		logger:  logger.With(zap.String("sequencer_client", api.Name)),
+		cfg:     api.Sequencer,
+		abi:     abi,
+		payload: payload,
+		client:  client,
+	}, nil
+}
+
+// BatchCallContext sends the batch call to the underlying client along with the sequencer checks,
+// and returns an error wrapping ErrSequencerUnavailable if the sequencer is not up. An error is
+// also returned if the checks could not be read, since the results of the calls cannot be trusted
+// without them.
+func (s *SequencerRPCClient) BatchCallContext(ctx context.Context, batchElems []rpc.BatchElem) error {
+	if len(batchElems) == 0 {
+		return nil
+	}
+
+	checks := s.checkElems()
+	req := append(batchElems[:len(batchElems):len(batchElems)], checks...)
+	if err := s.client.BatchCallContext(ctx, req); err != nil {
+		return err
+	}
+
+	if err := s.verify(req[len(batchElems):], time.Now()); err != nil {
+		s.logger.Debug("sequencer check failed", zap.Error(err))
+		return err
+	}
+
+	copy(batchElems, req)
+	return nil
+}
+
+// checkElems returns the batch elements of the configured checks: the latestRoundData call to the
+// uptime feed, followed by the eth_syncing call.
+func (s *SequencerRPCClient) checkElems() []rpc.BatchElem {
+	var elems []rpc.BatchElem
+	if s.cfg.UptimeFeed != "" {
+		elems = append(elems, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   common.HexToAddress(s.cfg.UptimeFeed),
+					"data": hexutil.Bytes(s.payload),
+				},
+				config.BlockTagLatest,
+			},
+			Result: new(string),
+		})
+	}
+
+	if s.cfg.CheckSyncing {
+		elems = append(elems, rpc.BatchElem{
+			Method: "eth_syncing",
+			Result: new(json.RawMessage),
+		})
+	}
+
+	return elems
+}
+
+// verify returns an error if any of the checks failed at the given time.
+func (s *SequencerRPCClient) verify(checks []rpc.BatchElem, now time.Time) error {
+	if s.cfg.UptimeFeed != "" {
+		if err := s.verifyUptime(checks[0], now); err != nil {
+			return err
+		}
+		checks = checks[1:]
+	}
+
+	if s.cfg.CheckSyncing {
+		if err := verifySyncing(checks[0]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyUptime verifies that the uptime feed reports the sequencer as up, i.e. that the answer of
+// its latest round is 0, and that it has been up since at least the grace period.
+func (s *SequencerRPCClient) verifyUptime(elem rpc.BatchElem, now time.Time) error {
+	if elem.Error != nil {
+		return fmt.Errorf("failed to read sequencer uptime feed: %w", elem.Error)
+	}
+
+	bz, err := hexutil.Decode(*elem.Result.(*string))
+	if err != nil {
+		return fmt.Errorf("failed to decode sequencer uptime feed result: %w", err)
+	}
+
+	out, err := s.abi.Methods["latestRoundData"].Outputs.UnpackValues(bz)
+	if err != nil {
+		return fmt.Errorf("failed to unpack sequencer uptime feed result: %w", err)
+	}
+
+	answer := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	startedAt := *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	// The uptime feed has no round yet on some chains until it is initialized.
+	if startedAt.Sign() == 0 {
+		return fmt.Errorf("%w: uptime feed has no round", ErrSequencerUnavailable)
+	}
+
+	since := time.Unix(startedAt.Int64(), 0).UTC()
+	if answer.Sign() != 0 {
+		return fmt.Errorf("%w: sequencer is down since %s", ErrSequencerUnavailable, since)
+	}
+
+	if up := now.Sub(since); up < s.cfg.GracePeriod {
+		return fmt.Errorf(
+			"%w: sequencer is up since %s, within the grace period of %s",
+			ErrSequencerUnavailable,
+			since,
+			s.cfg.GracePeriod,
+		)
+	}
+
+	return nil
+}
+
+// verifySyncing verifies that the endpoint is not syncing, i.e. that eth_syncing returned false.
+func verifySyncing(elem rpc.BatchElem) error {
+	if elem.Error != nil {
+		return fmt.Errorf("failed to read syncing status: %w", elem.Error)
+	}
+
+	var syncing bool
+	if err := json.Unmarshal(*elem.Result.(*json.RawMessage), &syncing); err != nil || syncing {
+		// eth_syncing returns an object describing the sync progress while the node is syncing.
+		return fmt.Errorf("%w: node is syncing", ErrSequencerUnavailable)
+	}
+
+	return nil
+}
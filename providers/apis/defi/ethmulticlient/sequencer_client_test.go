@@ -0,0 +1,150 @@
+package ethmulticlient_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/chainlink/aggregator"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+)
+
+const uptimeFeed = "0xFdB631F5EE196F0ed6FAa767959853A9F217697D"
+
+func TestSequencerRPCClient(t *testing.T) {
+	up := time.Now().Add(-2 * time.Hour).Unix()
+	recent := time.Now().Add(-time.Minute).Unix()
+
+	testCases := []struct {
+		name string
+		cfg  config.SequencerConfig
+		// answer and startedAt are the latest round of the uptime feed.
+		answer    int64
+		startedAt int64
+		feedErr   error
+		syncing   string
+		err       error
+	}{
+		{
+			name:      "sequencer is up",
+			cfg:       config.SequencerConfig{UptimeFeed: uptimeFeed, GracePeriod: time.Hour},
+			answer:    0,
+			startedAt: up,
+		},
+		{
+			name:      "sequencer is down",
+			cfg:       config.SequencerConfig{UptimeFeed: uptimeFeed, GracePeriod: time.Hour},
+			answer:    1,
+			startedAt: up,
+			err:       ethmulticlient.ErrSequencerUnavailable,
+		},
+		{
+			name:      "sequencer is up within the grace period",
+			cfg:       config.SequencerConfig{UptimeFeed: uptimeFeed, GracePeriod: time.Hour},
+			answer:    0,
+			startedAt: recent,
+			err:       ethmulticlient.ErrSequencerUnavailable,
+		},
+		{
+			name:      "uptime feed has no round",
+			cfg:       config.SequencerConfig{UptimeFeed: uptimeFeed},
+			answer:    0,
+			startedAt: 0,
+			err:       ethmulticlient.ErrSequencerUnavailable,
+		},
+		{
+			name:    "uptime feed cannot be read",
+			cfg:     config.SequencerConfig{UptimeFeed: uptimeFeed},
+			feedErr: errors.New("execution reverted"),
+		},
+		{
+			name:      "node is not syncing",
+			cfg:       config.SequencerConfig{UptimeFeed: uptimeFeed, CheckSyncing: true},
+			answer:    0,
+			startedAt: up,
+			syncing:   `false`,
+		},
+		{
+			name:      "node is syncing",
+			cfg:       config.SequencerConfig{UptimeFeed: uptimeFeed, CheckSyncing: true},
+			answer:    0,
+			startedAt: up,
+			syncing:   `{"startingBlock": "0x0", "currentBlock": "0x1", "highestBlock": "0x2"}`,
+			err:       ethmulticlient.ErrSequencerUnavailable,
+		},
+		{
+			name:    "only the syncing status is checked",
+			cfg:     config.SequencerConfig{CheckSyncing: true},
+			syncing: `false`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			underlying := mocks.NewEVMClient(t)
+			underlying.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				elems := args.Get(1).([]rpc.BatchElem)
+				*elems[0].Result.(*string) = "a"
+
+				checks := elems[1:]
+				if tc.cfg.UptimeFeed != "" {
+					require.Equal(t, "eth_call", checks[0].Method)
+					checks[0].Error = tc.feedErr
+					*checks[0].Result.(*string) = encodeUptimeRound(t, tc.answer, tc.startedAt)
+					checks = checks[1:]
+				}
+				if tc.cfg.CheckSyncing {
+					require.Equal(t, "eth_syncing", checks[0].Method)
+					*checks[0].Result.(*json.RawMessage) = json.RawMessage(tc.syncing)
+					checks = checks[1:]
+				}
+				require.Empty(t, checks)
+			})
+
+			api := config.APIConfig{Name: "chainlink_api-arbitrum", Sequencer: tc.cfg}
+			client, err := ethmulticlient.NewSequencerRPCClient(zap.NewNop(), api, underlying)
+			require.NoError(t, err)
+
+			calls, results := newBatch("a")
+			err = client.BatchCallContext(context.Background(), calls)
+			switch {
+			case tc.feedErr != nil:
+				require.ErrorContains(t, err, "failed to read sequencer uptime feed")
+			case tc.err != nil:
+				require.ErrorIs(t, err, tc.err)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, "a", *results[0])
+			}
+		})
+	}
+}
+
+func encodeUptimeRound(t *testing.T, answer, startedAt int64) string {
+	t.Helper()
+
+	abi, err := aggregator.AggregatorMetaData.GetAbi()
+	require.NoError(t, err)
+
+	bz, err := abi.Methods["latestRoundData"].Outputs.Pack(
+		big.NewInt(1),
+		big.NewInt(answer),
+		big.NewInt(startedAt),
+		big.NewInt(startedAt),
+		big.NewInt(1),
+	)
+	require.NoError(t, err)
+
+	return hexutil.Encode(bz)
+}
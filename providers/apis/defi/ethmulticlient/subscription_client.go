@@ -0,0 +1,264 @@
+package ethmulticlient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+var _ EVMClient = (*SubscriptionRPCClient)(nil)
+
+// SubscriptionRPCClient implements the EVMClient interface by wrapping an underlying EVMClient and
+// caching the responses of batch calls until a new block is observed. New blocks are observed via
+// a newHeads subscription over a websocket endpoint. This means that when the provider's fetch
+// interval is shorter than the chain's block time, repeated fetches within the same block are served
+// from the cache instead of the endpoint.
+//
+// If the subscription fails, the cache is bypassed and all calls are forwarded to the underlying
+// client until the subscription is re-established.
+type SubscriptionRPCClient struct {
+	mtx    sync.Mutex
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the underlying client used for batch calls.
+	client EVMClient
+	// subClient is the websocket client used for the newHeads subscription.
+	subClient *rpc.Client
+
+	// subscribed is true if the newHeads subscription is currently active.
+	subscribed bool
+	// height is the latest block height observed via the subscription.
+	height uint64
+	// cache is a cache of the batch call responses for the latest observed block.
+	cache map[string][]cachedResult
+}
+
+// cachedResult is the cached response of a single batch element.
+type cachedResult struct {
+	result json.RawMessage
+	err    error
+}
+
+// head is the subset of the block header returned by the newHeads subscription that is used by
+// the client.
+type head struct {
+	Number *hexutil.Big `json:"number"`
+}
+
+// NewSubscriptionRPCClient returns a new SubscriptionRPCClient and starts the newHeads subscription.
+// The subscription is re-established after the configured ReconnectTimeout if it fails, and is
+// closed once the given context is cancelled.
+func NewSubscriptionRPCClient(
+	ctx context.Context,
+	logger *zap.Logger,
+	api config.APIConfig,
+	client EVMClient,
+	subClient *rpc.Client,
+) EVMClient {
+	s := &SubscriptionRPCClient{
+		logger:    logger.With(zap.String("subscription_client", api.Name)),
+		api:       api,
+		client:    client,
+		subClient: subClient,
+		cache:     make(map[string][]cachedResult),
+	}
+
+	go s.subscribe(ctx)
+	return s
+}
+
+// NewSubscriptionRPCClientFromEndpoints creates a SubscriptionRPCClient from config endpoints. The
+// newHeads subscription is made over the first websocket endpoint in the config.
+func NewSubscriptionRPCClientFromEndpoints(
+	ctx context.Context,
+	logger *zap.Logger,
+	api config.APIConfig,
+	client EVMClient,
+) (EVMClient, error) {
+	for _, endpoint := range api.Endpoints {
+		if !endpoint.IsWebSocket() {
+			continue
+		}
+
+		subClient, err := DialEndpoint(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial websocket endpoint: %w", err)
+		}
+
+		return NewSubscriptionRPCClient(ctx, logger, api, client, subClient), nil
+	}
+
+	return nil, fmt.Errorf("no websocket endpoint provided for the new heads subscription")
+}
+
+// BatchCallContext serves the batch call from the cache if an identical batch call was made at the
+// latest observed block. Otherwise, the call is forwarded to the underlying client and the response
+// is cached.
+func (s *SubscriptionRPCClient) BatchCallContext(ctx context.Context, batchElems []rpc.BatchElem) error {
+	key, err := cacheKey(batchElems)
+	if err != nil {
+		s.logger.Debug("failed to derive cache key; bypassing cache", zap.Error(err))
+		return s.client.BatchCallContext(ctx, batchElems)
+	}
+
+	if s.fromCache(key, batchElems) {
+		return nil
+	}
+
+	s.mtx.Lock()
+	height := s.height
+	s.mtx.Unlock()
+
+	if err := s.client.BatchCallContext(ctx, batchElems); err != nil {
+		return err
+	}
+
+	s.toCache(key, height, batchElems)
+	return nil
+}
+
+// fromCache populates the batch elements from the cache. It returns false if the response is not
+// cached or the subscription is not active.
+func (s *SubscriptionRPCClient) fromCache(key string, batchElems []rpc.BatchElem) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	cached, ok := s.cache[key]
+	if !s.subscribed || !ok || len(cached) != len(batchElems) {
+		return false
+	}
+
+	for i, res := range cached {
+		if res.err != nil {
+			batchElems[i].Error = res.err
+			continue
+		}
+
+		if err := json.Unmarshal(res.result, batchElems[i].Result); err != nil {
+			return false
+		}
+	}
+
+	s.logger.Debug("served batch call from cache", zap.Uint64("height", s.height))
+	return true
+}
+
+// toCache caches the response of the batch call, so long as no new block was observed while the
+// call was in flight.
+func (s *SubscriptionRPCClient) toCache(key string, height uint64, batchElems []rpc.BatchElem) {
+	cached := make([]cachedResult, len(batchElems))
+	for i, elem := range batchElems {
+		if elem.Error != nil {
+			cached[i] = cachedResult{err: elem.Error}
+			continue
+		}
+
+		bz, err := json.Marshal(elem.Result)
+		if err != nil {
+			return
+		}
+		cached[i] = cachedResult{result: bz}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.subscribed || s.height != height {
+		return
+	}
+	s.cache[key] = cached
+}
+
+// subscribe maintains the newHeads subscription until the context is cancelled.
+func (s *SubscriptionRPCClient) subscribe(ctx context.Context) {
+	defer s.subClient.Close()
+
+	for {
+		heads := make(chan head)
+		sub, err := s.subClient.EthSubscribe(ctx, heads, "newHeads")
+		if err != nil {
+			s.logger.Debug("failed to subscribe to new heads", zap.Error(err))
+		} else {
+			s.logger.Debug("subscribed to new heads")
+			err = s.listen(ctx, sub, heads)
+			sub.Unsubscribe()
+			s.logger.Debug("new heads subscription closed", zap.Error(err))
+		}
+		s.setSubscribed(false)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.api.ReconnectTimeout):
+		}
+	}
+}
+
+// listen updates the latest block height for each new head until the subscription fails or the
+// context is cancelled.
+func (s *SubscriptionRPCClient) listen(ctx context.Context, sub *rpc.ClientSubscription, heads chan head) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case h := <-heads:
+			if h.Number == nil {
+				continue
+			}
+
+			height := h.Number.ToInt().Uint64()
+			s.mtx.Lock()
+			if height != s.height || !s.subscribed {
+				s.height = height
+				s.cache = make(map[string][]cachedResult)
+			}
+			s.subscribed = true
+			s.mtx.Unlock()
+
+			s.logger.Debug("received new head", zap.Uint64("height", height))
+		}
+	}
+}
+
+// setSubscribed sets whether the subscription is active. The cache is cleared whenever the
+// subscription is inactive since new blocks can no longer be observed.
+func (s *SubscriptionRPCClient) setSubscribed(subscribed bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.subscribed = subscribed
+	if !subscribed {
+		s.cache = make(map[string][]cachedResult)
+	}
+}
+
+// cacheKey derives the cache key for a batch call from the methods and arguments of its elements.
+func cacheKey(batchElems []rpc.BatchElem) (string, error) {
+	type call struct {
+		Method string        `json:"method"`
+		Args   []interface{} `json:"args"`
+	}
+
+	calls := make([]call, len(batchElems))
+	for i, elem := range batchElems {
+		calls[i] = call{Method: elem.Method, Args: elem.Args}
+	}
+
+	bz, err := json.Marshal(calls)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bz), nil
+}
@@ -0,0 +1,143 @@
+package ethmulticlient_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+)
+
+// headsService is an in-process eth service that serves a newHeads subscription.
+type headsService struct {
+	heads chan uint64
+}
+
+func (s *headsService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case <-sub.Err():
+				return
+			case height := <-s.heads:
+				_ = notifier.Notify(sub.ID, map[string]interface{}{
+					"number": hexutil.EncodeUint64(height),
+				})
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+func TestSubscriptionClient(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+
+	api := config.APIConfig{
+		Name:             "test",
+		ReconnectTimeout: 10 * time.Millisecond,
+	}
+
+	setup := func(t *testing.T) (*headsService, *mocks.EVMClient, ethmulticlient.EVMClient, *int) {
+		t.Helper()
+
+		service := &headsService{heads: make(chan uint64)}
+		server := rpc.NewServer()
+		require.NoError(t, server.RegisterName("eth", service))
+		t.Cleanup(server.Stop)
+
+		calls := 0
+		underlying := mocks.NewEVMClient(t)
+		underlying.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			elems, ok := args.Get(1).([]rpc.BatchElem)
+			require.True(t, ok)
+
+			calls++
+			for i := range elems {
+				*elems[i].Result.(*string) = fmt.Sprintf("response %d", calls)
+			}
+		}).Maybe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		client := ethmulticlient.NewSubscriptionRPCClient(ctx, logger, api, underlying, rpc.DialInProc(server))
+		return service, underlying, client, &calls
+	}
+
+	call := func(t *testing.T, client ethmulticlient.EVMClient) string {
+		t.Helper()
+
+		var result string
+		elems := []rpc.BatchElem{{Method: "eth_call", Args: []interface{}{"0x1"}, Result: &result}}
+		require.NoError(t, client.BatchCallContext(context.Background(), elems))
+		return result
+	}
+
+	t.Run("requests pass through before the first head is received", func(t *testing.T) {
+		_, _, client, calls := setup(t)
+
+		require.Equal(t, "response 1", call(t, client))
+		require.Equal(t, "response 2", call(t, client))
+		require.Equal(t, 2, *calls)
+	})
+
+	t.Run("requests within the same block are served from the cache", func(t *testing.T) {
+		service, _, client, calls := setup(t)
+
+		service.heads <- 1
+		waitForCache(t, client, call)
+
+		before := *calls
+		result := call(t, client)
+		require.Equal(t, result, call(t, client))
+		require.Equal(t, before, *calls)
+
+		// a new block invalidates the cache.
+		service.heads <- 2
+		require.Eventually(t, func() bool {
+			return call(t, client) != result
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, before+1, *calls)
+	})
+
+	t.Run("requests with different arguments are cached separately", func(t *testing.T) {
+		service, _, client, calls := setup(t)
+
+		service.heads <- 1
+		waitForCache(t, client, call)
+		before := *calls
+
+		var result string
+		elems := []rpc.BatchElem{{Method: "eth_call", Args: []interface{}{"0x2"}, Result: &result}}
+		require.NoError(t, client.BatchCallContext(context.Background(), elems))
+		require.Equal(t, before+1, *calls)
+	})
+}
+
+// waitForCache waits until consecutive requests are served from the cache, i.e. the client has
+// received a new head.
+func waitForCache(t *testing.T, client ethmulticlient.EVMClient, call func(*testing.T, ethmulticlient.EVMClient) string) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		result := call(t, client)
+		return call(t, client) == result
+	}, time.Second, 10*time.Millisecond)
+}
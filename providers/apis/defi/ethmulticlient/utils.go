@@ -1,6 +1,18 @@
 package ethmulticlient
 
-import "github.com/ethereum/go-ethereum/rpc"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
 
 // EthBlockNumberBatchElem returns an initialized BatchElem for the eth_blockNumber call.
 func EthBlockNumberBatchElem() rpc.BatchElem {
@@ -10,3 +22,110 @@ func EthBlockNumberBatchElem() rpc.BatchElem {
 		Result: &result,
 	}
 }
+
+// BlockHeader is the subset of a block returned by eth_getBlockByNumber that identifies the block
+// at which on-chain state was read.
+type BlockHeader struct {
+	Number    hexutil.Uint64 `json:"number"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+}
+
+// Info returns the block info of the header.
+func (h BlockHeader) Info() providertypes.BlockInfo {
+	return providertypes.BlockInfo{
+		Number:    uint64(h.Number),
+		Timestamp: time.Unix(int64(h.Timestamp), 0).UTC(),
+	}
+}
+
+// EthGetBlockByNumberBatchElem returns an initialized BatchElem for the eth_getBlockByNumber call
+// of the block at the given block tag, without its transactions. The result is a *BlockHeader.
+func EthGetBlockByNumberBatchElem(blockTag string) rpc.BatchElem {
+	return rpc.BatchElem{
+		Method: "eth_getBlockByNumber",
+		Args:   []interface{}{blockTag, false},
+		Result: new(BlockHeader),
+	}
+}
+
+// BatchCallWithBlock makes the batch call along with an eth_getBlockByNumber call of the block at
+// the given block tag, and returns the block at which the calls were read. The calls should read
+// state at the same block tag. Since the header is read in the same batch request, it is the block
+// the endpoint read the calls at, barring a new block between the calls. The block is zero if it
+// could not be read, in which case the results of the calls are still usable. Nothing is read if
+// there are no calls.
+func BatchCallWithBlock(
+	ctx context.Context,
+	client EVMClient,
+	blockTag string,
+	calls []rpc.BatchElem,
+) (providertypes.BlockInfo, error) {
+	if len(calls) == 0 {
+		return providertypes.BlockInfo{}, nil
+	}
+
+	batchElems := append(calls[:len(calls):len(calls)], EthGetBlockByNumberBatchElem(blockTag))
+	if err := client.BatchCallContext(ctx, batchElems); err != nil {
+		return providertypes.BlockInfo{}, err
+	}
+	copy(calls, batchElems)
+
+	elem := batchElems[len(calls)]
+	header, ok := elem.Result.(*BlockHeader)
+	if elem.Error != nil || !ok || header.Number == 0 {
+		return providertypes.BlockInfo{}, nil
+	}
+
+	return header.Info(), nil
+}
+
+// NewClientFromEndpoints returns an EVMClient for the endpoints in the API config. A single endpoint
+// is served by a GoEthereumClientImpl. Multiple endpoints are served by a FailoverRPCClient if
+// failover is enabled, and by a MultiRPCClient otherwise. If a retry policy is configured, the
+// client is wrapped in a RetryRPCClient. If the sequencer is checked, the client is wrapped in a
+// SequencerRPCClient. If the new heads subscription is enabled, the client is further wrapped in a
+// SubscriptionRPCClient.
+func NewClientFromEndpoints(
+	ctx context.Context,
+	logger *zap.Logger,
+	api config.APIConfig,
+	apiMetrics metrics.APIMetrics,
+) (EVMClient, error) {
+	var (
+		client EVMClient
+		err    error
+	)
+	switch {
+	case len(api.Endpoints) > 1 && api.Failover:
+		client, err = NewFailoverRPCClientFromEndpoints(ctx, logger, api, apiMetrics)
+	case len(api.Endpoints) > 1:
+		client, err = NewMultiRPCClientFromEndpoints(ctx, logger, api, apiMetrics)
+	case len(api.Endpoints) == 1:
+		client, err = NewGoEthereumClientImpl(ctx, apiMetrics, api, 0)
+	default:
+		err = fmt.Errorf("no endpoints were provided")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if api.Retry.Enabled() {
+		client = NewRetryRPCClient(logger, api, client)
+	}
+
+	if api.Sequencer.Enabled() {
+		client, err = NewSequencerRPCClient(logger, api, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if api.NewHeadsSubscription {
+		client, err = NewSubscriptionRPCClientFromEndpoints(ctx, logger, api, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
@@ -0,0 +1,74 @@
+package ethmulticlient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+func TestBatchCallWithBlock(t *testing.T) {
+	header := ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+	t.Run("returns the block the calls were read at", func(t *testing.T) {
+		client := mocks.NewEVMClient(t)
+		client.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			elems := args.Get(1).([]rpc.BatchElem)
+			require.Len(t, elems, 2)
+			require.Equal(t, "eth_getBlockByNumber", elems[1].Method)
+			require.Equal(t, []interface{}{"safe", false}, elems[1].Args)
+
+			*elems[0].Result.(*string) = "a"
+			*elems[1].Result.(*ethmulticlient.BlockHeader) = header
+		})
+
+		calls, results := newBatch("a")
+		block, err := ethmulticlient.BatchCallWithBlock(context.Background(), client, "safe", calls)
+		require.NoError(t, err)
+		require.Equal(t, providertypes.BlockInfo{
+			Number:    19_000_000,
+			Timestamp: time.Unix(1_700_000_000, 0).UTC(),
+		}, block)
+		require.Equal(t, "a", *results[0])
+	})
+
+	t.Run("returns a zero block if the header could not be read", func(t *testing.T) {
+		client := mocks.NewEVMClient(t)
+		client.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			elems := args.Get(1).([]rpc.BatchElem)
+			elems[0].Error = errors.New("execution reverted")
+			elems[1].Error = errors.New("method not found")
+		})
+
+		calls, _ := newBatch("a")
+		block, err := ethmulticlient.BatchCallWithBlock(context.Background(), client, "latest", calls)
+		require.NoError(t, err)
+		require.True(t, block.IsZero())
+		require.ErrorContains(t, calls[0].Error, "execution reverted")
+	})
+
+	t.Run("returns the error of the batch call", func(t *testing.T) {
+		client := mocks.NewEVMClient(t)
+		client.On("BatchCallContext", mock.Anything, mock.Anything).Return(errors.New("unavailable"))
+
+		calls, _ := newBatch("a")
+		_, err := ethmulticlient.BatchCallWithBlock(context.Background(), client, "latest", calls)
+		require.ErrorContains(t, err, "unavailable")
+	})
+
+	t.Run("reads nothing without calls", func(t *testing.T) {
+		client := mocks.NewEVMClient(t)
+
+		block, err := ethmulticlient.BatchCallWithBlock(context.Background(), client, "latest", nil)
+		require.NoError(t, err)
+		require.True(t, block.IsZero())
+	})
+}
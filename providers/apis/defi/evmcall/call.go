@@ -0,0 +1,296 @@
+package evmcall
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/skip-mev/connect/v2/pkg/math"
+)
+
+// Call is a read-only contract call built from a CallConfig. It holds the packed call payload and
+// the resolved path of the price in the outputs of the call, so that the ABI only has to be
+// parsed once per ticker.
+type Call struct {
+	// Config is the configuration the call was built from.
+	Config CallConfig
+	// Payload is the packed call data, i.e. the method selector followed by the arguments.
+	Payload []byte
+
+	// method is the method that is called.
+	method abi.Method
+	// path is the index of the output, followed by the indices of the tuple fields and array
+	// elements that lead to the price.
+	path []int
+}
+
+// NewCall parses the ABI fragment of the given config, packs the call arguments and resolves
+// the output path of the price.
+func NewCall(cfg CallConfig) (Call, error) {
+	fragment := bytes.TrimSpace(cfg.ABI)
+	if len(fragment) == 0 {
+		return Call{}, fmt.Errorf("abi cannot be empty")
+	}
+
+	// A single method definition is wrapped in a list, since the abi parser expects a full abi.
+	if fragment[0] == '{' {
+		fragment = append(append([]byte("["), fragment...), ']')
+	}
+
+	contract, err := abi.JSON(bytes.NewReader(fragment))
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to parse abi: %w", err)
+	}
+
+	method, ok := contract.Methods[cfg.Method]
+	if !ok {
+		return Call{}, fmt.Errorf("method %s not found in abi", cfg.Method)
+	}
+
+	if !method.IsConstant() {
+		return Call{}, fmt.Errorf("method %s is not a view or pure function", cfg.Method)
+	}
+
+	if len(cfg.Args) != len(method.Inputs) {
+		return Call{}, fmt.Errorf("method %s expects %d args, got %d", cfg.Method, len(method.Inputs), len(cfg.Args))
+	}
+
+	args := make([]interface{}, len(cfg.Args))
+	for i, arg := range cfg.Args {
+		args[i], err = ConvertArg(method.Inputs[i].Type, arg)
+		if err != nil {
+			return Call{}, fmt.Errorf("invalid arg %d of method %s: %w", i, cfg.Method, err)
+		}
+	}
+
+	payload, err := contract.Pack(cfg.Method, args...)
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to pack %s: %w", cfg.Method, err)
+	}
+
+	path, err := resolveOutputPath(method.Outputs, cfg.Output)
+	if err != nil {
+		return Call{}, err
+	}
+
+	return Call{
+		Config:  cfg,
+		Payload: payload,
+		method:  method,
+		path:    path,
+	}, nil
+}
+
+// ParseResult decodes the hex encoded result of the call, selects the value at the output path
+// and scales it by the decimals of the config.
+func (c Call) ParseResult(result string) (*big.Float, error) {
+	bz, err := hexutil.Decode(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := c.method.Outputs.UnpackValues(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	value := reflect.ValueOf(out[c.path[0]])
+	for _, index := range c.path[1:] {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
+
+		switch value.Kind() {
+		case reflect.Struct:
+			value = value.Field(index)
+		case reflect.Slice, reflect.Array:
+			if index >= value.Len() {
+				return nil, fmt.Errorf("index %d out of range of output %s", index, c.Config.Output)
+			}
+			value = value.Index(index)
+		default:
+			return nil, fmt.Errorf("cannot select %d of a %s", index, value.Kind())
+		}
+	}
+
+	amount, err := toBigInt(value)
+	if err != nil {
+		return nil, err
+	}
+
+	scaled, err := math.NewPrice(amount, -c.Config.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	price := scaled.BigFloat()
+	if c.Config.Invert {
+		if price.Sign() == 0 {
+			return nil, fmt.Errorf("cannot invert a zero price")
+		}
+		price = new(big.Float).Quo(big.NewFloat(1), price)
+	}
+
+	return price, nil
+}
+
+// ConvertArg converts a string encoded call argument to the go type expected by the abi encoder
+// for the given abi type. Only elementary types are supported.
+func ConvertArg(t abi.Type, arg string) (interface{}, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		amount, ok := new(big.Int).SetString(arg, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %s", arg)
+		}
+
+		if t.Size > 64 {
+			return amount, nil
+		}
+
+		value := reflect.New(t.GetType()).Elem()
+		if t.T == abi.IntTy {
+			if !amount.IsInt64() || value.OverflowInt(amount.Int64()) {
+				return nil, fmt.Errorf("integer %s overflows %s", arg, t)
+			}
+			value.SetInt(amount.Int64())
+		} else {
+			if !amount.IsUint64() || value.OverflowUint(amount.Uint64()) {
+				return nil, fmt.Errorf("integer %s overflows %s", arg, t)
+			}
+			value.SetUint(amount.Uint64())
+		}
+
+		return value.Interface(), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(arg)
+	case abi.StringTy:
+		return arg, nil
+	case abi.AddressTy:
+		if !common.IsHexAddress(arg) {
+			return nil, fmt.Errorf("invalid address %s", arg)
+		}
+		return common.HexToAddress(arg), nil
+	case abi.BytesTy:
+		return hexutil.Decode(arg)
+	case abi.FixedBytesTy:
+		bz, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(bz) != t.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", t.Size, len(bz))
+		}
+
+		value := reflect.New(t.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(bz))
+		return value.Interface(), nil
+	default:
+		return nil, fmt.Errorf("unsupported argument type %s", t)
+	}
+}
+
+// resolveOutputPath resolves the names and indices of the given output path to indices, and
+// checks that the path leads to an integer.
+func resolveOutputPath(outputs abi.Arguments, output string) ([]int, error) {
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("method has no outputs")
+	}
+
+	elems := []string{"0"}
+	if len(output) > 0 {
+		elems = strings.Split(output, OutputSeparator)
+	}
+
+	names := make([]string, len(outputs))
+	types := make([]*abi.Type, len(outputs))
+	for i := range outputs {
+		names[i] = outputs[i].Name
+		types[i] = &outputs[i].Type
+	}
+
+	var (
+		path []int
+		t    *abi.Type
+	)
+	for i, elem := range elems {
+		if i > 0 {
+			switch t.T {
+			case abi.TupleTy:
+				names = t.TupleRawNames
+				types = t.TupleElems
+			case abi.SliceTy, abi.ArrayTy:
+				index, err := strconv.Atoi(elem)
+				if err != nil || index < 0 || (t.T == abi.ArrayTy && index >= t.Size) {
+					return nil, fmt.Errorf("invalid index %s of %s in output %s", elem, t, output)
+				}
+
+				path = append(path, index)
+				t = t.Elem
+				continue
+			default:
+				return nil, fmt.Errorf("cannot select %s of %s in output %s", elem, t, output)
+			}
+		}
+
+		index, err := selectIndex(names, elem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output %s: %w", output, err)
+		}
+
+		path = append(path, index)
+		t = types[index]
+	}
+
+	if t.T != abi.IntTy && t.T != abi.UintTy {
+		return nil, fmt.Errorf("output %s is a %s, expected an integer", output, t)
+	}
+
+	return path, nil
+}
+
+// selectIndex returns the index of the element with the given name, or the given index.
+func selectIndex(names []string, elem string) (int, error) {
+	for i, name := range names {
+		if len(name) > 0 && name == elem {
+			return i, nil
+		}
+	}
+
+	index, err := strconv.Atoi(elem)
+	if err != nil || index < 0 || index >= len(names) {
+		return 0, fmt.Errorf("%s not found", elem)
+	}
+
+	return index, nil
+}
+
+// toBigInt converts a decoded integer to a big.Int.
+func toBigInt(value reflect.Value) (*big.Int, error) {
+	if !value.IsValid() {
+		return nil, fmt.Errorf("output is empty")
+	}
+
+	if amount, ok := value.Interface().(*big.Int); ok {
+		if amount == nil {
+			return nil, fmt.Errorf("output is nil")
+		}
+		return amount, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(value.Uint()), nil
+	default:
+		return nil, fmt.Errorf("expected an integer output, got %s", value.Type())
+	}
+}
@@ -0,0 +1,153 @@
+package evmcall_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/evmcall"
+)
+
+func TestCallConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  func() evmcall.CallConfig
+		err  bool
+	}{
+		{
+			name: "valid config without args",
+			cfg: func() evmcall.CallConfig {
+				return ethusdCfg
+			},
+			err: false,
+		},
+		{
+			name: "valid config with args",
+			cfg: func() evmcall.CallConfig {
+				return usdethCfg
+			},
+			err: false,
+		},
+		{
+			name: "invalid address",
+			cfg: func() evmcall.CallConfig {
+				cfg := ethusdCfg
+				cfg.Address = "0x1234"
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "negative decimals",
+			cfg: func() evmcall.CallConfig {
+				cfg := ethusdCfg
+				cfg.Decimals = -1
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "invalid abi",
+			cfg: func() evmcall.CallConfig {
+				cfg := ethusdCfg
+				cfg.ABI = json.RawMessage(`{"name":`)
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "method not in abi",
+			cfg: func() evmcall.CallConfig {
+				cfg := ethusdCfg
+				cfg.Method = "latestAnswer"
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "method is not a view function",
+			cfg: func() evmcall.CallConfig {
+				cfg := ethusdCfg
+				cfg.ABI = json.RawMessage(`{"inputs":[],"name":"latestRoundData","outputs":[{"name":"answer",` +
+					`"type":"int256"}],"stateMutability":"nonpayable","type":"function"}`)
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "wrong number of args",
+			cfg: func() evmcall.CallConfig {
+				cfg := usdethCfg
+				cfg.Args = cfg.Args[:1]
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "arg overflows its type",
+			cfg: func() evmcall.CallConfig {
+				cfg := usdethCfg
+				cfg.Args = []string{cfg.Args[0], "256"}
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "output not found",
+			cfg: func() evmcall.CallConfig {
+				cfg := ethusdCfg
+				cfg.Output = "price"
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "output is not an integer",
+			cfg: func() evmcall.CallConfig {
+				cfg := ethusdPythCfg
+				cfg.Output = "0"
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "output selects a tuple field by index",
+			cfg: func() evmcall.CallConfig {
+				cfg := ethusdPythCfg
+				cfg.Output = "0.3"
+				return cfg
+			},
+			err: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg()
+
+			err := cfg.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewCall(t *testing.T) {
+	call, err := evmcall.NewCall(usdethCfg)
+	require.NoError(t, err)
+
+	// The payload is the getRate(address,uint8) selector followed by the padded args.
+	selector := crypto.Keccak256([]byte("getRate(address,uint8)"))[:4]
+	require.Equal(t,
+		hexutil.Encode(selector)+
+			"000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"+
+			"0000000000000000000000000000000000000000000000000000000000000012",
+		hexutil.Encode(call.Payload),
+	)
+}
@@ -0,0 +1,254 @@
+package evmcall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// PriceFetcher is the EVM call price fetcher. This fetcher is responsible for calling arbitrary
+// read-only contract methods and returning the price of a given ticker. The contract, the ABI of
+// the method, the call arguments and the output that holds the price are all supplied by the
+// metadata of the ticker, so new price sources can be onboarded without writing a new provider.
+//
+// Similar to the Chainlink fetcher, we utilize the eth client's BatchCallContext to batch the calls
+// to the ethereum network.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the EVM client implementation. This is used to interact with the ethereum network.
+	client ethmulticlient.EVMClient
+
+	mtx sync.Mutex
+	// callCache is a cache of the tickers to calls. This is used to avoid unmarshalling the metadata
+	// and parsing the abi for each ticker.
+	callCache map[types.ProviderTicker]Call
+}
+
+// NewPriceFetcher returns a new EVM call price fetcher.
+func NewPriceFetcher(
+	ctx context.Context,
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("api metrics is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if !IsValidProviderName(api.Name) {
+		return nil, fmt.Errorf("invalid api config name %s", api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	client, err := ethmulticlient.NewClientFromEndpoints(
+		ctx,
+		logger,
+		api,
+		apiMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client ethmulticlient.EVMClient,
+) (*PriceFetcher, error) {
+	return &PriceFetcher{
+		logger:    logger.With(zap.String("fetcher", api.Name)),
+		api:       api,
+		client:    client,
+		callCache: make(map[types.ProviderTicker]Call),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. This fetch utilizes the batch call to lower
+// overhead of making individual RPC calls for each ticker. The fetcher will call the configured
+// method of each contract, select the configured output and scale it by the configured decimals.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+	)
+
+	// Create a batch element for each ticker and call.
+	batchElems := make([]rpc.BatchElem, len(tickers))
+	calls := make([]Call, len(tickers))
+	for i, ticker := range tickers {
+		call, err := f.GetCall(ticker)
+		if err != nil {
+			f.logger.Debug(
+				"failed to get call for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to get call: %w", err),
+					providertypes.ErrorFailedToDecode,
+				),
+			)
+		}
+
+		var result string
+		batchElems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   common.HexToAddress(call.Config.Address),
+					"data": hexutil.Bytes(call.Payload),
+				},
+				f.api.GetBlockTag(), // the configured block tag, latest by default.
+			},
+			Result: &result,
+		}
+		calls[i] = call
+	}
+
+	// Batch call to the EVM.
+	block, err := ethmulticlient.BatchCallWithBlock(ctx, f.client, f.api.GetBlockTag(), batchElems)
+	if err != nil {
+		f.logger.Debug(
+			"failed to batch call to ethereum network for all tickers",
+			zap.Error(err),
+		)
+
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+		)
+	}
+
+	// Parse the result from the batch call for each ticker.
+	now := time.Now().UTC()
+	for i, ticker := range tickers {
+		result := batchElems[i]
+		if result.Error != nil {
+			f.logger.Debug(
+				"failed to batch call to ethereum network for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(result.Error),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					result.Error,
+					ethmulticlient.ErrorCodeFromError(result.Error, providertypes.ErrorUnknown),
+				),
+			}
+
+			continue
+		}
+
+		r, ok := result.Result.(*string)
+		if !ok || r == nil {
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					fmt.Errorf("expected result to be a string, got %T", result.Result),
+					providertypes.ErrorInvalidResponse,
+				),
+			}
+
+			continue
+		}
+
+		price, err := calls[i].ParseResult(*r)
+		if err != nil {
+			f.logger.Debug(
+				"failed to parse call result",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorFailedToParsePrice,
+				),
+			}
+
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResultWithBlock(price, now, block)
+	}
+
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// GetCall returns the call for the given ticker. This will unmarshal the metadata, validate the
+// call config and build the call, which contains all required information to query the EVM.
+func (f *PriceFetcher) GetCall(
+	ticker types.ProviderTicker,
+) (Call, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if call, ok := f.callCache[ticker]; ok {
+		return call, nil
+	}
+
+	var cfg CallConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return Call{}, fmt.Errorf("failed to unmarshal call config on ticker: %w", err)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return Call{}, fmt.Errorf("invalid ticker call config: %w", err)
+	}
+
+	call, err := NewCall(cfg)
+	if err != nil {
+		return Call{}, err
+	}
+
+	f.callCache[ticker] = call
+	return call, nil
+}
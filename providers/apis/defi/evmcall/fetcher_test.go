@@ -0,0 +1,273 @@
+package evmcall_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/evmcall"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+const (
+	latestRoundDataABI = `{"inputs":[],"name":"latestRoundData","outputs":[{"name":"roundId","type":"uint80"},` +
+		`{"name":"answer","type":"int256"},{"name":"startedAt","type":"uint256"},{"name":"updatedAt","type":"uint256"},` +
+		`{"name":"answeredInRound","type":"uint80"}],"stateMutability":"view","type":"function"}`
+
+	getPriceABI = `[{"inputs":[{"name":"id","type":"bytes32"}],"name":"getPrice","outputs":[{"components":[` +
+		`{"name":"price","type":"int64"},{"name":"conf","type":"uint64"},{"name":"expo","type":"int32"},` +
+		`{"name":"publishTime","type":"uint256"}],"name":"","type":"tuple"}],"stateMutability":"view","type":"function"}]`
+
+	getRateABI = `{"inputs":[{"name":"token","type":"address"},{"name":"scale","type":"uint8"}],"name":"getRate",` +
+		`"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}`
+
+	priceID = "0xff61491a931112ddf1bd8147cd1b641375f79f5825126d665480874634fd0ace"
+)
+
+var (
+	logger, _ = zap.NewDevelopment()
+
+	// CallConfigs used for testing.
+	ethusdCfg = evmcall.CallConfig{
+		Address:  "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+		ABI:      json.RawMessage(latestRoundDataABI),
+		Method:   "latestRoundData",
+		Output:   "answer",
+		Decimals: 8,
+	}
+	ethusdPythCfg = evmcall.CallConfig{
+		Address:  "0x4305FB66699C3B2702D4d05CF36551390A4c69C6",
+		ABI:      json.RawMessage(getPriceABI),
+		Method:   "getPrice",
+		Args:     []string{priceID},
+		Output:   "0.price",
+		Decimals: 8,
+	}
+	usdethCfg = evmcall.CallConfig{
+		Address:  "0x07D91f5fb9Bf7798734C3f606dB065549F6893bb",
+		ABI:      json.RawMessage(getRateABI),
+		Method:   "getRate",
+		Args:     []string{"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "18"},
+		Decimals: 18,
+		Invert:   true,
+	}
+
+	// Tickers used for testing.
+	ethusdTicker     = types.NewProviderTicker("ETH/USD", ethusdCfg.MustToJSON())
+	ethusdPythTicker = types.NewProviderTicker("ETH/USD", ethusdPythCfg.MustToJSON())
+	usdethTicker     = types.NewProviderTicker("USD/ETH", usdethCfg.MustToJSON())
+)
+
+func TestFetch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tickers  []types.ProviderTicker
+		client   func() ethmulticlient.EVMClient
+		expected types.PriceResponse
+	}{
+		{
+			name: "fails to retrieve call for an empty ticker",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("ETH/USD", ""),
+			},
+			client: func() ethmulticlient.EVMClient {
+				return mocks.NewEVMClient(t)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					types.NewProviderTicker("ETH/USD", ""): {},
+				},
+			},
+		},
+		{
+			name: "fails to make a batch call",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, fmt.Errorf("failed to make a batch call"), nil, nil)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					ethusdTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request has an error for a single ticker",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{""}, []error{fmt.Errorf("execution reverted")})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					ethusdTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request returns a result that cannot be parsed",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{"not a valid result"}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					ethusdTicker: {},
+				},
+			},
+		},
+		{
+			name: "named output of a method without args",
+			tickers: []types.ProviderTicker{
+				ethusdTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				response := encodeOutputs(t, latestRoundDataABI, "latestRoundData",
+					big.NewInt(10), big.NewInt(250012345678), big.NewInt(1), big.NewInt(1), big.NewInt(10),
+				)
+				return createEVMClientWithResponse(t, nil, []string{response}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					ethusdTicker: {
+						Value: big.NewFloat(2500.12345678),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+		{
+			name: "tuple output and inverted output with args",
+			tickers: []types.ProviderTicker{
+				ethusdPythTicker,
+				usdethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				price := struct {
+					Price       int64
+					Conf        uint64
+					Expo        int32
+					PublishTime *big.Int
+				}{
+					Price:       250012345678,
+					Conf:        1,
+					Expo:        -8,
+					PublishTime: big.NewInt(1),
+				}
+				rate, ok := new(big.Int).SetString("400000000000000000000", 10)
+				require.True(t, ok)
+
+				return createEVMClientWithResponse(t, nil, []string{
+					encodeOutputs(t, getPriceABI, "getPrice", price),
+					encodeOutputs(t, getRateABI, "getRate", rate),
+				}, []error{nil, nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					ethusdPythTicker: {
+						Value: big.NewFloat(2500.12345678),
+					},
+					usdethTicker: {
+						Value: big.NewFloat(0.0025),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := evmcall.NewPriceFetcherWithClient(logger, evmcall.DefaultETHAPIConfig, tc.client())
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Equal(t, len(tc.expected.Resolved), len(response.Resolved))
+			require.Equal(t, len(tc.expected.UnResolved), len(response.UnResolved))
+
+			for ticker, result := range tc.expected.Resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, result.Value.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+			}
+
+			for ticker := range tc.expected.UnResolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+}
+
+func encodeOutputs(t *testing.T, fragment, method string, values ...interface{}) string {
+	t.Helper()
+
+	if !strings.HasPrefix(fragment, "[") {
+		fragment = "[" + fragment + "]"
+	}
+
+	contract, err := abi.JSON(strings.NewReader(fragment))
+	require.NoError(t, err)
+
+	bz, err := contract.Methods[method].Outputs.Pack(values...)
+	require.NoError(t, err)
+
+	return hexutil.Encode(bz)
+}
+
+func createEVMClientWithResponse(
+	t *testing.T,
+	failedRequestErr error,
+	responses []string,
+	errs []error,
+) ethmulticlient.EVMClient {
+	t.Helper()
+
+	c := mocks.NewEVMClient(t)
+	if failedRequestErr != nil {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(failedRequestErr)
+	} else {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			elems, ok := args.Get(1).([]rpc.BatchElem)
+			require.True(t, ok)
+			// The last call reads the block at which the other calls are read, if any.
+			if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+				*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+				elems = elems[:n-1]
+			}
+			require.Equal(t, len(elems), len(responses))
+			require.Equal(t, len(elems), len(errs))
+
+			for i, elem := range elems {
+				elem.Result = &responses[i]
+				elem.Error = errs[i]
+				elems[i] = elem
+			}
+		})
+	}
+
+	return c
+}
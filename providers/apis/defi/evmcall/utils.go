@@ -0,0 +1,132 @@
+package evmcall
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+const (
+	// BaseName is the name of the EVM call API.
+	BaseName = "evmcall_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// OutputSeparator is the separator of the elements of an output path.
+	OutputSeparator = "."
+
+	// ETH_URL is the URL for the EVM call API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+
+	// BASE_URL is the URL for the EVM call API. This uses a free public RPC provider on Base Mainnet.
+	BASE_URL = "https://mainnet.base.org"
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+	constants.BASE:     strings.Join([]string{BaseName, constants.BASE}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// CallConfig is the configuration of a read-only contract call. This is specific to each pair of
+// tokens, and allows any view function that returns a price to be used as a price source.
+type CallConfig struct {
+	// Address is the address of the contract to call.
+	Address string `json:"address" schema:"address"`
+	// ABI is the ABI fragment of the contract. This must contain the method that is called, and
+	// can either be a single method definition or a list of definitions.
+	ABI json.RawMessage `json:"abi"`
+	// Method is the name of the view function to call.
+	Method string `json:"method"`
+	// Args are the arguments of the call, encoded as strings. Addresses and bytes are hex
+	// encoded, and integers are decimal or 0x prefixed hex encoded.
+	Args []string `json:"args,omitempty"`
+	// Output is the path of the price in the outputs of the call. Outputs and tuple fields are
+	// selected by name or index, and array elements by index, separated by dots, e.g. answer or
+	// 0.price. If unset, the first output is used.
+	Output string `json:"output,omitempty"`
+	// Decimals is the number of decimals of the selected value, i.e. the value is divided by
+	// 10^decimals.
+	Decimals int64 `json:"decimals" schema:"decimals"`
+	// Invert is true if the scaled value should be inverted, i.e. the contract reports the price
+	// of the quote in units of the base.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// ValidateBasic validates the call configuration.
+func (cc *CallConfig) ValidateBasic() error {
+	if !common.IsHexAddress(cc.Address) {
+		return fmt.Errorf("contract address is not a valid ethereum address")
+	}
+
+	if len(cc.Method) == 0 {
+		return fmt.Errorf("method cannot be empty")
+	}
+
+	if cc.Decimals < 0 {
+		return fmt.Errorf("decimals must be non-negative")
+	}
+
+	if _, err := NewCall(*cc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MustToJSON converts the call configuration to JSON.
+func (cc CallConfig) MustToJSON() string {
+	b, err := json.Marshal(cc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+var (
+	// DefaultETHAPIConfig is the default configuration for the EVM call API. Specifically this is for
+	// Ethereum mainnet.
+	DefaultETHAPIConfig = config.APIConfig{
+		Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+		Atomic:            true,
+		Enabled:           true,
+		Timeout:           1000 * time.Millisecond,
+		Interval:          2000 * time.Millisecond,
+		ReconnectTimeout:  2000 * time.Millisecond,
+		MaxQueries:        1,
+		Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+		MaxBlockHeightAge: 30 * time.Second,
+	}
+
+	// DefaultBaseAPIConfig is the default configuration for the EVM call API. Specifically this is for
+	// Base mainnet.
+	DefaultBaseAPIConfig = config.APIConfig{
+		Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.BASE),
+		Atomic:            true,
+		Enabled:           true,
+		Timeout:           1000 * time.Millisecond,
+		Interval:          2000 * time.Millisecond,
+		ReconnectTimeout:  2000 * time.Millisecond,
+		MaxQueries:        1,
+		Endpoints:         []config.Endpoint{{URL: BASE_URL}},
+		MaxBlockHeightAge: 30 * time.Second,
+	}
+)
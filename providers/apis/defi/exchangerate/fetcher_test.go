@@ -0,0 +1,241 @@
+package exchangerate_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/exchangerate"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+var (
+	logger, _ = zap.NewDevelopment()
+
+	// convertToAssetsCfg reads the rate of an ERC4626 vault share.
+	convertToAssetsCfg = exchangerate.FeedConfig{
+		Address:  "0x83F20F44975D03b1b09e64809B757c47f942BEeA",
+		Method:   "convertToAssets(uint256)",
+		Args:     []string{"1000000000000000000"},
+		Decimals: 18,
+	}
+
+	// Tickers used for testing.
+	cbethTicker = types.NewProviderTicker("CBETH/ETH", exchangerate.CBETHFeedConfig.MustToJSON())
+	swethTicker = types.NewProviderTicker("SWETH/ETH", exchangerate.SWETHFeedConfig.MustToJSON())
+)
+
+func TestFetch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tickers  []types.ProviderTicker
+		client   func() ethmulticlient.EVMClient
+		expected types.PriceResponse
+	}{
+		{
+			name: "fails to retrieve rate for an invalid ticker",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("CBETH/ETH", `{"address":"0x1234"}`),
+			},
+			client: func() ethmulticlient.EVMClient {
+				return mocks.NewEVMClient(t)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					types.NewProviderTicker("CBETH/ETH", `{"address":"0x1234"}`): {},
+				},
+			},
+		},
+		{
+			name: "fails to make a batch call",
+			tickers: []types.ProviderTicker{
+				cbethTicker,
+				swethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, fmt.Errorf("failed to make a batch call"), nil, nil)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					cbethTicker: {},
+					swethTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request has an error for a single ticker",
+			tickers: []types.ProviderTicker{
+				cbethTicker,
+				swethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(
+					t,
+					nil,
+					[]string{encodeRate(t, "1075000000000000000"), ""},
+					[]error{nil, fmt.Errorf("execution reverted")},
+				)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					cbethTicker: {
+						Value: big.NewFloat(1.075),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					swethTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request returns a result that cannot be parsed",
+			tickers: []types.ProviderTicker{
+				cbethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{"0x"}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					cbethTicker: {},
+				},
+			},
+		},
+		{
+			name: "multiple tokens",
+			tickers: []types.ProviderTicker{
+				cbethTicker,
+				swethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(
+					t,
+					nil,
+					[]string{encodeRate(t, "1075000000000000000"), encodeRate(t, "1062500000000000000")},
+					[]error{nil, nil},
+				)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					cbethTicker: {
+						Value: big.NewFloat(1.075),
+					},
+					swethTicker: {
+						Value: big.NewFloat(1.0625),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := exchangerate.NewPriceFetcherWithClient(logger, exchangerate.DefaultETHAPIConfig, tc.client())
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Equal(t, len(tc.expected.Resolved), len(response.Resolved))
+			require.Equal(t, len(tc.expected.UnResolved), len(response.UnResolved))
+
+			for ticker, result := range tc.expected.Resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, result.Value.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+			}
+
+			for ticker := range tc.expected.UnResolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+}
+
+func TestFetchCallsConfiguredContract(t *testing.T) {
+	response := encodeRate(t, "1075000000000000000")
+
+	client := mocks.NewEVMClient(t)
+	client.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+		require.Len(t, elems, 2)
+		require.Equal(t, "eth_getBlockByNumber", elems[1].Method)
+
+		call, ok := elems[0].Args[0].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, common.HexToAddress(convertToAssetsCfg.Address), call["to"])
+		require.Equal(
+			t,
+			"0x07a2d13a0000000000000000000000000000000000000000000000000de0b6b3a7640000",
+			call["data"].(hexutil.Bytes).String(),
+		)
+
+		elems[0].Result = &response
+	})
+
+	fetcher, err := exchangerate.NewPriceFetcherWithClient(logger, exchangerate.DefaultETHAPIConfig, client)
+	require.NoError(t, err)
+
+	ticker := types.NewProviderTicker("SDAI/DAI", convertToAssetsCfg.MustToJSON())
+	resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{ticker})
+	require.Len(t, resp.Resolved, 1)
+}
+
+func encodeRate(t *testing.T, value string) string {
+	t.Helper()
+
+	v, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok)
+
+	return hexutil.Encode(common.LeftPadBytes(v.Bytes(), 32))
+}
+
+func createEVMClientWithResponse(
+	t *testing.T,
+	failedRequestErr error,
+	responses []string,
+	errs []error,
+) ethmulticlient.EVMClient {
+	t.Helper()
+
+	c := mocks.NewEVMClient(t)
+	if failedRequestErr != nil {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(failedRequestErr)
+	} else {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			elems, ok := args.Get(1).([]rpc.BatchElem)
+			require.True(t, ok)
+			// The last call reads the block at which the other calls are read, if any.
+			if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+				*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+				elems = elems[:n-1]
+			}
+			require.Equal(t, len(elems), len(responses))
+			require.Equal(t, len(elems), len(errs))
+
+			for i, elem := range elems {
+				elem.Result = &responses[i]
+				elem.Error = errs[i]
+				elems[i] = elem
+			}
+		})
+	}
+
+	return c
+}
@@ -0,0 +1,117 @@
+package exchangerate
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/skip-mev/connect/v2/pkg/math"
+)
+
+// wordSize is the size of a word in the EVM ABI encoding.
+const wordSize = 32
+
+// signatureRegex matches a method signature, e.g. convertToAssets(uint256), capturing the
+// parameter list.
+var signatureRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*\(([A-Za-z0-9,]*)\)$`)
+
+// Rate is a parsed exchange rate feed, which contains all required information to query and
+// scale the rate of a token.
+type Rate struct {
+	// Address is the address of the contract that reports the rate.
+	Address common.Address
+	// Payload is the packed call of the rate method.
+	Payload []byte
+	// Decimals is the number of decimals of the rate.
+	Decimals int64
+	// Invert is true if the scaled rate should be inverted.
+	Invert bool
+}
+
+// NewRate parses the method signature and arguments of the feed configuration and packs the call.
+func NewRate(cfg FeedConfig) (Rate, error) {
+	matches := signatureRegex.FindStringSubmatch(cfg.Method)
+	if matches == nil {
+		return Rate{}, fmt.Errorf("invalid method signature %s", cfg.Method)
+	}
+
+	var params []string
+	if len(matches[1]) > 0 {
+		params = strings.Split(matches[1], ",")
+	}
+
+	if len(params) != len(cfg.Args) {
+		return Rate{}, fmt.Errorf("method %s expects %d args, got %d", cfg.Method, len(params), len(cfg.Args))
+	}
+
+	// The payload is the 4 byte selector of the method followed by each argument as a word.
+	payload := crypto.Keccak256([]byte(cfg.Method))[:4]
+	for i, param := range params {
+		if param != ArgType {
+			return Rate{}, fmt.Errorf("unsupported arg type %s; only %s is supported", param, ArgType)
+		}
+
+		arg, err := parseArg(cfg.Args[i])
+		if err != nil {
+			return Rate{}, fmt.Errorf("invalid arg %d: %w", i, err)
+		}
+
+		payload = append(payload, common.LeftPadBytes(arg.Bytes(), wordSize)...)
+	}
+
+	return Rate{
+		Address:  common.HexToAddress(cfg.Address),
+		Payload:  payload,
+		Decimals: cfg.Decimals,
+		Invert:   cfg.Invert,
+	}, nil
+}
+
+// ParseResult parses the hex encoded result of the rate call. The rate is the first word of the
+// result, and must be positive.
+func (r Rate) ParseResult(result string) (*big.Float, error) {
+	bz, err := hexutil.Decode(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	if len(bz) < wordSize {
+		return nil, fmt.Errorf("expected result of at least %d bytes, got %d", wordSize, len(bz))
+	}
+
+	rate := new(big.Int).SetBytes(bz[:wordSize])
+	if rate.Sign() == 0 {
+		return nil, fmt.Errorf("exchange rate must be positive")
+	}
+
+	scaled, err := math.NewPrice(rate, -r.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	price := scaled.BigFloat()
+	if r.Invert {
+		price = new(big.Float).Quo(big.NewFloat(1), price)
+	}
+
+	return price, nil
+}
+
+// parseArg parses a decimal or 0x prefixed hex encoded uint256.
+func parseArg(arg string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(arg, 0)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse %s as an integer", arg)
+	}
+
+	if v.Sign() < 0 || v.BitLen() > wordSize*8 {
+		return nil, fmt.Errorf("%s is out of range for %s", arg, ArgType)
+	}
+
+	return v, nil
+}
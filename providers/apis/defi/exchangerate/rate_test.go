@@ -0,0 +1,234 @@
+package exchangerate_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/exchangerate"
+)
+
+func TestFeedConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  func() exchangerate.FeedConfig
+		err  bool
+	}{
+		{
+			name: "cbeth preset",
+			cfg: func() exchangerate.FeedConfig {
+				return exchangerate.CBETHFeedConfig
+			},
+			err: false,
+		},
+		{
+			name: "sfrxeth preset",
+			cfg: func() exchangerate.FeedConfig {
+				return exchangerate.SFRXETHFeedConfig
+			},
+			err: false,
+		},
+		{
+			name: "sweth preset",
+			cfg: func() exchangerate.FeedConfig {
+				return exchangerate.SWETHFeedConfig
+			},
+			err: false,
+		},
+		{
+			name: "ezeth preset",
+			cfg: func() exchangerate.FeedConfig {
+				return exchangerate.EZETHFeedConfig
+			},
+			err: false,
+		},
+		{
+			name: "valid config with args",
+			cfg: func() exchangerate.FeedConfig {
+				return convertToAssetsCfg
+			},
+			err: false,
+		},
+		{
+			name: "invalid address",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := exchangerate.CBETHFeedConfig
+				cfg.Address = "0x1234"
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "negative decimals",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := exchangerate.CBETHFeedConfig
+				cfg.Decimals = -1
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "method without parentheses",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := exchangerate.CBETHFeedConfig
+				cfg.Method = "exchangeRate"
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "method with spaces",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := convertToAssetsCfg
+				cfg.Method = "convertToAssets(uint256 shares)"
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "unsupported arg type",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := convertToAssetsCfg
+				cfg.Method = "convertToAssets(address)"
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "missing args",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := convertToAssetsCfg
+				cfg.Args = nil
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "too many args",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := exchangerate.CBETHFeedConfig
+				cfg.Args = []string{"1"}
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "negative arg",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := convertToAssetsCfg
+				cfg.Args = []string{"-1"}
+				return cfg
+			},
+			err: true,
+		},
+		{
+			name: "arg is not an integer",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := convertToAssetsCfg
+				cfg.Args = []string{"1.5"}
+				return cfg
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg()
+			err := cfg.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewRate(t *testing.T) {
+	t.Run("packs the selector of a method without args", func(t *testing.T) {
+		rate, err := exchangerate.NewRate(exchangerate.CBETHFeedConfig)
+		require.NoError(t, err)
+		require.Equal(t, crypto.Keccak256([]byte("exchangeRate()"))[:4], rate.Payload)
+	})
+
+	t.Run("packs the selector and args of a method with args", func(t *testing.T) {
+		rate, err := exchangerate.NewRate(convertToAssetsCfg)
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			"0x07a2d13a0000000000000000000000000000000000000000000000000de0b6b3a7640000",
+			hexutil.Encode(rate.Payload),
+		)
+	})
+}
+
+func TestParseResult(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      exchangerate.FeedConfig
+		result   string
+		expected *big.Float
+		err      bool
+	}{
+		{
+			name:     "scales the rate",
+			cfg:      exchangerate.CBETHFeedConfig,
+			result:   encodeRate(t, "1075000000000000000"),
+			expected: big.NewFloat(1.075),
+		},
+		{
+			name: "inverts the rate",
+			cfg: func() exchangerate.FeedConfig {
+				cfg := exchangerate.CBETHFeedConfig
+				cfg.Invert = true
+				return cfg
+			}(),
+			result:   encodeRate(t, "1250000000000000000"),
+			expected: big.NewFloat(0.8),
+		},
+		{
+			name:     "ignores trailing outputs",
+			cfg:      exchangerate.CBETHFeedConfig,
+			result:   encodeRate(t, "2000000000000000000") + encodeRate(t, "1")[2:],
+			expected: big.NewFloat(2),
+		},
+		{
+			name:   "zero rate",
+			cfg:    exchangerate.CBETHFeedConfig,
+			result: encodeRate(t, "0"),
+			err:    true,
+		},
+		{
+			name:   "short result",
+			cfg:    exchangerate.CBETHFeedConfig,
+			result: "0x01",
+			err:    true,
+		},
+		{
+			name:   "invalid hex",
+			cfg:    exchangerate.CBETHFeedConfig,
+			result: "not a valid result",
+			err:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rate, err := exchangerate.NewRate(tc.cfg)
+			require.NoError(t, err)
+
+			price, err := rate.ParseResult(tc.result)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.SetPrec(40), price.SetPrec(40))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package exchangerate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+const (
+	// BaseName is the name of the exchange rate API.
+	BaseName = "exchangerate_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// ArgType is the only supported type of the arguments of a rate method.
+	ArgType = "uint256"
+
+	// ETH_URL is the URL for the exchange rate API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedConfig is the configuration of the exchange rate of a liquid staking or restaking token.
+// This is specific to each token, and allows any view function that returns the amount of the
+// underlying asset backing one token to be used as a rate source.
+type FeedConfig struct {
+	// Address is the address of the contract that reports the rate.
+	Address string `json:"address" schema:"address"`
+	// Method is the signature of the view function that returns the rate, e.g. exchangeRate()
+	// or convertToAssets(uint256). The method must return a uint256 as its first output, and
+	// its arguments, if any, must be uint256.
+	Method string `json:"method"`
+	// Args are the arguments of the call, encoded as decimal or 0x prefixed hex strings.
+	Args []string `json:"args,omitempty"`
+	// Decimals is the number of decimals of the rate, i.e. the rate is divided by 10^decimals.
+	Decimals int64 `json:"decimals" schema:"decimals"`
+	// Invert is true if the scaled rate should be inverted, i.e. the contract reports the amount
+	// of the token that one unit of the underlying asset is worth.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if !common.IsHexAddress(fc.Address) {
+		return fmt.Errorf("rate contract address is not a valid ethereum address")
+	}
+
+	if fc.Decimals < 0 {
+		return fmt.Errorf("decimals must be non-negative")
+	}
+
+	if _, err := NewRate(*fc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+var (
+	// CBETHFeedConfig prices cbETH in ETH via exchangeRate() on the cbETH contract.
+	CBETHFeedConfig = FeedConfig{
+		Address:  "0xBe9895146f7AF43049ca1c1AE358B0541Ea49704",
+		Method:   "exchangeRate()",
+		Decimals: 18,
+	}
+
+	// SFRXETHFeedConfig prices sfrxETH in frxETH via pricePerShare() on the sfrxETH vault.
+	SFRXETHFeedConfig = FeedConfig{
+		Address:  "0xac3E018457B222d93114458476f3E3416Abbe38F",
+		Method:   "pricePerShare()",
+		Decimals: 18,
+	}
+
+	// SWETHFeedConfig prices swETH in ETH via getRate() on the swETH contract.
+	SWETHFeedConfig = FeedConfig{
+		Address:  "0xf951E335afb289353dc249e82926178EaC7DEd78",
+		Method:   "getRate()",
+		Decimals: 18,
+	}
+
+	// EZETHFeedConfig prices ezETH in ETH via getRate() on the Renzo ezETH rate provider.
+	EZETHFeedConfig = FeedConfig{
+		Address:  "0x387dBc0fB00b26fb085aa658527D5BE98302c84C",
+		Method:   "getRate()",
+		Decimals: 18,
+	}
+)
+
+// DefaultETHAPIConfig is the default configuration for the exchange rate API. Specifically this
+// is for Ethereum mainnet.
+var DefaultETHAPIConfig = config.APIConfig{
+	Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+	Atomic:            true,
+	Enabled:           true,
+	Timeout:           1000 * time.Millisecond,
+	Interval:          2000 * time.Millisecond,
+	ReconnectTimeout:  2000 * time.Millisecond,
+	MaxQueries:        1,
+	Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+	MaxBlockHeightAge: 30 * time.Second,
+}
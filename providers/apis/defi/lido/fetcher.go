@@ -0,0 +1,375 @@
+package lido
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// one is one whole unit (1e18) of wstETH, stETH, stETH shares or ETH.
+var one = new(big.Int).Exp(big.NewInt(10), big.NewInt(Decimals), nil)
+
+// PriceFetcher is the Lido price fetcher. This fetcher is responsible for reading the wstETH:ETH
+// and stETH:ETH exchange rates from the wstETH and stETH contracts, such that liquid staking
+// token redemption rates are derived from the protocol's own accounting rather than from
+// exchange order books.
+//
+// To read more about the contracts, see the Lido documentation
+// https://docs.lido.fi/contracts/wsteth and https://docs.lido.fi/contracts/lido.
+//
+// Similar to the Chainlink fetcher, we utilize the eth client's BatchCallContext to batch the calls
+// to the ethereum network.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the EVM client implementation. This is used to interact with the ethereum network.
+	client ethmulticlient.EVMClient
+	// abi is the wstETH and stETH abi. This is used to pack the calls to the contracts and parse
+	// the results.
+	abi abi.ABI
+	// payloads are the packed calls to the contracts for each rate. Since the payloads are the
+	// same for all feeds of a rate, we can reuse them for all feeds.
+	payloads map[string][]call
+
+	mtx sync.Mutex
+	// feedCache is a cache of the tickers to feed configs. This is used to avoid unmarshalling
+	// the metadata for each ticker.
+	feedCache map[types.ProviderTicker]FeedConfig
+}
+
+// call is a packed call to a contract method.
+type call struct {
+	method string
+	data   []byte
+}
+
+// NewPriceFetcher returns a new Lido price fetcher.
+func NewPriceFetcher(
+	ctx context.Context,
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("api metrics is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if !IsValidProviderName(api.Name) {
+		return nil, fmt.Errorf("invalid api config name %s", api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	client, err := ethmulticlient.NewClientFromEndpoints(
+		ctx,
+		logger,
+		api,
+		apiMetrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client ethmulticlient.EVMClient,
+) (*PriceFetcher, error) {
+	contractABI, err := abi.JSON(strings.NewReader(ContractABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lido abi: %w", err)
+	}
+
+	stEthPerToken, err := contractABI.Pack(StEthPerTokenMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", StEthPerTokenMethod, err)
+	}
+
+	getPooledEthByShares, err := contractABI.Pack(GetPooledEthBySharesMethod, one)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", GetPooledEthBySharesMethod, err)
+	}
+
+	getSharesByPooledEth, err := contractABI.Pack(GetSharesByPooledEthMethod, one)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", GetSharesByPooledEthMethod, err)
+	}
+
+	return &PriceFetcher{
+		logger: logger.With(zap.String("fetcher", api.Name)),
+		api:    api,
+		client: client,
+		abi:    contractABI,
+		payloads: map[string][]call{
+			RateWstETH: {
+				{method: StEthPerTokenMethod, data: stEthPerToken},
+			},
+			RateStETH: {
+				{method: GetPooledEthBySharesMethod, data: getPooledEthByShares},
+				{method: GetSharesByPooledEthMethod, data: getSharesByPooledEth},
+			},
+		},
+		feedCache: make(map[types.ProviderTicker]FeedConfig),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. This fetch utilizes the batch call to lower
+// overhead of making individual RPC calls for each ticker. The wstETH:ETH rate is the amount of
+// stETH backing one wstETH, which is redeemable 1:1 for ETH. The stETH:ETH rate is the amount of
+// ETH backing the shares that one ETH worth of stETH is minted as.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+	)
+
+	// Create the batch elements for each ticker and feed. Each ticker's calls are contiguous,
+	// starting at the ticker's offset.
+	var (
+		batchElems = make([]rpc.BatchElem, 0, len(tickers))
+		offsets    = make([]int, len(tickers))
+		feeds      = make([]FeedConfig, len(tickers))
+	)
+	for i, ticker := range tickers {
+		feed, err := f.GetFeed(ticker)
+		if err != nil {
+			f.logger.Debug(
+				"failed to get feed for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to get feed: %w", err),
+					providertypes.ErrorFailedToDecode,
+				),
+			)
+		}
+
+		offsets[i] = len(batchElems)
+		feeds[i] = feed
+		for _, c := range f.payloads[feed.Rate] {
+			var result string
+			batchElems = append(batchElems, rpc.BatchElem{
+				Method: "eth_call",
+				Args: []interface{}{
+					map[string]interface{}{
+						"to":   common.HexToAddress(feed.GetAddress()),
+						"data": hexutil.Bytes(c.data),
+					},
+					f.api.GetBlockTag(), // the configured block tag, latest by default.
+				},
+				Result: &result,
+			})
+		}
+	}
+
+	// Batch call to the EVM.
+	block, err := ethmulticlient.BatchCallWithBlock(ctx, f.client, f.api.GetBlockTag(), batchElems)
+	if err != nil {
+		f.logger.Debug(
+			"failed to batch call to ethereum network for all tickers",
+			zap.Error(err),
+		)
+
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+		)
+	}
+
+	// Parse the results from the batch call for each ticker.
+	now := time.Now().UTC()
+	for i, ticker := range tickers {
+		var (
+			calls   = f.payloads[feeds[i].Rate]
+			values  = make([]*big.Int, len(calls))
+			failure *providertypes.ErrorWithCode
+		)
+		for j, c := range calls {
+			result := batchElems[offsets[i]+j]
+			if result.Error != nil {
+				err := providertypes.NewErrorWithCode(
+					result.Error,
+					ethmulticlient.ErrorCodeFromError(result.Error, providertypes.ErrorUnknown),
+				)
+				failure = &err
+				break
+			}
+
+			value, err := f.ParseUint(c.method, result.Result)
+			if err != nil {
+				err := providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice)
+				failure = &err
+				break
+			}
+			values[j] = value
+		}
+
+		if failure != nil {
+			f.logger.Debug(
+				"failed to read exchange rate",
+				zap.String("ticker", ticker.String()),
+				zap.Error(failure),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: *failure,
+			}
+
+			continue
+		}
+
+		price, err := CalculateRate(feeds[i].Rate, values)
+		if err != nil {
+			f.logger.Debug(
+				"invalid exchange rate",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+			}
+
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResultWithBlock(price, now, block)
+	}
+
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// GetFeed returns the Lido feed for the given ticker. This will unmarshal the metadata and
+// validate the feed config which contains all required information to query the EVM.
+func (f *PriceFetcher) GetFeed(
+	ticker types.ProviderTicker,
+) (FeedConfig, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if feed, ok := f.feedCache[ticker]; ok {
+		return feed, nil
+	}
+
+	var cfg FeedConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal feed config on ticker: %w", err)
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid ticker feed config: %w", err)
+	}
+
+	f.feedCache[ticker] = cfg
+	return cfg, nil
+}
+
+// ParseUint parses the uint256 returned by the given method from the result of the batch call.
+func (f *PriceFetcher) ParseUint(
+	method string,
+	result interface{},
+) (*big.Int, error) {
+	r, ok := result.(*string)
+	if !ok {
+		return nil, fmt.Errorf("expected result to be a string, got %T", result)
+	}
+
+	if r == nil {
+		return nil, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := f.abi.Methods[method].Outputs.UnpackValues(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+// CalculateRate returns the exchange rate from the values returned by the calls of the rate.
+// The wstETH rate is the stETH per wstETH. The stETH rate is the ETH per share multiplied by
+// the shares per ETH, which is 1 unless the protocol's accounting is inconsistent.
+func CalculateRate(
+	rate string,
+	values []*big.Int,
+) (*big.Float, error) {
+	for _, v := range values {
+		if v == nil || v.Sign() <= 0 {
+			return nil, fmt.Errorf("exchange rate must be positive")
+		}
+	}
+
+	var (
+		price math.Price
+		err   error
+	)
+	switch {
+	case rate == RateWstETH && len(values) == 1:
+		price, err = math.NewPrice(values[0], -Decimals)
+	case rate == RateStETH && len(values) == 2:
+		product := new(big.Int).Mul(values[0], values[1])
+		price, err = math.NewPrice(product, -2*Decimals)
+	default:
+		return nil, fmt.Errorf("unexpected %d values for rate %s", len(values), rate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
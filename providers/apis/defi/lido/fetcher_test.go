@@ -0,0 +1,280 @@
+package lido_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/lido"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+var (
+	logger, _ = zap.NewDevelopment()
+
+	// Tickers used for testing.
+	wstethTicker = types.NewProviderTicker("WSTETH/ETH", lido.FeedConfig{Rate: lido.RateWstETH}.MustToJSON())
+	stethTicker  = types.NewProviderTicker("STETH/ETH", lido.FeedConfig{Rate: lido.RateStETH}.MustToJSON())
+)
+
+func TestFetch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tickers  []types.ProviderTicker
+		client   func() ethmulticlient.EVMClient
+		expected types.PriceResponse
+	}{
+		{
+			name: "fails to retrieve feed for an empty ticker",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("WSTETH/ETH", ""),
+			},
+			client: func() ethmulticlient.EVMClient {
+				return mocks.NewEVMClient(t)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					types.NewProviderTicker("WSTETH/ETH", ""): {},
+				},
+			},
+		},
+		{
+			name: "fails to make a batch call",
+			tickers: []types.ProviderTicker{
+				wstethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, fmt.Errorf("failed to make a batch call"), nil, nil)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					wstethTicker: {},
+				},
+			},
+		},
+		{
+			name: "one of the calls of a rate fails",
+			tickers: []types.ProviderTicker{
+				wstethTicker,
+				stethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(
+					t,
+					nil,
+					[]string{encodeUint(t, "1180000000000000000"), encodeUint(t, "1180000000000000000"), ""},
+					[]error{nil, nil, fmt.Errorf("execution reverted")},
+				)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					wstethTicker: {
+						Value: big.NewFloat(1.18),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					stethTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request returns a result that cannot be parsed",
+			tickers: []types.ProviderTicker{
+				wstethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{"not a valid result"}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					wstethTicker: {},
+				},
+			},
+		},
+		{
+			name: "zero exchange rate",
+			tickers: []types.ProviderTicker{
+				wstethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{encodeUint(t, "0")}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					wstethTicker: {},
+				},
+			},
+		},
+		{
+			name: "wsteth and steth rates",
+			tickers: []types.ProviderTicker{
+				wstethTicker,
+				stethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(
+					t,
+					nil,
+					[]string{
+						encodeUint(t, "1250000000000000000"), // stEthPerToken()
+						encodeUint(t, "1250000000000000000"), // getPooledEthByShares(1e18)
+						encodeUint(t, "800000000000000000"),  // getSharesByPooledEth(1e18)
+					},
+					[]error{nil, nil, nil},
+				)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					wstethTicker: {
+						Value: big.NewFloat(1.25),
+					},
+					stethTicker: {
+						Value: big.NewFloat(1),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := lido.NewPriceFetcherWithClient(logger, lido.DefaultETHAPIConfig, tc.client())
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Equal(t, len(tc.expected.Resolved), len(response.Resolved))
+			require.Equal(t, len(tc.expected.UnResolved), len(response.UnResolved))
+
+			for ticker, result := range tc.expected.Resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, result.Value.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+			}
+
+			for ticker := range tc.expected.UnResolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+}
+
+func TestFetchCalls(t *testing.T) {
+	client := mocks.NewEVMClient(t)
+	client.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+		require.Len(t, elems, 4)
+		require.Equal(t, "eth_getBlockByNumber", elems[3].Method)
+
+		// The wstETH rate is read from the wstETH contract and the stETH rate from the stETH
+		// contract.
+		expected := []string{lido.WstETHAddress, lido.StETHAddress, lido.StETHAddress}
+		for i, elem := range elems[:3] {
+			call, ok := elem.Args[0].(map[string]interface{})
+			require.True(t, ok)
+			require.Equal(t, common.HexToAddress(expected[i]), call["to"])
+		}
+	})
+
+	fetcher, err := lido.NewPriceFetcherWithClient(logger, lido.DefaultETHAPIConfig, client)
+	require.NoError(t, err)
+
+	fetcher.Fetch(context.Background(), []types.ProviderTicker{wstethTicker, stethTicker})
+}
+
+func TestFeedConfigValidateBasic(t *testing.T) {
+	t.Run("valid configs", func(t *testing.T) {
+		cfg := lido.FeedConfig{Rate: lido.RateWstETH}
+		require.NoError(t, cfg.ValidateBasic())
+		require.Equal(t, lido.WstETHAddress, cfg.GetAddress())
+
+		cfg = lido.FeedConfig{Rate: lido.RateStETH}
+		require.NoError(t, cfg.ValidateBasic())
+		require.Equal(t, lido.StETHAddress, cfg.GetAddress())
+	})
+
+	t.Run("address override", func(t *testing.T) {
+		cfg := lido.FeedConfig{Rate: lido.RateWstETH, Address: "0xB82381A3fBD3FaFA77B3a7bE693342618240067b"}
+		require.NoError(t, cfg.ValidateBasic())
+		require.Equal(t, cfg.Address, cfg.GetAddress())
+	})
+
+	t.Run("unknown rate", func(t *testing.T) {
+		cfg := lido.FeedConfig{Rate: "reth"}
+		require.Error(t, cfg.ValidateBasic())
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		cfg := lido.FeedConfig{Rate: lido.RateStETH, Address: "0x1234"}
+		require.Error(t, cfg.ValidateBasic())
+	})
+}
+
+func encodeUint(t *testing.T, value string) string {
+	t.Helper()
+
+	contractABI, err := abi.JSON(strings.NewReader(lido.ContractABI))
+	require.NoError(t, err)
+
+	v, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok)
+
+	bz, err := contractABI.Methods[lido.StEthPerTokenMethod].Outputs.Pack(v)
+	require.NoError(t, err)
+
+	return hexutil.Encode(bz)
+}
+
+func createEVMClientWithResponse(
+	t *testing.T,
+	failedRequestErr error,
+	responses []string,
+	errs []error,
+) ethmulticlient.EVMClient {
+	t.Helper()
+
+	c := mocks.NewEVMClient(t)
+	if failedRequestErr != nil {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(failedRequestErr)
+	} else {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			elems, ok := args.Get(1).([]rpc.BatchElem)
+			require.True(t, ok)
+			// The last call reads the block at which the other calls are read, if any.
+			if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+				*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+				elems = elems[:n-1]
+			}
+			require.Equal(t, len(elems), len(responses))
+			require.Equal(t, len(elems), len(errs))
+
+			for i, elem := range elems {
+				elem.Result = &responses[i]
+				elem.Error = errs[i]
+				elems[i] = elem
+			}
+		})
+	}
+
+	return c
+}
@@ -0,0 +1,136 @@
+package lido
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+const (
+	// BaseName is the name of the Lido API.
+	BaseName = "lido_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// StEthPerTokenMethod is the wstETH method that returns the amount of stETH backing one wstETH.
+	StEthPerTokenMethod = "stEthPerToken"
+
+	// GetPooledEthBySharesMethod is the stETH method that returns the amount of ETH backing an
+	// amount of stETH shares.
+	GetPooledEthBySharesMethod = "getPooledEthByShares"
+
+	// GetSharesByPooledEthMethod is the stETH method that returns the amount of stETH shares an
+	// amount of ETH is worth.
+	GetSharesByPooledEthMethod = "getSharesByPooledEth"
+
+	// ContractABI is the ABI of the wstETH and stETH methods used to derive the exchange rates.
+	ContractABI = `[
+		{"inputs":[],"name":"stEthPerToken","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"internalType":"uint256","name":"_sharesAmount","type":"uint256"}],"name":"getPooledEthByShares","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"internalType":"uint256","name":"_ethAmount","type":"uint256"}],"name":"getSharesByPooledEth","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+	]`
+
+	// Decimals is the number of decimals of wstETH, stETH, stETH shares and ETH.
+	Decimals = 18
+
+	// RateWstETH is the wstETH:ETH exchange rate, read from stEthPerToken() on the wstETH
+	// contract.
+	RateWstETH = "wsteth"
+
+	// RateStETH is the stETH:ETH exchange rate, derived from getPooledEthByShares() and
+	// getSharesByPooledEth() on the stETH contract.
+	RateStETH = "steth"
+
+	// WstETHAddress is the address of the wstETH contract on Ethereum Mainnet.
+	WstETHAddress = "0x7f39C581F595B53c5cb19bD0b3f8dA6c935E2Ca0"
+
+	// StETHAddress is the address of the stETH (Lido) contract on Ethereum Mainnet.
+	StETHAddress = "0xae7ab96520DE3A18E5e111B5EaAb095312D7fE84"
+
+	// ETH_URL is the URL for the Lido API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedConfig is the configuration for a Lido exchange rate. This is specific to each pair of
+// tokens.
+type FeedConfig struct {
+	// Rate is the exchange rate to read. This must be one of wsteth, which prices wstETH in ETH,
+	// or steth, which prices stETH in ETH.
+	Rate string `json:"rate"`
+	// Address is the address of the contract the rate is read from. If unset, the wstETH or
+	// stETH contract on Ethereum Mainnet is used.
+	Address string `json:"address" schema:"address"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	switch fc.Rate {
+	case RateWstETH, RateStETH:
+	default:
+		return fmt.Errorf("unknown rate: %s", fc.Rate)
+	}
+
+	if fc.Address != "" && !common.IsHexAddress(fc.Address) {
+		return fmt.Errorf("contract address is not a valid ethereum address")
+	}
+
+	return nil
+}
+
+// GetAddress returns the address of the contract the rate is read from.
+func (fc *FeedConfig) GetAddress() string {
+	switch {
+	case fc.Address != "":
+		return fc.Address
+	case fc.Rate == RateWstETH:
+		return WstETHAddress
+	default:
+		return StETHAddress
+	}
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// DefaultETHAPIConfig is the default configuration for the Lido API. Specifically this is for
+// Ethereum mainnet.
+var DefaultETHAPIConfig = config.APIConfig{
+	Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+	Atomic:            true,
+	Enabled:           true,
+	Timeout:           1000 * time.Millisecond,
+	Interval:          2000 * time.Millisecond,
+	ReconnectTimeout:  2000 * time.Millisecond,
+	MaxQueries:        1,
+	Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+	MaxBlockHeightAge: 30 * time.Second,
+}
@@ -34,6 +34,16 @@ type Client interface {
 		baseAsset,
 		quoteAsset string,
 	) (WrappedSpotPriceResponse, error)
+
+	// ArithmeticTWAP returns the arithmetic time-weighted average price of the pool from the
+	// given start time until now. The TWAP is returned as the spot price of the response so
+	// that responses can be filtered by block height in the same way as spot prices.
+	ArithmeticTWAP(ctx context.Context,
+		poolID uint64,
+		baseAsset,
+		quoteAsset string,
+		startTime time.Time,
+	) (WrappedSpotPriceResponse, error)
 }
 
 // ClientImpl is an implementation of a client to Osmosis using a
@@ -44,6 +54,9 @@ type ClientImpl struct {
 	redactedURL string
 	endpoint    config.Endpoint
 	httpClient  *http.Client
+	// inFlight limits the number of requests the provider has in flight. This is shared by all
+	// clients of the provider, and is nil if the provider does not limit its requests in flight.
+	inFlight *http.InFlightLimiter
 }
 
 func NewClient(
@@ -75,24 +88,70 @@ func NewClient(
 		redactedURL: redactedURL,
 		endpoint:    endpoint,
 		httpClient:  http.NewClient(),
+		inFlight:    http.SharedInFlightLimiter(api.Name, api.MaxInFlight),
 	}, nil
 }
 
 // SpotPrice uses the underlying x/poolmanager client to access spot prices.
 func (c *ClientImpl) SpotPrice(ctx context.Context, poolID uint64, baseAsset, quoteAsset string) (WrappedSpotPriceResponse, error) {
+	url, err := CreateURL(c.endpoint.URL, poolID, baseAsset, quoteAsset)
+	if err != nil {
+		return WrappedSpotPriceResponse{}, err
+	}
+
+	var spotPriceResponse SpotPriceResponse
+	blockHeight, err := c.get(ctx, url, &spotPriceResponse)
+	if err != nil {
+		return WrappedSpotPriceResponse{}, err
+	}
+
+	return WrappedSpotPriceResponse{
+		SpotPriceResponse: spotPriceResponse,
+		BlockHeight:       blockHeight,
+	}, nil
+}
+
+// ArithmeticTWAP uses the underlying x/twap client to access arithmetic time-weighted average
+// prices.
+func (c *ClientImpl) ArithmeticTWAP(
+	ctx context.Context,
+	poolID uint64,
+	baseAsset, quoteAsset string,
+	startTime time.Time,
+) (WrappedSpotPriceResponse, error) {
+	url, err := CreateTWAPURL(c.endpoint.URL, poolID, baseAsset, quoteAsset, startTime)
+	if err != nil {
+		return WrappedSpotPriceResponse{}, err
+	}
+
+	var twapResponse TWAPResponse
+	blockHeight, err := c.get(ctx, url, &twapResponse)
+	if err != nil {
+		return WrappedSpotPriceResponse{}, err
+	}
+
+	return WrappedSpotPriceResponse{
+		SpotPriceResponse: SpotPriceResponse{SpotPrice: twapResponse.ArithmeticTWAP},
+		BlockHeight:       blockHeight,
+	}, nil
+}
+
+// get queries the given url, decodes the response into out and returns the block height
+// of the response.
+func (c *ClientImpl) get(ctx context.Context, url string, out interface{}) (uint64, error) {
 	start := time.Now()
 	defer func() {
 		c.apiMetrics.ObserveProviderResponseLatency(c.api.Name, c.redactedURL, time.Since(start))
 	}()
 
-	url, err := CreateURL(c.endpoint.URL, poolID, baseAsset, quoteAsset)
-	if err != nil {
-		return WrappedSpotPriceResponse{}, err
+	if err := c.inFlight.Acquire(ctx); err != nil {
+		return 0, fmt.Errorf("too many requests in flight: %w", err)
 	}
+	defer c.inFlight.Release()
 
 	resp, err := c.httpClient.GetWithContext(ctx, url)
 	if err != nil {
-		return WrappedSpotPriceResponse{}, err
+		return 0, err
 	}
 
 	c.apiMetrics.AddHTTPStatusCode(c.api.Name, resp)
@@ -102,20 +161,15 @@ func (c *ClientImpl) SpotPrice(ctx context.Context, poolID uint64, baseAsset, qu
 	if heightStr != "" {
 		blockHeight, err = strconv.ParseUint(heightStr, 10, 64)
 		if err != nil {
-			return WrappedSpotPriceResponse{}, fmt.Errorf("failed to parse block height: %w", err)
+			return 0, fmt.Errorf("failed to parse block height: %w", err)
 		}
 	}
 
-	var spotPriceResponse SpotPriceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&spotPriceResponse); err != nil {
-		return WrappedSpotPriceResponse{}, err
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return 0, err
 	}
 
-	c.apiMetrics.AddHTTPStatusCode(c.api.Name, resp)
-	return WrappedSpotPriceResponse{
-		SpotPriceResponse: spotPriceResponse,
-		BlockHeight:       blockHeight,
-	}, nil
+	return blockHeight, nil
 }
 
 // MultiClientImpl is an Osmosis client that wraps a set of multiple Clients.
@@ -205,6 +259,31 @@ func NewMultiClientFromEndpoints(
 // SpotPrice delegates the request to all underlying clients and applies a filter to the
 // set of responses.
 func (mc *MultiClientImpl) SpotPrice(ctx context.Context, poolID uint64, baseAsset, quoteAsset string) (WrappedSpotPriceResponse, error) {
+	return mc.query(ctx, "spot price", func(client Client) (WrappedSpotPriceResponse, error) {
+		return client.SpotPrice(ctx, poolID, baseAsset, quoteAsset)
+	})
+}
+
+// ArithmeticTWAP delegates the request to all underlying clients and applies a filter to the
+// set of responses.
+func (mc *MultiClientImpl) ArithmeticTWAP(
+	ctx context.Context,
+	poolID uint64,
+	baseAsset, quoteAsset string,
+	startTime time.Time,
+) (WrappedSpotPriceResponse, error) {
+	return mc.query(ctx, "arithmetic twap", func(client Client) (WrappedSpotPriceResponse, error) {
+		return client.ArithmeticTWAP(ctx, poolID, baseAsset, quoteAsset, startTime)
+	})
+}
+
+// query runs the given query against all underlying clients in parallel and applies a filter
+// to the set of responses.
+func (mc *MultiClientImpl) query(
+	_ context.Context,
+	name string,
+	fn func(Client) (WrappedSpotPriceResponse, error),
+) (WrappedSpotPriceResponse, error) {
 	resps := make([]WrappedSpotPriceResponse, len(mc.clients))
 
 	var wg sync.WaitGroup
@@ -216,13 +295,13 @@ func (mc *MultiClientImpl) SpotPrice(ctx context.Context, poolID uint64, baseAss
 		index := i
 		go func(index int, client Client) {
 			defer wg.Done()
-			resp, err := client.SpotPrice(ctx, poolID, baseAsset, quoteAsset)
+			resp, err := fn(client)
 			if err != nil {
-				mc.logger.Error("failed to spot price in sub client", zap.String("url", url), zap.Error(err))
+				mc.logger.Error("failed to query "+name+" in sub client", zap.String("url", url), zap.Error(err))
 				return
 			}
 
-			mc.logger.Debug("successfully fetched accounts", zap.String("url", url))
+			mc.logger.Debug("successfully fetched "+name, zap.String("url", url))
 
 			resps[index] = resp
 		}(index, mc.clients[i])
@@ -127,4 +127,35 @@ func TestMultiClient(t *testing.T) {
 
 		require.Equal(t, expectedPrice, resp.SpotPrice)
 	})
+
+	t.Run("test correct aggregation of twap responses", func(t *testing.T) {
+		var (
+			poolID        uint64 = 1
+			baseAsset            = "test1"
+			quoteAsset           = "test2"
+			expectedPrice        = "12"
+			startTime            = time.Now().Add(-time.Hour)
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+
+		client1.On("ArithmeticTWAP", mock.Anything, poolID, baseAsset, quoteAsset, startTime).Return(osmosis.WrappedSpotPriceResponse{
+			SpotPriceResponse: osmosis.SpotPriceResponse{SpotPrice: expectedPrice},
+			BlockHeight:       2,
+		}, nil).Once()
+		client2.On("ArithmeticTWAP", mock.Anything, poolID, baseAsset, quoteAsset, startTime).Return(osmosis.WrappedSpotPriceResponse{
+			SpotPriceResponse: osmosis.SpotPriceResponse{SpotPrice: expectedPrice},
+			BlockHeight:       2,
+		}, nil).Once()
+		client3.On("ArithmeticTWAP", mock.Anything, poolID, baseAsset, quoteAsset, startTime).Return(
+			osmosis.WrappedSpotPriceResponse{}, fmt.Errorf("error"),
+		).Once()
+
+		resp, err := client.ArithmeticTWAP(ctx, poolID, baseAsset, quoteAsset, startTime)
+		require.NoError(t, err)
+
+		require.Equal(t, expectedPrice, resp.SpotPrice)
+		require.Equal(t, uint64(2), resp.BlockHeight)
+	})
 }
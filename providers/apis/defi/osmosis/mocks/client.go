@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	osmosis "github.com/skip-mev/connect/v2/providers/apis/defi/osmosis"
 )
 
@@ -23,6 +25,66 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// ArithmeticTWAP provides a mock function with given fields: ctx, poolID, baseAsset, quoteAsset, startTime
+func (_m *Client) ArithmeticTWAP(ctx context.Context, poolID uint64, baseAsset string, quoteAsset string, startTime time.Time) (osmosis.WrappedSpotPriceResponse, error) {
+	ret := _m.Called(ctx, poolID, baseAsset, quoteAsset, startTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArithmeticTWAP")
+	}
+
+	var r0 osmosis.WrappedSpotPriceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, string, time.Time) (osmosis.WrappedSpotPriceResponse, error)); ok {
+		return rf(ctx, poolID, baseAsset, quoteAsset, startTime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, string, time.Time) osmosis.WrappedSpotPriceResponse); ok {
+		r0 = rf(ctx, poolID, baseAsset, quoteAsset, startTime)
+	} else {
+		r0 = ret.Get(0).(osmosis.WrappedSpotPriceResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string, string, time.Time) error); ok {
+		r1 = rf(ctx, poolID, baseAsset, quoteAsset, startTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ArithmeticTWAP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArithmeticTWAP'
+type Client_ArithmeticTWAP_Call struct {
+	*mock.Call
+}
+
+// ArithmeticTWAP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - poolID uint64
+//   - baseAsset string
+//   - quoteAsset string
+//   - startTime time.Time
+func (_e *Client_Expecter) ArithmeticTWAP(ctx interface{}, poolID interface{}, baseAsset interface{}, quoteAsset interface{}, startTime interface{}) *Client_ArithmeticTWAP_Call {
+	return &Client_ArithmeticTWAP_Call{Call: _e.mock.On("ArithmeticTWAP", ctx, poolID, baseAsset, quoteAsset, startTime)}
+}
+
+func (_c *Client_ArithmeticTWAP_Call) Run(run func(ctx context.Context, poolID uint64, baseAsset string, quoteAsset string, startTime time.Time)) *Client_ArithmeticTWAP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(string), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Client_ArithmeticTWAP_Call) Return(_a0 osmosis.WrappedSpotPriceResponse, _a1 error) *Client_ArithmeticTWAP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ArithmeticTWAP_Call) RunAndReturn(run func(context.Context, uint64, string, string, time.Time) (osmosis.WrappedSpotPriceResponse, error)) *Client_ArithmeticTWAP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SpotPrice provides a mock function with given fields: ctx, poolID, baseAsset, quoteAsset
 func (_m *Client) SpotPrice(ctx context.Context, poolID uint64, baseAsset string, quoteAsset string) (osmosis.WrappedSpotPriceResponse, error) {
 	ret := _m.Called(ctx, poolID, baseAsset, quoteAsset)
@@ -166,11 +166,21 @@ func (pf *APIPriceFetcher) Fetch(
 			callCtx, cancel := context.WithTimeout(ctx, pf.api.Timeout)
 			defer cancel()
 
-			resp, err := pf.client.SpotPrice(callCtx,
-				metadata.PoolID,
-				metadata.BaseTokenDenom,
-				metadata.QuoteTokenDenom,
-			)
+			var resp WrappedSpotPriceResponse
+			if metadata.TWAPWindow > 0 {
+				resp, err = pf.client.ArithmeticTWAP(callCtx,
+					metadata.PoolID,
+					metadata.BaseTokenDenom,
+					metadata.QuoteTokenDenom,
+					time.Now().Add(-time.Duration(metadata.TWAPWindow)*time.Second),
+				)
+			} else {
+				resp, err = pf.client.SpotPrice(callCtx,
+					metadata.PoolID,
+					metadata.BaseTokenDenom,
+					metadata.QuoteTokenDenom,
+				)
+			}
 			if err != nil {
 				unresolvedTickerCallback(ticker, providertypes.NewErrorWithCode(
 					err,
@@ -216,6 +216,38 @@ func TestProviderFetch(t *testing.T) {
 		require.Equal(t, 0, len(resp.UnResolved))
 	})
 
+	t.Run("single valid twap ticker", func(t *testing.T) {
+		client := mocks.NewClient(t)
+		pf, err := newPriceFetcher(client)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+
+		twapMetadata := btcUSDTMetadata
+		twapMetadata.TWAPWindow = 600
+
+		client.On("ArithmeticTWAP", mock.Anything, twapMetadata.PoolID, twapMetadata.BaseTokenDenom,
+			twapMetadata.QuoteTokenDenom, mock.MatchedBy(func(startTime time.Time) bool {
+				age := time.Since(startTime)
+				return age >= 10*time.Minute && age < 11*time.Minute
+			}),
+		).Return(osmosis.WrappedSpotPriceResponse{
+			SpotPriceResponse: osmosis.SpotPriceResponse{
+				SpotPrice: expectedBTCUSDTPrice,
+			},
+		}, nil).Once()
+
+		ts := defaultTickersToProviderTickers([]types.DefaultProviderTicker{
+			{
+				OffChainTicker: "BTC/USDC",
+				JSON:           marshalDataToJSON(twapMetadata),
+			},
+		})
+		resp := pf.Fetch(ctx, ts)
+		require.Equal(t, 1, len(resp.Resolved))
+		require.Equal(t, 0, len(resp.UnResolved))
+	})
+
 	t.Run("failing query", func(t *testing.T) {
 		client := mocks.NewClient(t)
 		pf, err := newPriceFetcher(client)
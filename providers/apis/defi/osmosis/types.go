@@ -17,6 +17,7 @@ const (
 	QueryURLCharacter = "?"
 	URLSeparator      = "/"
 	URLSuffix         = "osmosis/poolmanager/v2/pools/%s/prices%sbase_asset_denom=%s&quote_asset_denom=%s"
+	TWAPURLSuffix     = "osmosis/twap/v1beta1/ArithmeticTwapToNow%spool_id=%s&base_asset=%s&quote_asset=%s&start_time=%s"
 )
 
 // CreateURL creates the properly formatted osmosis query URL for spot price.
@@ -30,6 +31,25 @@ func CreateURL(baseURL string, poolID uint64, baseAsset, quoteAsset string) (str
 	), nil
 }
 
+// CreateTWAPURL creates the properly formatted osmosis query URL for the arithmetic TWAP from the
+// given start time until now.
+func CreateTWAPURL(baseURL string, poolID uint64, baseAsset, quoteAsset string, startTime time.Time) (string, error) {
+	return strings.Join(
+		[]string{
+			baseURL,
+			fmt.Sprintf(
+				TWAPURLSuffix,
+				QueryURLCharacter,
+				strconv.FormatUint(poolID, 10),
+				baseAsset,
+				quoteAsset,
+				startTime.UTC().Format(time.RFC3339),
+			),
+		},
+		URLSeparator,
+	), nil
+}
+
 // NoOsmosisMetadataForTickerError is returned when there is no metadata associated with a given ticker.
 func NoOsmosisMetadataForTickerError(ticker string) error {
 	return fmt.Errorf("no osmosis metadata for ticker: %s", ticker)
@@ -89,6 +109,10 @@ type TickerMetadata struct {
 
 	// QuoteTokenDenom is the identifier (on osmosis) of the quote token.
 	QuoteTokenDenom string `json:"quote_token_denom"`
+
+	// TWAPWindow is the number of seconds over which the arithmetic time-weighted average price of
+	// the pool is queried from the x/twap module. If unset, the spot price is queried instead.
+	TWAPWindow uint32 `json:"twap_window,omitempty"`
 }
 
 // ValidateBasic checks that the pool and token information is formatted properly.
@@ -134,6 +158,11 @@ type SpotPriceResponse struct {
 	SpotPrice string `json:"spot_price"`
 }
 
+// TWAPResponse is the response of the x/twap ArithmeticTwapToNow query.
+type TWAPResponse struct {
+	ArithmeticTWAP string `json:"arithmetic_twap"`
+}
+
 type WrappedSpotPriceResponse struct {
 	SpotPriceResponse
 	BlockHeight uint64 `json:"block_height"`
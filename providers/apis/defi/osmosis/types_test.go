@@ -2,6 +2,7 @@ package osmosis_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -47,3 +48,12 @@ func TestCreateURL(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTWAPURL(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got, err := osmosis.CreateTWAPURL("http://localhost", 1, "base", "quote", startTime)
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost/osmosis/twap/v1beta1/ArithmeticTwapToNow?pool_id=1&base_asset=base&"+
+		"quote_asset=quote&start_time=2024-01-01T00:00:00Z", got)
+}
@@ -9,9 +9,11 @@ import (
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
 	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
 )
 
@@ -54,7 +56,7 @@ func NewJSONRPCClient(
 	)
 	switch {
 	case len(api.Endpoints) == 1:
-		client, err = solanaClientFromEndpoint(api.Endpoints[0])
+		client, err = SolanaClientFromEndpoint(api.Endpoints[0], connecthttp.SharedInFlightLimiter(api.Name, api.MaxInFlight))
 		redactedURL = metrics.RedactedEndpointURL(0)
 	default:
 		return nil, fmt.Errorf("no valid endpoints or url were provided")
@@ -92,23 +94,28 @@ func (c *JSONRPCClient) GetMultipleAccountsWithOpts(
 	return
 }
 
-// solanaClientFromEndpoint creates a new SolanaJSONRPCClient from an endpoint.
-func solanaClientFromEndpoint(endpoint config.Endpoint) (*rpc.Client, error) {
-	opts := []connecthttp.HeaderOption{
+// SolanaClientFromEndpoint creates a new SolanaJSONRPCClient from an endpoint. The requests in
+// flight are limited by the given limiter, if any.
+func SolanaClientFromEndpoint(endpoint config.Endpoint, inFlight *connecthttp.InFlightLimiter) (*rpc.Client, error) {
+	var transport http.RoundTripper = connecthttp.NewRoundTripperWithHeaders(
+		connecthttp.NewRoundTripperWithLimit(http.DefaultTransport, inFlight),
 		connecthttp.WithConnectVersionUserAgent(),
-	}
+	)
 
-	// if authentication is enabled
+	// if authentication is enabled, add the authentication header. The key is looked up on each
+	// request so that it can be rotated.
 	if endpoint.Authentication.Enabled() {
-		// add authentication header
-		opts = append(opts, connecthttp.WithAuthentication(endpoint.Authentication.APIKeyHeader, endpoint.Authentication.APIKey))
-	}
+		apiKey, err := secrets.APIKey(endpoint.Authentication)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read api key: %w", err)
+		}
 
-	transport := connecthttp.NewRoundTripperWithHeaders(http.DefaultTransport, opts...)
+		transport = connecthttp.NewRoundTripperWithAuthentication(transport, endpoint.Authentication.APIKeyHeader, apiKey.Value)
+	}
 
 	client := rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(endpoint.URL, &jsonrpc.RPCClientOpts{
 		HTTPClient: &http.Client{
-			Transport: transport,
+			Transport: otelhttp.NewTransport(transport),
 		},
 	}))
 
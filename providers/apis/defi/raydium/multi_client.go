@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/types"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
 )
@@ -74,10 +75,13 @@ func NewMultiJSONRPCClientFromEndpoints(
 		return nil, fmt.Errorf("metrics is nil")
 	}
 
-	var err error
+	var (
+		err      error
+		inFlight = connecthttp.SharedInFlightLimiter(api.Name, api.MaxInFlight)
+	)
 	clients := make([]SolanaJSONRPCClient, len(api.Endpoints))
 	for i := range api.Endpoints {
-		clients[i], err = solanaClientFromEndpoint(api.Endpoints[i])
+		clients[i], err = SolanaClientFromEndpoint(api.Endpoints[i], inFlight)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create solana client from endpoint: %w", err)
 		}
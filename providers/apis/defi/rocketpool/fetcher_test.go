@@ -0,0 +1,259 @@
+package rocketpool_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	oracleconfig "github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/rocketpool"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
+var (
+	logger, _ = zap.NewDevelopment()
+
+	// Tickers used for testing.
+	rethTicker = types.NewProviderTicker("RETH/ETH", "")
+)
+
+func TestFetch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tickers  []types.ProviderTicker
+		client   func() ethmulticlient.EVMClient
+		expected types.PriceResponse
+	}{
+		{
+			name: "fails to retrieve feed for an invalid ticker",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("RETH/ETH", `{"address":"0x1234"}`),
+			},
+			client: func() ethmulticlient.EVMClient {
+				return mocks.NewEVMClient(t)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					types.NewProviderTicker("RETH/ETH", `{"address":"0x1234"}`): {},
+				},
+			},
+		},
+		{
+			name: "fails to make a batch call",
+			tickers: []types.ProviderTicker{
+				rethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, fmt.Errorf("failed to make a batch call"), nil, nil)
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					rethTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request has an error for a single ticker",
+			tickers: []types.ProviderTicker{
+				rethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{""}, []error{fmt.Errorf("execution reverted")})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					rethTicker: {},
+				},
+			},
+		},
+		{
+			name: "batch request returns a result that cannot be parsed",
+			tickers: []types.ProviderTicker{
+				rethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{"not a valid result"}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					rethTicker: {},
+				},
+			},
+		},
+		{
+			name: "zero exchange rate",
+			tickers: []types.ProviderTicker{
+				rethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{encodeExchangeRate(t, "0")}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{
+					rethTicker: {},
+				},
+			},
+		},
+		{
+			name: "reth/eth mainnet result",
+			tickers: []types.ProviderTicker{
+				rethTicker,
+			},
+			client: func() ethmulticlient.EVMClient {
+				return createEVMClientWithResponse(t, nil, []string{encodeExchangeRate(t, "1125000000000000000")}, []error{nil})
+			},
+			expected: types.PriceResponse{
+				Resolved: map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{
+					rethTicker: {
+						Value: big.NewFloat(1.125),
+					},
+				},
+				UnResolved: map[types.ProviderTicker]providertypes.UnresolvedResult{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := rocketpool.NewPriceFetcherWithClient(logger, rocketpool.DefaultETHAPIConfig, tc.client())
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Equal(t, len(tc.expected.Resolved), len(response.Resolved))
+			require.Equal(t, len(tc.expected.UnResolved), len(response.UnResolved))
+
+			for ticker, result := range tc.expected.Resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, result.Value.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+			}
+
+			for ticker := range tc.expected.UnResolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+}
+
+func TestFetchAtBlockTag(t *testing.T) {
+	response := encodeExchangeRate(t, "1125000000000000000")
+
+	client := mocks.NewEVMClient(t)
+	client.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+		require.Len(t, elems, 2)
+		require.Equal(t, oracleconfig.BlockTagSafe, elems[0].Args[1])
+
+		// The block is read at the same block tag as the call.
+		require.Equal(t, "eth_getBlockByNumber", elems[1].Method)
+		require.Equal(t, []interface{}{oracleconfig.BlockTagSafe, false}, elems[1].Args)
+		*elems[1].Result.(*ethmulticlient.BlockHeader) = testBlock
+
+		call, ok := elems[0].Args[0].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, common.HexToAddress(rocketpool.RETHAddress), call["to"])
+
+		elems[0].Result = &response
+	})
+
+	api := rocketpool.DefaultETHAPIConfig
+	api.BlockTag = oracleconfig.BlockTagSafe
+
+	fetcher, err := rocketpool.NewPriceFetcherWithClient(logger, api, client)
+	require.NoError(t, err)
+
+	resp := fetcher.Fetch(context.Background(), []types.ProviderTicker{rethTicker})
+	require.Len(t, resp.Resolved, 1)
+	for _, result := range resp.Resolved {
+		require.Equal(t, testBlock.Info(), result.Block)
+	}
+}
+
+func TestFeedConfigValidateBasic(t *testing.T) {
+	t.Run("default address", func(t *testing.T) {
+		cfg := rocketpool.FeedConfig{}
+		require.NoError(t, cfg.ValidateBasic())
+		require.Equal(t, rocketpool.RETHAddress, cfg.GetAddress())
+	})
+
+	t.Run("address override", func(t *testing.T) {
+		cfg := rocketpool.FeedConfig{Address: "0xB6fe221Fe9EeF5aBa221c348bA20A1Bf5e73624c"}
+		require.NoError(t, cfg.ValidateBasic())
+		require.Equal(t, cfg.Address, cfg.GetAddress())
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		cfg := rocketpool.FeedConfig{Address: "0x1234"}
+		require.Error(t, cfg.ValidateBasic())
+	})
+}
+
+func encodeExchangeRate(t *testing.T, value string) string {
+	t.Helper()
+
+	contractABI, err := abi.JSON(strings.NewReader(rocketpool.ContractABI))
+	require.NoError(t, err)
+
+	v, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok)
+
+	bz, err := contractABI.Methods[rocketpool.ContractMethod].Outputs.Pack(v)
+	require.NoError(t, err)
+
+	return hexutil.Encode(bz)
+}
+
+func createEVMClientWithResponse(
+	t *testing.T,
+	failedRequestErr error,
+	responses []string,
+	errs []error,
+) ethmulticlient.EVMClient {
+	t.Helper()
+
+	c := mocks.NewEVMClient(t)
+	if failedRequestErr != nil {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(failedRequestErr)
+	} else {
+		c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			elems, ok := args.Get(1).([]rpc.BatchElem)
+			require.True(t, ok)
+			// The last call reads the block at which the other calls are read, if any.
+			if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+				*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+				elems = elems[:n-1]
+			}
+			require.Equal(t, len(elems), len(responses))
+			require.Equal(t, len(elems), len(errs))
+
+			for i, elem := range elems {
+				elem.Result = &responses[i]
+				elem.Error = errs[i]
+				elems[i] = elem
+			}
+		})
+	}
+
+	return c
+}
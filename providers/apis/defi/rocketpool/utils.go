@@ -0,0 +1,100 @@
+package rocketpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/constants"
+)
+
+const (
+	// BaseName is the name of the Rocket Pool API.
+	BaseName = "rocketpool_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// ContractMethod is the rETH method that returns the amount of ETH backing one rETH.
+	ContractMethod = "getExchangeRate"
+
+	// ContractABI is the ABI of the getExchangeRate method of the rETH contract.
+	ContractABI = `[{"inputs":[],"name":"getExchangeRate","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+	// Decimals is the number of decimals of the rETH exchange rate.
+	Decimals = 18
+
+	// RETHAddress is the address of the rETH contract on Ethereum Mainnet.
+	RETHAddress = "0xae78736Cd615f374D3085123A210448E74Fc6393"
+
+	// ETH_URL is the URL for the Rocket Pool API. This uses a free public RPC provider on Ethereum Mainnet.
+	ETH_URL = "https://eth.public-rpc.com/"
+)
+
+// ProviderNames is the set of all supported "dynamic" names mapped by chain.
+var ProviderNames = map[string]string{
+	constants.ETHEREUM: strings.Join([]string{BaseName, constants.ETHEREUM}, NameSeparator),
+}
+
+// IsValidProviderName returns a bool based on the validity of the passed in name.
+// Dynamic provider naming is supported via `BaseName“NameSeparator“SupportedChain`.
+func IsValidProviderName(name string) bool {
+	for _, providerName := range ProviderNames {
+		if name == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedConfig is the optional configuration for the rETH exchange rate that can be set on each
+// ticker.
+type FeedConfig struct {
+	// Address is the address of the rETH contract. If unset, RETHAddress is used.
+	Address string `json:"address" schema:"address"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if fc.Address != "" && !common.IsHexAddress(fc.Address) {
+		return fmt.Errorf("reth address is not a valid ethereum address")
+	}
+
+	return nil
+}
+
+// GetAddress returns the address of the rETH contract.
+func (fc *FeedConfig) GetAddress() string {
+	if fc.Address == "" {
+		return RETHAddress
+	}
+
+	return fc.Address
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// DefaultETHAPIConfig is the default configuration for the Rocket Pool API. Specifically this is
+// for Ethereum mainnet.
+var DefaultETHAPIConfig = config.APIConfig{
+	Name:              fmt.Sprintf("%s%s%s", BaseName, NameSeparator, constants.ETHEREUM),
+	Atomic:            true,
+	Enabled:           true,
+	Timeout:           1000 * time.Millisecond,
+	Interval:          2000 * time.Millisecond,
+	ReconnectTimeout:  2000 * time.Millisecond,
+	MaxQueries:        1,
+	Endpoints:         []config.Endpoint{{URL: ETH_URL}},
+	MaxBlockHeightAge: 30 * time.Second,
+}
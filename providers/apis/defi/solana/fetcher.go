@@ -0,0 +1,253 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	oracletypes "github.com/skip-mev/connect/v2/oracle/types"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/raydium"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ oracletypes.PriceAPIFetcher = &APIPriceFetcher{}
+
+// APIPriceFetcher reads prices from the data of Solana accounts, such as Pyth price accounts or
+// Switchboard aggregators, over JSON-RPC. The accounts of all tickers are read with a single
+// getMultipleAccounts query.
+type APIPriceFetcher struct {
+	// api is the APIConfiguration for this provider.
+	api config.APIConfig
+
+	// client is the solana JSON-RPC client used to query the accounts.
+	client raydium.SolanaJSONRPCClient
+
+	// feedCache caches the feed config of each ticker.
+	feedCache map[string]FeedConfig
+	mtx       sync.Mutex
+
+	logger *zap.Logger
+}
+
+// NewAPIPriceFetcher returns a new APIPriceFetcher. This method constructs a solana JSON-RPC
+// client for each of the config's endpoints. If multiple endpoints are configured, the response
+// with the highest slot is used.
+func NewAPIPriceFetcher(
+	logger *zap.Logger,
+	api config.APIConfig,
+	apiMetrics metrics.APIMetrics,
+) (*APIPriceFetcher, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if api.Name != Name {
+		return nil, fmt.Errorf("invalid api name; expected %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api is not enabled")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("metrics cannot be nil")
+	}
+
+	if len(api.Endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints provided")
+	}
+
+	inFlight := connecthttp.SharedInFlightLimiter(api.Name, api.MaxInFlight)
+	clients := make([]raydium.SolanaJSONRPCClient, len(api.Endpoints))
+	for i := range api.Endpoints {
+		client, err := raydium.SolanaClientFromEndpoint(api.Endpoints[i], inFlight)
+		if err != nil {
+			logger.Error("error creating client", zap.Error(err))
+			return nil, fmt.Errorf("failed to create solana client from endpoint: %w", err)
+		}
+
+		clients[i] = client
+	}
+
+	return NewAPIPriceFetcherWithClient(
+		logger,
+		api,
+		raydium.NewMultiJSONRPCClient(logger.With(zap.String("multi_client", Name)), api, apiMetrics, clients),
+	)
+}
+
+// NewAPIPriceFetcherWithClient returns a new APIPriceFetcher with the given client.
+func NewAPIPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client raydium.SolanaJSONRPCClient,
+) (*APIPriceFetcher, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("config for %s is invalid: %w", Name, err)
+	}
+
+	if api.Name != Name {
+		return nil, fmt.Errorf("configured name is incorrect; expected: %s, got: %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("config is not enabled")
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	return &APIPriceFetcher{
+		api:       api,
+		client:    client,
+		feedCache: make(map[string]FeedConfig),
+		logger:    logger.With(zap.String("fetcher", Name)),
+	}, nil
+}
+
+// Fetch reads the account of each ticker and decodes its price with the ticker's layout.
+func (pf *APIPriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []oracletypes.ProviderTicker,
+) oracletypes.PriceResponse {
+	var (
+		resolved   = make(oracletypes.ResolvedPrices)
+		unresolved = make(oracletypes.UnResolvedPrices)
+		feeds      = make([]FeedConfig, 0, len(tickers))
+		accounts   = make([]solanago.PublicKey, 0, len(tickers))
+		queried    = make([]oracletypes.ProviderTicker, 0, len(tickers))
+	)
+
+	for _, ticker := range tickers {
+		feed, err := pf.GetFeed(ticker)
+		if err != nil {
+			pf.logger.Debug("failed to get feed for ticker", zap.String("ticker", ticker.String()), zap.Error(err))
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorUnknownPair),
+			}
+			continue
+		}
+
+		feeds = append(feeds, feed)
+		accounts = append(accounts, solanago.MustPublicKeyFromBase58(feed.Account))
+		queried = append(queried, ticker)
+	}
+
+	if len(queried) == 0 {
+		return oracletypes.NewPriceResponse(resolved, unresolved)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pf.api.Timeout)
+	defer cancel()
+
+	// The accounts are returned in the order that they were queried.
+	accountsResp, err := pf.client.GetMultipleAccountsWithOpts(ctx, accounts, &rpc.GetMultipleAccountsOpts{
+		Encoding:   solanago.EncodingBase64,
+		Commitment: rpc.CommitmentConfirmed,
+	})
+	if err == nil && len(accountsResp.Value) != len(accounts) {
+		err = fmt.Errorf("expected %d accounts, got %d", len(accounts), len(accountsResp.Value))
+	}
+	if err != nil {
+		pf.logger.Error("error querying accounts", zap.Error(err))
+		for _, ticker := range queried {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					SolanaJSONRPCError(err),
+					providertypes.ErrorAPIGeneral,
+				),
+			}
+		}
+
+		return oracletypes.NewPriceResponse(resolved, unresolved)
+	}
+
+	now := time.Now().UTC()
+	for i, ticker := range queried {
+		account := accountsResp.Value[i]
+		if account == nil || account.Data == nil {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					fmt.Errorf("account %s not found", feeds[i].Account),
+					providertypes.ErrorNoResponse,
+				),
+			}
+			continue
+		}
+
+		price, err := feeds[i].GetLayout().Decode(account.Data.GetBinary())
+		if err != nil {
+			pf.logger.Debug("failed to decode account", zap.String("ticker", ticker.String()), zap.Error(err))
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+			}
+			continue
+		}
+
+		// Ensure that the price is positive and recent enough to be used.
+		if err := price.ValidateBasic(now, feeds[i].GetMaxAge()); err != nil {
+			pf.logger.Debug("invalid account price", zap.String("ticker", ticker.String()), zap.Error(err))
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorCodeFromError(err, providertypes.ErrorInvalidResponse),
+				),
+			}
+			continue
+		}
+
+		scaled, err := price.ScalePrice()
+		if err != nil {
+			pf.logger.Debug("failed to scale account price", zap.String("ticker", ticker.String()), zap.Error(err))
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice),
+			}
+			continue
+		}
+
+		resolved[ticker] = oracletypes.NewPriceResult(scaled, now)
+	}
+
+	return oracletypes.NewPriceResponse(resolved, unresolved)
+}
+
+// GetFeed returns the feed config of the given ticker, which is parsed from the ticker's metadata
+// and cached.
+func (pf *APIPriceFetcher) GetFeed(ticker oracletypes.ProviderTicker) (FeedConfig, error) {
+	pf.mtx.Lock()
+	defer pf.mtx.Unlock()
+
+	if feed, ok := pf.feedCache[ticker.String()]; ok {
+		return feed, nil
+	}
+
+	var feed FeedConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &feed); err != nil {
+		return FeedConfig{}, fmt.Errorf("failed to unmarshal feed config for ticker %s: %w", ticker.String(), err)
+	}
+
+	if err := feed.ValidateBasic(); err != nil {
+		return FeedConfig{}, fmt.Errorf("invalid feed config for ticker %s: %w", ticker.String(), err)
+	}
+
+	pf.feedCache[ticker.String()] = feed
+	return feed, nil
+}
@@ -0,0 +1,259 @@
+package solana_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/raydium/mocks"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/solana"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+)
+
+const (
+	BTCPythAccount  = "GVXRSBjFk6e6J3NbVPXohDJetcTjaeeuykUpbQF8UoMU"
+	USDCPythAccount = "Dpw1EAVrSB1ibxiDQyTAW6Zip3J4Btk2x4SgApQCeFbX"
+	SOLSBAccount    = "GvDMxPzN1sCj7L26YDK2HnMRXEQmQ2aemov8YBtPS7vR"
+)
+
+var (
+	logger = zap.NewExample()
+
+	btcTicker = types.NewProviderTicker("BTC/USD", solana.FeedConfig{
+		Account: BTCPythAccount,
+		Layout:  solana.LayoutPyth,
+		MaxAge:  60,
+	}.MustToJSON())
+	usdcTicker = types.NewProviderTicker("USDC/USD", solana.FeedConfig{
+		Account: USDCPythAccount,
+		Layout:  solana.LayoutPythPull,
+	}.MustToJSON())
+	solTicker = types.NewProviderTicker("SOL/USD", solana.FeedConfig{
+		Account: SOLSBAccount,
+		Layout:  solana.LayoutSwitchboard,
+	}.MustToJSON())
+)
+
+func TestFeedConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  solana.FeedConfig
+		err  bool
+	}{
+		{
+			name: "valid pyth feed",
+			cfg:  solana.FeedConfig{Account: BTCPythAccount, Layout: solana.LayoutPyth},
+		},
+		{
+			name: "valid custom feed",
+			cfg: solana.FeedConfig{
+				Account: BTCPythAccount,
+				Layout:  solana.LayoutCustom,
+				Fields:  &solana.FieldLayout{Price: solana.Field{Offset: 64, Type: solana.FieldU64}, Decimals: 6},
+			},
+		},
+		{
+			name: "invalid account",
+			cfg:  solana.FeedConfig{Account: "0x1234", Layout: solana.LayoutPyth},
+			err:  true,
+		},
+		{
+			name: "unknown layout",
+			cfg:  solana.FeedConfig{Account: BTCPythAccount, Layout: "chainlink"},
+			err:  true,
+		},
+		{
+			name: "custom layout without fields",
+			cfg:  solana.FeedConfig{Account: BTCPythAccount, Layout: solana.LayoutCustom},
+			err:  true,
+		},
+		{
+			name: "preset layout with fields",
+			cfg: solana.FeedConfig{
+				Account: BTCPythAccount,
+				Layout:  solana.LayoutSwitchboard,
+				Fields:  &solana.FieldLayout{Price: solana.Field{Type: solana.FieldU64}},
+			},
+			err: true,
+		},
+		{
+			name: "negative max age",
+			cfg:  solana.FeedConfig{Account: BTCPythAccount, Layout: solana.LayoutPyth, MaxAge: -1},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewAPIPriceFetcher(t *testing.T) {
+	t.Run("invalid name", func(t *testing.T) {
+		cfg := solana.DefaultAPIConfig
+		cfg.Name = "raydium_api"
+
+		_, err := solana.NewAPIPriceFetcher(logger, cfg, metrics.NewNopAPIMetrics())
+		require.Error(t, err)
+	})
+
+	t.Run("multiple endpoints", func(t *testing.T) {
+		cfg := solana.DefaultAPIConfig
+		cfg.Endpoints = append(cfg.Endpoints, cfg.Endpoints[0])
+
+		_, err := solana.NewAPIPriceFetcher(logger, cfg, metrics.NewNopAPIMetrics())
+		require.NoError(t, err)
+	})
+}
+
+func TestFetch(t *testing.T) {
+	now := time.Now().Unix()
+
+	testCases := []struct {
+		name       string
+		tickers    []types.ProviderTicker
+		accounts   []*rpc.Account
+		err        error
+		resolved   map[types.ProviderTicker]*big.Float
+		unresolved []types.ProviderTicker
+	}{
+		{
+			name: "decodes the accounts of each layout",
+			tickers: []types.ProviderTicker{
+				btcTicker,
+				usdcTicker,
+				solTicker,
+			},
+			accounts: []*rpc.Account{
+				account(pythAccount(6500012345678, -8, 1, now)),
+				account(pythPullAccount(99985000, -8, now, true)),
+				account(switchboardAccount(big.NewInt(150250), 3, now)),
+			},
+			resolved: map[types.ProviderTicker]*big.Float{
+				btcTicker:  big.NewFloat(65000.12345678),
+				usdcTicker: big.NewFloat(0.99985),
+				solTicker:  big.NewFloat(150.25),
+			},
+		},
+		{
+			name: "invalid metadata is not queried",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("ETH/USD", `{"account":"0x1234","layout":"pyth"}`),
+				btcTicker,
+			},
+			accounts: []*rpc.Account{
+				account(pythAccount(6500012345678, -8, 1, now)),
+			},
+			resolved: map[types.ProviderTicker]*big.Float{
+				btcTicker: big.NewFloat(65000.12345678),
+			},
+			unresolved: []types.ProviderTicker{
+				types.NewProviderTicker("ETH/USD", `{"account":"0x1234","layout":"pyth"}`),
+			},
+		},
+		{
+			name: "missing, undecodable and stale accounts",
+			tickers: []types.ProviderTicker{
+				btcTicker,
+				usdcTicker,
+				solTicker,
+			},
+			accounts: []*rpc.Account{
+				account(pythAccount(6500012345678, -8, 1, now-3600)),
+				nil,
+				account(pythAccount(6500012345678, -8, 1, now)),
+			},
+			unresolved: []types.ProviderTicker{
+				btcTicker,
+				usdcTicker,
+				solTicker,
+			},
+		},
+		{
+			name: "rpc error",
+			tickers: []types.ProviderTicker{
+				btcTicker,
+				solTicker,
+			},
+			err: fmt.Errorf("rpc error"),
+			unresolved: []types.ProviderTicker{
+				btcTicker,
+				solTicker,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := mocks.NewSolanaJSONRPCClient(t)
+
+			var resp *rpc.GetMultipleAccountsResult
+			if tc.err == nil {
+				resp = &rpc.GetMultipleAccountsResult{Value: tc.accounts}
+			}
+			client.On("GetMultipleAccountsWithOpts", mock.Anything, mock.Anything, mock.Anything).Return(resp, tc.err).Once()
+
+			fetcher, err := solana.NewAPIPriceFetcherWithClient(logger, solana.DefaultAPIConfig, client)
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Len(t, response.Resolved, len(tc.resolved))
+			require.Len(t, response.UnResolved, len(tc.unresolved))
+
+			for ticker, price := range tc.resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, price.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+			}
+
+			for _, ticker := range tc.unresolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+}
+
+func TestFetchQueriesAccountsInOrder(t *testing.T) {
+	client := mocks.NewSolanaJSONRPCClient(t)
+	client.On(
+		"GetMultipleAccountsWithOpts",
+		mock.Anything,
+		[]solanago.PublicKey{
+			solanago.MustPublicKeyFromBase58(SOLSBAccount),
+			solanago.MustPublicKeyFromBase58(BTCPythAccount),
+		},
+		mock.Anything,
+	).Return(&rpc.GetMultipleAccountsResult{
+		Value: []*rpc.Account{
+			account(switchboardAccount(big.NewInt(150250), 3, time.Now().Unix())),
+			account(pythAccount(6500012345678, -8, 1, time.Now().Unix())),
+		},
+	}, nil).Once()
+
+	fetcher, err := solana.NewAPIPriceFetcherWithClient(logger, solana.DefaultAPIConfig, client)
+	require.NoError(t, err)
+
+	response := fetcher.Fetch(context.Background(), []types.ProviderTicker{solTicker, btcTicker})
+	require.Len(t, response.Resolved, 2)
+}
+
+func account(data []byte) *rpc.Account {
+	return &rpc.Account{
+		Data: rpc.DataBytesOrJSONFromBytes(data),
+	}
+}
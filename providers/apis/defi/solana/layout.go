@@ -0,0 +1,335 @@
+package solana
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/skip-mev/connect/v2/pkg/math"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+const (
+	// FieldI32, FieldU32, FieldI64, FieldU64, FieldI128 and FieldU128 are the little-endian
+	// integer types a field can be decoded as.
+	FieldI32  = "i32"
+	FieldU32  = "u32"
+	FieldI64  = "i64"
+	FieldU64  = "u64"
+	FieldI128 = "i128"
+	FieldU128 = "u128"
+)
+
+// Layout decodes the data of a Solana account into a price.
+type Layout interface {
+	Decode(data []byte) (AccountPrice, error)
+}
+
+// AccountPrice is a price decoded from the data of a Solana account.
+type AccountPrice struct {
+	// Value is the unscaled value of the price.
+	Value *big.Int
+	// Exponent is the power of ten the value is multiplied by.
+	Exponent int64
+	// Timestamp is the unix timestamp at which the price was last updated, or zero if the layout
+	// does not have a timestamp.
+	Timestamp int64
+}
+
+// ValidateBasic ensures that the price is positive and no older than the given max age. A zero
+// max age disables the age check. Stale prices return an error with the ErrorStalePrice code.
+func (p AccountPrice) ValidateBasic(now time.Time, maxAge time.Duration) error {
+	if p.Value == nil || p.Value.Sign() <= 0 {
+		return fmt.Errorf("price must be positive")
+	}
+
+	if maxAge == 0 || p.Timestamp == 0 {
+		return nil
+	}
+
+	updatedAt := time.Unix(p.Timestamp, 0)
+	if age := now.Sub(updatedAt); age > maxAge {
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("price updated at %s is older than max age %s", updatedAt.UTC(), maxAge),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	return nil
+}
+
+// ScalePrice returns the price scaled by its exponent. An error is returned if the exponent is
+// out of range.
+func (p AccountPrice) ScalePrice() (*big.Float, error) {
+	price, err := math.NewPrice(p.Value, p.Exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
+
+// Field is an integer at a fixed offset of the account data.
+type Field struct {
+	// Offset is the offset, in bytes, of the field from the start of the account data.
+	Offset uint64 `json:"offset"`
+	// Type is the integer type of the field. Must be one of i32, u32, i64, u64, i128 or u128.
+	Type string `json:"type"`
+}
+
+// ValidateBasic validates the field.
+func (f Field) ValidateBasic() error {
+	if _, ok := fieldSizes[f.Type]; !ok {
+		return fmt.Errorf("unknown field type: %s", f.Type)
+	}
+
+	return nil
+}
+
+// Read reads the field from the given account data, starting at the given base offset.
+func (f Field) Read(data []byte, base uint64) (*big.Int, error) {
+	size, ok := fieldSizes[f.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown field type: %s", f.Type)
+	}
+
+	start := base + f.Offset
+	if start+size > uint64(len(data)) {
+		return nil, fmt.Errorf("account data of %d bytes is too short to read %s at offset %d", len(data), f.Type, start)
+	}
+
+	// Solana programs encode integers in little-endian, whereas big.Int expects big-endian.
+	bz := make([]byte, size)
+	for i := range bz {
+		bz[i] = data[start+size-1-uint64(i)]
+	}
+
+	value := new(big.Int).SetBytes(bz)
+	if f.Type[0] == 'i' && bz[0]&0x80 != 0 {
+		// Two's complement of a negative signed integer.
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+	}
+
+	return value, nil
+}
+
+var fieldSizes = map[string]uint64{
+	FieldI32:  4,
+	FieldU32:  4,
+	FieldI64:  8,
+	FieldU64:  8,
+	FieldI128: 16,
+	FieldU128: 16,
+}
+
+// FieldLayout decodes a price from fields at fixed offsets of the account data.
+type FieldLayout struct {
+	// Price is the field of the unscaled price.
+	Price Field `json:"price"`
+
+	// Exponent is the field of the power of ten the price is multiplied by. If unset, the price
+	// is divided by 10^Decimals instead.
+	Exponent *Field `json:"exponent,omitempty"`
+
+	// NegateExponent negates the exponent read from the account, for accounts that store the
+	// number of decimals (or scale) of the price rather than its exponent.
+	NegateExponent bool `json:"negate_exponent"`
+
+	// Decimals is the number of decimals of the price, used if the exponent is unset.
+	Decimals int64 `json:"decimals"`
+
+	// Timestamp is the field of the unix timestamp at which the price was last updated. If unset,
+	// the age of the price is not checked.
+	Timestamp *Field `json:"timestamp,omitempty"`
+}
+
+// ValidateBasic validates the field layout.
+func (l FieldLayout) ValidateBasic() error {
+	if err := l.Price.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid price field: %w", err)
+	}
+
+	if l.Exponent != nil {
+		if err := l.Exponent.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid exponent field: %w", err)
+		}
+
+		if l.Decimals != 0 {
+			return fmt.Errorf("decimals cannot be set with an exponent field")
+		}
+	}
+
+	if l.Decimals < 0 {
+		return fmt.Errorf("decimals must be non-negative")
+	}
+
+	if l.Timestamp != nil {
+		if err := l.Timestamp.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid timestamp field: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Decode decodes the price from the given account data.
+func (l FieldLayout) Decode(data []byte) (AccountPrice, error) {
+	return l.decodeAt(data, 0)
+}
+
+// decodeAt decodes the price from the given account data, with the offsets of all fields relative
+// to the given base offset.
+func (l FieldLayout) decodeAt(data []byte, base uint64) (AccountPrice, error) {
+	value, err := l.Price.Read(data, base)
+	if err != nil {
+		return AccountPrice{}, fmt.Errorf("failed to read price: %w", err)
+	}
+
+	exponent := -l.Decimals
+	if l.Exponent != nil {
+		v, err := l.Exponent.Read(data, base)
+		if err != nil {
+			return AccountPrice{}, fmt.Errorf("failed to read exponent: %w", err)
+		}
+
+		if !v.IsInt64() {
+			return AccountPrice{}, fmt.Errorf("exponent %s is out of range", v)
+		}
+
+		exponent = v.Int64()
+		if l.NegateExponent {
+			exponent = -exponent
+		}
+	}
+
+	var timestamp int64
+	if l.Timestamp != nil {
+		v, err := l.Timestamp.Read(data, base)
+		if err != nil {
+			return AccountPrice{}, fmt.Errorf("failed to read timestamp: %w", err)
+		}
+
+		if !v.IsInt64() {
+			return AccountPrice{}, fmt.Errorf("timestamp %s is out of range", v)
+		}
+
+		timestamp = v.Int64()
+	}
+
+	return AccountPrice{
+		Value:     value,
+		Exponent:  exponent,
+		Timestamp: timestamp,
+	}, nil
+}
+
+const (
+	// pythMagic is the magic number at the start of every Pyth oracle account.
+	pythMagic = 0xa1b2c3d4
+	// pythPriceAccountType is the account type of a Pyth price account.
+	pythPriceAccountType = 3
+	// pythStatusTrading is the status of a Pyth aggregate price that is valid.
+	pythStatusTrading = 1
+)
+
+// pythFields are the fields of a Pyth price account: the exponent (expo), the last update time
+// (timestamp) and the aggregate price (agg.price).
+var pythFields = FieldLayout{
+	Price:     Field{Offset: 208, Type: FieldI64},
+	Exponent:  &Field{Offset: 20, Type: FieldI32},
+	Timestamp: &Field{Offset: 96, Type: FieldI64},
+}
+
+// PythLayout decodes the aggregate price of a Pyth price account. Prices whose aggregate status
+// is not trading are rejected.
+type PythLayout struct{}
+
+// Decode decodes the price from the given account data.
+func (PythLayout) Decode(data []byte) (AccountPrice, error) {
+	if len(data) < 240 {
+		return AccountPrice{}, fmt.Errorf("account data of %d bytes is too short for a pyth price account", len(data))
+	}
+
+	if magic := binary.LittleEndian.Uint32(data[0:4]); magic != pythMagic {
+		return AccountPrice{}, fmt.Errorf("invalid pyth magic number: %x", magic)
+	}
+
+	if accountType := binary.LittleEndian.Uint32(data[8:12]); accountType != pythPriceAccountType {
+		return AccountPrice{}, fmt.Errorf("pyth account of type %d is not a price account", accountType)
+	}
+
+	if status := binary.LittleEndian.Uint32(data[224:228]); status != pythStatusTrading {
+		return AccountPrice{}, fmt.Errorf("pyth price has status %d and is not trading", status)
+	}
+
+	return pythFields.Decode(data)
+}
+
+// pythPullFields are the fields of the price feed message of a Pyth PriceUpdateV2 account,
+// relative to the start of the message.
+var pythPullFields = FieldLayout{
+	Price:     Field{Offset: 32, Type: FieldI64},
+	Exponent:  &Field{Offset: 48, Type: FieldI32},
+	Timestamp: &Field{Offset: 52, Type: FieldI64},
+}
+
+// pythPullDiscriminator is the anchor discriminator of a PriceUpdateV2 account.
+var pythPullDiscriminator = anchorDiscriminator("PriceUpdateV2")
+
+// PythPullLayout decodes the price of a Pyth PriceUpdateV2 account. Only price updates that were
+// fully verified by the Wormhole guardians are accepted.
+type PythPullLayout struct{}
+
+// Decode decodes the price from the given account data.
+func (PythPullLayout) Decode(data []byte) (AccountPrice, error) {
+	// discriminator (8) + write authority (32) + verification level (1 or 2)
+	if len(data) < 41 {
+		return AccountPrice{}, fmt.Errorf("account data of %d bytes is too short for a pyth price update account", len(data))
+	}
+
+	if !bytes.Equal(data[:8], pythPullDiscriminator) {
+		return AccountPrice{}, fmt.Errorf("account is not a pyth price update account")
+	}
+
+	// The verification level is an enum of Partial { num_signatures: u8 } and Full.
+	if level := data[40]; level != 1 {
+		return AccountPrice{}, fmt.Errorf("pyth price update is not fully verified")
+	}
+
+	return pythPullFields.decodeAt(data, 41)
+}
+
+// switchboardFields are the fields of the latest confirmed round of a Switchboard V2 aggregator
+// account: the round open timestamp and the mantissa and scale of the result.
+var switchboardFields = FieldLayout{
+	Price:          Field{Offset: 366, Type: FieldI128},
+	Exponent:       &Field{Offset: 382, Type: FieldU32},
+	NegateExponent: true,
+	Timestamp:      &Field{Offset: 358, Type: FieldI64},
+}
+
+// switchboardDiscriminator is the anchor discriminator of an AggregatorAccountData account.
+var switchboardDiscriminator = anchorDiscriminator("AggregatorAccountData")
+
+// SwitchboardLayout decodes the result of the latest confirmed round of a Switchboard V2
+// aggregator account.
+type SwitchboardLayout struct{}
+
+// Decode decodes the price from the given account data.
+func (SwitchboardLayout) Decode(data []byte) (AccountPrice, error) {
+	if len(data) < 8 || !bytes.Equal(data[:8], switchboardDiscriminator) {
+		return AccountPrice{}, fmt.Errorf("account is not a switchboard aggregator account")
+	}
+
+	return switchboardFields.Decode(data)
+}
+
+// anchorDiscriminator returns the discriminator anchor prefixes the data of accounts of the given
+// type with.
+func anchorDiscriminator(name string) []byte {
+	hash := sha256.Sum256([]byte("account:" + name))
+	return hash[:8]
+}
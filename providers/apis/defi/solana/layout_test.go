@@ -0,0 +1,248 @@
+package solana_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/solana"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+func TestFieldRead(t *testing.T) {
+	data := make([]byte, 32)
+	binary.LittleEndian.PutUint32(data[0:4], uint32(0xfffffff8)) // -8 as i32
+	binary.LittleEndian.PutUint64(data[4:12], 123456789)
+	putInt128(data[12:28], big.NewInt(-42))
+
+	testCases := []struct {
+		name     string
+		field    solana.Field
+		expected *big.Int
+		err      bool
+	}{
+		{
+			name:     "negative i32",
+			field:    solana.Field{Offset: 0, Type: solana.FieldI32},
+			expected: big.NewInt(-8),
+		},
+		{
+			name:     "u32",
+			field:    solana.Field{Offset: 0, Type: solana.FieldU32},
+			expected: big.NewInt(0xfffffff8),
+		},
+		{
+			name:     "u64",
+			field:    solana.Field{Offset: 4, Type: solana.FieldU64},
+			expected: big.NewInt(123456789),
+		},
+		{
+			name:     "negative i128",
+			field:    solana.Field{Offset: 12, Type: solana.FieldI128},
+			expected: big.NewInt(-42),
+		},
+		{
+			name:  "out of bounds",
+			field: solana.Field{Offset: 28, Type: solana.FieldI64},
+			err:   true,
+		},
+		{
+			name:  "unknown type",
+			field: solana.Field{Offset: 0, Type: "f64"},
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := tc.field.Read(data, 0)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.String(), value.String())
+		})
+	}
+}
+
+func TestPythLayout(t *testing.T) {
+	now := time.Now().Unix()
+
+	t.Run("decodes the aggregate price", func(t *testing.T) {
+		price, err := solana.PythLayout{}.Decode(pythAccount(6500012345678, -8, 1, now))
+		require.NoError(t, err)
+		require.Equal(t, now, price.Timestamp)
+		scaled, err := price.ScalePrice()
+		require.NoError(t, err)
+		require.Equal(t, big.NewFloat(65000.12345678).SetPrec(40), scaled.SetPrec(40))
+	})
+
+	t.Run("rejects exponents beyond the max exponent when scaling", func(t *testing.T) {
+		price, err := solana.PythLayout{}.Decode(pythAccount(1, -100, 1, now))
+		require.NoError(t, err)
+
+		_, err = price.ScalePrice()
+		require.Error(t, err)
+	})
+
+	t.Run("rejects prices that are not trading", func(t *testing.T) {
+		_, err := solana.PythLayout{}.Decode(pythAccount(6500012345678, -8, 0, now))
+		require.Error(t, err)
+	})
+
+	t.Run("rejects accounts that are not pyth price accounts", func(t *testing.T) {
+		data := pythAccount(6500012345678, -8, 1, now)
+		binary.LittleEndian.PutUint32(data[8:12], 2)
+
+		_, err := solana.PythLayout{}.Decode(data)
+		require.Error(t, err)
+
+		_, err = solana.PythLayout{}.Decode(make([]byte, 3312))
+		require.Error(t, err)
+	})
+}
+
+func TestPythPullLayout(t *testing.T) {
+	now := time.Now().Unix()
+
+	t.Run("decodes a fully verified price update", func(t *testing.T) {
+		price, err := solana.PythPullLayout{}.Decode(pythPullAccount(99985000, -8, now, true))
+		require.NoError(t, err)
+		require.Equal(t, now, price.Timestamp)
+		scaled, err := price.ScalePrice()
+		require.NoError(t, err)
+		require.Equal(t, big.NewFloat(0.99985).SetPrec(40), scaled.SetPrec(40))
+	})
+
+	t.Run("rejects partially verified price updates", func(t *testing.T) {
+		_, err := solana.PythPullLayout{}.Decode(pythPullAccount(99985000, -8, now, false))
+		require.Error(t, err)
+	})
+
+	t.Run("rejects accounts with another discriminator", func(t *testing.T) {
+		_, err := solana.PythPullLayout{}.Decode(make([]byte, 134))
+		require.Error(t, err)
+	})
+}
+
+func TestSwitchboardLayout(t *testing.T) {
+	now := time.Now().Unix()
+
+	price, err := solana.SwitchboardLayout{}.Decode(switchboardAccount(big.NewInt(1512345), 3, now))
+	require.NoError(t, err)
+	require.Equal(t, now, price.Timestamp)
+	scaled, err := price.ScalePrice()
+	require.NoError(t, err)
+	require.Equal(t, big.NewFloat(1512.345).SetPrec(40), scaled.SetPrec(40))
+
+	_, err = solana.SwitchboardLayout{}.Decode(make([]byte, 3851))
+	require.Error(t, err)
+}
+
+func TestFieldLayout(t *testing.T) {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data[8:16], 2500000)
+
+	t.Run("scales by the configured decimals", func(t *testing.T) {
+		layout := solana.FieldLayout{Price: solana.Field{Offset: 8, Type: solana.FieldU64}, Decimals: 6}
+		require.NoError(t, layout.ValidateBasic())
+
+		price, err := layout.Decode(data)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), price.Timestamp)
+		scaled, err := price.ScalePrice()
+		require.NoError(t, err)
+		require.Equal(t, big.NewFloat(2.5).SetPrec(40), scaled.SetPrec(40))
+	})
+
+	t.Run("invalid layouts", func(t *testing.T) {
+		require.Error(t, solana.FieldLayout{Price: solana.Field{Type: "f32"}}.ValidateBasic())
+		require.Error(t, solana.FieldLayout{Price: solana.Field{Type: solana.FieldU64}, Decimals: -1}.ValidateBasic())
+		require.Error(t, solana.FieldLayout{
+			Price:    solana.Field{Type: solana.FieldU64},
+			Exponent: &solana.Field{Type: solana.FieldI32},
+			Decimals: 6,
+		}.ValidateBasic())
+	})
+}
+
+func TestAccountPriceValidateBasic(t *testing.T) {
+	now := time.Now()
+
+	price := solana.AccountPrice{Value: big.NewInt(1), Timestamp: now.Add(-time.Hour).Unix()}
+	require.NoError(t, price.ValidateBasic(now, 0))
+	require.NoError(t, price.ValidateBasic(now, 2*time.Hour))
+
+	err := price.ValidateBasic(now, time.Minute)
+	require.Error(t, err)
+	require.Equal(t, providertypes.ErrorStalePrice, providertypes.ErrorCodeFromError(err, providertypes.ErrorUnknown))
+
+	require.Error(t, solana.AccountPrice{Value: big.NewInt(0)}.ValidateBasic(now, 0))
+	require.Error(t, solana.AccountPrice{Value: big.NewInt(-1)}.ValidateBasic(now, 0))
+}
+
+// pythAccount returns the data of a pyth price account with the given aggregate price.
+func pythAccount(price int64, expo int32, status uint32, timestamp int64) []byte {
+	data := make([]byte, 3312)
+	binary.LittleEndian.PutUint32(data[0:4], 0xa1b2c3d4)
+	binary.LittleEndian.PutUint32(data[4:8], 2)
+	binary.LittleEndian.PutUint32(data[8:12], 3)
+	binary.LittleEndian.PutUint32(data[20:24], uint32(expo))
+	binary.LittleEndian.PutUint64(data[96:104], uint64(timestamp))
+	binary.LittleEndian.PutUint64(data[208:216], uint64(price))
+	binary.LittleEndian.PutUint32(data[224:228], status)
+	return data
+}
+
+// pythPullAccount returns the data of a pyth PriceUpdateV2 account with the given price.
+func pythPullAccount(price int64, expo int32, publishTime int64, full bool) []byte {
+	data := anchorDiscriminator("PriceUpdateV2")
+	data = append(data, make([]byte, 32)...) // write authority
+	if full {
+		data = append(data, 1)
+	} else {
+		data = append(data, 0, 5)
+	}
+
+	message := make([]byte, 84)
+	binary.LittleEndian.PutUint64(message[32:40], uint64(price))
+	binary.LittleEndian.PutUint32(message[48:52], uint32(expo))
+	binary.LittleEndian.PutUint64(message[52:60], uint64(publishTime))
+	data = append(data, message...)
+
+	return append(data, make([]byte, 8)...) // posted slot
+}
+
+// switchboardAccount returns the data of a switchboard aggregator account with the given result.
+func switchboardAccount(mantissa *big.Int, scale uint32, timestamp int64) []byte {
+	data := make([]byte, 3851)
+	copy(data, anchorDiscriminator("AggregatorAccountData"))
+	binary.LittleEndian.PutUint64(data[358:366], uint64(timestamp))
+	putInt128(data[366:382], mantissa)
+	binary.LittleEndian.PutUint32(data[382:386], scale)
+	return data
+}
+
+func anchorDiscriminator(name string) []byte {
+	hash := sha256.Sum256([]byte("account:" + name))
+	return hash[:8]
+}
+
+// putInt128 writes the given value to the buffer as a little-endian two's complement i128.
+func putInt128(buf []byte, value *big.Int) {
+	v := new(big.Int).Set(value)
+	if v.Sign() < 0 {
+		v.Add(v, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+
+	bz := v.FillBytes(make([]byte, 16))
+	for i := range bz {
+		buf[i] = bz[15-i]
+	}
+}
@@ -0,0 +1,126 @@
+package solana
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	solanago "github.com/gagliardetto/solana-go"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+const (
+	// Name is the name of the Solana account data provider.
+	Name = "solana_api"
+
+	// LayoutPyth decodes a Pyth price account, as written by the Pyth oracle program on Solana.
+	LayoutPyth = "pyth"
+
+	// LayoutPythPull decodes a Pyth PriceUpdateV2 account, as posted by the Pyth receiver program.
+	LayoutPythPull = "pyth_pull"
+
+	// LayoutSwitchboard decodes a Switchboard V2 aggregator account.
+	LayoutSwitchboard = "switchboard"
+
+	// LayoutCustom decodes an account with the field offsets given in the feed config.
+	LayoutCustom = "custom"
+)
+
+// FeedConfig is the configuration of a feed read from a Solana account. This is specific to each
+// pair of tokens.
+type FeedConfig struct {
+	// Account is the base58 encoded address of the account to read the price from.
+	Account string `json:"account"`
+
+	// Layout is the layout the account data is decoded with. Must be one of pyth, pyth_pull,
+	// switchboard or custom.
+	Layout string `json:"layout"`
+
+	// Fields are the field offsets of the account data. These are required by the custom layout,
+	// and must be unset otherwise.
+	Fields *FieldLayout `json:"fields,omitempty"`
+
+	// MaxAge is the maximum age, in seconds, of the price. This is only enforced for layouts that
+	// have a timestamp. If unset, the age of the price is not checked.
+	MaxAge int64 `json:"max_age"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if _, err := solanago.PublicKeyFromBase58(fc.Account); err != nil {
+		return fmt.Errorf("invalid account %s: %w", fc.Account, err)
+	}
+
+	if fc.MaxAge < 0 {
+		return fmt.Errorf("max age must be non-negative")
+	}
+
+	switch fc.Layout {
+	case LayoutPyth, LayoutPythPull, LayoutSwitchboard:
+		if fc.Fields != nil {
+			return fmt.Errorf("fields can only be set for the %s layout", LayoutCustom)
+		}
+	case LayoutCustom:
+		if fc.Fields == nil {
+			return fmt.Errorf("fields are required for the %s layout", LayoutCustom)
+		}
+
+		return fc.Fields.ValidateBasic()
+	default:
+		return fmt.Errorf("unknown layout: %s", fc.Layout)
+	}
+
+	return nil
+}
+
+// GetLayout returns the layout the account data of the feed is decoded with.
+func (fc *FeedConfig) GetLayout() Layout {
+	switch fc.Layout {
+	case LayoutPyth:
+		return PythLayout{}
+	case LayoutPythPull:
+		return PythPullLayout{}
+	case LayoutSwitchboard:
+		return SwitchboardLayout{}
+	default:
+		return *fc.Fields
+	}
+}
+
+// GetMaxAge returns the maximum age of the price, or zero if the age is not checked.
+func (fc *FeedConfig) GetMaxAge() time.Duration {
+	return time.Duration(fc.MaxAge) * time.Second
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// SolanaJSONRPCError is returned when there is an error querying the solana JSON-RPC client.
+func SolanaJSONRPCError(err error) error {
+	return fmt.Errorf("solana json-rpc error: %s", err.Error())
+}
+
+// DefaultAPIConfig is the default configuration for the Solana account data provider.
+var DefaultAPIConfig = config.APIConfig{
+	Enabled:          true,
+	Name:             Name,
+	Timeout:          2 * time.Second,
+	Interval:         500 * time.Millisecond,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       10,
+	Atomic:           false,
+	BatchSize:        100, // maximal # of accounts in getMultipleAccounts query is 100
+	Endpoints: []config.Endpoint{
+		{
+			URL: "https://api.mainnet-beta.solana.com",
+		},
+	},
+	MaxBlockHeightAge: 30 * time.Second,
+}
@@ -0,0 +1,129 @@
+package subgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/rest"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	"github.com/skip-mev/connect/v2/providers/base/polling"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// NewPriceFetcher returns a new subgraph price fetcher. The fetcher executes the GraphQL query
+// configured by each ticker's QueryConfig, and selects the price from the data of the result by
+// the configured path. Tickers are queried concurrently by a polling fetcher.
+func NewPriceFetcher(
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+	client *http.Client,
+) (*polling.PriceFetcher[QueryConfig], error) {
+	if !strings.HasPrefix(api.Name, BaseName) {
+		return nil, fmt.Errorf("invalid api config name; expected %s, got %s", BaseName, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("metrics cannot be nil")
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	q := &querier{
+		api:     api,
+		metrics: apiMetrics,
+		client:  client,
+	}
+
+	return polling.NewPriceFetcher(logger, api, q.fetch)
+}
+
+// querier queries the price of a single ticker.
+type querier struct {
+	api     config.APIConfig
+	metrics metrics.APIMetrics
+	client  *http.Client
+}
+
+// fetch executes the query of the ticker and selects the price from the result.
+func (q *querier) fetch(ctx context.Context, _ types.ProviderTicker, qc QueryConfig) (*big.Float, error) {
+	ctx, cancel := context.WithTimeout(ctx, q.api.Timeout)
+	defer cancel()
+
+	body, err := json.Marshal(GraphQLRequest{Query: qc.Query, Variables: qc.Variables})
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorUnableToCreateURL)
+	}
+
+	url := q.api.Endpoints[0].URL
+	if len(qc.Subgraph) > 0 {
+		url = strings.TrimSuffix(url, "/") + "/" + qc.Subgraph
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorUnableToCreateURL)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := q.client.Do(req)
+	q.metrics.AddHTTPStatusCode(q.api.Name, resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, providertypes.NewErrorWithCode(fmt.Errorf("rate limited"), providertypes.ErrorRateLimitExceeded)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("unexpected status code %d", resp.StatusCode),
+			providertypes.ErrorCode(resp.StatusCode),
+		)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+
+	var result GraphQLResponse
+	if err := decoder.Decode(&result); err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode)
+	}
+
+	// GraphQL endpoints report errors in the result, e.g. for invalid queries or subgraphs that
+	// failed to index, alongside partial data if any.
+	if len(result.Errors) > 0 {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("query failed: %s", result.Errors[0].Message),
+			providertypes.ErrorInvalidResponse,
+		)
+	}
+
+	value, err := rest.SelectPath(result.Data, qc.Path)
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse)
+	}
+
+	price, err := rest.ParsePrice(value, qc.Scale, qc.Invert)
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice)
+	}
+
+	return price, nil
+}
@@ -0,0 +1,185 @@
+package subgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/subgraph"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+const poolQuery = `query pool($id: ID!) { pool(id: $id) { token0Price token1Price } }`
+
+func newAPIConfig(url string) config.APIConfig {
+	api := subgraph.DefaultAPIConfig
+	api.Timeout = time.Second
+	api.Endpoints = []config.Endpoint{{URL: url}}
+	return api
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req subgraph.GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path != "/uniswap":
+			fmt.Fprint(w, `{"errors": [{"message": "subgraph not found"}]}`)
+		case req.Variables["id"] == "0xpool":
+			fmt.Fprint(w, `{"data": {"pool": {"token0Price": "2500.5", "token1Price": "0.0004"}}}`)
+		default:
+			fmt.Fprint(w, `{"data": {"pool": null}}`)
+		}
+	}))
+	defer server.Close()
+
+	ticker := func(offChain string, qc subgraph.QueryConfig) types.ProviderTicker {
+		return types.NewProviderTicker(offChain, qc.MustToJSON())
+	}
+
+	var (
+		eth = ticker("ETH/USDC", subgraph.QueryConfig{
+			Subgraph:  "uniswap",
+			Query:     poolQuery,
+			Variables: map[string]interface{}{"id": "0xpool"},
+			Path:      "pool.token0Price",
+		})
+		inverted = ticker("USDC/ETH", subgraph.QueryConfig{
+			Subgraph:  "uniswap",
+			Query:     poolQuery,
+			Variables: map[string]interface{}{"id": "0xpool"},
+			Path:      "pool.token1Price",
+			Invert:    true,
+		})
+		missing = ticker("BTC/USDC", subgraph.QueryConfig{
+			Subgraph:  "uniswap",
+			Query:     poolQuery,
+			Variables: map[string]interface{}{"id": "0xmissing"},
+			Path:      "pool.token0Price",
+		})
+		failed = ticker("SOL/USDC", subgraph.QueryConfig{
+			Subgraph: "unknown",
+			Query:    poolQuery,
+			Path:     "pool.token0Price",
+		})
+		limited = ticker("ATOM/USDC", subgraph.QueryConfig{
+			Subgraph: "limited",
+			Query:    poolQuery,
+			Path:     "pool.token0Price",
+		})
+		invalid = ticker("TIA/USDC", subgraph.QueryConfig{
+			Subgraph: "uniswap",
+			Path:     "pool.token0Price",
+		})
+	)
+
+	fetcher, err := subgraph.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), newAPIConfig(server.URL), server.Client())
+	require.NoError(t, err)
+
+	response := fetcher.Fetch(context.Background(), []types.ProviderTicker{eth, inverted, missing, failed, limited, invalid})
+
+	require.Len(t, response.Resolved, 2)
+	price, _ := response.Resolved[eth].Value.Float64()
+	require.InDelta(t, 2500.5, price, 1e-9)
+	price, _ = response.Resolved[inverted].Value.Float64()
+	require.InDelta(t, 2500, price, 1e-9)
+
+	expectedCodes := map[types.ProviderTicker]providertypes.ErrorCode{
+		missing: providertypes.ErrorInvalidResponse,
+		failed:  providertypes.ErrorInvalidResponse,
+		limited: providertypes.ErrorRateLimitExceeded,
+		invalid: providertypes.ErrorFailedToDecode,
+	}
+	require.Len(t, response.UnResolved, len(expectedCodes))
+	for tkr, code := range expectedCodes {
+		require.Contains(t, response.UnResolved, tkr)
+		require.Equal(t, code, response.UnResolved[tkr].Code(), tkr.String())
+	}
+}
+
+func TestNewPriceFetcher(t *testing.T) {
+	_, err := subgraph.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), subgraph.DefaultAPIConfig, http.DefaultClient)
+	require.NoError(t, err)
+
+	misnamed := subgraph.DefaultAPIConfig
+	misnamed.Name = "rest_api"
+	_, err = subgraph.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), misnamed, http.DefaultClient)
+	require.Error(t, err)
+
+	_, err = subgraph.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), subgraph.DefaultAPIConfig, nil)
+	require.Error(t, err)
+}
+
+func TestQueryConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		qc   subgraph.QueryConfig
+		err  bool
+	}{
+		{
+			name: "valid",
+			qc:   subgraph.QueryConfig{Subgraph: "5zvR82QoaXYFyDEKLZ9t6v9adgnptxYpKpSbxtgVENFV", Query: poolQuery, Path: "pool.token0Price"},
+		},
+		{
+			name: "valid without a subgraph",
+			qc:   subgraph.QueryConfig{Query: poolQuery, Path: "pool.token0Price", Scale: 1e-6},
+		},
+		{
+			name: "subgraph is a path",
+			qc:   subgraph.QueryConfig{Subgraph: "id/5zvR82", Query: poolQuery, Path: "pool.token0Price"},
+			err:  true,
+		},
+		{
+			name: "empty query",
+			qc:   subgraph.QueryConfig{Query: " ", Path: "pool.token0Price"},
+			err:  true,
+		},
+		{
+			name: "empty path",
+			qc:   subgraph.QueryConfig{Query: poolQuery},
+			err:  true,
+		},
+		{
+			name: "empty path element",
+			qc:   subgraph.QueryConfig{Query: poolQuery, Path: "pool."},
+			err:  true,
+		},
+		{
+			name: "negative scale",
+			qc:   subgraph.QueryConfig{Query: poolQuery, Path: "pool.token0Price", Scale: -1},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.qc.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
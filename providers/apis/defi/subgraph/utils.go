@@ -0,0 +1,117 @@
+package subgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/rest"
+)
+
+const (
+	// BaseName is the name of the subgraph API. Several instances of the provider can be
+	// configured, e.g. for different GraphQL endpoints, by suffixing the name with NameSeparator
+	// and any identifier, e.g. subgraph_api-goldsky.
+	BaseName = "subgraph_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// URL is the URL of The Graph's decentralized network gateway. Subgraphs are queried at
+	// URL/<subgraph id>, and requests must be authenticated with an API key.
+	URL = "https://gateway.thegraph.com/api/subgraphs/id"
+)
+
+// DefaultAPIConfig is the default configuration for the subgraph API, which queries subgraphs via
+// The Graph's gateway.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             BaseName,
+	Atomic:           false,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         15 * time.Second,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+// QueryConfig is the configuration of the GraphQL query that fetches the price of a ticker, and of
+// where the price is in the result. This is specific to each ticker, and allows any subgraph that
+// indexes prices, e.g. the pools of Uniswap or Balancer, to be used as a price source.
+type QueryConfig struct {
+	// Subgraph is the ID of the subgraph to query, which is appended to the URL of the endpoint.
+	// If unset, the endpoint itself is queried, e.g. for hosted GraphQL endpoints.
+	Subgraph string `json:"subgraph,omitempty"`
+	// Query is the GraphQL query. Values specific to the ticker, such as the ID of a pool, should
+	// be passed as variables rather than templated into the query.
+	Query string `json:"query"`
+	// Variables are the variables of the query.
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	// Path is the path of the price in the data of the result. Object fields are selected by key
+	// and array elements by index, separated by dots, e.g. pool.token0Price. The selected value
+	// must be a number or a string containing a number, as subgraphs return BigDecimals.
+	Path string `json:"path"`
+	// Scale is the factor the selected value is multiplied by. If unset, the value is not scaled.
+	Scale float64 `json:"scale,omitempty"`
+	// Invert is true if the scaled value should be inverted, i.e. the subgraph reports the price
+	// of the quote in units of the base.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// ValidateBasic validates the query configuration.
+func (qc *QueryConfig) ValidateBasic() error {
+	if strings.Contains(qc.Subgraph, "/") {
+		return fmt.Errorf("subgraph must be a subgraph id, got %s", qc.Subgraph)
+	}
+
+	if len(strings.TrimSpace(qc.Query)) == 0 {
+		return fmt.Errorf("query cannot be empty")
+	}
+
+	if len(qc.Path) == 0 {
+		return fmt.Errorf("path cannot be empty")
+	}
+
+	for _, elem := range strings.Split(qc.Path, rest.PathSeparator) {
+		if len(elem) == 0 {
+			return fmt.Errorf("path %s has an empty element", qc.Path)
+		}
+	}
+
+	if qc.Scale < 0 {
+		return fmt.Errorf("scale must be non-negative")
+	}
+
+	return nil
+}
+
+// MustToJSON converts the query configuration to JSON.
+func (qc QueryConfig) MustToJSON() string {
+	b, err := json.Marshal(qc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+type (
+	// GraphQLRequest is the body of a GraphQL request.
+	GraphQLRequest struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}
+
+	// GraphQLResponse is the body of a GraphQL response. The data is decoded into a generic value
+	// from which the price is selected by path.
+	GraphQLResponse struct {
+		Data   interface{}    `json:"data"`
+		Errors []GraphQLError `json:"errors,omitempty"`
+	}
+
+	// GraphQLError is an error returned by a GraphQL endpoint.
+	GraphQLError struct {
+		Message string `json:"message"`
+	}
+)
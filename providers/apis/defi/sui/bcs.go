@@ -0,0 +1,245 @@
+package sui
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The transactions sent to sui_devInspectTransactionBlock are BCS encoded TransactionKinds. Only
+// the subset of BCS that is needed to call a move function with shared object arguments is
+// implemented here. See https://docs.sui.io/concepts/transactions/transaction-serialization.
+
+// Type tag variants.
+const (
+	typeTagBool    = 0
+	typeTagU8      = 1
+	typeTagU64     = 2
+	typeTagU128    = 3
+	typeTagAddress = 4
+	typeTagVector  = 6
+	typeTagStruct  = 7
+	typeTagU16     = 8
+	typeTagU32     = 9
+	typeTagU256    = 10
+)
+
+var primitiveTypeTags = map[string]byte{
+	"bool":    typeTagBool,
+	"u8":      typeTagU8,
+	"u16":     typeTagU16,
+	"u32":     typeTagU32,
+	"u64":     typeTagU64,
+	"u128":    typeTagU128,
+	"u256":    typeTagU256,
+	"address": typeTagAddress,
+}
+
+// identifierRegex matches a move identifier, i.e. a module or struct name.
+var identifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ParseTypeTag parses a move type, e.g. 0x2::sui::SUI or vector<u8>, into its BCS encoded type tag.
+func ParseTypeTag(typ string) ([]byte, error) {
+	typ = strings.TrimSpace(typ)
+	if tag, ok := primitiveTypeTags[typ]; ok {
+		return []byte{tag}, nil
+	}
+
+	if inner, ok := cutGeneric(typ, "vector"); ok {
+		tag, err := ParseTypeTag(inner)
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte{typeTagVector}, tag...), nil
+	}
+
+	name, params := typ, ""
+	if i := strings.Index(typ, "<"); i >= 0 {
+		if !strings.HasSuffix(typ, ">") {
+			return nil, fmt.Errorf("unbalanced type parameters in %s", typ)
+		}
+
+		name, params = typ[:i], typ[i+1:len(typ)-1]
+	}
+
+	parts := strings.Split(name, "::")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected a struct type of the form address::module::name, got %s", typ)
+	}
+
+	address, err := encodeAddress(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identifier := range parts[1:] {
+		if !identifierRegex.MatchString(identifier) {
+			return nil, fmt.Errorf("invalid identifier %s in %s", identifier, typ)
+		}
+	}
+
+	typeParams, err := splitTypeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	bz := append([]byte{typeTagStruct}, address...)
+	bz = append(bz, encodeString(parts[1])...)
+	bz = append(bz, encodeString(parts[2])...)
+	bz = append(bz, encodeULEB128(uint64(len(typeParams)))...)
+	for _, param := range typeParams {
+		tag, err := ParseTypeTag(param)
+		if err != nil {
+			return nil, err
+		}
+
+		bz = append(bz, tag...)
+	}
+
+	return bz, nil
+}
+
+// SharedObject is a shared object argument of a move call.
+type SharedObject struct {
+	ID                   string
+	InitialSharedVersion uint64
+}
+
+// EncodeMoveCall encodes a programmable transaction that calls the given move function with the
+// given type arguments and immutable shared object arguments.
+func EncodeMoveCall(
+	pkg, module, function string,
+	typeArgs []string,
+	objects []SharedObject,
+) ([]byte, error) {
+	// TransactionKind::ProgrammableTransaction
+	bz := []byte{0}
+
+	// inputs: CallArg::Object(ObjectArg::SharedObject { id, initial_shared_version, mutable })
+	bz = append(bz, encodeULEB128(uint64(len(objects)))...)
+	for _, object := range objects {
+		id, err := encodeAddress(object.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		bz = append(bz, 1, 1)
+		bz = append(bz, id...)
+		bz = append(bz, encodeU64(object.InitialSharedVersion)...)
+		bz = append(bz, 0)
+	}
+
+	// commands: Command::MoveCall(ProgrammableMoveCall)
+	pkgID, err := encodeAddress(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	bz = append(bz, 1, 0)
+	bz = append(bz, pkgID...)
+	bz = append(bz, encodeString(module)...)
+	bz = append(bz, encodeString(function)...)
+	bz = append(bz, encodeULEB128(uint64(len(typeArgs)))...)
+	for _, typeArg := range typeArgs {
+		tag, err := ParseTypeTag(typeArg)
+		if err != nil {
+			return nil, err
+		}
+
+		bz = append(bz, tag...)
+	}
+
+	// arguments: Argument::Input(index)
+	bz = append(bz, encodeULEB128(uint64(len(objects)))...)
+	for i := range objects {
+		bz = append(bz, 1, byte(i), byte(i>>8))
+	}
+
+	return bz, nil
+}
+
+// cutGeneric returns the type parameter of a type of the form name<param>.
+func cutGeneric(typ, name string) (string, bool) {
+	inner, ok := strings.CutPrefix(typ, name+"<")
+	if !ok || !strings.HasSuffix(inner, ">") {
+		return "", false
+	}
+
+	return inner[:len(inner)-1], true
+}
+
+// splitTypeParams splits a comma separated list of type parameters, which may be generic
+// themselves.
+func splitTypeParams(params string) ([]string, error) {
+	if strings.TrimSpace(params) == "" {
+		return nil, nil
+	}
+
+	var (
+		out   []string
+		depth int
+		start int
+	)
+	for i, c := range params {
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced type parameters in %s", params)
+			}
+		case ',':
+			if depth == 0 {
+				out = append(out, params[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced type parameters in %s", params)
+	}
+
+	return append(out, params[start:]), nil
+}
+
+// encodeAddress encodes a hex encoded address or object ID as 32 bytes.
+func encodeAddress(address string) ([]byte, error) {
+	if !objectIDRegex.MatchString(address) {
+		return nil, fmt.Errorf("invalid address: %s", address)
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(NormalizeObjectID(address), "0x"))
+}
+
+// encodeString encodes a string as its ULEB128 encoded length followed by its bytes.
+func encodeString(s string) []byte {
+	return append(encodeULEB128(uint64(len(s))), s...)
+}
+
+// encodeU64 encodes a u64 in little-endian.
+func encodeU64(v uint64) []byte {
+	bz := make([]byte, 8)
+	for i := range bz {
+		bz[i] = byte(v >> (8 * i))
+	}
+
+	return bz
+}
+
+// encodeULEB128 encodes an unsigned integer in ULEB128, as used for the lengths of sequences.
+func encodeULEB128(v uint64) []byte {
+	var bz []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(bz, b)
+		}
+
+		bz = append(bz, b|0x80)
+	}
+}
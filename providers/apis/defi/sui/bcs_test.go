@@ -0,0 +1,117 @@
+package sui_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/sui"
+)
+
+// suiTypeTag is the BCS encoded type tag of 0x2::sui::SUI.
+var suiTypeTag = "07" + strings.Repeat("00", 31) + "02" + "03737569" + "03535549" + "00"
+
+func TestParseTypeTag(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "primitive",
+			typ:      "u64",
+			expected: "02",
+		},
+		{
+			name:     "vector",
+			typ:      "vector<u8>",
+			expected: "0601",
+		},
+		{
+			name:     "struct",
+			typ:      "0x2::sui::SUI",
+			expected: suiTypeTag,
+		},
+		{
+			name:     "struct with full address",
+			typ:      "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI",
+			expected: suiTypeTag,
+		},
+		{
+			name: "generic struct",
+			typ:  "0x2::balance::Balance<0x2::sui::SUI>",
+			expected: "07" + strings.Repeat("00", 31) + "02" + "0762616c616e6365" + "0742616c616e6365" + "01" +
+				suiTypeTag,
+		},
+		{
+			name: "struct with multiple type parameters",
+			typ:  "0x2::a::B<vector<u8>, 0x2::sui::SUI>",
+			expected: "07" + strings.Repeat("00", 31) + "02" + "0161" + "0142" + "02" +
+				"0601" + suiTypeTag,
+		},
+		{
+			name: "missing module",
+			typ:  "0x2::SUI",
+			err:  true,
+		},
+		{
+			name: "invalid address",
+			typ:  "0xzz::sui::SUI",
+			err:  true,
+		},
+		{
+			name: "invalid identifier",
+			typ:  "0x2::sui::S-UI",
+			err:  true,
+		},
+		{
+			name: "unbalanced type parameters",
+			typ:  "0x2::a::B<0x2::sui::SUI",
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tag, err := sui.ParseTypeTag(tc.typ)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, hex.EncodeToString(tag))
+		})
+	}
+}
+
+func TestEncodeMoveCall(t *testing.T) {
+	tx, err := sui.EncodeMoveCall(
+		"0xdee9",
+		"pool",
+		"mid_price",
+		[]string{"0x2::sui::SUI"},
+		[]sui.SharedObject{
+			{ID: "0xabc", InitialSharedVersion: 300},
+			{ID: sui.ClockObjectID, InitialSharedVersion: sui.ClockInitialSharedVersion},
+		},
+	)
+	require.NoError(t, err)
+
+	expected := "00" + // ProgrammableTransaction
+		"02" + // two inputs
+		"0101" + strings.Repeat("00", 30) + "0abc" + "2c01000000000000" + "00" + // shared pool
+		"0101" + strings.Repeat("00", 31) + "06" + "0100000000000000" + "00" + // shared clock
+		"01" + // one command
+		"00" + strings.Repeat("00", 30) + "dee9" + // move call to the package
+		"04706f6f6c" + "096d69645f7072696365" + // pool::mid_price
+		"01" + suiTypeTag + // type arguments
+		"02" + "010000" + "010100" // arguments
+	require.Equal(t, expected, hex.EncodeToString(tx))
+
+	_, err = sui.EncodeMoveCall("0xdee9", "pool", "mid_price", []string{"SUI"}, nil)
+	require.Error(t, err)
+}
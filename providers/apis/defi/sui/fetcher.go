@@ -0,0 +1,449 @@
+package sui
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// devInspectSender is the sender of the transactions that are inspected. Inspected transactions
+// are not executed, so any address can be used.
+var devInspectSender = NormalizeObjectID("0x0")
+
+// PriceFetcher is the Sui price fetcher. This fetcher reads prices from objects on Sui over the
+// full node JSON-RPC API. Pyth PriceInfoObjects and objects storing a price in one of their fields
+// are read with a single sui_multiGetObjects call, and the mid-prices of DeepBook pools are read
+// by inspecting a call to the pool's mid_price function.
+//
+// Sui uses JSON-RPC 2.0, so the batched JSON-RPC client of the EVM providers is used to talk to
+// the full node.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the JSON-RPC client used to query the full node.
+	client ethmulticlient.EVMClient
+
+	mtx sync.Mutex
+	// feedCache is a cache of the tickers to feed configs.
+	feedCache map[types.ProviderTicker]FeedConfig
+	// versionCache is a cache of the initial shared versions of DeepBook pools, which are needed
+	// to pass the pools to the mid_price function. This never changes once a pool is shared.
+	versionCache map[string]uint64
+}
+
+// NewPriceFetcher returns a new Sui PriceFetcher. A single endpoint is queried directly, while
+// multiple endpoints are queried in priority order, failing over to the next endpoint on errors.
+func NewPriceFetcher(
+	ctx context.Context,
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context cannot be nil")
+	}
+
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("api metrics is nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if api.Name != Name {
+		return nil, fmt.Errorf("invalid api config name; expected %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	var (
+		client ethmulticlient.EVMClient
+		err    error
+	)
+	switch {
+	case len(api.Endpoints) > 1:
+		client, err = ethmulticlient.NewFailoverRPCClientFromEndpoints(ctx, logger, api, apiMetrics)
+	case len(api.Endpoints) == 1:
+		client, err = ethmulticlient.NewGoEthereumClientImpl(ctx, apiMetrics, api, 0)
+	default:
+		err = fmt.Errorf("no endpoints were provided")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if api.Retry.Enabled() {
+		client = ethmulticlient.NewRetryRPCClient(logger, api, client)
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new Sui PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client ethmulticlient.EVMClient,
+) (*PriceFetcher, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if api.Name != Name {
+		return nil, fmt.Errorf("invalid api config name; expected %s, got %s", Name, api.Name)
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	return &PriceFetcher{
+		logger:       logger.With(zap.String("fetcher", api.Name)),
+		api:          api,
+		client:       client,
+		feedCache:    make(map[types.ProviderTicker]FeedConfig),
+		versionCache: make(map[string]uint64),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. The objects of all pyth and path tickers are
+// read in a single call. The first time a DeepBook pool is fetched, its initial shared version is
+// read alongside these objects, after which the mid-prices of all DeepBook tickers are inspected in
+// a second batch.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+
+		objectTickers []types.ProviderTicker
+		objectIDs     []string
+		poolTickers   []types.ProviderTicker
+		poolIDs       []string
+		feeds         = make(map[types.ProviderTicker]FeedConfig, len(tickers))
+		queuedPools   = make(map[string]bool)
+	)
+
+	for _, ticker := range tickers {
+		feed, err := f.GetFeed(ticker)
+		if err != nil {
+			f.logger.Debug("failed to get feed for ticker", zap.String("ticker", ticker.String()), zap.Error(err))
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+			}
+			continue
+		}
+
+		feeds[ticker] = feed
+		if feed.Layout != LayoutDeepBook {
+			objectTickers = append(objectTickers, ticker)
+			objectIDs = append(objectIDs, feed.ObjectID)
+			continue
+		}
+
+		poolTickers = append(poolTickers, ticker)
+		if _, ok := f.getVersion(feed.ObjectID); !ok && !queuedPools[NormalizeObjectID(feed.ObjectID)] {
+			queuedPools[NormalizeObjectID(feed.ObjectID)] = true
+			poolIDs = append(poolIDs, feed.ObjectID)
+		}
+	}
+
+	// Read the objects of the pyth and path tickers, and the owners of unknown pools.
+	var (
+		objects    []ObjectResponse
+		pools      []ObjectResponse
+		batchElems []rpc.BatchElem
+	)
+	if len(objectIDs) > 0 {
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: MultiGetObjectsMethod,
+			Args:   []interface{}{objectIDs, map[string]bool{"showContent": true, "showType": true}},
+			Result: &objects,
+		})
+	}
+	if len(poolIDs) > 0 {
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: MultiGetObjectsMethod,
+			Args:   []interface{}{poolIDs, map[string]bool{"showOwner": true}},
+			Result: &pools,
+		})
+	}
+
+	if len(batchElems) > 0 {
+		if err := f.client.BatchCallContext(ctx, batchElems); err != nil {
+			f.logger.Debug("failed to batch call to sui full node", zap.Error(err))
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
+			)
+		}
+	}
+
+	now := time.Now().UTC()
+	if len(objectIDs) > 0 {
+		errs := f.resolveObjects(objectTickers, feeds, batchElems[0].Error, objects, now, resolved)
+		for ticker, err := range errs {
+			f.logger.Debug("failed to read price", zap.String("ticker", ticker.String()), zap.Error(err))
+			unResolved[ticker] = providertypes.UnresolvedResult{ErrorWithCode: err}
+		}
+	}
+
+	if len(poolIDs) > 0 {
+		f.cacheVersions(poolIDs, batchElems[len(batchElems)-1].Error, pools)
+	}
+
+	if len(poolTickers) > 0 {
+		errs := f.resolveMidPrices(ctx, poolTickers, feeds, now, resolved)
+		for ticker, err := range errs {
+			f.logger.Debug("failed to read mid-price", zap.String("ticker", ticker.String()), zap.Error(err))
+			unResolved[ticker] = providertypes.UnresolvedResult{ErrorWithCode: err}
+		}
+	}
+
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// resolveObjects resolves the prices of the pyth and path tickers from their objects, and returns
+// the errors of the tickers that could not be resolved.
+func (f *PriceFetcher) resolveObjects(
+	tickers []types.ProviderTicker,
+	feeds map[types.ProviderTicker]FeedConfig,
+	callErr error,
+	objects []ObjectResponse,
+	now time.Time,
+	resolved types.ResolvedPrices,
+) map[types.ProviderTicker]providertypes.ErrorWithCode {
+	errs := make(map[types.ProviderTicker]providertypes.ErrorWithCode)
+	if callErr == nil && len(objects) != len(tickers) {
+		callErr = fmt.Errorf("expected %d objects, got %d", len(tickers), len(objects))
+	}
+	if callErr != nil {
+		for _, ticker := range tickers {
+			errs[ticker] = providertypes.NewErrorWithCode(
+				callErr,
+				ethmulticlient.ErrorCodeFromError(callErr, providertypes.ErrorUnknown),
+			)
+		}
+
+		return errs
+	}
+
+	for i, ticker := range tickers {
+		feed := feeds[ticker]
+
+		data, err := objects[i].GetData()
+		if err != nil {
+			errs[ticker] = providertypes.NewErrorWithCode(err, providertypes.ErrorNoResponse)
+			continue
+		}
+
+		var price *big.Float
+		switch feed.Layout {
+		case LayoutPyth:
+			var pythPrice PythPrice
+			if pythPrice, err = DecodePythPrice(data); err == nil {
+				if err = pythPrice.ValidateBasic(now, feed.GetMaxAge()); err == nil {
+					price, err = pythPrice.ScalePrice()
+				}
+			}
+		default:
+			price, err = SelectPrice(data, *feed.Path)
+		}
+		if err != nil {
+			errs[ticker] = providertypes.NewErrorWithCode(
+				err,
+				providertypes.ErrorCodeFromError(err, providertypes.ErrorInvalidResponse),
+			)
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResult(price, now)
+	}
+
+	return errs
+}
+
+// cacheVersions caches the initial shared versions of the given pools.
+func (f *PriceFetcher) cacheVersions(poolIDs []string, callErr error, pools []ObjectResponse) {
+	if callErr != nil || len(pools) != len(poolIDs) {
+		f.logger.Debug("failed to read deepbook pools", zap.Strings("pools", poolIDs), zap.Error(callErr))
+		return
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	for i, pool := range pools {
+		data, err := pool.GetData()
+		if err == nil {
+			var version uint64
+			if version, err = data.InitialSharedVersion(); err == nil {
+				f.versionCache[NormalizeObjectID(poolIDs[i])] = version
+				continue
+			}
+		}
+
+		f.logger.Debug("failed to read deepbook pool", zap.String("pool", poolIDs[i]), zap.Error(err))
+	}
+}
+
+// resolveMidPrices resolves the mid-prices of the DeepBook tickers by inspecting a call to the
+// mid_price function of each pool, and returns the errors of the tickers that could not be
+// resolved.
+func (f *PriceFetcher) resolveMidPrices(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+	feeds map[types.ProviderTicker]FeedConfig,
+	now time.Time,
+	resolved types.ResolvedPrices,
+) map[types.ProviderTicker]providertypes.ErrorWithCode {
+	var (
+		errs       = make(map[types.ProviderTicker]providertypes.ErrorWithCode)
+		inspected  []types.ProviderTicker
+		results    = make([]DevInspectResponse, len(tickers))
+		batchElems []rpc.BatchElem
+	)
+
+	for _, ticker := range tickers {
+		feed := feeds[ticker]
+
+		version, ok := f.getVersion(feed.ObjectID)
+		if !ok {
+			errs[ticker] = providertypes.NewErrorWithCode(
+				fmt.Errorf("failed to read initial shared version of pool %s", feed.ObjectID),
+				providertypes.ErrorNoResponse,
+			)
+			continue
+		}
+
+		tx, err := EncodeMoveCall(
+			feed.DeepBook.Package,
+			DeepBookModule,
+			DeepBookMidPriceFunction,
+			[]string{feed.DeepBook.BaseType, feed.DeepBook.QuoteType},
+			[]SharedObject{
+				{ID: feed.ObjectID, InitialSharedVersion: version},
+				{ID: ClockObjectID, InitialSharedVersion: ClockInitialSharedVersion},
+			},
+		)
+		if err != nil {
+			errs[ticker] = providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidConfig)
+			continue
+		}
+
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: DevInspectMethod,
+			Args:   []interface{}{devInspectSender, base64.StdEncoding.EncodeToString(tx)},
+			Result: &results[len(inspected)],
+		})
+		inspected = append(inspected, ticker)
+	}
+
+	if len(batchElems) == 0 {
+		return errs
+	}
+
+	if err := f.client.BatchCallContext(ctx, batchElems); err != nil {
+		for _, ticker := range inspected {
+			errs[ticker] = providertypes.NewErrorWithCode(
+				err,
+				ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral),
+			)
+		}
+
+		return errs
+	}
+
+	for i, ticker := range inspected {
+		if err := batchElems[i].Error; err != nil {
+			errs[ticker] = providertypes.NewErrorWithCode(
+				err,
+				ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorUnknown),
+			)
+			continue
+		}
+
+		midPrice, err := results[i].U64()
+		if err == nil && midPrice == 0 {
+			err = fmt.Errorf("pool has no mid-price")
+		}
+		if err != nil {
+			errs[ticker] = providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse)
+			continue
+		}
+
+		price, err := ScaleMidPrice(midPrice, *feeds[ticker].DeepBook)
+		if err != nil {
+			errs[ticker] = providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice)
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResult(price, now)
+	}
+
+	return errs
+}
+
+// getVersion returns the cached initial shared version of the given pool.
+func (f *PriceFetcher) getVersion(poolID string) (uint64, bool) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	version, ok := f.versionCache[NormalizeObjectID(poolID)]
+	return version, ok
+}
+
+// GetFeed returns the Sui feed for the given ticker. This will unmarshal the metadata and
+// validate the feed config which contains all required information to read the price.
+func (f *PriceFetcher) GetFeed(
+	ticker types.ProviderTicker,
+) (FeedConfig, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if feed, ok := f.feedCache[ticker]; ok {
+		return feed, nil
+	}
+
+	var feed FeedConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &feed); err != nil {
+		return FeedConfig{}, fmt.Errorf("failed to unmarshal feed config for ticker %s: %w", ticker.String(), err)
+	}
+
+	if err := feed.ValidateBasic(); err != nil {
+		return FeedConfig{}, fmt.Errorf("invalid feed config for ticker %s: %w", ticker.String(), err)
+	}
+
+	f.feedCache[ticker] = feed
+	return feed, nil
+}
@@ -0,0 +1,385 @@
+package sui_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/sui"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+const (
+	pythPackage    = "0x8d97f1cd6ac663735be08d1d2b6d02a159e711586461306ce60a2b7a6a565a9e"
+	deepbook       = "0x2c8d603bc51326b8c13cef9dd07031a408a48dddb541963357661df5d3204809"
+	usdcType       = "0xdba34672e30cb065b1f93e3ab55318768fd6fef66c15942c9f7cb846e2f900e7::usdc::USDC"
+	btcPriceObject = "0x9a62b4863bdeaabdc9500fce769cf7e72d5585eeb28a6d26e4cafadc13f76ab2"
+	suiPriceObject = "0x801dbc2f0053d34734814b2d6df491ce7807a725fe9a01ad74a07e9c51396c37"
+	vaultObject    = "0x7a2f75a3e50fd5f72dfc2f8c9910da5eaa3a1486e4eb1e54a825c09d82214526"
+	suiUSDCPool    = "0xe05dafb5133bcffb8d59f4e12465dc0e9faeaa05e3e342a08fe135800e3e4407"
+)
+
+var (
+	logger = zap.NewExample()
+
+	btcTicker = types.NewProviderTicker("BTC/USD", sui.FeedConfig{
+		ObjectID: btcPriceObject,
+		Layout:   sui.LayoutPyth,
+		MaxAge:   60,
+	}.MustToJSON())
+	suiTicker = types.NewProviderTicker("SUI/USD", sui.FeedConfig{
+		ObjectID: suiPriceObject,
+		Layout:   sui.LayoutPyth,
+	}.MustToJSON())
+	vaultTicker = types.NewProviderTicker("VSUI/SUI", sui.FeedConfig{
+		ObjectID: vaultObject,
+		Layout:   sui.LayoutPath,
+		Path:     &sui.PathConfig{PricePath: "exchange_rate.fields.value", Decimals: 9},
+	}.MustToJSON())
+	poolTicker = types.NewProviderTicker("SUI/USDC", sui.FeedConfig{
+		ObjectID: suiUSDCPool,
+		Layout:   sui.LayoutDeepBook,
+		DeepBook: &sui.DeepBookConfig{
+			Package:       deepbook,
+			BaseType:      "0x2::sui::SUI",
+			QuoteType:     usdcType,
+			BaseDecimals:  9,
+			QuoteDecimals: 6,
+		},
+	}.MustToJSON())
+)
+
+func TestFeedConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  sui.FeedConfig
+		err  bool
+	}{
+		{
+			name: "valid pyth feed",
+			cfg:  sui.FeedConfig{ObjectID: btcPriceObject, Layout: sui.LayoutPyth},
+		},
+		{
+			name: "valid path feed",
+			cfg: sui.FeedConfig{
+				ObjectID: vaultObject,
+				Layout:   sui.LayoutPath,
+				Path:     &sui.PathConfig{PricePath: "rate"},
+			},
+		},
+		{
+			name: "invalid object id",
+			cfg:  sui.FeedConfig{ObjectID: "9a62b4", Layout: sui.LayoutPyth},
+			err:  true,
+		},
+		{
+			name: "unknown layout",
+			cfg:  sui.FeedConfig{ObjectID: btcPriceObject, Layout: "switchboard"},
+			err:  true,
+		},
+		{
+			name: "path layout without a path",
+			cfg:  sui.FeedConfig{ObjectID: vaultObject, Layout: sui.LayoutPath},
+			err:  true,
+		},
+		{
+			name: "path layout with an empty price path",
+			cfg: sui.FeedConfig{
+				ObjectID: vaultObject,
+				Layout:   sui.LayoutPath,
+				Path:     &sui.PathConfig{PricePath: "$"},
+			},
+			err: true,
+		},
+		{
+			name: "pyth layout with a deepbook config",
+			cfg: sui.FeedConfig{
+				ObjectID: btcPriceObject,
+				Layout:   sui.LayoutPyth,
+				DeepBook: &sui.DeepBookConfig{Package: deepbook, BaseType: "0x2::sui::SUI", QuoteType: usdcType},
+			},
+			err: true,
+		},
+		{
+			name: "deepbook layout with an invalid quote type",
+			cfg: sui.FeedConfig{
+				ObjectID: suiUSDCPool,
+				Layout:   sui.LayoutDeepBook,
+				DeepBook: &sui.DeepBookConfig{Package: deepbook, BaseType: "0x2::sui::SUI", QuoteType: "USDC"},
+			},
+			err: true,
+		},
+		{
+			name: "negative max age",
+			cfg:  sui.FeedConfig{ObjectID: btcPriceObject, Layout: sui.LayoutPyth, MaxAge: -1},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// node is a mocked sui full node.
+type node struct {
+	objects    map[string]string
+	owners     map[string]string
+	midPrices  map[string]string
+	batchErr   error
+	ownerCalls int
+}
+
+func TestFetch(t *testing.T) {
+	now := time.Now().Unix()
+
+	defaultNode := func() *node {
+		return &node{
+			objects: map[string]string{
+				btcPriceObject: pythObject(6500012345678, -8, now),
+				suiPriceObject: pythObject(150250, -5, now),
+				vaultObject:    `{"data":{"objectId":"` + vaultObject + `","content":{"dataType":"moveObject","type":"0x1::vault::Vault","fields":{"exchange_rate":{"type":"0x1::math::Decimal","fields":{"value":"1025000000"}}}}}}`,
+			},
+			owners: map[string]string{
+				suiUSDCPool: `{"data":{"objectId":"` + suiUSDCPool + `","owner":{"Shared":{"initial_shared_version":389723}}}}`,
+			},
+			midPrices: map[string]string{
+				// 1.5 USDC per SUI is 0.0015 USDC units (1e-6) per SUI unit (1e-9), scaled by 1e9.
+				suiUSDCPool: `{"error":null,"results":[{"returnValues":[[[96,227,22,0,0,0,0,0],"u64"]]}]}`,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		tickers    []types.ProviderTicker
+		node       func() *node
+		resolved   map[types.ProviderTicker]*big.Float
+		unresolved []types.ProviderTicker
+	}{
+		{
+			name:    "reads the price of each layout",
+			tickers: []types.ProviderTicker{btcTicker, suiTicker, vaultTicker, poolTicker},
+			node:    defaultNode,
+			resolved: map[types.ProviderTicker]*big.Float{
+				btcTicker:   big.NewFloat(65000.12345678),
+				suiTicker:   big.NewFloat(1.5025),
+				vaultTicker: big.NewFloat(1.025),
+				poolTicker:  big.NewFloat(1.5),
+			},
+		},
+		{
+			name: "invalid metadata",
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("ETH/USD", `{"object_id":"0x1","layout":"unknown"}`),
+				btcTicker,
+			},
+			node: defaultNode,
+			resolved: map[types.ProviderTicker]*big.Float{
+				btcTicker: big.NewFloat(65000.12345678),
+			},
+			unresolved: []types.ProviderTicker{
+				types.NewProviderTicker("ETH/USD", `{"object_id":"0x1","layout":"unknown"}`),
+			},
+		},
+		{
+			name:    "missing, stale and negative prices",
+			tickers: []types.ProviderTicker{btcTicker, suiTicker, vaultTicker},
+			node: func() *node {
+				n := defaultNode()
+				n.objects[btcPriceObject] = pythObject(6500012345678, -8, now-3600)
+				n.objects[suiPriceObject] = pythObject(-150250, -5, now)
+				n.objects[vaultObject] = `{"error":{"code":"notExists","object_id":"` + vaultObject + `"}}`
+				return n
+			},
+			unresolved: []types.ProviderTicker{btcTicker, suiTicker, vaultTicker},
+		},
+		{
+			name:    "exponents beyond the max exponent",
+			tickers: []types.ProviderTicker{btcTicker, suiTicker, vaultTicker},
+			node: func() *node {
+				n := defaultNode()
+				n.objects[btcPriceObject] = pythObject(6500012345678, -1_000_000_000, now)
+				n.objects[vaultObject] = `{"data":{"objectId":"` + vaultObject + `","content":{"dataType":"moveObject","type":"0x1::vault::Vault","fields":{"exchange_rate":{"type":"0x1::math::Decimal","fields":{"value":"1e9223372036854775807"}}}}}}`
+				return n
+			},
+			resolved: map[types.ProviderTicker]*big.Float{
+				suiTicker: big.NewFloat(1.5025),
+			},
+			unresolved: []types.ProviderTicker{btcTicker, vaultTicker},
+		},
+		{
+			name:    "pool that is not shared",
+			tickers: []types.ProviderTicker{btcTicker, poolTicker},
+			node: func() *node {
+				n := defaultNode()
+				n.owners[suiUSDCPool] = `{"data":{"objectId":"` + suiUSDCPool + `","owner":{"AddressOwner":"0x1"}}}`
+				return n
+			},
+			resolved: map[types.ProviderTicker]*big.Float{
+				btcTicker: big.NewFloat(65000.12345678),
+			},
+			unresolved: []types.ProviderTicker{poolTicker},
+		},
+		{
+			name:    "mid-price call aborts",
+			tickers: []types.ProviderTicker{poolTicker},
+			node: func() *node {
+				n := defaultNode()
+				n.midPrices[suiUSDCPool] = `{"error":"MoveAbort(...) in command 0","results":null}`
+				return n
+			},
+			unresolved: []types.ProviderTicker{poolTicker},
+		},
+		{
+			name:    "batch call fails",
+			tickers: []types.ProviderTicker{btcTicker, poolTicker},
+			node: func() *node {
+				n := defaultNode()
+				n.batchErr = fmt.Errorf("connection refused")
+				return n
+			},
+			unresolved: []types.ProviderTicker{btcTicker, poolTicker},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := mocks.NewEVMClient(t)
+			mockNode(t, client, tc.node())
+
+			fetcher, err := sui.NewPriceFetcherWithClient(logger, sui.DefaultAPIConfig, client)
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tc.tickers)
+			require.Len(t, response.Resolved, len(tc.resolved))
+			require.Len(t, response.UnResolved, len(tc.unresolved))
+
+			for ticker, price := range tc.resolved {
+				require.Contains(t, response.Resolved, ticker)
+				require.Equal(t, price.SetPrec(40), response.Resolved[ticker].Value.SetPrec(40))
+			}
+
+			for _, ticker := range tc.unresolved {
+				require.Contains(t, response.UnResolved, ticker)
+			}
+		})
+	}
+
+	t.Run("stale prices are reported as such", func(t *testing.T) {
+		n := defaultNode()
+		n.objects[btcPriceObject] = pythObject(6500012345678, -8, now-3600)
+
+		client := mocks.NewEVMClient(t)
+		mockNode(t, client, n)
+
+		fetcher, err := sui.NewPriceFetcherWithClient(logger, sui.DefaultAPIConfig, client)
+		require.NoError(t, err)
+
+		response := fetcher.Fetch(context.Background(), []types.ProviderTicker{btcTicker})
+		require.Equal(t, providertypes.ErrorStalePrice, response.UnResolved[btcTicker].Code())
+	})
+
+	t.Run("initial shared versions of pools are cached", func(t *testing.T) {
+		n := defaultNode()
+
+		client := mocks.NewEVMClient(t)
+		mockNode(t, client, n)
+
+		fetcher, err := sui.NewPriceFetcherWithClient(logger, sui.DefaultAPIConfig, client)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			response := fetcher.Fetch(context.Background(), []types.ProviderTicker{poolTicker})
+			require.Len(t, response.Resolved, 1)
+		}
+
+		require.Equal(t, 1, n.ownerCalls)
+	})
+}
+
+// mockNode mocks the batch calls of the client with the objects, owners and mid-prices of the
+// given node.
+func mockNode(t *testing.T, client *mocks.EVMClient, n *node) {
+	t.Helper()
+
+	client.On("BatchCallContext", mock.Anything, mock.Anything).Return(n.batchErr).Run(func(args mock.Arguments) {
+		if n.batchErr != nil {
+			return
+		}
+
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+
+		for i, elem := range elems {
+			switch elem.Method {
+			case sui.MultiGetObjectsMethod:
+				ids, ok := elem.Args[0].([]string)
+				require.True(t, ok)
+
+				options, ok := elem.Args[1].(map[string]bool)
+				require.True(t, ok)
+
+				source := n.objects
+				if options["showOwner"] {
+					source = n.owners
+					n.ownerCalls++
+				}
+
+				responses := make([]json.RawMessage, len(ids))
+				for j, id := range ids {
+					responses[j] = json.RawMessage(source[id])
+				}
+
+				bz, err := json.Marshal(responses)
+				require.NoError(t, err)
+				require.NoError(t, json.Unmarshal(bz, elems[i].Result))
+			case sui.DevInspectMethod:
+				// The pool is the first input of the inspected transaction.
+				require.Len(t, n.midPrices, 1)
+				for _, resp := range n.midPrices {
+					require.NoError(t, json.Unmarshal([]byte(resp), elems[i].Result))
+				}
+			default:
+				t.Fatalf("unexpected method %s", elem.Method)
+			}
+		}
+	}).Maybe()
+}
+
+// pythObject returns a pyth PriceInfoObject with the given price.
+func pythObject(price int64, expo int64, timestamp int64) string {
+	i64 := func(v int64) string {
+		negative := v < 0
+		if negative {
+			v = -v
+		}
+
+		return fmt.Sprintf(`{"type":"%s::i64::I64","fields":{"magnitude":"%d","negative":%t}}`, pythPackage, v, negative)
+	}
+
+	return fmt.Sprintf(
+		`{"data":{"objectId":"0x1","content":{"dataType":"moveObject","type":"%s::price_info::PriceInfoObject","fields":`+
+			`{"id":{"id":"0x1"},"price_info":{"type":"%s::price_info::PriceInfo","fields":{"arrival_time":"%d","attestation_time":"%d",`+
+			`"price_feed":{"type":"%s::price_feed::PriceFeed","fields":{"price":{"type":"%s::price::Price","fields":`+
+			`{"conf":"1000","expo":%s,"price":%s,"timestamp":"%d"}}}}}}}}}}`,
+		pythPackage, pythPackage, timestamp, timestamp, pythPackage, pythPackage, i64(expo), i64(price), timestamp,
+	)
+}
@@ -0,0 +1,286 @@
+package sui
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/pkg/math"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/cosmwasm"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// ObjectResponse is a single object of the response to sui_multiGetObjects.
+//
+//	{
+//	  "data": {
+//	    "objectId": "0x...",
+//	    "owner": {"Shared": {"initial_shared_version": 1}},
+//	    "content": {"dataType": "moveObject", "type": "0x...::price_info::PriceInfoObject", "fields": {...}}
+//	  }
+//	}
+type ObjectResponse struct {
+	Data  *ObjectData     `json:"data"`
+	Error json.RawMessage `json:"error,omitempty"`
+}
+
+// ObjectData is the data of an object.
+type ObjectData struct {
+	ObjectID string          `json:"objectId"`
+	Owner    json.RawMessage `json:"owner,omitempty"`
+	Content  *ObjectContent  `json:"content,omitempty"`
+}
+
+// ObjectContent is the content of a move object.
+type ObjectContent struct {
+	DataType string          `json:"dataType"`
+	Type     string          `json:"type"`
+	Fields   json.RawMessage `json:"fields"`
+}
+
+// GetData returns the data of the object, or an error if the object could not be read.
+func (r ObjectResponse) GetData() (*ObjectData, error) {
+	if len(r.Error) > 0 {
+		return nil, fmt.Errorf("failed to read object: %s", r.Error)
+	}
+
+	if r.Data == nil {
+		return nil, fmt.Errorf("object not found")
+	}
+
+	return r.Data, nil
+}
+
+// GetFields returns the fields of the object, or an error if the object has no content.
+func (d *ObjectData) GetFields() (json.RawMessage, error) {
+	if d.Content == nil || len(d.Content.Fields) == 0 {
+		return nil, fmt.Errorf("object %s has no content", d.ObjectID)
+	}
+
+	return d.Content.Fields, nil
+}
+
+// InitialSharedVersion returns the initial shared version of a shared object.
+func (d *ObjectData) InitialSharedVersion() (uint64, error) {
+	var owner struct {
+		Shared *struct {
+			InitialSharedVersion uint64 `json:"initial_shared_version"`
+		} `json:"Shared"`
+	}
+	if err := json.Unmarshal(d.Owner, &owner); err != nil || owner.Shared == nil {
+		return 0, fmt.Errorf("object %s is not a shared object", d.ObjectID)
+	}
+
+	return owner.Shared.InitialSharedVersion, nil
+}
+
+// PythPrice is the price of a Pyth PriceInfoObject.
+type PythPrice struct {
+	// Value is the unscaled value of the price.
+	Value *big.Int
+	// Exponent is the power of ten the value is multiplied by.
+	Exponent int64
+	// Timestamp is the unix timestamp at which the price was published.
+	Timestamp int64
+}
+
+// pythI64 is the signed integer type of the Pyth move package.
+type pythI64 struct {
+	Fields struct {
+		Magnitude json.Number `json:"magnitude"`
+		Negative  bool        `json:"negative"`
+	} `json:"fields"`
+}
+
+// pythPriceInfoObject are the fields of a Pyth PriceInfoObject that hold its price.
+type pythPriceInfoObject struct {
+	PriceInfo struct {
+		Fields struct {
+			PriceFeed struct {
+				Fields struct {
+					Price struct {
+						Fields struct {
+							Expo      pythI64     `json:"expo"`
+							Price     pythI64     `json:"price"`
+							Timestamp json.Number `json:"timestamp"`
+						} `json:"fields"`
+					} `json:"price"`
+				} `json:"fields"`
+			} `json:"price_feed"`
+		} `json:"fields"`
+	} `json:"price_info"`
+}
+
+// int returns the value of the signed integer.
+func (i pythI64) int() (*big.Int, error) {
+	v, ok := new(big.Int).SetString(i.Fields.Magnitude.String(), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid magnitude: %s", i.Fields.Magnitude)
+	}
+
+	if i.Fields.Negative {
+		v.Neg(v)
+	}
+
+	return v, nil
+}
+
+// DecodePythPrice decodes the price of a Pyth PriceInfoObject.
+func DecodePythPrice(data *ObjectData) (PythPrice, error) {
+	if data.Content == nil || !strings.HasSuffix(data.Content.Type, "::price_info::PriceInfoObject") {
+		return PythPrice{}, fmt.Errorf("object %s is not a pyth price info object", data.ObjectID)
+	}
+
+	var object pythPriceInfoObject
+	if err := json.Unmarshal(data.Content.Fields, &object); err != nil {
+		return PythPrice{}, fmt.Errorf("failed to decode pyth price info object: %w", err)
+	}
+
+	price := object.PriceInfo.Fields.PriceFeed.Fields.Price.Fields
+	value, err := price.Price.int()
+	if err != nil {
+		return PythPrice{}, fmt.Errorf("invalid price: %w", err)
+	}
+
+	exponent, err := price.Expo.int()
+	if err != nil || !exponent.IsInt64() {
+		return PythPrice{}, fmt.Errorf("invalid exponent: %s", price.Expo.Fields.Magnitude)
+	}
+
+	timestamp, err := price.Timestamp.Int64()
+	if err != nil {
+		return PythPrice{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+
+	return PythPrice{
+		Value:     value,
+		Exponent:  exponent.Int64(),
+		Timestamp: timestamp,
+	}, nil
+}
+
+// ValidateBasic ensures that the price is positive and no older than the given max age. A zero
+// max age disables the age check. Stale prices return an error with the ErrorStalePrice code.
+func (p PythPrice) ValidateBasic(now time.Time, maxAge time.Duration) error {
+	if p.Value == nil || p.Value.Sign() <= 0 {
+		return fmt.Errorf("price must be positive")
+	}
+
+	if maxAge == 0 {
+		return nil
+	}
+
+	updatedAt := time.Unix(p.Timestamp, 0)
+	if age := now.Sub(updatedAt); age > maxAge {
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("price updated at %s is older than max age %s", updatedAt.UTC(), maxAge),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	return nil
+}
+
+// ScalePrice returns the price scaled by its exponent. An error is returned if the exponent is
+// out of range.
+func (p PythPrice) ScalePrice() (*big.Float, error) {
+	price, err := math.NewPrice(p.Value, p.Exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
+
+// SelectPrice selects the price at the configured path of the fields of an object.
+func SelectPrice(data *ObjectData, cfg PathConfig) (*big.Float, error) {
+	fields, err := data.GetFields()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := cosmwasm.SelectValue(fields, cfg.PricePath)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := math.ParsePrice(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse price %s: %w", value, err)
+	}
+
+	price, err = math.NewPrice(price.Value, price.Exponent-cfg.Decimals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale price %s: %w", value, err)
+	}
+
+	scaled := price.BigFloat()
+	if scaled.Sign() <= 0 {
+		return nil, fmt.Errorf("price must be positive")
+	}
+
+	if cfg.Invert {
+		scaled = new(big.Float).Quo(big.NewFloat(1), scaled)
+	}
+
+	return scaled, nil
+}
+
+// DevInspectResponse is the response to sui_devInspectTransactionBlock. Each return value is a
+// pair of its BCS encoded bytes and its type.
+//
+//	{
+//	  "error": null,
+//	  "results": [{"returnValues": [[[0, 202, 154, 59, 0, 0, 0, 0], "u64"]]}]
+//	}
+type DevInspectResponse struct {
+	Error   *string `json:"error"`
+	Results []struct {
+		ReturnValues [][2]json.RawMessage `json:"returnValues"`
+	} `json:"results"`
+}
+
+// U64 returns the u64 returned by the single move call of the transaction.
+func (r DevInspectResponse) U64() (uint64, error) {
+	if r.Error != nil {
+		return 0, fmt.Errorf("move call failed: %s", *r.Error)
+	}
+
+	if len(r.Results) != 1 || len(r.Results[0].ReturnValues) != 1 {
+		return 0, fmt.Errorf("expected a single return value")
+	}
+
+	var (
+		value = r.Results[0].ReturnValues[0]
+		bz    []int
+		typ   string
+	)
+	if err := json.Unmarshal(value[0], &bz); err != nil {
+		return 0, fmt.Errorf("failed to decode return value: %w", err)
+	}
+
+	if err := json.Unmarshal(value[1], &typ); err != nil || typ != "u64" || len(bz) != 8 {
+		return 0, fmt.Errorf("expected a u64 return value, got %s", value[1])
+	}
+
+	var v uint64
+	for i, b := range bz {
+		v |= uint64(byte(b)) << (8 * i)
+	}
+
+	return v, nil
+}
+
+// ScaleMidPrice scales the mid-price of a DeepBook pool, which is the price of one unit of the
+// base coin in units of the quote coin, scaled by 10^DeepBookFloatScaling.
+func ScaleMidPrice(midPrice uint64, cfg DeepBookConfig) (*big.Float, error) {
+	exponent := cfg.BaseDecimals - cfg.QuoteDecimals - DeepBookFloatScaling
+	price, err := math.NewPrice(new(big.Int).SetUint64(midPrice), exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return price.BigFloat(), nil
+}
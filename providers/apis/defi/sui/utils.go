@@ -0,0 +1,212 @@
+package sui
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/cosmwasm"
+)
+
+// NOTE: All documentation for this file can be located on the Sui docs.
+// API documentation: https://docs.sui.io/sui-api-ref.
+
+const (
+	// Name is the name of the Sui API.
+	Name = "sui_api"
+
+	// LayoutPyth reads the price of a Pyth PriceInfoObject.
+	LayoutPyth = "pyth"
+
+	// LayoutPath reads the price at a path of the fields of an object.
+	LayoutPath = "path"
+
+	// LayoutDeepBook reads the mid-price of a DeepBook V3 pool.
+	LayoutDeepBook = "deepbook"
+
+	// MultiGetObjectsMethod is the JSON-RPC method used to read objects.
+	MultiGetObjectsMethod = "sui_multiGetObjects"
+
+	// DevInspectMethod is the JSON-RPC method used to call read-only move functions.
+	DevInspectMethod = "sui_devInspectTransactionBlock"
+
+	// DeepBookModule and DeepBookMidPriceFunction are the module and function called to read the
+	// mid-price of a DeepBook V3 pool.
+	DeepBookModule           = "pool"
+	DeepBookMidPriceFunction = "mid_price"
+
+	// DeepBookFloatScaling is the number of decimals of DeepBook prices.
+	DeepBookFloatScaling = 9
+
+	// ClockObjectID is the ID of the shared Clock object, which is passed to the mid_price function.
+	ClockObjectID = "0x6"
+
+	// ClockInitialSharedVersion is the initial shared version of the Clock object.
+	ClockInitialSharedVersion = 1
+
+	// URL is the URL of the public Sui mainnet full node.
+	URL = "https://fullnode.mainnet.sui.io:443"
+)
+
+// objectIDRegex matches a hex encoded object ID, or address, of up to 32 bytes.
+var objectIDRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{1,64}$`)
+
+// FeedConfig is the configuration of a price read from Sui. This is specific to each pair of tokens.
+type FeedConfig struct {
+	// ObjectID is the ID of the object holding the price, i.e. the PriceInfoObject of a Pyth
+	// feed or the shared Pool object of a DeepBook pool.
+	ObjectID string `json:"object_id"`
+
+	// Layout is how the price is read from the object. Must be one of pyth, path or deepbook.
+	Layout string `json:"layout"`
+
+	// Path configures the path layout, and must be unset otherwise.
+	Path *PathConfig `json:"path,omitempty"`
+
+	// DeepBook configures the deepbook layout, and must be unset otherwise.
+	DeepBook *DeepBookConfig `json:"deepbook,omitempty"`
+
+	// MaxAge is the maximum age, in seconds, of the price. This is only enforced by the pyth
+	// layout. If unset, the age of the price is not checked.
+	MaxAge int64 `json:"max_age"`
+}
+
+// PathConfig configures the price of an object that stores it in one of its fields.
+type PathConfig struct {
+	// PricePath is the JSONPath-style selector of the price in the fields of the object, e.g.
+	// price.fields.value. Keys are separated by dots and array elements are selected by their
+	// index. The selected value must be a number or a string encoded number.
+	PricePath string `json:"price_path"`
+
+	// Decimals is the number of decimals of the selected price.
+	Decimals int64 `json:"decimals"`
+
+	// Invert is true if the selected price should be inverted, i.e. the object stores the price
+	// of the quote in units of the base.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// DeepBookConfig configures the mid-price of a DeepBook V3 pool.
+type DeepBookConfig struct {
+	// Package is the ID of the DeepBook package the mid_price function is called on. This must be
+	// a version of the package that is allowed by the pool.
+	Package string `json:"package"`
+
+	// BaseType and QuoteType are the coin types of the pool, e.g. 0x2::sui::SUI.
+	BaseType  string `json:"base_type"`
+	QuoteType string `json:"quote_type"`
+
+	// BaseDecimals and QuoteDecimals are the decimals of the base and quote coins.
+	BaseDecimals  int64 `json:"base_decimals"`
+	QuoteDecimals int64 `json:"quote_decimals"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if !objectIDRegex.MatchString(fc.ObjectID) {
+		return fmt.Errorf("invalid object id: %s", fc.ObjectID)
+	}
+
+	if fc.MaxAge < 0 {
+		return fmt.Errorf("max age must be non-negative")
+	}
+
+	if fc.Layout != LayoutPath && fc.Path != nil {
+		return fmt.Errorf("path can only be set for the %s layout", LayoutPath)
+	}
+
+	if fc.Layout != LayoutDeepBook && fc.DeepBook != nil {
+		return fmt.Errorf("deepbook can only be set for the %s layout", LayoutDeepBook)
+	}
+
+	switch fc.Layout {
+	case LayoutPyth:
+	case LayoutPath:
+		if fc.Path == nil {
+			return fmt.Errorf("path is required for the %s layout", LayoutPath)
+		}
+
+		return fc.Path.ValidateBasic()
+	case LayoutDeepBook:
+		if fc.DeepBook == nil {
+			return fmt.Errorf("deepbook is required for the %s layout", LayoutDeepBook)
+		}
+
+		return fc.DeepBook.ValidateBasic()
+	default:
+		return fmt.Errorf("unknown layout: %s", fc.Layout)
+	}
+
+	return nil
+}
+
+// ValidateBasic validates the path configuration.
+func (pc *PathConfig) ValidateBasic() error {
+	if len(cosmwasm.ParsePath(pc.PricePath)) == 0 {
+		return fmt.Errorf("price path cannot be empty")
+	}
+
+	if pc.Decimals < 0 {
+		return fmt.Errorf("decimals must be non-negative")
+	}
+
+	return nil
+}
+
+// ValidateBasic validates the deepbook configuration.
+func (dc *DeepBookConfig) ValidateBasic() error {
+	if !objectIDRegex.MatchString(dc.Package) {
+		return fmt.Errorf("invalid package id: %s", dc.Package)
+	}
+
+	if _, err := ParseTypeTag(dc.BaseType); err != nil {
+		return fmt.Errorf("invalid base type %s: %w", dc.BaseType, err)
+	}
+
+	if _, err := ParseTypeTag(dc.QuoteType); err != nil {
+		return fmt.Errorf("invalid quote type %s: %w", dc.QuoteType, err)
+	}
+
+	if dc.BaseDecimals < 0 || dc.QuoteDecimals < 0 {
+		return fmt.Errorf("decimals must be non-negative")
+	}
+
+	return nil
+}
+
+// GetMaxAge returns the maximum age of the price, or zero if the age is not checked.
+func (fc *FeedConfig) GetMaxAge() time.Duration {
+	return time.Duration(fc.MaxAge) * time.Second
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// NormalizeObjectID returns the object ID zero-padded to 32 bytes, which is how object IDs are
+// returned by the API.
+func NormalizeObjectID(id string) string {
+	hex := strings.ToLower(strings.TrimPrefix(id, "0x"))
+	return "0x" + strings.Repeat("0", 64-len(hex)) + hex
+}
+
+// DefaultAPIConfig is the default configuration for the Sui API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           false,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         1000 * time.Millisecond,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	BatchSize:        50, // maximal # of objects in a sui_multiGetObjects query is 50
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
@@ -0,0 +1,172 @@
+package testutils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/catalyst"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// SimulatedChainID is the chain ID of a SimulatedChain.
+const SimulatedChainID = 1337
+
+// SimulatedChain is an in-process EVM chain, run by a go-ethereum node without networking, that
+// EVM providers can be integration tested against without an external RPC endpoint. The chain
+// serves JSON-RPC over HTTP at URL, so providers are configured with the chain's endpoint like any
+// other endpoint. Blocks are only produced when Commit is called.
+type SimulatedChain struct {
+	t *testing.T
+
+	node   *node.Node
+	beacon *catalyst.SimulatedBeacon
+	client *ethclient.Client
+	server *httptest.Server
+
+	// key is the key of the account that deploys contracts. The account is funded at genesis.
+	key   *ecdsa.PrivateKey
+	nonce uint64
+}
+
+// NewSimulatedChain starts a new simulated chain, which is stopped when the test completes.
+func NewSimulatedChain(t *testing.T) *SimulatedChain {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	stack, err := node.New(&node.Config{P2P: p2p.Config{NoDiscovery: true}})
+	require.NoError(t, err)
+
+	ethConf := ethconfig.Defaults
+	ethConf.Genesis = &core.Genesis{
+		Config:   params.AllDevChainProtocolChanges,
+		GasLimit: ethconfig.Defaults.Miner.GasCeil,
+		Alloc: types.GenesisAlloc{
+			crypto.PubkeyToAddress(key.PublicKey): {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+		},
+	}
+	ethConf.SyncMode = downloader.FullSync
+	ethConf.TxPool.NoLocals = true
+
+	backend, err := eth.New(stack, &ethConf)
+	require.NoError(t, err)
+	require.NoError(t, stack.Start())
+
+	beacon, err := catalyst.NewSimulatedBeacon(0, backend)
+	require.NoError(t, err)
+	require.NoError(t, beacon.Fork(backend.BlockChain().GetCanonicalHash(0)))
+
+	handler, err := stack.RPCHandler()
+	require.NoError(t, err)
+
+	c := &SimulatedChain{
+		t:      t,
+		node:   stack,
+		beacon: beacon,
+		client: ethclient.NewClient(stack.Attach()),
+		server: httptest.NewServer(handler),
+		key:    key,
+	}
+	t.Cleanup(c.close)
+
+	return c
+}
+
+// URL returns the URL of the chain's JSON-RPC endpoint.
+func (c *SimulatedChain) URL() string {
+	return c.server.URL
+}
+
+// Endpoint returns the chain's JSON-RPC endpoint.
+func (c *SimulatedChain) Endpoint() config.Endpoint {
+	return config.Endpoint{URL: c.server.URL}
+}
+
+// Commit produces a new block with the pending transactions.
+func (c *SimulatedChain) Commit() {
+	c.beacon.Commit()
+}
+
+// AdjustTime moves the timestamp of the next block forward by the given duration.
+func (c *SimulatedChain) AdjustTime(d time.Duration) {
+	require.NoError(c.t, c.beacon.AdjustTime(d))
+}
+
+// Deploy deploys a contract with the given runtime code, commits a block, and returns the address
+// of the contract.
+func (c *SimulatedChain) Deploy(code []byte) common.Address {
+	c.t.Helper()
+
+	tx, err := types.SignNewTx(c.key, types.LatestSignerForChainID(big.NewInt(SimulatedChainID)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(SimulatedChainID),
+		Nonce:     c.nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(100 * params.GWei),
+		Gas:       5_000_000,
+		Data:      DeploymentCode(code),
+	})
+	require.NoError(c.t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(c.t, c.client.SendTransaction(ctx, tx))
+	c.Commit()
+
+	receipt, err := c.client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(c.t, err)
+	require.Equal(c.t, types.ReceiptStatusSuccessful, receipt.Status, "contract deployment failed")
+
+	c.nonce++
+	return receipt.ContractAddress
+}
+
+// Fund transfers the given amount of wei to the given account, and commits a block.
+func (c *SimulatedChain) Fund(to common.Address, amount *big.Int) {
+	c.t.Helper()
+
+	tx, err := types.SignNewTx(c.key, types.LatestSignerForChainID(big.NewInt(SimulatedChainID)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(SimulatedChainID),
+		Nonce:     c.nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(100 * params.GWei),
+		Gas:       params.TxGas,
+		To:        &to,
+		Value:     amount,
+	})
+	require.NoError(c.t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(c.t, c.client.SendTransaction(ctx, tx))
+	c.Commit()
+
+	c.nonce++
+}
+
+// close stops the chain.
+func (c *SimulatedChain) close() {
+	c.server.Close()
+	c.client.Close()
+	require.NoError(c.t, c.beacon.Stop())
+	require.NoError(c.t, c.node.Close())
+}
@@ -0,0 +1,88 @@
+package testutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// StaticContract returns the runtime code of a contract that returns the given output for each
+// 4-byte function selector, regardless of the arguments of the call, and reverts for any other
+// call. This stands in for contracts whose view functions are read by providers, without requiring
+// a Solidity compiler. The outputs are ABI-encoded return values, e.g. as packed by the Outputs of
+// an abi.Method.
+func StaticContract(outputs map[[4]byte][]byte) []byte {
+	selectors := make([][4]byte, 0, len(outputs))
+	for selector := range outputs {
+		selectors = append(selectors, selector)
+	}
+	sort.Slice(selectors, func(i, j int) bool {
+		return bytes.Compare(selectors[i][:], selectors[j][:]) < 0
+	})
+
+	const (
+		// headerSize is the size of the code that loads the selector of the call.
+		headerSize = 6
+		// dispatchSize is the size of the code that jumps to the output of a selector.
+		dispatchSize = 11
+		// revertSize is the size of the code that reverts calls with an unknown selector.
+		revertSize = 4
+		// returnSize is the size of the code that returns the output of a selector.
+		returnSize = 16
+	)
+
+	returnsOffset := headerSize + dispatchSize*len(selectors) + revertSize
+	dataOffset := returnsOffset + returnSize*len(selectors)
+
+	// selector := calldata[0:4]
+	code := []byte{byte(vm.PUSH1), 0, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0xe0, byte(vm.SHR)}
+
+	// if selector == selectors[i] { goto return_i }
+	for i, selector := range selectors {
+		code = append(code, byte(vm.DUP1), byte(vm.PUSH4))
+		code = append(code, selector[:]...)
+		code = append(code, byte(vm.EQ), byte(vm.PUSH2))
+		code = binary.BigEndian.AppendUint16(code, uint16(returnsOffset+returnSize*i))
+		code = append(code, byte(vm.JUMPI))
+	}
+
+	// revert(0, 0)
+	code = append(code, byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.REVERT))
+
+	// return_i: codecopy(0, data_i, len_i); return(0, len_i)
+	var data []byte
+	for _, selector := range selectors {
+		output := outputs[selector]
+		size := uint16(len(output))
+
+		code = append(code, byte(vm.JUMPDEST), byte(vm.PUSH2))
+		code = binary.BigEndian.AppendUint16(code, size)
+		code = append(code, byte(vm.PUSH2))
+		code = binary.BigEndian.AppendUint16(code, uint16(dataOffset+len(data)))
+		code = append(code, byte(vm.PUSH1), 0, byte(vm.CODECOPY), byte(vm.PUSH2))
+		code = binary.BigEndian.AppendUint16(code, size)
+		code = append(code, byte(vm.PUSH1), 0, byte(vm.RETURN))
+
+		data = append(data, output...)
+	}
+
+	return append(code, data...)
+}
+
+// DeploymentCode returns the code of a contract creation transaction that deploys a contract with
+// the given runtime code.
+func DeploymentCode(runtime []byte) []byte {
+	// initSize is the size of the code that returns the runtime code.
+	const initSize = 13
+
+	// codecopy(0, initSize, len(runtime)); return(0, len(runtime))
+	code := []byte{byte(vm.PUSH2)}
+	code = binary.BigEndian.AppendUint16(code, uint16(len(runtime)))
+	code = append(code, byte(vm.DUP1), byte(vm.PUSH2))
+	code = binary.BigEndian.AppendUint16(code, initSize)
+	code = append(code, byte(vm.PUSH1), 0, byte(vm.CODECOPY), byte(vm.PUSH1), 0, byte(vm.RETURN))
+
+	return append(code, runtime...)
+}
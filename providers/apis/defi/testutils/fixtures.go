@@ -0,0 +1,105 @@
+package testutils
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureABI is the ABI of the ERC20 and ERC4626 view functions implemented by the fixtures.
+const fixtureABI = `[
+	{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"asset","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"shares","type":"uint256"}],"name":"convertToAssets","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// ERC20Token is a fixture of an ERC20 token, which implements the name, symbol and decimals view
+// functions.
+type ERC20Token struct {
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// Code returns the runtime code of the token.
+func (tok ERC20Token) Code(t *testing.T) []byte {
+	t.Helper()
+
+	return StaticContract(tok.outputs(t))
+}
+
+func (tok ERC20Token) outputs(t *testing.T) map[[4]byte][]byte {
+	t.Helper()
+
+	return map[[4]byte][]byte{
+		selector(t, "name"):     packOutput(t, "name", tok.Name),
+		selector(t, "symbol"):   packOutput(t, "symbol", tok.Symbol),
+		selector(t, "decimals"): packOutput(t, "decimals", tok.Decimals),
+	}
+}
+
+// ERC4626Vault is a fixture of an ERC4626 vault, which implements the ERC20 view functions of its
+// shares along with asset and convertToAssets. Since the fixture is static, convertToAssets
+// returns AssetsPerShare for any amount of shares, so it should be the amount of the asset that
+// one share (i.e. 10^Decimals) is worth.
+type ERC4626Vault struct {
+	ERC20Token
+
+	// Asset is the address of the underlying asset of the vault.
+	Asset common.Address
+	// AssetsPerShare is the amount of the underlying asset, in its smallest unit, that one share of
+	// the vault is worth.
+	AssetsPerShare *big.Int
+}
+
+// Code returns the runtime code of the vault.
+func (v ERC4626Vault) Code(t *testing.T) []byte {
+	t.Helper()
+
+	outputs := v.ERC20Token.outputs(t)
+	outputs[selector(t, "asset")] = packOutput(t, "asset", v.Asset)
+	outputs[selector(t, "convertToAssets")] = packOutput(t, "convertToAssets", v.AssetsPerShare)
+
+	return StaticContract(outputs)
+}
+
+// DeployERC4626Vault deploys the underlying asset and a vault of the asset to the chain, and
+// returns the address of the vault. The Asset of the vault is set to the deployed asset.
+func (c *SimulatedChain) DeployERC4626Vault(asset ERC20Token, vault ERC4626Vault) common.Address {
+	c.t.Helper()
+
+	vault.Asset = c.Deploy(asset.Code(c.t))
+	return c.Deploy(vault.Code(c.t))
+}
+
+func parseFixtureABI(t *testing.T) abi.ABI {
+	t.Helper()
+
+	parsed, err := abi.JSON(strings.NewReader(fixtureABI))
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func selector(t *testing.T, method string) [4]byte {
+	t.Helper()
+
+	var id [4]byte
+	copy(id[:], parseFixtureABI(t).Methods[method].ID)
+	return id
+}
+
+func packOutput(t *testing.T, method string, values ...interface{}) []byte {
+	t.Helper()
+
+	bz, err := parseFixtureABI(t).Methods[method].Outputs.Pack(values...)
+	require.NoError(t, err)
+
+	return bz
+}
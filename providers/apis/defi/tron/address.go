@@ -0,0 +1,76 @@
+package tron
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mr-tron/base58"
+)
+
+// AddressPrefix is the first byte of every TRON address. The remaining 20 bytes of an address are
+// the same as those of the corresponding EVM address.
+const AddressPrefix = 0x41
+
+// ParseAddress parses a TRON address into its EVM address. The address can either be base58check
+// encoded (e.g. TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t), or hex encoded with the 41 prefix.
+func ParseAddress(address string) (common.Address, error) {
+	var bz []byte
+	switch {
+	case strings.HasPrefix(address, "T"):
+		decoded, err := base58.Decode(address)
+		if err != nil || len(decoded) != 25 {
+			return common.Address{}, fmt.Errorf("invalid base58 address %s", address)
+		}
+
+		if !bytes.Equal(addressChecksum(decoded[:21]), decoded[21:]) {
+			return common.Address{}, fmt.Errorf("invalid checksum of address %s", address)
+		}
+
+		bz = decoded[:21]
+	case len(strings.TrimPrefix(address, "0x")) == 42:
+		decoded, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+		if err != nil {
+			return common.Address{}, fmt.Errorf("invalid hex address %s", address)
+		}
+
+		bz = decoded
+	default:
+		return common.Address{}, fmt.Errorf("invalid address %s", address)
+	}
+
+	if len(bz) != 21 || bz[0] != AddressPrefix {
+		return common.Address{}, fmt.Errorf("invalid hex address %s", address)
+	}
+
+	return common.BytesToAddress(bz[1:]), nil
+}
+
+// IsAddress returns true if the given string is a valid TRON address.
+func IsAddress(address string) bool {
+	_, err := ParseAddress(address)
+	return err == nil
+}
+
+// EncodeAddress returns the base58check encoding of the TRON address of an EVM address.
+func EncodeAddress(address common.Address) string {
+	bz := append([]byte{AddressPrefix}, address.Bytes()...)
+	return base58.Encode(append(bz, addressChecksum(bz)...))
+}
+
+// HexAddress returns the hex encoding, with the 41 prefix, of the TRON address of an EVM address.
+// This is the format of addresses in requests to the TRON HTTP API.
+func HexAddress(address common.Address) string {
+	return fmt.Sprintf("%x%x", AddressPrefix, address.Bytes())
+}
+
+// addressChecksum returns the checksum of a base58check encoded address, i.e. the first four bytes
+// of the double SHA256 hash of the address.
+func addressChecksum(bz []byte) []byte {
+	first := sha256.Sum256(bz)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
@@ -0,0 +1,81 @@
+package tron_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/defi/tron"
+)
+
+const (
+	// usdt is the address of the USDT TRC20 contract.
+	usdt    = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	usdtHex = "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	usdtEVM = "0xa614f803B6FD780986A42c78Ec9c7f77e6DeD13C"
+)
+
+func TestParseAddress(t *testing.T) {
+	testCases := []struct {
+		name     string
+		address  string
+		expected common.Address
+		err      bool
+	}{
+		{
+			name:     "base58 address",
+			address:  usdt,
+			expected: common.HexToAddress(usdtEVM),
+		},
+		{
+			name:     "hex address",
+			address:  usdtHex,
+			expected: common.HexToAddress(usdtEVM),
+		},
+		{
+			name:     "0x prefixed hex address",
+			address:  "0x" + usdtHex,
+			expected: common.HexToAddress(usdtEVM),
+		},
+		{
+			name:    "invalid checksum",
+			address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u",
+			err:     true,
+		},
+		{
+			name:    "invalid base58",
+			address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj60",
+			err:     true,
+		},
+		{
+			name:    "hex address without the 41 prefix",
+			address: "42a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+			err:     true,
+		},
+		{
+			name:    "evm address",
+			address: usdtEVM,
+			err:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address, err := tron.ParseAddress(tc.address)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, address)
+		})
+	}
+}
+
+func TestEncodeAddress(t *testing.T) {
+	address := common.HexToAddress(usdtEVM)
+	require.Equal(t, usdt, tron.EncodeAddress(address))
+	require.Equal(t, usdtHex, tron.HexAddress(address))
+}
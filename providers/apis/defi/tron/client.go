@@ -0,0 +1,224 @@
+package tron
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// Client is the interface of the TRON HTTP API used to call read-only contract methods.
+type Client interface {
+	// TriggerConstantContract calls a read-only method of a contract with the given call data,
+	// and returns the hex encoded result of the call.
+	TriggerConstantContract(ctx context.Context, contract common.Address, data []byte) (string, error)
+}
+
+// TriggerConstantContractRequest is the body of a triggerconstantcontract request. Addresses are
+// hex encoded with the 41 prefix.
+type TriggerConstantContractRequest struct {
+	OwnerAddress    string `json:"owner_address"`
+	ContractAddress string `json:"contract_address"`
+	Data            string `json:"data"`
+}
+
+// TriggerConstantContractResponse is the response to a triggerconstantcontract request.
+//
+//	{
+//	  "result": {"result": true},
+//	  "constant_result": ["0000000000000000000000000000000000000000000000000000000000000001"],
+//	  "transaction": {"ret": [{}]}
+//	}
+type TriggerConstantContractResponse struct {
+	Result struct {
+		Result bool `json:"result"`
+		// Code and Message are set if the call could not be executed. The message is usually
+		// hex encoded.
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"result"`
+	ConstantResult []string `json:"constant_result"`
+	Transaction    struct {
+		Ret []struct {
+			Ret string `json:"ret"`
+		} `json:"ret"`
+	} `json:"transaction"`
+}
+
+// GetResult returns the hex encoded result of the call, or an error if the call failed or
+// reverted.
+func (r TriggerConstantContractResponse) GetResult() (string, error) {
+	if !r.Result.Result || r.Result.Code != "" {
+		message := r.Result.Message
+		if bz, err := hex.DecodeString(message); err == nil {
+			message = string(bz)
+		}
+
+		return "", fmt.Errorf("call failed with code %s: %s", r.Result.Code, message)
+	}
+
+	if len(r.Transaction.Ret) > 0 && r.Transaction.Ret[0].Ret == "REVERT" {
+		return "", providertypes.NewErrorWithCode(fmt.Errorf("call reverted"), providertypes.ErrorContractReverted)
+	}
+
+	if len(r.ConstantResult) != 1 {
+		return "", fmt.Errorf("expected a single result, got %d", len(r.ConstantResult))
+	}
+
+	return "0x" + r.ConstantResult[0], nil
+}
+
+// RESTClient is an implementation of the TRON HTTP client. Multiple endpoints are queried in
+// priority order, failing over to the next endpoint when a request fails.
+type RESTClient struct {
+	api        config.APIConfig
+	apiMetrics metrics.APIMetrics
+
+	// clients are the http clients of each endpoint.
+	clients []*http.Client
+}
+
+var _ Client = (*RESTClient)(nil)
+
+// NewRESTClient returns a new RESTClient.
+func NewRESTClient(
+	api config.APIConfig,
+	apiMetrics metrics.APIMetrics,
+) (*RESTClient, error) {
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if api.Name != Name {
+		return nil, fmt.Errorf("invalid api name; expected %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api is not enabled")
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("metrics is required")
+	}
+
+	inFlight := connecthttp.SharedInFlightLimiter(api.Name, api.MaxInFlight)
+	clients := make([]*http.Client, len(api.Endpoints))
+	for i, endpoint := range api.Endpoints {
+		var transport http.RoundTripper = connecthttp.NewRoundTripperWithHeaders(
+			connecthttp.NewRoundTripperWithLimit(http.DefaultTransport, inFlight),
+			connecthttp.WithConnectVersionUserAgent(),
+		)
+
+		// if authentication is enabled, add the authentication header. The key is looked up on
+		// each request so that it can be rotated.
+		if endpoint.Authentication.Enabled() {
+			apiKey, err := secrets.APIKey(endpoint.Authentication)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read api key: %w", err)
+			}
+
+			transport = connecthttp.NewRoundTripperWithAuthentication(transport, endpoint.Authentication.APIKeyHeader, apiKey.Value)
+		}
+
+		clients[i] = &http.Client{
+			Transport: otelhttp.NewTransport(transport),
+		}
+	}
+
+	return &RESTClient{
+		api:        api,
+		apiMetrics: apiMetrics,
+		clients:    clients,
+	}, nil
+}
+
+// TriggerConstantContract calls a read-only method of a contract with the given call data, and
+// returns the hex encoded result of the call.
+func (c *RESTClient) TriggerConstantContract(ctx context.Context, contract common.Address, data []byte) (string, error) {
+	body, err := json.Marshal(TriggerConstantContractRequest{
+		OwnerAddress:    ownerAddress,
+		ContractAddress: HexAddress(contract),
+		Data:            hex.EncodeToString(data),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	// endpoints are queried in order until one succeeds. The error of the last endpoint is
+	// returned if all endpoints fail.
+	var resp TriggerConstantContractResponse
+	for i, client := range c.clients {
+		if err = c.post(ctx, client, i, body, &resp); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetResult()
+}
+
+// post sends a triggerconstantcontract request to a single endpoint and decodes its response
+// into out.
+func (c *RESTClient) post(
+	ctx context.Context,
+	client *http.Client,
+	i int,
+	body []byte,
+	out *TriggerConstantContractResponse,
+) error {
+	redactedURL := metrics.RedactedEndpointURL(i)
+	start := time.Now()
+	defer func() {
+		c.apiMetrics.ObserveProviderResponseLatency(c.api.Name, redactedURL, time.Since(start))
+	}()
+
+	url := strings.TrimSuffix(c.api.Endpoints[i].URL, "/") + TriggerConstantContractPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return providertypes.NewErrorWithCode(err, providertypes.ErrorUnableToCreateURL)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		c.apiMetrics.AddRPCStatusCode(c.api.Name, redactedURL, metrics.RPCCodeError)
+		return fmt.Errorf("request to %s failed: %w", redactedURL, err)
+	}
+	defer resp.Body.Close()
+
+	c.apiMetrics.AddHTTPStatusCode(c.api.Name, resp)
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("request to %s failed with status %d: %s", redactedURL, resp.StatusCode, bytes.TrimSpace(msg)),
+			providertypes.ErrorCode(resp.StatusCode),
+		)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("failed to decode response: %w", err),
+			providertypes.ErrorFailedToDecode,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package tron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/evmcall"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIFetcher = (*PriceFetcher)(nil)
+
+// PriceFetcher is the TRON price fetcher. This fetcher calls read-only methods of contracts on
+// TRON, such as the exchange rates of JustLend markets or the balances of TRC20 tokens, via the
+// triggerconstantcontract endpoint of the TRON HTTP API. The TVM is compatible with the EVM, so
+// calls are encoded and decoded by the EVM call provider.
+//
+// The HTTP API does not support batching, so the methods of all tickers are called concurrently.
+type PriceFetcher struct {
+	logger *zap.Logger
+	api    config.APIConfig
+
+	// client is the HTTP client used to call contracts.
+	client Client
+
+	mtx sync.Mutex
+	// callCache is a cache of the tickers to calls. This is used to avoid unmarshalling the
+	// metadata and parsing the ABI of each ticker on every fetch.
+	callCache map[types.ProviderTicker]evmcall.Call
+}
+
+// NewPriceFetcher returns a new TRON PriceFetcher.
+func NewPriceFetcher(
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+) (*PriceFetcher, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	client, err := NewRESTClient(api, apiMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceFetcherWithClient(
+		logger,
+		api,
+		client,
+	)
+}
+
+// NewPriceFetcherWithClient returns a new TRON PriceFetcher.
+// It requires a pre-validated config, and initialized client.
+func NewPriceFetcherWithClient(
+	logger *zap.Logger,
+	api config.APIConfig,
+	client Client,
+) (*PriceFetcher, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if api.Name != Name {
+		return nil, fmt.Errorf("invalid api config name; expected %s, got %s", Name, api.Name)
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	return &PriceFetcher{
+		logger:    logger.With(zap.String("fetcher", api.Name)),
+		api:       api,
+		client:    client,
+		callCache: make(map[types.ProviderTicker]evmcall.Call),
+	}, nil
+}
+
+// Fetch returns the price of a given set of tickers. The configured method of each contract is
+// called concurrently, after which the configured output is selected and scaled by the configured
+// decimals.
+func (f *PriceFetcher) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+
+		wg  sync.WaitGroup
+		mtx sync.Mutex
+	)
+
+	for _, ticker := range tickers {
+		call, err := f.GetCall(ticker)
+		if err != nil {
+			f.logger.Debug("failed to get call for ticker", zap.String("ticker", ticker.String()), zap.Error(err))
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to get call: %w", err),
+					providertypes.ErrorFailedToDecode,
+				),
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(ticker types.ProviderTicker, call evmcall.Call) {
+			defer wg.Done()
+
+			result, err := f.client.TriggerConstantContract(ctx, common.HexToAddress(call.Config.Address), call.Payload)
+			if err != nil {
+				f.logger.Debug("failed to call contract", zap.String("ticker", ticker.String()), zap.Error(err))
+
+				mtx.Lock()
+				defer mtx.Unlock()
+				unResolved[ticker] = providertypes.UnresolvedResult{
+					ErrorWithCode: providertypes.NewErrorWithCode(
+						err,
+						providertypes.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral),
+					),
+				}
+				return
+			}
+
+			price, err := call.ParseResult(result)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			if err != nil {
+				f.logger.Debug("failed to parse call result", zap.String("ticker", ticker.String()), zap.Error(err))
+				unResolved[ticker] = providertypes.UnresolvedResult{
+					ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice),
+				}
+				return
+			}
+
+			resolved[ticker] = types.NewPriceResult(price, time.Now().UTC())
+		}(ticker, call)
+	}
+
+	wg.Wait()
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// GetCall returns the call for the given ticker. This will unmarshal the metadata, validate the
+// call config and build the call, which contains all required information to call the contract.
+func (f *PriceFetcher) GetCall(
+	ticker types.ProviderTicker,
+) (evmcall.Call, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if call, ok := f.callCache[ticker]; ok {
+		return call, nil
+	}
+
+	var cfg evmcall.CallConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return evmcall.Call{}, fmt.Errorf("failed to unmarshal call config on ticker: %w", err)
+	}
+
+	call, err := NewCall(cfg)
+	if err != nil {
+		return evmcall.Call{}, fmt.Errorf("invalid ticker call config: %w", err)
+	}
+
+	f.callCache[ticker] = call
+	return call, nil
+}
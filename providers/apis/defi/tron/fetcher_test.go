@@ -0,0 +1,179 @@
+package tron_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/evmcall"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/tron"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+const (
+	// jUSDT is the address of the JustLend USDT market.
+	jUSDT    = "TXJgMdjVX5dKiQaUi9QobwNxtSQaFqccvd"
+	holder   = "TNUC9Qb1rRpS5CbWLmNMxXBjyFoydXjWFR"
+	noResult = "TKzxdSv2FZKQrEqkKVgp5DcwEXBEKMg2Ax"
+
+	exchangeRateStoredABI = `{"inputs":[],"name":"exchangeRateStored","outputs":[{"name":"","type":"uint256"}],` +
+		`"stateMutability":"view","type":"function"}`
+
+	balanceOfABI = `{"inputs":[{"name":"who","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],` +
+		`"stateMutability":"view","type":"function"}`
+)
+
+var (
+	logger = zap.NewExample()
+
+	// jUSDT has 8 decimals and USDT has 6, so the exchange rate has 18 - 8 + 6 = 16 decimals.
+	jusdtTicker = types.NewProviderTicker("JUSDT/USDT", evmcall.CallConfig{
+		Address:  jUSDT,
+		ABI:      json.RawMessage(exchangeRateStoredABI),
+		Method:   "exchangeRateStored",
+		Decimals: 16,
+	}.MustToJSON())
+	balanceTicker = types.NewProviderTicker("USDT/HOLDER", evmcall.CallConfig{
+		Address:  usdt,
+		ABI:      json.RawMessage(balanceOfABI),
+		Method:   "balanceOf",
+		Args:     []string{holder},
+		Decimals: 6,
+	}.MustToJSON())
+	revertTicker = types.NewProviderTicker("REVERT/USDT", evmcall.CallConfig{
+		Address:  noResult,
+		ABI:      json.RawMessage(exchangeRateStoredABI),
+		Method:   "exchangeRateStored",
+		Decimals: 16,
+	}.MustToJSON())
+)
+
+// word returns the hex encoding of a uint256.
+func word(v uint64) string {
+	return fmt.Sprintf("%064x", v)
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	holderAddress, err := tron.ParseAddress(holder)
+	require.NoError(t, err)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, tron.TriggerConstantContractPath, r.URL.Path)
+
+		var req tron.TriggerConstantContractRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "41"+strings.Repeat("0", 40), req.OwnerAddress)
+
+		contract, err := tron.ParseAddress(req.ContractAddress)
+		require.NoError(t, err)
+
+		switch tron.EncodeAddress(contract) {
+		case jUSDT:
+			require.Equal(t, "182df0f5", req.Data) // exchangeRateStored()
+			fmt.Fprintf(w, `{"result":{"result":true},"constant_result":["%s"],"transaction":{"ret":[{}]}}`, word(10_500_000_000_000_000))
+		case usdt:
+			// balanceOf(address) with the EVM address of the holder.
+			require.Equal(t, "70a08231"+strings.Repeat("0", 24)+common.Bytes2Hex(holderAddress.Bytes()), req.Data)
+			fmt.Fprintf(w, `{"result":{"result":true},"constant_result":["%s"],"transaction":{"ret":[{}]}}`, word(2_500_000))
+		default:
+			fmt.Fprint(w, `{"result":{"result":true},"constant_result":[""],"transaction":{"ret":[{"ret":"REVERT"}]}}`)
+		}
+	}))
+}
+
+func newFetcher(t *testing.T, urls ...string) *tron.PriceFetcher {
+	t.Helper()
+
+	api := tron.DefaultAPIConfig
+	api.Endpoints = nil
+	for _, url := range urls {
+		api.Endpoints = append(api.Endpoints, config.Endpoint{URL: url})
+	}
+
+	fetcher, err := tron.NewPriceFetcher(logger, metrics.NewNopAPIMetrics(), api)
+	require.NoError(t, err)
+
+	return fetcher
+}
+
+func TestFetch(t *testing.T) {
+	t.Run("resolves all tickers", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		resp := newFetcher(t, server.URL).Fetch(context.Background(), []types.ProviderTicker{jusdtTicker, balanceTicker})
+		require.Empty(t, resp.UnResolved)
+		require.Len(t, resp.Resolved, 2)
+
+		rate, _ := resp.Resolved[jusdtTicker].Value.Float64()
+		require.InDelta(t, 1.05, rate, 1e-9)
+
+		balance, _ := resp.Resolved[balanceTicker].Value.Float64()
+		require.InDelta(t, 2.5, balance, 1e-9)
+	})
+
+	t.Run("reverted calls are unresolved", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		resp := newFetcher(t, server.URL).Fetch(context.Background(), []types.ProviderTicker{jusdtTicker, revertTicker})
+		require.Len(t, resp.Resolved, 1)
+		require.Equal(t, providertypes.ErrorContractReverted, resp.UnResolved[revertTicker].Code())
+	})
+
+	t.Run("fails over to the next endpoint", func(t *testing.T) {
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer down.Close()
+
+		server := newServer(t)
+		defer server.Close()
+
+		resp := newFetcher(t, down.URL, server.URL).Fetch(context.Background(), []types.ProviderTicker{jusdtTicker})
+		require.Empty(t, resp.UnResolved)
+		require.Len(t, resp.Resolved, 1)
+
+		// the status of the last endpoint is returned if all endpoints fail.
+		resp = newFetcher(t, down.URL).Fetch(context.Background(), []types.ProviderTicker{jusdtTicker})
+		require.Equal(t, providertypes.ErrorCode(http.StatusTooManyRequests), resp.UnResolved[jusdtTicker].Code())
+	})
+
+	t.Run("invalid metadata is unresolved", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		ticker := types.NewProviderTicker("BAD/USDT", evmcall.CallConfig{
+			Address: "0x" + strings.Repeat("1", 40),
+			ABI:     json.RawMessage(exchangeRateStoredABI),
+			Method:  "exchangeRateStored",
+		}.MustToJSON())
+		resp := newFetcher(t, server.URL).Fetch(context.Background(), []types.ProviderTicker{ticker})
+		require.Equal(t, providertypes.ErrorFailedToDecode, resp.UnResolved[ticker].Code())
+	})
+}
+
+func TestGetResult(t *testing.T) {
+	var resp tron.TriggerConstantContractResponse
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"result":{"code":"CONTRACT_VALIDATE_ERROR","message":"636f6e7472616374206e6f7420666f756e64"}}`),
+		&resp,
+	))
+
+	_, err := resp.GetResult()
+	require.ErrorContains(t, err, "contract not found")
+}
@@ -0,0 +1,72 @@
+package tron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/evmcall"
+)
+
+// NOTE: All documentation for this file can be located on the TRON developer docs.
+// API documentation: https://developers.tron.network/reference/triggerconstantcontract.
+
+const (
+	// Name is the name of the TRON API.
+	Name = "tron_api"
+
+	// URL is the URL of the public TronGrid API on TRON mainnet.
+	URL = "https://api.trongrid.io"
+
+	// TriggerConstantContractPath is the path of the endpoint used to call read-only contract
+	// methods.
+	TriggerConstantContractPath = "/wallet/triggerconstantcontract"
+)
+
+// ownerAddress is the caller of read-only contract calls. Calls are not executed on-chain, so
+// any address can be used.
+var ownerAddress = HexAddress([20]byte{})
+
+// NewCall builds a read-only contract call from the call configuration of a ticker. The config
+// is the same as the one of the EVM call provider, except that the contract is a TRON address and
+// address arguments may be base58 encoded TRON addresses. These are converted to their EVM
+// addresses, after which the call is built by the EVM call provider.
+func NewCall(cfg evmcall.CallConfig) (evmcall.Call, error) {
+	contract, err := ParseAddress(cfg.Address)
+	if err != nil {
+		return evmcall.Call{}, fmt.Errorf("invalid contract address: %w", err)
+	}
+
+	evmCfg := cfg
+	evmCfg.Address = contract.Hex()
+	evmCfg.Args = make([]string, len(cfg.Args))
+	for i, arg := range cfg.Args {
+		evmCfg.Args[i] = arg
+		if !strings.HasPrefix(arg, "T") {
+			continue
+		}
+
+		if address, err := ParseAddress(arg); err == nil {
+			evmCfg.Args[i] = address.Hex()
+		}
+	}
+
+	if err := evmCfg.ValidateBasic(); err != nil {
+		return evmcall.Call{}, err
+	}
+
+	return evmcall.NewCall(evmCfg)
+}
+
+// DefaultAPIConfig is the default configuration for the TRON API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           false,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         2000 * time.Millisecond,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
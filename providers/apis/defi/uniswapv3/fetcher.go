@@ -16,6 +16,7 @@ import (
 
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc20"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
 	uniswappool "github.com/skip-mev/connect/v2/providers/apis/defi/uniswapv3/pool"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
@@ -49,6 +50,11 @@ type PriceFetcher struct {
 	// poolCache is a cache of the tickers to pool configs. This is used to avoid unmarshalling
 	// the metadata for each ticker.
 	poolCache map[types.ProviderTicker]PoolConfig
+	// tokenCache is a cache of the pool addresses to the addresses of their tokens. This is only
+	// populated for pools that are configured to discover the decimals of their tokens.
+	tokenCache map[common.Address][2]common.Address
+	// decimals is a cache of the decimals of the tokens, read from the token contracts.
+	decimals *erc20.DecimalsCache
 }
 
 // NewPriceFetcher returns a new Uniswap V3 price fetcher.
@@ -82,28 +88,12 @@ func NewPriceFetcher(
 		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
 	}
 
-	var (
-		client ethmulticlient.EVMClient
-		err    error
+	client, err := ethmulticlient.NewClientFromEndpoints(
+		ctx,
+		logger,
+		api,
+		apiMetrics,
 	)
-	switch {
-	case len(api.Endpoints) > 1:
-		client, err = ethmulticlient.NewMultiRPCClientFromEndpoints(
-			ctx,
-			logger,
-			api,
-			apiMetrics,
-		)
-	case len(api.Endpoints) == 1:
-		client, err = ethmulticlient.NewGoEthereumClientImpl(
-			ctx,
-			apiMetrics,
-			api,
-			0,
-		)
-	default:
-		err = fmt.Errorf("no endpoints were provided")
-	}
 	if err != nil {
 		return nil, err
 	}
@@ -132,20 +122,28 @@ func NewPriceFetcherWithClient(
 		return nil, fmt.Errorf("failed to pack slot0: %w", err)
 	}
 
+	decimals, err := erc20.NewDecimalsCache(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decimals cache: %w", err)
+	}
+
 	return &PriceFetcher{
-		logger:    logger.With(zap.String("fetcher", api.Name)),
-		api:       api,
-		client:    client,
-		abi:       abi,
-		payload:   payload,
-		poolCache: make(map[types.ProviderTicker]PoolConfig),
+		logger:     logger.With(zap.String("fetcher", api.Name)),
+		api:        api,
+		client:     client,
+		abi:        abi,
+		payload:    payload,
+		poolCache:  make(map[types.ProviderTicker]PoolConfig),
+		tokenCache: make(map[common.Address][2]common.Address),
+		decimals:   decimals,
 	}, nil
 }
 
 // Fetch returns the price of a given set of tickers. This fetch utilizes the batch call to lower
 // overhead of making individual RPC calls for each ticker. The fetcher will query the Uniswap V3
 // pool contract for the price of the pool. The price is derived from the slot 0 data of the pool
-// contract, specifically the sqrtPriceX96 value.
+// contract, specifically the sqrtPriceX96 value, or from the pool's observations if the pool is
+// configured with a TWAP window.
 func (u *PriceFetcher) Fetch(
 	ctx context.Context,
 	tickers []types.ProviderTicker,
@@ -155,8 +153,7 @@ func (u *PriceFetcher) Fetch(
 		unResolved = make(types.UnResolvedPrices)
 	)
 
-	// Create a batch element for each ticker and pool.
-	batchElems := make([]rpc.BatchElem, len(tickers))
+	// Get the pool config for each ticker.
 	pools := make([]PoolConfig, len(tickers))
 	for i, ticker := range tickers {
 		pool, err := u.GetPool(ticker)
@@ -175,25 +172,75 @@ func (u *PriceFetcher) Fetch(
 				),
 			)
 		}
+		pools[i] = pool
+	}
+
+	// Resolve the token decimals of the pools that are configured to discover them. Tickers whose
+	// decimals cannot be resolved are excluded from the price query.
+	for ticker, err := range u.ResolveDecimals(ctx, tickers, pools) {
+		u.logger.Debug(
+			"failed to resolve token decimals",
+			zap.String("ticker", ticker.String()),
+			zap.Error(err),
+		)
+
+		unResolved[ticker] = providertypes.UnresolvedResult{
+			ErrorWithCode: providertypes.NewErrorWithCode(
+				err,
+				providertypes.ErrorAPIGeneral,
+			),
+		}
+	}
+
+	// Create a batch element for each ticker and pool.
+	var (
+		batchElems   = make([]rpc.BatchElem, 0, len(tickers))
+		batchTickers = make([]types.ProviderTicker, 0, len(tickers))
+		batchPools   = make([]PoolConfig, 0, len(tickers))
+	)
+	for i, ticker := range tickers {
+		if _, ok := unResolved[ticker]; ok {
+			continue
+		}
+
+		pool := pools[i]
+		payload, err := u.GetPayload(pool)
+		if err != nil {
+			u.logger.Debug(
+				"failed to pack payload for ticker",
+				zap.String("ticker", ticker.String()),
+				zap.Error(err),
+			)
+
+			return types.NewPriceResponseWithErr(
+				tickers,
+				providertypes.NewErrorWithCode(
+					fmt.Errorf("failed to pack payload: %w", err),
+					providertypes.ErrorUnknown,
+				),
+			)
+		}
 
 		// Create a batch element for the ticker and pool.
 		var result string
-		batchElems[i] = rpc.BatchElem{
+		batchElems = append(batchElems, rpc.BatchElem{
 			Method: "eth_call",
 			Args: []interface{}{
 				map[string]interface{}{
 					"to":   common.HexToAddress(pool.Address),
-					"data": hexutil.Bytes(u.payload), // slot0 call to the pool contract.
+					"data": hexutil.Bytes(payload), // slot0 or observe call to the pool contract.
 				},
-				"latest", // latest signifies the latest block.
+				u.api.GetBlockTag(), // the configured block tag, latest by default.
 			},
 			Result: &result,
-		}
-		pools[i] = pool
+		})
+		batchTickers = append(batchTickers, ticker)
+		batchPools = append(batchPools, pool)
 	}
 
 	// Batch call to the EVM.
-	if err := u.client.BatchCallContext(ctx, batchElems); err != nil {
+	block, err := ethmulticlient.BatchCallWithBlock(ctx, u.client, u.api.GetBlockTag(), batchElems)
+	if err != nil {
 		u.logger.Debug(
 			"failed to batch call to ethereum network for all tickers",
 			zap.Error(err),
@@ -201,12 +248,12 @@ func (u *PriceFetcher) Fetch(
 
 		return types.NewPriceResponseWithErr(
 			tickers,
-			providertypes.NewErrorWithCode(err, providertypes.ErrorAPIGeneral),
+			providertypes.NewErrorWithCode(err, ethmulticlient.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral)),
 		)
 	}
 
 	// Parse the result from the batch call for each ticker.
-	for i, ticker := range tickers {
+	for i, ticker := range batchTickers {
 		result := batchElems[i]
 		if result.Error != nil {
 			u.logger.Debug(
@@ -218,18 +265,18 @@ func (u *PriceFetcher) Fetch(
 			unResolved[ticker] = providertypes.UnresolvedResult{
 				ErrorWithCode: providertypes.NewErrorWithCode(
 					result.Error,
-					providertypes.ErrorUnknown,
+					ethmulticlient.ErrorCodeFromError(result.Error, providertypes.ErrorUnknown),
 				),
 			}
 
 			continue
 		}
 
-		// Parse the sqrtPriceX96 from the result.
-		sqrtPriceX96, err := u.ParseSqrtPriceX96(result.Result)
+		// Parse the raw, unscaled price from the result.
+		price, err := u.ParsePrice(batchPools[i], result.Result)
 		if err != nil {
 			u.logger.Debug(
-				"failed to parse sqrt price x96",
+				"failed to parse price",
 				zap.String("ticker", ticker.String()),
 				zap.Error(err),
 			)
@@ -244,12 +291,9 @@ func (u *PriceFetcher) Fetch(
 			continue
 		}
 
-		// Convert the sqrtPriceX96 to a price. This is the raw, unscaled price.
-		price := ConvertSquareRootX96Price(sqrtPriceX96)
-
 		// Scale the price to the respective token decimals.
-		scaledPrice := ScalePrice(pools[i], price)
-		resolved[ticker] = types.NewPriceResult(scaledPrice, time.Now().UTC())
+		scaledPrice := ScalePrice(batchPools[i], price)
+		resolved[ticker] = types.NewPriceResultWithBlock(scaledPrice, time.Now().UTC(), block)
 	}
 
 	// Add the price to the resolved prices.
@@ -277,6 +321,255 @@ func (u *PriceFetcher) GetPool(
 	return cfg, nil
 }
 
+// ResolveDecimals sets the token decimals of all pools that are configured to discover them. The
+// token addresses of each pool are queried from the pool contract, and the decimals of each token
+// are queried from the token contract, the first time a pool is fetched. Both are cached, so
+// subsequent fetches do not make any additional calls. Configured, non-zero decimals take
+// precedence over the discovered decimals. The returned map contains the tickers whose decimals
+// could not be resolved.
+func (u *PriceFetcher) ResolveDecimals(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+	pools []PoolConfig,
+) map[types.ProviderTicker]error {
+	errs := make(map[types.ProviderTicker]error)
+
+	if err := u.resolveTokens(ctx, pools); err != nil {
+		u.logger.Debug("failed to resolve pool tokens", zap.Error(err))
+	}
+
+	var tokens []common.Address
+	for _, pool := range pools {
+		if !pool.DiscoverDecimals {
+			continue
+		}
+
+		if addresses, ok := u.tokenCache[common.HexToAddress(pool.Address)]; ok {
+			tokens = append(tokens, addresses[:]...)
+		}
+	}
+
+	if err := u.decimals.Load(ctx, tokens); err != nil {
+		u.logger.Debug("failed to load token decimals", zap.Error(err))
+	}
+
+	for i, ticker := range tickers {
+		pool := pools[i]
+		if !pool.DiscoverDecimals {
+			continue
+		}
+
+		addresses, ok := u.tokenCache[common.HexToAddress(pool.Address)]
+		if !ok {
+			errs[ticker] = fmt.Errorf("failed to query tokens of pool %s", pool.Address)
+			continue
+		}
+
+		base, quote := addresses[pool.BaseTokenIndex()], addresses[1-pool.BaseTokenIndex()]
+		if pool.BaseDecimals == 0 {
+			decimals, ok := u.decimals.Get(base)
+			if !ok {
+				errs[ticker] = fmt.Errorf("failed to query decimals of base token %s", base)
+				continue
+			}
+			pool.BaseDecimals = decimals
+		}
+
+		if pool.QuoteDecimals == 0 {
+			decimals, ok := u.decimals.Get(quote)
+			if !ok {
+				errs[ticker] = fmt.Errorf("failed to query decimals of quote token %s", quote)
+				continue
+			}
+			pool.QuoteDecimals = decimals
+		}
+
+		pools[i] = pool
+	}
+
+	return errs
+}
+
+// resolveTokens queries the token addresses of all pools that are configured to discover their
+// token decimals and are not cached yet.
+func (u *PriceFetcher) resolveTokens(
+	ctx context.Context,
+	pools []PoolConfig,
+) error {
+	token0, err := u.abi.Pack(Token0ContractMethod)
+	if err != nil {
+		return fmt.Errorf("failed to pack token0: %w", err)
+	}
+
+	token1, err := u.abi.Pack(Token1ContractMethod)
+	if err != nil {
+		return fmt.Errorf("failed to pack token1: %w", err)
+	}
+
+	var (
+		batchElems []rpc.BatchElem
+		queried    []common.Address
+		seen       = make(map[common.Address]struct{})
+	)
+	for _, pool := range pools {
+		if !pool.DiscoverDecimals {
+			continue
+		}
+
+		address := common.HexToAddress(pool.Address)
+
+		if _, ok := seen[address]; ok {
+			continue
+		}
+		seen[address] = struct{}{}
+
+		if _, ok := u.tokenCache[address]; ok {
+			continue
+		}
+
+		for _, payload := range [][]byte{token0, token1} {
+			var result string
+			batchElems = append(batchElems, rpc.BatchElem{
+				Method: "eth_call",
+				Args: []interface{}{
+					map[string]interface{}{
+						"to":   address,
+						"data": hexutil.Bytes(payload),
+					},
+					"latest",
+				},
+				Result: &result,
+			})
+		}
+		queried = append(queried, address)
+	}
+
+	if len(batchElems) == 0 {
+		return nil
+	}
+
+	if err := u.client.BatchCallContext(ctx, batchElems); err != nil {
+		return fmt.Errorf("failed to batch call pool tokens: %w", err)
+	}
+
+	for i, address := range queried {
+		t0, err := u.ParseTokenAddress(Token0ContractMethod, batchElems[2*i])
+		if err != nil {
+			u.logger.Debug("failed to parse token0", zap.Stringer("pool", address), zap.Error(err))
+			continue
+		}
+
+		t1, err := u.ParseTokenAddress(Token1ContractMethod, batchElems[2*i+1])
+		if err != nil {
+			u.logger.Debug("failed to parse token1", zap.Stringer("pool", address), zap.Error(err))
+			continue
+		}
+
+		u.tokenCache[address] = [2]common.Address{t0, t1}
+	}
+
+	return nil
+}
+
+// ParseTokenAddress parses the token address from the result of a token0 or token1 call.
+func (u *PriceFetcher) ParseTokenAddress(
+	method string,
+	elem rpc.BatchElem,
+) (common.Address, error) {
+	if elem.Error != nil {
+		return common.Address{}, elem.Error
+	}
+
+	r, ok := elem.Result.(*string)
+	if !ok {
+		return common.Address{}, fmt.Errorf("expected result to be a string, got %T", elem.Result)
+	}
+
+	if r == nil {
+		return common.Address{}, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := u.abi.Methods[method].Outputs.UnpackValues(bz)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
+}
+
+// GetPayload returns the packed contract call for the given pool. Pools configured with a TWAP
+// window query the tick cumulatives at the start and end of the window, all other pools query slot0.
+func (u *PriceFetcher) GetPayload(
+	pool PoolConfig,
+) ([]byte, error) {
+	if pool.TWAPWindow == 0 {
+		return u.payload, nil
+	}
+
+	return u.abi.Pack(TWAPContractMethod, []uint32{pool.TWAPWindow, 0})
+}
+
+// ParsePrice parses the raw, unscaled price of the pool from the result of the batch call.
+func (u *PriceFetcher) ParsePrice(
+	pool PoolConfig,
+	result interface{},
+) (*big.Float, error) {
+	if pool.TWAPWindow == 0 {
+		sqrtPriceX96, err := u.ParseSqrtPriceX96(result)
+		if err != nil {
+			return nil, err
+		}
+
+		return ConvertSquareRootX96Price(sqrtPriceX96), nil
+	}
+
+	tick, err := u.ParseTWAPTick(result, pool.TWAPWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConvertTickToPrice(tick), nil
+}
+
+// ParseTWAPTick parses the tick cumulatives from the result of the batch call and returns the
+// arithmetic mean tick over the window.
+func (u *PriceFetcher) ParseTWAPTick(
+	result interface{},
+	window uint32,
+) (int64, error) {
+	r, ok := result.(*string)
+	if !ok {
+		return 0, fmt.Errorf("expected result to be a string, got %T", result)
+	}
+
+	if r == nil {
+		return 0, fmt.Errorf("result is nil")
+	}
+
+	bz, err := hexutil.Decode(*r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode hex result: %w", err)
+	}
+
+	out, err := u.abi.Methods[TWAPContractMethod].Outputs.UnpackValues(bz)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unpack values: %w", err)
+	}
+
+	// Parse the tick cumulatives from the result.
+	tickCumulatives := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+	if len(tickCumulatives) != 2 {
+		return 0, fmt.Errorf("expected 2 tick cumulatives, got %d", len(tickCumulatives))
+	}
+
+	return CalculateTWAPTick(tickCumulatives[0], tickCumulatives[1], window), nil
+}
+
 // ParseSqrtPriceX96 parses the sqrtPriceX96 from the result of the batch call.
 func (u *PriceFetcher) ParseSqrtPriceX96(
 	result interface{},
@@ -3,12 +3,14 @@ package uniswapv3_test
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
 	"go.uber.org/zap"
 
-	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
@@ -16,6 +18,7 @@ import (
 	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/ethmulticlient/mocks"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/uniswapv3"
+	uniswappool "github.com/skip-mev/connect/v2/providers/apis/defi/uniswapv3/pool"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
@@ -31,9 +34,7 @@ func TestFetch(t *testing.T) {
 			name:    "no tickers",
 			tickers: []types.ProviderTicker{},
 			client: func() ethmulticlient.EVMClient {
-				c := mocks.NewEVMClient(t)
-				c.On("BatchCallContext", context.Background(), []rpc.BatchElem{}).Return(nil)
-				return c
+				return mocks.NewEVMClient(t)
 			},
 			expected: types.PriceResponse{
 				Resolved:   map[types.ProviderTicker]providertypes.ResolvedResult[*big.Float]{},
@@ -157,6 +158,143 @@ func TestFetch(t *testing.T) {
 	}
 }
 
+func TestFetchTWAP(t *testing.T) {
+	abi, err := uniswappool.UniswapMetaData.GetAbi()
+	require.NoError(t, err)
+
+	// The pool spent the entire window at tick 195000.
+	bz, err := abi.Methods[uniswapv3.TWAPContractMethod].Outputs.Pack(
+		[]*big.Int{big.NewInt(1000), big.NewInt(1000 + 195000*60)},
+		[]*big.Int{big.NewInt(0), big.NewInt(0)},
+	)
+	require.NoError(t, err)
+
+	client := createEVMClientWithResponse(t, nil, []string{hexutil.Encode(bz)}, []error{nil})
+	fetcher := createPriceFetcherWithClient(t, client)
+
+	response := fetcher.Fetch(context.Background(), []types.ProviderTicker{wethusdcTWAPTicker})
+	require.Len(t, response.UnResolved, 0)
+	require.Contains(t, response.Resolved, wethusdcTWAPTicker)
+
+	// The price is inverted and scaled by the difference in token decimals.
+	expected := 1e12 / math.Pow(1.0001, 195000)
+	actual, _ := response.Resolved[wethusdcTWAPTicker].Value.Float64()
+	require.InEpsilon(t, expected, actual, 1e-9)
+}
+
+func TestFetchDiscoverDecimals(t *testing.T) {
+	abi, err := uniswappool.UniswapMetaData.GetAbi()
+	require.NoError(t, err)
+
+	encode := func(method string, values ...interface{}) string {
+		bz, err := abi.Methods[method].Outputs.Pack(values...)
+		require.NoError(t, err)
+		return hexutil.Encode(bz)
+	}
+
+	encodeDecimals := func(decimals uint8) string {
+		return hexutil.Encode(common.LeftPadBytes([]byte{decimals}, 32))
+	}
+
+	// The pool spent the entire window at tick 195000.
+	observation := encode(
+		uniswapv3.TWAPContractMethod,
+		[]*big.Int{big.NewInt(1000), big.NewInt(1000 + 195000*60)},
+		[]*big.Int{big.NewInt(0), big.NewInt(0)},
+	)
+
+	t.Run("discovers and caches the token decimals", func(t *testing.T) {
+		ticker := types.NewProviderTicker("WETH/USDC", wethusdcDiscoverCfg.MustToJSON())
+
+		client := mocks.NewEVMClient(t)
+		expectBatchCall(
+			t,
+			client,
+			[]string{encode(uniswapv3.Token0ContractMethod, usdcToken), encode(uniswapv3.Token1ContractMethod, wethToken)},
+			[]error{nil, nil},
+		)
+		expectBatchCall(t, client, []string{encodeDecimals(6), encodeDecimals(18)}, []error{nil, nil})
+		expectBatchCall(t, client, []string{observation}, []error{nil})
+		fetcher := createPriceFetcherWithClient(t, client)
+
+		response := fetcher.Fetch(context.Background(), []types.ProviderTicker{ticker})
+		require.Len(t, response.UnResolved, 0)
+		require.Contains(t, response.Resolved, ticker)
+
+		// The price is inverted and scaled by the difference in token decimals.
+		expected := 1e12 / math.Pow(1.0001, 195000)
+		actual, _ := response.Resolved[ticker].Value.Float64()
+		require.InEpsilon(t, expected, actual, 1e-9)
+
+		// The tokens and decimals are cached, so only the pool is queried.
+		expectBatchCall(t, client, []string{observation}, []error{nil})
+		response = fetcher.Fetch(context.Background(), []types.ProviderTicker{ticker})
+		require.Len(t, response.UnResolved, 0)
+		actual, _ = response.Resolved[ticker].Value.Float64()
+		require.InEpsilon(t, expected, actual, 1e-9)
+	})
+
+	t.Run("configured decimals override the discovered decimals", func(t *testing.T) {
+		cfg := wethusdcDiscoverCfg
+		cfg.QuoteDecimals = 8
+		ticker := types.NewProviderTicker("WETH/USDC", cfg.MustToJSON())
+
+		client := mocks.NewEVMClient(t)
+		expectBatchCall(
+			t,
+			client,
+			[]string{encode(uniswapv3.Token0ContractMethod, usdcToken), encode(uniswapv3.Token1ContractMethod, wethToken)},
+			[]error{nil, nil},
+		)
+		expectBatchCall(t, client, []string{encodeDecimals(6), encodeDecimals(18)}, []error{nil, nil})
+		expectBatchCall(t, client, []string{observation}, []error{nil})
+		fetcher := createPriceFetcherWithClient(t, client)
+
+		response := fetcher.Fetch(context.Background(), []types.ProviderTicker{ticker})
+		require.Len(t, response.UnResolved, 0)
+		require.Contains(t, response.Resolved, ticker)
+
+		expected := 1e10 / math.Pow(1.0001, 195000)
+		actual, _ := response.Resolved[ticker].Value.Float64()
+		require.InEpsilon(t, expected, actual, 1e-9)
+	})
+
+	t.Run("fails to query the pool tokens", func(t *testing.T) {
+		ticker := types.NewProviderTicker("WETH/USDC", wethusdcDiscoverCfg.MustToJSON())
+
+		client := mocks.NewEVMClient(t)
+		expectBatchCall(
+			t,
+			client,
+			[]string{"", ""},
+			[]error{fmt.Errorf("execution reverted"), fmt.Errorf("execution reverted")},
+		)
+		fetcher := createPriceFetcherWithClient(t, client)
+
+		response := fetcher.Fetch(context.Background(), []types.ProviderTicker{ticker})
+		require.Len(t, response.Resolved, 0)
+		require.Contains(t, response.UnResolved, ticker)
+	})
+
+	t.Run("fails to query the token decimals", func(t *testing.T) {
+		ticker := types.NewProviderTicker("WETH/USDC", wethusdcDiscoverCfg.MustToJSON())
+
+		client := mocks.NewEVMClient(t)
+		expectBatchCall(
+			t,
+			client,
+			[]string{encode(uniswapv3.Token0ContractMethod, usdcToken), encode(uniswapv3.Token1ContractMethod, wethToken)},
+			[]error{nil, nil},
+		)
+		expectBatchCall(t, client, []string{encodeDecimals(6), ""}, []error{nil, fmt.Errorf("execution reverted")})
+		fetcher := createPriceFetcherWithClient(t, client)
+
+		response := fetcher.Fetch(context.Background(), []types.ProviderTicker{ticker})
+		require.Len(t, response.Resolved, 0)
+		require.Contains(t, response.UnResolved, ticker)
+	})
+}
+
 func TestGetPool(t *testing.T) {
 	fetcher := createPriceFetcher(t)
 
@@ -248,6 +386,27 @@ func TestParseSqrtPriceX96(t *testing.T) {
 	})
 }
 
+func TestParseTWAPTick(t *testing.T) {
+	fetcher := createPriceFetcher(t)
+
+	t.Run("result does not map to a string pointer", func(t *testing.T) {
+		_, err := fetcher.ParseTWAPTick(42, 60)
+		require.Error(t, err)
+	})
+
+	t.Run("result is a nil string pointer", func(t *testing.T) {
+		_, err := fetcher.ParseTWAPTick((*string)(nil), 60)
+		require.Error(t, err)
+	})
+
+	t.Run("result cannot be unpacked by the uniswap abi", func(t *testing.T) {
+		result := new(string)
+		*result = "0x1234"
+		_, err := fetcher.ParseTWAPTick(result, 60)
+		require.Error(t, err)
+	})
+}
+
 func TestNewPriceFetcher(t *testing.T) {
 	ctx := context.TODO()
 
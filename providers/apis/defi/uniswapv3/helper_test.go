@@ -3,6 +3,7 @@ package uniswapv3_test
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -14,6 +15,9 @@ import (
 	"github.com/skip-mev/connect/v2/providers/apis/defi/uniswapv3"
 )
 
+// testBlock is the block at which the mocked calls are read.
+var testBlock = ethmulticlient.BlockHeader{Number: 19_000_000, Timestamp: 1_700_000_000}
+
 var (
 	logger, _ = zap.NewDevelopment()
 
@@ -25,8 +29,28 @@ var (
 		Invert:        true,
 	}
 
+	wethusdcTWAPCfg = uniswapv3.PoolConfig{
+		Address:       "0x8ad599c3A0ff1De082011EFDDc58f1908eb6e6D8",
+		BaseDecimals:  18,
+		QuoteDecimals: 6,
+		Invert:        true,
+		TWAPWindow:    60,
+	}
+
+	wethusdcDiscoverCfg = uniswapv3.PoolConfig{
+		Address:          "0x8ad599c3A0ff1De082011EFDDc58f1908eb6e6D8",
+		Invert:           true,
+		TWAPWindow:       60,
+		DiscoverDecimals: true,
+	}
+
+	// Tokens of the WETH/USDC pool used for testing.
+	usdcToken = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	wethToken = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+
 	// Tickers used for testing.
-	wethusdcTicker = types.NewProviderTicker("WETH/USDC", wethusdcCfg.MustToJSON())
+	wethusdcTicker     = types.NewProviderTicker("WETH/USDC", wethusdcCfg.MustToJSON())
+	wethusdcTWAPTicker = types.NewProviderTicker("WETH/USDC", wethusdcTWAPCfg.MustToJSON())
 )
 
 func createPriceFetcher(
@@ -78,6 +102,11 @@ func createEVMClientWithResponse(
 			require.True(t, ok)
 
 			require.True(t, ok)
+			// The last call reads the block at which the other calls are read, if any.
+			if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+				*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+				elems = elems[:n-1]
+			}
 			require.Equal(t, len(elems), len(responses))
 			require.Equal(t, len(elems), len(errs))
 
@@ -91,3 +120,30 @@ func createEVMClientWithResponse(
 
 	return c
 }
+
+func expectBatchCall(
+	t *testing.T,
+	c *mocks.EVMClient,
+	responses []string,
+	errs []error,
+) {
+	t.Helper()
+
+	c.On("BatchCallContext", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		elems, ok := args.Get(1).([]rpc.BatchElem)
+		require.True(t, ok)
+		// The last call reads the block at which the other calls are read, if any.
+		if n := len(elems); n > 0 && elems[n-1].Method == "eth_getBlockByNumber" {
+			*elems[n-1].Result.(*ethmulticlient.BlockHeader) = testBlock
+			elems = elems[:n-1]
+		}
+		require.Equal(t, len(elems), len(responses))
+		require.Equal(t, len(elems), len(errs))
+
+		for i, elem := range elems {
+			elem.Result = &responses[i]
+			elem.Error = errs[i]
+			elems[i] = elem
+		}
+	}).Once()
+}
@@ -28,6 +28,54 @@ func ConvertSquareRootX96Price(
 	return new(big.Float).Mul(sqrtPriceFloat, sqrtPriceFloat)
 }
 
+// tickBase is the base of the tick price, i.e. each tick is a 1 basis point move in price.
+var tickBase = big.NewFloat(1.0001).SetPrec(256)
+
+// ConvertTickToPrice converts a pool tick to a price. Note that this price is not scaled to the
+// token decimals. This calculation is equivalent to:
+//
+// price = 1.0001 ^ tick.
+func ConvertTickToPrice(
+	tick int64,
+) *big.Float {
+	exp := math.Abs(tick)
+
+	// Exponentiation by squaring to retain precision for large ticks.
+	result := big.NewFloat(1).SetPrec(256)
+	base := new(big.Float).Copy(tickBase)
+	for exp > 0 {
+		if exp&1 == 1 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+		exp >>= 1
+	}
+
+	if tick < 0 {
+		return new(big.Float).SetPrec(256).Quo(big.NewFloat(1), result)
+	}
+	return result
+}
+
+// CalculateTWAPTick calculates the arithmetic mean tick over the window given the tick cumulatives
+// at the start and end of the window. This rounds towards negative infinity, matching the Uniswap
+// V3 OracleLibrary.
+func CalculateTWAPTick(
+	startTickCumulative, endTickCumulative *big.Int,
+	window uint32,
+) int64 {
+	delta := new(big.Int).Sub(endTickCumulative, startTickCumulative)
+	secondsAgo := big.NewInt(int64(window))
+
+	// Quo truncates towards zero, so negative ticks that do not divide evenly are rounded down.
+	tick := new(big.Int).Quo(delta, secondsAgo)
+	if delta.Sign() < 0 && new(big.Int).Rem(delta, secondsAgo).Sign() != 0 {
+		tick.Sub(tick, big.NewInt(1))
+	}
+
+	return tick.Int64()
+}
+
 // ScalePrice scales the price to the desired ticker decimals. The price is normalized to
 // the token decimals in the erc20 token contracts.
 func ScalePrice(
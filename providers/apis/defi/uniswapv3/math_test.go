@@ -1,6 +1,7 @@
 package uniswapv3_test
 
 import (
+	"math"
 	"math/big"
 	"testing"
 
@@ -110,3 +111,90 @@ func TestScalePrice(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertTickToPrice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tick     int64
+		expected float64
+	}{
+		{
+			name:     "tick of 0 is a price of 1",
+			tick:     0,
+			expected: 1,
+		},
+		{
+			name:     "tick of 1 is a single basis point",
+			tick:     1,
+			expected: 1.0001,
+		},
+		{
+			name:     "negative tick is the reciprocal",
+			tick:     -1,
+			expected: 1 / 1.0001,
+		},
+		{
+			name:     "mainnet example for weth/usdc",
+			tick:     195000,
+			expected: math.Pow(1.0001, 195000),
+		},
+		{
+			name:     "large negative tick",
+			tick:     -500000,
+			expected: math.Pow(1.0001, -500000),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, _ := uniswapv3.ConvertTickToPrice(tc.tick).Float64()
+			require.InEpsilon(t, tc.expected, actual, 1e-9)
+		})
+	}
+}
+
+func TestCalculateTWAPTick(t *testing.T) {
+	testCases := []struct {
+		name     string
+		start    int64
+		end      int64
+		window   uint32
+		expected int64
+	}{
+		{
+			name:     "positive tick",
+			start:    1000,
+			end:      1000 + 195000*60,
+			window:   60,
+			expected: 195000,
+		},
+		{
+			name:     "negative tick that divides evenly",
+			start:    0,
+			end:      -600,
+			window:   60,
+			expected: -10,
+		},
+		{
+			name:     "negative tick that does not divide evenly rounds down",
+			start:    0,
+			end:      -601,
+			window:   60,
+			expected: -11,
+		},
+		{
+			name:     "positive tick that does not divide evenly rounds down",
+			start:    0,
+			end:      601,
+			window:   60,
+			expected: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := uniswapv3.CalculateTWAPTick(big.NewInt(tc.start), big.NewInt(tc.end), tc.window)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
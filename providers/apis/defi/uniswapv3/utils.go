@@ -22,6 +22,15 @@ const (
 	// ContractMethod is the contract method to call for the Uniswap V3 API.
 	ContractMethod = "slot0"
 
+	// TWAPContractMethod is the contract method to call for the Uniswap V3 API when a pool is
+	// configured to report a time-weighted average price.
+	TWAPContractMethod = "observe"
+
+	// Token0ContractMethod and Token1ContractMethod are the contract methods that return the
+	// addresses of the pool's tokens. These are used to discover the decimals of the tokens.
+	Token0ContractMethod = "token0"
+	Token1ContractMethod = "token1"
+
 	// ETH_URL is the URL for the Uniswap V3 API. This uses a free public RPC provider on Ethereum Mainnet.
 	ETH_URL = "https://eth.public-rpc.com/"
 
@@ -49,17 +58,26 @@ func IsValidProviderName(name string) bool {
 // PoolConfig is the configuration for a Uniswap V3 pool. This is specific to each pair of tokens.
 type PoolConfig struct {
 	// Address is the Uniswap V3 pool address.
-	Address string `json:"address"`
+	Address string `json:"address" schema:"address"`
 	// BaseDecimals is the number of decimals for the base token. This should be derived from the
 	// token contract.
-	BaseDecimals int64 `json:"base_decimals"`
+	BaseDecimals int64 `json:"base_decimals" schema:"decimals"`
 	// QuoteDecimals is the number of decimals for the quote token. This should be derived from the
 	// token contract.
-	QuoteDecimals int64 `json:"quote_decimals"`
+	QuoteDecimals int64 `json:"quote_decimals" schema:"decimals"`
 	// Invert is utilized to invert the price of a pool's reserves. This may be required for certain
 	// pools as the price is derived based on the sorted order of the ERC20 addresses of the tokens
 	// in the pool.
 	Invert bool `json:"invert"`
+	// TWAPWindow is the number of seconds over which a time-weighted average price is computed
+	// from the pool's observations. If unset, the spot price from slot0 is used instead. Note that
+	// the pool's observation cardinality must be large enough to cover the window.
+	TWAPWindow uint32 `json:"twap_window,omitempty"`
+	// DiscoverDecimals reads the decimals of the pool's tokens from the token contracts the first
+	// time the pool is fetched, instead of relying on the configured decimals. A non-zero
+	// BaseDecimals or QuoteDecimals overrides the discovered decimals of the respective token,
+	// which can be used for non-standard tokens.
+	DiscoverDecimals bool `json:"discover_decimals,omitempty"`
 }
 
 // ValidateBasic validates the pool configuration.
@@ -79,6 +97,16 @@ func (pc *PoolConfig) ValidateBasic() error {
 	return nil
 }
 
+// BaseTokenIndex returns the index of the base token in the pool, i.e. 0 for token0 and 1 for
+// token1. The price of a pool is the price of token0 in units of token1, unless inverted.
+func (pc *PoolConfig) BaseTokenIndex() int {
+	if pc.Invert {
+		return 1
+	}
+
+	return 0
+}
+
 // MustToJSON converts the pool configuration to JSON.
 func (pc PoolConfig) MustToJSON() string {
 	b, err := json.Marshal(pc)
@@ -0,0 +1,146 @@
+package dia
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
+
+// APIHandler implements the PriceAPIDataHandler interface for DIA, which can be used
+// by a base provider. The handler fetches data from the asset quotation endpoint of the
+// DIA API. It is atomic in that it must request data from the DIA API sequentially for
+// each ticker, since assets are quoted one at a time.
+type APIHandler struct {
+	api config.APIConfig
+}
+
+// NewAPIHandler returns a new DIA PriceAPIDataHandler.
+func NewAPIHandler(
+	api config.APIConfig,
+) (types.PriceAPIDataHandler, error) {
+	if api.Name != Name {
+		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", Name)
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config for %s: %w", Name, err)
+	}
+
+	return &APIHandler{
+		api: api,
+	}, nil
+}
+
+// CreateURL returns the URL that is used to fetch the quotation of the given ticker from the
+// DIA API. The blockchain and address of the asset are read from the ticker's metadata. Since
+// the DIA API only quotes a single asset at a time, this function will return an error if the
+// ticker slice contains more than one ticker.
+func (h *APIHandler) CreateURL(
+	tickers []types.ProviderTicker,
+) (string, error) {
+	if len(tickers) != 1 {
+		return "", fmt.Errorf("expected 1 ticker, got %d", len(tickers))
+	}
+
+	cfg, err := GetFeedConfig(tickers[0])
+	if err != nil {
+		return "", err
+	}
+
+	return h.api.Endpoints[0].URL + fmt.Sprintf(AssetQuotationEndpoint, cfg.Blockchain, cfg.Address), nil
+}
+
+// ParseResponse parses the asset quotation HTTP response from the DIA API and returns the
+// resulting price. The timestamp of the price is the time of the quotation reported by DIA.
+// Note that this can only parse a single ticker at a time.
+func (h *APIHandler) ParseResponse(
+	tickers []types.ProviderTicker,
+	resp *http.Response,
+) types.PriceResponse {
+	if len(tickers) != 1 {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(
+				fmt.Errorf("expected 1 ticker, got %d", len(tickers)),
+				providertypes.ErrorInvalidResponse,
+			),
+		)
+	}
+
+	var result AssetQuotationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+		)
+	}
+
+	ticker := tickers[0]
+	cfg, err := GetFeedConfig(ticker)
+	if err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+		)
+	}
+
+	// Ensure the quotation is for the requested asset.
+	if !strings.EqualFold(result.Address, cfg.Address) || !strings.EqualFold(result.Blockchain, cfg.Blockchain) {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(
+				fmt.Errorf(
+					"expected quotation for %s on %s, got %s on %s",
+					cfg.Address,
+					cfg.Blockchain,
+					result.Address,
+					result.Blockchain,
+				),
+				providertypes.ErrorNoResponse,
+			),
+		)
+	}
+
+	if result.Price <= 0 {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(
+				fmt.Errorf("price must be positive: %f", result.Price),
+				providertypes.ErrorFailedToParsePrice,
+			),
+		)
+	}
+
+	return types.NewPriceResponse(
+		types.ResolvedPrices{
+			ticker: types.NewPriceResult(big.NewFloat(result.Price), result.Time.UTC()),
+		},
+		types.UnResolvedPrices{},
+	)
+}
+
+// GetFeedConfig unmarshals and validates the feed configuration in the ticker's metadata.
+func GetFeedConfig(ticker types.ProviderTicker) (FeedConfig, error) {
+	var cfg FeedConfig
+	if err := json.Unmarshal([]byte(ticker.GetJSON()), &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal feed config on ticker %s: %w", ticker, err)
+	}
+
+	if err := cfg.ValidateBasic(); err != nil {
+		return cfg, fmt.Errorf("invalid feed config on ticker %s: %w", ticker, err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,294 @@
+package dia_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/dia"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var (
+	btcusd = types.DefaultProviderTicker{
+		OffChainTicker: "BTC",
+		JSON: dia.FeedConfig{
+			Blockchain: "Bitcoin",
+			Address:    "0x0000000000000000000000000000000000000000",
+		}.MustToJSON(),
+	}
+	usdcusd = types.DefaultProviderTicker{
+		OffChainTicker: "USDC",
+		JSON: dia.FeedConfig{
+			Blockchain: "Ethereum",
+			Address:    "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		}.MustToJSON(),
+	}
+)
+
+func TestNewAPIHandler(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		_, err := dia.NewAPIHandler(dia.DefaultAPIConfig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		cfg := dia.DefaultAPIConfig
+		cfg.Name = "invalid"
+		_, err := dia.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("disabled api", func(t *testing.T) {
+		cfg := dia.DefaultAPIConfig
+		cfg.Enabled = false
+		_, err := dia.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cps         []types.ProviderTicker
+		url         string
+		expectedErr bool
+	}{
+		{
+			name: "native asset",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			url:         "https://api.diadata.org/v1/assetQuotation/Bitcoin/0x0000000000000000000000000000000000000000",
+			expectedErr: false,
+		},
+		{
+			name: "token",
+			cps: []types.ProviderTicker{
+				usdcusd,
+			},
+			url:         "https://api.diadata.org/v1/assetQuotation/Ethereum/0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+			expectedErr: false,
+		},
+		{
+			name: "missing metadata",
+			cps: []types.ProviderTicker{
+				types.DefaultProviderTicker{OffChainTicker: "BTC"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "missing address",
+			cps: []types.ProviderTicker{
+				types.DefaultProviderTicker{
+					OffChainTicker: "BTC",
+					JSON:           dia.FeedConfig{Blockchain: "Bitcoin"}.MustToJSON(),
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "multiple tickers",
+			cps: []types.ProviderTicker{
+				btcusd,
+				usdcusd,
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := dia.NewAPIHandler(dia.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			url, err := h.CreateURL(tc.cps)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.url, url)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	quoteTime := time.Date(2024, 4, 16, 19, 16, 52, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		cps      []types.ProviderTicker
+		response *http.Response
+		expected types.PriceResponse
+	}{
+		{
+			name: "valid quotation",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(`
+{
+	"Symbol": "BTC",
+	"Name": "Bitcoin",
+	"Address": "0x0000000000000000000000000000000000000000",
+	"Blockchain": "Bitcoin",
+	"Price": 61033.66879713,
+	"PriceYesterday": 63011.20348921,
+	"VolumeYesterdayUSD": 8914432871.51,
+	"Time": "2024-04-16T19:16:52Z",
+	"Source": "diadata.com"
+}
+	`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusd: {
+						Value:     big.NewFloat(61033.66879713),
+						Timestamp: quoteTime,
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "address is matched case-insensitively",
+			cps: []types.ProviderTicker{
+				usdcusd,
+			},
+			response: testutils.CreateResponseFromJSON(`
+{
+	"Symbol": "USDC",
+	"Name": "USD Coin",
+	"Address": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+	"Blockchain": "Ethereum",
+	"Price": 0.9998,
+	"Time": "2024-04-16T19:16:52Z",
+	"Source": "diadata.com"
+}
+	`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					usdcusd: {
+						Value:     big.NewFloat(0.9998),
+						Timestamp: quoteTime,
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "quotation for another asset",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(`
+{
+	"Symbol": "",
+	"Address": "",
+	"Blockchain": "",
+	"Price": 0,
+	"Time": "0001-01-01T00:00:00Z"
+}
+	`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+				},
+			),
+		},
+		{
+			name: "non-positive price",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(`
+{
+	"Symbol": "BTC",
+	"Address": "0x0000000000000000000000000000000000000000",
+	"Blockchain": "Bitcoin",
+	"Price": 0,
+	"Time": "2024-04-16T19:16:52Z"
+}
+	`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("price must be positive"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "bad response",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`
+shout out my label that's me
+	`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("json error"), providertypes.ErrorFailedToDecode),
+					},
+				},
+			),
+		},
+		{
+			name: "multiple tickers",
+			cps: []types.ProviderTicker{
+				btcusd,
+				usdcusd,
+			},
+			response: testutils.CreateResponseFromJSON(`{}`),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("expected 1 ticker"), providertypes.ErrorInvalidResponse),
+					},
+					usdcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("expected 1 ticker"), providertypes.ErrorInvalidResponse),
+					},
+				},
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := dia.NewAPIHandler(dia.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			resp := h.ParseResponse(tc.cps, tc.response)
+
+			require.Len(t, resp.Resolved, len(tc.expected.Resolved))
+			require.Len(t, resp.UnResolved, len(tc.expected.UnResolved))
+
+			for cp, result := range tc.expected.Resolved {
+				require.Contains(t, resp.Resolved, cp)
+				r := resp.Resolved[cp]
+				require.Equal(t, result.Value.SetPrec(18), r.Value.SetPrec(18))
+				require.Equal(t, result.Timestamp, r.Timestamp)
+			}
+
+			for cp := range tc.expected.UnResolved {
+				require.Contains(t, resp.UnResolved, cp)
+				require.Error(t, resp.UnResolved[cp])
+			}
+		})
+	}
+}
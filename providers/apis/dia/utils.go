@@ -0,0 +1,93 @@
+package dia
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// NOTE: All documentation for this file can be located on the DIA docs.
+// API documentation: https://docs.diadata.org/products/token-price-feeds/access-the-oracle/api-endpoints.
+// The public DIA API does not require an API key.
+
+const (
+	// Name is the name of the DIA provider.
+	Name = "dia_api"
+
+	// URL is the base URL of the DIA API.
+	URL = "https://api.diadata.org/v1"
+
+	// AssetQuotationEndpoint is the endpoint used to fetch the latest USD quotation of an
+	// asset, keyed by the blockchain and address of the asset.
+	AssetQuotationEndpoint = "/assetQuotation/%s/%s"
+)
+
+// DefaultAPIConfig is the default configuration for the DIA API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           false,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         500 * time.Millisecond,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+// FeedConfig is the metadata that must be set on each ticker. DIA keys assets by the
+// blockchain they are native to and their address on it, rather than by symbol.
+type FeedConfig struct {
+	// Blockchain is the DIA name of the blockchain of the asset, e.g. `Ethereum` or `Bitcoin`.
+	Blockchain string `json:"blockchain"`
+	// Address is the address of the asset on the blockchain. Native assets use the zero
+	// address of the blockchain, e.g. `0x0000000000000000000000000000000000000000`.
+	Address string `json:"address"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if fc.Blockchain == "" {
+		return fmt.Errorf("blockchain cannot be empty")
+	}
+
+	if fc.Address == "" {
+		return fmt.Errorf("address cannot be empty")
+	}
+
+	return nil
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// AssetQuotationResponse is the response returned by the asset quotation endpoint of the
+// DIA API. The response format looks like the following:
+//
+//	{
+//		"Symbol": "BTC",
+//		"Name": "Bitcoin",
+//		"Address": "0x0000000000000000000000000000000000000000",
+//		"Blockchain": "Bitcoin",
+//		"Price": 61033.66879713,
+//		"PriceYesterday": 63011.20348921,
+//		"VolumeYesterdayUSD": 8914432871.51,
+//		"Time": "2024-04-16T19:16:52Z",
+//		"Source": "diadata.com"
+//	}
+type AssetQuotationResponse struct {
+	Symbol     string    `json:"Symbol"`
+	Name       string    `json:"Name"`
+	Address    string    `json:"Address"`
+	Blockchain string    `json:"Blockchain"`
+	Price      float64   `json:"Price"`
+	Time       time.Time `json:"Time"`
+	Source     string    `json:"Source"`
+}
@@ -0,0 +1,233 @@
+package forex
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
+
+// APIHandler implements the PriceAPIDataHandler interface for the forex provider. The reference
+// rates of all currencies are quoted against a single base currency, so the rates of all tickers
+// are fetched in a single request and each ticker's rate is the cross rate of its currencies.
+type APIHandler struct {
+	// api is the config for the forex API.
+	api config.APIConfig
+
+	// now returns the current time.
+	now func() time.Time
+
+	mtx sync.Mutex
+	// fixes are the latest reference rates of each ticker, used to interpolate between rates.
+	fixes map[types.ProviderTicker]*fix
+}
+
+// fix is the reference rate of a ticker, along with the price it is interpolated from.
+type fix struct {
+	date  string
+	rate  *big.Float
+	start *big.Float
+	since time.Time
+}
+
+// NewAPIHandler returns a new forex PriceAPIDataHandler.
+func NewAPIHandler(
+	api config.APIConfig,
+) (types.PriceAPIDataHandler, error) {
+	return NewAPIHandlerWithClock(api, time.Now)
+}
+
+// NewAPIHandlerWithClock returns a new forex APIHandler that uses the given function to determine
+// the current time. This is useful for testing the daily fix and weekend policies.
+func NewAPIHandlerWithClock(
+	api config.APIConfig,
+	now func() time.Time,
+) (*APIHandler, error) {
+	if api.Name != Name {
+		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", Name)
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config for %s: %w", Name, err)
+	}
+
+	return &APIHandler{
+		api:   api,
+		now:   now,
+		fixes: make(map[types.ProviderTicker]*fix),
+	}, nil
+}
+
+// CreateURL returns the URL of the latest reference rates. The rates of all currencies are
+// returned, so the URL does not depend on the tickers.
+func (h *APIHandler) CreateURL(
+	_ []types.ProviderTicker,
+) (string, error) {
+	return h.api.Endpoints[0].URL, nil
+}
+
+// ParseResponse parses the reference rates from the response, and returns the cross rate of each
+// ticker per its metadata.
+func (h *APIHandler) ParseResponse(
+	tickers []types.ProviderTicker,
+	resp *http.Response,
+) types.PriceResponse {
+	var result RatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+		)
+	}
+
+	date, err := time.Parse(DateFormat, result.Date)
+	if err != nil || len(result.Base) == 0 {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(
+				fmt.Errorf("invalid reference rates of %s dated %s", result.Base, result.Date),
+				providertypes.ErrorInvalidResponse,
+			),
+		)
+	}
+
+	// The base currency is not quoted in the rates.
+	rates := make(map[string]float64, len(result.Rates)+1)
+	for currency, rate := range result.Rates {
+		rates[currency] = rate
+	}
+	rates[result.Base] = 1
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unresolved = make(types.UnResolvedPrices)
+		now        = h.now().UTC()
+	)
+
+	for _, ticker := range tickers {
+		price, err := h.price(ticker, rates, result.Date, date, now)
+		if err != nil {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(
+					err,
+					providertypes.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral),
+				),
+			}
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResult(price, now)
+	}
+
+	return types.NewPriceResponse(resolved, unresolved)
+}
+
+// price returns the price of the ticker given the reference rates fixed at the given date.
+func (h *APIHandler) price(
+	ticker types.ProviderTicker,
+	rates map[string]float64,
+	fixDate string,
+	date time.Time,
+	now time.Time,
+) (*big.Float, error) {
+	var metadata MetaData
+	if bz := ticker.GetJSON(); bz != "" {
+		if err := json.Unmarshal([]byte(bz), &metadata); err != nil {
+			return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode)
+		}
+	}
+
+	if err := metadata.ValidateBasic(); err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode)
+	}
+
+	if metadata.Weekend == WeekendSkip && IsWeekend(now) {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("fx markets are closed on weekends"),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	if expected := LatestFixDate(now); metadata.Mode == ModeDailyFix && date.Before(expected) {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("reference rates of %s are older than the fix of %s", fixDate, expected.Format(DateFormat)),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	currencies := strings.Split(ticker.GetOffChainTicker(), TickerSeparator)
+	if len(currencies) != 2 {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("invalid ticker %s; expected BASE%sQUOTE", ticker.GetOffChainTicker(), TickerSeparator),
+			providertypes.ErrorUnknownPair,
+		)
+	}
+
+	base, baseOk := rates[strings.ToUpper(currencies[0])]
+	quote, quoteOk := rates[strings.ToUpper(currencies[1])]
+	if !baseOk || !quoteOk || base <= 0 || quote <= 0 {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("no reference rate for %s", ticker.GetOffChainTicker()),
+			providertypes.ErrorNoResponse,
+		)
+	}
+
+	// The rates are the amount of each currency per unit of the base currency of the rates, so
+	// the price of the ticker is the amount of the quote per unit of the base of the ticker.
+	rate := new(big.Float).Quo(big.NewFloat(quote), big.NewFloat(base))
+
+	return h.interpolate(ticker, rate, fixDate, metadata.InterpolationPeriod, now), nil
+}
+
+// interpolate returns the price of the ticker as it moves linearly from the previous reference
+// rate to the given one over the interpolation period. The first rate of each ticker is reported
+// as is, since there is no previous rate to interpolate from.
+func (h *APIHandler) interpolate(
+	ticker types.ProviderTicker,
+	rate *big.Float,
+	fixDate string,
+	period time.Duration,
+	now time.Time,
+) *big.Float {
+	f, ok := h.fixes[ticker]
+	switch {
+	case !ok:
+		f = &fix{date: fixDate, rate: rate, start: rate, since: now}
+		h.fixes[ticker] = f
+	case f.date != fixDate:
+		// A new rate was published, so the price is interpolated from the current price, which may
+		// itself still be interpolated towards the previous rate.
+		current := f.at(period, now)
+		*f = fix{date: fixDate, rate: rate, start: current, since: now}
+	}
+
+	return f.at(period, now)
+}
+
+// at returns the price of the fix at the given time.
+func (f *fix) at(period time.Duration, now time.Time) *big.Float {
+	elapsed := now.Sub(f.since)
+	if period <= 0 || elapsed >= period {
+		return new(big.Float).Set(f.rate)
+	}
+
+	progress := big.NewFloat(float64(elapsed) / float64(period))
+	delta := new(big.Float).Sub(f.rate, f.start)
+	return new(big.Float).Add(f.start, delta.Mul(delta, progress))
+}
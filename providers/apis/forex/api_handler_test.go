@@ -0,0 +1,217 @@
+package forex_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/forex"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+const (
+	// friday is the reference rates of Friday, 5 January 2024.
+	friday = `{"amount": 1.0, "base": "EUR", "date": "2024-01-05", "rates": {"JPY": 158.27, "USD": 1.0921}}`
+	// monday is the reference rates of Monday, 8 January 2024.
+	monday = `{"amount": 1.0, "base": "EUR", "date": "2024-01-08", "rates": {"JPY": 158.27, "USD": 1.0971}}`
+)
+
+var (
+	eurusd = types.NewProviderTicker("EUR/USD", "")
+	usdjpy = types.NewProviderTicker("usd/jpy", "")
+)
+
+func TestNewAPIHandler(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		_, err := forex.NewAPIHandler(forex.DefaultAPIConfig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		cfg := forex.DefaultAPIConfig
+		cfg.Name = "invalid"
+		_, err := forex.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("disabled api", func(t *testing.T) {
+		cfg := forex.DefaultAPIConfig
+		cfg.Enabled = false
+		_, err := forex.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestParseResponse(t *testing.T) {
+	var (
+		saturday      = time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)
+		mondayMorn    = time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)
+		mondayEvening = time.Date(2024, 1, 8, 17, 0, 0, 0, time.UTC)
+
+		dailyFix = types.NewProviderTicker("EUR/USD", forex.MetaData{Mode: forex.ModeDailyFix}.MustToJSON())
+		skip     = types.NewProviderTicker("EUR/USD", forex.MetaData{Weekend: forex.WeekendSkip}.MustToJSON())
+	)
+
+	testCases := []struct {
+		name       string
+		now        time.Time
+		tickers    []types.ProviderTicker
+		response   string
+		prices     map[types.ProviderTicker]float64
+		unresolved map[types.ProviderTicker]providertypes.ErrorCode
+	}{
+		{
+			name:     "cross rates",
+			now:      mondayMorn,
+			tickers:  []types.ProviderTicker{eurusd, usdjpy},
+			response: friday,
+			prices: map[types.ProviderTicker]float64{
+				eurusd: 1.0921,
+				usdjpy: 158.27 / 1.0921,
+			},
+		},
+		{
+			name: "unknown currency and invalid ticker",
+			now:  mondayMorn,
+			tickers: []types.ProviderTicker{
+				types.NewProviderTicker("EUR/XYZ", ""),
+				types.NewProviderTicker("EURUSD", ""),
+			},
+			response: friday,
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				types.NewProviderTicker("EUR/XYZ", ""): providertypes.ErrorNoResponse,
+				types.NewProviderTicker("EURUSD", ""):  providertypes.ErrorUnknownPair,
+			},
+		},
+		{
+			name:     "daily fix of the previous business day before the fix time",
+			now:      mondayMorn,
+			tickers:  []types.ProviderTicker{dailyFix},
+			response: friday,
+			prices:   map[types.ProviderTicker]float64{dailyFix: 1.0921},
+		},
+		{
+			name:     "daily fix is held over the weekend",
+			now:      saturday,
+			tickers:  []types.ProviderTicker{dailyFix},
+			response: friday,
+			prices:   map[types.ProviderTicker]float64{dailyFix: 1.0921},
+		},
+		{
+			name:     "daily fix is stale after the fix time",
+			now:      mondayEvening,
+			tickers:  []types.ProviderTicker{dailyFix, eurusd},
+			response: friday,
+			prices:   map[types.ProviderTicker]float64{eurusd: 1.0921},
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				dailyFix: providertypes.ErrorStalePrice,
+			},
+		},
+		{
+			name:     "weekends are skipped",
+			now:      saturday,
+			tickers:  []types.ProviderTicker{skip, eurusd},
+			response: friday,
+			prices:   map[types.ProviderTicker]float64{eurusd: 1.0921},
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				skip: providertypes.ErrorStalePrice,
+			},
+		},
+		{
+			name:     "invalid metadata",
+			now:      mondayMorn,
+			tickers:  []types.ProviderTicker{types.NewProviderTicker("EUR/USD", `{"mode": "live"}`)},
+			response: friday,
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				types.NewProviderTicker("EUR/USD", `{"mode": "live"}`): providertypes.ErrorFailedToDecode,
+			},
+		},
+		{
+			name:     "invalid date",
+			now:      mondayMorn,
+			tickers:  []types.ProviderTicker{eurusd},
+			response: `{"base": "EUR", "date": "", "rates": {"USD": 1.0921}}`,
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				eurusd: providertypes.ErrorInvalidResponse,
+			},
+		},
+		{
+			name:     "bad response",
+			now:      mondayMorn,
+			tickers:  []types.ProviderTicker{eurusd},
+			response: `{"rates": [}`,
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				eurusd: providertypes.ErrorFailedToDecode,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := forex.NewAPIHandlerWithClock(forex.DefaultAPIConfig, func() time.Time { return tc.now })
+			require.NoError(t, err)
+
+			response := h.ParseResponse(tc.tickers, testutils.CreateResponseFromJSON(tc.response))
+			require.Len(t, response.Resolved, len(tc.prices))
+			for ticker, expected := range tc.prices {
+				require.Contains(t, response.Resolved, ticker)
+				price, _ := response.Resolved[ticker].Value.Float64()
+				require.InDelta(t, expected, price, 1e-9)
+				require.Equal(t, tc.now, response.Resolved[ticker].Timestamp)
+			}
+
+			require.Len(t, response.UnResolved, len(tc.unresolved))
+			for ticker, code := range tc.unresolved {
+				require.Contains(t, response.UnResolved, ticker)
+				require.Equal(t, code, response.UnResolved[ticker].Code())
+			}
+		})
+	}
+}
+
+func TestParseResponseInterpolation(t *testing.T) {
+	now := time.Date(2024, 1, 8, 17, 0, 0, 0, time.UTC)
+	ticker := types.NewProviderTicker("EUR/USD", forex.MetaData{InterpolationPeriod: time.Hour}.MustToJSON())
+
+	h, err := forex.NewAPIHandlerWithClock(forex.DefaultAPIConfig, func() time.Time { return now })
+	require.NoError(t, err)
+
+	price := func(response string) float64 {
+		resp := h.ParseResponse([]types.ProviderTicker{ticker}, testutils.CreateResponseFromJSON(response))
+		require.Contains(t, resp.Resolved, ticker)
+		f, _ := resp.Resolved[ticker].Value.Float64()
+		return f
+	}
+
+	// The first rate is reported as is.
+	require.InDelta(t, 1.0921, price(friday), 1e-9)
+
+	// A new rate is interpolated towards over the interpolation period.
+	require.InDelta(t, 1.0921, price(monday), 1e-9)
+	now = now.Add(30 * time.Minute)
+	require.InDelta(t, 1.0946, price(monday), 1e-9)
+	now = now.Add(30 * time.Minute)
+	require.InDelta(t, 1.0971, price(monday), 1e-9)
+	now = now.Add(30 * time.Minute)
+	require.InDelta(t, 1.0971, price(monday), 1e-9)
+}
+
+func TestLatestFixDate(t *testing.T) {
+	testCases := []struct {
+		now      time.Time
+		expected string
+	}{
+		{time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC), "2024-01-05"},
+		{time.Date(2024, 1, 8, 16, 0, 0, 0, time.UTC), "2024-01-08"},
+		{time.Date(2024, 1, 9, 12, 0, 0, 0, time.UTC), "2024-01-08"},
+		{time.Date(2024, 1, 6, 18, 0, 0, 0, time.UTC), "2024-01-05"},
+		{time.Date(2024, 1, 7, 18, 0, 0, 0, time.UTC), "2024-01-05"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, forex.LatestFixDate(tc.now).Format(forex.DateFormat), tc.now.String())
+	}
+}
@@ -0,0 +1,151 @@
+package forex
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// NOTE: All documentation for this file can be located on the Frankfurter docs, which serves the
+// euro foreign exchange reference rates published by the European Central Bank.
+// API documentation: https://www.frankfurter.app/docs.
+// The Frankfurter API does not require an API key.
+
+const (
+	// Name is the name of the forex provider.
+	Name = "forex_api"
+
+	// URL is the URL of the latest reference rates, quoted against the euro.
+	URL = "https://api.frankfurter.app/latest"
+
+	// TickerSeparator is the separator of the base and quote currencies of an off-chain ticker,
+	// e.g. EUR/USD.
+	TickerSeparator = "/"
+
+	// DateFormat is the format of the date of the reference rates.
+	DateFormat = "2006-01-02"
+
+	// FixTime is the time of day, in UTC, after which the reference rates of the day are
+	// expected to be available. The ECB publishes the rates at around 16:00 CET.
+	FixTime = 16 * time.Hour
+)
+
+const (
+	// ModeLatest reports the latest reference rate, regardless of when it was fixed. This is the
+	// default mode.
+	ModeLatest = "latest"
+	// ModeDailyFix only reports the reference rate of the latest business day, such that the
+	// price is not reported if the rates were not updated, e.g. because the API is lagging.
+	ModeDailyFix = "daily_fix"
+
+	// WeekendHold reports the reference rate of the last business day over weekends. This is the
+	// default weekend policy.
+	WeekendHold = "hold"
+	// WeekendSkip does not report a price over weekends, when FX markets are closed.
+	WeekendSkip = "skip"
+)
+
+// DefaultAPIConfig is the default configuration for the forex API. Reference rates are fixed once
+// a day, so the rates are queried infrequently.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           true,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         60 * time.Second,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+// MetaData is the optional per-ticker metadata of the forex provider, which configures how the
+// reference rate of the ticker is reported.
+type MetaData struct {
+	// Mode is either latest or daily_fix. If unset, the latest rate is reported.
+	Mode string `json:"mode,omitempty"`
+	// Weekend is the policy over weekends, i.e. hold or skip. If unset, the rate of the last
+	// business day is held.
+	Weekend string `json:"weekend,omitempty"`
+	// InterpolationPeriod is the period over which the price moves linearly from the previous
+	// reference rate to a newly published one, rather than jumping to it. This smooths the jump
+	// between the rates of consecutive business days, which is largest after weekends. If unset,
+	// new rates are reported immediately.
+	InterpolationPeriod time.Duration `json:"interpolationPeriod,omitempty"`
+}
+
+// ValidateBasic validates the metadata.
+func (m *MetaData) ValidateBasic() error {
+	switch m.Mode {
+	case "", ModeLatest, ModeDailyFix:
+	default:
+		return fmt.Errorf("invalid mode %s; expected %s or %s", m.Mode, ModeLatest, ModeDailyFix)
+	}
+
+	switch m.Weekend {
+	case "", WeekendHold, WeekendSkip:
+	default:
+		return fmt.Errorf("invalid weekend policy %s; expected %s or %s", m.Weekend, WeekendHold, WeekendSkip)
+	}
+
+	if m.InterpolationPeriod < 0 {
+		return fmt.Errorf("interpolation period cannot be negative")
+	}
+
+	return nil
+}
+
+// MustToJSON converts the metadata to JSON.
+func (m MetaData) MustToJSON() string {
+	b, err := json.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// RatesResponse is the expected response returned by the Frankfurter API.
+// Response format:
+//
+//	{
+//	  "amount": 1.0,
+//	  "base": "EUR",
+//	  "date": "2024-01-05",
+//	  "rates": {
+//	    "JPY": 158.27,
+//	    "USD": 1.0921
+//	  }
+//	}
+type RatesResponse struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// LatestFixDate returns the date of the latest reference rates expected to be published at the
+// given time, i.e. today after the fix time, otherwise the previous business day. Note that this
+// does not account for TARGET holidays, on which no rates are published.
+func LatestFixDate(now time.Time) time.Time {
+	now = now.UTC()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if now.Sub(date) < FixTime {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	for IsWeekend(date) {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	return date
+}
+
+// IsWeekend returns true if the given time is on a weekend in UTC.
+func IsWeekend(t time.Time) bool {
+	switch t.UTC().Weekday() {
+	case time.Saturday, time.Sunday:
+		return true
+	default:
+		return false
+	}
+}
@@ -103,11 +103,20 @@ func (h *APIHandler) ParseResponse(
 		)
 	}
 
+	// Kraken may respond with its nonstandard asset codes rather than the pair that was queried,
+	// so the tickers are also matched by their normalized pairs.
+	normalized := make(map[string]types.ProviderTicker, len(tickers))
+	for _, ticker := range tickers {
+		normalized[NormalizePair(ticker.GetOffChainTicker())] = ticker
+	}
+
 	for pair, resultTicker := range result.Tickers {
 		resultTicker.pair = pair
 		ticker, ok := h.cache.FromOffChainTicker(pair)
 		if !ok {
-			continue
+			if ticker, ok = normalized[NormalizePair(pair)]; !ok {
+				continue
+			}
 		}
 
 		price, err := math.Float64StringToBigFloat(resultTicker.LastPrice())
@@ -28,6 +28,9 @@ var (
 	ethusd = types.DefaultProviderTicker{
 		OffChainTicker: "XETHZUSD",
 	}
+	btcusdStandard = types.DefaultProviderTicker{
+		OffChainTicker: "BTCUSD",
+	}
 )
 
 func TestCreateURL(t *testing.T) {
@@ -123,6 +126,23 @@ func TestParseResponse(t *testing.T) {
 				types.UnResolvedPrices{},
 			),
 		},
+		{
+			name: "valid single with standard symbols",
+			cps: []types.ProviderTicker{
+				btcusdStandard,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"error":[],"result":{"XXBTZUSD":{"a":["64587.50000","2","2.000"],"b":["64587.40000","11","11.000"],"c":["64587.40000","0.01026127"],"v":["5866.14264484","6251.33408493"],"p":["64487.45123","64670.54770"],"t":[56819,62596],"l":["62356.50000","62356.50000"],"h":["68075.00000","68075.00000"],"o":"67600.00000"}}}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusdStandard: {
+						Value: big.NewFloat(64587.4),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
 		{
 			name: "bad response",
 			cps: []types.ProviderTicker{
@@ -264,3 +284,27 @@ func TestDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizePair(t *testing.T) {
+	testCases := []struct {
+		pair     string
+		expected string
+	}{
+		{"XXBTZUSD", "BTCUSD"},
+		{"XBTUSD", "BTCUSD"},
+		{"XBTUSDT", "BTCUSDT"},
+		{"XETHXXBT", "ETHBTC"},
+		{"ETHXBT", "ETHBTC"},
+		{"XDGUSD", "DOGEUSD"},
+		{"USDTZUSD", "USDTUSD"},
+		{"xethzeur", "ETHEUR"},
+		{"SOLUSD", "SOLUSD"},
+		{"ZUSD", "ZUSD"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pair, func(t *testing.T) {
+			require.Equal(t, tc.expected, kraken.NormalizePair(tc.pair))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package kraken
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
@@ -36,6 +37,61 @@ var DefaultAPIConfig = config.APIConfig{
 	Endpoints:        []config.Endpoint{{URL: URL}},
 }
 
+// AssetCodes maps Kraken's nonstandard asset codes to their standard symbols. Kraken prefixes
+// the codes of its oldest assets with an X (crypto) or Z (fiat), e.g. XXBT for bitcoin and ZUSD for
+// the US dollar, and it responds with those codes even if a pair was queried with the standard
+// symbols, e.g. a query for BTCUSD returns a result for XXBTZUSD.
+var AssetCodes = map[string]string{
+	"XXBT": "BTC",
+	"XBT":  "BTC",
+	"XXDG": "DOGE",
+	"XDG":  "DOGE",
+	"XETH": "ETH",
+	"XETC": "ETC",
+	"XLTC": "LTC",
+	"XMLN": "MLN",
+	"XREP": "REP",
+	"XXLM": "XLM",
+	"XXMR": "XMR",
+	"XXRP": "XRP",
+	"XZEC": "ZEC",
+	"ZAUD": "AUD",
+	"ZCAD": "CAD",
+	"ZCHF": "CHF",
+	"ZEUR": "EUR",
+	"ZGBP": "GBP",
+	"ZJPY": "JPY",
+	"ZUSD": "USD",
+}
+
+// NormalizePair converts a Kraken pair to its standard symbols using AssetCodes, e.g. XXBTZUSD and
+// XBTUSD are both normalized to BTCUSD. Pairs that do not contain any nonstandard asset codes are
+// returned as is.
+func NormalizePair(pair string) string {
+	pair = strings.ToUpper(pair)
+
+	base, rest := "", pair
+	for _, n := range []int{4, 3} {
+		if len(rest) > n {
+			if symbol, ok := AssetCodes[rest[:n]]; ok {
+				base, rest = symbol, rest[n:]
+				break
+			}
+		}
+	}
+
+	for _, n := range []int{4, 3} {
+		// If the base is not a nonstandard code, it must not be consumed by the quote.
+		if len(rest) > n || (base != "" && len(rest) == n) {
+			if symbol, ok := AssetCodes[rest[len(rest)-n:]]; ok {
+				return base + rest[:len(rest)-n] + symbol
+			}
+		}
+	}
+
+	return base + rest
+}
+
 // TickerResult is the result of a Kraken API call for a single ticker.
 //
 // https://api.kraken.com/0/public/Ticker
@@ -0,0 +1,124 @@
+package okx
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
+
+// APIHandler implements the PriceAPIDataHandler interface for OKX.
+// for more information about the OKX API, refer to the following link:
+// https://www.okx.com/docs-v5/en/#order-book-trading-market-data
+type APIHandler struct {
+	// api is the config for the OKX API.
+	api config.APIConfig
+	// cache maintains the latest set of tickers seen by the handler.
+	cache types.ProviderTickers
+}
+
+// NewAPIHandler returns a new OKX PriceAPIDataHandler.
+func NewAPIHandler(
+	api config.APIConfig,
+) (types.PriceAPIDataHandler, error) {
+	if api.Name != Name {
+		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", Name)
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config for %s: %w", Name, err)
+	}
+
+	return &APIHandler{
+		api:   api,
+		cache: types.NewProviderTickers(),
+	}, nil
+}
+
+// CreateURL returns the URL that is used to fetch data from the OKX API for the given
+// tickers. The OKX API returns the tickers of all spot instruments, so the URL is the
+// same for any set of tickers.
+func (h *APIHandler) CreateURL(
+	tickers []types.ProviderTicker,
+) (string, error) {
+	if len(tickers) == 0 {
+		return "", fmt.Errorf("empty url created. invalid or no ticker were provided")
+	}
+
+	for _, ticker := range tickers {
+		h.cache.Add(ticker)
+	}
+
+	return h.api.Endpoints[0].URL, nil
+}
+
+// ParseResponse parses the response from the OKX API and returns a GetResponse. Each
+// of the tickers supplied will get a response or an error.
+func (h *APIHandler) ParseResponse(
+	tickers []types.ProviderTicker,
+	resp *http.Response,
+) types.PriceResponse {
+	result, err := Decode(resp)
+	if err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+		)
+	}
+
+	if result.Code != SuccessCode {
+		err := fmt.Errorf("okx API call error: code %s: %s", result.Code, result.Msg)
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse),
+		)
+	}
+
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unresolved = make(types.UnResolvedPrices)
+	)
+
+	for _, data := range result.Data {
+		// Filter out the responses that are not expected.
+		ticker, ok := h.cache.FromOffChainTicker(data.InstrumentID)
+		if !ok {
+			continue
+		}
+
+		price, err := math.Float64StringToBigFloat(data.Last)
+		if err != nil {
+			wErr := fmt.Errorf("failed to convert price %s to big.Float: %w", data.Last, err)
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(wErr, providertypes.ErrorFailedToParsePrice),
+			}
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResult(price, time.Now().UTC())
+	}
+
+	// Add currency pairs that received no response to the unresolved map.
+	for _, ticker := range tickers {
+		_, resolvedOk := resolved[ticker]
+		_, unresolvedOk := unresolved[ticker]
+
+		if !resolvedOk && !unresolvedOk {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+			}
+		}
+	}
+
+	return types.NewPriceResponse(resolved, unresolved)
+}
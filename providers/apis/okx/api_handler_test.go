@@ -0,0 +1,223 @@
+package okx_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/okx"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var (
+	btcusdt = types.DefaultProviderTicker{
+		OffChainTicker: "BTC-USDT",
+	}
+	ethusdt = types.DefaultProviderTicker{
+		OffChainTicker: "ETH-USDT",
+	}
+)
+
+func TestCreateURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cps         []types.ProviderTicker
+		url         string
+		expectedErr bool
+	}{
+		{
+			name:        "empty",
+			cps:         []types.ProviderTicker{},
+			url:         "",
+			expectedErr: true,
+		},
+		{
+			name: "valid single",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			url:         "https://www.okx.com/api/v5/market/tickers?instType=SPOT",
+			expectedErr: false,
+		},
+		{
+			name: "valid multiple",
+			cps: []types.ProviderTicker{
+				btcusdt,
+				ethusdt,
+			},
+			url:         "https://www.okx.com/api/v5/market/tickers?instType=SPOT",
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := okx.NewAPIHandler(okx.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			url, err := h.CreateURL(tc.cps)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.url, url)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cps      []types.ProviderTicker
+		response *http.Response
+		expected types.PriceResponse
+	}{
+		{
+			name: "valid single",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","last":"64587.4","ts":"1597026383085"},{"instType":"SPOT","instId":"SOL-USDT","last":"150.1","ts":"1597026383085"}]}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusdt: {
+						Value: big.NewFloat(64587.4),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "valid multiple",
+			cps: []types.ProviderTicker{
+				btcusdt,
+				ethusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","last":"64587.4","ts":"1597026383085"},{"instType":"SPOT","instId":"ETH-USDT","last":"3338.08","ts":"1597026383085"}]}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusdt: {
+						Value: big.NewFloat(64587.4),
+					},
+					ethusdt: {
+						Value: big.NewFloat(3338.08),
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "bad response",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`shout out my label that's me`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("bad response"), providertypes.ErrorFailedToDecode),
+					},
+				},
+			),
+		},
+		{
+			name: "error code",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"code":"50011","msg":"Too Many Requests","data":[]}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("too many requests"), providertypes.ErrorInvalidResponse),
+					},
+				},
+			),
+		},
+		{
+			name: "bad price response",
+			cps: []types.ProviderTicker{
+				btcusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","last":"$64587.4","ts":"1597026383085"}]}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("invalid syntax"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "no response",
+			cps: []types.ProviderTicker{
+				btcusdt,
+				ethusdt,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`{"code":"0","msg":"","data":[]}`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+					ethusdt: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+				},
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := okx.NewAPIHandler(okx.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			// Update the cache since it is assumed that createURL is executed before ParseResponse.
+			_, err = h.CreateURL(tc.cps)
+			require.NoError(t, err)
+
+			now := time.Now()
+			resp := h.ParseResponse(tc.cps, tc.response)
+
+			require.Len(t, resp.Resolved, len(tc.expected.Resolved))
+			require.Len(t, resp.UnResolved, len(tc.expected.UnResolved))
+
+			for cp, result := range tc.expected.Resolved {
+				require.Contains(t, resp.Resolved, cp)
+				r := resp.Resolved[cp]
+				require.Equal(t, result.Value.SetPrec(18), r.Value.SetPrec(18))
+				require.True(t, r.Timestamp.After(now))
+			}
+
+			for cp, result := range tc.expected.UnResolved {
+				require.Contains(t, resp.UnResolved, cp)
+				require.Error(t, resp.UnResolved[cp])
+				require.Equal(t, result.Code(), resp.UnResolved[cp].Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package okx
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// NOTE: All documentation for this file can be located on the OKX docs.
+// API documentation: https://www.okx.com/docs-v5/en/#order-book-trading-market-data-get-tickers. This
+// API does not require a subscription to use (i.e. No API key is required).
+
+const (
+	// Name is the name of the OKX API provider.
+	Name = "okx_api"
+
+	// URL is the URL of the OKX API. This returns the tickers of all spot instruments in a
+	// single request.
+	URL = "https://www.okx.com/api/v5/market/tickers?instType=SPOT"
+
+	// SuccessCode is the code returned by the OKX API for a successful request.
+	SuccessCode = "0"
+)
+
+// DefaultAPIConfig is the default configuration for the OKX API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           true,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         750 * time.Millisecond,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+type (
+	// Response is the expected response returned by the OKX API.
+	// The response is json formatted.
+	// Response format:
+	//
+	//	{
+	//	  "code": "0",
+	//	  "msg": "",
+	//	  "data": [
+	//	    {
+	//	      "instType": "SPOT",
+	//	      "instId": "BTC-USDT",
+	//	      "last": "64587.4",
+	//	      "ts": "1597026383085"
+	//	    }
+	//	  ]
+	//	}
+	Response struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []Data `json:"data"`
+	}
+
+	// Data is the ticker data of a single instrument returned by the OKX API.
+	Data struct {
+		InstrumentType string `json:"instType"`
+		InstrumentID   string `json:"instId"`
+		Last           string `json:"last"`
+		Timestamp      string `json:"ts"`
+	}
+)
+
+// Decode decodes the given http response into a Response.
+func Decode(resp *http.Response) (Response, error) {
+	var result Response
+	err := json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
@@ -0,0 +1,112 @@
+package orderbook
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Level is a price level of an order book, i.e. the total size of the orders at a price. The
+// size is in units of the base asset.
+type Level struct {
+	Price float64
+	Size  float64
+}
+
+// Book is a snapshot of the top of an order book. Bids are sorted by descending price and asks by
+// ascending price, such that the best bid and ask are first.
+type Book struct {
+	Bids []Level
+	Asks []Level
+}
+
+// NewBook returns a new order book with the given levels, sorted from the best price and
+// truncated to the given depth per side. A depth of 0 keeps all levels.
+func NewBook(bids, asks []Level, depth int) Book {
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	sort.SliceStable(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+
+	if depth > 0 {
+		bids = bids[:min(depth, len(bids))]
+		asks = asks[:min(depth, len(asks))]
+	}
+
+	return Book{Bids: bids, Asks: asks}
+}
+
+// ValidateBasic returns an error if either side of the book is empty, any level has a
+// non-positive price or size, or the book is crossed.
+func (b Book) ValidateBasic() error {
+	if len(b.Bids) == 0 || len(b.Asks) == 0 {
+		return fmt.Errorf("order book has %d bids and %d asks; expected both sides", len(b.Bids), len(b.Asks))
+	}
+
+	for _, levels := range [][]Level{b.Bids, b.Asks} {
+		for _, level := range levels {
+			if level.Price <= 0 || level.Size <= 0 {
+				return fmt.Errorf("invalid level with price %f and size %f", level.Price, level.Size)
+			}
+		}
+	}
+
+	if b.Bids[0].Price >= b.Asks[0].Price {
+		return fmt.Errorf("order book is crossed; best bid %f, best ask %f", b.Bids[0].Price, b.Asks[0].Price)
+	}
+
+	return nil
+}
+
+// MidPrice returns the mean of the best bid and the best ask.
+func (b Book) MidPrice() (float64, error) {
+	if err := b.ValidateBasic(); err != nil {
+		return 0, err
+	}
+
+	return (b.Bids[0].Price + b.Asks[0].Price) / 2, nil
+}
+
+// ImpactPrice returns the mean of the average prices at which an order of the given notional, in
+// units of the quote asset, would be filled when buying against the asks and when selling against
+// the bids. Unlike the mid price, moving the impact price requires moving the book to the depth of
+// the notional. An error is returned if either side of the book is too shallow to fill the order.
+func (b Book) ImpactPrice(notional float64) (float64, error) {
+	if err := b.ValidateBasic(); err != nil {
+		return 0, err
+	}
+
+	if notional <= 0 {
+		return 0, fmt.Errorf("notional must be positive")
+	}
+
+	buy, err := fillPrice(b.Asks, notional)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fill buy order: %w", err)
+	}
+
+	sell, err := fillPrice(b.Bids, notional)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fill sell order: %w", err)
+	}
+
+	return (buy + sell) / 2, nil
+}
+
+// fillPrice returns the average price at which an order of the given notional is filled by
+// walking the given levels from the best price.
+func fillPrice(levels []Level, notional float64) (float64, error) {
+	var (
+		remaining = notional
+		filled    float64
+	)
+	for _, level := range levels {
+		value := level.Price * level.Size
+		if value >= remaining {
+			filled += remaining / level.Price
+			return notional / filled, nil
+		}
+
+		remaining -= value
+		filled += level.Size
+	}
+
+	return 0, fmt.Errorf("insufficient depth; %f of %f notional unfilled", remaining, notional)
+}
@@ -0,0 +1,111 @@
+package orderbook_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/apis/orderbook"
+)
+
+func TestNewBook(t *testing.T) {
+	book := orderbook.NewBook(
+		[]orderbook.Level{{Price: 98, Size: 1}, {Price: 99, Size: 1}, {Price: 97, Size: 1}},
+		[]orderbook.Level{{Price: 102, Size: 1}, {Price: 101, Size: 1}, {Price: 103, Size: 1}},
+		2,
+	)
+
+	require.Equal(t, []orderbook.Level{{Price: 99, Size: 1}, {Price: 98, Size: 1}}, book.Bids)
+	require.Equal(t, []orderbook.Level{{Price: 101, Size: 1}, {Price: 102, Size: 1}}, book.Asks)
+}
+
+func TestBookPrices(t *testing.T) {
+	book := orderbook.NewBook(
+		[]orderbook.Level{{Price: 99, Size: 10}, {Price: 98, Size: 10}},
+		[]orderbook.Level{{Price: 101, Size: 10}, {Price: 102, Size: 10}},
+		0,
+	)
+
+	testCases := []struct {
+		name     string
+		book     orderbook.Book
+		notional float64
+		mid      float64
+		impact   float64
+		midErr   bool
+		impErr   bool
+	}{
+		{
+			name:     "impact within the top of book",
+			book:     book,
+			notional: 500,
+			mid:      100,
+			impact:   100,
+		},
+		{
+			// Buying 1520 fills 10 at 101 and 5 at 102, i.e. at 1520 / 15. Selling 1520 fills 10
+			// at 99 and 5.3061 at 98, i.e. at 1520 / 15.3061.
+			name:     "impact through the top of book",
+			book:     book,
+			notional: 1520,
+			mid:      100,
+			impact:   (1520.0/15 + 1520/(10+530.0/98)) / 2,
+		},
+		{
+			name:     "impact deeper than the book",
+			book:     book,
+			notional: 5000,
+			mid:      100,
+			impErr:   true,
+		},
+		{
+			name:     "one sided book",
+			book:     orderbook.NewBook([]orderbook.Level{{Price: 99, Size: 1}}, nil, 0),
+			notional: 1,
+			midErr:   true,
+			impErr:   true,
+		},
+		{
+			name: "crossed book",
+			book: orderbook.NewBook(
+				[]orderbook.Level{{Price: 101, Size: 1}},
+				[]orderbook.Level{{Price: 100, Size: 1}},
+				0,
+			),
+			notional: 1,
+			midErr:   true,
+			impErr:   true,
+		},
+		{
+			name: "empty level",
+			book: orderbook.NewBook(
+				[]orderbook.Level{{Price: 99, Size: 0}},
+				[]orderbook.Level{{Price: 100, Size: 1}},
+				0,
+			),
+			notional: 1,
+			midErr:   true,
+			impErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mid, err := tc.book.MidPrice()
+			if tc.midErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.InDelta(t, tc.mid, mid, 1e-9)
+			}
+
+			impact, err := tc.book.ImpactPrice(tc.notional)
+			if tc.impErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.InDelta(t, tc.impact, impact, 1e-9)
+			}
+		})
+	}
+}
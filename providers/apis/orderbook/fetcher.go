@@ -0,0 +1,232 @@
+package orderbook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	"github.com/skip-mev/connect/v2/providers/base/polling"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// exchange defines how the order book of a ticker is requested from an exchange and decoded.
+type exchange struct {
+	// endpoint returns the endpoint of the order book of the off-chain ticker with the given depth.
+	endpoint func(ticker string, depth int) string
+	// decode decodes the bids and asks from the response. Errors reported by the exchange are
+	// returned with an error code.
+	decode func(decoder *json.Decoder) (bids, asks []RawLevel, err error)
+}
+
+// exchanges are the supported exchanges, keyed by the name of their provider.
+var exchanges = map[string]exchange{
+	BinanceName: {
+		endpoint: func(ticker string, depth int) string {
+			return fmt.Sprintf(BinanceEndpoint, url.QueryEscape(ticker), depth)
+		},
+		decode: func(decoder *json.Decoder) ([]RawLevel, []RawLevel, error) {
+			var resp BinanceDepthResponse
+			if err := decoder.Decode(&resp); err != nil {
+				return nil, nil, err
+			}
+			return resp.Bids, resp.Asks, nil
+		},
+	},
+	CoinbaseName: {
+		endpoint: func(ticker string, _ int) string {
+			return fmt.Sprintf(CoinbaseEndpoint, url.PathEscape(ticker))
+		},
+		decode: func(decoder *json.Decoder) ([]RawLevel, []RawLevel, error) {
+			var resp CoinbaseBookResponse
+			if err := decoder.Decode(&resp); err != nil {
+				return nil, nil, err
+			}
+			return resp.Bids, resp.Asks, nil
+		},
+	},
+	KrakenName: {
+		endpoint: func(ticker string, depth int) string {
+			return fmt.Sprintf(KrakenEndpoint, url.QueryEscape(ticker), depth)
+		},
+		decode: func(decoder *json.Decoder) ([]RawLevel, []RawLevel, error) {
+			var resp KrakenDepthResponse
+			if err := decoder.Decode(&resp); err != nil {
+				return nil, nil, err
+			}
+
+			if len(resp.Error) > 0 {
+				return nil, nil, providertypes.NewErrorWithCode(
+					fmt.Errorf("kraken error: %s", strings.Join(resp.Error, ", ")),
+					providertypes.ErrorInvalidResponse,
+				)
+			}
+
+			if len(resp.Result) != 1 {
+				return nil, nil, providertypes.NewErrorWithCode(
+					fmt.Errorf("expected the order book of 1 pair, got %d", len(resp.Result)),
+					providertypes.ErrorInvalidResponse,
+				)
+			}
+
+			for _, book := range resp.Result {
+				return book.Bids, book.Asks, nil
+			}
+			return nil, nil, nil
+		},
+	},
+	OKXName: {
+		endpoint: func(ticker string, depth int) string {
+			return fmt.Sprintf(OKXEndpoint, url.QueryEscape(ticker), depth)
+		},
+		decode: func(decoder *json.Decoder) ([]RawLevel, []RawLevel, error) {
+			var resp OKXBooksResponse
+			if err := decoder.Decode(&resp); err != nil {
+				return nil, nil, err
+			}
+
+			if resp.Code != "0" {
+				return nil, nil, providertypes.NewErrorWithCode(
+					fmt.Errorf("okx error %s: %s", resp.Code, resp.Msg),
+					providertypes.ErrorInvalidResponse,
+				)
+			}
+
+			if len(resp.Data) != 1 {
+				return nil, nil, providertypes.NewErrorWithCode(
+					fmt.Errorf("expected 1 order book, got %d", len(resp.Data)),
+					providertypes.ErrorInvalidResponse,
+				)
+			}
+			return resp.Data[0].Bids, resp.Data[0].Asks, nil
+		},
+	},
+}
+
+// IsOrderBookProvider returns true if the given provider name is an order book provider.
+func IsOrderBookProvider(name string) bool {
+	_, ok := exchanges[name]
+	return ok
+}
+
+// NewPriceFetcher returns a new order book price fetcher for the exchange of the given api
+// config. The fetcher requests the order book of each ticker and prices it at its mid price or
+// impact price, which are harder to manipulate than the last trade of illiquid markets.
+func NewPriceFetcher(
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+	client *http.Client,
+) (*polling.PriceFetcher[MetaData], error) {
+	ex, ok := exchanges[api.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown order book provider %s", api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("metrics cannot be nil")
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	r := &requester{
+		api:      api,
+		metrics:  apiMetrics,
+		client:   client,
+		exchange: ex,
+	}
+
+	return polling.NewPriceFetcher(logger, api, r.fetch)
+}
+
+// requester fetches the order book of a single ticker.
+type requester struct {
+	api      config.APIConfig
+	metrics  metrics.APIMetrics
+	client   *http.Client
+	exchange exchange
+}
+
+// fetch requests the order book of the ticker and prices it per the ticker's mode.
+func (r *requester) fetch(ctx context.Context, ticker types.ProviderTicker, md MetaData) (*big.Float, error) {
+	book, err := r.book(ctx, ticker, md.GetDepth())
+	if err != nil {
+		return nil, err
+	}
+
+	var price float64
+	switch md.Mode {
+	case ModeImpact:
+		price, err = book.ImpactPrice(md.Notional)
+	default:
+		price, err = book.MidPrice()
+	}
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("failed to price order book of %s: %w", ticker.GetOffChainTicker(), err),
+			providertypes.ErrorInvalidResponse,
+		)
+	}
+
+	return big.NewFloat(price), nil
+}
+
+// book requests the order book of the ticker, truncated to the given depth.
+func (r *requester) book(ctx context.Context, ticker types.ProviderTicker, depth int) (Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.api.Timeout)
+	defer cancel()
+
+	endpoint := strings.TrimSuffix(r.api.Endpoints[0].URL, "/") + r.exchange.endpoint(ticker.GetOffChainTicker(), depth)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return Book{}, providertypes.NewErrorWithCode(err, providertypes.ErrorUnableToCreateURL)
+	}
+
+	resp, err := r.client.Do(req)
+	r.metrics.AddHTTPStatusCode(r.api.Name, resp)
+	if err != nil {
+		return Book{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return Book{}, providertypes.NewErrorWithCode(fmt.Errorf("rate limited"), providertypes.ErrorRateLimitExceeded)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return Book{}, providertypes.NewErrorWithCode(
+			fmt.Errorf("unexpected status code %d", resp.StatusCode),
+			providertypes.ErrorCode(resp.StatusCode),
+		)
+	}
+
+	rawBids, rawAsks, err := r.exchange.decode(json.NewDecoder(resp.Body))
+	if err != nil {
+		return Book{}, providertypes.NewErrorWithCode(err, providertypes.ErrorCodeFromError(err, providertypes.ErrorFailedToDecode))
+	}
+
+	bids, err := parseLevels(rawBids)
+	if err != nil {
+		return Book{}, providertypes.NewErrorWithCode(fmt.Errorf("invalid bids: %w", err), providertypes.ErrorFailedToDecode)
+	}
+
+	asks, err := parseLevels(rawAsks)
+	if err != nil {
+		return Book{}, providertypes.NewErrorWithCode(fmt.Errorf("invalid asks: %w", err), providertypes.ErrorFailedToDecode)
+	}
+
+	return NewBook(bids, asks, depth), nil
+}
@@ -0,0 +1,139 @@
+package orderbook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/orderbook"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+func TestFetch(t *testing.T) {
+	var (
+		mid     = types.NewProviderTicker("BTC", "")
+		impact  = types.NewProviderTicker("BTC", orderbook.MetaData{Mode: orderbook.ModeImpact, Notional: 1520, Depth: 2}.MustToJSON())
+		deep    = types.NewProviderTicker("BTC", orderbook.MetaData{Mode: orderbook.ModeImpact, Notional: 1e9}.MustToJSON())
+		invalid = types.NewProviderTicker("BTC", `{"mode": "last"}`)
+		unknown = types.NewProviderTicker("UNKNOWN", "")
+		tickers = []types.ProviderTicker{mid, impact, deep, invalid, unknown}
+
+		// The book is the same on every exchange, with a third level per side that is only
+		// considered if the depth is greater than 2.
+		unresolved = map[types.ProviderTicker]providertypes.ErrorCode{
+			deep:    providertypes.ErrorInvalidResponse,
+			invalid: providertypes.ErrorFailedToDecode,
+		}
+		impactPrice = (1520.0/15 + 1520/(10+530.0/98)) / 2
+	)
+
+	testCases := []struct {
+		name    string
+		api     config.APIConfig
+		path    string
+		body    string
+		unknown providertypes.ErrorCode
+	}{
+		{
+			name:    "binance",
+			api:     orderbook.DefaultBinanceAPIConfig,
+			path:    "/api/v3/depth",
+			body:    `{"lastUpdateId": 1, "bids": [["99", "10"], ["98", "10"], ["1", "1000"]], "asks": [["101", "10"], ["102", "10"], ["1000", "1000"]]}`,
+			unknown: providertypes.ErrorCode(http.StatusBadRequest),
+		},
+		{
+			name:    "coinbase",
+			api:     orderbook.DefaultCoinbaseAPIConfig,
+			path:    "/products/BTC/book",
+			body:    `{"bids": [["99", "10", 1], ["98", "10", 2], ["1", "1000", 3]], "asks": [["102", "10", 1], ["101", "10", 1], ["1000", "1000", 3]], "sequence": 1}`,
+			unknown: providertypes.ErrorCode(http.StatusBadRequest),
+		},
+		{
+			name:    "kraken",
+			api:     orderbook.DefaultKrakenAPIConfig,
+			path:    "/0/public/Depth",
+			body:    `{"error": [], "result": {"XXBTZUSD": {"bids": [["99.0", "10.0", 1688671200], ["98.0", "10.0", 1688671200], ["1", "1000", 1688671200]], "asks": [["101.0", "10.0", 1688671200], ["102.0", "10.0", 1688671200], ["1000", "1000", 1688671200]]}}}`,
+			unknown: providertypes.ErrorInvalidResponse,
+		},
+		{
+			name:    "okx",
+			api:     orderbook.DefaultOKXAPIConfig,
+			path:    "/api/v5/market/books",
+			body:    `{"code": "0", "msg": "", "data": [{"bids": [["99", "10", "0", "1"], ["98", "10", "0", "1"], ["1", "1000", "0", "1"]], "asks": [["101", "10", "0", "1"], ["102", "10", "0", "1"], ["1000", "1000", "0", "1"]], "ts": "1"}]}`,
+			unknown: providertypes.ErrorInvalidResponse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path != tc.path && r.URL.Path != "/products/UNKNOWN/book":
+					w.WriteHeader(http.StatusNotFound)
+				case r.URL.Query().Get("symbol") == "UNKNOWN", r.URL.Path == "/products/UNKNOWN/book":
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"code": -1121, "msg": "Invalid symbol."}`)
+				case r.URL.Query().Get("pair") == "UNKNOWN":
+					fmt.Fprint(w, `{"error": ["EQuery:Unknown asset pair"]}`)
+				case r.URL.Query().Get("instId") == "UNKNOWN":
+					fmt.Fprint(w, `{"code": "51001", "msg": "Instrument ID does not exist", "data": []}`)
+				default:
+					fmt.Fprint(w, tc.body)
+				}
+			}))
+			defer server.Close()
+
+			api := tc.api
+			api.Endpoints = []config.Endpoint{{URL: server.URL}}
+			fetcher, err := orderbook.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), api, server.Client())
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tickers)
+			require.Len(t, response.Resolved, 2)
+
+			price, _ := response.Resolved[mid].Value.Float64()
+			require.InDelta(t, 100, price, 1e-9)
+			price, _ = response.Resolved[impact].Value.Float64()
+			require.InDelta(t, impactPrice, price, 1e-9)
+
+			require.Len(t, response.UnResolved, len(unresolved)+1)
+			for ticker, code := range unresolved {
+				require.Contains(t, response.UnResolved, ticker)
+				require.Equal(t, code, response.UnResolved[ticker].Code(), ticker.String())
+			}
+			require.Equal(t, tc.unknown, response.UnResolved[unknown].Code())
+		})
+	}
+}
+
+func TestNewPriceFetcher(t *testing.T) {
+	for _, api := range []config.APIConfig{
+		orderbook.DefaultBinanceAPIConfig,
+		orderbook.DefaultCoinbaseAPIConfig,
+		orderbook.DefaultKrakenAPIConfig,
+		orderbook.DefaultOKXAPIConfig,
+	} {
+		require.True(t, orderbook.IsOrderBookProvider(api.Name))
+		_, err := orderbook.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), api, http.DefaultClient)
+		require.NoError(t, err)
+	}
+
+	cfg := orderbook.DefaultBinanceAPIConfig
+	cfg.Name = "binance_api"
+	require.False(t, orderbook.IsOrderBookProvider(cfg.Name))
+	_, err := orderbook.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), cfg, http.DefaultClient)
+	require.Error(t, err)
+
+	cfg = orderbook.DefaultBinanceAPIConfig
+	cfg.Enabled = false
+	_, err = orderbook.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), cfg, http.DefaultClient)
+	require.Error(t, err)
+}
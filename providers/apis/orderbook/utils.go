@@ -0,0 +1,247 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+const (
+	// BinanceName is the name of the Binance order book provider.
+	BinanceName = "binance_orderbook_api"
+	// BinanceURL is the base URL of the Binance spot API.
+	BinanceURL = "https://api.binance.com"
+	// BinanceEndpoint is the endpoint of the order book of a symbol, e.g. BTCUSDT.
+	//
+	// ref: https://developers.binance.com/docs/binance-spot-api-docs/rest-api/market-data-endpoints#order-book
+	BinanceEndpoint = "/api/v3/depth?symbol=%s&limit=%d"
+
+	// CoinbaseName is the name of the Coinbase order book provider.
+	CoinbaseName = "coinbase_orderbook_api"
+	// CoinbaseURL is the base URL of the Coinbase Exchange API.
+	CoinbaseURL = "https://api.exchange.coinbase.com"
+	// CoinbaseEndpoint is the endpoint of the aggregated order book of a product, e.g. BTC-USD.
+	// The endpoint does not take a depth, so the book is truncated after it is received.
+	//
+	// ref: https://docs.cdp.coinbase.com/exchange/reference/exchangerestapi_getproductbook
+	CoinbaseEndpoint = "/products/%s/book?level=2"
+
+	// KrakenName is the name of the Kraken order book provider.
+	KrakenName = "kraken_orderbook_api"
+	// KrakenURL is the base URL of the Kraken API.
+	KrakenURL = "https://api.kraken.com"
+	// KrakenEndpoint is the endpoint of the order book of a pair, e.g. XBTUSD.
+	//
+	// ref: https://docs.kraken.com/api/docs/rest-api/get-order-book
+	KrakenEndpoint = "/0/public/Depth?pair=%s&count=%d"
+
+	// OKXName is the name of the OKX order book provider.
+	OKXName = "okx_orderbook_api"
+	// OKXURL is the base URL of the OKX API.
+	OKXURL = "https://www.okx.com"
+	// OKXEndpoint is the endpoint of the order book of an instrument, e.g. BTC-USDT.
+	//
+	// ref: https://www.okx.com/docs-v5/en/#order-book-trading-market-data-get-order-book
+	OKXEndpoint = "/api/v5/market/books?instId=%s&sz=%d"
+)
+
+const (
+	// ModeMid prices a ticker at the mean of its best bid and best ask. This is the default mode.
+	ModeMid = "mid"
+	// ModeImpact prices a ticker at the mean of the average fill prices of buying and selling a
+	// configured notional.
+	ModeImpact = "impact"
+
+	// DefaultDepth is the number of levels per side requested if the ticker does not configure a
+	// depth.
+	DefaultDepth = 20
+	// MaxDepth is the maximum number of levels per side that can be requested.
+	MaxDepth = 400
+)
+
+var (
+	// DefaultBinanceAPIConfig is the default configuration for the Binance order book provider.
+	DefaultBinanceAPIConfig = newAPIConfig(BinanceName, BinanceURL)
+	// DefaultCoinbaseAPIConfig is the default configuration for the Coinbase order book provider.
+	DefaultCoinbaseAPIConfig = newAPIConfig(CoinbaseName, CoinbaseURL)
+	// DefaultKrakenAPIConfig is the default configuration for the Kraken order book provider.
+	DefaultKrakenAPIConfig = newAPIConfig(KrakenName, KrakenURL)
+	// DefaultOKXAPIConfig is the default configuration for the OKX order book provider.
+	DefaultOKXAPIConfig = newAPIConfig(OKXName, OKXURL)
+)
+
+// newAPIConfig returns the default configuration of an order book provider. Order books are
+// requested per ticker, so requests are capped to stay within the exchanges' rate limits.
+func newAPIConfig(name, url string) config.APIConfig {
+	return config.APIConfig{
+		Name:             name,
+		Atomic:           false,
+		Enabled:          true,
+		Timeout:          3000 * time.Millisecond,
+		Interval:         2000 * time.Millisecond,
+		ReconnectTimeout: 2000 * time.Millisecond,
+		MaxQueries:       1,
+		MaxInFlight:      5,
+		Endpoints:        []config.Endpoint{{URL: url}},
+	}
+}
+
+// MetaData is the optional per-ticker metadata of the order book providers.
+type MetaData struct {
+	// Mode is how the ticker is priced from its order book, i.e. mid or impact. If unset, the mid
+	// price is used.
+	Mode string `json:"mode,omitempty"`
+	// Depth is the number of levels per side of the order book that are requested. If unset,
+	// DefaultDepth levels are requested.
+	Depth int `json:"depth,omitempty"`
+	// Notional is the size, in units of the quote asset, of the orders whose fill prices determine
+	// the impact price, e.g. 100000 for the price of filling $100k of a USD market.
+	Notional float64 `json:"notional,omitempty"`
+}
+
+// ValidateBasic validates the metadata.
+func (m *MetaData) ValidateBasic() error {
+	switch m.Mode {
+	case "", ModeMid:
+	case ModeImpact:
+		if m.Notional <= 0 {
+			return fmt.Errorf("notional must be positive in %s mode", ModeImpact)
+		}
+	default:
+		return fmt.Errorf("invalid mode %s; expected %s or %s", m.Mode, ModeMid, ModeImpact)
+	}
+
+	if m.Depth < 0 || m.Depth > MaxDepth {
+		return fmt.Errorf("depth must be in [0, %d]", MaxDepth)
+	}
+
+	return nil
+}
+
+// GetDepth returns the number of levels per side of the order book that are requested.
+func (m *MetaData) GetDepth() int {
+	if m.Depth == 0 {
+		return DefaultDepth
+	}
+
+	return m.Depth
+}
+
+// MustToJSON converts the metadata to JSON.
+func (m MetaData) MustToJSON() string {
+	b, err := json.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// RawLevel is a price level as returned by the exchanges, i.e. an array whose first two elements
+// are the price and size, either as strings or numbers. Exchanges append further elements, such
+// as the number of orders or a timestamp, which are ignored.
+type RawLevel []json.Number
+
+type (
+	// BinanceDepthResponse is the expected response of the Binance order book endpoint.
+	// Response format:
+	//
+	//	{
+	//	  "lastUpdateId": 1027024,
+	//	  "bids": [["4.00000000", "431.00000000"]],
+	//	  "asks": [["4.00000200", "12.00000000"]]
+	//	}
+	BinanceDepthResponse struct {
+		Bids []RawLevel `json:"bids"`
+		Asks []RawLevel `json:"asks"`
+	}
+
+	// CoinbaseBookResponse is the expected response of the Coinbase order book endpoint.
+	// Response format:
+	//
+	//	{
+	//	  "bids": [["29000.01", "0.5", 3]],
+	//	  "asks": [["29000.02", "1.2", 5]],
+	//	  "sequence": 13051505638
+	//	}
+	CoinbaseBookResponse struct {
+		Bids []RawLevel `json:"bids"`
+		Asks []RawLevel `json:"asks"`
+	}
+
+	// KrakenDepthResponse is the expected response of the Kraken order book endpoint. The result
+	// is keyed by Kraken's name of the pair, which may differ from the requested pair.
+	// Response format:
+	//
+	//	{
+	//	  "error": [],
+	//	  "result": {
+	//	    "XXBTZUSD": {
+	//	      "asks": [["29000.10000", "1.234", 1688671200]],
+	//	      "bids": [["29000.00000", "0.456", 1688671199]]
+	//	    }
+	//	  }
+	//	}
+	KrakenDepthResponse struct {
+		Error  []string                     `json:"error"`
+		Result map[string]KrakenDepthLevels `json:"result"`
+	}
+
+	// KrakenDepthLevels are the levels of a Kraken order book.
+	KrakenDepthLevels struct {
+		Bids []RawLevel `json:"bids"`
+		Asks []RawLevel `json:"asks"`
+	}
+
+	// OKXBooksResponse is the expected response of the OKX order book endpoint.
+	// Response format:
+	//
+	//	{
+	//	  "code": "0",
+	//	  "msg": "",
+	//	  "data": [
+	//	    {
+	//	      "asks": [["41006.8", "0.60038921", "0", "1"]],
+	//	      "bids": [["41006.3", "0.30178218", "0", "2"]],
+	//	      "ts": "1629966436396"
+	//	    }
+	//	  ]
+	//	}
+	OKXBooksResponse struct {
+		Code string          `json:"code"`
+		Msg  string          `json:"msg"`
+		Data []OKXBookLevels `json:"data"`
+	}
+
+	// OKXBookLevels are the levels of an OKX order book.
+	OKXBookLevels struct {
+		Bids []RawLevel `json:"bids"`
+		Asks []RawLevel `json:"asks"`
+	}
+)
+
+// parseLevels parses the raw levels of one side of an order book.
+func parseLevels(raw []RawLevel) ([]Level, error) {
+	levels := make([]Level, len(raw))
+	for i, level := range raw {
+		if len(level) < 2 {
+			return nil, fmt.Errorf("level %d has %d elements; expected at least 2", i, len(level))
+		}
+
+		price, err := strconv.ParseFloat(level[0].String(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse price of level %d: %w", i, err)
+		}
+
+		size, err := strconv.ParseFloat(level[1].String(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size of level %d: %w", i, err)
+		}
+
+		levels[i] = Level{Price: price, Size: size}
+	}
+
+	return levels, nil
+}
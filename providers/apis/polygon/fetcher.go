@@ -0,0 +1,197 @@
+package polygon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	"github.com/skip-mev/connect/v2/providers/base/polling"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// NewPriceFetcher returns a new Polygon.io price fetcher. The fetcher requests the last trade of
+// each stock and the last quote of each metal, and only reports prices while their market is open,
+// such that the last price before a market closes is not reported as if it were current.
+func NewPriceFetcher(
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+	client *http.Client,
+) (*polling.PriceFetcher[MetaData], error) {
+	return NewPriceFetcherWithClock(logger, apiMetrics, api, client, time.Now)
+}
+
+// NewPriceFetcherWithClock returns a new Polygon.io price fetcher that uses the given function to
+// determine the current time. This is useful for testing the market hours.
+func NewPriceFetcherWithClock(
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+	client *http.Client,
+	now func() time.Time,
+) (*polling.PriceFetcher[MetaData], error) {
+	if api.Name != Name {
+		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", Name)
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("metrics cannot be nil")
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	r := &requester{
+		api:     api,
+		metrics: apiMetrics,
+		client:  client,
+		now:     now,
+	}
+
+	return polling.NewPriceFetcher(logger, api, r.fetch)
+}
+
+// requester fetches the price of a single ticker.
+type requester struct {
+	api     config.APIConfig
+	metrics metrics.APIMetrics
+	client  *http.Client
+	now     func() time.Time
+}
+
+// fetch returns the price of the ticker if its market is open and the price is recent.
+func (r *requester) fetch(ctx context.Context, ticker types.ProviderTicker, md MetaData) (*big.Float, error) {
+	now := r.now()
+	if !IsMarketOpen(md.Market, now) {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("market of %s is closed", ticker.GetOffChainTicker()),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	var (
+		price     float64
+		timestamp time.Time
+		err       error
+	)
+	switch md.Market {
+	case MarketMetals:
+		price, timestamp, err = r.lastQuote(ctx, ticker)
+	default:
+		price, timestamp, err = r.lastTrade(ctx, ticker)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if price <= 0 {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("price %f of %s is not positive", price, ticker.GetOffChainTicker()),
+			providertypes.ErrorFailedToParsePrice,
+		)
+	}
+
+	if age := now.Sub(timestamp); md.MaxAge > 0 && age > md.MaxAge {
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("price of %s is %s old; max age is %s", ticker.GetOffChainTicker(), age, md.MaxAge),
+			providertypes.ErrorStalePrice,
+		)
+	}
+
+	return big.NewFloat(price), nil
+}
+
+// lastTrade returns the price and time of the last trade of a stock.
+func (r *requester) lastTrade(ctx context.Context, ticker types.ProviderTicker) (float64, time.Time, error) {
+	endpoint := fmt.Sprintf(LastTradeEndpoint, url.PathEscape(ticker.GetOffChainTicker()))
+
+	var resp LastTradeResponse
+	if err := r.get(ctx, endpoint, &resp); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if resp.Status != "OK" {
+		return 0, time.Time{}, providertypes.NewErrorWithCode(
+			fmt.Errorf("unexpected status %s", resp.Status),
+			providertypes.ErrorInvalidResponse,
+		)
+	}
+
+	return resp.Results.Price, time.Unix(0, resp.Results.Timestamp), nil
+}
+
+// lastQuote returns the mid price and time of the last quote of a metal.
+func (r *requester) lastQuote(ctx context.Context, ticker types.ProviderTicker) (float64, time.Time, error) {
+	currencies := strings.Split(ticker.GetOffChainTicker(), TickerSeparator)
+	if len(currencies) != 2 {
+		return 0, time.Time{}, providertypes.NewErrorWithCode(
+			fmt.Errorf("invalid ticker %s; expected BASE%sQUOTE", ticker.GetOffChainTicker(), TickerSeparator),
+			providertypes.ErrorUnknownPair,
+		)
+	}
+
+	endpoint := fmt.Sprintf(LastQuoteEndpoint, url.PathEscape(currencies[0]), url.PathEscape(currencies[1]))
+
+	var resp LastQuoteResponse
+	if err := r.get(ctx, endpoint, &resp); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if resp.Status != "success" {
+		return 0, time.Time{}, providertypes.NewErrorWithCode(
+			fmt.Errorf("unexpected status %s", resp.Status),
+			providertypes.ErrorInvalidResponse,
+		)
+	}
+
+	return (resp.Last.Ask + resp.Last.Bid) / 2, time.UnixMilli(resp.Last.Timestamp), nil
+}
+
+// get requests the given endpoint and decodes the response into v.
+func (r *requester) get(ctx context.Context, endpoint string, v interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, r.api.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(r.api.Endpoints[0].URL, "/")+endpoint, nil)
+	if err != nil {
+		return providertypes.NewErrorWithCode(err, providertypes.ErrorUnableToCreateURL)
+	}
+
+	resp, err := r.client.Do(req)
+	r.metrics.AddHTTPStatusCode(r.api.Name, resp)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return providertypes.NewErrorWithCode(fmt.Errorf("rate limited"), providertypes.ErrorRateLimitExceeded)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return providertypes.NewErrorWithCode(
+			fmt.Errorf("unexpected status code %d", resp.StatusCode),
+			providertypes.ErrorCode(resp.StatusCode),
+		)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package polygon_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/polygon"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// tradeTime is a time at which both stocks and metals trade, i.e. Friday, 5 January 2024 at
+// 11:00 in New York.
+var tradeTime = time.Date(2024, 1, 5, 16, 0, 0, 0, time.UTC)
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/last/trade/AAPL":
+			fmt.Fprintf(w, `{"results": {"T": "AAPL", "p": 181.5, "s": 100, "t": %d}, "status": "OK"}`, tradeTime.Add(-time.Second).UnixNano())
+		case "/v2/last/trade/THIN":
+			fmt.Fprintf(w, `{"results": {"T": "THIN", "p": 10, "s": 100, "t": %d}, "status": "OK"}`, tradeTime.Add(-time.Hour).UnixNano())
+		case "/v2/last/trade/ZERO":
+			fmt.Fprintf(w, `{"results": {"T": "ZERO", "p": 0, "t": %d}, "status": "OK"}`, tradeTime.UnixNano())
+		case "/v1/last_quote/currencies/XAU/USD":
+			fmt.Fprintf(w, `{"last": {"ask": 2045.2, "bid": 2044.8, "timestamp": %d}, "status": "success", "symbol": "XAU/USD"}`, tradeTime.UnixMilli())
+		case "/v2/last/trade/LIMITED":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status": "NOT_FOUND"}`)
+		}
+	}))
+	defer server.Close()
+
+	var (
+		aapl    = types.NewProviderTicker("AAPL", "")
+		thin    = types.NewProviderTicker("THIN", polygon.MetaData{MaxAge: time.Minute}.MustToJSON())
+		zero    = types.NewProviderTicker("ZERO", "")
+		xau     = types.NewProviderTicker("XAU/USD", polygon.MetaData{Market: polygon.MarketMetals}.MustToJSON())
+		xag     = types.NewProviderTicker("XAG", polygon.MetaData{Market: polygon.MarketMetals}.MustToJSON())
+		limited = types.NewProviderTicker("LIMITED", "")
+		unknown = types.NewProviderTicker("UNKNOWN", "")
+		invalid = types.NewProviderTicker("SPY", `{"market": "bonds"}`)
+		tickers = []types.ProviderTicker{aapl, thin, zero, xau, xag, limited, unknown, invalid}
+	)
+
+	api := polygon.DefaultAPIConfig
+	api.Endpoints = []config.Endpoint{{URL: server.URL}}
+
+	testCases := []struct {
+		name       string
+		now        time.Time
+		prices     map[types.ProviderTicker]float64
+		unresolved map[types.ProviderTicker]providertypes.ErrorCode
+	}{
+		{
+			name: "markets are open",
+			now:  tradeTime,
+			prices: map[types.ProviderTicker]float64{
+				aapl: 181.5,
+				xau:  2045,
+			},
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				thin:    providertypes.ErrorStalePrice,
+				zero:    providertypes.ErrorFailedToParsePrice,
+				xag:     providertypes.ErrorUnknownPair,
+				limited: providertypes.ErrorRateLimitExceeded,
+				unknown: providertypes.ErrorCode(http.StatusNotFound),
+				invalid: providertypes.ErrorFailedToDecode,
+			},
+		},
+		{
+			name: "stocks are closed after hours while metals trade",
+			now:  time.Date(2024, 1, 5, 21, 30, 0, 0, time.UTC),
+			prices: map[types.ProviderTicker]float64{
+				xau: 2045,
+			},
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				aapl:    providertypes.ErrorStalePrice,
+				thin:    providertypes.ErrorStalePrice,
+				zero:    providertypes.ErrorStalePrice,
+				xag:     providertypes.ErrorUnknownPair,
+				limited: providertypes.ErrorStalePrice,
+				unknown: providertypes.ErrorStalePrice,
+				invalid: providertypes.ErrorFailedToDecode,
+			},
+		},
+		{
+			name:   "markets are closed on weekends",
+			now:    time.Date(2024, 1, 6, 16, 0, 0, 0, time.UTC),
+			prices: map[types.ProviderTicker]float64{},
+			unresolved: map[types.ProviderTicker]providertypes.ErrorCode{
+				aapl:    providertypes.ErrorStalePrice,
+				thin:    providertypes.ErrorStalePrice,
+				zero:    providertypes.ErrorStalePrice,
+				xau:     providertypes.ErrorStalePrice,
+				xag:     providertypes.ErrorStalePrice,
+				limited: providertypes.ErrorStalePrice,
+				unknown: providertypes.ErrorStalePrice,
+				invalid: providertypes.ErrorFailedToDecode,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher, err := polygon.NewPriceFetcherWithClock(
+				zap.NewNop(),
+				metrics.NewNopAPIMetrics(),
+				api,
+				server.Client(),
+				func() time.Time { return tc.now },
+			)
+			require.NoError(t, err)
+
+			response := fetcher.Fetch(context.Background(), tickers)
+			require.Len(t, response.Resolved, len(tc.prices))
+			for ticker, expected := range tc.prices {
+				require.Contains(t, response.Resolved, ticker)
+				price, _ := response.Resolved[ticker].Value.Float64()
+				require.InDelta(t, expected, price, 1e-9)
+			}
+
+			require.Len(t, response.UnResolved, len(tc.unresolved))
+			for ticker, code := range tc.unresolved {
+				require.Contains(t, response.UnResolved, ticker)
+				require.Equal(t, code, response.UnResolved[ticker].Code(), ticker.String())
+			}
+		})
+	}
+}
+
+func TestNewPriceFetcher(t *testing.T) {
+	_, err := polygon.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), polygon.DefaultAPIConfig, http.DefaultClient)
+	require.NoError(t, err)
+
+	cfg := polygon.DefaultAPIConfig
+	cfg.Name = "invalid"
+	_, err = polygon.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), cfg, http.DefaultClient)
+	require.Error(t, err)
+
+	cfg = polygon.DefaultAPIConfig
+	cfg.Enabled = false
+	_, err = polygon.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), cfg, http.DefaultClient)
+	require.Error(t, err)
+}
+
+func TestIsMarketOpen(t *testing.T) {
+	testCases := []struct {
+		name   string
+		market string
+		time   time.Time
+		open   bool
+	}{
+		{"stocks at the open", polygon.MarketStocks, time.Date(2024, 1, 5, 14, 30, 0, 0, time.UTC), true},
+		{"stocks before the open", polygon.MarketStocks, time.Date(2024, 1, 5, 14, 29, 0, 0, time.UTC), false},
+		{"stocks at the close", polygon.MarketStocks, time.Date(2024, 1, 5, 21, 0, 0, 0, time.UTC), false},
+		{"stocks during daylight saving time", polygon.MarketStocks, time.Date(2024, 7, 1, 13, 30, 0, 0, time.UTC), true},
+		{"stocks on saturday", polygon.MarketStocks, time.Date(2024, 1, 6, 16, 0, 0, 0, time.UTC), false},
+		{"default market is stocks", "", time.Date(2024, 1, 6, 16, 0, 0, 0, time.UTC), false},
+		{"metals overnight", polygon.MarketMetals, time.Date(2024, 1, 4, 5, 0, 0, 0, time.UTC), true},
+		{"metals during the daily break", polygon.MarketMetals, time.Date(2024, 1, 4, 22, 30, 0, 0, time.UTC), false},
+		{"metals after friday's close", polygon.MarketMetals, time.Date(2024, 1, 5, 22, 0, 0, 0, time.UTC), false},
+		{"metals before sunday's open", polygon.MarketMetals, time.Date(2024, 1, 7, 22, 0, 0, 0, time.UTC), false},
+		{"metals after sunday's open", polygon.MarketMetals, time.Date(2024, 1, 7, 23, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.open, polygon.IsMarketOpen(tc.market, tc.time))
+		})
+	}
+}
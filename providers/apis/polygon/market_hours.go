@@ -0,0 +1,61 @@
+package polygon
+
+import (
+	"time"
+	// The market hours are defined in New York time, which must be available regardless of the
+	// time zone database of the host.
+	_ "time/tzdata"
+)
+
+// newYork is the time zone of the market hours.
+var newYork = mustLoadLocation("America/New_York")
+
+const (
+	// stocksOpen and stocksClose are the times of day, in New York, of the regular trading session
+	// of US stocks.
+	stocksOpen  = 9*time.Hour + 30*time.Minute
+	stocksClose = 16 * time.Hour
+
+	// metalsClose and metalsOpen are the times of day, in New York, of the daily break of the
+	// metals market, which trades from Sunday evening to Friday evening.
+	metalsClose = 17 * time.Hour
+	metalsOpen  = 18 * time.Hour
+)
+
+// IsMarketOpen returns true if the given market is open at the given time. Stocks trade from 9:30
+// to 16:00 New York time on weekdays, and metals from 18:00 to 17:00 New York time the next day,
+// from Sunday to Friday. Note that market holidays are not accounted for.
+func IsMarketOpen(market string, t time.Time) bool {
+	t = t.In(newYork)
+	hour, minute, second := t.Clock()
+	timeOfDay := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+
+	switch market {
+	case MarketMetals:
+		switch t.Weekday() {
+		case time.Saturday:
+			return false
+		case time.Sunday:
+			return timeOfDay >= metalsOpen
+		case time.Friday:
+			return timeOfDay < metalsClose
+		default:
+			return timeOfDay < metalsClose || timeOfDay >= metalsOpen
+		}
+	default:
+		switch t.Weekday() {
+		case time.Saturday, time.Sunday:
+			return false
+		default:
+			return timeOfDay >= stocksOpen && timeOfDay < stocksClose
+		}
+	}
+}
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
@@ -0,0 +1,142 @@
+package polygon
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// NOTE: All documentation for this file can be located on the Polygon.io docs.
+// API documentation: https://polygon.io/docs.
+// The Polygon.io API requires an API key, which is sent as a bearer token.
+
+const (
+	// Name is the name of the Polygon.io provider.
+	Name = "polygon_api"
+
+	// URL is the base URL of the Polygon.io API.
+	URL = "https://api.polygon.io"
+
+	// LastTradeEndpoint is the endpoint of the last trade of a stock or ETF, keyed by its symbol.
+	LastTradeEndpoint = "/v2/last/trade/%s"
+
+	// LastQuoteEndpoint is the endpoint of the last quote of a currency pair, keyed by its base and
+	// quote currencies. This is used for precious metals, e.g. XAU/USD.
+	LastQuoteEndpoint = "/v1/last_quote/currencies/%s/%s"
+
+	// TickerSeparator is the separator of the base and quote of a metals ticker, e.g. XAU/USD.
+	TickerSeparator = "/"
+)
+
+const (
+	// MarketStocks is the market of US stocks and ETFs. This is the default market.
+	MarketStocks = "stocks"
+	// MarketMetals is the market of precious metals, quoted as currency pairs, e.g. XAU/USD.
+	MarketMetals = "metals"
+)
+
+// DefaultAPIConfig is the default configuration for the Polygon.io API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           false,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         5 * time.Second,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+// MetaData is the optional per-ticker metadata of the Polygon.io provider.
+type MetaData struct {
+	// Market is the market of the ticker, i.e. stocks or metals. The off-chain ticker is the
+	// symbol of stocks, e.g. AAPL, and BASE/QUOTE for metals, e.g. XAU/USD. If unset, the ticker is
+	// a stock.
+	Market string `json:"market,omitempty"`
+	// MaxAge is the maximum age of the last trade or quote while the market is open. Older prices,
+	// e.g. of thinly traded stocks or if the market is closed for a holiday, are not reported. If
+	// unset, the age of prices is not checked.
+	MaxAge time.Duration `json:"maxAge,omitempty"`
+}
+
+// ValidateBasic validates the metadata.
+func (m *MetaData) ValidateBasic() error {
+	switch m.Market {
+	case "", MarketStocks, MarketMetals:
+	default:
+		return fmt.Errorf("invalid market %s; expected %s or %s", m.Market, MarketStocks, MarketMetals)
+	}
+
+	if m.MaxAge < 0 {
+		return fmt.Errorf("max age cannot be negative")
+	}
+
+	return nil
+}
+
+// MustToJSON converts the metadata to JSON.
+func (m MetaData) MustToJSON() string {
+	b, err := json.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+type (
+	// LastTradeResponse is the expected response of the last trade endpoint.
+	// Response format:
+	//
+	//	{
+	//	  "request_id": "f05562305bd26ced64b98ed68b3c5d96",
+	//	  "results": {
+	//	    "T": "AAPL",
+	//	    "p": 129.8473,
+	//	    "s": 25,
+	//	    "t": 1617901342969834000
+	//	  },
+	//	  "status": "OK"
+	//	}
+	LastTradeResponse struct {
+		Status  string    `json:"status"`
+		Results LastTrade `json:"results"`
+	}
+
+	// LastTrade is the last trade of a stock. The timestamp is the SIP timestamp of the trade, in
+	// nanoseconds since the Unix epoch. The symbol must be decoded explicitly, otherwise it would be
+	// decoded into the timestamp, as JSON keys are matched case-insensitively.
+	LastTrade struct {
+		Symbol    string  `json:"T"`
+		Price     float64 `json:"p"`
+		Timestamp int64   `json:"t"`
+	}
+
+	// LastQuoteResponse is the expected response of the last quote endpoint.
+	// Response format:
+	//
+	//	{
+	//	  "last": {
+	//	    "ask": 2045.12,
+	//	    "bid": 2044.88,
+	//	    "exchange": 48,
+	//	    "timestamp": 1704488400000
+	//	  },
+	//	  "request_id": "a73a29dbcab4613eeaf48583d3baacf0",
+	//	  "status": "success",
+	//	  "symbol": "XAU/USD"
+	//	}
+	LastQuoteResponse struct {
+		Status string    `json:"status"`
+		Last   LastQuote `json:"last"`
+	}
+
+	// LastQuote is the last quote of a currency pair. The timestamp is in milliseconds since the
+	// Unix epoch.
+	LastQuote struct {
+		Ask       float64 `json:"ask"`
+		Bid       float64 `json:"bid"`
+		Timestamp int64   `json:"timestamp"`
+	}
+)
@@ -0,0 +1,202 @@
+package pyth
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
+
+// APIHandler implements the PriceAPIDataHandler interface for Pyth, which can be used
+// by a base provider. The handler fetches data from the latest price updates endpoint
+// of the Hermes API. The off-chain ticker of each market is expected to be the Pyth
+// feed ID.
+type APIHandler struct {
+	api config.APIConfig
+}
+
+// NewAPIHandler returns a new Pyth PriceAPIDataHandler.
+func NewAPIHandler(
+	api config.APIConfig,
+) (types.PriceAPIDataHandler, error) {
+	if api.Name != Name {
+		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", Name)
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config for %s: %w", Name, err)
+	}
+
+	return &APIHandler{
+		api: api,
+	}, nil
+}
+
+// CreateURL returns the URL that is used to fetch data from the Hermes API for the
+// given tickers. The Hermes API supports fetching multiple feeds in a single request.
+func (h *APIHandler) CreateURL(
+	tickers []types.ProviderTicker,
+) (string, error) {
+	if len(tickers) == 0 {
+		return "", fmt.Errorf("no tickers specified")
+	}
+
+	var url strings.Builder
+	url.WriteString(h.api.Endpoints[0].URL)
+	url.WriteString(LatestPriceEndpoint)
+	for _, ticker := range tickers {
+		url.WriteString(fmt.Sprintf(FeedIDQueryParam, NormalizeFeedID(ticker.GetOffChainTicker())))
+	}
+
+	return url.String(), nil
+}
+
+// ParseResponse parses the response from the Hermes API. The timestamp of each resolved
+// price is the publish time reported by Pyth, such that stale feeds can be rejected by
+// the oracle's max price age. Prices whose confidence interval is wider than the ticker's
+// max confidence ratio are left unresolved. Each resolved price is weighted by the inverse of
+// its relative confidence interval.
+func (h *APIHandler) ParseResponse(
+	tickers []types.ProviderTicker,
+	resp *http.Response,
+) types.PriceResponse {
+	var result LatestPriceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+		)
+	}
+
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unresolved = make(types.UnResolvedPrices)
+		feeds      = make(map[string]types.ProviderTicker, len(tickers))
+	)
+
+	for _, ticker := range tickers {
+		feeds[NormalizeFeedID(ticker.GetOffChainTicker())] = ticker
+	}
+
+	for _, update := range result.Parsed {
+		ticker, ok := feeds[NormalizeFeedID(update.ID)]
+		if !ok {
+			continue
+		}
+
+		price, err := ParsePrice(ticker, update.Price)
+		if err != nil {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice),
+			}
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResultWithWeight(
+			price,
+			time.Unix(update.Price.PublishTime, 0).UTC(),
+			ConfidenceWeight(update.Price),
+		)
+	}
+
+	// Add all expected tickers that did not return a response to the unresolved
+	// map.
+	for _, ticker := range tickers {
+		_, resolvedOk := resolved[ticker]
+		_, unresolvedOk := unresolved[ticker]
+		if !resolvedOk && !unresolvedOk {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+			}
+		}
+	}
+
+	return types.NewPriceResponse(resolved, unresolved)
+}
+
+// ParsePrice converts a Pyth price into a big.Float, scaling it by its exponent. An error is
+// returned if the price is not positive or if its confidence interval exceeds the max
+// confidence ratio configured in the ticker's metadata.
+func ParsePrice(
+	ticker types.ProviderTicker,
+	p Price,
+) (*big.Float, error) {
+	var cfg FeedConfig
+	if metadata := ticker.GetJSON(); len(metadata) > 0 {
+		if err := json.Unmarshal([]byte(metadata), &cfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal feed config on ticker: %w", err)
+		}
+	}
+
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid feed config: %w", err)
+	}
+
+	price, ok := new(big.Int).SetString(p.Price, 10)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse price %s", p.Price)
+	}
+
+	if price.Sign() <= 0 {
+		return nil, fmt.Errorf("price must be positive: %s", p.Price)
+	}
+
+	conf, ok := new(big.Int).SetString(p.Conf, 10)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse confidence %s", p.Conf)
+	}
+
+	if cfg.MaxConfidenceRatio > 0 {
+		// The price and confidence share the same exponent, so the ratio can be computed
+		// on the unscaled values.
+		ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(conf), new(big.Float).SetInt(price)).Float64()
+		if ratio > cfg.MaxConfidenceRatio {
+			return nil, fmt.Errorf(
+				"confidence ratio %f exceeds max confidence ratio %f",
+				ratio,
+				cfg.MaxConfidenceRatio,
+			)
+		}
+	}
+
+	scaled, err := math.NewPrice(price, p.Expo)
+	if err != nil {
+		return nil, err
+	}
+
+	return scaled.BigFloat(), nil
+}
+
+// ConfidenceWeight returns the weight of a Pyth price, which is the ratio of the price to its
+// confidence interval. Prices with tighter confidence intervals are weighted more heavily. 0 is
+// returned if the price or confidence interval cannot be parsed or if the confidence interval
+// is zero, in which case the price is not weighted.
+func ConfidenceWeight(p Price) float64 {
+	price, ok := new(big.Int).SetString(p.Price, 10)
+	if !ok {
+		return 0
+	}
+
+	conf, ok := new(big.Int).SetString(p.Conf, 10)
+	if !ok || conf.Sign() <= 0 {
+		return 0
+	}
+
+	// The price and confidence share the same exponent, so the weight is computed on the
+	// unscaled values.
+	weight, _ := new(big.Float).Quo(new(big.Float).SetInt(price), new(big.Float).SetInt(conf)).Float64()
+	return weight
+}
@@ -0,0 +1,352 @@
+package pyth_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/pyth"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+const (
+	btcFeedID = "e62df6c8b4a85fe1a67db44dc12de5db330f7ac66b72dc658afedf0f4a415b43"
+	ethFeedID = "ff61491a931112ddf1bd8147cd1b641375f79f5825126d665480874634fd0ace"
+)
+
+var (
+	btcusd = types.DefaultProviderTicker{
+		OffChainTicker: "0x" + btcFeedID,
+	}
+	ethusd = types.DefaultProviderTicker{
+		OffChainTicker: ethFeedID,
+		JSON:           pyth.FeedConfig{MaxConfidenceRatio: 0.01}.MustToJSON(),
+	}
+)
+
+func TestNewAPIHandler(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		_, err := pyth.NewAPIHandler(pyth.DefaultAPIConfig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		cfg := pyth.DefaultAPIConfig
+		cfg.Name = "invalid"
+		_, err := pyth.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("disabled api", func(t *testing.T) {
+		cfg := pyth.DefaultAPIConfig
+		cfg.Enabled = false
+		_, err := pyth.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cps         []types.ProviderTicker
+		url         string
+		expectedErr bool
+	}{
+		{
+			name: "single valid feed",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			url:         fmt.Sprintf("https://hermes.pyth.network/v2/updates/price/latest?parsed=true&encoding=hex&ids[]=%s", btcFeedID),
+			expectedErr: false,
+		},
+		{
+			name: "multiple valid feeds",
+			cps: []types.ProviderTicker{
+				btcusd,
+				ethusd,
+			},
+			url:         fmt.Sprintf("https://hermes.pyth.network/v2/updates/price/latest?parsed=true&encoding=hex&ids[]=%s&ids[]=%s", btcFeedID, ethFeedID),
+			expectedErr: false,
+		},
+		{
+			name:        "no feeds",
+			cps:         []types.ProviderTicker{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := pyth.NewAPIHandler(pyth.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			url, err := h.CreateURL(tc.cps)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.url, url)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	publishTime := time.Unix(1713295012, 0).UTC()
+
+	testCases := []struct {
+		name     string
+		cps      []types.ProviderTicker
+		response *http.Response
+		expected types.PriceResponse
+	}{
+		{
+			name: "single valid feed",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				fmt.Sprintf(`
+{
+	"parsed": [
+		{
+			"id": "%s",
+			"price": {
+				"price": "6103366879713",
+				"conf": "3039980600",
+				"expo": -8,
+				"publish_time": 1713295012
+			}
+		}
+	]
+}
+	`, btcFeedID),
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusd: {
+						Value:     big.NewFloat(61033.66879713),
+						Timestamp: publishTime,
+						Weight:    6103366879713.0 / 3039980600.0,
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "multiple feeds with one missing",
+			cps: []types.ProviderTicker{
+				btcusd,
+				ethusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				fmt.Sprintf(`
+{
+	"parsed": [
+		{
+			"id": "%s",
+			"price": {
+				"price": "6103366879713",
+				"conf": "3039980600",
+				"expo": -8,
+				"publish_time": 1713295012
+			}
+		}
+	]
+}
+	`, btcFeedID),
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusd: {
+						Value:     big.NewFloat(61033.66879713),
+						Timestamp: publishTime,
+						Weight:    6103366879713.0 / 3039980600.0,
+					},
+				},
+				types.UnResolvedPrices{
+					ethusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+				},
+			),
+		},
+		{
+			name: "confidence interval exceeds the max confidence ratio",
+			cps: []types.ProviderTicker{
+				ethusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				fmt.Sprintf(`
+{
+	"parsed": [
+		{
+			"id": "%s",
+			"price": {
+				"price": "300000000000",
+				"conf": "6000000000",
+				"expo": -8,
+				"publish_time": 1713295012
+			}
+		}
+	]
+}
+	`, ethFeedID),
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					ethusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("confidence"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "confidence interval within the max confidence ratio",
+			cps: []types.ProviderTicker{
+				ethusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				fmt.Sprintf(`
+{
+	"parsed": [
+		{
+			"id": "%s",
+			"price": {
+				"price": "300000000000",
+				"conf": "100000000",
+				"expo": -8,
+				"publish_time": 1713295012
+			}
+		}
+	]
+}
+	`, ethFeedID),
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					ethusd: {
+						Value:     big.NewFloat(3000),
+						Timestamp: publishTime,
+						Weight:    3000,
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "non-positive price",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				fmt.Sprintf(`
+{
+	"parsed": [
+		{
+			"id": "%s",
+			"price": {
+				"price": "0",
+				"conf": "0",
+				"expo": -8,
+				"publish_time": 1713295012
+			}
+		}
+	]
+}
+	`, btcFeedID),
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("price must be positive"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "exponent beyond the max exponent",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				fmt.Sprintf(`
+{
+	"parsed": [
+		{
+			"id": "%s",
+			"price": {
+				"price": "6103366879713",
+				"conf": "3039980600",
+				"expo": -2147483648,
+				"publish_time": 1713295012
+			}
+		}
+	]
+}
+	`, btcFeedID),
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("exponent"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "bad response",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: testutils.CreateResponseFromJSON(
+				`
+shout out my label that's me
+	`,
+			),
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("json error"), providertypes.ErrorFailedToDecode),
+					},
+				},
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := pyth.NewAPIHandler(pyth.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			resp := h.ParseResponse(tc.cps, tc.response)
+
+			require.Len(t, resp.Resolved, len(tc.expected.Resolved))
+			require.Len(t, resp.UnResolved, len(tc.expected.UnResolved))
+
+			for cp, result := range tc.expected.Resolved {
+				require.Contains(t, resp.Resolved, cp)
+				r := resp.Resolved[cp]
+				require.Equal(t, result.Value.SetPrec(18), r.Value.SetPrec(18))
+				require.Equal(t, result.Timestamp, r.Timestamp)
+				require.InDelta(t, result.Weight, r.Weight, 1e-9)
+			}
+
+			for cp := range tc.expected.UnResolved {
+				require.Contains(t, resp.UnResolved, cp)
+				require.Error(t, resp.UnResolved[cp])
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package pyth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// NOTE: All documentation for this file can be located on the Pyth Hermes docs.
+// API documentation: https://hermes.pyth.network/docs. The public Hermes
+// endpoint does not require an API key but is rate limited.
+
+const (
+	// Name is the name of the Pyth provider.
+	Name = "pyth_api"
+
+	// URL is the base URL of the Pyth Hermes API.
+	URL = "https://hermes.pyth.network"
+
+	// LatestPriceEndpoint is the endpoint used to fetch the latest price updates for a set
+	// of feeds. Each feed is appended as an `ids[]` query parameter.
+	LatestPriceEndpoint = "/v2/updates/price/latest?parsed=true&encoding=hex"
+
+	// FeedIDQueryParam is the query parameter used to request a single feed.
+	FeedIDQueryParam = "&ids[]=%s"
+)
+
+// DefaultAPIConfig is the default configuration for the Pyth Hermes API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           true,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         1000 * time.Millisecond, // Hermes allows 30 requests every 10 seconds.
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+// FeedConfig is the optional metadata that can be set on each ticker. The off-chain ticker
+// of each market is the Pyth feed ID.
+type FeedConfig struct {
+	// MaxConfidenceRatio is the maximum ratio of the confidence interval to the price that is
+	// accepted, e.g. 0.01 rejects prices whose confidence interval exceeds 1% of the price. If
+	// unset, the confidence interval is not checked.
+	MaxConfidenceRatio float64 `json:"max_confidence_ratio"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	if fc.MaxConfidenceRatio < 0 {
+		return fmt.Errorf("max confidence ratio must be non-negative")
+	}
+
+	return nil
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+type (
+	// LatestPriceResponse is the response returned by the latest price updates endpoint of
+	// the Hermes API. The response format looks like the following:
+	//
+	//	{
+	//		"parsed": [
+	//			{
+	//				"id": "e62df6c8b4a85fe1a67db44dc12de5db330f7ac66b72dc658afedf0f4a415b43",
+	//				"price": {
+	//					"price": "6103366879713",
+	//					"conf": "3039980600",
+	//					"expo": -8,
+	//					"publish_time": 1713295012
+	//				}
+	//			}
+	//		]
+	//	}
+	LatestPriceResponse struct {
+		Parsed []PriceUpdate `json:"parsed"`
+	}
+
+	// PriceUpdate is the latest price update of a single feed.
+	PriceUpdate struct {
+		ID    string `json:"id"`
+		Price Price  `json:"price"`
+	}
+
+	// Price is a Pyth price with its confidence interval. The price and confidence are
+	// fixed-point numbers with the given exponent.
+	Price struct {
+		Price       string `json:"price"`
+		Conf        string `json:"conf"`
+		Expo        int64  `json:"expo"`
+		PublishTime int64  `json:"publish_time"`
+	}
+)
+
+// NormalizeFeedID returns the feed ID in the format returned by the Hermes API, i.e. lower case
+// hex without a 0x prefix.
+func NormalizeFeedID(id string) string {
+	return strings.TrimPrefix(strings.ToLower(id), "0x")
+}
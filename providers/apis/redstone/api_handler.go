@@ -0,0 +1,193 @@
+package redstone
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/pkg/math"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var _ types.PriceAPIDataHandler = (*APIHandler)(nil)
+
+// APIHandler implements the PriceAPIDataHandler interface for RedStone, which can be used
+// by a base provider. The handler fetches the latest data packages of a data service from a
+// RedStone data gateway. The off-chain ticker of each market is expected to be the RedStone
+// data feed ID.
+type APIHandler struct {
+	api config.APIConfig
+}
+
+// NewAPIHandler returns a new RedStone PriceAPIDataHandler.
+func NewAPIHandler(
+	api config.APIConfig,
+) (types.PriceAPIDataHandler, error) {
+	if api.Name != Name {
+		return nil, fmt.Errorf("expected api config name %s, got %s", Name, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", Name)
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config for %s: %w", Name, err)
+	}
+
+	return &APIHandler{
+		api: api,
+	}, nil
+}
+
+// CreateURL returns the URL that is used to fetch data from the RedStone data gateway. The
+// gateway returns the latest data packages of every feed of the data service, so the URL does
+// not depend on the tickers.
+func (h *APIHandler) CreateURL(
+	tickers []types.ProviderTicker,
+) (string, error) {
+	if len(tickers) == 0 {
+		return "", fmt.Errorf("no tickers specified")
+	}
+
+	return h.api.Endpoints[0].URL, nil
+}
+
+// ParseResponse parses the response from the RedStone data gateway. Only data packages whose
+// signature recovers to an address in the ticker's signer set are accepted, and a price is only
+// resolved if enough distinct signers have signed a value for it. The resolved price is the
+// median of the signed values and its timestamp is that of the oldest accepted package.
+func (h *APIHandler) ParseResponse(
+	tickers []types.ProviderTicker,
+	resp *http.Response,
+) types.PriceResponse {
+	var result LatestDataPackagesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return types.NewPriceResponseWithErr(
+			tickers,
+			providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+		)
+	}
+
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unresolved = make(types.UnResolvedPrices)
+	)
+
+	for _, ticker := range tickers {
+		packages, ok := result[ticker.GetOffChainTicker()]
+		if !ok || len(packages) == 0 {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+			}
+			continue
+		}
+
+		price, timestamp, err := ParsePrice(ticker, packages)
+		if err != nil {
+			unresolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice),
+			}
+			continue
+		}
+
+		resolved[ticker] = types.NewPriceResult(price, timestamp)
+	}
+
+	return types.NewPriceResponse(resolved, unresolved)
+}
+
+// ParsePrice verifies the data packages signed for a ticker against its signer set and returns
+// the median of the signed values along with the timestamp of the oldest accepted package. If
+// a signer signed several packages, only its latest one is used. An error is returned if fewer
+// than the required number of signers from the signer set signed a value.
+func ParsePrice(
+	ticker types.ProviderTicker,
+	packages []DataPackage,
+) (*big.Float, time.Time, error) {
+	var cfg FeedConfig
+	if metadata := ticker.GetJSON(); len(metadata) > 0 {
+		if err := json.Unmarshal([]byte(metadata), &cfg); err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to unmarshal feed config on ticker: %w", err)
+		}
+	}
+
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid feed config: %w", err)
+	}
+
+	signers, minSigners := cfg.SignerSet()
+	accepted := make(map[common.Address]DataPackage)
+	values := make(map[common.Address]*big.Int)
+	for _, pkg := range packages {
+		value, err := packageValue(pkg, ticker.GetOffChainTicker())
+		if err != nil {
+			continue
+		}
+
+		signer, err := pkg.RecoverSigner()
+		if err != nil {
+			continue
+		}
+
+		if _, ok := signers[signer]; !ok {
+			continue
+		}
+
+		if prev, ok := accepted[signer]; ok && prev.TimestampMilliseconds >= pkg.TimestampMilliseconds {
+			continue
+		}
+
+		accepted[signer] = pkg
+		values[signer] = value
+	}
+
+	if len(accepted) < minSigners {
+		return nil, time.Time{}, fmt.Errorf(
+			"expected values from at least %d signers, got %d valid packages from %d packages",
+			minSigners,
+			len(accepted),
+			len(packages),
+		)
+	}
+
+	var (
+		prices    = make([]*big.Float, 0, len(values))
+		timestamp time.Time
+	)
+	for signer, value := range values {
+		price, err := math.NewPrice(value, -ValueDecimals)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		prices = append(prices, price.BigFloat())
+
+		if ts := accepted[signer].Timestamp(); timestamp.IsZero() || ts.Before(timestamp) {
+			timestamp = ts
+		}
+	}
+
+	price := math.CalculateMedian(prices)
+	if price.Sign() <= 0 {
+		return nil, time.Time{}, fmt.Errorf("price must be positive: %s", price.String())
+	}
+
+	return price, timestamp, nil
+}
+
+// packageValue returns the scaled value of the given data feed in the data package.
+func packageValue(pkg DataPackage, feedID string) (*big.Int, error) {
+	for _, dp := range pkg.DataPoints {
+		if dp.DataFeedID == feedID {
+			return dp.ScaledValue()
+		}
+	}
+
+	return nil, fmt.Errorf("data package does not contain %s", feedID)
+}
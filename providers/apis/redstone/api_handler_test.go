@@ -0,0 +1,372 @@
+package redstone_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/redstone"
+	"github.com/skip-mev/connect/v2/providers/base/testutils"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var (
+	signerKeys = []*ecdsa.PrivateKey{
+		mustKey("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"),
+		mustKey("8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"),
+		mustKey("0a5aa0c2b4a2c6a5b3e2ea0c9a3e5e0e8b7b8e4e0d1b2f0c9a7b8c6d5e4f3a2b"),
+	}
+	otherKey = mustKey("1f2e3d4c5b6a79881f2e3d4c5b6a79881f2e3d4c5b6a79881f2e3d4c5b6a7988")
+
+	signers = []string{
+		crypto.PubkeyToAddress(signerKeys[0].PublicKey).Hex(),
+		crypto.PubkeyToAddress(signerKeys[1].PublicKey).Hex(),
+		crypto.PubkeyToAddress(signerKeys[2].PublicKey).Hex(),
+	}
+
+	btcusd = types.DefaultProviderTicker{
+		OffChainTicker: "BTC",
+		JSON:           redstone.FeedConfig{Signers: signers, MinSigners: 2}.MustToJSON(),
+	}
+	ethusd = types.DefaultProviderTicker{
+		OffChainTicker: "ETH",
+		JSON:           redstone.FeedConfig{Signers: signers}.MustToJSON(),
+	}
+
+	timestampMs int64 = 1713295010000
+)
+
+func mustKey(hex string) *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA(hex)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// signedPackage returns a data package with the given value signed by the given key.
+func signedPackage(t *testing.T, key *ecdsa.PrivateKey, feedID, value string, ts int64) redstone.DataPackage {
+	t.Helper()
+
+	pkg := redstone.DataPackage{
+		TimestampMilliseconds: ts,
+		DataPoints: []redstone.DataPoint{
+			{DataFeedID: feedID, Value: json.Number(value)},
+		},
+		DataServiceID: "redstone-primary-prod",
+		DataFeedID:    feedID,
+		SignerAddress: crypto.PubkeyToAddress(key.PublicKey).Hex(),
+	}
+
+	hash, err := pkg.SignableHash()
+	require.NoError(t, err)
+
+	sig, err := crypto.Sign(hash, key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+
+	pkg.Signature = base64.StdEncoding.EncodeToString(sig)
+	return pkg
+}
+
+func createResponse(t *testing.T, resp redstone.LatestDataPackagesResponse) *http.Response {
+	t.Helper()
+
+	bz, err := json.Marshal(resp)
+	require.NoError(t, err)
+	return testutils.CreateResponseFromJSON(string(bz))
+}
+
+func TestNewAPIHandler(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		_, err := redstone.NewAPIHandler(redstone.DefaultAPIConfig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		cfg := redstone.DefaultAPIConfig
+		cfg.Name = "invalid"
+		_, err := redstone.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("disabled api", func(t *testing.T) {
+		cfg := redstone.DefaultAPIConfig
+		cfg.Enabled = false
+		_, err := redstone.NewAPIHandler(cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateURL(t *testing.T) {
+	h, err := redstone.NewAPIHandler(redstone.DefaultAPIConfig)
+	require.NoError(t, err)
+
+	url, err := h.CreateURL([]types.ProviderTicker{btcusd, ethusd})
+	require.NoError(t, err)
+	require.Equal(t, redstone.URL, url)
+
+	_, err = h.CreateURL([]types.ProviderTicker{})
+	require.Error(t, err)
+}
+
+func TestFeedConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         redstone.FeedConfig
+		expectedErr bool
+	}{
+		{
+			name: "default signers",
+			cfg:  redstone.FeedConfig{},
+		},
+		{
+			name: "custom signers",
+			cfg:  redstone.FeedConfig{Signers: signers, MinSigners: 3},
+		},
+		{
+			name:        "invalid signer",
+			cfg:         redstone.FeedConfig{Signers: []string{"0x1234"}},
+			expectedErr: true,
+		},
+		{
+			name:        "negative min signers",
+			cfg:         redstone.FeedConfig{MinSigners: -1},
+			expectedErr: true,
+		},
+		{
+			name:        "min signers exceeds signers",
+			cfg:         redstone.FeedConfig{Signers: signers, MinSigners: 4},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.ValidateBasic()
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("signer set defaults", func(t *testing.T) {
+		cfg := redstone.FeedConfig{}
+		set, minSigners := cfg.SignerSet()
+		require.Len(t, set, len(redstone.DefaultSigners))
+		require.Equal(t, redstone.DefaultMinSigners, minSigners)
+
+		cfg = redstone.FeedConfig{Signers: signers[:2]}
+		set, minSigners = cfg.SignerSet()
+		require.Len(t, set, 2)
+		require.Equal(t, 2, minSigners)
+	})
+}
+
+func TestRecoverSigner(t *testing.T) {
+	pkg := signedPackage(t, signerKeys[0], "BTC", "61033.66879713", timestampMs)
+
+	signer, err := pkg.RecoverSigner()
+	require.NoError(t, err)
+	require.Equal(t, signers[0], signer.Hex())
+
+	// Tampering with the value changes the recovered signer.
+	pkg.DataPoints[0].Value = "71033.66879713"
+	signer, err = pkg.RecoverSigner()
+	require.NoError(t, err)
+	require.NotEqual(t, signers[0], signer.Hex())
+
+	pkg.Signature = "invalid"
+	_, err = pkg.RecoverSigner()
+	require.Error(t, err)
+}
+
+func TestParseResponse(t *testing.T) {
+	timestamp := time.UnixMilli(timestampMs).UTC()
+
+	testCases := []struct {
+		name     string
+		cps      []types.ProviderTicker
+		response func(t *testing.T) *http.Response
+		expected types.PriceResponse
+	}{
+		{
+			name: "median of signed values",
+			cps: []types.ProviderTicker{
+				ethusd,
+			},
+			response: func(t *testing.T) *http.Response {
+				return createResponse(t, redstone.LatestDataPackagesResponse{
+					"ETH": {
+						signedPackage(t, signerKeys[0], "ETH", "3000.5", timestampMs),
+						signedPackage(t, signerKeys[1], "ETH", "3001", timestampMs+1000),
+						signedPackage(t, signerKeys[2], "ETH", "2999", timestampMs+2000),
+					},
+				})
+			},
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					ethusd: {
+						Value:     big.NewFloat(3000.5),
+						Timestamp: timestamp,
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "packages from unknown signers are ignored",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: func(t *testing.T) *http.Response {
+				return createResponse(t, redstone.LatestDataPackagesResponse{
+					"BTC": {
+						signedPackage(t, signerKeys[0], "BTC", "61000", timestampMs),
+						signedPackage(t, signerKeys[1], "BTC", "61002", timestampMs),
+						signedPackage(t, otherKey, "BTC", "1", timestampMs),
+						signedPackage(t, otherKey, "BTC", "1", timestampMs),
+					},
+				})
+			},
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusd: {
+						Value:     big.NewFloat(61001),
+						Timestamp: timestamp,
+					},
+				},
+				types.UnResolvedPrices{},
+			),
+		},
+		{
+			name: "tampered packages are rejected",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: func(t *testing.T) *http.Response {
+				tampered := signedPackage(t, signerKeys[1], "BTC", "61002", timestampMs)
+				tampered.DataPoints[0].Value = "1"
+
+				return createResponse(t, redstone.LatestDataPackagesResponse{
+					"BTC": {
+						signedPackage(t, signerKeys[0], "BTC", "61000", timestampMs),
+						tampered,
+					},
+				})
+			},
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("not enough signers"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "a signer is only counted once",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: func(t *testing.T) *http.Response {
+				return createResponse(t, redstone.LatestDataPackagesResponse{
+					"BTC": {
+						signedPackage(t, signerKeys[0], "BTC", "61000", timestampMs),
+						signedPackage(t, signerKeys[0], "BTC", "61002", timestampMs+1000),
+					},
+				})
+			},
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("not enough signers"), providertypes.ErrorFailedToParsePrice),
+					},
+				},
+			),
+		},
+		{
+			name: "missing feed",
+			cps: []types.ProviderTicker{
+				btcusd,
+				ethusd,
+			},
+			response: func(t *testing.T) *http.Response {
+				return createResponse(t, redstone.LatestDataPackagesResponse{
+					"BTC": {
+						signedPackage(t, signerKeys[0], "BTC", "61000", timestampMs),
+						signedPackage(t, signerKeys[1], "BTC", "61002", timestampMs),
+					},
+				})
+			},
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{
+					btcusd: {
+						Value:     big.NewFloat(61001),
+						Timestamp: timestamp,
+					},
+				},
+				types.UnResolvedPrices{
+					ethusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("no response"), providertypes.ErrorNoResponse),
+					},
+				},
+			),
+		},
+		{
+			name: "bad response",
+			cps: []types.ProviderTicker{
+				btcusd,
+			},
+			response: func(*testing.T) *http.Response {
+				return testutils.CreateResponseFromJSON(`
+shout out my label that's me
+	`)
+			},
+			expected: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{
+					btcusd: providertypes.UnresolvedResult{
+						ErrorWithCode: providertypes.NewErrorWithCode(fmt.Errorf("json error"), providertypes.ErrorFailedToDecode),
+					},
+				},
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := redstone.NewAPIHandler(redstone.DefaultAPIConfig)
+			require.NoError(t, err)
+
+			resp := h.ParseResponse(tc.cps, tc.response(t))
+
+			require.Len(t, resp.Resolved, len(tc.expected.Resolved))
+			require.Len(t, resp.UnResolved, len(tc.expected.UnResolved))
+
+			for cp, result := range tc.expected.Resolved {
+				require.Contains(t, resp.Resolved, cp)
+				r := resp.Resolved[cp]
+				require.Equal(t, result.Value.SetPrec(18), r.Value.SetPrec(18))
+				require.Equal(t, result.Timestamp, r.Timestamp)
+			}
+
+			for cp := range tc.expected.UnResolved {
+				require.Contains(t, resp.UnResolved, cp)
+				require.Error(t, resp.UnResolved[cp])
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package redstone
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignableHash returns the keccak256 hash of the serialized data package, which is the digest
+// signed by the node of the data service. The package is serialized as its data points sorted
+// by data feed ID, each as the feed ID right-padded to 32 bytes followed by the value scaled to
+// ValueDecimals as a 32 byte unsigned integer, followed by the timestamp in milliseconds (6
+// bytes), the value byte size (4 bytes) and the number of data points (3 bytes).
+func (p DataPackage) SignableHash() ([]byte, error) {
+	dataPoints := make([]DataPoint, len(p.DataPoints))
+	copy(dataPoints, p.DataPoints)
+	sort.Slice(dataPoints, func(i, j int) bool {
+		return dataPoints[i].DataFeedID < dataPoints[j].DataFeedID
+	})
+
+	var bz []byte
+	for _, dp := range dataPoints {
+		if len(dp.DataFeedID) > 32 {
+			return nil, fmt.Errorf("data feed id %s exceeds 32 bytes", dp.DataFeedID)
+		}
+
+		value, err := dp.ScaledValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if value.BitLen() > ValueByteSize*8 {
+			return nil, fmt.Errorf("value of %s exceeds %d bytes", dp.DataFeedID, ValueByteSize)
+		}
+
+		bz = append(bz, common.RightPadBytes([]byte(dp.DataFeedID), 32)...)
+		bz = append(bz, common.LeftPadBytes(value.Bytes(), ValueByteSize)...)
+	}
+
+	bz = append(bz, uintBytes(uint64(p.TimestampMilliseconds), 6)...)
+	bz = append(bz, uintBytes(ValueByteSize, 4)...)
+	bz = append(bz, uintBytes(uint64(len(dataPoints)), 3)...)
+
+	return crypto.Keccak256(bz), nil
+}
+
+// RecoverSigner returns the address that signed the data package. The address is recovered
+// from the signature rather than read from the package, which is not signed.
+func (p DataPackage) RecoverSigner() (common.Address, error) {
+	sig, err := base64.StdEncoding.DecodeString(p.Signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("expected signature of %d bytes, got %d", crypto.SignatureLength, len(sig))
+	}
+
+	// The recovery id is encoded as 27 or 28 by the data service.
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	hash, err := p.SignableHash()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// Timestamp returns the time at which the data package was signed.
+func (p DataPackage) Timestamp() time.Time {
+	return time.UnixMilli(p.TimestampMilliseconds).UTC()
+}
+
+// ScaledValue returns the value of the data point scaled to ValueDecimals and rounded to the
+// nearest integer, which is how it is serialized before being signed.
+func (dp DataPoint) ScaledValue() (*big.Int, error) {
+	value, ok := new(big.Rat).SetString(dp.Value.String())
+	if !ok {
+		return nil, fmt.Errorf("failed to parse value %s of %s", dp.Value, dp.DataFeedID)
+	}
+
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("value of %s must be non-negative: %s", dp.DataFeedID, dp.Value)
+	}
+
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(ValueDecimals), nil)))
+
+	quo, rem := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(value.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+
+	return quo, nil
+}
+
+// uintBytes returns v as a big-endian unsigned integer of the given number of bytes.
+func uintBytes(v uint64, size int) []byte {
+	return common.LeftPadBytes(new(big.Int).SetUint64(v).Bytes(), size)
+}
@@ -0,0 +1,161 @@
+package redstone
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+// NOTE: All documentation for this file can be located on the RedStone docs.
+// API documentation: https://docs.redstone.finance/docs/get-started/data-formatting-processing.
+// The public data gateways do not require an API key.
+
+const (
+	// Name is the name of the RedStone provider.
+	Name = "redstone_api"
+
+	// URL is the URL of the latest data packages of the RedStone primary data service. Other
+	// data services can be queried by overriding the endpoint URL.
+	URL = "https://oracle-gateway-1.a.redstone.finance/data-packages/latest/redstone-primary-prod"
+
+	// DefaultMinSigners is the default number of distinct signers from the signer set that must
+	// have signed a value before it is accepted.
+	DefaultMinSigners = 3
+
+	// ValueDecimals is the number of decimals with which data point values are serialized
+	// before they are signed.
+	ValueDecimals = 8
+
+	// ValueByteSize is the number of bytes with which data point values are serialized before
+	// they are signed.
+	ValueByteSize = 32
+)
+
+// DefaultSigners are the signers authorized for the RedStone primary data service. Packages
+// signed by any other address are rejected unless a ticker configures its own signer set.
+var DefaultSigners = []string{
+	"0x8BB8F32Df04c8b654987DAaeD53D6B6091e3B774",
+	"0xdEB22f54738d54976C4c0fe5ce6d408E40d88499",
+	"0x51Ce04Be4b3E32572C4Ec9135221d0691Ba7d202",
+	"0xDD682daEC5A90dD295d14DA4b0bec9281017b5bE",
+	"0x9c5AE89C4Af6aA32cE58588DBaF90d18a855B6de",
+}
+
+// DefaultAPIConfig is the default configuration for the RedStone API.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           true,
+	Enabled:          true,
+	Timeout:          3000 * time.Millisecond,
+	Interval:         1000 * time.Millisecond,
+	ReconnectTimeout: 2000 * time.Millisecond,
+	MaxQueries:       1,
+	Endpoints:        []config.Endpoint{{URL: URL}},
+}
+
+// FeedConfig is the optional metadata that can be set on each ticker. The off-chain ticker
+// of each market is the RedStone data feed ID.
+type FeedConfig struct {
+	// Signers is the set of addresses whose signed data packages are accepted. If unset, the
+	// default signers of the RedStone primary data service are used.
+	Signers []string `json:"signers,omitempty"`
+
+	// MinSigners is the number of distinct signers from the signer set that must have signed
+	// a value for the price to be resolved. If unset, DefaultMinSigners is used, capped at the
+	// size of the signer set.
+	MinSigners int `json:"min_signers,omitempty"`
+}
+
+// ValidateBasic validates the feed configuration.
+func (fc *FeedConfig) ValidateBasic() error {
+	for _, signer := range fc.Signers {
+		if !common.IsHexAddress(signer) {
+			return fmt.Errorf("invalid signer address %s", signer)
+		}
+	}
+
+	if fc.MinSigners < 0 {
+		return fmt.Errorf("min signers must be non-negative")
+	}
+
+	if len(fc.Signers) > 0 && fc.MinSigners > len(fc.Signers) {
+		return fmt.Errorf("min signers %d exceeds the number of signers %d", fc.MinSigners, len(fc.Signers))
+	}
+
+	return nil
+}
+
+// SignerSet returns the set of accepted signers and the number of them that must have signed
+// a value, applying the defaults for any unset fields.
+func (fc *FeedConfig) SignerSet() (map[common.Address]struct{}, int) {
+	signers := fc.Signers
+	if len(signers) == 0 {
+		signers = DefaultSigners
+	}
+
+	set := make(map[common.Address]struct{}, len(signers))
+	for _, signer := range signers {
+		set[common.HexToAddress(signer)] = struct{}{}
+	}
+
+	minSigners := fc.MinSigners
+	if minSigners == 0 {
+		minSigners = min(DefaultMinSigners, len(set))
+	}
+
+	return set, minSigners
+}
+
+// MustToJSON converts the feed configuration to JSON.
+func (fc FeedConfig) MustToJSON() string {
+	b, err := json.Marshal(fc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+type (
+	// LatestDataPackagesResponse is the response returned by the latest data packages endpoint
+	// of a RedStone data gateway. It maps each data feed ID to the packages signed for it by
+	// each node of the data service. The response format looks like the following:
+	//
+	//	{
+	//		"BTC": [
+	//			{
+	//				"timestampMilliseconds": 1713295010000,
+	//				"signature": "NZEPlM3d...HA==",
+	//				"dataPoints": [
+	//					{
+	//						"dataFeedId": "BTC",
+	//						"value": 61033.66879713
+	//					}
+	//				],
+	//				"dataServiceId": "redstone-primary-prod",
+	//				"dataFeedId": "BTC",
+	//				"signerAddress": "0x8BB8F32Df04c8b654987DAaeD53D6B6091e3B774"
+	//			}
+	//		]
+	//	}
+	LatestDataPackagesResponse map[string][]DataPackage
+
+	// DataPackage is a set of data points signed by a single node of a data service.
+	DataPackage struct {
+		TimestampMilliseconds int64       `json:"timestampMilliseconds"`
+		Signature             string      `json:"signature"`
+		DataPoints            []DataPoint `json:"dataPoints"`
+		DataServiceID         string      `json:"dataServiceId"`
+		DataFeedID            string      `json:"dataFeedId"`
+		SignerAddress         string      `json:"signerAddress"`
+	}
+
+	// DataPoint is the value of a single data feed.
+	DataPoint struct {
+		DataFeedID string      `json:"dataFeedId"`
+		Value      json.Number `json:"value"`
+	}
+)
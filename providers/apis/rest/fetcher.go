@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	"github.com/skip-mev/connect/v2/providers/base/polling"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// NewPriceFetcher returns a new REST API price fetcher. The fetcher requests the price of each
+// ticker from the URL configured by the ticker's RequestConfig, and selects the price from the
+// JSON response by the configured path. Tickers are fetched concurrently by a polling fetcher.
+func NewPriceFetcher(
+	logger *zap.Logger,
+	apiMetrics metrics.APIMetrics,
+	api config.APIConfig,
+	client *http.Client,
+) (*polling.PriceFetcher[RequestConfig], error) {
+	if !strings.HasPrefix(api.Name, BaseName) {
+		return nil, fmt.Errorf("invalid api config name; expected %s, got %s", BaseName, api.Name)
+	}
+
+	if !api.Enabled {
+		return nil, fmt.Errorf("api config for %s is not enabled", api.Name)
+	}
+
+	if apiMetrics == nil {
+		return nil, fmt.Errorf("metrics cannot be nil")
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	r := &requester{
+		api:     api,
+		metrics: apiMetrics,
+		client:  client,
+	}
+
+	return polling.NewPriceFetcher(logger, api, r.fetch)
+}
+
+// requester fetches the price of a single ticker.
+type requester struct {
+	api     config.APIConfig
+	metrics metrics.APIMetrics
+	client  *http.Client
+}
+
+// fetch requests the price of the ticker and selects it from the response.
+func (r *requester) fetch(ctx context.Context, ticker types.ProviderTicker, rc RequestConfig) (*big.Float, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.api.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url(ticker, rc), nil)
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorUnableToCreateURL)
+	}
+
+	for key, value := range rc.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := r.client.Do(req)
+	r.metrics.AddHTTPStatusCode(r.api.Name, resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, providertypes.NewErrorWithCode(fmt.Errorf("rate limited"), providertypes.ErrorRateLimitExceeded)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return nil, providertypes.NewErrorWithCode(
+			fmt.Errorf("unexpected status code %d", resp.StatusCode),
+			providertypes.ErrorCode(resp.StatusCode),
+		)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode)
+	}
+
+	value, err := SelectPath(body, rc.Path)
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorInvalidResponse)
+	}
+
+	price, err := ParsePrice(value, rc.Scale, rc.Invert)
+	if err != nil {
+		return nil, providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToParsePrice)
+	}
+
+	return price, nil
+}
+
+// url returns the URL of the request of the ticker, with the placeholders of the template
+// replaced.
+func (r *requester) url(ticker types.ProviderTicker, rc RequestConfig) string {
+	return strings.NewReplacer(
+		EndpointPlaceholder, strings.TrimSuffix(r.api.Endpoints[0].URL, "/"),
+		TickerPlaceholder, url.PathEscape(ticker.GetOffChainTicker()),
+	).Replace(rc.URL)
+}
+
+// SelectPath returns the value at the given path of the decoded JSON value. Object fields are
+// selected by key and array elements by index, separated by dots.
+func SelectPath(value interface{}, path string) (interface{}, error) {
+	for _, elem := range strings.Split(path, PathSeparator) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			field, ok := v[elem]
+			if !ok {
+				return nil, fmt.Errorf("field %s not found at path %s", elem, path)
+			}
+			value = field
+		case []interface{}:
+			i, err := strconv.Atoi(elem)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %s at path %s", elem, path)
+			}
+
+			if i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("index %d out of range at path %s", i, path)
+			}
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("cannot select %s of a %T at path %s", elem, value, path)
+		}
+	}
+
+	return value, nil
+}
+
+// ParsePrice parses the selected value into a price, multiplying it by the scale if set and
+// inverting it if invert is set. The value must be a positive number, or a string containing one.
+func ParsePrice(value interface{}, scale float64, invert bool) (*big.Float, error) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return nil, fmt.Errorf("expected a number, got %T", value)
+	}
+
+	price, ok := new(big.Float).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse %s as a number", s)
+	}
+
+	if scale != 0 {
+		price.Mul(price, big.NewFloat(scale))
+	}
+
+	if price.Sign() <= 0 {
+		return nil, fmt.Errorf("price %s is not positive", price.String())
+	}
+
+	if invert {
+		price.Quo(big.NewFloat(1), price)
+	}
+
+	return price, nil
+}
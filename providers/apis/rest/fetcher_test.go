@@ -0,0 +1,212 @@
+package rest_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/apis/rest"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+func newAPIConfig(url string) config.APIConfig {
+	return config.APIConfig{
+		Name:             rest.BaseName,
+		Atomic:           false,
+		Enabled:          true,
+		Timeout:          time.Second,
+		Interval:         time.Second,
+		ReconnectTimeout: time.Second,
+		MaxQueries:       1,
+		Endpoints:        []config.Endpoint{{URL: url}},
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ticker/BTC-USD":
+			fmt.Fprint(w, `{"data": {"price": "60000.5"}}`)
+		case "/tickers":
+			fmt.Fprint(w, `{"data": [{"price": 1}, {"price": 250}]}`)
+		case "/cents":
+			fmt.Fprint(w, `{"price": 150}`)
+		case "/auth":
+			if r.Header.Get("X-API-Key") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"price": 2}`)
+		case "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/negative":
+			fmt.Fprint(w, `{"price": -1}`)
+		default:
+			fmt.Fprint(w, `not json`)
+		}
+	}))
+	defer server.Close()
+
+	ticker := func(offChain string, rc rest.RequestConfig) types.ProviderTicker {
+		return types.NewProviderTicker(offChain, rc.MustToJSON())
+	}
+
+	var (
+		btc      = ticker("BTC-USD", rest.RequestConfig{URL: "{endpoint}/ticker/{ticker}", Path: "data.price"})
+		eth      = ticker("ETH-USD", rest.RequestConfig{URL: server.URL + "/tickers", Path: "data.1.price"})
+		inverted = ticker("USD-ETH", rest.RequestConfig{URL: server.URL + "/tickers", Path: "data.1.price", Invert: true})
+		cents    = ticker("SOL-USD", rest.RequestConfig{URL: "{endpoint}/cents", Path: "price", Scale: 0.01})
+		auth     = ticker("ATOM-USD", rest.RequestConfig{
+			URL:     "{endpoint}/auth",
+			Headers: map[string]string{"X-API-Key": "secret"},
+			Path:    "price",
+		})
+		unauthorized = ticker("TIA-USD", rest.RequestConfig{URL: "{endpoint}/auth", Path: "price"})
+		limited      = ticker("OSMO-USD", rest.RequestConfig{URL: "{endpoint}/limited", Path: "price"})
+		missing      = ticker("DYDX-USD", rest.RequestConfig{URL: "{endpoint}/tickers", Path: "data.2.price"})
+		negative     = ticker("USDT-USD", rest.RequestConfig{URL: "{endpoint}/negative", Path: "price"})
+		malformed    = ticker("USDC-USD", rest.RequestConfig{URL: "{endpoint}/malformed", Path: "price"})
+		invalid      = ticker("PEPE-USD", rest.RequestConfig{URL: "{endpoint}/cents"})
+	)
+
+	fetcher, err := rest.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), newAPIConfig(server.URL+"/"), server.Client())
+	require.NoError(t, err)
+
+	response := fetcher.Fetch(context.Background(), []types.ProviderTicker{
+		btc, eth, inverted, cents, auth, unauthorized, limited, missing, negative, malformed, invalid,
+	})
+
+	expectedPrices := map[types.ProviderTicker]*big.Float{
+		btc:      big.NewFloat(60000.5),
+		eth:      big.NewFloat(250),
+		inverted: big.NewFloat(0.004),
+		cents:    big.NewFloat(1.5),
+		auth:     big.NewFloat(2),
+	}
+	require.Len(t, response.Resolved, len(expectedPrices))
+	for tkr, expected := range expectedPrices {
+		require.Contains(t, response.Resolved, tkr)
+		price, _ := response.Resolved[tkr].Value.Float64()
+		expectedPrice, _ := expected.Float64()
+		require.InDelta(t, expectedPrice, price, 1e-9, tkr.String())
+	}
+
+	expectedCodes := map[types.ProviderTicker]providertypes.ErrorCode{
+		unauthorized: providertypes.ErrorCode(http.StatusUnauthorized),
+		limited:      providertypes.ErrorRateLimitExceeded,
+		missing:      providertypes.ErrorInvalidResponse,
+		negative:     providertypes.ErrorFailedToParsePrice,
+		malformed:    providertypes.ErrorFailedToDecode,
+		invalid:      providertypes.ErrorFailedToDecode,
+	}
+	require.Len(t, response.UnResolved, len(expectedCodes))
+	for tkr, code := range expectedCodes {
+		require.Contains(t, response.UnResolved, tkr)
+		require.Equal(t, code, response.UnResolved[tkr].Code(), tkr.String())
+	}
+}
+
+func TestNewPriceFetcher(t *testing.T) {
+	api := newAPIConfig("https://api.example.com")
+
+	_, err := rest.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), api, http.DefaultClient)
+	require.NoError(t, err)
+
+	named := api
+	named.Name = rest.BaseName + rest.NameSeparator + "example"
+	_, err = rest.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), named, http.DefaultClient)
+	require.NoError(t, err)
+
+	misnamed := api
+	misnamed.Name = "binance_api"
+	_, err = rest.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), misnamed, http.DefaultClient)
+	require.Error(t, err)
+
+	_, err = rest.NewPriceFetcher(zap.NewNop(), metrics.NewNopAPIMetrics(), api, nil)
+	require.Error(t, err)
+}
+
+func TestSelectPath(t *testing.T) {
+	value := map[string]interface{}{
+		"data": []interface{}{
+			map[string]interface{}{"price": "1"},
+		},
+	}
+
+	selected, err := rest.SelectPath(value, "data.0.price")
+	require.NoError(t, err)
+	require.Equal(t, "1", selected)
+
+	for _, path := range []string{"price", "data.price", "data.1.price", "data.-1", "data.0.price.value"} {
+		_, err := rest.SelectPath(value, path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestRequestConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name string
+		rc   rest.RequestConfig
+		err  bool
+	}{
+		{
+			name: "valid endpoint template",
+			rc:   rest.RequestConfig{URL: "{endpoint}/price?symbol={ticker}", Path: "data.price"},
+		},
+		{
+			name: "valid url",
+			rc:   rest.RequestConfig{URL: "https://api.example.com/{ticker}", Path: "price", Scale: 0.01},
+		},
+		{
+			name: "empty url",
+			rc:   rest.RequestConfig{Path: "price"},
+			err:  true,
+		},
+		{
+			name: "url without a scheme",
+			rc:   rest.RequestConfig{URL: "api.example.com/price", Path: "price"},
+			err:  true,
+		},
+		{
+			name: "empty header name",
+			rc:   rest.RequestConfig{URL: "{endpoint}", Headers: map[string]string{" ": "value"}, Path: "price"},
+			err:  true,
+		},
+		{
+			name: "empty path",
+			rc:   rest.RequestConfig{URL: "{endpoint}"},
+			err:  true,
+		},
+		{
+			name: "empty path element",
+			rc:   rest.RequestConfig{URL: "{endpoint}", Path: "data..price"},
+			err:  true,
+		},
+		{
+			name: "negative scale",
+			rc:   rest.RequestConfig{URL: "{endpoint}", Path: "price", Scale: -1},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rc.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// BaseName is the name of the REST API provider. Several instances of the provider can be
+	// configured, e.g. with different API keys, by suffixing the name with NameSeparator and any
+	// identifier, e.g. rest_api-kaiko.
+	BaseName = "rest_api"
+
+	// NameSeparator is the character used to separate elements of dynamic naming for the provider.
+	NameSeparator = "-"
+
+	// PathSeparator is the separator of the elements of a path.
+	PathSeparator = "."
+
+	// EndpointPlaceholder is replaced by the URL of the provider's endpoint in URL templates.
+	EndpointPlaceholder = "{endpoint}"
+
+	// TickerPlaceholder is replaced by the off-chain ticker in URL templates.
+	TickerPlaceholder = "{ticker}"
+)
+
+// RequestConfig is the configuration of the request that fetches the price of a ticker, and of
+// where the price is in the response. This is specific to each ticker, and allows simple HTTP
+// price APIs to be onboarded via config alone.
+type RequestConfig struct {
+	// URL is the URL template of the request. {endpoint} is replaced by the URL of the provider's
+	// endpoint, and {ticker} by the (path escaped) off-chain ticker.
+	URL string `json:"url"`
+	// Headers are the headers set on the request.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Path is the path of the price in the JSON response. Object fields are selected by key and
+	// array elements by index, separated by dots, e.g. data.0.price. The selected value must be a
+	// number or a string containing a number.
+	Path string `json:"path"`
+	// Scale is the factor the selected value is multiplied by, e.g. 0.01 for prices quoted in
+	// cents. If unset, the value is not scaled.
+	Scale float64 `json:"scale,omitempty"`
+	// Invert is true if the scaled value should be inverted, i.e. the API reports the price of the
+	// quote in units of the base.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// ValidateBasic validates the request configuration.
+func (rc *RequestConfig) ValidateBasic() error {
+	if len(rc.URL) == 0 {
+		return fmt.Errorf("url cannot be empty")
+	}
+
+	if !strings.HasPrefix(rc.URL, EndpointPlaceholder) {
+		u, err := url.Parse(strings.ReplaceAll(rc.URL, TickerPlaceholder, "ticker"))
+		if err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("url must be an http or https url or start with %s", EndpointPlaceholder)
+		}
+	}
+
+	for key := range rc.Headers {
+		if len(strings.TrimSpace(key)) == 0 {
+			return fmt.Errorf("header names cannot be empty")
+		}
+	}
+
+	if len(rc.Path) == 0 {
+		return fmt.Errorf("path cannot be empty")
+	}
+
+	for _, elem := range strings.Split(rc.Path, PathSeparator) {
+		if len(elem) == 0 {
+			return fmt.Errorf("path %s has an empty element", rc.Path)
+		}
+	}
+
+	if rc.Scale < 0 {
+		return fmt.Errorf("scale must be non-negative")
+	}
+
+	return nil
+}
+
+// MustToJSON converts the request configuration to JSON.
+func (rc RequestConfig) MustToJSON() string {
+	b, err := json.Marshal(rc)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	gomath "math"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// AdaptiveIntervals adapts the interval at which each ID is fetched to the volatility of its
+// recent values, within the bounds of the adaptive interval config. It is safe for concurrent use.
+type AdaptiveIntervals[K providertypes.ResponseKey] struct {
+	cfg config.AdaptiveIntervalConfig
+
+	mtx sync.Mutex
+	ids map[K]*adaptiveState
+}
+
+// adaptiveState is the current interval and the recent values of an ID.
+type adaptiveState struct {
+	interval time.Duration
+	values   []float64
+}
+
+// NewAdaptiveIntervals returns a new AdaptiveIntervals with the given config.
+func NewAdaptiveIntervals[K providertypes.ResponseKey](cfg config.AdaptiveIntervalConfig) *AdaptiveIntervals[K] {
+	return &AdaptiveIntervals[K]{
+		cfg: cfg,
+		ids: make(map[K]*adaptiveState),
+	}
+}
+
+// Interval returns the current interval of the given ID. IDs start at the given base interval,
+// clamped to the configured bounds.
+func (a *AdaptiveIntervals[K]) Interval(id K, base time.Duration) time.Duration {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	return a.state(id, base).interval
+}
+
+// Observe records the latest value of the given ID, and adapts its interval to the volatility of
+// its recent values. The interval is halved if the volatility is above the high volatility, and
+// doubled if it is below the low volatility. The new interval is returned.
+func (a *AdaptiveIntervals[K]) Observe(id K, base time.Duration, value float64) time.Duration {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	s := a.state(id, base)
+	if value <= 0 || gomath.IsNaN(value) || gomath.IsInf(value, 0) {
+		return s.interval
+	}
+
+	s.values = append(s.values, value)
+	if len(s.values) > a.cfg.Window {
+		s.values = s.values[len(s.values)-a.cfg.Window:]
+	}
+
+	if len(s.values) < 2 {
+		return s.interval
+	}
+
+	switch volatility := Volatility(s.values); {
+	case volatility > a.cfg.HighVolatility:
+		s.interval = a.clamp(s.interval / 2)
+	case volatility <= a.cfg.LowVolatility:
+		s.interval = a.clamp(s.interval * 2)
+	}
+
+	return s.interval
+}
+
+// Retain drops the state of all IDs that are not in the given set.
+func (a *AdaptiveIntervals[K]) Retain(ids []K) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	retained := make(map[K]struct{}, len(ids))
+	for _, id := range ids {
+		retained[id] = struct{}{}
+	}
+
+	for id := range a.ids {
+		if _, ok := retained[id]; !ok {
+			delete(a.ids, id)
+		}
+	}
+}
+
+// state returns the state of the given ID, initializing it if needed. The caller must hold the
+// lock.
+func (a *AdaptiveIntervals[K]) state(id K, base time.Duration) *adaptiveState {
+	s, ok := a.ids[id]
+	if !ok {
+		s = &adaptiveState{interval: a.clamp(base)}
+		a.ids[id] = s
+	}
+
+	return s
+}
+
+// clamp bounds the interval to the configured min and max intervals.
+func (a *AdaptiveIntervals[K]) clamp(interval time.Duration) time.Duration {
+	return min(max(interval, a.cfg.MinInterval), a.cfg.MaxInterval)
+}
+
+// Volatility returns the root mean square of the relative changes between consecutive values.
+func Volatility(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	var sum float64
+	for i := 1; i < len(values); i++ {
+		change := (values[i] - values[i-1]) / values[i-1]
+		sum += change * change
+	}
+
+	return gomath.Sqrt(sum / float64(len(values)-1))
+}
+
+// valueToFloat converts the value of a response to a float64, if it is numeric.
+func valueToFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case *big.Float:
+		if v == nil {
+			return 0, false
+		}
+		f, _ := v.Float64()
+		return f, true
+	case *big.Int:
+		if v == nil {
+			return 0, false
+		}
+		f, _ := new(big.Float).SetInt(v).Float64()
+		return f, true
+	case interface{ String() string }:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
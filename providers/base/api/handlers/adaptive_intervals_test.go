@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/skip-mev/connect/v2/providers/base/api/handlers"
+)
+
+var adaptiveCfg = config.AdaptiveIntervalConfig{
+	Enabled:        true,
+	MinInterval:    250 * time.Millisecond,
+	MaxInterval:    4 * time.Second,
+	Window:         3,
+	HighVolatility: 0.01,
+	LowVolatility:  0.001,
+}
+
+func TestVolatility(t *testing.T) {
+	require.Zero(t, handlers.Volatility(nil))
+	require.Zero(t, handlers.Volatility([]float64{100}))
+	require.Zero(t, handlers.Volatility([]float64{100, 100, 100}))
+	require.InDelta(t, 0.1, handlers.Volatility([]float64{100, 110, 99}), 1e-9)
+}
+
+func TestAdaptiveIntervals(t *testing.T) {
+	t.Run("starts at the base interval within the bounds", func(t *testing.T) {
+		a := handlers.NewAdaptiveIntervals[connecttypes.CurrencyPair](adaptiveCfg)
+		require.Equal(t, time.Second, a.Interval(btcusd, time.Second))
+		require.Equal(t, adaptiveCfg.MinInterval, a.Interval(ethusd, time.Millisecond))
+		require.Equal(t, adaptiveCfg.MaxInterval, a.Interval(atomusd, time.Hour))
+	})
+
+	t.Run("lengthens the interval of flat prices", func(t *testing.T) {
+		a := handlers.NewAdaptiveIntervals[connecttypes.CurrencyPair](adaptiveCfg)
+
+		// A single price does not adapt the interval.
+		require.Equal(t, time.Second, a.Observe(btcusd, time.Second, 100))
+		require.Equal(t, 2*time.Second, a.Observe(btcusd, time.Second, 100))
+		require.Equal(t, 4*time.Second, a.Observe(btcusd, time.Second, 100))
+		require.Equal(t, adaptiveCfg.MaxInterval, a.Observe(btcusd, time.Second, 100))
+	})
+
+	t.Run("shortens the interval of volatile prices", func(t *testing.T) {
+		a := handlers.NewAdaptiveIntervals[connecttypes.CurrencyPair](adaptiveCfg)
+
+		require.Equal(t, time.Second, a.Observe(btcusd, time.Second, 100))
+		require.Equal(t, 500*time.Millisecond, a.Observe(btcusd, time.Second, 105))
+		require.Equal(t, 250*time.Millisecond, a.Observe(btcusd, time.Second, 100))
+		require.Equal(t, adaptiveCfg.MinInterval, a.Observe(btcusd, time.Second, 105))
+	})
+
+	t.Run("keeps the interval of moderately volatile prices", func(t *testing.T) {
+		a := handlers.NewAdaptiveIntervals[connecttypes.CurrencyPair](adaptiveCfg)
+
+		require.Equal(t, time.Second, a.Observe(btcusd, time.Second, 100))
+		require.Equal(t, time.Second, a.Observe(btcusd, time.Second, 100.5))
+	})
+
+	t.Run("ignores invalid prices", func(t *testing.T) {
+		a := handlers.NewAdaptiveIntervals[connecttypes.CurrencyPair](adaptiveCfg)
+
+		require.Equal(t, time.Second, a.Observe(btcusd, time.Second, 100))
+		require.Equal(t, time.Second, a.Observe(btcusd, time.Second, 0))
+		require.Equal(t, time.Second, a.Observe(btcusd, time.Second, -1))
+	})
+
+	t.Run("drops the state of removed ids", func(t *testing.T) {
+		a := handlers.NewAdaptiveIntervals[connecttypes.CurrencyPair](adaptiveCfg)
+
+		a.Observe(btcusd, time.Second, 100)
+		a.Observe(btcusd, time.Second, 100)
+		require.Equal(t, 2*time.Second, a.Interval(btcusd, time.Second))
+
+		a.Retain([]connecttypes.CurrencyPair{ethusd})
+		require.Equal(t, time.Second, a.Interval(btcusd, time.Second))
+	})
+}
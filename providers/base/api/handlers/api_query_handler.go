@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	gomath "math"
+	"sort"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/pkg/math"
+	"github.com/skip-mev/connect/v2/pkg/tracing"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
@@ -29,6 +33,14 @@ type APIQueryHandler[K providertypes.ResponseKey, V providertypes.ResponseValue]
 		ids []K,
 		responseCh chan<- providertypes.GetResponse[K, V],
 	)
+
+	// QueryOnce queries the data provider for the given IDs a single time, rather than at the
+	// provider's interval. This blocks until all responses have been sent to the response channel.
+	QueryOnce(
+		ctx context.Context,
+		ids []K,
+		responseCh chan<- providertypes.GetResponse[K, V],
+	)
 }
 
 // APIFetcher is an interface that encapsulates fetching data from a provider. This interface
@@ -58,6 +70,10 @@ type APIQueryHandlerImpl[K providertypes.ResponseKey, V providertypes.ResponseVa
 
 	// fetcher is responsible for fetching data from the API.
 	fetcher APIFetcher[K, V]
+
+	// adaptive adapts the interval of each ID to the volatility of its values. This is nil if
+	// adaptive intervals are disabled.
+	adaptive *AdaptiveIntervals[K]
 }
 
 // NewAPIQueryHandler creates a new APIQueryHandler. It manages querying the data
@@ -74,12 +90,7 @@ func NewAPIQueryHandler[K providertypes.ResponseKey, V providertypes.ResponseVal
 		return nil, fmt.Errorf("failed to create api fetcher: %w", err)
 	}
 
-	return &APIQueryHandlerImpl[K, V]{
-		logger:  logger.With(zap.String("api_query_handler", cfg.Name)),
-		config:  cfg,
-		metrics: metrics,
-		fetcher: fetcher,
-	}, nil
+	return newAPIQueryHandler(logger, cfg, fetcher, metrics), nil
 }
 
 // NewAPIQueryHandlerWithFetcher creates a new APIQueryHandler with a custom api fetcher.
@@ -109,12 +120,27 @@ func NewAPIQueryHandlerWithFetcher[K providertypes.ResponseKey, V providertypes.
 		return nil, fmt.Errorf("no fetcher specified for api query handler")
 	}
 
-	return &APIQueryHandlerImpl[K, V]{
+	return newAPIQueryHandler(logger, cfg, fetcher, metrics), nil
+}
+
+func newAPIQueryHandler[K providertypes.ResponseKey, V providertypes.ResponseValue](
+	logger *zap.Logger,
+	cfg config.APIConfig,
+	fetcher APIFetcher[K, V],
+	metrics metrics.APIMetrics,
+) *APIQueryHandlerImpl[K, V] {
+	h := &APIQueryHandlerImpl[K, V]{
 		logger:  logger.With(zap.String("api_query_handler", cfg.Name)),
 		config:  cfg,
 		metrics: metrics,
 		fetcher: fetcher,
-	}, nil
+	}
+
+	if cfg.AdaptiveInterval.Enabled {
+		h.adaptive = NewAdaptiveIntervals[K](cfg.AdaptiveInterval)
+	}
+
+	return h
 }
 
 // Query is used to query the API data provider for the given IDs. This method blocks
@@ -149,49 +175,81 @@ func (h *APIQueryHandlerImpl[K, V]) Query(
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// If our task is atomic, we can make a single request for all the IDs. Otherwise,
-	// we need to make a request for each ID.
-	var tasks []func() error
-	if h.config.Atomic {
-		tasks = append(tasks, h.subTask(ctx, ids, responseCh))
+	if len(h.config.TickerIntervals) > 0 || h.adaptive != nil {
+		h.querySchedule(ctx, ids, responseCh, &wg)
 	} else {
-		// Calculate the batch size based on the configuration.
-		batchSize := math.Max(1, h.config.BatchSize)
+		h.queryInterval(ctx, ids, responseCh, &wg, limit)
+	}
 
-		// determine the number of queries we need to make based on the batch size.
-		threads := int(gomath.Ceil(float64(len(ids)) / float64(batchSize)))
+	// Wait for all tasks to complete.
+	h.logger.Debug("waiting for api sub-tasks to complete")
+	if err := wg.Wait(); err != nil {
+		h.logger.Debug("error querying ids", zap.Error(err))
+	}
+	h.logger.Debug("all api sub-tasks completed")
+}
 
-		// update limit in accordance with # of threads necessary, we want to avoid unnecessary go routines
-		// if the number of threads (tasks) is less than the limit.
-		limit = math.Min(limit, threads)
+// QueryOnce queries the API data provider for the given IDs a single time. The IDs are fetched
+// in the same batches as by Query, with at most MaxQueries concurrent requests.
+func (h *APIQueryHandlerImpl[K, V]) QueryOnce(
+	ctx context.Context,
+	ids []K,
+	responseCh chan<- providertypes.GetResponse[K, V],
+) {
+	if len(ids) == 0 {
+		return
+	}
 
-		for i := 0; i < threads; i++ {
-			// Calculate the start and end indices for the batch.
-			start := i * batchSize
-			end := math.Min(len(ids), (i+1)*batchSize)
+	wg := errgroup.Group{}
+	wg.SetLimit(math.Min(h.config.MaxQueries, len(ids)))
+	for _, batch := range h.batches(ids) {
+		wg.Go(h.subTask(ctx, batch, responseCh))
+	}
 
-			// Create a new task for the batch.
-			tasks = append(tasks, h.subTask(ctx, ids[start:end], responseCh))
-		}
+	if err := wg.Wait(); err != nil {
+		h.logger.Debug("error querying ids once", zap.Error(err))
+	}
+}
+
+// queryInterval fetches all of the IDs at the provider's interval. Each interval, limit requests
+// are made, cycling through the batches of IDs.
+func (h *APIQueryHandlerImpl[K, V]) queryInterval(
+	ctx context.Context,
+	ids []K,
+	responseCh chan<- providertypes.GetResponse[K, V],
+	wg *errgroup.Group,
+	limit int,
+) {
+	// If our task is atomic, we can make a single request for all the IDs. Otherwise,
+	// we need to make a request for each batch of IDs.
+	batches := h.batches(ids)
+	if !h.config.Atomic {
+		// update limit in accordance with # of threads necessary, we want to avoid unnecessary go routines
+		// if the number of threads (tasks) is less than the limit.
+		limit = math.Min(limit, len(batches))
 
 		h.logger.Debug(
 			"created sub-tasks",
-			zap.Int("threads", threads),
+			zap.Int("threads", len(batches)),
 			zap.Int("limit", limit),
-			zap.Int("batch_size", batchSize),
+			zap.Int("batch_size", math.Max(1, h.config.BatchSize)),
 		)
 	}
 
+	tasks := make([]func() error, len(batches))
+	for i, batch := range batches {
+		tasks[i] = h.subTask(ctx, batch, responseCh)
+	}
+
 	// Block each task until the wait group has capacity to accept a new response.
 	index := 0
 	ticker, stop := tickerWithImmediateFirstTick(h.config.Interval)
 	defer stop()
-MainLoop:
 	for {
 		select {
 		case <-ctx.Done():
 			h.logger.Debug("context cancelled, stopping queries")
-			break MainLoop
+			return
 		case <-ticker:
 			// spin up limit number of tasks
 			for i := 0; i < limit; i++ {
@@ -202,13 +260,138 @@ MainLoop:
 			h.logger.Debug("interval complete", zap.Duration("interval", h.config.Interval), zap.Int("index", index))
 		}
 	}
+}
 
-	// Wait for all tasks to complete.
-	h.logger.Debug("waiting for api sub-tasks to complete")
-	if err := wg.Wait(); err != nil {
-		h.logger.Debug("error querying ids", zap.Error(err))
+// querySchedule fetches each ID at its own interval, as configured by the ticker intervals of the
+// provider and adapted to the volatility of the ID if adaptive intervals are enabled. The schedule
+// ticks at the shortest interval of the IDs, and each tick fetches the IDs that are due. IDs that
+// are due at the same tick are coalesced into the same batches, so that they share requests to the
+// provider's endpoints. At most MaxQueries requests are made per tick; IDs that are still due are
+// fetched on the following ticks, most overdue first.
+func (h *APIQueryHandlerImpl[K, V]) querySchedule(
+	ctx context.Context,
+	ids []K,
+	responseCh chan<- providertypes.GetResponse[K, V],
+	wg *errgroup.Group,
+) {
+	type scheduledID struct {
+		id      K
+		fetched time.Time
+		due     time.Time
+	}
+
+	var (
+		schedule = make([]*scheduledID, len(ids))
+		tick     time.Duration
+	)
+	for i, id := range ids {
+		schedule[i] = &scheduledID{id: id}
+		if interval := h.config.GetInterval(id.String()); tick == 0 || interval < tick {
+			tick = interval
+		}
+	}
+
+	if h.adaptive != nil {
+		// The intervals of the IDs may shrink down to the min interval.
+		h.adaptive.Retain(ids)
+		tick = min(tick, h.config.AdaptiveInterval.MinInterval)
+	}
+
+	h.logger.Debug("scheduling ids at their ticker intervals", zap.Duration("tick", tick))
+
+	ticker, stop := tickerWithImmediateFirstTick(tick)
+	defer stop()
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Debug("context cancelled, stopping queries")
+			return
+		case <-ticker:
+			// IDs that are due within half a tick are fetched now rather than a tick late, since
+			// ticks are not perfectly aligned with the IDs' intervals.
+			now := time.Now()
+			var due []*scheduledID
+			for _, s := range schedule {
+				s.due = s.fetched.Add(h.interval(s.id))
+				if !s.due.After(now.Add(tick / 2)) {
+					due = append(due, s)
+				}
+			}
+			sort.SliceStable(due, func(i, j int) bool {
+				return due[i].due.Before(due[j].due)
+			})
+
+			dueIDs := make([]K, len(due))
+			for i, s := range due {
+				dueIDs[i] = s.id
+			}
+
+			batches := h.batches(dueIDs)
+			if len(batches) > h.config.MaxQueries {
+				batches = batches[:h.config.MaxQueries]
+			}
+
+			fetched := 0
+			for _, batch := range batches {
+				for _, s := range due[fetched : fetched+len(batch)] {
+					s.fetched = now
+				}
+				fetched += len(batch)
+
+				wg.Go(h.subTask(ctx, batch, responseCh))
+			}
+
+			h.logger.Debug("tick complete", zap.Int("due", len(due)), zap.Int("fetched", fetched))
+		}
+	}
+}
+
+// interval returns the current interval at which the given ID is fetched.
+func (h *APIQueryHandlerImpl[K, V]) interval(id K) time.Duration {
+	base := h.config.GetInterval(id.String())
+	if h.adaptive == nil {
+		return base
+	}
+
+	return h.adaptive.Interval(id, base)
+}
+
+// observe adapts the intervals of the resolved IDs of a response to their latest values.
+func (h *APIQueryHandlerImpl[K, V]) observe(response providertypes.GetResponse[K, V]) {
+	if h.adaptive == nil {
+		return
+	}
+
+	for id, result := range response.Resolved {
+		value, ok := valueToFloat(any(result.Value))
+		if !ok {
+			continue
+		}
+
+		interval := h.adaptive.Observe(id, h.config.GetInterval(id.String()), value)
+		h.logger.Debug("adapted interval", zap.Stringer("id", id), zap.Duration("interval", interval))
 	}
-	h.logger.Debug("all api sub-tasks completed")
+}
+
+// batches splits the IDs into the batches that are fetched by a single request. Atomic providers
+// fetch all of the IDs in a single request. Otherwise, each batch has at most max(1, BatchSize)
+// IDs.
+func (h *APIQueryHandlerImpl[K, V]) batches(ids []K) [][]K {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if h.config.Atomic {
+		return [][]K{ids}
+	}
+
+	batchSize := math.Max(1, h.config.BatchSize)
+	batches := make([][]K, 0, int(gomath.Ceil(float64(len(ids))/float64(batchSize))))
+	for start := 0; start < len(ids); start += batchSize {
+		batches = append(batches, ids[start:math.Min(len(ids), start+batchSize)])
+	}
+
+	return batches
 }
 
 // tickerWithImmediateFirstTick creates a ticker that sends an initial tick immediately, and then ticks
@@ -249,11 +432,42 @@ func (h *APIQueryHandlerImpl[K, V]) subTask(
 		}()
 
 		h.logger.Debug("starting subtask", zap.Any("ids", ids))
-		h.writeResponse(ctx, responseCh, h.fetcher.Fetch(ctx, ids))
+
+		// Trace the fetch so that the context is propagated to the provider's http and rpc
+		// clients.
+		ctx, span := tracing.Start(ctx, "provider.fetch", trace.WithAttributes(
+			attribute.String("provider", h.config.Name),
+			attribute.Int("ids", len(ids)),
+		))
+		response := h.fetcher.Fetch(ctx, ids)
+		span.SetAttributes(
+			attribute.Int("resolved", len(response.Resolved)),
+			attribute.Int("unresolved", len(response.UnResolved)),
+		)
+		tracing.End(span, unresolvedErr(response))
+
+		h.observe(response)
+		h.writeResponse(ctx, responseCh, response)
 		return nil
 	}
 }
 
+// unresolvedErr returns the error of an unresolved ID if none of the IDs in the response were
+// resolved, and nil otherwise.
+func unresolvedErr[K providertypes.ResponseKey, V providertypes.ResponseValue](
+	response providertypes.GetResponse[K, V],
+) error {
+	if len(response.Resolved) > 0 {
+		return nil
+	}
+
+	for _, result := range response.UnResolved {
+		return result
+	}
+
+	return nil
+}
+
 // writeResponse is used to write the response to the response channel.
 func (h *APIQueryHandlerImpl[K, V]) writeResponse(
 	ctx context.Context,
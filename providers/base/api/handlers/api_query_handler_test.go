@@ -740,6 +740,139 @@ func TestAPIQueryHandlerWithBatchSize(t *testing.T) {
 	})
 }
 
+func TestAPIQueryHandlerWithTickerIntervals(t *testing.T) {
+	tickerCfg := config.APIConfig{
+		Enabled:          true,
+		Timeout:          500 * time.Millisecond,
+		Interval:         time.Second,
+		ReconnectTimeout: 250 * time.Millisecond,
+		MaxQueries:       1,
+		Atomic:           true,
+		Endpoints:        []config.Endpoint{{URL: constantURL}},
+		Name:             "handler1",
+		TickerIntervals: map[string]time.Duration{
+			btcusd.String(): 100 * time.Millisecond,
+			ethusd.String(): time.Hour,
+		},
+	}
+
+	pf := mocks.NewAPIFetcher[connecttypes.CurrencyPair, *big.Int](t)
+	handler, err := handlers.NewAPIQueryHandlerWithFetcher(
+		zap.NewNop(),
+		tickerCfg,
+		pf,
+		metrics.NewNopAPIMetrics(),
+	)
+	require.NoError(t, err)
+
+	var (
+		mtx     sync.Mutex
+		fetches [][]connecttypes.CurrencyPair
+	)
+	pf.On("Fetch", mock.Anything, mock.Anything).Return(
+		providertypes.NewGetResponse[connecttypes.CurrencyPair, *big.Int](nil, nil),
+	).Run(func(args mock.Arguments) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		fetches = append(fetches, args.Get(1).([]connecttypes.CurrencyPair))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1050*time.Millisecond)
+	defer cancel()
+
+	responseCh := make(chan providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int], 100)
+	handler.Query(ctx, []connecttypes.CurrencyPair{btcusd, ethusd, atomusd}, responseCh)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	counts := make(map[connecttypes.CurrencyPair]int)
+	for _, ids := range fetches {
+		for _, id := range ids {
+			counts[id]++
+		}
+	}
+
+	// The tickers that are due at the same time are fetched in the same request.
+	require.NotEmpty(t, fetches)
+	require.ElementsMatch(t, []connecttypes.CurrencyPair{btcusd, ethusd, atomusd}, fetches[0])
+
+	// Each ticker is fetched at its own interval, defaulting to the provider's interval.
+	require.GreaterOrEqual(t, counts[btcusd], 8)
+	require.Equal(t, 1, counts[ethusd])
+	require.GreaterOrEqual(t, counts[atomusd], 1)
+	require.LessOrEqual(t, counts[atomusd], 2)
+}
+
+func TestAPIQueryHandlerWithAdaptiveIntervals(t *testing.T) {
+	adaptiveHandlerCfg := config.APIConfig{
+		Enabled:          true,
+		Timeout:          500 * time.Millisecond,
+		Interval:         100 * time.Millisecond,
+		ReconnectTimeout: 250 * time.Millisecond,
+		MaxQueries:       1,
+		Atomic:           true,
+		Endpoints:        []config.Endpoint{{URL: constantURL}},
+		Name:             "handler1",
+		AdaptiveInterval: config.AdaptiveIntervalConfig{
+			Enabled:        true,
+			MinInterval:    50 * time.Millisecond,
+			MaxInterval:    800 * time.Millisecond,
+			Window:         2,
+			HighVolatility: 0.01,
+			LowVolatility:  0.001,
+		},
+	}
+
+	pf := mocks.NewAPIFetcher[connecttypes.CurrencyPair, *big.Int](t)
+	handler, err := handlers.NewAPIQueryHandlerWithFetcher(
+		zap.NewNop(),
+		adaptiveHandlerCfg,
+		pf,
+		metrics.NewNopAPIMetrics(),
+	)
+	require.NoError(t, err)
+
+	// The price of BTC/USD is flat, while the price of ETH/USD alternates by 10%.
+	var (
+		mtx    sync.Mutex
+		counts = make(map[connecttypes.CurrencyPair]int)
+	)
+	pf.On("Fetch", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, ids []connecttypes.CurrencyPair) providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int] {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			resolved := make(map[connecttypes.CurrencyPair]providertypes.ResolvedResult[*big.Int])
+			for _, id := range ids {
+				counts[id]++
+
+				price := big.NewInt(100)
+				if id == ethusd && counts[id]%2 == 0 {
+					price = big.NewInt(110)
+				}
+				resolved[id] = providertypes.NewResult(price, time.Now())
+			}
+
+			return providertypes.NewGetResponse(resolved, nil)
+		},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	responseCh := make(chan providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int], 1000)
+	handler.Query(ctx, []connecttypes.CurrencyPair{btcusd, ethusd}, responseCh)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	// The flat price is fetched at the max interval, and the volatile price at the min interval.
+	require.LessOrEqual(t, counts[btcusd], 8)
+	require.GreaterOrEqual(t, counts[ethusd], 25)
+}
+
 func newRateLimitResponse() *http.Response {
 	return &http.Response{
 		StatusCode: http.StatusTooManyRequests,
@@ -58,6 +58,41 @@ func (_c *APIQueryHandler_Query_Call[K, V]) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// QueryOnce provides a mock function with given fields: ctx, ids, responseCh
+func (_m *APIQueryHandler[K, V]) QueryOnce(ctx context.Context, ids []K, responseCh chan<- types.GetResponse[K, V]) {
+	_m.Called(ctx, ids, responseCh)
+}
+
+// APIQueryHandler_QueryOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryOnce'
+type APIQueryHandler_QueryOnce_Call[K types.ResponseKey, V types.ResponseValue] struct {
+	*mock.Call
+}
+
+// QueryOnce is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []K
+//   - responseCh chan<- types.GetResponse[K,V]
+func (_e *APIQueryHandler_Expecter[K, V]) QueryOnce(ctx interface{}, ids interface{}, responseCh interface{}) *APIQueryHandler_QueryOnce_Call[K, V] {
+	return &APIQueryHandler_QueryOnce_Call[K, V]{Call: _e.mock.On("QueryOnce", ctx, ids, responseCh)}
+}
+
+func (_c *APIQueryHandler_QueryOnce_Call[K, V]) Run(run func(ctx context.Context, ids []K, responseCh chan<- types.GetResponse[K, V])) *APIQueryHandler_QueryOnce_Call[K, V] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]K), args[2].(chan<- types.GetResponse[K, V]))
+	})
+	return _c
+}
+
+func (_c *APIQueryHandler_QueryOnce_Call[K, V]) Return() *APIQueryHandler_QueryOnce_Call[K, V] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *APIQueryHandler_QueryOnce_Call[K, V]) RunAndReturn(run func(context.Context, []K, chan<- types.GetResponse[K, V])) *APIQueryHandler_QueryOnce_Call[K, V] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewAPIQueryHandler creates a new instance of APIQueryHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAPIQueryHandler[K types.ResponseKey, V types.ResponseValue](t interface {
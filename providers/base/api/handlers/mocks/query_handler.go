@@ -20,6 +20,11 @@ func (_m *QueryHandler[K, V]) Query(ctx context.Context, ids []K, responseCh cha
 	_m.Called(ctx, ids, responseCh)
 }
 
+// QueryOnce provides a mock function with given fields: ctx, ids, responseCh
+func (_m *QueryHandler[K, V]) QueryOnce(ctx context.Context, ids []K, responseCh chan<- types.GetResponse[K, V]) {
+	_m.Called(ctx, ids, responseCh)
+}
+
 // NewQueryHandler creates a new instance of QueryHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewQueryHandler[K types.ResponseKey, V types.ResponseValue](t interface {
@@ -1,5 +1,7 @@
 package handlers
 
+import "golang.org/x/time/rate"
+
 // Option is a function that is used to configure a RequestHandler.
 type Option func(*RequestHandlerImpl)
 
@@ -16,3 +18,11 @@ func WithHTTPHeaders(headers map[string]string) Option {
 		r.headers = headers
 	}
 }
+
+// WithRateLimiter is an option that is used to set a client-side rate limiter that every
+// request must wait on before it is sent.
+func WithRateLimiter(limiter *rate.Limiter) Option {
+	return func(r *RequestHandlerImpl) {
+		r.limiter = limiter
+	}
+}
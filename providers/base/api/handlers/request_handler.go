@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"golang.org/x/time/rate"
 )
 
 // RequestHandler is an interface that encapsulates sending an HTTP request to a data provider.
@@ -28,6 +30,9 @@ type RequestHandlerImpl struct {
 	method string
 	// headers is the HTTP headers to use when sending requests.
 	headers map[string]string
+	// limiter is an optional client-side rate limiter that every request must wait on
+	// before it is sent.
+	limiter *rate.Limiter
 }
 
 // NewRequestHandlerImpl creates a new RequestHandlerImpl. It manages making HTTP requests.
@@ -49,8 +54,15 @@ func NewRequestHandlerImpl(client *http.Client, opts ...Option) (RequestHandler,
 }
 
 // Do is used to send a request with the given URL to the data provider. It first
-// wraps the request with the given context before sending it to the data provider.
+// wraps the request with the given context before sending it to the data provider. If a
+// rate limiter is configured, this blocks until the limiter allows the request.
 func (r *RequestHandlerImpl) Do(ctx context.Context, url string) (*http.Response, error) {
+	if r.limiter != nil {
+		if err := r.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, r.method, url, nil)
 	if err != nil {
 		return nil, err
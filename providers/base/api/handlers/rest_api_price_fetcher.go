@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/providers/base/api/errors"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	"github.com/skip-mev/connect/v2/providers/base/debug"
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
 
@@ -107,7 +110,7 @@ func (pf *RestAPIFetcher[K, V]) Fetch(
 	resp, err := pf.requestHandler.Do(apiCtx, url)
 	pf.metrics.AddHTTPStatusCode(pf.config.Name, resp)
 	if err != nil {
-		status := providertypes.ErrorUnknown
+		status := providertypes.ErrorCodeFromError(err, providertypes.ErrorUnknown)
 		if resp != nil {
 			status = providertypes.ErrorCode(resp.StatusCode)
 		}
@@ -128,6 +131,16 @@ func (pf *RestAPIFetcher[K, V]) Fetch(
 	}
 	defer resp.Body.Close()
 
+	// Record the raw response if the raw responses of the providers are recorded for debugging.
+	if recorder := debug.Default(); recorder != nil {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			pf.logger.Debug("failed to read response body for debugging", zap.Error(err))
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		recorder.Record(pf.config.Name, debug.Tickers(ids), url, resp.StatusCode, body)
+	}
+
 	pf.logger.Debug("received response", zap.Int("status_code", resp.StatusCode))
 	// TODO: add more error handling here.
 	// TODO(nikhil): move this logic to a shared HTTPClient
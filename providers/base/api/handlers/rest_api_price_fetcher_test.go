@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/skip-mev/connect/v2/providers/base/api/handlers"
+	"github.com/skip-mev/connect/v2/providers/base/api/handlers/mocks"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	"github.com/skip-mev/connect/v2/providers/base/debug"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+func TestRestAPIFetcherRecordsResponses(t *testing.T) {
+	recorder := debug.Enable(8)
+	defer debug.Disable()
+
+	ids := []connecttypes.CurrencyPair{btcusd, ethusd}
+
+	requestHandler := mocks.NewRequestHandler(t)
+	requestHandler.On("Do", mock.Anything, constantURL).Return(newValidResponse(), nil)
+
+	apiHandler := mocks.NewAPIDataHandler[connecttypes.CurrencyPair, *big.Int](t)
+	apiHandler.On("CreateURL", ids).Return(constantURL, nil)
+	apiHandler.On("ParseResponse", ids, mock.Anything).Return(
+		providertypes.NewGetResponse[connecttypes.CurrencyPair, *big.Int](nil, nil),
+	).Run(func(args mock.Arguments) {
+		// the body can still be parsed after it was recorded.
+		body, err := io.ReadAll(args.Get(1).(*http.Response).Body)
+		require.NoError(t, err)
+		require.Equal(t, `{"result": "100"}`, string(body))
+	})
+
+	fetcher, err := handlers.NewRestAPIFetcher(requestHandler, apiHandler, metrics.NewNopAPIMetrics(), cfg, logger)
+	require.NoError(t, err)
+	fetcher.Fetch(context.Background(), ids)
+
+	responses := recorder.Responses(cfg.Name)
+	require.Len(t, responses, 2)
+	for _, id := range ids {
+		resp := responses[id.String()]
+		require.Equal(t, constantURL, resp.Source)
+		require.Equal(t, http.StatusOK, resp.Status)
+		require.Equal(t, `{"result`, resp.Body)
+		require.True(t, resp.Truncated)
+	}
+}
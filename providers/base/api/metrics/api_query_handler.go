@@ -36,6 +36,10 @@ type APIMetrics interface {
 	// within a single interval. Note that if the provider is not atomic, this will be the
 	// time it took for all the requests to complete.
 	ObserveProviderResponseLatency(providerName, endpoint string, duration time.Duration)
+
+	// AddAPICredits increments the number of API credits consumed by a provider. This is used
+	// to track the plan usage of providers that bill requests in credits.
+	AddAPICredits(providerName string, credits int)
 }
 
 // APIMetricsImpl contains metrics exposed by this package.
@@ -51,6 +55,9 @@ type APIMetricsImpl struct {
 
 	// Histogram paginated by provider, measuring the latency between invocation and collection.
 	apiResponseTimePerProvider *prometheus.HistogramVec
+
+	// Number of API credits consumed by provider.
+	apiCreditsPerProvider *prometheus.CounterVec
 }
 
 // NewAPIMetricsFromConfig returns a new Metrics struct given the main oracle metrics config.
@@ -85,6 +92,11 @@ func NewAPIMetrics() APIMetrics {
 			Help:      "Response time per API provider. URL may be redacted but will correspond to indices in the oracle config.",
 			Buckets:   []float64{50, 100, 250, 500, 1000, 2000},
 		}, []string{providermetrics.ProviderLabel, EndpointLabel}),
+		apiCreditsPerProvider: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: oraclemetrics.OracleSubsystem,
+			Name:      "api_credits_total",
+			Help:      "Number of API credits consumed per provider, for providers that bill requests in credits.",
+		}, []string{providermetrics.ProviderLabel}),
 	}
 
 	// register the above metrics
@@ -92,6 +104,7 @@ func NewAPIMetrics() APIMetrics {
 	prometheus.MustRegister(m.apiHTTPStatusCodePerProvider)
 	prometheus.MustRegister(m.apiRPCStatusCodePerProvider)
 	prometheus.MustRegister(m.apiResponseTimePerProvider)
+	prometheus.MustRegister(m.apiCreditsPerProvider)
 
 	return m
 }
@@ -107,6 +120,7 @@ func (m *noOpAPIMetricsImpl) AddProviderResponse(_ string, _ string, _ providert
 func (m *noOpAPIMetricsImpl) AddHTTPStatusCode(_ string, _ *http.Response)                      {}
 func (m *noOpAPIMetricsImpl) AddRPCStatusCode(_, _ string, _ RPCCode)                           {}
 func (m *noOpAPIMetricsImpl) ObserveProviderResponseLatency(_, _ string, _ time.Duration)       {}
+func (m *noOpAPIMetricsImpl) AddAPICredits(_ string, _ int)                                     {}
 
 // AddProviderResponse increments the number of requests by provider and status.
 func (m *APIMetricsImpl) AddProviderResponse(providerName string, id string, err providertypes.ErrorCode) {
@@ -172,3 +186,10 @@ func (m *APIMetricsImpl) ObserveProviderResponseLatency(providerName, endpoint s
 	},
 	).Observe(float64(duration.Milliseconds()))
 }
+
+// AddAPICredits increments the number of API credits consumed by a provider.
+func (m *APIMetricsImpl) AddAPICredits(providerName string, credits int) {
+	m.apiCreditsPerProvider.With(prometheus.Labels{
+		providermetrics.ProviderLabel: providerName,
+	}).Add(float64(credits))
+}
@@ -23,8 +23,20 @@ type (
 const (
 	// RPCCodeOK is the status code for a successful RPC request.
 	RPCCodeOK RPCCode = "ok"
-	// RPCCodeError is the status code for a failed RPC request.
+	// RPCCodeError is the status code for a failed RPC request that does not fall into any of the
+	// more specific categories below.
 	RPCCodeError RPCCode = "request_error"
+	// RPCCodeTimeout is the status code for an RPC request that timed out or was cancelled.
+	RPCCodeTimeout RPCCode = "timeout"
+	// RPCCodeRateLimited is the status code for an RPC request that was rejected by the endpoint
+	// due to rate limiting.
+	RPCCodeRateLimited RPCCode = "rate_limited"
+	// RPCCodeServerError is the status code for an RPC request that failed due to an error on the
+	// endpoint's server.
+	RPCCodeServerError RPCCode = "server_error"
+	// RPCCodeConnectionError is the status code for an RPC request that failed because a connection
+	// to the endpoint could not be established.
+	RPCCodeConnectionError RPCCode = "connection_error"
 )
 
 // RedactedEndpointURL returns a redacted version of the given URL.
@@ -27,6 +27,40 @@ func (_m *APIMetrics) EXPECT() *APIMetrics_Expecter {
 	return &APIMetrics_Expecter{mock: &_m.Mock}
 }
 
+// AddAPICredits provides a mock function with given fields: providerName, credits
+func (_m *APIMetrics) AddAPICredits(providerName string, credits int) {
+	_m.Called(providerName, credits)
+}
+
+// APIMetrics_AddAPICredits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAPICredits'
+type APIMetrics_AddAPICredits_Call struct {
+	*mock.Call
+}
+
+// AddAPICredits is a helper method to define mock.On call
+//   - providerName string
+//   - credits int
+func (_e *APIMetrics_Expecter) AddAPICredits(providerName interface{}, credits interface{}) *APIMetrics_AddAPICredits_Call {
+	return &APIMetrics_AddAPICredits_Call{Call: _e.mock.On("AddAPICredits", providerName, credits)}
+}
+
+func (_c *APIMetrics_AddAPICredits_Call) Run(run func(providerName string, credits int)) *APIMetrics_AddAPICredits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *APIMetrics_AddAPICredits_Call) Return() *APIMetrics_AddAPICredits_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *APIMetrics_AddAPICredits_Call) RunAndReturn(run func(string, int)) *APIMetrics_AddAPICredits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddHTTPStatusCode provides a mock function with given fields: providerName, resp
 func (_m *APIMetrics) AddHTTPStatusCode(providerName string, resp *http.Response) {
 	_m.Called(providerName, resp)
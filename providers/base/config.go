@@ -51,15 +51,100 @@ func (p *Provider[K, V]) Update(opts ...UpdateOption[K, V]) {
 	}
 }
 
-// SetIDs sets the set of IDs that the provider is responsible for fetching data for.
+// Refresh restarts the provider's API query handler so that all of the provider's IDs are queried
+// immediately rather than at the next interval. This is a no-op for websocket providers, whose data
+// is streamed as it is updated.
+func (p *Provider[K, V]) Refresh() {
+	if p.Type() != providertypes.API {
+		return
+	}
+
+	if _, cancel := p.getFetchCtx(); cancel != nil {
+		p.logger.Debug("canceling fetch context; refreshing provider")
+		cancel()
+	}
+}
+
+// RefreshIDs fetches the given IDs once, immediately, alongside the provider's regular queries,
+// so that their data is fresh before their next interval. IDs that the provider does not fetch
+// are ignored. This does not block. This is a no-op for websocket providers, whose data is
+// streamed as it is updated, and for providers that are not running or are quarantined.
+func (p *Provider[K, V]) RefreshIDs(ids []K) {
+	if p.Type() != providertypes.API || p.Health() == providertypes.Quarantined {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ctx, handler, responseCh := p.fetchCtx, p.api, p.responseCh
+	if ctx == nil || ctx.Err() != nil {
+		return
+	}
+
+	refreshed := make([]K, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := p.idSet[id]; ok {
+			refreshed = append(refreshed, id)
+		}
+	}
+	if len(refreshed) == 0 {
+		return
+	}
+
+	p.logger.Debug("refreshing ids", zap.Any("ids", refreshed))
+	p.refreshes.Add(1)
+	go func() {
+		defer p.refreshes.Done()
+		handler.QueryOnce(ctx, refreshed, responseCh)
+	}()
+}
+
+// setIDs sets the set of IDs that the provider is responsible for fetching data for. The IDs are
+// copied, so the caller may reuse the slice, and the data and errors of the IDs that were removed
+// are dropped so that they are no longer reported by GetData and GetErrors.
 func (p *Provider[K, V]) setIDs(ids []K) {
 	p.mu.Lock()
-	p.ids = ids
+	p.replaceIDs(ids)
+	for id := range p.removedIDs {
+		delete(p.data, id)
+		delete(p.errors, id)
+	}
 	p.mu.Unlock()
 
 	p.logger.Debug("set ids", zap.Any("ids", ids))
 }
 
+// replaceIDs replaces the provider's IDs with a copy of the given IDs. The previous slice and set
+// are never mutated, as they may still be in use by the fetch routines (copy-on-write). The caller
+// must hold the provider's lock.
+func (p *Provider[K, V]) replaceIDs(ids []K) {
+	p.ids = make([]K, len(ids))
+	copy(p.ids, ids)
+
+	idSet := make(map[K]struct{}, len(ids))
+	for _, id := range ids {
+		idSet[id] = struct{}{}
+	}
+
+	p.removedIDs = make(map[K]struct{})
+	for id := range p.idSet {
+		if _, ok := idSet[id]; !ok {
+			p.removedIDs[id] = struct{}{}
+		}
+	}
+	p.idSet = idSet
+}
+
+// clearRemovedIDs clears the set of removed IDs once the routines that may still be fetching
+// them have stopped.
+func (p *Provider[K, V]) clearRemovedIDs() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.removedIDs = make(map[K]struct{})
+}
+
 // GetIDs returns the set of IDs that the provider is responsible for fetching data for.
 func (p *Provider[K, V]) GetIDs() []K {
 	p.mu.Lock()
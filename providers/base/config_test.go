@@ -3,13 +3,16 @@ package base_test
 import (
 	"context"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
 	"github.com/skip-mev/connect/v2/providers/base"
+	apihandlermocks "github.com/skip-mev/connect/v2/providers/base/api/handlers/mocks"
 	"github.com/skip-mev/connect/v2/providers/base/testutils"
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
@@ -239,4 +242,112 @@ func TestConfigUpdater(t *testing.T) {
 		provider.Stop()
 		require.Eventually(t, func() bool { return !provider.IsRunning() }, 2*time.Second, 100*time.Millisecond)
 	})
+
+	t.Run("drops the data of removed IDs", func(t *testing.T) {
+		pairs := []connecttypes.CurrencyPair{btcusd, ethusd}
+		// The handler returns data for the IDs it is queried for.
+		apiHandler := apihandlermocks.NewQueryHandler[connecttypes.CurrencyPair, *big.Int](t)
+		apiHandler.On("Query", mock.Anything, mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			ids := args.Get(1).([]connecttypes.CurrencyPair)
+			responseCh := args.Get(2).(chan<- providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int])
+
+			resolved := make(map[connecttypes.CurrencyPair]providertypes.ResolvedResult[*big.Int])
+			for _, id := range ids {
+				resolved[id] = providertypes.NewResult(big.NewInt(100), time.Now())
+			}
+
+			select {
+			case <-ctx.Done():
+			case responseCh <- providertypes.NewGetResponse(resolved, nil):
+			}
+		}).Maybe()
+
+		provider, err := base.NewProvider[connecttypes.CurrencyPair, *big.Int](
+			base.WithName[connecttypes.CurrencyPair, *big.Int](apiCfg.Name),
+			base.WithAPIQueryHandler[connecttypes.CurrencyPair, *big.Int](apiHandler),
+			base.WithAPIConfig[connecttypes.CurrencyPair, *big.Int](apiCfg),
+			base.WithLogger[connecttypes.CurrencyPair, *big.Int](logger),
+			base.WithIDs[connecttypes.CurrencyPair, *big.Int](pairs),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+		defer cancel()
+
+		go func() {
+			provider.Start(ctx)
+		}()
+
+		require.Eventually(t, func() bool { return len(provider.GetData()) == 2 }, 3*time.Second, 50*time.Millisecond)
+
+		updated := []connecttypes.CurrencyPair{btcusd}
+		provider.Update(base.WithNewIDs[connecttypes.CurrencyPair, *big.Int](updated))
+
+		// The provider keeps its own copy of the IDs.
+		updated[0] = solusd
+		require.Equal(t, []connecttypes.CurrencyPair{btcusd}, provider.GetIDs())
+
+		require.Eventually(t, provider.IsRunning, 3*time.Second, 50*time.Millisecond)
+		time.Sleep(2 * apiCfg.Interval)
+
+		data := provider.GetData()
+		require.Len(t, data, 1)
+		require.Contains(t, data, btcusd)
+
+		provider.Stop()
+		require.Eventually(t, func() bool { return !provider.IsRunning() }, 2*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("concurrent IDs updates and reads", func(t *testing.T) {
+		apiHandler := testutils.CreateAPIQueryHandlerWithGetResponses[connecttypes.CurrencyPair, *big.Int](
+			t,
+			logger,
+			nil,
+			10*time.Millisecond,
+		)
+
+		provider, err := base.NewProvider[connecttypes.CurrencyPair, *big.Int](
+			base.WithName[connecttypes.CurrencyPair, *big.Int](apiCfg.Name),
+			base.WithAPIQueryHandler[connecttypes.CurrencyPair, *big.Int](apiHandler),
+			base.WithAPIConfig[connecttypes.CurrencyPair, *big.Int](apiCfg),
+			base.WithLogger[connecttypes.CurrencyPair, *big.Int](logger),
+			base.WithIDs[connecttypes.CurrencyPair, *big.Int]([]connecttypes.CurrencyPair{btcusd}),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+		defer cancel()
+
+		go func() {
+			provider.Start(ctx)
+		}()
+
+		sets := [][]connecttypes.CurrencyPair{{btcusd}, {ethusd, solusd}, {btcusd, ethusd, solusd}}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					provider.Update(base.WithNewIDs[connecttypes.CurrencyPair, *big.Int](sets[(i+j)%len(sets)]))
+				}
+			}(i)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					provider.GetIDs()
+					provider.GetData()
+					provider.GetErrors()
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.Contains(t, sets, provider.GetIDs())
+
+		provider.Stop()
+		require.Eventually(t, func() bool { return !provider.IsRunning() }, 2*time.Second, 100*time.Millisecond)
+	})
 }
@@ -0,0 +1,132 @@
+package debug
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMaxBodySize is the default maximum number of bytes of a response that is recorded.
+const DefaultMaxBodySize = 16 * 1024
+
+// recorder is the recorder the raw responses of the providers are recorded to. This is nil unless
+// recording is enabled.
+var recorder atomic.Pointer[Recorder]
+
+// Response is a raw response of an upstream data source of a provider.
+type Response struct {
+	// Timestamp is the time the response was received.
+	Timestamp time.Time `json:"timestamp"`
+	// Source is where the response was received from, e.g. the URL of an API request or the
+	// websocket the message was read from.
+	Source string `json:"source"`
+	// Status is the HTTP status code of the response. This is unset for websocket messages.
+	Status int `json:"status,omitempty"`
+	// Body is the raw body of the response, truncated to the max body size of the recorder.
+	Body string `json:"body"`
+	// Truncated is true if the body was truncated.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Recorder records the most recent raw response of each provider for each of its tickers, so that
+// scale and decoding issues can be diagnosed by comparing them to the decoded values. A response
+// that carries the data of several tickers, e.g. of an atomic API request, is recorded for each of
+// them. Methods of a nil Recorder are no-ops.
+type Recorder struct {
+	mu          sync.RWMutex
+	maxBodySize int
+	// responses are the most recent responses, indexed by provider and ticker.
+	responses map[string]map[string]Response
+}
+
+// NewRecorder returns a new Recorder that truncates the bodies of responses to maxBodySize bytes.
+func NewRecorder(maxBodySize int) *Recorder {
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+
+	return &Recorder{
+		maxBodySize: maxBodySize,
+		responses:   make(map[string]map[string]Response),
+	}
+}
+
+// Enable enables recording the raw responses of all providers to a new recorder with the given max
+// body size, and returns it.
+func Enable(maxBodySize int) *Recorder {
+	r := NewRecorder(maxBodySize)
+	recorder.Store(r)
+	return r
+}
+
+// Disable disables recording the raw responses of the providers.
+func Disable() {
+	recorder.Store(nil)
+}
+
+// Default returns the recorder the raw responses of the providers are recorded to, or nil if
+// recording is not enabled.
+func Default() *Recorder {
+	return recorder.Load()
+}
+
+// Record records the given response as the most recent response of the provider for each of the
+// given tickers. The body of the response is truncated to the max body size of the recorder.
+func (r *Recorder) Record(provider string, tickers []string, source string, status int, body []byte) {
+	if r == nil || len(tickers) == 0 {
+		return
+	}
+
+	resp := Response{
+		Timestamp: time.Now().UTC(),
+		Source:    source,
+		Status:    status,
+	}
+	if len(body) > r.maxBodySize {
+		body = body[:r.maxBodySize]
+		resp.Truncated = true
+	}
+	resp.Body = string(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	responses, ok := r.responses[provider]
+	if !ok {
+		responses = make(map[string]Response)
+		r.responses[provider] = responses
+	}
+
+	for _, ticker := range tickers {
+		responses[ticker] = resp
+	}
+}
+
+// Responses returns a copy of the most recent responses of the given provider, indexed by ticker.
+func (r *Recorder) Responses(provider string) map[string]Response {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cpy := make(map[string]Response, len(r.responses[provider]))
+	for ticker, resp := range r.responses[provider] {
+		cpy[ticker] = resp
+	}
+
+	return cpy
+}
+
+// Tickers returns the string representations of the given tickers, which the responses are indexed
+// by.
+func Tickers[K fmt.Stringer](ids []K) []string {
+	tickers := make([]string, len(ids))
+	for i, id := range ids {
+		tickers[i] = id.String()
+	}
+
+	return tickers
+}
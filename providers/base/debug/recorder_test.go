@@ -0,0 +1,58 @@
+package debug_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/base/debug"
+)
+
+func TestRecorder(t *testing.T) {
+	recorder := debug.NewRecorder(16)
+
+	recorder.Record("binance_api", []string{"BTCUSDT", "ETHUSDT"}, "https://api.binance.com/api/v3/ticker/price", http.StatusOK, []byte(`[{"symbol":"BTCUSDT","price":"60000.00"}]`))
+	recorder.Record("binance_api", []string{"ETHUSDT"}, "https://api.binance.com/api/v3/ticker/price", http.StatusTooManyRequests, []byte(`{}`))
+	recorder.Record("okx_ws", []string{"BTC-USDT"}, "wss://ws.okx.com:8443/ws/v5/public", 0, []byte(`{"arg":{}}`))
+
+	responses := recorder.Responses("binance_api")
+	require.Len(t, responses, 2)
+
+	// the body is truncated to the max body size.
+	require.Equal(t, `[{"symbol":"BTCU`, responses["BTCUSDT"].Body)
+	require.True(t, responses["BTCUSDT"].Truncated)
+	require.Equal(t, http.StatusOK, responses["BTCUSDT"].Status)
+	require.False(t, responses["BTCUSDT"].Timestamp.IsZero())
+
+	// only the most recent response of a ticker is kept.
+	require.Equal(t, `{}`, responses["ETHUSDT"].Body)
+	require.False(t, responses["ETHUSDT"].Truncated)
+	require.Equal(t, http.StatusTooManyRequests, responses["ETHUSDT"].Status)
+
+	// the responses are copied.
+	delete(responses, "BTCUSDT")
+	require.Len(t, recorder.Responses("binance_api"), 2)
+
+	require.Equal(t, "wss://ws.okx.com:8443/ws/v5/public", recorder.Responses("okx_ws")["BTC-USDT"].Source)
+	require.Empty(t, recorder.Responses("coinbase_api"))
+}
+
+func TestDefaultRecorder(t *testing.T) {
+	require.Nil(t, debug.Default())
+
+	// a nil recorder records nothing.
+	debug.Default().Record("binance_api", []string{"BTCUSDT"}, "", http.StatusOK, nil)
+	require.Nil(t, debug.Default().Responses("binance_api"))
+
+	recorder := debug.Enable(0)
+	defer debug.Disable()
+	require.Same(t, recorder, debug.Default())
+
+	body := make([]byte, debug.DefaultMaxBodySize+1)
+	debug.Default().Record("binance_api", []string{"BTCUSDT"}, "", http.StatusOK, body)
+	require.Len(t, recorder.Responses("binance_api")["BTCUSDT"].Body, debug.DefaultMaxBodySize)
+
+	debug.Disable()
+	require.Nil(t, debug.Default())
+}
@@ -47,12 +47,23 @@ func (p *Provider[K, V]) startAPI(ctx context.Context) error {
 			return ctx.Err()
 
 		default:
-			if restarts > 0 {
+			switch {
+			case p.Health() == providertypes.Quarantined:
+				// If the provider was quarantined, the query handler was stopped. Wait for the
+				// cooldown to elapse before probing the provider.
+				if err := p.awaitHealth(ctx); err != nil {
+					p.logger.Debug("api stopped via context")
+					return err
+				}
+			case restarts > 0:
 				p.logger.Debug("restarting api query handler", zap.Int("num_restarts", restarts))
 
 				// If the API query handler returns, then the connection was closed. Wait for
 				// a bit before trying to reconnect.
-				time.Sleep(p.apiCfg.ReconnectTimeout)
+				if err := sleep(ctx, p.apiCfg.ReconnectTimeout); err != nil {
+					p.logger.Debug("api stopped via context")
+					return err
+				}
 			}
 
 			p.logger.Debug(
@@ -61,7 +72,12 @@ func (p *Provider[K, V]) startAPI(ctx context.Context) error {
 				zap.Int("num_ids", len(ids)),
 			)
 
-			handler.Query(ctx, ids, p.responseCh)
+			// The query handler is run with its own context so that it can be stopped when
+			// the provider is quarantined.
+			queryCtx, cancel := context.WithCancel(ctx)
+			p.setQueryCancel(cancel)
+			handler.Query(queryCtx, ids, p.responseCh)
+			cancel()
 			restarts++
 		}
 	}
@@ -143,7 +159,10 @@ func (p *Provider[K, V]) startWebSocket(ctx context.Context, subIDs []K) func()
 
 					// If the websocket query handler returns, then the connection was closed. Wait for
 					// a bit before trying to reconnect.
-					time.Sleep(p.wsCfg.ReconnectionTimeout)
+					if err := sleep(ctx, p.wsCfg.ReconnectionTimeout); err != nil {
+						p.logger.Debug("web socket stopped via context")
+						return err
+					}
 				}
 
 				p.logger.Debug("starting websocket query handler", zap.Int("num_ids", len(subIDs)), zap.Any("ids", subIDs))
@@ -168,6 +187,9 @@ func (p *Provider[K, V]) recv(ctx context.Context) {
 			return
 		case r := <-p.responseCh:
 			resolved, unResolved := r.Resolved, r.UnResolved
+			if len(resolved) > 0 || len(unResolved) > 0 {
+				p.recordHealth(len(resolved) > 0)
+			}
 
 			// Update all the resolved data.
 			for id, result := range resolved {
@@ -178,6 +200,7 @@ func (p *Provider[K, V]) recv(ctx context.Context) {
 				)
 
 				p.updateData(id, result)
+				p.updateError(id, nil)
 
 				// Update the metrics.
 				strID := strings.ToLower(id.String())
@@ -191,9 +214,12 @@ func (p *Provider[K, V]) recv(ctx context.Context) {
 				p.logger.Debug(
 					"failed to fetch data",
 					zap.Any("id", id),
+					zap.String("kind", string(result.Kind())),
 					zap.Error(fmt.Errorf("%s", result.Error())),
 				)
 
+				p.updateError(id, &result)
+
 				// Update the metrics.
 				strID := strings.ToLower(id.String())
 				p.metrics.AddProviderResponseByID(p.name, strID, providermetrics.Failure, result.Code(), p.Type())
@@ -209,6 +235,11 @@ func (p *Provider[K, V]) updateData(id K, result providertypes.ResolvedResult[V]
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	// Drop in-flight results for IDs that were removed from the provider.
+	if _, ok := p.removedIDs[id]; ok {
+		return
+	}
+
 	current, ok := p.data[id]
 	if !ok {
 		// Deal with the case where we have no received any updates but may have received a heartbeat.
@@ -259,3 +290,21 @@ func (p *Provider[K, V]) updateData(id K, result providertypes.ResolvedResult[V]
 		p.data[id] = result
 	}
 }
+
+// updateError sets the latest error for the given ID. A nil result clears the error, which is
+// done once data for the ID is fetched successfully.
+func (p *Provider[K, V]) updateError(id K, result *providertypes.UnresolvedResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if result == nil {
+		delete(p.errors, id)
+		return
+	}
+
+	if _, ok := p.removedIDs[id]; ok {
+		return
+	}
+
+	p.errors[id] = *result
+}
@@ -0,0 +1,136 @@
+package base
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// health tracks the health of an API provider. The provider is quarantined after a configured
+// number of consecutive failed responses, in which case its query handler is stopped until the
+// cooldown has elapsed. The provider is then probed by restarting the query handler, and is only
+// considered healthy again once a response succeeds.
+type health struct {
+	mtx sync.Mutex
+
+	// status is the current health status of the provider.
+	status providertypes.HealthStatus
+
+	// failures is the number of consecutive failed responses.
+	failures int
+
+	// quarantinedUntil is the time at which the cooldown of a quarantined provider elapses.
+	quarantinedUntil time.Time
+
+	// cancelQuery cancels the currently running query handler.
+	cancelQuery context.CancelFunc
+}
+
+// Health returns the current health status of the provider. Providers without a health policy are
+// always healthy.
+func (p *Provider[K, V]) Health() providertypes.HealthStatus {
+	p.health.mtx.Lock()
+	defer p.health.mtx.Unlock()
+
+	return p.health.status
+}
+
+// isHealthy returns true if the provider's data should be reported.
+func (p *Provider[K, V]) isHealthy() bool {
+	return p.Health() == providertypes.Healthy
+}
+
+// recordHealth records the outcome of a response. A response fails if the provider did not resolve
+// any of the IDs it was queried for. Responses received while the provider is quarantined are in
+// flight from before the quarantine and are ignored.
+func (p *Provider[K, V]) recordHealth(success bool) {
+	cfg := p.apiCfg.Health
+	if p.Type() != providertypes.API || !cfg.Enabled() {
+		return
+	}
+
+	p.health.mtx.Lock()
+	defer p.health.mtx.Unlock()
+
+	switch {
+	case p.health.status == providertypes.Quarantined:
+		return
+	case success:
+		if p.health.status == providertypes.Probing {
+			p.logger.Info("probe succeeded; provider is healthy")
+		}
+
+		p.health.failures = 0
+		p.setHealthStatus(providertypes.Healthy)
+		return
+	}
+
+	p.health.failures++
+	if p.health.status == providertypes.Healthy && p.health.failures < cfg.FailureThreshold {
+		return
+	}
+
+	// Either the failure threshold was reached or a probe failed, so the provider is quarantined
+	// and its query handler is stopped until the cooldown elapses.
+	p.logger.Warn(
+		"quarantining provider after consecutive failures",
+		zap.Int("failures", p.health.failures),
+		zap.Duration("cooldown", cfg.Cooldown),
+	)
+
+	p.health.failures = 0
+	p.health.quarantinedUntil = time.Now().Add(cfg.Cooldown)
+	p.setHealthStatus(providertypes.Quarantined)
+	if p.health.cancelQuery != nil {
+		p.health.cancelQuery()
+	}
+}
+
+// awaitHealth blocks until the cooldown of a quarantined provider has elapsed, after which the
+// provider is probed. This returns immediately if the provider is not quarantined.
+func (p *Provider[K, V]) awaitHealth(ctx context.Context) error {
+	p.health.mtx.Lock()
+	status, until := p.health.status, p.health.quarantinedUntil
+	p.health.mtx.Unlock()
+
+	if status != providertypes.Quarantined {
+		return nil
+	}
+
+	p.logger.Debug("waiting for provider cooldown to elapse", zap.Time("until", until))
+	if err := sleep(ctx, time.Until(until)); err != nil {
+		return err
+	}
+
+	p.logger.Info("probing quarantined provider")
+
+	p.health.mtx.Lock()
+	defer p.health.mtx.Unlock()
+
+	p.setHealthStatus(providertypes.Probing)
+	return nil
+}
+
+// setQueryCancel sets the function used to stop the currently running query handler when the
+// provider is quarantined.
+func (p *Provider[K, V]) setQueryCancel(cancel context.CancelFunc) {
+	p.health.mtx.Lock()
+	defer p.health.mtx.Unlock()
+
+	p.health.cancelQuery = cancel
+}
+
+// setHealthStatus sets the health status of the provider and updates the metrics. The caller must
+// hold the health lock.
+func (p *Provider[K, V]) setHealthStatus(status providertypes.HealthStatus) {
+	if p.health.status == status {
+		return
+	}
+
+	p.health.status = status
+	p.metrics.SetHealthStatus(p.name, status, p.Type())
+}
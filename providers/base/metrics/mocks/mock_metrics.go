@@ -131,6 +131,41 @@ func (_c *ProviderMetrics_LastUpdated_Call) RunAndReturn(run func(string, string
 	return _c
 }
 
+// SetHealthStatus provides a mock function with given fields: providerName, status, providerType
+func (_m *ProviderMetrics) SetHealthStatus(providerName string, status types.HealthStatus, providerType types.ProviderType) {
+	_m.Called(providerName, status, providerType)
+}
+
+// ProviderMetrics_SetHealthStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetHealthStatus'
+type ProviderMetrics_SetHealthStatus_Call struct {
+	*mock.Call
+}
+
+// SetHealthStatus is a helper method to define mock.On call
+//   - providerName string
+//   - status types.HealthStatus
+//   - providerType types.ProviderType
+func (_e *ProviderMetrics_Expecter) SetHealthStatus(providerName interface{}, status interface{}, providerType interface{}) *ProviderMetrics_SetHealthStatus_Call {
+	return &ProviderMetrics_SetHealthStatus_Call{Call: _e.mock.On("SetHealthStatus", providerName, status, providerType)}
+}
+
+func (_c *ProviderMetrics_SetHealthStatus_Call) Run(run func(providerName string, status types.HealthStatus, providerType types.ProviderType)) *ProviderMetrics_SetHealthStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(types.HealthStatus), args[2].(types.ProviderType))
+	})
+	return _c
+}
+
+func (_c *ProviderMetrics_SetHealthStatus_Call) Return() *ProviderMetrics_SetHealthStatus_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ProviderMetrics_SetHealthStatus_Call) RunAndReturn(run func(string, types.HealthStatus, types.ProviderType)) *ProviderMetrics_SetHealthStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewProviderMetrics creates a new instance of ProviderMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProviderMetrics(t interface {
@@ -24,6 +24,10 @@ const (
 	ErrorLabel = "error"
 	// ErrorCodeLabel is a label for and an error code of a failed provider response.
 	ErrorCodeLabel = "code"
+	// ErrorKindLabel is a label for the kind of error of a failed provider response.
+	ErrorKindLabel = "kind"
+	// HealthStatusLabel is a label for the health status of a provider.
+	HealthStatusLabel = "health"
 )
 
 type (
@@ -50,6 +54,9 @@ type ProviderMetrics interface {
 
 	// LastUpdated updates the last time a given ID (i.e. currency pair) was updated.
 	LastUpdated(providerName, id string, providerType providertypes.ProviderType)
+
+	// SetHealthStatus sets the current health status of a given provider.
+	SetHealthStatus(providerName string, status providertypes.HealthStatus, providerType providertypes.ProviderType)
 }
 
 // ProviderMetricsImpl contains metrics exposed by this package.
@@ -62,6 +69,9 @@ type ProviderMetricsImpl struct {
 
 	// Last time a given ID (i.e. currency pair) was updated.
 	lastUpdatedPerProvider *prometheus.GaugeVec
+
+	// Current health status of a given provider.
+	healthStatusPerProvider *prometheus.GaugeVec
 }
 
 // NewProviderMetricsFromConfig returns a new Metrics struct given the main oracle metrics config.
@@ -79,23 +89,29 @@ func NewProviderMetrics() ProviderMetrics {
 			Namespace: oraclemetrics.OracleSubsystem,
 			Name:      "provider_status_responses_per_id",
 			Help:      "Number of provider successes with a given ID.",
-		}, []string{ProviderLabel, IDLabel, StatusLabel, ErrorCodeLabel, ProviderTypeLabel}),
+		}, []string{ProviderLabel, IDLabel, StatusLabel, ErrorCodeLabel, ErrorKindLabel, ProviderTypeLabel}),
 		responseStatusPerProvider: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: oraclemetrics.OracleSubsystem,
 			Name:      "provider_status_responses",
 			Help:      "Number of provider successes.",
-		}, []string{ProviderLabel, StatusLabel, ErrorCodeLabel, ProviderTypeLabel}),
+		}, []string{ProviderLabel, StatusLabel, ErrorCodeLabel, ErrorKindLabel, ProviderTypeLabel}),
 		lastUpdatedPerProvider: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: oraclemetrics.OracleSubsystem,
 			Name:      "provider_last_updated_id",
 			Help:      "Last time a given ID (i.e. currency pair) was updated.",
 		}, []string{ProviderLabel, IDLabel, ProviderTypeLabel}),
+		healthStatusPerProvider: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: oraclemetrics.OracleSubsystem,
+			Name:      "provider_health_status",
+			Help:      "Health status of a given provider, set to 1 for the current status and 0 otherwise.",
+		}, []string{ProviderLabel, HealthStatusLabel, ProviderTypeLabel}),
 	}
 
 	// register the above metrics
 	prometheus.MustRegister(m.responseStatusPerProviderByID)
 	prometheus.MustRegister(m.responseStatusPerProvider)
 	prometheus.MustRegister(m.lastUpdatedPerProvider)
+	prometheus.MustRegister(m.healthStatusPerProvider)
 
 	return m
 }
@@ -114,6 +130,9 @@ func (m *noOpProviderMetricsImpl) AddProviderResponse(_ string, _ Status, _ prov
 }
 func (m *noOpProviderMetricsImpl) LastUpdated(_, _ string, _ providertypes.ProviderType) {}
 
+func (m *noOpProviderMetricsImpl) SetHealthStatus(_ string, _ providertypes.HealthStatus, _ providertypes.ProviderType) {
+}
+
 // AddProviderResponseByID increments the number of ticks with a fully successful provider update
 // for a given provider and ID (i.e. currency pair).
 func (m *ProviderMetricsImpl) AddProviderResponseByID(providerName, id string, status Status, ec providertypes.ErrorCode, providerType providertypes.ProviderType) {
@@ -122,6 +141,7 @@ func (m *ProviderMetricsImpl) AddProviderResponseByID(providerName, id string, s
 		IDLabel:           id,
 		StatusLabel:       string(status),
 		ErrorCodeLabel:    fmt.Sprintf("%d", ec),
+		ErrorKindLabel:    string(ec.Kind()),
 		ProviderTypeLabel: string(providerType),
 	},
 	).Add(1)
@@ -133,6 +153,7 @@ func (m *ProviderMetricsImpl) AddProviderResponse(providerName string, status St
 		ProviderLabel:     providerName,
 		StatusLabel:       string(status),
 		ErrorCodeLabel:    fmt.Sprintf("%d", ec),
+		ErrorKindLabel:    string(ec.Kind()),
 		ProviderTypeLabel: string(providerType),
 	},
 	).Add(1)
@@ -148,3 +169,21 @@ func (m *ProviderMetricsImpl) LastUpdated(providerName, id string, providerType
 	},
 	).Set(float64(now.Unix()))
 }
+
+// SetHealthStatus sets the current health status of a given provider. The gauge of the current
+// status is set to 1 and the gauges of all other statuses are set to 0.
+func (m *ProviderMetricsImpl) SetHealthStatus(providerName string, status providertypes.HealthStatus, providerType providertypes.ProviderType) {
+	for _, s := range []providertypes.HealthStatus{providertypes.Healthy, providertypes.Quarantined, providertypes.Probing} {
+		value := 0.0
+		if s == status {
+			value = 1
+		}
+
+		m.healthStatusPerProvider.With(prometheus.Labels{
+			ProviderLabel:     providerName,
+			HealthStatusLabel: string(s),
+			ProviderTypeLabel: string(providerType),
+		},
+		).Set(value)
+	}
+}
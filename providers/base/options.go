@@ -96,7 +96,7 @@ func WithIDs[K providertypes.ResponseKey, V providertypes.ResponseValue](ids []K
 			panic("cannot set nil ids")
 		}
 
-		p.ids = ids
+		p.replaceIDs(ids)
 	}
 }
 
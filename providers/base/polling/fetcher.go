@@ -0,0 +1,220 @@
+package polling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+// FetchFunc fetches the price of a single ticker, given the metadata decoded from the ticker's
+// JSON. Errors that carry an error code (i.e. a providertypes.ErrorWithCode) are reported with
+// that code.
+type FetchFunc[T any] func(ctx context.Context, ticker types.ProviderTicker, metadata T) (*big.Float, error)
+
+// Validator is implemented by metadata that can be validated. The metadata of each ticker is
+// validated once, when it is first decoded.
+type Validator interface {
+	ValidateBasic() error
+}
+
+var _ types.PriceAPIFetcher = (*PriceFetcher[struct{}])(nil)
+
+// PriceFetcher is a PriceAPIFetcher that implements the logic shared by providers that fetch the
+// price of each ticker separately, so that such providers only implement a FetchFunc:
+//
+//   - The metadata of each ticker is decoded from its JSON into a T, validated if T implements
+//     Validator, and cached. Tickers with invalid metadata fail without being fetched.
+//   - Tickers are fetched concurrently, with at most MaxInFlight tickers in flight if set.
+//   - Failed fetches are retried per the retry policy of the API config, unless the failure is not
+//     resolved by retrying.
+//   - Failed tickers are reported as unresolved with the code of their error.
+//
+// Batching tickers across queries, the fetch interval, health and metrics are handled by the API
+// query handler and base provider, as for any other fetcher.
+type PriceFetcher[T any] struct {
+	logger *zap.Logger
+	api    config.APIConfig
+	fetch  FetchFunc[T]
+
+	mtx sync.Mutex
+	// metadataCache is a cache of the tickers to their decoded metadata.
+	metadataCache map[types.ProviderTicker]T
+}
+
+// NewPriceFetcher returns a new PriceFetcher that fetches each ticker with the given function.
+func NewPriceFetcher[T any](
+	logger *zap.Logger,
+	api config.APIConfig,
+	fetch FetchFunc[T],
+) (*PriceFetcher[T], error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger cannot be nil")
+	}
+
+	if err := api.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid api config: %w", err)
+	}
+
+	if fetch == nil {
+		return nil, fmt.Errorf("fetch function cannot be nil")
+	}
+
+	return &PriceFetcher[T]{
+		logger:        logger.With(zap.String("fetcher", api.Name)),
+		api:           api,
+		fetch:         fetch,
+		metadataCache: make(map[types.ProviderTicker]T),
+	}, nil
+}
+
+// Fetch returns the prices of the given tickers, fetching each ticker concurrently.
+func (f *PriceFetcher[T]) Fetch(
+	ctx context.Context,
+	tickers []types.ProviderTicker,
+) types.PriceResponse {
+	var (
+		resolved   = make(types.ResolvedPrices)
+		unResolved = make(types.UnResolvedPrices)
+
+		wg  sync.WaitGroup
+		mtx sync.Mutex
+	)
+
+	var inFlight chan struct{}
+	if f.api.MaxInFlight > 0 {
+		inFlight = make(chan struct{}, f.api.MaxInFlight)
+	}
+
+	for _, ticker := range tickers {
+		metadata, err := f.GetMetadata(ticker)
+		if err != nil {
+			f.logger.Debug("failed to get metadata for ticker", zap.String("ticker", ticker.String()), zap.Error(err))
+			unResolved[ticker] = providertypes.UnresolvedResult{
+				ErrorWithCode: providertypes.NewErrorWithCode(err, providertypes.ErrorFailedToDecode),
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if inFlight != nil {
+				select {
+				case inFlight <- struct{}{}:
+					defer func() { <-inFlight }()
+				case <-ctx.Done():
+				}
+			}
+
+			price, err := f.fetchWithRetry(ctx, ticker, metadata)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			if err != nil {
+				f.logger.Debug("failed to fetch price", zap.String("ticker", ticker.String()), zap.Error(err))
+				unResolved[ticker] = providertypes.UnresolvedResult{
+					ErrorWithCode: providertypes.NewErrorWithCode(
+						err,
+						providertypes.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral),
+					),
+				}
+				return
+			}
+
+			resolved[ticker] = types.NewPriceResult(price, time.Now().UTC())
+		}()
+	}
+
+	wg.Wait()
+	return types.NewPriceResponse(resolved, unResolved)
+}
+
+// fetchWithRetry fetches the price of the ticker, retrying per the retry policy of the API config
+// if the fetch fails.
+func (f *PriceFetcher[T]) fetchWithRetry(ctx context.Context, ticker types.ProviderTicker, metadata T) (*big.Float, error) {
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		price, err := f.fetch(ctx, ticker, metadata)
+		if err == nil {
+			return price, nil
+		}
+
+		kind := providertypes.ErrorCodeFromError(err, providertypes.ErrorAPIGeneral).Kind()
+		if attempt >= f.api.Retry.MaxAttempts || !kind.Retryable() {
+			return nil, err
+		}
+
+		backoff := f.api.Retry.Backoff(attempt, kind == providertypes.ErrorKindRateLimited)
+		f.logger.Debug(
+			"fetch failed; retrying",
+			zap.String("ticker", ticker.String()),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context done while retrying fetch: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// GetMetadata returns the metadata of the given ticker, decoded from the ticker's JSON. Tickers
+// without JSON have the zero value of the metadata.
+func (f *PriceFetcher[T]) GetMetadata(ticker types.ProviderTicker) (T, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if metadata, ok := f.metadataCache[ticker]; ok {
+		return metadata, nil
+	}
+
+	var metadata T
+	if bz := ticker.GetJSON(); bz != "" {
+		if err := json.Unmarshal([]byte(bz), &metadata); err != nil {
+			return metadata, fmt.Errorf("failed to unmarshal metadata of ticker: %w", err)
+		}
+	}
+
+	if v, ok := any(&metadata).(Validator); ok {
+		if err := v.ValidateBasic(); err != nil {
+			return metadata, fmt.Errorf("invalid ticker metadata: %w", err)
+		}
+	}
+
+	f.metadataCache[ticker] = metadata
+	return metadata, nil
+}
+
+// NewPriceAPIQueryHandler returns an API query handler that fetches each ticker with the given
+// function. This is all that is needed to create a provider that fetches tickers separately.
+func NewPriceAPIQueryHandler[T any](
+	logger *zap.Logger,
+	api config.APIConfig,
+	apiMetrics metrics.APIMetrics,
+	fetch FetchFunc[T],
+) (types.PriceAPIQueryHandler, error) {
+	fetcher, err := NewPriceFetcher(logger, api, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewPriceAPIQueryHandlerWithFetcher(logger, api, fetcher, apiMetrics)
+}
@@ -0,0 +1,158 @@
+package polling_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/base/polling"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+)
+
+var api = config.APIConfig{
+	Enabled:          true,
+	Timeout:          time.Second,
+	Interval:         time.Second,
+	ReconnectTimeout: time.Second,
+	MaxQueries:       1,
+	Atomic:           true,
+	Name:             "polling",
+	Endpoints:        []config.Endpoint{{URL: "http://test.com"}},
+}
+
+// feed is the metadata of the tickers of the test provider.
+type feed struct {
+	Price float64 `json:"price"`
+	Err   string  `json:"err"`
+}
+
+func (f *feed) ValidateBasic() error {
+	if f.Price < 0 {
+		return fmt.Errorf("price cannot be negative")
+	}
+
+	return nil
+}
+
+func fetchFeed(_ context.Context, _ types.ProviderTicker, f feed) (*big.Float, error) {
+	switch f.Err {
+	case "":
+		return big.NewFloat(f.Price), nil
+	case "rate limited":
+		return nil, providertypes.NewErrorWithCode(fmt.Errorf("rate limited"), providertypes.ErrorRateLimitExceeded)
+	default:
+		return nil, fmt.Errorf("%s", f.Err)
+	}
+}
+
+func TestPriceFetcher(t *testing.T) {
+	var (
+		btc      = types.NewProviderTicker("BTC/USD", `{"price": 100}`)
+		eth      = types.NewProviderTicker("ETH/USD", `{"price": 10}`)
+		noJSON   = types.NewProviderTicker("USDT/USD", "")
+		invalid  = types.NewProviderTicker("SOL/USD", `{"price": -1}`)
+		badJSON  = types.NewProviderTicker("ATOM/USD", `{"price": "1"}`)
+		failed   = types.NewProviderTicker("TIA/USD", `{"err": "unavailable"}`)
+		limited  = types.NewProviderTicker("OSMO/USD", `{"err": "rate limited"}`)
+		expected = map[types.ProviderTicker]providertypes.ErrorCode{
+			invalid: providertypes.ErrorFailedToDecode,
+			badJSON: providertypes.ErrorFailedToDecode,
+			failed:  providertypes.ErrorAPIGeneral,
+			limited: providertypes.ErrorRateLimitExceeded,
+		}
+	)
+
+	fetcher, err := polling.NewPriceFetcher(zap.NewNop(), api, fetchFeed)
+	require.NoError(t, err)
+
+	response := fetcher.Fetch(context.Background(), []types.ProviderTicker{btc, eth, noJSON, invalid, badJSON, failed, limited})
+	require.Len(t, response.Resolved, 3)
+	require.Equal(t, big.NewFloat(100), response.Resolved[btc].Value)
+	require.Equal(t, big.NewFloat(10), response.Resolved[eth].Value)
+	require.Equal(t, big.NewFloat(0), response.Resolved[noJSON].Value)
+
+	require.Len(t, response.UnResolved, len(expected))
+	for ticker, code := range expected {
+		require.Contains(t, response.UnResolved, ticker)
+		require.Equal(t, code, response.UnResolved[ticker].Code(), ticker.String())
+	}
+
+	_, err = polling.NewPriceFetcher[feed](zap.NewNop(), api, nil)
+	require.Error(t, err)
+}
+
+func TestPriceFetcherRetries(t *testing.T) {
+	cfg := api
+	cfg.Retry = config.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	var attempts, reverts atomic.Int32
+	fetch := func(_ context.Context, ticker types.ProviderTicker, _ feed) (*big.Float, error) {
+		if ticker.GetOffChainTicker() == "REVERTED" {
+			reverts.Add(1)
+			return nil, providertypes.NewErrorWithCode(fmt.Errorf("reverted"), providertypes.ErrorContractReverted)
+		}
+
+		if attempts.Add(1) < 3 {
+			return nil, fmt.Errorf("unavailable")
+		}
+		return big.NewFloat(1), nil
+	}
+
+	fetcher, err := polling.NewPriceFetcher(zap.NewNop(), cfg, fetch)
+	require.NoError(t, err)
+
+	ok := types.NewProviderTicker("OK", "")
+	reverted := types.NewProviderTicker("REVERTED", "")
+	response := fetcher.Fetch(context.Background(), []types.ProviderTicker{ok, reverted})
+
+	// transient failures are retried, while failures that are not resolved by retrying are not.
+	require.Contains(t, response.Resolved, ok)
+	require.Equal(t, int32(3), attempts.Load())
+	require.Contains(t, response.UnResolved, reverted)
+	require.Equal(t, int32(1), reverts.Load())
+}
+
+func TestPriceFetcherMaxInFlight(t *testing.T) {
+	cfg := api
+	cfg.MaxInFlight = 2
+
+	var (
+		mtx            sync.Mutex
+		inFlight, peak int
+	)
+	fetch := func(_ context.Context, _ types.ProviderTicker, _ feed) (*big.Float, error) {
+		mtx.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mtx.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mtx.Lock()
+		inFlight--
+		mtx.Unlock()
+
+		return big.NewFloat(1), nil
+	}
+
+	fetcher, err := polling.NewPriceFetcher(zap.NewNop(), cfg, fetch)
+	require.NoError(t, err)
+
+	tickers := make([]types.ProviderTicker, 10)
+	for i := range tickers {
+		tickers[i] = types.NewProviderTicker(fmt.Sprintf("T%d/USD", i), "")
+	}
+
+	response := fetcher.Fetch(context.Background(), tickers)
+	require.Len(t, response.Resolved, 10)
+	require.Equal(t, 2, peak)
+}
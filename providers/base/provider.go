@@ -2,6 +2,7 @@ package base
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"sync"
@@ -15,7 +16,12 @@ import (
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
 
-// Provider implements a base provider that can be used to build other providers.
+// ErrAlreadyRunning is returned by Start if the provider is already running.
+var ErrAlreadyRunning = errors.New("provider is already running")
+
+// Provider implements a base provider that can be used to build other providers. All of its
+// methods are safe for concurrent use: its IDs, data and errors are guarded by mu, and the IDs
+// can be updated while the provider is running.
 type Provider[K providertypes.ResponseKey, V providertypes.ResponseValue] struct {
 	mu     sync.Mutex
 	logger *zap.Logger
@@ -45,8 +51,19 @@ type Provider[K providertypes.ResponseKey, V providertypes.ResponseValue] struct
 	// for a given set of currency pairs.
 	data map[K]providertypes.ResolvedResult[V]
 
-	// ids is the set of IDs that the provider will fetch data for.
-	ids []K
+	// errors is the latest error for each ID that the provider failed to fetch data for. An
+	// error is cleared once data for the ID is fetched successfully.
+	errors map[K]providertypes.UnresolvedResult
+
+	// ids is the set of IDs that the provider will fetch data for, and idSet is the same set
+	// indexed by ID. Both are replaced, never mutated, when the IDs are updated.
+	ids   []K
+	idSet map[K]struct{}
+
+	// removedIDs is the set of IDs that were removed by the last update of the IDs. Results for
+	// these IDs from fetches that were in flight during the update are dropped until the
+	// provider is restarted.
+	removedIDs map[K]struct{}
 
 	// metrics is the metrics implementation for the provider.
 	metrics providermetrics.ProviderMetrics
@@ -63,16 +80,31 @@ type Provider[K providertypes.ResponseKey, V providertypes.ResponseValue] struct
 	// cancelMainFn is the function that is used to cancel the main loop.
 	cancelMainFn context.CancelFunc
 
+	// stopped is closed once the main loop has exited, i.e. once all of the provider's fetch
+	// and receive routines have returned.
+	stopped chan struct{}
+
 	// responseCh is the channel that is used to receive the response(s) from the query handler.
 	responseCh chan providertypes.GetResponse[K, V]
+
+	// refreshes tracks the one-shot fetches of RefreshIDs, which write to the response channel
+	// and must return before it is closed.
+	refreshes sync.WaitGroup
+
+	// health tracks whether the provider is quarantined after consecutive failures.
+	health health
 }
 
 // NewProvider returns a new Base provider.
 func NewProvider[K providertypes.ResponseKey, V providertypes.ResponseValue](opts ...ProviderOption[K, V]) (*Provider[K, V], error) {
 	p := &Provider[K, V]{
-		logger: zap.NewNop(),
-		ids:    make([]K, 0),
-		data:   make(map[K]providertypes.ResolvedResult[V]),
+		logger:     zap.NewNop(),
+		ids:        make([]K, 0),
+		idSet:      make(map[K]struct{}),
+		removedIDs: make(map[K]struct{}),
+		data:       make(map[K]providertypes.ResolvedResult[V]),
+		errors:     make(map[K]providertypes.UnresolvedResult),
+		health:     health{status: providertypes.Healthy},
 	}
 
 	for _, opt := range opts {
@@ -98,16 +130,24 @@ func NewProvider[K providertypes.ResponseKey, V providertypes.ResponseValue](opt
 }
 
 // Start starts the provider's main loop. The provider will fetch the data from the handler
-// and continuously update the data. This blocks until the provider is stopped.
+// and continuously update the data. This blocks until the provider is stopped. ErrAlreadyRunning
+// is returned if the provider is already running, e.g. if it was started concurrently.
 func (p *Provider[K, V]) Start(ctx context.Context) error {
 	if ctx == nil {
 		p.logger.Error("context is nil; exiting")
 		return nil
 	}
 
+	mainCtx, mainCancel, stopped, err := p.setMainCtx(ctx)
+	if err != nil {
+		return err
+	}
+
 	p.logger.Info("starting provider")
-	mainCtx, mainCancel := p.setMainCtx(ctx)
-	defer mainCancel()
+	defer func() {
+		mainCancel()
+		close(stopped)
+	}()
 
 	wg := sync.WaitGroup{}
 
@@ -126,6 +166,10 @@ func (p *Provider[K, V]) Start(ctx context.Context) error {
 			return nil
 		}
 
+		// The routines of the previous run have stopped, so there are no more in-flight
+		// results for the IDs that were removed.
+		p.clearRemovedIDs()
+
 		// Create the response channel for the provider. This channel is used to receive the
 		// response(s) from the query handler.
 		if err := p.createResponseCh(); err != nil {
@@ -149,7 +193,13 @@ func (p *Provider[K, V]) Start(ctx context.Context) error {
 		go func() {
 			defer wg.Done()
 			errCh <- p.fetch(fetchCtx)
+
+			// The fetch context is cancelled under the lock so that no refresh is started
+			// once the in-flight refreshes are waited for.
+			p.mu.Lock()
 			fetchCancel()
+			p.mu.Unlock()
+			p.refreshes.Wait()
 			close(p.responseCh)
 		}()
 
@@ -175,7 +225,8 @@ func (p *Provider[K, V]) Start(ctx context.Context) error {
 	return retErr
 }
 
-// Stop stops the provider's main loop.
+// Stop stops the provider's main loop. This blocks until the main loop has exited, such that
+// all in-flight fetches have been drained by the time Stop returns.
 func (p *Provider[K, V]) Stop() {
 	mainCtx, cancelMain := p.getMainCtx()
 	if mainCtx == nil {
@@ -187,12 +238,16 @@ func (p *Provider[K, V]) Stop() {
 	case <-mainCtx.Done():
 		// The provider is already stopped.
 		p.logger.Debug("provider is not running")
-		return
 	default:
 		// Cancel the main context to stop the provider.
 		p.logger.Debug("manually stopping provider")
 		cancelMain()
 	}
+
+	// Wait for the main loop to exit. The provider may still be draining its routines even if
+	// the main context was already cancelled.
+	<-p.getStopped()
+	p.logger.Debug("provider stopped")
 }
 
 // IsRunning returns true if the provider is running.
@@ -217,18 +272,34 @@ func (p *Provider[K, V]) Name() string {
 
 // GetData returns the latest data recorded by the provider. The data is constantly
 // updated by the provider's main loop and provides access to the latest data - prices
-// in constant time.
+// in constant time. No data is returned while the provider is quarantined or being probed.
 func (p *Provider[K, V]) GetData() map[K]providertypes.ResolvedResult[V] {
+	cpy := make(map[K]providertypes.ResolvedResult[V])
+	if !p.isHealthy() {
+		return cpy
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	// Deep copy the prices into a new map.
-	cpy := make(map[K]providertypes.ResolvedResult[V])
 	maps.Copy(cpy, p.data)
 
 	return cpy
 }
 
+// GetErrors returns the latest error for each ID that the provider failed to fetch data for
+// since the data for the ID was last fetched successfully.
+func (p *Provider[K, V]) GetErrors() map[K]providertypes.UnresolvedResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cpy := make(map[K]providertypes.UnresolvedResult)
+	maps.Copy(cpy, p.errors)
+
+	return cpy
+}
+
 // Type returns the type of data handler the provider uses.
 func (p *Provider[K, V]) Type() providertypes.ProviderType {
 	switch {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -237,6 +238,39 @@ func TestStart(t *testing.T) {
 		err = provider.Start(ctx)
 		require.Equal(t, context.DeadlineExceeded, err)
 	})
+
+	t.Run("returns an error if already running", func(t *testing.T) {
+		t.Parallel()
+
+		handler := testutils.CreateAPIQueryHandlerWithGetResponses[connecttypes.CurrencyPair, *big.Int](
+			t,
+			logger,
+			nil,
+			200*time.Millisecond,
+		)
+
+		provider, err := base.NewProvider(
+			base.WithName[connecttypes.CurrencyPair, *big.Int](apiCfg.Name),
+			base.WithAPIQueryHandler[connecttypes.CurrencyPair, *big.Int](handler),
+			base.WithAPIConfig[connecttypes.CurrencyPair, *big.Int](apiCfg),
+			base.WithLogger[connecttypes.CurrencyPair, *big.Int](logger),
+			base.WithIDs[connecttypes.CurrencyPair, *big.Int](pairs),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), apiCfg.Interval*10)
+		defer cancel()
+
+		go provider.Start(ctx)
+		require.Eventually(t, provider.IsRunning, time.Second*3, time.Millisecond*10)
+
+		err = provider.Start(ctx)
+		require.ErrorIs(t, err, base.ErrAlreadyRunning)
+		require.True(t, provider.IsRunning())
+
+		provider.Stop()
+		require.False(t, provider.IsRunning())
+	})
 }
 
 func TestStop(t *testing.T) {
@@ -291,6 +325,41 @@ func TestStop(t *testing.T) {
 		require.Eventually(t, func() bool { return !provider.IsRunning() }, time.Second*3, time.Millisecond*100)
 	})
 
+	t.Run("waits for in-flight queries to drain", func(t *testing.T) {
+		// The query handler takes some time to return once its context is cancelled.
+		var drained atomic.Bool
+		started := make(chan struct{})
+		handler := testutils.CreateAPIQueryHandlerWithResponseFn[connecttypes.CurrencyPair, *big.Int](
+			t,
+			func(ctx context.Context, _ chan<- providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int]) {
+				close(started)
+				<-ctx.Done()
+				time.Sleep(500 * time.Millisecond)
+				drained.Store(true)
+			},
+		)
+
+		provider, err := base.NewProvider(
+			base.WithName[connecttypes.CurrencyPair, *big.Int](apiCfg.Name),
+			base.WithAPIQueryHandler[connecttypes.CurrencyPair, *big.Int](handler),
+			base.WithAPIConfig[connecttypes.CurrencyPair, *big.Int](apiCfg),
+			base.WithLogger[connecttypes.CurrencyPair, *big.Int](logger),
+			base.WithIDs[connecttypes.CurrencyPair, *big.Int](pairs),
+		)
+		require.NoError(t, err)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- provider.Start(context.Background())
+		}()
+		<-started
+
+		provider.Stop()
+		require.True(t, drained.Load())
+		require.False(t, provider.IsRunning())
+		require.Equal(t, context.Canceled, <-errCh)
+	})
+
 	t.Run("no error when running a WebSocket provider", func(t *testing.T) {
 		handler := testutils.CreateWebSocketQueryHandlerWithGetResponses[connecttypes.CurrencyPair, *big.Int](
 			t,
@@ -327,6 +396,106 @@ func TestStop(t *testing.T) {
 	})
 }
 
+func TestRefresh(t *testing.T) {
+	// The query handler blocks until it is stopped, so each query is due to a (re)start.
+	var queries atomic.Int32
+	handler := testutils.CreateAPIQueryHandlerWithResponseFn[connecttypes.CurrencyPair, *big.Int](
+		t,
+		func(ctx context.Context, _ chan<- providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int]) {
+			queries.Add(1)
+			<-ctx.Done()
+		},
+	)
+
+	provider, err := base.NewProvider(
+		base.WithName[connecttypes.CurrencyPair, *big.Int](apiCfg.Name),
+		base.WithAPIQueryHandler[connecttypes.CurrencyPair, *big.Int](handler),
+		base.WithAPIConfig[connecttypes.CurrencyPair, *big.Int](apiCfg),
+		base.WithLogger[connecttypes.CurrencyPair, *big.Int](logger),
+		base.WithIDs[connecttypes.CurrencyPair, *big.Int](pairs),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = provider.Start(ctx)
+	}()
+	require.Eventually(t, func() bool { return queries.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Refreshing the provider restarts the query handler immediately.
+	provider.Refresh()
+	require.Eventually(t, func() bool { return queries.Load() == 2 }, time.Second, 10*time.Millisecond)
+	require.True(t, provider.IsRunning())
+
+	provider.Stop()
+}
+
+func TestRefreshIDs(t *testing.T) {
+	// The query handler blocks until it is stopped, so each query is due to a (re)start.
+	var queries atomic.Int32
+	handler := apihandlermocks.NewQueryHandler[connecttypes.CurrencyPair, *big.Int](t)
+	handler.On("Query", mock.Anything, mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+		queries.Add(1)
+		<-args.Get(0).(context.Context).Done()
+	})
+
+	// A one-shot query resolves the refreshed ids.
+	refreshed := make(chan []connecttypes.CurrencyPair, 1)
+	handler.On("QueryOnce", mock.Anything, mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+		ids := args.Get(1).([]connecttypes.CurrencyPair)
+		resolved := make(map[connecttypes.CurrencyPair]providertypes.ResolvedResult[*big.Int])
+		for _, id := range ids {
+			resolved[id] = providertypes.NewResult(big.NewInt(100), time.Now())
+		}
+
+		args.Get(2).(chan<- providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int]) <- providertypes.NewGetResponse(
+			resolved,
+			nil,
+		)
+		refreshed <- ids
+	})
+
+	provider, err := base.NewProvider(
+		base.WithName[connecttypes.CurrencyPair, *big.Int](apiCfg.Name),
+		base.WithAPIQueryHandler[connecttypes.CurrencyPair, *big.Int](handler),
+		base.WithAPIConfig[connecttypes.CurrencyPair, *big.Int](apiCfg),
+		base.WithLogger[connecttypes.CurrencyPair, *big.Int](logger),
+		base.WithIDs[connecttypes.CurrencyPair, *big.Int](pairs),
+	)
+	require.NoError(t, err)
+
+	// Refreshing a provider that is not running is a no-op.
+	provider.RefreshIDs(pairs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = provider.Start(ctx)
+	}()
+	require.Eventually(t, func() bool { return queries.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Only the ids of the provider are fetched, and the provider's queries are not restarted.
+	unknown := connecttypes.NewCurrencyPair("UNKNOWN", "USD")
+	provider.RefreshIDs([]connecttypes.CurrencyPair{pairs[0], unknown})
+	select {
+	case ids := <-refreshed:
+		require.Equal(t, []connecttypes.CurrencyPair{pairs[0]}, ids)
+	case <-time.After(time.Second):
+		t.Fatal("ids were not refreshed")
+	}
+
+	require.Eventually(t, func() bool {
+		result, ok := provider.GetData()[pairs[0]]
+		return ok && result.Value.Int64() == 100
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(1), queries.Load())
+
+	provider.Stop()
+}
+
 func TestWebSocketProvider(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -826,6 +995,152 @@ func TestAPIProviderLoop(t *testing.T) {
 	}
 }
 
+func TestAPIProviderErrors(t *testing.T) {
+	// The first response fails for both pairs, the second resolves the first pair.
+	unresolved := map[connecttypes.CurrencyPair]providertypes.UnresolvedResult{
+		pairs[0]: {
+			ErrorWithCode: providertypes.NewErrorWithCode(apierrors.ErrRateLimit, providertypes.ErrorAPIGeneral),
+		},
+		pairs[1]: {
+			ErrorWithCode: providertypes.NewErrorWithCode(apierrors.ErrRateLimit, providertypes.ErrorAPIGeneral),
+		},
+	}
+	resolved := map[connecttypes.CurrencyPair]providertypes.ResolvedResult[*big.Int]{
+		pairs[0]: {
+			Value:     big.NewInt(100),
+			Timestamp: respTime,
+		},
+	}
+
+	handler := testutils.CreateAPIQueryHandlerWithGetResponses[connecttypes.CurrencyPair, *big.Int](
+		t,
+		logger,
+		[]providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int]{
+			providertypes.NewGetResponse[connecttypes.CurrencyPair, *big.Int](nil, unresolved),
+			providertypes.NewGetResponse[connecttypes.CurrencyPair, *big.Int](resolved, nil),
+		},
+		200*time.Millisecond,
+	)
+
+	provider, err := base.NewProvider[connecttypes.CurrencyPair, *big.Int](
+		base.WithName[connecttypes.CurrencyPair, *big.Int](apiCfg.Name),
+		base.WithAPIQueryHandler[connecttypes.CurrencyPair, *big.Int](handler),
+		base.WithAPIConfig[connecttypes.CurrencyPair, *big.Int](apiCfg),
+		base.WithLogger[connecttypes.CurrencyPair, *big.Int](logger),
+		base.WithIDs[connecttypes.CurrencyPair, *big.Int](pairs),
+	)
+	require.NoError(t, err)
+	require.Empty(t, provider.GetErrors())
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiCfg.Interval*2)
+	defer cancel()
+
+	err = provider.Start(ctx)
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	errs := provider.GetErrors()
+	require.Len(t, errs, 1)
+	require.Contains(t, errs, pairs[1])
+	require.Equal(t, providertypes.ErrorAPIGeneral, errs[pairs[1]].Code())
+
+	require.Contains(t, provider.GetData(), pairs[0])
+}
+
+func TestAPIProviderHealth(t *testing.T) {
+	resolved := map[connecttypes.CurrencyPair]providertypes.ResolvedResult[*big.Int]{
+		pairs[0]: {
+			Value:     big.NewInt(100),
+			Timestamp: respTime,
+		},
+	}
+	unresolved := map[connecttypes.CurrencyPair]providertypes.UnresolvedResult{
+		pairs[0]: {
+			ErrorWithCode: providertypes.NewErrorWithCode(apierrors.ErrRateLimit, providertypes.ErrorAPIGeneral),
+		},
+	}
+
+	// The first query succeeds once and then fails until the provider is quarantined, the first
+	// probe fails, and the second probe succeeds.
+	var queries atomic.Int32
+	handler := testutils.CreateAPIQueryHandlerWithResponseFn[connecttypes.CurrencyPair, *big.Int](
+		t,
+		func(ctx context.Context, responseCh chan<- providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int]) {
+			responses := []providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int]{
+				providertypes.NewGetResponse(resolved, nil),
+			}
+			switch queries.Add(1) {
+			case 1:
+				for i := 0; i < 10; i++ {
+					responses = append(responses, providertypes.NewGetResponse[connecttypes.CurrencyPair, *big.Int](nil, unresolved))
+				}
+			case 2:
+				responses = []providertypes.GetResponse[connecttypes.CurrencyPair, *big.Int]{
+					providertypes.NewGetResponse[connecttypes.CurrencyPair, *big.Int](nil, unresolved),
+				}
+			}
+
+			for _, resp := range responses {
+				select {
+				case <-ctx.Done():
+					return
+				case responseCh <- resp:
+				}
+			}
+
+			<-ctx.Done()
+		},
+	)
+
+	cfg := apiCfg
+	cfg.Health = config.HealthConfig{
+		FailureThreshold: 3,
+		Cooldown:         200 * time.Millisecond,
+	}
+
+	m := metricmocks.NewProviderMetrics(t)
+	m.On("AddProviderResponseByID", cfg.Name, mock.Anything, mock.Anything, mock.Anything, providertypes.API).Maybe()
+	m.On("AddProviderResponse", cfg.Name, mock.Anything, mock.Anything, providertypes.API).Maybe()
+	m.On("LastUpdated", cfg.Name, mock.Anything, providertypes.API).Maybe()
+	m.On("SetHealthStatus", cfg.Name, providertypes.Quarantined, providertypes.API).Twice()
+	m.On("SetHealthStatus", cfg.Name, providertypes.Probing, providertypes.API).Twice()
+	m.On("SetHealthStatus", cfg.Name, providertypes.Healthy, providertypes.API).Once()
+
+	provider, err := base.NewProvider[connecttypes.CurrencyPair, *big.Int](
+		base.WithName[connecttypes.CurrencyPair, *big.Int](cfg.Name),
+		base.WithAPIQueryHandler[connecttypes.CurrencyPair, *big.Int](handler),
+		base.WithAPIConfig[connecttypes.CurrencyPair, *big.Int](cfg),
+		base.WithLogger[connecttypes.CurrencyPair, *big.Int](logger),
+		base.WithIDs[connecttypes.CurrencyPair, *big.Int]([]connecttypes.CurrencyPair{pairs[0]}),
+		base.WithMetrics[connecttypes.CurrencyPair, *big.Int](m),
+	)
+	require.NoError(t, err)
+	require.Equal(t, providertypes.Healthy, provider.Health())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- provider.Start(ctx)
+	}()
+
+	// The provider is quarantined after consecutive failures and its data is not reported.
+	require.Eventually(t, func() bool {
+		return provider.Health() == providertypes.Quarantined
+	}, time.Second, 10*time.Millisecond)
+	require.Empty(t, provider.GetData())
+
+	// The provider is healthy again once the second probe succeeds.
+	require.Eventually(t, func() bool {
+		return provider.Health() == providertypes.Healthy
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Contains(t, provider.GetData(), pairs[0])
+	require.Equal(t, int32(3), queries.Load())
+
+	cancel()
+	require.Equal(t, context.Canceled, <-errCh)
+}
+
 func TestMetrics(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -3,6 +3,7 @@ package base
 import (
 	"context"
 	"fmt"
+	"time"
 
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
 )
@@ -24,13 +25,24 @@ func (p *Provider[K, V]) createResponseCh() error {
 	return nil
 }
 
-// setMainCtx sets the main context for the provider.
-func (p *Provider[K, V]) setMainCtx(ctx context.Context) (context.Context, context.CancelFunc) {
+// setMainCtx sets the main context for the provider. This also returns the channel that must be
+// closed once the main loop has exited. ErrAlreadyRunning is returned if the main loop of a
+// previous call has not exited yet, so that the provider is never run by two main loops at once.
+func (p *Provider[K, V]) setMainCtx(ctx context.Context) (context.Context, context.CancelFunc, chan struct{}, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.stopped != nil {
+		select {
+		case <-p.stopped:
+		default:
+			return nil, nil, nil, ErrAlreadyRunning
+		}
+	}
+
 	p.mainCtx, p.cancelMainFn = context.WithCancel(ctx)
-	return p.mainCtx, p.cancelMainFn
+	p.stopped = make(chan struct{})
+	return p.mainCtx, p.cancelMainFn, p.stopped, nil
 }
 
 // getMainCtx returns the main context for the provider.
@@ -41,6 +53,14 @@ func (p *Provider[K, V]) getMainCtx() (context.Context, context.CancelFunc) {
 	return p.mainCtx, p.cancelMainFn
 }
 
+// getStopped returns the channel that is closed once the main loop of the provider has exited.
+func (p *Provider[K, V]) getStopped() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.stopped
+}
+
 // setFetchCtx sets the fetch context for the provider.
 func (p *Provider[K, V]) setFetchCtx(ctx context.Context) (context.Context, context.CancelFunc) {
 	p.mu.Lock()
@@ -57,3 +77,14 @@ func (p *Provider[K, V]) getFetchCtx() (context.Context, context.CancelFunc) {
 
 	return p.fetchCtx, p.cancelFetchFn
 }
+
+// sleep blocks for the given duration or until the context is cancelled, in which case the
+// context's error is returned.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/providers/base/debug"
 	"github.com/skip-mev/connect/v2/providers/base/websocket/errors"
 	"github.com/skip-mev/connect/v2/providers/base/websocket/metrics"
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
@@ -303,6 +304,19 @@ func (h *WebSocketQueryHandlerImpl[K, V]) recv(ctx context.Context, responseCh c
 				continue
 			}
 
+			// Record the raw message for the tickers it carried data of if the raw responses of
+			// the providers are recorded for debugging.
+			if recorder := debug.Default(); recorder != nil {
+				tickers := make([]string, 0, len(response.Resolved)+len(response.UnResolved))
+				for id := range response.Resolved {
+					tickers = append(tickers, id.String())
+				}
+				for id := range response.UnResolved {
+					tickers = append(tickers, id.String())
+				}
+				recorder.Record(h.config.Name, tickers, h.config.Endpoints[0].URL, 0, message)
+			}
+
 			// Immediately send the response to the response channel. Even if this is
 			// empty, it will be handled by the provider. Note that if the context has been
 			// cancelled, we should not send the response to the channel. Otherwise, we risk
@@ -6,21 +6,49 @@ import (
 	"net/http"
 	"strings"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+	"github.com/skip-mev/connect/v2/providers/apis/band"
 	"github.com/skip-mev/connect/v2/providers/apis/binance"
 	"github.com/skip-mev/connect/v2/providers/apis/bitstamp"
+	bybitapi "github.com/skip-mev/connect/v2/providers/apis/bybit"
 	coinbaseapi "github.com/skip-mev/connect/v2/providers/apis/coinbase"
 	"github.com/skip-mev/connect/v2/providers/apis/coingecko"
 	"github.com/skip-mev/connect/v2/providers/apis/coinmarketcap"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/api3"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/aptos"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/chainlink"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/cosmwasm"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/curve"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc4626"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/evmcall"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/exchangerate"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/lido"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/osmosis"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/raydium"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/rocketpool"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/solana"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/subgraph"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/sui"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/tron"
 	"github.com/skip-mev/connect/v2/providers/apis/defi/uniswapv3"
+	"github.com/skip-mev/connect/v2/providers/apis/dia"
+	"github.com/skip-mev/connect/v2/providers/apis/forex"
 	"github.com/skip-mev/connect/v2/providers/apis/geckoterminal"
 	"github.com/skip-mev/connect/v2/providers/apis/kraken"
+	"github.com/skip-mev/connect/v2/providers/apis/okx"
+	"github.com/skip-mev/connect/v2/providers/apis/orderbook"
+	"github.com/skip-mev/connect/v2/providers/apis/polygon"
 	"github.com/skip-mev/connect/v2/providers/apis/polymarket"
+	"github.com/skip-mev/connect/v2/providers/apis/pyth"
+	"github.com/skip-mev/connect/v2/providers/apis/redstone"
+	"github.com/skip-mev/connect/v2/providers/apis/rest"
 	apihandlers "github.com/skip-mev/connect/v2/providers/base/api/handlers"
 	"github.com/skip-mev/connect/v2/providers/base/api/metrics"
 	"github.com/skip-mev/connect/v2/providers/static"
@@ -43,28 +71,37 @@ func APIQueryHandlerFactory(
 	}
 
 	// Create the underlying client that will be used to fetch data from the API. This client
-	// will limit the number of concurrent connections and uses the configured timeout to
-	// ensure requests do not hang.
+	// will limit the number of concurrent connections and requests in flight, and uses the
+	// configured timeout to ensure requests do not hang.
 	client := &http.Client{
-		Transport: &http.Transport{
-			MaxConnsPerHost: cfg.API.MaxQueries,
-			Proxy:           http.ProxyFromEnvironment,
-		},
+		Transport: otelhttp.NewTransport(connecthttp.NewRoundTripperWithLimit(
+			&http.Transport{
+				MaxConnsPerHost: cfg.API.MaxQueries,
+				Proxy:           http.ProxyFromEnvironment,
+			},
+			connecthttp.SharedInFlightLimiter(cfg.Name, cfg.API.MaxInFlight),
+		)),
 		Timeout: cfg.API.Timeout,
 	}
 
 	var (
 		apiPriceFetcher types.PriceAPIFetcher
 		apiDataHandler  types.PriceAPIDataHandler
-		headers         = make(map[string]string)
 	)
 
-	// If the provider has an API key, add it to the headers.
+	// If the provider has an API key, add it to the headers of each request. The key is looked up
+	// on each request so that it can be rotated without restarting the provider.
 	if len(cfg.API.Endpoints) == 1 && cfg.API.Endpoints[0].Authentication.Enabled() {
-		headers[cfg.API.Endpoints[0].Authentication.APIKeyHeader] = cfg.API.Endpoints[0].Authentication.APIKey
+		auth := cfg.API.Endpoints[0].Authentication
+		apiKey, err := secrets.APIKey(auth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read api key of %s: %w", cfg.Name, err)
+		}
+
+		client.Transport = connecthttp.NewRoundTripperWithAuthentication(client.Transport, auth.APIKeyHeader, apiKey.Value)
 	}
 
-	requestHandler, err := apihandlers.NewRequestHandlerImpl(client, apihandlers.WithHTTPHeaders(headers))
+	requestHandler, err := apihandlers.NewRequestHandlerImpl(client)
 	if err != nil {
 		return nil, err
 	}
@@ -74,18 +111,56 @@ func APIQueryHandlerFactory(
 		apiDataHandler, err = binance.NewAPIHandler(cfg.API)
 	case providerName == bitstamp.Name:
 		apiDataHandler, err = bitstamp.NewAPIHandler(cfg.API)
+	case providerName == bybitapi.Name:
+		apiDataHandler, err = bybitapi.NewAPIHandler(cfg.API)
 	case providerName == coinbaseapi.Name:
 		apiDataHandler, err = coinbaseapi.NewAPIHandler(cfg.API)
 	case providerName == coingecko.Name:
 		apiDataHandler, err = coingecko.NewAPIHandler(cfg.API)
+		if err != nil {
+			return nil, err
+		}
+
+		// CoinGecko enforces low rate limits, so requests are limited client-side based on
+		// the tier of the configured API key.
+		requestHandler, err = apihandlers.NewRequestHandlerImpl(
+			client,
+			apihandlers.WithRateLimiter(coingecko.NewRateLimiter(cfg.API.Endpoints[0])),
+		)
 	case providerName == coinmarketcap.Name:
-		apiDataHandler, err = coinmarketcap.NewAPIHandler(cfg.API)
+		apiDataHandler, err = coinmarketcap.NewAPIHandler(cfg.API, metrics)
 	case providerName == geckoterminal.Name:
 		apiDataHandler, err = geckoterminal.NewAPIHandler(cfg.API)
 	case providerName == kraken.Name:
 		apiDataHandler, err = kraken.NewAPIHandler(cfg.API)
+	case providerName == okx.Name:
+		apiDataHandler, err = okx.NewAPIHandler(cfg.API)
+	case strings.HasPrefix(providerName, cosmwasm.BaseName):
+		apiDataHandler, err = cosmwasm.NewAPIHandler(cfg.API)
 	case strings.HasPrefix(providerName, uniswapv3.BaseName):
 		apiPriceFetcher, err = uniswapv3.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, chainlink.BaseName):
+		apiPriceFetcher, err = chainlink.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, api3.BaseName):
+		apiPriceFetcher, err = api3.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, lido.BaseName):
+		apiPriceFetcher, err = lido.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, rocketpool.BaseName):
+		apiPriceFetcher, err = rocketpool.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, erc4626.BaseName):
+		apiPriceFetcher, err = erc4626.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, evmcall.BaseName):
+		apiPriceFetcher, err = evmcall.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, exchangerate.BaseName):
+		apiPriceFetcher, err = exchangerate.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, curve.BaseName):
+		apiPriceFetcher, err = curve.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, balancer.BaseName):
+		apiPriceFetcher, err = balancer.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case strings.HasPrefix(providerName, subgraph.BaseName):
+		apiPriceFetcher, err = subgraph.NewPriceFetcher(logger, metrics, cfg.API, client)
+	case strings.HasPrefix(providerName, rest.BaseName):
+		apiPriceFetcher, err = rest.NewPriceFetcher(logger, metrics, cfg.API, client)
 	case providerName == static.Name:
 		apiDataHandler = static.NewAPIHandler()
 		requestHandler = static.NewStaticMockClient()
@@ -94,10 +169,32 @@ func APIQueryHandlerFactory(
 		requestHandler = static.NewStaticMockClient()
 	case providerName == raydium.Name:
 		apiPriceFetcher, err = raydium.NewAPIPriceFetcher(logger, cfg.API, metrics)
+	case providerName == solana.Name:
+		apiPriceFetcher, err = solana.NewAPIPriceFetcher(logger, cfg.API, metrics)
+	case providerName == sui.Name:
+		apiPriceFetcher, err = sui.NewPriceFetcher(ctx, logger, metrics, cfg.API)
+	case providerName == aptos.Name:
+		apiPriceFetcher, err = aptos.NewPriceFetcher(logger, metrics, cfg.API)
+	case providerName == tron.Name:
+		apiPriceFetcher, err = tron.NewPriceFetcher(logger, metrics, cfg.API)
 	case providerName == osmosis.Name:
 		apiPriceFetcher, err = osmosis.NewAPIPriceFetcher(logger, cfg.API, metrics)
+	case orderbook.IsOrderBookProvider(providerName):
+		apiPriceFetcher, err = orderbook.NewPriceFetcher(logger, metrics, cfg.API, client)
+	case providerName == polygon.Name:
+		apiPriceFetcher, err = polygon.NewPriceFetcher(logger, metrics, cfg.API, client)
 	case providerName == polymarket.Name:
 		apiDataHandler, err = polymarket.NewAPIHandler(cfg.API)
+	case providerName == pyth.Name:
+		apiDataHandler, err = pyth.NewAPIHandler(cfg.API)
+	case providerName == redstone.Name:
+		apiDataHandler, err = redstone.NewAPIHandler(cfg.API)
+	case providerName == band.Name:
+		apiDataHandler, err = band.NewAPIHandler(cfg.API)
+	case providerName == dia.Name:
+		apiDataHandler, err = dia.NewAPIHandler(cfg.API)
+	case providerName == forex.Name:
+		apiDataHandler, err = forex.NewAPIHandler(cfg.API)
 	default:
 		return nil, fmt.Errorf("unknown provider: %s", cfg.Name)
 	}
@@ -3,9 +3,11 @@ package oracle
 import (
 	"net/http"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
 	"github.com/skip-mev/connect/v2/providers/apis/dydx"
 	"github.com/skip-mev/connect/v2/providers/apis/marketmap"
 	"github.com/skip-mev/connect/v2/providers/base"
@@ -31,10 +33,13 @@ func MarketMapProviderFactory(
 	}
 
 	client := &http.Client{
-		Transport: &http.Transport{
-			MaxConnsPerHost: cfg.API.MaxQueries,
-			Proxy:           http.ProxyFromEnvironment,
-		},
+		Transport: otelhttp.NewTransport(connecthttp.NewRoundTripperWithLimit(
+			&http.Transport{
+				MaxConnsPerHost: cfg.API.MaxQueries,
+				Proxy:           http.ProxyFromEnvironment,
+			},
+			connecthttp.SharedInFlightLimiter(cfg.Name, cfg.API.MaxInFlight),
+		)),
 		Timeout: cfg.API.Timeout,
 	}
 
@@ -0,0 +1,79 @@
+package oracle
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/skip-mev/connect/v2/pkg/schema"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/api3"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/balancer"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/chainlink"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/curve"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/erc4626"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/evmcall"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/exchangerate"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/lido"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/rocketpool"
+	"github.com/skip-mev/connect/v2/providers/apis/defi/uniswapv3"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// EVMMetadataSchema returns a pointer to the zero value of the metadata config of the given EVM
+// provider, i.e. the type its fetcher unmarshals a ticker's Metadata_JSON into. It returns false if
+// the provider is not an EVM provider.
+func EVMMetadataSchema(providerName string) (any, bool) {
+	switch {
+	case strings.HasPrefix(providerName, uniswapv3.BaseName):
+		return &uniswapv3.PoolConfig{}, true
+	case strings.HasPrefix(providerName, chainlink.BaseName):
+		return &chainlink.FeedConfig{}, true
+	case strings.HasPrefix(providerName, api3.BaseName):
+		return &api3.FeedConfig{}, true
+	case strings.HasPrefix(providerName, lido.BaseName):
+		return &lido.FeedConfig{}, true
+	case strings.HasPrefix(providerName, rocketpool.BaseName):
+		return &rocketpool.FeedConfig{}, true
+	case strings.HasPrefix(providerName, erc4626.BaseName):
+		return &erc4626.FeedConfig{}, true
+	case strings.HasPrefix(providerName, evmcall.BaseName):
+		return &evmcall.CallConfig{}, true
+	case strings.HasPrefix(providerName, exchangerate.BaseName):
+		return &exchangerate.FeedConfig{}, true
+	case strings.HasPrefix(providerName, curve.BaseName):
+		return &curve.PoolConfig{}, true
+	case strings.HasPrefix(providerName, balancer.BaseName):
+		return &balancer.PoolConfig{}, true
+	default:
+		return nil, false
+	}
+}
+
+// ValidateEVMMetadata strictly validates the metadata of every EVM provider config in the market
+// map against the metadata config of its provider. The paths of the violations are relative to the
+// market map's JSON encoding, e.g. $.markets["ETH/USD"].provider_configs[0].metadata_JSON.address.
+func ValidateEVMMetadata(marketMap mmtypes.MarketMap) schema.Violations {
+	var violations schema.Violations
+	for _, ticker := range sortedTickers(marketMap) {
+		marketPath := schema.ChildPath(schema.ChildPath(schema.Root, "markets"), ticker)
+		for i, providerCfg := range marketMap.Markets[ticker].ProviderConfigs {
+			target, ok := EVMMetadataSchema(providerCfg.Name)
+			if !ok || providerCfg.Metadata_JSON == "" {
+				continue
+			}
+
+			path := schema.ChildPath(schema.IndexPath(schema.ChildPath(marketPath, "provider_configs"), i), "metadata_JSON")
+			violations = append(violations, schema.ValidateAt(path, []byte(providerCfg.Metadata_JSON), target)...)
+		}
+	}
+
+	return violations
+}
+
+func sortedTickers(marketMap mmtypes.MarketMap) []string {
+	tickers := make([]string, 0, len(marketMap.Markets))
+	for ticker := range marketMap.Markets {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+	return tickers
+}
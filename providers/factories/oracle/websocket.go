@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect/v2/oracle/config"
 	"github.com/skip-mev/connect/v2/oracle/types"
+	connecthttp "github.com/skip-mev/connect/v2/pkg/http"
 	apihandlers "github.com/skip-mev/connect/v2/providers/base/api/handlers"
 	wshandlers "github.com/skip-mev/connect/v2/providers/base/websocket/handlers"
 	wsmetrics "github.com/skip-mev/connect/v2/providers/base/websocket/metrics"
@@ -43,10 +45,13 @@ func WebSocketQueryHandlerFactory(
 	// Create the underlying client that can be utilized by websocket providers that need to
 	// interact with an API.
 	client := &http.Client{
-		Transport: &http.Transport{
-			MaxConnsPerHost: cfg.API.MaxQueries,
-			Proxy:           http.ProxyFromEnvironment,
-		},
+		Transport: otelhttp.NewTransport(connecthttp.NewRoundTripperWithLimit(
+			&http.Transport{
+				MaxConnsPerHost: cfg.API.MaxQueries,
+				Proxy:           http.ProxyFromEnvironment,
+			},
+			connecthttp.SharedInFlightLimiter(cfg.Name, cfg.API.MaxInFlight),
+		)),
 		Timeout: cfg.API.Timeout,
 	}
 
@@ -63,7 +68,7 @@ func WebSocketQueryHandlerFactory(
 		wsDataHandler, err = bitfinex.NewWebSocketDataHandler(logger, cfg.WebSocket)
 	case bitstamp.Name:
 		wsDataHandler, err = bitstamp.NewWebSocketDataHandler(logger, cfg.WebSocket)
-	case bybit.Name:
+	case bybit.Name, bybit.LinearName:
 		wsDataHandler, err = bybit.NewWebSocketDataHandler(logger, cfg.WebSocket)
 	case coinbasews.Name:
 		wsDataHandler, err = coinbasews.NewWebSocketDataHandler(logger, cfg.WebSocket)
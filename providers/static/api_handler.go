@@ -1,8 +1,11 @@
 package static
 
 import (
+	"fmt"
 	"math/big"
+	"math/rand"
 	"net/http"
+	"sync"
 	"time"
 
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
@@ -17,14 +20,43 @@ const (
 	Name = "static-mock-provider"
 )
 
-// MockAPIHandler implements a mock API handler that returns static data.
-type MockAPIHandler struct{}
+// MockAPIHandler implements a mock API handler that returns static or scripted data. The
+// scripts of ramps and steps are relative to the first time each ticker is queried, and random
+// walks advance on every query.
+type MockAPIHandler struct {
+	mtx sync.Mutex
+
+	// now returns the current time.
+	now func() time.Time
+
+	// started is the time each ticker was first queried.
+	started map[types.ProviderTicker]time.Time
+
+	// walks are the random walks of each ticker using the random walk script.
+	walks map[types.ProviderTicker]*randomWalk
+}
+
+// randomWalk is the state of a single random walk.
+type randomWalk struct {
+	price float64
+	rng   *rand.Rand
+}
 
 // NewAPIHandler returns a new MockAPIHandler. This constructs a new static mock provider from
 // the config. Notice this method expects the market configuration map to the offchain ticker
 // to the desired price.
 func NewAPIHandler() types.PriceAPIDataHandler {
-	return &MockAPIHandler{}
+	return NewAPIHandlerWithClock(time.Now)
+}
+
+// NewAPIHandlerWithClock returns a new MockAPIHandler that uses the given function to determine
+// the current time. This is useful for testing scripted prices.
+func NewAPIHandlerWithClock(now func() time.Time) *MockAPIHandler {
+	return &MockAPIHandler{
+		now:     now,
+		started: make(map[types.ProviderTicker]time.Time),
+		walks:   make(map[types.ProviderTicker]*randomWalk),
+	}
 }
 
 // CreateURL is a no-op.
@@ -32,33 +64,84 @@ func (s *MockAPIHandler) CreateURL(_ []types.ProviderTicker) (string, error) {
 	return "static-url", nil
 }
 
-// ParseResponse is a no-op. This simply returns the price of the tickers configured,
-// timestamped with the current time.
+// ParseResponse is a no-op. This simply returns the price of the tickers configured, or the
+// current price of their script, timestamped with the current time.
 func (s *MockAPIHandler) ParseResponse(
 	tickers []types.ProviderTicker,
 	_ *http.Response,
 ) types.PriceResponse {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
 	var (
 		resolved   = make(types.ResolvedPrices)
 		unresolved = make(types.UnResolvedPrices)
+		now        = s.now().UTC()
 	)
 
 	for _, ticker := range tickers {
-		var metaData MetaData
-		if err := metaData.FromJSON(ticker.GetJSON()); err == nil {
-			resolved[ticker] = types.NewPriceResult(
-				big.NewFloat(metaData.Price),
-				time.Now().UTC(),
-			)
-		} else {
+		price, err := s.price(ticker, now)
+		if err != nil {
 			unresolved[ticker] = providertypes.UnresolvedResult{
 				ErrorWithCode: providertypes.NewErrorWithCode(
 					err,
 					providertypes.ErrorFailedToParsePrice,
 				),
 			}
+
+			continue
 		}
+
+		resolved[ticker] = types.NewPriceResult(big.NewFloat(price), now)
 	}
 
 	return types.NewPriceResponse(resolved, unresolved)
 }
+
+// price returns the current price of the given ticker according to its metadata.
+func (s *MockAPIHandler) price(ticker types.ProviderTicker, now time.Time) (float64, error) {
+	var metaData MetaData
+	if err := metaData.FromJSON(ticker.GetJSON()); err != nil {
+		return 0, err
+	}
+
+	if err := metaData.ValidateBasic(); err != nil {
+		return 0, fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	started, ok := s.started[ticker]
+	if !ok {
+		started = now
+		s.started[ticker] = now
+	}
+	elapsed := now.Sub(started)
+
+	switch metaData.Script {
+	case ScriptRamp:
+		price := metaData.Price + metaData.Rate*elapsed.Seconds()
+		if price <= 0 {
+			return 0, fmt.Errorf("ramp price %f is not positive", price)
+		}
+
+		return price, nil
+	case ScriptStep:
+		step := int(elapsed / metaData.Period)
+		return metaData.Prices[step%len(metaData.Prices)], nil
+	case ScriptRandomWalk:
+		walk, ok := s.walks[ticker]
+		if !ok {
+			walk = &randomWalk{
+				price: metaData.Price,
+				rng:   rand.New(rand.NewSource(metaData.Seed)), //nolint:gosec
+			}
+			s.walks[ticker] = walk
+
+			return walk.price, nil
+		}
+
+		walk.price *= 1 + metaData.Volatility*(2*walk.rng.Float64()-1)
+		return walk.price, nil
+	default:
+		return metaData.Price, nil
+	}
+}
@@ -0,0 +1,190 @@
+package static_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/oracle/types"
+	"github.com/skip-mev/connect/v2/providers/static"
+)
+
+// clock is a manually advanced clock.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newTicker(t *testing.T, metaData static.MetaData) types.ProviderTicker {
+	t.Helper()
+	return types.NewProviderTicker("FOO/BAR", metaData.MustToJSON())
+}
+
+func price(t *testing.T, h types.PriceAPIDataHandler, ticker types.ProviderTicker) float64 {
+	t.Helper()
+
+	resp := h.ParseResponse([]types.ProviderTicker{ticker}, nil)
+	require.Contains(t, resp.Resolved, ticker)
+
+	f, _ := resp.Resolved[ticker].Value.Float64()
+	return f
+}
+
+func TestParseResponse(t *testing.T) {
+	c := &clock{now: time.Unix(1700000000, 0)}
+
+	t.Run("fixed price", func(t *testing.T) {
+		h := static.NewAPIHandlerWithClock(c.Now)
+		ticker := types.NewProviderTicker("FOO/BAR", `{"price": 100}`)
+
+		require.Equal(t, float64(100), price(t, h, ticker))
+		c.now = c.now.Add(time.Hour)
+		require.Equal(t, float64(100), price(t, h, ticker))
+
+		resp := h.ParseResponse([]types.ProviderTicker{ticker}, nil)
+		require.Equal(t, c.now.UTC(), resp.Resolved[ticker].Timestamp)
+	})
+
+	t.Run("ramp", func(t *testing.T) {
+		h := static.NewAPIHandlerWithClock(c.Now)
+		ticker := newTicker(t, static.MetaData{Script: static.ScriptRamp, Price: 100, Rate: -0.5})
+
+		require.Equal(t, float64(100), price(t, h, ticker))
+		c.now = c.now.Add(10 * time.Second)
+		require.Equal(t, float64(95), price(t, h, ticker))
+		c.now = c.now.Add(90 * time.Second)
+		require.Equal(t, float64(50), price(t, h, ticker))
+
+		// The ramp no longer resolves once its price is no longer positive.
+		c.now = c.now.Add(100 * time.Second)
+		resp := h.ParseResponse([]types.ProviderTicker{ticker}, nil)
+		require.Contains(t, resp.UnResolved, ticker)
+	})
+
+	t.Run("step", func(t *testing.T) {
+		h := static.NewAPIHandlerWithClock(c.Now)
+		ticker := newTicker(t, static.MetaData{
+			Script: static.ScriptStep,
+			Prices: []float64{100, 150, 80},
+			Period: time.Minute,
+		})
+
+		expected := []float64{100, 100, 150, 150, 80, 80, 100}
+		for _, e := range expected {
+			require.Equal(t, e, price(t, h, ticker))
+			c.now = c.now.Add(30 * time.Second)
+		}
+	})
+
+	t.Run("random walk", func(t *testing.T) {
+		metaData := static.MetaData{Script: static.ScriptRandomWalk, Price: 100, Volatility: 0.01, Seed: 42}
+
+		walk := func() []float64 {
+			h := static.NewAPIHandlerWithClock(c.Now)
+			ticker := newTicker(t, metaData)
+
+			prices := make([]float64, 100)
+			for i := range prices {
+				prices[i] = price(t, h, ticker)
+			}
+
+			return prices
+		}
+
+		prices := walk()
+		require.Equal(t, float64(100), prices[0])
+		for i := 1; i < len(prices); i++ {
+			require.InEpsilon(t, prices[i-1], prices[i], 0.0101)
+		}
+
+		// The same seed returns the same sequence of prices.
+		require.Equal(t, prices, walk())
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		h := static.NewAPIHandlerWithClock(c.Now)
+		tickers := []types.ProviderTicker{
+			types.NewProviderTicker("FOO/BAR", `{"price":`),
+			newTicker(t, static.MetaData{Script: "sine", Price: 100}),
+			newTicker(t, static.MetaData{Script: static.ScriptStep, Prices: []float64{100}}),
+		}
+
+		resp := h.ParseResponse(tickers, nil)
+		require.Empty(t, resp.Resolved)
+		require.Len(t, resp.UnResolved, len(tickers))
+	})
+}
+
+func TestMetaDataValidateBasic(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metaData static.MetaData
+		err      bool
+	}{
+		{
+			name:     "fixed price",
+			metaData: static.MetaData{Price: 1},
+			err:      false,
+		},
+		{
+			name:     "non-positive fixed price",
+			metaData: static.MetaData{Price: 0},
+			err:      true,
+		},
+		{
+			name:     "ramp",
+			metaData: static.MetaData{Script: static.ScriptRamp, Price: 1, Rate: 0.1},
+			err:      false,
+		},
+		{
+			name:     "step",
+			metaData: static.MetaData{Script: static.ScriptStep, Prices: []float64{1, 2}, Period: time.Second},
+			err:      false,
+		},
+		{
+			name:     "step without prices",
+			metaData: static.MetaData{Script: static.ScriptStep, Period: time.Second},
+			err:      true,
+		},
+		{
+			name:     "step with non-positive price",
+			metaData: static.MetaData{Script: static.ScriptStep, Prices: []float64{1, 0}, Period: time.Second},
+			err:      true,
+		},
+		{
+			name:     "step without period",
+			metaData: static.MetaData{Script: static.ScriptStep, Prices: []float64{1}},
+			err:      true,
+		},
+		{
+			name:     "random walk",
+			metaData: static.MetaData{Script: static.ScriptRandomWalk, Price: 1, Volatility: 0.05},
+			err:      false,
+		},
+		{
+			name:     "random walk with volatility out of range",
+			metaData: static.MetaData{Script: static.ScriptRandomWalk, Price: 1, Volatility: 1},
+			err:      true,
+		},
+		{
+			name:     "unknown script",
+			metaData: static.MetaData{Script: "sine", Price: 1},
+			err:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.metaData.ValidateBasic()
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
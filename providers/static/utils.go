@@ -3,11 +3,66 @@ package static
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+)
+
+const (
+	// ScriptFixed always returns the configured price. This is the default script.
+	ScriptFixed = "fixed"
+	// ScriptRamp changes the price linearly at the configured rate per second, starting from the
+	// configured price.
+	ScriptRamp = "ramp"
+	// ScriptStep cycles through the configured prices, holding each price for the configured
+	// period.
+	ScriptStep = "step"
+	// ScriptRandomWalk moves the price by a random fraction of up to the configured volatility on
+	// every query, starting from the configured price.
+	ScriptRandomWalk = "random_walk"
 )
 
+// DefaultAPIConfig is the default configuration for the static provider. The endpoint is never
+// queried.
+var DefaultAPIConfig = config.APIConfig{
+	Name:             Name,
+	Atomic:           true,
+	Enabled:          true,
+	MaxQueries:       1,
+	Timeout:          500 * time.Millisecond,
+	Interval:         500 * time.Millisecond,
+	ReconnectTimeout: 500 * time.Millisecond,
+	Endpoints:        []config.Endpoint{{URL: Name}},
+}
+
 // MetaData is the per-ticker specific metadata that is used to configure the static provider.
+// Tickers with only a price always return that price. Setting a script instead returns a
+// scripted sequence of prices, which is useful to exercise the oracle end-to-end in testnets and
+// CI without querying external APIs.
 type MetaData struct {
+	// Price is the price of a fixed ticker, or the initial price of a ramp or random walk.
 	Price float64 `json:"price"`
+
+	// Script is the script used to generate the prices of the ticker. Must be one of fixed,
+	// ramp, step or random_walk. If unset, the price is fixed.
+	Script string `json:"script,omitempty"`
+
+	// Rate is the change in price per second of a ramp.
+	Rate float64 `json:"rate,omitempty"`
+
+	// Prices are the prices a step cycles through.
+	Prices []float64 `json:"prices,omitempty"`
+
+	// Period is the duration each price of a step is held for.
+	Period time.Duration `json:"period,omitempty"`
+
+	// Volatility is the maximum fraction, in (0, 1), by which a random walk moves the price on
+	// each query.
+	Volatility float64 `json:"volatility,omitempty"`
+
+	// Seed seeds the random walk, such that the same sequence of prices is returned on every
+	// run.
+	Seed int64 `json:"seed,omitempty"`
 }
 
 // FromJSON unmarshals the JSON data into a MetaData struct.
@@ -24,3 +79,39 @@ func (m *MetaData) MustToJSON() string {
 	}
 	return string(bz)
 }
+
+// ValidateBasic performs basic validation of the metadata.
+func (m *MetaData) ValidateBasic() error {
+	switch m.Script {
+	case "", ScriptFixed, ScriptRamp:
+		if m.Price <= 0 {
+			return fmt.Errorf("price must be positive")
+		}
+	case ScriptStep:
+		if len(m.Prices) == 0 {
+			return fmt.Errorf("step script requires at least one price")
+		}
+
+		for _, price := range m.Prices {
+			if price <= 0 {
+				return fmt.Errorf("step prices must be positive")
+			}
+		}
+
+		if m.Period <= 0 {
+			return fmt.Errorf("step period must be positive")
+		}
+	case ScriptRandomWalk:
+		if m.Price <= 0 {
+			return fmt.Errorf("price must be positive")
+		}
+
+		if m.Volatility <= 0 || m.Volatility >= 1 {
+			return fmt.Errorf("volatility must be in (0, 1)")
+		}
+	default:
+		return fmt.Errorf("unknown script: %s", m.Script)
+	}
+
+	return nil
+}
@@ -1,7 +1,10 @@
 package types
 
 import (
+	"context"
 	"errors"
+	"net"
+	"net/http"
 )
 
 // ErrorCode is a type alias for an int error code.
@@ -26,8 +29,80 @@ const (
 	ErrorGRPCGeneral            ErrorCode = 15
 	ErrorNoExistingPrice        ErrorCode = 16
 	ErrorTickerMetadataNotFound ErrorCode = 17
+	ErrorTimeout                ErrorCode = 18
+	ErrorContractReverted       ErrorCode = 19
+	ErrorStalePrice             ErrorCode = 20
+	ErrorInvalidConfig          ErrorCode = 21
+	ErrorSequencerUnavailable   ErrorCode = 22
 )
 
+// ErrorKind is the kind of failure that an ErrorCode represents. Error kinds group error codes
+// such that callers (i.e. the retry logic and metrics) can react to a failure based on its cause
+// rather than its specific code.
+type ErrorKind string
+
+const (
+	// ErrorKindNone is the kind of a successful response.
+	ErrorKindNone ErrorKind = "none"
+	// ErrorKindRateLimited indicates that the data source rate limited the request.
+	ErrorKindRateLimited ErrorKind = "rate_limited"
+	// ErrorKindTimeout indicates that the request did not complete in time.
+	ErrorKindTimeout ErrorKind = "timeout"
+	// ErrorKindBadResponse indicates that the data source returned an error or a response that
+	// could not be parsed.
+	ErrorKindBadResponse ErrorKind = "bad_response"
+	// ErrorKindContractReverted indicates that an on-chain call reverted.
+	ErrorKindContractReverted ErrorKind = "contract_reverted"
+	// ErrorKindStalePrice indicates that the data source returned a price that is too old, or that
+	// cannot be trusted to be current (i.e. while the sequencer of an L2 chain is down).
+	ErrorKindStalePrice ErrorKind = "stale_price"
+	// ErrorKindConfig indicates that the provider or ticker is misconfigured.
+	ErrorKindConfig ErrorKind = "config_error"
+	// ErrorKindUnknown indicates that the cause of the failure is unknown.
+	ErrorKindUnknown ErrorKind = "unknown"
+)
+
+// Retryable returns true if a request that failed with the given kind of error may succeed if
+// it is retried. Misconfigurations, reverted calls and stale prices are not resolved by retrying.
+func (k ErrorKind) Retryable() bool {
+	switch k {
+	case ErrorKindConfig, ErrorKindContractReverted, ErrorKindStalePrice:
+		return false
+	default:
+		return true
+	}
+}
+
+// Kind returns the kind of failure that the ErrorCode represents. Error codes of HTTP status codes
+// are classified by their status.
+func (e ErrorCode) Kind() ErrorKind {
+	switch e {
+	case OK:
+		return ErrorKindNone
+	case ErrorRateLimitExceeded, http.StatusTooManyRequests:
+		return ErrorKindRateLimited
+	case ErrorTimeout, http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return ErrorKindTimeout
+	case ErrorContractReverted:
+		return ErrorKindContractReverted
+	case ErrorStalePrice, ErrorSequencerUnavailable:
+		return ErrorKindStalePrice
+	case ErrorUnknownPair, ErrorUnableToCreateURL, ErrorInvalidAPIChains, ErrorInvalidChainID,
+		ErrorTickerMetadataNotFound, ErrorInvalidConfig,
+		http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return ErrorKindConfig
+	case ErrorNoResponse, ErrorInvalidResponse, ErrorFailedToParsePrice, ErrorInvalidWebSocketTopic,
+		ErrorFailedToDecode, ErrorNoExistingPrice:
+		return ErrorKindBadResponse
+	}
+
+	if e >= 400 && e < 600 {
+		return ErrorKindBadResponse
+	}
+
+	return ErrorKindUnknown
+}
+
 // Error returns the error representation of the ErrorCode.
 func (e ErrorCode) Error() error {
 	switch e {
@@ -65,6 +140,16 @@ func (e ErrorCode) Error() error {
 		return errors.New("no existing price")
 	case ErrorTickerMetadataNotFound:
 		return errors.New("ticker metadata not found")
+	case ErrorTimeout:
+		return errors.New("request timed out")
+	case ErrorContractReverted:
+		return errors.New("contract call reverted")
+	case ErrorStalePrice:
+		return errors.New("stale price")
+	case ErrorInvalidConfig:
+		return errors.New("invalid config")
+	case ErrorSequencerUnavailable:
+		return errors.New("sequencer unavailable")
 	case ErrorUnknown:
 		fallthrough
 	default:
@@ -87,9 +172,40 @@ func (ec ErrorWithCode) Code() ErrorCode {
 	return ec.code
 }
 
+// Kind returns the kind of the internal ErrorCode.
+func (ec ErrorWithCode) Kind() ErrorKind {
+	return ec.code.Kind()
+}
+
+// Unwrap returns the internal error.
+func (ec ErrorWithCode) Unwrap() error {
+	return ec.internalErr
+}
+
 func NewErrorWithCode(err error, ec ErrorCode) ErrorWithCode {
 	return ErrorWithCode{
 		code:        ec,
 		internalErr: err,
 	}
 }
+
+// ErrorCodeFromError returns the error code of the given error. If the error wraps an
+// ErrorWithCode, its code is returned. Errors caused by an exceeded deadline or a network timeout
+// return ErrorTimeout. Otherwise, the fallback error code is returned.
+func ErrorCodeFromError(err error, fallback ErrorCode) ErrorCode {
+	if err == nil {
+		return OK
+	}
+
+	var ec ErrorWithCode
+	if errors.As(err, &ec) {
+		return ec.Code()
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorTimeout
+	}
+
+	return fallback
+}
@@ -0,0 +1,92 @@
+package types_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect/v2/providers/types"
+)
+
+func TestErrorCodeKind(t *testing.T) {
+	testCases := []struct {
+		code      types.ErrorCode
+		kind      types.ErrorKind
+		retryable bool
+	}{
+		{types.OK, types.ErrorKindNone, true},
+		{types.ErrorRateLimitExceeded, types.ErrorKindRateLimited, true},
+		{http.StatusTooManyRequests, types.ErrorKindRateLimited, true},
+		{types.ErrorTimeout, types.ErrorKindTimeout, true},
+		{http.StatusGatewayTimeout, types.ErrorKindTimeout, true},
+		{types.ErrorInvalidResponse, types.ErrorKindBadResponse, true},
+		{types.ErrorFailedToParsePrice, types.ErrorKindBadResponse, true},
+		{http.StatusInternalServerError, types.ErrorKindBadResponse, true},
+		{types.ErrorContractReverted, types.ErrorKindContractReverted, false},
+		{types.ErrorStalePrice, types.ErrorKindStalePrice, false},
+		{types.ErrorSequencerUnavailable, types.ErrorKindStalePrice, false},
+		{types.ErrorInvalidConfig, types.ErrorKindConfig, false},
+		{types.ErrorUnknownPair, types.ErrorKindConfig, false},
+		{http.StatusUnauthorized, types.ErrorKindConfig, false},
+		{types.ErrorAPIGeneral, types.ErrorKindUnknown, true},
+		{types.ErrorUnknown, types.ErrorKindUnknown, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", tc.code), func(t *testing.T) {
+			require.Equal(t, tc.kind, tc.code.Kind())
+			require.Equal(t, tc.retryable, tc.code.Kind().Retryable())
+		})
+	}
+}
+
+func TestErrorCodeFromError(t *testing.T) {
+	stale := types.NewErrorWithCode(fmt.Errorf("stale round"), types.ErrorStalePrice)
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected types.ErrorCode
+	}{
+		{
+			name:     "no error",
+			err:      nil,
+			expected: types.OK,
+		},
+		{
+			name:     "error with code",
+			err:      stale,
+			expected: types.ErrorStalePrice,
+		},
+		{
+			name:     "wrapped error with code",
+			err:      fmt.Errorf("failed to validate round: %w", stale),
+			expected: types.ErrorStalePrice,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("request failed: %w", context.DeadlineExceeded),
+			expected: types.ErrorTimeout,
+		},
+		{
+			name:     "unknown error",
+			err:      fmt.Errorf("unknown"),
+			expected: types.ErrorUnknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, types.ErrorCodeFromError(tc.err, types.ErrorUnknown))
+		})
+	}
+
+	t.Run("error with code unwraps to the internal error", func(t *testing.T) {
+		err := types.NewErrorWithCode(context.DeadlineExceeded, types.ErrorTimeout)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, types.ErrorKindTimeout, err.Kind())
+	})
+}
@@ -206,6 +206,38 @@ func (_c *Provider_Start_Call[K, V]) RunAndReturn(run func(context.Context) erro
 	return _c
 }
 
+// Stop provides a mock function with given fields:
+func (_m *Provider[K, V]) Stop() {
+	_m.Called()
+}
+
+// Provider_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type Provider_Stop_Call[K types.ResponseKey, V types.ResponseValue] struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+func (_e *Provider_Expecter[K, V]) Stop() *Provider_Stop_Call[K, V] {
+	return &Provider_Stop_Call[K, V]{Call: _e.mock.On("Stop")}
+}
+
+func (_c *Provider_Stop_Call[K, V]) Run(run func()) *Provider_Stop_Call[K, V] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_Stop_Call[K, V]) Return() *Provider_Stop_Call[K, V] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Provider_Stop_Call[K, V]) RunAndReturn(run func()) *Provider_Stop_Call[K, V] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Type provides a mock function with given fields:
 func (_m *Provider[K, V]) Type() types.ProviderType {
 	ret := _m.Called()
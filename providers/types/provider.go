@@ -11,8 +11,27 @@ const (
 	API        ProviderType = "api"
 )
 
+// HealthStatus is the health status of a provider.
+type HealthStatus string
+
+const (
+	// Healthy indicates that the provider is being queried and its data is reported.
+	Healthy HealthStatus = "healthy"
+	// Quarantined indicates that the provider failed consecutively and is not being queried
+	// until its cooldown has elapsed.
+	Quarantined HealthStatus = "quarantined"
+	// Probing indicates that the provider's cooldown has elapsed and that it is being queried,
+	// but that its data is not reported until a query succeeds.
+	Probing HealthStatus = "probing"
+)
+
 // Provider defines an interface a data provider must implement.
 //
+// Implementations must be safe for concurrent use. In particular, the set of keys a provider
+// fetches data for (e.g. its currency pairs) may be updated while it is running and while its data
+// is being read, so the set must be guarded or replaced rather than mutated in place
+// (copy-on-write). Once a key is removed, its data must no longer be returned by GetData.
+//
 //go:generate mockery --name Provider --filename mock_provider.go
 type Provider[K ResponseKey, V ResponseValue] interface {
 	// Name returns the name of the provider.
@@ -21,12 +40,17 @@ type Provider[K ResponseKey, V ResponseValue] interface {
 	// GetData returns the aggregated data for the given (key, value) pairs.
 	// For example, if the provider is fetching prices for a set of currency
 	// pairs, the data returned by this function would be the latest prices
-	// for those currency pairs.
+	// for those currency pairs. The returned map is a copy owned by the caller.
 	GetData() map[K]ResolvedResult[V]
 
-	// Start starts the provider.
+	// Start starts the provider. This blocks until the provider is stopped or the context
+	// is cancelled. Start returns an error without starting the provider if it is already
+	// running, so a provider is never run by two concurrent calls.
 	Start(context.Context) error
 
+	// Stop stops the provider. This blocks until the provider has exited.
+	Stop()
+
 	// Type returns the type of the provider data handler.
 	Type() ProviderType
 
@@ -56,6 +56,28 @@ type ResolvedResult[V ResponseValue] struct {
 	// ResponseCode is an optional code that can be attached to responses to provide
 	// additional context.
 	ResponseCode ResponseCode
+	// Weight is an optional, non-negative confidence weight of the value, e.g. the inverse of a
+	// price's relative confidence interval. A weight of 0 means that no weight was reported.
+	Weight float64
+	// Volume is an optional, non-negative volume traded on the venue over the venue's reporting
+	// window, in units of the base asset. A volume of 0 means that no volume was reported.
+	Volume float64
+	// Block is the block at which the value was read, for values read from a chain. The block is
+	// zero if it was not reported.
+	Block BlockInfo
+}
+
+// BlockInfo identifies the block at which an on-chain value was read.
+type BlockInfo struct {
+	// Number is the number (height) of the block.
+	Number uint64
+	// Timestamp is the timestamp of the block.
+	Timestamp time.Time
+}
+
+// IsZero returns true if no block was reported.
+func (b BlockInfo) IsZero() bool {
+	return b.Number == 0 && b.Timestamp.IsZero()
 }
 
 // UnresolvedResult is an unresolved (failed) result of a single requested ID.
@@ -122,13 +144,42 @@ func NewResultWithCode[V ResponseValue](value V, timestamp time.Time, code Respo
 	}
 }
 
+// NewResultWithWeight creates a new ResolvedResult with the given weight.
+func NewResultWithWeight[V ResponseValue](value V, timestamp time.Time, weight float64) ResolvedResult[V] {
+	return ResolvedResult[V]{
+		Value:     value,
+		Timestamp: timestamp,
+		Weight:    weight,
+	}
+}
+
+// NewResultWithVolume creates a new ResolvedResult with the given volume.
+func NewResultWithVolume[V ResponseValue](value V, timestamp time.Time, volume float64) ResolvedResult[V] {
+	return ResolvedResult[V]{
+		Value:     value,
+		Timestamp: timestamp,
+		Volume:    volume,
+	}
+}
+
+// NewResultWithBlock creates a new ResolvedResult read at the given block.
+func NewResultWithBlock[V ResponseValue](value V, timestamp time.Time, block BlockInfo) ResolvedResult[V] {
+	return ResolvedResult[V]{
+		Value:     value,
+		Timestamp: timestamp,
+		Block:     block,
+	}
+}
+
 // String returns a string representation of the ResolvedResult. This is mostly used for logging
 // and testing purposes.
 func (r ResolvedResult[V]) String() string {
 	return fmt.Sprintf(
-		"(value: %s, timestamp: %s, response code: %s)",
+		"(value: %s, timestamp: %s, response code: %s, weight: %f, volume: %f)",
 		r.Value.String(),
 		r.Timestamp.String(),
 		r.ResponseCode.String(),
+		r.Weight,
+		r.Volume,
 	)
 }
@@ -2,6 +2,7 @@ package binance
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
@@ -11,7 +12,8 @@ import (
 )
 
 // parsePriceUpdateMessage parses a price update message from the Binance websocket feed.
-// This is repurposed for ticker and aggregate trade messages.
+// This is repurposed for ticker and aggregate trade messages. The price is reported with the
+// latest 24h volume of the ticker, if any.
 func (h *WebSocketHandler) parsePriceUpdateMessage(offChainTicker string, price string) (types.PriceResponse, error) {
 	var (
 		resolved   = make(types.ResolvedPrices)
@@ -33,6 +35,22 @@ func (h *WebSocketHandler) parsePriceUpdateMessage(offChainTicker string, price
 		return types.NewPriceResponse(resolved, unResolved), err
 	}
 
-	resolved[ticker] = types.NewPriceResult(priceFloat, time.Now().UTC())
+	resolved[ticker] = types.NewPriceResultWithVolume(priceFloat, time.Now().UTC(), h.volumes[ticker.GetOffChainTicker()])
 	return types.NewPriceResponse(resolved, unResolved), nil
 }
+
+// updateVolume records the 24h volume received on the ticker stream for the given ticker. Volumes
+// that fail to parse are discarded.
+func (h *WebSocketHandler) updateVolume(offChainTicker string, volume string) {
+	ticker, ok := h.cache.FromOffChainTicker(offChainTicker)
+	if !ok {
+		return
+	}
+
+	v, err := strconv.ParseFloat(volume, 64)
+	if err != nil || v < 0 {
+		return
+	}
+
+	h.volumes[ticker.GetOffChainTicker()] = v
+}
@@ -27,6 +27,10 @@ type WebSocketHandler struct {
 	messageIDs map[int64][]string
 	// nextID is the next message ID to use for the Binance websocket API.
 	nextID int64
+	// volumes is the latest 24h base asset volume per off-chain ticker, as received on the ticker
+	// stream. Aggregate trades do not include the volume, so it is reported alongside their prices
+	// from here.
+	volumes map[string]float64
 }
 
 // NewWebSocketDataHandler returns a new Binance PriceWebSocketDataHandler.
@@ -52,6 +56,7 @@ func NewWebSocketDataHandler(
 		cache:      types.NewProviderTickers(),
 		messageIDs: make(map[int64][]string),
 		nextID:     rand.Int63() + 1,
+		volumes:    make(map[string]float64),
 	}, nil
 }
 
@@ -113,6 +118,7 @@ func (h *WebSocketHandler) HandleMessage(
 		}
 
 		h.logger.Debug("received ticker message", zap.String("ticker", tickerResp.Data.Ticker))
+		h.updateVolume(tickerResp.Data.Ticker, tickerResp.Data.Volume)
 		resp, err := h.parsePriceUpdateMessage(tickerResp.Data.Ticker, tickerResp.Data.LastPrice)
 		return resp, nil, err
 	case AggregateTradeStream:
@@ -163,5 +169,6 @@ func (h *WebSocketHandler) Copy() types.PriceWebSocketDataHandler {
 		cache:      types.NewProviderTickers(),
 		messageIDs: make(map[int64][]string),
 		nextID:     rand.Int63() + 1,
+		volumes:    make(map[string]float64),
 	}
 }
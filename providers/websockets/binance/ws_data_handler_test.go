@@ -111,6 +111,7 @@ func TestHandleMessage(t *testing.T) {
 					"data": {
 						"s": "btcusdt",
 						"c": "10000.00000000",
+						"v": "1234.5",
 						"C": 1600000000000
 						}
 				}`
@@ -120,7 +121,8 @@ func TestHandleMessage(t *testing.T) {
 			resp: types.NewPriceResponse(
 				types.ResolvedPrices{
 					btcusdt: {
-						Value: big.NewFloat(10000.0),
+						Value:  big.NewFloat(10000.0),
+						Volume: 1234.5,
 					},
 				},
 				types.UnResolvedPrices{},
@@ -292,6 +294,7 @@ func TestHandleMessage(t *testing.T) {
 			for cp, result := range tc.resp.Resolved {
 				require.Contains(t, resp.Resolved, cp)
 				require.Equal(t, result.Value.SetPrec(18), resp.Resolved[cp].Value.SetPrec(18))
+				require.Equal(t, result.Volume, resp.Resolved[cp].Volume)
 			}
 
 			for cp := range tc.resp.UnResolved {
@@ -302,6 +305,29 @@ func TestHandleMessage(t *testing.T) {
 	}
 }
 
+func TestHandleMessageVolume(t *testing.T) {
+	wsHandlerI, err := binance.NewWebSocketDataHandler(logger, binance.DefaultWebSocketConfig)
+	require.NoError(t, err)
+
+	wsHandler := wsHandlerI.(*binance.WebSocketHandler)
+	_, err = wsHandler.CreateMessages([]types.ProviderTicker{btcusdt})
+	require.NoError(t, err)
+
+	trade := []byte(`{"stream": "btcusdt@aggTrade", "data": {"e": "aggTrade", "s": "BTCUSDT", "p": "10001.0"}}`)
+
+	// Trades do not report a volume until the volume is received on the ticker stream.
+	resp, _, err := wsHandler.HandleMessage(trade)
+	require.NoError(t, err)
+	require.Zero(t, resp.Resolved[btcusdt].Volume)
+
+	_, _, err = wsHandler.HandleMessage([]byte(`{"stream": "btcusdt@ticker", "data": {"s": "BTCUSDT", "c": "10000.0", "v": "1234.5"}}`))
+	require.NoError(t, err)
+
+	resp, _, err = wsHandler.HandleMessage(trade)
+	require.NoError(t, err)
+	require.Equal(t, 1234.5, resp.Resolved[btcusdt].Volume)
+}
+
 func TestCreateMessages(t *testing.T) {
 	batchCfg := binance.DefaultWebSocketConfig
 	batchCfg.MaxSubscriptionsPerBatch = 2
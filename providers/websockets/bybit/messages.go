@@ -179,4 +179,6 @@ type TickerUpdateMessage struct {
 type TickerUpdateData struct {
 	Symbol    string `json:"symbol"`
 	LastPrice string `json:"lastPrice"`
+	// Volume24h is the base asset volume traded over the last 24 hours.
+	Volume24h string `json:"volume24h"`
 }
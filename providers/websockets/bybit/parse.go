@@ -2,6 +2,7 @@ package bybit
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -57,6 +58,17 @@ func (h *WebSocketHandler) parseTickerUpdate(
 		return types.NewPriceResponse(resolved, unresolved), fmt.Errorf("unknown ticker %s", data.Symbol)
 	}
 
+	// Record the 24h volume, which is reported alongside the price. Volumes that fail to parse are discarded.
+	if volume, err := strconv.ParseFloat(data.Volume24h, 64); err == nil && volume >= 0 {
+		h.volumes[ticker.GetOffChainTicker()] = volume
+	}
+
+	// Linear markets push delta updates that only include the fields that changed, so an
+	// update without a last price means the price did not change.
+	if len(data.LastPrice) == 0 {
+		return types.NewPriceResponse(resolved, unresolved), nil
+	}
+
 	// Convert the price to a big.Float.
 	price, err := math.Float64StringToBigFloat(data.LastPrice)
 	if err != nil {
@@ -67,6 +79,6 @@ func (h *WebSocketHandler) parseTickerUpdate(
 		return types.NewPriceResponse(resolved, unresolved), nil
 	}
 
-	resolved[ticker] = types.NewPriceResult(price, time.Now().UTC())
+	resolved[ticker] = types.NewPriceResultWithVolume(price, time.Now().UTC(), h.volumes[ticker.GetOffChainTicker()])
 	return types.NewPriceResponse(resolved, unresolved), nil
 }
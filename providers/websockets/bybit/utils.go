@@ -20,6 +20,13 @@ const (
 	// URLTest is the public testnet ByBit Websocket URL.
 	URLTest = "wss://stream-testnet.bybit.com/v5/public/spot"
 
+	// LinearName is the name of the ByBit provider for linear (USDT and USDC margined)
+	// perpetual and futures markets.
+	LinearName = "bybit_ws-linear"
+
+	// URLLinearProd is the public ByBit Websocket URL for linear markets.
+	URLLinearProd = "wss://stream.bybit.com/v5/public/linear"
+
 	// DefaultPingInterval is the default ping interval for the ByBit websocket.
 	DefaultPingInterval = 15 * time.Second
 )
@@ -44,3 +51,25 @@ var DefaultWebSocketConfig = config.WebSocketConfig{
 	MaxSubscriptionsPerConnection: config.DefaultMaxSubscriptionsPerConnection,
 	MaxSubscriptionsPerBatch:      config.DefaultMaxSubscriptionsPerBatch,
 }
+
+// DefaultLinearWebSocketConfig is the default configuration for the ByBit Websocket for
+// linear markets.
+var DefaultLinearWebSocketConfig = config.WebSocketConfig{
+	Name:                          LinearName,
+	Enabled:                       true,
+	MaxBufferSize:                 1000,
+	ReconnectionTimeout:           config.DefaultReconnectionTimeout,
+	PostConnectionTimeout:         config.DefaultPostConnectionTimeout,
+	Endpoints:                     []config.Endpoint{{URL: URLLinearProd}},
+	ReadBufferSize:                config.DefaultReadBufferSize,
+	WriteBufferSize:               config.DefaultWriteBufferSize,
+	HandshakeTimeout:              config.DefaultHandshakeTimeout,
+	EnableCompression:             config.DefaultEnableCompression,
+	ReadTimeout:                   config.DefaultReadTimeout,
+	WriteTimeout:                  config.DefaultWriteTimeout,
+	PingInterval:                  DefaultPingInterval,
+	WriteInterval:                 config.DefaultWriteInterval,
+	MaxReadErrorCount:             config.DefaultMaxReadErrorCount,
+	MaxSubscriptionsPerConnection: config.DefaultMaxSubscriptionsPerConnection,
+	MaxSubscriptionsPerBatch:      config.DefaultMaxSubscriptionsPerBatch,
+}
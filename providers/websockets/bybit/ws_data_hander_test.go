@@ -68,6 +68,7 @@ func TestHandlerMessage(t *testing.T) {
 					Data: bybit.TickerUpdateData{
 						Symbol:    "BTCUSDT",
 						LastPrice: "1",
+						Volume24h: "6780.5",
 					},
 				}
 
@@ -79,7 +80,8 @@ func TestHandlerMessage(t *testing.T) {
 			resp: types.NewPriceResponse(
 				types.ResolvedPrices{
 					btcusdt: {
-						Value: big.NewFloat(1.0),
+						Value:  big.NewFloat(1.0),
+						Volume: 6780.5,
 					},
 				},
 				types.UnResolvedPrices{},
@@ -87,6 +89,28 @@ func TestHandlerMessage(t *testing.T) {
 			updateMsg: func() []handlers.WebsocketEncodedMessage { return nil },
 			expErr:    false,
 		},
+		{
+			name: "delta update without a price change",
+			msg: func() []byte {
+				msg := bybit.TickerUpdateMessage{
+					Topic: "tickers.BTCUSDT",
+					Data: bybit.TickerUpdateData{
+						Symbol: "BTCUSDT",
+					},
+				}
+
+				bz, err := json.Marshal(msg)
+				require.NoError(t, err)
+
+				return bz
+			},
+			resp: types.NewPriceResponse(
+				types.ResolvedPrices{},
+				types.UnResolvedPrices{},
+			),
+			updateMsg: func() []handlers.WebsocketEncodedMessage { return nil },
+			expErr:    false,
+		},
 		{
 			name: "price update with unknown pair ID",
 			msg: func() []byte {
@@ -187,6 +211,7 @@ func TestHandlerMessage(t *testing.T) {
 			for cp, result := range tc.resp.Resolved {
 				require.Contains(t, resp.Resolved, cp)
 				require.Equal(t, result.Value.SetPrec(18), resp.Resolved[cp].Value.SetPrec(18))
+				require.Equal(t, result.Volume, resp.Resolved[cp].Volume)
 			}
 
 			for cp := range tc.resp.UnResolved {
@@ -197,6 +222,31 @@ func TestHandlerMessage(t *testing.T) {
 	}
 }
 
+func TestHandlerMessageVolume(t *testing.T) {
+	wsHandler, err := bybit.NewWebSocketDataHandler(logger, bybit.DefaultWebSocketConfig)
+	require.NoError(t, err)
+
+	_, err = wsHandler.CreateMessages([]types.ProviderTicker{btcusdt})
+	require.NoError(t, err)
+
+	update := func(data bybit.TickerUpdateData) types.PriceResponse {
+		bz, err := json.Marshal(bybit.TickerUpdateMessage{Topic: "tickers.BTCUSDT", Data: data})
+		require.NoError(t, err)
+
+		resp, _, err := wsHandler.HandleMessage(bz)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// A delta update that only changes the volume does not resolve a price.
+	resp := update(bybit.TickerUpdateData{Symbol: "BTCUSDT", Volume24h: "100"})
+	require.Empty(t, resp.Resolved)
+
+	// A delta update that only changes the price reports the latest volume.
+	resp = update(bybit.TickerUpdateData{Symbol: "BTCUSDT", LastPrice: "2"})
+	require.Equal(t, 100.0, resp.Resolved[btcusdt].Volume)
+}
+
 func TestCreateMessage(t *testing.T) {
 	batchCfg := bybit.DefaultWebSocketConfig
 	batchCfg.MaxSubscriptionsPerBatch = 2
@@ -341,3 +391,16 @@ func TestCreateMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWebSocketDataHandler(t *testing.T) {
+	_, err := bybit.NewWebSocketDataHandler(logger, bybit.DefaultWebSocketConfig)
+	require.NoError(t, err)
+
+	_, err = bybit.NewWebSocketDataHandler(logger, bybit.DefaultLinearWebSocketConfig)
+	require.NoError(t, err)
+
+	cfg := bybit.DefaultWebSocketConfig
+	cfg.Name = "invalid"
+	_, err = bybit.NewWebSocketDataHandler(logger, cfg)
+	require.Error(t, err)
+}
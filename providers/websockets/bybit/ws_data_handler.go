@@ -23,6 +23,9 @@ type WebSocketHandler struct {
 	ws config.WebSocketConfig
 	// cache maintains the latest set of tickers seen by the handler.
 	cache types.ProviderTickers
+	// volumes is the latest 24h base asset volume per off-chain ticker. Delta updates only
+	// include the fields that changed, so the volume is reported alongside prices from here.
+	volumes map[string]float64
 }
 
 // NewWebSocketDataHandler returns a new ByBit PriceWebSocketDataHandler.
@@ -30,22 +33,23 @@ func NewWebSocketDataHandler(
 	logger *zap.Logger,
 	ws config.WebSocketConfig,
 ) (types.PriceWebSocketDataHandler, error) {
-	if ws.Name != Name {
-		return nil, fmt.Errorf("expected websocket config name %s, got %s", Name, ws.Name)
+	if ws.Name != Name && ws.Name != LinearName {
+		return nil, fmt.Errorf("expected websocket config name %s or %s, got %s", Name, LinearName, ws.Name)
 	}
 
 	if !ws.Enabled {
-		return nil, fmt.Errorf("websocket config for %s is not enabled", Name)
+		return nil, fmt.Errorf("websocket config for %s is not enabled", ws.Name)
 	}
 
 	if err := ws.ValidateBasic(); err != nil {
-		return nil, fmt.Errorf("invalid websocket config for %s: %w", Name, err)
+		return nil, fmt.Errorf("invalid websocket config for %s: %w", ws.Name, err)
 	}
 
 	return &WebSocketHandler{
-		logger: logger,
-		ws:     ws,
-		cache:  types.NewProviderTickers(),
+		logger:  logger,
+		ws:      ws,
+		cache:   types.NewProviderTickers(),
+		volumes: make(map[string]float64),
 	}, nil
 }
 
@@ -111,7 +115,7 @@ func (h *WebSocketHandler) HandleMessage(
 
 // CreateMessages is used to create an initial subscription message to send to the data provider.
 // Only the tickers that are specified in the config are subscribed to. The only channel that is
-// subscribed to is the index tickers channel - which supports spot markets.
+// subscribed to is the tickers channel - which supports spot and linear markets.
 func (h *WebSocketHandler) CreateMessages(
 	tickers []types.ProviderTicker,
 ) ([]handlers.WebsocketEncodedMessage, error) {
@@ -143,8 +147,9 @@ func (h *WebSocketHandler) HeartBeatMessages() ([]handlers.WebsocketEncodedMessa
 // Copy is used to create a copy of the WebSocketHandler.
 func (h *WebSocketHandler) Copy() types.PriceWebSocketDataHandler {
 	return &WebSocketHandler{
-		logger: h.logger,
-		ws:     h.ws,
-		cache:  types.NewProviderTickers(),
+		logger:  h.logger,
+		ws:      h.ws,
+		cache:   types.NewProviderTickers(),
+		volumes: make(map[string]float64),
 	}
 }
@@ -236,6 +236,9 @@ type TickerResponseMessage struct {
 	// Price is the price of the ticker.
 	Price string `json:"price"`
 
+	// Volume24h is the base asset volume traded over the last 24 hours.
+	Volume24h string `json:"volume_24h"`
+
 	// TradeID is the trade ID of the ticker.
 	TradeID int64 `json:"trade_id"`
 }
@@ -3,6 +3,7 @@ package coinbase
 import (
 	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
@@ -51,8 +52,13 @@ func (h *WebSocketHandler) parseTickerResponseMessage(
 	// Update the trade ID.
 	h.tradeIDs[ticker] = msg.TradeID
 
-	// Convert the time to a time object and resolve the price into the response.
-	resolved[ticker] = types.NewPriceResult(price, time.Now().UTC())
+	// Resolve the price into the response along with the 24h volume. Volumes that fail to parse
+	// are not reported.
+	volume, err := strconv.ParseFloat(msg.Volume24h, 64)
+	if err != nil || volume < 0 {
+		volume = 0
+	}
+	resolved[ticker] = types.NewPriceResultWithVolume(price, time.Now().UTC(), volume)
 	return types.NewPriceResponse(resolved, unResolved), nil
 }
 
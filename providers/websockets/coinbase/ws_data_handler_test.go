@@ -52,10 +52,11 @@ func TestHandleMessage(t *testing.T) {
 			name: "ticker message",
 			msg: func() []byte {
 				msg := coinbase.TickerResponseMessage{
-					Type:     string(coinbase.TickerMessage),
-					Ticker:   "BTC-USD",
-					Price:    "10000.00",
-					Sequence: 1,
+					Type:      string(coinbase.TickerMessage),
+					Ticker:    "BTC-USD",
+					Price:     "10000.00",
+					Volume24h: "245532.79",
+					Sequence:  1,
 				}
 
 				bz, err := json.Marshal(msg)
@@ -66,7 +67,8 @@ func TestHandleMessage(t *testing.T) {
 			resp: types.PriceResponse{
 				Resolved: types.ResolvedPrices{
 					btcusd: {
-						Value: big.NewFloat(10000.00),
+						Value:  big.NewFloat(10000.00),
+						Volume: 245532.79,
 					},
 				},
 			},
@@ -314,6 +316,7 @@ func TestHandleMessage(t *testing.T) {
 			for cp, result := range tc.resp.Resolved {
 				require.Contains(t, resp.Resolved, cp)
 				require.Equal(t, result.Value.SetPrec(18), resp.Resolved[cp].Value.SetPrec(18))
+				require.Equal(t, result.Volume, resp.Resolved[cp].Volume)
 				require.Equal(t, result.ResponseCode, resp.Resolved[cp].ResponseCode)
 			}
 
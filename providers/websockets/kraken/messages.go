@@ -339,6 +339,9 @@ const (
 type TickerData struct {
 	// VolumeWeightedAveragePrice is the volume weighted average price.
 	VolumeWeightedAveragePrice []string `json:"p"`
+
+	// Volume is the volume traded today and over the last 24 hours.
+	Volume []string `json:"v"`
 }
 
 const (
@@ -349,4 +352,8 @@ const (
 	// ExpectedVolumeWeightedAveragePriceLength is the expected length of the ticker's
 	// VolumeWeightedAveragePrice array.
 	ExpectedVolumeWeightedAveragePriceLength = 2
+
+	// Last24HoursVolumeIndex is the index of the last 24 hours' volume in the ticker's Volume
+	// array.
+	Last24HoursVolumeIndex = 1
 )
@@ -3,6 +3,7 @@ package kraken
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	providertypes "github.com/skip-mev/connect/v2/providers/types"
@@ -116,10 +117,25 @@ func (h *WebSocketHandler) parseTickerMessage(
 		return types.NewPriceResponse(resolved, unResolved), unResolved[ticker]
 	}
 
-	resolved[ticker] = types.NewPriceResult(price, time.Now().UTC())
+	resolved[ticker] = types.NewPriceResultWithVolume(price, time.Now().UTC(), parseVolume(resp.TickerData.Volume))
 	return types.NewPriceResponse(resolved, unResolved), nil
 }
 
+// parseVolume returns the volume traded over the last 24 hours, or 0 if the volume is missing or
+// fails to parse.
+func parseVolume(volume []string) float64 {
+	if len(volume) <= Last24HoursVolumeIndex {
+		return 0
+	}
+
+	v, err := strconv.ParseFloat(volume[Last24HoursVolumeIndex], 64)
+	if err != nil || v < 0 {
+		return 0
+	}
+
+	return v
+}
+
 // DecodeTickerResponseMessage decodes a ticker response message.
 func DecodeTickerResponseMessage(message []byte) (TickerResponseMessage, error) {
 	var rawResponse []json.RawMessage
@@ -43,7 +43,8 @@ func TestHandleMessage(t *testing.T) {
 			resp: types.PriceResponse{
 				Resolved: types.ResolvedPrices{
 					btcusd: {
-						Value: big.NewFloat(42596.41907000),
+						Value:  big.NewFloat(42596.41907000),
+						Volume: 2075.61202911,
 					},
 				},
 				UnResolved: types.UnResolvedPrices{},
@@ -224,6 +225,7 @@ func TestHandleMessage(t *testing.T) {
 			for cp, result := range tc.resp.Resolved {
 				require.Contains(t, resp.Resolved, cp)
 				require.Equal(t, result.Value.SetPrec(18), resp.Resolved[cp].Value.SetPrec(18))
+				require.Equal(t, result.Volume, resp.Resolved[cp].Volume)
 			}
 
 			for cp := range tc.resp.UnResolved {
@@ -399,6 +401,7 @@ func TestDecodeTickerResponseMessage(t *testing.T) {
 				ChannelID: 340,
 				TickerData: kraken.TickerData{
 					VolumeWeightedAveragePrice: []string{"42596.41907", "42598.31137"},
+					Volume:                     []string{"2068.49653432", "2075.61202911"},
 				},
 				ChannelName: "ticker",
 				Pair:        "XBT/USD",
@@ -214,4 +214,7 @@ type IndexTicker struct {
 
 	// LastPrice is the last price.
 	LastPrice string `json:"last" validate:"required"`
+
+	// Volume24h is the base currency volume traded over the last 24 hours.
+	Volume24h string `json:"vol24h"`
 }
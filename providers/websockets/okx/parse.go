@@ -3,6 +3,7 @@ package okx
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -100,7 +101,12 @@ func (h *WebSocketHandler) parseTickerResponseMessage(
 			continue
 		}
 
-		resolved[ticker] = types.NewPriceResult(price, time.Now().UTC())
+		// Report the 24h volume alongside the price. Volumes that fail to parse are not reported.
+		volume, err := strconv.ParseFloat(instrument.Volume24h, 64)
+		if err != nil || volume < 0 {
+			volume = 0
+		}
+		resolved[ticker] = types.NewPriceResultWithVolume(price, time.Now().UTC(), volume)
 	}
 
 	return types.NewPriceResponse(resolved, unresolved), nil
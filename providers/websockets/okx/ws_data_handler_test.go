@@ -73,6 +73,7 @@ func TestHandleMessage(t *testing.T) {
 						{
 							ID:        "BTC-USDT",
 							LastPrice: "1",
+							Volume24h: "2222",
 						},
 					},
 				}
@@ -85,7 +86,8 @@ func TestHandleMessage(t *testing.T) {
 			resp: types.NewPriceResponse(
 				types.ResolvedPrices{
 					btcusdt: {
-						Value: big.NewFloat(1.0),
+						Value:  big.NewFloat(1.0),
+						Volume: 2222,
 					},
 				},
 				types.UnResolvedPrices{},
@@ -318,6 +320,7 @@ func TestHandleMessage(t *testing.T) {
 			for cp, result := range tc.resp.Resolved {
 				require.Contains(t, resp.Resolved, cp)
 				require.Equal(t, result.Value.SetPrec(18), resp.Resolved[cp].Value.SetPrec(18))
+				require.Equal(t, result.Volume, resp.Resolved[cp].Volume)
 			}
 
 			for cp := range tc.resp.UnResolved {
@@ -264,3 +264,22 @@ func (c *GRPCClient) Version(ctx context.Context, req *types.QueryVersionRequest
 
 	return c.client.Version(ctx, req, grpc.WaitForReady(true))
 }
+
+// StreamPrices opens a stream of the latest prices from the remote oracle service. Unlike the other
+// methods, the client's timeout is not applied since the stream is long-lived; the stream is closed
+// once the given context is cancelled.
+func (c *GRPCClient) StreamPrices(
+	ctx context.Context,
+	req *types.StreamPricesRequest,
+	_ ...grpc.CallOption,
+) (types.Oracle_StreamPricesClient, error) {
+	c.mutex.Lock()
+	client := c.client
+	c.mutex.Unlock()
+
+	if client == nil {
+		return nil, fmt.Errorf("oracle client not started")
+	}
+
+	return client.StreamPrices(ctx, req, grpc.WaitForReady(true))
+}
@@ -62,3 +62,11 @@ func (c NoOpClient) Version(
 ) (*types.QueryVersionResponse, error) {
 	return nil, nil
 }
+
+func (c NoOpClient) StreamPrices(
+	_ context.Context,
+	_ *types.StreamPricesRequest,
+	_ ...grpc.CallOption,
+) (types.Oracle_StreamPricesClient, error) {
+	return nil, nil
+}
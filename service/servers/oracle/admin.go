@@ -0,0 +1,235 @@
+package oracle
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/log"
+)
+
+const (
+	// PausedPairsPath is the path of the admin endpoint that lists the paused pairs of the oracle.
+	// A pair is paused by a PUT request to the path of the pair, e.g. PUT
+	// /admin/paused_pairs/BTC/USD, and resumed by a DELETE request to the same path.
+	PausedPairsPath = "/admin/paused_pairs"
+	// DisabledProvidersPath is the path of the admin endpoint that lists the disabled providers of
+	// the oracle. A provider is disabled by a PUT request to the path of the provider, e.g. PUT
+	// /admin/disabled_providers/binance_api, and enabled by a DELETE request to the same path.
+	DisabledProvidersPath = "/admin/disabled_providers"
+	// RefreshPath is the path of the admin endpoint that immediately re-queries every provider of
+	// the oracle on POST requests, or a single provider if the path names it, e.g. POST
+	// /admin/refresh/binance_api.
+	RefreshPath = "/admin/refresh"
+	// LogLevelPath is the path of the admin endpoint that serves the log levels of the oracle on
+	// GET requests, and sets them on PUT requests.
+	LogLevelPath = "/admin/log_level"
+)
+
+// PausedPairsResponse is the response of the paused pairs endpoint.
+type PausedPairsResponse struct {
+	// Paused are the currency pairs whose prices are not served, sorted by ticker.
+	Paused []string `json:"paused"`
+}
+
+// DisabledProvidersResponse is the response of the disabled providers endpoint.
+type DisabledProvidersResponse struct {
+	// Disabled are the providers whose prices are not aggregated, sorted by name.
+	Disabled []string `json:"disabled"`
+}
+
+// RefreshResponse is the response of the refresh endpoint.
+type RefreshResponse struct {
+	// Refreshed are the providers that were re-queried, sorted by name.
+	Refreshed []string `json:"refreshed"`
+}
+
+// LogLevels are the log levels of the oracle. This is both the request and the response of the
+// log level endpoint. Empty levels of a request are left unchanged.
+type LogLevels struct {
+	// StdOut is the level of the standard out logger.
+	StdOut string `json:"stdout,omitempty"`
+	// FileOut is the level of the file logger.
+	FileOut string `json:"file,omitempty"`
+}
+
+// WithAdmin serves the admin API to requests that present a token with the admin role. The pairs
+// and providers of the oracle are controlled by the given controller, and the log levels by the
+// given levels. Either may be nil, in which case the respective endpoints are not served.
+func WithAdmin(controller oracle.Controller, levels *log.Levels) ServerOption {
+	return func(os *OracleServer) {
+		os.controller = controller
+		os.logLevels = levels
+	}
+}
+
+// registerAdminHandlers registers the endpoints of the admin API on the given router. The admin
+// API is only served if a token with the admin role is accepted.
+func (os *OracleServer) registerAdminHandlers(router *http.ServeMux) {
+	if os.auth == nil || !os.auth.grants(config.RoleAdmin) {
+		return
+	}
+
+	if os.breaker != nil {
+		router.HandleFunc(BreakersPath, os.admin(os.serveBreakers))
+		router.HandleFunc(BreakersPath+"/", os.admin(os.serveBreakers))
+	}
+
+	if os.controller != nil {
+		router.HandleFunc(PausedPairsPath, os.admin(os.servePausedPairs))
+		router.HandleFunc(PausedPairsPath+"/", os.admin(os.servePausedPairs))
+		router.HandleFunc(DisabledProvidersPath, os.admin(os.serveDisabledProviders))
+		router.HandleFunc(DisabledProvidersPath+"/", os.admin(os.serveDisabledProviders))
+		router.HandleFunc(RefreshPath, os.admin(os.serveRefresh))
+		router.HandleFunc(RefreshPath+"/", os.admin(os.serveRefresh))
+	}
+
+	if os.logLevels != nil {
+		router.HandleFunc(LogLevelPath, os.admin(os.serveLogLevel))
+	}
+}
+
+// servePausedPairs lists the paused pairs of the oracle, or pauses or resumes the pair named by
+// the path on PUT and DELETE requests.
+func (os *OracleServer) servePausedPairs(w http.ResponseWriter, r *http.Request) {
+	ticker := strings.ToUpper(adminResource(r, PausedPairsPath))
+
+	var err error
+	switch {
+	case r.Method == http.MethodGet && ticker == "":
+	case r.Method == http.MethodPut && ticker != "":
+		err = os.controller.PausePair(ticker)
+	case r.Method == http.MethodDelete && ticker != "":
+		err = os.controller.ResumePair(ticker)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	os.writeJSON(w, http.StatusOK, PausedPairsResponse{Paused: os.controller.GetPausedPairs()})
+}
+
+// serveDisabledProviders lists the disabled providers of the oracle, or disables or enables the
+// provider named by the path on PUT and DELETE requests.
+func (os *OracleServer) serveDisabledProviders(w http.ResponseWriter, r *http.Request) {
+	name := adminResource(r, DisabledProvidersPath)
+
+	var err error
+	switch {
+	case r.Method == http.MethodGet && name == "":
+	case r.Method == http.MethodPut && name != "":
+		err = os.controller.DisableProvider(name)
+	case r.Method == http.MethodDelete && name != "":
+		err = os.controller.EnableProvider(name)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	os.writeJSON(w, http.StatusOK, DisabledProvidersResponse{Disabled: os.controller.GetDisabledProviders()})
+}
+
+// serveRefresh immediately re-queries the provider named by the path, or every provider of the
+// oracle if the path does not name one.
+func (os *OracleServer) serveRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var names []string
+	if name := adminResource(r, RefreshPath); name != "" {
+		names = append(names, name)
+	} else {
+		for name := range os.o.GetProviderState() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	if err := os.controller.RefreshProviders(names...); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	os.writeJSON(w, http.StatusOK, RefreshResponse{Refreshed: names})
+}
+
+// serveLogLevel serves the log levels of the oracle, or sets the levels given in the body of PUT
+// requests.
+func (os *OracleServer) serveLogLevel(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req LogLevels
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Parse every level before setting any so that a bad request has no effect.
+		stdOut, err := parseLevel(req.StdOut, os.logLevels.StdOut.Level())
+		if err != nil {
+			http.Error(w, "invalid stdout level: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		fileOut, err := parseLevel(req.FileOut, os.logLevels.FileOut.Level())
+		if err != nil {
+			http.Error(w, "invalid file level: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		os.logLevels.StdOut.SetLevel(stdOut)
+		os.logLevels.FileOut.SetLevel(fileOut)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	os.writeJSON(w, http.StatusOK, LogLevels{
+		StdOut:  os.logLevels.StdOut.String(),
+		FileOut: os.logLevels.FileOut.String(),
+	})
+}
+
+// admin returns a handler that serves the given handler to requests that present a token with
+// the admin role, and responds with a 401 to all other requests.
+func (os *OracleServer) admin(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !os.auth.Authorize(r, config.RoleAdmin) {
+			unauthorized(w)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// adminResource returns the resource named by the path of an admin request below the given path
+// of the endpoint, e.g. BTC/USD for /admin/paused_pairs/BTC/USD, or an empty string if the path
+// does not name one.
+func adminResource(r *http.Request, path string) string {
+	return strings.Trim(strings.TrimPrefix(r.URL.Path, path), "/")
+}
+
+// parseLevel parses the given log level, or returns the current level if the given level is empty.
+func parseLevel(level string, current zapcore.Level) (zapcore.Level, error) {
+	if level == "" {
+		return current, nil
+	}
+
+	return zapcore.ParseLevel(level)
+}
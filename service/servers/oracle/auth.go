@@ -0,0 +1,185 @@
+package oracle
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/pkg/secrets"
+)
+
+// adminPathPrefix is the path prefix of the endpoints of the admin API.
+const adminPathPrefix = "/admin/"
+
+// Authenticator authorizes the requests of the oracle server by the bearer token they present. A
+// token grants either the read role, which grants access to the price APIs, or the admin role,
+// which additionally grants access to the admin API.
+type Authenticator struct {
+	// credentials are the tokens accepted by the server.
+	credentials []credential
+
+	// readRequired is true if the price APIs require a token with the read role.
+	readRequired bool
+}
+
+// credential is a bearer token and the role it grants.
+type credential struct {
+	token *secrets.Secret
+	role  string
+}
+
+// NewAuthenticator returns the authenticator of the oracle server, which accepts the tokens of
+// the server's auth config and the admin token of the admin config. The price APIs only require a
+// token if the server's auth config is enabled, and the admin API is only served if a token with
+// the admin role is accepted.
+func NewAuthenticator(cfg config.OracleConfig) (*Authenticator, error) {
+	a := &Authenticator{
+		readRequired: cfg.Server.Auth.Enabled,
+	}
+
+	var tokens []config.TokenConfig
+	if cfg.Server.Auth.Enabled {
+		tokens = append(tokens, cfg.Server.Auth.Tokens...)
+	}
+	if cfg.Admin.Enabled && cfg.Admin.TokenSource != "" {
+		tokens = append(tokens, config.TokenConfig{Source: cfg.Admin.TokenSource, Role: config.RoleAdmin})
+	}
+
+	for _, token := range tokens {
+		src, err := secrets.NewSource(token.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err := secrets.NewSecret(src, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s token: %w", token.Role, err)
+		}
+
+		a.credentials = append(a.credentials, credential{token: secret, role: token.Role})
+	}
+
+	return a, nil
+}
+
+// Authorize returns true if the request presents a token that grants the given role. Every
+// request is granted the read role if the price APIs do not require a token.
+func (a *Authenticator) Authorize(r *http.Request, role string) bool {
+	if role == config.RoleRead && !a.readRequired {
+		return true
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+
+	// Compare against every credential so that the time taken does not reveal which matched.
+	authorized := false
+	for _, c := range a.credentials {
+		match := subtle.ConstantTimeCompare([]byte(token), []byte(c.token.Value())) == 1
+		if match && (c.role == role || c.role == config.RoleAdmin) {
+			authorized = true
+		}
+	}
+
+	return authorized
+}
+
+// grants returns true if any token accepted by the authenticator grants the given role.
+func (a *Authenticator) grants(role string) bool {
+	for _, c := range a.credentials {
+		if c.role == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithAuth sets the authenticator of the server's requests.
+func WithAuth(auth *Authenticator) ServerOption {
+	return func(os *OracleServer) {
+		os.auth = auth
+	}
+}
+
+// WithTLS serves the oracle's listener over TLS with the given config.
+func WithTLS(cfg *tls.Config) ServerOption {
+	return func(os *OracleServer) {
+		os.tls = cfg
+	}
+}
+
+// WithAdminListener serves the admin API on the given listener, over TLS if a TLS config is
+// given, rather than on the oracle's listener.
+func WithAdminListener(ln net.Listener, cfg *tls.Config) ServerOption {
+	return func(os *OracleServer) {
+		os.adminLn = ln
+		os.adminTLS = cfg
+	}
+}
+
+// NewTLSConfig returns the TLS config of a listener, or nil if TLS is not enabled. If the config
+// sets client certificate authorities, clients must present a certificate signed by one of them.
+func NewTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate authorities: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.ClientCAFile)
+		}
+
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsCfg, nil
+}
+
+// authenticate returns a handler that serves the given handler to requests that are granted the
+// read role, and responds with a 401 to all other requests. The health endpoint, and the admin
+// API, which authorizes its own requests, are served to every request.
+func (os *OracleServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case os.auth == nil,
+			r.URL.Path == HealthPath,
+			strings.HasPrefix(r.URL.Path, adminPathPrefix),
+			os.auth.Authorize(r, config.RoleRead):
+			next.ServeHTTP(w, r)
+		default:
+			unauthorized(w)
+		}
+	})
+}
+
+// unauthorized responds with a 401 that asks the client for a bearer token.
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
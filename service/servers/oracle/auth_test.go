@@ -0,0 +1,285 @@
+package oracle_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	server "github.com/skip-mev/connect/v2/service/servers/oracle"
+	stypes "github.com/skip-mev/connect/v2/service/servers/oracle/types"
+)
+
+func (s *ServerTestSuite) TestOracleServerAuth() {
+	s.T().Setenv("CONNECT_TEST_READ_TOKEN", "reader")
+	s.T().Setenv("CONNECT_TEST_ADMIN_TOKEN", "admin")
+	auth, err := server.NewAuthenticator(config.OracleConfig{
+		Server: config.ListenerConfig{
+			Auth: config.AuthConfig{
+				Enabled: true,
+				Tokens: []config.TokenConfig{
+					{Source: "env:CONNECT_TEST_READ_TOKEN", Role: config.RoleRead},
+				},
+			},
+		},
+		Admin: config.AdminConfig{Enabled: true, TokenSource: "env:CONNECT_TEST_ADMIN_TOKEN"},
+	})
+	s.Require().NoError(err)
+
+	ctrl := &controller{markets: map[string]bool{}, providers: map[string]bool{}}
+	s.mockOracle.EXPECT().IsRunning().Return(false).Maybe()
+	s.mockOracle.EXPECT().GetLastSyncTime().Return(time.Time{}).Maybe()
+
+	// start a second server that requires a token
+	srv := server.NewOracleServer(s.mockOracle, zap.NewNop(), server.WithAuth(auth), server.WithAdmin(ctrl, nil))
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	get := func(path, token string) int {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s%s", ln.Addr().String(), path), nil)
+		s.Require().NoError(err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		var resp *http.Response
+		s.Require().Eventually(func() bool {
+			resp, err = s.httpClient.Do(req)
+			return err == nil
+		}, 5*time.Second, 100*time.Millisecond)
+		resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	// the health endpoint does not require a token.
+	s.Require().Equal(http.StatusServiceUnavailable, get(server.HealthPath, ""))
+
+	// the price apis, including the grpc gateway, require a token with the read or admin role.
+	for _, path := range []string{server.ProvidersPath, "/connect/oracle/v2/version"} {
+		s.Require().Equal(http.StatusUnauthorized, get(path, ""), path)
+		s.Require().Equal(http.StatusUnauthorized, get(path, "wrong"), path)
+	}
+	s.mockOracle.EXPECT().GetProviderState().Return(nil).Maybe()
+	for _, token := range []string{"reader", "admin"} {
+		s.Require().Equal(http.StatusOK, get(server.ProvidersPath, token), token)
+		s.Require().Equal(http.StatusOK, get("/connect/oracle/v2/version", token), token)
+	}
+
+	// the admin api requires a token with the admin role.
+	s.Require().Equal(http.StatusUnauthorized, get(server.PausedPairsPath, "reader"))
+	s.Require().Equal(http.StatusOK, get(server.PausedPairsPath, "admin"))
+
+	// grpc requests require a token.
+	conn, err := grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	defer conn.Close()
+	client := stypes.NewOracleClient(conn)
+
+	_, err = client.Version(context.Background(), &stypes.QueryVersionRequest{})
+	s.Require().Equal(codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer reader")
+	_, err = client.Version(ctx, &stypes.QueryVersionRequest{})
+	s.Require().NoError(err)
+}
+
+func (s *ServerTestSuite) TestOracleServerTLS() {
+	certs := newTestCerts(s.T())
+
+	tlsCfg, err := server.NewTLSConfig(config.TLSConfig{
+		Enabled:      true,
+		CertFile:     certs.serverCert,
+		KeyFile:      certs.serverKey,
+		ClientCAFile: certs.caCert,
+	})
+	s.Require().NoError(err)
+
+	s.T().Setenv("CONNECT_TEST_ADMIN_TOKEN", "admin")
+	auth, err := server.NewAuthenticator(config.OracleConfig{
+		Admin: config.AdminConfig{Enabled: true, TokenSource: "env:CONNECT_TEST_ADMIN_TOKEN"},
+	})
+	s.Require().NoError(err)
+
+	adminLn, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+
+	// start a second server over mutual tls with the admin api on a plaintext listener
+	ctrl := &controller{markets: map[string]bool{}, providers: map[string]bool{}}
+	srv := server.NewOracleServer(
+		s.mockOracle,
+		zap.NewNop(),
+		server.WithTLS(tlsCfg),
+		server.WithAuth(auth),
+		server.WithAdmin(ctrl, nil),
+		server.WithAdminListener(adminLn, nil),
+	)
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	newClient := func(cert []tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: certs.pool, Certificates: cert, MinVersion: tls.VersionTLS12},
+		}}
+	}
+	url := fmt.Sprintf("https://%s/connect/oracle/v2/version", ln.Addr().String())
+
+	// clients must present a certificate signed by the client certificate authority.
+	var resp *http.Response
+	s.Require().Eventually(func() bool {
+		resp, err = newClient([]tls.Certificate{certs.client}).Get(url)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	_, err = newClient(nil).Get(url)
+	s.Require().Error(err)
+
+	// the admin api is only served on the admin listener.
+	resp, err = newClient([]tls.Certificate{certs.client}).Get(fmt.Sprintf("https://%s%s", ln.Addr().String(), server.PausedPairsPath))
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusNotFound, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s%s", adminLn.Addr().String(), server.PausedPairsPath), nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer admin")
+	resp, err = s.httpClient.Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certs := newTestCerts(t)
+
+	tlsCfg, err := server.NewTLSConfig(config.TLSConfig{})
+	require.NoError(t, err)
+	require.Nil(t, tlsCfg)
+
+	tlsCfg, err = server.NewTLSConfig(config.TLSConfig{Enabled: true, CertFile: certs.serverCert, KeyFile: certs.serverKey})
+	require.NoError(t, err)
+	require.Equal(t, tls.NoClientCert, tlsCfg.ClientAuth)
+
+	tlsCfg, err = server.NewTLSConfig(config.TLSConfig{
+		Enabled:      true,
+		CertFile:     certs.serverCert,
+		KeyFile:      certs.serverKey,
+		ClientCAFile: certs.caCert,
+	})
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, tlsCfg.ClientAuth)
+
+	_, err = server.NewTLSConfig(config.TLSConfig{Enabled: true, CertFile: certs.serverCert, KeyFile: certs.caCert})
+	require.Error(t, err)
+
+	_, err = server.NewTLSConfig(config.TLSConfig{
+		Enabled:      true,
+		CertFile:     certs.serverCert,
+		KeyFile:      certs.serverKey,
+		ClientCAFile: certs.serverKey,
+	})
+	require.Error(t, err)
+}
+
+// testCerts are a certificate authority, and a server and client certificate signed by it.
+type testCerts struct {
+	caCert     string
+	serverCert string
+	serverKey  string
+	client     tls.Certificate
+	pool       *x509.CertPool
+}
+
+// newTestCerts writes a certificate authority and a server certificate for localhost to a
+// temporary directory, and returns them along with a client certificate.
+func newTestCerts(t *testing.T) testCerts {
+	t.Helper()
+	dir := t.TempDir()
+
+	newKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		return key
+	}
+	writePEM := func(name, typ string, der []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+		return path
+	}
+
+	caKey := newKey()
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "connect test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	sign := func(serial int64, usage x509.ExtKeyUsage, key *ecdsa.PrivateKey) []byte {
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: localhost},
+			DNSNames:     []string{localhost},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		return der
+	}
+
+	serverKey := newKey()
+	serverKeyDER, err := x509.MarshalECPrivateKey(serverKey)
+	require.NoError(t, err)
+
+	clientKey := newKey()
+	clientDER := sign(3, x509.ExtKeyUsageClientAuth, clientKey)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	return testCerts{
+		caCert:     writePEM("ca.crt", "CERTIFICATE", caDER),
+		serverCert: writePEM("server.crt", "CERTIFICATE", sign(2, x509.ExtKeyUsageServerAuth, serverKey)),
+		serverKey:  writePEM("server.key", "EC PRIVATE KEY", serverKeyDER),
+		client:     tls.Certificate{Certificate: [][]byte{clientDER}, PrivateKey: clientKey},
+		pool:       pool,
+	}
+}
@@ -0,0 +1,98 @@
+package oracle
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/connect/v2/oracle/breaker"
+)
+
+// BreakersPath is the path of the admin endpoint that lists the halted markets of the circuit
+// breaker. The breaker of a halted market is cleared by a DELETE request to the path of the
+// market, e.g. DELETE /admin/breakers/BTC/USD.
+const BreakersPath = "/admin/breakers"
+
+// BreakersResponse is the response of the breakers endpoint.
+type BreakersResponse struct {
+	// Halted are the markets that are currently halted, sorted by ticker.
+	Halted []HaltedMarket `json:"halted"`
+}
+
+// HaltedMarket is a market whose price is not published because its aggregated price moved too
+// far too quickly. The prices are scaled to the decimals of the market.
+type HaltedMarket struct {
+	// Ticker is the ticker of the market.
+	Ticker string `json:"ticker"`
+	// Since is the time the market was halted.
+	Since time.Time `json:"since"`
+	// LastPrice is the last price published before the move.
+	LastPrice string `json:"last_price"`
+	// ReferencePrice is the price published within the window that the move is measured from.
+	ReferencePrice string `json:"reference_price"`
+	// Price is the latest aggregated price of the market.
+	Price string `json:"price"`
+	// Move is the fraction by which the latest aggregated price deviates from the reference price.
+	Move float64 `json:"move"`
+	// Ticks is the number of aggregations since the market was halted in which the move persisted.
+	Ticks int `json:"ticks"`
+	// Held is true if the last price is published while the market is halted.
+	Held bool `json:"held"`
+}
+
+// WithCircuitBreaker sets the circuit breaker of the oracle's prices. The halted markets are
+// flagged in the prices endpoint, and listed and cleared by the breakers endpoint of the admin API,
+// if served.
+func WithCircuitBreaker(b *breaker.Aggregator) ServerOption {
+	return func(os *OracleServer) {
+		os.breaker = b
+	}
+}
+
+// halted returns the tickers of the markets that are currently halted, or nil if the oracle does
+// not have a circuit breaker.
+func (os *OracleServer) halted() []string {
+	if os.breaker == nil {
+		return nil
+	}
+
+	var tickers []string
+	for _, halt := range os.breaker.GetHalted() {
+		tickers = append(tickers, halt.Ticker)
+	}
+
+	return tickers
+}
+
+// serveBreakers lists the halted markets of the circuit breaker, or clears the breaker of the
+// market named by the path on DELETE requests.
+func (os *OracleServer) serveBreakers(w http.ResponseWriter, r *http.Request) {
+	ticker := strings.ToUpper(adminResource(r, BreakersPath))
+	switch {
+	case r.Method == http.MethodGet && ticker == "":
+	case r.Method == http.MethodDelete && ticker != "":
+		if err := os.breaker.Clear(ticker); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp := BreakersResponse{Halted: make([]HaltedMarket, 0)}
+	for _, halt := range os.breaker.GetHalted() {
+		resp.Halted = append(resp.Halted, HaltedMarket{
+			Ticker:         halt.Ticker,
+			Since:          halt.Since,
+			LastPrice:      halt.LastPrice.Text('f', 0),
+			ReferencePrice: halt.Reference.Text('f', 0),
+			Price:          halt.Price.Text('f', 0),
+			Move:           halt.Move,
+			Ticks:          halt.Ticks,
+			Held:           halt.Held,
+		})
+	}
+
+	os.writeJSON(w, http.StatusOK, resp)
+}
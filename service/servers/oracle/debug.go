@@ -0,0 +1,89 @@
+package oracle
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/skip-mev/connect/v2/providers/base/debug"
+)
+
+// DebugProvidersPath is the path prefix of the endpoint serving the most recent raw responses and
+// decoded values of a provider, e.g. /debug/providers/binance_api.
+const DebugProvidersPath = "/debug/providers/"
+
+// ProviderDebugResponse is the response of the provider debug endpoint.
+type ProviderDebugResponse struct {
+	// Name is the name of the provider.
+	Name string `json:"name"`
+	// Health is the health status of the provider. The decoded values of a provider that is not
+	// healthy are not served.
+	Health string `json:"health"`
+	// Tickers are the most recent raw response and decoded value of each ticker of the provider,
+	// indexed by off-chain ticker.
+	Tickers map[string]TickerDebug `json:"tickers"`
+}
+
+// TickerDebug is the most recent raw response and decoded value of a provider for a ticker.
+type TickerDebug struct {
+	// Response is the most recent raw response of the provider's upstream data source that carried
+	// the ticker. This is only recorded for providers that fetch data over HTTP or websockets.
+	Response *debug.Response `json:"response,omitempty"`
+	// Price is the latest price decoded by the provider.
+	Price *ProviderPrice `json:"price,omitempty"`
+	// Error is the latest error of the provider for the ticker.
+	Error *ProviderError `json:"error,omitempty"`
+}
+
+// WithProviderDebug sets the recorder of the raw responses of the providers, and serves the
+// provider debug endpoint.
+func WithProviderDebug(recorder *debug.Recorder) ServerOption {
+	return func(os *OracleServer) {
+		os.debug = recorder
+	}
+}
+
+// serveProviderDebug serves the most recent raw responses and decoded values of each ticker of the
+// provider named by the path, e.g. /debug/providers/binance_api.
+func (os *OracleServer) serveProviderDebug(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, DebugProvidersPath)
+	if name == "" {
+		http.Error(w, "provider name is required", http.StatusBadRequest)
+		return
+	}
+
+	state, ok := os.o.GetProviderState()[name]
+	if !ok || state.Provider == nil {
+		http.Error(w, "unknown provider "+name, http.StatusNotFound)
+		return
+	}
+	provider := state.Provider
+
+	resp := ProviderDebugResponse{
+		Name:    name,
+		Health:  string(provider.Health()),
+		Tickers: make(map[string]TickerDebug),
+	}
+
+	for ticker, response := range os.debug.Responses(name) {
+		response := response
+		resp.Tickers[ticker] = TickerDebug{Response: &response}
+	}
+
+	for ticker, result := range provider.GetData() {
+		price := toProviderPrice(result)
+		td := resp.Tickers[ticker.GetOffChainTicker()]
+		td.Price = &price
+		resp.Tickers[ticker.GetOffChainTicker()] = td
+	}
+
+	for ticker, result := range provider.GetErrors() {
+		td := resp.Tickers[ticker.GetOffChainTicker()]
+		td.Error = &ProviderError{
+			Code:  int(result.Code()),
+			Error: result.Error(),
+		}
+		resp.Tickers[ticker.GetOffChainTicker()] = td
+	}
+
+	os.writeJSON(w, http.StatusOK, resp)
+}
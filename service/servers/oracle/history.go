@@ -0,0 +1,100 @@
+package oracle
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/history"
+)
+
+const (
+	// HistoryPath is the path of the endpoint serving the oracle's price history.
+	HistoryPath = "/history"
+
+	// DefaultHistoryLimit is the maximum number of records served by the history endpoint if the
+	// limit query parameter is not set.
+	DefaultHistoryLimit = 1000
+	// MaxHistoryLimit is the maximum number of records served by the history endpoint.
+	MaxHistoryLimit = 10000
+)
+
+// HistoryResponse is the response of the history endpoint.
+type HistoryResponse struct {
+	// CurrencyPair is the currency pair the records are of. This is empty if no currency pair was
+	// requested.
+	CurrencyPair string `json:"currency_pair,omitempty"`
+	// Records are the records of the currency pair in the requested range, in chronological
+	// order.
+	Records []history.Record `json:"records,omitempty"`
+	// CurrencyPairs are the currency pairs that have a history. This is only set if no currency
+	// pair was requested.
+	CurrencyPairs []string `json:"currency_pairs,omitempty"`
+}
+
+// WithHistory sets the store of the oracle's price history, and serves the history endpoint.
+func WithHistory(store *history.Store) ServerOption {
+	return func(os *OracleServer) {
+		os.history = store
+	}
+}
+
+// serveHistory serves the price history of a currency pair, e.g.
+// /history?pair=BTC/USD&from=2024-06-01T00:00:00Z&to=2024-06-01T01:00:00Z&limit=100. from and to
+// are RFC 3339 timestamps and are inclusive. If no pair is requested, the currency pairs that have
+// a history are served instead.
+func (os *OracleServer) serveHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	pair := strings.ToUpper(query.Get("pair"))
+	if pair == "" {
+		pairs, err := os.history.CurrencyPairs()
+		if err != nil {
+			os.logger.Error("failed to read price history", zap.Error(err))
+			http.Error(w, "failed to read price history", http.StatusInternalServerError)
+			return
+		}
+
+		os.writeJSON(w, http.StatusOK, HistoryResponse{CurrencyPairs: pairs})
+		return
+	}
+
+	var (
+		from, to time.Time
+		limit    = DefaultHistoryLimit
+		err      error
+	)
+	if param := query.Get("from"); param != "" {
+		if from, err = time.Parse(time.RFC3339, param); err != nil {
+			http.Error(w, "invalid from: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if param := query.Get("to"); param != "" {
+		if to, err = time.Parse(time.RFC3339, param); err != nil {
+			http.Error(w, "invalid to: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if param := query.Get("limit"); param != "" {
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 || limit > MaxHistoryLimit {
+			http.Error(w, "limit must be in [1, "+strconv.Itoa(MaxHistoryLimit)+"]", http.StatusBadRequest)
+			return
+		}
+	}
+
+	records, err := os.history.Query(pair, from, to, limit)
+	if err != nil {
+		os.logger.Error("failed to read price history", zap.String("pair", pair), zap.Error(err))
+		http.Error(w, "failed to read price history", http.StatusInternalServerError)
+		return
+	}
+
+	os.writeJSON(w, http.StatusOK, HistoryResponse{
+		CurrencyPair: pair,
+		Records:      records,
+	})
+}
@@ -0,0 +1,261 @@
+package oracle
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/cmd/build"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
+	"github.com/skip-mev/connect/v2/service/servers/oracle/types"
+)
+
+const (
+	// HealthPath is the path of the liveness endpoint of the oracle server.
+	HealthPath = "/health"
+	// PricesPath is the path of the endpoint serving the latest aggregated prices.
+	PricesPath = "/prices"
+	// ProvidersPath is the path of the endpoint serving the latest prices and errors of each
+	// provider.
+	ProvidersPath = "/providers"
+
+	// maxSyncAgeParam is the query parameter of the health endpoint that sets the maximum age of
+	// the oracle's last price update for the oracle to be considered healthy, e.g. ?max_sync_age=10s.
+	maxSyncAgeParam = "max_sync_age"
+)
+
+// HealthResponse is the response of the health endpoint.
+type HealthResponse struct {
+	// Healthy is true if the oracle is running and has updated its prices within the requested
+	// maximum sync age.
+	Healthy bool `json:"healthy"`
+	// Running is true if the oracle is running.
+	Running bool `json:"running"`
+	// LastSyncTime is the time of the oracle's last price update.
+	LastSyncTime time.Time `json:"last_sync_time"`
+	// Version is the version of the oracle.
+	Version string `json:"version"`
+}
+
+// PricesResponse is the response of the prices endpoint.
+type PricesResponse struct {
+	// Prices are the latest aggregated prices, indexed by currency pair.
+	Prices map[string]string `json:"prices"`
+	// Timestamp is the time of the oracle's last price update.
+	Timestamp time.Time `json:"timestamp"`
+	// Signature is the signature of the prices and timestamp by the oracle. This is only set if
+	// the oracle signs its price reports.
+	Signature []byte `json:"signature,omitempty"`
+	// PublicKey is the public key of the oracle that the signature can be verified with.
+	PublicKey []byte `json:"public_key,omitempty"`
+	// SignatureAlgorithm is the algorithm of the signature, i.e. ed25519 or secp256k1.
+	SignatureAlgorithm string `json:"signature_algorithm,omitempty"`
+	// Halted are the currency pairs whose price is halted by the circuit breaker. The price of a
+	// halted pair is either not served, or is the last price before the move that halted it. The
+	// halted pairs are not covered by the signature.
+	Halted []string `json:"halted,omitempty"`
+}
+
+// ProvidersResponse is the response of the providers endpoint.
+type ProvidersResponse struct {
+	// Providers is the status of each provider, sorted by name.
+	Providers []ProviderStatus `json:"providers"`
+}
+
+// ProviderStatus is the status of a single provider.
+type ProviderStatus struct {
+	// Name is the name of the provider.
+	Name string `json:"name"`
+	// Type is the type of the provider, i.e. api or websockets.
+	Type string `json:"type"`
+	// Running is true if the provider is running.
+	Running bool `json:"running"`
+	// Health is the health status of the provider, i.e. healthy, quarantined or probing. The
+	// prices of a provider that is not healthy are not used by the oracle.
+	Health string `json:"health"`
+	// Disabled is true if the provider was disabled via the admin API. The prices of a disabled
+	// provider are not used by the oracle.
+	Disabled bool `json:"disabled"`
+	// Prices are the latest prices reported by the provider, indexed by off-chain ticker.
+	Prices map[string]ProviderPrice `json:"prices"`
+	// Errors are the latest errors of the tickers the provider failed to fetch prices for since
+	// the last successful fetch, indexed by off-chain ticker.
+	Errors map[string]ProviderError `json:"errors"`
+}
+
+// ProviderPrice is the latest price reported by a provider for a ticker.
+type ProviderPrice struct {
+	// Price is the unscaled price.
+	Price string `json:"price"`
+	// Timestamp is the time the price was fetched.
+	Timestamp time.Time `json:"timestamp"`
+	// BlockNumber is the number of the block the price was read at, for prices read from a chain.
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	// BlockTimestamp is the timestamp of the block the price was read at, for prices read from a
+	// chain.
+	BlockTimestamp *time.Time `json:"blockTimestamp,omitempty"`
+}
+
+// ProviderError is the latest error of a provider for a ticker.
+type ProviderError struct {
+	// Code is the error code.
+	Code int `json:"code"`
+	// Error is the error message.
+	Error string `json:"error"`
+}
+
+// registerHTTPHandlers registers the JSON endpoints of the oracle server on the given router.
+func (os *OracleServer) registerHTTPHandlers(router *http.ServeMux) {
+	router.HandleFunc(HealthPath, os.health)
+	router.HandleFunc(PricesPath, os.prices)
+	router.HandleFunc(ProvidersPath, os.providers)
+
+	if os.push.Enabled {
+		router.HandleFunc(PushPath, os.servePush)
+	}
+
+	if os.history != nil {
+		router.HandleFunc(HistoryPath, os.serveHistory)
+	}
+
+	if len(os.payloads) > 0 {
+		router.HandleFunc(PayloadPath, os.servePayload)
+	}
+
+	if os.debug != nil {
+		router.HandleFunc(DebugProvidersPath, os.serveProviderDebug)
+	}
+
+	// The admin API is served on the oracle's listener unless it has its own listener.
+	if os.adminLn == nil {
+		os.registerAdminHandlers(router)
+	}
+}
+
+// health serves the liveness of the oracle. This responds with a 503 if the oracle is not running,
+// has not updated its prices yet, or, if the max_sync_age query parameter is set, has not updated
+// its prices within the given duration.
+func (os *OracleServer) health(w http.ResponseWriter, r *http.Request) {
+	resp := HealthResponse{
+		Running:      os.o.IsRunning(),
+		LastSyncTime: os.o.GetLastSyncTime().UTC(),
+		Version:      build.Build,
+	}
+	resp.Healthy = resp.Running && !resp.LastSyncTime.IsZero()
+
+	if param := r.URL.Query().Get(maxSyncAgeParam); param != "" {
+		maxSyncAge, err := time.ParseDuration(param)
+		if err != nil {
+			http.Error(w, "invalid "+maxSyncAgeParam+": "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp.Healthy = resp.Healthy && time.Since(resp.LastSyncTime) <= maxSyncAge
+	}
+
+	status := http.StatusOK
+	if !resp.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	os.writeJSON(w, status, resp)
+}
+
+// prices serves the latest aggregated prices of the oracle, signed if the oracle signs its price
+// reports.
+func (os *OracleServer) prices(w http.ResponseWriter, _ *http.Request) {
+	report := &types.QueryPricesResponse{
+		Prices:    ToReqPrices(os.o.GetPrices()),
+		Timestamp: os.o.GetLastSyncTime().UTC(),
+	}
+	os.signPrices(report)
+
+	os.writeJSON(w, http.StatusOK, PricesResponse{
+		Prices:             report.Prices,
+		Timestamp:          report.Timestamp,
+		Signature:          report.Signature,
+		PublicKey:          report.PublicKey,
+		SignatureAlgorithm: report.SignatureAlgorithm,
+		Halted:             os.halted(),
+	})
+}
+
+// providers serves the latest prices and errors of each of the oracle's providers.
+func (os *OracleServer) providers(w http.ResponseWriter, _ *http.Request) {
+	state := os.o.GetProviderState()
+
+	resp := ProvidersResponse{
+		Providers: make([]ProviderStatus, 0, len(state)),
+	}
+
+	disabled := make(map[string]bool)
+	if os.controller != nil {
+		for _, name := range os.controller.GetDisabledProviders() {
+			disabled[name] = true
+		}
+	}
+	for name, s := range state {
+		provider := s.Provider
+		if provider == nil {
+			continue
+		}
+
+		status := ProviderStatus{
+			Name:     name,
+			Type:     string(provider.Type()),
+			Running:  provider.IsRunning(),
+			Health:   string(provider.Health()),
+			Disabled: disabled[name],
+			Prices:   make(map[string]ProviderPrice),
+			Errors:   make(map[string]ProviderError),
+		}
+
+		for ticker, result := range provider.GetData() {
+			status.Prices[ticker.GetOffChainTicker()] = toProviderPrice(result)
+		}
+
+		for ticker, result := range provider.GetErrors() {
+			status.Errors[ticker.GetOffChainTicker()] = ProviderError{
+				Code:  int(result.Code()),
+				Error: result.Error(),
+			}
+		}
+
+		resp.Providers = append(resp.Providers, status)
+	}
+
+	sort.Slice(resp.Providers, func(i, j int) bool {
+		return resp.Providers[i].Name < resp.Providers[j].Name
+	})
+
+	os.writeJSON(w, http.StatusOK, resp)
+}
+
+// toProviderPrice returns the given price of a provider as served by the oracle server.
+func toProviderPrice(result providertypes.ResolvedResult[*big.Float]) ProviderPrice {
+	price := ProviderPrice{
+		Price:     result.Value.String(),
+		Timestamp: result.Timestamp.UTC(),
+	}
+	if !result.Block.IsZero() {
+		blockTimestamp := result.Block.Timestamp.UTC()
+		price.BlockNumber = result.Block.Number
+		price.BlockTimestamp = &blockTimestamp
+	}
+
+	return price
+}
+
+// writeJSON writes the given response as JSON with the given status code.
+func (os *OracleServer) writeJSON(w http.ResponseWriter, status int, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		os.logger.Error("failed to write http response", zap.Error(err))
+	}
+}
@@ -0,0 +1,63 @@
+package oracle
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/payload"
+)
+
+// PayloadPath is the path of the endpoint serving the payloads of the oracle's target chains.
+const PayloadPath = "/payload"
+
+// PayloadResponse is the response of the payload endpoint.
+type PayloadResponse struct {
+	// Target is the name of the target chain the payload is built for.
+	Target string `json:"target"`
+	// Encoder is the name of the encoder of the payload, e.g. connect, json or evm.
+	Encoder string `json:"encoder"`
+	// Payload is the encoded payload.
+	Payload []byte `json:"payload"`
+	// Timestamp is the time of the oracle's last price update.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WithPayloads sets the builders of the payloads of the oracle's target chains, indexed by the
+// name of the target, and serves the payload endpoint.
+func WithPayloads(builders map[string]*payload.Builder) ServerOption {
+	return func(os *OracleServer) {
+		os.payloads = builders
+	}
+}
+
+// servePayload serves the payload of the requested target chain built from the oracle's latest
+// prices, e.g. /payload?target=dydx.
+func (os *OracleServer) servePayload(w http.ResponseWriter, r *http.Request) {
+	target := r.URL.Query().Get("target")
+	builder, ok := os.payloads[target]
+	if !ok {
+		// the names of the targets may have been lower-cased when the config was read.
+		builder, ok = os.payloads[strings.ToLower(target)]
+	}
+	if !ok {
+		http.Error(w, "unknown payload target "+target, http.StatusNotFound)
+		return
+	}
+
+	bz, err := builder.Build(os.o.GetPrices(), os.o.GetMarketMap())
+	if err != nil {
+		os.logger.Error("failed to build payload", zap.String("target", target), zap.Error(err))
+		http.Error(w, "failed to build payload", http.StatusInternalServerError)
+		return
+	}
+
+	os.writeJSON(w, http.StatusOK, PayloadResponse{
+		Target:    target,
+		Encoder:   builder.Encoder(),
+		Payload:   bz,
+		Timestamp: os.o.GetLastSyncTime().UTC(),
+	})
+}
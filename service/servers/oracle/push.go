@@ -0,0 +1,330 @@
+package oracle
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/service/servers/oracle/types"
+)
+
+const (
+	// PushPath is the path of the websocket push API of the oracle server.
+	PushPath = "/ws"
+
+	// PushMethodSubscribe subscribes a connection to the prices of a set of currency pairs.
+	PushMethodSubscribe = "subscribe"
+	// PushMethodUnsubscribe unsubscribes a connection from the prices of a set of currency pairs.
+	PushMethodUnsubscribe = "unsubscribe"
+
+	// PushTypePrices is the type of a message pushing updated prices to a client.
+	PushTypePrices = "prices"
+	// PushTypeSubscriptions is the type of a message acknowledging a subscription request. It
+	// holds the currency pairs the connection is subscribed to after the request.
+	PushTypeSubscriptions = "subscriptions"
+	// PushTypeError is the type of a message reporting an invalid request.
+	PushTypeError = "error"
+
+	// pushPingInterval is the interval at which connections are pinged. Connections that do not
+	// respond within two intervals are closed.
+	pushPingInterval = 30 * time.Second
+	// pushWriteTimeout is the timeout of writing a message to a connection.
+	pushWriteTimeout = 5 * time.Second
+)
+
+// PushRequest is a request sent by a client of the websocket push API.
+//
+//	{"method": "subscribe", "currency_pairs": ["BTC/USD", "ETH/USD"], "threshold": 0.001}
+type PushRequest struct {
+	// Method is either subscribe or unsubscribe.
+	Method string `json:"method"`
+	// CurrencyPairs are the currency pairs to (un)subscribe to.
+	CurrencyPairs []string `json:"currency_pairs"`
+	// Threshold is the minimum relative change of the prices of the subscribed pairs since they
+	// were last pushed for them to be pushed again. If unset, the server's default threshold is
+	// used. This is ignored when unsubscribing.
+	Threshold *float64 `json:"threshold,omitempty"`
+}
+
+// PushMessage is a message pushed to a client of the websocket push API.
+type PushMessage struct {
+	// Type is the type of the message, i.e. prices, subscriptions or error.
+	Type string `json:"type"`
+	// Prices are the updated prices of the subscribed currency pairs. Only pairs whose price
+	// changed by more than the threshold of their subscription are included.
+	Prices map[string]string `json:"prices,omitempty"`
+	// Timestamp is the time of the oracle's price update the prices are from.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// Signature, PublicKey and SignatureAlgorithm sign the prices and timestamp of the message,
+	// if the oracle signs its price reports.
+	Signature          []byte `json:"signature,omitempty"`
+	PublicKey          []byte `json:"public_key,omitempty"`
+	SignatureAlgorithm string `json:"signature_algorithm,omitempty"`
+	// CurrencyPairs are the currency pairs the connection is subscribed to.
+	CurrencyPairs []string `json:"currency_pairs,omitempty"`
+	// Error is the reason a request was rejected.
+	Error string `json:"error,omitempty"`
+}
+
+// WithPush enables the websocket push API of the server with the given config.
+func WithPush(cfg config.PushConfig) ServerOption {
+	return func(os *OracleServer) {
+		os.push = cfg
+	}
+}
+
+// pushUpgrader upgrades requests to the push API to websocket connections. Clients are not
+// restricted by origin, as the prices are public.
+var pushUpgrader = websocket.Upgrader{
+	CheckOrigin: func(_ *http.Request) bool { return true },
+}
+
+// subscription is the subscription of a connection to the price of a currency pair.
+type subscription struct {
+	// threshold is the minimum relative change of the price for it to be pushed.
+	threshold float64
+	// last is the last price pushed to the connection, or nil if no price was pushed yet.
+	last *big.Float
+}
+
+// pushConnections is the number of open connections to the push API.
+type pushConnections struct {
+	n atomic.Int64
+}
+
+// acquire reserves a connection, returning false if the maximum number of connections is open.
+func (c *pushConnections) acquire(limit int) bool {
+	if n := c.n.Add(1); limit > 0 && n > int64(limit) {
+		c.n.Add(-1)
+		return false
+	}
+
+	return true
+}
+
+// release releases a reserved connection.
+func (c *pushConnections) release() {
+	c.n.Add(-1)
+}
+
+// servePush upgrades the request to a websocket connection and pushes the prices of the pairs the
+// client subscribes to whenever they change by more than the threshold of the subscription. The
+// oracle is polled for updated prices at the same interval as price streams.
+func (os *OracleServer) servePush(w http.ResponseWriter, r *http.Request) {
+	if !os.pushConns.acquire(os.push.MaxConnections) {
+		http.Error(w, "too many connections", http.StatusServiceUnavailable)
+		return
+	}
+	defer os.pushConns.release()
+
+	conn, err := pushUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		os.logger.Debug("failed to upgrade push connection", zap.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	os.logger.Debug("push connection opened", zap.String("remote", r.RemoteAddr))
+
+	// requests are read in a separate goroutine, as websocket connections support a single
+	// concurrent reader and writer. The channel is closed once the connection is closed.
+	requests := make(chan PushRequest)
+	done := make(chan struct{})
+	defer close(done)
+	go os.readPushRequests(conn, requests, done)
+
+	ticker := time.NewTicker(StreamPricesPollInterval)
+	defer ticker.Stop()
+	ping := time.NewTicker(pushPingInterval)
+	defer ping.Stop()
+
+	var (
+		subscriptions = make(map[string]*subscription)
+		lastSync      time.Time
+	)
+	for {
+		select {
+		case <-os.Done():
+			return
+		case req, ok := <-requests:
+			if !ok {
+				os.logger.Debug("push connection closed by client", zap.String("remote", r.RemoteAddr))
+				return
+			}
+
+			msg := os.handlePushRequest(req, subscriptions)
+			if err := os.writePushMessage(conn, msg); err != nil {
+				return
+			}
+
+			// newly subscribed pairs are pushed without waiting for the next price update.
+			lastSync = time.Time{}
+		case <-ping.C:
+			deadline := time.Now().Add(pushWriteTimeout)
+			if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				os.logger.Debug("failed to ping push connection", zap.Error(err))
+				return
+			}
+		case <-ticker.C:
+			timestamp := os.o.GetLastSyncTime()
+			if len(subscriptions) == 0 || !timestamp.After(lastSync) {
+				continue
+			}
+			lastSync = timestamp
+
+			msg, ok := os.pushPrices(subscriptions, timestamp)
+			if !ok {
+				continue
+			}
+
+			if err := os.writePushMessage(conn, msg); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readPushRequests reads requests from the connection until it is closed, the client stops
+// responding to pings, or the connection is no longer served (i.e. done is closed). Requests that
+// cannot be decoded are forwarded with no method, so that an error is reported to the client.
+func (os *OracleServer) readPushRequests(conn *websocket.Conn, requests chan<- PushRequest, done <-chan struct{}) {
+	defer close(requests)
+
+	conn.SetReadLimit(1 << 16)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * pushPingInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * pushPingInterval))
+	})
+
+	for {
+		_, bz, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req PushRequest
+		if err := json.Unmarshal(bz, &req); err != nil {
+			req = PushRequest{}
+		}
+
+		select {
+		case requests <- req:
+		case <-done:
+			return
+		}
+	}
+}
+
+// handlePushRequest applies a subscription request to the subscriptions of a connection, and
+// returns the response to the request.
+func (os *OracleServer) handlePushRequest(req PushRequest, subscriptions map[string]*subscription) PushMessage {
+	threshold := os.push.Threshold
+	if req.Threshold != nil {
+		threshold = *req.Threshold
+	}
+
+	switch {
+	case req.Method != PushMethodSubscribe && req.Method != PushMethodUnsubscribe:
+		return PushMessage{Type: PushTypeError, Error: "method must be one of subscribe or unsubscribe"}
+	case len(req.CurrencyPairs) == 0:
+		return PushMessage{Type: PushTypeError, Error: "currency pairs cannot be empty"}
+	case threshold < 0:
+		return PushMessage{Type: PushTypeError, Error: "threshold cannot be negative"}
+	}
+
+	for _, cp := range req.CurrencyPairs {
+		cp = strings.ToUpper(cp)
+		if req.Method == PushMethodUnsubscribe {
+			delete(subscriptions, cp)
+			continue
+		}
+
+		// resubscribing to a pair updates its threshold, and pushes its price again.
+		subscriptions[cp] = &subscription{threshold: threshold}
+	}
+
+	msg := PushMessage{
+		Type:          PushTypeSubscriptions,
+		CurrencyPairs: make([]string, 0, len(subscriptions)),
+	}
+	for cp := range subscriptions {
+		msg.CurrencyPairs = append(msg.CurrencyPairs, cp)
+	}
+	sort.Strings(msg.CurrencyPairs)
+
+	return msg
+}
+
+// pushPrices returns the message pushing the prices of the subscribed pairs that changed by more
+// than the threshold of their subscription, and records them as pushed. This returns false if no
+// price changed by more than its threshold.
+func (os *OracleServer) pushPrices(subscriptions map[string]*subscription, timestamp time.Time) (PushMessage, bool) {
+	prices := os.o.GetPrices()
+
+	updated := make(map[string]*big.Float)
+	for cp, sub := range subscriptions {
+		price, ok := prices[cp]
+		if !ok || price == nil {
+			continue
+		}
+
+		if sub.last == nil || exceedsThreshold(sub.last, price, sub.threshold) {
+			updated[cp] = price
+		}
+	}
+
+	if len(updated) == 0 {
+		return PushMessage{}, false
+	}
+
+	report := &types.QueryPricesResponse{
+		Prices:    ToReqPrices(updated),
+		Timestamp: timestamp.UTC(),
+	}
+	os.signPrices(report)
+
+	for cp, price := range updated {
+		subscriptions[cp].last = price
+	}
+
+	return PushMessage{
+		Type:               PushTypePrices,
+		Prices:             report.Prices,
+		Timestamp:          &report.Timestamp,
+		Signature:          report.Signature,
+		PublicKey:          report.PublicKey,
+		SignatureAlgorithm: report.SignatureAlgorithm,
+	}, true
+}
+
+// writePushMessage writes a message to the connection.
+func (os *OracleServer) writePushMessage(conn *websocket.Conn, msg PushMessage) error {
+	_ = conn.SetWriteDeadline(time.Now().Add(pushWriteTimeout))
+	if err := conn.WriteJSON(msg); err != nil {
+		os.logger.Debug("failed to write to push connection", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// exceedsThreshold returns true if the relative change from last to price is greater than the
+// threshold. A zero threshold is exceeded by any change.
+func exceedsThreshold(last, price *big.Float, threshold float64) bool {
+	if last.Sign() == 0 {
+		return price.Sign() != 0
+	}
+
+	change := new(big.Float).Sub(price, last)
+	change.Quo(change, last).Abs(change)
+
+	return change.Cmp(big.NewFloat(threshold)) > 0
+}
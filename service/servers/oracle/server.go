@@ -2,6 +2,7 @@ package oracle
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,6 +11,8 @@ import (
 
 	gateway "github.com/cosmos/gogogateway"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
@@ -19,11 +22,25 @@ import (
 
 	"github.com/skip-mev/connect/v2/cmd/build"
 	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/breaker"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/history"
+	"github.com/skip-mev/connect/v2/oracle/payload"
+	"github.com/skip-mev/connect/v2/pkg/log"
+	"github.com/skip-mev/connect/v2/pkg/signing"
 	"github.com/skip-mev/connect/v2/pkg/sync"
+	"github.com/skip-mev/connect/v2/pkg/tracing"
+	"github.com/skip-mev/connect/v2/providers/base/debug"
 	"github.com/skip-mev/connect/v2/service/servers/oracle/types"
 )
 
-const DefaultServerShutdownTimeout = 3 * time.Second
+const (
+	DefaultServerShutdownTimeout = 3 * time.Second
+
+	// StreamPricesPollInterval is the interval at which price streams check the oracle for
+	// updated prices.
+	StreamPricesPollInterval = 100 * time.Millisecond
+)
 
 // OracleServer is the base implementation of the service.OracleServer interface, this is meant to
 // serve requests from a remote OracleClient.
@@ -47,22 +64,86 @@ type OracleServer struct { //nolint
 
 	// logger to log incoming requests
 	logger *zap.Logger
+
+	// signer signs the price reports served by the server. This is nil if price reports are not
+	// signed.
+	signer *signing.Signer
+
+	// push is the config of the websocket push API. The push API is only served if enabled.
+	push config.PushConfig
+
+	// pushConns is the number of open connections to the push API.
+	pushConns pushConnections
+
+	// history is the store of the oracle's price history. The history API is only served if this
+	// is set.
+	history *history.Store
+
+	// payloads are the builders of the payloads of the oracle's target chains, indexed by the
+	// name of the target. The payload API is only served if any are set.
+	payloads map[string]*payload.Builder
+
+	// debug records the raw responses of the providers. The provider debug API is only served if
+	// this is set.
+	debug *debug.Recorder
+
+	// breaker is the circuit breaker of the oracle's prices. Halted markets are only flagged if
+	// this is set.
+	breaker *breaker.Aggregator
+
+	// auth authorizes the requests of the server by the bearer token they present. Requests are
+	// not authorized, and the admin API is not served, if this is nil.
+	auth *Authenticator
+
+	// tls is the TLS config of the oracle's listener. The listener is served over TLS if this is
+	// set.
+	tls *tls.Config
+
+	// adminLn is the separate listener of the admin API, served over TLS if adminTLS is set. The
+	// admin API is served on the oracle's listener if this is nil.
+	adminLn  net.Listener
+	adminTLS *tls.Config
+
+	// adminSrv is the http server of the admin listener.
+	adminSrv *http.Server
+
+	// controller pauses the pairs and disables the providers of the oracle via the admin API.
+	controller oracle.Controller
+
+	// logLevels are the log levels of the oracle that are set via the admin API.
+	logLevels *log.Levels
+}
+
+// ServerOption is a functional option for the oracle server.
+type ServerOption func(*OracleServer)
+
+// WithSigner sets the signer that the server signs its price reports with.
+func WithSigner(signer *signing.Signer) ServerOption {
+	return func(os *OracleServer) {
+		os.signer = signer
+	}
 }
 
 // NewOracleServer returns a new instance of the OracleServer, given an implementation of the Oracle interface.
-func NewOracleServer(o oracle.Oracle, logger *zap.Logger) *OracleServer {
+func NewOracleServer(o oracle.Oracle, logger *zap.Logger, opts ...ServerOption) *OracleServer {
 	logger = logger.With(zap.String("server", "oracle"))
 
 	os := &OracleServer{
 		o:      o,
 		logger: logger,
 	}
+	for _, opt := range opts {
+		opt(os)
+	}
 	os.Closer = sync.NewCloser().WithCallback(func() {
 		// if the server has been started, close it
 		if os.httpSrv != nil {
 			ctx, cf := context.WithTimeout(context.Background(), DefaultServerShutdownTimeout)
 			os.httpSrv.Shutdown(ctx) // close HTTP server backing GRPC-gateway
 			os.grpcSrv.Stop()        // close GRPC server serving listeners that have been routed to GRPC server
+			if os.adminSrv != nil {
+				os.adminSrv.Shutdown(ctx) // close HTTP server of the admin listener
+			}
 			cf()
 		}
 	})
@@ -89,7 +170,7 @@ func (os *OracleServer) StartServerWithListener(ctx context.Context, ln net.List
 		ReadHeaderTimeout: DefaultServerShutdownTimeout,
 	}
 	// create grpc server
-	os.grpcSrv = grpc.NewServer()
+	os.grpcSrv = grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
 	// register oracle server
 	types.RegisterOracleServer(os.grpcSrv, os)
 
@@ -102,15 +183,36 @@ func (os *OracleServer) StartServerWithListener(ctx context.Context, ln net.List
 			OrigName:     true,
 		}),
 	)
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithNoProxy()}
-	err := types.RegisterOracleHandlerFromEndpoint(ctx, os.gatewayMux, ln.Addr().String(), opts)
+	var err error
+	if os.tls != nil {
+		// The gateway cannot dial the server's TLS listener without a client certificate, so it
+		// calls the server in-process. The gateway only serves unary methods, which are supported
+		// in-process.
+		err = types.RegisterOracleHandlerServer(ctx, os.gatewayMux, os)
+	} else {
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithNoProxy()}
+		err = types.RegisterOracleHandlerFromEndpoint(ctx, os.gatewayMux, ln.Addr().String(), opts)
+	}
 	if err != nil {
 		return err
 	}
 
 	router := http.NewServeMux()
+	os.registerHTTPHandlers(router)
 	router.HandleFunc("/", os.routeRequest)
-	os.httpSrv.Handler = h2c.NewHandler(router, &http2.Server{})
+	os.httpSrv.Handler = h2c.NewHandler(os.authenticate(router), &http2.Server{})
+	os.httpSrv.TLSConfig = os.tls
+
+	// serve the admin api on its own listener if configured.
+	if os.adminLn != nil {
+		adminRouter := http.NewServeMux()
+		os.registerAdminHandlers(adminRouter)
+		os.adminSrv = &http.Server{
+			Handler:           adminRouter,
+			ReadHeaderTimeout: DefaultServerShutdownTimeout,
+			TLSConfig:         os.adminTLS,
+		}
+	}
 
 	eg, ctx := errgroup.WithContext(ctx)
 
@@ -137,7 +239,7 @@ func (os *OracleServer) StartServerWithListener(ctx context.Context, ln net.List
 			zap.String("port", port),
 		)
 
-		err = os.httpSrv.Serve(ln)
+		err = serve(os.httpSrv, ln)
 		if err != nil {
 			return fmt.Errorf("[grpc server]: error serving: %w", err)
 		}
@@ -145,10 +247,32 @@ func (os *OracleServer) StartServerWithListener(ctx context.Context, ln net.List
 		return nil
 	})
 
+	// start the admin server
+	if os.adminSrv != nil {
+		eg.Go(func() error {
+			os.logger.Info("starting admin server", zap.String("address", os.adminLn.Addr().String()))
+
+			if err := serve(os.adminSrv, os.adminLn); err != nil {
+				return fmt.Errorf("[admin server]: error serving: %w", err)
+			}
+
+			return nil
+		})
+	}
+
 	// wait for everything to finish
 	return eg.Wait()
 }
 
+// serve serves the given server on the given listener, over TLS if the server has a TLS config.
+func serve(srv *http.Server, ln net.Listener) error {
+	if srv.TLSConfig != nil {
+		return srv.ServeTLS(ln, "", "")
+	}
+
+	return srv.Serve(ln)
+}
+
 // StartServer starts the oracle gRPC server on the given host and port. The server is killed on any errors from the listener, or if ctx is cancelled.
 // This method returns an error via any failure from the listener. This is a blocking call, i.e. until the server is closed or the server errors,
 // this method will block.
@@ -181,17 +305,24 @@ func (os *OracleServer) Prices(ctx context.Context, req *types.QueryPricesReques
 
 	// run the request in a goroutine, to unblock server + ctx cancellation
 	go func() {
+		_, span := tracing.Start(ctx, "oracle.get_prices")
+		defer span.End()
+
 		// get the prices
 		prices := os.o.GetPrices()
+		span.SetAttributes(attribute.Int("prices", len(prices)))
 
 		// get the latest timestamp of the latest update from the oracle
 		timestamp := os.o.GetLastSyncTime()
 
-		resCh <- &types.QueryPricesResponse{
+		resp := &types.QueryPricesResponse{
 			Prices:    ToReqPrices(prices),
 			Timestamp: timestamp,
 			Version:   build.Build,
 		}
+		os.signPrices(resp)
+
+		resCh <- resp
 	}()
 
 	// defer to context closure
@@ -204,6 +335,88 @@ func (os *OracleServer) Prices(ctx context.Context, req *types.QueryPricesReques
 	}
 }
 
+// StreamPrices streams the latest prices of the underlying oracle to the client. A response is sent
+// each time the oracle updates its prices, until the client cancels the stream or the server is
+// closed. If the request specifies currency pairs, only the prices of those pairs are streamed.
+func (os *OracleServer) StreamPrices(req *types.StreamPricesRequest, stream types.Oracle_StreamPricesServer) error {
+	// check that the request is non-nil
+	if req == nil {
+		return ErrNilRequest
+	}
+
+	os.logger.Debug("received request to stream prices", zap.Strings("currency_pairs", req.CurrencyPairs))
+
+	// check that oracle is running
+	if !os.o.IsRunning() {
+		os.logger.Error("oracle not running")
+		return ErrOracleNotRunning
+	}
+
+	pairs := make(map[string]struct{}, len(req.CurrencyPairs))
+	for _, cp := range req.CurrencyPairs {
+		pairs[strings.ToUpper(cp)] = struct{}{}
+	}
+
+	ticker := time.NewTicker(StreamPricesPollInterval)
+	defer ticker.Stop()
+
+	var lastSync time.Time
+	for {
+		// only send the prices once the oracle has updated them
+		if timestamp := os.o.GetLastSyncTime(); timestamp.After(lastSync) {
+			prices := ToReqPrices(os.o.GetPrices())
+			if len(pairs) > 0 {
+				for cp := range prices {
+					if _, ok := pairs[cp]; !ok {
+						delete(prices, cp)
+					}
+				}
+			}
+
+			resp := &types.QueryPricesResponse{
+				Prices:    prices,
+				Timestamp: timestamp,
+				Version:   build.Build,
+			}
+			os.signPrices(resp)
+
+			if err := stream.Send(resp); err != nil {
+				os.logger.Debug("failed to send prices to stream", zap.Error(err))
+				return err
+			}
+
+			lastSync = timestamp
+		}
+
+		select {
+		case <-stream.Context().Done():
+			os.logger.Debug("price stream closed by client")
+			return nil
+		case <-os.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// signPrices signs the prices and timestamp of the response, if the server signs its price
+// reports. If the prices cannot be signed, the response is left unsigned.
+func (os *OracleServer) signPrices(resp *types.QueryPricesResponse) {
+	if os.signer == nil {
+		return
+	}
+
+	signature, err := os.signer.Sign(resp.Prices, resp.Timestamp)
+	if err != nil {
+		os.logger.Error("failed to sign prices", zap.Error(err))
+		return
+	}
+
+	resp.Signature = signature
+	resp.PublicKey = os.signer.PublicKey()
+	resp.SignatureAlgorithm = os.signer.Algorithm()
+}
+
 // MarketMap returns the current market map from the Oracle.
 func (os *OracleServer) MarketMap(_ context.Context, _ *types.QueryMarketMapRequest) (*types.QueryMarketMapResponse, error) {
 	mm := os.o.GetMarketMap()
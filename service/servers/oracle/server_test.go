@@ -2,22 +2,40 @@ package oracle_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"net"
 	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"cosmossdk.io/log"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect/v2/oracle"
+	"github.com/skip-mev/connect/v2/oracle/breaker"
+	"github.com/skip-mev/connect/v2/oracle/config"
+	"github.com/skip-mev/connect/v2/oracle/history"
 	"github.com/skip-mev/connect/v2/oracle/mocks"
+	"github.com/skip-mev/connect/v2/oracle/payload"
 	"github.com/skip-mev/connect/v2/oracle/types"
+	connectlog "github.com/skip-mev/connect/v2/pkg/log"
+	"github.com/skip-mev/connect/v2/pkg/signing"
 	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/skip-mev/connect/v2/providers/apis/coinbase"
+	"github.com/skip-mev/connect/v2/providers/base"
+	apihandlermocks "github.com/skip-mev/connect/v2/providers/base/api/handlers/mocks"
+	"github.com/skip-mev/connect/v2/providers/base/debug"
+	providertypes "github.com/skip-mev/connect/v2/providers/types"
 	client "github.com/skip-mev/connect/v2/service/clients/oracle"
 	"github.com/skip-mev/connect/v2/service/metrics"
 	server "github.com/skip-mev/connect/v2/service/servers/oracle"
@@ -184,6 +202,57 @@ func (s *ServerTestSuite) TestOracleServerPrices() {
 	s.Require().Contains(string(respBz), fmt.Sprintf(`{"prices":{"%s":"100","%s":"200"},"timestamp":`, cp1.String(), cp2.String()))
 }
 
+func (s *ServerTestSuite) TestOracleServerStreamPrices() {
+	s.mockOracle.EXPECT().IsRunning().Return(true)
+	cp1 := mmtypes.Ticker{
+		CurrencyPair: connecttypes.CurrencyPair{
+			Base:  "BTC",
+			Quote: "USD",
+		},
+		Decimals: 8,
+	}
+
+	cp2 := mmtypes.Ticker{
+		CurrencyPair: connecttypes.CurrencyPair{
+			Base:  "ETH",
+			Quote: "USD",
+		},
+		Decimals: 8,
+	}
+
+	s.mockOracle.On("GetPrices").Return(types.Prices{
+		cp1.String(): big.NewFloat(100.1),
+		cp2.String(): big.NewFloat(200.1),
+	})
+	ts := time.Now()
+	s.mockOracle.On("GetLastSyncTime").Return(ts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// stream only the prices of the first currency pair
+	stream, err := s.client.StreamPrices(ctx, &stypes.StreamPricesRequest{
+		CurrencyPairs: []string{"btc/usd"},
+	})
+	s.Require().NoError(err)
+
+	resp, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().Len(resp.Prices, 1)
+	s.Require().Equal(big.NewInt(100).String(), resp.Prices[cp1.String()])
+	s.Require().Equal(ts.UTC(), resp.Timestamp)
+}
+
+func (s *ServerTestSuite) TestOracleServerStreamPricesNotRunning() {
+	s.mockOracle.EXPECT().IsRunning().Return(false)
+
+	stream, err := s.client.StreamPrices(context.Background(), &stypes.StreamPricesRequest{})
+	s.Require().NoError(err)
+
+	_, err = stream.Recv()
+	s.Require().Equal(grpcErrPrefix+server.ErrOracleNotRunning.Error(), err.Error())
+}
+
 func (s *ServerTestSuite) TestOracleMarketMap() {
 	dummyMarketMap := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
 		"foo": {
@@ -216,6 +285,717 @@ func (s *ServerTestSuite) TestOracleMarketMap() {
 	s.Require().Equal(*res.GetMarketMap(), dummyMarketMap)
 }
 
+func (s *ServerTestSuite) TestOracleServerHealth() {
+	s.Run("healthy", func() {
+		s.mockOracle.EXPECT().IsRunning().Return(true).Once()
+		s.mockOracle.EXPECT().GetLastSyncTime().Return(time.Now()).Once()
+
+		var resp server.HealthResponse
+		s.Require().Equal(http.StatusOK, s.getJSON(server.HealthPath+"?max_sync_age=1m", &resp))
+		s.Require().True(resp.Healthy)
+		s.Require().True(resp.Running)
+	})
+
+	s.Run("not running", func() {
+		s.mockOracle.EXPECT().IsRunning().Return(false).Once()
+		s.mockOracle.EXPECT().GetLastSyncTime().Return(time.Now()).Once()
+
+		var resp server.HealthResponse
+		s.Require().Equal(http.StatusServiceUnavailable, s.getJSON(server.HealthPath, &resp))
+		s.Require().False(resp.Healthy)
+		s.Require().False(resp.Running)
+	})
+
+	s.Run("not synced", func() {
+		s.mockOracle.EXPECT().IsRunning().Return(true).Once()
+		s.mockOracle.EXPECT().GetLastSyncTime().Return(time.Time{}).Once()
+
+		var resp server.HealthResponse
+		s.Require().Equal(http.StatusServiceUnavailable, s.getJSON(server.HealthPath, &resp))
+		s.Require().False(resp.Healthy)
+	})
+
+	s.Run("stale", func() {
+		s.mockOracle.EXPECT().IsRunning().Return(true).Once()
+		s.mockOracle.EXPECT().GetLastSyncTime().Return(time.Now().Add(-time.Minute)).Once()
+
+		var resp server.HealthResponse
+		s.Require().Equal(http.StatusServiceUnavailable, s.getJSON(server.HealthPath+"?max_sync_age=10s", &resp))
+		s.Require().False(resp.Healthy)
+		s.Require().True(resp.Running)
+	})
+
+	s.Run("invalid max sync age", func() {
+		s.mockOracle.EXPECT().IsRunning().Return(true).Once()
+		s.mockOracle.EXPECT().GetLastSyncTime().Return(time.Now()).Once()
+
+		httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s:%s%s?max_sync_age=foo", localhost, s.port, server.HealthPath))
+		s.Require().NoError(err)
+		defer httpResp.Body.Close()
+		s.Require().Equal(http.StatusBadRequest, httpResp.StatusCode)
+	})
+}
+
+func (s *ServerTestSuite) TestOracleServerHTTPPrices() {
+	cp := mmtypes.Ticker{
+		CurrencyPair: connecttypes.CurrencyPair{
+			Base:  "BTC",
+			Quote: "USD",
+		},
+		Decimals: 8,
+	}
+
+	ts := time.Now()
+	s.mockOracle.EXPECT().GetPrices().Return(types.Prices{
+		cp.String(): big.NewFloat(100.1),
+	})
+	s.mockOracle.EXPECT().GetLastSyncTime().Return(ts)
+
+	var resp server.PricesResponse
+	s.Require().Equal(http.StatusOK, s.getJSON(server.PricesPath, &resp))
+	s.Require().Equal(map[string]string{cp.String(): "100"}, resp.Prices)
+	s.Require().True(ts.Equal(resp.Timestamp))
+}
+
+func (s *ServerTestSuite) TestOracleServerSignedPrices() {
+	signer, err := signing.NewSigner(config.SigningAlgorithmEd25519, []byte(strings.Repeat("\x01", 32)))
+	s.Require().NoError(err)
+
+	// start a second server that signs its price reports
+	srv := server.NewOracleServer(s.mockOracle, zap.NewNop(), server.WithSigner(signer))
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	cp := connecttypes.NewCurrencyPair("BTC", "USD")
+	ts := time.Now().UTC()
+	s.mockOracle.EXPECT().IsRunning().Return(true)
+	s.mockOracle.EXPECT().GetPrices().Return(types.Prices{cp.String(): big.NewFloat(100.1)})
+	s.mockOracle.EXPECT().GetLastSyncTime().Return(ts)
+
+	// verify the report served over grpc
+	c, err := client.NewClient(
+		log.NewTestLogger(s.T()),
+		ln.Addr().String(),
+		timeout,
+		metrics.NewNopMetrics(),
+		client.WithBlockingDial(),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(c.Start(s.ctx))
+	defer c.Stop()
+
+	resp, err := c.Prices(context.Background(), &stypes.QueryPricesRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(config.SigningAlgorithmEd25519, resp.SignatureAlgorithm)
+	s.Require().Equal(signer.PublicKey(), resp.PublicKey)
+	s.Require().NoError(signing.Verify(resp.SignatureAlgorithm, resp.PublicKey, resp.Signature, resp.Prices, resp.Timestamp))
+
+	// verify the report served over http
+	httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s%s", ln.Addr().String(), server.PricesPath))
+	s.Require().NoError(err)
+	defer httpResp.Body.Close()
+
+	var report server.PricesResponse
+	s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(&report))
+	s.Require().Equal(signer.PublicKey(), report.PublicKey)
+	s.Require().NoError(signing.Verify(report.SignatureAlgorithm, report.PublicKey, report.Signature, report.Prices, report.Timestamp))
+
+	// the signature does not verify if the prices were tampered with
+	report.Prices[cp.String()] = "101"
+	s.Require().Error(signing.Verify(report.SignatureAlgorithm, report.PublicKey, report.Signature, report.Prices, report.Timestamp))
+}
+
+func (s *ServerTestSuite) TestOracleServerProviders() {
+	handler := apihandlermocks.NewQueryHandler[types.ProviderTicker, *big.Float](s.T())
+	provider, err := types.NewPriceProvider(
+		base.WithName[types.ProviderTicker, *big.Float]("coinbase"),
+		base.WithAPIQueryHandler[types.ProviderTicker, *big.Float](handler),
+		base.WithAPIConfig[types.ProviderTicker, *big.Float](coinbase.DefaultAPIConfig),
+	)
+	s.Require().NoError(err)
+
+	s.mockOracle.EXPECT().GetProviderState().Return(map[string]oracle.ProviderState{
+		"coinbase": {Provider: provider},
+		"binance":  {},
+	})
+
+	var resp server.ProvidersResponse
+	s.Require().Equal(http.StatusOK, s.getJSON(server.ProvidersPath, &resp))
+	s.Require().Len(resp.Providers, 1)
+	s.Require().Equal("coinbase", resp.Providers[0].Name)
+	s.Require().Equal(string(providertypes.API), resp.Providers[0].Type)
+	s.Require().False(resp.Providers[0].Running)
+	s.Require().Equal(string(providertypes.Healthy), resp.Providers[0].Health)
+	s.Require().Empty(resp.Providers[0].Prices)
+	s.Require().Empty(resp.Providers[0].Errors)
+}
+
+func (s *ServerTestSuite) TestOracleServerPush() {
+	// start a second server that serves the push api to a single connection
+	srv := server.NewOracleServer(s.mockOracle, zap.NewNop(), server.WithPush(config.PushConfig{
+		Enabled:        true,
+		Threshold:      0.01,
+		MaxConnections: 1,
+	}))
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	var (
+		mtx    sync.Mutex
+		price  = big.NewFloat(100)
+		synced = time.Now()
+	)
+	setPrice := func(p float64) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		price = big.NewFloat(p)
+		synced = synced.Add(time.Second)
+	}
+
+	cp := connecttypes.NewCurrencyPair("BTC", "USD")
+	s.mockOracle.EXPECT().GetPrices().RunAndReturn(func() types.Prices {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return types.Prices{cp.String(): price}
+	}).Maybe()
+	s.mockOracle.EXPECT().GetLastSyncTime().RunAndReturn(func() time.Time {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return synced
+	}).Maybe()
+
+	url := fmt.Sprintf("ws://%s%s", ln.Addr().String(), server.PushPath)
+	var conn *websocket.Conn
+	s.Require().Eventually(func() bool {
+		conn, _, err = websocket.DefaultDialer.Dial(url, nil)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	defer conn.Close()
+
+	// connections beyond the maximum are rejected
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	s.Require().Error(err)
+	s.Require().Equal(http.StatusServiceUnavailable, resp.StatusCode)
+
+	read := func() server.PushMessage {
+		var msg server.PushMessage
+		s.Require().NoError(conn.SetReadDeadline(time.Now().Add(2 * time.Second)))
+		s.Require().NoError(conn.ReadJSON(&msg))
+		return msg
+	}
+
+	// invalid requests are rejected
+	s.Require().NoError(conn.WriteJSON(server.PushRequest{Method: "poll", CurrencyPairs: []string{"btc/usd"}}))
+	s.Require().Equal(server.PushTypeError, read().Type)
+
+	// the price of a newly subscribed pair is pushed immediately
+	s.Require().NoError(conn.WriteJSON(server.PushRequest{Method: server.PushMethodSubscribe, CurrencyPairs: []string{"btc/usd"}}))
+	msg := read()
+	s.Require().Equal(server.PushTypeSubscriptions, msg.Type)
+	s.Require().Equal([]string{cp.String()}, msg.CurrencyPairs)
+
+	msg = read()
+	s.Require().Equal(server.PushTypePrices, msg.Type)
+	s.Require().Equal(map[string]string{cp.String(): "100"}, msg.Prices)
+
+	// changes within the threshold are not pushed
+	setPrice(100.5)
+	time.Sleep(5 * server.StreamPricesPollInterval)
+	setPrice(102)
+
+	msg = read()
+	s.Require().Equal(map[string]string{cp.String(): "102"}, msg.Prices)
+
+	// unsubscribing stops the prices from being pushed
+	s.Require().NoError(conn.WriteJSON(server.PushRequest{Method: server.PushMethodUnsubscribe, CurrencyPairs: []string{"BTC/USD"}}))
+	msg = read()
+	s.Require().Equal(server.PushTypeSubscriptions, msg.Type)
+	s.Require().Empty(msg.CurrencyPairs)
+}
+
+func (s *ServerTestSuite) TestOracleServerHistory() {
+	store, err := history.Open(filepath.Join(s.T().TempDir(), "history.db"))
+	s.Require().NoError(err)
+	defer store.Close()
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		s.Require().NoError(store.Write(map[string]history.Record{
+			"BTC/USD": {
+				Timestamp: start.Add(time.Duration(i) * time.Minute),
+				Price:     big.NewFloat(float64(60000 + i)),
+				Providers: map[string]*big.Float{"coinbase": big.NewFloat(float64(60000 + i))},
+			},
+		}))
+	}
+
+	// start a second server that serves the price history
+	srv := server.NewOracleServer(s.mockOracle, zap.NewNop(), server.WithHistory(store))
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	get := func(query string) (int, server.HistoryResponse) {
+		httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s%s?%s", ln.Addr().String(), server.HistoryPath, query))
+		s.Require().NoError(err)
+		defer httpResp.Body.Close()
+
+		var resp server.HistoryResponse
+		if httpResp.StatusCode == http.StatusOK {
+			s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(&resp))
+		}
+		return httpResp.StatusCode, resp
+	}
+
+	status, resp := get("")
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal([]string{"BTC/USD"}, resp.CurrencyPairs)
+
+	status, resp = get("pair=btc/usd&from=2024-06-01T00:01:00Z&to=2024-06-01T00:02:00Z")
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Equal("BTC/USD", resp.CurrencyPair)
+	s.Require().Len(resp.Records, 2)
+	s.Require().Equal(start.Add(time.Minute), resp.Records[0].Timestamp)
+	s.Require().Equal("60001", resp.Records[0].Price.String())
+	s.Require().Equal("60001", resp.Records[0].Providers["coinbase"].String())
+
+	status, resp = get("pair=BTC/USD&limit=1")
+	s.Require().Equal(http.StatusOK, status)
+	s.Require().Len(resp.Records, 1)
+	s.Require().Equal(start, resp.Records[0].Timestamp)
+
+	status, _ = get("pair=BTC/USD&from=yesterday")
+	s.Require().Equal(http.StatusBadRequest, status)
+
+	status, _ = get("pair=BTC/USD&limit=0")
+	s.Require().Equal(http.StatusBadRequest, status)
+
+	// the history is not served by servers without a store
+	httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s:%s%s", localhost, s.port, server.HistoryPath))
+	s.Require().NoError(err)
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusNotFound, httpResp.StatusCode)
+}
+
+func (s *ServerTestSuite) TestOracleServerPayload() {
+	builder, err := payload.NewBuilder(config.PayloadTargetConfig{
+		Encoder:  config.PayloadEncoderJSON,
+		Decimals: 2,
+		PairIDs:  map[string]uint64{"BTC/USD": 7},
+	})
+	s.Require().NoError(err)
+
+	// start a second server that serves the payload of a target chain
+	srv := server.NewOracleServer(s.mockOracle, zap.NewNop(), server.WithPayloads(map[string]*payload.Builder{"chain": builder}))
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	ticker := mmtypes.NewTicker("BTC", "USD", 8, 1, true)
+	ts := time.Now().UTC()
+	s.mockOracle.EXPECT().GetPrices().Return(types.Prices{ticker.String(): big.NewFloat(6000012345678)})
+	s.mockOracle.EXPECT().GetMarketMap().Return(mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{ticker.String(): {Ticker: ticker}},
+	})
+	s.mockOracle.EXPECT().GetLastSyncTime().Return(ts)
+
+	get := func(target string) *http.Response {
+		httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s%s?target=%s", ln.Addr().String(), server.PayloadPath, target))
+		s.Require().NoError(err)
+		return httpResp
+	}
+
+	httpResp := get("chain")
+	defer httpResp.Body.Close()
+	s.Require().Equal(http.StatusOK, httpResp.StatusCode)
+
+	var resp server.PayloadResponse
+	s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(&resp))
+	s.Require().Equal("chain", resp.Target)
+	s.Require().Equal(config.PayloadEncoderJSON, resp.Encoder)
+	s.Require().True(ts.Equal(resp.Timestamp))
+	s.Require().JSONEq(`{"prices": [{"id": 7, "currency_pair": "BTC/USD", "price": "6000012", "exponent": -2}]}`, string(resp.Payload))
+
+	httpResp = get("unknown")
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusNotFound, httpResp.StatusCode)
+}
+
+func (s *ServerTestSuite) TestOracleServerProviderDebug() {
+	handler := apihandlermocks.NewQueryHandler[types.ProviderTicker, *big.Float](s.T())
+	provider, err := types.NewPriceProvider(
+		base.WithName[types.ProviderTicker, *big.Float]("coinbase"),
+		base.WithAPIQueryHandler[types.ProviderTicker, *big.Float](handler),
+		base.WithAPIConfig[types.ProviderTicker, *big.Float](coinbase.DefaultAPIConfig),
+	)
+	s.Require().NoError(err)
+
+	s.mockOracle.EXPECT().GetProviderState().Return(map[string]oracle.ProviderState{
+		"coinbase": {Provider: provider},
+	})
+
+	// the endpoint is not served unless enabled.
+	httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s:%s%scoinbase", localhost, s.port, server.DebugProvidersPath))
+	s.Require().NoError(err)
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusNotFound, httpResp.StatusCode)
+
+	// start a second server that serves the raw responses of the providers
+	recorder := debug.NewRecorder(0)
+	recorder.Record("coinbase", []string{"BTC-USD"}, "https://api.coinbase.com/v2/prices/BTC-USD/spot", http.StatusOK, []byte(`{"data":{"amount":"60000.00"}}`))
+	srv := server.NewOracleServer(s.mockOracle, zap.NewNop(), server.WithProviderDebug(recorder))
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	get := func(name string) *http.Response {
+		httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s%s%s", ln.Addr().String(), server.DebugProvidersPath, name))
+		s.Require().NoError(err)
+		return httpResp
+	}
+
+	httpResp = get("coinbase")
+	defer httpResp.Body.Close()
+	s.Require().Equal(http.StatusOK, httpResp.StatusCode)
+
+	var resp server.ProviderDebugResponse
+	s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(&resp))
+	s.Require().Equal("coinbase", resp.Name)
+	s.Require().Equal(string(providertypes.Healthy), resp.Health)
+	s.Require().Len(resp.Tickers, 1)
+
+	btc := resp.Tickers["BTC-USD"]
+	s.Require().NotNil(btc.Response)
+	s.Require().Equal("https://api.coinbase.com/v2/prices/BTC-USD/spot", btc.Response.Source)
+	s.Require().Equal(http.StatusOK, btc.Response.Status)
+	s.Require().JSONEq(`{"data":{"amount":"60000.00"}}`, btc.Response.Body)
+	s.Require().Nil(btc.Price)
+	s.Require().Nil(btc.Error)
+
+	httpResp = get("binance")
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusNotFound, httpResp.StatusCode)
+
+	httpResp = get("")
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusBadRequest, httpResp.StatusCode)
+}
+
+func (s *ServerTestSuite) TestOracleServerBreakers() {
+	s.T().Setenv("CONNECT_TEST_ADMIN_TOKEN", "secret")
+	cfg := config.CircuitBreakerConfig{
+		Enabled: true,
+		Default: config.CircuitBreakerPolicy{MaxMove: 0.1, Window: time.Minute, HoldLastPrice: true},
+	}
+
+	// halt BTC/USD by moving its price by 50%.
+	price := big.NewFloat(6000000000000)
+	wrapped := mocks.NewPriceAggregator(s.T())
+	wrapped.EXPECT().AggregatePrices().Return()
+	wrapped.EXPECT().GetPrices().RunAndReturn(func() types.Prices { return types.Prices{"BTC/USD": price} })
+
+	b, err := breaker.NewAggregator(zap.NewNop(), wrapped, cfg)
+	s.Require().NoError(err)
+	b.AggregatePrices()
+	price = big.NewFloat(9000000000000)
+	b.AggregatePrices()
+
+	auth, err := server.NewAuthenticator(config.OracleConfig{
+		Admin: config.AdminConfig{Enabled: true, TokenSource: "env:CONNECT_TEST_ADMIN_TOKEN"},
+	})
+	s.Require().NoError(err)
+
+	s.mockOracle.EXPECT().GetPrices().Return(b.GetPrices())
+	s.mockOracle.EXPECT().GetLastSyncTime().Return(time.Now())
+
+	// start a second server with the circuit breaker
+	srv := server.NewOracleServer(
+		s.mockOracle,
+		zap.NewNop(),
+		server.WithCircuitBreaker(b),
+		server.WithAuth(auth),
+	)
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	do := func(method, path, token string) *http.Response {
+		req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", ln.Addr().String(), path), nil)
+		s.Require().NoError(err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		httpResp, err := s.httpClient.Do(req)
+		s.Require().NoError(err)
+		return httpResp
+	}
+
+	// the last price is held and flagged as halted.
+	httpResp := do(http.MethodGet, server.PricesPath, "")
+	var prices server.PricesResponse
+	s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(&prices))
+	httpResp.Body.Close()
+	s.Require().Equal("6000000000000", prices.Prices["BTC/USD"])
+	s.Require().Equal([]string{"BTC/USD"}, prices.Halted)
+
+	// the admin api requires the admin token.
+	httpResp = do(http.MethodGet, server.BreakersPath, "")
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusUnauthorized, httpResp.StatusCode)
+
+	httpResp = do(http.MethodGet, server.BreakersPath, "wrong")
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusUnauthorized, httpResp.StatusCode)
+
+	httpResp = do(http.MethodGet, server.BreakersPath, "secret")
+	var resp server.BreakersResponse
+	s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(&resp))
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusOK, httpResp.StatusCode)
+	s.Require().Len(resp.Halted, 1)
+	s.Require().Equal("BTC/USD", resp.Halted[0].Ticker)
+	s.Require().Equal("6000000000000", resp.Halted[0].LastPrice)
+	s.Require().Equal("9000000000000", resp.Halted[0].Price)
+	s.Require().InDelta(0.5, resp.Halted[0].Move, 1e-9)
+	s.Require().True(resp.Halted[0].Held)
+
+	// markets that are not halted cannot be cleared.
+	httpResp = do(http.MethodDelete, server.BreakersPath+"/ETH/USD", "secret")
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusNotFound, httpResp.StatusCode)
+
+	httpResp = do(http.MethodPost, server.BreakersPath+"/BTC/USD", "secret")
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusMethodNotAllowed, httpResp.StatusCode)
+
+	httpResp = do(http.MethodDelete, server.BreakersPath+"/BTC/USD", "secret")
+	resp = server.BreakersResponse{}
+	s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(&resp))
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusOK, httpResp.StatusCode)
+	s.Require().Empty(resp.Halted)
+	s.Require().Empty(b.GetHalted())
+
+	// the admin api is not served without a circuit breaker.
+	httpResp, err = s.httpClient.Get(fmt.Sprintf("http://%s:%s%s", localhost, s.port, server.BreakersPath))
+	s.Require().NoError(err)
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusNotFound, httpResp.StatusCode)
+}
+
+// controller is an in-memory oracle.Controller.
+type controller struct {
+	markets   map[string]bool
+	providers map[string]bool
+	refreshed []string
+}
+
+func (c *controller) PausePair(ticker string) error {
+	if _, ok := c.markets[ticker]; !ok {
+		return fmt.Errorf("market %s not found", ticker)
+	}
+	c.markets[ticker] = true
+	return nil
+}
+
+func (c *controller) ResumePair(ticker string) error {
+	if !c.markets[ticker] {
+		return fmt.Errorf("market %s is not paused", ticker)
+	}
+	c.markets[ticker] = false
+	return nil
+}
+
+func (c *controller) GetPausedPairs() []string {
+	return trueKeys(c.markets)
+}
+
+func (c *controller) DisableProvider(name string) error {
+	if _, ok := c.providers[name]; !ok {
+		return fmt.Errorf("provider %s not found", name)
+	}
+	c.providers[name] = true
+	return nil
+}
+
+func (c *controller) EnableProvider(name string) error {
+	if !c.providers[name] {
+		return fmt.Errorf("provider %s is not disabled", name)
+	}
+	c.providers[name] = false
+	return nil
+}
+
+func (c *controller) GetDisabledProviders() []string {
+	return trueKeys(c.providers)
+}
+
+func (c *controller) RefreshProviders(names ...string) error {
+	for _, name := range names {
+		if _, ok := c.providers[name]; !ok {
+			return fmt.Errorf("provider %s not found", name)
+		}
+	}
+	c.refreshed = append(c.refreshed, names...)
+	return nil
+}
+
+func trueKeys(set map[string]bool) []string {
+	keys := make([]string, 0)
+	for key, ok := range set {
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *ServerTestSuite) TestOracleServerAdmin() {
+	s.T().Setenv("CONNECT_TEST_ADMIN_TOKEN", "secret")
+	auth, err := server.NewAuthenticator(config.OracleConfig{
+		Admin: config.AdminConfig{Enabled: true, TokenSource: "env:CONNECT_TEST_ADMIN_TOKEN"},
+	})
+	s.Require().NoError(err)
+
+	ctrl := &controller{
+		markets:   map[string]bool{"BTC/USD": false, "ETH/USD": false},
+		providers: map[string]bool{"binance": false, "coinbase": false},
+	}
+	levels := connectlog.Levels{StdOut: zap.NewAtomicLevel(), FileOut: zap.NewAtomicLevel()}
+
+	provider, err := types.NewPriceProvider(
+		base.WithName[types.ProviderTicker, *big.Float]("coinbase"),
+		base.WithAPIQueryHandler[types.ProviderTicker, *big.Float](apihandlermocks.NewQueryHandler[types.ProviderTicker, *big.Float](s.T())),
+		base.WithAPIConfig[types.ProviderTicker, *big.Float](coinbase.DefaultAPIConfig),
+	)
+	s.Require().NoError(err)
+
+	s.mockOracle.EXPECT().GetProviderState().Return(map[string]oracle.ProviderState{
+		"binance":  {},
+		"coinbase": {Provider: provider},
+	}).Maybe()
+
+	// start a second server with the admin api
+	srv := server.NewOracleServer(
+		s.mockOracle,
+		zap.NewNop(),
+		server.WithAuth(auth),
+		server.WithAdmin(ctrl, &levels),
+	)
+	ln, err := net.Listen("tcp", localhost+":0")
+	s.Require().NoError(err)
+	go srv.StartServerWithListener(s.ctx, ln)
+	defer srv.Close()
+
+	do := func(method, path, body string, resp interface{}) int {
+		req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", ln.Addr().String(), path), strings.NewReader(body))
+		s.Require().NoError(err)
+		req.Header.Set("Authorization", "Bearer secret")
+
+		httpResp, err := s.httpClient.Do(req)
+		s.Require().NoError(err)
+		defer httpResp.Body.Close()
+
+		if httpResp.StatusCode == http.StatusOK && resp != nil {
+			s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(resp))
+		}
+		return httpResp.StatusCode
+	}
+
+	// the admin api requires the admin token.
+	httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s%s", ln.Addr().String(), server.PausedPairsPath))
+	s.Require().NoError(err)
+	httpResp.Body.Close()
+	s.Require().Equal(http.StatusUnauthorized, httpResp.StatusCode)
+
+	s.Run("pauses and resumes pairs", func() {
+		var resp server.PausedPairsResponse
+		s.Require().Equal(http.StatusOK, do(http.MethodPut, server.PausedPairsPath+"/btc/usd", "", &resp))
+		s.Require().Equal([]string{"BTC/USD"}, resp.Paused)
+
+		s.Require().Equal(http.StatusNotFound, do(http.MethodPut, server.PausedPairsPath+"/DOGE/USD", "", nil))
+		s.Require().Equal(http.StatusMethodNotAllowed, do(http.MethodPost, server.PausedPairsPath+"/BTC/USD", "", nil))
+
+		s.Require().Equal(http.StatusOK, do(http.MethodDelete, server.PausedPairsPath+"/BTC/USD", "", &resp))
+		s.Require().Empty(resp.Paused)
+
+		s.Require().Equal(http.StatusNotFound, do(http.MethodDelete, server.PausedPairsPath+"/BTC/USD", "", nil))
+	})
+
+	s.Run("disables and enables providers", func() {
+		var resp server.DisabledProvidersResponse
+		s.Require().Equal(http.StatusOK, do(http.MethodPut, server.DisabledProvidersPath+"/coinbase", "", &resp))
+		s.Require().Equal([]string{"coinbase"}, resp.Disabled)
+
+		s.Require().Equal(http.StatusOK, do(http.MethodGet, server.DisabledProvidersPath, "", &resp))
+		s.Require().Equal([]string{"coinbase"}, resp.Disabled)
+
+		// disabled providers are flagged in the providers endpoint.
+		var providers server.ProvidersResponse
+		s.Require().Equal(http.StatusOK, do(http.MethodGet, server.ProvidersPath, "", &providers))
+		s.Require().Len(providers.Providers, 1)
+		s.Require().True(providers.Providers[0].Disabled)
+
+		s.Require().Equal(http.StatusNotFound, do(http.MethodPut, server.DisabledProvidersPath+"/kraken", "", nil))
+
+		s.Require().Equal(http.StatusOK, do(http.MethodDelete, server.DisabledProvidersPath+"/coinbase", "", &resp))
+		s.Require().Empty(resp.Disabled)
+	})
+
+	s.Run("refreshes providers", func() {
+		var resp server.RefreshResponse
+		s.Require().Equal(http.StatusOK, do(http.MethodPost, server.RefreshPath+"/coinbase", "", &resp))
+		s.Require().Equal([]string{"coinbase"}, resp.Refreshed)
+
+		s.Require().Equal(http.StatusOK, do(http.MethodPost, server.RefreshPath, "", &resp))
+		s.Require().Equal([]string{"binance", "coinbase"}, resp.Refreshed)
+		s.Require().Equal([]string{"coinbase", "binance", "coinbase"}, ctrl.refreshed)
+
+		s.Require().Equal(http.StatusNotFound, do(http.MethodPost, server.RefreshPath+"/kraken", "", nil))
+		s.Require().Equal(http.StatusMethodNotAllowed, do(http.MethodGet, server.RefreshPath, "", nil))
+	})
+
+	s.Run("adjusts log levels", func() {
+		var resp server.LogLevels
+		s.Require().Equal(http.StatusOK, do(http.MethodGet, server.LogLevelPath, "", &resp))
+		s.Require().Equal(server.LogLevels{StdOut: "info", FileOut: "info"}, resp)
+
+		s.Require().Equal(http.StatusOK, do(http.MethodPut, server.LogLevelPath, `{"stdout":"debug"}`, &resp))
+		s.Require().Equal(server.LogLevels{StdOut: "debug", FileOut: "info"}, resp)
+		s.Require().Equal(zap.DebugLevel, levels.StdOut.Level())
+
+		// bad levels leave every level unchanged.
+		s.Require().Equal(http.StatusBadRequest, do(http.MethodPut, server.LogLevelPath, `{"stdout":"warn","file":"loud"}`, nil))
+		s.Require().Equal(zap.DebugLevel, levels.StdOut.Level())
+		s.Require().Equal(zap.InfoLevel, levels.FileOut.Level())
+	})
+
+	// the breakers endpoint is not served without a circuit breaker.
+	s.Require().Equal(http.StatusNotFound, do(http.MethodGet, server.BreakersPath, "", nil))
+}
+
+// getJSON queries the given path of the oracle server and decodes the JSON response into resp.
+// This returns the status code of the response.
+func (s *ServerTestSuite) getJSON(path string, resp interface{}) int {
+	httpResp, err := s.httpClient.Get(fmt.Sprintf("http://%s:%s%s", localhost, s.port, path))
+	s.Require().NoError(err)
+	defer httpResp.Body.Close()
+
+	s.Require().Equal("application/json", httpResp.Header.Get("Content-Type"))
+	s.Require().NoError(json.NewDecoder(httpResp.Body).Decode(resp))
+	return httpResp.StatusCode
+}
+
 // test that the oracle server closes when expected.
 func (s *ServerTestSuite) TestOracleServerClose() {
 	// close the server, and check that no requests are received
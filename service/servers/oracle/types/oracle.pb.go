@@ -80,6 +80,15 @@ type QueryPricesResponse struct {
 	Timestamp time.Time `protobuf:"bytes,2,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
 	// Version defines the version of the oracle service that provided the prices.
 	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Signature defines the signature of the prices and timestamp by the oracle.
+	// This is only set if the oracle is configured to sign its price reports.
+	Signature []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	// PublicKey defines the public key of the oracle that the signature can be
+	// verified with.
+	PublicKey []byte `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// SignatureAlgorithm defines the algorithm of the signature, i.e. ed25519 or
+	// secp256k1.
+	SignatureAlgorithm string `protobuf:"bytes,6,opt,name=signature_algorithm,json=signatureAlgorithm,proto3" json:"signature_algorithm,omitempty"`
 }
 
 func (m *QueryPricesResponse) Reset()         { *m = QueryPricesResponse{} }
@@ -136,6 +145,27 @@ func (m *QueryPricesResponse) GetVersion() string {
 	return ""
 }
 
+func (m *QueryPricesResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *QueryPricesResponse) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *QueryPricesResponse) GetSignatureAlgorithm() string {
+	if m != nil {
+		return m.SignatureAlgorithm
+	}
+	return ""
+}
+
 // QueryMarketMapRequest defines the request type for the MarketMap method.
 type QueryMarketMapRequest struct {
 }
@@ -302,6 +332,53 @@ func (m *QueryVersionResponse) GetVersion() string {
 	return ""
 }
 
+// StreamPricesRequest defines the request type for the StreamPrices method.
+type StreamPricesRequest struct {
+	// CurrencyPairs defines the currency pairs to stream prices for, e.g.
+	// BTC/USD. If empty, the prices of all currency pairs are streamed.
+	CurrencyPairs []string `protobuf:"bytes,1,rep,name=currency_pairs,json=currencyPairs,proto3" json:"currency_pairs,omitempty"`
+}
+
+func (m *StreamPricesRequest) Reset()         { *m = StreamPricesRequest{} }
+func (m *StreamPricesRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamPricesRequest) ProtoMessage()    {}
+func (*StreamPricesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b4d2eaa50661ccd, []int{6}
+}
+func (m *StreamPricesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamPricesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamPricesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamPricesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamPricesRequest.Merge(m, src)
+}
+func (m *StreamPricesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamPricesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamPricesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamPricesRequest proto.InternalMessageInfo
+
+func (m *StreamPricesRequest) GetCurrencyPairs() []string {
+	if m != nil {
+		return m.CurrencyPairs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QueryPricesRequest)(nil), "connect.service.v2.QueryPricesRequest")
 	proto.RegisterType((*QueryPricesResponse)(nil), "connect.service.v2.QueryPricesResponse")
@@ -310,47 +387,55 @@ func init() {
 	proto.RegisterType((*QueryMarketMapResponse)(nil), "connect.service.v2.QueryMarketMapResponse")
 	proto.RegisterType((*QueryVersionRequest)(nil), "connect.service.v2.QueryVersionRequest")
 	proto.RegisterType((*QueryVersionResponse)(nil), "connect.service.v2.QueryVersionResponse")
+	proto.RegisterType((*StreamPricesRequest)(nil), "connect.service.v2.StreamPricesRequest")
 }
 
 func init() { proto.RegisterFile("connect/service/v2/oracle.proto", fileDescriptor_9b4d2eaa50661ccd) }
 
 var fileDescriptor_9b4d2eaa50661ccd = []byte{
-	// 552 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcf, 0x6f, 0xd3, 0x30,
-	0x14, 0xae, 0x5b, 0xe8, 0xa8, 0x7b, 0x41, 0xa6, 0x83, 0x2e, 0x54, 0x49, 0x17, 0x21, 0x28, 0x48,
-	0x38, 0x28, 0xbb, 0xf0, 0x43, 0xe2, 0x50, 0x89, 0xe3, 0x04, 0x8b, 0x00, 0x21, 0x2e, 0x93, 0x1b,
-	0x99, 0x10, 0xb5, 0x89, 0x4d, 0xec, 0x44, 0xaa, 0xc4, 0x01, 0x38, 0x71, 0x9c, 0x04, 0x7f, 0xd4,
-	0x8e, 0x93, 0xb8, 0x70, 0x02, 0xd4, 0xf2, 0x87, 0xa0, 0xd8, 0x4e, 0xb6, 0x8e, 0x4e, 0xdb, 0xa9,
-	0x7e, 0x7e, 0xdf, 0x7b, 0xef, 0xfb, 0xbe, 0xe7, 0x14, 0x3a, 0x21, 0x4b, 0x53, 0x1a, 0x4a, 0x4f,
-	0xd0, 0xac, 0x88, 0x43, 0xea, 0x15, 0xbe, 0xc7, 0x32, 0x12, 0xce, 0x28, 0xe6, 0x19, 0x93, 0x0c,
-	0x21, 0x03, 0xc0, 0x06, 0x80, 0x0b, 0xdf, 0xea, 0x45, 0x2c, 0x62, 0x2a, 0xed, 0x95, 0x27, 0x8d,
-	0xb4, 0x06, 0x11, 0x63, 0xd1, 0x8c, 0x7a, 0x84, 0xc7, 0x1e, 0x49, 0x53, 0x26, 0x89, 0x8c, 0x59,
-	0x2a, 0x4c, 0xd6, 0x31, 0x59, 0x15, 0x4d, 0xf2, 0x77, 0x9e, 0x8c, 0x13, 0x2a, 0x24, 0x49, 0xb8,
-	0x01, 0x6c, 0x85, 0x4c, 0x24, 0x4c, 0xec, 0xeb, 0xbe, 0x3a, 0x30, 0xa9, 0xed, 0x8a, 0x64, 0x42,
-	0xb2, 0x29, 0x95, 0x09, 0xe1, 0x25, 0x4d, 0x1d, 0x68, 0x88, 0xdb, 0x83, 0x68, 0x2f, 0xa7, 0xd9,
-	0xfc, 0x45, 0x16, 0x87, 0x54, 0x04, 0xf4, 0x43, 0x4e, 0x85, 0x74, 0x3f, 0x37, 0xe1, 0xb5, 0x95,
-	0x6b, 0xc1, 0x59, 0x2a, 0x28, 0xda, 0x83, 0x6d, 0xae, 0x6e, 0xfa, 0x60, 0xd8, 0x1a, 0x75, 0xfd,
-	0x1d, 0xfc, 0xbf, 0x4a, 0xbc, 0xa6, 0x10, 0xeb, 0xf0, 0x59, 0x2a, 0xb3, 0xf9, 0xf8, 0xd2, 0xe1,
-	0x2f, 0xa7, 0x11, 0x98, 0x46, 0x68, 0x0c, 0x3b, 0xb5, 0xa2, 0x7e, 0x73, 0x08, 0x46, 0x5d, 0xdf,
-	0xc2, 0x5a, 0x33, 0xae, 0x34, 0xe3, 0x97, 0x15, 0x62, 0x7c, 0xa5, 0x2c, 0x3e, 0xf8, 0xed, 0x80,
-	0xe0, 0xb8, 0x0c, 0xf5, 0xe1, 0x46, 0x41, 0x33, 0x11, 0xb3, 0xb4, 0xdf, 0x1a, 0x82, 0x51, 0x27,
-	0xa8, 0x42, 0xeb, 0x11, 0xec, 0x9e, 0x18, 0x8d, 0xae, 0xc2, 0xd6, 0x94, 0xce, 0xfb, 0x40, 0x81,
-	0xca, 0x23, 0xea, 0xc1, 0xcb, 0x05, 0x99, 0xe5, 0x54, 0x8d, 0xee, 0x04, 0x3a, 0x78, 0xdc, 0x7c,
-	0x08, 0xdc, 0x1b, 0x70, 0x53, 0x29, 0xd9, 0x55, 0x76, 0xed, 0x12, 0x5e, 0x99, 0xf3, 0x06, 0x5e,
-	0x3f, 0x9d, 0x30, 0xf6, 0x3c, 0x85, 0x50, 0x9b, 0xbb, 0x9f, 0x10, 0xae, 0xa6, 0x74, 0x7d, 0xa7,
-	0xb6, 0xa8, 0x5e, 0x42, 0x69, 0xd2, 0x71, 0x71, 0x27, 0xa9, 0x8e, 0xee, 0xa6, 0x71, 0xfd, 0xb5,
-	0x66, 0x5f, 0x0d, 0x7c, 0x00, 0x7b, 0xab, 0xd7, 0x66, 0xdc, 0x09, 0xd9, 0x60, 0x45, 0xb6, 0xff,
-	0xbd, 0x05, 0xdb, 0xcf, 0xd5, 0x6b, 0x44, 0x1f, 0x61, 0x5b, 0x3b, 0x80, 0x6e, 0x9f, 0xbb, 0x2c,
-	0x35, 0xce, 0xba, 0x73, 0xc1, 0xa5, 0xba, 0xdb, 0x5f, 0x7e, 0xfc, 0xfd, 0xd6, 0xbc, 0x89, 0xb6,
-	0xbc, 0xea, 0x9d, 0xe9, 0x2f, 0xa0, 0x7c, 0x64, 0x66, 0xbb, 0x5f, 0x01, 0xec, 0xd4, 0x52, 0xd1,
-	0xdd, 0x33, 0x3b, 0x9f, 0x36, 0xd9, 0xba, 0x77, 0x11, 0xa8, 0xe1, 0x71, 0x4b, 0xf1, 0xb0, 0xd1,
-	0x60, 0x0d, 0x8f, 0xda, 0x74, 0xf4, 0x09, 0xc0, 0x0d, 0xe3, 0x20, 0x3a, 0x5b, 0xe2, 0xaa, 0xf5,
-	0xd6, 0xe8, 0x7c, 0xa0, 0x21, 0xe1, 0x2a, 0x12, 0x03, 0x64, 0xad, 0x21, 0x61, 0xd6, 0x32, 0x7e,
-	0x75, 0xb8, 0xb0, 0xc1, 0xd1, 0xc2, 0x06, 0x7f, 0x16, 0x36, 0x38, 0x58, 0xda, 0x8d, 0xa3, 0xa5,
-	0xdd, 0xf8, 0xb9, 0xb4, 0x1b, 0x6f, 0x9f, 0x44, 0xb1, 0x7c, 0x9f, 0x4f, 0x70, 0xc8, 0x12, 0x4f,
-	0x4c, 0x63, 0x7e, 0x3f, 0xa1, 0x45, 0xdd, 0xa8, 0xf0, 0xeb, 0x7f, 0x99, 0xf2, 0x97, 0x66, 0xa2,
-	0xea, 0x2d, 0xe7, 0x9c, 0x8a, 0x49, 0x5b, 0x7d, 0x27, 0x3b, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff,
-	0x88, 0x1b, 0xe4, 0x95, 0x94, 0x04, 0x00, 0x00,
+	// 658 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6f, 0xd3, 0x30,
+	0x14, 0xaf, 0xdb, 0xad, 0xa3, 0xee, 0x40, 0xc8, 0xeb, 0xa0, 0x0b, 0x25, 0xed, 0x22, 0x3e, 0x0a,
+	0x12, 0xc9, 0x94, 0x5d, 0xf8, 0x12, 0x12, 0x95, 0x38, 0xa1, 0x89, 0x2d, 0x7c, 0x08, 0x71, 0xa9,
+	0xdc, 0xc8, 0x64, 0xd1, 0x9a, 0x38, 0xd8, 0x4e, 0xa4, 0x4a, 0x1c, 0x10, 0x27, 0x8e, 0x93, 0xf8,
+	0xa7, 0x76, 0x9c, 0xc4, 0x85, 0x13, 0xa0, 0x8d, 0x3b, 0x57, 0x8e, 0x28, 0xb6, 0x93, 0xad, 0xa3,
+	0xd3, 0x76, 0x8a, 0xdf, 0x7b, 0x3f, 0xfb, 0xfd, 0xde, 0xfb, 0xbd, 0x17, 0xd8, 0xf5, 0x69, 0x1c,
+	0x13, 0x5f, 0x38, 0x9c, 0xb0, 0x2c, 0xf4, 0x89, 0x93, 0xb9, 0x0e, 0x65, 0xd8, 0x1f, 0x13, 0x3b,
+	0x61, 0x54, 0x50, 0x84, 0x34, 0xc0, 0xd6, 0x00, 0x3b, 0x73, 0x8d, 0x56, 0x40, 0x03, 0x2a, 0xc3,
+	0x4e, 0x7e, 0x52, 0x48, 0xa3, 0x13, 0x50, 0x1a, 0x8c, 0x89, 0x83, 0x93, 0xd0, 0xc1, 0x71, 0x4c,
+	0x05, 0x16, 0x21, 0x8d, 0xb9, 0x8e, 0x76, 0x75, 0x54, 0x5a, 0xa3, 0xf4, 0xbd, 0x23, 0xc2, 0x88,
+	0x70, 0x81, 0xa3, 0x44, 0x03, 0x56, 0x7c, 0xca, 0x23, 0xca, 0x87, 0xea, 0x5d, 0x65, 0xe8, 0xd0,
+	0x6a, 0x41, 0x32, 0xc2, 0x6c, 0x87, 0x88, 0x08, 0x27, 0x39, 0x4d, 0x65, 0x28, 0x88, 0xd5, 0x82,
+	0x68, 0x2b, 0x25, 0x6c, 0xb2, 0xc9, 0x42, 0x9f, 0x70, 0x8f, 0x7c, 0x48, 0x09, 0x17, 0xd6, 0x9f,
+	0x2a, 0x5c, 0x9a, 0x72, 0xf3, 0x84, 0xc6, 0x9c, 0xa0, 0x2d, 0x58, 0x4f, 0xa4, 0xa7, 0x0d, 0x7a,
+	0xb5, 0x7e, 0xd3, 0x5d, 0xb7, 0xff, 0xaf, 0xd2, 0x9e, 0x71, 0xd1, 0x56, 0xe6, 0xb3, 0x58, 0xb0,
+	0xc9, 0x60, 0x6e, 0xef, 0x47, 0xb7, 0xe2, 0xe9, 0x87, 0xd0, 0x00, 0x36, 0xca, 0x8a, 0xda, 0xd5,
+	0x1e, 0xe8, 0x37, 0x5d, 0xc3, 0x56, 0x35, 0xdb, 0x45, 0xcd, 0xf6, 0xab, 0x02, 0x31, 0xb8, 0x90,
+	0x5f, 0xde, 0xfd, 0xd9, 0x05, 0xde, 0xd1, 0x35, 0xd4, 0x86, 0x0b, 0x19, 0x61, 0x3c, 0xa4, 0x71,
+	0xbb, 0xd6, 0x03, 0xfd, 0x86, 0x57, 0x98, 0xa8, 0x03, 0x1b, 0x3c, 0x0c, 0x62, 0x2c, 0x52, 0x46,
+	0xda, 0x73, 0x3d, 0xd0, 0x5f, 0xf4, 0x8e, 0x1c, 0xe8, 0x3a, 0x84, 0x49, 0x3a, 0x1a, 0x87, 0xfe,
+	0x70, 0x87, 0x4c, 0xda, 0xf3, 0x2a, 0xac, 0x3c, 0xcf, 0xc9, 0x04, 0x39, 0x70, 0xa9, 0xc4, 0x0e,
+	0xf1, 0x38, 0xa0, 0x2c, 0x14, 0xdb, 0x51, 0xbb, 0x2e, 0x53, 0xa0, 0x32, 0xf4, 0xb4, 0x88, 0x18,
+	0x0f, 0x60, 0xf3, 0x58, 0xa1, 0xe8, 0x32, 0xac, 0xe5, 0xef, 0x02, 0x89, 0xcf, 0x8f, 0xa8, 0x05,
+	0xe7, 0x33, 0x3c, 0x4e, 0x89, 0x2c, 0xb4, 0xe1, 0x29, 0xe3, 0x61, 0xf5, 0x3e, 0xb0, 0xae, 0xc2,
+	0x65, 0xd9, 0xb7, 0x0d, 0x29, 0xce, 0x06, 0x4e, 0x0a, 0x29, 0xde, 0xc2, 0x2b, 0x27, 0x03, 0x5a,
+	0x8c, 0x27, 0x10, 0x2a, 0x29, 0x87, 0x11, 0x4e, 0x64, 0x96, 0xa6, 0xdb, 0x2d, 0x05, 0x29, 0x25,
+	0xcf, 0x25, 0x39, 0xba, 0xdc, 0x88, 0x8a, 0xa3, 0xb5, 0xac, 0x35, 0x7e, 0xa3, 0x7a, 0x55, 0x24,
+	0x5c, 0x83, 0xad, 0x69, 0xb7, 0x4e, 0x77, 0xac, 0xc9, 0x60, 0xaa, 0xc9, 0xd6, 0x63, 0xb8, 0xf4,
+	0x52, 0x30, 0x82, 0xa3, 0xa9, 0x21, 0x42, 0x37, 0xe1, 0x25, 0x3f, 0x65, 0x8c, 0xc4, 0xfe, 0x64,
+	0x98, 0xe0, 0x90, 0xa9, 0xa1, 0x69, 0x78, 0x17, 0x0b, 0xef, 0x66, 0xee, 0x74, 0xff, 0xd6, 0x60,
+	0xfd, 0x85, 0xdc, 0x1c, 0xf4, 0x11, 0xd6, 0xd5, 0x13, 0xe8, 0xd6, 0x99, 0x83, 0x25, 0x73, 0x18,
+	0xb7, 0xcf, 0x39, 0x80, 0xd6, 0xea, 0xe7, 0x6f, 0xbf, 0xbf, 0x56, 0xaf, 0xa1, 0x15, 0xa7, 0xd8,
+	0x09, 0xb5, 0xad, 0xf9, 0x42, 0xe8, 0x49, 0xfc, 0x02, 0x60, 0xa3, 0x6c, 0x14, 0xba, 0x73, 0xea,
+	0xcb, 0x27, 0x25, 0x32, 0xee, 0x9e, 0x07, 0xaa, 0x79, 0xdc, 0x90, 0x3c, 0x4c, 0xd4, 0x99, 0xc1,
+	0xa3, 0x94, 0x0c, 0x7d, 0x02, 0x70, 0x41, 0xf7, 0x1f, 0x9d, 0x5e, 0xe2, 0xb4, 0x70, 0x46, 0xff,
+	0x6c, 0xa0, 0x26, 0x61, 0x49, 0x12, 0x1d, 0x64, 0xcc, 0x20, 0x51, 0x6c, 0xce, 0x08, 0x2e, 0x1e,
+	0x17, 0x75, 0x36, 0x8d, 0x19, 0xb2, 0x9f, 0x5b, 0x92, 0x35, 0x30, 0x78, 0xfd, 0xee, 0x51, 0x10,
+	0x8a, 0xed, 0x74, 0x64, 0xfb, 0x34, 0x72, 0xf8, 0x4e, 0x98, 0xdc, 0x8b, 0x48, 0x56, 0x92, 0xca,
+	0xdc, 0xf2, 0xef, 0x9a, 0x7f, 0x09, 0xe3, 0x05, 0x4f, 0x31, 0x49, 0x08, 0xdf, 0x3b, 0x30, 0xc1,
+	0xfe, 0x81, 0x09, 0x7e, 0x1d, 0x98, 0x60, 0xf7, 0xd0, 0xac, 0xec, 0x1f, 0x9a, 0x95, 0xef, 0x87,
+	0x66, 0x65, 0x54, 0x97, 0xff, 0x8d, 0xf5, 0x7f, 0x03, 0x00, 0xdf, 0x09, 0x05, 0xb6, 0xa4, 0x05,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -373,6 +458,9 @@ type OracleClient interface {
 	// Version defines a method for fetching the current version of the oracle
 	// service.
 	Version(ctx context.Context, in *QueryVersionRequest, opts ...grpc.CallOption) (*QueryVersionResponse, error)
+	// StreamPrices defines a method for streaming the latest prices. A response
+	// is sent each time the oracle updates its prices.
+	StreamPrices(ctx context.Context, in *StreamPricesRequest, opts ...grpc.CallOption) (Oracle_StreamPricesClient, error)
 }
 
 type oracleClient struct {
@@ -410,6 +498,38 @@ func (c *oracleClient) Version(ctx context.Context, in *QueryVersionRequest, opt
 	return out, nil
 }
 
+func (c *oracleClient) StreamPrices(ctx context.Context, in *StreamPricesRequest, opts ...grpc.CallOption) (Oracle_StreamPricesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Oracle_serviceDesc.Streams[0], "/connect.service.v2.Oracle/StreamPrices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oracleStreamPricesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Oracle_StreamPricesClient interface {
+	Recv() (*QueryPricesResponse, error)
+	grpc.ClientStream
+}
+
+type oracleStreamPricesClient struct {
+	grpc.ClientStream
+}
+
+func (x *oracleStreamPricesClient) Recv() (*QueryPricesResponse, error) {
+	m := new(QueryPricesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // OracleServer is the server API for Oracle service.
 type OracleServer interface {
 	// Prices defines a method for fetching the latest prices.
@@ -420,6 +540,9 @@ type OracleServer interface {
 	// Version defines a method for fetching the current version of the oracle
 	// service.
 	Version(context.Context, *QueryVersionRequest) (*QueryVersionResponse, error)
+	// StreamPrices defines a method for streaming the latest prices. A response
+	// is sent each time the oracle updates its prices.
+	StreamPrices(*StreamPricesRequest, Oracle_StreamPricesServer) error
 }
 
 // UnimplementedOracleServer can be embedded to have forward compatible implementations.
@@ -435,6 +558,9 @@ func (*UnimplementedOracleServer) MarketMap(ctx context.Context, req *QueryMarke
 func (*UnimplementedOracleServer) Version(ctx context.Context, req *QueryVersionRequest) (*QueryVersionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
 }
+func (*UnimplementedOracleServer) StreamPrices(req *StreamPricesRequest, srv Oracle_StreamPricesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPrices not implemented")
+}
 
 func RegisterOracleServer(s grpc1.Server, srv OracleServer) {
 	s.RegisterService(&_Oracle_serviceDesc, srv)
@@ -494,6 +620,27 @@ func _Oracle_Version_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Oracle_StreamPrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPricesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OracleServer).StreamPrices(m, &oracleStreamPricesServer{stream})
+}
+
+type Oracle_StreamPricesServer interface {
+	Send(*QueryPricesResponse) error
+	grpc.ServerStream
+}
+
+type oracleStreamPricesServer struct {
+	grpc.ServerStream
+}
+
+func (x *oracleStreamPricesServer) Send(m *QueryPricesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var Oracle_serviceDesc = _Oracle_serviceDesc
 var _Oracle_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "connect.service.v2.Oracle",
@@ -512,7 +659,13 @@ var _Oracle_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Oracle_Version_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPrices",
+			Handler:       _Oracle_StreamPrices_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "connect/service/v2/oracle.proto",
 }
 
@@ -559,6 +712,27 @@ func (m *QueryPricesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.SignatureAlgorithm) > 0 {
+		i -= len(m.SignatureAlgorithm)
+		copy(dAtA[i:], m.SignatureAlgorithm)
+		i = encodeVarintOracle(dAtA, i, uint64(len(m.SignatureAlgorithm)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintOracle(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintOracle(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.Version) > 0 {
 		i -= len(m.Version)
 		copy(dAtA[i:], m.Version)
@@ -707,6 +881,38 @@ func (m *QueryVersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *StreamPricesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamPricesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamPricesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CurrencyPairs) > 0 {
+		for iNdEx := len(m.CurrencyPairs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CurrencyPairs[iNdEx])
+			copy(dAtA[i:], m.CurrencyPairs[iNdEx])
+			i = encodeVarintOracle(dAtA, i, uint64(len(m.CurrencyPairs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintOracle(dAtA []byte, offset int, v uint64) int {
 	offset -= sovOracle(v)
 	base := offset
@@ -747,6 +953,18 @@ func (m *QueryPricesResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovOracle(uint64(l))
 	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovOracle(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovOracle(uint64(l))
+	}
+	l = len(m.SignatureAlgorithm)
+	if l > 0 {
+		n += 1 + l + sovOracle(uint64(l))
+	}
 	return n
 }
 
@@ -794,6 +1012,21 @@ func (m *QueryVersionResponse) Size() (n int) {
 	return n
 }
 
+func (m *StreamPricesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.CurrencyPairs) > 0 {
+		for _, s := range m.CurrencyPairs {
+			l = len(s)
+			n += 1 + l + sovOracle(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovOracle(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1071,6 +1304,106 @@ func (m *QueryPricesResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthOracle
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthOracle
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOracle
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignatureAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOracle(dAtA[iNdEx:])
@@ -1360,6 +1693,88 @@ func (m *QueryVersionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *StreamPricesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOracle
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamPricesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamPricesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrencyPairs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOracle
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CurrencyPairs = append(m.CurrencyPairs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOracle(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipOracle(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0